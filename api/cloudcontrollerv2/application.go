@@ -27,38 +27,33 @@ func (application *Application) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func (client *CloudControllerClient) GetApplications(queryParams []Query) ([]Application, Warnings, error) {
-	request := Request{
+// GetApplicationsPages returns the pages of the applications matching the
+// given queries, so they can be used as each page arrives.
+func (client *CloudControllerClient) GetApplicationsPages(queryParams []Query) *Pages {
+	return client.newPages(Request{
 		RequestName: AppsRequest,
 		Query:       FormatQueryParameters(queryParams),
-	}
+	})
+}
+
+func (client *CloudControllerClient) GetApplications(queryParams []Query) ([]Application, Warnings, error) {
+	pages := client.GetApplicationsPages(queryParams)
 
 	fullAppsList := []Application{}
 	fullWarningsList := Warnings{}
 
 	for {
 		var apps []Application
-		wrapper := PaginatedWrapper{
-			Resources: &apps,
-		}
-		response := Response{
-			Result: &wrapper,
-		}
-
-		err := client.connection.Make(request, &response)
-		fullWarningsList = append(fullWarningsList, response.Warnings...)
+		more, warnings, err := pages.Next(&apps)
+		fullWarningsList = append(fullWarningsList, warnings...)
 		if err != nil {
 			return nil, fullWarningsList, err
 		}
-		fullAppsList = append(fullAppsList, apps...)
-
-		if wrapper.NextURL == "" {
+		if !more {
 			break
 		}
-		request = Request{
-			URI:    wrapper.NextURL,
-			Method: "GET",
-		}
+
+		fullAppsList = append(fullAppsList, apps...)
 	}
 
 	return fullAppsList, fullWarningsList, nil
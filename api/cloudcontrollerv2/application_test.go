@@ -75,6 +75,42 @@ var _ = Describe("Application", func() {
 			)
 		})
 
+		Describe("GetApplicationsPages", func() {
+			It("requests each page only when it is asked for", func() {
+				pages := client.GetApplicationsPages([]Query{{
+					Filter:   SpaceGUIDFilter,
+					Operator: EqualOperator,
+					Value:    "some-space-guid",
+				}})
+				requestsBefore := len(server.ReceivedRequests())
+
+				var apps []Application
+				more, warnings, err := pages.Next(&apps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(more).To(BeTrue())
+				Expect(warnings).To(ConsistOf(Warnings{"this is a warning"}))
+				Expect(apps).To(Equal([]Application{
+					{Name: "some-app-name-1", GUID: "some-app-guid-1"},
+					{Name: "some-app-name-2", GUID: "some-app-guid-2"},
+				}))
+				Expect(server.ReceivedRequests()).To(HaveLen(requestsBefore + 1))
+
+				apps = nil
+				more, _, err = pages.Next(&apps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(more).To(BeTrue())
+				Expect(apps).To(Equal([]Application{
+					{Name: "some-app-name-3", GUID: "some-app-guid-3"},
+					{Name: "some-app-name-4", GUID: "some-app-guid-4"},
+				}))
+
+				more, _, err = pages.Next(&apps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(more).To(BeFalse())
+				Expect(server.ReceivedRequests()).To(HaveLen(requestsBefore + 2))
+			})
+		})
+
 		Context("when apps exist", func() {
 			It("returns all the queried apps", func() {
 				apps, warnings, err := client.GetApplications([]Query{{
@@ -0,0 +1,50 @@
+package cloudcontrollerv2
+
+// Pages iterates through the pages of a paginated list. Each page is only
+// requested when it is asked for, so callers can use the resources of a page,
+// or stop, before the rest of the list has been fetched.
+type Pages struct {
+	connection *Connection
+	request    Request
+	done       bool
+}
+
+func (client *CloudControllerClient) newPages(request Request) *Pages {
+	return &Pages{
+		connection: client.connection,
+		request:    request,
+	}
+}
+
+// Next requests the next page and unmarshals its resources into resources,
+// which must be a pointer to a slice. It returns false, without making a
+// request, once every page has been fetched.
+func (pages *Pages) Next(resources interface{}) (bool, Warnings, error) {
+	if pages.done {
+		return false, nil, nil
+	}
+
+	wrapper := PaginatedWrapper{
+		Resources: resources,
+	}
+	response := Response{
+		Result: &wrapper,
+	}
+
+	err := pages.connection.Make(pages.request, &response)
+	if err != nil {
+		pages.done = true
+		return false, response.Warnings, err
+	}
+
+	if wrapper.NextURL == "" {
+		pages.done = true
+	} else {
+		pages.request = Request{
+			URI:    wrapper.NextURL,
+			Method: "GET",
+		}
+	}
+
+	return true, response.Warnings, nil
+}
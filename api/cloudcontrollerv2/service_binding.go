@@ -23,39 +23,33 @@ func (serviceBinding *ServiceBinding) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func (client *CloudControllerClient) GetServiceBindings(queries []Query) ([]ServiceBinding, Warnings, error) {
-	request := Request{
+// GetServiceBindingsPages returns the pages of the service bindings
+// matching the given queries, so they can be used as each page arrives.
+func (client *CloudControllerClient) GetServiceBindingsPages(queries []Query) *Pages {
+	return client.newPages(Request{
 		RequestName: ServiceBindingsRequest,
 		Query:       FormatQueryParameters(queries),
-	}
+	})
+}
+
+func (client *CloudControllerClient) GetServiceBindings(queries []Query) ([]ServiceBinding, Warnings, error) {
+	pages := client.GetServiceBindingsPages(queries)
 
 	allServiceBindingsList := []ServiceBinding{}
 	allWarningsList := Warnings{}
 
 	for {
 		var serviceBindings []ServiceBinding
-		wrapper := PaginatedWrapper{
-			Resources: &serviceBindings,
-		}
-		response := Response{
-			Result: &wrapper,
-		}
-
-		err := client.connection.Make(request, &response)
-		allWarningsList = append(allWarningsList, response.Warnings...)
+		more, warnings, err := pages.Next(&serviceBindings)
+		allWarningsList = append(allWarningsList, warnings...)
 		if err != nil {
 			return nil, allWarningsList, err
 		}
-
-		allServiceBindingsList = append(allServiceBindingsList, serviceBindings...)
-
-		if wrapper.NextURL == "" {
+		if !more {
 			break
 		}
-		request = Request{
-			URI:    wrapper.NextURL,
-			Method: "GET",
-		}
+
+		allServiceBindingsList = append(allServiceBindingsList, serviceBindings...)
 	}
 
 	return allServiceBindingsList, allWarningsList, nil
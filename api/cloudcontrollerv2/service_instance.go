@@ -28,39 +28,33 @@ func (serviceInstance *ServiceInstance) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func (client *CloudControllerClient) GetServiceInstances(queries []Query) ([]ServiceInstance, Warnings, error) {
-	request := Request{
+// GetServiceInstancesPages returns the pages of the service instances
+// matching the given queries, so they can be used as each page arrives.
+func (client *CloudControllerClient) GetServiceInstancesPages(queries []Query) *Pages {
+	return client.newPages(Request{
 		RequestName: ServiceInstancesRequest,
 		Query:       FormatQueryParameters(queries),
-	}
+	})
+}
+
+func (client *CloudControllerClient) GetServiceInstances(queries []Query) ([]ServiceInstance, Warnings, error) {
+	pages := client.GetServiceInstancesPages(queries)
 
 	allServiceInstancesList := []ServiceInstance{}
 	allWarningsList := Warnings{}
 
 	for {
 		var serviceInstances []ServiceInstance
-		wrapper := PaginatedWrapper{
-			Resources: &serviceInstances,
-		}
-		response := Response{
-			Result: &wrapper,
-		}
-
-		err := client.connection.Make(request, &response)
-		allWarningsList = append(allWarningsList, response.Warnings...)
+		more, warnings, err := pages.Next(&serviceInstances)
+		allWarningsList = append(allWarningsList, warnings...)
 		if err != nil {
 			return nil, allWarningsList, err
 		}
-
-		allServiceInstancesList = append(allServiceInstancesList, serviceInstances...)
-
-		if wrapper.NextURL == "" {
+		if !more {
 			break
 		}
-		request = Request{
-			URI:    wrapper.NextURL,
-			Method: "GET",
-		}
+
+		allServiceInstancesList = append(allServiceInstancesList, serviceInstances...)
 	}
 
 	return allServiceInstancesList, allWarningsList, nil
@@ -0,0 +1,80 @@
+// This file was generated by counterfeiter
+package actorsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+)
+
+type FakeBlueGreenDeployer struct {
+	DeployStub        func(appName string, push actors.PushFunc, keepOldApp bool) error
+	deployMutex       sync.RWMutex
+	deployArgsForCall []struct {
+		appName    string
+		push       actors.PushFunc
+		keepOldApp bool
+	}
+	deployReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBlueGreenDeployer) Deploy(appName string, push actors.PushFunc, keepOldApp bool) error {
+	fake.deployMutex.Lock()
+	fake.deployArgsForCall = append(fake.deployArgsForCall, struct {
+		appName    string
+		push       actors.PushFunc
+		keepOldApp bool
+	}{appName, push, keepOldApp})
+	fake.recordInvocation("Deploy", []interface{}{appName, push, keepOldApp})
+	fake.deployMutex.Unlock()
+	if fake.DeployStub != nil {
+		return fake.DeployStub(appName, push, keepOldApp)
+	} else {
+		return fake.deployReturns.result1
+	}
+}
+
+func (fake *FakeBlueGreenDeployer) DeployCallCount() int {
+	fake.deployMutex.RLock()
+	defer fake.deployMutex.RUnlock()
+	return len(fake.deployArgsForCall)
+}
+
+func (fake *FakeBlueGreenDeployer) DeployArgsForCall(i int) (string, actors.PushFunc, bool) {
+	fake.deployMutex.RLock()
+	defer fake.deployMutex.RUnlock()
+	return fake.deployArgsForCall[i].appName, fake.deployArgsForCall[i].push, fake.deployArgsForCall[i].keepOldApp
+}
+
+func (fake *FakeBlueGreenDeployer) DeployReturns(result1 error) {
+	fake.DeployStub = nil
+	fake.deployReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBlueGreenDeployer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deployMutex.RLock()
+	defer fake.deployMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeBlueGreenDeployer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ actors.BlueGreenDeployer = new(FakeBlueGreenDeployer)
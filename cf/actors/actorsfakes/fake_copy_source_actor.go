@@ -0,0 +1,84 @@
+// This file was generated by counterfeiter
+package actorsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+)
+
+type FakeCopySourceActor struct {
+	CopySourceStub        func(sourceAppGUID string, targetAppGUID string, target actors.CopyTarget, copyEnv bool) (actors.CopyReport, error)
+	copySourceMutex       sync.RWMutex
+	copySourceArgsForCall []struct {
+		sourceAppGUID string
+		targetAppGUID string
+		target        actors.CopyTarget
+		copyEnv       bool
+	}
+	copySourceReturns struct {
+		result1 actors.CopyReport
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCopySourceActor) CopySource(sourceAppGUID string, targetAppGUID string, target actors.CopyTarget, copyEnv bool) (actors.CopyReport, error) {
+	fake.copySourceMutex.Lock()
+	fake.copySourceArgsForCall = append(fake.copySourceArgsForCall, struct {
+		sourceAppGUID string
+		targetAppGUID string
+		target        actors.CopyTarget
+		copyEnv       bool
+	}{sourceAppGUID, targetAppGUID, target, copyEnv})
+	fake.recordInvocation("CopySource", []interface{}{sourceAppGUID, targetAppGUID, target, copyEnv})
+	fake.copySourceMutex.Unlock()
+	if fake.CopySourceStub != nil {
+		return fake.CopySourceStub(sourceAppGUID, targetAppGUID, target, copyEnv)
+	} else {
+		return fake.copySourceReturns.result1, fake.copySourceReturns.result2
+	}
+}
+
+func (fake *FakeCopySourceActor) CopySourceCallCount() int {
+	fake.copySourceMutex.RLock()
+	defer fake.copySourceMutex.RUnlock()
+	return len(fake.copySourceArgsForCall)
+}
+
+func (fake *FakeCopySourceActor) CopySourceArgsForCall(i int) (string, string, actors.CopyTarget, bool) {
+	fake.copySourceMutex.RLock()
+	defer fake.copySourceMutex.RUnlock()
+	return fake.copySourceArgsForCall[i].sourceAppGUID, fake.copySourceArgsForCall[i].targetAppGUID, fake.copySourceArgsForCall[i].target, fake.copySourceArgsForCall[i].copyEnv
+}
+
+func (fake *FakeCopySourceActor) CopySourceReturns(result1 actors.CopyReport, result2 error) {
+	fake.CopySourceStub = nil
+	fake.copySourceReturns = struct {
+		result1 actors.CopyReport
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCopySourceActor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.copySourceMutex.RLock()
+	defer fake.copySourceMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeCopySourceActor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ actors.CopySourceActor = new(FakeCopySourceActor)
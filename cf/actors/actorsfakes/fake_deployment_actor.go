@@ -0,0 +1,398 @@
+// This file was generated by counterfeiter
+package actorsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type FakeDeploymentActor struct {
+	StageLatestPackageStub        func(appGUID string) (models.Build, error)
+	stageLatestPackageMutex       sync.RWMutex
+	stageLatestPackageArgsForCall []struct {
+		appGUID string
+	}
+	stageLatestPackageReturns struct {
+		result1 models.Build
+		result2 error
+	}
+	StagePackageStub        func(packageGUID string) (models.Build, error)
+	stagePackageMutex       sync.RWMutex
+	stagePackageArgsForCall []struct {
+		packageGUID string
+	}
+	stagePackageReturns struct {
+		result1 models.Build
+		result2 error
+	}
+	WaitForBuildStub        func(build models.Build) (models.Build, error)
+	waitForBuildMutex       sync.RWMutex
+	waitForBuildArgsForCall []struct {
+		build models.Build
+	}
+	waitForBuildReturns struct {
+		result1 models.Build
+		result2 error
+	}
+	DeployStub        func(appGUID string, params models.DeploymentParams) (models.Deployment, error)
+	deployMutex       sync.RWMutex
+	deployArgsForCall []struct {
+		appGUID string
+		params  models.DeploymentParams
+	}
+	deployReturns struct {
+		result1 models.Deployment
+		result2 error
+	}
+	WaitForDeploymentStub        func(deployment models.Deployment, progress func(models.Deployment)) (models.Deployment, error)
+	waitForDeploymentMutex       sync.RWMutex
+	waitForDeploymentArgsForCall []struct {
+		deployment models.Deployment
+		progress   func(models.Deployment)
+	}
+	waitForDeploymentReturns struct {
+		result1 models.Deployment
+		result2 error
+	}
+	ListDeploymentsStub        func(appGUID string) ([]models.Deployment, error)
+	listDeploymentsMutex       sync.RWMutex
+	listDeploymentsArgsForCall []struct {
+		appGUID string
+	}
+	listDeploymentsReturns struct {
+		result1 []models.Deployment
+		result2 error
+	}
+	CancelDeploymentStub        func(appGUID string) (models.Deployment, error)
+	cancelDeploymentMutex       sync.RWMutex
+	cancelDeploymentArgsForCall []struct {
+		appGUID string
+	}
+	cancelDeploymentReturns struct {
+		result1 models.Deployment
+		result2 error
+	}
+	ContinueDeploymentStub        func(appGUID string) (models.Deployment, error)
+	continueDeploymentMutex       sync.RWMutex
+	continueDeploymentArgsForCall []struct {
+		appGUID string
+	}
+	continueDeploymentReturns struct {
+		result1 models.Deployment
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDeploymentActor) StageLatestPackage(appGUID string) (models.Build, error) {
+	fake.stageLatestPackageMutex.Lock()
+	fake.stageLatestPackageArgsForCall = append(fake.stageLatestPackageArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("StageLatestPackage", []interface{}{appGUID})
+	fake.stageLatestPackageMutex.Unlock()
+	if fake.StageLatestPackageStub != nil {
+		return fake.StageLatestPackageStub(appGUID)
+	} else {
+		return fake.stageLatestPackageReturns.result1, fake.stageLatestPackageReturns.result2
+	}
+}
+
+func (fake *FakeDeploymentActor) StageLatestPackageCallCount() int {
+	fake.stageLatestPackageMutex.RLock()
+	defer fake.stageLatestPackageMutex.RUnlock()
+	return len(fake.stageLatestPackageArgsForCall)
+}
+
+func (fake *FakeDeploymentActor) StageLatestPackageArgsForCall(i int) string {
+	fake.stageLatestPackageMutex.RLock()
+	defer fake.stageLatestPackageMutex.RUnlock()
+	return fake.stageLatestPackageArgsForCall[i].appGUID
+}
+
+func (fake *FakeDeploymentActor) StageLatestPackageReturns(result1 models.Build, result2 error) {
+	fake.StageLatestPackageStub = nil
+	fake.stageLatestPackageReturns = struct {
+		result1 models.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeploymentActor) StagePackage(packageGUID string) (models.Build, error) {
+	fake.stagePackageMutex.Lock()
+	fake.stagePackageArgsForCall = append(fake.stagePackageArgsForCall, struct {
+		packageGUID string
+	}{packageGUID})
+	fake.recordInvocation("StagePackage", []interface{}{packageGUID})
+	fake.stagePackageMutex.Unlock()
+	if fake.StagePackageStub != nil {
+		return fake.StagePackageStub(packageGUID)
+	} else {
+		return fake.stagePackageReturns.result1, fake.stagePackageReturns.result2
+	}
+}
+
+func (fake *FakeDeploymentActor) StagePackageCallCount() int {
+	fake.stagePackageMutex.RLock()
+	defer fake.stagePackageMutex.RUnlock()
+	return len(fake.stagePackageArgsForCall)
+}
+
+func (fake *FakeDeploymentActor) StagePackageArgsForCall(i int) string {
+	fake.stagePackageMutex.RLock()
+	defer fake.stagePackageMutex.RUnlock()
+	return fake.stagePackageArgsForCall[i].packageGUID
+}
+
+func (fake *FakeDeploymentActor) StagePackageReturns(result1 models.Build, result2 error) {
+	fake.StagePackageStub = nil
+	fake.stagePackageReturns = struct {
+		result1 models.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeploymentActor) WaitForBuild(build models.Build) (models.Build, error) {
+	fake.waitForBuildMutex.Lock()
+	fake.waitForBuildArgsForCall = append(fake.waitForBuildArgsForCall, struct {
+		build models.Build
+	}{build})
+	fake.recordInvocation("WaitForBuild", []interface{}{build})
+	fake.waitForBuildMutex.Unlock()
+	if fake.WaitForBuildStub != nil {
+		return fake.WaitForBuildStub(build)
+	} else {
+		return fake.waitForBuildReturns.result1, fake.waitForBuildReturns.result2
+	}
+}
+
+func (fake *FakeDeploymentActor) WaitForBuildCallCount() int {
+	fake.waitForBuildMutex.RLock()
+	defer fake.waitForBuildMutex.RUnlock()
+	return len(fake.waitForBuildArgsForCall)
+}
+
+func (fake *FakeDeploymentActor) WaitForBuildArgsForCall(i int) models.Build {
+	fake.waitForBuildMutex.RLock()
+	defer fake.waitForBuildMutex.RUnlock()
+	return fake.waitForBuildArgsForCall[i].build
+}
+
+func (fake *FakeDeploymentActor) WaitForBuildReturns(result1 models.Build, result2 error) {
+	fake.WaitForBuildStub = nil
+	fake.waitForBuildReturns = struct {
+		result1 models.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeploymentActor) Deploy(appGUID string, params models.DeploymentParams) (models.Deployment, error) {
+	fake.deployMutex.Lock()
+	fake.deployArgsForCall = append(fake.deployArgsForCall, struct {
+		appGUID string
+		params  models.DeploymentParams
+	}{appGUID, params})
+	fake.recordInvocation("Deploy", []interface{}{appGUID, params})
+	fake.deployMutex.Unlock()
+	if fake.DeployStub != nil {
+		return fake.DeployStub(appGUID, params)
+	} else {
+		return fake.deployReturns.result1, fake.deployReturns.result2
+	}
+}
+
+func (fake *FakeDeploymentActor) DeployCallCount() int {
+	fake.deployMutex.RLock()
+	defer fake.deployMutex.RUnlock()
+	return len(fake.deployArgsForCall)
+}
+
+func (fake *FakeDeploymentActor) DeployArgsForCall(i int) (string, models.DeploymentParams) {
+	fake.deployMutex.RLock()
+	defer fake.deployMutex.RUnlock()
+	return fake.deployArgsForCall[i].appGUID, fake.deployArgsForCall[i].params
+}
+
+func (fake *FakeDeploymentActor) DeployReturns(result1 models.Deployment, result2 error) {
+	fake.DeployStub = nil
+	fake.deployReturns = struct {
+		result1 models.Deployment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeploymentActor) WaitForDeployment(deployment models.Deployment, progress func(models.Deployment)) (models.Deployment, error) {
+	fake.waitForDeploymentMutex.Lock()
+	fake.waitForDeploymentArgsForCall = append(fake.waitForDeploymentArgsForCall, struct {
+		deployment models.Deployment
+		progress   func(models.Deployment)
+	}{deployment, progress})
+	fake.recordInvocation("WaitForDeployment", []interface{}{deployment, progress})
+	fake.waitForDeploymentMutex.Unlock()
+	if fake.WaitForDeploymentStub != nil {
+		return fake.WaitForDeploymentStub(deployment, progress)
+	} else {
+		return fake.waitForDeploymentReturns.result1, fake.waitForDeploymentReturns.result2
+	}
+}
+
+func (fake *FakeDeploymentActor) WaitForDeploymentCallCount() int {
+	fake.waitForDeploymentMutex.RLock()
+	defer fake.waitForDeploymentMutex.RUnlock()
+	return len(fake.waitForDeploymentArgsForCall)
+}
+
+func (fake *FakeDeploymentActor) WaitForDeploymentArgsForCall(i int) (models.Deployment, func(models.Deployment)) {
+	fake.waitForDeploymentMutex.RLock()
+	defer fake.waitForDeploymentMutex.RUnlock()
+	return fake.waitForDeploymentArgsForCall[i].deployment, fake.waitForDeploymentArgsForCall[i].progress
+}
+
+func (fake *FakeDeploymentActor) WaitForDeploymentReturns(result1 models.Deployment, result2 error) {
+	fake.WaitForDeploymentStub = nil
+	fake.waitForDeploymentReturns = struct {
+		result1 models.Deployment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeploymentActor) ListDeployments(appGUID string) ([]models.Deployment, error) {
+	fake.listDeploymentsMutex.Lock()
+	fake.listDeploymentsArgsForCall = append(fake.listDeploymentsArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("ListDeployments", []interface{}{appGUID})
+	fake.listDeploymentsMutex.Unlock()
+	if fake.ListDeploymentsStub != nil {
+		return fake.ListDeploymentsStub(appGUID)
+	} else {
+		return fake.listDeploymentsReturns.result1, fake.listDeploymentsReturns.result2
+	}
+}
+
+func (fake *FakeDeploymentActor) ListDeploymentsCallCount() int {
+	fake.listDeploymentsMutex.RLock()
+	defer fake.listDeploymentsMutex.RUnlock()
+	return len(fake.listDeploymentsArgsForCall)
+}
+
+func (fake *FakeDeploymentActor) ListDeploymentsArgsForCall(i int) string {
+	fake.listDeploymentsMutex.RLock()
+	defer fake.listDeploymentsMutex.RUnlock()
+	return fake.listDeploymentsArgsForCall[i].appGUID
+}
+
+func (fake *FakeDeploymentActor) ListDeploymentsReturns(result1 []models.Deployment, result2 error) {
+	fake.ListDeploymentsStub = nil
+	fake.listDeploymentsReturns = struct {
+		result1 []models.Deployment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeploymentActor) CancelDeployment(appGUID string) (models.Deployment, error) {
+	fake.cancelDeploymentMutex.Lock()
+	fake.cancelDeploymentArgsForCall = append(fake.cancelDeploymentArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("CancelDeployment", []interface{}{appGUID})
+	fake.cancelDeploymentMutex.Unlock()
+	if fake.CancelDeploymentStub != nil {
+		return fake.CancelDeploymentStub(appGUID)
+	} else {
+		return fake.cancelDeploymentReturns.result1, fake.cancelDeploymentReturns.result2
+	}
+}
+
+func (fake *FakeDeploymentActor) CancelDeploymentCallCount() int {
+	fake.cancelDeploymentMutex.RLock()
+	defer fake.cancelDeploymentMutex.RUnlock()
+	return len(fake.cancelDeploymentArgsForCall)
+}
+
+func (fake *FakeDeploymentActor) CancelDeploymentArgsForCall(i int) string {
+	fake.cancelDeploymentMutex.RLock()
+	defer fake.cancelDeploymentMutex.RUnlock()
+	return fake.cancelDeploymentArgsForCall[i].appGUID
+}
+
+func (fake *FakeDeploymentActor) CancelDeploymentReturns(result1 models.Deployment, result2 error) {
+	fake.CancelDeploymentStub = nil
+	fake.cancelDeploymentReturns = struct {
+		result1 models.Deployment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeploymentActor) ContinueDeployment(appGUID string) (models.Deployment, error) {
+	fake.continueDeploymentMutex.Lock()
+	fake.continueDeploymentArgsForCall = append(fake.continueDeploymentArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("ContinueDeployment", []interface{}{appGUID})
+	fake.continueDeploymentMutex.Unlock()
+	if fake.ContinueDeploymentStub != nil {
+		return fake.ContinueDeploymentStub(appGUID)
+	} else {
+		return fake.continueDeploymentReturns.result1, fake.continueDeploymentReturns.result2
+	}
+}
+
+func (fake *FakeDeploymentActor) ContinueDeploymentCallCount() int {
+	fake.continueDeploymentMutex.RLock()
+	defer fake.continueDeploymentMutex.RUnlock()
+	return len(fake.continueDeploymentArgsForCall)
+}
+
+func (fake *FakeDeploymentActor) ContinueDeploymentArgsForCall(i int) string {
+	fake.continueDeploymentMutex.RLock()
+	defer fake.continueDeploymentMutex.RUnlock()
+	return fake.continueDeploymentArgsForCall[i].appGUID
+}
+
+func (fake *FakeDeploymentActor) ContinueDeploymentReturns(result1 models.Deployment, result2 error) {
+	fake.ContinueDeploymentStub = nil
+	fake.continueDeploymentReturns = struct {
+		result1 models.Deployment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDeploymentActor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.stageLatestPackageMutex.RLock()
+	defer fake.stageLatestPackageMutex.RUnlock()
+	fake.stagePackageMutex.RLock()
+	defer fake.stagePackageMutex.RUnlock()
+	fake.waitForBuildMutex.RLock()
+	defer fake.waitForBuildMutex.RUnlock()
+	fake.deployMutex.RLock()
+	defer fake.deployMutex.RUnlock()
+	fake.waitForDeploymentMutex.RLock()
+	defer fake.waitForDeploymentMutex.RUnlock()
+	fake.listDeploymentsMutex.RLock()
+	defer fake.listDeploymentsMutex.RUnlock()
+	fake.cancelDeploymentMutex.RLock()
+	defer fake.cancelDeploymentMutex.RUnlock()
+	fake.continueDeploymentMutex.RLock()
+	defer fake.continueDeploymentMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeDeploymentActor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ actors.DeploymentActor = new(FakeDeploymentActor)
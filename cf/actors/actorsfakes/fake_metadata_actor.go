@@ -0,0 +1,222 @@
+// This file was generated by counterfeiter
+package actorsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type FakeMetadataActor struct {
+	GetLabelsStub        func(resourceType string, name string) (models.LabeledResource, error)
+	getLabelsMutex       sync.RWMutex
+	getLabelsArgsForCall []struct {
+		resourceType string
+		name         string
+	}
+	getLabelsReturns struct {
+		result1 models.LabeledResource
+		result2 error
+	}
+	GetMetadataStub        func(resourceType string, guid string) (models.Metadata, error)
+	getMetadataMutex       sync.RWMutex
+	getMetadataArgsForCall []struct {
+		resourceType string
+		guid         string
+	}
+	getMetadataReturns struct {
+		result1 models.Metadata
+		result2 error
+	}
+	UpdateLabelsStub        func(resourceType string, name string, labels map[string]*string) error
+	updateLabelsMutex       sync.RWMutex
+	updateLabelsArgsForCall []struct {
+		resourceType string
+		name         string
+		labels       map[string]*string
+	}
+	updateLabelsReturns struct {
+		result1 error
+	}
+	MatchingGUIDsStub        func(resourceType string, selector string) (map[string]bool, error)
+	matchingGUIDsMutex       sync.RWMutex
+	matchingGUIDsArgsForCall []struct {
+		resourceType string
+		selector     string
+	}
+	matchingGUIDsReturns struct {
+		result1 map[string]bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeMetadataActor) GetLabels(resourceType string, name string) (models.LabeledResource, error) {
+	fake.getLabelsMutex.Lock()
+	fake.getLabelsArgsForCall = append(fake.getLabelsArgsForCall, struct {
+		resourceType string
+		name         string
+	}{resourceType, name})
+	fake.recordInvocation("GetLabels", []interface{}{resourceType, name})
+	fake.getLabelsMutex.Unlock()
+	if fake.GetLabelsStub != nil {
+		return fake.GetLabelsStub(resourceType, name)
+	} else {
+		return fake.getLabelsReturns.result1, fake.getLabelsReturns.result2
+	}
+}
+
+func (fake *FakeMetadataActor) GetLabelsCallCount() int {
+	fake.getLabelsMutex.RLock()
+	defer fake.getLabelsMutex.RUnlock()
+	return len(fake.getLabelsArgsForCall)
+}
+
+func (fake *FakeMetadataActor) GetLabelsArgsForCall(i int) (string, string) {
+	fake.getLabelsMutex.RLock()
+	defer fake.getLabelsMutex.RUnlock()
+	return fake.getLabelsArgsForCall[i].resourceType, fake.getLabelsArgsForCall[i].name
+}
+
+func (fake *FakeMetadataActor) GetLabelsReturns(result1 models.LabeledResource, result2 error) {
+	fake.GetLabelsStub = nil
+	fake.getLabelsReturns = struct {
+		result1 models.LabeledResource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMetadataActor) GetMetadata(resourceType string, guid string) (models.Metadata, error) {
+	fake.getMetadataMutex.Lock()
+	fake.getMetadataArgsForCall = append(fake.getMetadataArgsForCall, struct {
+		resourceType string
+		guid         string
+	}{resourceType, guid})
+	fake.recordInvocation("GetMetadata", []interface{}{resourceType, guid})
+	fake.getMetadataMutex.Unlock()
+	if fake.GetMetadataStub != nil {
+		return fake.GetMetadataStub(resourceType, guid)
+	} else {
+		return fake.getMetadataReturns.result1, fake.getMetadataReturns.result2
+	}
+}
+
+func (fake *FakeMetadataActor) GetMetadataCallCount() int {
+	fake.getMetadataMutex.RLock()
+	defer fake.getMetadataMutex.RUnlock()
+	return len(fake.getMetadataArgsForCall)
+}
+
+func (fake *FakeMetadataActor) GetMetadataArgsForCall(i int) (string, string) {
+	fake.getMetadataMutex.RLock()
+	defer fake.getMetadataMutex.RUnlock()
+	return fake.getMetadataArgsForCall[i].resourceType, fake.getMetadataArgsForCall[i].guid
+}
+
+func (fake *FakeMetadataActor) GetMetadataReturns(result1 models.Metadata, result2 error) {
+	fake.GetMetadataStub = nil
+	fake.getMetadataReturns = struct {
+		result1 models.Metadata
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMetadataActor) UpdateLabels(resourceType string, name string, labels map[string]*string) error {
+	fake.updateLabelsMutex.Lock()
+	fake.updateLabelsArgsForCall = append(fake.updateLabelsArgsForCall, struct {
+		resourceType string
+		name         string
+		labels       map[string]*string
+	}{resourceType, name, labels})
+	fake.recordInvocation("UpdateLabels", []interface{}{resourceType, name, labels})
+	fake.updateLabelsMutex.Unlock()
+	if fake.UpdateLabelsStub != nil {
+		return fake.UpdateLabelsStub(resourceType, name, labels)
+	} else {
+		return fake.updateLabelsReturns.result1
+	}
+}
+
+func (fake *FakeMetadataActor) UpdateLabelsCallCount() int {
+	fake.updateLabelsMutex.RLock()
+	defer fake.updateLabelsMutex.RUnlock()
+	return len(fake.updateLabelsArgsForCall)
+}
+
+func (fake *FakeMetadataActor) UpdateLabelsArgsForCall(i int) (string, string, map[string]*string) {
+	fake.updateLabelsMutex.RLock()
+	defer fake.updateLabelsMutex.RUnlock()
+	return fake.updateLabelsArgsForCall[i].resourceType, fake.updateLabelsArgsForCall[i].name, fake.updateLabelsArgsForCall[i].labels
+}
+
+func (fake *FakeMetadataActor) UpdateLabelsReturns(result1 error) {
+	fake.UpdateLabelsStub = nil
+	fake.updateLabelsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeMetadataActor) MatchingGUIDs(resourceType string, selector string) (map[string]bool, error) {
+	fake.matchingGUIDsMutex.Lock()
+	fake.matchingGUIDsArgsForCall = append(fake.matchingGUIDsArgsForCall, struct {
+		resourceType string
+		selector     string
+	}{resourceType, selector})
+	fake.recordInvocation("MatchingGUIDs", []interface{}{resourceType, selector})
+	fake.matchingGUIDsMutex.Unlock()
+	if fake.MatchingGUIDsStub != nil {
+		return fake.MatchingGUIDsStub(resourceType, selector)
+	} else {
+		return fake.matchingGUIDsReturns.result1, fake.matchingGUIDsReturns.result2
+	}
+}
+
+func (fake *FakeMetadataActor) MatchingGUIDsCallCount() int {
+	fake.matchingGUIDsMutex.RLock()
+	defer fake.matchingGUIDsMutex.RUnlock()
+	return len(fake.matchingGUIDsArgsForCall)
+}
+
+func (fake *FakeMetadataActor) MatchingGUIDsArgsForCall(i int) (string, string) {
+	fake.matchingGUIDsMutex.RLock()
+	defer fake.matchingGUIDsMutex.RUnlock()
+	return fake.matchingGUIDsArgsForCall[i].resourceType, fake.matchingGUIDsArgsForCall[i].selector
+}
+
+func (fake *FakeMetadataActor) MatchingGUIDsReturns(result1 map[string]bool, result2 error) {
+	fake.MatchingGUIDsStub = nil
+	fake.matchingGUIDsReturns = struct {
+		result1 map[string]bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeMetadataActor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getLabelsMutex.RLock()
+	defer fake.getLabelsMutex.RUnlock()
+	fake.getMetadataMutex.RLock()
+	defer fake.getMetadataMutex.RUnlock()
+	fake.updateLabelsMutex.RLock()
+	defer fake.updateLabelsMutex.RUnlock()
+	fake.matchingGUIDsMutex.RLock()
+	defer fake.matchingGUIDsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeMetadataActor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ actors.MetadataActor = new(FakeMetadataActor)
@@ -21,6 +21,18 @@ type FakePushActor struct {
 	uploadAppReturns struct {
 		result1 error
 	}
+	UploadAppInChunksStub        func(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource, chunkSize int64, maxConcurrency int) error
+	uploadAppInChunksMutex       sync.RWMutex
+	uploadAppInChunksArgsForCall []struct {
+		appGUID        string
+		zipFile        *os.File
+		presentFiles   []resources.AppFileResource
+		chunkSize      int64
+		maxConcurrency int
+	}
+	uploadAppInChunksReturns struct {
+		result1 error
+	}
 	ProcessPathStub        func(dirOrZipFile string, f func(string) error) error
 	processPathMutex       sync.RWMutex
 	processPathArgsForCall []struct {
@@ -30,16 +42,16 @@ type FakePushActor struct {
 	processPathReturns struct {
 		result1 error
 	}
-	GatherFilesStub        func(localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error)
+	GatherFilesStub        func(appGUID string, localFiles []models.AppFileFields, appDir string) ([]resources.AppFileResource, []models.AppFileFields, error)
 	gatherFilesMutex       sync.RWMutex
 	gatherFilesArgsForCall []struct {
+		appGUID    string
 		localFiles []models.AppFileFields
 		appDir     string
-		uploadDir  string
 	}
 	gatherFilesReturns struct {
 		result1 []resources.AppFileResource
-		result2 bool
+		result2 []models.AppFileFields
 		result3 error
 	}
 	ValidateAppParamsStub        func(apps []models.AppParams) []error
@@ -60,6 +72,17 @@ type FakePushActor struct {
 	mapManifestRouteReturns struct {
 		result1 error
 	}
+	DiffAppStub        func(app models.Application, appParams models.AppParams, localFiles []models.AppFileFields) (actors.PushDiff, error)
+	diffAppMutex       sync.RWMutex
+	diffAppArgsForCall []struct {
+		app        models.Application
+		appParams  models.AppParams
+		localFiles []models.AppFileFields
+	}
+	diffAppReturns struct {
+		result1 actors.PushDiff
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -104,6 +127,48 @@ func (fake *FakePushActor) UploadAppReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePushActor) UploadAppInChunks(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource, chunkSize int64, maxConcurrency int) error {
+	var presentFilesCopy []resources.AppFileResource
+	if presentFiles != nil {
+		presentFilesCopy = make([]resources.AppFileResource, len(presentFiles))
+		copy(presentFilesCopy, presentFiles)
+	}
+	fake.uploadAppInChunksMutex.Lock()
+	fake.uploadAppInChunksArgsForCall = append(fake.uploadAppInChunksArgsForCall, struct {
+		appGUID        string
+		zipFile        *os.File
+		presentFiles   []resources.AppFileResource
+		chunkSize      int64
+		maxConcurrency int
+	}{appGUID, zipFile, presentFilesCopy, chunkSize, maxConcurrency})
+	fake.recordInvocation("UploadAppInChunks", []interface{}{appGUID, zipFile, presentFilesCopy, chunkSize, maxConcurrency})
+	fake.uploadAppInChunksMutex.Unlock()
+	if fake.UploadAppInChunksStub != nil {
+		return fake.UploadAppInChunksStub(appGUID, zipFile, presentFiles, chunkSize, maxConcurrency)
+	} else {
+		return fake.uploadAppInChunksReturns.result1
+	}
+}
+
+func (fake *FakePushActor) UploadAppInChunksCallCount() int {
+	fake.uploadAppInChunksMutex.RLock()
+	defer fake.uploadAppInChunksMutex.RUnlock()
+	return len(fake.uploadAppInChunksArgsForCall)
+}
+
+func (fake *FakePushActor) UploadAppInChunksArgsForCall(i int) (string, *os.File, []resources.AppFileResource, int64, int) {
+	fake.uploadAppInChunksMutex.RLock()
+	defer fake.uploadAppInChunksMutex.RUnlock()
+	return fake.uploadAppInChunksArgsForCall[i].appGUID, fake.uploadAppInChunksArgsForCall[i].zipFile, fake.uploadAppInChunksArgsForCall[i].presentFiles, fake.uploadAppInChunksArgsForCall[i].chunkSize, fake.uploadAppInChunksArgsForCall[i].maxConcurrency
+}
+
+func (fake *FakePushActor) UploadAppInChunksReturns(result1 error) {
+	fake.UploadAppInChunksStub = nil
+	fake.uploadAppInChunksReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakePushActor) ProcessPath(dirOrZipFile string, f func(string) error) error {
 	fake.processPathMutex.Lock()
 	fake.processPathArgsForCall = append(fake.processPathArgsForCall, struct {
@@ -138,7 +203,7 @@ func (fake *FakePushActor) ProcessPathReturns(result1 error) {
 	}{result1}
 }
 
-func (fake *FakePushActor) GatherFiles(localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error) {
+func (fake *FakePushActor) GatherFiles(appGUID string, localFiles []models.AppFileFields, appDir string) ([]resources.AppFileResource, []models.AppFileFields, error) {
 	var localFilesCopy []models.AppFileFields
 	if localFiles != nil {
 		localFilesCopy = make([]models.AppFileFields, len(localFiles))
@@ -146,14 +211,14 @@ func (fake *FakePushActor) GatherFiles(localFiles []models.AppFileFields, appDir
 	}
 	fake.gatherFilesMutex.Lock()
 	fake.gatherFilesArgsForCall = append(fake.gatherFilesArgsForCall, struct {
+		appGUID    string
 		localFiles []models.AppFileFields
 		appDir     string
-		uploadDir  string
-	}{localFilesCopy, appDir, uploadDir})
-	fake.recordInvocation("GatherFiles", []interface{}{localFilesCopy, appDir, uploadDir})
+	}{appGUID, localFilesCopy, appDir})
+	fake.recordInvocation("GatherFiles", []interface{}{appGUID, localFilesCopy, appDir})
 	fake.gatherFilesMutex.Unlock()
 	if fake.GatherFilesStub != nil {
-		return fake.GatherFilesStub(localFiles, appDir, uploadDir)
+		return fake.GatherFilesStub(appGUID, localFiles, appDir)
 	} else {
 		return fake.gatherFilesReturns.result1, fake.gatherFilesReturns.result2, fake.gatherFilesReturns.result3
 	}
@@ -165,17 +230,17 @@ func (fake *FakePushActor) GatherFilesCallCount() int {
 	return len(fake.gatherFilesArgsForCall)
 }
 
-func (fake *FakePushActor) GatherFilesArgsForCall(i int) ([]models.AppFileFields, string, string) {
+func (fake *FakePushActor) GatherFilesArgsForCall(i int) (string, []models.AppFileFields, string) {
 	fake.gatherFilesMutex.RLock()
 	defer fake.gatherFilesMutex.RUnlock()
-	return fake.gatherFilesArgsForCall[i].localFiles, fake.gatherFilesArgsForCall[i].appDir, fake.gatherFilesArgsForCall[i].uploadDir
+	return fake.gatherFilesArgsForCall[i].appGUID, fake.gatherFilesArgsForCall[i].localFiles, fake.gatherFilesArgsForCall[i].appDir
 }
 
-func (fake *FakePushActor) GatherFilesReturns(result1 []resources.AppFileResource, result2 bool, result3 error) {
+func (fake *FakePushActor) GatherFilesReturns(result1 []resources.AppFileResource, result2 []models.AppFileFields, result3 error) {
 	fake.GatherFilesStub = nil
 	fake.gatherFilesReturns = struct {
 		result1 []resources.AppFileResource
-		result2 bool
+		result2 []models.AppFileFields
 		result3 error
 	}{result1, result2, result3}
 }
@@ -253,11 +318,54 @@ func (fake *FakePushActor) MapManifestRouteReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakePushActor) DiffApp(app models.Application, appParams models.AppParams, localFiles []models.AppFileFields) (actors.PushDiff, error) {
+	var localFilesCopy []models.AppFileFields
+	if localFiles != nil {
+		localFilesCopy = make([]models.AppFileFields, len(localFiles))
+		copy(localFilesCopy, localFiles)
+	}
+	fake.diffAppMutex.Lock()
+	fake.diffAppArgsForCall = append(fake.diffAppArgsForCall, struct {
+		app        models.Application
+		appParams  models.AppParams
+		localFiles []models.AppFileFields
+	}{app, appParams, localFilesCopy})
+	fake.recordInvocation("DiffApp", []interface{}{app, appParams, localFilesCopy})
+	fake.diffAppMutex.Unlock()
+	if fake.DiffAppStub != nil {
+		return fake.DiffAppStub(app, appParams, localFiles)
+	} else {
+		return fake.diffAppReturns.result1, fake.diffAppReturns.result2
+	}
+}
+
+func (fake *FakePushActor) DiffAppCallCount() int {
+	fake.diffAppMutex.RLock()
+	defer fake.diffAppMutex.RUnlock()
+	return len(fake.diffAppArgsForCall)
+}
+
+func (fake *FakePushActor) DiffAppArgsForCall(i int) (models.Application, models.AppParams, []models.AppFileFields) {
+	fake.diffAppMutex.RLock()
+	defer fake.diffAppMutex.RUnlock()
+	return fake.diffAppArgsForCall[i].app, fake.diffAppArgsForCall[i].appParams, fake.diffAppArgsForCall[i].localFiles
+}
+
+func (fake *FakePushActor) DiffAppReturns(result1 actors.PushDiff, result2 error) {
+	fake.DiffAppStub = nil
+	fake.diffAppReturns = struct {
+		result1 actors.PushDiff
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePushActor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.uploadAppMutex.RLock()
 	defer fake.uploadAppMutex.RUnlock()
+	fake.uploadAppInChunksMutex.RLock()
+	defer fake.uploadAppInChunksMutex.RUnlock()
 	fake.processPathMutex.RLock()
 	defer fake.processPathMutex.RUnlock()
 	fake.gatherFilesMutex.RLock()
@@ -266,6 +374,8 @@ func (fake *FakePushActor) Invocations() map[string][][]interface{} {
 	defer fake.validateAppParamsMutex.RUnlock()
 	fake.mapManifestRouteMutex.RLock()
 	defer fake.mapManifestRouteMutex.RUnlock()
+	fake.diffAppMutex.RLock()
+	defer fake.diffAppMutex.RUnlock()
 	return fake.invocations
 }
 
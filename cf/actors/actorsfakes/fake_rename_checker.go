@@ -0,0 +1,78 @@
+// This file was generated by counterfeiter
+package actorsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+)
+
+type FakeRenameChecker struct {
+	ReferencesStub        func(name string, appPath string) []string
+	referencesMutex       sync.RWMutex
+	referencesArgsForCall []struct {
+		name    string
+		appPath string
+	}
+	referencesReturns struct {
+		result1 []string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRenameChecker) References(name string, appPath string) []string {
+	fake.referencesMutex.Lock()
+	fake.referencesArgsForCall = append(fake.referencesArgsForCall, struct {
+		name    string
+		appPath string
+	}{name, appPath})
+	fake.recordInvocation("References", []interface{}{name, appPath})
+	fake.referencesMutex.Unlock()
+	if fake.ReferencesStub != nil {
+		return fake.ReferencesStub(name, appPath)
+	} else {
+		return fake.referencesReturns.result1
+	}
+}
+
+func (fake *FakeRenameChecker) ReferencesCallCount() int {
+	fake.referencesMutex.RLock()
+	defer fake.referencesMutex.RUnlock()
+	return len(fake.referencesArgsForCall)
+}
+
+func (fake *FakeRenameChecker) ReferencesArgsForCall(i int) (string, string) {
+	fake.referencesMutex.RLock()
+	defer fake.referencesMutex.RUnlock()
+	return fake.referencesArgsForCall[i].name, fake.referencesArgsForCall[i].appPath
+}
+
+func (fake *FakeRenameChecker) ReferencesReturns(result1 []string) {
+	fake.ReferencesStub = nil
+	fake.referencesReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeRenameChecker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.referencesMutex.RLock()
+	defer fake.referencesMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRenameChecker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ actors.RenameChecker = new(FakeRenameChecker)
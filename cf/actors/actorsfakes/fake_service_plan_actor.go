@@ -58,6 +58,18 @@ type FakeServicePlanActor struct {
 	updatePlanAndOrgForServiceReturns struct {
 		result1 error
 	}
+	UpdatePlansAndOrgsForServiceStub        func(string, []string, []string, bool) ([]actors.ServiceAccessUpdate, error)
+	updatePlansAndOrgsForServiceMutex       sync.RWMutex
+	updatePlansAndOrgsForServiceArgsForCall []struct {
+		arg1 string
+		arg2 []string
+		arg3 []string
+		arg4 bool
+	}
+	updatePlansAndOrgsForServiceReturns struct {
+		result1 []actors.ServiceAccessUpdate
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -237,6 +249,53 @@ func (fake *FakeServicePlanActor) UpdatePlanAndOrgForServiceReturns(result1 erro
 	}{result1}
 }
 
+func (fake *FakeServicePlanActor) UpdatePlansAndOrgsForService(arg1 string, arg2 []string, arg3 []string, arg4 bool) ([]actors.ServiceAccessUpdate, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.updatePlansAndOrgsForServiceMutex.Lock()
+	fake.updatePlansAndOrgsForServiceArgsForCall = append(fake.updatePlansAndOrgsForServiceArgsForCall, struct {
+		arg1 string
+		arg2 []string
+		arg3 []string
+		arg4 bool
+	}{arg1, arg2Copy, arg3Copy, arg4})
+	fake.recordInvocation("UpdatePlansAndOrgsForService", []interface{}{arg1, arg2Copy, arg3Copy, arg4})
+	fake.updatePlansAndOrgsForServiceMutex.Unlock()
+	if fake.UpdatePlansAndOrgsForServiceStub != nil {
+		return fake.UpdatePlansAndOrgsForServiceStub(arg1, arg2, arg3, arg4)
+	} else {
+		return fake.updatePlansAndOrgsForServiceReturns.result1, fake.updatePlansAndOrgsForServiceReturns.result2
+	}
+}
+
+func (fake *FakeServicePlanActor) UpdatePlansAndOrgsForServiceCallCount() int {
+	fake.updatePlansAndOrgsForServiceMutex.RLock()
+	defer fake.updatePlansAndOrgsForServiceMutex.RUnlock()
+	return len(fake.updatePlansAndOrgsForServiceArgsForCall)
+}
+
+func (fake *FakeServicePlanActor) UpdatePlansAndOrgsForServiceArgsForCall(i int) (string, []string, []string, bool) {
+	fake.updatePlansAndOrgsForServiceMutex.RLock()
+	defer fake.updatePlansAndOrgsForServiceMutex.RUnlock()
+	return fake.updatePlansAndOrgsForServiceArgsForCall[i].arg1, fake.updatePlansAndOrgsForServiceArgsForCall[i].arg2, fake.updatePlansAndOrgsForServiceArgsForCall[i].arg3, fake.updatePlansAndOrgsForServiceArgsForCall[i].arg4
+}
+
+func (fake *FakeServicePlanActor) UpdatePlansAndOrgsForServiceReturns(result1 []actors.ServiceAccessUpdate, result2 error) {
+	fake.UpdatePlansAndOrgsForServiceStub = nil
+	fake.updatePlansAndOrgsForServiceReturns = struct {
+		result1 []actors.ServiceAccessUpdate
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeServicePlanActor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -250,6 +309,8 @@ func (fake *FakeServicePlanActor) Invocations() map[string][][]interface{} {
 	defer fake.updateSinglePlanForServiceMutex.RUnlock()
 	fake.updatePlanAndOrgForServiceMutex.RLock()
 	defer fake.updatePlanAndOrgForServiceMutex.RUnlock()
+	fake.updatePlansAndOrgsForServiceMutex.RLock()
+	defer fake.updatePlansAndOrgsForServiceMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,173 @@
+// This file was generated by counterfeiter
+package actorsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type FakeTaskActor struct {
+	RunTaskStub        func(appGUID string, params models.TaskParams) (models.Task, error)
+	runTaskMutex       sync.RWMutex
+	runTaskArgsForCall []struct {
+		appGUID string
+		params  models.TaskParams
+	}
+	runTaskReturns struct {
+		result1 models.Task
+		result2 error
+	}
+	ListTasksStub        func(appGUID string) ([]models.Task, error)
+	listTasksMutex       sync.RWMutex
+	listTasksArgsForCall []struct {
+		appGUID string
+	}
+	listTasksReturns struct {
+		result1 []models.Task
+		result2 error
+	}
+	TerminateTaskStub        func(appGUID string, sequenceID int) (models.Task, error)
+	terminateTaskMutex       sync.RWMutex
+	terminateTaskArgsForCall []struct {
+		appGUID    string
+		sequenceID int
+	}
+	terminateTaskReturns struct {
+		result1 models.Task
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTaskActor) RunTask(appGUID string, params models.TaskParams) (models.Task, error) {
+	fake.runTaskMutex.Lock()
+	fake.runTaskArgsForCall = append(fake.runTaskArgsForCall, struct {
+		appGUID string
+		params  models.TaskParams
+	}{appGUID, params})
+	fake.recordInvocation("RunTask", []interface{}{appGUID, params})
+	fake.runTaskMutex.Unlock()
+	if fake.RunTaskStub != nil {
+		return fake.RunTaskStub(appGUID, params)
+	} else {
+		return fake.runTaskReturns.result1, fake.runTaskReturns.result2
+	}
+}
+
+func (fake *FakeTaskActor) RunTaskCallCount() int {
+	fake.runTaskMutex.RLock()
+	defer fake.runTaskMutex.RUnlock()
+	return len(fake.runTaskArgsForCall)
+}
+
+func (fake *FakeTaskActor) RunTaskArgsForCall(i int) (string, models.TaskParams) {
+	fake.runTaskMutex.RLock()
+	defer fake.runTaskMutex.RUnlock()
+	return fake.runTaskArgsForCall[i].appGUID, fake.runTaskArgsForCall[i].params
+}
+
+func (fake *FakeTaskActor) RunTaskReturns(result1 models.Task, result2 error) {
+	fake.RunTaskStub = nil
+	fake.runTaskReturns = struct {
+		result1 models.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskActor) ListTasks(appGUID string) ([]models.Task, error) {
+	fake.listTasksMutex.Lock()
+	fake.listTasksArgsForCall = append(fake.listTasksArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("ListTasks", []interface{}{appGUID})
+	fake.listTasksMutex.Unlock()
+	if fake.ListTasksStub != nil {
+		return fake.ListTasksStub(appGUID)
+	} else {
+		return fake.listTasksReturns.result1, fake.listTasksReturns.result2
+	}
+}
+
+func (fake *FakeTaskActor) ListTasksCallCount() int {
+	fake.listTasksMutex.RLock()
+	defer fake.listTasksMutex.RUnlock()
+	return len(fake.listTasksArgsForCall)
+}
+
+func (fake *FakeTaskActor) ListTasksArgsForCall(i int) string {
+	fake.listTasksMutex.RLock()
+	defer fake.listTasksMutex.RUnlock()
+	return fake.listTasksArgsForCall[i].appGUID
+}
+
+func (fake *FakeTaskActor) ListTasksReturns(result1 []models.Task, result2 error) {
+	fake.ListTasksStub = nil
+	fake.listTasksReturns = struct {
+		result1 []models.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskActor) TerminateTask(appGUID string, sequenceID int) (models.Task, error) {
+	fake.terminateTaskMutex.Lock()
+	fake.terminateTaskArgsForCall = append(fake.terminateTaskArgsForCall, struct {
+		appGUID    string
+		sequenceID int
+	}{appGUID, sequenceID})
+	fake.recordInvocation("TerminateTask", []interface{}{appGUID, sequenceID})
+	fake.terminateTaskMutex.Unlock()
+	if fake.TerminateTaskStub != nil {
+		return fake.TerminateTaskStub(appGUID, sequenceID)
+	} else {
+		return fake.terminateTaskReturns.result1, fake.terminateTaskReturns.result2
+	}
+}
+
+func (fake *FakeTaskActor) TerminateTaskCallCount() int {
+	fake.terminateTaskMutex.RLock()
+	defer fake.terminateTaskMutex.RUnlock()
+	return len(fake.terminateTaskArgsForCall)
+}
+
+func (fake *FakeTaskActor) TerminateTaskArgsForCall(i int) (string, int) {
+	fake.terminateTaskMutex.RLock()
+	defer fake.terminateTaskMutex.RUnlock()
+	return fake.terminateTaskArgsForCall[i].appGUID, fake.terminateTaskArgsForCall[i].sequenceID
+}
+
+func (fake *FakeTaskActor) TerminateTaskReturns(result1 models.Task, result2 error) {
+	fake.TerminateTaskStub = nil
+	fake.terminateTaskReturns = struct {
+		result1 models.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskActor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.runTaskMutex.RLock()
+	defer fake.runTaskMutex.RUnlock()
+	fake.listTasksMutex.RLock()
+	defer fake.listTasksMutex.RUnlock()
+	fake.terminateTaskMutex.RLock()
+	defer fake.terminateTaskMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeTaskActor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ actors.TaskActor = new(FakeTaskActor)
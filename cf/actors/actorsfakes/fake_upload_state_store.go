@@ -0,0 +1,166 @@
+// This file was generated by counterfeiter
+package actorsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+)
+
+type FakeUploadStateStore struct {
+	LoadStub        func(appGUID string) (actors.UploadState, error)
+	loadMutex       sync.RWMutex
+	loadArgsForCall []struct {
+		appGUID string
+	}
+	loadReturns struct {
+		result1 actors.UploadState
+		result2 error
+	}
+	SaveStub        func(appGUID string, state actors.UploadState) error
+	saveMutex       sync.RWMutex
+	saveArgsForCall []struct {
+		appGUID string
+		state   actors.UploadState
+	}
+	saveReturns struct {
+		result1 error
+	}
+	DeleteStub        func(appGUID string) error
+	deleteMutex       sync.RWMutex
+	deleteArgsForCall []struct {
+		appGUID string
+	}
+	deleteReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeUploadStateStore) Load(appGUID string) (actors.UploadState, error) {
+	fake.loadMutex.Lock()
+	fake.loadArgsForCall = append(fake.loadArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("Load", []interface{}{appGUID})
+	fake.loadMutex.Unlock()
+	if fake.LoadStub != nil {
+		return fake.LoadStub(appGUID)
+	} else {
+		return fake.loadReturns.result1, fake.loadReturns.result2
+	}
+}
+
+func (fake *FakeUploadStateStore) LoadCallCount() int {
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	return len(fake.loadArgsForCall)
+}
+
+func (fake *FakeUploadStateStore) LoadArgsForCall(i int) string {
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	return fake.loadArgsForCall[i].appGUID
+}
+
+func (fake *FakeUploadStateStore) LoadReturns(result1 actors.UploadState, result2 error) {
+	fake.LoadStub = nil
+	fake.loadReturns = struct {
+		result1 actors.UploadState
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeUploadStateStore) Save(appGUID string, state actors.UploadState) error {
+	fake.saveMutex.Lock()
+	fake.saveArgsForCall = append(fake.saveArgsForCall, struct {
+		appGUID string
+		state   actors.UploadState
+	}{appGUID, state})
+	fake.recordInvocation("Save", []interface{}{appGUID, state})
+	fake.saveMutex.Unlock()
+	if fake.SaveStub != nil {
+		return fake.SaveStub(appGUID, state)
+	} else {
+		return fake.saveReturns.result1
+	}
+}
+
+func (fake *FakeUploadStateStore) SaveCallCount() int {
+	fake.saveMutex.RLock()
+	defer fake.saveMutex.RUnlock()
+	return len(fake.saveArgsForCall)
+}
+
+func (fake *FakeUploadStateStore) SaveArgsForCall(i int) (string, actors.UploadState) {
+	fake.saveMutex.RLock()
+	defer fake.saveMutex.RUnlock()
+	return fake.saveArgsForCall[i].appGUID, fake.saveArgsForCall[i].state
+}
+
+func (fake *FakeUploadStateStore) SaveReturns(result1 error) {
+	fake.SaveStub = nil
+	fake.saveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUploadStateStore) Delete(appGUID string) error {
+	fake.deleteMutex.Lock()
+	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("Delete", []interface{}{appGUID})
+	fake.deleteMutex.Unlock()
+	if fake.DeleteStub != nil {
+		return fake.DeleteStub(appGUID)
+	} else {
+		return fake.deleteReturns.result1
+	}
+}
+
+func (fake *FakeUploadStateStore) DeleteCallCount() int {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return len(fake.deleteArgsForCall)
+}
+
+func (fake *FakeUploadStateStore) DeleteArgsForCall(i int) string {
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return fake.deleteArgsForCall[i].appGUID
+}
+
+func (fake *FakeUploadStateStore) DeleteReturns(result1 error) {
+	fake.DeleteStub = nil
+	fake.deleteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUploadStateStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	fake.saveMutex.RLock()
+	defer fake.saveMutex.RUnlock()
+	fake.deleteMutex.RLock()
+	defer fake.deleteMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeUploadStateStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ actors.UploadStateStore = new(FakeUploadStateStore)
@@ -0,0 +1,129 @@
+package actors
+
+import (
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+// ManifestPlan is what applying a manifest changes in a space: the apps it
+// declares that do not exist yet are created, the ones that exist but differ
+// from the manifest are updated and the apps in the space it does not declare
+// are deleted. Apps that already match the manifest are left unchanged.
+type ManifestPlan struct {
+	Create    []string
+	Update    []string
+	Unchanged []string
+	Delete    []models.Application
+}
+
+// HasChanges reports whether applying the manifest changes the space.
+func (plan ManifestPlan) HasChanges() bool {
+	return len(plan.Create) > 0 || len(plan.Update) > 0 || len(plan.Delete) > 0
+}
+
+// PlanManifest works out the ManifestPlan for the apps declared in a manifest
+// against the apps in the space. Apps are matched by name, and keep the order
+// of the manifest or of the space.
+func PlanManifest(declared []models.AppParams, existing []models.Application) ManifestPlan {
+	plan := ManifestPlan{}
+
+	existingApps := map[string]models.Application{}
+	for _, app := range existing {
+		existingApps[app.Name] = app
+	}
+
+	declaredNames := map[string]bool{}
+	for _, app := range declared {
+		if app.Name == nil {
+			continue
+		}
+
+		declaredNames[*app.Name] = true
+		existingApp, found := existingApps[*app.Name]
+		switch {
+		case !found:
+			plan.Create = append(plan.Create, *app.Name)
+		case appChanged(app, existingApp):
+			plan.Update = append(plan.Update, *app.Name)
+		default:
+			plan.Unchanged = append(plan.Unchanged, *app.Name)
+		}
+	}
+
+	for _, app := range existing {
+		if !declaredNames[app.Name] {
+			plan.Delete = append(plan.Delete, app)
+		}
+	}
+
+	return plan
+}
+
+// appChanged reports whether the instances, memory, disk, buildpack,
+// environment or routes the manifest declares for an app differ from the
+// app in the space. Attributes the manifest leaves out are not compared.
+func appChanged(declared models.AppParams, existing models.Application) bool {
+	if declared.InstanceCount != nil && *declared.InstanceCount != existing.InstanceCount {
+		return true
+	}
+	if declared.Memory != nil && *declared.Memory != existing.Memory {
+		return true
+	}
+	if declared.DiskQuota != nil && *declared.DiskQuota != existing.DiskQuota {
+		return true
+	}
+
+	if declared.BuildpackURL != nil && *declared.BuildpackURL != existing.BuildpackURL {
+		return true
+	}
+	if declared.Buildpacks != nil {
+		if len(declared.Buildpacks) != 1 || declared.Buildpacks[0] != existing.BuildpackURL {
+			return true
+		}
+	}
+
+	// push merges the declared variables into the ones the app has, so only
+	// the declared ones have to match
+	if declared.EnvironmentVars != nil {
+		for name, value := range *declared.EnvironmentVars {
+			existingValue, found := existing.EnvironmentVars[name]
+			if !found || fmt.Sprint(value) != fmt.Sprint(existingValue) {
+				return true
+			}
+		}
+	}
+
+	if declared.NoRoute {
+		return len(existing.Routes) > 0
+	}
+	if declared.Routes != nil {
+		return routesChanged(declared.Routes, existing.Routes)
+	}
+
+	return false
+}
+
+func routesChanged(declared []models.ManifestRoute, existing []models.RouteSummary) bool {
+	declaredURLs := map[string]bool{}
+	for _, route := range declared {
+		declaredURLs[strings.ToLower(route.Route)] = true
+	}
+
+	existingURLs := map[string]bool{}
+	for _, route := range existing {
+		existingURLs[strings.ToLower(route.URL())] = true
+	}
+
+	if len(declaredURLs) != len(existingURLs) {
+		return true
+	}
+	for url := range declaredURLs {
+		if !existingURLs[url] {
+			return true
+		}
+	}
+
+	return false
+}
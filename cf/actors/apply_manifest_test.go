@@ -0,0 +1,148 @@
+package actors_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/models"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Apply Manifest", func() {
+	Describe("PlanManifest", func() {
+		declare := func(names ...string) []models.AppParams {
+			apps := []models.AppParams{}
+			for i := range names {
+				apps = append(apps, models.AppParams{Name: &names[i]})
+			}
+			return apps
+		}
+
+		existing := func(names ...string) []models.Application {
+			apps := []models.Application{}
+			for _, name := range names {
+				app := models.Application{}
+				app.Name = name
+				app.GUID = name + "-guid"
+				apps = append(apps, app)
+			}
+			return apps
+		}
+
+		It("creates the declared apps the space does not have", func() {
+			plan := actors.PlanManifest(declare("app-1", "app-2"), existing())
+			Expect(plan.Create).To(Equal([]string{"app-1", "app-2"}))
+			Expect(plan.Update).To(BeEmpty())
+			Expect(plan.Delete).To(BeEmpty())
+			Expect(plan.HasChanges()).To(BeTrue())
+		})
+
+		It("leaves the declared apps the space has unchanged when the manifest declares nothing else", func() {
+			plan := actors.PlanManifest(declare("app-1", "app-2"), existing("app-2"))
+			Expect(plan.Create).To(Equal([]string{"app-1"}))
+			Expect(plan.Update).To(BeEmpty())
+			Expect(plan.Unchanged).To(Equal([]string{"app-2"}))
+		})
+
+		It("deletes the apps in the space the manifest does not declare", func() {
+			plan := actors.PlanManifest(declare("app-2"), existing("app-3", "app-2", "app-1"))
+			Expect(plan.Unchanged).To(Equal([]string{"app-2"}))
+			Expect(plan.Delete).To(HaveLen(2))
+			Expect(plan.Delete[0].GUID).To(Equal("app-3-guid"))
+			Expect(plan.Delete[1].GUID).To(Equal("app-1-guid"))
+		})
+
+		It("has no changes when the manifest declares no apps and the space is empty", func() {
+			plan := actors.PlanManifest(declare(), existing())
+			Expect(plan.HasChanges()).To(BeFalse())
+		})
+
+		Context("when the space has the declared app", func() {
+			var (
+				declared []models.AppParams
+				apps     []models.Application
+			)
+
+			BeforeEach(func() {
+				instances := 2
+				memory := int64(256)
+				disk := int64(1024)
+				buildpack := "go_buildpack"
+				env := map[string]interface{}{"PORT": 8080}
+
+				declared = declare("app-1")
+				declared[0].InstanceCount = &instances
+				declared[0].Memory = &memory
+				declared[0].DiskQuota = &disk
+				declared[0].BuildpackURL = &buildpack
+				declared[0].EnvironmentVars = &env
+				declared[0].Routes = []models.ManifestRoute{{Route: "app-1.example.com"}, {Route: "App-1.example.com/api"}}
+
+				apps = existing("app-1")
+				apps[0].InstanceCount = 2
+				apps[0].Memory = 256
+				apps[0].DiskQuota = 1024
+				apps[0].BuildpackURL = "go_buildpack"
+				apps[0].EnvironmentVars = map[string]interface{}{"PORT": float64(8080), "OTHER": "value"}
+				apps[0].Routes = []models.RouteSummary{
+					{Host: "app-1", Domain: models.DomainFields{Name: "example.com"}},
+					{Host: "app-1", Domain: models.DomainFields{Name: "example.com"}, Path: "/api"},
+				}
+			})
+
+			It("leaves the app unchanged when the declared attributes match", func() {
+				plan := actors.PlanManifest(declared, apps)
+				Expect(plan.Update).To(BeEmpty())
+				Expect(plan.Unchanged).To(Equal([]string{"app-1"}))
+				Expect(plan.HasChanges()).To(BeFalse())
+			})
+
+			It("updates the app when the instances differ", func() {
+				apps[0].InstanceCount = 1
+				Expect(actors.PlanManifest(declared, apps).Update).To(Equal([]string{"app-1"}))
+			})
+
+			It("updates the app when the memory differs", func() {
+				apps[0].Memory = 512
+				Expect(actors.PlanManifest(declared, apps).Update).To(Equal([]string{"app-1"}))
+			})
+
+			It("updates the app when the disk differs", func() {
+				apps[0].DiskQuota = 2048
+				Expect(actors.PlanManifest(declared, apps).Update).To(Equal([]string{"app-1"}))
+			})
+
+			It("updates the app when the buildpack differs", func() {
+				apps[0].BuildpackURL = "java_buildpack"
+				Expect(actors.PlanManifest(declared, apps).Update).To(Equal([]string{"app-1"}))
+			})
+
+			It("updates the app when more than one buildpack is declared", func() {
+				declared[0].BuildpackURL = nil
+				declared[0].Buildpacks = []string{"go_buildpack", "other_buildpack"}
+				Expect(actors.PlanManifest(declared, apps).Update).To(Equal([]string{"app-1"}))
+			})
+
+			It("updates the app when a declared environment variable differs or is missing", func() {
+				apps[0].EnvironmentVars = map[string]interface{}{"PORT": float64(9090)}
+				Expect(actors.PlanManifest(declared, apps).Update).To(Equal([]string{"app-1"}))
+
+				apps[0].EnvironmentVars = map[string]interface{}{}
+				Expect(actors.PlanManifest(declared, apps).Update).To(Equal([]string{"app-1"}))
+			})
+
+			It("updates the app when the routes differ", func() {
+				apps[0].Routes = apps[0].Routes[:1]
+				Expect(actors.PlanManifest(declared, apps).Update).To(Equal([]string{"app-1"}))
+			})
+
+			It("updates the app when no-route is declared and the app has routes", func() {
+				declared[0].Routes = nil
+				declared[0].NoRoute = true
+				Expect(actors.PlanManifest(declared, apps).Update).To(Equal([]string{"app-1"}))
+
+				apps[0].Routes = nil
+				Expect(actors.PlanManifest(declared, apps).Unchanged).To(Equal([]string{"app-1"}))
+			})
+		})
+	})
+})
@@ -0,0 +1,248 @@
+package actors
+
+import (
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/applications"
+	"code.cloudfoundry.org/cli/cf/errors"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+//go:generate counterfeiter . BlueGreenDeployer
+
+const (
+	NewAppSuffix       = "-new"
+	VenerableAppSuffix = "-venerable"
+)
+
+// PushFunc pushes and starts the app called appName, returning once its
+// instances are running. mapRoutes is false when the app is a temporary copy
+// that must not receive traffic until its routes are swapped over to it.
+type PushFunc func(appName string, mapRoutes bool) error
+
+type BlueGreenDeployer interface {
+	Deploy(appName string, push PushFunc, keepOldApp bool) error
+}
+
+type blueGreenDeployer struct {
+	ui        terminal.UI
+	appRepo   applications.Repository
+	routeRepo api.RouteRepository
+}
+
+func NewBlueGreenDeployer(ui terminal.UI, appRepo applications.Repository, routeRepo api.RouteRepository) BlueGreenDeployer {
+	return blueGreenDeployer{
+		ui:        ui,
+		appRepo:   appRepo,
+		routeRepo: routeRepo,
+	}
+}
+
+// Deploy replaces the running app called appName without downtime. The new
+// version is pushed as appName-new and started without routes. Once it is
+// running, the routes of the old app are mapped to it and then unmapped from
+// the old app, the old app is renamed to appName-venerable and the new one to
+// appName. The old app is deleted afterwards unless keepOldApp is set.
+//
+// If any step fails, the steps already taken are undone in reverse order so
+// the old app is left serving its routes under its own name.
+func (deployer blueGreenDeployer) Deploy(appName string, push PushFunc, keepOldApp bool) error {
+	oldApp, err := deployer.appRepo.Read(appName)
+	switch err.(type) {
+	case nil:
+	case *errors.ModelNotFoundError:
+		deployer.ui.Say(T("App {{.AppName}} does not exist yet, pushing it without replacing an old version",
+			map[string]interface{}{"AppName": terminal.EntityNameColor(appName)}))
+		deployer.ui.Say("")
+		return push(appName, true)
+	default:
+		return err
+	}
+
+	newAppName := appName + NewAppSuffix
+	venerableAppName := appName + VenerableAppSuffix
+	for _, name := range []string{newAppName, venerableAppName} {
+		err = deployer.ensureAppDoesNotExist(name)
+		if err != nil {
+			return err
+		}
+	}
+
+	deployment := &blueGreenDeployment{deployer: deployer}
+
+	err = push(newAppName, false)
+	if err != nil {
+		deployment.deleteAppIfPushed(newAppName)
+		return deployment.rollBack(err)
+	}
+
+	newApp, err := deployer.appRepo.Read(newAppName)
+	if err != nil {
+		return deployment.rollBack(err)
+	}
+	deployment.onRollBack(func() error {
+		return deployer.appRepo.Delete(newApp.GUID)
+	})
+
+	err = deployment.swapRoutes(oldApp, newApp)
+	if err != nil {
+		return deployment.rollBack(err)
+	}
+
+	err = deployment.rename(oldApp, venerableAppName)
+	if err != nil {
+		return deployment.rollBack(err)
+	}
+
+	err = deployment.rename(newApp, appName)
+	if err != nil {
+		return deployment.rollBack(err)
+	}
+
+	if keepOldApp {
+		deployer.ui.Say(T("Keeping the old version of the app as {{.AppName}}",
+			map[string]interface{}{"AppName": terminal.EntityNameColor(venerableAppName)}))
+		return nil
+	}
+
+	deployer.ui.Say(T("Deleting the old version of the app {{.AppName}}...",
+		map[string]interface{}{"AppName": terminal.EntityNameColor(venerableAppName)}))
+	err = deployer.appRepo.Delete(oldApp.GUID)
+	if err != nil {
+		deployer.ui.Warn(T("Could not delete the old version of the app {{.AppName}}: {{.Err}}",
+			map[string]interface{}{"AppName": venerableAppName, "Err": err.Error()}))
+		return nil
+	}
+	deployer.ui.Ok()
+
+	return nil
+}
+
+func (deployer blueGreenDeployer) ensureAppDoesNotExist(appName string) error {
+	_, err := deployer.appRepo.Read(appName)
+	switch err.(type) {
+	case nil:
+		return errors.New(T("App {{.AppName}} already exists. Delete or rename it before pushing again.",
+			map[string]interface{}{"AppName": appName}))
+	case *errors.ModelNotFoundError:
+		return nil
+	default:
+		return err
+	}
+}
+
+// blueGreenDeployment records how to undo each step of a deployment that has
+// been taken so far.
+type blueGreenDeployment struct {
+	deployer blueGreenDeployer
+	undo     []func() error
+}
+
+func (deployment *blueGreenDeployment) onRollBack(undo func() error) {
+	deployment.undo = append(deployment.undo, undo)
+}
+
+func (deployment *blueGreenDeployment) rollBack(cause error) error {
+	ui := deployment.deployer.ui
+
+	ui.Say("")
+	ui.Warn(T("Deployment failed, rolling back..."))
+	for i := len(deployment.undo) - 1; i >= 0; i-- {
+		err := deployment.undo[i]()
+		if err != nil {
+			ui.Warn(T("Could not roll back a step of the deployment: {{.Err}}", map[string]interface{}{"Err": err.Error()}))
+		}
+	}
+
+	return cause
+}
+
+// deleteAppIfPushed deletes the new app when its push failed part way, such
+// as when it was created but did not start.
+func (deployment *blueGreenDeployment) deleteAppIfPushed(appName string) {
+	appRepo := deployment.deployer.appRepo
+
+	deployment.onRollBack(func() error {
+		app, err := appRepo.Read(appName)
+		switch err.(type) {
+		case nil:
+			return appRepo.Delete(app.GUID)
+		case *errors.ModelNotFoundError:
+			return nil
+		default:
+			return err
+		}
+	})
+}
+
+func (deployment *blueGreenDeployment) swapRoutes(oldApp models.Application, newApp models.Application) error {
+	ui := deployment.deployer.ui
+	routeRepo := deployment.deployer.routeRepo
+
+	for _, route := range oldApp.Routes {
+		ui.Say(T("Mapping route {{.URL}} to {{.AppName}}...",
+			map[string]interface{}{
+				"URL":     terminal.EntityNameColor(route.URL()),
+				"AppName": terminal.EntityNameColor(newApp.Name),
+			}))
+
+		err := routeRepo.Bind(route.GUID, newApp.GUID)
+		if err != nil {
+			return err
+		}
+
+		routeGUID := route.GUID
+		deployment.onRollBack(func() error {
+			return routeRepo.Unbind(routeGUID, newApp.GUID)
+		})
+	}
+
+	for _, route := range oldApp.Routes {
+		ui.Say(T("Unmapping route {{.URL}} from {{.AppName}}...",
+			map[string]interface{}{
+				"URL":     terminal.EntityNameColor(route.URL()),
+				"AppName": terminal.EntityNameColor(oldApp.Name),
+			}))
+
+		err := routeRepo.Unbind(route.GUID, oldApp.GUID)
+		if err != nil {
+			return err
+		}
+
+		routeGUID := route.GUID
+		deployment.onRollBack(func() error {
+			return routeRepo.Bind(routeGUID, oldApp.GUID)
+		})
+	}
+
+	ui.Ok()
+	ui.Say("")
+	return nil
+}
+
+func (deployment *blueGreenDeployment) rename(app models.Application, newName string) error {
+	ui := deployment.deployer.ui
+	appRepo := deployment.deployer.appRepo
+
+	ui.Say(T("Renaming app {{.AppName}} to {{.NewName}}...",
+		map[string]interface{}{
+			"AppName": terminal.EntityNameColor(app.Name),
+			"NewName": terminal.EntityNameColor(newName),
+		}))
+
+	_, err := appRepo.Update(app.GUID, models.AppParams{Name: &newName})
+	if err != nil {
+		return err
+	}
+
+	oldName := app.Name
+	deployment.onRollBack(func() error {
+		_, err := appRepo.Update(app.GUID, models.AppParams{Name: &oldName})
+		return err
+	})
+
+	ui.Ok()
+	ui.Say("")
+	return nil
+}
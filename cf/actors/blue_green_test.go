@@ -0,0 +1,255 @@
+package actors_test
+
+import (
+	"errors"
+
+	. "code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
+	cferrors "code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BlueGreenDeployer", func() {
+	var (
+		fakeUI        *terminalfakes.FakeUI
+		fakeAppRepo   *applicationsfakes.FakeRepository
+		fakeRouteRepo *apifakes.FakeRouteRepository
+		deployer      BlueGreenDeployer
+
+		apps       map[string]models.Application
+		pushes     []string
+		mapsRoutes []bool
+		pushErr    error
+		keepOldApp bool
+		deployErr  error
+	)
+
+	push := func(appName string, mapRoutes bool) error {
+		pushes = append(pushes, appName)
+		mapsRoutes = append(mapsRoutes, mapRoutes)
+		if pushErr != nil {
+			return pushErr
+		}
+
+		app := models.Application{}
+		app.Name = appName
+		app.GUID = appName + "-guid"
+		apps[appName] = app
+		return nil
+	}
+
+	BeforeEach(func() {
+		fakeUI = new(terminalfakes.FakeUI)
+		fakeAppRepo = new(applicationsfakes.FakeRepository)
+		fakeRouteRepo = new(apifakes.FakeRouteRepository)
+		deployer = NewBlueGreenDeployer(fakeUI, fakeAppRepo, fakeRouteRepo)
+
+		oldApp := models.Application{}
+		oldApp.Name = "my-app"
+		oldApp.GUID = "old-app-guid"
+		oldApp.Routes = []models.RouteSummary{
+			{GUID: "route-1-guid", Host: "my-app", Domain: models.DomainFields{Name: "example.com"}},
+			{GUID: "route-2-guid", Host: "www", Domain: models.DomainFields{Name: "example.com"}},
+		}
+		apps = map[string]models.Application{"my-app": oldApp}
+		pushes = nil
+		mapsRoutes = nil
+		pushErr = nil
+		keepOldApp = false
+
+		fakeAppRepo.ReadStub = func(name string) (models.Application, error) {
+			app, ok := apps[name]
+			if !ok {
+				return models.Application{}, cferrors.NewModelNotFoundError("App", name)
+			}
+			return app, nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		deployErr = deployer.Deploy("my-app", push, keepOldApp)
+	})
+
+	Context("when the app does not exist yet", func() {
+		BeforeEach(func() {
+			delete(apps, "my-app")
+		})
+
+		It("pushes the app under its own name with its routes", func() {
+			Expect(deployErr).NotTo(HaveOccurred())
+			Expect(pushes).To(Equal([]string{"my-app"}))
+			Expect(mapsRoutes).To(Equal([]bool{true}))
+			Expect(fakeRouteRepo.BindCallCount()).To(BeZero())
+			Expect(fakeAppRepo.UpdateCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the app exists", func() {
+		It("pushes the new version under a temporary name without routes", func() {
+			Expect(deployErr).NotTo(HaveOccurred())
+			Expect(pushes).To(Equal([]string{"my-app-new"}))
+			Expect(mapsRoutes).To(Equal([]bool{false}))
+		})
+
+		It("maps the routes to the new app before unmapping them from the old app", func() {
+			Expect(fakeRouteRepo.BindCallCount()).To(Equal(2))
+			routeGUID, appGUID := fakeRouteRepo.BindArgsForCall(0)
+			Expect(routeGUID).To(Equal("route-1-guid"))
+			Expect(appGUID).To(Equal("my-app-new-guid"))
+			routeGUID, appGUID = fakeRouteRepo.BindArgsForCall(1)
+			Expect(routeGUID).To(Equal("route-2-guid"))
+			Expect(appGUID).To(Equal("my-app-new-guid"))
+
+			Expect(fakeRouteRepo.UnbindCallCount()).To(Equal(2))
+			routeGUID, appGUID = fakeRouteRepo.UnbindArgsForCall(0)
+			Expect(routeGUID).To(Equal("route-1-guid"))
+			Expect(appGUID).To(Equal("old-app-guid"))
+			routeGUID, appGUID = fakeRouteRepo.UnbindArgsForCall(1)
+			Expect(routeGUID).To(Equal("route-2-guid"))
+			Expect(appGUID).To(Equal("old-app-guid"))
+		})
+
+		It("renames the old app out of the way and the new app to the app name", func() {
+			Expect(fakeAppRepo.UpdateCallCount()).To(Equal(2))
+			appGUID, params := fakeAppRepo.UpdateArgsForCall(0)
+			Expect(appGUID).To(Equal("old-app-guid"))
+			Expect(*params.Name).To(Equal("my-app-venerable"))
+			appGUID, params = fakeAppRepo.UpdateArgsForCall(1)
+			Expect(appGUID).To(Equal("my-app-new-guid"))
+			Expect(*params.Name).To(Equal("my-app"))
+		})
+
+		It("deletes the old app", func() {
+			Expect(fakeAppRepo.DeleteCallCount()).To(Equal(1))
+			Expect(fakeAppRepo.DeleteArgsForCall(0)).To(Equal("old-app-guid"))
+		})
+
+		Context("when the old app should be kept", func() {
+			BeforeEach(func() {
+				keepOldApp = true
+			})
+
+			It("does not delete it", func() {
+				Expect(deployErr).NotTo(HaveOccurred())
+				Expect(fakeAppRepo.DeleteCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when deleting the old app fails", func() {
+			BeforeEach(func() {
+				fakeAppRepo.DeleteReturns(errors.New("delete-error"))
+			})
+
+			It("warns but does not fail", func() {
+				Expect(deployErr).NotTo(HaveOccurred())
+				Expect(fakeUI.WarnCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when an app already has the temporary name", func() {
+			BeforeEach(func() {
+				apps["my-app-venerable"] = models.Application{}
+			})
+
+			It("returns an error without pushing", func() {
+				Expect(deployErr).To(MatchError("App my-app-venerable already exists. Delete or rename it before pushing again."))
+				Expect(pushes).To(BeEmpty())
+			})
+		})
+
+		Context("when the push fails", func() {
+			BeforeEach(func() {
+				pushErr = errors.New("push-error")
+			})
+
+			It("returns the error and leaves the old app alone", func() {
+				Expect(deployErr).To(MatchError("push-error"))
+				Expect(fakeRouteRepo.BindCallCount()).To(BeZero())
+				Expect(fakeAppRepo.UpdateCallCount()).To(BeZero())
+				Expect(fakeAppRepo.DeleteCallCount()).To(BeZero())
+			})
+
+			Context("after the new app was created", func() {
+				BeforeEach(func() {
+					newApp := models.Application{}
+					newApp.GUID = "my-app-new-guid"
+					fakeAppRepo.ReadStub = func(name string) (models.Application, error) {
+						if name == "my-app-new" && len(pushes) > 0 {
+							return newApp, nil
+						}
+						app, ok := apps[name]
+						if !ok {
+							return models.Application{}, cferrors.NewModelNotFoundError("App", name)
+						}
+						return app, nil
+					}
+				})
+
+				It("deletes the new app", func() {
+					Expect(deployErr).To(MatchError("push-error"))
+					Expect(fakeAppRepo.DeleteCallCount()).To(Equal(1))
+					Expect(fakeAppRepo.DeleteArgsForCall(0)).To(Equal("my-app-new-guid"))
+				})
+			})
+		})
+
+		Context("when unmapping a route from the old app fails", func() {
+			BeforeEach(func() {
+				fakeRouteRepo.UnbindStub = func(routeGUID string, appGUID string) error {
+					if routeGUID == "route-2-guid" && appGUID == "old-app-guid" {
+						return errors.New("unbind-error")
+					}
+					return nil
+				}
+			})
+
+			It("puts the routes back and deletes the new app", func() {
+				Expect(deployErr).To(MatchError("unbind-error"))
+
+				Expect(fakeRouteRepo.BindCallCount()).To(Equal(3))
+				routeGUID, appGUID := fakeRouteRepo.BindArgsForCall(2)
+				Expect(routeGUID).To(Equal("route-1-guid"))
+				Expect(appGUID).To(Equal("old-app-guid"))
+
+				Expect(fakeRouteRepo.UnbindCallCount()).To(Equal(4))
+				routeGUID, appGUID = fakeRouteRepo.UnbindArgsForCall(2)
+				Expect(routeGUID).To(Equal("route-2-guid"))
+				Expect(appGUID).To(Equal("my-app-new-guid"))
+				routeGUID, appGUID = fakeRouteRepo.UnbindArgsForCall(3)
+				Expect(routeGUID).To(Equal("route-1-guid"))
+				Expect(appGUID).To(Equal("my-app-new-guid"))
+
+				Expect(fakeAppRepo.DeleteCallCount()).To(Equal(1))
+				Expect(fakeAppRepo.DeleteArgsForCall(0)).To(Equal("my-app-new-guid"))
+			})
+		})
+
+		Context("when renaming the new app fails", func() {
+			BeforeEach(func() {
+				fakeAppRepo.UpdateStub = func(appGUID string, params models.AppParams) (models.Application, error) {
+					if appGUID == "my-app-new-guid" {
+						return models.Application{}, errors.New("rename-error")
+					}
+					return models.Application{}, nil
+				}
+			})
+
+			It("renames the old app back and restores its routes", func() {
+				Expect(deployErr).To(MatchError("rename-error"))
+
+				Expect(fakeAppRepo.UpdateCallCount()).To(Equal(3))
+				appGUID, params := fakeAppRepo.UpdateArgsForCall(2)
+				Expect(appGUID).To(Equal("old-app-guid"))
+				Expect(*params.Name).To(Equal("my-app"))
+
+				Expect(fakeRouteRepo.BindCallCount()).To(Equal(4))
+				Expect(fakeAppRepo.DeleteCallCount()).To(Equal(1))
+				Expect(fakeAppRepo.DeleteArgsForCall(0)).To(Equal("my-app-new-guid"))
+			})
+		})
+	})
+})
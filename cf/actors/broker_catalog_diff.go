@@ -0,0 +1,131 @@
+package actors
+
+import (
+	"sort"
+
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+const (
+	CatalogAdded   = "added"
+	CatalogRemoved = "removed"
+	CatalogChanged = "changed"
+)
+
+// CatalogChange is a difference between the catalog of a service broker and
+// the services and plans registered for it, which updating the broker would
+// bring in line with the catalog.
+type CatalogChange struct {
+	Service string
+	// Plan is empty when the change is to the service itself.
+	Plan string
+	Kind string
+	// Fields lists what differs about a changed service or plan.
+	Fields []string
+
+	// Public and OrgNames are the access a removed or changed plan has now.
+	Public   bool
+	OrgNames []string
+}
+
+// DiffBrokerCatalog compares catalog with services, the registered services
+// of the broker with their plans. Services and plans are matched by name, so
+// a renamed one shows up as removed and added. The changes are sorted by
+// service and then plan.
+func DiffBrokerCatalog(catalog models.BrokerCatalog, services []models.ServiceOffering) []CatalogChange {
+	changes := []CatalogChange{}
+
+	registered := map[string]models.ServiceOffering{}
+	for _, service := range services {
+		registered[service.Label] = service
+	}
+
+	for _, catalogService := range catalog.Services {
+		service, found := registered[catalogService.Name]
+		if !found {
+			changes = append(changes, CatalogChange{Service: catalogService.Name, Kind: CatalogAdded})
+			for _, catalogPlan := range catalogService.Plans {
+				changes = append(changes, CatalogChange{Service: catalogService.Name, Plan: catalogPlan.Name, Kind: CatalogAdded})
+			}
+			continue
+		}
+		delete(registered, catalogService.Name)
+
+		if service.Description != catalogService.Description {
+			changes = append(changes, CatalogChange{Service: service.Label, Kind: CatalogChanged, Fields: []string{"description"}})
+		}
+		changes = append(changes, diffPlans(service, catalogService.Plans)...)
+	}
+
+	for _, service := range registered {
+		changes = append(changes, CatalogChange{Service: service.Label, Kind: CatalogRemoved})
+		for _, plan := range service.Plans {
+			changes = append(changes, removedPlan(service, plan))
+		}
+	}
+
+	sort.Sort(catalogChanges(changes))
+	return changes
+}
+
+func diffPlans(service models.ServiceOffering, catalogPlans []models.BrokerCatalogPlan) []CatalogChange {
+	changes := []CatalogChange{}
+
+	registered := map[string]models.ServicePlanFields{}
+	for _, plan := range service.Plans {
+		registered[plan.Name] = plan
+	}
+
+	for _, catalogPlan := range catalogPlans {
+		plan, found := registered[catalogPlan.Name]
+		if !found {
+			changes = append(changes, CatalogChange{Service: service.Label, Plan: catalogPlan.Name, Kind: CatalogAdded})
+			continue
+		}
+		delete(registered, catalogPlan.Name)
+
+		fields := []string{}
+		if plan.Description != catalogPlan.Description {
+			fields = append(fields, "description")
+		}
+		if plan.Free != catalogPlan.Free {
+			fields = append(fields, "free")
+		}
+		if len(fields) > 0 {
+			changes = append(changes, CatalogChange{
+				Service:  service.Label,
+				Plan:     plan.Name,
+				Kind:     CatalogChanged,
+				Fields:   fields,
+				Public:   plan.Public,
+				OrgNames: plan.OrgNames,
+			})
+		}
+	}
+
+	for _, plan := range registered {
+		changes = append(changes, removedPlan(service, plan))
+	}
+	return changes
+}
+
+func removedPlan(service models.ServiceOffering, plan models.ServicePlanFields) CatalogChange {
+	return CatalogChange{
+		Service:  service.Label,
+		Plan:     plan.Name,
+		Kind:     CatalogRemoved,
+		Public:   plan.Public,
+		OrgNames: plan.OrgNames,
+	}
+}
+
+type catalogChanges []CatalogChange
+
+func (c catalogChanges) Len() int      { return len(c) }
+func (c catalogChanges) Swap(i, j int) { c[i], c[j] = c[j], c[i] }
+func (c catalogChanges) Less(i, j int) bool {
+	if c[i].Service != c[j].Service {
+		return c[i].Service < c[j].Service
+	}
+	return c[i].Plan < c[j].Plan
+}
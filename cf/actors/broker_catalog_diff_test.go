@@ -0,0 +1,77 @@
+package actors_test
+
+import (
+	. "code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DiffBrokerCatalog", func() {
+	var services []models.ServiceOffering
+
+	BeforeEach(func() {
+		services = []models.ServiceOffering{
+			{
+				ServiceOfferingFields: models.ServiceOfferingFields{Label: "mysql", Description: "MySQL databases"},
+				Plans: []models.ServicePlanFields{
+					{Name: "small", Description: "small db", Free: true, Public: true},
+					{Name: "large", Description: "large db", Free: true, OrgNames: []string{"org-1"}},
+					{Name: "legacy", Description: "old db", Free: true, OrgNames: []string{"org-1", "org-2"}},
+				},
+			},
+			{
+				ServiceOfferingFields: models.ServiceOfferingFields{Label: "cache", Description: "caches"},
+				Plans: []models.ServicePlanFields{
+					{Name: "default", Description: "a cache", Free: true, Public: true},
+				},
+			},
+		}
+	})
+
+	It("finds no changes when the catalog matches the registered services", func() {
+		catalog := models.BrokerCatalog{Services: []models.BrokerCatalogService{
+			{Name: "cache", Description: "caches", Plans: []models.BrokerCatalogPlan{
+				{Name: "default", Description: "a cache", Free: true},
+			}},
+		}}
+
+		Expect(DiffBrokerCatalog(catalog, services[1:])).To(BeEmpty())
+	})
+
+	It("lists the added, removed and changed services and plans, sorted", func() {
+		catalog := models.BrokerCatalog{Services: []models.BrokerCatalogService{
+			{Name: "mysql", Description: "MySQL databases", Plans: []models.BrokerCatalogPlan{
+				{Name: "small", Description: "small db", Free: true},
+				{Name: "large", Description: "large db", Free: false},
+				{Name: "xlarge", Description: "huge db", Free: false},
+			}},
+			{Name: "queue", Description: "queues", Plans: []models.BrokerCatalogPlan{
+				{Name: "basic", Description: "a queue", Free: true},
+			}},
+		}}
+
+		Expect(DiffBrokerCatalog(catalog, services)).To(Equal([]CatalogChange{
+			{Service: "cache", Kind: CatalogRemoved},
+			{Service: "cache", Plan: "default", Kind: CatalogRemoved, Public: true},
+			{Service: "mysql", Plan: "large", Kind: CatalogChanged, Fields: []string{"free"}, OrgNames: []string{"org-1"}},
+			{Service: "mysql", Plan: "legacy", Kind: CatalogRemoved, OrgNames: []string{"org-1", "org-2"}},
+			{Service: "mysql", Plan: "xlarge", Kind: CatalogAdded},
+			{Service: "queue", Kind: CatalogAdded},
+			{Service: "queue", Plan: "basic", Kind: CatalogAdded},
+		}))
+	})
+
+	It("reports changes to the description of a service", func() {
+		catalog := models.BrokerCatalog{Services: []models.BrokerCatalogService{
+			{Name: "cache", Description: "fast caches", Plans: []models.BrokerCatalogPlan{
+				{Name: "default", Description: "a cache", Free: true},
+			}},
+		}}
+
+		Expect(DiffBrokerCatalog(catalog, services[1:])).To(Equal([]CatalogChange{
+			{Service: "cache", Kind: CatalogChanged, Fields: []string{"description"}},
+		}))
+	})
+})
@@ -0,0 +1,132 @@
+package actors
+
+import (
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/applicationbits"
+	"code.cloudfoundry.org/cli/cf/api/applications"
+	"code.cloudfoundry.org/cli/cf/api/copyapplicationsource"
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+//go:generate counterfeiter . CopySourceActor
+
+// CopySourceActor copies the bits of an app to another app, through the Cloud
+// Controller when both apps are on the same foundation and through a download
+// and an upload when they are not.
+type CopySourceActor interface {
+	CopySource(sourceAppGUID string, targetAppGUID string, target CopyTarget, copyEnv bool) (CopyReport, error)
+}
+
+// CopyTarget holds the repositories of the foundation the target app is on.
+// OtherFoundation is set when it is not the foundation of the source app.
+type CopyTarget struct {
+	AppRepo         applications.Repository
+	AppSummaryRepo  api.AppSummaryRepository
+	AppBitsRepo     applicationbits.Repository
+	OtherFoundation bool
+}
+
+// CopyReport lists the environment variables copied with the bits, and the
+// service instances bound to the source app, which are never copied.
+type CopyReport struct {
+	EnvVars  []string
+	Services []CopiedAppService
+}
+
+type CopiedAppService struct {
+	Name          string
+	BoundToTarget bool
+}
+
+type copySourceActor struct {
+	copyAppSourceRepo copyapplicationsource.Repository
+	appSummaryRepo    api.AppSummaryRepository
+}
+
+func NewCopySourceActor(copyAppSourceRepo copyapplicationsource.Repository, appSummaryRepo api.AppSummaryRepository) CopySourceActor {
+	return copySourceActor{
+		copyAppSourceRepo: copyAppSourceRepo,
+		appSummaryRepo:    appSummaryRepo,
+	}
+}
+
+func (actor copySourceActor) CopySource(sourceAppGUID string, targetAppGUID string, target CopyTarget, copyEnv bool) (CopyReport, error) {
+	source, err := actor.appSummaryRepo.GetSummary(sourceAppGUID)
+	if err != nil {
+		return CopyReport{}, err
+	}
+
+	targetSummary, err := target.AppSummaryRepo.GetSummary(targetAppGUID)
+	if err != nil {
+		return CopyReport{}, err
+	}
+
+	if target.OtherFoundation {
+		err = actor.transferBits(sourceAppGUID, targetAppGUID, target.AppBitsRepo)
+	} else {
+		err = actor.copyAppSourceRepo.CopyApplication(sourceAppGUID, targetAppGUID)
+	}
+	if err != nil {
+		return CopyReport{}, err
+	}
+
+	report := CopyReport{
+		EnvVars:  []string{},
+		Services: []CopiedAppService{},
+	}
+
+	if copyEnv && len(source.EnvironmentVars) > 0 {
+		envVars := map[string]interface{}{}
+		for name, value := range targetSummary.EnvironmentVars {
+			envVars[name] = value
+		}
+		for name, value := range source.EnvironmentVars {
+			envVars[name] = value
+			report.EnvVars = append(report.EnvVars, name)
+		}
+		sort.Strings(report.EnvVars)
+
+		_, err = target.AppRepo.Update(targetAppGUID, models.AppParams{EnvironmentVars: &envVars})
+		if err != nil {
+			return CopyReport{}, err
+		}
+	}
+
+	bound := map[string]bool{}
+	for _, service := range targetSummary.Services {
+		bound[service.Name] = true
+	}
+	for _, service := range source.Services {
+		report.Services = append(report.Services, CopiedAppService{Name: service.Name, BoundToTarget: bound[service.Name]})
+	}
+
+	return report, nil
+}
+
+// transferBits downloads the bits of the source app and uploads them to the
+// target app on the other foundation.
+func (actor copySourceActor) transferBits(sourceAppGUID string, targetAppGUID string, appBitsRepo applicationbits.Repository) error {
+	zipFile, err := ioutil.TempFile("", "copy-source")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(zipFile.Name())
+	defer zipFile.Close()
+
+	err = actor.copyAppSourceRepo.DownloadApplication(sourceAppGUID, zipFile)
+	if err != nil {
+		return err
+	}
+
+	_, err = zipFile.Seek(0, 0)
+	if err != nil {
+		return err
+	}
+
+	return appBitsRepo.UploadBits(targetAppGUID, zipFile, []resources.AppFileResource{})
+}
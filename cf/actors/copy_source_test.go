@@ -0,0 +1,156 @@
+package actors_test
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+
+	. "code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/applicationbits/applicationbitsfakes"
+	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
+	"code.cloudfoundry.org/cli/cf/api/copyapplicationsource/copyapplicationsourcefakes"
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/models"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CopySourceActor", func() {
+	var (
+		copyAppSourceRepo    *copyapplicationsourcefakes.FakeRepository
+		sourceAppSummaryRepo *apifakes.FakeAppSummaryRepository
+		targetAppRepo        *applicationsfakes.FakeRepository
+		targetAppSummaryRepo *apifakes.FakeAppSummaryRepository
+		targetAppBitsRepo    *applicationbitsfakes.FakeRepository
+		target               CopyTarget
+		actor                CopySourceActor
+	)
+
+	BeforeEach(func() {
+		copyAppSourceRepo = new(copyapplicationsourcefakes.FakeRepository)
+		sourceAppSummaryRepo = new(apifakes.FakeAppSummaryRepository)
+		targetAppRepo = new(applicationsfakes.FakeRepository)
+		targetAppSummaryRepo = new(apifakes.FakeAppSummaryRepository)
+		targetAppBitsRepo = new(applicationbitsfakes.FakeRepository)
+
+		target = CopyTarget{
+			AppRepo:        targetAppRepo,
+			AppSummaryRepo: targetAppSummaryRepo,
+			AppBitsRepo:    targetAppBitsRepo,
+		}
+		actor = NewCopySourceActor(copyAppSourceRepo, sourceAppSummaryRepo)
+
+		source := models.Application{}
+		source.GUID = "source-app-guid"
+		source.EnvironmentVars = map[string]interface{}{"LOG_LEVEL": "debug", "FEATURE_X": "on"}
+		source.Services = []models.ServicePlanSummary{{Name: "my-db"}, {Name: "my-cache"}}
+		sourceAppSummaryRepo.GetSummaryReturns(source, nil)
+
+		targetApp := models.Application{}
+		targetApp.GUID = "target-app-guid"
+		targetApp.EnvironmentVars = map[string]interface{}{"LOG_LEVEL": "info", "REGION": "eu"}
+		targetApp.Services = []models.ServicePlanSummary{{Name: "my-db"}}
+		targetAppSummaryRepo.GetSummaryReturns(targetApp, nil)
+	})
+
+	Context("when both apps are on the same foundation", func() {
+		It("copies the bits through the Cloud Controller", func() {
+			_, err := actor.CopySource("source-app-guid", "target-app-guid", target, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			sourceGUID, targetGUID := copyAppSourceRepo.CopyApplicationArgsForCall(0)
+			Expect(sourceGUID).To(Equal("source-app-guid"))
+			Expect(targetGUID).To(Equal("target-app-guid"))
+			Expect(copyAppSourceRepo.DownloadApplicationCallCount()).To(BeZero())
+			Expect(targetAppBitsRepo.UploadBitsCallCount()).To(BeZero())
+		})
+
+		It("returns the error when the bits cannot be copied", func() {
+			copyAppSourceRepo.CopyApplicationReturns(errors.New("copy failed"))
+
+			_, err := actor.CopySource("source-app-guid", "target-app-guid", target, true)
+			Expect(err).To(MatchError("copy failed"))
+			Expect(targetAppRepo.UpdateCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the target app is on another foundation", func() {
+		BeforeEach(func() {
+			target.OtherFoundation = true
+
+			copyAppSourceRepo.DownloadApplicationStub = func(appGUID string, dest io.Writer) error {
+				_, err := dest.Write([]byte("app-bits-zip"))
+				return err
+			}
+		})
+
+		It("downloads the bits of the source app and uploads them to the target app", func() {
+			var uploaded string
+			targetAppBitsRepo.UploadBitsStub = func(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) error {
+				contents, err := ioutil.ReadAll(zipFile)
+				uploaded = string(contents)
+				return err
+			}
+
+			_, err := actor.CopySource("source-app-guid", "target-app-guid", target, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(copyAppSourceRepo.DownloadApplicationCallCount()).To(Equal(1))
+			appGUID, _ := copyAppSourceRepo.DownloadApplicationArgsForCall(0)
+			Expect(appGUID).To(Equal("source-app-guid"))
+
+			appGUID, _, presentFiles := targetAppBitsRepo.UploadBitsArgsForCall(0)
+			Expect(appGUID).To(Equal("target-app-guid"))
+			Expect(presentFiles).To(BeEmpty())
+			Expect(uploaded).To(Equal("app-bits-zip"))
+			Expect(copyAppSourceRepo.CopyApplicationCallCount()).To(BeZero())
+		})
+
+		It("returns the error when the bits cannot be downloaded", func() {
+			copyAppSourceRepo.DownloadApplicationReturns(errors.New("download failed"))
+			copyAppSourceRepo.DownloadApplicationStub = nil
+
+			_, err := actor.CopySource("source-app-guid", "target-app-guid", target, false)
+			Expect(err).To(MatchError("download failed"))
+			Expect(targetAppBitsRepo.UploadBitsCallCount()).To(BeZero())
+		})
+	})
+
+	It("copies the environment variables of the source app over those of the target app when asked to", func() {
+		report, err := actor.CopySource("source-app-guid", "target-app-guid", target, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		appGUID, params := targetAppRepo.UpdateArgsForCall(0)
+		Expect(appGUID).To(Equal("target-app-guid"))
+		Expect(*params.EnvironmentVars).To(Equal(map[string]interface{}{"LOG_LEVEL": "debug", "FEATURE_X": "on", "REGION": "eu"}))
+		Expect(report.EnvVars).To(Equal([]string{"FEATURE_X", "LOG_LEVEL"}))
+	})
+
+	It("leaves the environment variables of the target app alone by default", func() {
+		report, err := actor.CopySource("source-app-guid", "target-app-guid", target, false)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(targetAppRepo.UpdateCallCount()).To(BeZero())
+		Expect(report.EnvVars).To(BeEmpty())
+	})
+
+	It("reports the services of the source app and whether the target app is bound to them", func() {
+		report, err := actor.CopySource("source-app-guid", "target-app-guid", target, false)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(report.Services).To(Equal([]CopiedAppService{
+			{Name: "my-db", BoundToTarget: true},
+			{Name: "my-cache", BoundToTarget: false},
+		}))
+	})
+
+	It("returns the error when the target app cannot be read", func() {
+		targetAppSummaryRepo.GetSummaryReturns(models.Application{}, errors.New("app not found"))
+
+		_, err := actor.CopySource("source-app-guid", "target-app-guid", target, false)
+		Expect(err).To(MatchError("app not found"))
+		Expect(copyAppSourceRepo.CopyApplicationCallCount()).To(BeZero())
+	})
+})
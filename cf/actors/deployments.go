@@ -0,0 +1,164 @@
+package actors
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/builds"
+	"code.cloudfoundry.org/cli/cf/api/deployments"
+	"code.cloudfoundry.org/cli/cf/errors"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+// ErrDeploymentTimeout is returned when a deployment neither finishes nor
+// pauses within the startup timeout.
+var ErrDeploymentTimeout = errors.New("deployment timed out")
+
+//go:generate counterfeiter . DeploymentActor
+
+type DeploymentActor interface {
+	StageLatestPackage(appGUID string) (models.Build, error)
+	StagePackage(packageGUID string) (models.Build, error)
+	WaitForBuild(build models.Build) (models.Build, error)
+	Deploy(appGUID string, params models.DeploymentParams) (models.Deployment, error)
+	WaitForDeployment(deployment models.Deployment, progress func(models.Deployment)) (models.Deployment, error)
+	ListDeployments(appGUID string) ([]models.Deployment, error)
+	CancelDeployment(appGUID string) (models.Deployment, error)
+	ContinueDeployment(appGUID string) (models.Deployment, error)
+}
+
+type deploymentActor struct {
+	deploymentRepo deployments.Repository
+	buildRepo      builds.Repository
+	pollInterval   time.Duration
+	timeout        time.Duration
+}
+
+// NewDeploymentActor returns a DeploymentActor that polls builds and
+// deployments every pollInterval, and waits at most timeout for a deployment,
+// which is the startup timeout of the CLI.
+func NewDeploymentActor(deploymentRepo deployments.Repository, buildRepo builds.Repository, pollInterval time.Duration, timeout time.Duration) DeploymentActor {
+	return deploymentActor{
+		deploymentRepo: deploymentRepo,
+		buildRepo:      buildRepo,
+		pollInterval:   pollInterval,
+		timeout:        timeout,
+	}
+}
+
+// StageLatestPackage stages the newest package of the app into a new droplet
+// and waits for staging to finish. The app keeps running on its current
+// droplet meanwhile; callers check the state of the build returned.
+func (actor deploymentActor) StageLatestPackage(appGUID string) (models.Build, error) {
+	packageGUID, err := actor.buildRepo.GetLatestPackageGUID(appGUID)
+	if err != nil {
+		return models.Build{}, err
+	}
+
+	return actor.StagePackage(packageGUID)
+}
+
+// StagePackage stages the given package into a new droplet and waits for
+// staging to finish, without changing the droplet the app runs.
+func (actor deploymentActor) StagePackage(packageGUID string) (models.Build, error) {
+	build, err := actor.buildRepo.CreateBuild(packageGUID)
+	if err != nil {
+		return models.Build{}, err
+	}
+
+	return actor.WaitForBuild(build)
+}
+
+// WaitForBuild polls the build until it is no longer staging.
+func (actor deploymentActor) WaitForBuild(build models.Build) (models.Build, error) {
+	var err error
+	for build.State == models.BuildStateStaging {
+		time.Sleep(actor.pollInterval)
+
+		build, err = actor.buildRepo.GetBuild(build.GUID)
+		if err != nil {
+			return models.Build{}, err
+		}
+	}
+
+	return build, nil
+}
+
+func (actor deploymentActor) Deploy(appGUID string, params models.DeploymentParams) (models.Deployment, error) {
+	return actor.deploymentRepo.CreateDeployment(appGUID, params)
+}
+
+// WaitForDeployment polls the deployment until it finishes or, for canary
+// deployments, pauses, calling progress whenever its status changes so that
+// users can follow along. It always polls at least once, so a deployment that
+// was just continued is not mistaken for still being paused. It returns
+// ErrDeploymentTimeout when the deployment is still going on after the
+// timeout of the actor.
+func (actor deploymentActor) WaitForDeployment(deployment models.Deployment, progress func(models.Deployment)) (models.Deployment, error) {
+	status := deployment.StatusDescription()
+	deadline := time.Now().Add(actor.timeout)
+
+	for {
+		time.Sleep(actor.pollInterval)
+
+		var err error
+		deployment, err = actor.deploymentRepo.GetDeployment(deployment.GUID)
+		if err != nil {
+			return models.Deployment{}, err
+		}
+
+		if deployment.StatusDescription() != status {
+			status = deployment.StatusDescription()
+			progress(deployment)
+		}
+
+		if deployment.IsFinished() || deployment.IsPaused() {
+			return deployment, nil
+		}
+
+		if time.Now().After(deadline) {
+			return deployment, ErrDeploymentTimeout
+		}
+	}
+}
+
+func (actor deploymentActor) ListDeployments(appGUID string) ([]models.Deployment, error) {
+	return actor.deploymentRepo.ListDeployments(appGUID)
+}
+
+// CancelDeployment cancels the deployment in progress for the app, which
+// rolls the app back to the droplet it ran before the deployment.
+func (actor deploymentActor) CancelDeployment(appGUID string) (models.Deployment, error) {
+	deployment, err := actor.activeDeployment(appGUID)
+	if err != nil {
+		return models.Deployment{}, err
+	}
+
+	return deployment, actor.deploymentRepo.CancelDeployment(deployment.GUID)
+}
+
+// ContinueDeployment lets the paused canary deployment of the app go on to
+// its next step, or replace the rest of its instances after the last one.
+func (actor deploymentActor) ContinueDeployment(appGUID string) (models.Deployment, error) {
+	deployment, err := actor.activeDeployment(appGUID)
+	if err != nil {
+		return models.Deployment{}, err
+	}
+
+	return deployment, actor.deploymentRepo.ContinueDeployment(deployment.GUID)
+}
+
+func (actor deploymentActor) activeDeployment(appGUID string) (models.Deployment, error) {
+	deployments, err := actor.deploymentRepo.ListDeployments(appGUID)
+	if err != nil {
+		return models.Deployment{}, err
+	}
+
+	for _, deployment := range deployments {
+		if !deployment.IsFinished() {
+			return deployment, nil
+		}
+	}
+
+	return models.Deployment{}, errors.NewModelNotFoundError(T("Deployment"), T("in progress"))
+}
@@ -0,0 +1,196 @@
+package actors_test
+
+import (
+	"errors"
+	"time"
+
+	. "code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/api/builds/buildsfakes"
+	"code.cloudfoundry.org/cli/cf/api/deployments/deploymentsfakes"
+	cferrors "code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeploymentActor", func() {
+	var (
+		fakeDeploymentRepo *deploymentsfakes.FakeRepository
+		fakeBuildRepo      *buildsfakes.FakeRepository
+		actor              DeploymentActor
+	)
+
+	BeforeEach(func() {
+		fakeDeploymentRepo = new(deploymentsfakes.FakeRepository)
+		fakeBuildRepo = new(buildsfakes.FakeRepository)
+		actor = NewDeploymentActor(fakeDeploymentRepo, fakeBuildRepo, 0, time.Minute)
+	})
+
+	Describe("StageLatestPackage", func() {
+		BeforeEach(func() {
+			fakeBuildRepo.GetLatestPackageGUIDReturns("package-guid", nil)
+			fakeBuildRepo.CreateBuildReturns(models.Build{GUID: "build-guid", State: models.BuildStateStaging}, nil)
+			fakeBuildRepo.GetBuildReturns(models.Build{GUID: "build-guid", State: models.BuildStateStaged, DropletGUID: "droplet-guid"}, nil)
+		})
+
+		It("builds the newest package and waits for it to stage", func() {
+			build, err := actor.StageLatestPackage("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.DropletGUID).To(Equal("droplet-guid"))
+
+			Expect(fakeBuildRepo.GetLatestPackageGUIDArgsForCall(0)).To(Equal("app-guid"))
+			Expect(fakeBuildRepo.CreateBuildArgsForCall(0)).To(Equal("package-guid"))
+			Expect(fakeBuildRepo.GetBuildArgsForCall(0)).To(Equal("build-guid"))
+		})
+
+		It("returns the error when the app has no package", func() {
+			fakeBuildRepo.GetLatestPackageGUIDReturns("", cferrors.NewModelNotFoundError("Package", "app-guid"))
+
+			_, err := actor.StageLatestPackage("app-guid")
+			Expect(err).To(HaveOccurred())
+			Expect(fakeBuildRepo.CreateBuildCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("StagePackage", func() {
+		BeforeEach(func() {
+			fakeBuildRepo.CreateBuildReturns(models.Build{GUID: "build-guid", State: models.BuildStateStaging}, nil)
+			fakeBuildRepo.GetBuildReturns(models.Build{GUID: "build-guid", State: models.BuildStateFailed, Error: "NoAppDetectedError"}, nil)
+		})
+
+		It("builds the given package and returns the build once staging is done", func() {
+			build, err := actor.StagePackage("package-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.State).To(Equal(models.BuildStateFailed))
+			Expect(build.Error).To(Equal("NoAppDetectedError"))
+
+			Expect(fakeBuildRepo.GetLatestPackageGUIDCallCount()).To(BeZero())
+			Expect(fakeBuildRepo.CreateBuildArgsForCall(0)).To(Equal("package-guid"))
+		})
+
+		It("returns the error when the build cannot be created", func() {
+			fakeBuildRepo.CreateBuildReturns(models.Build{}, errors.New("package not ready"))
+
+			_, err := actor.StagePackage("package-guid")
+			Expect(err).To(MatchError("package not ready"))
+			Expect(fakeBuildRepo.GetBuildCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("WaitForBuild", func() {
+		It("polls the build until it is no longer staging", func() {
+			fakeBuildRepo.GetBuildStub = func(guid string) (models.Build, error) {
+				if fakeBuildRepo.GetBuildCallCount() < 3 {
+					return models.Build{GUID: guid, State: models.BuildStateStaging}, nil
+				}
+				return models.Build{GUID: guid, State: models.BuildStateStaged, DropletGUID: "droplet-guid"}, nil
+			}
+
+			build, err := actor.WaitForBuild(models.Build{GUID: "build-guid", State: models.BuildStateStaging})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.DropletGUID).To(Equal("droplet-guid"))
+			Expect(fakeBuildRepo.GetBuildCallCount()).To(Equal(3))
+		})
+
+		It("returns a build that has finished staging without polling", func() {
+			build, err := actor.WaitForBuild(models.Build{GUID: "build-guid", State: models.BuildStateFailed})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build.State).To(Equal(models.BuildStateFailed))
+			Expect(fakeBuildRepo.GetBuildCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("WaitForDeployment", func() {
+		It("reports each change of status until the deployment finishes", func() {
+			fakeDeploymentRepo.GetDeploymentStub = func(guid string) (models.Deployment, error) {
+				switch fakeDeploymentRepo.GetDeploymentCallCount() {
+				case 1, 2:
+					return models.Deployment{GUID: guid, StatusValue: "ACTIVE", StatusReason: "DEPLOYING"}, nil
+				default:
+					return models.Deployment{GUID: guid, StatusValue: "FINALIZED", StatusReason: "DEPLOYED"}, nil
+				}
+			}
+
+			reported := []string{}
+			deployment, err := actor.WaitForDeployment(models.Deployment{GUID: "deployment-guid"}, func(deployment models.Deployment) {
+				reported = append(reported, deployment.StatusDescription())
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deployment.Succeeded()).To(BeTrue())
+			Expect(reported).To(Equal([]string{"ACTIVE (DEPLOYING)", "FINALIZED (DEPLOYED)"}))
+		})
+
+		It("stops waiting when a canary deployment pauses", func() {
+			fakeDeploymentRepo.GetDeploymentReturns(models.Deployment{GUID: "deployment-guid", StatusValue: "ACTIVE", StatusReason: "PAUSED"}, nil)
+
+			deployment, err := actor.WaitForDeployment(models.Deployment{GUID: "deployment-guid", StatusValue: "ACTIVE", StatusReason: "PAUSED"}, func(models.Deployment) {})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deployment.IsPaused()).To(BeTrue())
+			Expect(fakeDeploymentRepo.GetDeploymentCallCount()).To(Equal(1))
+		})
+
+		It("gives up when the deployment has not finished within the timeout", func() {
+			actor = NewDeploymentActor(fakeDeploymentRepo, fakeBuildRepo, time.Millisecond, 10*time.Millisecond)
+			fakeDeploymentRepo.GetDeploymentReturns(models.Deployment{GUID: "deployment-guid", StatusValue: "ACTIVE", StatusReason: "DEPLOYING"}, nil)
+
+			deployment, err := actor.WaitForDeployment(models.Deployment{GUID: "deployment-guid"}, func(models.Deployment) {})
+			Expect(err).To(Equal(ErrDeploymentTimeout))
+			Expect(deployment.GUID).To(Equal("deployment-guid"))
+			Expect(fakeDeploymentRepo.GetDeploymentCallCount()).To(BeNumerically(">", 1))
+		})
+
+		It("polls the deployment at least once even without a timeout", func() {
+			actor = NewDeploymentActor(fakeDeploymentRepo, fakeBuildRepo, 0, 0)
+			fakeDeploymentRepo.GetDeploymentReturns(models.Deployment{GUID: "deployment-guid", StatusValue: "FINALIZED", StatusReason: "DEPLOYED"}, nil)
+
+			deployment, err := actor.WaitForDeployment(models.Deployment{GUID: "deployment-guid"}, func(models.Deployment) {})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deployment.Succeeded()).To(BeTrue())
+		})
+
+		It("returns the error when the deployment cannot be read", func() {
+			fakeDeploymentRepo.GetDeploymentReturns(models.Deployment{}, errors.New("boom"))
+
+			_, err := actor.WaitForDeployment(models.Deployment{GUID: "deployment-guid"}, func(models.Deployment) {})
+			Expect(err).To(MatchError("boom"))
+		})
+	})
+
+	Describe("CancelDeployment", func() {
+		It("cancels the newest deployment that has not finished", func() {
+			fakeDeploymentRepo.ListDeploymentsReturns([]models.Deployment{
+				{GUID: "active-guid", StatusValue: "ACTIVE", StatusReason: "DEPLOYING"},
+				{GUID: "old-guid", StatusValue: "FINALIZED", StatusReason: "DEPLOYED"},
+			}, nil)
+
+			deployment, err := actor.CancelDeployment("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deployment.GUID).To(Equal("active-guid"))
+			Expect(fakeDeploymentRepo.ListDeploymentsArgsForCall(0)).To(Equal("app-guid"))
+			Expect(fakeDeploymentRepo.CancelDeploymentArgsForCall(0)).To(Equal("active-guid"))
+		})
+
+		It("returns a not found error when no deployment is in progress", func() {
+			fakeDeploymentRepo.ListDeploymentsReturns([]models.Deployment{
+				{GUID: "old-guid", StatusValue: "FINALIZED", StatusReason: "DEPLOYED"},
+			}, nil)
+
+			_, err := actor.CancelDeployment("app-guid")
+			Expect(err).To(BeAssignableToTypeOf(&cferrors.ModelNotFoundError{}))
+			Expect(fakeDeploymentRepo.CancelDeploymentCallCount()).To(BeZero())
+		})
+	})
+
+	Describe("ContinueDeployment", func() {
+		It("continues the newest deployment that has not finished", func() {
+			fakeDeploymentRepo.ListDeploymentsReturns([]models.Deployment{
+				{GUID: "paused-guid", StatusValue: "ACTIVE", StatusReason: "PAUSED"},
+			}, nil)
+
+			deployment, err := actor.ContinueDeployment("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deployment.GUID).To(Equal("paused-guid"))
+			Expect(fakeDeploymentRepo.ContinueDeploymentArgsForCall(0)).To(Equal("paused-guid"))
+		})
+	})
+})
@@ -0,0 +1,158 @@
+package actors
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/api/metadata"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+//go:generate counterfeiter . MetadataActor
+
+// MetadataActor reads and changes the labels of resources by the type and
+// name users type on the command line, such as "app my-app".
+type MetadataActor interface {
+	GetLabels(resourceType string, name string) (models.LabeledResource, error)
+	GetMetadata(resourceType string, guid string) (models.Metadata, error)
+	UpdateLabels(resourceType string, name string, labels map[string]*string) error
+	MatchingGUIDs(resourceType string, selector string) (map[string]bool, error)
+}
+
+type labeledResourceType struct {
+	path  string
+	scope string
+}
+
+// Apps, service instances and spaces are looked up in the targeted space or
+// org. The other types are global, so their names are unique on their own.
+var labeledResourceTypes = map[string]labeledResourceType{
+	"app":              {path: "/v3/apps", scope: "space_guids"},
+	"buildpack":        {path: "/v3/buildpacks"},
+	"org":              {path: "/v3/organizations"},
+	"service-instance": {path: "/v3/service_instances", scope: "space_guids"},
+	"space":            {path: "/v3/spaces", scope: "organization_guids"},
+	"stack":            {path: "/v3/stacks"},
+}
+
+// LabeledResourceTypes returns the resource types that can be labeled.
+func LabeledResourceTypes() []string {
+	types := []string{}
+	for resourceType := range labeledResourceTypes {
+		types = append(types, resourceType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+type metadataActor struct {
+	metadataRepo metadata.Repository
+	config       coreconfig.Reader
+}
+
+func NewMetadataActor(metadataRepo metadata.Repository, config coreconfig.Reader) MetadataActor {
+	return metadataActor{
+		metadataRepo: metadataRepo,
+		config:       config,
+	}
+}
+
+func (actor metadataActor) GetLabels(resourceType string, name string) (models.LabeledResource, error) {
+	typeInfo, filters, err := actor.filtersFor(resourceType)
+	if err != nil {
+		return models.LabeledResource{}, err
+	}
+	filters.Set("names", name)
+
+	labeled, err := actor.metadataRepo.ListResources(typeInfo.path, filters)
+	if err != nil {
+		return models.LabeledResource{}, err
+	}
+
+	switch len(labeled) {
+	case 0:
+		return models.LabeledResource{}, errors.NewModelNotFoundError(resourceType, name)
+	case 1:
+		return labeled[0], nil
+	default:
+		return models.LabeledResource{}, errors.New(T("More than one {{.ResourceType}} named {{.Name}} was found",
+			map[string]interface{}{"ResourceType": resourceType, "Name": name}))
+	}
+}
+
+// GetMetadata returns the labels and annotations of the resource with the
+// guid, for commands that have already found the resource.
+func (actor metadataActor) GetMetadata(resourceType string, guid string) (models.Metadata, error) {
+	typeInfo, ok := labeledResourceTypes[resourceType]
+	if !ok {
+		return models.Metadata{}, unsupportedTypeError(resourceType)
+	}
+
+	labeled, err := actor.metadataRepo.ListResources(typeInfo.path, url.Values{"guids": []string{guid}})
+	if err != nil {
+		return models.Metadata{}, err
+	}
+	if len(labeled) == 0 {
+		return models.Metadata{}, errors.NewModelNotFoundError(resourceType, guid)
+	}
+	return labeled[0].Metadata, nil
+}
+
+// UpdateLabels sets the labels with a value and removes the labels with a nil
+// value. Other labels of the resource are left alone.
+func (actor metadataActor) UpdateLabels(resourceType string, name string, labels map[string]*string) error {
+	resource, err := actor.GetLabels(resourceType, name)
+	if err != nil {
+		return err
+	}
+
+	return actor.metadataRepo.UpdateMetadata(labeledResourceTypes[resourceType].path, resource.GUID, models.MetadataUpdate{
+		Labels: labels,
+	})
+}
+
+// MatchingGUIDs returns the guids of the resources in the target that match
+// the label selector, such as "env=prod,tier!=web".
+func (actor metadataActor) MatchingGUIDs(resourceType string, selector string) (map[string]bool, error) {
+	typeInfo, filters, err := actor.filtersFor(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	filters.Set("label_selector", selector)
+
+	labeled, err := actor.metadataRepo.ListResources(typeInfo.path, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	guids := map[string]bool{}
+	for _, resource := range labeled {
+		guids[resource.GUID] = true
+	}
+	return guids, nil
+}
+
+func (actor metadataActor) filtersFor(resourceType string) (labeledResourceType, url.Values, error) {
+	typeInfo, ok := labeledResourceTypes[resourceType]
+	if !ok {
+		return labeledResourceType{}, nil, unsupportedTypeError(resourceType)
+	}
+
+	filters := url.Values{}
+	switch typeInfo.scope {
+	case "space_guids":
+		filters.Set(typeInfo.scope, actor.config.SpaceFields().GUID)
+	case "organization_guids":
+		filters.Set(typeInfo.scope, actor.config.OrganizationFields().GUID)
+	}
+	return typeInfo, filters, nil
+}
+
+func unsupportedTypeError(resourceType string) error {
+	return errors.New(T("Unsupported resource type {{.ResourceType}}. Supported types are: {{.Types}}",
+		map[string]interface{}{"ResourceType": resourceType, "Types": strings.Join(LabeledResourceTypes(), ", ")}))
+}
@@ -0,0 +1,155 @@
+package actors_test
+
+import (
+	"errors"
+	"net/url"
+
+	. "code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/api/metadata/metadatafakes"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	cferrors "code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MetadataActor", func() {
+	var (
+		fakeMetadataRepo *metadatafakes.FakeRepository
+		config           coreconfig.Repository
+		actor            MetadataActor
+	)
+
+	BeforeEach(func() {
+		fakeMetadataRepo = new(metadatafakes.FakeRepository)
+		config = testconfig.NewRepositoryWithDefaults()
+		actor = NewMetadataActor(fakeMetadataRepo, config)
+	})
+
+	Describe("GetLabels", func() {
+		It("looks up apps by name in the targeted space", func() {
+			resource := models.LabeledResource{GUID: "app-guid", Name: "my-app", Metadata: models.Metadata{Labels: map[string]string{"env": "prod"}}}
+			fakeMetadataRepo.ListResourcesReturns([]models.LabeledResource{resource}, nil)
+
+			labeled, err := actor.GetLabels("app", "my-app")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(labeled).To(Equal(resource))
+
+			path, filters := fakeMetadataRepo.ListResourcesArgsForCall(0)
+			Expect(path).To(Equal("/v3/apps"))
+			Expect(filters).To(Equal(url.Values{
+				"names":       []string{"my-app"},
+				"space_guids": []string{config.SpaceFields().GUID},
+			}))
+		})
+
+		It("looks up spaces by name in the targeted org", func() {
+			fakeMetadataRepo.ListResourcesReturns([]models.LabeledResource{{GUID: "space-guid"}}, nil)
+
+			_, err := actor.GetLabels("space", "my-space")
+			Expect(err).NotTo(HaveOccurred())
+
+			path, filters := fakeMetadataRepo.ListResourcesArgsForCall(0)
+			Expect(path).To(Equal("/v3/spaces"))
+			Expect(filters).To(Equal(url.Values{
+				"names":              []string{"my-space"},
+				"organization_guids": []string{config.OrganizationFields().GUID},
+			}))
+		})
+
+		It("looks up global resources by name alone", func() {
+			fakeMetadataRepo.ListResourcesReturns([]models.LabeledResource{{GUID: "stack-guid"}}, nil)
+
+			_, err := actor.GetLabels("stack", "cflinuxfs3")
+			Expect(err).NotTo(HaveOccurred())
+
+			path, filters := fakeMetadataRepo.ListResourcesArgsForCall(0)
+			Expect(path).To(Equal("/v3/stacks"))
+			Expect(filters).To(Equal(url.Values{"names": []string{"cflinuxfs3"}}))
+		})
+
+		It("returns a not found error when nothing has the name", func() {
+			fakeMetadataRepo.ListResourcesReturns([]models.LabeledResource{}, nil)
+
+			_, err := actor.GetLabels("app", "my-app")
+			Expect(err).To(BeAssignableToTypeOf(&cferrors.ModelNotFoundError{}))
+		})
+
+		It("returns an error when more than one resource has the name", func() {
+			fakeMetadataRepo.ListResourcesReturns([]models.LabeledResource{{GUID: "buildpack-1-guid"}, {GUID: "buildpack-2-guid"}}, nil)
+
+			_, err := actor.GetLabels("buildpack", "ruby_buildpack")
+			Expect(err).To(MatchError("More than one buildpack named ruby_buildpack was found"))
+		})
+
+		It("returns an error for unsupported resource types", func() {
+			_, err := actor.GetLabels("route", "example.com")
+			Expect(err).To(MatchError("Unsupported resource type route. Supported types are: app, buildpack, org, service-instance, space, stack"))
+			Expect(fakeMetadataRepo.ListResourcesCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("GetMetadata", func() {
+		It("looks up the resource by guid", func() {
+			metadata := models.Metadata{Labels: map[string]string{"env": "prod"}, Annotations: map[string]string{"owner": "billing"}}
+			fakeMetadataRepo.ListResourcesReturns([]models.LabeledResource{{GUID: "app-guid", Metadata: metadata}}, nil)
+
+			actual, err := actor.GetMetadata("app", "app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(Equal(metadata))
+
+			path, filters := fakeMetadataRepo.ListResourcesArgsForCall(0)
+			Expect(path).To(Equal("/v3/apps"))
+			Expect(filters).To(Equal(url.Values{"guids": []string{"app-guid"}}))
+		})
+
+		It("returns a not found error when nothing has the guid", func() {
+			fakeMetadataRepo.ListResourcesReturns([]models.LabeledResource{}, nil)
+
+			_, err := actor.GetMetadata("app", "app-guid")
+			Expect(err).To(BeAssignableToTypeOf(&cferrors.ModelNotFoundError{}))
+		})
+	})
+
+	Describe("UpdateLabels", func() {
+		It("updates the labels of the resource with the name", func() {
+			fakeMetadataRepo.ListResourcesReturns([]models.LabeledResource{{GUID: "instance-guid", Name: "my-db"}}, nil)
+
+			env := "prod"
+			labels := map[string]*string{"env": &env, "tier": nil}
+			err := actor.UpdateLabels("service-instance", "my-db", labels)
+			Expect(err).NotTo(HaveOccurred())
+
+			path, guid, update := fakeMetadataRepo.UpdateMetadataArgsForCall(0)
+			Expect(path).To(Equal("/v3/service_instances"))
+			Expect(guid).To(Equal("instance-guid"))
+			Expect(update).To(Equal(models.MetadataUpdate{Labels: labels}))
+		})
+
+		It("does not update anything when the resource cannot be found", func() {
+			fakeMetadataRepo.ListResourcesReturns(nil, errors.New("list failed"))
+
+			err := actor.UpdateLabels("app", "my-app", map[string]*string{"env": nil})
+			Expect(err).To(MatchError("list failed"))
+			Expect(fakeMetadataRepo.UpdateMetadataCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("MatchingGUIDs", func() {
+		It("returns the guids of the resources that match the selector", func() {
+			fakeMetadataRepo.ListResourcesReturns([]models.LabeledResource{{GUID: "app-1-guid"}, {GUID: "app-2-guid"}}, nil)
+
+			guids, err := actor.MatchingGUIDs("app", "env=prod")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(guids).To(Equal(map[string]bool{"app-1-guid": true, "app-2-guid": true}))
+
+			path, filters := fakeMetadataRepo.ListResourcesArgsForCall(0)
+			Expect(path).To(Equal("/v3/apps"))
+			Expect(filters).To(Equal(url.Values{
+				"label_selector": []string{"env=prod"},
+				"space_guids":    []string{config.SpaceFields().GUID},
+			}))
+		})
+	})
+})
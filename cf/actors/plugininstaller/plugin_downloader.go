@@ -37,44 +37,57 @@ func (downloader *PluginDownloader) downloadFromPath(pluginSourceFilepath string
 }
 
 func (downloader *PluginDownloader) downloadFromPlugin(plugin clipr.Plugin) (string, string) {
+	platform, ok := binaryPlatform()
+	if !ok {
+		downloader.binaryNotAvailable()
+		return "", ""
+	}
+	return downloader.downloadFromPath(downloader.getBinaryURL(plugin, platform)), downloader.getBinaryChecksum(plugin, platform)
+}
+
+// binaryPlatform returns the platform, as plugin repos name it, of the
+// binaries that run on this machine.
+func binaryPlatform() (string, bool) {
 	arch := runtime.GOARCH
 
 	switch runtime.GOOS {
 	case "darwin":
-		return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "osx")), downloader.getBinaryChecksum(plugin, "osx")
+		return "osx", true
 	case "linux":
 		if arch == "386" {
-			return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "linux32")), downloader.getBinaryChecksum(plugin, "linux32")
+			return "linux32", true
 		}
-		return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "linux64")), downloader.getBinaryChecksum(plugin, "linux64")
+		return "linux64", true
 	case "windows":
 		if arch == "386" {
-			return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "win32")), downloader.getBinaryChecksum(plugin, "win32")
+			return "win32", true
 		}
-		return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "win64")), downloader.getBinaryChecksum(plugin, "win64")
+		return "win64", true
 	default:
-		downloader.binaryNotAvailable()
+		return "", false
 	}
-	return "", ""
 }
 
-func (downloader *PluginDownloader) getBinaryURL(plugin clipr.Plugin, os string) string {
+func findBinary(plugin clipr.Plugin, platform string) (clipr.Binary, bool) {
 	for _, binary := range plugin.Binaries {
-		if binary.Platform == os {
-			return binary.Url
+		if binary.Platform == platform {
+			return binary, true
 		}
 	}
+	return clipr.Binary{}, false
+}
+
+func (downloader *PluginDownloader) getBinaryURL(plugin clipr.Plugin, os string) string {
+	if binary, ok := findBinary(plugin, os); ok {
+		return binary.Url
+	}
 	downloader.binaryNotAvailable()
 	return ""
 }
 
 func (downloader *PluginDownloader) getBinaryChecksum(plugin clipr.Plugin, os string) string {
-	for _, binary := range plugin.Binaries {
-		if binary.Platform == os {
-			return binary.Checksum
-		}
-	}
-	return ""
+	binary, _ := findBinary(plugin, os)
+	return binary.Checksum
 }
 
 func (downloader *PluginDownloader) binaryNotAvailable() {
@@ -15,12 +15,14 @@ type PluginInstaller interface {
 }
 
 type Context struct {
-	Checksummer    utils.Sha1Checksum
+	Checksummer    utils.Sha256Checksum
 	FileDownloader downloader.Downloader
 	GetPluginRepos pluginReposFetcher
 	PluginRepo     pluginrepo.PluginRepo
 	RepoName       string
+	SkipChecksum   bool
 	UI             terminal.UI
+	Version        string
 }
 
 type pluginReposFetcher func() []models.PluginRepo
@@ -40,6 +42,8 @@ func NewPluginInstaller(context *Context) PluginInstaller {
 			UI:               context.UI,
 			PluginDownloader: pluginDownloader,
 			RepoName:         context.RepoName,
+			Version:          context.Version,
+			SkipChecksum:     context.SkipChecksum,
 			Checksummer:      context.Checksummer,
 			PluginRepo:       context.PluginRepo,
 			GetPluginRepos:   context.GetPluginRepos,
@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/terminal"
 	"code.cloudfoundry.org/cli/utils"
+	"github.com/blang/semver"
 	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
 )
 
@@ -17,14 +18,14 @@ type pluginInstallerWithRepo struct {
 	PluginDownloader *PluginDownloader
 	DownloadFromPath downloadFromPath
 	RepoName         string
-	Checksummer      utils.Sha1Checksum
+	Version          string
+	SkipChecksum     bool
+	Checksummer      utils.Sha256Checksum
 	PluginRepo       pluginrepo.PluginRepo
 	GetPluginRepos   pluginReposFetcher
 }
 
 func (installer *pluginInstallerWithRepo) Install(inputSourceFilepath string) string {
-	var outputSourceFilepath string
-
 	targetPluginName := strings.ToLower(inputSourceFilepath)
 
 	installer.UI.Say(T("Looking up '{{.filePath}}' from repository '{{.repoName}}'", map[string]interface{}{"filePath": inputSourceFilepath, "repoName": installer.RepoName}))
@@ -39,25 +40,100 @@ func (installer *pluginInstallerWithRepo) Install(inputSourceFilepath string) st
 		installer.UI.Failed(T("Error getting plugin metadata from repo: ") + repoAry[0])
 	}
 
-	found := false
-	sha1 := ""
+	matches := []clipr.Plugin{}
 	for _, plugin := range findRepoCaseInsensity(pluginList, installer.RepoName) {
 		if strings.ToLower(plugin.Name) == targetPluginName {
-			found = true
-			outputSourceFilepath, sha1 = installer.PluginDownloader.downloadFromPlugin(plugin)
+			matches = append(matches, plugin)
+		}
+	}
+	if len(matches) == 0 {
+		installer.UI.Failed(inputSourceFilepath + T(" is not available in repo '") + installer.RepoName + "'")
+		return ""
+	}
+
+	plugin, found := installer.selectVersion(matches)
+	if !found {
+		versions := []string{}
+		for _, match := range matches {
+			versions = append(versions, match.Version)
+		}
+		installer.UI.Failed(T("Version {{.Version}} of {{.Plugin}} is not available in repo '{{.RepoName}}'; it has versions {{.Versions}}",
+			map[string]interface{}{
+				"Version":  installer.Version,
+				"Plugin":   inputSourceFilepath,
+				"RepoName": installer.RepoName,
+				"Versions": strings.Join(versions, ", "),
+			}))
+		return ""
+	}
 
-			installer.Checksummer.SetFilePath(outputSourceFilepath)
-			if !installer.Checksummer.CheckSha1(sha1) {
-				installer.UI.Failed(T("Downloaded plugin binary's checksum does not match repo metadata"))
+	outputSourceFilepath, checksum := installer.PluginDownloader.downloadFromPlugin(plugin)
+	if !installer.verifyChecksum(outputSourceFilepath, checksum) {
+		return ""
+	}
+
+	return outputSourceFilepath
+}
+
+// selectVersion returns the plugin with the version the user asked for, or
+// the newest one when no version was given.
+func (installer *pluginInstallerWithRepo) selectVersion(plugins []clipr.Plugin) (clipr.Plugin, bool) {
+	if installer.Version == "" {
+		newest := plugins[0]
+		for _, plugin := range plugins[1:] {
+			if compareVersions(plugin.Version, newest.Version) > 0 {
+				newest = plugin
 			}
 		}
+		return newest, true
+	}
 
+	for _, plugin := range plugins {
+		if compareVersions(plugin.Version, installer.Version) == 0 {
+			return plugin, true
+		}
 	}
-	if !found {
-		installer.UI.Failed(inputSourceFilepath + T(" is not available in repo '") + installer.RepoName + "'")
+	return clipr.Plugin{}, false
+}
+
+func (installer *pluginInstallerWithRepo) verifyChecksum(filepath string, checksum string) bool {
+	if installer.SkipChecksum {
+		installer.UI.Warn(T("Skipping checksum verification of the downloaded plugin binary"))
+		return true
 	}
 
-	return outputSourceFilepath
+	if checksum == "" {
+		installer.UI.Failed(T("Repo '{{.RepoName}}' does not give a SHA256 checksum for the plugin binary; use --skip-checksum to install it anyway",
+			map[string]interface{}{"RepoName": installer.RepoName}))
+		return false
+	}
+
+	if IsSha1Checksum(checksum) {
+		installer.UI.Failed(T("Repo '{{.RepoName}}' gives a SHA1 checksum for the plugin binary, which is not verified as it is not secure; use --skip-checksum to install it anyway",
+			map[string]interface{}{"RepoName": installer.RepoName}))
+		return false
+	}
+
+	installer.Checksummer.SetFilePath(filepath)
+	if !installer.Checksummer.CheckSha256(checksum) {
+		installer.UI.Failed(T("Downloaded plugin binary's checksum does not match repo metadata"))
+		return false
+	}
+	return true
+}
+
+// IsSha1Checksum reports whether checksum is a SHA1 checksum, which repos
+// gave for plugin binaries before SHA256 checksums were required.
+func IsSha1Checksum(checksum string) bool {
+	if len(checksum) != 40 {
+		return false
+	}
+	for _, c := range strings.ToLower(checksum) {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+	}
+	return true
 }
 
 func (installer *pluginInstallerWithRepo) getRepoFromConfig(repoName string) (models.PluginRepo, error) {
@@ -82,3 +158,15 @@ func findRepoCaseInsensity(repoList map[string][]clipr.Plugin, repoName string)
 	}
 	return nil
 }
+
+// compareVersions compares plugin versions as semantic versions, so 1.10.0 is
+// newer than 1.9.0, and falls back to comparing them as strings when either
+// is not one.
+func compareVersions(a string, b string) int {
+	versionA, errA := semver.ParseTolerant(a)
+	versionB, errB := semver.ParseTolerant(b)
+	if errA == nil && errB == nil {
+		return versionA.Compare(versionB)
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,79 @@
+package plugininstaller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
+	"code.cloudfoundry.org/cli/utils/sortutils"
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
+)
+
+// PluginUpdate is a newer version of an installed plugin that a repo has a
+// binary of for this machine.
+type PluginUpdate struct {
+	Name             string
+	InstalledVersion string
+	Version          string
+	RepoName         string
+	Checksum         string
+}
+
+// FindUpdates returns the updates of the installed plugins, in the order of
+// their names, using the newest version in any of the repos. Plugins that do
+// not give their version cannot be compared, so they are left out.
+func FindUpdates(installed map[string]pluginconfig.PluginMetadata, repoPlugins map[string][]clipr.Plugin) []PluginUpdate {
+	platform, ok := binaryPlatform()
+	if !ok {
+		return nil
+	}
+
+	var repoNames sortutils.Alphabetic
+	for repoName := range repoPlugins {
+		repoNames = append(repoNames, repoName)
+	}
+	sort.Sort(repoNames)
+
+	var pluginNames sortutils.Alphabetic
+	for pluginName := range installed {
+		pluginNames = append(pluginNames, pluginName)
+	}
+	sort.Sort(pluginNames)
+
+	updates := []PluginUpdate{}
+	for _, pluginName := range pluginNames {
+		version := installed[pluginName].Version
+		if version.Major == 0 && version.Minor == 0 && version.Build == 0 {
+			continue
+		}
+
+		update := PluginUpdate{
+			Name:             pluginName,
+			InstalledVersion: fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Build),
+		}
+		update.Version = update.InstalledVersion
+
+		for _, repoName := range repoNames {
+			for _, plugin := range repoPlugins[repoName] {
+				if !strings.EqualFold(plugin.Name, pluginName) || compareVersions(plugin.Version, update.Version) <= 0 {
+					continue
+				}
+
+				binary, ok := findBinary(plugin, platform)
+				if !ok {
+					continue
+				}
+
+				update.Version = plugin.Version
+				update.RepoName = repoName
+				update.Checksum = binary.Checksum
+			}
+		}
+
+		if update.RepoName != "" {
+			updates = append(updates, update)
+		}
+	}
+	return updates
+}
@@ -1,45 +1,62 @@
 package actors
 
 import (
+	"crypto/sha1"
+	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strings"
+	"sync"
 
 	"code.cloudfoundry.org/cli/cf/api/applicationbits"
 	"code.cloudfoundry.org/cli/cf/api/resources"
 	"code.cloudfoundry.org/cli/cf/appfiles"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/formatters"
 	. "code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/cf/models"
-	"code.cloudfoundry.org/gofileutils/fileutils"
+	"code.cloudfoundry.org/cli/utils/words/generator"
 )
 
 const windowsPathPrefix = `\\?\`
 
+const (
+	DefaultUploadChunkSize   int64 = 64 * 1024 * 1024
+	DefaultUploadConcurrency       = 4
+)
+
 //go:generate counterfeiter . PushActor
 
 type PushActor interface {
 	UploadApp(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) error
+	UploadAppInChunks(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource, chunkSize int64, maxConcurrency int) error
 	ProcessPath(dirOrZipFile string, f func(string) error) error
-	GatherFiles(localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error)
+	GatherFiles(appGUID string, localFiles []models.AppFileFields, appDir string) ([]resources.AppFileResource, []models.AppFileFields, error)
 	ValidateAppParams(apps []models.AppParams) []error
 	MapManifestRoute(routeName string, app models.Application, appParamsFromContext models.AppParams) error
+	DiffApp(app models.Application, appParams models.AppParams, localFiles []models.AppFileFields) (PushDiff, error)
 }
 
 type PushActorImpl struct {
-	appBitsRepo applicationbits.Repository
-	appfiles    appfiles.AppFiles
-	zipper      appfiles.Zipper
-	routeActor  RouteActor
+	appBitsRepo  applicationbits.Repository
+	appfiles     appfiles.AppFiles
+	zipper       appfiles.Zipper
+	routeActor   RouteActor
+	uploadStates UploadStateStore
 }
 
-func NewPushActor(appBitsRepo applicationbits.Repository, zipper appfiles.Zipper, appfiles appfiles.AppFiles, routeActor RouteActor) PushActor {
+func NewPushActor(appBitsRepo applicationbits.Repository, zipper appfiles.Zipper, appfiles appfiles.AppFiles, routeActor RouteActor, uploadStates UploadStateStore) PushActor {
 	return PushActorImpl{
-		appBitsRepo: appBitsRepo,
-		appfiles:    appfiles,
-		zipper:      zipper,
-		routeActor:  routeActor,
+		appBitsRepo:  appBitsRepo,
+		appfiles:     appfiles,
+		zipper:       zipper,
+		routeActor:   routeActor,
+		uploadStates: uploadStates,
 	}
 }
 
@@ -105,49 +122,32 @@ func (actor PushActorImpl) ProcessPath(dirOrZipFile string, f func(string) error
 	return nil
 }
 
-func (actor PushActorImpl) GatherFiles(localFiles []models.AppFileFields, appDir string, uploadDir string) ([]resources.AppFileResource, bool, error) {
-	appFileResource := []resources.AppFileResource{}
-	for _, file := range localFiles {
-		appFileResource = append(appFileResource, resources.AppFileResource{
-			Path: file.Path,
-			Sha1: file.Sha1,
-			Size: file.Size,
-		})
-	}
-
-	remoteFiles, err := actor.appBitsRepo.GetApplicationFiles(appFileResource)
+// GatherFiles asks the Cloud Controller which of the local files it already
+// has and returns them along with the files that still need to be uploaded.
+// The matching results are remembered for appGUID, so a push that is retried
+// with the same files after a failed upload does not need to match them again.
+func (actor PushActorImpl) GatherFiles(appGUID string, localFiles []models.AppFileFields, appDir string) ([]resources.AppFileResource, []models.AppFileFields, error) {
+	remoteFiles, err := actor.matchFiles(appGUID, toAppFileResources(localFiles))
 	if err != nil {
-		return []resources.AppFileResource{}, false, err
+		return []resources.AppFileResource{}, nil, err
 	}
 
-	filesToUpload := make([]models.AppFileFields, len(localFiles), len(localFiles))
-	copy(filesToUpload, localFiles)
-
+	matched := map[string]bool{}
 	for _, remoteFile := range remoteFiles {
-		for i, fileToUpload := range filesToUpload {
-			if remoteFile.Path == fileToUpload.Path {
-				filesToUpload = append(filesToUpload[:i], filesToUpload[i+1:]...)
-			}
-		}
+		matched[remoteFile.Path] = true
 	}
 
-	err = actor.appfiles.CopyFiles(filesToUpload, appDir, uploadDir)
-	if err != nil {
-		return []resources.AppFileResource{}, false, err
-	}
-
-	_, err = os.Stat(filepath.Join(appDir, ".cfignore"))
-	if err == nil {
-		err = fileutils.CopyPathToPath(filepath.Join(appDir, ".cfignore"), filepath.Join(uploadDir, ".cfignore"))
-		if err != nil {
-			return []resources.AppFileResource{}, false, err
+	filesToUpload := []models.AppFileFields{}
+	for _, localFile := range localFiles {
+		if !matched[localFile.Path] {
+			filesToUpload = append(filesToUpload, localFile)
 		}
 	}
 
 	for i := range remoteFiles {
 		fullPath, err := filepath.Abs(filepath.Join(appDir, remoteFiles[i].Path))
 		if err != nil {
-			return []resources.AppFileResource{}, false, err
+			return []resources.AppFileResource{}, nil, err
 		}
 
 		if runtime.GOOS == "windows" {
@@ -155,7 +155,7 @@ func (actor PushActorImpl) GatherFiles(localFiles []models.AppFileFields, appDir
 		}
 		fileInfo, err := os.Lstat(fullPath)
 		if err != nil {
-			return []resources.AppFileResource{}, false, err
+			return []resources.AppFileResource{}, nil, err
 		}
 		fileMode := fileInfo.Mode()
 
@@ -166,11 +166,213 @@ func (actor PushActorImpl) GatherFiles(localFiles []models.AppFileFields, appDir
 		remoteFiles[i].Mode = fmt.Sprintf("%#o", fileMode)
 	}
 
-	return remoteFiles, len(filesToUpload) > 0, nil
+	return remoteFiles, filesToUpload, nil
+}
+
+func (actor PushActorImpl) matchFiles(appGUID string, appFileResource []resources.AppFileResource) ([]resources.AppFileResource, error) {
+	fingerprint, err := filesFingerprint(appFileResource)
+	if err != nil {
+		return nil, err
+	}
+
+	// A missing or unreadable upload state only means there is nothing to
+	// resume, so errors from the store never fail the push.
+	state, err := actor.uploadStates.Load(appGUID)
+	if err == nil && state.FilesFingerprint == fingerprint && state.MatchedFiles != nil {
+		remoteFiles := make([]resources.AppFileResource, len(state.MatchedFiles))
+		copy(remoteFiles, state.MatchedFiles)
+		return remoteFiles, nil
+	}
+
+	remoteFiles, err := actor.appBitsRepo.GetApplicationFiles(appFileResource)
+	if err != nil {
+		return nil, err
+	}
+
+	matchedFiles := make([]resources.AppFileResource, len(remoteFiles))
+	copy(matchedFiles, remoteFiles)
+	_ = actor.uploadStates.Save(appGUID, UploadState{
+		FilesFingerprint: fingerprint,
+		MatchedFiles:     matchedFiles,
+	})
+
+	return remoteFiles, nil
+}
+
+func filesFingerprint(appFileResource []resources.AppFileResource) (string, error) {
+	data, err := json.Marshal(appFileResource)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", sha1.Sum(data)), nil
 }
 
 func (actor PushActorImpl) UploadApp(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) error {
-	return actor.appBitsRepo.UploadBits(appGUID, zipFile, presentFiles)
+	err := actor.appBitsRepo.UploadBits(appGUID, zipFile, presentFiles)
+	if err != nil {
+		actor.forgetMatchedFiles(appGUID)
+		return err
+	}
+
+	_ = actor.uploadStates.Delete(appGUID)
+	return nil
+}
+
+// UploadAppInChunks splits the zip file into chunks of at most chunkSize bytes
+// and uploads up to maxConcurrency of them at the same time. Once every chunk
+// has been uploaded the Cloud Controller is asked to reassemble them, along
+// with the already present files, into the application bits. Cloud Controllers
+// that cannot take the bits in chunks are sent the whole zip file in a single
+// request instead.
+//
+// No more chunks are started after the first failed upload; chunks that are
+// already in flight are allowed to finish before the error is returned.
+//
+// Every uploaded chunk is recorded in the app's upload state. When the upload
+// is retried, chunks whose offset, size and SHA1 match a recorded chunk are
+// not sent again.
+func (actor PushActorImpl) UploadAppInChunks(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource, chunkSize int64, maxConcurrency int) error {
+	chunks, err := splitIntoChunks(zipFile, chunkSize)
+	if err != nil {
+		return err
+	}
+
+	supported, err := actor.appBitsRepo.ChunkedUploadSupported(appGUID)
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return actor.UploadApp(appGUID, zipFile, presentFiles)
+	}
+
+	state, err := actor.uploadStates.Load(appGUID)
+	if err != nil {
+		state = UploadState{}
+	}
+
+	alreadyUploaded := map[resources.AppBitsChunkResource]bool{}
+	for _, chunk := range state.UploadedChunks {
+		alreadyUploaded[chunk] = true
+	}
+
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+
+	var (
+		wg         sync.WaitGroup
+		errMutex   sync.Mutex
+		firstErr   error
+		stateMutex sync.Mutex
+	)
+
+	failed := func() bool {
+		errMutex.Lock()
+		defer errMutex.Unlock()
+		return firstErr != nil
+	}
+
+	work := make(chan resources.AppBitsChunkResource)
+	for i := 0; i < maxConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range work {
+				body := io.NewSectionReader(zipFile, chunk.Offset, chunk.Size)
+				uploadErr := actor.appBitsRepo.UploadBitsChunk(appGUID, chunk, body)
+				if uploadErr != nil {
+					errMutex.Lock()
+					if firstErr == nil {
+						firstErr = uploadErr
+					}
+					errMutex.Unlock()
+					continue
+				}
+
+				stateMutex.Lock()
+				state.UploadedChunks = append(state.UploadedChunks, chunk)
+				_ = actor.uploadStates.Save(appGUID, state)
+				stateMutex.Unlock()
+			}
+		}()
+	}
+
+	for _, chunk := range chunks {
+		if failed() {
+			break
+		}
+		if alreadyUploaded[chunk] {
+			continue
+		}
+		work <- chunk
+	}
+	close(work)
+	wg.Wait()
+
+	if firstErr != nil {
+		actor.forgetMatchedFiles(appGUID)
+		return firstErr
+	}
+
+	err = actor.appBitsRepo.CompleteChunkedUpload(appGUID, chunks, presentFiles)
+	if err != nil {
+		actor.forgetMatchedFiles(appGUID)
+		return err
+	}
+
+	_ = actor.uploadStates.Delete(appGUID)
+	return nil
+}
+
+// forgetMatchedFiles drops the cached resource matching results from the
+// app's upload state after a failed upload, while keeping the chunks that were
+// uploaded. A failed upload may mean the Cloud Controller no longer has some
+// of the matched files, so they are matched again on the next push.
+func (actor PushActorImpl) forgetMatchedFiles(appGUID string) {
+	state, err := actor.uploadStates.Load(appGUID)
+	if err != nil {
+		_ = actor.uploadStates.Delete(appGUID)
+		return
+	}
+
+	state.FilesFingerprint = ""
+	state.MatchedFiles = nil
+	_ = actor.uploadStates.Save(appGUID, state)
+}
+
+func splitIntoChunks(zipFile *os.File, chunkSize int64) ([]resources.AppBitsChunkResource, error) {
+	if chunkSize <= 0 {
+		return nil, errors.New(T("Invalid chunk size: {{.ChunkSize}}", map[string]interface{}{"ChunkSize": chunkSize}))
+	}
+
+	fileInfo, err := zipFile.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := []resources.AppBitsChunkResource{}
+	for offset := int64(0); offset < fileInfo.Size(); offset += chunkSize {
+		size := chunkSize
+		if remaining := fileInfo.Size() - offset; remaining < size {
+			size = remaining
+		}
+
+		hash := sha1.New()
+		_, err = io.Copy(hash, io.NewSectionReader(zipFile, offset, size))
+		if err != nil {
+			return nil, err
+		}
+
+		chunks = append(chunks, resources.AppBitsChunkResource{
+			Index:  len(chunks),
+			Offset: offset,
+			Size:   size,
+			Sha1:   fmt.Sprintf("%x", hash.Sum(nil)),
+		})
+	}
+
+	return chunks, nil
 }
 
 func (actor PushActorImpl) ValidateAppParams(apps []models.AppParams) []error {
@@ -192,6 +394,69 @@ func (actor PushActorImpl) ValidateAppParams(apps []models.AppParams) []error {
 				errs = append(errs, fmt.Errorf(T("Application {{.AppName}} must not be configured with both 'routes' and 'no-hostname'", map[string]interface{}{"AppName": appName})))
 			}
 		}
+
+		if app.BuildpackURL != nil && app.Buildpacks != nil {
+			errs = append(errs, fmt.Errorf(T("Application {{.AppName}} must not be configured with both 'buildpack' and 'buildpacks'", map[string]interface{}{"AppName": appName})))
+		}
+
+		if len(app.Buildpacks) > 1 {
+			for _, buildpack := range app.Buildpacks {
+				if buildpack == "default" || buildpack == "null" {
+					errs = append(errs, fmt.Errorf(T("Application {{.AppName}} must not combine '{{.Buildpack}}' with other 'buildpacks'", map[string]interface{}{"AppName": appName, "Buildpack": buildpack})))
+					break
+				}
+			}
+		}
+
+		if app.Lifecycle != nil && *app.Lifecycle != models.AppLifecycleBuildpack && *app.Lifecycle != models.AppLifecycleCNB {
+			errs = append(errs, fmt.Errorf(T("Application {{.AppName}} has an invalid 'lifecycle' {{.Lifecycle}}; it must be 'buildpack' or 'cnb'", map[string]interface{}{
+				"AppName":   appName,
+				"Lifecycle": *app.Lifecycle,
+			})))
+		}
+
+		if app.DockerImage != nil {
+			if app.Lifecycle != nil {
+				errs = append(errs, fmt.Errorf(T("Application {{.AppName}} must not be configured with both 'docker' and 'lifecycle'", map[string]interface{}{"AppName": appName})))
+			}
+
+			if app.BuildpackURL != nil {
+				errs = append(errs, fmt.Errorf(T("Application {{.AppName}} must not be configured with both 'docker' and 'buildpack'", map[string]interface{}{"AppName": appName})))
+			}
+
+			if app.Buildpacks != nil {
+				errs = append(errs, fmt.Errorf(T("Application {{.AppName}} must not be configured with both 'docker' and 'buildpacks'", map[string]interface{}{"AppName": appName})))
+			}
+
+			if app.Path != nil {
+				errs = append(errs, fmt.Errorf(T("Application {{.AppName}} must not be configured with both 'docker' and 'path'", map[string]interface{}{"AppName": appName})))
+			}
+		}
+
+		if app.DockerUsername != nil || app.DockerPassword != nil {
+			if app.DockerImage == nil {
+				errs = append(errs, fmt.Errorf(T("Application {{.AppName}} must have a 'docker.image' when configured with docker credentials", map[string]interface{}{"AppName": appName})))
+			}
+
+			if app.DockerUsername == nil {
+				errs = append(errs, fmt.Errorf(T("Application {{.AppName}} must have a 'docker.username' when configured with a docker password", map[string]interface{}{"AppName": appName})))
+			}
+
+			if app.DockerPassword == nil {
+				errs = append(errs, fmt.Errorf(T("Application {{.AppName}} must have a 'docker.password', or the environment variable CF_DOCKER_PASSWORD set, when configured with a docker username", map[string]interface{}{"AppName": appName})))
+			}
+		}
+
+		if app.RandomRouteStrategy != nil && !generator.IsValidStrategy(*app.RandomRouteStrategy) {
+			errs = append(errs, fmt.Errorf(T("Application {{.AppName}} has an invalid 'random-route-strategy' {{.Strategy}}; it must be one of {{.Strategies}}", map[string]interface{}{
+				"AppName":    appName,
+				"Strategy":   *app.RandomRouteStrategy,
+				"Strategies": strings.Join(generator.Strategies, ", "),
+			})))
+		}
+
+		errs = append(errs, validateSidecars(app)...)
+		errs = append(errs, validateHealthChecks(app)...)
 	}
 
 	if len(errs) > 0 {
@@ -204,3 +469,152 @@ func (actor PushActorImpl) ValidateAppParams(apps []models.AppParams) []error {
 func (actor PushActorImpl) MapManifestRoute(routeName string, app models.Application, appParamsFromContext models.AppParams) error {
 	return actor.routeActor.FindAndBindRoute(routeName, app, appParamsFromContext)
 }
+
+// HealthCheckTypes are the health check types a process can be given.
+var HealthCheckTypes = []string{"port", "process", "http", "none"}
+
+// ReadinessHealthCheckTypes are the readiness check types a process can be
+// given.
+var ReadinessHealthCheckTypes = []string{"port", "process", "http"}
+
+func validateHealthChecks(app models.AppParams) []error {
+	web := models.ProcessParams{
+		Type:                             "web",
+		HealthCheckType:                  app.HealthCheckType,
+		HealthCheckHTTPEndpoint:          app.HealthCheckHTTPEndpoint,
+		HealthCheckInvocationTimeout:     app.HealthCheckInvocationTimeout,
+		ReadinessHealthCheckType:         app.ReadinessHealthCheckType,
+		ReadinessHealthCheckHTTPEndpoint: app.ReadinessHealthCheckHTTPEndpoint,
+		ReadinessHealthCheckInterval:     app.ReadinessHealthCheckInterval,
+	}
+	errs := validateProcessHealthChecks(app.Name, web)
+
+	types := map[string]bool{}
+	for _, process := range app.Processes {
+		if types[process.Type] {
+			errs = append(errs, fmt.Errorf(T("Application {{.AppName}} has more than one process of type {{.ProcessType}}", map[string]interface{}{
+				"AppName":     app.Name,
+				"ProcessType": process.Type,
+			})))
+		}
+		types[process.Type] = true
+
+		errs = append(errs, validateProcessHealthChecks(app.Name, process)...)
+	}
+
+	return errs
+}
+
+func validateProcessHealthChecks(appName *string, process models.ProcessParams) []error {
+	errs := validateHealthCheck(appName, process.Type, "", HealthCheckTypes, process.HealthCheck())
+	return append(errs, validateHealthCheck(appName, process.Type, "readiness-", ReadinessHealthCheckTypes, process.ReadinessHealthCheck())...)
+}
+
+// validateHealthCheck validates the health or readiness check of a process,
+// whose manifest keys start with keyPrefix.
+func validateHealthCheck(appName *string, processType string, keyPrefix string, types []string, healthCheck models.ProcessHealthCheck) []error {
+	errs := []error{}
+	typeKey := keyPrefix + "health-check-type"
+
+	if healthCheck.Type != nil && !contains(types, *healthCheck.Type) {
+		errs = append(errs, fmt.Errorf(T("Application {{.AppName}} has an invalid '{{.Key}}' {{.HealthCheckType}} for its {{.ProcessType}} process; it must be one of {{.HealthCheckTypes}}", map[string]interface{}{
+			"AppName":          appName,
+			"Key":              typeKey,
+			"HealthCheckType":  *healthCheck.Type,
+			"ProcessType":      processType,
+			"HealthCheckTypes": strings.Join(types, ", "),
+		})))
+	}
+
+	if healthCheck.HTTPEndpoint != nil {
+		endpointKey := keyPrefix + "health-check-http-endpoint"
+
+		if healthCheck.Type == nil || *healthCheck.Type != "http" {
+			errs = append(errs, fmt.Errorf(T("Application {{.AppName}} must have a '{{.TypeKey}}' of http for its {{.ProcessType}} process when configured with a '{{.EndpointKey}}'", map[string]interface{}{
+				"AppName":     appName,
+				"TypeKey":     typeKey,
+				"EndpointKey": endpointKey,
+				"ProcessType": processType,
+			})))
+		}
+
+		if !strings.HasPrefix(*healthCheck.HTTPEndpoint, "/") {
+			errs = append(errs, fmt.Errorf(T("Application {{.AppName}} has an invalid '{{.Key}}' {{.Endpoint}} for its {{.ProcessType}} process; it must be a path starting with /", map[string]interface{}{
+				"AppName":     appName,
+				"Key":         endpointKey,
+				"Endpoint":    *healthCheck.HTTPEndpoint,
+				"ProcessType": processType,
+			})))
+		}
+	}
+
+	if healthCheck.InvocationTimeout != nil && *healthCheck.InvocationTimeout < 1 {
+		errs = append(errs, fmt.Errorf(T("Application {{.AppName}} has an invalid '{{.Key}}' for its {{.ProcessType}} process; it must be a positive number of seconds", map[string]interface{}{
+			"AppName":     appName,
+			"Key":         keyPrefix + "health-check-invocation-timeout",
+			"ProcessType": processType,
+		})))
+	}
+
+	if healthCheck.Interval != nil && *healthCheck.Interval < 1 {
+		errs = append(errs, fmt.Errorf(T("Application {{.AppName}} has an invalid '{{.Key}}' for its {{.ProcessType}} process; it must be a positive number of seconds", map[string]interface{}{
+			"AppName":     appName,
+			"Key":         keyPrefix + "health-check-interval",
+			"ProcessType": processType,
+		})))
+	}
+
+	return errs
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func validateSidecars(app models.AppParams) []error {
+	errs := []error{}
+
+	names := map[string]bool{}
+	memoryByProcessType := map[string]int64{}
+	for _, sidecar := range app.Sidecars {
+		if names[sidecar.Name] {
+			errs = append(errs, fmt.Errorf(T("Application {{.AppName}} has more than one sidecar named {{.SidecarName}}", map[string]interface{}{
+				"AppName":     app.Name,
+				"SidecarName": sidecar.Name,
+			})))
+		}
+		names[sidecar.Name] = true
+
+		for _, processType := range sidecar.ProcessTypes {
+			memoryByProcessType[processType] += sidecar.MemoryInMB
+		}
+	}
+
+	if app.Memory == nil {
+		return errs
+	}
+
+	processTypes := []string{}
+	for processType := range memoryByProcessType {
+		processTypes = append(processTypes, processType)
+	}
+	sort.Strings(processTypes)
+
+	for _, processType := range processTypes {
+		if memoryByProcessType[processType] >= *app.Memory {
+			errs = append(errs, fmt.Errorf(T("Application {{.AppName}} has sidecars using {{.SidecarMemory}} of memory in its {{.ProcessType}} process, which must be less than the app memory of {{.AppMemory}}", map[string]interface{}{
+				"AppName":       app.Name,
+				"SidecarMemory": formatters.ByteSize(memoryByProcessType[processType] * formatters.MEGABYTE),
+				"ProcessType":   processType,
+				"AppMemory":     formatters.ByteSize(*app.Memory * formatters.MEGABYTE),
+			})))
+		}
+	}
+
+	return errs
+}
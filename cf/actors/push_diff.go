@@ -0,0 +1,154 @@
+package actors
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/cf/trace"
+)
+
+// PushDiff describes the changes that pushing an app would make to its bits
+// and environment.
+type PushDiff struct {
+	FilesToUpload  []models.AppFileFields
+	MatchedFiles   []resources.AppFileResource
+	EnvVarsAdded   []string
+	EnvVarsChanged []string
+}
+
+// RouteChanges collects the route changes that a dry run route actor would
+// have made.
+type RouteChanges struct {
+	Created []string
+	Bound   []string
+	Unbound []string
+}
+
+// DiffApp works out what pushing appParams would change about app, which is
+// the zero Application when the app does not exist yet. The local files are
+// matched against the resources the Cloud Controller already has, but nothing
+// is copied, uploaded or remembered.
+func (actor PushActorImpl) DiffApp(app models.Application, appParams models.AppParams, localFiles []models.AppFileFields) (PushDiff, error) {
+	diff := PushDiff{}
+
+	if len(localFiles) > 0 {
+		matchedFiles, err := actor.appBitsRepo.GetApplicationFiles(toAppFileResources(localFiles))
+		if err != nil {
+			return PushDiff{}, err
+		}
+
+		matched := map[string]bool{}
+		for _, file := range matchedFiles {
+			matched[file.Path] = true
+		}
+
+		for _, file := range localFiles {
+			if !matched[file.Path] {
+				diff.FilesToUpload = append(diff.FilesToUpload, file)
+			}
+		}
+		diff.MatchedFiles = matchedFiles
+	}
+
+	if appParams.EnvironmentVars != nil {
+		for key, value := range *appParams.EnvironmentVars {
+			currentValue, ok := app.EnvironmentVars[key]
+			switch {
+			case !ok:
+				diff.EnvVarsAdded = append(diff.EnvVarsAdded, key)
+			case fmt.Sprint(currentValue) != fmt.Sprint(value):
+				diff.EnvVarsChanged = append(diff.EnvVarsChanged, key)
+			}
+		}
+		sort.Strings(diff.EnvVarsAdded)
+		sort.Strings(diff.EnvVarsChanged)
+	}
+
+	return diff, nil
+}
+
+// NewDryRunRouteActor returns a RouteActor that looks up domains and routes
+// as usual but only records the routes it would create, bind to or unbind
+// from app in the returned RouteChanges.
+func NewDryRunRouteActor(routeRepo api.RouteRepository, domainRepo api.DomainRepository, app models.Application) (RouteActor, *RouteChanges) {
+	changes := &RouteChanges{}
+	repo := dryRunRouteRepository{
+		RouteRepository: routeRepo,
+		urls:            map[string]string{},
+		changes:         changes,
+	}
+	for _, route := range app.Routes {
+		repo.urls[route.GUID] = route.URL()
+	}
+
+	ui := terminal.NewUI(os.Stdin, ioutil.Discard, terminal.NewTeePrinter(ioutil.Discard), trace.NewWriterPrinter(ioutil.Discard, false))
+	return NewRouteActor(ui, repo, domainRepo), changes
+}
+
+type dryRunRouteRepository struct {
+	api.RouteRepository
+	urls    map[string]string
+	changes *RouteChanges
+}
+
+func (repo dryRunRouteRepository) Find(host string, domain models.DomainFields, path string, port int) (models.Route, error) {
+	route, err := repo.RouteRepository.Find(host, domain, path, port)
+	if err == nil {
+		repo.urls[route.GUID] = route.URL()
+	}
+	return route, err
+}
+
+func (repo dryRunRouteRepository) Create(host string, domain models.DomainFields, path string, port int, useRandomPort bool) (models.Route, error) {
+	route := models.Route{
+		GUID:   fmt.Sprintf("dry-run-route-%d", len(repo.changes.Created)),
+		Host:   host,
+		Domain: domain,
+		Path:   path,
+		Port:   port,
+	}
+
+	url := route.URL()
+	if useRandomPort {
+		url = T("{{.Domain}} with a random port", map[string]interface{}{"Domain": domain.Name})
+	}
+
+	repo.urls[route.GUID] = url
+	repo.changes.Created = append(repo.changes.Created, url)
+	return route, nil
+}
+
+func (repo dryRunRouteRepository) Bind(routeGUID, appGUID string) error {
+	repo.changes.Bound = append(repo.changes.Bound, repo.urls[routeGUID])
+	return nil
+}
+
+func (repo dryRunRouteRepository) Unbind(routeGUID, appGUID string) error {
+	repo.changes.Unbound = append(repo.changes.Unbound, repo.urls[routeGUID])
+	return nil
+}
+
+// toAppFileResources lists the files to be matched against the resources the
+// Cloud Controller already has. Symbolic links are always uploaded, so they
+// are left out.
+func toAppFileResources(localFiles []models.AppFileFields) []resources.AppFileResource {
+	appFileResource := []resources.AppFileResource{}
+	for _, file := range localFiles {
+		if file.Symlink {
+			continue
+		}
+		appFileResource = append(appFileResource, resources.AppFileResource{
+			Path: file.Path,
+			Sha1: file.Sha1,
+			Size: file.Size,
+		})
+	}
+	return appFileResource
+}
@@ -0,0 +1,173 @@
+package actors_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/applicationbits/applicationbitsfakes"
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/appfiles/appfilesfakes"
+	cferrors "code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Push Diff", func() {
+	Describe("DiffApp", func() {
+		var (
+			appBitsRepo *applicationbitsfakes.FakeApplicationBitsRepository
+			actor       actors.PushActor
+			app         models.Application
+			appParams   models.AppParams
+			localFiles  []models.AppFileFields
+		)
+
+		BeforeEach(func() {
+			appBitsRepo = new(applicationbitsfakes.FakeApplicationBitsRepository)
+			actor = actors.NewPushActor(appBitsRepo, new(appfilesfakes.FakeZipper), new(appfilesfakes.FakeAppFiles), new(actorsfakes.FakeRouteActor), new(actorsfakes.FakeUploadStateStore))
+
+			app = models.Application{}
+			appParams = models.AppParams{}
+			localFiles = []models.AppFileFields{
+				{Path: "app.rb", Sha1: "app-sha", Size: 10},
+				{Path: "Gemfile", Sha1: "gemfile-sha", Size: 20},
+			}
+		})
+
+		It("returns the local files the cloud controller does not already have", func() {
+			appBitsRepo.GetApplicationFilesReturns([]resources.AppFileResource{{Path: "Gemfile", Sha1: "gemfile-sha", Size: 20}}, nil)
+
+			diff, err := actor.DiffApp(app, appParams, localFiles)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(appBitsRepo.GetApplicationFilesArgsForCall(0)).To(Equal([]resources.AppFileResource{
+				{Path: "app.rb", Sha1: "app-sha", Size: 10},
+				{Path: "Gemfile", Sha1: "gemfile-sha", Size: 20},
+			}))
+			Expect(diff.FilesToUpload).To(Equal([]models.AppFileFields{{Path: "app.rb", Sha1: "app-sha", Size: 10}}))
+			Expect(diff.MatchedFiles).To(HaveLen(1))
+		})
+
+		It("does not upload anything", func() {
+			_, err := actor.DiffApp(app, appParams, localFiles)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(appBitsRepo.UploadBitsCallCount()).To(Equal(0))
+		})
+
+		Context("when matching the files fails", func() {
+			BeforeEach(func() {
+				appBitsRepo.GetApplicationFilesReturns(nil, errors.New("match-error"))
+			})
+
+			It("returns the error", func() {
+				_, err := actor.DiffApp(app, appParams, localFiles)
+				Expect(err).To(MatchError("match-error"))
+			})
+		})
+
+		Context("when there are no local files", func() {
+			It("does not contact the cloud controller", func() {
+				diff, err := actor.DiffApp(app, appParams, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(appBitsRepo.GetApplicationFilesCallCount()).To(Equal(0))
+				Expect(diff.FilesToUpload).To(BeEmpty())
+			})
+		})
+
+		It("returns the environment variables that would be added or changed", func() {
+			app.EnvironmentVars = map[string]interface{}{
+				"UNCHANGED": "same",
+				"CHANGED":   "old",
+				"NUMBER":    float64(1),
+				"KEPT":      "not in manifest",
+			}
+			appParams.EnvironmentVars = &map[string]interface{}{
+				"UNCHANGED": "same",
+				"CHANGED":   "new",
+				"NUMBER":    1,
+				"B_NEW":     "value",
+				"A_NEW":     "value",
+			}
+
+			diff, err := actor.DiffApp(app, appParams, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(diff.EnvVarsAdded).To(Equal([]string{"A_NEW", "B_NEW"}))
+			Expect(diff.EnvVarsChanged).To(Equal([]string{"CHANGED"}))
+		})
+	})
+
+	Describe("NewDryRunRouteActor", func() {
+		var (
+			routeRepo    *apifakes.FakeRouteRepository
+			domainRepo   *apifakes.FakeDomainRepository
+			app          models.Application
+			domain       models.DomainFields
+			routeActor   actors.RouteActor
+			routeChanges *actors.RouteChanges
+		)
+
+		BeforeEach(func() {
+			routeRepo = new(apifakes.FakeRouteRepository)
+			domainRepo = new(apifakes.FakeDomainRepository)
+			domain = models.DomainFields{GUID: "domain-guid", Name: "example.com"}
+
+			app = models.Application{}
+			app.GUID = "app-guid"
+			app.Name = "my-app"
+			app.Routes = []models.RouteSummary{
+				{GUID: "old-route-guid", Host: "old", Domain: domain},
+			}
+		})
+
+		JustBeforeEach(func() {
+			routeActor, routeChanges = actors.NewDryRunRouteActor(routeRepo, domainRepo, app)
+		})
+
+		Context("when the route does not exist", func() {
+			BeforeEach(func() {
+				routeRepo.FindReturns(models.Route{}, cferrors.NewModelNotFoundError("Route", "new.example.com"))
+			})
+
+			It("records the route as created and bound without creating it", func() {
+				route, err := routeActor.FindOrCreateRoute("new", domain, "", 0, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = routeActor.BindRoute(app, route)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(routeRepo.CreateCallCount()).To(Equal(0))
+				Expect(routeRepo.BindCallCount()).To(Equal(0))
+				Expect(routeChanges.Created).To(Equal([]string{"new.example.com"}))
+				Expect(routeChanges.Bound).To(Equal([]string{"new.example.com"}))
+			})
+		})
+
+		Context("when the route exists but is not bound to the app", func() {
+			BeforeEach(func() {
+				routeRepo.FindReturns(models.Route{GUID: "existing-route-guid", Host: "existing", Domain: domain}, nil)
+			})
+
+			It("only records the route as bound", func() {
+				route, err := routeActor.FindOrCreateRoute("existing", domain, "", 0, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = routeActor.BindRoute(app, route)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(routeChanges.Created).To(BeEmpty())
+				Expect(routeChanges.Bound).To(Equal([]string{"existing.example.com"}))
+			})
+		})
+
+		It("records the app's routes as unbound without unbinding them", func() {
+			err := routeActor.UnbindAll(app)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(routeRepo.UnbindCallCount()).To(Equal(0))
+			Expect(routeChanges.Unbound).To(Equal([]string{"old.example.com"}))
+		})
+	})
+})
@@ -25,6 +25,7 @@ var _ = Describe("Push Actor", func() {
 		appFiles     *appfilesfakes.FakeAppFiles
 		fakezipper   *appfilesfakes.FakeZipper
 		routeActor   *actorsfakes.FakeRouteActor
+		uploadStates *actorsfakes.FakeUploadStateStore
 		actor        actors.PushActor
 		fixturesDir  string
 		appDir       string
@@ -37,7 +38,8 @@ var _ = Describe("Push Actor", func() {
 		appFiles = new(appfilesfakes.FakeAppFiles)
 		fakezipper = new(appfilesfakes.FakeZipper)
 		routeActor = new(actorsfakes.FakeRouteActor)
-		actor = actors.NewPushActor(appBitsRepo, fakezipper, appFiles, routeActor)
+		uploadStates = new(actorsfakes.FakeUploadStateStore)
+		actor = actors.NewPushActor(appBitsRepo, fakezipper, appFiles, routeActor, uploadStates)
 		fixturesDir = filepath.Join("..", "..", "fixtures", "applications")
 		allFiles = []models.AppFileFields{
 			{Path: "example-app/.cfignore"},
@@ -51,8 +53,6 @@ var _ = Describe("Push Actor", func() {
 	})
 
 	Describe("GatherFiles", func() {
-		var tmpDir string
-
 		BeforeEach(func() {
 			presentFiles = []resources.AppFileResource{
 				{Path: "example-app/ignore-me"},
@@ -60,13 +60,6 @@ var _ = Describe("Push Actor", func() {
 
 			appDir = filepath.Join(fixturesDir, "example-app.zip")
 			appBitsRepo.GetApplicationFilesReturns(presentFiles, nil)
-			var err error
-			tmpDir, err = ioutil.TempDir("", "gather-files")
-			Expect(err).NotTo(HaveOccurred())
-		})
-
-		AfterEach(func() {
-			os.RemoveAll(tmpDir)
 		})
 
 		Context("when we cannot reach CC", func() {
@@ -78,40 +71,62 @@ var _ = Describe("Push Actor", func() {
 			})
 
 			It("returns an error if we cannot reach the cc", func() {
-				_, _, err := actor.GatherFiles(allFiles, appDir, tmpDir)
+				_, _, err := actor.GatherFiles("app-guid", allFiles, appDir)
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(Equal(expectedErr))
 			})
 		})
 
-		Context("when we cannot copy the app files", func() {
-			var expectedErr error
+		It("does not ask the cc to match symbolic links", func() {
+			localFiles := append([]models.AppFileFields{{Path: "example-app/link", Sha1: "link-sha", Symlink: true}}, allFiles...)
+			_, filesToUpload, err := actor.GatherFiles("app-guid", localFiles, fixturesDir)
+			Expect(err).NotTo(HaveOccurred())
 
-			BeforeEach(func() {
-				expectedErr = errors.New("error")
-				appFiles.CopyFilesReturns(expectedErr)
-			})
+			matchedFiles := appBitsRepo.GetApplicationFilesArgsForCall(0)
+			Expect(matchedFiles).To(HaveLen(len(allFiles)))
+			for _, file := range matchedFiles {
+				Expect(file.Path).NotTo(Equal("example-app/link"))
+			}
 
-			It("returns an error", func() {
-				_, _, err := actor.GatherFiles(allFiles, appDir, tmpDir)
-				Expect(err).To(HaveOccurred())
-				Expect(err).To(Equal(expectedErr))
-			})
+			Expect(filesToUpload[0]).To(Equal(localFiles[0]))
+		})
+
+		It("remembers the resource matching results for the app", func() {
+			_, _, err := actor.GatherFiles("app-guid", allFiles, fixturesDir)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(uploadStates.SaveCallCount()).To(Equal(1))
+			appGUID, state := uploadStates.SaveArgsForCall(0)
+			Expect(appGUID).To(Equal("app-guid"))
+			Expect(state.FilesFingerprint).NotTo(BeEmpty())
+			Expect(state.MatchedFiles).To(Equal([]resources.AppFileResource{
+				{Path: "example-app/ignore-me"},
+			}))
 		})
 
-		Context("when using .cfignore", func() {
+		Context("when the app has an upload state for the same files", func() {
 			BeforeEach(func() {
-				appDir = filepath.Join(fixturesDir, "exclude-a-default-cfignore")
-				// Ignore app files for this test as .cfignore is not one of them
-				appBitsRepo.GetApplicationFilesReturns(nil, nil)
+				_, _, err := actor.GatherFiles("app-guid", allFiles, fixturesDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, state := uploadStates.SaveArgsForCall(0)
+				uploadStates.LoadReturns(state, nil)
+			})
+
+			It("reuses the saved matching results instead of asking the cc again", func() {
+				remoteFiles, _, err := actor.GatherFiles("app-guid", allFiles, fixturesDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appBitsRepo.GetApplicationFilesCallCount()).To(Equal(1))
+				Expect(remoteFiles).To(HaveLen(1))
+				Expect(remoteFiles[0].Path).To(Equal("example-app/ignore-me"))
 			})
 
-			It("copies the .cfignore file to the upload directory", func() {
-				_, _, err := actor.GatherFiles(allFiles, appDir, tmpDir)
+			It("matches the files again when the local files have changed", func() {
+				_, _, err := actor.GatherFiles("app-guid", allFiles[1:], fixturesDir)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, err = os.Stat(filepath.Join(tmpDir, ".cfignore"))
-				Expect(os.IsNotExist(err)).To(BeFalse())
+				Expect(appBitsRepo.GetApplicationFilesCallCount()).To(Equal(2))
 			})
 		})
 
@@ -125,7 +140,7 @@ var _ = Describe("Push Actor", func() {
 
 			expectedFileMode := fmt.Sprintf("%#o", info.Mode())
 
-			actualFiles, _, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+			actualFiles, _, err := actor.GatherFiles("app-guid", allFiles, fixturesDir)
 			Expect(err).NotTo(HaveOccurred())
 
 			expectedFiles := []resources.AppFileResource{
@@ -148,7 +163,7 @@ var _ = Describe("Push Actor", func() {
 
 			expectedFileMode := fmt.Sprintf("%#o", info.Mode()|0700)
 
-			actualFiles, _, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+			actualFiles, _, err := actor.GatherFiles("app-guid", allFiles, fixturesDir)
 			Expect(err).NotTo(HaveOccurred())
 
 			expectedFiles := []resources.AppFileResource{
@@ -166,13 +181,7 @@ var _ = Describe("Push Actor", func() {
 				appBitsRepo.GetApplicationFilesReturns([]resources.AppFileResource{}, nil)
 			})
 
-			It("returns true for hasFileToUpload", func() {
-				_, hasFileToUpload, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
-				Expect(err).NotTo(HaveOccurred())
-				Expect(hasFileToUpload).To(BeTrue())
-			})
-
-			It("copies all local files to the upload dir", func() {
+			It("returns all local files as files to upload", func() {
 				expectedFiles := []models.AppFileFields{
 					{Path: "example-app/.cfignore"},
 					{Path: "example-app/app.rb"},
@@ -182,14 +191,9 @@ var _ = Describe("Push Actor", func() {
 					{Path: "example-app/ignore-me"},
 					{Path: "example-app/manifest.yml"},
 				}
-				_, _, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+				_, filesToUpload, err := actor.GatherFiles("app-guid", allFiles, fixturesDir)
 				Expect(err).NotTo(HaveOccurred())
-
-				Expect(appFiles.CopyFilesCallCount()).To(Equal(1))
-				filesToUpload, fromDir, toDir := appFiles.CopyFilesArgsForCall(0)
 				Expect(filesToUpload).To(Equal(expectedFiles))
-				Expect(fromDir).To(Equal(fixturesDir))
-				Expect(toDir).To(Equal(tmpDir))
 			})
 		})
 
@@ -204,13 +208,7 @@ var _ = Describe("Push Actor", func() {
 				appBitsRepo.GetApplicationFilesReturns(remoteFiles, nil)
 			})
 
-			It("returns true for hasFileToUpload", func() {
-				_, hasFileToUpload, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
-				Expect(err).NotTo(HaveOccurred())
-				Expect(hasFileToUpload).To(BeTrue())
-			})
-
-			It("copies unmatched local files to the upload dir", func() {
+			It("returns the unmatched local files as files to upload", func() {
 				expectedFiles := []models.AppFileFields{
 					{Path: "example-app/.cfignore"},
 					{Path: "example-app/app.rb"},
@@ -219,14 +217,9 @@ var _ = Describe("Push Actor", func() {
 					{Path: "example-app/Gemfile.lock"},
 					{Path: "example-app/ignore-me"},
 				}
-				_, _, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+				_, filesToUpload, err := actor.GatherFiles("app-guid", allFiles, fixturesDir)
 				Expect(err).NotTo(HaveOccurred())
-
-				Expect(appFiles.CopyFilesCallCount()).To(Equal(1))
-				filesToUpload, fromDir, toDir := appFiles.CopyFilesArgsForCall(0)
 				Expect(filesToUpload).To(Equal(expectedFiles))
-				Expect(fromDir).To(Equal(fixturesDir))
-				Expect(toDir).To(Equal(tmpDir))
 			})
 		})
 
@@ -245,27 +238,236 @@ var _ = Describe("Push Actor", func() {
 				appBitsRepo.GetApplicationFilesReturns(remoteFiles, nil)
 			})
 
-			It("returns false for hasFileToUpload", func() {
-				_, hasFileToUpload, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
+			It("returns no files to upload", func() {
+				_, filesToUpload, err := actor.GatherFiles("app-guid", allFiles, fixturesDir)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(hasFileToUpload).To(BeFalse())
+				Expect(filesToUpload).To(BeEmpty())
 			})
+		})
+	})
 
-			It("copies nothing to the upload dir", func() {
-				_, _, err := actor.GatherFiles(allFiles, fixturesDir, tmpDir)
-				Expect(err).NotTo(HaveOccurred())
+	Describe("UploadApp", func() {
+		It("uploads the bits and clears the app's upload state", func() {
+			err := actor.UploadApp("app-guid", nil, presentFiles)
+			Expect(err).NotTo(HaveOccurred())
 
-				Expect(appFiles.CopyFilesCallCount()).To(Equal(1))
-				filesToUpload, fromDir, toDir := appFiles.CopyFilesArgsForCall(0)
-				Expect(filesToUpload).To(BeEmpty())
-				Expect(fromDir).To(Equal(fixturesDir))
-				Expect(toDir).To(Equal(tmpDir))
+			Expect(appBitsRepo.UploadBitsCallCount()).To(Equal(1))
+			Expect(uploadStates.DeleteCallCount()).To(Equal(1))
+			Expect(uploadStates.DeleteArgsForCall(0)).To(Equal("app-guid"))
+		})
+
+		Context("when uploading the bits fails", func() {
+			BeforeEach(func() {
+				appBitsRepo.UploadBitsReturns(errors.New("upload-error"))
+			})
+
+			It("keeps the upload state so the push can be resumed", func() {
+				err := actor.UploadApp("app-guid", nil, presentFiles)
+				Expect(err).To(MatchError("upload-error"))
+				Expect(uploadStates.DeleteCallCount()).To(Equal(0))
+			})
+
+			Context("when the upload state has cached matched files", func() {
+				BeforeEach(func() {
+					uploadStates.LoadReturns(actors.UploadState{
+						FilesFingerprint: "some-fingerprint",
+						MatchedFiles:     []resources.AppFileResource{{Path: "some-path"}},
+					}, nil)
+				})
+
+				It("clears the matched files so they are matched again on the next push", func() {
+					err := actor.UploadApp("app-guid", nil, presentFiles)
+					Expect(err).To(MatchError("upload-error"))
+
+					Expect(uploadStates.SaveCallCount()).To(Equal(1))
+					appGUID, state := uploadStates.SaveArgsForCall(0)
+					Expect(appGUID).To(Equal("app-guid"))
+					Expect(state.FilesFingerprint).To(BeEmpty())
+					Expect(state.MatchedFiles).To(BeNil())
+				})
 			})
 		})
 	})
 
-	Describe("UploadApp", func() {
-		It("Simply delegates to the UploadApp function on the app bits repo, which is not worth testing", func() {})
+	Describe("UploadAppInChunks", func() {
+		var zipFile *os.File
+
+		BeforeEach(func() {
+			var err error
+			zipFile, err = ioutil.TempFile("", "chunked-upload")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = zipFile.WriteString("0123456789")
+			Expect(err).NotTo(HaveOccurred())
+
+			presentFiles = []resources.AppFileResource{
+				{Path: "example-app/ignore-me"},
+			}
+
+			appBitsRepo.ChunkedUploadSupportedReturns(true, nil)
+		})
+
+		AfterEach(func() {
+			zipFile.Close()
+			os.Remove(zipFile.Name())
+		})
+
+		It("uploads the zip file in chunks of at most the given size", func() {
+			err := actor.UploadAppInChunks("app-guid", zipFile, presentFiles, 4, 2)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(appBitsRepo.UploadBitsChunkCallCount()).To(Equal(3))
+
+			uploaded := map[int]string{}
+			for i := 0; i < appBitsRepo.UploadBitsChunkCallCount(); i++ {
+				appGUID, chunk, body := appBitsRepo.UploadBitsChunkArgsForCall(i)
+				Expect(appGUID).To(Equal("app-guid"))
+
+				contents, err := ioutil.ReadAll(body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(int64(len(contents))).To(Equal(chunk.Size))
+				uploaded[chunk.Index] = string(contents)
+			}
+
+			Expect(uploaded).To(Equal(map[int]string{0: "0123", 1: "4567", 2: "89"}))
+		})
+
+		It("completes the upload with every chunk and the present files", func() {
+			err := actor.UploadAppInChunks("app-guid", zipFile, presentFiles, 4, 2)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(appBitsRepo.CompleteChunkedUploadCallCount()).To(Equal(1))
+			appGUID, chunks, files := appBitsRepo.CompleteChunkedUploadArgsForCall(0)
+			Expect(appGUID).To(Equal("app-guid"))
+			Expect(files).To(Equal(presentFiles))
+			Expect(chunks).To(Equal([]resources.AppBitsChunkResource{
+				{Index: 0, Offset: 0, Size: 4, Sha1: "c4b5c86bd577da3d93fea7c89cba61c78b48e589"},
+				{Index: 1, Offset: 4, Size: 4, Sha1: "83787f060a59493aefdcd4b2369990e7303e186e"},
+				{Index: 2, Offset: 8, Size: 2, Sha1: "16b06bd9b738835e2d134fe8d596e9ab0086a985"},
+			}))
+		})
+
+		It("records every uploaded chunk and clears the upload state once complete", func() {
+			err := actor.UploadAppInChunks("app-guid", zipFile, presentFiles, 4, 2)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(uploadStates.SaveCallCount()).To(Equal(3))
+			appGUID, state := uploadStates.SaveArgsForCall(2)
+			Expect(appGUID).To(Equal("app-guid"))
+			Expect(state.UploadedChunks).To(HaveLen(3))
+
+			Expect(uploadStates.DeleteCallCount()).To(Equal(1))
+			Expect(uploadStates.DeleteArgsForCall(0)).To(Equal("app-guid"))
+		})
+
+		Context("when some chunks were uploaded by a previous attempt", func() {
+			BeforeEach(func() {
+				uploadStates.LoadReturns(actors.UploadState{
+					UploadedChunks: []resources.AppBitsChunkResource{
+						{Index: 0, Offset: 0, Size: 4, Sha1: "c4b5c86bd577da3d93fea7c89cba61c78b48e589"},
+						{Index: 1, Offset: 4, Size: 4, Sha1: "stale-sha"},
+					},
+				}, nil)
+			})
+
+			It("only uploads the chunks that have not been uploaded with the same contents", func() {
+				err := actor.UploadAppInChunks("app-guid", zipFile, presentFiles, 4, 2)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appBitsRepo.UploadBitsChunkCallCount()).To(Equal(2))
+				indexes := []int{}
+				for i := 0; i < appBitsRepo.UploadBitsChunkCallCount(); i++ {
+					_, chunk, _ := appBitsRepo.UploadBitsChunkArgsForCall(i)
+					indexes = append(indexes, chunk.Index)
+				}
+				Expect(indexes).To(ConsistOf(1, 2))
+
+				_, chunks, _ := appBitsRepo.CompleteChunkedUploadArgsForCall(0)
+				Expect(chunks).To(HaveLen(3))
+			})
+		})
+
+		Context("when uploading a chunk fails", func() {
+			BeforeEach(func() {
+				appBitsRepo.UploadBitsChunkReturns(errors.New("upload-error"))
+			})
+
+			It("stops uploading, returns the error and does not complete the upload", func() {
+				err := actor.UploadAppInChunks("app-guid", zipFile, presentFiles, 4, 1)
+				Expect(err).To(MatchError("upload-error"))
+
+				Expect(appBitsRepo.UploadBitsChunkCallCount()).To(BeNumerically("<", 3))
+				Expect(appBitsRepo.CompleteChunkedUploadCallCount()).To(Equal(0))
+				Expect(uploadStates.DeleteCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when completing the upload fails", func() {
+			BeforeEach(func() {
+				uploadStates.LoadReturns(actors.UploadState{
+					FilesFingerprint: "some-fingerprint",
+					MatchedFiles:     []resources.AppFileResource{{Path: "some-path"}},
+					UploadedChunks: []resources.AppBitsChunkResource{
+						{Index: 0, Offset: 0, Size: 4, Sha1: "c4b5c86bd577da3d93fea7c89cba61c78b48e589"},
+					},
+				}, nil)
+				appBitsRepo.CompleteChunkedUploadReturns(errors.New("complete-error"))
+			})
+
+			It("clears the matched files but keeps the uploaded chunks", func() {
+				err := actor.UploadAppInChunks("app-guid", zipFile, presentFiles, 4, 2)
+				Expect(err).To(MatchError("complete-error"))
+
+				Expect(uploadStates.DeleteCallCount()).To(Equal(0))
+				saves := uploadStates.SaveCallCount()
+				Expect(saves).To(BeNumerically(">", 0))
+				_, state := uploadStates.SaveArgsForCall(saves - 1)
+				Expect(state.FilesFingerprint).To(BeEmpty())
+				Expect(state.MatchedFiles).To(BeNil())
+				Expect(state.UploadedChunks).To(HaveLen(1))
+			})
+		})
+
+		Context("when the Cloud Controller cannot take the bits in chunks", func() {
+			BeforeEach(func() {
+				appBitsRepo.ChunkedUploadSupportedReturns(false, nil)
+			})
+
+			It("uploads the zip file in a single request", func() {
+				err := actor.UploadAppInChunks("app-guid", zipFile, presentFiles, 4, 2)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(appBitsRepo.ChunkedUploadSupportedArgsForCall(0)).To(Equal("app-guid"))
+				Expect(appBitsRepo.UploadBitsChunkCallCount()).To(Equal(0))
+				Expect(appBitsRepo.CompleteChunkedUploadCallCount()).To(Equal(0))
+				Expect(appBitsRepo.UploadBitsCallCount()).To(Equal(1))
+				appGUID, uploadedZip, files := appBitsRepo.UploadBitsArgsForCall(0)
+				Expect(appGUID).To(Equal("app-guid"))
+				Expect(uploadedZip).To(Equal(zipFile))
+				Expect(files).To(Equal(presentFiles))
+			})
+		})
+
+		Context("when checking for chunked upload support fails", func() {
+			BeforeEach(func() {
+				appBitsRepo.ChunkedUploadSupportedReturns(false, errors.New("check-error"))
+			})
+
+			It("returns the error without uploading", func() {
+				err := actor.UploadAppInChunks("app-guid", zipFile, presentFiles, 4, 2)
+				Expect(err).To(MatchError("check-error"))
+				Expect(appBitsRepo.UploadBitsChunkCallCount()).To(Equal(0))
+				Expect(appBitsRepo.UploadBitsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the chunk size is not positive", func() {
+			It("returns an error", func() {
+				err := actor.UploadAppInChunks("app-guid", zipFile, presentFiles, 0, 2)
+				Expect(err).To(HaveOccurred())
+				Expect(appBitsRepo.UploadBitsChunkCallCount()).To(Equal(0))
+			})
+		})
 	})
 
 	Describe("ProcessPath", func() {
@@ -276,7 +478,7 @@ var _ = Describe("Push Actor", func() {
 
 		BeforeEach(func() {
 			zipper := &appfiles.ApplicationZipper{}
-			actor = actors.NewPushActor(appBitsRepo, zipper, appFiles, routeActor)
+			actor = actors.NewPushActor(appBitsRepo, zipper, appFiles, routeActor, uploadStates)
 		})
 
 		Context("when given a zip file", func() {
@@ -327,7 +529,7 @@ var _ = Describe("Push Actor", func() {
 				e := errors.New("some-error")
 				fakezipper.UnzipReturns(e)
 				fakezipper.IsZipFileReturns(true)
-				actor = actors.NewPushActor(appBitsRepo, fakezipper, appFiles, routeActor)
+				actor = actors.NewPushActor(appBitsRepo, fakezipper, appFiles, routeActor, uploadStates)
 
 				f := func(_ string) error {
 					return nil
@@ -458,6 +660,368 @@ var _ = Describe("Push Actor", func() {
 				})
 			})
 		})
+
+		Context("when 'random-route-strategy' is provided", func() {
+			var strategy string
+
+			BeforeEach(func() {
+				appName := "my-app"
+				apps = []models.AppParams{
+					{
+						Name:                &appName,
+						UseRandomRoute:      true,
+						RandomRouteStrategy: &strategy,
+					},
+				}
+			})
+
+			Context("and it is a known strategy", func() {
+				BeforeEach(func() {
+					strategy = "uuid"
+				})
+
+				It("does not return an error", func() {
+					Expect(actor.ValidateAppParams(apps)).To(BeEmpty())
+				})
+			})
+
+			Context("and it is not a known strategy", func() {
+				BeforeEach(func() {
+					strategy = "dice"
+				})
+
+				It("returns an error", func() {
+					errs := actor.ValidateAppParams(apps)
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0].Error()).To(Equal("Application my-app has an invalid 'random-route-strategy' dice; it must be one of word, uuid, timestamp"))
+				})
+			})
+		})
+
+		Context("when 'buildpacks' is provided", func() {
+			BeforeEach(func() {
+				appName := "my-app"
+				apps = []models.AppParams{
+					{
+						Name:       &appName,
+						Buildpacks: []string{"go_buildpack", "binary_buildpack"},
+					},
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(actor.ValidateAppParams(apps)).To(BeEmpty())
+			})
+
+			Context("and 'buildpack' is provided", func() {
+				BeforeEach(func() {
+					buildpack := "some-buildpack"
+					apps[0].BuildpackURL = &buildpack
+				})
+
+				It("returns an error", func() {
+					errs := actor.ValidateAppParams(apps)
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0].Error()).To(Equal("Application my-app must not be configured with both 'buildpack' and 'buildpacks'"))
+				})
+			})
+
+			Context("and it combines 'default' with other buildpacks", func() {
+				BeforeEach(func() {
+					apps[0].Buildpacks = []string{"default", "binary_buildpack"}
+				})
+
+				It("returns an error", func() {
+					errs := actor.ValidateAppParams(apps)
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0].Error()).To(Equal("Application my-app must not combine 'default' with other 'buildpacks'"))
+				})
+			})
+		})
+
+		Context("when 'lifecycle' is provided", func() {
+			var lifecycle string
+
+			BeforeEach(func() {
+				appName := "my-app"
+				apps = []models.AppParams{
+					{
+						Name:      &appName,
+						Lifecycle: &lifecycle,
+					},
+				}
+			})
+
+			Context("and it is cnb", func() {
+				BeforeEach(func() {
+					lifecycle = "cnb"
+				})
+
+				It("does not return an error", func() {
+					Expect(actor.ValidateAppParams(apps)).To(BeEmpty())
+				})
+			})
+
+			Context("and it is not a known lifecycle", func() {
+				BeforeEach(func() {
+					lifecycle = "kpack"
+				})
+
+				It("returns an error", func() {
+					errs := actor.ValidateAppParams(apps)
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0].Error()).To(Equal("Application my-app has an invalid 'lifecycle' kpack; it must be 'buildpack' or 'cnb'"))
+				})
+			})
+		})
+
+		Context("when 'docker' is provided", func() {
+			BeforeEach(func() {
+				appName := "my-app"
+				dockerImage := "some-image"
+				apps = []models.AppParams{
+					{
+						Name:        &appName,
+						DockerImage: &dockerImage,
+					},
+				}
+			})
+
+			It("does not return an error", func() {
+				Expect(actor.ValidateAppParams(apps)).To(BeEmpty())
+			})
+
+			Context("and 'buildpack' is provided", func() {
+				BeforeEach(func() {
+					buildpack := "some-buildpack"
+					apps[0].BuildpackURL = &buildpack
+				})
+
+				It("returns an error", func() {
+					errs := actor.ValidateAppParams(apps)
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0].Error()).To(Equal("Application my-app must not be configured with both 'docker' and 'buildpack'"))
+				})
+			})
+
+			Context("and 'buildpacks' is provided", func() {
+				BeforeEach(func() {
+					apps[0].Buildpacks = []string{"some-buildpack"}
+				})
+
+				It("returns an error", func() {
+					errs := actor.ValidateAppParams(apps)
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0].Error()).To(Equal("Application my-app must not be configured with both 'docker' and 'buildpacks'"))
+				})
+			})
+
+			Context("and 'lifecycle' is provided", func() {
+				BeforeEach(func() {
+					lifecycle := "cnb"
+					apps[0].Lifecycle = &lifecycle
+				})
+
+				It("returns an error", func() {
+					errs := actor.ValidateAppParams(apps)
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0].Error()).To(Equal("Application my-app must not be configured with both 'docker' and 'lifecycle'"))
+				})
+			})
+
+			Context("and 'path' is provided", func() {
+				BeforeEach(func() {
+					path := "some-path"
+					apps[0].Path = &path
+				})
+
+				It("returns an error", func() {
+					errs := actor.ValidateAppParams(apps)
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0].Error()).To(Equal("Application my-app must not be configured with both 'docker' and 'path'"))
+				})
+			})
+
+			Context("and a username is provided without a password", func() {
+				BeforeEach(func() {
+					username := "some-user"
+					apps[0].DockerUsername = &username
+				})
+
+				It("returns an error", func() {
+					errs := actor.ValidateAppParams(apps)
+					Expect(errs).To(HaveLen(1))
+					Expect(errs[0].Error()).To(Equal("Application my-app must have a 'docker.password', or the environment variable CF_DOCKER_PASSWORD set, when configured with a docker username"))
+				})
+			})
+
+			Context("and a username and password are provided", func() {
+				BeforeEach(func() {
+					username := "some-user"
+					password := "some-password"
+					apps[0].DockerUsername = &username
+					apps[0].DockerPassword = &password
+				})
+
+				It("does not return an error", func() {
+					Expect(actor.ValidateAppParams(apps)).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("when docker credentials are provided without an image", func() {
+			BeforeEach(func() {
+				appName := "my-app"
+				username := "some-user"
+				password := "some-password"
+				apps = []models.AppParams{
+					{
+						Name:           &appName,
+						DockerUsername: &username,
+						DockerPassword: &password,
+					},
+				}
+			})
+
+			It("returns an error", func() {
+				errs := actor.ValidateAppParams(apps)
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Error()).To(Equal("Application my-app must have a 'docker.image' when configured with docker credentials"))
+			})
+		})
+
+		Context("when 'sidecars' are provided", func() {
+			var memory int64
+
+			BeforeEach(func() {
+				appName := "my-app"
+				memory = 256
+				apps = []models.AppParams{
+					{
+						Name:   &appName,
+						Memory: &memory,
+						Sidecars: []models.Sidecar{
+							{Name: "proxy", Command: "./proxy", ProcessTypes: []string{"web"}, MemoryInMB: 64},
+							{Name: "shipper", Command: "./ship-logs", ProcessTypes: []string{"web", "worker"}, MemoryInMB: 128},
+						},
+					},
+				}
+			})
+
+			It("does not return an error when their memory fits within the app memory", func() {
+				Expect(actor.ValidateAppParams(apps)).To(BeNil())
+			})
+
+			It("returns an error when their memory in a process type does not fit within the app memory", func() {
+				memory = 192
+				errs := actor.ValidateAppParams(apps)
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Error()).To(Equal("Application my-app has sidecars using 192M of memory in its web process, which must be less than the app memory of 192M"))
+			})
+
+			It("does not check their memory when the app memory is not set", func() {
+				apps[0].Memory = nil
+				Expect(actor.ValidateAppParams(apps)).To(BeNil())
+			})
+
+			It("returns an error when two sidecars have the same name", func() {
+				apps[0].Sidecars[1].Name = "proxy"
+				errs := actor.ValidateAppParams(apps)
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Error()).To(Equal("Application my-app has more than one sidecar named proxy"))
+			})
+		})
+
+		Context("when health checks are provided", func() {
+			var (
+				httpType string
+				endpoint string
+			)
+
+			BeforeEach(func() {
+				appName := "my-app"
+				httpType = "http"
+				endpoint = "/health"
+				invocationTimeout := 5
+				processType := "process"
+				apps = []models.AppParams{
+					{
+						Name:                         &appName,
+						HealthCheckType:              &httpType,
+						HealthCheckHTTPEndpoint:      &endpoint,
+						HealthCheckInvocationTimeout: &invocationTimeout,
+						Processes: []models.ProcessParams{
+							{Type: "worker", HealthCheckType: &processType},
+						},
+					},
+				}
+			})
+
+			It("does not return an error when they are valid", func() {
+				Expect(actor.ValidateAppParams(apps)).To(BeNil())
+			})
+
+			It("returns an error when a health check type is invalid", func() {
+				invalidType := "tcp"
+				apps[0].Processes[0].HealthCheckType = &invalidType
+				errs := actor.ValidateAppParams(apps)
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Error()).To(Equal("Application my-app has an invalid 'health-check-type' tcp for its worker process; it must be one of port, process, http, none"))
+			})
+
+			It("returns an error when an endpoint is given for a health check that is not http", func() {
+				httpType = "port"
+				errs := actor.ValidateAppParams(apps)
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Error()).To(Equal("Application my-app must have a 'health-check-type' of http for its web process when configured with a 'health-check-http-endpoint'"))
+			})
+
+			It("returns an error when the endpoint is not a path", func() {
+				endpoint = "health"
+				errs := actor.ValidateAppParams(apps)
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Error()).To(Equal("Application my-app has an invalid 'health-check-http-endpoint' health for its web process; it must be a path starting with /"))
+			})
+
+			It("returns an error when the invocation timeout is not positive", func() {
+				invocationTimeout := 0
+				apps[0].Processes[0].HealthCheckInvocationTimeout = &invocationTimeout
+				errs := actor.ValidateAppParams(apps)
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Error()).To(Equal("Application my-app has an invalid 'health-check-invocation-timeout' for its worker process; it must be a positive number of seconds"))
+			})
+
+			It("returns an error when a readiness check type is invalid", func() {
+				noneType := "none"
+				apps[0].ReadinessHealthCheckType = &noneType
+				errs := actor.ValidateAppParams(apps)
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Error()).To(Equal("Application my-app has an invalid 'readiness-health-check-type' none for its web process; it must be one of port, process, http"))
+			})
+
+			It("returns an error when a readiness endpoint is given for a readiness check that is not http", func() {
+				readinessEndpoint := "/ready"
+				apps[0].Processes[0].ReadinessHealthCheckHTTPEndpoint = &readinessEndpoint
+				errs := actor.ValidateAppParams(apps)
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Error()).To(Equal("Application my-app must have a 'readiness-health-check-type' of http for its worker process when configured with a 'readiness-health-check-http-endpoint'"))
+			})
+
+			It("returns an error when the readiness interval is not positive", func() {
+				interval := -1
+				apps[0].ReadinessHealthCheckInterval = &interval
+				errs := actor.ValidateAppParams(apps)
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Error()).To(Equal("Application my-app has an invalid 'readiness-health-check-interval' for its web process; it must be a positive number of seconds"))
+			})
+
+			It("returns an error when two processes have the same type", func() {
+				apps[0].Processes = append(apps[0].Processes, models.ProcessParams{Type: "worker"})
+				errs := actor.ValidateAppParams(apps)
+				Expect(errs).To(HaveLen(1))
+				Expect(errs[0].Error()).To(Equal("Application my-app has more than one process of type worker"))
+			})
+		})
 	})
 
 	Describe("MapManifestRoute", func() {
@@ -0,0 +1,112 @@
+package actors
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+)
+
+//go:generate counterfeiter . RenameChecker
+
+// RenameChecker finds the places outside the Cloud Controller that refer to
+// an org or space by name. Renaming the org or space does not change them, so
+// they are left pointing at the old name.
+type RenameChecker interface {
+	References(name string, appPath string) []string
+}
+
+type renameChecker struct {
+	pluginConfig pluginconfig.PluginConfiguration
+}
+
+// NewRenameChecker returns a checker that looks through the manifest of an
+// app and the commands of the installed plugins.
+func NewRenameChecker(pluginConfig pluginconfig.PluginConfiguration) RenameChecker {
+	return renameChecker{
+		pluginConfig: pluginConfig,
+	}
+}
+
+// References describes each place that mentions name as a whole word, in the
+// order manifests then plugins. appPath is the directory of the app, whose
+// manifest.yml or manifest.yaml is checked, or the manifest itself; when it
+// is empty the current directory is used, as push does.
+func (checker renameChecker) References(name string, appPath string) []string {
+	if name == "" {
+		return nil
+	}
+	pattern := regexp.MustCompile(`(^|[^\w.-])` + regexp.QuoteMeta(name) + `($|[^\w.-])`)
+
+	references := checker.manifestReferences(name, appPath, pattern)
+	return append(references, checker.pluginReferences(name, pattern)...)
+}
+
+func manifestCandidates(appPath string) []string {
+	if appPath == "" {
+		appPath = "."
+	}
+
+	info, err := os.Stat(appPath)
+	if err != nil {
+		return nil
+	}
+
+	if !info.IsDir() {
+		return []string{appPath}
+	}
+	return []string{
+		filepath.Join(appPath, "manifest.yml"),
+		filepath.Join(appPath, "manifest.yaml"),
+	}
+}
+
+func (checker renameChecker) manifestReferences(name string, appPath string, pattern *regexp.Regexp) []string {
+	references := []string{}
+	for _, path := range manifestCandidates(appPath) {
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+
+		scanner := bufio.NewScanner(file)
+		for line := 1; scanner.Scan(); line++ {
+			if pattern.MatchString(scanner.Text()) {
+				references = append(references, T("manifest {{.Path}} mentions {{.Name}} on line {{.Line}}",
+					map[string]interface{}{"Path": path, "Name": name, "Line": line}))
+			}
+		}
+		file.Close()
+	}
+	return references
+}
+
+func (checker renameChecker) pluginReferences(name string, pattern *regexp.Regexp) []string {
+	plugins := checker.pluginConfig.Plugins()
+	pluginNames := make([]string, 0, len(plugins))
+	for pluginName := range plugins {
+		pluginNames = append(pluginNames, pluginName)
+	}
+	sort.Strings(pluginNames)
+
+	references := []string{}
+	for _, pluginName := range pluginNames {
+		for _, command := range plugins[pluginName].Commands {
+			texts := []string{command.Name, command.Alias, command.HelpText, command.UsageDetails.Usage}
+			for _, option := range command.UsageDetails.Options {
+				texts = append(texts, option)
+			}
+
+			if pattern.MatchString(strings.Join(texts, "\n")) {
+				references = append(references, T("plugin {{.Plugin}} mentions {{.Name}} in its command {{.Command}}",
+					map[string]interface{}{"Plugin": pluginName, "Name": name, "Command": command.Name}))
+			}
+		}
+	}
+	return references
+}
@@ -0,0 +1,83 @@
+package actors_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig/pluginconfigfakes"
+	"code.cloudfoundry.org/cli/plugin"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RenameChecker", func() {
+	var (
+		tmpDir       string
+		pluginConfig *pluginconfigfakes.FakePluginConfiguration
+		checker      actors.RenameChecker
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "rename-references")
+		Expect(err).NotTo(HaveOccurred())
+
+		pluginConfig = new(pluginconfigfakes.FakePluginConfiguration)
+		checker = actors.NewRenameChecker(pluginConfig)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	It("finds nothing when there is no manifest and no plugins", func() {
+		Expect(checker.References("prod", tmpDir)).To(BeEmpty())
+	})
+
+	It("reports the manifest lines that mention the name as a whole word", func() {
+		manifest := "---\napplications:\n- name: prod-api\n  env:\n    ORG: prod\n    BACKUP_ORG: prod.old\n"
+		Expect(ioutil.WriteFile(filepath.Join(tmpDir, "manifest.yml"), []byte(manifest), 0600)).To(Succeed())
+
+		Expect(checker.References("prod", tmpDir)).To(Equal([]string{
+			"manifest " + filepath.Join(tmpDir, "manifest.yml") + " mentions prod on line 5",
+		}))
+	})
+
+	It("checks the manifest it is given instead of an app directory", func() {
+		manifestPath := filepath.Join(tmpDir, "production.yml")
+		Expect(ioutil.WriteFile(manifestPath, []byte("---\nenv:\n  ORG: prod\n"), 0600)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(tmpDir, "manifest.yml"), []byte("---\nenv:\n  ORG: prod\n"), 0600)).To(Succeed())
+
+		Expect(checker.References("prod", manifestPath)).To(Equal([]string{
+			"manifest " + manifestPath + " mentions prod on line 3",
+		}))
+	})
+
+	It("finds nothing when the app path does not exist", func() {
+		Expect(checker.References("prod", filepath.Join(tmpDir, "missing"))).To(BeEmpty())
+	})
+
+	It("reports the plugin commands that mention the name", func() {
+		pluginConfig.PluginsReturns(map[string]pluginconfig.PluginMetadata{
+			"deployer": {
+				Commands: []plugin.Command{
+					{Name: "deploy", HelpText: "Deploy to the staging space"},
+					{
+						Name: "promote",
+						UsageDetails: plugin.Usage{
+							Usage:   "cf promote APP",
+							Options: map[string]string{"to": "The space to promote to, prod by default"},
+						},
+					},
+				},
+			},
+		})
+
+		Expect(checker.References("prod", tmpDir)).To(Equal([]string{
+			"plugin deployer mentions prod in its command promote",
+		}))
+	})
+})
@@ -158,19 +158,26 @@ func (routeActor routeActor) FindPort(routeName string) (string, int, error) {
 	var err error
 	routeSlice := strings.Split(routeName, ":")
 	port := 0
+	if len(routeSlice) > 2 {
+		return "", 0, invalidPortError(routeName)
+	}
 	if len(routeSlice) == 2 {
 		port, err = strconv.Atoi(routeSlice[1])
-		if err != nil {
-			return "", 0, errors.New(T("Invalid port for route {{.RouteName}}",
-				map[string]interface{}{
-					"RouteName": routeName,
-				},
-			))
+		if err != nil || port < 1 || port > 65535 {
+			return "", 0, invalidPortError(routeName)
 		}
 	}
 	return routeSlice[0], port, nil
 }
 
+func invalidPortError(routeName string) error {
+	return errors.New(T("Invalid port for route {{.RouteName}}",
+		map[string]interface{}{
+			"RouteName": routeName,
+		},
+	))
+}
+
 func (routeActor routeActor) replaceDomain(routeWithoutPathAndPort string, domain string) (string, error) {
 	_, flagDomain, err := routeActor.FindDomain(domain)
 	if err != nil {
@@ -213,12 +220,15 @@ func (routeActor routeActor) FindAndBindRoute(routeName string, app models.Appli
 	}
 
 	if appParamsFromContext.UseRandomRoute && domain.RouterGroupType != tcp {
-		hostname = generator.NewWordGenerator().Babble()
+		hostname, err = randomHostname(appParamsFromContext.RandomRouteStrategy)
+		if err != nil {
+			return err
+		}
 	}
 
 	replaceHostname(domain.RouterGroupType, appParamsFromContext.Hosts, &hostname)
 
-	err = validateRoute(domain.Name, domain.RouterGroupType, port, path)
+	err = validateRoute(domain, hostname, port, path, appParamsFromContext.UseRandomRoute)
 	if err != nil {
 		return err
 	}
@@ -231,7 +241,45 @@ func (routeActor routeActor) FindAndBindRoute(routeName string, app models.Appli
 	return routeActor.BindRoute(app, route)
 }
 
-func validateRoute(routeName string, domainType string, port int, path string) error {
+func randomHostname(strategy *string) (string, error) {
+	var strategyName string
+	if strategy != nil {
+		strategyName = *strategy
+	}
+
+	wordGenerator, err := generator.NewGenerator(strategyName)
+	if err != nil {
+		return "", errors.New(T("Invalid random route strategy {{.Strategy}}; it must be one of {{.Strategies}}",
+			map[string]interface{}{
+				"Strategy":   strategyName,
+				"Strategies": strings.Join(generator.Strategies, ", "),
+			},
+		))
+	}
+
+	return strings.ToLower(wordGenerator.Babble()), nil
+}
+
+func validateRoute(domain models.DomainFields, hostname string, port int, path string, useRandomPort bool) error {
+	routeName := domain.Name
+	domainType := domain.RouterGroupType
+
+	if domainType == tcp && hostname != "" {
+		return fmt.Errorf(T("Host not allowed in TCP route {{.RouteName}}",
+			map[string]interface{}{
+				"RouteName": routeName,
+			},
+		))
+	}
+
+	if domainType == tcp && port == 0 && !useRandomPort {
+		return fmt.Errorf(T("Port required for TCP route {{.RouteName}}",
+			map[string]interface{}{
+				"RouteName": routeName,
+			},
+		))
+	}
+
 	if domainType == tcp && path != "" {
 		return fmt.Errorf(T("Path not allowed in TCP route {{.RouteName}}",
 			map[string]interface{}{
@@ -240,6 +288,14 @@ func validateRoute(routeName string, domainType string, port int, path string) e
 		))
 	}
 
+	if domain.Internal && path != "" {
+		return fmt.Errorf(T("Path not allowed in internal route {{.RouteName}}",
+			map[string]interface{}{
+				"RouteName": routeName,
+			},
+		))
+	}
+
 	if domainType == "" && port != 0 {
 		return fmt.Errorf(T("Port not allowed in HTTP route {{.RouteName}}",
 			map[string]interface{}{
@@ -511,6 +511,23 @@ var _ = Describe("Routes", func() {
 				Expect(err).To(HaveOccurred())
 			})
 		})
+
+		Context("when the port is out of range", func() {
+			It("returns an error", func() {
+				_, _, err := routeActor.FindPort("host.domain:65536")
+				Expect(err).To(MatchError("Invalid port for route host.domain:65536"))
+
+				_, _, err = routeActor.FindPort("host.domain:0")
+				Expect(err).To(MatchError("Invalid port for route host.domain:0"))
+			})
+		})
+
+		Context("when there is more than one port", func() {
+			It("returns an error", func() {
+				_, _, err := routeActor.FindPort("host.domain:1234:5678")
+				Expect(err).To(MatchError("Invalid port for route host.domain:1234:5678"))
+			})
+		})
 	})
 
 	Describe("FindAndBindRoute", func() {
@@ -875,6 +892,21 @@ var _ = Describe("Routes", func() {
 					})
 				})
 
+				Context("when a random route strategy is given", func() {
+					BeforeEach(func() {
+						strategy := "timestamp"
+						appParamsFromContext.RandomRouteStrategy = &strategy
+						routeName = "host.domain.com/path"
+					})
+
+					It("should replace the hostname with one made by that strategy", func() {
+						Expect(findAndBindRouteErr).NotTo(HaveOccurred())
+
+						actualHost, _, _, _ := fakeRouteRepository.FindArgsForCall(0)
+						Expect(actualHost).To(MatchRegexp(`^\d{8}-\d{6}-\d{3}$`))
+					})
+				})
+
 				Context("when --hostname flag is present", func() {
 					BeforeEach(func() {
 						appParamsFromContext = models.AppParams{
@@ -1059,6 +1091,44 @@ var _ = Describe("Routes", func() {
 				})
 			})
 
+			Context("contains a host", func() {
+				BeforeEach(func() {
+					routeName = "host.tcp-domain.com:3333"
+				})
+
+				It("returns an error", func() {
+					Expect(findAndBindRouteErr).To(MatchError("Host not allowed in TCP route tcp-domain.com"))
+					Expect(fakeRouteRepository.CreateCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("does not contain a port", func() {
+				BeforeEach(func() {
+					routeName = "tcp-domain.com"
+				})
+
+				It("returns an error", func() {
+					Expect(findAndBindRouteErr).To(MatchError("Port required for TCP route tcp-domain.com"))
+					Expect(fakeRouteRepository.CreateCallCount()).To(Equal(0))
+				})
+
+				Context("when the --random-route flag is provided", func() {
+					BeforeEach(func() {
+						appParamsFromContext = models.AppParams{UseRandomRoute: true}
+						fakeRouteRepository.CreateReturns(models.Route{GUID: "route-guid", Domain: tcpDomain}, nil)
+					})
+
+					It("creates a route with a random port", func() {
+						Expect(findAndBindRouteErr).NotTo(HaveOccurred())
+
+						_, actualDomain, _, actualPort, actualUseRandomPort := fakeRouteRepository.CreateArgsForCall(0)
+						Expect(actualDomain).To(Equal(tcpDomain))
+						Expect(actualPort).To(Equal(0))
+						Expect(actualUseRandomPort).To(BeTrue())
+					})
+				})
+			})
+
 			Context("does not contain a path", func() {
 				BeforeEach(func() {
 					routeName = "tcp-domain.com:3333"
@@ -1119,5 +1189,58 @@ var _ = Describe("Routes", func() {
 				})
 			})
 		})
+
+		Context("when the route is on an internal domain", func() {
+			var internalDomain models.DomainFields
+
+			BeforeEach(func() {
+				internalDomain = models.DomainFields{
+					Name:     "apps.internal",
+					GUID:     "internal-domain-guid",
+					Internal: true,
+				}
+				domainNotFoundError := cferrors.NewModelNotFoundError("Domain", "some-domain.com")
+
+				fakeDomainRepository.FindPrivateByNameReturns(models.DomainFields{}, domainNotFoundError)
+				fakeDomainRepository.FindSharedByNameStub = func(name string) (models.DomainFields, error) {
+					if name == "apps.internal" {
+						return internalDomain, nil
+					}
+					return models.DomainFields{}, domainNotFoundError
+				}
+			})
+
+			Context("contains a path", func() {
+				BeforeEach(func() {
+					routeName = "host.apps.internal/path"
+				})
+
+				It("returns an error", func() {
+					Expect(findAndBindRouteErr).To(MatchError("Path not allowed in internal route apps.internal"))
+					Expect(fakeRouteRepository.CreateCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("does not contain a path", func() {
+				BeforeEach(func() {
+					routeName = "host.apps.internal"
+
+					fakeRouteRepository.FindReturns(models.Route{}, cferrors.NewModelNotFoundError("Route", "some-route"))
+					fakeRouteRepository.CreateReturns(models.Route{GUID: "route-guid", Domain: internalDomain}, nil)
+				})
+
+				It("creates and binds the route", func() {
+					Expect(findAndBindRouteErr).NotTo(HaveOccurred())
+
+					Expect(fakeRouteRepository.CreateCallCount()).To(Equal(1))
+					actualHost, actualDomain, actualPath, _, _ := fakeRouteRepository.CreateArgsForCall(0)
+					Expect(actualHost).To(Equal("host"))
+					Expect(actualDomain).To(Equal(internalDomain))
+					Expect(actualPath).To(Equal(""))
+
+					Expect(fakeRouteRepository.BindCallCount()).To(Equal(1))
+				})
+			})
+		})
 	})
 })
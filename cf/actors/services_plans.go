@@ -2,6 +2,9 @@ package actors
 
 import (
 	"fmt"
+	"path"
+	"strings"
+	"sync"
 
 	"code.cloudfoundry.org/cli/cf/api/organizations"
 
@@ -19,6 +22,7 @@ type ServicePlanActor interface {
 	UpdateOrgForService(string, string, bool) error
 	UpdateSinglePlanForService(string, string, bool) error
 	UpdatePlanAndOrgForService(string, string, string, bool) error
+	UpdatePlansAndOrgsForService(string, []string, []string, bool) ([]ServiceAccessUpdate, error)
 }
 
 type ServiceAccess int
@@ -135,6 +139,136 @@ func (actor ServicePlanHandler) UpdatePlanAndOrgForService(serviceName, planName
 	return err
 }
 
+// serviceAccessConcurrency is how many plan visibility updates
+// UpdatePlansAndOrgsForService makes at the same time.
+const serviceAccessConcurrency = 5
+
+// ServiceAccessUpdate is the outcome of changing the access to one plan, for
+// one org or, when Org is empty, for all of them.
+type ServiceAccessUpdate struct {
+	Plan string
+	Org  string
+	// AlreadyPublic is set when the plan is public, so access for a single
+	// org was left as it is.
+	AlreadyPublic bool
+	Err           error
+}
+
+// UpdatePlansAndOrgsForService changes the access to every plan of the service
+// matched by one of planPatterns, which may be glob patterns as understood by
+// path.Match. With orgNames the access is changed for each of those orgs,
+// otherwise for all orgs. The updates are made concurrently and one result is
+// returned per plan and org; an error is only returned when the service cannot
+// be found or a pattern matches no plan.
+func (actor ServicePlanHandler) UpdatePlansAndOrgsForService(serviceName string, planPatterns []string, orgNames []string, setPlanVisibility bool) ([]ServiceAccessUpdate, error) {
+	service, err := actor.serviceBuilder.GetServiceByNameWithPlans(serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := matchPlans(service, planPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs := make([]models.Organization, len(orgNames))
+	orgErrs := make([]error, len(orgNames))
+	for i, orgName := range orgNames {
+		orgs[i], orgErrs[i] = actor.orgRepo.FindByName(orgName)
+	}
+
+	updates := []ServiceAccessUpdate{}
+	for _, plan := range plans {
+		if len(orgNames) == 0 {
+			updates = append(updates, ServiceAccessUpdate{Plan: plan.Name})
+			continue
+		}
+		for i, orgName := range orgNames {
+			updates = append(updates, ServiceAccessUpdate{Plan: plan.Name, Org: orgName, Err: orgErrs[i]})
+		}
+	}
+
+	plansByName := map[string]models.ServicePlanFields{}
+	for _, plan := range plans {
+		plansByName[plan.Name] = plan
+	}
+	orgsByName := map[string]models.Organization{}
+	for i, orgName := range orgNames {
+		orgsByName[orgName] = orgs[i]
+	}
+
+	var wg sync.WaitGroup
+	work := make(chan int)
+	for i := 0; i < serviceAccessConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range work {
+				update := &updates[index]
+				plan := plansByName[update.Plan]
+				switch {
+				case update.Org == "":
+					update.Err = actor.updateServicePlanAvailability(service.GUID, plan, setPlanVisibility)
+				case plan.Public:
+					update.AlreadyPublic = true
+				default:
+					update.Err = actor.updatePlanForOrg(plan, orgsByName[update.Org], setPlanVisibility)
+				}
+			}
+		}()
+	}
+
+	for i, update := range updates {
+		if update.Err == nil {
+			work <- i
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	return updates, nil
+}
+
+// matchPlans returns the plans of service whose names match one of patterns,
+// each plan once and in the order of the service.
+func matchPlans(service models.ServiceOffering, patterns []string) ([]models.ServicePlanFields, error) {
+	matched := make([]bool, len(service.Plans))
+	for _, pattern := range patterns {
+		found := false
+		for i, plan := range service.Plans {
+			isMatch, err := path.Match(pattern, plan.Name)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid plan pattern %s: %s", pattern, err.Error())
+			}
+			if isMatch {
+				matched[i] = true
+				found = true
+			}
+		}
+		if !found {
+			if strings.ContainsAny(pattern, "*?[") {
+				return nil, fmt.Errorf("No plans of service %s match %s", service.Label, pattern)
+			}
+			return nil, fmt.Errorf("The plan %s could not be found for service %s", pattern, service.Label)
+		}
+	}
+
+	plans := []models.ServicePlanFields{}
+	for i, plan := range service.Plans {
+		if matched[i] {
+			plans = append(plans, plan)
+		}
+	}
+	return plans, nil
+}
+
+func (actor ServicePlanHandler) updatePlanForOrg(plan models.ServicePlanFields, org models.Organization, setPlanVisibility bool) error {
+	if setPlanVisibility {
+		return actor.servicePlanVisibilityRepo.Create(plan.GUID, org.GUID)
+	}
+	return actor.deleteServicePlanVisibilities(map[string]string{"organization_guid": org.GUID, "service_plan_guid": plan.GUID})
+}
+
 func (actor ServicePlanHandler) UpdateSinglePlanForService(serviceName string, planName string, setPlanVisibility bool) error {
 	serviceOffering, err := actor.serviceBuilder.GetServiceByNameWithPlans(serviceName)
 	if err != nil {
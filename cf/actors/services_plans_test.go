@@ -493,4 +493,107 @@ var _ = Describe("Service Plans", func() {
 			})
 		})
 	})
+
+	Describe(".UpdatePlansAndOrgsForService", func() {
+		BeforeEach(func() {
+			serviceBuilder.GetServiceByNameWithPlansReturns(mixedService, nil)
+		})
+
+		It("returns an error if the service cannot be found", func() {
+			serviceBuilder.GetServiceByNameWithPlansReturns(models.ServiceOffering{}, errors.New("service was not found"))
+
+			_, err := actor.UpdatePlansAndOrgsForService("not-a-service", []string{"*"}, nil, true)
+			Expect(err).To(MatchError("service was not found"))
+		})
+
+		It("returns an error if a pattern matches no plan", func() {
+			_, err := actor.UpdatePlansAndOrgsForService("my-mixed-service", []string{"private-*", "gold-*"}, nil, true)
+			Expect(err).To(MatchError("No plans of service my-mixed-service match gold-*"))
+			Expect(servicePlanVisibilityRepo.CreateCallCount()).To(Equal(0))
+		})
+
+		It("makes the plans matching the patterns public when no orgs are given", func() {
+			updates, err := actor.UpdatePlansAndOrgsForService("my-mixed-service", []string{"private-*", "limited-service-plan"}, nil, true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(updates).To(Equal([]actors.ServiceAccessUpdate{
+				{Plan: "private-service-plan"},
+				{Plan: "limited-service-plan"},
+			}))
+			Expect(servicePlanRepo.UpdateCallCount()).To(Equal(2))
+		})
+
+		It("creates a visibility for each matched private plan and org", func() {
+			orgRepo.FindByNameStub = func(name string) (models.Organization, error) {
+				if name == "org-2" {
+					return org2, nil
+				}
+				return org1, nil
+			}
+
+			updates, err := actor.UpdatePlansAndOrgsForService("my-mixed-service", []string{"*-service-plan"}, []string{"org-1", "org-2"}, true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(updates).To(Equal([]actors.ServiceAccessUpdate{
+				{Plan: "public-service-plan", Org: "org-1", AlreadyPublic: true},
+				{Plan: "public-service-plan", Org: "org-2", AlreadyPublic: true},
+				{Plan: "private-service-plan", Org: "org-1"},
+				{Plan: "private-service-plan", Org: "org-2"},
+				{Plan: "limited-service-plan", Org: "org-1"},
+				{Plan: "limited-service-plan", Org: "org-2"},
+			}))
+
+			Expect(servicePlanVisibilityRepo.CreateCallCount()).To(Equal(4))
+			created := []string{}
+			for i := 0; i < 4; i++ {
+				planGUID, orgGUID := servicePlanVisibilityRepo.CreateArgsForCall(i)
+				created = append(created, planGUID+" "+orgGUID)
+			}
+			Expect(created).To(ConsistOf(
+				"private-service-plan-guid org-1-guid",
+				"private-service-plan-guid org-2-guid",
+				"limited-service-plan-guid org-1-guid",
+				"limited-service-plan-guid org-2-guid",
+			))
+		})
+
+		It("reports the updates that fail without stopping the others", func() {
+			orgRepo.FindByNameStub = func(name string) (models.Organization, error) {
+				if name == "not-an-org" {
+					return models.Organization{}, errors.NewModelNotFoundError("organization", name)
+				}
+				return org1, nil
+			}
+			servicePlanVisibilityRepo.CreateStub = func(planGUID, orgGUID string) error {
+				if planGUID == "limited-service-plan-guid" {
+					return errors.New("visibility already exists")
+				}
+				return nil
+			}
+
+			updates, err := actor.UpdatePlansAndOrgsForService("my-mixed-service", []string{"private-service-plan", "limited-service-plan"}, []string{"org-1", "not-an-org"}, true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(updates).To(HaveLen(4))
+			Expect(updates[0]).To(Equal(actors.ServiceAccessUpdate{Plan: "private-service-plan", Org: "org-1"}))
+			Expect(updates[1].Err).To(HaveOccurred())
+			Expect(updates[2].Err).To(MatchError("visibility already exists"))
+			Expect(updates[3].Err).To(HaveOccurred())
+			Expect(servicePlanVisibilityRepo.CreateCallCount()).To(Equal(2))
+		})
+
+		It("deletes the visibilities of the org when disabling access", func() {
+			servicePlanVisibilityRepo.SearchReturns([]models.ServicePlanVisibilityFields{limitedServicePlanVisibilityFields}, nil)
+
+			updates, err := actor.UpdatePlansAndOrgsForService("my-mixed-service", []string{"limited-service-plan"}, []string{"org-1"}, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updates).To(Equal([]actors.ServiceAccessUpdate{{Plan: "limited-service-plan", Org: "org-1"}}))
+
+			Expect(servicePlanVisibilityRepo.SearchArgsForCall(0)).To(Equal(map[string]string{
+				"organization_guid": "org-1-guid",
+				"service_plan_guid": "limited-service-plan-guid",
+			}))
+			Expect(servicePlanVisibilityRepo.DeleteArgsForCall(0)).To(Equal("limited-service-plan-visibility-guid"))
+		})
+	})
 })
@@ -0,0 +1,281 @@
+package actors
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/appinstances"
+	"code.cloudfoundry.org/cli/cf/api/applications"
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+const (
+	DefaultStagingTimeout    = 15 * time.Minute
+	DefaultStartupTimeout    = 5 * time.Minute
+	DefaultStartPollInterval = 5 * time.Second
+
+	// CrashLogLines is how many of the last log lines of crashed instances
+	// are kept to explain why an app did not start.
+	CrashLogLines = 20
+)
+
+// ErrStartupTimeout is returned when no instance of an app is running within
+// the startup timeout.
+var ErrStartupTimeout = errors.New("app startup timed out")
+
+// StartTimeouts bound how long starting an app may take. The staging timeout
+// counts from the last time staging made progress, such as logging a line;
+// the startup timeout counts from the end of staging until an instance runs,
+// so that a slow staging does not use up the time instances get to start.
+type StartTimeouts struct {
+	Staging time.Duration
+	Startup time.Duration
+}
+
+// StartTimeoutsFromEnv returns the default timeouts, overridden by the
+// CF_STAGING_TIMEOUT and CF_STARTUP_TIMEOUT environment variables, both in
+// minutes.
+func StartTimeoutsFromEnv() (StartTimeouts, error) {
+	timeouts := StartTimeouts{
+		Staging: DefaultStagingTimeout,
+		Startup: DefaultStartupTimeout,
+	}
+
+	for name, timeout := range map[string]*time.Duration{
+		"CF_STAGING_TIMEOUT": &timeouts.Staging,
+		"CF_STARTUP_TIMEOUT": &timeouts.Startup,
+	} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+
+		minutes, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return StartTimeouts{}, errors.New(T("invalid value for env var {{.Name}}\n{{.Err}}",
+				map[string]interface{}{"Name": name, "Err": err}))
+		}
+		*timeout = time.Duration(minutes) * time.Minute
+	}
+
+	return timeouts, nil
+}
+
+// InstanceCounts tallies the instances of an app by state while it starts.
+type InstanceCounts struct {
+	Running  int
+	Starting int
+	Flapping int
+	Down     int
+	Crashed  int
+	Total    int
+
+	// StartingDetails are the distinct details the starting instances
+	// report, sorted.
+	StartingDetails []string
+
+	// FailedIndexes are the indexes of the flapping and crashed instances.
+	FailedIndexes []int
+}
+
+func (counts InstanceCounts) Failed() bool {
+	return counts.Flapping > 0 || counts.Crashed > 0
+}
+
+// StartMonitor follows an app that is being started: first until its package
+// has staged, then until one of its instances is running. It replaces the
+// polling that was spread through the start command, so that every command
+// that starts apps waits the same way.
+type StartMonitor struct {
+	appRepo          applications.Repository
+	appInstancesRepo appinstances.Repository
+	logRepo          logs.Repository
+
+	Timeouts     StartTimeouts
+	PollInterval time.Duration
+
+	// lastStagingActivity holds the time.Time staging last made progress.
+	lastStagingActivity atomic.Value
+}
+
+func NewStartMonitor(appRepo applications.Repository, appInstancesRepo appinstances.Repository, logRepo logs.Repository, timeouts StartTimeouts) *StartMonitor {
+	return &StartMonitor{
+		appRepo:          appRepo,
+		appInstancesRepo: appInstancesRepo,
+		logRepo:          logRepo,
+		Timeouts:         timeouts,
+		PollInterval:     DefaultStartPollInterval,
+	}
+}
+
+// RecordStagingActivity notes that staging made progress, which holds off the
+// staging timeout. It is safe to call while WaitForStaging polls.
+func (monitor *StartMonitor) RecordStagingActivity() {
+	monitor.lastStagingActivity.Store(time.Now())
+}
+
+func (monitor *StartMonitor) timeSinceStagingActivity() time.Duration {
+	last, ok := monitor.lastStagingActivity.Load().(time.Time)
+	if !ok {
+		return 0
+	}
+	return time.Since(last)
+}
+
+// WaitForStaging polls the app until its package has staged or failed to
+// stage, or until staging has made no progress for the staging timeout. It
+// calls stateChanged whenever the package state of the app changes, and
+// returns the app as it last saw it: a package state other than STAGED or
+// FAILED means staging timed out. A zero staging timeout checks the app once.
+func (monitor *StartMonitor) WaitForStaging(app models.Application, stateChanged func(models.Application)) (models.Application, error) {
+	monitor.RecordStagingActivity()
+
+	lastState := ""
+	poll := func() error {
+		var err error
+		app, err = monitor.appRepo.GetApp(app.GUID)
+		if err != nil {
+			return err
+		}
+
+		if app.PackageState != lastState {
+			lastState = app.PackageState
+			stateChanged(app)
+		}
+		return nil
+	}
+
+	if monitor.Timeouts.Staging == 0 {
+		return app, poll()
+	}
+
+	for app.PackageState != "STAGED" && app.PackageState != "FAILED" && monitor.timeSinceStagingActivity() < monitor.Timeouts.Staging {
+		err := poll()
+		if err != nil {
+			return models.Application{}, err
+		}
+
+		time.Sleep(monitor.PollInterval)
+	}
+
+	return app, nil
+}
+
+// WaitForRunningInstance polls the instances of the app until one of them is
+// running or one of them has crashed or is flapping, calling progress with the
+// counts each time. Errors fetching the instances are passed to warn and the
+// instances are fetched again. It returns ErrStartupTimeout when no instance
+// runs within the startup timeout.
+func (monitor *StartMonitor) WaitForRunningInstance(app models.Application, progress func(InstanceCounts), warn func(error)) (InstanceCounts, error) {
+	timer := time.NewTimer(monitor.Timeouts.Startup)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			return InstanceCounts{}, ErrStartupTimeout
+		default:
+		}
+
+		counts, err := monitor.countInstances(app.GUID)
+		if err != nil {
+			warn(err)
+			time.Sleep(monitor.PollInterval)
+			continue
+		}
+
+		progress(counts)
+
+		if counts.Running > 0 || counts.Failed() {
+			return counts, nil
+		}
+
+		time.Sleep(monitor.PollInterval)
+	}
+}
+
+// CrashLogs returns the last log lines the given instances of the app wrote,
+// so that users can see why they crashed without looking through the logs
+// themselves.
+func (monitor *StartMonitor) CrashLogs(appGUID string, indexes []int) ([]string, error) {
+	messages, err := monitor.logRepo.RecentLogsFor(appGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return LastInstanceLogs(messages, indexes, time.Time{}), nil
+}
+
+// LastInstanceLogs returns the last CrashLogLines lines among messages that
+// the given instances wrote, leaving out staging and router logs. When until
+// is set, lines written after it are left out too, so that the logs of an
+// instance that crashed are not mixed up with those of its replacement.
+func LastInstanceLogs(messages []logs.Loggable, indexes []int, until time.Time) []string {
+	instances := map[string]bool{}
+	for _, index := range indexes {
+		instances[strconv.Itoa(index)] = true
+	}
+
+	lines := []string{}
+	for _, message := range messages {
+		if message.GetSourceName() == "STG" || message.GetSourceName() == "RTR" {
+			continue
+		}
+		if len(instances) > 0 && !instances[message.GetSourceInstance()] {
+			continue
+		}
+		if !until.IsZero() && message.ToEnvelope().Timestamp.After(until) {
+			continue
+		}
+		lines = append(lines, message.ToSimpleLog())
+	}
+
+	if len(lines) > CrashLogLines {
+		lines = lines[len(lines)-CrashLogLines:]
+	}
+
+	return lines
+}
+
+func (monitor *StartMonitor) countInstances(appGUID string) (InstanceCounts, error) {
+	instances, err := monitor.appInstancesRepo.GetInstances(appGUID)
+	if err != nil {
+		return InstanceCounts{}, err
+	}
+
+	counts := InstanceCounts{Total: len(instances)}
+	details := map[string]struct{}{}
+
+	for index, instance := range instances {
+		switch instance.State {
+		case models.InstanceRunning:
+			counts.Running++
+		case models.InstanceStarting:
+			counts.Starting++
+			if instance.Details != "" {
+				details[instance.Details] = struct{}{}
+			}
+		case models.InstanceFlapping:
+			counts.Flapping++
+			counts.FailedIndexes = append(counts.FailedIndexes, index)
+		case models.InstanceDown:
+			counts.Down++
+		case models.InstanceCrashed:
+			counts.Crashed++
+			counts.FailedIndexes = append(counts.FailedIndexes, index)
+		}
+	}
+
+	for detail := range details {
+		counts.StartingDetails = append(counts.StartingDetails, detail)
+	}
+	sort.Strings(counts.StartingDetails)
+
+	return counts, nil
+}
@@ -0,0 +1,250 @@
+package actors_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	. "code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/api/appinstances/appinstancesfakes"
+	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	"code.cloudfoundry.org/cli/cf/api/logs/logsfakes"
+	"code.cloudfoundry.org/cli/cf/models"
+	testlogs "code.cloudfoundry.org/cli/testhelpers/logs"
+	"github.com/cloudfoundry/loggregatorlib/logmessage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StartMonitor", func() {
+	var (
+		appRepo          *applicationsfakes.FakeRepository
+		appInstancesRepo *appinstancesfakes.FakeRepository
+		logRepo          *logsfakes.FakeRepository
+		monitor          *StartMonitor
+		app              models.Application
+	)
+
+	BeforeEach(func() {
+		appRepo = new(applicationsfakes.FakeRepository)
+		appInstancesRepo = new(appinstancesfakes.FakeRepository)
+		logRepo = new(logsfakes.FakeRepository)
+		monitor = NewStartMonitor(appRepo, appInstancesRepo, logRepo, StartTimeouts{
+			Staging: time.Second,
+			Startup: time.Second,
+		})
+		monitor.PollInterval = time.Millisecond
+
+		app = models.Application{}
+		app.GUID = "app-guid"
+	})
+
+	Describe("StartTimeoutsFromEnv", func() {
+		BeforeEach(func() {
+			os.Unsetenv("CF_STAGING_TIMEOUT")
+			os.Unsetenv("CF_STARTUP_TIMEOUT")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("CF_STAGING_TIMEOUT")
+			os.Unsetenv("CF_STARTUP_TIMEOUT")
+		})
+
+		It("returns the defaults when the env vars are not set", func() {
+			timeouts, err := StartTimeoutsFromEnv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(timeouts).To(Equal(StartTimeouts{Staging: DefaultStagingTimeout, Startup: DefaultStartupTimeout}))
+		})
+
+		It("reads the timeouts in minutes from the env vars", func() {
+			os.Setenv("CF_STAGING_TIMEOUT", "30")
+			os.Setenv("CF_STARTUP_TIMEOUT", "2")
+
+			timeouts, err := StartTimeoutsFromEnv()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(timeouts).To(Equal(StartTimeouts{Staging: 30 * time.Minute, Startup: 2 * time.Minute}))
+		})
+
+		It("returns an error naming the env var when its value is not a number", func() {
+			os.Setenv("CF_STARTUP_TIMEOUT", "soon")
+
+			_, err := StartTimeoutsFromEnv()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid value for env var CF_STARTUP_TIMEOUT"))
+		})
+	})
+
+	Describe("WaitForStaging", func() {
+		It("polls until the package has staged, reporting each change of state", func() {
+			appRepo.GetAppStub = func(guid string) (models.Application, error) {
+				stagingApp := models.Application{}
+				stagingApp.GUID = guid
+				stagingApp.PackageState = "PENDING"
+				if appRepo.GetAppCallCount() > 2 {
+					stagingApp.PackageState = "STAGED"
+				}
+				return stagingApp, nil
+			}
+
+			states := []string{}
+			stagedApp, err := monitor.WaitForStaging(app, func(app models.Application) {
+				states = append(states, app.PackageState)
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stagedApp.PackageState).To(Equal("STAGED"))
+			Expect(states).To(Equal([]string{"PENDING", "STAGED"}))
+			Expect(appRepo.GetAppArgsForCall(0)).To(Equal("app-guid"))
+		})
+
+		It("gives up once staging has made no progress for the staging timeout", func() {
+			monitor.Timeouts.Staging = 20 * time.Millisecond
+			pendingApp := app
+			pendingApp.PackageState = "PENDING"
+			appRepo.GetAppReturns(pendingApp, nil)
+
+			stagingApp, err := monitor.WaitForStaging(app, func(models.Application) {})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stagingApp.PackageState).To(Equal("PENDING"))
+		})
+
+		It("checks the app once when the staging timeout is zero", func() {
+			monitor.Timeouts.Staging = 0
+			pendingApp := app
+			pendingApp.PackageState = "PENDING"
+			appRepo.GetAppReturns(pendingApp, nil)
+
+			_, err := monitor.WaitForStaging(app, func(models.Application) {})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+		})
+
+		It("returns the error when the app cannot be read", func() {
+			appRepo.GetAppReturns(models.Application{}, errors.New("boom"))
+
+			_, err := monitor.WaitForStaging(app, func(models.Application) {})
+			Expect(err).To(MatchError("boom"))
+		})
+	})
+
+	Describe("WaitForRunningInstance", func() {
+		It("polls until an instance is running", func() {
+			appInstancesRepo.GetInstancesStub = func(guid string) ([]models.AppInstanceFields, error) {
+				if appInstancesRepo.GetInstancesCallCount() == 1 {
+					return []models.AppInstanceFields{
+						{State: models.InstanceStarting, Details: "pulling image"},
+						{State: models.InstanceDown},
+					}, nil
+				}
+				return []models.AppInstanceFields{
+					{State: models.InstanceRunning},
+					{State: models.InstanceStarting},
+				}, nil
+			}
+
+			reported := []InstanceCounts{}
+			counts, err := monitor.WaitForRunningInstance(app, func(counts InstanceCounts) {
+				reported = append(reported, counts)
+			}, func(error) {})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counts.Running).To(Equal(1))
+			Expect(reported).To(HaveLen(2))
+			Expect(reported[0].StartingDetails).To(Equal([]string{"pulling image"}))
+			Expect(reported[0].Down).To(Equal(1))
+		})
+
+		It("stops polling when an instance crashes, recording its index", func() {
+			appInstancesRepo.GetInstancesReturns([]models.AppInstanceFields{
+				{State: models.InstanceStarting},
+				{State: models.InstanceCrashed},
+				{State: models.InstanceFlapping},
+			}, nil)
+
+			counts, err := monitor.WaitForRunningInstance(app, func(InstanceCounts) {}, func(error) {})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counts.Failed()).To(BeTrue())
+			Expect(counts.FailedIndexes).To(Equal([]int{1, 2}))
+		})
+
+		It("warns about errors fetching the instances and tries again", func() {
+			appInstancesRepo.GetInstancesStub = func(guid string) ([]models.AppInstanceFields, error) {
+				if appInstancesRepo.GetInstancesCallCount() == 1 {
+					return nil, errors.New("instances unavailable")
+				}
+				return []models.AppInstanceFields{{State: models.InstanceRunning}}, nil
+			}
+
+			warnings := []error{}
+			_, err := monitor.WaitForRunningInstance(app, func(InstanceCounts) {}, func(err error) {
+				warnings = append(warnings, err)
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(Equal([]error{errors.New("instances unavailable")}))
+		})
+
+		It("returns ErrStartupTimeout when no instance runs within the startup timeout", func() {
+			monitor.Timeouts.Startup = 20 * time.Millisecond
+			appInstancesRepo.GetInstancesReturns([]models.AppInstanceFields{{State: models.InstanceStarting}}, nil)
+
+			_, err := monitor.WaitForRunningInstance(app, func(InstanceCounts) {}, func(error) {})
+			Expect(err).To(Equal(ErrStartupTimeout))
+		})
+	})
+
+	Describe("CrashLogs", func() {
+		var now time.Time
+
+		BeforeEach(func() {
+			now = time.Now()
+		})
+
+		It("returns the app logs of the given instances, leaving out staging and router logs", func() {
+			logRepo.RecentLogsForReturns([]logs.Loggable{
+				testlogs.NewLogMessage("Staging complete", "app-guid", "STG", "0", logmessage.LogMessage_OUT, now),
+				testlogs.NewLogMessage("GET / 502", "app-guid", "RTR", "1", logmessage.LogMessage_OUT, now),
+				testlogs.NewLogMessage("listening on 8080", "app-guid", "APP", "0", logmessage.LogMessage_OUT, now),
+				testlogs.NewLogMessage("panic: out of memory", "app-guid", "APP", "1", logmessage.LogMessage_ERR, now),
+			}, nil)
+
+			lines, err := monitor.CrashLogs("app-guid", []int{1})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lines).To(Equal([]string{"panic: out of memory"}))
+			Expect(logRepo.RecentLogsForArgsForCall(0)).To(Equal("app-guid"))
+		})
+
+		It("keeps only the last lines", func() {
+			messages := []logs.Loggable{}
+			for i := 0; i < CrashLogLines+5; i++ {
+				messages = append(messages, testlogs.NewLogMessage(fmt.Sprintf("line %d", i), "app-guid", "APP", "0", logmessage.LogMessage_OUT, now))
+			}
+			logRepo.RecentLogsForReturns(messages, nil)
+
+			lines, err := monitor.CrashLogs("app-guid", []int{0})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lines).To(HaveLen(CrashLogLines))
+			Expect(lines[0]).To(Equal("line 5"))
+		})
+
+		It("returns the error when the logs cannot be read", func() {
+			logRepo.RecentLogsForReturns(nil, errors.New("boom"))
+
+			_, err := monitor.CrashLogs("app-guid", []int{0})
+			Expect(err).To(MatchError("boom"))
+		})
+	})
+
+	Describe("LastInstanceLogs", func() {
+		It("leaves out the lines written after until", func() {
+			now := time.Now()
+			messages := []logs.Loggable{
+				testlogs.NewLogMessage("before the crash", "app-guid", "APP", "0", logmessage.LogMessage_OUT, now.Add(-time.Minute)),
+				testlogs.NewLogMessage("after the crash", "app-guid", "APP", "0", logmessage.LogMessage_OUT, now.Add(time.Minute)),
+			}
+
+			Expect(LastInstanceLogs(messages, []int{0}, now)).To(Equal([]string{"before the crash"}))
+			Expect(LastInstanceLogs(messages, []int{0}, time.Time{})).To(HaveLen(2))
+		})
+	})
+})
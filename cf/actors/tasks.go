@@ -0,0 +1,56 @@
+package actors
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/api/tasks"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+//go:generate counterfeiter . TaskActor
+
+const (
+	TaskStateSucceeded = "SUCCEEDED"
+	TaskStateFailed    = "FAILED"
+)
+
+type TaskActor interface {
+	RunTask(appGUID string, params models.TaskParams) (models.Task, error)
+	ListTasks(appGUID string) ([]models.Task, error)
+	TerminateTask(appGUID string, sequenceID int) (models.Task, error)
+}
+
+type taskActor struct {
+	taskRepo tasks.Repository
+}
+
+func NewTaskActor(taskRepo tasks.Repository) TaskActor {
+	return taskActor{
+		taskRepo: taskRepo,
+	}
+}
+
+func (actor taskActor) RunTask(appGUID string, params models.TaskParams) (models.Task, error) {
+	return actor.taskRepo.CreateTask(appGUID, params)
+}
+
+func (actor taskActor) ListTasks(appGUID string) ([]models.Task, error) {
+	return actor.taskRepo.ListTasks(appGUID)
+}
+
+// TerminateTask cancels the task of the app with the given sequence id, the
+// number users see in the tasks list, instead of the guid of the task.
+func (actor taskActor) TerminateTask(appGUID string, sequenceID int) (models.Task, error) {
+	task, err := actor.taskRepo.FindTaskBySequenceID(appGUID, sequenceID)
+	if err != nil {
+		return models.Task{}, err
+	}
+
+	if task.State == TaskStateSucceeded || task.State == TaskStateFailed {
+		return models.Task{}, errors.New(T("Task {{.SequenceID}} has already finished with state {{.State}}",
+			map[string]interface{}{"SequenceID": sequenceID, "State": task.State}))
+	}
+
+	return actor.taskRepo.CancelTask(task.GUID)
+}
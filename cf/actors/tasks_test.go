@@ -0,0 +1,95 @@
+package actors_test
+
+import (
+	"errors"
+
+	. "code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/api/tasks/tasksfakes"
+	cferrors "code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TaskActor", func() {
+	var (
+		fakeTaskRepo *tasksfakes.FakeRepository
+		actor        TaskActor
+	)
+
+	BeforeEach(func() {
+		fakeTaskRepo = new(tasksfakes.FakeRepository)
+		actor = NewTaskActor(fakeTaskRepo)
+	})
+
+	Describe("RunTask", func() {
+		It("creates the task for the app", func() {
+			fakeTaskRepo.CreateTaskReturns(models.Task{GUID: "task-guid", SequenceID: 1}, nil)
+
+			params := models.TaskParams{Name: "migrate", Command: "rake db:migrate"}
+			task, err := actor.RunTask("app-guid", params)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.SequenceID).To(Equal(1))
+
+			appGUID, createParams := fakeTaskRepo.CreateTaskArgsForCall(0)
+			Expect(appGUID).To(Equal("app-guid"))
+			Expect(createParams).To(Equal(params))
+		})
+	})
+
+	Describe("TerminateTask", func() {
+		Context("when the task is running", func() {
+			BeforeEach(func() {
+				fakeTaskRepo.FindTaskBySequenceIDReturns(models.Task{GUID: "task-guid", SequenceID: 3, State: "RUNNING"}, nil)
+				fakeTaskRepo.CancelTaskReturns(models.Task{GUID: "task-guid", SequenceID: 3, State: "CANCELING"}, nil)
+			})
+
+			It("cancels the task with the sequence id", func() {
+				task, err := actor.TerminateTask("app-guid", 3)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task.State).To(Equal("CANCELING"))
+
+				appGUID, sequenceID := fakeTaskRepo.FindTaskBySequenceIDArgsForCall(0)
+				Expect(appGUID).To(Equal("app-guid"))
+				Expect(sequenceID).To(Equal(3))
+				Expect(fakeTaskRepo.CancelTaskArgsForCall(0)).To(Equal("task-guid"))
+			})
+		})
+
+		Context("when the task has already finished", func() {
+			BeforeEach(func() {
+				fakeTaskRepo.FindTaskBySequenceIDReturns(models.Task{GUID: "task-guid", SequenceID: 3, State: "SUCCEEDED"}, nil)
+			})
+
+			It("returns an error without canceling it", func() {
+				_, err := actor.TerminateTask("app-guid", 3)
+				Expect(err).To(MatchError("Task 3 has already finished with state SUCCEEDED"))
+				Expect(fakeTaskRepo.CancelTaskCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the app has no such task", func() {
+			BeforeEach(func() {
+				fakeTaskRepo.FindTaskBySequenceIDReturns(models.Task{}, cferrors.NewModelNotFoundError("Task", "3"))
+			})
+
+			It("returns the not found error", func() {
+				_, err := actor.TerminateTask("app-guid", 3)
+				Expect(err).To(BeAssignableToTypeOf(&cferrors.ModelNotFoundError{}))
+				Expect(fakeTaskRepo.CancelTaskCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when canceling fails", func() {
+			BeforeEach(func() {
+				fakeTaskRepo.FindTaskBySequenceIDReturns(models.Task{GUID: "task-guid", SequenceID: 3, State: "RUNNING"}, nil)
+				fakeTaskRepo.CancelTaskReturns(models.Task{}, errors.New("cancel failed"))
+			})
+
+			It("returns the error", func() {
+				_, err := actor.TerminateTask("app-guid", 3)
+				Expect(err).To(MatchError("cancel failed"))
+			})
+		})
+	})
+})
@@ -0,0 +1,78 @@
+package actors
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/api/resources"
+)
+
+// UploadState is what is remembered about an application's last, unfinished
+// upload: the resource matching results for the files that were pushed and
+// the chunks of the zip that the Cloud Controller has already received.
+type UploadState struct {
+	FilesFingerprint string                           `json:"files_fingerprint"`
+	MatchedFiles     []resources.AppFileResource      `json:"matched_files"`
+	UploadedChunks   []resources.AppBitsChunkResource `json:"uploaded_chunks"`
+}
+
+//go:generate counterfeiter . UploadStateStore
+
+type UploadStateStore interface {
+	Load(appGUID string) (UploadState, error)
+	Save(appGUID string, state UploadState) error
+	Delete(appGUID string) error
+}
+
+type diskUploadStateStore struct {
+	dir string
+}
+
+// NewUploadStateStore returns a store that keeps one JSON file per
+// application in dir. The directory is created on the first save.
+func NewUploadStateStore(dir string) UploadStateStore {
+	return diskUploadStateStore{dir: dir}
+}
+
+func (store diskUploadStateStore) Load(appGUID string) (UploadState, error) {
+	var state UploadState
+
+	data, err := ioutil.ReadFile(store.path(appGUID))
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+
+	err = json.Unmarshal(data, &state)
+	return state, err
+}
+
+func (store diskUploadStateStore) Save(appGUID string, state UploadState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(store.dir, 0700)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(store.path(appGUID), data, 0600)
+}
+
+func (store diskUploadStateStore) Delete(appGUID string) error {
+	err := os.Remove(store.path(appGUID))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (store diskUploadStateStore) path(appGUID string) string {
+	return filepath.Join(store.dir, appGUID+".json")
+}
@@ -0,0 +1,68 @@
+package actors_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UploadStateStore", func() {
+	var (
+		tmpDir string
+		store  actors.UploadStateStore
+		state  actors.UploadState
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "upload-states")
+		Expect(err).NotTo(HaveOccurred())
+
+		store = actors.NewUploadStateStore(filepath.Join(tmpDir, "uploads"))
+		state = actors.UploadState{
+			FilesFingerprint: "some-fingerprint",
+			MatchedFiles:     []resources.AppFileResource{{Path: "app.rb", Sha1: "some-sha", Size: 51}},
+			UploadedChunks:   []resources.AppBitsChunkResource{{Index: 0, Offset: 0, Size: 4, Sha1: "chunk-sha"}},
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	It("returns an empty state when nothing was saved for the app", func() {
+		loaded, err := store.Load("app-guid")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(Equal(actors.UploadState{}))
+	})
+
+	It("loads a saved state", func() {
+		err := store.Save("app-guid", state)
+		Expect(err).NotTo(HaveOccurred())
+
+		loaded, err := store.Load("app-guid")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(Equal(state))
+	})
+
+	It("deletes a saved state", func() {
+		err := store.Save("app-guid", state)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = store.Delete("app-guid")
+		Expect(err).NotTo(HaveOccurred())
+
+		loaded, err := store.Load("app-guid")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(Equal(actors.UploadState{}))
+	})
+
+	It("does not error when deleting a state that does not exist", func() {
+		Expect(store.Delete("app-guid")).To(Succeed())
+	})
+})
@@ -73,3 +73,36 @@ func (p *SpaceUsersUIPrinter) PrintUsers(guid string, username string) {
 		}
 	}
 }
+
+// UsersTableUIPrinter lists the users of every role in a single table with
+// the origin and guid of each user, for commands run with --details or with
+// JSON or YAML output.
+type UsersTableUIPrinter struct {
+	UI               terminal.UI
+	UserLister       func(guid string, role models.Role) ([]models.UserFields, error)
+	Roles            []models.Role
+	RoleDisplayNames map[models.Role]string
+}
+
+func (p *UsersTableUIPrinter) PrintUsers(guid string, username string) {
+	table := p.UI.Table([]string{T("role"), T("user"), T("origin"), T("guid")})
+	for _, role := range p.Roles {
+		displayName := p.RoleDisplayNames[role]
+		users, err := p.UserLister(guid, role)
+		if err != nil {
+			p.UI.Failed(T("Failed fetching users for role {{.Role}}.\n{{.Error}}",
+				map[string]interface{}{
+					"Error": err.Error(),
+					"Role":  displayName,
+				}))
+			return
+		}
+
+		for _, user := range users {
+			table.Add(displayName, user.Username, user.Origin, user.GUID)
+		}
+	}
+
+	p.UI.Say("")
+	table.Print()
+}
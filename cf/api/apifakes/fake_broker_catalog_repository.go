@@ -0,0 +1,83 @@
+// This file was generated by counterfeiter
+package apifakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type FakeBrokerCatalogRepository struct {
+	GetCatalogStub        func(brokerURL, username, password string) (models.BrokerCatalog, error)
+	getCatalogMutex       sync.RWMutex
+	getCatalogArgsForCall []struct {
+		brokerURL string
+		username  string
+		password  string
+	}
+	getCatalogReturns struct {
+		result1 models.BrokerCatalog
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBrokerCatalogRepository) GetCatalog(brokerURL string, username string, password string) (models.BrokerCatalog, error) {
+	fake.getCatalogMutex.Lock()
+	fake.getCatalogArgsForCall = append(fake.getCatalogArgsForCall, struct {
+		brokerURL string
+		username  string
+		password  string
+	}{brokerURL, username, password})
+	fake.recordInvocation("GetCatalog", []interface{}{brokerURL, username, password})
+	fake.getCatalogMutex.Unlock()
+	if fake.GetCatalogStub != nil {
+		return fake.GetCatalogStub(brokerURL, username, password)
+	} else {
+		return fake.getCatalogReturns.result1, fake.getCatalogReturns.result2
+	}
+}
+
+func (fake *FakeBrokerCatalogRepository) GetCatalogCallCount() int {
+	fake.getCatalogMutex.RLock()
+	defer fake.getCatalogMutex.RUnlock()
+	return len(fake.getCatalogArgsForCall)
+}
+
+func (fake *FakeBrokerCatalogRepository) GetCatalogArgsForCall(i int) (string, string, string) {
+	fake.getCatalogMutex.RLock()
+	defer fake.getCatalogMutex.RUnlock()
+	return fake.getCatalogArgsForCall[i].brokerURL, fake.getCatalogArgsForCall[i].username, fake.getCatalogArgsForCall[i].password
+}
+
+func (fake *FakeBrokerCatalogRepository) GetCatalogReturns(result1 models.BrokerCatalog, result2 error) {
+	fake.GetCatalogStub = nil
+	fake.getCatalogReturns = struct {
+		result1 models.BrokerCatalog
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBrokerCatalogRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getCatalogMutex.RLock()
+	defer fake.getCatalogMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeBrokerCatalogRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ api.BrokerCatalogRepository = new(FakeBrokerCatalogRepository)
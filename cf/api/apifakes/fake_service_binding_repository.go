@@ -9,11 +9,12 @@ import (
 )
 
 type FakeServiceBindingRepository struct {
-	CreateStub        func(instanceGUID string, appGUID string, paramsMap map[string]interface{}) error
+	CreateStub        func(instanceGUID string, appGUID string, bindingName string, paramsMap map[string]interface{}) error
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
 		instanceGUID string
 		appGUID      string
+		bindingName  string
 		paramsMap    map[string]interface{}
 	}
 	createReturns struct {
@@ -38,21 +39,31 @@ type FakeServiceBindingRepository struct {
 		result1 []models.ServiceBindingFields
 		result2 error
 	}
+	ListAllForAppStub        func(appGUID string) ([]models.ServiceBindingFields, error)
+	listAllForAppMutex       sync.RWMutex
+	listAllForAppArgsForCall []struct {
+		appGUID string
+	}
+	listAllForAppReturns struct {
+		result1 []models.ServiceBindingFields
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeServiceBindingRepository) Create(instanceGUID string, appGUID string, paramsMap map[string]interface{}) error {
+func (fake *FakeServiceBindingRepository) Create(instanceGUID string, appGUID string, bindingName string, paramsMap map[string]interface{}) error {
 	fake.createMutex.Lock()
 	fake.createArgsForCall = append(fake.createArgsForCall, struct {
 		instanceGUID string
 		appGUID      string
+		bindingName  string
 		paramsMap    map[string]interface{}
-	}{instanceGUID, appGUID, paramsMap})
-	fake.recordInvocation("Create", []interface{}{instanceGUID, appGUID, paramsMap})
+	}{instanceGUID, appGUID, bindingName, paramsMap})
+	fake.recordInvocation("Create", []interface{}{instanceGUID, appGUID, bindingName, paramsMap})
 	fake.createMutex.Unlock()
 	if fake.CreateStub != nil {
-		return fake.CreateStub(instanceGUID, appGUID, paramsMap)
+		return fake.CreateStub(instanceGUID, appGUID, bindingName, paramsMap)
 	} else {
 		return fake.createReturns.result1
 	}
@@ -64,10 +75,10 @@ func (fake *FakeServiceBindingRepository) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
-func (fake *FakeServiceBindingRepository) CreateArgsForCall(i int) (string, string, map[string]interface{}) {
+func (fake *FakeServiceBindingRepository) CreateArgsForCall(i int) (string, string, string, map[string]interface{}) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].instanceGUID, fake.createArgsForCall[i].appGUID, fake.createArgsForCall[i].paramsMap
+	return fake.createArgsForCall[i].instanceGUID, fake.createArgsForCall[i].appGUID, fake.createArgsForCall[i].bindingName, fake.createArgsForCall[i].paramsMap
 }
 
 func (fake *FakeServiceBindingRepository) CreateReturns(result1 error) {
@@ -146,6 +157,40 @@ func (fake *FakeServiceBindingRepository) ListAllForServiceReturns(result1 []mod
 	}{result1, result2}
 }
 
+func (fake *FakeServiceBindingRepository) ListAllForApp(appGUID string) ([]models.ServiceBindingFields, error) {
+	fake.listAllForAppMutex.Lock()
+	fake.listAllForAppArgsForCall = append(fake.listAllForAppArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("ListAllForApp", []interface{}{appGUID})
+	fake.listAllForAppMutex.Unlock()
+	if fake.ListAllForAppStub != nil {
+		return fake.ListAllForAppStub(appGUID)
+	} else {
+		return fake.listAllForAppReturns.result1, fake.listAllForAppReturns.result2
+	}
+}
+
+func (fake *FakeServiceBindingRepository) ListAllForAppCallCount() int {
+	fake.listAllForAppMutex.RLock()
+	defer fake.listAllForAppMutex.RUnlock()
+	return len(fake.listAllForAppArgsForCall)
+}
+
+func (fake *FakeServiceBindingRepository) ListAllForAppArgsForCall(i int) string {
+	fake.listAllForAppMutex.RLock()
+	defer fake.listAllForAppMutex.RUnlock()
+	return fake.listAllForAppArgsForCall[i].appGUID
+}
+
+func (fake *FakeServiceBindingRepository) ListAllForAppReturns(result1 []models.ServiceBindingFields, result2 error) {
+	fake.ListAllForAppStub = nil
+	fake.listAllForAppReturns = struct {
+		result1 []models.ServiceBindingFields
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeServiceBindingRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -155,6 +200,8 @@ func (fake *FakeServiceBindingRepository) Invocations() map[string][][]interface
 	defer fake.deleteMutex.RUnlock()
 	fake.listAllForServiceMutex.RLock()
 	defer fake.listAllForServiceMutex.RUnlock()
+	fake.listAllForAppMutex.RLock()
+	defer fake.listAllForAppMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -28,6 +28,15 @@ type FakeUserProvidedServiceInstanceRepository struct {
 	updateReturns struct {
 		result1 error
 	}
+	GetStub        func(guid string) (models.UserProvidedService, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		guid string
+	}
+	getReturns struct {
+		result1 models.UserProvidedService
+		result2 error
+	}
 	GetSummariesStub        func() (models.UserProvidedServiceSummary, error)
 	getSummariesMutex       sync.RWMutex
 	getSummariesArgsForCall []struct{}
@@ -108,6 +117,40 @@ func (fake *FakeUserProvidedServiceInstanceRepository) UpdateReturns(result1 err
 	}{result1}
 }
 
+func (fake *FakeUserProvidedServiceInstanceRepository) Get(guid string) (models.UserProvidedService, error) {
+	fake.getMutex.Lock()
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		guid string
+	}{guid})
+	fake.recordInvocation("Get", []interface{}{guid})
+	fake.getMutex.Unlock()
+	if fake.GetStub != nil {
+		return fake.GetStub(guid)
+	} else {
+		return fake.getReturns.result1, fake.getReturns.result2
+	}
+}
+
+func (fake *FakeUserProvidedServiceInstanceRepository) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeUserProvidedServiceInstanceRepository) GetArgsForCall(i int) string {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return fake.getArgsForCall[i].guid
+}
+
+func (fake *FakeUserProvidedServiceInstanceRepository) GetReturns(result1 models.UserProvidedService, result2 error) {
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 models.UserProvidedService
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeUserProvidedServiceInstanceRepository) GetSummaries() (models.UserProvidedServiceSummary, error) {
 	fake.getSummariesMutex.Lock()
 	fake.getSummariesArgsForCall = append(fake.getSummariesArgsForCall, struct{}{})
@@ -141,6 +184,8 @@ func (fake *FakeUserProvidedServiceInstanceRepository) Invocations() map[string]
 	defer fake.createMutex.RUnlock()
 	fake.updateMutex.RLock()
 	defer fake.updateMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
 	fake.getSummariesMutex.RLock()
 	defer fake.getSummariesMutex.RUnlock()
 	return fake.invocations
@@ -67,6 +67,25 @@ type FakeUserRepository struct {
 	createReturns struct {
 		result1 error
 	}
+	CreateWithOriginStub        func(username, password, origin, externalID string) (apiErr error)
+	createWithOriginMutex       sync.RWMutex
+	createWithOriginArgsForCall []struct {
+		username   string
+		password   string
+		origin     string
+		externalID string
+	}
+	createWithOriginReturns struct {
+		result1 error
+	}
+	CreateClientUserStub        func(clientID string) (apiErr error)
+	createClientUserMutex       sync.RWMutex
+	createClientUserArgsForCall []struct {
+		clientID string
+	}
+	createClientUserReturns struct {
+		result1 error
+	}
 	DeleteStub        func(userGUID string) (apiErr error)
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -369,6 +388,75 @@ func (fake *FakeUserRepository) CreateReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeUserRepository) CreateWithOrigin(username string, password string, origin string, externalID string) (apiErr error) {
+	fake.createWithOriginMutex.Lock()
+	fake.createWithOriginArgsForCall = append(fake.createWithOriginArgsForCall, struct {
+		username   string
+		password   string
+		origin     string
+		externalID string
+	}{username, password, origin, externalID})
+	fake.recordInvocation("CreateWithOrigin", []interface{}{username, password, origin, externalID})
+	fake.createWithOriginMutex.Unlock()
+	if fake.CreateWithOriginStub != nil {
+		return fake.CreateWithOriginStub(username, password, origin, externalID)
+	} else {
+		return fake.createWithOriginReturns.result1
+	}
+}
+
+func (fake *FakeUserRepository) CreateWithOriginCallCount() int {
+	fake.createWithOriginMutex.RLock()
+	defer fake.createWithOriginMutex.RUnlock()
+	return len(fake.createWithOriginArgsForCall)
+}
+
+func (fake *FakeUserRepository) CreateWithOriginArgsForCall(i int) (string, string, string, string) {
+	fake.createWithOriginMutex.RLock()
+	defer fake.createWithOriginMutex.RUnlock()
+	return fake.createWithOriginArgsForCall[i].username, fake.createWithOriginArgsForCall[i].password, fake.createWithOriginArgsForCall[i].origin, fake.createWithOriginArgsForCall[i].externalID
+}
+
+func (fake *FakeUserRepository) CreateWithOriginReturns(result1 error) {
+	fake.CreateWithOriginStub = nil
+	fake.createWithOriginReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeUserRepository) CreateClientUser(clientID string) (apiErr error) {
+	fake.createClientUserMutex.Lock()
+	fake.createClientUserArgsForCall = append(fake.createClientUserArgsForCall, struct {
+		clientID string
+	}{clientID})
+	fake.recordInvocation("CreateClientUser", []interface{}{clientID})
+	fake.createClientUserMutex.Unlock()
+	if fake.CreateClientUserStub != nil {
+		return fake.CreateClientUserStub(clientID)
+	} else {
+		return fake.createClientUserReturns.result1
+	}
+}
+
+func (fake *FakeUserRepository) CreateClientUserCallCount() int {
+	fake.createClientUserMutex.RLock()
+	defer fake.createClientUserMutex.RUnlock()
+	return len(fake.createClientUserArgsForCall)
+}
+
+func (fake *FakeUserRepository) CreateClientUserArgsForCall(i int) string {
+	fake.createClientUserMutex.RLock()
+	defer fake.createClientUserMutex.RUnlock()
+	return fake.createClientUserArgsForCall[i].clientID
+}
+
+func (fake *FakeUserRepository) CreateClientUserReturns(result1 error) {
+	fake.CreateClientUserStub = nil
+	fake.createClientUserReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeUserRepository) Delete(userGUID string) (apiErr error) {
 	fake.deleteMutex.Lock()
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
@@ -699,6 +787,10 @@ func (fake *FakeUserRepository) Invocations() map[string][][]interface{} {
 	defer fake.listUsersInSpaceForRoleWithNoUAAMutex.RUnlock()
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
+	fake.createWithOriginMutex.RLock()
+	defer fake.createWithOriginMutex.RUnlock()
+	fake.createClientUserMutex.RLock()
+	defer fake.createClientUserMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.setOrgRoleByGUIDMutex.RLock()
@@ -10,6 +10,7 @@ type OldFakeBuildpackRepository struct {
 
 	FindByNameNotFound    bool
 	FindByNameName        string
+	FindByNameStack       string
 	FindByNameBuildpack   models.Buildpack
 	FindByNameAPIResponse error
 
@@ -47,12 +48,17 @@ func (repo *OldFakeBuildpackRepository) FindByName(name string) (buildpack model
 	return
 }
 
-func (repo *OldFakeBuildpackRepository) Create(name string, position *int, enabled *bool, locked *bool) (createdBuildpack models.Buildpack, apiErr error) {
+func (repo *OldFakeBuildpackRepository) FindByNameAndStack(name, stack string) (buildpack models.Buildpack, apiErr error) {
+	repo.FindByNameStack = stack
+	return repo.FindByName(name)
+}
+
+func (repo *OldFakeBuildpackRepository) Create(name string, position *int, enabled *bool, locked *bool, stack string) (createdBuildpack models.Buildpack, apiErr error) {
 	if repo.CreateBuildpackExists {
 		return repo.CreateBuildpack, errors.NewHTTPError(400, errors.BuildpackNameTaken, "Buildpack already exists")
 	}
 
-	repo.CreateBuildpack = models.Buildpack{Name: name, Position: position, Enabled: enabled, Locked: locked}
+	repo.CreateBuildpack = models.Buildpack{Name: name, Position: position, Enabled: enabled, Locked: locked, Stack: stack}
 	return repo.CreateBuildpack, repo.CreateAPIResponse
 }
 
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"code.cloudfoundry.org/cli/cf/api/resources"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/net"
@@ -43,6 +44,10 @@ type ApplicationFromSummary struct {
 	PackageState         string     `json:"package_state"`
 	PackageUpdatedAt     *time.Time `json:"package_updated_at"`
 	Buildpack            string
+	DetectedBuildpack    string                       `json:"detected_buildpack"`
+	HealthCheckType      string                       `json:"health_check_type"`
+	DockerImage          string                       `json:"docker_image"`
+	DockerCredentials    *resources.DockerCredentials `json:"docker_credentials"`
 }
 
 func (resource ApplicationFromSummary) ToFields() (app models.ApplicationFields) {
@@ -62,9 +67,15 @@ func (resource ApplicationFromSummary) ToFields() (app models.ApplicationFields)
 	app.DetectedStartCommand = resource.DetectedStartCommand
 	app.HealthCheckTimeout = resource.HealthCheckTimeout
 	app.BuildpackURL = resource.Buildpack
+	app.DetectedBuildpack = resource.DetectedBuildpack
 	app.Command = resource.Command
 	app.AppPorts = resource.AppPorts
 	app.EnvironmentVars = resource.EnvironmentVars
+	app.HealthCheckType = resource.HealthCheckType
+	app.DockerImage = resource.DockerImage
+	if resource.DockerCredentials != nil {
+		app.DockerUsername = resource.DockerCredentials.Username
+	}
 
 	return
 }
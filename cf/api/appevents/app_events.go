@@ -1,6 +1,11 @@
 package appevents
 
 import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
 	"code.cloudfoundry.org/cli/cf/api/resources"
 	"code.cloudfoundry.org/cli/cf/api/strategy"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -12,8 +17,24 @@ import (
 
 type Repository interface {
 	RecentEvents(appGUID string, limit int64) ([]models.EventFields, error)
+	ListEvents(query Query) ([]models.EventFields, error)
+}
+
+// Query selects audit events by what they act on and by their type. With
+// Since set, the events at or after Since are listed oldest first; otherwise
+// the most recent events are listed newest first. A Limit of 0 lists every
+// matching event.
+type Query struct {
+	ActeeGUID        string
+	SpaceGUID        string
+	OrganizationGUID string
+	Types            []string
+	Since            time.Time
+	Limit            int64
 }
 
+const maxEventsPerPage = 100
+
 type CloudControllerAppEventsRepository struct {
 	config   coreconfig.Reader
 	gateway  net.Gateway
@@ -50,3 +71,49 @@ func (repo CloudControllerAppEventsRepository) listEvents(appGUID string, limit
 			return cb(resource.(resources.EventResource).ToFields())
 		})
 }
+
+func (repo CloudControllerAppEventsRepository) ListEvents(query Query) ([]models.EventFields, error) {
+	events := []models.EventFields{}
+	err := repo.gateway.ListPaginatedResources(
+		repo.config.APIEndpoint(),
+		eventsPath(query),
+		resources.EventResourceNewV2{},
+		func(resource interface{}) bool {
+			events = append(events, resource.(resources.EventResourceNewV2).ToFields())
+			return query.Limit == 0 || int64(len(events)) < query.Limit
+		})
+
+	return events, err
+}
+
+func eventsPath(query Query) string {
+	values := url.Values{}
+
+	perPage := int64(maxEventsPerPage)
+	if query.Limit > 0 && query.Limit < perPage {
+		perPage = query.Limit
+	}
+	values.Set("results-per-page", strconv.FormatInt(perPage, 10))
+
+	if query.Since.IsZero() {
+		values.Set("order-direction", "desc")
+	} else {
+		values.Set("order-direction", "asc")
+		values.Add("q", "timestamp>="+query.Since.UTC().Format(time.RFC3339))
+	}
+
+	if query.ActeeGUID != "" {
+		values.Add("q", "actee:"+query.ActeeGUID)
+	}
+	if query.SpaceGUID != "" {
+		values.Add("q", "space_guid:"+query.SpaceGUID)
+	}
+	if query.OrganizationGUID != "" {
+		values.Add("q", "organization_guid:"+query.OrganizationGUID)
+	}
+	if len(query.Types) > 0 {
+		values.Add("q", "type IN "+strings.Join(query.Types, ","))
+	}
+
+	return "/v2/events?" + values.Encode()
+}
@@ -15,6 +15,8 @@ import (
 	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
 	testnet "code.cloudfoundry.org/cli/testhelpers/net"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -77,6 +79,100 @@ var _ = Describe("App Events Repo", func() {
 			}))
 		})
 	})
+
+	Describe("ListEvents", func() {
+		It("lists the most recent events matching the query", func() {
+			setupTestServer(testnet.TestRequest{
+				Method: "GET",
+				Path:   "/v2/events?order-direction=desc&q=space_guid%3Amy-space-guid&q=type+IN+audit.app.create%2Caudit.app.delete-request&results-per-page=2",
+				Response: testnet.TestResponse{
+					Status: http.StatusOK,
+					Body: `{
+						"next_url": null,
+						"resources": [
+							{
+								"metadata": { "guid": "event-1-guid" },
+								"entity": {
+									"type": "audit.app.create",
+									"timestamp": "2014-01-21T00:20:11+00:00",
+									"actor": "user-guid",
+									"actor_name": "somebody@example.com",
+									"actee_type": "app",
+									"actee_name": "my-app",
+									"metadata": { "request": { "memory": 256 } }
+								}
+							}
+						]
+					}`,
+				},
+			})
+
+			list, err := repo.ListEvents(Query{
+				SpaceGUID: "my-space-guid",
+				Types:     []string{"audit.app.create", "audit.app.delete-request"},
+				Limit:     2,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(handler).To(HaveAllRequestsCalled())
+
+			timestamp, err := time.Parse(eventTimestampFormat, "2014-01-21T00:20:11+00:00")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(list).To(Equal([]models.EventFields{
+				{
+					GUID:        "event-1-guid",
+					Name:        "audit.app.create",
+					Timestamp:   timestamp,
+					Description: "memory: 256",
+					Actor:       "user-guid",
+					ActorName:   "somebody@example.com",
+					ActeeType:   "app",
+					ActeeName:   "my-app",
+				},
+			}))
+		})
+
+		It("lists the events since a time oldest first, following pagination", func() {
+			setupTestServer(
+				testnet.TestRequest{
+					Method: "GET",
+					Path:   "/v2/events?order-direction=asc&q=timestamp%3E%3D2014-01-21T00%3A20%3A11Z&q=actee%3Amy-app-guid&results-per-page=100",
+					Response: testnet.TestResponse{
+						Status: http.StatusOK,
+						Body: `{
+							"next_url": "/v2/events?page=2",
+							"resources": [
+								{ "metadata": { "guid": "event-1-guid" }, "entity": { "type": "audit.app.update" } }
+							]
+						}`,
+					},
+				},
+				testnet.TestRequest{
+					Method: "GET",
+					Path:   "/v2/events?page=2",
+					Response: testnet.TestResponse{
+						Status: http.StatusOK,
+						Body: `{
+							"next_url": null,
+							"resources": [
+								{ "metadata": { "guid": "event-2-guid" }, "entity": { "type": "audit.app.restage" } }
+							]
+						}`,
+					},
+				},
+			)
+
+			since, err := time.Parse(eventTimestampFormat, "2014-01-21T00:20:11+00:00")
+			Expect(err).ToNot(HaveOccurred())
+
+			list, err := repo.ListEvents(Query{ActeeGUID: "my-app-guid", Since: since})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(handler).To(HaveAllRequestsCalled())
+
+			Expect(list).To(HaveLen(2))
+			Expect(list[0].GUID).To(Equal("event-1-guid"))
+			Expect(list[1].GUID).To(Equal("event-2-guid"))
+		})
+	})
 })
 
 const eventTimestampFormat = "2006-01-02T15:04:05-07:00"
@@ -19,6 +19,17 @@ type FakeAppEventsRepository struct {
 		result1 []models.EventFields
 		result2 error
 	}
+	ListEventsStub        func(query appevents.Query) ([]models.EventFields, error)
+	listEventsMutex       sync.RWMutex
+	listEventsArgsForCall []struct {
+		query appevents.Query
+	}
+	listEventsReturns struct {
+		result1 []models.EventFields
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
 }
 
 func (fake *FakeAppEventsRepository) RecentEvents(appGUID string, limit int64) ([]models.EventFields, error) {
@@ -27,6 +38,7 @@ func (fake *FakeAppEventsRepository) RecentEvents(appGUID string, limit int64) (
 		appGUID string
 		limit   int64
 	}{appGUID, limit})
+	fake.recordInvocation("RecentEvents", []interface{}{appGUID, limit})
 	fake.recentEventsMutex.Unlock()
 	if fake.RecentEventsStub != nil {
 		return fake.RecentEventsStub(appGUID, limit)
@@ -55,4 +67,60 @@ func (fake *FakeAppEventsRepository) RecentEventsReturns(result1 []models.EventF
 	}{result1, result2}
 }
 
+func (fake *FakeAppEventsRepository) ListEvents(query appevents.Query) ([]models.EventFields, error) {
+	fake.listEventsMutex.Lock()
+	fake.listEventsArgsForCall = append(fake.listEventsArgsForCall, struct {
+		query appevents.Query
+	}{query})
+	fake.recordInvocation("ListEvents", []interface{}{query})
+	fake.listEventsMutex.Unlock()
+	if fake.ListEventsStub != nil {
+		return fake.ListEventsStub(query)
+	} else {
+		return fake.listEventsReturns.result1, fake.listEventsReturns.result2
+	}
+}
+
+func (fake *FakeAppEventsRepository) ListEventsCallCount() int {
+	fake.listEventsMutex.RLock()
+	defer fake.listEventsMutex.RUnlock()
+	return len(fake.listEventsArgsForCall)
+}
+
+func (fake *FakeAppEventsRepository) ListEventsArgsForCall(i int) appevents.Query {
+	fake.listEventsMutex.RLock()
+	defer fake.listEventsMutex.RUnlock()
+	return fake.listEventsArgsForCall[i].query
+}
+
+func (fake *FakeAppEventsRepository) ListEventsReturns(result1 []models.EventFields, result2 error) {
+	fake.ListEventsStub = nil
+	fake.listEventsReturns = struct {
+		result1 []models.EventFields
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAppEventsRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.recentEventsMutex.RLock()
+	defer fake.recentEventsMutex.RUnlock()
+	fake.listEventsMutex.RLock()
+	defer fake.listEventsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAppEventsRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
 var _ appevents.Repository = new(FakeAppEventsRepository)
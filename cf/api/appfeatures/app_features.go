@@ -0,0 +1,83 @@
+package appfeatures
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+)
+
+//go:generate counterfeiter . Repository
+
+// Repository reads and toggles the features of an app, such as ssh and
+// revisions, through the v3 app features API.
+type Repository interface {
+	ListAppFeatures(appGUID string) ([]models.AppFeature, error)
+	UpdateAppFeature(appGUID string, name string, enabled bool) (models.AppFeature, error)
+	GetSSHEnabled(appGUID string) (models.SSHEnabled, error)
+}
+
+type CloudControllerRepository struct {
+	config  coreconfig.Reader
+	gateway net.Gateway
+}
+
+func NewCloudControllerRepository(config coreconfig.Reader, gateway net.Gateway) CloudControllerRepository {
+	return CloudControllerRepository{
+		config:  config,
+		gateway: gateway,
+	}
+}
+
+func (repo CloudControllerRepository) ListAppFeatures(appGUID string) ([]models.AppFeature, error) {
+	resource := resources.AppFeaturesResource{}
+	err := repo.gateway.GetResource(fmt.Sprintf("%s/v3/apps/%s/features", repo.config.APIEndpoint(), appGUID), &resource)
+	if err != nil {
+		return nil, err
+	}
+
+	features := []models.AppFeature{}
+	for _, feature := range resource.Resources {
+		features = append(features, feature.ToModel())
+	}
+
+	return features, nil
+}
+
+func (repo CloudControllerRepository) UpdateAppFeature(appGUID string, name string, enabled bool) (models.AppFeature, error) {
+	body, err := json.Marshal(resources.AppFeatureUpdateResource{Enabled: enabled})
+	if err != nil {
+		return models.AppFeature{}, err
+	}
+
+	url := fmt.Sprintf("%s/v3/apps/%s/features/%s", repo.config.APIEndpoint(), appGUID, name)
+	request, err := repo.gateway.NewRequest("PATCH", url, repo.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return models.AppFeature{}, err
+	}
+
+	resource := resources.AppFeatureResource{}
+	_, err = repo.gateway.PerformRequestForJSONResponse(request, &resource)
+	if err != nil {
+		return models.AppFeature{}, err
+	}
+
+	return resource.ToModel(), nil
+}
+
+// GetSSHEnabled tells whether SSH to the app is allowed at all. Unlike the
+// ssh app feature, it also accounts for SSH being disabled for the space or
+// the whole foundation.
+func (repo CloudControllerRepository) GetSSHEnabled(appGUID string) (models.SSHEnabled, error) {
+	resource := resources.SSHEnabledResource{}
+	err := repo.gateway.GetResource(fmt.Sprintf("%s/v3/apps/%s/ssh_enabled", repo.config.APIEndpoint(), appGUID), &resource)
+	if err != nil {
+		return models.SSHEnabled{}, err
+	}
+
+	return models.SSHEnabled{Enabled: resource.Enabled, Reason: resource.Reason}, nil
+}
@@ -0,0 +1,108 @@
+package appfeatures_test
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+
+	"github.com/onsi/gomega/ghttp"
+
+	. "code.cloudfoundry.org/cli/cf/api/appfeatures"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppFeaturesRepo", func() {
+	var (
+		testServer *ghttp.Server
+		configRepo coreconfig.ReadWriter
+		repo       Repository
+	)
+
+	BeforeEach(func() {
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		configRepo.SetAccessToken("BEARER my_access_token")
+
+		gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		repo = NewCloudControllerRepository(configRepo, gateway)
+	})
+
+	BeforeEach(func() {
+		testServer = ghttp.NewServer()
+		configRepo.SetAPIEndpoint(testServer.URL())
+	})
+
+	AfterEach(func() {
+		if testServer != nil {
+			testServer.Close()
+		}
+	})
+
+	Describe("ListAppFeatures", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/features"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"resources": [
+							{ "name": "ssh", "description": "Enable SSHing into the app.", "enabled": true },
+							{ "name": "revisions", "description": "Enable versioning of an application", "enabled": false }
+						]
+					}`),
+				),
+			)
+		})
+
+		It("returns the features of the app", func() {
+			features, err := repo.ListAppFeatures("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(features).To(Equal([]models.AppFeature{
+				{Name: "ssh", Description: "Enable SSHing into the app.", Enabled: true},
+				{Name: "revisions", Description: "Enable versioning of an application", Enabled: false},
+			}))
+		})
+	})
+
+	Describe("UpdateAppFeature", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/v3/apps/app-guid/features/revisions"),
+					ghttp.VerifyJSON(`{ "enabled": true }`),
+					ghttp.RespondWith(http.StatusOK, `{ "name": "revisions", "description": "Enable versioning of an application", "enabled": true }`),
+				),
+			)
+		})
+
+		It("turns the feature on or off", func() {
+			feature, err := repo.UpdateAppFeature("app-guid", "revisions", true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(feature.Name).To(Equal("revisions"))
+			Expect(feature.Enabled).To(BeTrue())
+		})
+	})
+
+	Describe("GetSSHEnabled", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/ssh_enabled"),
+					ghttp.RespondWith(http.StatusOK, `{ "enabled": false, "reason": "Disabled for space my-space" }`),
+				),
+			)
+		})
+
+		It("returns whether SSH is allowed and why not", func() {
+			sshEnabled, err := repo.GetSSHEnabled("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sshEnabled).To(Equal(models.SSHEnabled{Enabled: false, Reason: "Disabled for space my-space"}))
+		})
+	})
+})
@@ -0,0 +1,173 @@
+// This file was generated by counterfeiter
+package appfeaturesfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/api/appfeatures"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type FakeRepository struct {
+	ListAppFeaturesStub        func(appGUID string) ([]models.AppFeature, error)
+	listAppFeaturesMutex       sync.RWMutex
+	listAppFeaturesArgsForCall []struct {
+		appGUID string
+	}
+	listAppFeaturesReturns struct {
+		result1 []models.AppFeature
+		result2 error
+	}
+	UpdateAppFeatureStub        func(appGUID string, name string, enabled bool) (models.AppFeature, error)
+	updateAppFeatureMutex       sync.RWMutex
+	updateAppFeatureArgsForCall []struct {
+		appGUID string
+		name    string
+		enabled bool
+	}
+	updateAppFeatureReturns struct {
+		result1 models.AppFeature
+		result2 error
+	}
+	GetSSHEnabledStub        func(appGUID string) (models.SSHEnabled, error)
+	getSSHEnabledMutex       sync.RWMutex
+	getSSHEnabledArgsForCall []struct {
+		appGUID string
+	}
+	getSSHEnabledReturns struct {
+		result1 models.SSHEnabled
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRepository) ListAppFeatures(appGUID string) ([]models.AppFeature, error) {
+	fake.listAppFeaturesMutex.Lock()
+	fake.listAppFeaturesArgsForCall = append(fake.listAppFeaturesArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("ListAppFeatures", []interface{}{appGUID})
+	fake.listAppFeaturesMutex.Unlock()
+	if fake.ListAppFeaturesStub != nil {
+		return fake.ListAppFeaturesStub(appGUID)
+	} else {
+		return fake.listAppFeaturesReturns.result1, fake.listAppFeaturesReturns.result2
+	}
+}
+
+func (fake *FakeRepository) ListAppFeaturesCallCount() int {
+	fake.listAppFeaturesMutex.RLock()
+	defer fake.listAppFeaturesMutex.RUnlock()
+	return len(fake.listAppFeaturesArgsForCall)
+}
+
+func (fake *FakeRepository) ListAppFeaturesArgsForCall(i int) string {
+	fake.listAppFeaturesMutex.RLock()
+	defer fake.listAppFeaturesMutex.RUnlock()
+	return fake.listAppFeaturesArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) ListAppFeaturesReturns(result1 []models.AppFeature, result2 error) {
+	fake.ListAppFeaturesStub = nil
+	fake.listAppFeaturesReturns = struct {
+		result1 []models.AppFeature
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) UpdateAppFeature(appGUID string, name string, enabled bool) (models.AppFeature, error) {
+	fake.updateAppFeatureMutex.Lock()
+	fake.updateAppFeatureArgsForCall = append(fake.updateAppFeatureArgsForCall, struct {
+		appGUID string
+		name    string
+		enabled bool
+	}{appGUID, name, enabled})
+	fake.recordInvocation("UpdateAppFeature", []interface{}{appGUID, name, enabled})
+	fake.updateAppFeatureMutex.Unlock()
+	if fake.UpdateAppFeatureStub != nil {
+		return fake.UpdateAppFeatureStub(appGUID, name, enabled)
+	} else {
+		return fake.updateAppFeatureReturns.result1, fake.updateAppFeatureReturns.result2
+	}
+}
+
+func (fake *FakeRepository) UpdateAppFeatureCallCount() int {
+	fake.updateAppFeatureMutex.RLock()
+	defer fake.updateAppFeatureMutex.RUnlock()
+	return len(fake.updateAppFeatureArgsForCall)
+}
+
+func (fake *FakeRepository) UpdateAppFeatureArgsForCall(i int) (string, string, bool) {
+	fake.updateAppFeatureMutex.RLock()
+	defer fake.updateAppFeatureMutex.RUnlock()
+	return fake.updateAppFeatureArgsForCall[i].appGUID, fake.updateAppFeatureArgsForCall[i].name, fake.updateAppFeatureArgsForCall[i].enabled
+}
+
+func (fake *FakeRepository) UpdateAppFeatureReturns(result1 models.AppFeature, result2 error) {
+	fake.UpdateAppFeatureStub = nil
+	fake.updateAppFeatureReturns = struct {
+		result1 models.AppFeature
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetSSHEnabled(appGUID string) (models.SSHEnabled, error) {
+	fake.getSSHEnabledMutex.Lock()
+	fake.getSSHEnabledArgsForCall = append(fake.getSSHEnabledArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("GetSSHEnabled", []interface{}{appGUID})
+	fake.getSSHEnabledMutex.Unlock()
+	if fake.GetSSHEnabledStub != nil {
+		return fake.GetSSHEnabledStub(appGUID)
+	} else {
+		return fake.getSSHEnabledReturns.result1, fake.getSSHEnabledReturns.result2
+	}
+}
+
+func (fake *FakeRepository) GetSSHEnabledCallCount() int {
+	fake.getSSHEnabledMutex.RLock()
+	defer fake.getSSHEnabledMutex.RUnlock()
+	return len(fake.getSSHEnabledArgsForCall)
+}
+
+func (fake *FakeRepository) GetSSHEnabledArgsForCall(i int) string {
+	fake.getSSHEnabledMutex.RLock()
+	defer fake.getSSHEnabledMutex.RUnlock()
+	return fake.getSSHEnabledArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) GetSSHEnabledReturns(result1 models.SSHEnabled, result2 error) {
+	fake.GetSSHEnabledStub = nil
+	fake.getSSHEnabledReturns = struct {
+		result1 models.SSHEnabled
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listAppFeaturesMutex.RLock()
+	defer fake.listAppFeaturesMutex.RUnlock()
+	fake.updateAppFeatureMutex.RLock()
+	defer fake.updateAppFeatureMutex.RUnlock()
+	fake.getSSHEnabledMutex.RLock()
+	defer fake.getSSHEnabledMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ appfeatures.Repository = new(FakeRepository)
@@ -12,6 +12,7 @@ import (
 
 	"code.cloudfoundry.org/cli/cf/api/resources"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
 	. "code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/cf/net"
 	"code.cloudfoundry.org/gofileutils/fileutils"
@@ -26,6 +27,9 @@ const (
 type Repository interface {
 	GetApplicationFiles(appFilesRequest []resources.AppFileResource) ([]resources.AppFileResource, error)
 	UploadBits(appGUID string, zipFile *os.File, presentFiles []resources.AppFileResource) (apiErr error)
+	ChunkedUploadSupported(appGUID string) (bool, error)
+	UploadBitsChunk(appGUID string, chunk resources.AppBitsChunkResource, body io.ReadSeeker) error
+	CompleteChunkedUpload(appGUID string, chunks []resources.AppBitsChunkResource, presentFiles []resources.AppFileResource) error
 }
 
 type CloudControllerApplicationBitsRepository struct {
@@ -83,6 +87,67 @@ func (repo CloudControllerApplicationBitsRepository) UploadBits(appGUID string,
 	return
 }
 
+// ChunkedUploadSupported asks the Cloud Controller for the app's chunk
+// collection. Cloud Controllers that cannot take the bits in chunks do not
+// know the endpoint and answer 404.
+func (repo CloudControllerApplicationBitsRepository) ChunkedUploadSupported(appGUID string) (bool, error) {
+	apiURL := fmt.Sprintf("%s/v2/apps/%s/bits/chunks", repo.config.APIEndpoint(), appGUID)
+	request, err := repo.gateway.NewRequest("GET", apiURL, repo.config.AccessToken(), nil)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = repo.gateway.PerformRequest(request)
+	if _, ok := err.(*errors.HTTPNotFoundError); ok {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (repo CloudControllerApplicationBitsRepository) UploadBitsChunk(appGUID string, chunk resources.AppBitsChunkResource, body io.ReadSeeker) error {
+	apiURL := fmt.Sprintf("%s/v2/apps/%s/bits/chunks/%d", repo.config.APIEndpoint(), appGUID, chunk.Index)
+	request, err := repo.gateway.NewRequestForReader("PUT", apiURL, repo.config.AccessToken(), body, chunk.Size)
+	if err != nil {
+		return err
+	}
+
+	request.HTTPReq.Header.Set("Content-Type", "application/octet-stream")
+	request.HTTPReq.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/*", chunk.Offset, chunk.Offset+chunk.Size-1))
+	request.HTTPReq.Header.Set("X-Cf-Chunk-Sha1", chunk.Sha1)
+
+	_, err = repo.gateway.PerformRequest(request)
+	return err
+}
+
+func (repo CloudControllerApplicationBitsRepository) CompleteChunkedUpload(appGUID string, chunks []resources.AppBitsChunkResource, presentFiles []resources.AppFileResource) error {
+	// json.Marshal represents a nil value as "null" instead of an empty slice "[]"
+	if presentFiles == nil {
+		presentFiles = []resources.AppFileResource{}
+	}
+
+	body, err := json.Marshal(resources.AppBitsChunkedUploadResource{
+		Resources: presentFiles,
+		Chunks:    chunks,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %s", T("Error marshaling JSON"), err.Error())
+	}
+
+	apiURL := fmt.Sprintf("%s/v2/apps/%s/bits/chunks", repo.config.APIEndpoint(), appGUID)
+	request, err := repo.gateway.NewRequest("PUT", apiURL, repo.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	response := &resources.Resource{}
+	_, err = repo.gateway.PerformPollingRequestForJSONResponse(repo.config.APIEndpoint(), request, response, DefaultAppUploadBitsTimeout)
+	return err
+}
+
 func (repo CloudControllerApplicationBitsRepository) GetApplicationFiles(appFilesToCheck []resources.AppFileResource) ([]resources.AppFileResource, error) {
 	integrityFieldsJSON, err := json.Marshal(mapAppFilesToIntegrityFields(appFilesToCheck))
 	if err != nil {
@@ -3,6 +3,7 @@ package applicationbits_test
 import (
 	"archive/zip"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"net/http/httptest"
@@ -200,6 +201,135 @@ var _ = Describe("CloudControllerApplicationBitsRepository", func() {
 		})
 	})
 
+	Describe(".ChunkedUploadSupported", func() {
+		AfterEach(func() {
+			testServer.Close()
+		})
+
+		It("returns true when the cloud controller knows the chunk collection", func() {
+			setupTestServer(testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method:   "GET",
+				Path:     "/v2/apps/my-cool-app-guid/bits/chunks",
+				Response: testnet.TestResponse{Status: http.StatusOK, Body: `{"chunks": []}`},
+			}))
+
+			supported, apiErr := repo.ChunkedUploadSupported("my-cool-app-guid")
+			Expect(apiErr).NotTo(HaveOccurred())
+			Expect(supported).To(BeTrue())
+		})
+
+		It("returns false when the cloud controller does not know the chunk collection", func() {
+			setupTestServer(testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method: "GET",
+				Path:   "/v2/apps/my-cool-app-guid/bits/chunks",
+				Response: testnet.TestResponse{
+					Status: http.StatusNotFound,
+					Body:   `{"code": 10000, "description": "Unknown request", "error_code": "CF-NotFound"}`,
+				},
+			}))
+
+			supported, apiErr := repo.ChunkedUploadSupported("my-cool-app-guid")
+			Expect(apiErr).NotTo(HaveOccurred())
+			Expect(supported).To(BeFalse())
+		})
+
+		It("returns any other error", func() {
+			setupTestServer(testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method:   "GET",
+				Path:     "/v2/apps/my-cool-app-guid/bits/chunks",
+				Response: testnet.TestResponse{Status: http.StatusInternalServerError},
+			}))
+
+			_, apiErr := repo.ChunkedUploadSupported("my-cool-app-guid")
+			Expect(apiErr).To(HaveOccurred())
+		})
+	})
+
+	Describe(".UploadBitsChunk", func() {
+		AfterEach(func() {
+			testServer.Close()
+		})
+
+		It("uploads the chunk with its range and checksum", func() {
+			setupTestServer(testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method: "PUT",
+				Path:   "/v2/apps/my-cool-app-guid/bits/chunks/1",
+				Matcher: func(request *http.Request) {
+					defer GinkgoRecover()
+					Expect(request.Header.Get("Content-Type")).To(Equal("application/octet-stream"))
+					Expect(request.Header.Get("Content-Range")).To(Equal("bytes 4-7/*"))
+					Expect(request.Header.Get("X-Cf-Chunk-Sha1")).To(Equal("my-chunk-sha"))
+
+					body, err := ioutil.ReadAll(request.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(Equal("4567"))
+				},
+				Response: testnet.TestResponse{Status: http.StatusCreated},
+			}))
+
+			chunk := resources.AppBitsChunkResource{Index: 1, Offset: 4, Size: 4, Sha1: "my-chunk-sha"}
+			apiErr := repo.UploadBitsChunk("my-cool-app-guid", chunk, strings.NewReader("4567"))
+			Expect(apiErr).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe(".CompleteChunkedUpload", func() {
+		AfterEach(func() {
+			testServer.Close()
+		})
+
+		It("asks the cloud controller to assemble the chunks and waits for the job", func() {
+			setupTestServer(testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method: "PUT",
+				Path:   "/v2/apps/my-cool-app-guid/bits/chunks",
+				Matcher: testnet.RequestBodyMatcher(`{
+					"resources": [],
+					"chunks": [{"index": 0, "offset": 0, "size": 4, "sha1": "my-chunk-sha"}]
+				}`),
+				Response: testnet.TestResponse{
+					Status: http.StatusCreated,
+					Body: `
+					{
+						"metadata":{
+							"guid": "my-job-guid",
+							"url": "/v2/jobs/my-job-guid"
+						}
+					}`,
+				},
+			}),
+				createProgressEndpoint("running"),
+				createProgressEndpoint("finished"),
+			)
+
+			chunks := []resources.AppBitsChunkResource{{Index: 0, Offset: 0, Size: 4, Sha1: "my-chunk-sha"}}
+			apiErr := repo.CompleteChunkedUpload("my-cool-app-guid", chunks, nil)
+			Expect(apiErr).NotTo(HaveOccurred())
+		})
+
+		It("returns a failure when assembling the chunks fails", func() {
+			setupTestServer(testapi.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method: "PUT",
+				Path:   "/v2/apps/my-cool-app-guid/bits/chunks",
+				Response: testnet.TestResponse{
+					Status: http.StatusCreated,
+					Body: `
+					{
+						"metadata":{
+							"guid": "my-job-guid",
+							"url": "/v2/jobs/my-job-guid"
+						}
+					}`,
+				},
+			}),
+				createProgressEndpoint("running"),
+				createProgressEndpoint("failed"),
+			)
+
+			apiErr := repo.CompleteChunkedUpload("my-cool-app-guid", []resources.AppBitsChunkResource{}, nil)
+			Expect(apiErr).To(HaveOccurred())
+		})
+	})
+
 	Describe(".GetApplicationFiles", func() {
 		It("accepts a slice of files and returns a slice of the files that it already has", func() {
 			setupTestServer(matchResourceRequest)
@@ -2,6 +2,7 @@
 package applicationbitsfakes
 
 import (
+	"io"
 	"os"
 	"sync"
 
@@ -29,6 +30,37 @@ type FakeApplicationBitsRepository struct {
 	uploadBitsReturns struct {
 		result1 error
 	}
+	UploadBitsChunkStub        func(appGUID string, chunk resources.AppBitsChunkResource, body io.ReadSeeker) error
+	uploadBitsChunkMutex       sync.RWMutex
+	uploadBitsChunkArgsForCall []struct {
+		appGUID string
+		chunk   resources.AppBitsChunkResource
+		body    io.ReadSeeker
+	}
+	uploadBitsChunkReturns struct {
+		result1 error
+	}
+	CompleteChunkedUploadStub        func(appGUID string, chunks []resources.AppBitsChunkResource, presentFiles []resources.AppFileResource) error
+	completeChunkedUploadMutex       sync.RWMutex
+	completeChunkedUploadArgsForCall []struct {
+		appGUID      string
+		chunks       []resources.AppBitsChunkResource
+		presentFiles []resources.AppFileResource
+	}
+	completeChunkedUploadReturns struct {
+		result1 error
+	}
+	ChunkedUploadSupportedStub        func(appGUID string) (bool, error)
+	chunkedUploadSupportedMutex       sync.RWMutex
+	chunkedUploadSupportedArgsForCall []struct {
+		appGUID string
+	}
+	chunkedUploadSupportedReturns struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
 }
 
 func (fake *FakeApplicationBitsRepository) GetApplicationFiles(appFilesRequest []resources.AppFileResource) ([]resources.AppFileResource, error) {
@@ -41,6 +73,7 @@ func (fake *FakeApplicationBitsRepository) GetApplicationFiles(appFilesRequest [
 	fake.getApplicationFilesArgsForCall = append(fake.getApplicationFilesArgsForCall, struct {
 		appFilesRequest []resources.AppFileResource
 	}{appFilesRequestCopy})
+	fake.recordInvocation("GetApplicationFiles", []interface{}{appFilesRequestCopy})
 	fake.getApplicationFilesMutex.Unlock()
 	if fake.GetApplicationFilesStub != nil {
 		return fake.GetApplicationFilesStub(appFilesRequest)
@@ -81,6 +114,7 @@ func (fake *FakeApplicationBitsRepository) UploadBits(appGUID string, zipFile *o
 		zipFile      *os.File
 		presentFiles []resources.AppFileResource
 	}{appGUID, zipFile, presentFilesCopy})
+	fake.recordInvocation("UploadBits", []interface{}{appGUID, zipFile, presentFilesCopy})
 	fake.uploadBitsMutex.Unlock()
 	if fake.UploadBitsStub != nil {
 		return fake.UploadBitsStub(appGUID, zipFile, presentFiles)
@@ -108,4 +142,146 @@ func (fake *FakeApplicationBitsRepository) UploadBitsReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeApplicationBitsRepository) UploadBitsChunk(appGUID string, chunk resources.AppBitsChunkResource, body io.ReadSeeker) error {
+	fake.uploadBitsChunkMutex.Lock()
+	fake.uploadBitsChunkArgsForCall = append(fake.uploadBitsChunkArgsForCall, struct {
+		appGUID string
+		chunk   resources.AppBitsChunkResource
+		body    io.ReadSeeker
+	}{appGUID, chunk, body})
+	fake.recordInvocation("UploadBitsChunk", []interface{}{appGUID, chunk, body})
+	fake.uploadBitsChunkMutex.Unlock()
+	if fake.UploadBitsChunkStub != nil {
+		return fake.UploadBitsChunkStub(appGUID, chunk, body)
+	} else {
+		return fake.uploadBitsChunkReturns.result1
+	}
+}
+
+func (fake *FakeApplicationBitsRepository) UploadBitsChunkCallCount() int {
+	fake.uploadBitsChunkMutex.RLock()
+	defer fake.uploadBitsChunkMutex.RUnlock()
+	return len(fake.uploadBitsChunkArgsForCall)
+}
+
+func (fake *FakeApplicationBitsRepository) UploadBitsChunkArgsForCall(i int) (string, resources.AppBitsChunkResource, io.ReadSeeker) {
+	fake.uploadBitsChunkMutex.RLock()
+	defer fake.uploadBitsChunkMutex.RUnlock()
+	return fake.uploadBitsChunkArgsForCall[i].appGUID, fake.uploadBitsChunkArgsForCall[i].chunk, fake.uploadBitsChunkArgsForCall[i].body
+}
+
+func (fake *FakeApplicationBitsRepository) UploadBitsChunkReturns(result1 error) {
+	fake.UploadBitsChunkStub = nil
+	fake.uploadBitsChunkReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationBitsRepository) CompleteChunkedUpload(appGUID string, chunks []resources.AppBitsChunkResource, presentFiles []resources.AppFileResource) error {
+	var chunksCopy []resources.AppBitsChunkResource
+	if chunks != nil {
+		chunksCopy = make([]resources.AppBitsChunkResource, len(chunks))
+		copy(chunksCopy, chunks)
+	}
+	var presentFilesCopy []resources.AppFileResource
+	if presentFiles != nil {
+		presentFilesCopy = make([]resources.AppFileResource, len(presentFiles))
+		copy(presentFilesCopy, presentFiles)
+	}
+	fake.completeChunkedUploadMutex.Lock()
+	fake.completeChunkedUploadArgsForCall = append(fake.completeChunkedUploadArgsForCall, struct {
+		appGUID      string
+		chunks       []resources.AppBitsChunkResource
+		presentFiles []resources.AppFileResource
+	}{appGUID, chunksCopy, presentFilesCopy})
+	fake.recordInvocation("CompleteChunkedUpload", []interface{}{appGUID, chunksCopy, presentFilesCopy})
+	fake.completeChunkedUploadMutex.Unlock()
+	if fake.CompleteChunkedUploadStub != nil {
+		return fake.CompleteChunkedUploadStub(appGUID, chunks, presentFiles)
+	} else {
+		return fake.completeChunkedUploadReturns.result1
+	}
+}
+
+func (fake *FakeApplicationBitsRepository) CompleteChunkedUploadCallCount() int {
+	fake.completeChunkedUploadMutex.RLock()
+	defer fake.completeChunkedUploadMutex.RUnlock()
+	return len(fake.completeChunkedUploadArgsForCall)
+}
+
+func (fake *FakeApplicationBitsRepository) CompleteChunkedUploadArgsForCall(i int) (string, []resources.AppBitsChunkResource, []resources.AppFileResource) {
+	fake.completeChunkedUploadMutex.RLock()
+	defer fake.completeChunkedUploadMutex.RUnlock()
+	return fake.completeChunkedUploadArgsForCall[i].appGUID, fake.completeChunkedUploadArgsForCall[i].chunks, fake.completeChunkedUploadArgsForCall[i].presentFiles
+}
+
+func (fake *FakeApplicationBitsRepository) CompleteChunkedUploadReturns(result1 error) {
+	fake.CompleteChunkedUploadStub = nil
+	fake.completeChunkedUploadReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeApplicationBitsRepository) ChunkedUploadSupported(appGUID string) (bool, error) {
+	fake.chunkedUploadSupportedMutex.Lock()
+	fake.chunkedUploadSupportedArgsForCall = append(fake.chunkedUploadSupportedArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("ChunkedUploadSupported", []interface{}{appGUID})
+	fake.chunkedUploadSupportedMutex.Unlock()
+	if fake.ChunkedUploadSupportedStub != nil {
+		return fake.ChunkedUploadSupportedStub(appGUID)
+	} else {
+		return fake.chunkedUploadSupportedReturns.result1, fake.chunkedUploadSupportedReturns.result2
+	}
+}
+
+func (fake *FakeApplicationBitsRepository) ChunkedUploadSupportedCallCount() int {
+	fake.chunkedUploadSupportedMutex.RLock()
+	defer fake.chunkedUploadSupportedMutex.RUnlock()
+	return len(fake.chunkedUploadSupportedArgsForCall)
+}
+
+func (fake *FakeApplicationBitsRepository) ChunkedUploadSupportedArgsForCall(i int) string {
+	fake.chunkedUploadSupportedMutex.RLock()
+	defer fake.chunkedUploadSupportedMutex.RUnlock()
+	return fake.chunkedUploadSupportedArgsForCall[i].appGUID
+}
+
+func (fake *FakeApplicationBitsRepository) ChunkedUploadSupportedReturns(result1 bool, result2 error) {
+	fake.ChunkedUploadSupportedStub = nil
+	fake.chunkedUploadSupportedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeApplicationBitsRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getApplicationFilesMutex.RLock()
+	defer fake.getApplicationFilesMutex.RUnlock()
+	fake.uploadBitsMutex.RLock()
+	defer fake.uploadBitsMutex.RUnlock()
+	fake.uploadBitsChunkMutex.RLock()
+	defer fake.uploadBitsChunkMutex.RUnlock()
+	fake.completeChunkedUploadMutex.RLock()
+	defer fake.completeChunkedUploadMutex.RUnlock()
+	fake.chunkedUploadSupportedMutex.RLock()
+	defer fake.chunkedUploadSupportedMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeApplicationBitsRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
 var _ applicationbits.Repository = new(FakeApplicationBitsRepository)
@@ -2,6 +2,7 @@
 package applicationbitsfakes
 
 import (
+	"io"
 	"os"
 	"sync"
 
@@ -29,6 +30,35 @@ type FakeRepository struct {
 	uploadBitsReturns struct {
 		result1 error
 	}
+	UploadBitsChunkStub        func(appGUID string, chunk resources.AppBitsChunkResource, body io.ReadSeeker) error
+	uploadBitsChunkMutex       sync.RWMutex
+	uploadBitsChunkArgsForCall []struct {
+		appGUID string
+		chunk   resources.AppBitsChunkResource
+		body    io.ReadSeeker
+	}
+	uploadBitsChunkReturns struct {
+		result1 error
+	}
+	CompleteChunkedUploadStub        func(appGUID string, chunks []resources.AppBitsChunkResource, presentFiles []resources.AppFileResource) error
+	completeChunkedUploadMutex       sync.RWMutex
+	completeChunkedUploadArgsForCall []struct {
+		appGUID      string
+		chunks       []resources.AppBitsChunkResource
+		presentFiles []resources.AppFileResource
+	}
+	completeChunkedUploadReturns struct {
+		result1 error
+	}
+	ChunkedUploadSupportedStub        func(appGUID string) (bool, error)
+	chunkedUploadSupportedMutex       sync.RWMutex
+	chunkedUploadSupportedArgsForCall []struct {
+		appGUID string
+	}
+	chunkedUploadSupportedReturns struct {
+		result1 bool
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -112,6 +142,120 @@ func (fake *FakeRepository) UploadBitsReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeRepository) UploadBitsChunk(appGUID string, chunk resources.AppBitsChunkResource, body io.ReadSeeker) error {
+	fake.uploadBitsChunkMutex.Lock()
+	fake.uploadBitsChunkArgsForCall = append(fake.uploadBitsChunkArgsForCall, struct {
+		appGUID string
+		chunk   resources.AppBitsChunkResource
+		body    io.ReadSeeker
+	}{appGUID, chunk, body})
+	fake.recordInvocation("UploadBitsChunk", []interface{}{appGUID, chunk, body})
+	fake.uploadBitsChunkMutex.Unlock()
+	if fake.UploadBitsChunkStub != nil {
+		return fake.UploadBitsChunkStub(appGUID, chunk, body)
+	} else {
+		return fake.uploadBitsChunkReturns.result1
+	}
+}
+
+func (fake *FakeRepository) UploadBitsChunkCallCount() int {
+	fake.uploadBitsChunkMutex.RLock()
+	defer fake.uploadBitsChunkMutex.RUnlock()
+	return len(fake.uploadBitsChunkArgsForCall)
+}
+
+func (fake *FakeRepository) UploadBitsChunkArgsForCall(i int) (string, resources.AppBitsChunkResource, io.ReadSeeker) {
+	fake.uploadBitsChunkMutex.RLock()
+	defer fake.uploadBitsChunkMutex.RUnlock()
+	return fake.uploadBitsChunkArgsForCall[i].appGUID, fake.uploadBitsChunkArgsForCall[i].chunk, fake.uploadBitsChunkArgsForCall[i].body
+}
+
+func (fake *FakeRepository) UploadBitsChunkReturns(result1 error) {
+	fake.UploadBitsChunkStub = nil
+	fake.uploadBitsChunkReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) CompleteChunkedUpload(appGUID string, chunks []resources.AppBitsChunkResource, presentFiles []resources.AppFileResource) error {
+	var chunksCopy []resources.AppBitsChunkResource
+	if chunks != nil {
+		chunksCopy = make([]resources.AppBitsChunkResource, len(chunks))
+		copy(chunksCopy, chunks)
+	}
+	var presentFilesCopy []resources.AppFileResource
+	if presentFiles != nil {
+		presentFilesCopy = make([]resources.AppFileResource, len(presentFiles))
+		copy(presentFilesCopy, presentFiles)
+	}
+	fake.completeChunkedUploadMutex.Lock()
+	fake.completeChunkedUploadArgsForCall = append(fake.completeChunkedUploadArgsForCall, struct {
+		appGUID      string
+		chunks       []resources.AppBitsChunkResource
+		presentFiles []resources.AppFileResource
+	}{appGUID, chunksCopy, presentFilesCopy})
+	fake.recordInvocation("CompleteChunkedUpload", []interface{}{appGUID, chunksCopy, presentFilesCopy})
+	fake.completeChunkedUploadMutex.Unlock()
+	if fake.CompleteChunkedUploadStub != nil {
+		return fake.CompleteChunkedUploadStub(appGUID, chunks, presentFiles)
+	} else {
+		return fake.completeChunkedUploadReturns.result1
+	}
+}
+
+func (fake *FakeRepository) CompleteChunkedUploadCallCount() int {
+	fake.completeChunkedUploadMutex.RLock()
+	defer fake.completeChunkedUploadMutex.RUnlock()
+	return len(fake.completeChunkedUploadArgsForCall)
+}
+
+func (fake *FakeRepository) CompleteChunkedUploadArgsForCall(i int) (string, []resources.AppBitsChunkResource, []resources.AppFileResource) {
+	fake.completeChunkedUploadMutex.RLock()
+	defer fake.completeChunkedUploadMutex.RUnlock()
+	return fake.completeChunkedUploadArgsForCall[i].appGUID, fake.completeChunkedUploadArgsForCall[i].chunks, fake.completeChunkedUploadArgsForCall[i].presentFiles
+}
+
+func (fake *FakeRepository) CompleteChunkedUploadReturns(result1 error) {
+	fake.CompleteChunkedUploadStub = nil
+	fake.completeChunkedUploadReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) ChunkedUploadSupported(appGUID string) (bool, error) {
+	fake.chunkedUploadSupportedMutex.Lock()
+	fake.chunkedUploadSupportedArgsForCall = append(fake.chunkedUploadSupportedArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("ChunkedUploadSupported", []interface{}{appGUID})
+	fake.chunkedUploadSupportedMutex.Unlock()
+	if fake.ChunkedUploadSupportedStub != nil {
+		return fake.ChunkedUploadSupportedStub(appGUID)
+	} else {
+		return fake.chunkedUploadSupportedReturns.result1, fake.chunkedUploadSupportedReturns.result2
+	}
+}
+
+func (fake *FakeRepository) ChunkedUploadSupportedCallCount() int {
+	fake.chunkedUploadSupportedMutex.RLock()
+	defer fake.chunkedUploadSupportedMutex.RUnlock()
+	return len(fake.chunkedUploadSupportedArgsForCall)
+}
+
+func (fake *FakeRepository) ChunkedUploadSupportedArgsForCall(i int) string {
+	fake.chunkedUploadSupportedMutex.RLock()
+	defer fake.chunkedUploadSupportedMutex.RUnlock()
+	return fake.chunkedUploadSupportedArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) ChunkedUploadSupportedReturns(result1 bool, result2 error) {
+	fake.ChunkedUploadSupportedStub = nil
+	fake.chunkedUploadSupportedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -119,6 +263,12 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.getApplicationFilesMutex.RUnlock()
 	fake.uploadBitsMutex.RLock()
 	defer fake.uploadBitsMutex.RUnlock()
+	fake.uploadBitsChunkMutex.RLock()
+	defer fake.uploadBitsChunkMutex.RUnlock()
+	fake.completeChunkedUploadMutex.RLock()
+	defer fake.completeChunkedUploadMutex.RUnlock()
+	fake.chunkedUploadSupportedMutex.RLock()
+	defer fake.chunkedUploadSupportedMutex.RUnlock()
 	return fake.invocations
 }
 
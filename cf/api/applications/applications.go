@@ -27,6 +27,9 @@ type Repository interface {
 	Delete(appGUID string) (apiErr error)
 	ReadEnv(guid string) (*models.Environment, error)
 	CreateRestageRequest(guid string) (apiErr error)
+	CreateWithLifecycle(name string, spaceGUID string, lifecycle models.AppLifecycle) (models.Application, error)
+	GetLifecycle(appGUID string) (models.AppLifecycle, error)
+	SetLifecycle(appGUID string, lifecycle models.AppLifecycle) error
 }
 
 type CloudControllerRepository struct {
@@ -134,3 +137,69 @@ func (repo CloudControllerRepository) CreateRestageRequest(guid string) error {
 	path := fmt.Sprintf("/v2/apps/%s/restage", guid)
 	return repo.gateway.CreateResource(repo.config.APIEndpoint(), path, strings.NewReader(""), nil)
 }
+
+// CreateWithLifecycle creates the app through the v3 apps API, since the v2
+// apps API cannot create apps with any lifecycle other than buildpack or
+// docker, and the lifecycle type of an app cannot be changed later.
+func (repo CloudControllerRepository) CreateWithLifecycle(name string, spaceGUID string, lifecycle models.AppLifecycle) (models.Application, error) {
+	app := resources.V3AppCreateResource{
+		Name:                 name,
+		AppLifecycleResource: resources.NewAppLifecycleResource(lifecycle),
+	}
+	app.Relationships.Space.Data.GUID = spaceGUID
+
+	body, err := json.Marshal(app)
+	if err != nil {
+		return models.Application{}, err
+	}
+
+	request, err := repo.gateway.NewRequest("POST", repo.config.APIEndpoint()+"/v3/apps", repo.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return models.Application{}, err
+	}
+
+	created := resources.V3AppResource{}
+	_, err = repo.gateway.PerformRequestForJSONResponse(request, &created)
+	if err != nil {
+		return models.Application{}, err
+	}
+
+	return models.Application{
+		ApplicationFields: models.ApplicationFields{
+			GUID:      created.GUID,
+			Name:      created.Name,
+			SpaceGUID: spaceGUID,
+		},
+	}, nil
+}
+
+// GetLifecycle returns how the app is staged, which only the v3 apps API
+// knows.
+func (repo CloudControllerRepository) GetLifecycle(appGUID string) (models.AppLifecycle, error) {
+	app := resources.V3AppResource{}
+	err := repo.gateway.GetResource(fmt.Sprintf("%s/v3/apps/%s", repo.config.APIEndpoint(), appGUID), &app)
+	if err != nil {
+		return models.AppLifecycle{}, err
+	}
+
+	return app.ToLifecycleModel(), nil
+}
+
+// SetLifecycle sets the buildpacks the app is staged with, in the order they
+// run, and the credentials of the registries they are pulled from, through
+// the v3 apps API. The v2 apps API only knows a single buildpack. No
+// buildpacks at all means the buildpack is detected.
+func (repo CloudControllerRepository) SetLifecycle(appGUID string, lifecycle models.AppLifecycle) error {
+	body, err := json.Marshal(resources.NewAppLifecycleResource(lifecycle))
+	if err != nil {
+		return err
+	}
+
+	request, err := repo.gateway.NewRequest("PATCH", fmt.Sprintf("%s/v3/apps/%s", repo.config.APIEndpoint(), appGUID), repo.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.gateway.PerformRequest(request)
+	return err
+}
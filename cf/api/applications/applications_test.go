@@ -315,6 +315,99 @@ var _ = Describe("ApplicationsRepository", func() {
 		})
 	})
 
+	Describe("creating applications with a lifecycle", func() {
+		It("POSTs a v3 app", func() {
+			request := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method:  "POST",
+				Path:    "/v3/apps",
+				Matcher: testnet.RequestBodyMatcher(`{"name":"my-cool-app","relationships":{"space":{"data":{"guid":"my-space-guid"}}},"lifecycle":{"type":"cnb","data":{"buildpacks":["docker://example.com/go-cnb"],"credentials":{"example.com":{"username":"some-user","password":"some-password"}}}}}`),
+				Response: testnet.TestResponse{
+					Status: http.StatusCreated,
+					Body:   `{"guid":"my-cool-app-guid","name":"my-cool-app"}`,
+				},
+			})
+
+			ts, handler, repo := createAppRepo([]testnet.TestRequest{request})
+			defer ts.Close()
+
+			app, err := repo.CreateWithLifecycle("my-cool-app", "my-space-guid", models.AppLifecycle{
+				Type:       models.AppLifecycleCNB,
+				Buildpacks: []string{"docker://example.com/go-cnb"},
+				Credentials: map[string]models.RegistryCredentials{
+					"example.com": {Username: "some-user", Password: "some-password"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(handler).To(HaveAllRequestsCalled())
+			Expect(app.GUID).To(Equal("my-cool-app-guid"))
+			Expect(app.Name).To(Equal("my-cool-app"))
+			Expect(app.SpaceGUID).To(Equal("my-space-guid"))
+		})
+	})
+
+	Describe("getting the lifecycle of applications", func() {
+		It("reads the lifecycle of the v3 app", func() {
+			request := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method: "GET",
+				Path:   "/v3/apps/some-cool-app-guid",
+				Response: testnet.TestResponse{
+					Status: http.StatusOK,
+					Body:   `{"guid":"some-cool-app-guid","name":"my-cool-app","lifecycle":{"type":"buildpack","data":{"buildpacks":["ruby_buildpack"],"stack":"cflinuxfs3"}}}`,
+				},
+			})
+
+			ts, handler, repo := createAppRepo([]testnet.TestRequest{request})
+			defer ts.Close()
+
+			lifecycle, err := repo.GetLifecycle("some-cool-app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(handler).To(HaveAllRequestsCalled())
+			Expect(lifecycle).To(Equal(models.AppLifecycle{
+				Type:       models.AppLifecycleBuildpack,
+				Buildpacks: []string{"ruby_buildpack"},
+			}))
+		})
+	})
+
+	Describe("setting the lifecycle of applications", func() {
+		It("PATCHes the lifecycle of the v3 app", func() {
+			request := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method:  "PATCH",
+				Path:    "/v3/apps/some-cool-app-guid",
+				Matcher: testnet.RequestBodyMatcher(`{"lifecycle":{"type":"buildpack","data":{"buildpacks":["nodejs_buildpack","https://github.com/example/apm-buildpack.git"]}}}`),
+				Response: testnet.TestResponse{
+					Status: http.StatusOK,
+					Body:   `{"guid":"some-cool-app-guid"}`,
+				},
+			})
+
+			ts, handler, repo := createAppRepo([]testnet.TestRequest{request})
+			defer ts.Close()
+
+			err := repo.SetLifecycle("some-cool-app-guid", models.AppLifecycle{
+				Type:       models.AppLifecycleBuildpack,
+				Buildpacks: []string{"nodejs_buildpack", "https://github.com/example/apm-buildpack.git"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(handler).To(HaveAllRequestsCalled())
+		})
+
+		It("sends an empty list to detect the buildpack", func() {
+			request := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method:   "PATCH",
+				Path:     "/v3/apps/some-cool-app-guid",
+				Matcher:  testnet.RequestBodyMatcher(`{"lifecycle":{"type":"buildpack","data":{"buildpacks":[]}}}`),
+				Response: testnet.TestResponse{Status: http.StatusOK, Body: `{}`},
+			})
+
+			ts, handler, repo := createAppRepo([]testnet.TestRequest{request})
+			defer ts.Close()
+
+			Expect(repo.SetLifecycle("some-cool-app-guid", models.AppLifecycle{Type: models.AppLifecycleBuildpack})).To(Succeed())
+			Expect(handler).To(HaveAllRequestsCalled())
+		})
+	})
+
 	Describe("updating applications", func() {
 		It("makes the right request", func() {
 			ts, handler, repo := createAppRepo([]testnet.TestRequest{updateApplicationRequest})
@@ -81,6 +81,35 @@ type FakeRepository struct {
 	createRestageRequestReturns struct {
 		result1 error
 	}
+	CreateWithLifecycleStub        func(name string, spaceGUID string, lifecycle models.AppLifecycle) (models.Application, error)
+	createWithLifecycleMutex       sync.RWMutex
+	createWithLifecycleArgsForCall []struct {
+		name      string
+		spaceGUID string
+		lifecycle models.AppLifecycle
+	}
+	createWithLifecycleReturns struct {
+		result1 models.Application
+		result2 error
+	}
+	SetLifecycleStub        func(appGUID string, lifecycle models.AppLifecycle) error
+	setLifecycleMutex       sync.RWMutex
+	setLifecycleArgsForCall []struct {
+		appGUID   string
+		lifecycle models.AppLifecycle
+	}
+	setLifecycleReturns struct {
+		result1 error
+	}
+	GetLifecycleStub        func(appGUID string) (models.AppLifecycle, error)
+	getLifecycleMutex       sync.RWMutex
+	getLifecycleArgsForCall []struct {
+		appGUID string
+	}
+	getLifecycleReturns struct {
+		result1 models.AppLifecycle
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -357,6 +386,110 @@ func (fake *FakeRepository) CreateRestageRequestReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeRepository) CreateWithLifecycle(name string, spaceGUID string, lifecycle models.AppLifecycle) (models.Application, error) {
+	fake.createWithLifecycleMutex.Lock()
+	fake.createWithLifecycleArgsForCall = append(fake.createWithLifecycleArgsForCall, struct {
+		name      string
+		spaceGUID string
+		lifecycle models.AppLifecycle
+	}{name, spaceGUID, lifecycle})
+	fake.recordInvocation("CreateWithLifecycle", []interface{}{name, spaceGUID, lifecycle})
+	fake.createWithLifecycleMutex.Unlock()
+	if fake.CreateWithLifecycleStub != nil {
+		return fake.CreateWithLifecycleStub(name, spaceGUID, lifecycle)
+	} else {
+		return fake.createWithLifecycleReturns.result1, fake.createWithLifecycleReturns.result2
+	}
+}
+
+func (fake *FakeRepository) CreateWithLifecycleCallCount() int {
+	fake.createWithLifecycleMutex.RLock()
+	defer fake.createWithLifecycleMutex.RUnlock()
+	return len(fake.createWithLifecycleArgsForCall)
+}
+
+func (fake *FakeRepository) CreateWithLifecycleArgsForCall(i int) (string, string, models.AppLifecycle) {
+	fake.createWithLifecycleMutex.RLock()
+	defer fake.createWithLifecycleMutex.RUnlock()
+	return fake.createWithLifecycleArgsForCall[i].name, fake.createWithLifecycleArgsForCall[i].spaceGUID, fake.createWithLifecycleArgsForCall[i].lifecycle
+}
+
+func (fake *FakeRepository) CreateWithLifecycleReturns(result1 models.Application, result2 error) {
+	fake.CreateWithLifecycleStub = nil
+	fake.createWithLifecycleReturns = struct {
+		result1 models.Application
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) SetLifecycle(appGUID string, lifecycle models.AppLifecycle) error {
+	fake.setLifecycleMutex.Lock()
+	fake.setLifecycleArgsForCall = append(fake.setLifecycleArgsForCall, struct {
+		appGUID   string
+		lifecycle models.AppLifecycle
+	}{appGUID, lifecycle})
+	fake.recordInvocation("SetLifecycle", []interface{}{appGUID, lifecycle})
+	fake.setLifecycleMutex.Unlock()
+	if fake.SetLifecycleStub != nil {
+		return fake.SetLifecycleStub(appGUID, lifecycle)
+	} else {
+		return fake.setLifecycleReturns.result1
+	}
+}
+
+func (fake *FakeRepository) SetLifecycleCallCount() int {
+	fake.setLifecycleMutex.RLock()
+	defer fake.setLifecycleMutex.RUnlock()
+	return len(fake.setLifecycleArgsForCall)
+}
+
+func (fake *FakeRepository) SetLifecycleArgsForCall(i int) (string, models.AppLifecycle) {
+	fake.setLifecycleMutex.RLock()
+	defer fake.setLifecycleMutex.RUnlock()
+	return fake.setLifecycleArgsForCall[i].appGUID, fake.setLifecycleArgsForCall[i].lifecycle
+}
+
+func (fake *FakeRepository) SetLifecycleReturns(result1 error) {
+	fake.SetLifecycleStub = nil
+	fake.setLifecycleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) GetLifecycle(appGUID string) (models.AppLifecycle, error) {
+	fake.getLifecycleMutex.Lock()
+	fake.getLifecycleArgsForCall = append(fake.getLifecycleArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("GetLifecycle", []interface{}{appGUID})
+	fake.getLifecycleMutex.Unlock()
+	if fake.GetLifecycleStub != nil {
+		return fake.GetLifecycleStub(appGUID)
+	} else {
+		return fake.getLifecycleReturns.result1, fake.getLifecycleReturns.result2
+	}
+}
+
+func (fake *FakeRepository) GetLifecycleCallCount() int {
+	fake.getLifecycleMutex.RLock()
+	defer fake.getLifecycleMutex.RUnlock()
+	return len(fake.getLifecycleArgsForCall)
+}
+
+func (fake *FakeRepository) GetLifecycleArgsForCall(i int) string {
+	fake.getLifecycleMutex.RLock()
+	defer fake.getLifecycleMutex.RUnlock()
+	return fake.getLifecycleArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) GetLifecycleReturns(result1 models.AppLifecycle, result2 error) {
+	fake.GetLifecycleStub = nil
+	fake.getLifecycleReturns = struct {
+		result1 models.AppLifecycle
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -376,6 +509,12 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.readEnvMutex.RUnlock()
 	fake.createRestageRequestMutex.RLock()
 	defer fake.createRestageRequestMutex.RUnlock()
+	fake.createWithLifecycleMutex.RLock()
+	defer fake.createWithLifecycleMutex.RUnlock()
+	fake.setLifecycleMutex.RLock()
+	defer fake.setLifecycleMutex.RUnlock()
+	fake.getLifecycleMutex.RLock()
+	defer fake.getLifecycleMutex.RUnlock()
 	return fake.invocations
 }
 
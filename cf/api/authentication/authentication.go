@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -27,7 +28,12 @@ type Repository interface {
 	net.RequestDumperInterface
 
 	RefreshAuthToken() (updatedToken string, apiErr error)
+	RefreshStaleAuthToken(staleToken string) (updatedToken string, apiErr error)
+	DeriveAuthToken(scopes []string) (string, error)
 	Authenticate(credentials map[string]string) (apiErr error)
+	AuthenticateClientCredentials(clientID, clientSecret string) (apiErr error)
+	AuthorizationCodeURL(redirectURI, state string) (string, error)
+	AuthenticateWithAuthorizationCode(code, redirectURI string) (apiErr error)
 	Authorize(token string) (string, error)
 	GetLoginPromptsAndSaveUAAServerURL() (map[string]coreconfig.AuthPrompt, error)
 }
@@ -36,15 +42,25 @@ type UAARepository struct {
 	config  coreconfig.ReadWriter
 	gateway net.Gateway
 	dumper  net.RequestDumper
+
+	// refreshMutex is shared by every copy of the repository, so that only
+	// one token refresh is in flight at a time.
+	refreshMutex *sync.Mutex
 }
 
 var ErrPreventRedirect = errors.New("prevent-redirect")
 
+const (
+	defaultClientID            = "cf"
+	clientCredentialsGrantType = "client_credentials"
+)
+
 func NewUAARepository(gateway net.Gateway, config coreconfig.ReadWriter, dumper net.RequestDumper) UAARepository {
 	return UAARepository{
-		config:  config,
-		gateway: gateway,
-		dumper:  dumper,
+		config:       config,
+		gateway:      gateway,
+		dumper:       dumper,
+		refreshMutex: new(sync.Mutex),
 	}
 }
 
@@ -119,24 +135,87 @@ func (uaa UAARepository) Authenticate(credentials map[string]string) error {
 		data[key] = []string{val}
 	}
 
-	err := uaa.getAuthToken(data)
+	err := uaa.getAuthToken(defaultClientID, "", data)
 	if err != nil {
-		httpError, ok := err.(errors.HTTPError)
-		if ok {
-			switch {
-			case httpError.StatusCode() == http.StatusUnauthorized:
-				return errors.New(T("Credentials were rejected, please try again."))
-			case httpError.StatusCode() >= http.StatusInternalServerError:
-				return errors.New(T("The targeted API endpoint could not be reached."))
-			}
-		}
+		return translateAuthenticationError(err)
+	}
 
-		return err
+	return nil
+}
+
+// AuthenticateClientCredentials gets a token for the client itself, rather
+// than for a user, with the client credentials grant. The client is saved in
+// the config so that the token can be renewed once it expires, since the
+// grant does not give a refresh token.
+func (uaa UAARepository) AuthenticateClientCredentials(clientID, clientSecret string) error {
+	data := url.Values{
+		"grant_type": {clientCredentialsGrantType},
+	}
+
+	err := uaa.getAuthToken(clientID, clientSecret, data)
+	if err != nil {
+		return translateAuthenticationError(err)
+	}
+
+	uaa.config.SetUAAGrantType(clientCredentialsGrantType)
+	uaa.config.SetUAAOAuthClient(clientID)
+	uaa.config.SetUAAOAuthClientSecret(clientSecret)
+
+	return nil
+}
+
+// AuthorizationCodeURL returns the page where the user logs in with single
+// sign-on, after which the browser is redirected to redirectURI with an
+// authorization code.
+func (uaa UAARepository) AuthorizationCodeURL(redirectURI, state string) (string, error) {
+	authorizeURL, err := url.Parse(uaa.config.UaaEndpoint())
+	if err != nil {
+		return "", err
+	}
+
+	values := url.Values{}
+	values.Set("response_type", "code")
+	values.Set("client_id", defaultClientID)
+	values.Set("redirect_uri", redirectURI)
+	values.Set("state", state)
+
+	authorizeURL.Path = "/oauth/authorize"
+	authorizeURL.RawQuery = values.Encode()
+
+	return authorizeURL.String(), nil
+}
+
+// AuthenticateWithAuthorizationCode exchanges the authorization code from a
+// single sign-on login for an access and a refresh token.
+func (uaa UAARepository) AuthenticateWithAuthorizationCode(code, redirectURI string) error {
+	data := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {redirectURI},
+	}
+
+	err := uaa.getAuthToken(defaultClientID, "", data)
+	if err != nil {
+		return translateAuthenticationError(err)
 	}
 
 	return nil
 }
 
+func translateAuthenticationError(err error) error {
+	httpError, ok := err.(errors.HTTPError)
+	if ok {
+		switch {
+		case httpError.StatusCode() == http.StatusUnauthorized:
+			return errors.New(T("Credentials were rejected, please try again."))
+		case httpError.StatusCode() >= http.StatusInternalServerError:
+			return errors.New(T("The targeted API endpoint could not be reached."))
+		}
+	}
+
+	return err
+}
+
 func (uaa UAARepository) DumpRequest(req *http.Request) {
 	uaa.dumper.DumpRequest(req)
 }
@@ -181,58 +260,130 @@ func (uaa UAARepository) GetLoginPromptsAndSaveUAAServerURL() (prompts map[strin
 }
 
 func (uaa UAARepository) RefreshAuthToken() (string, error) {
-	data := url.Values{
-		"refresh_token": {uaa.config.RefreshToken()},
-		"grant_type":    {"refresh_token"},
-		"scope":         {""},
+	uaa.refreshMutex.Lock()
+	defer uaa.refreshMutex.Unlock()
+
+	return uaa.refreshAuthToken()
+}
+
+// RefreshStaleAuthToken refreshes the token after a request made with
+// staleToken was rejected. When several requests are rejected at once, the
+// first one refreshes the token and the others get the token it saved,
+// rather than each of them refreshing it again.
+func (uaa UAARepository) RefreshStaleAuthToken(staleToken string) (string, error) {
+	uaa.refreshMutex.Lock()
+	defer uaa.refreshMutex.Unlock()
+
+	if currentToken := uaa.config.AccessToken(); currentToken != "" && currentToken != staleToken {
+		return currentToken, nil
 	}
 
-	apiErr := uaa.getAuthToken(data)
+	return uaa.refreshAuthToken()
+}
+
+func (uaa UAARepository) refreshAuthToken() (string, error) {
+	var apiErr error
+	if uaa.config.UAAGrantType() == clientCredentialsGrantType {
+		data := url.Values{
+			"grant_type": {clientCredentialsGrantType},
+		}
+		apiErr = uaa.getAuthToken(uaa.config.UAAOAuthClient(), uaa.config.UAAOAuthClientSecret(), data)
+	} else {
+		data := url.Values{
+			"refresh_token": {uaa.config.RefreshToken()},
+			"grant_type":    {"refresh_token"},
+			"scope":         {""},
+		}
+		apiErr = uaa.getAuthToken(defaultClientID, "", data)
+	}
 	updatedToken := uaa.config.AccessToken()
 
 	return updatedToken, apiErr
 }
 
-func (uaa UAARepository) getAuthToken(data url.Values) error {
-	type uaaErrorResponse struct {
-		Code        string `json:"error"`
-		Description string `json:"error_description"`
+// DeriveAuthToken gets a new access token for the logged in user or client
+// limited to scopes, without saving it or replacing the access token in the
+// config. UAA may rotate the refresh token it is derived with, which revokes
+// the old one, so a new refresh token is saved in the config.
+func (uaa UAARepository) DeriveAuthToken(scopes []string) (string, error) {
+	var (
+		response *authenticationResponse
+		err      error
+	)
+	if uaa.config.UAAGrantType() == clientCredentialsGrantType {
+		data := url.Values{
+			"grant_type": {clientCredentialsGrantType},
+			"scope":      {strings.Join(scopes, " ")},
+		}
+		response, err = uaa.requestAuthToken(uaa.config.UAAOAuthClient(), uaa.config.UAAOAuthClientSecret(), data)
+	} else {
+		data := url.Values{
+			"refresh_token": {uaa.config.RefreshToken()},
+			"grant_type":    {"refresh_token"},
+			"scope":         {strings.Join(scopes, " ")},
+		}
+		response, err = uaa.requestAuthToken(defaultClientID, "", data)
+	}
+	if err != nil {
+		return "", err
 	}
 
-	type AuthenticationResponse struct {
-		AccessToken  string           `json:"access_token"`
-		TokenType    string           `json:"token_type"`
-		RefreshToken string           `json:"refresh_token"`
-		Error        uaaErrorResponse `json:"error"`
+	if response.RefreshToken != "" && response.RefreshToken != uaa.config.RefreshToken() {
+		uaa.config.SetRefreshToken(response.RefreshToken)
 	}
 
+	return fmt.Sprintf("%s %s", response.TokenType, response.AccessToken), nil
+}
+
+type uaaErrorResponse struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+type authenticationResponse struct {
+	AccessToken  string           `json:"access_token"`
+	TokenType    string           `json:"token_type"`
+	RefreshToken string           `json:"refresh_token"`
+	Error        uaaErrorResponse `json:"error"`
+}
+
+func (uaa UAARepository) getAuthToken(clientID, clientSecret string, data url.Values) error {
+	response, err := uaa.requestAuthToken(clientID, clientSecret, data)
+	if err != nil {
+		return err
+	}
+
+	uaa.config.SetAccessToken(fmt.Sprintf("%s %s", response.TokenType, response.AccessToken))
+	uaa.config.SetRefreshToken(response.RefreshToken)
+
+	return nil
+}
+
+func (uaa UAARepository) requestAuthToken(clientID, clientSecret string, data url.Values) (*authenticationResponse, error) {
 	path := fmt.Sprintf("%s/oauth/token", uaa.config.AuthenticationEndpoint())
-	request, err := uaa.gateway.NewRequest("POST", path, "Basic "+base64.StdEncoding.EncodeToString([]byte("cf:")), strings.NewReader(data.Encode()))
+	request, err := uaa.gateway.NewRequest("POST", path, "Basic "+base64.StdEncoding.EncodeToString([]byte(clientID+":"+clientSecret)), strings.NewReader(data.Encode()))
 	if err != nil {
-		return fmt.Errorf("%s: %s", T("Failed to start oauth request"), err.Error())
+		return nil, fmt.Errorf("%s: %s", T("Failed to start oauth request"), err.Error())
 	}
 	request.HTTPReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	response := new(AuthenticationResponse)
+	response := new(authenticationResponse)
 	_, err = uaa.gateway.PerformRequestForJSONResponse(request, &response)
 
 	switch err.(type) {
 	case nil:
 	case errors.HTTPError:
-		return err
+		return nil, err
 	case *errors.InvalidTokenError:
-		return errors.New(T("Authentication has expired.  Please log back in to re-authenticate.\n\nTIP: Use `cf login -a <endpoint> -u <user> -o <org> -s <space>` to log back in and re-authenticate."))
+		return nil, errors.New(T("Authentication has expired.  Please log back in to re-authenticate.\n\nTIP: Use `cf login -a <endpoint> -u <user> -o <org> -s <space>` to log back in and re-authenticate."))
 	default:
-		return fmt.Errorf("%s: %s", T("auth request failed"), err.Error())
+		return nil, fmt.Errorf("%s: %s", T("auth request failed"), err.Error())
 	}
 
 	// TODO: get the actual status code
 	if response.Error.Code != "" {
-		return errors.NewHTTPError(0, response.Error.Code, response.Error.Description)
+		return nil, errors.NewHTTPError(0, response.Error.Code, response.Error.Description)
 	}
 
-	uaa.config.SetAccessToken(fmt.Sprintf("%s %s", response.TokenType, response.AccessToken))
-	uaa.config.SetRefreshToken(response.RefreshToken)
-
-	return nil
+	return response, nil
 }
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"sync"
 
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/net"
@@ -136,6 +138,88 @@ var _ = Describe("AuthenticationRepository", func() {
 			})
 		})
 
+		Describe("authenticating with client credentials", func() {
+			var err error
+
+			JustBeforeEach(func() {
+				err = auth.AuthenticateClientCredentials("some-client", "some-secret")
+			})
+
+			Context("when authentication succeeds", func() {
+				BeforeEach(func() {
+					setupTestServer(successfulClientCredentialsRequest)
+				})
+
+				It("stores the access token and the client in the config", func() {
+					Expect(handler).To(HaveAllRequestsCalled())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(config.AccessToken()).To(Equal("bearer client_access_token"))
+					Expect(config.RefreshToken()).To(BeEmpty())
+					Expect(config.UAAGrantType()).To(Equal("client_credentials"))
+					Expect(config.UAAOAuthClient()).To(Equal("some-client"))
+					Expect(config.UAAOAuthClientSecret()).To(Equal("some-secret"))
+				})
+			})
+
+			Context("when the credentials are rejected", func() {
+				BeforeEach(func() {
+					setupTestServer(unsuccessfulLoginRequest)
+				})
+
+				It("returns an error and does not store the client", func() {
+					Expect(handler).To(HaveAllRequestsCalled())
+					Expect(err).To(MatchError("Credentials were rejected, please try again."))
+					Expect(config.AccessToken()).To(BeEmpty())
+					Expect(config.UAAGrantType()).To(BeEmpty())
+					Expect(config.UAAOAuthClient()).To(BeEmpty())
+				})
+			})
+		})
+
+		Describe("authenticating with an authorization code", func() {
+			var err error
+
+			JustBeforeEach(func() {
+				err = auth.AuthenticateWithAuthorizationCode("some-code", "http://127.0.0.1:1234/callback")
+			})
+
+			Context("when the code is accepted", func() {
+				BeforeEach(func() {
+					request := successfulLoginRequest
+					request.Matcher = func(request *http.Request) {
+						err := request.ParseForm()
+						if err != nil {
+							Fail(fmt.Sprintf("Failed to parse form: %s", err))
+							return
+						}
+
+						Expect(request.Form.Get("grant_type")).To(Equal("authorization_code"))
+						Expect(request.Form.Get("code")).To(Equal("some-code"))
+						Expect(request.Form.Get("redirect_uri")).To(Equal("http://127.0.0.1:1234/callback"))
+					}
+					setupTestServer(request)
+				})
+
+				It("stores the access and refresh tokens in the config", func() {
+					Expect(handler).To(HaveAllRequestsCalled())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(config.AccessToken()).To(Equal("BEARER my_access_token"))
+					Expect(config.RefreshToken()).To(Equal("my_refresh_token"))
+				})
+			})
+
+			Context("when the code is rejected", func() {
+				BeforeEach(func() {
+					setupTestServer(unsuccessfulLoginRequest)
+				})
+
+				It("returns an error", func() {
+					Expect(handler).To(HaveAllRequestsCalled())
+					Expect(err).To(MatchError("Credentials were rejected, please try again."))
+				})
+			})
+		})
+
 		Describe("getting login info", func() {
 			var (
 				apiErr  error
@@ -220,6 +304,221 @@ var _ = Describe("AuthenticationRepository", func() {
 					Expect(apiErr).NotTo(BeNil())
 				})
 			})
+
+			Context("when authenticated with client credentials", func() {
+				BeforeEach(func() {
+					config.SetUAAGrantType("client_credentials")
+					config.SetUAAOAuthClient("some-client")
+					config.SetUAAOAuthClientSecret("some-secret")
+					setupTestServer(successfulClientCredentialsRequest)
+				})
+
+				It("gets a new token with the client credentials", func() {
+					Expect(handler).To(HaveAllRequestsCalled())
+					Expect(apiErr).NotTo(HaveOccurred())
+					Expect(config.AccessToken()).To(Equal("bearer client_access_token"))
+				})
+			})
+		})
+	})
+
+	Describe("RefreshStaleAuthToken", func() {
+		var (
+			uaaServer *ghttp.Server
+			config    coreconfig.ReadWriter
+			authRepo  Repository
+		)
+
+		BeforeEach(func() {
+			uaaServer = ghttp.NewServer()
+			config = testconfig.NewRepository()
+			config.SetAuthenticationEndpoint(uaaServer.URL())
+			config.SetAccessToken("bearer stale-access-token")
+			config.SetRefreshToken("some-refresh-token")
+
+			fakePrinter := new(tracefakes.FakePrinter)
+			gateway := net.NewUAAGateway(config, new(terminalfakes.FakeUI), fakePrinter, "")
+			authRepo = NewUAARepository(gateway, config, net.NewRequestDumper(fakePrinter))
+
+			uaaServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/oauth/token"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"access_token": "new-access-token",
+						"token_type": "bearer",
+						"refresh_token": "new-refresh-token"
+					}`),
+				),
+			)
+		})
+
+		AfterEach(func() {
+			uaaServer.Close()
+		})
+
+		It("refreshes the token when it is the stale one", func() {
+			token, err := authRepo.RefreshStaleAuthToken("bearer stale-access-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("bearer new-access-token"))
+			Expect(uaaServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("returns the current token when it has already been refreshed", func() {
+			config.SetAccessToken("bearer fresh-access-token")
+
+			token, err := authRepo.RefreshStaleAuthToken("bearer stale-access-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("bearer fresh-access-token"))
+			Expect(uaaServer.ReceivedRequests()).To(BeEmpty())
+		})
+
+		It("refreshes the token only once when requests are rejected at the same time", func() {
+			var wg sync.WaitGroup
+			tokens := make([]string, 5)
+			for i := range tokens {
+				wg.Add(1)
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					token, err := authRepo.RefreshStaleAuthToken("bearer stale-access-token")
+					Expect(err).NotTo(HaveOccurred())
+					tokens[i] = token
+				}(i)
+			}
+			wg.Wait()
+
+			Expect(uaaServer.ReceivedRequests()).To(HaveLen(1))
+			for _, token := range tokens {
+				Expect(token).To(Equal("bearer new-access-token"))
+			}
+		})
+	})
+
+	Describe("DeriveAuthToken", func() {
+		var (
+			uaaServer *ghttp.Server
+			config    coreconfig.ReadWriter
+			authRepo  Repository
+		)
+
+		BeforeEach(func() {
+			uaaServer = ghttp.NewServer()
+			config = testconfig.NewRepository()
+			config.SetAuthenticationEndpoint(uaaServer.URL())
+			config.SetAccessToken("bearer user-access-token")
+			config.SetRefreshToken("some-refresh-token")
+
+			fakePrinter := new(tracefakes.FakePrinter)
+			gateway := net.NewUAAGateway(config, new(terminalfakes.FakeUI), fakePrinter, "")
+			authRepo = NewUAARepository(gateway, config, net.NewRequestDumper(fakePrinter))
+		})
+
+		AfterEach(func() {
+			uaaServer.Close()
+		})
+
+		It("gets a token limited to the scopes with the refresh token, without saving it", func() {
+			uaaServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/oauth/token"),
+					ghttp.VerifyForm(url.Values{
+						"grant_type":    {"refresh_token"},
+						"refresh_token": {"some-refresh-token"},
+						"scope":         {"cloud_controller.read openid"},
+					}),
+					ghttp.RespondWith(http.StatusOK, `{
+						"access_token": "derived-access-token",
+						"token_type": "bearer",
+						"refresh_token": "some-refresh-token"
+					}`),
+				),
+			)
+
+			token, err := authRepo.DeriveAuthToken([]string{"cloud_controller.read", "openid"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("bearer derived-access-token"))
+			Expect(config.AccessToken()).To(Equal("bearer user-access-token"))
+			Expect(config.RefreshToken()).To(Equal("some-refresh-token"))
+		})
+
+		It("saves the refresh token when the UAA rotates it", func() {
+			uaaServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/oauth/token"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"access_token": "derived-access-token",
+						"token_type": "bearer",
+						"refresh_token": "rotated-refresh-token"
+					}`),
+				),
+			)
+
+			_, err := authRepo.DeriveAuthToken([]string{"cloud_controller.read"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.AccessToken()).To(Equal("bearer user-access-token"))
+			Expect(config.RefreshToken()).To(Equal("rotated-refresh-token"))
+		})
+
+		It("gets the token with the client credentials when authenticated with them", func() {
+			config.SetUAAGrantType("client_credentials")
+			config.SetUAAOAuthClient("some-client")
+			config.SetUAAOAuthClientSecret("some-secret")
+			uaaServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/oauth/token"),
+					ghttp.VerifyBasicAuth("some-client", "some-secret"),
+					ghttp.VerifyForm(url.Values{
+						"grant_type": {"client_credentials"},
+						"scope":      {"cloud_controller.read"},
+					}),
+					ghttp.RespondWith(http.StatusOK, `{
+						"access_token": "derived-access-token",
+						"token_type": "bearer"
+					}`),
+				),
+			)
+
+			token, err := authRepo.DeriveAuthToken([]string{"cloud_controller.read"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("bearer derived-access-token"))
+		})
+
+		It("returns the error from the UAA", func() {
+			uaaServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/oauth/token"),
+					ghttp.RespondWith(http.StatusBadRequest, `{
+						"error": "invalid_scope",
+						"error_description": "Invalid scopes: scim.write"
+					}`),
+				),
+			)
+
+			_, err := authRepo.DeriveAuthToken([]string{"scim.write"})
+			Expect(err).To(HaveOccurred())
+			Expect(config.AccessToken()).To(Equal("bearer user-access-token"))
+		})
+	})
+
+	Describe("AuthorizationCodeURL", func() {
+		It("returns the authorize page of the UAA for the cf client", func() {
+			config := testconfig.NewRepository()
+			config.SetUaaEndpoint("https://uaa.example.com")
+			authRepo := NewUAARepository(net.Gateway{}, config, net.NewRequestDumper(new(tracefakes.FakePrinter)))
+
+			authorizationURL, err := authRepo.AuthorizationCodeURL("http://127.0.0.1:1234/callback", "some-state")
+			Expect(err).NotTo(HaveOccurred())
+
+			parsedURL, err := url.Parse(authorizationURL)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsedURL.Host).To(Equal("uaa.example.com"))
+			Expect(parsedURL.Path).To(Equal("/oauth/authorize"))
+			Expect(parsedURL.Query()).To(Equal(url.Values{
+				"response_type": {"code"},
+				"client_id":     {"cf"},
+				"redirect_uri":  {"http://127.0.0.1:1234/callback"},
+				"state":         {"some-state"},
+			}))
 		})
 	})
 
@@ -360,6 +659,34 @@ var successfulLoginMatcher = func(request *http.Request) {
 	Expect(request.Form.Get("scope")).To(Equal(""))
 }
 
+var successfulClientCredentialsRequest = testnet.TestRequest{
+	Method: "POST",
+	Path:   "/oauth/token",
+	Header: http.Header{
+		"accept":        {"application/json"},
+		"content-type":  {"application/x-www-form-urlencoded"},
+		"authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte("some-client:some-secret"))},
+	},
+	Matcher: func(request *http.Request) {
+		err := request.ParseForm()
+		if err != nil {
+			Fail(fmt.Sprintf("Failed to parse form: %s", err))
+			return
+		}
+
+		Expect(request.Form.Get("grant_type")).To(Equal("client_credentials"))
+		Expect(request.Form.Get("refresh_token")).To(BeEmpty())
+	},
+	Response: testnet.TestResponse{
+		Status: http.StatusOK,
+		Body: `
+{
+  "access_token": "client_access_token",
+  "token_type": "bearer",
+  "expires_in": 43199
+} `},
+}
+
 var unsuccessfulLoginRequest = testnet.TestRequest{
 	Method: "POST",
 	Path:   "/oauth/token",
@@ -27,6 +27,24 @@ type FakeRepository struct {
 		result1 string
 		result2 error
 	}
+	RefreshStaleAuthTokenStub        func(staleToken string) (updatedToken string, apiErr error)
+	refreshStaleAuthTokenMutex       sync.RWMutex
+	refreshStaleAuthTokenArgsForCall []struct {
+		staleToken string
+	}
+	refreshStaleAuthTokenReturns struct {
+		result1 string
+		result2 error
+	}
+	DeriveAuthTokenStub        func(scopes []string) (string, error)
+	deriveAuthTokenMutex       sync.RWMutex
+	deriveAuthTokenArgsForCall []struct {
+		scopes []string
+	}
+	deriveAuthTokenReturns struct {
+		result1 string
+		result2 error
+	}
 	AuthenticateStub        func(credentials map[string]string) (apiErr error)
 	authenticateMutex       sync.RWMutex
 	authenticateArgsForCall []struct {
@@ -35,6 +53,34 @@ type FakeRepository struct {
 	authenticateReturns struct {
 		result1 error
 	}
+	AuthenticateClientCredentialsStub        func(clientID, clientSecret string) (apiErr error)
+	authenticateClientCredentialsMutex       sync.RWMutex
+	authenticateClientCredentialsArgsForCall []struct {
+		clientID     string
+		clientSecret string
+	}
+	authenticateClientCredentialsReturns struct {
+		result1 error
+	}
+	AuthorizationCodeURLStub        func(redirectURI, state string) (string, error)
+	authorizationCodeURLMutex       sync.RWMutex
+	authorizationCodeURLArgsForCall []struct {
+		redirectURI string
+		state       string
+	}
+	authorizationCodeURLReturns struct {
+		result1 string
+		result2 error
+	}
+	AuthenticateWithAuthorizationCodeStub        func(code, redirectURI string) (apiErr error)
+	authenticateWithAuthorizationCodeMutex       sync.RWMutex
+	authenticateWithAuthorizationCodeArgsForCall []struct {
+		code        string
+		redirectURI string
+	}
+	authenticateWithAuthorizationCodeReturns struct {
+		result1 error
+	}
 	AuthorizeStub        func(token string) (string, error)
 	authorizeMutex       sync.RWMutex
 	authorizeArgsForCall []struct {
@@ -129,6 +175,79 @@ func (fake *FakeRepository) RefreshAuthTokenReturns(result1 string, result2 erro
 	}{result1, result2}
 }
 
+func (fake *FakeRepository) RefreshStaleAuthToken(staleToken string) (updatedToken string, apiErr error) {
+	fake.refreshStaleAuthTokenMutex.Lock()
+	fake.refreshStaleAuthTokenArgsForCall = append(fake.refreshStaleAuthTokenArgsForCall, struct {
+		staleToken string
+	}{staleToken})
+	fake.recordInvocation("RefreshStaleAuthToken", []interface{}{staleToken})
+	fake.refreshStaleAuthTokenMutex.Unlock()
+	if fake.RefreshStaleAuthTokenStub != nil {
+		return fake.RefreshStaleAuthTokenStub(staleToken)
+	} else {
+		return fake.refreshStaleAuthTokenReturns.result1, fake.refreshStaleAuthTokenReturns.result2
+	}
+}
+
+func (fake *FakeRepository) RefreshStaleAuthTokenCallCount() int {
+	fake.refreshStaleAuthTokenMutex.RLock()
+	defer fake.refreshStaleAuthTokenMutex.RUnlock()
+	return len(fake.refreshStaleAuthTokenArgsForCall)
+}
+
+func (fake *FakeRepository) RefreshStaleAuthTokenArgsForCall(i int) string {
+	fake.refreshStaleAuthTokenMutex.RLock()
+	defer fake.refreshStaleAuthTokenMutex.RUnlock()
+	return fake.refreshStaleAuthTokenArgsForCall[i].staleToken
+}
+
+func (fake *FakeRepository) RefreshStaleAuthTokenReturns(result1 string, result2 error) {
+	fake.RefreshStaleAuthTokenStub = nil
+	fake.refreshStaleAuthTokenReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) DeriveAuthToken(scopes []string) (string, error) {
+	var scopesCopy []string
+	if scopes != nil {
+		scopesCopy = make([]string, len(scopes))
+		copy(scopesCopy, scopes)
+	}
+	fake.deriveAuthTokenMutex.Lock()
+	fake.deriveAuthTokenArgsForCall = append(fake.deriveAuthTokenArgsForCall, struct {
+		scopes []string
+	}{scopesCopy})
+	fake.recordInvocation("DeriveAuthToken", []interface{}{scopesCopy})
+	fake.deriveAuthTokenMutex.Unlock()
+	if fake.DeriveAuthTokenStub != nil {
+		return fake.DeriveAuthTokenStub(scopes)
+	} else {
+		return fake.deriveAuthTokenReturns.result1, fake.deriveAuthTokenReturns.result2
+	}
+}
+
+func (fake *FakeRepository) DeriveAuthTokenCallCount() int {
+	fake.deriveAuthTokenMutex.RLock()
+	defer fake.deriveAuthTokenMutex.RUnlock()
+	return len(fake.deriveAuthTokenArgsForCall)
+}
+
+func (fake *FakeRepository) DeriveAuthTokenArgsForCall(i int) []string {
+	fake.deriveAuthTokenMutex.RLock()
+	defer fake.deriveAuthTokenMutex.RUnlock()
+	return fake.deriveAuthTokenArgsForCall[i].scopes
+}
+
+func (fake *FakeRepository) DeriveAuthTokenReturns(result1 string, result2 error) {
+	fake.DeriveAuthTokenStub = nil
+	fake.deriveAuthTokenReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRepository) Authenticate(credentials map[string]string) (apiErr error) {
 	fake.authenticateMutex.Lock()
 	fake.authenticateArgsForCall = append(fake.authenticateArgsForCall, struct {
@@ -162,6 +281,109 @@ func (fake *FakeRepository) AuthenticateReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeRepository) AuthenticateClientCredentials(clientID string, clientSecret string) (apiErr error) {
+	fake.authenticateClientCredentialsMutex.Lock()
+	fake.authenticateClientCredentialsArgsForCall = append(fake.authenticateClientCredentialsArgsForCall, struct {
+		clientID     string
+		clientSecret string
+	}{clientID, clientSecret})
+	fake.recordInvocation("AuthenticateClientCredentials", []interface{}{clientID, clientSecret})
+	fake.authenticateClientCredentialsMutex.Unlock()
+	if fake.AuthenticateClientCredentialsStub != nil {
+		return fake.AuthenticateClientCredentialsStub(clientID, clientSecret)
+	} else {
+		return fake.authenticateClientCredentialsReturns.result1
+	}
+}
+
+func (fake *FakeRepository) AuthenticateClientCredentialsCallCount() int {
+	fake.authenticateClientCredentialsMutex.RLock()
+	defer fake.authenticateClientCredentialsMutex.RUnlock()
+	return len(fake.authenticateClientCredentialsArgsForCall)
+}
+
+func (fake *FakeRepository) AuthenticateClientCredentialsArgsForCall(i int) (string, string) {
+	fake.authenticateClientCredentialsMutex.RLock()
+	defer fake.authenticateClientCredentialsMutex.RUnlock()
+	return fake.authenticateClientCredentialsArgsForCall[i].clientID, fake.authenticateClientCredentialsArgsForCall[i].clientSecret
+}
+
+func (fake *FakeRepository) AuthenticateClientCredentialsReturns(result1 error) {
+	fake.AuthenticateClientCredentialsStub = nil
+	fake.authenticateClientCredentialsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) AuthorizationCodeURL(redirectURI string, state string) (string, error) {
+	fake.authorizationCodeURLMutex.Lock()
+	fake.authorizationCodeURLArgsForCall = append(fake.authorizationCodeURLArgsForCall, struct {
+		redirectURI string
+		state       string
+	}{redirectURI, state})
+	fake.recordInvocation("AuthorizationCodeURL", []interface{}{redirectURI, state})
+	fake.authorizationCodeURLMutex.Unlock()
+	if fake.AuthorizationCodeURLStub != nil {
+		return fake.AuthorizationCodeURLStub(redirectURI, state)
+	} else {
+		return fake.authorizationCodeURLReturns.result1, fake.authorizationCodeURLReturns.result2
+	}
+}
+
+func (fake *FakeRepository) AuthorizationCodeURLCallCount() int {
+	fake.authorizationCodeURLMutex.RLock()
+	defer fake.authorizationCodeURLMutex.RUnlock()
+	return len(fake.authorizationCodeURLArgsForCall)
+}
+
+func (fake *FakeRepository) AuthorizationCodeURLArgsForCall(i int) (string, string) {
+	fake.authorizationCodeURLMutex.RLock()
+	defer fake.authorizationCodeURLMutex.RUnlock()
+	return fake.authorizationCodeURLArgsForCall[i].redirectURI, fake.authorizationCodeURLArgsForCall[i].state
+}
+
+func (fake *FakeRepository) AuthorizationCodeURLReturns(result1 string, result2 error) {
+	fake.AuthorizationCodeURLStub = nil
+	fake.authorizationCodeURLReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) AuthenticateWithAuthorizationCode(code string, redirectURI string) (apiErr error) {
+	fake.authenticateWithAuthorizationCodeMutex.Lock()
+	fake.authenticateWithAuthorizationCodeArgsForCall = append(fake.authenticateWithAuthorizationCodeArgsForCall, struct {
+		code        string
+		redirectURI string
+	}{code, redirectURI})
+	fake.recordInvocation("AuthenticateWithAuthorizationCode", []interface{}{code, redirectURI})
+	fake.authenticateWithAuthorizationCodeMutex.Unlock()
+	if fake.AuthenticateWithAuthorizationCodeStub != nil {
+		return fake.AuthenticateWithAuthorizationCodeStub(code, redirectURI)
+	} else {
+		return fake.authenticateWithAuthorizationCodeReturns.result1
+	}
+}
+
+func (fake *FakeRepository) AuthenticateWithAuthorizationCodeCallCount() int {
+	fake.authenticateWithAuthorizationCodeMutex.RLock()
+	defer fake.authenticateWithAuthorizationCodeMutex.RUnlock()
+	return len(fake.authenticateWithAuthorizationCodeArgsForCall)
+}
+
+func (fake *FakeRepository) AuthenticateWithAuthorizationCodeArgsForCall(i int) (string, string) {
+	fake.authenticateWithAuthorizationCodeMutex.RLock()
+	defer fake.authenticateWithAuthorizationCodeMutex.RUnlock()
+	return fake.authenticateWithAuthorizationCodeArgsForCall[i].code, fake.authenticateWithAuthorizationCodeArgsForCall[i].redirectURI
+}
+
+func (fake *FakeRepository) AuthenticateWithAuthorizationCodeReturns(result1 error) {
+	fake.AuthenticateWithAuthorizationCodeStub = nil
+	fake.authenticateWithAuthorizationCodeReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRepository) Authorize(token string) (string, error) {
 	fake.authorizeMutex.Lock()
 	fake.authorizeArgsForCall = append(fake.authorizeArgsForCall, struct {
@@ -231,8 +453,18 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.dumpResponseMutex.RUnlock()
 	fake.refreshAuthTokenMutex.RLock()
 	defer fake.refreshAuthTokenMutex.RUnlock()
+	fake.refreshStaleAuthTokenMutex.RLock()
+	defer fake.refreshStaleAuthTokenMutex.RUnlock()
+	fake.deriveAuthTokenMutex.RLock()
+	defer fake.deriveAuthTokenMutex.RUnlock()
 	fake.authenticateMutex.RLock()
 	defer fake.authenticateMutex.RUnlock()
+	fake.authenticateClientCredentialsMutex.RLock()
+	defer fake.authenticateClientCredentialsMutex.RUnlock()
+	fake.authorizationCodeURLMutex.RLock()
+	defer fake.authorizationCodeURLMutex.RUnlock()
+	fake.authenticateWithAuthorizationCodeMutex.RLock()
+	defer fake.authenticateWithAuthorizationCodeMutex.RUnlock()
 	fake.authorizeMutex.RLock()
 	defer fake.authorizeMutex.RUnlock()
 	fake.getLoginPromptsAndSaveUAAServerURLMutex.RLock()
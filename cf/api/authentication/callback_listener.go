@@ -0,0 +1,117 @@
+package authentication
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/errors"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+)
+
+// CallbackListener receives the authorization code from the browser at the
+// end of a single sign-on login, on a local address that the authorization
+// server redirects to.
+type CallbackListener struct {
+	listener net.Listener
+	server   *http.Server
+	state    string
+	results  chan callbackResult
+}
+
+type callbackResult struct {
+	code  string
+	state string
+	err   string
+}
+
+func NewCallbackListener() (*CallbackListener, error) {
+	state := make([]byte, 16)
+	_, err := rand.Read(state)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	callback := &CallbackListener{
+		listener: listener,
+		state:    hex.EncodeToString(state),
+		results:  make(chan callbackResult, 1),
+	}
+	callback.server = &http.Server{Handler: http.HandlerFunc(callback.handle)}
+	go callback.server.Serve(listener)
+
+	return callback, nil
+}
+
+// RedirectURI is the address for the authorization server to redirect to.
+func (c *CallbackListener) RedirectURI() string {
+	return fmt.Sprintf("http://%s/callback", c.listener.Addr())
+}
+
+// State is the value to pass as the state of the authorization request. The
+// redirect has to return it for the code to be accepted.
+func (c *CallbackListener) State() string {
+	return c.state
+}
+
+// WaitForCode waits for the redirect and returns the authorization code in it.
+func (c *CallbackListener) WaitForCode(timeout time.Duration) (string, error) {
+	select {
+	case result := <-c.results:
+		switch {
+		case result.err != "":
+			return "", errors.New(T("The authorization server denied the login: {{.Err}}",
+				map[string]interface{}{"Err": result.err}))
+		case result.state != c.state:
+			return "", errors.New(T("The login was not started by this CLI; please try again."))
+		case result.code == "":
+			return "", errors.New(T("Unable to acquire one time code from authorization response"))
+		}
+		return result.code, nil
+	case <-time.After(timeout):
+		return "", errors.New(T("Timed out waiting for the login to complete in the browser"))
+	}
+}
+
+func (c *CallbackListener) Close() error {
+	return c.server.Close()
+}
+
+func (c *CallbackListener) handle(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/callback" {
+		http.NotFound(w, r)
+		return
+	}
+
+	query := r.URL.Query()
+	result := callbackResult{
+		code:  query.Get("code"),
+		state: query.Get("state"),
+		err:   query.Get("error_description"),
+	}
+	if result.err == "" {
+		result.err = query.Get("error")
+	}
+
+	// the browser is only told the login is complete when WaitForCode will
+	// accept the code
+	if result.err == "" && result.code != "" && result.state == c.state {
+		fmt.Fprintln(w, T("Login complete. You can close this window and return to the CLI."))
+	} else {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintln(w, T("Login failed. Return to the CLI for details."))
+	}
+
+	select {
+	case c.results <- result:
+	default:
+	}
+}
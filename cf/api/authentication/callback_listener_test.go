@@ -0,0 +1,70 @@
+package authentication_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	. "code.cloudfoundry.org/cli/cf/api/authentication"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CallbackListener", func() {
+	var listener *CallbackListener
+
+	BeforeEach(func() {
+		var err error
+		listener, err = NewCallbackListener()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		listener.Close()
+	})
+
+	redirect := func(query url.Values) *http.Response {
+		response, err := http.Get(listener.RedirectURI() + "?" + query.Encode())
+		Expect(err).NotTo(HaveOccurred())
+		return response
+	}
+
+	It("listens on the loopback interface", func() {
+		Expect(listener.RedirectURI()).To(MatchRegexp(`^http://127\.0\.0\.1:\d+/callback$`))
+	})
+
+	It("returns the code from the redirect", func() {
+		response := redirect(url.Values{"code": {"some-code"}, "state": {listener.State()}})
+		body, _ := ioutil.ReadAll(response.Body)
+		Expect(string(body)).To(ContainSubstring("Login complete"))
+
+		code, err := listener.WaitForCode(time.Second)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(code).To(Equal("some-code"))
+	})
+
+	It("rejects a redirect with another state", func() {
+		response := redirect(url.Values{"code": {"some-code"}, "state": {"some-other-state"}})
+		Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+		body, _ := ioutil.ReadAll(response.Body)
+		Expect(string(body)).To(ContainSubstring("Login failed"))
+		Expect(string(body)).NotTo(ContainSubstring("Login complete"))
+
+		_, err := listener.WaitForCode(time.Second)
+		Expect(err).To(MatchError("The login was not started by this CLI; please try again."))
+	})
+
+	It("returns the error from the authorization server", func() {
+		response := redirect(url.Values{"error": {"access_denied"}, "error_description": {"User denied access"}, "state": {listener.State()}})
+		Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+		_, err := listener.WaitForCode(time.Second)
+		Expect(err).To(MatchError("The authorization server denied the login: User denied access"))
+	})
+
+	It("times out when no redirect arrives", func() {
+		_, err := listener.WaitForCode(10 * time.Millisecond)
+		Expect(err).To(MatchError("Timed out waiting for the login to complete in the browser"))
+	})
+})
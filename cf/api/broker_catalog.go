@@ -0,0 +1,76 @@
+package api
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	gonet "net"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+// brokerAPIVersion is the version of the service broker API the catalog is
+// requested with.
+const brokerAPIVersion = "2.10"
+
+//go:generate counterfeiter . BrokerCatalogRepository
+
+type BrokerCatalogRepository interface {
+	GetCatalog(brokerURL, username, password string) (models.BrokerCatalog, error)
+}
+
+// HTTPBrokerCatalogRepository asks the service broker for its catalog
+// directly rather than through the Cloud Controller, which does not show the
+// catalog, so the broker has to be reachable from where the CLI runs.
+type HTTPBrokerCatalogRepository struct {
+	config coreconfig.Reader
+}
+
+func NewHTTPBrokerCatalogRepository(config coreconfig.Reader) HTTPBrokerCatalogRepository {
+	return HTTPBrokerCatalogRepository{config: config}
+}
+
+func (repo HTTPBrokerCatalogRepository) GetCatalog(brokerURL, username, password string) (models.BrokerCatalog, error) {
+	request, err := http.NewRequest("GET", strings.TrimSuffix(brokerURL, "/")+"/v2/catalog", nil)
+	if err != nil {
+		return models.BrokerCatalog{}, err
+	}
+	request.SetBasicAuth(username, password)
+	request.Header.Set("X-Broker-API-Version", brokerAPIVersion)
+	request.Header.Set("Accept", "application/json")
+
+	client := &http.Client{
+		Timeout: 60 * time.Second,
+		Transport: &http.Transport{
+			Dial:            (&gonet.Dialer{Timeout: 5 * time.Second}).Dial,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: repo.config.IsSSLDisabled()},
+			Proxy:           http.ProxyFromEnvironment,
+		},
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return models.BrokerCatalog{}, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return models.BrokerCatalog{}, errors.New(T("The service broker at {{.URL}} returned {{.Status}} for its catalog",
+			map[string]interface{}{"URL": brokerURL, "Status": response.Status}))
+	}
+
+	resource := resources.BrokerCatalogResource{}
+	err = json.NewDecoder(response.Body).Decode(&resource)
+	if err != nil {
+		return models.BrokerCatalog{}, errors.New(T("Invalid catalog from the service broker at {{.URL}}: {{.Err}}",
+			map[string]interface{}{"URL": brokerURL, "Err": err.Error()}))
+	}
+
+	return resource.ToModel(), nil
+}
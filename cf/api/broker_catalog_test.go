@@ -0,0 +1,88 @@
+package api_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/cli/cf/models"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+
+	. "code.cloudfoundry.org/cli/cf/api"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("BrokerCatalogRepository", func() {
+	var (
+		server *ghttp.Server
+		repo   HTTPBrokerCatalogRepository
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		repo = NewHTTPBrokerCatalogRepository(testconfig.NewRepositoryWithDefaults())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("GetCatalog", func() {
+		It("fetches the catalog from the broker with its credentials", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/catalog"),
+					ghttp.VerifyBasicAuth("broker-user", "broker-password"),
+					ghttp.VerifyHeaderKV("X-Broker-API-Version", "2.10"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"services": [{
+							"id": "service-id",
+							"name": "my-service",
+							"description": "a service",
+							"plans": [
+								{"id": "plan-1-id", "name": "small", "description": "small plan"},
+								{"id": "plan-2-id", "name": "large", "description": "large plan", "free": false}
+							]
+						}]
+					}`),
+				),
+			)
+
+			catalog, err := repo.GetCatalog(server.URL()+"/", "broker-user", "broker-password")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(catalog).To(Equal(models.BrokerCatalog{
+				Services: []models.BrokerCatalogService{
+					{
+						ID:          "service-id",
+						Name:        "my-service",
+						Description: "a service",
+						Plans: []models.BrokerCatalogPlan{
+							{ID: "plan-1-id", Name: "small", Description: "small plan", Free: true},
+							{ID: "plan-2-id", Name: "large", Description: "large plan", Free: false},
+						},
+					},
+				},
+			}))
+		})
+
+		It("returns an error when the broker does not return the catalog", func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusUnauthorized, `{}`),
+			)
+
+			_, err := repo.GetCatalog(server.URL(), "broker-user", "wrong-password")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("401"))
+		})
+
+		It("returns an error when the catalog is not JSON", func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `<html></html>`),
+			)
+
+			_, err := repo.GetCatalog(server.URL(), "broker-user", "broker-password")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Invalid catalog"))
+		})
+	})
+})
@@ -89,7 +89,7 @@ func (repo CloudControllerBuildpackBitsRepository) CreateBuildpackZipFile(buildp
 
 		if stats.IsDir() {
 			buildpackFileName += ".zip" // FIXME: remove once #71167394 is fixed
-			err = repo.zipper.Zip(buildpackPath, zipFileToUpload)
+			err = repo.zipper.Zip(buildpackPath, zipFileToUpload, false)
 			if err != nil {
 				return nil, "", zipErrorHelper(err)
 			}
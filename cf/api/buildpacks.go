@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"code.cloudfoundry.org/cli/cf/api/resources"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -18,8 +19,9 @@ import (
 
 type BuildpackRepository interface {
 	FindByName(name string) (buildpack models.Buildpack, apiErr error)
+	FindByNameAndStack(name, stack string) (buildpack models.Buildpack, apiErr error)
 	ListBuildpacks(func(models.Buildpack) bool) error
-	Create(name string, position *int, enabled *bool, locked *bool) (createdBuildpack models.Buildpack, apiErr error)
+	Create(name string, position *int, enabled *bool, locked *bool, stack string) (createdBuildpack models.Buildpack, apiErr error)
 	Delete(buildpackGUID string) (apiErr error)
 	Update(buildpack models.Buildpack) (updatedBuildpack models.Buildpack, apiErr error)
 }
@@ -63,8 +65,43 @@ func (repo CloudControllerBuildpackRepository) FindByName(name string) (buildpac
 	return
 }
 
-func (repo CloudControllerBuildpackRepository) Create(name string, position *int, enabled *bool, locked *bool) (createdBuildpack models.Buildpack, apiErr error) {
-	entity := resources.BuildpackEntity{Name: name, Position: position, Enabled: enabled, Locked: locked}
+// FindByNameAndStack returns the buildpack with the given name for stack.
+// Buildpacks for different stacks can share a name, so with an empty stack it
+// fails when more than one buildpack has the name.
+func (repo CloudControllerBuildpackRepository) FindByNameAndStack(name, stack string) (models.Buildpack, error) {
+	var found []models.Buildpack
+	err := repo.gateway.ListPaginatedResources(
+		repo.config.APIEndpoint(),
+		fmt.Sprintf("%s?q=%s", buildpacksPath, url.QueryEscape("name:"+name)),
+		resources.BuildpackResource{},
+		func(resource interface{}) bool {
+			buildpack := resource.(resources.BuildpackResource).ToFields()
+			if stack == "" || buildpack.Stack == stack {
+				found = append(found, buildpack)
+			}
+			return true
+		})
+	if err != nil {
+		return models.Buildpack{}, err
+	}
+
+	switch len(found) {
+	case 0:
+		return models.Buildpack{}, errors.NewModelNotFoundError("Buildpack", name)
+	case 1:
+		return found[0], nil
+	}
+
+	stacks := make([]string, len(found))
+	for i, buildpack := range found {
+		stacks[i] = buildpack.Stack
+	}
+	return models.Buildpack{}, errors.New(T("Multiple buildpacks named {{.BuildpackName}} found, for the stacks {{.Stacks}}. Specify the stack with --stack.",
+		map[string]interface{}{"BuildpackName": name, "Stacks": strings.Join(stacks, ", ")}))
+}
+
+func (repo CloudControllerBuildpackRepository) Create(name string, position *int, enabled *bool, locked *bool, stack string) (createdBuildpack models.Buildpack, apiErr error) {
+	entity := resources.BuildpackEntity{Name: name, Position: position, Enabled: enabled, Locked: locked, Stack: stack}
 	body, err := json.Marshal(entity)
 	if err != nil {
 		apiErr = fmt.Errorf("%s: %s", T("Could not serialize information"), err.Error())
@@ -156,7 +156,72 @@ var _ = Describe("Buildpacks repo", func() {
 		})
 	})
 
+	Describe("finding buildpacks by name and stack", func() {
+		var twoStacks = `{"resources": [
+		  {"metadata": {"guid": "ruby-trusty-guid"}, "entity": {"name": "ruby", "stack": "cflinuxfs2"}},
+		  {"metadata": {"guid": "ruby-windows-guid"}, "entity": {"name": "ruby", "stack": "windows2012R2"}}
+		]}`
+
+		It("returns the buildpack with that name for the stack", func() {
+			setupTestServer(apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method:   "GET",
+				Path:     "/v2/buildpacks?q=name%3Aruby",
+				Response: testnet.TestResponse{Status: http.StatusOK, Body: twoStacks},
+			}))
+
+			buildpack, err := repo.FindByNameAndStack("ruby", "windows2012R2")
+			Expect(handler).To(HaveAllRequestsCalled())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(buildpack.GUID).To(Equal("ruby-windows-guid"))
+			Expect(buildpack.Stack).To(Equal("windows2012R2"))
+		})
+
+		It("fails when no stack is given and the name is used for several stacks", func() {
+			setupTestServer(apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method:   "GET",
+				Path:     "/v2/buildpacks?q=name%3Aruby",
+				Response: testnet.TestResponse{Status: http.StatusOK, Body: twoStacks},
+			}))
+
+			_, err := repo.FindByNameAndStack("ruby", "")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Multiple buildpacks named ruby found, for the stacks cflinuxfs2, windows2012R2"))
+		})
+
+		It("returns a ModelNotFoundError when there is no buildpack for the stack", func() {
+			setupTestServer(apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method:   "GET",
+				Path:     "/v2/buildpacks?q=name%3Aruby",
+				Response: testnet.TestResponse{Status: http.StatusOK, Body: twoStacks},
+			}))
+
+			_, err := repo.FindByNameAndStack("ruby", "cflinuxfs3")
+			Expect(err).To(BeAssignableToTypeOf(&errors.ModelNotFoundError{}))
+		})
+	})
+
 	Describe("creating buildpacks", func() {
+		It("sets the stack when creating a buildpack", func() {
+			setupTestServer(apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method:  "POST",
+				Path:    "/v2/buildpacks",
+				Matcher: testnet.RequestBodyMatcher(`{"name":"my-cool-buildpack","position":3,"stack":"cflinuxfs2"}`),
+				Response: testnet.TestResponse{
+					Status: http.StatusCreated,
+					Body: `{
+					"metadata": {"guid": "my-cool-buildpack-guid"},
+					"entity": {"name": "my-cool-buildpack", "position": 3, "stack": "cflinuxfs2"}
+				}`},
+			}))
+
+			position := 3
+			created, apiErr := repo.Create("my-cool-buildpack", &position, nil, nil, "cflinuxfs2")
+
+			Expect(handler).To(HaveAllRequestsCalled())
+			Expect(apiErr).NotTo(HaveOccurred())
+			Expect(created.Stack).To(Equal("cflinuxfs2"))
+		})
+
 		It("returns an error when the buildpack has an invalid name", func() {
 			setupTestServer(testnet.TestRequest{
 				Method: "POST",
@@ -171,7 +236,7 @@ var _ = Describe("Buildpacks repo", func() {
 				}})
 
 			one := 1
-			createdBuildpack, apiErr := repo.Create("name with space", &one, nil, nil)
+			createdBuildpack, apiErr := repo.Create("name with space", &one, nil, nil, "")
 			Expect(apiErr).To(HaveOccurred())
 			Expect(createdBuildpack).To(Equal(models.Buildpack{}))
 			Expect(apiErr.(errors.HTTPError).ErrorCode()).To(Equal("290003"))
@@ -197,7 +262,7 @@ var _ = Describe("Buildpacks repo", func() {
 			}))
 
 			position := 999
-			created, apiErr := repo.Create("my-cool-buildpack", &position, nil, nil)
+			created, apiErr := repo.Create("my-cool-buildpack", &position, nil, nil, "")
 
 			Expect(handler).To(HaveAllRequestsCalled())
 			Expect(apiErr).NotTo(HaveOccurred())
@@ -228,7 +293,7 @@ var _ = Describe("Buildpacks repo", func() {
 
 			position := 999
 			enabled := true
-			created, apiErr := repo.Create("my-cool-buildpack", &position, &enabled, nil)
+			created, apiErr := repo.Create("my-cool-buildpack", &position, &enabled, nil, "")
 
 			Expect(handler).To(HaveAllRequestsCalled())
 			Expect(apiErr).NotTo(HaveOccurred())
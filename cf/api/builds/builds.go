@@ -0,0 +1,110 @@
+package builds
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+)
+
+//go:generate counterfeiter . Repository
+
+// Repository stages the packages of apps into droplets through the v3 builds
+// API, without changing the droplet the app runs.
+type Repository interface {
+	GetLatestPackageGUID(appGUID string) (string, error)
+	ListBuilds(appGUID string) ([]models.Build, error)
+	CreateBuild(packageGUID string) (models.Build, error)
+	GetBuild(buildGUID string) (models.Build, error)
+}
+
+type CloudControllerRepository struct {
+	config  coreconfig.Reader
+	gateway net.Gateway
+}
+
+func NewCloudControllerRepository(config coreconfig.Reader, gateway net.Gateway) CloudControllerRepository {
+	return CloudControllerRepository{
+		config:  config,
+		gateway: gateway,
+	}
+}
+
+// GetLatestPackageGUID returns the newest package of the app that is ready to
+// be staged.
+func (repo CloudControllerRepository) GetLatestPackageGUID(appGUID string) (string, error) {
+	page := resources.PaginatedPackageResources{}
+	err := repo.gateway.GetResource(fmt.Sprintf("%s/v3/apps/%s/packages?states=READY&order_by=-created_at&per_page=1", repo.config.APIEndpoint(), appGUID), &page)
+	if err != nil {
+		return "", err
+	}
+
+	if len(page.Resources) == 0 {
+		return "", errors.NewModelNotFoundError("Package", appGUID)
+	}
+
+	return page.Resources[0].GUID, nil
+}
+
+// ListBuilds returns the builds of the app, newest first.
+func (repo CloudControllerRepository) ListBuilds(appGUID string) ([]models.Build, error) {
+	builds := []models.Build{}
+
+	url := fmt.Sprintf("%s/v3/builds?app_guids=%s&order_by=-created_at", repo.config.APIEndpoint(), appGUID)
+	for url != "" {
+		page := resources.PaginatedBuildResources{}
+		err := repo.gateway.GetResource(url, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range page.Resources {
+			builds = append(builds, resource.ToModel())
+		}
+
+		url = ""
+		if page.Pagination.Next != nil {
+			url = page.Pagination.Next.Href
+		}
+	}
+
+	return builds, nil
+}
+
+func (repo CloudControllerRepository) CreateBuild(packageGUID string) (models.Build, error) {
+	buildRequest := resources.BuildRequest{}
+	buildRequest.Package.GUID = packageGUID
+
+	body, err := json.Marshal(buildRequest)
+	if err != nil {
+		return models.Build{}, err
+	}
+
+	request, err := repo.gateway.NewRequest("POST", fmt.Sprintf("%s/v3/builds", repo.config.APIEndpoint()), repo.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return models.Build{}, err
+	}
+
+	resource := resources.BuildResource{}
+	_, err = repo.gateway.PerformRequestForJSONResponse(request, &resource)
+	if err != nil {
+		return models.Build{}, err
+	}
+
+	return resource.ToModel(), nil
+}
+
+func (repo CloudControllerRepository) GetBuild(buildGUID string) (models.Build, error) {
+	resource := resources.BuildResource{}
+	err := repo.gateway.GetResource(fmt.Sprintf("%s/v3/builds/%s", repo.config.APIEndpoint(), buildGUID), &resource)
+	if err != nil {
+		return models.Build{}, err
+	}
+
+	return resource.ToModel(), nil
+}
@@ -0,0 +1,168 @@
+package builds_test
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+
+	"github.com/onsi/gomega/ghttp"
+
+	. "code.cloudfoundry.org/cli/cf/api/builds"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildsRepo", func() {
+	var (
+		testServer *ghttp.Server
+		configRepo coreconfig.ReadWriter
+		repo       Repository
+	)
+
+	BeforeEach(func() {
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		configRepo.SetAccessToken("BEARER my_access_token")
+
+		gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		repo = NewCloudControllerRepository(configRepo, gateway)
+	})
+
+	BeforeEach(func() {
+		testServer = ghttp.NewServer()
+		configRepo.SetAPIEndpoint(testServer.URL())
+	})
+
+	AfterEach(func() {
+		if testServer != nil {
+			testServer.Close()
+		}
+	})
+
+	Describe("GetLatestPackageGUID", func() {
+		It("returns the newest ready package", func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/packages", "states=READY&order_by=-created_at&per_page=1"),
+					ghttp.RespondWith(http.StatusOK, `{ "resources": [ { "guid": "package-guid" } ] }`),
+				),
+			)
+
+			packageGUID, err := repo.GetLatestPackageGUID("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(packageGUID).To(Equal("package-guid"))
+		})
+
+		It("returns a not found error when the app has no ready package", func() {
+			testServer.AppendHandlers(
+				ghttp.RespondWith(http.StatusOK, `{ "resources": [] }`),
+			)
+
+			_, err := repo.GetLatestPackageGUID("app-guid")
+			Expect(err).To(BeAssignableToTypeOf(&errors.ModelNotFoundError{}))
+		})
+	})
+
+	Describe("ListBuilds", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/builds", "app_guids=app-guid&order_by=-created_at"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": { "next": { "href": "`+testServer.URL()+`/v3/builds?app_guids=app-guid&order_by=-created_at&page=2" } },
+						"resources": [
+							{
+								"guid": "build-2-guid",
+								"state": "STAGING",
+								"created_at": "2016-11-02T15:04:05Z",
+								"package": { "guid": "package-2-guid" },
+								"droplet": null,
+								"relationships": { "app": { "data": { "guid": "app-guid" } } }
+							}
+						]
+					}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/builds", "app_guids=app-guid&order_by=-created_at&page=2"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": { "next": null },
+						"resources": [
+							{
+								"guid": "build-1-guid",
+								"state": "STAGED",
+								"created_at": "2016-11-01T15:04:05Z",
+								"package": { "guid": "package-1-guid" },
+								"droplet": { "guid": "droplet-guid" },
+								"links": { "app": { "href": "`+testServer.URL()+`/v3/apps/app-guid" } }
+							}
+						]
+					}`),
+				),
+			)
+		})
+
+		It("returns the builds of every page", func() {
+			builds, err := repo.ListBuilds("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(builds).To(Equal([]models.Build{
+				{
+					GUID:        "build-2-guid",
+					State:       models.BuildStateStaging,
+					AppGUID:     "app-guid",
+					PackageGUID: "package-2-guid",
+					CreatedAt:   time.Date(2016, 11, 2, 15, 4, 5, 0, time.UTC),
+				},
+				{
+					GUID:        "build-1-guid",
+					State:       models.BuildStateStaged,
+					AppGUID:     "app-guid",
+					PackageGUID: "package-1-guid",
+					DropletGUID: "droplet-guid",
+					CreatedAt:   time.Date(2016, 11, 1, 15, 4, 5, 0, time.UTC),
+				},
+			}))
+		})
+	})
+
+	Describe("CreateBuild", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/v3/builds"),
+					ghttp.VerifyJSON(`{ "package": { "guid": "package-guid" } }`),
+					ghttp.RespondWith(http.StatusCreated, `{ "guid": "build-guid", "state": "STAGING", "droplet": null }`),
+				),
+			)
+		})
+
+		It("starts staging the package", func() {
+			build, err := repo.CreateBuild("package-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build).To(Equal(models.Build{GUID: "build-guid", State: models.BuildStateStaging}))
+		})
+	})
+
+	Describe("GetBuild", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/builds/build-guid"),
+					ghttp.RespondWith(http.StatusOK, `{ "guid": "build-guid", "state": "STAGED", "droplet": { "guid": "droplet-guid" } }`),
+				),
+			)
+		})
+
+		It("returns the build with the droplet it staged", func() {
+			build, err := repo.GetBuild("build-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build).To(Equal(models.Build{GUID: "build-guid", State: models.BuildStateStaged, DropletGUID: "droplet-guid"}))
+		})
+	})
+})
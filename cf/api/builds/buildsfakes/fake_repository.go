@@ -0,0 +1,214 @@
+// This file was generated by counterfeiter
+package buildsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/api/builds"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type FakeRepository struct {
+	GetLatestPackageGUIDStub        func(appGUID string) (string, error)
+	getLatestPackageGUIDMutex       sync.RWMutex
+	getLatestPackageGUIDArgsForCall []struct {
+		appGUID string
+	}
+	getLatestPackageGUIDReturns struct {
+		result1 string
+		result2 error
+	}
+	ListBuildsStub        func(appGUID string) ([]models.Build, error)
+	listBuildsMutex       sync.RWMutex
+	listBuildsArgsForCall []struct {
+		appGUID string
+	}
+	listBuildsReturns struct {
+		result1 []models.Build
+		result2 error
+	}
+	CreateBuildStub        func(packageGUID string) (models.Build, error)
+	createBuildMutex       sync.RWMutex
+	createBuildArgsForCall []struct {
+		packageGUID string
+	}
+	createBuildReturns struct {
+		result1 models.Build
+		result2 error
+	}
+	GetBuildStub        func(buildGUID string) (models.Build, error)
+	getBuildMutex       sync.RWMutex
+	getBuildArgsForCall []struct {
+		buildGUID string
+	}
+	getBuildReturns struct {
+		result1 models.Build
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRepository) GetLatestPackageGUID(appGUID string) (string, error) {
+	fake.getLatestPackageGUIDMutex.Lock()
+	fake.getLatestPackageGUIDArgsForCall = append(fake.getLatestPackageGUIDArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("GetLatestPackageGUID", []interface{}{appGUID})
+	fake.getLatestPackageGUIDMutex.Unlock()
+	if fake.GetLatestPackageGUIDStub != nil {
+		return fake.GetLatestPackageGUIDStub(appGUID)
+	} else {
+		return fake.getLatestPackageGUIDReturns.result1, fake.getLatestPackageGUIDReturns.result2
+	}
+}
+
+func (fake *FakeRepository) GetLatestPackageGUIDCallCount() int {
+	fake.getLatestPackageGUIDMutex.RLock()
+	defer fake.getLatestPackageGUIDMutex.RUnlock()
+	return len(fake.getLatestPackageGUIDArgsForCall)
+}
+
+func (fake *FakeRepository) GetLatestPackageGUIDArgsForCall(i int) string {
+	fake.getLatestPackageGUIDMutex.RLock()
+	defer fake.getLatestPackageGUIDMutex.RUnlock()
+	return fake.getLatestPackageGUIDArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) GetLatestPackageGUIDReturns(result1 string, result2 error) {
+	fake.GetLatestPackageGUIDStub = nil
+	fake.getLatestPackageGUIDReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) ListBuilds(appGUID string) ([]models.Build, error) {
+	fake.listBuildsMutex.Lock()
+	fake.listBuildsArgsForCall = append(fake.listBuildsArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("ListBuilds", []interface{}{appGUID})
+	fake.listBuildsMutex.Unlock()
+	if fake.ListBuildsStub != nil {
+		return fake.ListBuildsStub(appGUID)
+	} else {
+		return fake.listBuildsReturns.result1, fake.listBuildsReturns.result2
+	}
+}
+
+func (fake *FakeRepository) ListBuildsCallCount() int {
+	fake.listBuildsMutex.RLock()
+	defer fake.listBuildsMutex.RUnlock()
+	return len(fake.listBuildsArgsForCall)
+}
+
+func (fake *FakeRepository) ListBuildsArgsForCall(i int) string {
+	fake.listBuildsMutex.RLock()
+	defer fake.listBuildsMutex.RUnlock()
+	return fake.listBuildsArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) ListBuildsReturns(result1 []models.Build, result2 error) {
+	fake.ListBuildsStub = nil
+	fake.listBuildsReturns = struct {
+		result1 []models.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) CreateBuild(packageGUID string) (models.Build, error) {
+	fake.createBuildMutex.Lock()
+	fake.createBuildArgsForCall = append(fake.createBuildArgsForCall, struct {
+		packageGUID string
+	}{packageGUID})
+	fake.recordInvocation("CreateBuild", []interface{}{packageGUID})
+	fake.createBuildMutex.Unlock()
+	if fake.CreateBuildStub != nil {
+		return fake.CreateBuildStub(packageGUID)
+	} else {
+		return fake.createBuildReturns.result1, fake.createBuildReturns.result2
+	}
+}
+
+func (fake *FakeRepository) CreateBuildCallCount() int {
+	fake.createBuildMutex.RLock()
+	defer fake.createBuildMutex.RUnlock()
+	return len(fake.createBuildArgsForCall)
+}
+
+func (fake *FakeRepository) CreateBuildArgsForCall(i int) string {
+	fake.createBuildMutex.RLock()
+	defer fake.createBuildMutex.RUnlock()
+	return fake.createBuildArgsForCall[i].packageGUID
+}
+
+func (fake *FakeRepository) CreateBuildReturns(result1 models.Build, result2 error) {
+	fake.CreateBuildStub = nil
+	fake.createBuildReturns = struct {
+		result1 models.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetBuild(buildGUID string) (models.Build, error) {
+	fake.getBuildMutex.Lock()
+	fake.getBuildArgsForCall = append(fake.getBuildArgsForCall, struct {
+		buildGUID string
+	}{buildGUID})
+	fake.recordInvocation("GetBuild", []interface{}{buildGUID})
+	fake.getBuildMutex.Unlock()
+	if fake.GetBuildStub != nil {
+		return fake.GetBuildStub(buildGUID)
+	} else {
+		return fake.getBuildReturns.result1, fake.getBuildReturns.result2
+	}
+}
+
+func (fake *FakeRepository) GetBuildCallCount() int {
+	fake.getBuildMutex.RLock()
+	defer fake.getBuildMutex.RUnlock()
+	return len(fake.getBuildArgsForCall)
+}
+
+func (fake *FakeRepository) GetBuildArgsForCall(i int) string {
+	fake.getBuildMutex.RLock()
+	defer fake.getBuildMutex.RUnlock()
+	return fake.getBuildArgsForCall[i].buildGUID
+}
+
+func (fake *FakeRepository) GetBuildReturns(result1 models.Build, result2 error) {
+	fake.GetBuildStub = nil
+	fake.getBuildReturns = struct {
+		result1 models.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getLatestPackageGUIDMutex.RLock()
+	defer fake.getLatestPackageGUIDMutex.RUnlock()
+	fake.listBuildsMutex.RLock()
+	defer fake.listBuildsMutex.RUnlock()
+	fake.createBuildMutex.RLock()
+	defer fake.createBuildMutex.RUnlock()
+	fake.getBuildMutex.RLock()
+	defer fake.getBuildMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ builds.Repository = new(FakeRepository)
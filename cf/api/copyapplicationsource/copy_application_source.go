@@ -2,9 +2,11 @@ package copyapplicationsource
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	. "code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/cf/net"
 )
 
@@ -12,6 +14,7 @@ import (
 
 type Repository interface {
 	CopyApplication(sourceAppGUID, targetAppGUID string) error
+	DownloadApplication(appGUID string, dest io.Writer) error
 }
 
 type CloudControllerApplicationSourceRepository struct {
@@ -31,3 +34,25 @@ func (repo *CloudControllerApplicationSourceRepository) CopyApplication(sourceAp
 	body := fmt.Sprintf(`{"source_app_guid":"%s"}`, sourceAppGUID)
 	return repo.gateway.CreateResource(repo.config.APIEndpoint(), url, strings.NewReader(body), new(interface{}))
 }
+
+// DownloadApplication writes the zip of the bits the app was last pushed with
+// to dest, for copying them to an app on another foundation.
+func (repo *CloudControllerApplicationSourceRepository) DownloadApplication(appGUID string, dest io.Writer) error {
+	url := fmt.Sprintf("%s/v2/apps/%s/download", repo.config.APIEndpoint(), appGUID)
+	request, err := repo.gateway.NewRequest("GET", url, repo.config.AccessToken(), nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := repo.gateway.PerformRequest(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	_, err = io.Copy(dest, response.Body)
+	if err != nil {
+		return fmt.Errorf("%s: %s", T("Error downloading app bits"), err.Error())
+	}
+	return nil
+}
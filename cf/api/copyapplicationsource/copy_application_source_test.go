@@ -1,6 +1,7 @@
 package copyapplicationsource_test
 
 import (
+	"bytes"
 	"net/http"
 	"net/http/httptest"
 	"time"
@@ -59,4 +60,24 @@ var _ = Describe("CopyApplicationSource", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 	})
+
+	Describe(".DownloadApplication", func() {
+		BeforeEach(func() {
+			setupTestServer(apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method: "GET",
+				Path:   "/v2/apps/source-app-guid/download",
+				Response: testnet.TestResponse{
+					Status: http.StatusOK,
+					Body:   "app-bits-zip",
+				},
+			}))
+		})
+
+		It("writes the bits of the app to the destination", func() {
+			dest := &bytes.Buffer{}
+			err := repo.DownloadApplication("source-app-guid", dest)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dest.String()).To(HavePrefix("app-bits-zip"))
+		})
+	})
 })
@@ -2,6 +2,7 @@
 package copyapplicationsourcefakes
 
 import (
+	"io"
 	"sync"
 
 	"code.cloudfoundry.org/cli/cf/api/copyapplicationsource"
@@ -17,6 +18,15 @@ type FakeRepository struct {
 	copyApplicationReturns struct {
 		result1 error
 	}
+	DownloadApplicationStub        func(appGUID string, dest io.Writer) error
+	downloadApplicationMutex       sync.RWMutex
+	downloadApplicationArgsForCall []struct {
+		appGUID string
+		dest    io.Writer
+	}
+	downloadApplicationReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -55,11 +65,47 @@ func (fake *FakeRepository) CopyApplicationReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeRepository) DownloadApplication(appGUID string, dest io.Writer) error {
+	fake.downloadApplicationMutex.Lock()
+	fake.downloadApplicationArgsForCall = append(fake.downloadApplicationArgsForCall, struct {
+		appGUID string
+		dest    io.Writer
+	}{appGUID, dest})
+	fake.recordInvocation("DownloadApplication", []interface{}{appGUID, dest})
+	fake.downloadApplicationMutex.Unlock()
+	if fake.DownloadApplicationStub != nil {
+		return fake.DownloadApplicationStub(appGUID, dest)
+	} else {
+		return fake.downloadApplicationReturns.result1
+	}
+}
+
+func (fake *FakeRepository) DownloadApplicationCallCount() int {
+	fake.downloadApplicationMutex.RLock()
+	defer fake.downloadApplicationMutex.RUnlock()
+	return len(fake.downloadApplicationArgsForCall)
+}
+
+func (fake *FakeRepository) DownloadApplicationArgsForCall(i int) (string, io.Writer) {
+	fake.downloadApplicationMutex.RLock()
+	defer fake.downloadApplicationMutex.RUnlock()
+	return fake.downloadApplicationArgsForCall[i].appGUID, fake.downloadApplicationArgsForCall[i].dest
+}
+
+func (fake *FakeRepository) DownloadApplicationReturns(result1 error) {
+	fake.DownloadApplicationStub = nil
+	fake.downloadApplicationReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.copyApplicationMutex.RLock()
 	defer fake.copyApplicationMutex.RUnlock()
+	fake.downloadApplicationMutex.RLock()
+	defer fake.downloadApplicationMutex.RUnlock()
 	return fake.invocations
 }
 
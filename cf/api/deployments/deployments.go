@@ -0,0 +1,114 @@
+package deployments
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+)
+
+//go:generate counterfeiter . Repository
+
+// Repository creates, reads, cancels and continues the deployments of apps
+// through the v3 deployments API.
+type Repository interface {
+	CreateDeployment(appGUID string, params models.DeploymentParams) (models.Deployment, error)
+	GetDeployment(deploymentGUID string) (models.Deployment, error)
+	ListDeployments(appGUID string) ([]models.Deployment, error)
+	CancelDeployment(deploymentGUID string) error
+	ContinueDeployment(deploymentGUID string) error
+}
+
+type CloudControllerRepository struct {
+	config  coreconfig.Reader
+	gateway net.Gateway
+}
+
+func NewCloudControllerRepository(config coreconfig.Reader, gateway net.Gateway) CloudControllerRepository {
+	return CloudControllerRepository{
+		config:  config,
+		gateway: gateway,
+	}
+}
+
+// CreateDeployment starts a deployment of the app. Without a droplet the
+// deployment restarts the app on its current droplet.
+func (repo CloudControllerRepository) CreateDeployment(appGUID string, params models.DeploymentParams) (models.Deployment, error) {
+	body, err := json.Marshal(resources.NewDeploymentRequest(appGUID, params))
+	if err != nil {
+		return models.Deployment{}, err
+	}
+
+	request, err := repo.gateway.NewRequest("POST", fmt.Sprintf("%s/v3/deployments", repo.config.APIEndpoint()), repo.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return models.Deployment{}, err
+	}
+
+	resource := resources.DeploymentResource{}
+	_, err = repo.gateway.PerformRequestForJSONResponse(request, &resource)
+	if err != nil {
+		return models.Deployment{}, err
+	}
+
+	return resource.ToModel(), nil
+}
+
+func (repo CloudControllerRepository) GetDeployment(deploymentGUID string) (models.Deployment, error) {
+	resource := resources.DeploymentResource{}
+	err := repo.gateway.GetResource(fmt.Sprintf("%s/v3/deployments/%s", repo.config.APIEndpoint(), deploymentGUID), &resource)
+	if err != nil {
+		return models.Deployment{}, err
+	}
+
+	return resource.ToModel(), nil
+}
+
+// ListDeployments returns the deployments of the app, newest first.
+func (repo CloudControllerRepository) ListDeployments(appGUID string) ([]models.Deployment, error) {
+	deployments := []models.Deployment{}
+
+	url := fmt.Sprintf("%s/v3/deployments?app_guids=%s&order_by=-created_at", repo.config.APIEndpoint(), appGUID)
+	for url != "" {
+		page := resources.PaginatedDeploymentResources{}
+		err := repo.gateway.GetResource(url, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range page.Resources {
+			deployments = append(deployments, resource.ToModel())
+		}
+
+		url = ""
+		if page.Pagination.Next != nil {
+			url = page.Pagination.Next.Href
+		}
+	}
+
+	return deployments, nil
+}
+
+func (repo CloudControllerRepository) CancelDeployment(deploymentGUID string) error {
+	return repo.performAction(deploymentGUID, "cancel")
+}
+
+// ContinueDeployment goes on with a canary deployment that is paused once its
+// first instances are running.
+func (repo CloudControllerRepository) ContinueDeployment(deploymentGUID string) error {
+	return repo.performAction(deploymentGUID, "continue")
+}
+
+func (repo CloudControllerRepository) performAction(deploymentGUID string, action string) error {
+	url := fmt.Sprintf("%s/v3/deployments/%s/actions/%s", repo.config.APIEndpoint(), deploymentGUID, action)
+	request, err := repo.gateway.NewRequest("POST", url, repo.config.AccessToken(), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.gateway.PerformRequest(request)
+	return err
+}
@@ -0,0 +1,238 @@
+package deployments_test
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+
+	"github.com/onsi/gomega/ghttp"
+
+	. "code.cloudfoundry.org/cli/cf/api/deployments"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DeploymentsRepo", func() {
+	var (
+		testServer *ghttp.Server
+		configRepo coreconfig.ReadWriter
+		repo       Repository
+	)
+
+	BeforeEach(func() {
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		configRepo.SetAccessToken("BEARER my_access_token")
+
+		gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		repo = NewCloudControllerRepository(configRepo, gateway)
+	})
+
+	BeforeEach(func() {
+		testServer = ghttp.NewServer()
+		configRepo.SetAPIEndpoint(testServer.URL())
+	})
+
+	AfterEach(func() {
+		if testServer != nil {
+			testServer.Close()
+		}
+	})
+
+	Describe("CreateDeployment", func() {
+		Context("when restarting on the current droplet", func() {
+			BeforeEach(func() {
+				testServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v3/deployments"),
+						ghttp.VerifyJSON(`{ "strategy": "rolling", "relationships": { "app": { "data": { "guid": "app-guid" } } } }`),
+						ghttp.RespondWith(http.StatusCreated, `{
+							"guid": "deployment-guid",
+							"status": { "value": "ACTIVE", "reason": "DEPLOYING" },
+							"strategy": "rolling",
+							"droplet": { "guid": "droplet-guid" },
+							"created_at": "2016-11-02T10:00:00Z"
+						}`),
+					),
+				)
+			})
+
+			It("creates the deployment without a droplet", func() {
+				deployment, err := repo.CreateDeployment("app-guid", models.DeploymentParams{Strategy: models.DeploymentStrategyRolling})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deployment).To(Equal(models.Deployment{
+					GUID:         "deployment-guid",
+					StatusValue:  "ACTIVE",
+					StatusReason: "DEPLOYING",
+					Strategy:     "rolling",
+					DropletGUID:  "droplet-guid",
+					CreatedAt:    time.Date(2016, 11, 2, 10, 0, 0, 0, time.UTC),
+				}))
+			})
+		})
+
+		Context("when deploying an earlier revision", func() {
+			BeforeEach(func() {
+				testServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v3/deployments"),
+						ghttp.VerifyJSON(`{
+							"revision": { "guid": "revision-guid" },
+							"strategy": "rolling",
+							"relationships": { "app": { "data": { "guid": "app-guid" } } }
+						}`),
+						ghttp.RespondWith(http.StatusCreated, `{ "guid": "deployment-guid" }`),
+					),
+				)
+			})
+
+			It("creates the deployment of the revision", func() {
+				deployment, err := repo.CreateDeployment("app-guid", models.DeploymentParams{RevisionGUID: "revision-guid", Strategy: models.DeploymentStrategyRolling})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deployment.GUID).To(Equal("deployment-guid"))
+			})
+		})
+
+		Context("when deploying a droplet a few instances at a time", func() {
+			BeforeEach(func() {
+				testServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v3/deployments"),
+						ghttp.VerifyJSON(`{
+							"droplet": { "guid": "new-droplet-guid" },
+							"strategy": "rolling",
+							"options": { "max_in_flight": 3 },
+							"relationships": { "app": { "data": { "guid": "app-guid" } } }
+						}`),
+						ghttp.RespondWith(http.StatusCreated, `{ "guid": "deployment-guid" }`),
+					),
+				)
+			})
+
+			It("sends the droplet and the options", func() {
+				maxInFlight := 3
+				deployment, err := repo.CreateDeployment("app-guid", models.DeploymentParams{
+					DropletGUID: "new-droplet-guid",
+					Strategy:    models.DeploymentStrategyRolling,
+					MaxInFlight: &maxInFlight,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deployment.GUID).To(Equal("deployment-guid"))
+			})
+		})
+
+		Context("when deploying a droplet to canary instances first", func() {
+			BeforeEach(func() {
+				testServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v3/deployments"),
+						ghttp.VerifyJSON(`{
+							"droplet": { "guid": "new-droplet-guid" },
+							"strategy": "canary",
+							"options": { "canary": { "steps": [ { "instance_weight": 10 }, { "instance_weight": 50 } ] } },
+							"relationships": { "app": { "data": { "guid": "app-guid" } } }
+						}`),
+						ghttp.RespondWith(http.StatusCreated, `{ "guid": "deployment-guid" }`),
+					),
+				)
+			})
+
+			It("sends the instance steps", func() {
+				_, err := repo.CreateDeployment("app-guid", models.DeploymentParams{
+					DropletGUID:   "new-droplet-guid",
+					Strategy:      models.DeploymentStrategyCanary,
+					InstanceSteps: []int{10, 50},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("GetDeployment", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/deployments/deployment-guid"),
+					ghttp.RespondWith(http.StatusOK, `{ "guid": "deployment-guid", "status": { "value": "FINALIZED", "reason": "DEPLOYED" } }`),
+				),
+			)
+		})
+
+		It("returns the deployment", func() {
+			deployment, err := repo.GetDeployment("deployment-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deployment.IsFinished()).To(BeTrue())
+			Expect(deployment.Succeeded()).To(BeTrue())
+		})
+	})
+
+	Describe("ListDeployments", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/deployments", "app_guids=app-guid&order_by=-created_at"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": {
+							"next": { "href": "`+testServer.URL()+`/v3/deployments?app_guids=app-guid&order_by=-created_at&page=2" }
+						},
+						"resources": [ { "guid": "deployment-2-guid", "status": { "value": "ACTIVE", "reason": "DEPLOYING" } } ]
+					}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/deployments", "app_guids=app-guid&order_by=-created_at&page=2"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": { "next": null },
+						"resources": [ { "guid": "deployment-1-guid", "state": "DEPLOYED" } ]
+					}`),
+				),
+			)
+		})
+
+		It("returns the deployments from every page", func() {
+			deployments, err := repo.ListDeployments("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deployments).To(Equal([]models.Deployment{
+				{GUID: "deployment-2-guid", StatusValue: "ACTIVE", StatusReason: "DEPLOYING"},
+				{GUID: "deployment-1-guid", State: "DEPLOYED"},
+			}))
+		})
+	})
+
+	Describe("CancelDeployment", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/v3/deployments/deployment-guid/actions/cancel"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+			)
+		})
+
+		It("cancels the deployment", func() {
+			Expect(repo.CancelDeployment("deployment-guid")).To(Succeed())
+			Expect(testServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("ContinueDeployment", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/v3/deployments/deployment-guid/actions/continue"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+			)
+		})
+
+		It("continues the deployment", func() {
+			Expect(repo.ContinueDeployment("deployment-guid")).To(Succeed())
+			Expect(testServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,257 @@
+// This file was generated by counterfeiter
+package deploymentsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/api/deployments"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type FakeRepository struct {
+	CreateDeploymentStub        func(appGUID string, params models.DeploymentParams) (models.Deployment, error)
+	createDeploymentMutex       sync.RWMutex
+	createDeploymentArgsForCall []struct {
+		appGUID string
+		params  models.DeploymentParams
+	}
+	createDeploymentReturns struct {
+		result1 models.Deployment
+		result2 error
+	}
+	GetDeploymentStub        func(deploymentGUID string) (models.Deployment, error)
+	getDeploymentMutex       sync.RWMutex
+	getDeploymentArgsForCall []struct {
+		deploymentGUID string
+	}
+	getDeploymentReturns struct {
+		result1 models.Deployment
+		result2 error
+	}
+	ListDeploymentsStub        func(appGUID string) ([]models.Deployment, error)
+	listDeploymentsMutex       sync.RWMutex
+	listDeploymentsArgsForCall []struct {
+		appGUID string
+	}
+	listDeploymentsReturns struct {
+		result1 []models.Deployment
+		result2 error
+	}
+	CancelDeploymentStub        func(deploymentGUID string) error
+	cancelDeploymentMutex       sync.RWMutex
+	cancelDeploymentArgsForCall []struct {
+		deploymentGUID string
+	}
+	cancelDeploymentReturns struct {
+		result1 error
+	}
+	ContinueDeploymentStub        func(deploymentGUID string) error
+	continueDeploymentMutex       sync.RWMutex
+	continueDeploymentArgsForCall []struct {
+		deploymentGUID string
+	}
+	continueDeploymentReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRepository) CreateDeployment(appGUID string, params models.DeploymentParams) (models.Deployment, error) {
+	fake.createDeploymentMutex.Lock()
+	fake.createDeploymentArgsForCall = append(fake.createDeploymentArgsForCall, struct {
+		appGUID string
+		params  models.DeploymentParams
+	}{appGUID, params})
+	fake.recordInvocation("CreateDeployment", []interface{}{appGUID, params})
+	fake.createDeploymentMutex.Unlock()
+	if fake.CreateDeploymentStub != nil {
+		return fake.CreateDeploymentStub(appGUID, params)
+	} else {
+		return fake.createDeploymentReturns.result1, fake.createDeploymentReturns.result2
+	}
+}
+
+func (fake *FakeRepository) CreateDeploymentCallCount() int {
+	fake.createDeploymentMutex.RLock()
+	defer fake.createDeploymentMutex.RUnlock()
+	return len(fake.createDeploymentArgsForCall)
+}
+
+func (fake *FakeRepository) CreateDeploymentArgsForCall(i int) (string, models.DeploymentParams) {
+	fake.createDeploymentMutex.RLock()
+	defer fake.createDeploymentMutex.RUnlock()
+	return fake.createDeploymentArgsForCall[i].appGUID, fake.createDeploymentArgsForCall[i].params
+}
+
+func (fake *FakeRepository) CreateDeploymentReturns(result1 models.Deployment, result2 error) {
+	fake.CreateDeploymentStub = nil
+	fake.createDeploymentReturns = struct {
+		result1 models.Deployment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetDeployment(deploymentGUID string) (models.Deployment, error) {
+	fake.getDeploymentMutex.Lock()
+	fake.getDeploymentArgsForCall = append(fake.getDeploymentArgsForCall, struct {
+		deploymentGUID string
+	}{deploymentGUID})
+	fake.recordInvocation("GetDeployment", []interface{}{deploymentGUID})
+	fake.getDeploymentMutex.Unlock()
+	if fake.GetDeploymentStub != nil {
+		return fake.GetDeploymentStub(deploymentGUID)
+	} else {
+		return fake.getDeploymentReturns.result1, fake.getDeploymentReturns.result2
+	}
+}
+
+func (fake *FakeRepository) GetDeploymentCallCount() int {
+	fake.getDeploymentMutex.RLock()
+	defer fake.getDeploymentMutex.RUnlock()
+	return len(fake.getDeploymentArgsForCall)
+}
+
+func (fake *FakeRepository) GetDeploymentArgsForCall(i int) string {
+	fake.getDeploymentMutex.RLock()
+	defer fake.getDeploymentMutex.RUnlock()
+	return fake.getDeploymentArgsForCall[i].deploymentGUID
+}
+
+func (fake *FakeRepository) GetDeploymentReturns(result1 models.Deployment, result2 error) {
+	fake.GetDeploymentStub = nil
+	fake.getDeploymentReturns = struct {
+		result1 models.Deployment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) ListDeployments(appGUID string) ([]models.Deployment, error) {
+	fake.listDeploymentsMutex.Lock()
+	fake.listDeploymentsArgsForCall = append(fake.listDeploymentsArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("ListDeployments", []interface{}{appGUID})
+	fake.listDeploymentsMutex.Unlock()
+	if fake.ListDeploymentsStub != nil {
+		return fake.ListDeploymentsStub(appGUID)
+	} else {
+		return fake.listDeploymentsReturns.result1, fake.listDeploymentsReturns.result2
+	}
+}
+
+func (fake *FakeRepository) ListDeploymentsCallCount() int {
+	fake.listDeploymentsMutex.RLock()
+	defer fake.listDeploymentsMutex.RUnlock()
+	return len(fake.listDeploymentsArgsForCall)
+}
+
+func (fake *FakeRepository) ListDeploymentsArgsForCall(i int) string {
+	fake.listDeploymentsMutex.RLock()
+	defer fake.listDeploymentsMutex.RUnlock()
+	return fake.listDeploymentsArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) ListDeploymentsReturns(result1 []models.Deployment, result2 error) {
+	fake.ListDeploymentsStub = nil
+	fake.listDeploymentsReturns = struct {
+		result1 []models.Deployment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) CancelDeployment(deploymentGUID string) error {
+	fake.cancelDeploymentMutex.Lock()
+	fake.cancelDeploymentArgsForCall = append(fake.cancelDeploymentArgsForCall, struct {
+		deploymentGUID string
+	}{deploymentGUID})
+	fake.recordInvocation("CancelDeployment", []interface{}{deploymentGUID})
+	fake.cancelDeploymentMutex.Unlock()
+	if fake.CancelDeploymentStub != nil {
+		return fake.CancelDeploymentStub(deploymentGUID)
+	} else {
+		return fake.cancelDeploymentReturns.result1
+	}
+}
+
+func (fake *FakeRepository) CancelDeploymentCallCount() int {
+	fake.cancelDeploymentMutex.RLock()
+	defer fake.cancelDeploymentMutex.RUnlock()
+	return len(fake.cancelDeploymentArgsForCall)
+}
+
+func (fake *FakeRepository) CancelDeploymentArgsForCall(i int) string {
+	fake.cancelDeploymentMutex.RLock()
+	defer fake.cancelDeploymentMutex.RUnlock()
+	return fake.cancelDeploymentArgsForCall[i].deploymentGUID
+}
+
+func (fake *FakeRepository) CancelDeploymentReturns(result1 error) {
+	fake.CancelDeploymentStub = nil
+	fake.cancelDeploymentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) ContinueDeployment(deploymentGUID string) error {
+	fake.continueDeploymentMutex.Lock()
+	fake.continueDeploymentArgsForCall = append(fake.continueDeploymentArgsForCall, struct {
+		deploymentGUID string
+	}{deploymentGUID})
+	fake.recordInvocation("ContinueDeployment", []interface{}{deploymentGUID})
+	fake.continueDeploymentMutex.Unlock()
+	if fake.ContinueDeploymentStub != nil {
+		return fake.ContinueDeploymentStub(deploymentGUID)
+	} else {
+		return fake.continueDeploymentReturns.result1
+	}
+}
+
+func (fake *FakeRepository) ContinueDeploymentCallCount() int {
+	fake.continueDeploymentMutex.RLock()
+	defer fake.continueDeploymentMutex.RUnlock()
+	return len(fake.continueDeploymentArgsForCall)
+}
+
+func (fake *FakeRepository) ContinueDeploymentArgsForCall(i int) string {
+	fake.continueDeploymentMutex.RLock()
+	defer fake.continueDeploymentMutex.RUnlock()
+	return fake.continueDeploymentArgsForCall[i].deploymentGUID
+}
+
+func (fake *FakeRepository) ContinueDeploymentReturns(result1 error) {
+	fake.ContinueDeploymentStub = nil
+	fake.continueDeploymentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createDeploymentMutex.RLock()
+	defer fake.createDeploymentMutex.RUnlock()
+	fake.getDeploymentMutex.RLock()
+	defer fake.getDeploymentMutex.RUnlock()
+	fake.listDeploymentsMutex.RLock()
+	defer fake.listDeploymentsMutex.RUnlock()
+	fake.cancelDeploymentMutex.RLock()
+	defer fake.cancelDeploymentMutex.RUnlock()
+	fake.continueDeploymentMutex.RLock()
+	defer fake.continueDeploymentMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ deployments.Repository = new(FakeRepository)
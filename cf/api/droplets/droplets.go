@@ -0,0 +1,193 @@
+package droplets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+	"code.cloudfoundry.org/gofileutils/fileutils"
+)
+
+//go:generate counterfeiter . Repository
+
+// Repository reads and sets the droplets of an app through the v3 droplets
+// API, which keeps the droplets of earlier pushes around.
+type Repository interface {
+	ListStagedDroplets(appGUID string) ([]models.Droplet, error)
+	GetCurrentDropletGUID(appGUID string) (string, error)
+	SetCurrentDroplet(appGUID string, dropletGUID string) error
+	GetDroplet(dropletGUID string) (models.Droplet, error)
+	DownloadDroplet(dropletGUID string, dest io.Writer) error
+	CreateDroplet(appGUID string) (models.Droplet, error)
+	UploadDroplet(dropletGUID string, dropletFile *os.File) error
+}
+
+type CloudControllerRepository struct {
+	config  coreconfig.Reader
+	gateway net.Gateway
+}
+
+func NewCloudControllerRepository(config coreconfig.Reader, gateway net.Gateway) CloudControllerRepository {
+	return CloudControllerRepository{
+		config:  config,
+		gateway: gateway,
+	}
+}
+
+// ListStagedDroplets returns the droplets of the app that staged
+// successfully, newest first.
+func (repo CloudControllerRepository) ListStagedDroplets(appGUID string) ([]models.Droplet, error) {
+	droplets := []models.Droplet{}
+
+	url := fmt.Sprintf("%s/v3/apps/%s/droplets?states=STAGED&order_by=-created_at", repo.config.APIEndpoint(), appGUID)
+	for url != "" {
+		page := resources.PaginatedDropletResources{}
+		err := repo.gateway.GetResource(url, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range page.Resources {
+			droplets = append(droplets, resource.ToModel())
+		}
+
+		url = ""
+		if page.Pagination.Next != nil {
+			url = page.Pagination.Next.Href
+		}
+	}
+
+	return droplets, nil
+}
+
+func (repo CloudControllerRepository) GetCurrentDropletGUID(appGUID string) (string, error) {
+	relationship := resources.CurrentDropletRelationshipResource{}
+	err := repo.gateway.GetResource(fmt.Sprintf("%s/v3/apps/%s/relationships/current_droplet", repo.config.APIEndpoint(), appGUID), &relationship)
+	if err != nil {
+		return "", err
+	}
+
+	return relationship.Data.GUID, nil
+}
+
+func (repo CloudControllerRepository) SetCurrentDroplet(appGUID string, dropletGUID string) error {
+	relationship := resources.CurrentDropletRelationshipResource{}
+	relationship.Data.GUID = dropletGUID
+
+	body, err := json.Marshal(relationship)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/v3/apps/%s/relationships/current_droplet", repo.config.APIEndpoint(), appGUID)
+	request, err := repo.gateway.NewRequest("PATCH", url, repo.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.gateway.PerformRequest(request)
+	return err
+}
+
+func (repo CloudControllerRepository) GetDroplet(dropletGUID string) (models.Droplet, error) {
+	resource := resources.DropletResource{}
+	err := repo.gateway.GetResource(fmt.Sprintf("%s/v3/droplets/%s", repo.config.APIEndpoint(), dropletGUID), &resource)
+	if err != nil {
+		return models.Droplet{}, err
+	}
+
+	return resource.ToModel(), nil
+}
+
+// DownloadDroplet writes the gzipped tarball of the droplet to dest. The
+// Cloud Controller redirects the download to its blobstore.
+func (repo CloudControllerRepository) DownloadDroplet(dropletGUID string, dest io.Writer) error {
+	url := fmt.Sprintf("%s/v3/droplets/%s/download", repo.config.APIEndpoint(), dropletGUID)
+	request, err := repo.gateway.NewRequest("GET", url, repo.config.AccessToken(), nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := repo.gateway.PerformRequest(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	_, err = io.Copy(dest, response.Body)
+	if err != nil {
+		return fmt.Errorf("%s: %s", T("Error downloading droplet"), err.Error())
+	}
+	return nil
+}
+
+// CreateDroplet creates an empty droplet for the app, for a droplet staged
+// elsewhere to be uploaded into.
+func (repo CloudControllerRepository) CreateDroplet(appGUID string) (models.Droplet, error) {
+	droplet := resources.DropletCreateResource{}
+	droplet.Relationships.App.Data.GUID = appGUID
+
+	body, err := json.Marshal(droplet)
+	if err != nil {
+		return models.Droplet{}, err
+	}
+
+	request, err := repo.gateway.NewRequest("POST", repo.config.APIEndpoint()+"/v3/droplets", repo.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return models.Droplet{}, err
+	}
+
+	resource := resources.DropletResource{}
+	_, err = repo.gateway.PerformRequestForJSONResponse(request, &resource)
+	if err != nil {
+		return models.Droplet{}, err
+	}
+
+	return resource.ToModel(), nil
+}
+
+// UploadDroplet uploads the gzipped tarball of a droplet into a droplet
+// created with CreateDroplet. The Cloud Controller processes the upload in
+// the background; the droplet is STAGED once it is done.
+func (repo CloudControllerRepository) UploadDroplet(dropletGUID string, dropletFile *os.File) (apiErr error) {
+	fileutils.TempFile("requests", func(requestFile *os.File, err error) {
+		if err != nil {
+			apiErr = fmt.Errorf("%s: %s", T("Error creating tmp file"), err.Error())
+			return
+		}
+
+		writer := multipart.NewWriter(requestFile)
+		part, err := writer.CreateFormFile("bits", filepath.Base(dropletFile.Name()))
+		if err == nil {
+			_, err = io.Copy(part, dropletFile)
+		}
+		if err == nil {
+			err = writer.Close()
+		}
+		if err != nil {
+			apiErr = fmt.Errorf("%s: %s", T("Error writing to tmp file"), err.Error())
+			return
+		}
+
+		url := fmt.Sprintf("%s/v3/droplets/%s/upload", repo.config.APIEndpoint(), dropletGUID)
+		request, err := repo.gateway.NewRequestForFile("POST", url, repo.config.AccessToken(), requestFile)
+		if err != nil {
+			apiErr = err
+			return
+		}
+		request.HTTPReq.Header.Set("Content-Type", writer.FormDataContentType())
+
+		_, apiErr = repo.gateway.PerformRequest(request)
+	})
+
+	return
+}
@@ -0,0 +1,273 @@
+package droplets_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+
+	"github.com/onsi/gomega/ghttp"
+
+	. "code.cloudfoundry.org/cli/cf/api/droplets"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DropletsRepo", func() {
+	var (
+		testServer *ghttp.Server
+		configRepo coreconfig.ReadWriter
+		repo       Repository
+	)
+
+	BeforeEach(func() {
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		configRepo.SetAccessToken("BEARER my_access_token")
+
+		gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		repo = NewCloudControllerRepository(configRepo, gateway)
+	})
+
+	BeforeEach(func() {
+		testServer = ghttp.NewServer()
+		configRepo.SetAPIEndpoint(testServer.URL())
+	})
+
+	AfterEach(func() {
+		if testServer != nil {
+			testServer.Close()
+		}
+	})
+
+	Describe("ListStagedDroplets", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/droplets", "states=STAGED&order_by=-created_at"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": {
+							"next": { "href": "`+testServer.URL()+`/v3/apps/app-guid/droplets?states=STAGED&order_by=-created_at&page=2" }
+						},
+						"resources": [
+							{
+								"guid": "droplet-2-guid",
+								"state": "STAGED",
+								"created_at": "2016-11-02T10:00:00Z",
+								"lifecycle": { "data": { "buildpack": "ruby_buildpack", "stack": "cflinuxfs2" } }
+							}
+						]
+					}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/droplets", "states=STAGED&order_by=-created_at&page=2"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": { "next": null },
+						"resources": [
+							{
+								"guid": "droplet-1-guid",
+								"state": "STAGED",
+								"created_at": "2016-11-01T10:00:00Z",
+								"lifecycle": { "data": {} }
+							},
+							{
+								"guid": "droplet-0-guid",
+								"state": "STAGED",
+								"created_at": "2016-10-31T10:00:00Z",
+								"lifecycle": { "type": "cnb", "data": { "stack": "cflinuxfs4" } },
+								"buildpacks": [
+									{ "name": "docker://registry.example.com/go-cnb", "buildpack_name": "paketo-buildpacks/go" },
+									{ "name": "docker://registry.example.com/procfile-cnb" }
+								]
+							}
+						]
+					}`),
+				),
+			)
+		})
+
+		It("returns the droplets from every page", func() {
+			droplets, err := repo.ListStagedDroplets("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testServer.ReceivedRequests()).To(HaveLen(2))
+
+			Expect(droplets).To(Equal([]models.Droplet{
+				{
+					GUID:      "droplet-2-guid",
+					State:     "STAGED",
+					Buildpack: "ruby_buildpack",
+					Stack:     "cflinuxfs2",
+					CreatedAt: time.Date(2016, 11, 2, 10, 0, 0, 0, time.UTC),
+				},
+				{
+					GUID:      "droplet-1-guid",
+					State:     "STAGED",
+					CreatedAt: time.Date(2016, 11, 1, 10, 0, 0, 0, time.UTC),
+				},
+				{
+					GUID:      "droplet-0-guid",
+					State:     "STAGED",
+					Lifecycle: "cnb",
+					Buildpack: "paketo-buildpacks/go, docker://registry.example.com/procfile-cnb",
+					Stack:     "cflinuxfs4",
+					CreatedAt: time.Date(2016, 10, 31, 10, 0, 0, 0, time.UTC),
+				},
+			}))
+		})
+	})
+
+	Describe("GetCurrentDropletGUID", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/relationships/current_droplet"),
+					ghttp.RespondWith(http.StatusOK, `{ "data": { "guid": "droplet-2-guid" } }`),
+				),
+			)
+		})
+
+		It("returns the guid of the current droplet", func() {
+			guid, err := repo.GetCurrentDropletGUID("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(guid).To(Equal("droplet-2-guid"))
+		})
+	})
+
+	Describe("SetCurrentDroplet", func() {
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				testServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PATCH", "/v3/apps/app-guid/relationships/current_droplet"),
+						ghttp.VerifyJSON(`{ "data": { "guid": "droplet-1-guid" } }`),
+						ghttp.RespondWith(http.StatusOK, `{ "data": { "guid": "droplet-1-guid" } }`),
+					),
+				)
+			})
+
+			It("sets the current droplet", func() {
+				err := repo.SetCurrentDroplet("app-guid", "droplet-1-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(testServer.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				testServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PATCH", "/v3/apps/app-guid/relationships/current_droplet"),
+						ghttp.RespondWith(http.StatusUnprocessableEntity, `{ "code": 10008, "description": "Unable to assign current droplet" }`),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				err := repo.SetCurrentDroplet("app-guid", "droplet-1-guid")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("GetDroplet", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/droplets/droplet-1-guid"),
+					ghttp.RespondWith(http.StatusOK, `{ "guid": "droplet-1-guid", "state": "PROCESSING_UPLOAD" }`),
+				),
+			)
+		})
+
+		It("returns the droplet", func() {
+			droplet, err := repo.GetDroplet("droplet-1-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(droplet.GUID).To(Equal("droplet-1-guid"))
+			Expect(droplet.State).To(Equal("PROCESSING_UPLOAD"))
+		})
+	})
+
+	Describe("DownloadDroplet", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/droplets/droplet-1-guid/download"),
+					ghttp.RespondWith(http.StatusOK, "droplet-tarball"),
+				),
+			)
+		})
+
+		It("writes the droplet to the destination", func() {
+			dest := &bytes.Buffer{}
+			err := repo.DownloadDroplet("droplet-1-guid", dest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dest.String()).To(Equal("droplet-tarball"))
+		})
+	})
+
+	Describe("CreateDroplet", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/v3/droplets"),
+					ghttp.VerifyJSON(`{ "relationships": { "app": { "data": { "guid": "app-guid" } } } }`),
+					ghttp.RespondWith(http.StatusCreated, `{ "guid": "droplet-1-guid", "state": "AWAITING_UPLOAD" }`),
+				),
+			)
+		})
+
+		It("creates a droplet for the app", func() {
+			droplet, err := repo.CreateDroplet("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(droplet.GUID).To(Equal("droplet-1-guid"))
+			Expect(droplet.State).To(Equal("AWAITING_UPLOAD"))
+		})
+	})
+
+	Describe("UploadDroplet", func() {
+		var dropletFile *os.File
+
+		BeforeEach(func() {
+			var err error
+			dropletFile, err = ioutil.TempFile("", "droplet")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = dropletFile.WriteString("droplet-tarball")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = dropletFile.Seek(0, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/v3/droplets/droplet-1-guid/upload"),
+					func(w http.ResponseWriter, r *http.Request) {
+						file, _, err := r.FormFile("bits")
+						Expect(err).NotTo(HaveOccurred())
+						contents, err := ioutil.ReadAll(file)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(contents)).To(Equal("droplet-tarball"))
+					},
+					ghttp.RespondWith(http.StatusAccepted, `{ "guid": "droplet-1-guid", "state": "PROCESSING_UPLOAD" }`),
+				),
+			)
+		})
+
+		AfterEach(func() {
+			dropletFile.Close()
+			os.Remove(dropletFile.Name())
+		})
+
+		It("uploads the droplet as the bits of a multipart form", func() {
+			err := repo.UploadDroplet("droplet-1-guid", dropletFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
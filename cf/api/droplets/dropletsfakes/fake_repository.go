@@ -0,0 +1,351 @@
+// This file was generated by counterfeiter
+package dropletsfakes
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/api/droplets"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type FakeRepository struct {
+	ListStagedDropletsStub        func(appGUID string) ([]models.Droplet, error)
+	listStagedDropletsMutex       sync.RWMutex
+	listStagedDropletsArgsForCall []struct {
+		appGUID string
+	}
+	listStagedDropletsReturns struct {
+		result1 []models.Droplet
+		result2 error
+	}
+	GetCurrentDropletGUIDStub        func(appGUID string) (string, error)
+	getCurrentDropletGUIDMutex       sync.RWMutex
+	getCurrentDropletGUIDArgsForCall []struct {
+		appGUID string
+	}
+	getCurrentDropletGUIDReturns struct {
+		result1 string
+		result2 error
+	}
+	SetCurrentDropletStub        func(appGUID string, dropletGUID string) error
+	setCurrentDropletMutex       sync.RWMutex
+	setCurrentDropletArgsForCall []struct {
+		appGUID     string
+		dropletGUID string
+	}
+	setCurrentDropletReturns struct {
+		result1 error
+	}
+	GetDropletStub        func(dropletGUID string) (models.Droplet, error)
+	getDropletMutex       sync.RWMutex
+	getDropletArgsForCall []struct {
+		dropletGUID string
+	}
+	getDropletReturns struct {
+		result1 models.Droplet
+		result2 error
+	}
+	DownloadDropletStub        func(dropletGUID string, dest io.Writer) error
+	downloadDropletMutex       sync.RWMutex
+	downloadDropletArgsForCall []struct {
+		dropletGUID string
+		dest        io.Writer
+	}
+	downloadDropletReturns struct {
+		result1 error
+	}
+	CreateDropletStub        func(appGUID string) (models.Droplet, error)
+	createDropletMutex       sync.RWMutex
+	createDropletArgsForCall []struct {
+		appGUID string
+	}
+	createDropletReturns struct {
+		result1 models.Droplet
+		result2 error
+	}
+	UploadDropletStub        func(dropletGUID string, dropletFile *os.File) error
+	uploadDropletMutex       sync.RWMutex
+	uploadDropletArgsForCall []struct {
+		dropletGUID string
+		dropletFile *os.File
+	}
+	uploadDropletReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRepository) ListStagedDroplets(appGUID string) ([]models.Droplet, error) {
+	fake.listStagedDropletsMutex.Lock()
+	fake.listStagedDropletsArgsForCall = append(fake.listStagedDropletsArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("ListStagedDroplets", []interface{}{appGUID})
+	fake.listStagedDropletsMutex.Unlock()
+	if fake.ListStagedDropletsStub != nil {
+		return fake.ListStagedDropletsStub(appGUID)
+	} else {
+		return fake.listStagedDropletsReturns.result1, fake.listStagedDropletsReturns.result2
+	}
+}
+
+func (fake *FakeRepository) ListStagedDropletsCallCount() int {
+	fake.listStagedDropletsMutex.RLock()
+	defer fake.listStagedDropletsMutex.RUnlock()
+	return len(fake.listStagedDropletsArgsForCall)
+}
+
+func (fake *FakeRepository) ListStagedDropletsArgsForCall(i int) string {
+	fake.listStagedDropletsMutex.RLock()
+	defer fake.listStagedDropletsMutex.RUnlock()
+	return fake.listStagedDropletsArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) ListStagedDropletsReturns(result1 []models.Droplet, result2 error) {
+	fake.ListStagedDropletsStub = nil
+	fake.listStagedDropletsReturns = struct {
+		result1 []models.Droplet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetCurrentDropletGUID(appGUID string) (string, error) {
+	fake.getCurrentDropletGUIDMutex.Lock()
+	fake.getCurrentDropletGUIDArgsForCall = append(fake.getCurrentDropletGUIDArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("GetCurrentDropletGUID", []interface{}{appGUID})
+	fake.getCurrentDropletGUIDMutex.Unlock()
+	if fake.GetCurrentDropletGUIDStub != nil {
+		return fake.GetCurrentDropletGUIDStub(appGUID)
+	} else {
+		return fake.getCurrentDropletGUIDReturns.result1, fake.getCurrentDropletGUIDReturns.result2
+	}
+}
+
+func (fake *FakeRepository) GetCurrentDropletGUIDCallCount() int {
+	fake.getCurrentDropletGUIDMutex.RLock()
+	defer fake.getCurrentDropletGUIDMutex.RUnlock()
+	return len(fake.getCurrentDropletGUIDArgsForCall)
+}
+
+func (fake *FakeRepository) GetCurrentDropletGUIDArgsForCall(i int) string {
+	fake.getCurrentDropletGUIDMutex.RLock()
+	defer fake.getCurrentDropletGUIDMutex.RUnlock()
+	return fake.getCurrentDropletGUIDArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) GetCurrentDropletGUIDReturns(result1 string, result2 error) {
+	fake.GetCurrentDropletGUIDStub = nil
+	fake.getCurrentDropletGUIDReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) SetCurrentDroplet(appGUID string, dropletGUID string) error {
+	fake.setCurrentDropletMutex.Lock()
+	fake.setCurrentDropletArgsForCall = append(fake.setCurrentDropletArgsForCall, struct {
+		appGUID     string
+		dropletGUID string
+	}{appGUID, dropletGUID})
+	fake.recordInvocation("SetCurrentDroplet", []interface{}{appGUID, dropletGUID})
+	fake.setCurrentDropletMutex.Unlock()
+	if fake.SetCurrentDropletStub != nil {
+		return fake.SetCurrentDropletStub(appGUID, dropletGUID)
+	} else {
+		return fake.setCurrentDropletReturns.result1
+	}
+}
+
+func (fake *FakeRepository) SetCurrentDropletCallCount() int {
+	fake.setCurrentDropletMutex.RLock()
+	defer fake.setCurrentDropletMutex.RUnlock()
+	return len(fake.setCurrentDropletArgsForCall)
+}
+
+func (fake *FakeRepository) SetCurrentDropletArgsForCall(i int) (string, string) {
+	fake.setCurrentDropletMutex.RLock()
+	defer fake.setCurrentDropletMutex.RUnlock()
+	return fake.setCurrentDropletArgsForCall[i].appGUID, fake.setCurrentDropletArgsForCall[i].dropletGUID
+}
+
+func (fake *FakeRepository) SetCurrentDropletReturns(result1 error) {
+	fake.SetCurrentDropletStub = nil
+	fake.setCurrentDropletReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) GetDroplet(dropletGUID string) (models.Droplet, error) {
+	fake.getDropletMutex.Lock()
+	fake.getDropletArgsForCall = append(fake.getDropletArgsForCall, struct {
+		dropletGUID string
+	}{dropletGUID})
+	fake.recordInvocation("GetDroplet", []interface{}{dropletGUID})
+	fake.getDropletMutex.Unlock()
+	if fake.GetDropletStub != nil {
+		return fake.GetDropletStub(dropletGUID)
+	} else {
+		return fake.getDropletReturns.result1, fake.getDropletReturns.result2
+	}
+}
+
+func (fake *FakeRepository) GetDropletCallCount() int {
+	fake.getDropletMutex.RLock()
+	defer fake.getDropletMutex.RUnlock()
+	return len(fake.getDropletArgsForCall)
+}
+
+func (fake *FakeRepository) GetDropletArgsForCall(i int) string {
+	fake.getDropletMutex.RLock()
+	defer fake.getDropletMutex.RUnlock()
+	return fake.getDropletArgsForCall[i].dropletGUID
+}
+
+func (fake *FakeRepository) GetDropletReturns(result1 models.Droplet, result2 error) {
+	fake.GetDropletStub = nil
+	fake.getDropletReturns = struct {
+		result1 models.Droplet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) DownloadDroplet(dropletGUID string, dest io.Writer) error {
+	fake.downloadDropletMutex.Lock()
+	fake.downloadDropletArgsForCall = append(fake.downloadDropletArgsForCall, struct {
+		dropletGUID string
+		dest        io.Writer
+	}{dropletGUID, dest})
+	fake.recordInvocation("DownloadDroplet", []interface{}{dropletGUID, dest})
+	fake.downloadDropletMutex.Unlock()
+	if fake.DownloadDropletStub != nil {
+		return fake.DownloadDropletStub(dropletGUID, dest)
+	} else {
+		return fake.downloadDropletReturns.result1
+	}
+}
+
+func (fake *FakeRepository) DownloadDropletCallCount() int {
+	fake.downloadDropletMutex.RLock()
+	defer fake.downloadDropletMutex.RUnlock()
+	return len(fake.downloadDropletArgsForCall)
+}
+
+func (fake *FakeRepository) DownloadDropletArgsForCall(i int) (string, io.Writer) {
+	fake.downloadDropletMutex.RLock()
+	defer fake.downloadDropletMutex.RUnlock()
+	return fake.downloadDropletArgsForCall[i].dropletGUID, fake.downloadDropletArgsForCall[i].dest
+}
+
+func (fake *FakeRepository) DownloadDropletReturns(result1 error) {
+	fake.DownloadDropletStub = nil
+	fake.downloadDropletReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) CreateDroplet(appGUID string) (models.Droplet, error) {
+	fake.createDropletMutex.Lock()
+	fake.createDropletArgsForCall = append(fake.createDropletArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("CreateDroplet", []interface{}{appGUID})
+	fake.createDropletMutex.Unlock()
+	if fake.CreateDropletStub != nil {
+		return fake.CreateDropletStub(appGUID)
+	} else {
+		return fake.createDropletReturns.result1, fake.createDropletReturns.result2
+	}
+}
+
+func (fake *FakeRepository) CreateDropletCallCount() int {
+	fake.createDropletMutex.RLock()
+	defer fake.createDropletMutex.RUnlock()
+	return len(fake.createDropletArgsForCall)
+}
+
+func (fake *FakeRepository) CreateDropletArgsForCall(i int) string {
+	fake.createDropletMutex.RLock()
+	defer fake.createDropletMutex.RUnlock()
+	return fake.createDropletArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) CreateDropletReturns(result1 models.Droplet, result2 error) {
+	fake.CreateDropletStub = nil
+	fake.createDropletReturns = struct {
+		result1 models.Droplet
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) UploadDroplet(dropletGUID string, dropletFile *os.File) error {
+	fake.uploadDropletMutex.Lock()
+	fake.uploadDropletArgsForCall = append(fake.uploadDropletArgsForCall, struct {
+		dropletGUID string
+		dropletFile *os.File
+	}{dropletGUID, dropletFile})
+	fake.recordInvocation("UploadDroplet", []interface{}{dropletGUID, dropletFile})
+	fake.uploadDropletMutex.Unlock()
+	if fake.UploadDropletStub != nil {
+		return fake.UploadDropletStub(dropletGUID, dropletFile)
+	} else {
+		return fake.uploadDropletReturns.result1
+	}
+}
+
+func (fake *FakeRepository) UploadDropletCallCount() int {
+	fake.uploadDropletMutex.RLock()
+	defer fake.uploadDropletMutex.RUnlock()
+	return len(fake.uploadDropletArgsForCall)
+}
+
+func (fake *FakeRepository) UploadDropletArgsForCall(i int) (string, *os.File) {
+	fake.uploadDropletMutex.RLock()
+	defer fake.uploadDropletMutex.RUnlock()
+	return fake.uploadDropletArgsForCall[i].dropletGUID, fake.uploadDropletArgsForCall[i].dropletFile
+}
+
+func (fake *FakeRepository) UploadDropletReturns(result1 error) {
+	fake.UploadDropletStub = nil
+	fake.uploadDropletReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listStagedDropletsMutex.RLock()
+	defer fake.listStagedDropletsMutex.RUnlock()
+	fake.getCurrentDropletGUIDMutex.RLock()
+	defer fake.getCurrentDropletGUIDMutex.RUnlock()
+	fake.setCurrentDropletMutex.RLock()
+	defer fake.setCurrentDropletMutex.RUnlock()
+	fake.getDropletMutex.RLock()
+	defer fake.getDropletMutex.RUnlock()
+	fake.downloadDropletMutex.RLock()
+	defer fake.downloadDropletMutex.RUnlock()
+	fake.createDropletMutex.RLock()
+	defer fake.createDropletMutex.RUnlock()
+	fake.uploadDropletMutex.RLock()
+	defer fake.uploadDropletMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ droplets.Repository = new(FakeRepository)
@@ -55,6 +55,7 @@ var _ = Describe("Feature Flags Repository", func() {
 			Expect(len(featureFlagModels)).To(Equal(5))
 			Expect(featureFlagModels[0].Name).To(Equal("user_org_creation"))
 			Expect(featureFlagModels[0].Enabled).To(BeFalse())
+			Expect(featureFlagModels[0].Description).To(Equal("Any user can create an organization"))
 			Expect(featureFlagModels[1].Name).To(Equal("private_domain_creation"))
 			Expect(featureFlagModels[1].Enabled).To(BeFalse())
 			Expect(featureFlagModels[2].Name).To(Equal("app_bits_upload"))
@@ -79,6 +80,7 @@ var _ = Describe("Feature Flags Repository", func() {
 
 			Expect(featureFlagModel.Name).To(Equal("user_org_creation"))
 			Expect(featureFlagModel.Enabled).To(BeFalse())
+			Expect(featureFlagModel.Description).To(Equal("Any user can create an organization"))
 		})
 	})
 
@@ -115,6 +117,7 @@ var featureFlagsGetAllRequest = apifakes.NewCloudControllerTestRequest(testnet.T
       "name": "user_org_creation",
       "enabled": false,
       "error_message": null,
+      "description": "Any user can create an organization",
       "url": "/v2/config/feature_flags/user_org_creation"
     },
     {
@@ -154,6 +157,7 @@ var featureFlagRequest = apifakes.NewCloudControllerTestRequest(testnet.TestRequ
   "name": "user_org_creation",
   "enabled": false,
   "error_message": null,
+  "description": "Any user can create an organization",
   "url": "/v2/config/feature_flags/user_org_creation"
 }`,
 	},
@@ -0,0 +1,117 @@
+package logs
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Filter selects log messages by their text, the type of their source, the
+// instance of their source and their time. Empty fields match every message.
+// Lines keeps only the last Lines of the matching recent logs.
+type Filter struct {
+	Pattern        *regexp.Regexp
+	SourceType     string
+	SourceInstance string
+	Since          time.Time
+	Until          time.Time
+	Lines          int
+}
+
+// Matches returns whether msg passes the filter. Source types match
+// case-insensitively, on the whole type or on its first segment, so that
+// "app" matches "APP/PROC/WEB".
+func (f Filter) Matches(msg Loggable) bool {
+	if f.SourceType != "" {
+		sourceType := strings.ToUpper(msg.GetSourceName())
+		wanted := strings.ToUpper(f.SourceType)
+		if sourceType != wanted && !strings.HasPrefix(sourceType, wanted+"/") {
+			return false
+		}
+	}
+
+	if f.SourceInstance != "" && msg.GetSourceInstance() != f.SourceInstance {
+		return false
+	}
+
+	if f.Pattern != nil && !f.Pattern.MatchString(msg.ToSimpleLog()) {
+		return false
+	}
+
+	if !f.Since.IsZero() || !f.Until.IsZero() {
+		timestamp := msg.ToEnvelope().Timestamp
+		if !f.Since.IsZero() && timestamp.Before(f.Since) {
+			return false
+		}
+		if !f.Until.IsZero() && timestamp.After(f.Until) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FilteringRepository passes on only the messages of a Repository that match
+// a Filter.
+type FilteringRepository struct {
+	repo   Repository
+	filter Filter
+}
+
+func NewFilteringRepository(repo Repository, filter Filter) *FilteringRepository {
+	return &FilteringRepository{
+		repo:   repo,
+		filter: filter,
+	}
+}
+
+func (repo *FilteringRepository) Close() {
+	repo.repo.Close()
+}
+
+func (repo *FilteringRepository) RecentLogsFor(appGUID string) ([]Loggable, error) {
+	messages, err := repo.repo.RecentLogsFor(appGUID)
+
+	filtered := []Loggable{}
+	for _, msg := range messages {
+		if repo.filter.Matches(msg) {
+			filtered = append(filtered, msg)
+		}
+	}
+
+	if repo.filter.Lines > 0 && len(filtered) > repo.filter.Lines {
+		filtered = filtered[len(filtered)-repo.filter.Lines:]
+	}
+
+	return filtered, err
+}
+
+func (repo *FilteringRepository) TailLogsFor(appGUID string, onConnect func(), logChan chan<- Loggable, errChan chan<- error) {
+	c := make(chan Loggable)
+	e := make(chan error)
+
+	go func() {
+		for c != nil {
+			select {
+			case msg, ok := <-c:
+				if !ok {
+					c = nil
+					continue
+				}
+				if repo.filter.Matches(msg) {
+					logChan <- msg
+				}
+			case err, ok := <-e:
+				if !ok {
+					e = nil
+					continue
+				}
+				errChan <- err
+			}
+		}
+
+		close(logChan)
+	}()
+
+	repo.repo.TailLogsFor(appGUID, onConnect, c, e)
+}
@@ -0,0 +1,144 @@
+package logs_test
+
+import (
+	"regexp"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	"code.cloudfoundry.org/cli/cf/api/logs/logsfakes"
+	testlogs "code.cloudfoundry.org/cli/testhelpers/logs"
+	"github.com/cloudfoundry/loggregatorlib/logmessage"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FilteringRepository", func() {
+	var (
+		fakeRepo *logsfakes.FakeRepository
+		filter   logs.Filter
+		repo     *logs.FilteringRepository
+		messages []logs.Loggable
+		now      time.Time
+	)
+
+	BeforeEach(func() {
+		fakeRepo = new(logsfakes.FakeRepository)
+		filter = logs.Filter{}
+
+		now = time.Date(2016, 11, 2, 15, 0, 0, 0, time.UTC)
+		messages = []logs.Loggable{
+			testlogs.NewLogMessage("GET /index 200", "app-guid", "RTR", "0", logmessage.LogMessage_OUT, now.Add(-3*time.Hour)),
+			testlogs.NewLogMessage("ERROR database unreachable", "app-guid", "APP/PROC/WEB", "0", logmessage.LogMessage_ERR, now.Add(-2*time.Hour)),
+			testlogs.NewLogMessage("ERROR disk full", "app-guid", "APP/PROC/WEB", "1", logmessage.LogMessage_ERR, now.Add(-1*time.Hour)),
+			testlogs.NewLogMessage("Staging complete", "app-guid", "STG", "0", logmessage.LogMessage_OUT, now),
+		}
+		fakeRepo.RecentLogsForReturns(messages, nil)
+	})
+
+	JustBeforeEach(func() {
+		repo = logs.NewFilteringRepository(fakeRepo, filter)
+	})
+
+	recentLogs := func() []string {
+		recent, err := repo.RecentLogsFor("app-guid")
+		Expect(err).NotTo(HaveOccurred())
+
+		texts := []string{}
+		for _, msg := range recent {
+			texts = append(texts, msg.ToSimpleLog())
+		}
+		return texts
+	}
+
+	It("passes on every message with an empty filter", func() {
+		Expect(recentLogs()).To(HaveLen(4))
+		Expect(fakeRepo.RecentLogsForArgsForCall(0)).To(Equal("app-guid"))
+	})
+
+	Context("with a pattern", func() {
+		BeforeEach(func() {
+			filter.Pattern = regexp.MustCompile("^ERROR")
+		})
+
+		It("passes on the messages matching the pattern", func() {
+			Expect(recentLogs()).To(Equal([]string{"ERROR database unreachable", "ERROR disk full"}))
+		})
+	})
+
+	Context("with a source type", func() {
+		BeforeEach(func() {
+			filter.SourceType = "app"
+		})
+
+		It("matches the first segment of the source type case-insensitively", func() {
+			Expect(recentLogs()).To(Equal([]string{"ERROR database unreachable", "ERROR disk full"}))
+		})
+	})
+
+	Context("with a source instance", func() {
+		BeforeEach(func() {
+			filter.SourceType = "app"
+			filter.SourceInstance = "1"
+		})
+
+		It("passes on the messages from that instance", func() {
+			Expect(recentLogs()).To(Equal([]string{"ERROR disk full"}))
+		})
+	})
+
+	Context("with a time range", func() {
+		BeforeEach(func() {
+			filter.Since = now.Add(-2 * time.Hour)
+			filter.Until = now.Add(-1 * time.Hour)
+		})
+
+		It("passes on the messages in the range", func() {
+			Expect(recentLogs()).To(Equal([]string{"ERROR database unreachable", "ERROR disk full"}))
+		})
+	})
+
+	Context("with a number of lines", func() {
+		BeforeEach(func() {
+			filter.Lines = 2
+			filter.SourceType = "app"
+		})
+
+		It("passes on the last lines of the matching messages", func() {
+			filter.SourceType = ""
+			repo = logs.NewFilteringRepository(fakeRepo, filter)
+			Expect(recentLogs()).To(Equal([]string{"ERROR disk full", "Staging complete"}))
+		})
+
+		It("applies the other filters first", func() {
+			Expect(recentLogs()).To(Equal([]string{"ERROR database unreachable", "ERROR disk full"}))
+		})
+	})
+
+	Describe("TailLogsFor", func() {
+		BeforeEach(func() {
+			filter.SourceType = "rtr"
+			fakeRepo.TailLogsForStub = func(appGUID string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+				onConnect()
+				go func() {
+					for _, msg := range messages {
+						logChan <- msg
+					}
+					close(logChan)
+				}()
+			}
+		})
+
+		It("passes on the matching messages and closes the channel when the stream ends", func() {
+			logChan := make(chan logs.Loggable)
+			errChan := make(chan error)
+			connected := false
+
+			repo.TailLogsFor("app-guid", func() { connected = true }, logChan, errChan)
+
+			Expect(connected).To(BeTrue())
+			Expect((<-logChan).ToSimpleLog()).To(Equal("GET /index 200"))
+			Eventually(logChan).Should(BeClosed())
+		})
+	})
+})
@@ -25,6 +25,26 @@ func (m *loggregatorLogMessage) GetSourceName() string {
 	return m.msg.GetSourceName()
 }
 
+func (m *loggregatorLogMessage) GetSourceInstance() string {
+	return m.msg.GetSourceId()
+}
+
+func (m *loggregatorLogMessage) ToEnvelope() Envelope {
+	messageType := "OUT"
+	if m.msg.GetMessageType() == logmessage.LogMessage_ERR {
+		messageType = "ERR"
+	}
+
+	return Envelope{
+		Timestamp:      time.Unix(0, m.msg.GetTimestamp()).UTC(),
+		AppGUID:        m.msg.GetAppId(),
+		SourceType:     m.msg.GetSourceName(),
+		SourceInstance: m.msg.GetSourceId(),
+		MessageType:    messageType,
+		Message:        m.ToSimpleLog(),
+	}
+}
+
 func (m *loggregatorLogMessage) ToLog(loc *time.Location) string {
 	logMsg := m.msg
 
@@ -3,6 +3,7 @@ package logs_test
 import (
 	"time"
 
+	"code.cloudfoundry.org/cli/cf/api/logs"
 	testlogs "code.cloudfoundry.org/cli/testhelpers/logs"
 
 	"code.cloudfoundry.org/cli/cf/terminal"
@@ -43,4 +44,20 @@ var _ = Describe("loggregatorMessage", func() {
 			Expect(terminal.Decolorize(msg.ToLog(time.FixedZone("the-zone", 3*60*60)))).To(Equal("2014-04-04T14:39:20.00+0300 [DEA/4]      ERR Hello World!"))
 		})
 	})
+
+	Describe("ToEnvelope", func() {
+		It("returns the fields of the message", func() {
+			date := time.Date(2014, 4, 4, 11, 39, 20, 5, time.UTC)
+			msg := testlogs.NewLogMessage("Hello World!\n", "app-guid", "DEA", "4", logmessage.LogMessage_ERR, date)
+
+			Expect(msg.ToEnvelope()).To(Equal(logs.Envelope{
+				Timestamp:      date,
+				AppGUID:        "app-guid",
+				SourceType:     "DEA",
+				SourceInstance: "4",
+				MessageType:    "ERR",
+				Message:        "Hello World!",
+			}))
+		})
+	})
 })
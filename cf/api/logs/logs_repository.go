@@ -5,7 +5,19 @@ import "time"
 type Loggable interface {
 	ToLog(loc *time.Location) string
 	ToSimpleLog() string
+	ToEnvelope() Envelope
 	GetSourceName() string
+	GetSourceInstance() string
+}
+
+// Envelope is the form of a log message printed by logs --json.
+type Envelope struct {
+	Timestamp      time.Time `json:"timestamp"`
+	AppGUID        string    `json:"app_guid"`
+	SourceType     string    `json:"source_type"`
+	SourceInstance string    `json:"source_instance"`
+	MessageType    string    `json:"message_type"`
+	Message        string    `json:"message"`
 }
 
 //go:generate counterfeiter . Repository
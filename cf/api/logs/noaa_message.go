@@ -30,6 +30,26 @@ func (m *noaaLogMessage) GetSourceName() string {
 	return m.msg.GetSourceType()
 }
 
+func (m *noaaLogMessage) GetSourceInstance() string {
+	return m.msg.GetSourceInstance()
+}
+
+func (m *noaaLogMessage) ToEnvelope() Envelope {
+	messageType := "OUT"
+	if m.msg.GetMessageType() == events.LogMessage_ERR {
+		messageType = "ERR"
+	}
+
+	return Envelope{
+		Timestamp:      time.Unix(0, m.msg.GetTimestamp()).UTC(),
+		AppGUID:        m.msg.GetAppId(),
+		SourceType:     m.msg.GetSourceType(),
+		SourceInstance: m.msg.GetSourceInstance(),
+		MessageType:    messageType,
+		Message:        m.ToSimpleLog(),
+	}
+}
+
 func (m *noaaLogMessage) ToLog(loc *time.Location) string {
 	logMsg := m.msg
 
@@ -0,0 +1,81 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+)
+
+//go:generate counterfeiter . Repository
+
+// Repository reads and changes the labels and annotations of any kind of v3
+// resource. Resources are addressed by the path of their collection, such as
+// /v3/apps, since the metadata API is the same for all of them.
+type Repository interface {
+	ListResources(resourcePath string, filters url.Values) ([]models.LabeledResource, error)
+	UpdateMetadata(resourcePath string, guid string, update models.MetadataUpdate) error
+}
+
+type CloudControllerRepository struct {
+	config  coreconfig.Reader
+	gateway net.Gateway
+}
+
+func NewCloudControllerRepository(config coreconfig.Reader, gateway net.Gateway) CloudControllerRepository {
+	return CloudControllerRepository{
+		config:  config,
+		gateway: gateway,
+	}
+}
+
+// ListResources returns the resources of the collection that match the
+// filters, such as names or label_selector.
+func (repo CloudControllerRepository) ListResources(resourcePath string, filters url.Values) ([]models.LabeledResource, error) {
+	labeled := []models.LabeledResource{}
+
+	url := fmt.Sprintf("%s%s", repo.config.APIEndpoint(), resourcePath)
+	if len(filters) > 0 {
+		url += "?" + filters.Encode()
+	}
+
+	for url != "" {
+		page := resources.PaginatedLabeledResources{}
+		err := repo.gateway.GetResource(url, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range page.Resources {
+			labeled = append(labeled, resource.ToModel())
+		}
+
+		url = ""
+		if page.Pagination.Next != nil {
+			url = page.Pagination.Next.Href
+		}
+	}
+
+	return labeled, nil
+}
+
+func (repo CloudControllerRepository) UpdateMetadata(resourcePath string, guid string, update models.MetadataUpdate) error {
+	body, err := json.Marshal(resources.NewMetadataUpdateRequest(update))
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s%s/%s", repo.config.APIEndpoint(), resourcePath, guid)
+	request, err := repo.gateway.NewRequest("PATCH", url, repo.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.gateway.PerformRequest(request)
+	return err
+}
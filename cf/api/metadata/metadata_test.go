@@ -0,0 +1,109 @@
+package metadata_test
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+
+	"github.com/onsi/gomega/ghttp"
+
+	. "code.cloudfoundry.org/cli/cf/api/metadata"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MetadataRepo", func() {
+	var (
+		testServer *ghttp.Server
+		configRepo coreconfig.ReadWriter
+		repo       Repository
+	)
+
+	BeforeEach(func() {
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		configRepo.SetAccessToken("BEARER my_access_token")
+
+		gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		repo = NewCloudControllerRepository(configRepo, gateway)
+	})
+
+	BeforeEach(func() {
+		testServer = ghttp.NewServer()
+		configRepo.SetAPIEndpoint(testServer.URL())
+	})
+
+	AfterEach(func() {
+		if testServer != nil {
+			testServer.Close()
+		}
+	})
+
+	Describe("ListResources", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps", "label_selector=env%3Dprod&space_guids=space-guid"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": {
+							"next": { "href": "`+testServer.URL()+`/v3/apps?label_selector=env%3Dprod&space_guids=space-guid&page=2" }
+						},
+						"resources": [
+							{ "guid": "app-1-guid", "name": "app-1", "metadata": { "labels": { "env": "prod" }, "annotations": { "owner": "billing" } } }
+						]
+					}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps", "label_selector=env%3Dprod&space_guids=space-guid&page=2"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": { "next": null },
+						"resources": [
+							{ "guid": "app-2-guid", "name": "app-2", "metadata": { "labels": { "env": "prod", "tier": "web" }, "annotations": {} } }
+						]
+					}`),
+				),
+			)
+		})
+
+		It("returns the matching resources of every page", func() {
+			filters := url.Values{}
+			filters.Set("label_selector", "env=prod")
+			filters.Set("space_guids", "space-guid")
+
+			labeled, err := repo.ListResources("/v3/apps", filters)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(labeled).To(Equal([]models.LabeledResource{
+				{GUID: "app-1-guid", Name: "app-1", Metadata: models.Metadata{Labels: map[string]string{"env": "prod"}, Annotations: map[string]string{"owner": "billing"}}},
+				{GUID: "app-2-guid", Name: "app-2", Metadata: models.Metadata{Labels: map[string]string{"env": "prod", "tier": "web"}, Annotations: map[string]string{}}},
+			}))
+		})
+	})
+
+	Describe("UpdateMetadata", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/v3/spaces/space-guid"),
+					ghttp.VerifyJSON(`{ "metadata": { "labels": { "env": "prod", "tier": null } } }`),
+					ghttp.RespondWith(http.StatusOK, `{ "guid": "space-guid" }`),
+				),
+			)
+		})
+
+		It("sets and removes labels", func() {
+			env := "prod"
+			err := repo.UpdateMetadata("/v3/spaces", "space-guid", models.MetadataUpdate{
+				Labels: map[string]*string{"env": &env, "tier": nil},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,129 @@
+// This file was generated by counterfeiter
+package metadatafakes
+
+import (
+	"net/url"
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/api/metadata"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type FakeRepository struct {
+	ListResourcesStub        func(resourcePath string, filters url.Values) ([]models.LabeledResource, error)
+	listResourcesMutex       sync.RWMutex
+	listResourcesArgsForCall []struct {
+		resourcePath string
+		filters      url.Values
+	}
+	listResourcesReturns struct {
+		result1 []models.LabeledResource
+		result2 error
+	}
+	UpdateMetadataStub        func(resourcePath string, guid string, update models.MetadataUpdate) error
+	updateMetadataMutex       sync.RWMutex
+	updateMetadataArgsForCall []struct {
+		resourcePath string
+		guid         string
+		update       models.MetadataUpdate
+	}
+	updateMetadataReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRepository) ListResources(resourcePath string, filters url.Values) ([]models.LabeledResource, error) {
+	fake.listResourcesMutex.Lock()
+	fake.listResourcesArgsForCall = append(fake.listResourcesArgsForCall, struct {
+		resourcePath string
+		filters      url.Values
+	}{resourcePath, filters})
+	fake.recordInvocation("ListResources", []interface{}{resourcePath, filters})
+	fake.listResourcesMutex.Unlock()
+	if fake.ListResourcesStub != nil {
+		return fake.ListResourcesStub(resourcePath, filters)
+	} else {
+		return fake.listResourcesReturns.result1, fake.listResourcesReturns.result2
+	}
+}
+
+func (fake *FakeRepository) ListResourcesCallCount() int {
+	fake.listResourcesMutex.RLock()
+	defer fake.listResourcesMutex.RUnlock()
+	return len(fake.listResourcesArgsForCall)
+}
+
+func (fake *FakeRepository) ListResourcesArgsForCall(i int) (string, url.Values) {
+	fake.listResourcesMutex.RLock()
+	defer fake.listResourcesMutex.RUnlock()
+	return fake.listResourcesArgsForCall[i].resourcePath, fake.listResourcesArgsForCall[i].filters
+}
+
+func (fake *FakeRepository) ListResourcesReturns(result1 []models.LabeledResource, result2 error) {
+	fake.ListResourcesStub = nil
+	fake.listResourcesReturns = struct {
+		result1 []models.LabeledResource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) UpdateMetadata(resourcePath string, guid string, update models.MetadataUpdate) error {
+	fake.updateMetadataMutex.Lock()
+	fake.updateMetadataArgsForCall = append(fake.updateMetadataArgsForCall, struct {
+		resourcePath string
+		guid         string
+		update       models.MetadataUpdate
+	}{resourcePath, guid, update})
+	fake.recordInvocation("UpdateMetadata", []interface{}{resourcePath, guid, update})
+	fake.updateMetadataMutex.Unlock()
+	if fake.UpdateMetadataStub != nil {
+		return fake.UpdateMetadataStub(resourcePath, guid, update)
+	} else {
+		return fake.updateMetadataReturns.result1
+	}
+}
+
+func (fake *FakeRepository) UpdateMetadataCallCount() int {
+	fake.updateMetadataMutex.RLock()
+	defer fake.updateMetadataMutex.RUnlock()
+	return len(fake.updateMetadataArgsForCall)
+}
+
+func (fake *FakeRepository) UpdateMetadataArgsForCall(i int) (string, string, models.MetadataUpdate) {
+	fake.updateMetadataMutex.RLock()
+	defer fake.updateMetadataMutex.RUnlock()
+	return fake.updateMetadataArgsForCall[i].resourcePath, fake.updateMetadataArgsForCall[i].guid, fake.updateMetadataArgsForCall[i].update
+}
+
+func (fake *FakeRepository) UpdateMetadataReturns(result1 error) {
+	fake.UpdateMetadataStub = nil
+	fake.updateMetadataReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listResourcesMutex.RLock()
+	defer fake.listResourcesMutex.RUnlock()
+	fake.updateMetadataMutex.RLock()
+	defer fake.updateMetadataMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ metadata.Repository = new(FakeRepository)
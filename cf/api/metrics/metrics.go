@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+)
+
+type StatsAPIResponse map[string]InstanceStatsAPIResponse
+
+type InstanceStatsAPIResponse struct {
+	State string
+	Stats struct {
+		Uptime    int64
+		DiskQuota int64 `json:"disk_quota"`
+		MemQuota  int64 `json:"mem_quota"`
+		Usage     struct {
+			CPU  float64
+			Disk int64
+			Mem  int64
+		}
+	}
+}
+
+//go:generate counterfeiter . Repository
+
+// Repository reads the resource usage of the instances of an app, as
+// averaged by the platform over its most recent collection window.
+type Repository interface {
+	GetAppMetrics(appGUID string) ([]models.AppInstanceMetrics, error)
+}
+
+type CloudControllerRepository struct {
+	config  coreconfig.Reader
+	gateway net.Gateway
+}
+
+func NewCloudControllerRepository(config coreconfig.Reader, gateway net.Gateway) CloudControllerRepository {
+	return CloudControllerRepository{
+		config:  config,
+		gateway: gateway,
+	}
+}
+
+func (repo CloudControllerRepository) GetAppMetrics(appGUID string) ([]models.AppInstanceMetrics, error) {
+	statsResponse := StatsAPIResponse{}
+	err := repo.gateway.GetResource(fmt.Sprintf("%s/v2/apps/%s/stats", repo.config.APIEndpoint(), appGUID), &statsResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := []models.AppInstanceMetrics{}
+	for k, v := range statsResponse {
+		index, err := strconv.Atoi(k)
+		if err != nil {
+			continue
+		}
+
+		metrics = append(metrics, models.AppInstanceMetrics{
+			Index:     index,
+			State:     models.InstanceState(strings.ToLower(v.State)),
+			Uptime:    time.Duration(v.Stats.Uptime) * time.Second,
+			CPUUsage:  v.Stats.Usage.CPU,
+			MemUsage:  v.Stats.Usage.Mem,
+			MemQuota:  v.Stats.MemQuota,
+			DiskUsage: v.Stats.Usage.Disk,
+			DiskQuota: v.Stats.DiskQuota,
+		})
+	}
+
+	sort.Sort(byIndex(metrics))
+	return metrics, nil
+}
+
+type byIndex []models.AppInstanceMetrics
+
+func (m byIndex) Len() int           { return len(m) }
+func (m byIndex) Swap(i, j int)      { m[i], m[j] = m[j], m[i] }
+func (m byIndex) Less(i, j int) bool { return m[i].Index < m[j].Index }
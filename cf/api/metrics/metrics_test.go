@@ -0,0 +1,124 @@
+package metrics_test
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+
+	"github.com/onsi/gomega/ghttp"
+
+	. "code.cloudfoundry.org/cli/cf/api/metrics"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MetricsRepo", func() {
+	var (
+		testServer *ghttp.Server
+		configRepo coreconfig.ReadWriter
+		repo       Repository
+	)
+
+	BeforeEach(func() {
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		configRepo.SetAccessToken("BEARER my_access_token")
+
+		gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		repo = NewCloudControllerRepository(configRepo, gateway)
+	})
+
+	BeforeEach(func() {
+		testServer = ghttp.NewServer()
+		configRepo.SetAPIEndpoint(testServer.URL())
+	})
+
+	AfterEach(func() {
+		if testServer != nil {
+			testServer.Close()
+		}
+	})
+
+	Describe("GetAppMetrics", func() {
+		Context("when the app has instances", func() {
+			BeforeEach(func() {
+				testServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/apps/app-guid/stats"),
+						ghttp.RespondWith(http.StatusOK, `{
+						"1": {
+							"state": "STARTING",
+							"stats": {
+								"uptime": 5,
+								"mem_quota": 67108864,
+								"disk_quota": 1073741824,
+								"usage": { "cpu": 0.5, "mem": 1048576, "disk": 2097152 }
+							}
+						},
+						"0": {
+							"state": "RUNNING",
+							"stats": {
+								"uptime": 3723,
+								"mem_quota": 67108864,
+								"disk_quota": 1073741824,
+								"usage": { "cpu": 0.0125, "mem": 19218432, "disk": 56037376 }
+							}
+						}
+					}`),
+					),
+				)
+			})
+
+			It("returns the metrics of the instances ordered by index", func() {
+				metrics, err := repo.GetAppMetrics("app-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(testServer.ReceivedRequests()).To(HaveLen(1))
+
+				Expect(metrics).To(Equal([]models.AppInstanceMetrics{
+					{
+						Index:     0,
+						State:     models.InstanceRunning,
+						Uptime:    3723 * time.Second,
+						CPUUsage:  0.0125,
+						MemUsage:  19218432,
+						MemQuota:  67108864,
+						DiskUsage: 56037376,
+						DiskQuota: 1073741824,
+					},
+					{
+						Index:     1,
+						State:     models.InstanceStarting,
+						Uptime:    5 * time.Second,
+						CPUUsage:  0.5,
+						MemUsage:  1048576,
+						MemQuota:  67108864,
+						DiskUsage: 2097152,
+						DiskQuota: 1073741824,
+					},
+				}))
+			})
+		})
+
+		Context("when the app does not exist", func() {
+			BeforeEach(func() {
+				testServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/apps/app-guid/stats"),
+						ghttp.RespondWith(http.StatusNotFound, `{"code": 100004, "description": "The app could not be found: app-guid"}`),
+					),
+				)
+			})
+
+			It("returns the error", func() {
+				_, err := repo.GetAppMetrics("app-guid")
+				Expect(err).To(MatchError(ContainSubstring("The app could not be found")))
+			})
+		})
+	})
+})
@@ -0,0 +1,79 @@
+// This file was generated by counterfeiter
+package metricsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/api/metrics"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type FakeRepository struct {
+	GetAppMetricsStub        func(appGUID string) ([]models.AppInstanceMetrics, error)
+	getAppMetricsMutex       sync.RWMutex
+	getAppMetricsArgsForCall []struct {
+		appGUID string
+	}
+	getAppMetricsReturns struct {
+		result1 []models.AppInstanceMetrics
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRepository) GetAppMetrics(appGUID string) ([]models.AppInstanceMetrics, error) {
+	fake.getAppMetricsMutex.Lock()
+	fake.getAppMetricsArgsForCall = append(fake.getAppMetricsArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("GetAppMetrics", []interface{}{appGUID})
+	fake.getAppMetricsMutex.Unlock()
+	if fake.GetAppMetricsStub != nil {
+		return fake.GetAppMetricsStub(appGUID)
+	} else {
+		return fake.getAppMetricsReturns.result1, fake.getAppMetricsReturns.result2
+	}
+}
+
+func (fake *FakeRepository) GetAppMetricsCallCount() int {
+	fake.getAppMetricsMutex.RLock()
+	defer fake.getAppMetricsMutex.RUnlock()
+	return len(fake.getAppMetricsArgsForCall)
+}
+
+func (fake *FakeRepository) GetAppMetricsArgsForCall(i int) string {
+	fake.getAppMetricsMutex.RLock()
+	defer fake.getAppMetricsMutex.RUnlock()
+	return fake.getAppMetricsArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) GetAppMetricsReturns(result1 []models.AppInstanceMetrics, result2 error) {
+	fake.GetAppMetricsStub = nil
+	fake.getAppMetricsReturns = struct {
+		result1 []models.AppInstanceMetrics
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getAppMetricsMutex.RLock()
+	defer fake.getAppMetricsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ metrics.Repository = new(FakeRepository)
@@ -0,0 +1,74 @@
+package networkpolicies
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+)
+
+//go:generate counterfeiter . Repository
+
+// Repository lists, creates and deletes the container-to-container network
+// policies of the CF networking policy server, which is served under the
+// /networking path of the API endpoint.
+type Repository interface {
+	ListPolicies(appGUIDs []string) ([]models.NetworkPolicy, error)
+	CreatePolicy(policy models.NetworkPolicy) error
+	DeletePolicy(policy models.NetworkPolicy) error
+}
+
+type NetworkingRepository struct {
+	config  coreconfig.Reader
+	gateway net.Gateway
+}
+
+func NewNetworkingRepository(config coreconfig.Reader, gateway net.Gateway) NetworkingRepository {
+	return NetworkingRepository{
+		config:  config,
+		gateway: gateway,
+	}
+}
+
+// ListPolicies returns the policies whose source or destination is one of
+// appGUIDs, or every policy the user can see when appGUIDs is empty.
+func (repo NetworkingRepository) ListPolicies(appGUIDs []string) ([]models.NetworkPolicy, error) {
+	endpoint := repo.policiesEndpoint()
+	if len(appGUIDs) > 0 {
+		endpoint += "?id=" + url.QueryEscape(strings.Join(appGUIDs, ","))
+	}
+
+	response := resources.NetworkPoliciesResource{}
+	err := repo.gateway.GetResource(endpoint, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := []models.NetworkPolicy{}
+	for _, resource := range response.Policies {
+		policies = append(policies, resource.ToModel())
+	}
+	return policies, nil
+}
+
+func (repo NetworkingRepository) CreatePolicy(policy models.NetworkPolicy) error {
+	return repo.gateway.CreateResourceFromStruct(repo.policiesEndpoint(), "", policiesBody(policy))
+}
+
+func (repo NetworkingRepository) DeletePolicy(policy models.NetworkPolicy) error {
+	return repo.gateway.CreateResourceFromStruct(repo.policiesEndpoint(), "/delete", policiesBody(policy))
+}
+
+func (repo NetworkingRepository) policiesEndpoint() string {
+	return fmt.Sprintf("%s/networking/v1/external/policies", repo.config.APIEndpoint())
+}
+
+func policiesBody(policy models.NetworkPolicy) resources.NetworkPoliciesResource {
+	return resources.NetworkPoliciesResource{
+		Policies: []resources.NetworkPolicyResource{resources.NewNetworkPolicyResource(policy)},
+	}
+}
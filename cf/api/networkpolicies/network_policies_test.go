@@ -0,0 +1,164 @@
+package networkpolicies_test
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+
+	"github.com/onsi/gomega/ghttp"
+
+	. "code.cloudfoundry.org/cli/cf/api/networkpolicies"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NetworkPoliciesRepo", func() {
+	var (
+		testServer *ghttp.Server
+		configRepo coreconfig.ReadWriter
+		repo       Repository
+	)
+
+	BeforeEach(func() {
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		configRepo.SetAccessToken("BEARER my_access_token")
+
+		gateway := net.NewNetworkingGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		repo = NewNetworkingRepository(configRepo, gateway)
+	})
+
+	BeforeEach(func() {
+		testServer = ghttp.NewServer()
+		configRepo.SetAPIEndpoint(testServer.URL())
+	})
+
+	AfterEach(func() {
+		if testServer != nil {
+			testServer.Close()
+		}
+	})
+
+	policyJSON := `{
+		"policies": [{
+			"source": { "id": "source-guid" },
+			"destination": { "id": "destination-guid", "protocol": "tcp", "ports": { "start": 8080, "end": 8090 } }
+		}]
+	}`
+
+	Describe("ListPolicies", func() {
+		Context("when app guids are given", func() {
+			BeforeEach(func() {
+				testServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/networking/v1/external/policies", "id=source-guid%2Cother-guid"),
+						ghttp.VerifyHeaderKV("Authorization", "BEARER my_access_token"),
+						ghttp.RespondWith(http.StatusOK, policyJSON),
+					),
+				)
+			})
+
+			It("returns the policies of those apps", func() {
+				policies, err := repo.ListPolicies([]string{"source-guid", "other-guid"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(testServer.ReceivedRequests()).To(HaveLen(1))
+				Expect(policies).To(Equal([]models.NetworkPolicy{
+					{
+						SourceGUID:      "source-guid",
+						DestinationGUID: "destination-guid",
+						Protocol:        "tcp",
+						StartPort:       8080,
+						EndPort:         8090,
+					},
+				}))
+			})
+		})
+
+		Context("when no app guids are given", func() {
+			BeforeEach(func() {
+				testServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/networking/v1/external/policies", ""),
+						ghttp.RespondWith(http.StatusOK, `{"total_policies": 0, "policies": []}`),
+					),
+				)
+			})
+
+			It("lists every policy", func() {
+				policies, err := repo.ListPolicies(nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policies).To(BeEmpty())
+			})
+		})
+
+		Context("when the policy server returns an error", func() {
+			BeforeEach(func() {
+				testServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/networking/v1/external/policies"),
+						ghttp.RespondWith(http.StatusForbidden, `{"error": "provided scopes [] do not include allowed scopes [network.admin network.write]"}`),
+					),
+				)
+			})
+
+			It("returns the error", func() {
+				_, err := repo.ListPolicies(nil)
+				Expect(err).To(MatchError(ContainSubstring("do not include allowed scopes")))
+			})
+		})
+	})
+
+	Describe("CreatePolicy", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/networking/v1/external/policies"),
+					ghttp.VerifyJSON(policyJSON),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+			)
+		})
+
+		It("creates the policy", func() {
+			err := repo.CreatePolicy(models.NetworkPolicy{
+				SourceGUID:      "source-guid",
+				DestinationGUID: "destination-guid",
+				Protocol:        "tcp",
+				StartPort:       8080,
+				EndPort:         8090,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
+	Describe("DeletePolicy", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/networking/v1/external/policies/delete"),
+					ghttp.VerifyJSON(policyJSON),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+			)
+		})
+
+		It("deletes the policy", func() {
+			err := repo.DeletePolicy(models.NetworkPolicy{
+				SourceGUID:      "source-guid",
+				DestinationGUID: "destination-guid",
+				Protocol:        "tcp",
+				StartPort:       8080,
+				EndPort:         8090,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,170 @@
+// This file was generated by counterfeiter
+package networkpoliciesfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/api/networkpolicies"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type FakeRepository struct {
+	ListPoliciesStub        func(appGUIDs []string) ([]models.NetworkPolicy, error)
+	listPoliciesMutex       sync.RWMutex
+	listPoliciesArgsForCall []struct {
+		appGUIDs []string
+	}
+	listPoliciesReturns struct {
+		result1 []models.NetworkPolicy
+		result2 error
+	}
+	CreatePolicyStub        func(policy models.NetworkPolicy) error
+	createPolicyMutex       sync.RWMutex
+	createPolicyArgsForCall []struct {
+		policy models.NetworkPolicy
+	}
+	createPolicyReturns struct {
+		result1 error
+	}
+	DeletePolicyStub        func(policy models.NetworkPolicy) error
+	deletePolicyMutex       sync.RWMutex
+	deletePolicyArgsForCall []struct {
+		policy models.NetworkPolicy
+	}
+	deletePolicyReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRepository) ListPolicies(appGUIDs []string) ([]models.NetworkPolicy, error) {
+	var appGUIDsCopy []string
+	if appGUIDs != nil {
+		appGUIDsCopy = make([]string, len(appGUIDs))
+		copy(appGUIDsCopy, appGUIDs)
+	}
+	fake.listPoliciesMutex.Lock()
+	fake.listPoliciesArgsForCall = append(fake.listPoliciesArgsForCall, struct {
+		appGUIDs []string
+	}{appGUIDsCopy})
+	fake.recordInvocation("ListPolicies", []interface{}{appGUIDsCopy})
+	fake.listPoliciesMutex.Unlock()
+	if fake.ListPoliciesStub != nil {
+		return fake.ListPoliciesStub(appGUIDs)
+	} else {
+		return fake.listPoliciesReturns.result1, fake.listPoliciesReturns.result2
+	}
+}
+
+func (fake *FakeRepository) ListPoliciesCallCount() int {
+	fake.listPoliciesMutex.RLock()
+	defer fake.listPoliciesMutex.RUnlock()
+	return len(fake.listPoliciesArgsForCall)
+}
+
+func (fake *FakeRepository) ListPoliciesArgsForCall(i int) []string {
+	fake.listPoliciesMutex.RLock()
+	defer fake.listPoliciesMutex.RUnlock()
+	return fake.listPoliciesArgsForCall[i].appGUIDs
+}
+
+func (fake *FakeRepository) ListPoliciesReturns(result1 []models.NetworkPolicy, result2 error) {
+	fake.ListPoliciesStub = nil
+	fake.listPoliciesReturns = struct {
+		result1 []models.NetworkPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) CreatePolicy(policy models.NetworkPolicy) error {
+	fake.createPolicyMutex.Lock()
+	fake.createPolicyArgsForCall = append(fake.createPolicyArgsForCall, struct {
+		policy models.NetworkPolicy
+	}{policy})
+	fake.recordInvocation("CreatePolicy", []interface{}{policy})
+	fake.createPolicyMutex.Unlock()
+	if fake.CreatePolicyStub != nil {
+		return fake.CreatePolicyStub(policy)
+	} else {
+		return fake.createPolicyReturns.result1
+	}
+}
+
+func (fake *FakeRepository) CreatePolicyCallCount() int {
+	fake.createPolicyMutex.RLock()
+	defer fake.createPolicyMutex.RUnlock()
+	return len(fake.createPolicyArgsForCall)
+}
+
+func (fake *FakeRepository) CreatePolicyArgsForCall(i int) models.NetworkPolicy {
+	fake.createPolicyMutex.RLock()
+	defer fake.createPolicyMutex.RUnlock()
+	return fake.createPolicyArgsForCall[i].policy
+}
+
+func (fake *FakeRepository) CreatePolicyReturns(result1 error) {
+	fake.CreatePolicyStub = nil
+	fake.createPolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) DeletePolicy(policy models.NetworkPolicy) error {
+	fake.deletePolicyMutex.Lock()
+	fake.deletePolicyArgsForCall = append(fake.deletePolicyArgsForCall, struct {
+		policy models.NetworkPolicy
+	}{policy})
+	fake.recordInvocation("DeletePolicy", []interface{}{policy})
+	fake.deletePolicyMutex.Unlock()
+	if fake.DeletePolicyStub != nil {
+		return fake.DeletePolicyStub(policy)
+	} else {
+		return fake.deletePolicyReturns.result1
+	}
+}
+
+func (fake *FakeRepository) DeletePolicyCallCount() int {
+	fake.deletePolicyMutex.RLock()
+	defer fake.deletePolicyMutex.RUnlock()
+	return len(fake.deletePolicyArgsForCall)
+}
+
+func (fake *FakeRepository) DeletePolicyArgsForCall(i int) models.NetworkPolicy {
+	fake.deletePolicyMutex.RLock()
+	defer fake.deletePolicyMutex.RUnlock()
+	return fake.deletePolicyArgsForCall[i].policy
+}
+
+func (fake *FakeRepository) DeletePolicyReturns(result1 error) {
+	fake.DeletePolicyStub = nil
+	fake.deletePolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listPoliciesMutex.RLock()
+	defer fake.listPoliciesMutex.RUnlock()
+	fake.createPolicyMutex.RLock()
+	defer fake.createPolicyMutex.RUnlock()
+	fake.deletePolicyMutex.RLock()
+	defer fake.deletePolicyMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ networkpolicies.Repository = new(FakeRepository)
@@ -23,6 +23,7 @@ type OrganizationRepository interface {
 	Delete(orgGUID string) (apiErr error)
 	SharePrivateDomain(orgGUID string, domainGUID string) (apiErr error)
 	UnsharePrivateDomain(orgGUID string, domainGUID string) (apiErr error)
+	GetUsage(orgGUID string) (models.OrganizationUsage, error)
 }
 
 type CloudControllerOrganizationRepository struct {
@@ -114,3 +115,56 @@ func (repo CloudControllerOrganizationRepository) UnsharePrivateDomain(orgGUID s
 	url := fmt.Sprintf("/v2/organizations/%s/private_domains/%s", orgGUID, domainGUID)
 	return repo.gateway.DeleteResource(repo.config.APIEndpoint(), url)
 }
+
+// GetUsage adds up what the org uses of the resources its quota limits: the
+// memory of its running app instances, its routes, its managed service
+// instances and the instances of its started apps.
+func (repo CloudControllerOrganizationRepository) GetUsage(orgGUID string) (models.OrganizationUsage, error) {
+	usage := models.OrganizationUsage{}
+
+	memory := struct {
+		MemoryUsageInMB int64 `json:"memory_usage_in_mb"`
+	}{}
+	err := repo.gateway.GetResource(fmt.Sprintf("%s/v2/organizations/%s/memory_usage", repo.config.APIEndpoint(), orgGUID), &memory)
+	if err != nil {
+		return models.OrganizationUsage{}, err
+	}
+	usage.MemoryInMB = memory.MemoryUsageInMB
+
+	query := url.QueryEscape("organization_guid:" + orgGUID)
+
+	usage.Routes, err = repo.countResources(fmt.Sprintf("/v2/routes?q=%s&results-per-page=1", query))
+	if err != nil {
+		return models.OrganizationUsage{}, err
+	}
+
+	usage.ServiceInstances, err = repo.countResources(fmt.Sprintf("/v2/service_instances?q=%s&results-per-page=1", query))
+	if err != nil {
+		return models.OrganizationUsage{}, err
+	}
+
+	err = repo.gateway.ListPaginatedResources(
+		repo.config.APIEndpoint(),
+		fmt.Sprintf("/v2/apps?q=%s", query),
+		resources.ApplicationResource{},
+		func(resource interface{}) bool {
+			app := resource.(resources.ApplicationResource).ToModel()
+			if app.State == models.ApplicationStateStarted {
+				usage.AppInstances += app.InstanceCount
+			}
+			return true
+		})
+	if err != nil {
+		return models.OrganizationUsage{}, err
+	}
+
+	return usage, nil
+}
+
+func (repo CloudControllerOrganizationRepository) countResources(path string) (int, error) {
+	page := struct {
+		TotalResults int `json:"total_results"`
+	}{}
+	err := repo.gateway.GetResource(repo.config.APIEndpoint()+path, &page)
+	return page.TotalResults, err
+}
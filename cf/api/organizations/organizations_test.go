@@ -260,7 +260,7 @@ var _ = Describe("Organization Repository", func() {
 			requestHandler := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
 				Method:   "GET",
 				Path:     "/v2/organizations?q=name%3Aorg1&inline-relations-depth=1",
-				Response: testnet.TestResponse{Status: http.StatusBadGateway, Body: `{"resources": []}`},
+				Response: testnet.TestResponse{Status: http.StatusInternalServerError, Body: `{"resources": []}`},
 			})
 
 			testserver, handler, repo := createOrganizationRepo(requestHandler)
@@ -388,6 +388,83 @@ var _ = Describe("Organization Repository", func() {
 			Expect(apiErr).NotTo(HaveOccurred())
 		})
 	})
+
+	Describe("GetUsage", func() {
+		var (
+			ccServer *ghttp.Server
+			repo     CloudControllerOrganizationRepository
+		)
+
+		BeforeEach(func() {
+			ccServer = ghttp.NewServer()
+			configRepo := testconfig.NewRepositoryWithDefaults()
+			configRepo.SetAPIEndpoint(ccServer.URL())
+			gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+			repo = NewCloudControllerOrganizationRepository(configRepo, gateway)
+		})
+
+		AfterEach(func() {
+			ccServer.Close()
+		})
+
+		Context("when every request succeeds", func() {
+			BeforeEach(func() {
+				ccServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/organizations/org-guid/memory_usage"),
+						ghttp.RespondWith(http.StatusOK, `{"memory_usage_in_mb": 1536}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/routes", "q=organization_guid:org-guid&results-per-page=1"),
+						ghttp.RespondWith(http.StatusOK, `{"total_results": 7, "resources": []}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/service_instances", "q=organization_guid:org-guid&results-per-page=1"),
+						ghttp.RespondWith(http.StatusOK, `{"total_results": 3, "resources": []}`),
+					),
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/apps", "q=organization_guid:org-guid"),
+						ghttp.RespondWith(http.StatusOK, `{
+							"next_url": null,
+							"resources": [
+								{ "metadata": { "guid": "app-1-guid" }, "entity": { "name": "app-1", "state": "STARTED", "instances": 2 } },
+								{ "metadata": { "guid": "app-2-guid" }, "entity": { "name": "app-2", "state": "STOPPED", "instances": 5 } },
+								{ "metadata": { "guid": "app-3-guid" }, "entity": { "name": "app-3", "state": "STARTED", "instances": 1 } }
+							]
+						}`),
+					),
+				)
+			})
+
+			It("adds up the usage of the org, counting only the instances of started apps", func() {
+				usage, err := repo.GetUsage("org-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ccServer.ReceivedRequests()).To(HaveLen(4))
+				Expect(usage).To(Equal(models.OrganizationUsage{
+					MemoryInMB:       1536,
+					Routes:           7,
+					ServiceInstances: 3,
+					AppInstances:     3,
+				}))
+			})
+		})
+
+		Context("when the memory usage cannot be fetched", func() {
+			BeforeEach(func() {
+				ccServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/organizations/org-guid/memory_usage"),
+						ghttp.RespondWith(http.StatusForbidden, `{"code": 10003, "description": "You are not authorized to perform the requested action"}`),
+					),
+				)
+			})
+
+			It("returns the error", func() {
+				_, err := repo.GetUsage("org-guid")
+				Expect(err).To(MatchError(ContainSubstring("You are not authorized")))
+			})
+		})
+	})
 })
 
 func createOrganizationRepo(reqs ...testnet.TestRequest) (testserver *httptest.Server, handler *testnet.TestHandler, repo OrganizationRepository) {
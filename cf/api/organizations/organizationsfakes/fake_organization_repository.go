@@ -79,6 +79,15 @@ type FakeOrganizationRepository struct {
 	unsharePrivateDomainReturns struct {
 		result1 error
 	}
+	GetUsageStub        func(orgGUID string) (models.OrganizationUsage, error)
+	getUsageMutex       sync.RWMutex
+	getUsageArgsForCall []struct {
+		orgGUID string
+	}
+	getUsageReturns struct {
+		result1 models.OrganizationUsage
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -358,6 +367,40 @@ func (fake *FakeOrganizationRepository) UnsharePrivateDomainReturns(result1 erro
 	}{result1}
 }
 
+func (fake *FakeOrganizationRepository) GetUsage(orgGUID string) (models.OrganizationUsage, error) {
+	fake.getUsageMutex.Lock()
+	fake.getUsageArgsForCall = append(fake.getUsageArgsForCall, struct {
+		orgGUID string
+	}{orgGUID})
+	fake.recordInvocation("GetUsage", []interface{}{orgGUID})
+	fake.getUsageMutex.Unlock()
+	if fake.GetUsageStub != nil {
+		return fake.GetUsageStub(orgGUID)
+	} else {
+		return fake.getUsageReturns.result1, fake.getUsageReturns.result2
+	}
+}
+
+func (fake *FakeOrganizationRepository) GetUsageCallCount() int {
+	fake.getUsageMutex.RLock()
+	defer fake.getUsageMutex.RUnlock()
+	return len(fake.getUsageArgsForCall)
+}
+
+func (fake *FakeOrganizationRepository) GetUsageArgsForCall(i int) string {
+	fake.getUsageMutex.RLock()
+	defer fake.getUsageMutex.RUnlock()
+	return fake.getUsageArgsForCall[i].orgGUID
+}
+
+func (fake *FakeOrganizationRepository) GetUsageReturns(result1 models.OrganizationUsage, result2 error) {
+	fake.GetUsageStub = nil
+	fake.getUsageReturns = struct {
+		result1 models.OrganizationUsage
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeOrganizationRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -377,6 +420,8 @@ func (fake *FakeOrganizationRepository) Invocations() map[string][][]interface{}
 	defer fake.sharePrivateDomainMutex.RUnlock()
 	fake.unsharePrivateDomainMutex.RLock()
 	defer fake.unsharePrivateDomainMutex.RUnlock()
+	fake.getUsageMutex.RLock()
+	defer fake.getUsageMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,138 @@
+package packages
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+	"code.cloudfoundry.org/gofileutils/fileutils"
+)
+
+//go:generate counterfeiter . Repository
+
+// Repository lists and creates the packages of an app through the v3
+// packages API, so that uploading bits can be kept apart from staging them.
+type Repository interface {
+	ListPackages(appGUID string) ([]models.Package, error)
+	GetPackage(packageGUID string) (models.Package, error)
+	CreatePackage(appGUID string) (models.Package, error)
+	UploadBits(packageGUID string, zipFile *os.File) error
+}
+
+type CloudControllerRepository struct {
+	config  coreconfig.Reader
+	gateway net.Gateway
+}
+
+func NewCloudControllerRepository(config coreconfig.Reader, gateway net.Gateway) CloudControllerRepository {
+	return CloudControllerRepository{
+		config:  config,
+		gateway: gateway,
+	}
+}
+
+// ListPackages returns the packages of the app, newest first.
+func (repo CloudControllerRepository) ListPackages(appGUID string) ([]models.Package, error) {
+	packages := []models.Package{}
+
+	url := fmt.Sprintf("%s/v3/apps/%s/packages?order_by=-created_at", repo.config.APIEndpoint(), appGUID)
+	for url != "" {
+		page := resources.PaginatedPackageResources{}
+		err := repo.gateway.GetResource(url, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range page.Resources {
+			packages = append(packages, resource.ToModel())
+		}
+
+		url = ""
+		if page.Pagination.Next != nil {
+			url = page.Pagination.Next.Href
+		}
+	}
+
+	return packages, nil
+}
+
+func (repo CloudControllerRepository) GetPackage(packageGUID string) (models.Package, error) {
+	resource := resources.PackageResource{}
+	err := repo.gateway.GetResource(fmt.Sprintf("%s/v3/packages/%s", repo.config.APIEndpoint(), packageGUID), &resource)
+	if err != nil {
+		return models.Package{}, err
+	}
+
+	return resource.ToModel(), nil
+}
+
+// CreatePackage creates an empty bits package for the app, for UploadBits to
+// upload the app files into.
+func (repo CloudControllerRepository) CreatePackage(appGUID string) (models.Package, error) {
+	pkg := resources.PackageCreateResource{Type: models.PackageTypeBits}
+	pkg.Relationships.App.Data.GUID = appGUID
+
+	body, err := json.Marshal(pkg)
+	if err != nil {
+		return models.Package{}, err
+	}
+
+	request, err := repo.gateway.NewRequest("POST", repo.config.APIEndpoint()+"/v3/packages", repo.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return models.Package{}, err
+	}
+
+	resource := resources.PackageResource{}
+	_, err = repo.gateway.PerformRequestForJSONResponse(request, &resource)
+	if err != nil {
+		return models.Package{}, err
+	}
+
+	return resource.ToModel(), nil
+}
+
+// UploadBits uploads the zipped app files into a package created with
+// CreatePackage. The Cloud Controller processes the upload in the background;
+// the package is READY once it is done.
+func (repo CloudControllerRepository) UploadBits(packageGUID string, zipFile *os.File) (apiErr error) {
+	fileutils.TempFile("requests", func(requestFile *os.File, err error) {
+		if err != nil {
+			apiErr = fmt.Errorf("%s: %s", T("Error creating tmp file"), err.Error())
+			return
+		}
+
+		writer := multipart.NewWriter(requestFile)
+		part, err := writer.CreateFormFile("bits", filepath.Base(zipFile.Name()))
+		if err == nil {
+			_, err = io.Copy(part, zipFile)
+		}
+		if err == nil {
+			err = writer.Close()
+		}
+		if err != nil {
+			apiErr = fmt.Errorf("%s: %s", T("Error writing to tmp file"), err.Error())
+			return
+		}
+
+		url := fmt.Sprintf("%s/v3/packages/%s/upload", repo.config.APIEndpoint(), packageGUID)
+		request, err := repo.gateway.NewRequestForFile("POST", url, repo.config.AccessToken(), requestFile)
+		if err != nil {
+			apiErr = err
+			return
+		}
+		request.HTTPReq.Header.Set("Content-Type", writer.FormDataContentType())
+
+		_, apiErr = repo.gateway.PerformRequest(request)
+	})
+
+	return
+}
@@ -0,0 +1,161 @@
+package packages_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+
+	"github.com/onsi/gomega/ghttp"
+
+	. "code.cloudfoundry.org/cli/cf/api/packages"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PackagesRepo", func() {
+	var (
+		testServer *ghttp.Server
+		configRepo coreconfig.ReadWriter
+		repo       Repository
+	)
+
+	BeforeEach(func() {
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		configRepo.SetAccessToken("BEARER my_access_token")
+
+		gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		repo = NewCloudControllerRepository(configRepo, gateway)
+	})
+
+	BeforeEach(func() {
+		testServer = ghttp.NewServer()
+		configRepo.SetAPIEndpoint(testServer.URL())
+	})
+
+	AfterEach(func() {
+		if testServer != nil {
+			testServer.Close()
+		}
+	})
+
+	Describe("ListPackages", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/packages", "order_by=-created_at"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": {
+							"next": { "href": "`+testServer.URL()+`/v3/apps/app-guid/packages?order_by=-created_at&page=2" }
+						},
+						"resources": [
+							{ "guid": "package-2-guid", "type": "bits", "state": "READY", "created_at": "2016-11-02T10:00:00Z" }
+						]
+					}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/packages", "order_by=-created_at&page=2"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": { "next": null },
+						"resources": [
+							{ "guid": "package-1-guid", "type": "bits", "state": "FAILED", "created_at": "2016-11-01T10:00:00Z" }
+						]
+					}`),
+				),
+			)
+		})
+
+		It("returns the packages of every page", func() {
+			packages, err := repo.ListPackages("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(packages).To(Equal([]models.Package{
+				{GUID: "package-2-guid", Type: "bits", State: "READY", CreatedAt: time.Date(2016, 11, 2, 10, 0, 0, 0, time.UTC)},
+				{GUID: "package-1-guid", Type: "bits", State: "FAILED", CreatedAt: time.Date(2016, 11, 1, 10, 0, 0, 0, time.UTC)},
+			}))
+		})
+	})
+
+	Describe("GetPackage", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/packages/package-1-guid"),
+					ghttp.RespondWith(http.StatusOK, `{ "guid": "package-1-guid", "type": "bits", "state": "PROCESSING_UPLOAD" }`),
+				),
+			)
+		})
+
+		It("returns the package", func() {
+			pkg, err := repo.GetPackage("package-1-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pkg.GUID).To(Equal("package-1-guid"))
+			Expect(pkg.State).To(Equal("PROCESSING_UPLOAD"))
+		})
+	})
+
+	Describe("CreatePackage", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/v3/packages"),
+					ghttp.VerifyJSON(`{ "type": "bits", "relationships": { "app": { "data": { "guid": "app-guid" } } } }`),
+					ghttp.RespondWith(http.StatusCreated, `{ "guid": "package-1-guid", "type": "bits", "state": "AWAITING_UPLOAD" }`),
+				),
+			)
+		})
+
+		It("creates a bits package for the app", func() {
+			pkg, err := repo.CreatePackage("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pkg.GUID).To(Equal("package-1-guid"))
+			Expect(pkg.State).To(Equal("AWAITING_UPLOAD"))
+		})
+	})
+
+	Describe("UploadBits", func() {
+		var zipFile *os.File
+
+		BeforeEach(func() {
+			var err error
+			zipFile, err = ioutil.TempFile("", "package")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = zipFile.WriteString("app-zip")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = zipFile.Seek(0, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/v3/packages/package-1-guid/upload"),
+					func(w http.ResponseWriter, r *http.Request) {
+						file, _, err := r.FormFile("bits")
+						Expect(err).NotTo(HaveOccurred())
+						contents, err := ioutil.ReadAll(file)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(contents)).To(Equal("app-zip"))
+					},
+					ghttp.RespondWith(http.StatusOK, `{ "guid": "package-1-guid", "state": "PROCESSING_UPLOAD" }`),
+				),
+			)
+		})
+
+		AfterEach(func() {
+			zipFile.Close()
+			os.Remove(zipFile.Name())
+		})
+
+		It("uploads the zip as the bits of a multipart form", func() {
+			err := repo.UploadBits("package-1-guid", zipFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+})
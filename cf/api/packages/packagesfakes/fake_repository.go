@@ -0,0 +1,215 @@
+// This file was generated by counterfeiter
+package packagesfakes
+
+import (
+	"os"
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/api/packages"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type FakeRepository struct {
+	ListPackagesStub        func(appGUID string) ([]models.Package, error)
+	listPackagesMutex       sync.RWMutex
+	listPackagesArgsForCall []struct {
+		appGUID string
+	}
+	listPackagesReturns struct {
+		result1 []models.Package
+		result2 error
+	}
+	GetPackageStub        func(packageGUID string) (models.Package, error)
+	getPackageMutex       sync.RWMutex
+	getPackageArgsForCall []struct {
+		packageGUID string
+	}
+	getPackageReturns struct {
+		result1 models.Package
+		result2 error
+	}
+	CreatePackageStub        func(appGUID string) (models.Package, error)
+	createPackageMutex       sync.RWMutex
+	createPackageArgsForCall []struct {
+		appGUID string
+	}
+	createPackageReturns struct {
+		result1 models.Package
+		result2 error
+	}
+	UploadBitsStub        func(packageGUID string, zipFile *os.File) error
+	uploadBitsMutex       sync.RWMutex
+	uploadBitsArgsForCall []struct {
+		packageGUID string
+		zipFile     *os.File
+	}
+	uploadBitsReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRepository) ListPackages(appGUID string) ([]models.Package, error) {
+	fake.listPackagesMutex.Lock()
+	fake.listPackagesArgsForCall = append(fake.listPackagesArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("ListPackages", []interface{}{appGUID})
+	fake.listPackagesMutex.Unlock()
+	if fake.ListPackagesStub != nil {
+		return fake.ListPackagesStub(appGUID)
+	} else {
+		return fake.listPackagesReturns.result1, fake.listPackagesReturns.result2
+	}
+}
+
+func (fake *FakeRepository) ListPackagesCallCount() int {
+	fake.listPackagesMutex.RLock()
+	defer fake.listPackagesMutex.RUnlock()
+	return len(fake.listPackagesArgsForCall)
+}
+
+func (fake *FakeRepository) ListPackagesArgsForCall(i int) string {
+	fake.listPackagesMutex.RLock()
+	defer fake.listPackagesMutex.RUnlock()
+	return fake.listPackagesArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) ListPackagesReturns(result1 []models.Package, result2 error) {
+	fake.ListPackagesStub = nil
+	fake.listPackagesReturns = struct {
+		result1 []models.Package
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetPackage(packageGUID string) (models.Package, error) {
+	fake.getPackageMutex.Lock()
+	fake.getPackageArgsForCall = append(fake.getPackageArgsForCall, struct {
+		packageGUID string
+	}{packageGUID})
+	fake.recordInvocation("GetPackage", []interface{}{packageGUID})
+	fake.getPackageMutex.Unlock()
+	if fake.GetPackageStub != nil {
+		return fake.GetPackageStub(packageGUID)
+	} else {
+		return fake.getPackageReturns.result1, fake.getPackageReturns.result2
+	}
+}
+
+func (fake *FakeRepository) GetPackageCallCount() int {
+	fake.getPackageMutex.RLock()
+	defer fake.getPackageMutex.RUnlock()
+	return len(fake.getPackageArgsForCall)
+}
+
+func (fake *FakeRepository) GetPackageArgsForCall(i int) string {
+	fake.getPackageMutex.RLock()
+	defer fake.getPackageMutex.RUnlock()
+	return fake.getPackageArgsForCall[i].packageGUID
+}
+
+func (fake *FakeRepository) GetPackageReturns(result1 models.Package, result2 error) {
+	fake.GetPackageStub = nil
+	fake.getPackageReturns = struct {
+		result1 models.Package
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) CreatePackage(appGUID string) (models.Package, error) {
+	fake.createPackageMutex.Lock()
+	fake.createPackageArgsForCall = append(fake.createPackageArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("CreatePackage", []interface{}{appGUID})
+	fake.createPackageMutex.Unlock()
+	if fake.CreatePackageStub != nil {
+		return fake.CreatePackageStub(appGUID)
+	} else {
+		return fake.createPackageReturns.result1, fake.createPackageReturns.result2
+	}
+}
+
+func (fake *FakeRepository) CreatePackageCallCount() int {
+	fake.createPackageMutex.RLock()
+	defer fake.createPackageMutex.RUnlock()
+	return len(fake.createPackageArgsForCall)
+}
+
+func (fake *FakeRepository) CreatePackageArgsForCall(i int) string {
+	fake.createPackageMutex.RLock()
+	defer fake.createPackageMutex.RUnlock()
+	return fake.createPackageArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) CreatePackageReturns(result1 models.Package, result2 error) {
+	fake.CreatePackageStub = nil
+	fake.createPackageReturns = struct {
+		result1 models.Package
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) UploadBits(packageGUID string, zipFile *os.File) error {
+	fake.uploadBitsMutex.Lock()
+	fake.uploadBitsArgsForCall = append(fake.uploadBitsArgsForCall, struct {
+		packageGUID string
+		zipFile     *os.File
+	}{packageGUID, zipFile})
+	fake.recordInvocation("UploadBits", []interface{}{packageGUID, zipFile})
+	fake.uploadBitsMutex.Unlock()
+	if fake.UploadBitsStub != nil {
+		return fake.UploadBitsStub(packageGUID, zipFile)
+	} else {
+		return fake.uploadBitsReturns.result1
+	}
+}
+
+func (fake *FakeRepository) UploadBitsCallCount() int {
+	fake.uploadBitsMutex.RLock()
+	defer fake.uploadBitsMutex.RUnlock()
+	return len(fake.uploadBitsArgsForCall)
+}
+
+func (fake *FakeRepository) UploadBitsArgsForCall(i int) (string, *os.File) {
+	fake.uploadBitsMutex.RLock()
+	defer fake.uploadBitsMutex.RUnlock()
+	return fake.uploadBitsArgsForCall[i].packageGUID, fake.uploadBitsArgsForCall[i].zipFile
+}
+
+func (fake *FakeRepository) UploadBitsReturns(result1 error) {
+	fake.UploadBitsStub = nil
+	fake.uploadBitsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listPackagesMutex.RLock()
+	defer fake.listPackagesMutex.RUnlock()
+	fake.getPackageMutex.RLock()
+	defer fake.getPackageMutex.RUnlock()
+	fake.createPackageMutex.RLock()
+	defer fake.createPackageMutex.RUnlock()
+	fake.uploadBitsMutex.RLock()
+	defer fake.uploadBitsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ packages.Repository = new(FakeRepository)
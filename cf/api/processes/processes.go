@@ -0,0 +1,128 @@
+package processes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+)
+
+//go:generate counterfeiter . Repository
+
+// Repository lists, scales and updates the process types of an app through
+// the v3 processes API.
+type Repository interface {
+	ListProcesses(appGUID string) ([]models.Process, error)
+	ScaleProcess(appGUID string, processType string, params models.ProcessScaleParams) (models.Process, error)
+	GetProcessInstances(processGUID string) ([]models.AppInstanceFields, error)
+	UpdateProcessHealthCheck(processGUID string, healthCheck models.ProcessHealthCheck) (models.Process, error)
+	UpdateProcessReadinessHealthCheck(processGUID string, healthCheck models.ProcessHealthCheck) (models.Process, error)
+}
+
+type CloudControllerRepository struct {
+	config  coreconfig.Reader
+	gateway net.Gateway
+}
+
+func NewCloudControllerRepository(config coreconfig.Reader, gateway net.Gateway) CloudControllerRepository {
+	return CloudControllerRepository{
+		config:  config,
+		gateway: gateway,
+	}
+}
+
+func (repo CloudControllerRepository) ListProcesses(appGUID string) ([]models.Process, error) {
+	processes := []models.Process{}
+
+	url := fmt.Sprintf("%s/v3/apps/%s/processes", repo.config.APIEndpoint(), appGUID)
+	for url != "" {
+		page := resources.PaginatedProcessResources{}
+		err := repo.gateway.GetResource(url, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range page.Resources {
+			processes = append(processes, resource.ToModel())
+		}
+
+		url = ""
+		if page.Pagination.Next != nil {
+			url = page.Pagination.Next.Href
+		}
+	}
+
+	return processes, nil
+}
+
+func (repo CloudControllerRepository) ScaleProcess(appGUID string, processType string, params models.ProcessScaleParams) (models.Process, error) {
+	body, err := json.Marshal(resources.NewProcessScaleRequest(params))
+	if err != nil {
+		return models.Process{}, err
+	}
+
+	scaleURL := fmt.Sprintf("%s/v3/apps/%s/processes/%s/actions/scale", repo.config.APIEndpoint(), appGUID, url.QueryEscape(processType))
+	request, err := repo.gateway.NewRequest("POST", scaleURL, repo.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return models.Process{}, err
+	}
+
+	resource := resources.ProcessResource{}
+	_, err = repo.gateway.PerformRequestForJSONResponse(request, &resource)
+	if err != nil {
+		return models.Process{}, err
+	}
+
+	return resource.ToModel(), nil
+}
+
+func (repo CloudControllerRepository) UpdateProcessHealthCheck(processGUID string, healthCheck models.ProcessHealthCheck) (models.Process, error) {
+	return repo.updateProcess(processGUID, resources.NewProcessUpdateRequest(healthCheck))
+}
+
+func (repo CloudControllerRepository) UpdateProcessReadinessHealthCheck(processGUID string, healthCheck models.ProcessHealthCheck) (models.Process, error) {
+	return repo.updateProcess(processGUID, resources.NewProcessReadinessUpdateRequest(healthCheck))
+}
+
+func (repo CloudControllerRepository) updateProcess(processGUID string, update resources.ProcessUpdateRequest) (models.Process, error) {
+	body, err := json.Marshal(update)
+	if err != nil {
+		return models.Process{}, err
+	}
+
+	processURL := fmt.Sprintf("%s/v3/processes/%s", repo.config.APIEndpoint(), processGUID)
+	request, err := repo.gateway.NewRequest("PATCH", processURL, repo.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return models.Process{}, err
+	}
+
+	resource := resources.ProcessResource{}
+	_, err = repo.gateway.PerformRequestForJSONResponse(request, &resource)
+	if err != nil {
+		return models.Process{}, err
+	}
+
+	return resource.ToModel(), nil
+}
+
+func (repo CloudControllerRepository) GetProcessInstances(processGUID string) ([]models.AppInstanceFields, error) {
+	stats := resources.ProcessStatsResources{}
+	err := repo.gateway.GetResource(fmt.Sprintf("%s/v3/processes/%s/stats", repo.config.APIEndpoint(), processGUID), &stats)
+	if err != nil {
+		return nil, err
+	}
+
+	instances := make([]models.AppInstanceFields, len(stats.Resources))
+	for _, resource := range stats.Resources {
+		if resource.Index >= 0 && resource.Index < len(instances) {
+			instances[resource.Index] = resource.ToModel()
+		}
+	}
+
+	return instances, nil
+}
@@ -0,0 +1,234 @@
+package processes_test
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+
+	"github.com/onsi/gomega/ghttp"
+
+	. "code.cloudfoundry.org/cli/cf/api/processes"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProcessesRepo", func() {
+	var (
+		testServer *ghttp.Server
+		configRepo coreconfig.ReadWriter
+		repo       Repository
+	)
+
+	BeforeEach(func() {
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		configRepo.SetAccessToken("BEARER my_access_token")
+
+		gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		repo = NewCloudControllerRepository(configRepo, gateway)
+	})
+
+	BeforeEach(func() {
+		testServer = ghttp.NewServer()
+		configRepo.SetAPIEndpoint(testServer.URL())
+	})
+
+	AfterEach(func() {
+		if testServer != nil {
+			testServer.Close()
+		}
+	})
+
+	Describe("ListProcesses", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/processes"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": {
+							"next": { "href": "`+testServer.URL()+`/v3/apps/app-guid/processes?page=2" }
+						},
+						"resources": [
+							{ "guid": "web-guid", "type": "web", "instances": 2, "memory_in_mb": 512, "disk_in_mb": 1024 }
+						]
+					}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/processes", "page=2"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": { "next": null },
+						"resources": [
+							{ "guid": "worker-guid", "type": "worker", "instances": 1, "memory_in_mb": 256, "disk_in_mb": 512 }
+						]
+					}`),
+				),
+			)
+		})
+
+		It("returns the processes from every page", func() {
+			processes, err := repo.ListProcesses("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processes).To(Equal([]models.Process{
+				{GUID: "web-guid", Type: "web", Instances: 2, MemoryInMB: 512, DiskInMB: 1024},
+				{GUID: "worker-guid", Type: "worker", Instances: 1, MemoryInMB: 256, DiskInMB: 512},
+			}))
+		})
+	})
+
+	Describe("ScaleProcess", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/v3/apps/app-guid/processes/worker/actions/scale"),
+					ghttp.VerifyJSON(`{ "instances": 3, "memory_in_mb": 256 }`),
+					ghttp.RespondWith(http.StatusAccepted, `{ "guid": "worker-guid", "type": "worker", "instances": 3, "memory_in_mb": 256, "disk_in_mb": 512 }`),
+				),
+			)
+		})
+
+		It("sends only the given limits and returns the scaled process", func() {
+			instances := 3
+			memory := int64(256)
+			process, err := repo.ScaleProcess("app-guid", "worker", models.ProcessScaleParams{
+				Instances:  &instances,
+				MemoryInMB: &memory,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(process).To(Equal(models.Process{GUID: "worker-guid", Type: "worker", Instances: 3, MemoryInMB: 256, DiskInMB: 512}))
+		})
+	})
+
+	Describe("UpdateProcessHealthCheck", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/v3/processes/web-guid"),
+					ghttp.VerifyJSON(`{ "health_check": { "type": "http", "data": { "endpoint": "/health", "invocation_timeout": 5 } } }`),
+					ghttp.RespondWith(http.StatusOK, `{
+						"guid": "web-guid",
+						"type": "web",
+						"instances": 2,
+						"health_check": { "type": "http", "data": { "endpoint": "/health", "invocation_timeout": 5, "timeout": null } }
+					}`),
+				),
+			)
+		})
+
+		It("updates the health check and returns the updated process", func() {
+			healthCheckType := "http"
+			endpoint := "/health"
+			invocationTimeout := 5
+			process, err := repo.UpdateProcessHealthCheck("web-guid", models.ProcessHealthCheck{
+				Type:              &healthCheckType,
+				HTTPEndpoint:      &endpoint,
+				InvocationTimeout: &invocationTimeout,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(process).To(Equal(models.Process{
+				GUID:                         "web-guid",
+				Type:                         "web",
+				Instances:                    2,
+				HealthCheckType:              "http",
+				HealthCheckHTTPEndpoint:      "/health",
+				HealthCheckInvocationTimeout: 5,
+			}))
+		})
+	})
+
+	Describe("UpdateProcessReadinessHealthCheck", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/v3/processes/web-guid"),
+					ghttp.VerifyJSON(`{ "readiness_health_check": { "type": "http", "data": { "endpoint": "/ready", "interval": 10 } } }`),
+					ghttp.RespondWith(http.StatusOK, `{
+						"guid": "web-guid",
+						"type": "web",
+						"health_check": { "type": "port", "data": {} },
+						"readiness_health_check": { "type": "http", "data": { "endpoint": "/ready", "interval": 10, "invocation_timeout": null } }
+					}`),
+				),
+			)
+		})
+
+		It("updates only the readiness check and returns the updated process", func() {
+			healthCheckType := "http"
+			endpoint := "/ready"
+			interval := 10
+			process, err := repo.UpdateProcessReadinessHealthCheck("web-guid", models.ProcessHealthCheck{
+				Type:         &healthCheckType,
+				HTTPEndpoint: &endpoint,
+				Interval:     &interval,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(process).To(Equal(models.Process{
+				GUID:                             "web-guid",
+				Type:                             "web",
+				HealthCheckType:                  "port",
+				ReadinessHealthCheckType:         "http",
+				ReadinessHealthCheckHTTPEndpoint: "/ready",
+				ReadinessHealthCheckInterval:     10,
+			}))
+		})
+	})
+
+	Describe("GetProcessInstances", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/processes/worker-guid/stats"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"resources": [
+							{
+								"type": "worker",
+								"index": 1,
+								"state": "CRASHED",
+								"usage": {},
+								"details": "exited"
+							},
+							{
+								"type": "worker",
+								"index": 0,
+								"state": "RUNNING",
+								"usage": { "time": "2016-11-02T10:00:00Z", "cpu": 0.25, "mem": 1024, "disk": 2048 },
+								"uptime": 60,
+								"mem_quota": 4096,
+								"disk_quota": 8192,
+								"routable": true
+							}
+						]
+					}`),
+				),
+			)
+		})
+
+		It("returns the stats of the instances in index order", func() {
+			instances, err := repo.GetProcessInstances("worker-guid")
+			Expect(err).NotTo(HaveOccurred())
+
+			routable := true
+			Expect(instances).To(Equal([]models.AppInstanceFields{
+				{
+					State:     models.InstanceRunning,
+					Since:     time.Date(2016, 11, 2, 9, 59, 0, 0, time.UTC),
+					CPUUsage:  0.25,
+					MemUsage:  1024,
+					MemQuota:  4096,
+					DiskUsage: 2048,
+					DiskQuota: 8192,
+					Routable:  &routable,
+				},
+				{
+					State:   models.InstanceCrashed,
+					Details: "exited",
+				},
+			}))
+		})
+	})
+})
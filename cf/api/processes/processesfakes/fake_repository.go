@@ -0,0 +1,267 @@
+// This file was generated by counterfeiter
+package processesfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/api/processes"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type FakeRepository struct {
+	ListProcessesStub        func(appGUID string) ([]models.Process, error)
+	listProcessesMutex       sync.RWMutex
+	listProcessesArgsForCall []struct {
+		appGUID string
+	}
+	listProcessesReturns struct {
+		result1 []models.Process
+		result2 error
+	}
+	ScaleProcessStub        func(appGUID string, processType string, params models.ProcessScaleParams) (models.Process, error)
+	scaleProcessMutex       sync.RWMutex
+	scaleProcessArgsForCall []struct {
+		appGUID     string
+		processType string
+		params      models.ProcessScaleParams
+	}
+	scaleProcessReturns struct {
+		result1 models.Process
+		result2 error
+	}
+	GetProcessInstancesStub        func(processGUID string) ([]models.AppInstanceFields, error)
+	getProcessInstancesMutex       sync.RWMutex
+	getProcessInstancesArgsForCall []struct {
+		processGUID string
+	}
+	getProcessInstancesReturns struct {
+		result1 []models.AppInstanceFields
+		result2 error
+	}
+	UpdateProcessHealthCheckStub        func(processGUID string, healthCheck models.ProcessHealthCheck) (models.Process, error)
+	updateProcessHealthCheckMutex       sync.RWMutex
+	updateProcessHealthCheckArgsForCall []struct {
+		processGUID string
+		healthCheck models.ProcessHealthCheck
+	}
+	updateProcessHealthCheckReturns struct {
+		result1 models.Process
+		result2 error
+	}
+	UpdateProcessReadinessHealthCheckStub        func(processGUID string, healthCheck models.ProcessHealthCheck) (models.Process, error)
+	updateProcessReadinessHealthCheckMutex       sync.RWMutex
+	updateProcessReadinessHealthCheckArgsForCall []struct {
+		processGUID string
+		healthCheck models.ProcessHealthCheck
+	}
+	updateProcessReadinessHealthCheckReturns struct {
+		result1 models.Process
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRepository) ListProcesses(appGUID string) ([]models.Process, error) {
+	fake.listProcessesMutex.Lock()
+	fake.listProcessesArgsForCall = append(fake.listProcessesArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("ListProcesses", []interface{}{appGUID})
+	fake.listProcessesMutex.Unlock()
+	if fake.ListProcessesStub != nil {
+		return fake.ListProcessesStub(appGUID)
+	} else {
+		return fake.listProcessesReturns.result1, fake.listProcessesReturns.result2
+	}
+}
+
+func (fake *FakeRepository) ListProcessesCallCount() int {
+	fake.listProcessesMutex.RLock()
+	defer fake.listProcessesMutex.RUnlock()
+	return len(fake.listProcessesArgsForCall)
+}
+
+func (fake *FakeRepository) ListProcessesArgsForCall(i int) string {
+	fake.listProcessesMutex.RLock()
+	defer fake.listProcessesMutex.RUnlock()
+	return fake.listProcessesArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) ListProcessesReturns(result1 []models.Process, result2 error) {
+	fake.ListProcessesStub = nil
+	fake.listProcessesReturns = struct {
+		result1 []models.Process
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) ScaleProcess(appGUID string, processType string, params models.ProcessScaleParams) (models.Process, error) {
+	fake.scaleProcessMutex.Lock()
+	fake.scaleProcessArgsForCall = append(fake.scaleProcessArgsForCall, struct {
+		appGUID     string
+		processType string
+		params      models.ProcessScaleParams
+	}{appGUID, processType, params})
+	fake.recordInvocation("ScaleProcess", []interface{}{appGUID, processType, params})
+	fake.scaleProcessMutex.Unlock()
+	if fake.ScaleProcessStub != nil {
+		return fake.ScaleProcessStub(appGUID, processType, params)
+	} else {
+		return fake.scaleProcessReturns.result1, fake.scaleProcessReturns.result2
+	}
+}
+
+func (fake *FakeRepository) ScaleProcessCallCount() int {
+	fake.scaleProcessMutex.RLock()
+	defer fake.scaleProcessMutex.RUnlock()
+	return len(fake.scaleProcessArgsForCall)
+}
+
+func (fake *FakeRepository) ScaleProcessArgsForCall(i int) (string, string, models.ProcessScaleParams) {
+	fake.scaleProcessMutex.RLock()
+	defer fake.scaleProcessMutex.RUnlock()
+	return fake.scaleProcessArgsForCall[i].appGUID, fake.scaleProcessArgsForCall[i].processType, fake.scaleProcessArgsForCall[i].params
+}
+
+func (fake *FakeRepository) ScaleProcessReturns(result1 models.Process, result2 error) {
+	fake.ScaleProcessStub = nil
+	fake.scaleProcessReturns = struct {
+		result1 models.Process
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) GetProcessInstances(processGUID string) ([]models.AppInstanceFields, error) {
+	fake.getProcessInstancesMutex.Lock()
+	fake.getProcessInstancesArgsForCall = append(fake.getProcessInstancesArgsForCall, struct {
+		processGUID string
+	}{processGUID})
+	fake.recordInvocation("GetProcessInstances", []interface{}{processGUID})
+	fake.getProcessInstancesMutex.Unlock()
+	if fake.GetProcessInstancesStub != nil {
+		return fake.GetProcessInstancesStub(processGUID)
+	} else {
+		return fake.getProcessInstancesReturns.result1, fake.getProcessInstancesReturns.result2
+	}
+}
+
+func (fake *FakeRepository) GetProcessInstancesCallCount() int {
+	fake.getProcessInstancesMutex.RLock()
+	defer fake.getProcessInstancesMutex.RUnlock()
+	return len(fake.getProcessInstancesArgsForCall)
+}
+
+func (fake *FakeRepository) GetProcessInstancesArgsForCall(i int) string {
+	fake.getProcessInstancesMutex.RLock()
+	defer fake.getProcessInstancesMutex.RUnlock()
+	return fake.getProcessInstancesArgsForCall[i].processGUID
+}
+
+func (fake *FakeRepository) GetProcessInstancesReturns(result1 []models.AppInstanceFields, result2 error) {
+	fake.GetProcessInstancesStub = nil
+	fake.getProcessInstancesReturns = struct {
+		result1 []models.AppInstanceFields
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) UpdateProcessHealthCheck(processGUID string, healthCheck models.ProcessHealthCheck) (models.Process, error) {
+	fake.updateProcessHealthCheckMutex.Lock()
+	fake.updateProcessHealthCheckArgsForCall = append(fake.updateProcessHealthCheckArgsForCall, struct {
+		processGUID string
+		healthCheck models.ProcessHealthCheck
+	}{processGUID, healthCheck})
+	fake.recordInvocation("UpdateProcessHealthCheck", []interface{}{processGUID, healthCheck})
+	fake.updateProcessHealthCheckMutex.Unlock()
+	if fake.UpdateProcessHealthCheckStub != nil {
+		return fake.UpdateProcessHealthCheckStub(processGUID, healthCheck)
+	} else {
+		return fake.updateProcessHealthCheckReturns.result1, fake.updateProcessHealthCheckReturns.result2
+	}
+}
+
+func (fake *FakeRepository) UpdateProcessHealthCheckCallCount() int {
+	fake.updateProcessHealthCheckMutex.RLock()
+	defer fake.updateProcessHealthCheckMutex.RUnlock()
+	return len(fake.updateProcessHealthCheckArgsForCall)
+}
+
+func (fake *FakeRepository) UpdateProcessHealthCheckArgsForCall(i int) (string, models.ProcessHealthCheck) {
+	fake.updateProcessHealthCheckMutex.RLock()
+	defer fake.updateProcessHealthCheckMutex.RUnlock()
+	return fake.updateProcessHealthCheckArgsForCall[i].processGUID, fake.updateProcessHealthCheckArgsForCall[i].healthCheck
+}
+
+func (fake *FakeRepository) UpdateProcessHealthCheckReturns(result1 models.Process, result2 error) {
+	fake.UpdateProcessHealthCheckStub = nil
+	fake.updateProcessHealthCheckReturns = struct {
+		result1 models.Process
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) UpdateProcessReadinessHealthCheck(processGUID string, healthCheck models.ProcessHealthCheck) (models.Process, error) {
+	fake.updateProcessReadinessHealthCheckMutex.Lock()
+	fake.updateProcessReadinessHealthCheckArgsForCall = append(fake.updateProcessReadinessHealthCheckArgsForCall, struct {
+		processGUID string
+		healthCheck models.ProcessHealthCheck
+	}{processGUID, healthCheck})
+	fake.recordInvocation("UpdateProcessReadinessHealthCheck", []interface{}{processGUID, healthCheck})
+	fake.updateProcessReadinessHealthCheckMutex.Unlock()
+	if fake.UpdateProcessReadinessHealthCheckStub != nil {
+		return fake.UpdateProcessReadinessHealthCheckStub(processGUID, healthCheck)
+	} else {
+		return fake.updateProcessReadinessHealthCheckReturns.result1, fake.updateProcessReadinessHealthCheckReturns.result2
+	}
+}
+
+func (fake *FakeRepository) UpdateProcessReadinessHealthCheckCallCount() int {
+	fake.updateProcessReadinessHealthCheckMutex.RLock()
+	defer fake.updateProcessReadinessHealthCheckMutex.RUnlock()
+	return len(fake.updateProcessReadinessHealthCheckArgsForCall)
+}
+
+func (fake *FakeRepository) UpdateProcessReadinessHealthCheckArgsForCall(i int) (string, models.ProcessHealthCheck) {
+	fake.updateProcessReadinessHealthCheckMutex.RLock()
+	defer fake.updateProcessReadinessHealthCheckMutex.RUnlock()
+	return fake.updateProcessReadinessHealthCheckArgsForCall[i].processGUID, fake.updateProcessReadinessHealthCheckArgsForCall[i].healthCheck
+}
+
+func (fake *FakeRepository) UpdateProcessReadinessHealthCheckReturns(result1 models.Process, result2 error) {
+	fake.UpdateProcessReadinessHealthCheckStub = nil
+	fake.updateProcessReadinessHealthCheckReturns = struct {
+		result1 models.Process
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listProcessesMutex.RLock()
+	defer fake.listProcessesMutex.RUnlock()
+	fake.scaleProcessMutex.RLock()
+	defer fake.scaleProcessMutex.RUnlock()
+	fake.getProcessInstancesMutex.RLock()
+	defer fake.getProcessInstancesMutex.RUnlock()
+	fake.updateProcessHealthCheckMutex.RLock()
+	defer fake.updateProcessHealthCheckMutex.RUnlock()
+	fake.updateProcessReadinessHealthCheckMutex.RLock()
+	defer fake.updateProcessReadinessHealthCheckMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ processes.Repository = new(FakeRepository)
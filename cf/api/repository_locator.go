@@ -6,26 +6,38 @@ import (
 
 	"code.cloudfoundry.org/cli/cf"
 	"code.cloudfoundry.org/cli/cf/api/appevents"
+	"code.cloudfoundry.org/cli/cf/api/appfeatures"
 	api_appfiles "code.cloudfoundry.org/cli/cf/api/appfiles"
 	"code.cloudfoundry.org/cli/cf/api/appinstances"
 	"code.cloudfoundry.org/cli/cf/api/applicationbits"
 	"code.cloudfoundry.org/cli/cf/api/applications"
 	"code.cloudfoundry.org/cli/cf/api/authentication"
+	"code.cloudfoundry.org/cli/cf/api/builds"
 	"code.cloudfoundry.org/cli/cf/api/copyapplicationsource"
+	"code.cloudfoundry.org/cli/cf/api/deployments"
+	"code.cloudfoundry.org/cli/cf/api/droplets"
 	"code.cloudfoundry.org/cli/cf/api/environmentvariablegroups"
 	"code.cloudfoundry.org/cli/cf/api/featureflags"
 	"code.cloudfoundry.org/cli/cf/api/logs"
+	"code.cloudfoundry.org/cli/cf/api/metadata"
+	"code.cloudfoundry.org/cli/cf/api/metrics"
+	"code.cloudfoundry.org/cli/cf/api/networkpolicies"
 	"code.cloudfoundry.org/cli/cf/api/organizations"
+	"code.cloudfoundry.org/cli/cf/api/packages"
 	"code.cloudfoundry.org/cli/cf/api/password"
+	"code.cloudfoundry.org/cli/cf/api/processes"
 	"code.cloudfoundry.org/cli/cf/api/quotas"
+	"code.cloudfoundry.org/cli/cf/api/revisions"
 	"code.cloudfoundry.org/cli/cf/api/securitygroups"
 	"code.cloudfoundry.org/cli/cf/api/securitygroups/defaults/running"
 	"code.cloudfoundry.org/cli/cf/api/securitygroups/defaults/staging"
 	securitygroupspaces "code.cloudfoundry.org/cli/cf/api/securitygroups/spaces"
+	"code.cloudfoundry.org/cli/cf/api/sidecars"
 	"code.cloudfoundry.org/cli/cf/api/spacequotas"
 	"code.cloudfoundry.org/cli/cf/api/spaces"
 	"code.cloudfoundry.org/cli/cf/api/stacks"
 	"code.cloudfoundry.org/cli/cf/api/strategy"
+	"code.cloudfoundry.org/cli/cf/api/tasks"
 	"code.cloudfoundry.org/cli/cf/appfiles"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/net"
@@ -46,11 +58,23 @@ type RepositoryLocator struct {
 	quotaRepo                       quotas.QuotaRepository
 	spaceRepo                       spaces.SpaceRepository
 	appRepo                         applications.Repository
-	appBitsRepo                     applicationbits.CloudControllerApplicationBitsRepository
+	appBitsRepo                     applicationbits.Repository
 	appSummaryRepo                  AppSummaryRepository
 	appInstancesRepo                appinstances.Repository
 	appEventsRepo                   appevents.Repository
+	appFeatureRepo                  appfeatures.Repository
 	appFilesRepo                    api_appfiles.Repository
+	dropletRepo                     droplets.Repository
+	deploymentRepo                  deployments.Repository
+	revisionRepo                    revisions.Repository
+	buildRepo                       builds.Repository
+	packageRepo                     packages.Repository
+	taskRepo                        tasks.Repository
+	processRepo                     processes.Repository
+	sidecarRepo                     sidecars.Repository
+	metadataRepo                    metadata.Repository
+	metricsRepo                     metrics.Repository
+	networkPolicyRepo               networkpolicies.Repository
 	domainRepo                      DomainRepository
 	routeRepo                       RouteRepository
 	routingAPIRepo                  RoutingAPIRepository
@@ -65,6 +89,7 @@ type RepositoryLocator struct {
 	logsRepo                        logs.Repository
 	authTokenRepo                   ServiceAuthTokenRepository
 	serviceBrokerRepo               ServiceBrokerRepository
+	brokerCatalogRepo               BrokerCatalogRepository
 	servicePlanRepo                 CloudControllerServicePlanRepository
 	servicePlanVisibilityRepo       ServicePlanVisibilityRepository
 	userProvidedServiceInstanceRepo UserProvidedServiceInstanceRepository
@@ -87,15 +112,18 @@ func NewRepositoryLocator(config coreconfig.ReadWriter, gatewaysByName map[strin
 
 	cloudControllerGateway := gatewaysByName["cloud-controller"]
 	routingAPIGateway := gatewaysByName["routing-api"]
+	networkingGateway := gatewaysByName["networking"]
 	uaaGateway := gatewaysByName["uaa"]
 	loc.authRepo = authentication.NewUAARepository(uaaGateway, config, net.NewRequestDumper(logger))
 
 	// ensure gateway refreshers are set before passing them by value to repositories
 	cloudControllerGateway.SetTokenRefresher(loc.authRepo)
 	uaaGateway.SetTokenRefresher(loc.authRepo)
+	networkingGateway.SetTokenRefresher(loc.authRepo)
 
 	loc.appBitsRepo = applicationbits.NewCloudControllerApplicationBitsRepository(config, cloudControllerGateway)
 	loc.appEventsRepo = appevents.NewCloudControllerAppEventsRepository(config, cloudControllerGateway, strategy)
+	loc.appFeatureRepo = appfeatures.NewCloudControllerRepository(config, cloudControllerGateway)
 	loc.appFilesRepo = api_appfiles.NewCloudControllerAppFilesRepository(config, cloudControllerGateway)
 	loc.appRepo = applications.NewCloudControllerRepository(config, cloudControllerGateway)
 	loc.appSummaryRepo = NewCloudControllerAppSummaryRepository(config, cloudControllerGateway)
@@ -126,10 +154,22 @@ func NewRepositoryLocator(config coreconfig.ReadWriter, gatewaysByName map[strin
 	loc.routeServiceBindingRepo = NewCloudControllerRouteServiceBindingRepository(config, cloudControllerGateway)
 	loc.routingAPIRepo = NewRoutingAPIRepository(config, routingAPIGateway)
 	loc.stackRepo = stacks.NewCloudControllerStackRepository(config, cloudControllerGateway)
+	loc.dropletRepo = droplets.NewCloudControllerRepository(config, cloudControllerGateway)
+	loc.deploymentRepo = deployments.NewCloudControllerRepository(config, cloudControllerGateway)
+	loc.revisionRepo = revisions.NewCloudControllerRepository(config, cloudControllerGateway)
+	loc.buildRepo = builds.NewCloudControllerRepository(config, cloudControllerGateway)
+	loc.packageRepo = packages.NewCloudControllerRepository(config, cloudControllerGateway)
+	loc.taskRepo = tasks.NewCloudControllerRepository(config, cloudControllerGateway)
+	loc.processRepo = processes.NewCloudControllerRepository(config, cloudControllerGateway)
+	loc.sidecarRepo = sidecars.NewCloudControllerRepository(config, cloudControllerGateway)
+	loc.metadataRepo = metadata.NewCloudControllerRepository(config, cloudControllerGateway)
+	loc.metricsRepo = metrics.NewCloudControllerRepository(config, cloudControllerGateway)
+	loc.networkPolicyRepo = networkpolicies.NewNetworkingRepository(config, networkingGateway)
 	loc.serviceRepo = NewCloudControllerServiceRepository(config, cloudControllerGateway)
 	loc.serviceKeyRepo = NewCloudControllerServiceKeyRepository(config, cloudControllerGateway)
 	loc.serviceBindingRepo = NewCloudControllerServiceBindingRepository(config, cloudControllerGateway)
 	loc.serviceBrokerRepo = NewCloudControllerServiceBrokerRepository(config, cloudControllerGateway)
+	loc.brokerCatalogRepo = NewHTTPBrokerCatalogRepository(config)
 	loc.servicePlanRepo = NewCloudControllerServicePlanRepository(config, cloudControllerGateway)
 	loc.servicePlanVisibilityRepo = NewCloudControllerServicePlanVisibilityRepository(config, cloudControllerGateway)
 	loc.serviceSummaryRepo = NewCloudControllerServiceSummaryRepository(config, cloudControllerGateway)
@@ -216,6 +256,11 @@ func (locator RepositoryLocator) GetApplicationRepository() applications.Reposit
 	return locator.appRepo
 }
 
+func (locator RepositoryLocator) SetApplicationBitsRepository(repo applicationbits.Repository) RepositoryLocator {
+	locator.appBitsRepo = repo
+	return locator
+}
+
 func (locator RepositoryLocator) GetApplicationBitsRepository() applicationbits.Repository {
 	return locator.appBitsRepo
 }
@@ -252,6 +297,15 @@ func (locator RepositoryLocator) GetAppEventsRepository() appevents.Repository {
 	return locator.appEventsRepo
 }
 
+func (locator RepositoryLocator) SetAppFeatureRepository(repo appfeatures.Repository) RepositoryLocator {
+	locator.appFeatureRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetAppFeatureRepository() appfeatures.Repository {
+	return locator.appFeatureRepo
+}
+
 func (locator RepositoryLocator) SetAppFileRepository(repo api_appfiles.Repository) RepositoryLocator {
 	locator.appFilesRepo = repo
 	return locator
@@ -297,6 +351,105 @@ func (locator RepositoryLocator) GetStackRepository() stacks.StackRepository {
 	return locator.stackRepo
 }
 
+func (locator RepositoryLocator) SetDropletRepository(repo droplets.Repository) RepositoryLocator {
+	locator.dropletRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetDropletRepository() droplets.Repository {
+	return locator.dropletRepo
+}
+
+func (locator RepositoryLocator) SetDeploymentRepository(repo deployments.Repository) RepositoryLocator {
+	locator.deploymentRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetDeploymentRepository() deployments.Repository {
+	return locator.deploymentRepo
+}
+
+func (locator RepositoryLocator) SetRevisionRepository(repo revisions.Repository) RepositoryLocator {
+	locator.revisionRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetRevisionRepository() revisions.Repository {
+	return locator.revisionRepo
+}
+
+func (locator RepositoryLocator) SetBuildRepository(repo builds.Repository) RepositoryLocator {
+	locator.buildRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetBuildRepository() builds.Repository {
+	return locator.buildRepo
+}
+
+func (locator RepositoryLocator) SetPackageRepository(repo packages.Repository) RepositoryLocator {
+	locator.packageRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetPackageRepository() packages.Repository {
+	return locator.packageRepo
+}
+
+func (locator RepositoryLocator) SetTaskRepository(repo tasks.Repository) RepositoryLocator {
+	locator.taskRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetTaskRepository() tasks.Repository {
+	return locator.taskRepo
+}
+
+func (locator RepositoryLocator) SetProcessRepository(repo processes.Repository) RepositoryLocator {
+	locator.processRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetProcessRepository() processes.Repository {
+	return locator.processRepo
+}
+
+func (locator RepositoryLocator) SetSidecarRepository(repo sidecars.Repository) RepositoryLocator {
+	locator.sidecarRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetSidecarRepository() sidecars.Repository {
+	return locator.sidecarRepo
+}
+
+func (locator RepositoryLocator) SetMetadataRepository(repo metadata.Repository) RepositoryLocator {
+	locator.metadataRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetMetadataRepository() metadata.Repository {
+	return locator.metadataRepo
+}
+
+func (locator RepositoryLocator) SetMetricsRepository(repo metrics.Repository) RepositoryLocator {
+	locator.metricsRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetMetricsRepository() metrics.Repository {
+	return locator.metricsRepo
+}
+
+func (locator RepositoryLocator) SetNetworkPolicyRepository(repo networkpolicies.Repository) RepositoryLocator {
+	locator.networkPolicyRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetNetworkPolicyRepository() networkpolicies.Repository {
+	return locator.networkPolicyRepo
+}
+
 func (locator RepositoryLocator) SetServiceRepository(repo ServiceRepository) RepositoryLocator {
 	locator.serviceRepo = repo
 	return locator
@@ -381,6 +534,15 @@ func (locator RepositoryLocator) GetServiceBrokerRepository() ServiceBrokerRepos
 	return locator.serviceBrokerRepo
 }
 
+func (locator RepositoryLocator) SetBrokerCatalogRepository(repo BrokerCatalogRepository) RepositoryLocator {
+	locator.brokerCatalogRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetBrokerCatalogRepository() BrokerCatalogRepository {
+	return locator.brokerCatalogRepo
+}
+
 func (locator RepositoryLocator) GetServicePlanRepository() ServicePlanRepository {
 	return locator.servicePlanRepo
 }
@@ -0,0 +1,30 @@
+package resources
+
+import "code.cloudfoundry.org/cli/cf/models"
+
+type AppFeatureResource struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+type AppFeaturesResource struct {
+	Resources []AppFeatureResource `json:"resources"`
+}
+
+type AppFeatureUpdateResource struct {
+	Enabled bool `json:"enabled"`
+}
+
+type SSHEnabledResource struct {
+	Enabled bool   `json:"enabled"`
+	Reason  string `json:"reason"`
+}
+
+func (resource AppFeatureResource) ToModel() models.AppFeature {
+	return models.AppFeature{
+		Name:        resource.Name,
+		Description: resource.Description,
+		Enabled:     resource.Enabled,
+	}
+}
@@ -38,6 +38,23 @@ type AppFileResource struct {
 	Mode string `json:"mode"`
 }
 
+type AppBitsChunkResource struct {
+	Index  int    `json:"index"`
+	Offset int64  `json:"offset"`
+	Size   int64  `json:"size"`
+	Sha1   string `json:"sha1"`
+}
+
+type AppBitsChunkedUploadResource struct {
+	Resources []AppFileResource      `json:"resources"`
+	Chunks    []AppBitsChunkResource `json:"chunks"`
+}
+
+type DockerCredentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
 type ApplicationResource struct {
 	Resource
 	Entity ApplicationEntity
@@ -64,6 +81,7 @@ type ApplicationEntity struct {
 	StagingFailedReason  *string                 `json:"staging_failed_reason,omitempty"`
 	Diego                *bool                   `json:"diego,omitempty"`
 	DockerImage          *string                 `json:"docker_image,omitempty"`
+	DockerCredentials    *DockerCredentials      `json:"docker_credentials,omitempty"`
 	EnableSSH            *bool                   `json:"enable_ssh,omitempty"`
 	PackageUpdatedAt     *time.Time              `json:"package_updated_at,omitempty"`
 	AppPorts             *[]int                  `json:"ports,omitempty"`
@@ -109,6 +127,15 @@ func NewApplicationEntityFromAppParams(app models.AppParams) ApplicationEntity {
 		AppPorts:           app.AppPorts,
 	}
 
+	if app.DockerUsername != nil {
+		entity.DockerCredentials = &DockerCredentials{
+			Username: *app.DockerUsername,
+		}
+		if app.DockerPassword != nil {
+			entity.DockerCredentials.Password = *app.DockerPassword
+		}
+	}
+
 	if app.State != nil {
 		state := strings.ToUpper(*app.State)
 		entity.State = &state
@@ -161,6 +188,9 @@ func (resource ApplicationResource) ToFields() (app models.ApplicationFields) {
 	if entity.DockerImage != nil {
 		app.DockerImage = *entity.DockerImage
 	}
+	if entity.DockerCredentials != nil {
+		app.DockerUsername = entity.DockerCredentials.Username
+	}
 	if entity.Buildpack != nil {
 		app.Buildpack = *entity.Buildpack
 	}
@@ -199,3 +229,69 @@ func (resource ApplicationResource) ToModel() (app models.Application) {
 
 	return
 }
+
+// AppLifecycleResource is the part of a v3 app that says how it is staged.
+type AppLifecycleResource struct {
+	Lifecycle struct {
+		Type string `json:"type"`
+		Data struct {
+			Buildpacks  []string                               `json:"buildpacks"`
+			Credentials map[string]RegistryCredentialsResource `json:"credentials,omitempty"`
+		} `json:"data"`
+	} `json:"lifecycle"`
+}
+
+type RegistryCredentialsResource struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// V3AppCreateResource is the body of a request to create a v3 app, which,
+// unlike a v2 app, can be created with any lifecycle.
+type V3AppCreateResource struct {
+	Name          string `json:"name"`
+	Relationships struct {
+		Space struct {
+			Data struct {
+				GUID string `json:"guid"`
+			} `json:"data"`
+		} `json:"space"`
+	} `json:"relationships"`
+	AppLifecycleResource
+}
+
+type V3AppResource struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+	AppLifecycleResource
+}
+
+// ToLifecycleModel returns the lifecycle type and buildpacks of the app. The
+// Cloud Controller never returns registry credentials.
+func (resource AppLifecycleResource) ToLifecycleModel() models.AppLifecycle {
+	return models.AppLifecycle{
+		Type:       resource.Lifecycle.Type,
+		Buildpacks: resource.Lifecycle.Data.Buildpacks,
+	}
+}
+
+func NewAppLifecycleResource(lifecycle models.AppLifecycle) AppLifecycleResource {
+	resource := AppLifecycleResource{}
+	resource.Lifecycle.Type = lifecycle.Type
+	resource.Lifecycle.Data.Buildpacks = lifecycle.Buildpacks
+	if lifecycle.Buildpacks == nil {
+		resource.Lifecycle.Data.Buildpacks = []string{}
+	}
+
+	if len(lifecycle.Credentials) > 0 {
+		resource.Lifecycle.Data.Credentials = map[string]RegistryCredentialsResource{}
+		for registry, credentials := range lifecycle.Credentials {
+			resource.Lifecycle.Data.Credentials[registry] = RegistryCredentialsResource{
+				Username: credentials.Username,
+				Password: credentials.Password,
+			}
+		}
+	}
+
+	return resource
+}
@@ -131,5 +131,27 @@ var _ = Describe("Application resources", func() {
 			entity := resources.NewApplicationEntityFromAppParams(appParams)
 			Expect(entity.EnvironmentJSON).To(BeNil())
 		})
+
+		It("does not include docker credentials when no docker username is given", func() {
+			entity := resources.NewApplicationEntityFromAppParams(appParams)
+			Expect(entity.DockerCredentials).To(BeNil())
+		})
+
+		Context("when a docker username and password are given", func() {
+			BeforeEach(func() {
+				username := "docker-user"
+				password := "docker-password"
+				appParams.DockerUsername = &username
+				appParams.DockerPassword = &password
+			})
+
+			It("includes them as docker credentials", func() {
+				entity := resources.NewApplicationEntityFromAppParams(appParams)
+				Expect(*entity.DockerCredentials).To(Equal(resources.DockerCredentials{
+					Username: "docker-user",
+					Password: "docker-password",
+				}))
+			})
+		})
 	})
 })
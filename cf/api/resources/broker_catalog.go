@@ -0,0 +1,46 @@
+package resources
+
+import "code.cloudfoundry.org/cli/cf/models"
+
+type BrokerCatalogResource struct {
+	Services []BrokerCatalogServiceResource `json:"services"`
+}
+
+type BrokerCatalogServiceResource struct {
+	ID          string                      `json:"id"`
+	Name        string                      `json:"name"`
+	Description string                      `json:"description"`
+	Plans       []BrokerCatalogPlanResource `json:"plans"`
+}
+
+type BrokerCatalogPlanResource struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Free        *bool  `json:"free"`
+}
+
+func (resource BrokerCatalogResource) ToModel() models.BrokerCatalog {
+	catalog := models.BrokerCatalog{}
+	for _, service := range resource.Services {
+		catalogService := models.BrokerCatalogService{
+			ID:          service.ID,
+			Name:        service.Name,
+			Description: service.Description,
+		}
+
+		for _, plan := range service.Plans {
+			// Plans are free unless the broker says otherwise.
+			free := plan.Free == nil || *plan.Free
+			catalogService.Plans = append(catalogService.Plans, models.BrokerCatalogPlan{
+				ID:          plan.ID,
+				Name:        plan.Name,
+				Description: plan.Description,
+				Free:        free,
+			})
+		}
+
+		catalog.Services = append(catalog.Services, catalogService)
+	}
+	return catalog
+}
@@ -14,6 +14,7 @@ type BuildpackEntity struct {
 	Key      string `json:"key,omitempty"`
 	Filename string `json:"filename,omitempty"`
 	Locked   *bool  `json:"locked,omitempty"`
+	Stack    string `json:"stack,omitempty"`
 }
 
 func (resource BuildpackResource) ToFields() models.Buildpack {
@@ -25,5 +26,6 @@ func (resource BuildpackResource) ToFields() models.Buildpack {
 		Key:      resource.Entity.Key,
 		Filename: resource.Entity.Filename,
 		Locked:   resource.Entity.Locked,
+		Stack:    resource.Entity.Stack,
 	}
 }
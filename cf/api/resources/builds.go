@@ -0,0 +1,73 @@
+package resources
+
+import (
+	"path"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type PaginatedBuildResources struct {
+	Pagination struct {
+		Next *struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"pagination"`
+	Resources []BuildResource `json:"resources"`
+}
+
+type BuildResource struct {
+	GUID      string    `json:"guid"`
+	State     string    `json:"state"`
+	Error     string    `json:"error"`
+	CreatedAt time.Time `json:"created_at"`
+	Package   *struct {
+		GUID string `json:"guid"`
+	} `json:"package"`
+	Droplet *struct {
+		GUID string `json:"guid"`
+	} `json:"droplet"`
+	Relationships struct {
+		App struct {
+			Data struct {
+				GUID string `json:"guid"`
+			} `json:"data"`
+		} `json:"app"`
+	} `json:"relationships"`
+	Links struct {
+		App struct {
+			Href string `json:"href"`
+		} `json:"app"`
+	} `json:"links"`
+}
+
+type BuildRequest struct {
+	Package struct {
+		GUID string `json:"guid"`
+	} `json:"package"`
+}
+
+func (resource BuildResource) ToModel() models.Build {
+	build := models.Build{
+		GUID:      resource.GUID,
+		State:     resource.State,
+		Error:     resource.Error,
+		AppGUID:   resource.Relationships.App.Data.GUID,
+		CreatedAt: resource.CreatedAt,
+	}
+
+	// older APIs only link the build to its app
+	if build.AppGUID == "" && resource.Links.App.Href != "" {
+		build.AppGUID = path.Base(resource.Links.App.Href)
+	}
+
+	if resource.Package != nil {
+		build.PackageGUID = resource.Package.GUID
+	}
+
+	if resource.Droplet != nil {
+		build.DropletGUID = resource.Droplet.GUID
+	}
+
+	return build
+}
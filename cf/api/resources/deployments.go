@@ -0,0 +1,107 @@
+package resources
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type PaginatedDeploymentResources struct {
+	Pagination struct {
+		Next *struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"pagination"`
+	Resources []DeploymentResource `json:"resources"`
+}
+
+type DeploymentResource struct {
+	GUID   string `json:"guid"`
+	State  string `json:"state"`
+	Status struct {
+		Value  string `json:"value"`
+		Reason string `json:"reason"`
+	} `json:"status"`
+	Strategy string `json:"strategy"`
+	Droplet  struct {
+		GUID string `json:"guid"`
+	} `json:"droplet"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type DeploymentRequest struct {
+	Droplet       *DeploymentDropletRequest  `json:"droplet,omitempty"`
+	Revision      *DeploymentRevisionRequest `json:"revision,omitempty"`
+	Strategy      string                     `json:"strategy,omitempty"`
+	Options       *DeploymentOptionsRequest  `json:"options,omitempty"`
+	Relationships struct {
+		App struct {
+			Data struct {
+				GUID string `json:"guid"`
+			} `json:"data"`
+		} `json:"app"`
+	} `json:"relationships"`
+}
+
+type DeploymentDropletRequest struct {
+	GUID string `json:"guid"`
+}
+
+type DeploymentRevisionRequest struct {
+	GUID string `json:"guid"`
+}
+
+type DeploymentOptionsRequest struct {
+	MaxInFlight int                      `json:"max_in_flight,omitempty"`
+	Canary      *DeploymentCanaryRequest `json:"canary,omitempty"`
+}
+
+type DeploymentCanaryRequest struct {
+	Steps []DeploymentCanaryStepRequest `json:"steps"`
+}
+
+type DeploymentCanaryStepRequest struct {
+	InstanceWeight int `json:"instance_weight"`
+}
+
+func (resource DeploymentResource) ToModel() models.Deployment {
+	return models.Deployment{
+		GUID:         resource.GUID,
+		State:        resource.State,
+		StatusValue:  resource.Status.Value,
+		StatusReason: resource.Status.Reason,
+		Strategy:     resource.Strategy,
+		DropletGUID:  resource.Droplet.GUID,
+		CreatedAt:    resource.CreatedAt,
+	}
+}
+
+func NewDeploymentRequest(appGUID string, params models.DeploymentParams) DeploymentRequest {
+	request := DeploymentRequest{Strategy: params.Strategy}
+	request.Relationships.App.Data.GUID = appGUID
+
+	if params.DropletGUID != "" {
+		request.Droplet = &DeploymentDropletRequest{GUID: params.DropletGUID}
+	}
+
+	if params.RevisionGUID != "" {
+		request.Revision = &DeploymentRevisionRequest{GUID: params.RevisionGUID}
+	}
+
+	if params.MaxInFlight != nil || len(params.InstanceSteps) > 0 {
+		request.Options = &DeploymentOptionsRequest{}
+	}
+
+	if params.MaxInFlight != nil {
+		request.Options.MaxInFlight = *params.MaxInFlight
+	}
+
+	if len(params.InstanceSteps) > 0 {
+		request.Options.Canary = &DeploymentCanaryRequest{}
+		for _, weight := range params.InstanceSteps {
+			request.Options.Canary.Steps = append(request.Options.Canary.Steps, DeploymentCanaryStepRequest{InstanceWeight: weight})
+		}
+	}
+
+	return request
+}
@@ -0,0 +1,77 @@
+package resources
+
+import (
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type PaginatedDropletResources struct {
+	Pagination struct {
+		Next *struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"pagination"`
+	Resources []DropletResource `json:"resources"`
+}
+
+type DropletResource struct {
+	GUID      string    `json:"guid"`
+	State     string    `json:"state"`
+	CreatedAt time.Time `json:"created_at"`
+	Lifecycle struct {
+		Type string `json:"type"`
+		Data struct {
+			Buildpack string `json:"buildpack"`
+			Stack     string `json:"stack"`
+		} `json:"data"`
+	} `json:"lifecycle"`
+	Buildpacks []struct {
+		Name          string `json:"name"`
+		BuildpackName string `json:"buildpack_name"`
+	} `json:"buildpacks"`
+}
+
+type DropletCreateResource struct {
+	Relationships struct {
+		App struct {
+			Data struct {
+				GUID string `json:"guid"`
+			} `json:"data"`
+		} `json:"app"`
+	} `json:"relationships"`
+}
+
+type CurrentDropletRelationshipResource struct {
+	Data struct {
+		GUID string `json:"guid"`
+	} `json:"data"`
+}
+
+// ToModel converts the droplet. Droplets staged with more than one
+// buildpack, and cnb droplets, only list their buildpacks outside of the
+// lifecycle data, so those are joined into the buildpack of the model.
+func (resource DropletResource) ToModel() models.Droplet {
+	buildpack := resource.Lifecycle.Data.Buildpack
+	if buildpack == "" {
+		names := []string{}
+		for _, b := range resource.Buildpacks {
+			name := b.BuildpackName
+			if name == "" {
+				name = b.Name
+			}
+			names = append(names, name)
+		}
+		buildpack = strings.Join(names, ", ")
+	}
+
+	return models.Droplet{
+		GUID:      resource.GUID,
+		State:     resource.State,
+		Lifecycle: resource.Lifecycle.Type,
+		Buildpack: buildpack,
+		Stack:     resource.Lifecycle.Data.Stack,
+		CreatedAt: resource.CreatedAt,
+	}
+}
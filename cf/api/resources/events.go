@@ -22,6 +22,8 @@ type EventResourceNewV2 struct {
 		Type      string
 		Actor     string `json:"actor"`
 		ActorName string `json:"actor_name"`
+		ActeeType string `json:"actee_type"`
+		ActeeName string `json:"actee_name"`
 		Metadata  map[string]interface{}
 	}
 }
@@ -42,14 +44,27 @@ func (resource EventResourceNewV2) ToFields() models.EventFields {
 		metadata = generic.NewMap(metadata.Get("request"))
 	}
 
-	return models.EventFields{
+	fields := models.EventFields{
 		GUID:        resource.Metadata.GUID,
 		Name:        resource.Entity.Type,
 		Timestamp:   resource.Entity.Timestamp,
 		Description: formatDescription(metadata, knownMetadataKeys),
 		Actor:       resource.Entity.Actor,
 		ActorName:   resource.Entity.ActorName,
+		ActeeType:   resource.Entity.ActeeType,
+		ActeeName:   resource.Entity.ActeeName,
+	}
+
+	if crashEventTypes[resource.Entity.Type] {
+		fields.Crash = &models.CrashFields{
+			InstanceIndex:   metadataInt(metadata, "index"),
+			ExitStatus:      metadataInt(metadata, "exit_status"),
+			ExitDescription: metadataString(metadata, "exit_description"),
+			Reason:          metadataString(metadata, "reason"),
+		}
 	}
+
+	return fields
 }
 
 func (resource EventResourceOldV2) ToFields() models.EventFields {
@@ -63,9 +78,31 @@ func (resource EventResourceOldV2) ToFields() models.EventFields {
 				"ExitDescription": resource.Entity.ExitDescription,
 				"ExitStatus":      strconv.Itoa(resource.Entity.ExitStatus),
 			})),
+		Crash: &models.CrashFields{
+			InstanceIndex:   resource.Entity.InstanceIndex,
+			ExitStatus:      resource.Entity.ExitStatus,
+			ExitDescription: resource.Entity.ExitDescription,
+		},
 	}
 }
 
+// crashEventTypes are the types of the events of an app instance crashing,
+// as recorded by the v2 and v3 APIs.
+var crashEventTypes = map[string]bool{
+	"app.crash":               true,
+	"audit.app.process.crash": true,
+}
+
+func metadataInt(metadata generic.Map, key string) int {
+	value, _ := metadata.Get(key).(float64)
+	return int(value)
+}
+
+func metadataString(metadata generic.Map, key string) string {
+	value, _ := metadata.Get(key).(string)
+	return value
+}
+
 var knownMetadataKeys = []string{
 	"index",
 	"reason",
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	. "code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/models"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -46,6 +47,12 @@ var _ = Describe("Event resources", func() {
 			Expect(eventFields.Name).To(Equal("app.crash"))
 			Expect(eventFields.Timestamp).To(Equal(timestamp))
 			Expect(eventFields.Description).To(Equal(`index: 3, reason: CRASHED, exit_description: unknown, exit_status: -1`))
+			Expect(eventFields.Crash).To(Equal(&models.CrashFields{
+				InstanceIndex:   3,
+				ExitStatus:      -1,
+				ExitDescription: "unknown",
+				Reason:          "CRASHED",
+			}))
 		})
 
 		It("unmarshals app update events", func() {
@@ -78,6 +85,7 @@ var _ = Describe("Event resources", func() {
 			Expect(eventFields.Name).To(Equal("audit.app.update"))
 			Expect(eventFields.Timestamp).To(Equal(timestamp))
 			Expect(eventFields.Description).To(Equal("instances: 1, memory: 256, state: STOPPED, command: PRIVATE DATA HIDDEN, environment_json: PRIVATE DATA HIDDEN"))
+			Expect(eventFields.Crash).To(BeNil())
 		})
 
 		It("unmarshals app delete events", func() {
@@ -174,6 +182,11 @@ var _ = Describe("Event resources", func() {
 			Expect(eventFields.Name).To(Equal("app crashed"))
 			Expect(eventFields.Timestamp).To(Equal(timestamp))
 			Expect(eventFields.Description).To(Equal("instance: 4, reason: the exit description, exit_status: 3"))
+			Expect(eventFields.Crash).To(Equal(&models.CrashFields{
+				InstanceIndex:   4,
+				ExitStatus:      3,
+				ExitDescription: "the exit description",
+			}))
 		})
 	})
 })
@@ -0,0 +1,46 @@
+package resources
+
+import "code.cloudfoundry.org/cli/cf/models"
+
+type PaginatedLabeledResources struct {
+	Pagination struct {
+		Next *struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"pagination"`
+	Resources []LabeledResource `json:"resources"`
+}
+
+type LabeledResource struct {
+	GUID     string `json:"guid"`
+	Name     string `json:"name"`
+	Metadata struct {
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+}
+
+type MetadataUpdateRequest struct {
+	Metadata struct {
+		Labels      map[string]*string `json:"labels,omitempty"`
+		Annotations map[string]*string `json:"annotations,omitempty"`
+	} `json:"metadata"`
+}
+
+func (resource LabeledResource) ToModel() models.LabeledResource {
+	return models.LabeledResource{
+		GUID: resource.GUID,
+		Name: resource.Name,
+		Metadata: models.Metadata{
+			Labels:      resource.Metadata.Labels,
+			Annotations: resource.Metadata.Annotations,
+		},
+	}
+}
+
+func NewMetadataUpdateRequest(update models.MetadataUpdate) MetadataUpdateRequest {
+	request := MetadataUpdateRequest{}
+	request.Metadata.Labels = update.Labels
+	request.Metadata.Annotations = update.Annotations
+	return request
+}
@@ -0,0 +1,42 @@
+package resources
+
+import "code.cloudfoundry.org/cli/cf/models"
+
+type NetworkPoliciesResource struct {
+	TotalPolicies int                     `json:"total_policies,omitempty"`
+	Policies      []NetworkPolicyResource `json:"policies"`
+}
+
+type NetworkPolicyResource struct {
+	Source struct {
+		ID string `json:"id"`
+	} `json:"source"`
+	Destination struct {
+		ID       string `json:"id"`
+		Protocol string `json:"protocol"`
+		Ports    struct {
+			Start int `json:"start"`
+			End   int `json:"end"`
+		} `json:"ports"`
+	} `json:"destination"`
+}
+
+func (resource NetworkPolicyResource) ToModel() models.NetworkPolicy {
+	return models.NetworkPolicy{
+		SourceGUID:      resource.Source.ID,
+		DestinationGUID: resource.Destination.ID,
+		Protocol:        resource.Destination.Protocol,
+		StartPort:       resource.Destination.Ports.Start,
+		EndPort:         resource.Destination.Ports.End,
+	}
+}
+
+func NewNetworkPolicyResource(policy models.NetworkPolicy) NetworkPolicyResource {
+	resource := NetworkPolicyResource{}
+	resource.Source.ID = policy.SourceGUID
+	resource.Destination.ID = policy.DestinationGUID
+	resource.Destination.Protocol = policy.Protocol
+	resource.Destination.Ports.Start = policy.StartPort
+	resource.Destination.Ports.End = policy.EndPort
+	return resource
+}
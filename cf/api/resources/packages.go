@@ -0,0 +1,43 @@
+package resources
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type PaginatedPackageResources struct {
+	Pagination struct {
+		Next *struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"pagination"`
+	Resources []PackageResource `json:"resources"`
+}
+
+type PackageResource struct {
+	GUID      string    `json:"guid"`
+	Type      string    `json:"type"`
+	State     string    `json:"state"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PackageCreateResource struct {
+	Type          string `json:"type"`
+	Relationships struct {
+		App struct {
+			Data struct {
+				GUID string `json:"guid"`
+			} `json:"data"`
+		} `json:"app"`
+	} `json:"relationships"`
+}
+
+func (resource PackageResource) ToModel() models.Package {
+	return models.Package{
+		GUID:      resource.GUID,
+		Type:      resource.Type,
+		State:     resource.State,
+		CreatedAt: resource.CreatedAt,
+	}
+}
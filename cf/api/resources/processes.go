@@ -0,0 +1,145 @@
+package resources
+
+import (
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type PaginatedProcessResources struct {
+	Pagination struct {
+		Next *struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"pagination"`
+	Resources []ProcessResource `json:"resources"`
+}
+
+type ProcessResource struct {
+	GUID        string              `json:"guid"`
+	Type        string              `json:"type"`
+	Instances   int                 `json:"instances"`
+	MemoryInMB  int64               `json:"memory_in_mb"`
+	DiskInMB    int64               `json:"disk_in_mb"`
+	HealthCheck HealthCheckResource `json:"health_check"`
+
+	ReadinessHealthCheck HealthCheckResource `json:"readiness_health_check"`
+}
+
+type HealthCheckResource struct {
+	Type string `json:"type,omitempty"`
+	Data struct {
+		Endpoint          *string `json:"endpoint,omitempty"`
+		InvocationTimeout *int    `json:"invocation_timeout,omitempty"`
+		Interval          *int    `json:"interval,omitempty"`
+	} `json:"data"`
+}
+
+type ProcessUpdateRequest struct {
+	HealthCheck          *HealthCheckResource `json:"health_check,omitempty"`
+	ReadinessHealthCheck *HealthCheckResource `json:"readiness_health_check,omitempty"`
+}
+
+type ProcessScaleRequest struct {
+	Instances  *int   `json:"instances,omitempty"`
+	MemoryInMB *int64 `json:"memory_in_mb,omitempty"`
+	DiskInMB   *int64 `json:"disk_in_mb,omitempty"`
+}
+
+type ProcessStatsResources struct {
+	Resources []ProcessStatsResource `json:"resources"`
+}
+
+type ProcessStatsResource struct {
+	Index int    `json:"index"`
+	State string `json:"state"`
+	Usage struct {
+		Time time.Time `json:"time"`
+		CPU  float64   `json:"cpu"`
+		Mem  int64     `json:"mem"`
+		Disk int64     `json:"disk"`
+	} `json:"usage"`
+	Uptime    int64  `json:"uptime"`
+	MemQuota  int64  `json:"mem_quota"`
+	DiskQuota int64  `json:"disk_quota"`
+	Details   string `json:"details"`
+	Routable  *bool  `json:"routable"`
+}
+
+func (resource ProcessResource) ToModel() models.Process {
+	process := models.Process{
+		GUID:       resource.GUID,
+		Type:       resource.Type,
+		Instances:  resource.Instances,
+		MemoryInMB: resource.MemoryInMB,
+		DiskInMB:   resource.DiskInMB,
+
+		HealthCheckType:          resource.HealthCheck.Type,
+		ReadinessHealthCheckType: resource.ReadinessHealthCheck.Type,
+	}
+
+	if resource.HealthCheck.Data.Endpoint != nil {
+		process.HealthCheckHTTPEndpoint = *resource.HealthCheck.Data.Endpoint
+	}
+	if resource.HealthCheck.Data.InvocationTimeout != nil {
+		process.HealthCheckInvocationTimeout = *resource.HealthCheck.Data.InvocationTimeout
+	}
+	if resource.ReadinessHealthCheck.Data.Endpoint != nil {
+		process.ReadinessHealthCheckHTTPEndpoint = *resource.ReadinessHealthCheck.Data.Endpoint
+	}
+	if resource.ReadinessHealthCheck.Data.Interval != nil {
+		process.ReadinessHealthCheckInterval = *resource.ReadinessHealthCheck.Data.Interval
+	}
+
+	return process
+}
+
+// ToModel returns the stats of the instance in the form the v2 instances API
+// uses, so both can be shown the same way. The instance has been up since
+// its uptime before the time the usage was taken.
+func (resource ProcessStatsResource) ToModel() models.AppInstanceFields {
+	instance := models.AppInstanceFields{
+		State:     models.InstanceState(strings.ToLower(resource.State)),
+		Details:   resource.Details,
+		CPUUsage:  resource.Usage.CPU,
+		DiskQuota: resource.DiskQuota,
+		DiskUsage: resource.Usage.Disk,
+		MemQuota:  resource.MemQuota,
+		MemUsage:  resource.Usage.Mem,
+		Routable:  resource.Routable,
+	}
+
+	if !resource.Usage.Time.IsZero() {
+		instance.Since = resource.Usage.Time.Add(-time.Duration(resource.Uptime) * time.Second)
+	}
+
+	return instance
+}
+
+func NewProcessScaleRequest(params models.ProcessScaleParams) ProcessScaleRequest {
+	return ProcessScaleRequest{
+		Instances:  params.Instances,
+		MemoryInMB: params.MemoryInMB,
+		DiskInMB:   params.DiskInMB,
+	}
+}
+
+func NewProcessUpdateRequest(healthCheck models.ProcessHealthCheck) ProcessUpdateRequest {
+	return ProcessUpdateRequest{HealthCheck: newHealthCheckResource(healthCheck)}
+}
+
+func NewProcessReadinessUpdateRequest(healthCheck models.ProcessHealthCheck) ProcessUpdateRequest {
+	return ProcessUpdateRequest{ReadinessHealthCheck: newHealthCheckResource(healthCheck)}
+}
+
+func newHealthCheckResource(healthCheck models.ProcessHealthCheck) *HealthCheckResource {
+	resource := &HealthCheckResource{}
+	if healthCheck.Type != nil {
+		resource.Type = *healthCheck.Type
+	}
+	resource.Data.Endpoint = healthCheck.HTTPEndpoint
+	resource.Data.InvocationTimeout = healthCheck.InvocationTimeout
+	resource.Data.Interval = healthCheck.Interval
+	return resource
+}
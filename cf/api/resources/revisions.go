@@ -0,0 +1,38 @@
+package resources
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type PaginatedRevisionResources struct {
+	Pagination struct {
+		Next *struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"pagination"`
+	Resources []RevisionResource `json:"resources"`
+}
+
+type RevisionResource struct {
+	GUID        string `json:"guid"`
+	Version     int    `json:"version"`
+	Description string `json:"description"`
+	Deployable  bool   `json:"deployable"`
+	Droplet     struct {
+		GUID string `json:"guid"`
+	} `json:"droplet"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (resource RevisionResource) ToModel() models.Revision {
+	return models.Revision{
+		GUID:        resource.GUID,
+		Version:     resource.Version,
+		Description: resource.Description,
+		Deployable:  resource.Deployable,
+		DropletGUID: resource.Droplet.GUID,
+		CreatedAt:   resource.CreatedAt,
+	}
+}
@@ -8,13 +8,17 @@ type ServiceBindingResource struct {
 }
 
 type ServiceBindingEntity struct {
-	AppGUID string `json:"app_guid"`
+	Name                string `json:"name"`
+	AppGUID             string `json:"app_guid"`
+	ServiceInstanceGUID string `json:"service_instance_guid"`
 }
 
 func (resource ServiceBindingResource) ToFields() models.ServiceBindingFields {
 	return models.ServiceBindingFields{
-		URL:     resource.Metadata.URL,
-		GUID:    resource.Metadata.GUID,
-		AppGUID: resource.Entity.AppGUID,
+		URL:                 resource.Metadata.URL,
+		GUID:                resource.Metadata.GUID,
+		Name:                resource.Entity.Name,
+		AppGUID:             resource.Entity.AppGUID,
+		ServiceInstanceGUID: resource.Entity.ServiceInstanceGUID,
 	}
 }
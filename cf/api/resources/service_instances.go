@@ -23,6 +23,7 @@ type LastOperation struct {
 type ServiceInstanceEntity struct {
 	Name            string                   `json:"name"`
 	DashboardURL    string                   `json:"dashboard_url"`
+	RouteServiceURL string                   `json:"route_service_url"`
 	Tags            []string                 `json:"tags"`
 	ServiceBindings []ServiceBindingResource `json:"service_bindings"`
 	ServiceKeys     []ServiceKeyResource     `json:"service_keys"`
@@ -32,10 +33,11 @@ type ServiceInstanceEntity struct {
 
 func (resource ServiceInstanceResource) ToFields() models.ServiceInstanceFields {
 	return models.ServiceInstanceFields{
-		GUID:         resource.Metadata.GUID,
-		Name:         resource.Entity.Name,
-		Tags:         resource.Entity.Tags,
-		DashboardURL: resource.Entity.DashboardURL,
+		GUID:            resource.Metadata.GUID,
+		Name:            resource.Entity.Name,
+		Tags:            resource.Entity.Tags,
+		DashboardURL:    resource.Entity.DashboardURL,
+		RouteServiceURL: resource.Entity.RouteServiceURL,
 		LastOperation: models.LastOperationFields{
 			Type:        resource.Entity.LastOperation.Type,
 			State:       resource.Entity.LastOperation.State,
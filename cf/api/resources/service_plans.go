@@ -1,6 +1,7 @@
 package resources
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"code.cloudfoundry.org/cli/cf/models"
@@ -17,6 +18,7 @@ type ServicePlanEntity struct {
 	Public              bool
 	Active              bool
 	Description         string                  `json:"description"`
+	Extra               string                  `json:"extra"`
 	ServiceOfferingGUID string                  `json:"service_guid"`
 	ServiceOffering     ServiceOfferingResource `json:"service"`
 }
@@ -35,6 +37,17 @@ func (resource ServicePlanResource) ToFields() (fields models.ServicePlanFields)
 	fields.Public = resource.Entity.Public
 	fields.Active = resource.Entity.Active
 	fields.ServiceOfferingGUID = resource.Entity.ServiceOfferingGUID
+
+	// The metadata of the plan is free-form, so costs that cannot be read
+	// are left out rather than failing.
+	if resource.Entity.Extra != "" {
+		var extra struct {
+			Costs []models.ServicePlanCost `json:"costs"`
+		}
+		if err := json.Unmarshal([]byte(resource.Entity.Extra), &extra); err == nil {
+			fields.Costs = extra.Costs
+		}
+	}
 	return
 }
 
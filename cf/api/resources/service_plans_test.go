@@ -0,0 +1,46 @@
+package resources_test
+
+import (
+	"encoding/json"
+
+	. "code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServicePlanResource", func() {
+	var resource ServicePlanResource
+
+	Describe("ToFields", func() {
+		It("reads the costs from the metadata of the plan", func() {
+			err := json.Unmarshal([]byte(`{
+				"metadata": {"guid": "plan-guid"},
+				"entity": {
+					"name": "large",
+					"free": false,
+					"extra": "{\"costs\":[{\"amount\":{\"usd\":10.5,\"eur\":9.0},\"unit\":\"MONTHLY\"}],\"bullets\":[\"10 GB\"]}"
+				}
+			}`), &resource)
+			Expect(err).NotTo(HaveOccurred())
+
+			fields := resource.ToFields()
+			Expect(fields.GUID).To(Equal("plan-guid"))
+			Expect(fields.Free).To(BeFalse())
+			Expect(fields.Costs).To(Equal([]models.ServicePlanCost{
+				{Amount: map[string]float64{"usd": 10.5, "eur": 9.0}, Unit: "MONTHLY"},
+			}))
+		})
+
+		It("leaves out costs when the metadata cannot be read", func() {
+			err := json.Unmarshal([]byte(`{
+				"metadata": {"guid": "plan-guid"},
+				"entity": {"name": "large", "extra": "not json"}
+			}`), &resource)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resource.ToFields().Costs).To(BeNil())
+		})
+	})
+})
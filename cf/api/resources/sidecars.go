@@ -0,0 +1,39 @@
+package resources
+
+import "code.cloudfoundry.org/cli/cf/models"
+
+type PaginatedSidecarResources struct {
+	Pagination struct {
+		Next *struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"pagination"`
+	Resources []SidecarResource `json:"resources"`
+}
+
+type SidecarResource struct {
+	GUID         string   `json:"guid,omitempty"`
+	Name         string   `json:"name"`
+	Command      string   `json:"command"`
+	ProcessTypes []string `json:"process_types"`
+	MemoryInMB   int64    `json:"memory_in_mb,omitempty"`
+}
+
+func (resource SidecarResource) ToModel() models.Sidecar {
+	return models.Sidecar{
+		GUID:         resource.GUID,
+		Name:         resource.Name,
+		Command:      resource.Command,
+		ProcessTypes: resource.ProcessTypes,
+		MemoryInMB:   resource.MemoryInMB,
+	}
+}
+
+func NewSidecarResource(sidecar models.Sidecar) SidecarResource {
+	return SidecarResource{
+		Name:         sidecar.Name,
+		Command:      sidecar.Command,
+		ProcessTypes: sidecar.ProcessTypes,
+		MemoryInMB:   sidecar.MemoryInMB,
+	}
+}
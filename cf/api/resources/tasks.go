@@ -0,0 +1,56 @@
+package resources
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type PaginatedTaskResources struct {
+	Pagination struct {
+		Next *struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"pagination"`
+	Resources []TaskResource `json:"resources"`
+}
+
+type TaskResource struct {
+	GUID       string    `json:"guid"`
+	SequenceID int       `json:"sequence_id"`
+	Name       string    `json:"name"`
+	Command    string    `json:"command"`
+	State      string    `json:"state"`
+	MemoryInMB int64     `json:"memory_in_mb"`
+	DiskInMB   int64     `json:"disk_in_mb"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type TaskRequest struct {
+	Name       string `json:"name,omitempty"`
+	Command    string `json:"command"`
+	MemoryInMB int64  `json:"memory_in_mb,omitempty"`
+	DiskInMB   int64  `json:"disk_in_mb,omitempty"`
+}
+
+func (resource TaskResource) ToModel() models.Task {
+	return models.Task{
+		GUID:       resource.GUID,
+		SequenceID: resource.SequenceID,
+		Name:       resource.Name,
+		Command:    resource.Command,
+		State:      resource.State,
+		MemoryInMB: resource.MemoryInMB,
+		DiskInMB:   resource.DiskInMB,
+		CreatedAt:  resource.CreatedAt,
+	}
+}
+
+func NewTaskRequest(params models.TaskParams) TaskRequest {
+	return TaskRequest{
+		Name:       params.Name,
+		Command:    params.Command,
+		MemoryInMB: params.MemoryInMB,
+		DiskInMB:   params.DiskInMB,
+	}
+}
@@ -16,6 +16,7 @@ type UAAUserResources struct {
 	Resources []struct {
 		ID       string
 		Username string
+		Origin   string
 	}
 }
 
@@ -37,10 +38,12 @@ type UAAUserResourceName struct {
 }
 
 type UAAUserResource struct {
-	Username string                 `json:"userName"`
-	Emails   []UAAUserResourceEmail `json:"emails"`
-	Password string                 `json:"password"`
-	Name     UAAUserResourceName    `json:"name"`
+	Username   string                 `json:"userName"`
+	Emails     []UAAUserResourceEmail `json:"emails"`
+	Password   string                 `json:"password,omitempty"`
+	Name       UAAUserResourceName    `json:"name"`
+	Origin     string                 `json:"origin,omitempty"`
+	ExternalID string                 `json:"externalId,omitempty"`
 }
 
 func NewUAAUserResource(username, password string) UAAUserResource {
@@ -0,0 +1,66 @@
+package revisions
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+)
+
+//go:generate counterfeiter . Repository
+
+// Repository reads the revisions of an app through the v3 revisions API. The
+// Cloud Controller only records revisions for apps with the revisions app
+// feature enabled.
+type Repository interface {
+	ListRevisions(appGUID string) ([]models.Revision, error)
+	ListDeployedRevisions(appGUID string) ([]models.Revision, error)
+}
+
+type CloudControllerRepository struct {
+	config  coreconfig.Reader
+	gateway net.Gateway
+}
+
+func NewCloudControllerRepository(config coreconfig.Reader, gateway net.Gateway) CloudControllerRepository {
+	return CloudControllerRepository{
+		config:  config,
+		gateway: gateway,
+	}
+}
+
+// ListRevisions returns the revisions of the app, newest first.
+func (repo CloudControllerRepository) ListRevisions(appGUID string) ([]models.Revision, error) {
+	return repo.listRevisions(fmt.Sprintf("%s/v3/apps/%s/revisions?order_by=-created_at", repo.config.APIEndpoint(), appGUID))
+}
+
+// ListDeployedRevisions returns the revisions that instances of the app are
+// running. There is more than one while a deployment is in progress.
+func (repo CloudControllerRepository) ListDeployedRevisions(appGUID string) ([]models.Revision, error) {
+	return repo.listRevisions(fmt.Sprintf("%s/v3/apps/%s/revisions/deployed", repo.config.APIEndpoint(), appGUID))
+}
+
+func (repo CloudControllerRepository) listRevisions(url string) ([]models.Revision, error) {
+	revisions := []models.Revision{}
+
+	for url != "" {
+		page := resources.PaginatedRevisionResources{}
+		err := repo.gateway.GetResource(url, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range page.Resources {
+			revisions = append(revisions, resource.ToModel())
+		}
+
+		url = ""
+		if page.Pagination.Next != nil {
+			url = page.Pagination.Next.Href
+		}
+	}
+
+	return revisions, nil
+}
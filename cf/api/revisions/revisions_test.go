@@ -0,0 +1,118 @@
+package revisions_test
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+
+	"github.com/onsi/gomega/ghttp"
+
+	. "code.cloudfoundry.org/cli/cf/api/revisions"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RevisionsRepo", func() {
+	var (
+		testServer *ghttp.Server
+		configRepo coreconfig.ReadWriter
+		repo       Repository
+	)
+
+	BeforeEach(func() {
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		configRepo.SetAccessToken("BEARER my_access_token")
+
+		gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		repo = NewCloudControllerRepository(configRepo, gateway)
+	})
+
+	BeforeEach(func() {
+		testServer = ghttp.NewServer()
+		configRepo.SetAPIEndpoint(testServer.URL())
+	})
+
+	AfterEach(func() {
+		if testServer != nil {
+			testServer.Close()
+		}
+	})
+
+	Describe("ListRevisions", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/revisions", "order_by=-created_at"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": {
+							"next": { "href": "`+testServer.URL()+`/v3/apps/app-guid/revisions?order_by=-created_at&page=2" }
+						},
+						"resources": [
+							{
+								"guid": "revision-2-guid",
+								"version": 2,
+								"description": "New environment variables deployed.",
+								"deployable": true,
+								"droplet": { "guid": "droplet-1-guid" },
+								"created_at": "2016-11-02T10:00:00Z"
+							}
+						]
+					}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/revisions", "order_by=-created_at&page=2"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": { "next": null },
+						"resources": [
+							{
+								"guid": "revision-1-guid",
+								"version": 1,
+								"description": "Initial revision.",
+								"deployable": false,
+								"droplet": { "guid": "droplet-1-guid" },
+								"created_at": "2016-11-01T10:00:00Z"
+							}
+						]
+					}`),
+				),
+			)
+		})
+
+		It("returns the revisions of every page", func() {
+			revisions, err := repo.ListRevisions("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revisions).To(Equal([]models.Revision{
+				{GUID: "revision-2-guid", Version: 2, Description: "New environment variables deployed.", Deployable: true, DropletGUID: "droplet-1-guid", CreatedAt: time.Date(2016, 11, 2, 10, 0, 0, 0, time.UTC)},
+				{GUID: "revision-1-guid", Version: 1, Description: "Initial revision.", Deployable: false, DropletGUID: "droplet-1-guid", CreatedAt: time.Date(2016, 11, 1, 10, 0, 0, 0, time.UTC)},
+			}))
+		})
+	})
+
+	Describe("ListDeployedRevisions", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/revisions/deployed"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": { "next": null },
+						"resources": [ { "guid": "revision-2-guid", "version": 2 } ]
+					}`),
+				),
+			)
+		})
+
+		It("returns the revisions the app is running", func() {
+			revisions, err := repo.ListDeployedRevisions("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revisions).To(HaveLen(1))
+			Expect(revisions[0].GUID).To(Equal("revision-2-guid"))
+		})
+	})
+})
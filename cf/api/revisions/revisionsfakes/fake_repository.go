@@ -0,0 +1,124 @@
+// This file was generated by counterfeiter
+package revisionsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/api/revisions"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type FakeRepository struct {
+	ListRevisionsStub        func(appGUID string) ([]models.Revision, error)
+	listRevisionsMutex       sync.RWMutex
+	listRevisionsArgsForCall []struct {
+		appGUID string
+	}
+	listRevisionsReturns struct {
+		result1 []models.Revision
+		result2 error
+	}
+	ListDeployedRevisionsStub        func(appGUID string) ([]models.Revision, error)
+	listDeployedRevisionsMutex       sync.RWMutex
+	listDeployedRevisionsArgsForCall []struct {
+		appGUID string
+	}
+	listDeployedRevisionsReturns struct {
+		result1 []models.Revision
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRepository) ListRevisions(appGUID string) ([]models.Revision, error) {
+	fake.listRevisionsMutex.Lock()
+	fake.listRevisionsArgsForCall = append(fake.listRevisionsArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("ListRevisions", []interface{}{appGUID})
+	fake.listRevisionsMutex.Unlock()
+	if fake.ListRevisionsStub != nil {
+		return fake.ListRevisionsStub(appGUID)
+	} else {
+		return fake.listRevisionsReturns.result1, fake.listRevisionsReturns.result2
+	}
+}
+
+func (fake *FakeRepository) ListRevisionsCallCount() int {
+	fake.listRevisionsMutex.RLock()
+	defer fake.listRevisionsMutex.RUnlock()
+	return len(fake.listRevisionsArgsForCall)
+}
+
+func (fake *FakeRepository) ListRevisionsArgsForCall(i int) string {
+	fake.listRevisionsMutex.RLock()
+	defer fake.listRevisionsMutex.RUnlock()
+	return fake.listRevisionsArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) ListRevisionsReturns(result1 []models.Revision, result2 error) {
+	fake.ListRevisionsStub = nil
+	fake.listRevisionsReturns = struct {
+		result1 []models.Revision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) ListDeployedRevisions(appGUID string) ([]models.Revision, error) {
+	fake.listDeployedRevisionsMutex.Lock()
+	fake.listDeployedRevisionsArgsForCall = append(fake.listDeployedRevisionsArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("ListDeployedRevisions", []interface{}{appGUID})
+	fake.listDeployedRevisionsMutex.Unlock()
+	if fake.ListDeployedRevisionsStub != nil {
+		return fake.ListDeployedRevisionsStub(appGUID)
+	} else {
+		return fake.listDeployedRevisionsReturns.result1, fake.listDeployedRevisionsReturns.result2
+	}
+}
+
+func (fake *FakeRepository) ListDeployedRevisionsCallCount() int {
+	fake.listDeployedRevisionsMutex.RLock()
+	defer fake.listDeployedRevisionsMutex.RUnlock()
+	return len(fake.listDeployedRevisionsArgsForCall)
+}
+
+func (fake *FakeRepository) ListDeployedRevisionsArgsForCall(i int) string {
+	fake.listDeployedRevisionsMutex.RLock()
+	defer fake.listDeployedRevisionsMutex.RUnlock()
+	return fake.listDeployedRevisionsArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) ListDeployedRevisionsReturns(result1 []models.Revision, result2 error) {
+	fake.ListDeployedRevisionsStub = nil
+	fake.listDeployedRevisionsReturns = struct {
+		result1 []models.Revision
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listRevisionsMutex.RLock()
+	defer fake.listRevisionsMutex.RUnlock()
+	fake.listDeployedRevisionsMutex.RLock()
+	defer fake.listDeployedRevisionsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ revisions.Repository = new(FakeRepository)
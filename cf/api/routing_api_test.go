@@ -49,7 +49,8 @@ var _ = Describe("RoutingApi", func() {
 			{
 				  "guid": "bad25cff-9332-48a6-8603-b619858e7992",
 					"name": "default-tcp",
-					"type": "tcp"
+					"type": "tcp",
+					"reservable_ports": "1024-1033"
 			}]`)
 						w.Header().Set("Content-Length", strconv.Itoa(len(responseBody)))
 						w.Header().Set("Content-Type", "application/json")
@@ -62,9 +63,10 @@ var _ = Describe("RoutingApi", func() {
 			It("lists routing groups", func() {
 				cb := func(grp models.RouterGroup) bool {
 					Expect(grp).To(Equal(models.RouterGroup{
-						GUID: "bad25cff-9332-48a6-8603-b619858e7992",
-						Name: "default-tcp",
-						Type: "tcp",
+						GUID:            "bad25cff-9332-48a6-8603-b619858e7992",
+						Name:            "default-tcp",
+						Type:            "tcp",
+						ReservablePorts: "1024-1033",
 					}))
 					return true
 				}
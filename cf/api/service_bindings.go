@@ -14,9 +14,10 @@ import (
 //go:generate counterfeiter . ServiceBindingRepository
 
 type ServiceBindingRepository interface {
-	Create(instanceGUID string, appGUID string, paramsMap map[string]interface{}) error
+	Create(instanceGUID string, appGUID string, bindingName string, paramsMap map[string]interface{}) error
 	Delete(instance models.ServiceInstance, appGUID string) (bool, error)
 	ListAllForService(instanceGUID string) ([]models.ServiceBindingFields, error)
+	ListAllForApp(appGUID string) ([]models.ServiceBindingFields, error)
 }
 
 type CloudControllerServiceBindingRepository struct {
@@ -30,11 +31,12 @@ func NewCloudControllerServiceBindingRepository(config coreconfig.Reader, gatewa
 	return
 }
 
-func (repo CloudControllerServiceBindingRepository) Create(instanceGUID, appGUID string, paramsMap map[string]interface{}) error {
+func (repo CloudControllerServiceBindingRepository) Create(instanceGUID, appGUID, bindingName string, paramsMap map[string]interface{}) error {
 	path := "/v2/service_bindings"
 	request := models.ServiceBindingRequest{
 		AppGUID:             appGUID,
 		ServiceInstanceGUID: instanceGUID,
+		Name:                bindingName,
 		Params:              paramsMap,
 	}
 
@@ -77,3 +79,19 @@ func (repo CloudControllerServiceBindingRepository) ListAllForService(instanceGU
 	)
 	return serviceBindings, err
 }
+
+func (repo CloudControllerServiceBindingRepository) ListAllForApp(appGUID string) ([]models.ServiceBindingFields, error) {
+	serviceBindings := []models.ServiceBindingFields{}
+	err := repo.gateway.ListPaginatedResources(
+		repo.config.APIEndpoint(),
+		fmt.Sprintf("/v2/apps/%s/service_bindings", appGUID),
+		resources.ServiceBindingResource{},
+		func(resource interface{}) bool {
+			if serviceBindingResource, ok := resource.(resources.ServiceBindingResource); ok {
+				serviceBindings = append(serviceBindings, serviceBindingResource.ToFields())
+			}
+			return true
+		},
+	)
+	return serviceBindings, err
+}
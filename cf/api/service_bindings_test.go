@@ -60,7 +60,24 @@ var _ = Describe("ServiceBindingsRepository", func() {
 				})
 
 				It("creates the service binding", func() {
-					err := repo.Create("my-service-instance-guid", "my-app-guid", nil)
+					err := repo.Create("my-service-instance-guid", "my-app-guid", "", nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(server.ReceivedRequests()).To(HaveLen(1))
+				})
+			})
+
+			Context("when a binding name is passed", func() {
+				BeforeEach(func() {
+					requestBody = `{
+						"app_guid":"my-app-guid",
+						"service_instance_guid":"my-service-instance-guid",
+						"name":"my-binding"
+					}`
+				})
+
+				It("sends the name as part of the request body", func() {
+					err := repo.Create("my-service-instance-guid", "my-app-guid", "my-binding", nil)
 					Expect(err).NotTo(HaveOccurred())
 
 					Expect(server.ReceivedRequests()).To(HaveLen(1))
@@ -80,6 +97,7 @@ var _ = Describe("ServiceBindingsRepository", func() {
 					err := repo.Create(
 						"my-service-instance-guid",
 						"my-app-guid",
+						"",
 						map[string]interface{}{"foo": "bar"},
 					)
 					Expect(err).NotTo(HaveOccurred())
@@ -92,7 +110,7 @@ var _ = Describe("ServiceBindingsRepository", func() {
 						paramsMap := make(map[string]interface{})
 						paramsMap["data"] = make(chan bool)
 
-						err := repo.Create("my-service-instance-guid", "my-app-guid", paramsMap)
+						err := repo.Create("my-service-instance-guid", "my-app-guid", "", paramsMap)
 						Expect(err).To(MatchError("json: unsupported type: chan bool"))
 					})
 				})
@@ -117,7 +135,7 @@ var _ = Describe("ServiceBindingsRepository", func() {
 			})
 
 			It("returns an error", func() {
-				err := repo.Create("my-service-instance-guid", "my-app-guid", nil)
+				err := repo.Create("my-service-instance-guid", "my-app-guid", "", nil)
 				Expect(err).To(HaveOccurred())
 				Expect(err.(errors.HTTPError).ErrorCode()).To(Equal("90003"))
 			})
@@ -244,4 +262,68 @@ var _ = Describe("ServiceBindingsRepository", func() {
 			})
 		})
 	})
+
+	Describe("ListAllForApp", func() {
+		Context("when the app has bindings", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/v2/apps/app-guid/service_bindings"),
+						ghttp.RespondWith(http.StatusOK, `{
+						"total_results": 2,
+						"total_pages": 1,
+						"resources": [
+							{
+								"metadata": {
+									"guid": "service-binding-1-guid",
+									"url": "/v2/service_bindings/service-binding-1-guid"
+								},
+								"entity": {
+									"app_guid": "app-guid",
+									"service_instance_guid": "service-instance-1-guid",
+									"name": "my-binding"
+								}
+							},
+							{
+								"metadata": {
+									"guid": "service-binding-2-guid",
+									"url": "/v2/service_bindings/service-binding-2-guid"
+								},
+								"entity": {
+									"app_guid": "app-guid",
+									"service_instance_guid": "service-instance-2-guid",
+									"name": null
+								}
+							}
+						]
+					}`)),
+				)
+			})
+
+			It("returns the bindings with their names", func() {
+				bindings, err := repo.ListAllForApp("app-guid")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(bindings).To(HaveLen(2))
+				Expect(bindings[0].ServiceInstanceGUID).To(Equal("service-instance-1-guid"))
+				Expect(bindings[0].Name).To(Equal("my-binding"))
+				Expect(bindings[1].ServiceInstanceGUID).To(Equal("service-instance-2-guid"))
+				Expect(bindings[1].Name).To(BeEmpty())
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v2/apps/app-guid/service_bindings"),
+					ghttp.RespondWith(http.StatusGatewayTimeout, nil),
+				))
+			})
+
+			It("returns an error", func() {
+				_, err := repo.ListAllForApp("app-guid")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 })
@@ -2,6 +2,10 @@ package api
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/api/resources"
 
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/models"
@@ -30,6 +34,7 @@ func (resource ServiceInstancesSummaries) ToModels() []models.ServiceInstance {
 		serviceOffering.Version = offeringSummary.Version
 
 		instance := models.ServiceInstance{}
+		instance.GUID = instanceSummary.GUID
 		instance.Name = instanceSummary.Name
 		instance.LastOperation.Type = instanceSummary.LastOperation.Type
 		instance.LastOperation.State = instanceSummary.LastOperation.State
@@ -57,7 +62,33 @@ func (resource ServiceInstancesSummaries) findApplicationNamesForInstance(instan
 	return applicationNames
 }
 
+// setBindingNames sets the BindingNames of each instance from its bindings,
+// matching each binding to one of the ApplicationNames by the GUID of the app.
+func (resource ServiceInstancesSummaries) setBindingNames(instances []models.ServiceInstance, bindings []models.ServiceBindingFields) {
+	appNames := map[string]string{}
+	for _, app := range resource.Apps {
+		appNames[app.GUID] = app.Name
+	}
+
+	for i := range instances {
+		instance := &instances[i]
+		instance.BindingNames = make([]string, len(instance.ApplicationNames))
+
+		for _, binding := range bindings {
+			if binding.ServiceInstanceGUID != instance.GUID || binding.Name == "" {
+				continue
+			}
+			for j, appName := range instance.ApplicationNames {
+				if appName == appNames[binding.AppGUID] {
+					instance.BindingNames[j] = binding.Name
+				}
+			}
+		}
+	}
+}
+
 type ServiceInstanceSummaryApp struct {
+	GUID         string
 	Name         string
 	ServiceNames []string `json:"service_names"`
 }
@@ -69,6 +100,7 @@ type LastOperationSummary struct {
 }
 
 type ServiceInstanceSummary struct {
+	GUID          string
 	Name          string
 	LastOperation LastOperationSummary `json:"last_operation"`
 	ServicePlan   ServicePlanSummary   `json:"service_plan"`
@@ -116,5 +148,37 @@ func (repo CloudControllerServiceSummaryRepository) GetSummariesInCurrentSpace()
 
 	instances = resource.ToModels()
 
+	boundInstanceGUIDs := []string{}
+	for _, instance := range instances {
+		if len(instance.ApplicationNames) > 0 {
+			boundInstanceGUIDs = append(boundInstanceGUIDs, instance.GUID)
+		}
+	}
+	if len(boundInstanceGUIDs) == 0 {
+		return instances, nil
+	}
+
+	bindings, err := repo.listBindings(boundInstanceGUIDs)
+	if err != nil {
+		return nil, err
+	}
+	resource.setBindingNames(instances, bindings)
+
 	return instances, nil
 }
+
+func (repo CloudControllerServiceSummaryRepository) listBindings(instanceGUIDs []string) ([]models.ServiceBindingFields, error) {
+	bindings := []models.ServiceBindingFields{}
+	err := repo.gateway.ListPaginatedResources(
+		repo.config.APIEndpoint(),
+		fmt.Sprintf("/v2/service_bindings?q=%s", url.QueryEscape("service_instance_guid IN "+strings.Join(instanceGUIDs, ","))),
+		resources.ServiceBindingResource{},
+		func(resource interface{}) bool {
+			if bindingResource, ok := resource.(resources.ServiceBindingResource); ok {
+				bindings = append(bindings, bindingResource.ToFields())
+			}
+			return true
+		},
+	)
+	return bindings, err
+}
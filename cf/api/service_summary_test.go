@@ -26,11 +26,13 @@ var _ = Describe("ServiceSummaryRepository", func() {
 			{
 			  "apps":[
 				{
+				  "guid":"app1-guid",
 				  "name":"app1",
 				  "service_names":[
 					"my-service-instance"
 				  ]
 				},{
+				  "guid":"app2-guid",
 				  "name":"app2",
 				  "service_names":[
 					"my-service-instance"
@@ -70,7 +72,7 @@ var _ = Describe("ServiceSummaryRepository", func() {
 			Response: serviceInstanceSummariesResponse,
 		})
 
-		ts, handler, repo := createServiceSummaryRepo(req)
+		ts, handler, repo := createServiceSummaryRepo(req, serviceBindingsRequest)
 		defer ts.Close()
 
 		serviceInstances, apiErr := repo.GetSummariesInCurrentSpace()
@@ -92,11 +94,48 @@ var _ = Describe("ServiceSummaryRepository", func() {
 		Expect(len(instance1.ApplicationNames)).To(Equal(2))
 		Expect(instance1.ApplicationNames[0]).To(Equal("app1"))
 		Expect(instance1.ApplicationNames[1]).To(Equal("app2"))
+		Expect(instance1.BindingNames).To(Equal([]string{"", "reports-db"}))
+	})
+
+	It("does not list the bindings when no app is bound", func() {
+		req := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+			Method: "GET",
+			Path:   "/v2/spaces/my-space-guid/summary",
+			Response: testnet.TestResponse{Status: http.StatusOK, Body: `{
+				"apps": [],
+				"services": [{"guid": "my-service-instance-guid", "name": "my-service-instance"}]
+			}`},
+		})
+
+		ts, handler, repo := createServiceSummaryRepo(req)
+		defer ts.Close()
+
+		serviceInstances, apiErr := repo.GetSummariesInCurrentSpace()
+		Expect(handler).To(HaveAllRequestsCalled())
+		Expect(apiErr).NotTo(HaveOccurred())
+		Expect(serviceInstances).To(HaveLen(1))
 	})
 })
 
-func createServiceSummaryRepo(req testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, repo ServiceSummaryRepository) {
-	ts, handler = testnet.NewServer([]testnet.TestRequest{req})
+var serviceBindingsRequest = apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+	Method: "GET",
+	Path:   "/v2/service_bindings?q=service_instance_guid+IN+my-service-instance-guid",
+	Response: testnet.TestResponse{Status: http.StatusOK, Body: `{
+		"resources": [
+			{
+				"metadata": {"guid": "binding-1-guid"},
+				"entity": {"app_guid": "app1-guid", "service_instance_guid": "my-service-instance-guid", "name": null}
+			},
+			{
+				"metadata": {"guid": "binding-2-guid"},
+				"entity": {"app_guid": "app2-guid", "service_instance_guid": "my-service-instance-guid", "name": "reports-db"}
+			}
+		]
+	}`},
+})
+
+func createServiceSummaryRepo(reqs ...testnet.TestRequest) (ts *httptest.Server, handler *testnet.TestHandler, repo ServiceSummaryRepository) {
+	ts, handler = testnet.NewServer(reqs)
 	configRepo := testconfig.NewRepositoryWithDefaults()
 	configRepo.SetAPIEndpoint(ts.URL)
 	gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
@@ -0,0 +1,88 @@
+package sidecars
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+)
+
+//go:generate counterfeiter . Repository
+
+// Repository lists and defines the sidecars of an app through the v3
+// sidecars API.
+type Repository interface {
+	ListSidecars(appGUID string) ([]models.Sidecar, error)
+	CreateSidecar(appGUID string, sidecar models.Sidecar) (models.Sidecar, error)
+	UpdateSidecar(sidecarGUID string, sidecar models.Sidecar) (models.Sidecar, error)
+}
+
+type CloudControllerRepository struct {
+	config  coreconfig.Reader
+	gateway net.Gateway
+}
+
+func NewCloudControllerRepository(config coreconfig.Reader, gateway net.Gateway) CloudControllerRepository {
+	return CloudControllerRepository{
+		config:  config,
+		gateway: gateway,
+	}
+}
+
+func (repo CloudControllerRepository) ListSidecars(appGUID string) ([]models.Sidecar, error) {
+	sidecars := []models.Sidecar{}
+
+	url := fmt.Sprintf("%s/v3/apps/%s/sidecars", repo.config.APIEndpoint(), appGUID)
+	for url != "" {
+		page := resources.PaginatedSidecarResources{}
+		err := repo.gateway.GetResource(url, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range page.Resources {
+			sidecars = append(sidecars, resource.ToModel())
+		}
+
+		url = ""
+		if page.Pagination.Next != nil {
+			url = page.Pagination.Next.Href
+		}
+	}
+
+	return sidecars, nil
+}
+
+func (repo CloudControllerRepository) CreateSidecar(appGUID string, sidecar models.Sidecar) (models.Sidecar, error) {
+	url := fmt.Sprintf("%s/v3/apps/%s/sidecars", repo.config.APIEndpoint(), appGUID)
+	return repo.performSidecarRequest("POST", url, sidecar)
+}
+
+func (repo CloudControllerRepository) UpdateSidecar(sidecarGUID string, sidecar models.Sidecar) (models.Sidecar, error) {
+	url := fmt.Sprintf("%s/v3/sidecars/%s", repo.config.APIEndpoint(), sidecarGUID)
+	return repo.performSidecarRequest("PATCH", url, sidecar)
+}
+
+func (repo CloudControllerRepository) performSidecarRequest(method string, url string, sidecar models.Sidecar) (models.Sidecar, error) {
+	body, err := json.Marshal(resources.NewSidecarResource(sidecar))
+	if err != nil {
+		return models.Sidecar{}, err
+	}
+
+	request, err := repo.gateway.NewRequest(method, url, repo.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return models.Sidecar{}, err
+	}
+
+	resource := resources.SidecarResource{}
+	_, err = repo.gateway.PerformRequestForJSONResponse(request, &resource)
+	if err != nil {
+		return models.Sidecar{}, err
+	}
+
+	return resource.ToModel(), nil
+}
@@ -0,0 +1,119 @@
+package sidecars_test
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+
+	"github.com/onsi/gomega/ghttp"
+
+	. "code.cloudfoundry.org/cli/cf/api/sidecars"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SidecarsRepo", func() {
+	var (
+		testServer *ghttp.Server
+		configRepo coreconfig.ReadWriter
+		repo       Repository
+	)
+
+	BeforeEach(func() {
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		configRepo.SetAccessToken("BEARER my_access_token")
+
+		gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		repo = NewCloudControllerRepository(configRepo, gateway)
+	})
+
+	BeforeEach(func() {
+		testServer = ghttp.NewServer()
+		configRepo.SetAPIEndpoint(testServer.URL())
+	})
+
+	AfterEach(func() {
+		if testServer != nil {
+			testServer.Close()
+		}
+	})
+
+	Describe("ListSidecars", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/sidecars"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": {
+							"next": { "href": "`+testServer.URL()+`/v3/apps/app-guid/sidecars?page=2" }
+						},
+						"resources": [
+							{ "guid": "proxy-guid", "name": "proxy", "command": "./proxy", "process_types": ["web"], "memory_in_mb": 64 }
+						]
+					}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/sidecars", "page=2"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": { "next": null },
+						"resources": [
+							{ "guid": "shipper-guid", "name": "shipper", "command": "./ship-logs", "process_types": ["web", "worker"] }
+						]
+					}`),
+				),
+			)
+		})
+
+		It("returns the sidecars from every page", func() {
+			sidecars, err := repo.ListSidecars("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sidecars).To(Equal([]models.Sidecar{
+				{GUID: "proxy-guid", Name: "proxy", Command: "./proxy", ProcessTypes: []string{"web"}, MemoryInMB: 64},
+				{GUID: "shipper-guid", Name: "shipper", Command: "./ship-logs", ProcessTypes: []string{"web", "worker"}},
+			}))
+		})
+	})
+
+	Describe("CreateSidecar", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/v3/apps/app-guid/sidecars"),
+					ghttp.VerifyJSON(`{ "name": "proxy", "command": "./proxy", "process_types": ["web"], "memory_in_mb": 64 }`),
+					ghttp.RespondWith(http.StatusCreated, `{ "guid": "proxy-guid", "name": "proxy", "command": "./proxy", "process_types": ["web"], "memory_in_mb": 64 }`),
+				),
+			)
+		})
+
+		It("creates the sidecar", func() {
+			sidecar, err := repo.CreateSidecar("app-guid", models.Sidecar{Name: "proxy", Command: "./proxy", ProcessTypes: []string{"web"}, MemoryInMB: 64})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sidecar.GUID).To(Equal("proxy-guid"))
+		})
+	})
+
+	Describe("UpdateSidecar", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PATCH", "/v3/sidecars/proxy-guid"),
+					ghttp.VerifyJSON(`{ "name": "proxy", "command": "./proxy --verbose", "process_types": ["web", "worker"] }`),
+					ghttp.RespondWith(http.StatusOK, `{ "guid": "proxy-guid", "name": "proxy", "command": "./proxy --verbose", "process_types": ["web", "worker"] }`),
+				),
+			)
+		})
+
+		It("updates the sidecar", func() {
+			sidecar, err := repo.UpdateSidecar("proxy-guid", models.Sidecar{Name: "proxy", Command: "./proxy --verbose", ProcessTypes: []string{"web", "worker"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sidecar.Command).To(Equal("./proxy --verbose"))
+		})
+	})
+})
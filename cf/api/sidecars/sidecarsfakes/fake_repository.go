@@ -0,0 +1,173 @@
+// This file was generated by counterfeiter
+package sidecarsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/api/sidecars"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type FakeRepository struct {
+	ListSidecarsStub        func(appGUID string) ([]models.Sidecar, error)
+	listSidecarsMutex       sync.RWMutex
+	listSidecarsArgsForCall []struct {
+		appGUID string
+	}
+	listSidecarsReturns struct {
+		result1 []models.Sidecar
+		result2 error
+	}
+	CreateSidecarStub        func(appGUID string, sidecar models.Sidecar) (models.Sidecar, error)
+	createSidecarMutex       sync.RWMutex
+	createSidecarArgsForCall []struct {
+		appGUID string
+		sidecar models.Sidecar
+	}
+	createSidecarReturns struct {
+		result1 models.Sidecar
+		result2 error
+	}
+	UpdateSidecarStub        func(sidecarGUID string, sidecar models.Sidecar) (models.Sidecar, error)
+	updateSidecarMutex       sync.RWMutex
+	updateSidecarArgsForCall []struct {
+		sidecarGUID string
+		sidecar     models.Sidecar
+	}
+	updateSidecarReturns struct {
+		result1 models.Sidecar
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRepository) ListSidecars(appGUID string) ([]models.Sidecar, error) {
+	fake.listSidecarsMutex.Lock()
+	fake.listSidecarsArgsForCall = append(fake.listSidecarsArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("ListSidecars", []interface{}{appGUID})
+	fake.listSidecarsMutex.Unlock()
+	if fake.ListSidecarsStub != nil {
+		return fake.ListSidecarsStub(appGUID)
+	} else {
+		return fake.listSidecarsReturns.result1, fake.listSidecarsReturns.result2
+	}
+}
+
+func (fake *FakeRepository) ListSidecarsCallCount() int {
+	fake.listSidecarsMutex.RLock()
+	defer fake.listSidecarsMutex.RUnlock()
+	return len(fake.listSidecarsArgsForCall)
+}
+
+func (fake *FakeRepository) ListSidecarsArgsForCall(i int) string {
+	fake.listSidecarsMutex.RLock()
+	defer fake.listSidecarsMutex.RUnlock()
+	return fake.listSidecarsArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) ListSidecarsReturns(result1 []models.Sidecar, result2 error) {
+	fake.ListSidecarsStub = nil
+	fake.listSidecarsReturns = struct {
+		result1 []models.Sidecar
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) CreateSidecar(appGUID string, sidecar models.Sidecar) (models.Sidecar, error) {
+	fake.createSidecarMutex.Lock()
+	fake.createSidecarArgsForCall = append(fake.createSidecarArgsForCall, struct {
+		appGUID string
+		sidecar models.Sidecar
+	}{appGUID, sidecar})
+	fake.recordInvocation("CreateSidecar", []interface{}{appGUID, sidecar})
+	fake.createSidecarMutex.Unlock()
+	if fake.CreateSidecarStub != nil {
+		return fake.CreateSidecarStub(appGUID, sidecar)
+	} else {
+		return fake.createSidecarReturns.result1, fake.createSidecarReturns.result2
+	}
+}
+
+func (fake *FakeRepository) CreateSidecarCallCount() int {
+	fake.createSidecarMutex.RLock()
+	defer fake.createSidecarMutex.RUnlock()
+	return len(fake.createSidecarArgsForCall)
+}
+
+func (fake *FakeRepository) CreateSidecarArgsForCall(i int) (string, models.Sidecar) {
+	fake.createSidecarMutex.RLock()
+	defer fake.createSidecarMutex.RUnlock()
+	return fake.createSidecarArgsForCall[i].appGUID, fake.createSidecarArgsForCall[i].sidecar
+}
+
+func (fake *FakeRepository) CreateSidecarReturns(result1 models.Sidecar, result2 error) {
+	fake.CreateSidecarStub = nil
+	fake.createSidecarReturns = struct {
+		result1 models.Sidecar
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) UpdateSidecar(sidecarGUID string, sidecar models.Sidecar) (models.Sidecar, error) {
+	fake.updateSidecarMutex.Lock()
+	fake.updateSidecarArgsForCall = append(fake.updateSidecarArgsForCall, struct {
+		sidecarGUID string
+		sidecar     models.Sidecar
+	}{sidecarGUID, sidecar})
+	fake.recordInvocation("UpdateSidecar", []interface{}{sidecarGUID, sidecar})
+	fake.updateSidecarMutex.Unlock()
+	if fake.UpdateSidecarStub != nil {
+		return fake.UpdateSidecarStub(sidecarGUID, sidecar)
+	} else {
+		return fake.updateSidecarReturns.result1, fake.updateSidecarReturns.result2
+	}
+}
+
+func (fake *FakeRepository) UpdateSidecarCallCount() int {
+	fake.updateSidecarMutex.RLock()
+	defer fake.updateSidecarMutex.RUnlock()
+	return len(fake.updateSidecarArgsForCall)
+}
+
+func (fake *FakeRepository) UpdateSidecarArgsForCall(i int) (string, models.Sidecar) {
+	fake.updateSidecarMutex.RLock()
+	defer fake.updateSidecarMutex.RUnlock()
+	return fake.updateSidecarArgsForCall[i].sidecarGUID, fake.updateSidecarArgsForCall[i].sidecar
+}
+
+func (fake *FakeRepository) UpdateSidecarReturns(result1 models.Sidecar, result2 error) {
+	fake.UpdateSidecarStub = nil
+	fake.updateSidecarReturns = struct {
+		result1 models.Sidecar
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listSidecarsMutex.RLock()
+	defer fake.listSidecarsMutex.RUnlock()
+	fake.createSidecarMutex.RLock()
+	defer fake.createSidecarMutex.RUnlock()
+	fake.updateSidecarMutex.RLock()
+	defer fake.updateSidecarMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ sidecars.Repository = new(FakeRepository)
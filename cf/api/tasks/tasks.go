@@ -0,0 +1,109 @@
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+)
+
+//go:generate counterfeiter . Repository
+
+// Repository runs and cancels the tasks of an app through the v3 tasks API.
+type Repository interface {
+	CreateTask(appGUID string, params models.TaskParams) (models.Task, error)
+	ListTasks(appGUID string) ([]models.Task, error)
+	FindTaskBySequenceID(appGUID string, sequenceID int) (models.Task, error)
+	CancelTask(taskGUID string) (models.Task, error)
+}
+
+type CloudControllerRepository struct {
+	config  coreconfig.Reader
+	gateway net.Gateway
+}
+
+func NewCloudControllerRepository(config coreconfig.Reader, gateway net.Gateway) CloudControllerRepository {
+	return CloudControllerRepository{
+		config:  config,
+		gateway: gateway,
+	}
+}
+
+func (repo CloudControllerRepository) CreateTask(appGUID string, params models.TaskParams) (models.Task, error) {
+	body, err := json.Marshal(resources.NewTaskRequest(params))
+	if err != nil {
+		return models.Task{}, err
+	}
+
+	url := fmt.Sprintf("%s/v3/apps/%s/tasks", repo.config.APIEndpoint(), appGUID)
+	return repo.performTaskRequest("POST", url, body)
+}
+
+// ListTasks returns the tasks of the app, newest first.
+func (repo CloudControllerRepository) ListTasks(appGUID string) ([]models.Task, error) {
+	url := fmt.Sprintf("%s/v3/apps/%s/tasks?order_by=-created_at", repo.config.APIEndpoint(), appGUID)
+	return repo.listTasks(url)
+}
+
+func (repo CloudControllerRepository) FindTaskBySequenceID(appGUID string, sequenceID int) (models.Task, error) {
+	url := fmt.Sprintf("%s/v3/apps/%s/tasks?sequence_ids=%d", repo.config.APIEndpoint(), appGUID, sequenceID)
+	tasks, err := repo.listTasks(url)
+	if err != nil {
+		return models.Task{}, err
+	}
+
+	if len(tasks) == 0 {
+		return models.Task{}, errors.NewModelNotFoundError("Task", strconv.Itoa(sequenceID))
+	}
+
+	return tasks[0], nil
+}
+
+func (repo CloudControllerRepository) CancelTask(taskGUID string) (models.Task, error) {
+	url := fmt.Sprintf("%s/v3/tasks/%s/cancel", repo.config.APIEndpoint(), taskGUID)
+	return repo.performTaskRequest("PUT", url, nil)
+}
+
+func (repo CloudControllerRepository) listTasks(url string) ([]models.Task, error) {
+	tasks := []models.Task{}
+
+	for url != "" {
+		page := resources.PaginatedTaskResources{}
+		err := repo.gateway.GetResource(url, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range page.Resources {
+			tasks = append(tasks, resource.ToModel())
+		}
+
+		url = ""
+		if page.Pagination.Next != nil {
+			url = page.Pagination.Next.Href
+		}
+	}
+
+	return tasks, nil
+}
+
+func (repo CloudControllerRepository) performTaskRequest(method string, url string, body []byte) (models.Task, error) {
+	request, err := repo.gateway.NewRequest(method, url, repo.config.AccessToken(), bytes.NewReader(body))
+	if err != nil {
+		return models.Task{}, err
+	}
+
+	resource := resources.TaskResource{}
+	_, err = repo.gateway.PerformRequestForJSONResponse(request, &resource)
+	if err != nil {
+		return models.Task{}, err
+	}
+
+	return resource.ToModel(), nil
+}
@@ -0,0 +1,199 @@
+package tasks_test
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+
+	"github.com/onsi/gomega/ghttp"
+
+	. "code.cloudfoundry.org/cli/cf/api/tasks"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TasksRepo", func() {
+	var (
+		testServer *ghttp.Server
+		configRepo coreconfig.ReadWriter
+		repo       Repository
+	)
+
+	BeforeEach(func() {
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		configRepo.SetAccessToken("BEARER my_access_token")
+
+		gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+		repo = NewCloudControllerRepository(configRepo, gateway)
+	})
+
+	BeforeEach(func() {
+		testServer = ghttp.NewServer()
+		configRepo.SetAPIEndpoint(testServer.URL())
+	})
+
+	AfterEach(func() {
+		if testServer != nil {
+			testServer.Close()
+		}
+	})
+
+	Describe("CreateTask", func() {
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				testServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v3/apps/app-guid/tasks"),
+						ghttp.VerifyJSON(`{ "name": "migrate", "command": "rake db:migrate", "memory_in_mb": 256 }`),
+						ghttp.RespondWith(http.StatusAccepted, `{
+							"guid": "task-guid",
+							"sequence_id": 3,
+							"name": "migrate",
+							"command": "rake db:migrate",
+							"state": "RUNNING",
+							"memory_in_mb": 256,
+							"disk_in_mb": 1024,
+							"created_at": "2016-11-02T10:00:00Z"
+						}`),
+					),
+				)
+			})
+
+			It("returns the task", func() {
+				task, err := repo.CreateTask("app-guid", models.TaskParams{
+					Name:       "migrate",
+					Command:    "rake db:migrate",
+					MemoryInMB: 256,
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(task).To(Equal(models.Task{
+					GUID:       "task-guid",
+					SequenceID: 3,
+					Name:       "migrate",
+					Command:    "rake db:migrate",
+					State:      "RUNNING",
+					MemoryInMB: 256,
+					DiskInMB:   1024,
+					CreatedAt:  time.Date(2016, 11, 2, 10, 0, 0, 0, time.UTC),
+				}))
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				testServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("POST", "/v3/apps/app-guid/tasks"),
+						ghttp.RespondWith(http.StatusUnprocessableEntity, `{
+							"errors": [
+								{ "code": 10008, "title": "CF-UnprocessableEntity", "detail": "Task must have a droplet. Specify droplet or assign current droplet to app." }
+							]
+						}`),
+					),
+				)
+			})
+
+			It("returns the detail of the v3 error", func() {
+				_, err := repo.CreateTask("app-guid", models.TaskParams{Command: "rake db:migrate"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("Task must have a droplet"))
+				Expect(err.(errors.HTTPError).ErrorCode()).To(Equal("10008"))
+			})
+		})
+	})
+
+	Describe("ListTasks", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/tasks", "order_by=-created_at"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": {
+							"next": { "href": "`+testServer.URL()+`/v3/apps/app-guid/tasks?order_by=-created_at&page=2" }
+						},
+						"resources": [
+							{ "guid": "task-2-guid", "sequence_id": 2, "name": "seed", "command": "rake db:seed", "state": "RUNNING" }
+						]
+					}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/tasks", "order_by=-created_at&page=2"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": { "next": null },
+						"resources": [
+							{ "guid": "task-1-guid", "sequence_id": 1, "name": "migrate", "command": "rake db:migrate", "state": "SUCCEEDED" }
+						]
+					}`),
+				),
+			)
+		})
+
+		It("returns the tasks from every page", func() {
+			tasks, err := repo.ListTasks("app-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(testServer.ReceivedRequests()).To(HaveLen(2))
+
+			Expect(tasks).To(Equal([]models.Task{
+				{GUID: "task-2-guid", SequenceID: 2, Name: "seed", Command: "rake db:seed", State: "RUNNING"},
+				{GUID: "task-1-guid", SequenceID: 1, Name: "migrate", Command: "rake db:migrate", State: "SUCCEEDED"},
+			}))
+		})
+	})
+
+	Describe("FindTaskBySequenceID", func() {
+		It("returns the task with the sequence id", func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/tasks", "sequence_ids=2"),
+					ghttp.RespondWith(http.StatusOK, `{
+						"pagination": { "next": null },
+						"resources": [
+							{ "guid": "task-2-guid", "sequence_id": 2, "name": "seed", "state": "RUNNING" }
+						]
+					}`),
+				),
+			)
+
+			task, err := repo.FindTaskBySequenceID("app-guid", 2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.GUID).To(Equal("task-2-guid"))
+		})
+
+		It("returns a not found error when the app has no such task", func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/v3/apps/app-guid/tasks", "sequence_ids=5"),
+					ghttp.RespondWith(http.StatusOK, `{ "pagination": { "next": null }, "resources": [] }`),
+				),
+			)
+
+			_, err := repo.FindTaskBySequenceID("app-guid", 5)
+			Expect(err).To(BeAssignableToTypeOf(&errors.ModelNotFoundError{}))
+		})
+	})
+
+	Describe("CancelTask", func() {
+		BeforeEach(func() {
+			testServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v3/tasks/task-guid/cancel"),
+					ghttp.RespondWith(http.StatusAccepted, `{ "guid": "task-guid", "sequence_id": 3, "state": "CANCELING" }`),
+				),
+			)
+		})
+
+		It("returns the canceling task", func() {
+			task, err := repo.CancelTask("task-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.State).To(Equal("CANCELING"))
+		})
+	})
+})
@@ -0,0 +1,218 @@
+// This file was generated by counterfeiter
+package tasksfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/api/tasks"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+type FakeRepository struct {
+	CreateTaskStub        func(appGUID string, params models.TaskParams) (models.Task, error)
+	createTaskMutex       sync.RWMutex
+	createTaskArgsForCall []struct {
+		appGUID string
+		params  models.TaskParams
+	}
+	createTaskReturns struct {
+		result1 models.Task
+		result2 error
+	}
+	ListTasksStub        func(appGUID string) ([]models.Task, error)
+	listTasksMutex       sync.RWMutex
+	listTasksArgsForCall []struct {
+		appGUID string
+	}
+	listTasksReturns struct {
+		result1 []models.Task
+		result2 error
+	}
+	FindTaskBySequenceIDStub        func(appGUID string, sequenceID int) (models.Task, error)
+	findTaskBySequenceIDMutex       sync.RWMutex
+	findTaskBySequenceIDArgsForCall []struct {
+		appGUID    string
+		sequenceID int
+	}
+	findTaskBySequenceIDReturns struct {
+		result1 models.Task
+		result2 error
+	}
+	CancelTaskStub        func(taskGUID string) (models.Task, error)
+	cancelTaskMutex       sync.RWMutex
+	cancelTaskArgsForCall []struct {
+		taskGUID string
+	}
+	cancelTaskReturns struct {
+		result1 models.Task
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRepository) CreateTask(appGUID string, params models.TaskParams) (models.Task, error) {
+	fake.createTaskMutex.Lock()
+	fake.createTaskArgsForCall = append(fake.createTaskArgsForCall, struct {
+		appGUID string
+		params  models.TaskParams
+	}{appGUID, params})
+	fake.recordInvocation("CreateTask", []interface{}{appGUID, params})
+	fake.createTaskMutex.Unlock()
+	if fake.CreateTaskStub != nil {
+		return fake.CreateTaskStub(appGUID, params)
+	} else {
+		return fake.createTaskReturns.result1, fake.createTaskReturns.result2
+	}
+}
+
+func (fake *FakeRepository) CreateTaskCallCount() int {
+	fake.createTaskMutex.RLock()
+	defer fake.createTaskMutex.RUnlock()
+	return len(fake.createTaskArgsForCall)
+}
+
+func (fake *FakeRepository) CreateTaskArgsForCall(i int) (string, models.TaskParams) {
+	fake.createTaskMutex.RLock()
+	defer fake.createTaskMutex.RUnlock()
+	return fake.createTaskArgsForCall[i].appGUID, fake.createTaskArgsForCall[i].params
+}
+
+func (fake *FakeRepository) CreateTaskReturns(result1 models.Task, result2 error) {
+	fake.CreateTaskStub = nil
+	fake.createTaskReturns = struct {
+		result1 models.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) ListTasks(appGUID string) ([]models.Task, error) {
+	fake.listTasksMutex.Lock()
+	fake.listTasksArgsForCall = append(fake.listTasksArgsForCall, struct {
+		appGUID string
+	}{appGUID})
+	fake.recordInvocation("ListTasks", []interface{}{appGUID})
+	fake.listTasksMutex.Unlock()
+	if fake.ListTasksStub != nil {
+		return fake.ListTasksStub(appGUID)
+	} else {
+		return fake.listTasksReturns.result1, fake.listTasksReturns.result2
+	}
+}
+
+func (fake *FakeRepository) ListTasksCallCount() int {
+	fake.listTasksMutex.RLock()
+	defer fake.listTasksMutex.RUnlock()
+	return len(fake.listTasksArgsForCall)
+}
+
+func (fake *FakeRepository) ListTasksArgsForCall(i int) string {
+	fake.listTasksMutex.RLock()
+	defer fake.listTasksMutex.RUnlock()
+	return fake.listTasksArgsForCall[i].appGUID
+}
+
+func (fake *FakeRepository) ListTasksReturns(result1 []models.Task, result2 error) {
+	fake.ListTasksStub = nil
+	fake.listTasksReturns = struct {
+		result1 []models.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) FindTaskBySequenceID(appGUID string, sequenceID int) (models.Task, error) {
+	fake.findTaskBySequenceIDMutex.Lock()
+	fake.findTaskBySequenceIDArgsForCall = append(fake.findTaskBySequenceIDArgsForCall, struct {
+		appGUID    string
+		sequenceID int
+	}{appGUID, sequenceID})
+	fake.recordInvocation("FindTaskBySequenceID", []interface{}{appGUID, sequenceID})
+	fake.findTaskBySequenceIDMutex.Unlock()
+	if fake.FindTaskBySequenceIDStub != nil {
+		return fake.FindTaskBySequenceIDStub(appGUID, sequenceID)
+	} else {
+		return fake.findTaskBySequenceIDReturns.result1, fake.findTaskBySequenceIDReturns.result2
+	}
+}
+
+func (fake *FakeRepository) FindTaskBySequenceIDCallCount() int {
+	fake.findTaskBySequenceIDMutex.RLock()
+	defer fake.findTaskBySequenceIDMutex.RUnlock()
+	return len(fake.findTaskBySequenceIDArgsForCall)
+}
+
+func (fake *FakeRepository) FindTaskBySequenceIDArgsForCall(i int) (string, int) {
+	fake.findTaskBySequenceIDMutex.RLock()
+	defer fake.findTaskBySequenceIDMutex.RUnlock()
+	return fake.findTaskBySequenceIDArgsForCall[i].appGUID, fake.findTaskBySequenceIDArgsForCall[i].sequenceID
+}
+
+func (fake *FakeRepository) FindTaskBySequenceIDReturns(result1 models.Task, result2 error) {
+	fake.FindTaskBySequenceIDStub = nil
+	fake.findTaskBySequenceIDReturns = struct {
+		result1 models.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) CancelTask(taskGUID string) (models.Task, error) {
+	fake.cancelTaskMutex.Lock()
+	fake.cancelTaskArgsForCall = append(fake.cancelTaskArgsForCall, struct {
+		taskGUID string
+	}{taskGUID})
+	fake.recordInvocation("CancelTask", []interface{}{taskGUID})
+	fake.cancelTaskMutex.Unlock()
+	if fake.CancelTaskStub != nil {
+		return fake.CancelTaskStub(taskGUID)
+	} else {
+		return fake.cancelTaskReturns.result1, fake.cancelTaskReturns.result2
+	}
+}
+
+func (fake *FakeRepository) CancelTaskCallCount() int {
+	fake.cancelTaskMutex.RLock()
+	defer fake.cancelTaskMutex.RUnlock()
+	return len(fake.cancelTaskArgsForCall)
+}
+
+func (fake *FakeRepository) CancelTaskArgsForCall(i int) string {
+	fake.cancelTaskMutex.RLock()
+	defer fake.cancelTaskMutex.RUnlock()
+	return fake.cancelTaskArgsForCall[i].taskGUID
+}
+
+func (fake *FakeRepository) CancelTaskReturns(result1 models.Task, result2 error) {
+	fake.CancelTaskStub = nil
+	fake.cancelTaskReturns = struct {
+		result1 models.Task
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createTaskMutex.RLock()
+	defer fake.createTaskMutex.RUnlock()
+	fake.listTasksMutex.RLock()
+	defer fake.listTasksMutex.RUnlock()
+	fake.findTaskBySequenceIDMutex.RLock()
+	defer fake.findTaskBySequenceIDMutex.RUnlock()
+	fake.cancelTaskMutex.RLock()
+	defer fake.cancelTaskMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ tasks.Repository = new(FakeRepository)
@@ -15,6 +15,7 @@ import (
 type UserProvidedServiceInstanceRepository interface {
 	Create(name, drainURL string, routeServiceURL string, params map[string]interface{}) (apiErr error)
 	Update(serviceInstanceFields models.ServiceInstanceFields) (apiErr error)
+	Get(guid string) (models.UserProvidedService, error)
 	GetSummaries() (models.UserProvidedServiceSummary, error)
 }
 
@@ -65,6 +66,19 @@ func (repo CCUserProvidedServiceInstanceRepository) Update(serviceInstanceFields
 	return repo.gateway.UpdateResource(repo.config.APIEndpoint(), path, bytes.NewReader(jsonBytes))
 }
 
+func (repo CCUserProvidedServiceInstanceRepository) Get(guid string) (models.UserProvidedService, error) {
+	path := fmt.Sprintf("%s/v2/user_provided_service_instances/%s", repo.config.APIEndpoint(), guid)
+
+	model := models.UserProvidedServiceEntity{}
+
+	err := repo.gateway.GetResource(path, &model)
+	if err != nil {
+		return models.UserProvidedService{}, err
+	}
+
+	return model.UserProvidedService, nil
+}
+
 func (repo CCUserProvidedServiceInstanceRepository) GetSummaries() (models.UserProvidedServiceSummary, error) {
 	path := fmt.Sprintf("%s/v2/user_provided_service_instances", repo.config.APIEndpoint())
 
@@ -112,6 +112,44 @@ var _ = Describe("UserProvidedServiceRepository", func() {
 		})
 	})
 
+	Context("Get()", func() {
+		It("returns the user provided service with its credentials", func() {
+			req := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+				Method: "GET",
+				Path:   "/v2/user_provided_service_instances/my-instance-guid",
+				Response: testnet.TestResponse{Status: http.StatusOK, Body: `
+{
+   "metadata": {
+      "guid": "my-instance-guid",
+      "url": "/v2/user_provided_service_instances/my-instance-guid"
+   },
+   "entity": {
+      "name": "my-custom-service",
+      "credentials": {
+         "user": "me",
+         "db": {"host": "example.com"}
+      },
+      "syslog_drain_url": "syslog://example.com",
+      "route_service_url": ""
+   }
+}`},
+			})
+
+			ts, handler, repo := createUserProvidedServiceInstanceRepo([]testnet.TestRequest{req})
+			defer ts.Close()
+
+			service, err := repo.Get("my-instance-guid")
+			Expect(handler).To(HaveAllRequestsCalled())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(service.Name).To(Equal("my-custom-service"))
+			Expect(service.SysLogDrainURL).To(Equal("syslog://example.com"))
+			Expect(service.Credentials).To(Equal(map[string]interface{}{
+				"user": "me",
+				"db":   map[string]interface{}{"host": "example.com"},
+			}))
+		})
+	})
+
 	Context("GetSummaries()", func() {
 		It("returns all user created service in []models.UserProvidedService", func() {
 			responseStr := testnet.TestResponse{Status: http.StatusOK, Body: `
@@ -45,6 +45,8 @@ type UserRepository interface {
 	ListUsersInSpaceForRole(spaceGUID string, role models.Role) ([]models.UserFields, error)
 	ListUsersInSpaceForRoleWithNoUAA(spaceGUID string, role models.Role) ([]models.UserFields, error)
 	Create(username, password string) (apiErr error)
+	CreateWithOrigin(username, password, origin, externalID string) (apiErr error)
+	CreateClientUser(clientID string) (apiErr error)
 	Delete(userGUID string) (apiErr error)
 	SetOrgRoleByGUID(userGUID, orgGUID string, role models.Role) (apiErr error)
 	SetOrgRoleByUsername(username, orgGUID string, role models.Role) (apiErr error)
@@ -155,7 +157,7 @@ func (repo CloudControllerUserRepository) listUsersWithPath(path string) (users
 	}
 
 	filter := strings.Join(guidFilters, " or ")
-	usersURL := fmt.Sprintf("%s/Users?attributes=id,userName&filter=%s", uaaEndpoint, neturl.QueryEscape(filter))
+	usersURL := fmt.Sprintf("%s/Users?attributes=id,userName,origin&filter=%s", uaaEndpoint, neturl.QueryEscape(filter))
 	users, apiErr = repo.updateOrFindUsersWithUAAPath(users, usersURL)
 	return
 }
@@ -180,6 +182,7 @@ func (repo CloudControllerUserRepository) updateOrFindUsersWithUAAPath(ccUsers [
 		updatedUsers = append(updatedUsers, models.UserFields{
 			GUID:     uaaResource.ID,
 			Username: uaaResource.Username,
+			Origin:   uaaResource.Origin,
 			IsAdmin:  ccUserFields.IsAdmin,
 		})
 	}
@@ -187,13 +190,24 @@ func (repo CloudControllerUserRepository) updateOrFindUsersWithUAAPath(ccUsers [
 }
 
 func (repo CloudControllerUserRepository) Create(username, password string) (err error) {
+	return repo.CreateWithOrigin(username, password, "", "")
+}
+
+// CreateWithOrigin creates a user that signs in through the identity provider
+// origin, such as ldap. Users from other origins than uaa have no password in
+// UAA and are matched to their external account by externalID.
+func (repo CloudControllerUserRepository) CreateWithOrigin(username, password, origin, externalID string) (err error) {
 	uaaEndpoint, err := repo.getAuthEndpoint()
 	if err != nil {
 		return
 	}
 
+	uaaUser := resources.NewUAAUserResource(username, password)
+	uaaUser.Origin = origin
+	uaaUser.ExternalID = externalID
+
 	path := "/Users"
-	body, err := json.Marshal(resources.NewUAAUserResource(username, password))
+	body, err := json.Marshal(uaaUser)
 
 	if err != nil {
 		return
@@ -225,6 +239,24 @@ func (repo CloudControllerUserRepository) Create(username, password string) (err
 	return repo.ccGateway.CreateResource(repo.config.APIEndpoint(), path, bytes.NewReader(body))
 }
 
+// CreateClientUser makes sure the Cloud Controller knows the UAA client with
+// the given ID as a user, so that roles can be assigned to it. A client that
+// is already known is left as it is.
+func (repo CloudControllerUserRepository) CreateClientUser(clientID string) error {
+	body, err := json.Marshal(resources.Metadata{
+		GUID: clientID,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = repo.ccGateway.CreateResource(repo.config.APIEndpoint(), "/v2/users", bytes.NewReader(body))
+	if httpErr, ok := err.(errors.HTTPError); ok && httpErr.ErrorCode() == errors.UaaIDTaken {
+		return nil
+	}
+	return err
+}
+
 func (repo CloudControllerUserRepository) Delete(userGUID string) (apiErr error) {
 	path := fmt.Sprintf("/v2/users/%s", userGUID)
 
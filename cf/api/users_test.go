@@ -97,13 +97,13 @@ var _ = Describe("UserRepository", func() {
 
 				uaaServer.AppendHandlers(
 					ghttp.CombineHandlers(
-						ghttp.VerifyRequest("GET", "/Users", fmt.Sprintf("attributes=id,userName&filter=%s", url.QueryEscape(`ID eq "user-1-guid"`))),
+						ghttp.VerifyRequest("GET", "/Users", fmt.Sprintf("attributes=id,userName,origin&filter=%s", url.QueryEscape(`ID eq "user-1-guid"`))),
 						ghttp.VerifyHeader(http.Header{
 							"accept": []string{"application/json"},
 						}),
 						ghttp.RespondWith(http.StatusOK, `{
 							"resources": [
-							{ "id": "user-1-guid", "userName": "Super user 1" }
+							{ "id": "user-1-guid", "userName": "Super user 1", "origin": "ldap" }
 							]}`),
 					),
 				)
@@ -128,6 +128,7 @@ var _ = Describe("UserRepository", func() {
 				Expect(len(users)).To(Equal(1))
 				Expect(users[0].GUID).To(Equal("user-1-guid"))
 				Expect(users[0].Username).To(Equal("Super user 1"))
+				Expect(users[0].Origin).To(Equal("ldap"))
 			})
 		})
 
@@ -160,7 +161,7 @@ var _ = Describe("UserRepository", func() {
 
 				uaaServer.AppendHandlers(
 					ghttp.CombineHandlers(
-						ghttp.VerifyRequest("GET", "/Users", fmt.Sprintf("attributes=id,userName&filter=%s", url.QueryEscape(`ID eq "user-1-guid" or ID eq "user-2-guid" or ID eq "user-3-guid"`))),
+						ghttp.VerifyRequest("GET", "/Users", fmt.Sprintf("attributes=id,userName,origin&filter=%s", url.QueryEscape(`ID eq "user-1-guid" or ID eq "user-2-guid" or ID eq "user-3-guid"`))),
 						ghttp.VerifyHeader(http.Header{
 							"accept": []string{"application/json"},
 						}),
@@ -423,7 +424,7 @@ var _ = Describe("UserRepository", func() {
 				config.SetUaaEndpoint(uaaServer.URL())
 				uaaServer.AppendHandlers(
 					ghttp.CombineHandlers(
-						ghttp.VerifyRequest("GET", "/Users", fmt.Sprintf("attributes=id,userName&filter=%s", url.QueryEscape(`ID eq "user-1-guid"`))),
+						ghttp.VerifyRequest("GET", "/Users", fmt.Sprintf("attributes=id,userName,origin&filter=%s", url.QueryEscape(`ID eq "user-1-guid"`))),
 						ghttp.VerifyHeader(http.Header{
 							"accept": []string{"application/json"},
 						}),
@@ -869,6 +870,78 @@ var _ = Describe("UserRepository", func() {
 		})
 	})
 
+	Describe("CreateClientUser", func() {
+		It("creates a CC user with the client ID as its GUID", func() {
+			ccServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/v2/users"),
+					ghttp.VerifyJSON(`{"guid":"my-client-id"}`),
+					ghttp.RespondWith(http.StatusCreated, `{"metadata":{"guid":"my-client-id"}}`),
+				),
+			)
+
+			err := client.CreateClientUser("my-client-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(1))
+			Expect(uaaServer.ReceivedRequests()).To(BeZero())
+		})
+
+		It("succeeds when CC already knows the client", func() {
+			ccServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/v2/users"),
+					ghttp.RespondWith(http.StatusBadRequest, `{"code":20002,"description":"The UAA ID is taken: my-client-id","error_code":"CF-UaaIdTaken"}`),
+				),
+			)
+
+			Expect(client.CreateClientUser("my-client-id")).To(Succeed())
+		})
+
+		It("returns any other error", func() {
+			ccServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/v2/users"),
+					ghttp.RespondWith(http.StatusForbidden, `{"code":10003,"description":"You are not authorized to perform the requested action","error_code":"CF-NotAuthorized"}`),
+				),
+			)
+
+			err := client.CreateClientUser("my-client-id")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("You are not authorized"))
+		})
+	})
+
+	Describe("CreateWithOrigin", func() {
+		It("creates a UAA user with the origin and external ID and no password", func() {
+			uaaServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/Users"),
+					ghttp.VerifyJSON(`{
+						"userName":"my-user",
+						"emails":[{"value":"my-user"}],
+						"name":{
+							"givenName":"my-user",
+							"familyName":"my-user"},
+						"origin":"ldap",
+						"externalId":"uid=my-user,dc=example,dc=com"
+					}`),
+					ghttp.RespondWith(http.StatusOK, `{"id":"my-user-guid"}`),
+				),
+			)
+			ccServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/v2/users"),
+					ghttp.VerifyJSON(`{"guid":"my-user-guid"}`),
+				),
+			)
+
+			err := client.CreateWithOrigin("my-user", "", "ldap", "uid=my-user,dc=example,dc=com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uaaServer.ReceivedRequests()).To(HaveLen(1))
+			Expect(ccServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
 	Describe("Delete", func() {
 		Context("when the user is found in CC", func() {
 			BeforeEach(func() {
@@ -1634,7 +1707,7 @@ var _ = Describe("UserRepository", func() {
 
 				uaaServer.AppendHandlers(
 					ghttp.CombineHandlers(
-						ghttp.VerifyRequest("GET", "/Users", fmt.Sprintf("attributes=id,userName&filter=%s", url.QueryEscape(`ID eq "user-1-guid"`))),
+						ghttp.VerifyRequest("GET", "/Users", fmt.Sprintf("attributes=id,userName,origin&filter=%s", url.QueryEscape(`ID eq "user-1-guid"`))),
 						ghttp.VerifyHeader(http.Header{
 							"accept": []string{"application/json"},
 						}),
@@ -1698,7 +1771,7 @@ var _ = Describe("UserRepository", func() {
 
 				uaaServer.AppendHandlers(
 					ghttp.CombineHandlers(
-						ghttp.VerifyRequest("GET", "/Users", fmt.Sprintf("attributes=id,userName&filter=%s", url.QueryEscape(`ID eq "user-1-guid" or ID eq "user-2-guid" or ID eq "user-3-guid"`))),
+						ghttp.VerifyRequest("GET", "/Users", fmt.Sprintf("attributes=id,userName,origin&filter=%s", url.QueryEscape(`ID eq "user-1-guid" or ID eq "user-2-guid" or ID eq "user-3-guid"`))),
 						ghttp.VerifyHeader(http.Header{
 							"accept": []string{"application/json"},
 						}),
@@ -3,6 +3,22 @@ package cf
 import "github.com/blang/semver"
 
 var (
+	CNBLifecycleMinimumAPIVersion, _                    = semver.Make("2.230.0")
+	CanaryDeploymentsMinimumAPIVersion, _               = semver.Make("2.210.0")
+	ReadinessHealthChecksMinimumAPIVersion, _           = semver.Make("2.200.0")
+	RevisionsMinimumAPIVersion, _                       = semver.Make("2.145.0")
+	AppFeaturesMinimumAPIVersion, _                     = semver.Make("2.140.0")
+	SidecarsMinimumAPIVersion, _                        = semver.Make("2.135.0")
+	MetadataMinimumAPIVersion, _                        = semver.Make("2.133.0")
+	DeploymentsMinimumAPIVersion, _                     = semver.Make("2.131.0")
+	BuildsMinimumAPIVersion, _                          = semver.Make("2.90.0")
+	MultipleBuildpacksMinimumAPIVersion, _              = semver.Make("2.90.0")
+	DropletUploadMinimumAPIVersion, _                   = semver.Make("2.84.0")
+	DockerCredentialsMinimumAPIVersion, _               = semver.Make("2.82.0")
+	DropletHistoryMinimumAPIVersion, _                  = semver.Make("2.75.0")
+	PackagesMinimumAPIVersion, _                        = semver.Make("2.75.0")
+	TasksMinimumAPIVersion, _                           = semver.Make("2.75.0")
+	ProcessTypesMinimumAPIVersion, _                    = semver.Make("2.75.0")
 	ReservedRoutePortsMinimumAPIVersion, _              = semver.Make("2.55.0") // #112023051
 	TCPRoutingMinimumAPIVersion, _                      = semver.Make("2.53.0") // #111475922
 	MultipleAppPortsMinimumAPIVersion, _                = semver.Make("2.51.0")
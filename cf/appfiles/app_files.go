@@ -8,9 +8,10 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
+	"code.cloudfoundry.org/cli/cf/errors"
 	"code.cloudfoundry.org/cli/cf/models"
-	"code.cloudfoundry.org/gofileutils/fileutils"
 )
 
 const windowsPathPrefix = `\\?\`
@@ -18,15 +19,18 @@ const windowsPathPrefix = `\\?\`
 //go:generate counterfeiter . AppFiles
 
 type AppFiles interface {
-	AppFilesInDir(dir string) (appFiles []models.AppFileFields, err error)
-	CopyFiles(appFiles []models.AppFileFields, fromDir, toDir string) (err error)
-	CountFiles(directory string) int64
-	WalkAppFiles(dir string, onEachFile func(string, string) error) (err error)
+	AppFilesInDir(dir string, preserveSymlinks bool, hashCache HashCache) (appFiles []models.AppFileFields, err error)
+	WalkAppFiles(dir string, preserveSymlinks bool, onEachFile func(string, string) error) (err error)
 }
 
 type ApplicationFiles struct{}
 
-func (appfiles ApplicationFiles) AppFilesInDir(dir string) ([]models.AppFileFields, error) {
+// AppFilesInDir lists the files in dir that are not ignored. Symbolic links
+// are left out unless preserveSymlinks is set, in which case they are listed
+// as links and must point to somewhere inside dir. Files that have not
+// changed since they were last hashed reuse the digest from hashCache, unless
+// it is nil.
+func (appfiles ApplicationFiles) AppFilesInDir(dir string, preserveSymlinks bool, hashCache HashCache) ([]models.AppFileFields, error) {
 	appFiles := []models.AppFileFields{}
 
 	fullDirPath, toplevelErr := filepath.Abs(dir)
@@ -34,7 +38,24 @@ func (appfiles ApplicationFiles) AppFilesInDir(dir string) ([]models.AppFileFiel
 		return appFiles, toplevelErr
 	}
 
-	toplevelErr = appfiles.WalkAppFiles(fullDirPath, func(fileName string, fullPath string) error {
+	// A cache that cannot be read only means every file is hashed again.
+	cachedHashes := map[string]FileHash{}
+	if hashCache != nil {
+		if loadedHashes, err := hashCache.Load(fullDirPath); err == nil {
+			cachedHashes = loadedHashes
+		}
+	}
+	hashes := map[string]FileHash{}
+
+	// Regular files that are not in the cache are hashed together once the
+	// walk is done.
+	var (
+		filesToHash     []int
+		fullPathsToHash []string
+		fileInfosToHash []os.FileInfo
+	)
+
+	toplevelErr = appfiles.WalkAppFiles(fullDirPath, preserveSymlinks, func(fileName string, fullPath string) error {
 		fileInfo, err := os.Lstat(fullPath)
 		if err != nil {
 			return err
@@ -45,125 +66,99 @@ func (appfiles ApplicationFiles) AppFilesInDir(dir string) ([]models.AppFileFiel
 			Size: fileInfo.Size(),
 		}
 
-		if fileInfo.IsDir() {
-			appFile.Sha1 = "0"
-			appFile.Size = 0
-		} else {
-			sha, err := appfiles.shaFile(fullPath)
+		if fileInfo.Mode()&os.ModeSymlink != 0 {
+			target, err := symlinkTargetInDir(fullDirPath, fullPath)
 			if err != nil {
 				return err
 			}
-			appFile.Sha1 = sha
+			appFile.Sha1 = fmt.Sprintf("%x", sha1.Sum([]byte(target)))
+			appFile.Size = int64(len(target))
+			appFile.Symlink = true
+		} else if fileInfo.IsDir() {
+			appFile.Sha1 = "0"
+			appFile.Size = 0
+		} else if cachedHash, ok := cachedHashes[appFile.Path]; ok && cachedHash.matches(fileInfo) {
+			appFile.Sha1 = cachedHash.Sha1
+			hashes[appFile.Path] = cachedHash
+		} else {
+			filesToHash = append(filesToHash, len(appFiles))
+			fullPathsToHash = append(fullPathsToHash, fullPath)
+			fileInfosToHash = append(fileInfosToHash, fileInfo)
 		}
 
 		appFiles = append(appFiles, appFile)
 
 		return nil
 	})
-
-	return appFiles, toplevelErr
-}
-
-func (appfiles ApplicationFiles) shaFile(fullPath string) (string, error) {
-	hash := sha1.New()
-	file, err := os.Open(fullPath)
-	if err != nil {
-		return "", err
+	if toplevelErr != nil {
+		return appFiles, toplevelErr
 	}
-	defer file.Close()
 
-	_, err = io.Copy(hash, file)
-	if err != nil {
-		return "", err
+	digests, toplevelErr := Sha1Files(fullPathsToHash, hashConcurrency())
+	if toplevelErr != nil {
+		return appFiles, toplevelErr
 	}
 
-	return fmt.Sprintf("%x", hash.Sum(nil)), nil
-}
-
-func (appfiles ApplicationFiles) CopyFiles(appFiles []models.AppFileFields, fromDir, toDir string) error {
-	for _, file := range appFiles {
-		err := func() error {
-			fromPath, err := filepath.Abs(filepath.Join(fromDir, file.Path))
-			if err != nil {
-				return err
-			}
-
-			if runtime.GOOS == "windows" {
-				fromPath = windowsPathPrefix + fromPath
-			}
-
-			srcFileInfo, err := os.Stat(fromPath)
-			if err != nil {
-				return err
-			}
-
-			toPath, err := filepath.Abs(filepath.Join(toDir, file.Path))
-			if err != nil {
-				return err
-			}
-
-			if runtime.GOOS == "windows" {
-				toPath = windowsPathPrefix + toPath
-			}
-
-			if srcFileInfo.IsDir() {
-				err = os.MkdirAll(toPath, srcFileInfo.Mode())
-				if err != nil {
-					return err
-				}
-				return nil
-			}
-
-			return appfiles.copyFile(fromPath, toPath, srcFileInfo.Mode())
-		}()
-
-		if err != nil {
-			return err
+	for i, index := range filesToHash {
+		appFiles[index].Sha1 = digests[i]
+		hashes[appFiles[index].Path] = FileHash{
+			Size:    fileInfosToHash[i].Size(),
+			ModTime: fileInfosToHash[i].ModTime().UnixNano(),
+			Sha1:    digests[i],
 		}
 	}
 
-	return nil
+	if hashCache != nil {
+		_ = hashCache.Save(fullDirPath, hashes)
+	}
+
+	return appFiles, nil
 }
 
-func (appfiles ApplicationFiles) copyFile(srcPath string, dstPath string, fileMode os.FileMode) error {
-	dst, err := fileutils.Create(dstPath)
+// symlinkTargetInDir returns the target of the link at fullPath, as long as
+// it is a relative path that stays within dir once the link is resolved.
+func symlinkTargetInDir(dir string, fullPath string) (string, error) {
+	target, err := os.Readlink(fullPath)
 	if err != nil {
-		return err
+		return "", err
 	}
-	defer dst.Close()
 
-	if runtime.GOOS != "windows" {
-		err = dst.Chmod(fileMode)
-		if err != nil {
-			return err
-		}
+	relativePath, _ := filepath.Rel(dir, fullPath)
+	if filepath.IsAbs(target) {
+		return "", errors.NewSymlinkOutsideAppDirError(filepath.ToSlash(relativePath), target)
 	}
 
-	src, err := os.Open(srcPath)
-	if err != nil {
-		return err
+	resolvedPath, err := filepath.Rel(dir, filepath.Join(filepath.Dir(fullPath), target))
+	if err != nil || resolvedPath == ".." || strings.HasPrefix(resolvedPath, ".."+string(filepath.Separator)) {
+		return "", errors.NewSymlinkOutsideAppDirError(filepath.ToSlash(relativePath), target)
 	}
-	defer src.Close()
 
-	_, err = io.Copy(dst, src)
+	return target, nil
+}
+
+func sha1File(fullPath string) (string, error) {
+	hash := sha1.New()
+	file, err := os.Open(fullPath)
 	if err != nil {
-		return err
+		return "", err
 	}
+	defer file.Close()
 
-	return nil
-}
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", err
+	}
 
-func (appfiles ApplicationFiles) CountFiles(directory string) int64 {
-	var count int64
-	appfiles.WalkAppFiles(directory, func(_, _ string) error {
-		count++
-		return nil
-	})
-	return count
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
 }
 
-func (appfiles ApplicationFiles) WalkAppFiles(dir string, onEachFile func(string, string) error) error {
+func (appfiles ApplicationFiles) WalkAppFiles(dir string, preserveSymlinks bool, onEachFile func(string, string) error) error {
 	cfIgnore := loadIgnoreFile(dir)
+
+	// Ignored directories that may still contain re-included files are
+	// walked, but only reported once something inside them is.
+	var ignoredDirs []appFilePath
+
 	walkFunc := func(fullPath string, f os.FileInfo, err error) error {
 		fileRelativePath, _ := filepath.Rel(dir, fullPath)
 		fileRelativeUnixPath := filepath.ToSlash(fileRelativePath)
@@ -180,10 +175,21 @@ func (appfiles ApplicationFiles) WalkAppFiles(dir string, onEachFile func(string
 			return nil
 		}
 
-		if cfIgnore.FileShouldBeIgnored(fileRelativeUnixPath) {
-			if err == nil && f.IsDir() {
+		for len(ignoredDirs) > 0 && !strings.HasPrefix(fileRelativeUnixPath, ignoredDirs[len(ignoredDirs)-1].unixPath+"/") {
+			ignoredDirs = ignoredDirs[:len(ignoredDirs)-1]
+		}
+
+		isDir := err == nil && f.IsDir()
+		if isDir && cfIgnore.DirShouldBeIgnored(fileRelativeUnixPath) {
+			if cfIgnore.DirShouldBeSkipped(fileRelativeUnixPath) {
 				return filepath.SkipDir
 			}
+			ignoredDirs = append(ignoredDirs, appFilePath{fileRelativePath, fileRelativeUnixPath, fullPath})
+			addNestedIgnoreFile(cfIgnore, fullPath, fileRelativeUnixPath)
+			return nil
+		}
+
+		if !isDir && cfIgnore.FileShouldBeIgnored(fileRelativeUnixPath) {
 			return nil
 		}
 
@@ -191,16 +197,44 @@ func (appfiles ApplicationFiles) WalkAppFiles(dir string, onEachFile func(string
 			return err
 		}
 
-		if !f.Mode().IsRegular() && !f.IsDir() {
+		isSymlink := f.Mode()&os.ModeSymlink != 0
+		if !f.Mode().IsRegular() && !f.IsDir() && !(preserveSymlinks && isSymlink) {
 			return nil
 		}
 
+		for _, ignoredDir := range ignoredDirs {
+			err = onEachFile(ignoredDir.path, ignoredDir.fullPath)
+			if err != nil {
+				return err
+			}
+		}
+		ignoredDirs = nil
+
+		if isDir {
+			addNestedIgnoreFile(cfIgnore, fullPath, fileRelativeUnixPath)
+		}
+
 		return onEachFile(fileRelativePath, fullPath)
 	}
 
 	return filepath.Walk(dir, walkFunc)
 }
 
+type appFilePath struct {
+	path     string
+	unixPath string
+	fullPath string
+}
+
+func addNestedIgnoreFile(cfIgnore CfIgnore, fullPath string, relativeUnixPath string) {
+	fileContents, err := ioutil.ReadFile(filepath.Join(fullPath, ".cfignore"))
+	if err != nil {
+		return
+	}
+
+	cfIgnore.AddIgnoreFile(relativeUnixPath, string(fileContents))
+}
+
 func loadIgnoreFile(dir string) CfIgnore {
 	fileContents, err := ioutil.ReadFile(filepath.Join(dir, ".cfignore"))
 	if err != nil {
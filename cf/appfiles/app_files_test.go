@@ -1,6 +1,7 @@
 package appfiles_test
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -8,6 +9,7 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/cli/cf/appfiles"
+	"code.cloudfoundry.org/cli/cf/appfiles/appfilesfakes"
 	"github.com/nu7hatch/gouuid"
 
 	"code.cloudfoundry.org/cli/cf/models"
@@ -46,7 +48,7 @@ var _ = Describe("AppFiles", func() {
 
 	Describe("AppFilesInDir", func() {
 		It("all files have '/' path separators", func() {
-			files, err := appFiles.AppFilesInDir(fixturePath)
+			files, err := appFiles.AppFilesInDir(fixturePath, false, nil)
 			Expect(err).NotTo(HaveOccurred())
 
 			for _, afile := range files {
@@ -54,12 +56,114 @@ var _ = Describe("AppFiles", func() {
 			}
 		})
 
+		It("computes the SHA1 of each file", func() {
+			files, err := appFiles.AppFilesInDir(filepath.Join(fixturePath, "app-copy-test"), false, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			sha1s := map[string]string{}
+			for _, file := range files {
+				sha1s[file.Path] = file.Sha1
+			}
+			Expect(sha1s).To(Equal(map[string]string{
+				"dir1":                            "0",
+				"dir1/child-dir":                  "0",
+				"dir1/child-dir/file2.txt":        "b46c0c8ea1e5ef8e46fc8894bfd4752a88ec939e",
+				"dir1/child-dir/file3.txt":        "20b7fb0d9ca37ead9db60857631d9cb4b2c23c0b",
+				"dir1/file1.txt":                  "da00d1e3757ca7c5271aa25d129579d22a576f5b",
+				"dir2":                            "0",
+				"dir2/child-dir2":                 "0",
+				"dir2/child-dir2/grandchild-dir2": "0",
+				"dir2/child-dir2/grandchild-dir2/file4.txt": "5d23fbae52ce3f99aa1ba5500adb3caa81e9e27c",
+			}))
+		})
+
+		Context("when CF_HASH_CONCURRENCY is set", func() {
+			BeforeEach(func() {
+				os.Setenv("CF_HASH_CONCURRENCY", "1")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("CF_HASH_CONCURRENCY")
+			})
+
+			It("still computes the SHA1 of each file", func() {
+				files, err := appFiles.AppFilesInDir(filepath.Join(fixturePath, "app-copy-test"), false, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files[4].Path).To(Equal("dir1/file1.txt"))
+				Expect(files[4].Sha1).To(Equal("da00d1e3757ca7c5271aa25d129579d22a576f5b"))
+			})
+		})
+
+		Context("when a hash cache is given", func() {
+			var (
+				hashCache *appfilesfakes.FakeHashCache
+				appDir    string
+				fileInfo  os.FileInfo
+			)
+
+			BeforeEach(func() {
+				hashCache = new(appfilesfakes.FakeHashCache)
+				appDir = filepath.Join(fixturePath, "app-copy-test")
+
+				var err error
+				fileInfo, err = os.Stat(filepath.Join(appDir, "dir1", "file1.txt"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reuses the digests of files whose size and modification time are unchanged", func() {
+				hashCache.LoadReturns(map[string]appfiles.FileHash{
+					"dir1/file1.txt": {Size: fileInfo.Size(), ModTime: fileInfo.ModTime().UnixNano(), Sha1: "cached-sha"},
+				}, nil)
+
+				files, err := appFiles.AppFilesInDir(appDir, false, hashCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files[4].Path).To(Equal("dir1/file1.txt"))
+				Expect(files[4].Sha1).To(Equal("cached-sha"))
+			})
+
+			It("hashes files that changed since they were cached", func() {
+				hashCache.LoadReturns(map[string]appfiles.FileHash{
+					"dir1/file1.txt": {Size: fileInfo.Size() + 1, ModTime: fileInfo.ModTime().UnixNano(), Sha1: "cached-sha"},
+				}, nil)
+
+				files, err := appFiles.AppFilesInDir(appDir, false, hashCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files[4].Sha1).To(Equal("da00d1e3757ca7c5271aa25d129579d22a576f5b"))
+			})
+
+			It("saves the digests of all the files in the dir", func() {
+				_, err := appFiles.AppFilesInDir(appDir, false, hashCache)
+				Expect(err).NotTo(HaveOccurred())
+
+				absAppDir, err := filepath.Abs(appDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(hashCache.SaveCallCount()).To(Equal(1))
+				dir, hashes := hashCache.SaveArgsForCall(0)
+				Expect(dir).To(Equal(absAppDir))
+				Expect(hashes).To(HaveLen(4))
+				Expect(hashes["dir1/file1.txt"]).To(Equal(appfiles.FileHash{
+					Size:    fileInfo.Size(),
+					ModTime: fileInfo.ModTime().UnixNano(),
+					Sha1:    "da00d1e3757ca7c5271aa25d129579d22a576f5b",
+				}))
+			})
+
+			It("hashes every file when the cache cannot be loaded", func() {
+				hashCache.LoadReturns(nil, errors.New("corrupt cache"))
+
+				files, err := appFiles.AppFilesInDir(appDir, false, hashCache)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files[4].Sha1).To(Equal("da00d1e3757ca7c5271aa25d129579d22a576f5b"))
+			})
+		})
+
 		Context("when .cfignore is provided", func() {
 			var paths []string
 
 			BeforeEach(func() {
 				appPath := filepath.Join(fixturePath, "app-with-cfignore")
-				files, err := appFiles.AppFilesInDir(appPath)
+				files, err := appFiles.AppFilesInDir(appPath, false, nil)
 				Expect(err).NotTo(HaveOccurred())
 
 				paths = []string{}
@@ -75,14 +179,121 @@ var _ = Describe("AppFiles", func() {
 					"dir1/child-dir/file3.txt",
 					"dir1/file1.txt",
 					"dir2",
-
-					// TODO: this should be excluded.
-					// .cfignore doesn't handle ** patterns right now
-					"dir2/child-dir2",
 				}))
 			})
 		})
 
+		Context("when subdirectories have their own .cfignore", func() {
+			It("applies the nested patterns to the files below them", func() {
+				fileutils.TempDir("nested-cfignore", func(tempdir string, err error) {
+					Expect(err).NotTo(HaveOccurred())
+
+					for _, file := range []string{
+						".cfignore",
+						"app.log",
+						"app.rb",
+						"lib/.cfignore",
+						"lib/keep.log",
+						"lib/generated/code.rb",
+						"lib/code.rb",
+					} {
+						err = os.MkdirAll(filepath.Join(tempdir, filepath.Dir(file)), 0755)
+						Expect(err).NotTo(HaveOccurred())
+						err = ioutil.WriteFile(filepath.Join(tempdir, file), []byte{}, 0644)
+						Expect(err).NotTo(HaveOccurred())
+					}
+
+					err = ioutil.WriteFile(filepath.Join(tempdir, ".cfignore"), []byte("*.log\n"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+					err = ioutil.WriteFile(filepath.Join(tempdir, "lib", ".cfignore"), []byte("!keep.log\ngenerated/\n"), 0644)
+					Expect(err).NotTo(HaveOccurred())
+
+					files, err := appFiles.AppFilesInDir(tempdir, false, nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					paths := []string{}
+					for _, file := range files {
+						paths = append(paths, file.Path)
+					}
+
+					Expect(paths).To(Equal([]string{
+						"app.rb",
+						"lib",
+						"lib/code.rb",
+						"lib/keep.log",
+					}))
+				})
+			})
+		})
+
+		Context("when the dir contains symbolic links", func() {
+			var tempdir string
+
+			BeforeEach(func() {
+				if runtime.GOOS == "windows" {
+					Skip("This test does not run on Windows")
+				}
+
+				var err error
+				tempdir, err = ioutil.TempDir("", "symlinks")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = os.Mkdir(filepath.Join(tempdir, "lib"), 0755)
+				Expect(err).NotTo(HaveOccurred())
+				err = ioutil.WriteFile(filepath.Join(tempdir, "target.txt"), []byte("some content"), 0644)
+				Expect(err).NotTo(HaveOccurred())
+				err = os.Symlink("../target.txt", filepath.Join(tempdir, "lib", "link.txt"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(tempdir)
+			})
+
+			It("leaves the links out by default", func() {
+				files, err := appFiles.AppFilesInDir(tempdir, false, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				paths := []string{}
+				for _, file := range files {
+					paths = append(paths, file.Path)
+				}
+				Expect(paths).To(Equal([]string{"lib", "target.txt"}))
+			})
+
+			Context("when preserving symbolic links", func() {
+				It("lists the links as links", func() {
+					files, err := appFiles.AppFilesInDir(tempdir, true, nil)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(files).To(HaveLen(3))
+					Expect(files[1]).To(Equal(models.AppFileFields{
+						Path:    "lib/link.txt",
+						Sha1:    "82d04f9fda7b6d5aa1a1baf1d0bd91f7f89115de",
+						Size:    int64(len("../target.txt")),
+						Symlink: true,
+					}))
+				})
+
+				It("returns an error when a link points outside of the dir", func() {
+					err := os.Symlink("../../elsewhere", filepath.Join(tempdir, "lib", "escaping-link"))
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = appFiles.AppFilesInDir(tempdir, true, nil)
+					Expect(err).To(MatchError("Symbolic link lib/escaping-link points to ../../elsewhere, which is outside the app directory"))
+				})
+
+				It("returns an error when a link has an absolute target", func() {
+					err := os.Symlink(filepath.Join(tempdir, "target.txt"), filepath.Join(tempdir, "absolute-link"))
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = appFiles.AppFilesInDir(tempdir, true, nil)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("outside the app directory"))
+				})
+			})
+		})
+
 		// NB: on windows, you can never rely on the size of a directory being zero
 		// see: http://msdn.microsoft.com/en-us/library/windows/desktop/aa364946(v=vs.85).aspx
 		// and: https://www.pivotaltracker.com/story/show/70470232
@@ -93,7 +304,7 @@ var _ = Describe("AppFiles", func() {
 				err = os.Mkdir(filepath.Join(tempdir, "nothing"), 0600)
 				Expect(err).ToNot(HaveOccurred())
 
-				files, err := appFiles.AppFilesInDir(tempdir)
+				files, err := appFiles.AppFilesInDir(tempdir, false, nil)
 				Expect(err).ToNot(HaveOccurred())
 
 				sizes := []int64{}
@@ -106,38 +317,6 @@ var _ = Describe("AppFiles", func() {
 		})
 	})
 
-	Describe("CopyFiles", func() {
-		It("copies only the files specified", func() {
-			copyDir := filepath.Join(fixturePath, "app-copy-test")
-
-			filesToCopy := []models.AppFileFields{
-				{Path: filepath.Join("dir1")},
-				{Path: filepath.Join("dir1", "child-dir", "file2.txt")},
-			}
-
-			files := []string{}
-
-			fileutils.TempDir("copyToDir", func(tmpDir string, err error) {
-				copyErr := appFiles.CopyFiles(filesToCopy, copyDir, tmpDir)
-				Expect(copyErr).ToNot(HaveOccurred())
-
-				filepath.Walk(tmpDir, func(path string, fileInfo os.FileInfo, err error) error {
-					Expect(err).ToNot(HaveOccurred())
-
-					if !fileInfo.IsDir() {
-						files = append(files, fileInfo.Name())
-					}
-					return nil
-				})
-			})
-
-			// file2.txt is in lowest subtree, thus is walked first.
-			Expect(files).To(Equal([]string{
-				"file2.txt",
-			}))
-		})
-	})
-
 	Describe("WalkAppFiles", func() {
 		var cb func(string, string) error
 		var actualWalkAppFileArgs []WalkAppFileArgs
@@ -154,7 +333,7 @@ var _ = Describe("AppFiles", func() {
 		})
 
 		It("calls the callback with the relative and absolute path for each file within the given dir", func() {
-			err := appFiles.WalkAppFiles(filepath.Join(fixturePath, "app-copy-test"), cb)
+			err := appFiles.WalkAppFiles(filepath.Join(fixturePath, "app-copy-test"), false, cb)
 			Expect(err).NotTo(HaveOccurred())
 			expectedArgs := []WalkAppFileArgs{
 				{
@@ -245,12 +424,12 @@ var _ = Describe("AppFiles", func() {
 				})
 
 				It("does not return an error", func() {
-					err := appFiles.WalkAppFiles(filepath.Join(fixturePath, "app-copy-test"), cb)
+					err := appFiles.WalkAppFiles(filepath.Join(fixturePath, "app-copy-test"), false, cb)
 					Expect(err).NotTo(HaveOccurred())
 				})
 
 				It("does not call the callback with the untraversable dir", func() {
-					appFiles.WalkAppFiles(filepath.Join(fixturePath, "app-copy-test"), cb)
+					appFiles.WalkAppFiles(filepath.Join(fixturePath, "app-copy-test"), false, cb)
 					for _, actual := range actualWalkAppFileArgs {
 						Expect(actual.RelativePath()).NotTo(Equal(untraversableDirName))
 					}
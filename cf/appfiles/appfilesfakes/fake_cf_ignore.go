@@ -16,6 +16,28 @@ type FakeCfIgnore struct {
 	fileShouldBeIgnoredReturns struct {
 		result1 bool
 	}
+	DirShouldBeIgnoredStub        func(path string) bool
+	dirShouldBeIgnoredMutex       sync.RWMutex
+	dirShouldBeIgnoredArgsForCall []struct {
+		path string
+	}
+	dirShouldBeIgnoredReturns struct {
+		result1 bool
+	}
+	DirShouldBeSkippedStub        func(path string) bool
+	dirShouldBeSkippedMutex       sync.RWMutex
+	dirShouldBeSkippedArgsForCall []struct {
+		path string
+	}
+	dirShouldBeSkippedReturns struct {
+		result1 bool
+	}
+	AddIgnoreFileStub        func(dir string, text string)
+	addIgnoreFileMutex       sync.RWMutex
+	addIgnoreFileArgsForCall []struct {
+		dir  string
+		text string
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -53,11 +75,108 @@ func (fake *FakeCfIgnore) FileShouldBeIgnoredReturns(result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeCfIgnore) DirShouldBeIgnored(path string) bool {
+	fake.dirShouldBeIgnoredMutex.Lock()
+	fake.dirShouldBeIgnoredArgsForCall = append(fake.dirShouldBeIgnoredArgsForCall, struct {
+		path string
+	}{path})
+	fake.recordInvocation("DirShouldBeIgnored", []interface{}{path})
+	fake.dirShouldBeIgnoredMutex.Unlock()
+	if fake.DirShouldBeIgnoredStub != nil {
+		return fake.DirShouldBeIgnoredStub(path)
+	} else {
+		return fake.dirShouldBeIgnoredReturns.result1
+	}
+}
+
+func (fake *FakeCfIgnore) DirShouldBeIgnoredCallCount() int {
+	fake.dirShouldBeIgnoredMutex.RLock()
+	defer fake.dirShouldBeIgnoredMutex.RUnlock()
+	return len(fake.dirShouldBeIgnoredArgsForCall)
+}
+
+func (fake *FakeCfIgnore) DirShouldBeIgnoredArgsForCall(i int) string {
+	fake.dirShouldBeIgnoredMutex.RLock()
+	defer fake.dirShouldBeIgnoredMutex.RUnlock()
+	return fake.dirShouldBeIgnoredArgsForCall[i].path
+}
+
+func (fake *FakeCfIgnore) DirShouldBeIgnoredReturns(result1 bool) {
+	fake.DirShouldBeIgnoredStub = nil
+	fake.dirShouldBeIgnoredReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeCfIgnore) DirShouldBeSkipped(path string) bool {
+	fake.dirShouldBeSkippedMutex.Lock()
+	fake.dirShouldBeSkippedArgsForCall = append(fake.dirShouldBeSkippedArgsForCall, struct {
+		path string
+	}{path})
+	fake.recordInvocation("DirShouldBeSkipped", []interface{}{path})
+	fake.dirShouldBeSkippedMutex.Unlock()
+	if fake.DirShouldBeSkippedStub != nil {
+		return fake.DirShouldBeSkippedStub(path)
+	} else {
+		return fake.dirShouldBeSkippedReturns.result1
+	}
+}
+
+func (fake *FakeCfIgnore) DirShouldBeSkippedCallCount() int {
+	fake.dirShouldBeSkippedMutex.RLock()
+	defer fake.dirShouldBeSkippedMutex.RUnlock()
+	return len(fake.dirShouldBeSkippedArgsForCall)
+}
+
+func (fake *FakeCfIgnore) DirShouldBeSkippedArgsForCall(i int) string {
+	fake.dirShouldBeSkippedMutex.RLock()
+	defer fake.dirShouldBeSkippedMutex.RUnlock()
+	return fake.dirShouldBeSkippedArgsForCall[i].path
+}
+
+func (fake *FakeCfIgnore) DirShouldBeSkippedReturns(result1 bool) {
+	fake.DirShouldBeSkippedStub = nil
+	fake.dirShouldBeSkippedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeCfIgnore) AddIgnoreFile(dir string, text string) {
+	fake.addIgnoreFileMutex.Lock()
+	fake.addIgnoreFileArgsForCall = append(fake.addIgnoreFileArgsForCall, struct {
+		dir  string
+		text string
+	}{dir, text})
+	fake.recordInvocation("AddIgnoreFile", []interface{}{dir, text})
+	fake.addIgnoreFileMutex.Unlock()
+	if fake.AddIgnoreFileStub != nil {
+		fake.AddIgnoreFileStub(dir, text)
+	}
+}
+
+func (fake *FakeCfIgnore) AddIgnoreFileCallCount() int {
+	fake.addIgnoreFileMutex.RLock()
+	defer fake.addIgnoreFileMutex.RUnlock()
+	return len(fake.addIgnoreFileArgsForCall)
+}
+
+func (fake *FakeCfIgnore) AddIgnoreFileArgsForCall(i int) (string, string) {
+	fake.addIgnoreFileMutex.RLock()
+	defer fake.addIgnoreFileMutex.RUnlock()
+	return fake.addIgnoreFileArgsForCall[i].dir, fake.addIgnoreFileArgsForCall[i].text
+}
+
 func (fake *FakeCfIgnore) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.fileShouldBeIgnoredMutex.RLock()
 	defer fake.fileShouldBeIgnoredMutex.RUnlock()
+	fake.dirShouldBeIgnoredMutex.RLock()
+	defer fake.dirShouldBeIgnoredMutex.RUnlock()
+	fake.dirShouldBeSkippedMutex.RLock()
+	defer fake.dirShouldBeSkippedMutex.RUnlock()
+	fake.addIgnoreFileMutex.RLock()
+	defer fake.addIgnoreFileMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,123 @@
+// This file was generated by counterfeiter
+package appfilesfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/appfiles"
+)
+
+type FakeHashCache struct {
+	LoadStub        func(dir string) (map[string]appfiles.FileHash, error)
+	loadMutex       sync.RWMutex
+	loadArgsForCall []struct {
+		dir string
+	}
+	loadReturns struct {
+		result1 map[string]appfiles.FileHash
+		result2 error
+	}
+	SaveStub        func(dir string, hashes map[string]appfiles.FileHash) error
+	saveMutex       sync.RWMutex
+	saveArgsForCall []struct {
+		dir    string
+		hashes map[string]appfiles.FileHash
+	}
+	saveReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeHashCache) Load(dir string) (map[string]appfiles.FileHash, error) {
+	fake.loadMutex.Lock()
+	fake.loadArgsForCall = append(fake.loadArgsForCall, struct {
+		dir string
+	}{dir})
+	fake.recordInvocation("Load", []interface{}{dir})
+	fake.loadMutex.Unlock()
+	if fake.LoadStub != nil {
+		return fake.LoadStub(dir)
+	} else {
+		return fake.loadReturns.result1, fake.loadReturns.result2
+	}
+}
+
+func (fake *FakeHashCache) LoadCallCount() int {
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	return len(fake.loadArgsForCall)
+}
+
+func (fake *FakeHashCache) LoadArgsForCall(i int) string {
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	return fake.loadArgsForCall[i].dir
+}
+
+func (fake *FakeHashCache) LoadReturns(result1 map[string]appfiles.FileHash, result2 error) {
+	fake.LoadStub = nil
+	fake.loadReturns = struct {
+		result1 map[string]appfiles.FileHash
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeHashCache) Save(dir string, hashes map[string]appfiles.FileHash) error {
+	fake.saveMutex.Lock()
+	fake.saveArgsForCall = append(fake.saveArgsForCall, struct {
+		dir    string
+		hashes map[string]appfiles.FileHash
+	}{dir, hashes})
+	fake.recordInvocation("Save", []interface{}{dir, hashes})
+	fake.saveMutex.Unlock()
+	if fake.SaveStub != nil {
+		return fake.SaveStub(dir, hashes)
+	} else {
+		return fake.saveReturns.result1
+	}
+}
+
+func (fake *FakeHashCache) SaveCallCount() int {
+	fake.saveMutex.RLock()
+	defer fake.saveMutex.RUnlock()
+	return len(fake.saveArgsForCall)
+}
+
+func (fake *FakeHashCache) SaveArgsForCall(i int) (string, map[string]appfiles.FileHash) {
+	fake.saveMutex.RLock()
+	defer fake.saveMutex.RUnlock()
+	return fake.saveArgsForCall[i].dir, fake.saveArgsForCall[i].hashes
+}
+
+func (fake *FakeHashCache) SaveReturns(result1 error) {
+	fake.SaveStub = nil
+	fake.saveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHashCache) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.loadMutex.RLock()
+	defer fake.loadMutex.RUnlock()
+	fake.saveMutex.RLock()
+	defer fake.saveMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeHashCache) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ appfiles.HashCache = new(FakeHashCache)
@@ -6,18 +6,30 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/cli/cf/appfiles"
+	"code.cloudfoundry.org/cli/cf/models"
 )
 
 type FakeZipper struct {
-	ZipStub        func(dirToZip string, targetFile *os.File) (err error)
+	ZipStub        func(dirToZip string, targetFile *os.File, preserveSymlinks bool) (err error)
 	zipMutex       sync.RWMutex
 	zipArgsForCall []struct {
-		dirToZip   string
-		targetFile *os.File
+		dirToZip         string
+		targetFile       *os.File
+		preserveSymlinks bool
 	}
 	zipReturns struct {
 		result1 error
 	}
+	ZipFilesStub        func(dir string, files []models.AppFileFields, targetFile *os.File) (err error)
+	zipFilesMutex       sync.RWMutex
+	zipFilesArgsForCall []struct {
+		dir        string
+		files      []models.AppFileFields
+		targetFile *os.File
+	}
+	zipFilesReturns struct {
+		result1 error
+	}
 	IsZipFileStub        func(path string) bool
 	isZipFileMutex       sync.RWMutex
 	isZipFileArgsForCall []struct {
@@ -48,16 +60,17 @@ type FakeZipper struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeZipper) Zip(dirToZip string, targetFile *os.File) (err error) {
+func (fake *FakeZipper) Zip(dirToZip string, targetFile *os.File, preserveSymlinks bool) (err error) {
 	fake.zipMutex.Lock()
 	fake.zipArgsForCall = append(fake.zipArgsForCall, struct {
-		dirToZip   string
-		targetFile *os.File
-	}{dirToZip, targetFile})
-	fake.recordInvocation("Zip", []interface{}{dirToZip, targetFile})
+		dirToZip         string
+		targetFile       *os.File
+		preserveSymlinks bool
+	}{dirToZip, targetFile, preserveSymlinks})
+	fake.recordInvocation("Zip", []interface{}{dirToZip, targetFile, preserveSymlinks})
 	fake.zipMutex.Unlock()
 	if fake.ZipStub != nil {
-		return fake.ZipStub(dirToZip, targetFile)
+		return fake.ZipStub(dirToZip, targetFile, preserveSymlinks)
 	} else {
 		return fake.zipReturns.result1
 	}
@@ -69,10 +82,10 @@ func (fake *FakeZipper) ZipCallCount() int {
 	return len(fake.zipArgsForCall)
 }
 
-func (fake *FakeZipper) ZipArgsForCall(i int) (string, *os.File) {
+func (fake *FakeZipper) ZipArgsForCall(i int) (string, *os.File, bool) {
 	fake.zipMutex.RLock()
 	defer fake.zipMutex.RUnlock()
-	return fake.zipArgsForCall[i].dirToZip, fake.zipArgsForCall[i].targetFile
+	return fake.zipArgsForCall[i].dirToZip, fake.zipArgsForCall[i].targetFile, fake.zipArgsForCall[i].preserveSymlinks
 }
 
 func (fake *FakeZipper) ZipReturns(result1 error) {
@@ -82,6 +95,46 @@ func (fake *FakeZipper) ZipReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeZipper) ZipFiles(dir string, files []models.AppFileFields, targetFile *os.File) (err error) {
+	var filesCopy []models.AppFileFields
+	if files != nil {
+		filesCopy = make([]models.AppFileFields, len(files))
+		copy(filesCopy, files)
+	}
+	fake.zipFilesMutex.Lock()
+	fake.zipFilesArgsForCall = append(fake.zipFilesArgsForCall, struct {
+		dir        string
+		files      []models.AppFileFields
+		targetFile *os.File
+	}{dir, filesCopy, targetFile})
+	fake.recordInvocation("ZipFiles", []interface{}{dir, filesCopy, targetFile})
+	fake.zipFilesMutex.Unlock()
+	if fake.ZipFilesStub != nil {
+		return fake.ZipFilesStub(dir, files, targetFile)
+	} else {
+		return fake.zipFilesReturns.result1
+	}
+}
+
+func (fake *FakeZipper) ZipFilesCallCount() int {
+	fake.zipFilesMutex.RLock()
+	defer fake.zipFilesMutex.RUnlock()
+	return len(fake.zipFilesArgsForCall)
+}
+
+func (fake *FakeZipper) ZipFilesArgsForCall(i int) (string, []models.AppFileFields, *os.File) {
+	fake.zipFilesMutex.RLock()
+	defer fake.zipFilesMutex.RUnlock()
+	return fake.zipFilesArgsForCall[i].dir, fake.zipFilesArgsForCall[i].files, fake.zipFilesArgsForCall[i].targetFile
+}
+
+func (fake *FakeZipper) ZipFilesReturns(result1 error) {
+	fake.ZipFilesStub = nil
+	fake.zipFilesReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeZipper) IsZipFile(path string) bool {
 	fake.isZipFileMutex.Lock()
 	fake.isZipFileArgsForCall = append(fake.isZipFileArgsForCall, struct {
@@ -188,6 +241,8 @@ func (fake *FakeZipper) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.zipMutex.RLock()
 	defer fake.zipMutex.RUnlock()
+	fake.zipFilesMutex.RLock()
+	defer fake.zipFilesMutex.RUnlock()
 	fake.isZipFileMutex.RLock()
 	defer fake.isZipFileMutex.RUnlock()
 	fake.unzipMutex.RLock()
@@ -3,77 +3,232 @@ package appfiles
 import (
 	"path"
 	"strings"
-
-	"code.cloudfoundry.org/cli/utils/glob"
 )
 
 //go:generate counterfeiter . CfIgnore
 
 type CfIgnore interface {
 	FileShouldBeIgnored(path string) bool
+	DirShouldBeIgnored(path string) bool
+	DirShouldBeSkipped(path string) bool
+	AddIgnoreFile(dir string, text string)
 }
 
+// NewCfIgnore parses the contents of an app's top level .cfignore. Patterns
+// follow the .gitignore format: blank lines and lines starting with # are
+// skipped, a leading ! re-includes what an earlier pattern excluded, a
+// trailing / only matches directories, and a pattern containing a / is
+// anchored to the directory of the file it was read from. Unanchored patterns
+// match a file or directory name at any depth.
+//
+// Unlike git, a file can be re-included even when one of its parent
+// directories is excluded.
 func NewCfIgnore(text string) CfIgnore {
-	patterns := []ignorePattern{}
-	lines := strings.Split(text, "\n")
-	lines = append(defaultIgnoreLines, lines...)
+	ignore := &cfIgnore{}
+	ignore.addPatterns("", defaultIgnoreLines)
+	ignore.AddIgnoreFile("", text)
+	return ignore
+}
+
+// AddIgnoreFile merges the patterns of a nested .cfignore found in dir, a
+// slash separated path relative to the app root. They only apply to paths
+// below dir and take precedence over the patterns added before them.
+func (ignore *cfIgnore) AddIgnoreFile(dir string, text string) {
+	ignore.addPatterns(cleanRelativePath(dir), strings.Split(text, "\n"))
+}
+
+func (ignore *cfIgnore) FileShouldBeIgnored(path string) bool {
+	return ignore.isIgnored(splitPath(path), false)
+}
+
+func (ignore *cfIgnore) DirShouldBeIgnored(path string) bool {
+	return ignore.isIgnored(splitPath(path), true)
+}
+
+// DirShouldBeSkipped reports whether nothing below the directory can be
+// included, so there is no need to walk it at all.
+func (ignore *cfIgnore) DirShouldBeSkipped(path string) bool {
+	parts := splitPath(path)
+	if !ignore.isIgnored(parts, true) {
+		return false
+	}
+
+	for _, pattern := range ignore.patterns {
+		if pattern.exclude {
+			continue
+		}
+
+		rel, ok := pattern.relativeParts(parts)
+		if !ok {
+			if strings.HasPrefix(pattern.base+"/", strings.Join(parts, "/")+"/") {
+				return false
+			}
+			continue
+		}
+
+		if couldMatchBelow(pattern.components, rel) {
+			return false
+		}
+	}
 
+	return true
+}
+
+// isIgnored applies the patterns to the path and each of its parent
+// directories in turn. The last pattern matching the deepest of them decides,
+// so the contents of an excluded directory are excluded as well unless a
+// pattern says otherwise.
+func (ignore *cfIgnore) isIgnored(parts []string, isDir bool) bool {
+	ignored := false
+
+	for i := range parts {
+		partIsDir := isDir || i < len(parts)-1
+		for _, pattern := range ignore.patterns {
+			if pattern.matches(parts[:i+1], partIsDir) {
+				ignored = pattern.exclude
+			}
+		}
+	}
+
+	return ignored
+}
+
+func (ignore *cfIgnore) addPatterns(base string, lines []string) {
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
-		if line == "" {
+		if line == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
 
-		ignore := true
+		pattern := ignorePattern{exclude: true, base: base}
 		if strings.HasPrefix(line, "!") {
+			pattern.exclude = false
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
 			line = line[1:]
-			ignore = false
 		}
 
-		for _, p := range globsForPattern(path.Clean(line)) {
-			patterns = append(patterns, ignorePattern{ignore, p})
+		if strings.HasSuffix(line, "/") {
+			pattern.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+
+		anchored := strings.Contains(line, "/")
+		line = cleanRelativePath(line)
+		if line == "" || !validPattern(line) {
+			continue
+		}
+
+		pattern.components = strings.Split(line, "/")
+		if !anchored {
+			pattern.components = append([]string{"**"}, pattern.components...)
 		}
+		if pattern.components[len(pattern.components)-1] == "**" {
+			// a trailing /** matches everything inside a directory but not
+			// the directory itself
+			pattern.components = append(pattern.components[:len(pattern.components)-1], "*", "**")
+		}
+
+		ignore.patterns = append(ignore.patterns, pattern)
 	}
+}
+
+type ignorePattern struct {
+	exclude    bool
+	dirOnly    bool
+	base       string
+	components []string
+}
 
-	return cfIgnore(patterns)
+type cfIgnore struct {
+	patterns []ignorePattern
 }
 
-func (ignore cfIgnore) FileShouldBeIgnored(path string) bool {
-	result := false
+func (pattern ignorePattern) matches(parts []string, isDir bool) bool {
+	if pattern.dirOnly && !isDir {
+		return false
+	}
+
+	rel, ok := pattern.relativeParts(parts)
+	return ok && matchComponents(pattern.components, rel)
+}
+
+// relativeParts returns the path relative to the directory of the .cfignore
+// the pattern came from, or false when the path is not below it.
+func (pattern ignorePattern) relativeParts(parts []string) ([]string, bool) {
+	if pattern.base == "" {
+		return parts, true
+	}
+
+	baseParts := strings.Split(pattern.base, "/")
+	if len(parts) <= len(baseParts) {
+		return nil, false
+	}
 
-	for _, pattern := range ignore {
-		if strings.HasPrefix(pattern.glob.String(), "/") && !strings.HasPrefix(path, "/") {
-			path = "/" + path
+	for i, basePart := range baseParts {
+		if parts[i] != basePart {
+			return nil, false
 		}
+	}
+
+	return parts[len(baseParts):], true
+}
+
+func matchComponents(components []string, parts []string) bool {
+	if len(components) == 0 {
+		return len(parts) == 0
+	}
 
-		if pattern.glob.Match(path) {
-			result = pattern.exclude
+	if components[0] == "**" {
+		for i := 0; i <= len(parts); i++ {
+			if matchComponents(components[1:], parts[i:]) {
+				return true
+			}
 		}
+		return false
+	}
+
+	if len(parts) == 0 {
+		return false
 	}
 
-	return result
+	matched, err := path.Match(components[0], parts[0])
+	return err == nil && matched && matchComponents(components[1:], parts[1:])
 }
 
-func globsForPattern(pattern string) (globs []glob.Glob) {
-	globs = append(globs, glob.MustCompileGlob(pattern))
-	globs = append(globs, glob.MustCompileGlob(path.Join(pattern, "*")))
-	globs = append(globs, glob.MustCompileGlob(path.Join(pattern, "**", "*")))
+func couldMatchBelow(components []string, parts []string) bool {
+	if len(parts) == 0 {
+		return len(components) > 0
+	}
 
-	if !strings.HasPrefix(pattern, "/") {
-		globs = append(globs, glob.MustCompileGlob(path.Join("**", pattern)))
-		globs = append(globs, glob.MustCompileGlob(path.Join("**", pattern, "*")))
-		globs = append(globs, glob.MustCompileGlob(path.Join("**", pattern, "**", "*")))
+	if len(components) == 0 {
+		return false
 	}
 
-	return
+	if components[0] == "**" {
+		return true
+	}
+
+	matched, err := path.Match(components[0], parts[0])
+	return err == nil && matched && couldMatchBelow(components[1:], parts[1:])
 }
 
-type ignorePattern struct {
-	exclude bool
-	glob    glob.Glob
+func validPattern(pattern string) bool {
+	_, err := path.Match(pattern, "")
+	return err == nil
 }
 
-type cfIgnore []ignorePattern
+func cleanRelativePath(p string) string {
+	return strings.Trim(path.Clean("/"+p), "/")
+}
+
+func splitPath(p string) []string {
+	p = cleanRelativePath(p)
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
 
 var defaultIgnoreLines = []string{
 	".cfignore",
@@ -67,6 +67,114 @@ stuff/exclude.c`)
 		Expect(ignore.FileShouldBeIgnored(".git/objects")).To(BeFalse())
 	})
 
+	It("matches double-star patterns against zero or more directories", func() {
+		ignore := NewCfIgnore(`
+**/logs
+dir1/**/*.so
+dir2/**`)
+
+		Expect(ignore.FileShouldBeIgnored("logs")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("app/logs")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("dir1/file1.so")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("dir2/file")).To(BeTrue())
+		Expect(ignore.DirShouldBeIgnored("dir2")).To(BeFalse())
+	})
+
+	It("anchors patterns starting with or containing a slash", func() {
+		ignore := NewCfIgnore(`
+/tmp
+build/out`)
+
+		Expect(ignore.FileShouldBeIgnored("tmp")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("src/tmp")).To(BeFalse())
+		Expect(ignore.FileShouldBeIgnored("build/out")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("src/build/out")).To(BeFalse())
+	})
+
+	It("only matches directories with patterns ending in a slash", func() {
+		ignore := NewCfIgnore(`cache/`)
+
+		Expect(ignore.DirShouldBeIgnored("cache")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("cache/file")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("cache")).To(BeFalse())
+	})
+
+	It("skips comments and unescapes leading special characters", func() {
+		ignore := NewCfIgnore(`
+# this is a comment
+\#file
+\!important`)
+
+		Expect(ignore.FileShouldBeIgnored("# this is a comment")).To(BeFalse())
+		Expect(ignore.FileShouldBeIgnored("#file")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("!important")).To(BeTrue())
+	})
+
+	It("re-includes files below an excluded directory", func() {
+		ignore := NewCfIgnore(`
+vendor
+!vendor/keep/*.go`)
+
+		Expect(ignore.FileShouldBeIgnored("vendor/other/file.go")).To(BeTrue())
+		Expect(ignore.FileShouldBeIgnored("vendor/keep/file.go")).To(BeFalse())
+		Expect(ignore.FileShouldBeIgnored("vendor/keep/file.c")).To(BeTrue())
+	})
+
+	Describe("DirShouldBeSkipped", func() {
+		It("skips excluded directories nothing can be re-included from", func() {
+			ignore := NewCfIgnore(`
+vendor
+tmp
+!vendor/keep`)
+
+			Expect(ignore.DirShouldBeSkipped("tmp")).To(BeTrue())
+			Expect(ignore.DirShouldBeSkipped("vendor")).To(BeFalse())
+			Expect(ignore.DirShouldBeSkipped("vendor/other")).To(BeTrue())
+			Expect(ignore.DirShouldBeSkipped("src")).To(BeFalse())
+		})
+
+		It("does not skip excluded directories when an unanchored pattern re-includes files", func() {
+			ignore := NewCfIgnore(`
+vendor
+!*.go`)
+
+			Expect(ignore.DirShouldBeSkipped("vendor")).To(BeFalse())
+		})
+	})
+
+	Describe("nested ignore files", func() {
+		var ignore CfIgnore
+
+		BeforeEach(func() {
+			ignore = NewCfIgnore(`
+*.log
+/top-only`)
+			ignore.AddIgnoreFile("sub", `
+!keep.log
+/local
+data/`)
+		})
+
+		It("applies the nested patterns relative to their directory", func() {
+			Expect(ignore.FileShouldBeIgnored("sub/local")).To(BeTrue())
+			Expect(ignore.FileShouldBeIgnored("sub/other/local")).To(BeFalse())
+			Expect(ignore.FileShouldBeIgnored("local")).To(BeFalse())
+			Expect(ignore.DirShouldBeIgnored("sub/deeper/data")).To(BeTrue())
+		})
+
+		It("lets the nested patterns override the ones from parent directories", func() {
+			Expect(ignore.FileShouldBeIgnored("sub/keep.log")).To(BeFalse())
+			Expect(ignore.FileShouldBeIgnored("keep.log")).To(BeTrue())
+			Expect(ignore.FileShouldBeIgnored("sub/other.log")).To(BeTrue())
+		})
+
+		It("does not apply the nested patterns outside their directory", func() {
+			Expect(ignore.FileShouldBeIgnored("top-only")).To(BeTrue())
+			Expect(ignore.FileShouldBeIgnored("sub/top-only")).To(BeFalse())
+			Expect(ignore.DirShouldBeIgnored("data")).To(BeFalse())
+		})
+	})
+
 	Describe("files named manifest.yml", func() {
 		var (
 			ignore CfIgnore
@@ -0,0 +1,74 @@
+package appfiles
+
+import (
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// FileHash is what is remembered about a file that was hashed before. The
+// digest is only reused while the file's size and modification time are
+// unchanged.
+type FileHash struct {
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mtime"`
+	Sha1    string `json:"sha1"`
+}
+
+//go:generate counterfeiter . HashCache
+
+type HashCache interface {
+	Load(dir string) (map[string]FileHash, error)
+	Save(dir string, hashes map[string]FileHash) error
+}
+
+type diskHashCache struct {
+	cacheDir string
+}
+
+// NewHashCache returns a cache that keeps the file hashes of each app
+// directory in its own JSON file in cacheDir, keyed by the path of the file
+// relative to the app directory. The directory is created on the first save.
+func NewHashCache(cacheDir string) HashCache {
+	return diskHashCache{cacheDir: cacheDir}
+}
+
+func (cache diskHashCache) Load(dir string) (map[string]FileHash, error) {
+	hashes := map[string]FileHash{}
+
+	data, err := ioutil.ReadFile(cache.path(dir))
+	if os.IsNotExist(err) {
+		return hashes, nil
+	}
+	if err != nil {
+		return hashes, err
+	}
+
+	err = json.Unmarshal(data, &hashes)
+	return hashes, err
+}
+
+func (cache diskHashCache) Save(dir string, hashes map[string]FileHash) error {
+	data, err := json.Marshal(hashes)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(cache.cacheDir, 0700)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(cache.path(dir), data, 0600)
+}
+
+func (cache diskHashCache) path(dir string) string {
+	return filepath.Join(cache.cacheDir, fmt.Sprintf("%x.json", sha1.Sum([]byte(dir))))
+}
+
+func (hash FileHash) matches(fileInfo os.FileInfo) bool {
+	return hash.Sha1 != "" && hash.Size == fileInfo.Size() && hash.ModTime == fileInfo.ModTime().UnixNano()
+}
@@ -0,0 +1,70 @@
+package appfiles_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "code.cloudfoundry.org/cli/cf/appfiles"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HashCache", func() {
+	var (
+		cacheDir  string
+		hashCache HashCache
+	)
+
+	BeforeEach(func() {
+		var err error
+		cacheDir, err = ioutil.TempDir("", "hash-cache")
+		Expect(err).NotTo(HaveOccurred())
+
+		hashCache = NewHashCache(filepath.Join(cacheDir, "hashes"))
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(cacheDir)
+	})
+
+	It("returns no hashes for a directory it has not seen", func() {
+		hashes, err := hashCache.Load("/some/app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hashes).To(BeEmpty())
+	})
+
+	It("remembers the hashes of each directory separately", func() {
+		err := hashCache.Save("/some/app", map[string]FileHash{
+			"app.rb": {Size: 10, ModTime: 1000, Sha1: "app-sha"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = hashCache.Save("/other/app", map[string]FileHash{
+			"main.go": {Size: 20, ModTime: 2000, Sha1: "main-sha"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		hashes, err := hashCache.Load("/some/app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hashes).To(Equal(map[string]FileHash{
+			"app.rb": {Size: 10, ModTime: 1000, Sha1: "app-sha"},
+		}))
+	})
+
+	It("returns an error when the cache file is corrupt", func() {
+		err := hashCache.Save("/some/app", map[string]FileHash{})
+		Expect(err).NotTo(HaveOccurred())
+
+		files, err := ioutil.ReadDir(filepath.Join(cacheDir, "hashes"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+
+		err = ioutil.WriteFile(filepath.Join(cacheDir, "hashes", files[0].Name()), []byte("not json"), 0600)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = hashCache.Load("/some/app")
+		Expect(err).To(HaveOccurred())
+	})
+})
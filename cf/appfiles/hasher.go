@@ -0,0 +1,77 @@
+package appfiles
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// Sha1Files computes the SHA1 digests of the files at fullPaths, hashing up
+// to concurrency files at a time. The digests are returned in the same order
+// as the paths. Once hashing a file fails no new files are started, and the
+// first error is returned.
+func Sha1Files(fullPaths []string, concurrency int) ([]string, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		errMutex sync.Mutex
+		firstErr error
+	)
+
+	failed := func() bool {
+		errMutex.Lock()
+		defer errMutex.Unlock()
+		return firstErr != nil
+	}
+
+	digests := make([]string, len(fullPaths))
+	work := make(chan int)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range work {
+				digest, err := sha1File(fullPaths[index])
+				if err != nil {
+					errMutex.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					errMutex.Unlock()
+					continue
+				}
+				digests[index] = digest
+			}
+		}()
+	}
+
+	for index := range fullPaths {
+		if failed() {
+			break
+		}
+		work <- index
+	}
+	close(work)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return digests, nil
+}
+
+// hashConcurrency is the number of files hashed at once, which is
+// CF_HASH_CONCURRENCY when it is set to a positive number and GOMAXPROCS
+// otherwise.
+func hashConcurrency() int {
+	concurrency, err := strconv.Atoi(os.Getenv("CF_HASH_CONCURRENCY"))
+	if err != nil || concurrency < 1 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return concurrency
+}
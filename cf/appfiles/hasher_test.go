@@ -0,0 +1,46 @@
+package appfiles_test
+
+import (
+	"path/filepath"
+
+	. "code.cloudfoundry.org/cli/cf/appfiles"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sha1Files", func() {
+	var fullPaths []string
+
+	BeforeEach(func() {
+		dir := filepath.Join("..", "..", "fixtures", "applications", "app-copy-test")
+		fullPaths = []string{
+			filepath.Join(dir, "dir1", "file1.txt"),
+			filepath.Join(dir, "dir1", "child-dir", "file2.txt"),
+			filepath.Join(dir, "dir2", "child-dir2", "grandchild-dir2", "file4.txt"),
+		}
+	})
+
+	It("returns the digests in the order of the paths", func() {
+		digests, err := Sha1Files(fullPaths, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(digests).To(Equal([]string{
+			"da00d1e3757ca7c5271aa25d129579d22a576f5b",
+			"b46c0c8ea1e5ef8e46fc8894bfd4752a88ec939e",
+			"5d23fbae52ce3f99aa1ba5500adb3caa81e9e27c",
+		}))
+	})
+
+	It("hashes the files one at a time when the concurrency is not positive", func() {
+		digests, err := Sha1Files(fullPaths, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(digests).To(HaveLen(3))
+	})
+
+	It("returns an error when a file cannot be hashed", func() {
+		fullPaths = append(fullPaths, filepath.Join("does", "not", "exist"))
+
+		_, err := Sha1Files(fullPaths, 2)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -10,13 +10,15 @@ import (
 	"runtime"
 
 	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/gofileutils/fileutils"
 )
 
 //go:generate counterfeiter . Zipper
 
 type Zipper interface {
-	Zip(dirToZip string, targetFile *os.File) (err error)
+	Zip(dirToZip string, targetFile *os.File, preserveSymlinks bool) (err error)
+	ZipFiles(dir string, files []models.AppFileFields, targetFile *os.File) (err error)
 	IsZipFile(path string) bool
 	Unzip(appDir string, destDir string) (err error)
 	GetZipSize(zipFile *os.File) (int64, error)
@@ -24,7 +26,10 @@ type Zipper interface {
 
 type ApplicationZipper struct{}
 
-func (zipper ApplicationZipper) Zip(dirOrZipFilePath string, targetFile *os.File) error {
+// Zip writes the files in dirOrZipFilePath to targetFile, or copies it if it
+// already is a zip file. With preserveSymlinks, symbolic links are stored as
+// links instead of being left out.
+func (zipper ApplicationZipper) Zip(dirOrZipFilePath string, targetFile *os.File, preserveSymlinks bool) error {
 	if zipper.IsZipFile(dirOrZipFilePath) {
 		zipFile, err := os.Open(dirOrZipFilePath)
 		if err != nil {
@@ -37,7 +42,7 @@ func (zipper ApplicationZipper) Zip(dirOrZipFilePath string, targetFile *os.File
 			return err
 		}
 	} else {
-		err := writeZipFile(dirOrZipFilePath, targetFile)
+		err := writeZipFile(dirOrZipFilePath, targetFile, preserveSymlinks)
 		if err != nil {
 			return err
 		}
@@ -51,6 +56,52 @@ func (zipper ApplicationZipper) Zip(dirOrZipFilePath string, targetFile *os.File
 	return nil
 }
 
+// ZipFiles writes the given files straight from dir to targetFile, along
+// with the directories they are in. Symbolic links in the list are stored
+// as links.
+func (zipper ApplicationZipper) ZipFiles(dir string, files []models.AppFileFields, targetFile *os.File) error {
+	if len(files) == 0 {
+		return errors.NewEmptyDirError(dir)
+	}
+
+	writer := zip.NewWriter(targetFile)
+
+	zipped := map[string]bool{}
+	for _, file := range files {
+		fileName := filepath.FromSlash(file.Path)
+
+		for _, parentDir := range parentDirs(fileName) {
+			if zipped[parentDir] {
+				continue
+			}
+
+			err := writeZipEntry(writer, parentDir, zipEntryPath(dir, parentDir))
+			if err != nil {
+				return err
+			}
+			zipped[parentDir] = true
+		}
+
+		if zipped[fileName] {
+			continue
+		}
+
+		err := writeZipEntry(writer, fileName, zipEntryPath(dir, fileName))
+		if err != nil {
+			return err
+		}
+		zipped[fileName] = true
+	}
+
+	err := writer.Close()
+	if err != nil {
+		return err
+	}
+
+	_, err = targetFile.Seek(0, os.SEEK_SET)
+	return err
+}
+
 func (zipper ApplicationZipper) IsZipFile(name string) bool {
 	f, err := os.Open(name)
 	if err != nil {
@@ -136,7 +187,7 @@ func (zipper ApplicationZipper) GetZipSize(zipFile *os.File) (int64, error) {
 	return zipFileSize, nil
 }
 
-func writeZipFile(dir string, targetFile *os.File) error {
+func writeZipFile(dir string, targetFile *os.File, preserveSymlinks bool) error {
 	isEmpty, err := fileutils.IsDirEmpty(dir)
 	if err != nil {
 		return err
@@ -150,50 +201,96 @@ func writeZipFile(dir string, targetFile *os.File) error {
 	defer writer.Close()
 
 	appfiles := ApplicationFiles{}
-	return appfiles.WalkAppFiles(dir, func(fileName string, fullPath string) error {
-		fileInfo, err := os.Stat(fullPath)
-		if err != nil {
-			return err
-		}
+	return appfiles.WalkAppFiles(dir, preserveSymlinks, func(fileName string, fullPath string) error {
+		return writeZipEntry(writer, fileName, fullPath)
+	})
+}
 
-		header, err := zip.FileInfoHeader(fileInfo)
-		if err != nil {
-			return err
-		}
+func writeZipEntry(writer *zip.Writer, fileName string, fullPath string) error {
+	fileInfo, err := os.Lstat(fullPath)
+	if err != nil {
+		return err
+	}
 
-		if runtime.GOOS == "windows" {
-			header.SetMode(header.Mode() | 0700)
-		}
+	if fileInfo.Mode()&os.ModeSymlink != 0 {
+		return writeZipSymlink(writer, fileName, fullPath, fileInfo)
+	}
 
-		header.Name = filepath.ToSlash(fileName)
-		header.Method = zip.Deflate
+	header, err := zip.FileInfoHeader(fileInfo)
+	if err != nil {
+		return err
+	}
 
-		if fileInfo.IsDir() {
-			header.Name += "/"
-		}
+	if runtime.GOOS == "windows" {
+		header.SetMode(header.Mode() | 0700)
+	}
 
-		zipFilePart, err := writer.CreateHeader(header)
-		if err != nil {
-			return err
-		}
+	header.Name = filepath.ToSlash(fileName)
+	header.Method = zip.Deflate
 
-		if fileInfo.IsDir() {
-			return nil
-		}
+	if fileInfo.IsDir() {
+		header.Name += "/"
+	}
 
-		file, err := os.Open(fullPath)
-		if err != nil {
-			return err
-		}
-		defer file.Close()
+	zipFilePart, err := writer.CreateHeader(header)
+	if err != nil {
+		return err
+	}
 
-		_, err = io.Copy(zipFilePart, file)
-		if err != nil {
-			return err
+	if fileInfo.IsDir() {
+		return nil
+	}
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(zipFilePart, file)
+	return err
+}
+
+func writeZipSymlink(writer *zip.Writer, fileName string, fullPath string, fileInfo os.FileInfo) error {
+	target, err := os.Readlink(fullPath)
+	if err != nil {
+		return err
+	}
+
+	header, err := zip.FileInfoHeader(fileInfo)
+	if err != nil {
+		return err
+	}
+
+	header.Name = filepath.ToSlash(fileName)
+	header.Method = zip.Store
+
+	zipFilePart, err := writer.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(zipFilePart, filepath.ToSlash(target))
+	return err
+}
+
+func zipEntryPath(dir string, fileName string) string {
+	fullPath := filepath.Join(dir, fileName)
+	if runtime.GOOS == "windows" {
+		if absPath, err := filepath.Abs(fullPath); err == nil {
+			fullPath = windowsPathPrefix + absPath
 		}
+	}
+	return fullPath
+}
 
-		return nil
-	})
+// parentDirs returns the directories leading up to fileName, outermost first.
+func parentDirs(fileName string) []string {
+	dirs := []string{}
+	for dir := filepath.Dir(fileName); dir != "." && dir != string(filepath.Separator); dir = filepath.Dir(dir) {
+		dirs = append([]string{dir}, dirs...)
+	}
+	return dirs
 }
 
 func (zipper ApplicationZipper) zipFileHeaderLocation(name string) (int64, error) {
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	. "code.cloudfoundry.org/cli/cf/appfiles"
+	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/gofileutils/fileutils"
 
 	. "github.com/onsi/ginkgo"
@@ -130,7 +131,7 @@ var _ = Describe("Zipper", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			dir := filepath.Join(workingDir, "../../fixtures/zip/")
-			err = zipper.Zip(dir, zipFile)
+			err = zipper.Zip(dir, zipFile, false)
 			Expect(err).NotTo(HaveOccurred())
 
 			fileStat, err := zipFile.Stat()
@@ -162,7 +163,7 @@ var _ = Describe("Zipper", func() {
 			err = os.Chmod(filepath.Join(dir, "subDir/bar.txt"), 0666)
 			Expect(err).NotTo(HaveOccurred())
 
-			err = zipper.Zip(dir, zipFile)
+			err = zipper.Zip(dir, zipFile, false)
 			Expect(err).NotTo(HaveOccurred())
 
 			fileStat, err := zipFile.Stat()
@@ -187,7 +188,7 @@ var _ = Describe("Zipper", func() {
 			err = os.Chmod(filepath.Join(dir, "subDir/bar.txt"), 0666)
 			Expect(err).NotTo(HaveOccurred())
 
-			err = zipper.Zip(dir, zipFile)
+			err = zipper.Zip(dir, zipFile, false)
 			Expect(err).NotTo(HaveOccurred())
 
 			fileStat, err := zipFile.Stat()
@@ -206,7 +207,7 @@ var _ = Describe("Zipper", func() {
 
 			zipper := ApplicationZipper{}
 			fixture := filepath.Join(dir, "../../fixtures/applications/example-app.zip")
-			err = zipper.Zip(fixture, zipFile)
+			err = zipper.Zip(fixture, zipFile, false)
 			Expect(err).NotTo(HaveOccurred())
 
 			zippedFile, err := os.Open(fixture)
@@ -223,7 +224,7 @@ var _ = Describe("Zipper", func() {
 			Expect(err).NotTo(HaveOccurred())
 			originalFileSize := fileStat.Size()
 
-			err = zipper.Zip(dir, zipFile)
+			err = zipper.Zip(dir, zipFile, false)
 			Expect(err).NotTo(HaveOccurred())
 
 			fileStat, err = zipFile.Stat()
@@ -233,9 +234,62 @@ var _ = Describe("Zipper", func() {
 			Expect(compressedFileSize).To(BeNumerically("<", originalFileSize))
 		})
 
+		Context("when the directory contains symbolic links", func() {
+			var dir string
+
+			BeforeEach(func() {
+				if runtime.GOOS == "windows" {
+					Skip("This test does not run on Windows")
+				}
+
+				var err error
+				dir, err = ioutil.TempDir("", "zip_symlinks")
+				Expect(err).NotTo(HaveOccurred())
+
+				err = ioutil.WriteFile(filepath.Join(dir, "target.txt"), []byte("some content"), 0644)
+				Expect(err).NotTo(HaveOccurred())
+				err = os.Symlink("target.txt", filepath.Join(dir, "link.txt"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("leaves the links out by default", func() {
+				err := zipper.Zip(dir, zipFile, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				fileStat, err := zipFile.Stat()
+				Expect(err).NotTo(HaveOccurred())
+
+				reader, err := zip.NewReader(zipFile, fileStat.Size())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reader.File).To(HaveLen(1))
+				Expect(reader.File[0].Name).To(Equal("target.txt"))
+			})
+
+			It("stores the links as links when preserving them", func() {
+				err := zipper.Zip(dir, zipFile, true)
+				Expect(err).NotTo(HaveOccurred())
+
+				fileStat, err := zipFile.Stat()
+				Expect(err).NotTo(HaveOccurred())
+
+				reader, err := zip.NewReader(zipFile, fileStat.Size())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(reader.File).To(HaveLen(2))
+
+				name, contents := readFileInZip(0, reader)
+				Expect(name).To(Equal("link.txt"))
+				Expect(contents).To(Equal("target.txt"))
+				Expect(reader.File[0].FileInfo().Mode() & os.ModeSymlink).NotTo(BeZero())
+			})
+		})
+
 		It("returns an error when zipping fails", func() {
 			zipper := ApplicationZipper{}
-			err := zipper.Zip("/a/bogus/directory", zipFile)
+			err := zipper.Zip("/a/bogus/directory", zipFile, false)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("open /a/bogus/directory"))
 		})
@@ -243,13 +297,108 @@ var _ = Describe("Zipper", func() {
 		It("returns an error when the directory is empty", func() {
 			fileutils.TempDir("zip_test", func(emptyDir string, err error) {
 				zipper := ApplicationZipper{}
-				err = zipper.Zip(emptyDir, zipFile)
+				err = zipper.Zip(emptyDir, zipFile, false)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("is empty"))
 			})
 		})
 	})
 
+	Describe("ZipFiles", func() {
+		var (
+			zipFile *os.File
+			zipper  ApplicationZipper
+			dir     string
+		)
+
+		BeforeEach(func() {
+			var err error
+			zipFile, err = ioutil.TempFile("", "zip_test")
+			Expect(err).NotTo(HaveOccurred())
+
+			zipper = ApplicationZipper{}
+
+			workingDir, err := os.Getwd()
+			Expect(err).NotTo(HaveOccurred())
+			dir = filepath.Join(workingDir, "../../fixtures/applications/app-copy-test")
+		})
+
+		AfterEach(func() {
+			zipFile.Close()
+			os.Remove(zipFile.Name())
+		})
+
+		It("zips only the given files, along with the directories they are in", func() {
+			err := zipper.ZipFiles(dir, []models.AppFileFields{
+				{Path: "dir1/child-dir/file2.txt"},
+				{Path: "dir2/child-dir2"},
+			}, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+
+			fileStat, err := zipFile.Stat()
+			Expect(err).NotTo(HaveOccurred())
+
+			reader, err := zip.NewReader(zipFile, fileStat.Size())
+			Expect(err).NotTo(HaveOccurred())
+
+			filenames := []string{}
+			for _, file := range reader.File {
+				filenames = append(filenames, file.Name)
+			}
+			Expect(filenames).To(Equal([]string{
+				"dir1/",
+				"dir1/child-dir/",
+				"dir1/child-dir/file2.txt",
+				"dir2/",
+				"dir2/child-dir2/",
+			}))
+
+			expectedContents, err := ioutil.ReadFile(filepath.Join(dir, "dir1", "child-dir", "file2.txt"))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, contents := readFileInZip(2, reader)
+			Expect(contents).To(Equal(string(expectedContents)))
+		})
+
+		It("stores symbolic links as links", func() {
+			if runtime.GOOS == "windows" {
+				Skip("This test does not run on Windows")
+			}
+
+			fileutils.TempDir("zip_symlinks", func(symlinkDir string, err error) {
+				Expect(err).NotTo(HaveOccurred())
+
+				err = os.Symlink("target.txt", filepath.Join(symlinkDir, "link.txt"))
+				Expect(err).NotTo(HaveOccurred())
+
+				err = zipper.ZipFiles(symlinkDir, []models.AppFileFields{{Path: "link.txt", Symlink: true}}, zipFile)
+				Expect(err).NotTo(HaveOccurred())
+
+				fileStat, err := zipFile.Stat()
+				Expect(err).NotTo(HaveOccurred())
+
+				reader, err := zip.NewReader(zipFile, fileStat.Size())
+				Expect(err).NotTo(HaveOccurred())
+
+				name, contents := readFileInZip(0, reader)
+				Expect(name).To(Equal("link.txt"))
+				Expect(contents).To(Equal("target.txt"))
+				Expect(reader.File[0].FileInfo().Mode() & os.ModeSymlink).NotTo(BeZero())
+			})
+		})
+
+		It("returns an error when there are no files to zip", func() {
+			err := zipper.ZipFiles(dir, []models.AppFileFields{}, zipFile)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is empty"))
+		})
+
+		It("returns an error when a file cannot be read", func() {
+			err := zipper.ZipFiles(dir, []models.AppFileFields{{Path: "does-not-exist"}}, zipFile)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("IsZipFile", func() {
 		var (
 			inDir, outDir string
@@ -1,15 +1,19 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"runtime"
 	"strings"
+	"time"
 
 	"path/filepath"
 
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/commandsloader"
+	"code.cloudfoundry.org/cli/cf/completion"
 	"code.cloudfoundry.org/cli/cf/configuration"
 	"code.cloudfoundry.org/cli/cf/configuration/confighelpers"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -31,6 +35,13 @@ var cmdRegistry = commandregistry.Commands
 
 func Main(traceEnv string, args []string) {
 
+	//handles `cf __complete WORD...`, run by the completion scripts, before
+	//any of its words are taken as options of cf
+	if len(args) > 1 && args[1] == completion.CompleteCommand {
+		complete(args[2:])
+		return
+	}
+
 	//handle `cf -v` for cf version
 	if len(args) == 2 && (args[1] == "-v" || args[1] == "--version") {
 		args[1] = "version"
@@ -48,25 +59,79 @@ func Main(traceEnv string, args []string) {
 	newArgs, isVerbose := handleVerbose(args)
 	args = newArgs
 
+	cliArgs := newCfArgs(args)
+
+	traceFlag, _ := cliArgs.take("trace", func(args []string) ([]string, string, error) {
+		args, trace := handleTrace(args)
+		return args, trace, nil
+	})
+	if traceFlag != "" {
+		traceEnv = traceFlag
+	}
+
+	nonInteractive, _ := cliArgs.take("non-interactive", func(args []string) ([]string, string, error) {
+		args, nonInteractive := handleNonInteractive(args)
+		if nonInteractive {
+			return args, "true", nil
+		}
+		return args, "", nil
+	})
+	if nonInteractive != "" {
+		os.Setenv("CF_NON_INTERACTIVE", "true")
+	}
+
+	newUI := func() terminal.UI {
+		return terminal.NewUI(
+			os.Stdin,
+			Writer,
+			terminal.NewTeePrinter(Writer),
+			trace.NewLogger(Writer, isVerbose, traceEnv, ""),
+		)
+	}
+
 	errFunc := func(err error) {
 		if err != nil {
-			ui := terminal.NewUI(
-				os.Stdin,
-				Writer,
-				terminal.NewTeePrinter(Writer),
-				trace.NewLogger(Writer, isVerbose, traceEnv, ""),
-			)
-			ui.Failed(fmt.Sprintf("Config error: %s", err))
+			newUI().Failed(fmt.Sprintf("Config error: %s", err))
 			os.Exit(1)
 		}
 	}
 
+	globalOptions := map[string]string{}
+	for _, name := range []string{"context", "org", "space"} {
+		name := name
+		var optionErr error
+		globalOptions[name], optionErr = cliArgs.take(name, func(args []string) ([]string, string, error) {
+			return handleValueOption(args, name)
+		})
+		if optionErr != nil {
+			newUI().Failed(optionErr.Error())
+			os.Exit(1)
+		}
+	}
+	contextName, orgName, spaceName := globalOptions["context"], globalOptions["org"], globalOptions["space"]
+
+	// commands with an --output flag of their own, such as push, handle it
+	// themselves
+	outputFormat, outputErr := cliArgs.take("output", handleOutputFormat)
+	if outputErr != nil {
+		newUI().Failed(outputErr.Error())
+		os.Exit(1)
+	}
+
+	args = cliArgs.args()
+
+	if contextName != "" {
+		// the config of every part of the CLI, including plugins calling
+		// back into it, is read from the context
+		os.Setenv("CF_CONTEXT", contextName)
+	}
+
 	// Only used to get Trace, so our errorHandler doesn't matter, since it's not used
 	configPath, err := confighelpers.DefaultFilePath()
 	if err != nil {
 		errFunc(err)
 	}
-	config := coreconfig.NewRepositoryFromFilepath(configPath, errFunc)
+	config := coreconfig.NewContextRepositoryFromFilepath(configPath, os.Getenv("CF_CONTEXT"), errFunc)
 	defer config.Close()
 
 	traceConfigVal := config.Trace()
@@ -86,6 +151,14 @@ func Main(traceEnv string, args []string) {
 
 	commandsloader.Load()
 
+	if orgName != "" || spaceName != "" {
+		err = requirements.NewFactory(deps.Config, deps.RepoLocator).NewTargetOverrideRequirement(orgName, spaceName).Execute()
+		if err != nil {
+			deps.UI.Failed(err.Error())
+			os.Exit(1)
+		}
+	}
+
 	//run core command
 	cmdName := args[1]
 	cmd := cmdRegistry.FindCommand(cmdName)
@@ -94,13 +167,21 @@ func Main(traceEnv string, args []string) {
 		flagContext := flags.NewFlagContext(meta.Flags)
 		flagContext.SkipFlagParsing(meta.SkipFlagParsing)
 
-		cmdArgs := args[2:]
-		err = flagContext.Parse(cmdArgs...)
+		err = flagContext.Parse(args[2:]...)
 		if err != nil {
 			usage := cmdRegistry.CommandUsage(cmdName)
 			deps.UI.Failed(T("Incorrect Usage") + "\n\n" + err.Error() + "\n\n" + usage)
 		}
 
+		if outputFormat != "" && outputFormat != terminal.OutputFormatTable {
+			if !meta.StructuredOutput {
+				deps.UI.Failed(T("Command {{.CommandName}} does not support --output {{.Format}}",
+					map[string]interface{}{"CommandName": cmdName, "Format": outputFormat}))
+				os.Exit(1)
+			}
+			deps.UI = terminal.NewFormattedUI(deps.UI, outputFormat)
+		}
+
 		cmd = cmd.SetDependency(deps, false)
 		cmdRegistry.SetCommand(cmd)
 
@@ -124,10 +205,21 @@ func Main(traceEnv string, args []string) {
 			os.Exit(1)
 		}
 
-		err = warningsCollector.PrintWarnings()
-		if err != nil {
-			deps.UI.Failed(err.Error())
-			os.Exit(1)
+		if printer, ok := deps.UI.(terminal.DataPrinter); ok {
+			err = printer.Flush()
+			if err != nil {
+				deps.UI.Failed(err.Error())
+				os.Exit(1)
+			}
+		}
+
+		// warnings would break the JSON or YAML output of the command
+		if _, ok := deps.UI.(terminal.DataPrinter); !ok {
+			err = warningsCollector.PrintWarnings()
+			if err != nil {
+				deps.UI.Failed(err.Error())
+				os.Exit(1)
+			}
 		}
 
 		os.Exit(0)
@@ -151,7 +243,11 @@ func Main(traceEnv string, args []string) {
 	)
 	pluginList := pluginConfig.Plugins()
 
-	ran := rpc.RunMethodIfExists(rpcService, args[1:], pluginList)
+	ran, err := rpc.RunMethodIfExists(rpcService, args[1:], pluginList)
+	if err != nil {
+		deps.UI.Failed(err.Error())
+		os.Exit(1)
+	}
 	if !ran {
 		deps.UI.Say("'" + args[1] + T("' is not a registered command. See 'cf help'"))
 		suggestCommands(cmdName, deps.UI, append(cmdRegistry.ListCommands(), pluginConfig.ListCommands()...))
@@ -159,6 +255,43 @@ func Main(traceEnv string, args []string) {
 	}
 }
 
+// complete prints the candidates for the last of words, one per line, and
+// nothing else, as the output is read by the shell.
+func complete(words []string) {
+	logger := trace.NewLogger(ioutil.Discard, false, "", "")
+	deps := commandregistry.NewDependency(ioutil.Discard, logger, os.Getenv("CF_DIAL_TIMEOUT"))
+	defer deps.Config.Close()
+
+	commandsloader.Load()
+
+	pluginPath := filepath.Join(confighelpers.PluginRepoDir(), ".cf", "plugins")
+	pluginConfig := pluginconfig.NewPluginConfig(
+		func(err error) {},
+		configuration.NewDiskPersistor(filepath.Join(pluginPath, "config.json")),
+		pluginPath,
+	)
+
+	cachePath := ""
+	configPath, err := confighelpers.DefaultFilePath()
+	if err == nil {
+		cachePath = filepath.Join(filepath.Dir(configPath), "completion-cache.json")
+	}
+
+	lookup := completion.NewAPILookup(
+		deps.Config,
+		deps.RepoLocator.GetAppSummaryRepository(),
+		deps.RepoLocator.GetOrganizationRepository(),
+		deps.RepoLocator.GetSpaceRepository(),
+		cachePath,
+		time.Now,
+	)
+	completer := completion.NewCompleter(cmdRegistry.Metadatas(), pluginConfig.ListCommands(), lookup)
+
+	for _, candidate := range completer.Complete(words) {
+		fmt.Fprintln(Writer, candidate)
+	}
+}
+
 func suggestCommands(cmdName string, ui terminal.UI, cmdsList []string) {
 	cmdSuggester := spellcheck.NewCommandSuggester(cmdsList)
 	recommendedCmds := cmdSuggester.Recommend(cmdName)
@@ -236,3 +369,191 @@ func handleVerbose(args []string) ([]string, bool) {
 
 	return args, verbose
 }
+
+// cfArgs are the args of cf split at the command name, so that global
+// options can be taken out of them depending on where they are given.
+type cfArgs struct {
+	program string
+	// options are the args before the command name, which can only be
+	// global options
+	options []string
+	// command is the command name followed by its args
+	command []string
+	// meta is the metadata of the command, or nil when it is not a core
+	// command
+	meta *commandregistry.CommandMetadata
+}
+
+// newCfArgs splits args at the command name: the first argument that is
+// neither an option nor the value of one of the global options.
+func newCfArgs(args []string) *cfArgs {
+	i := 1
+	for i < len(args) && strings.HasPrefix(args[i], "-") {
+		switch args[i] {
+		case "--context", "--org", "--space", "--output":
+			i += 2
+		default:
+			i++
+		}
+	}
+	if i > len(args) {
+		i = len(args)
+	}
+
+	a := &cfArgs{
+		program: args[0],
+		options: args[1:i],
+		command: args[i:],
+	}
+	if len(a.command) > 0 {
+		if cmd := cmdRegistry.FindCommand(a.command[0]); cmd != nil {
+			meta := cmd.MetaData()
+			a.meta = &meta
+		}
+	}
+
+	return a
+}
+
+// take removes the global option --name with handle and returns its value.
+// The option is always taken from before the command name, and from after it
+// only when the command takes it (see takesGlobalOption); a value given after
+// the command name wins.
+func (a *cfArgs) take(name string, handle func([]string) ([]string, string, error)) (string, error) {
+	var (
+		value string
+		err   error
+	)
+	a.options, value, err = handle(a.options)
+	if err != nil {
+		return "", err
+	}
+
+	if takesGlobalOption(a.meta, name) {
+		var commandValue string
+		a.command, commandValue, err = handle(a.command)
+		if err != nil {
+			return "", err
+		}
+		if commandValue != "" {
+			value = commandValue
+		}
+	}
+
+	return value, nil
+}
+
+// args returns the args of cf with the global options that were taken
+// removed.
+func (a *cfArgs) args() []string {
+	args := append([]string{a.program}, a.options...)
+	return append(args, a.command...)
+}
+
+// takesGlobalOption reports whether the global option --name is taken out of
+// the args of the command with the given metadata. Plugins, core commands
+// that parse their args themselves and core commands with a flag of that
+// name, such as config with --trace, get the option in their args.
+func takesGlobalOption(meta *commandregistry.CommandMetadata, name string) bool {
+	if meta == nil || meta.SkipFlagParsing {
+		return false
+	}
+
+	_, ownFlag := meta.Flags[name]
+	return !ownFlag
+}
+
+// handleTrace removes the global --trace option from args and returns where
+// to trace to: "true" for stdout, or the file named by --trace=FILE. The
+// file has to be given with "=" so that it is not taken for an argument of
+// the command.
+func handleTrace(args []string) ([]string, string) {
+	trace := ""
+	newArgs := []string{}
+
+	for _, arg := range args {
+		switch {
+		case arg == "--trace":
+			trace = "true"
+		case strings.HasPrefix(arg, "--trace="):
+			trace = strings.TrimPrefix(arg, "--trace=")
+		default:
+			newArgs = append(newArgs, arg)
+		}
+	}
+
+	return newArgs, trace
+}
+
+// handleNonInteractive removes the global --non-interactive option from args
+// and returns whether it was given.
+func handleNonInteractive(args []string) ([]string, bool) {
+	nonInteractive := false
+	newArgs := []string{}
+
+	for _, arg := range args {
+		if arg == "--non-interactive" {
+			nonInteractive = true
+		} else {
+			newArgs = append(newArgs, arg)
+		}
+	}
+
+	return newArgs, nonInteractive
+}
+
+// handleValueOption removes the global option --NAME VALUE, or --NAME=VALUE,
+// from args and returns its value.
+func handleValueOption(args []string, name string) ([]string, string, error) {
+	value := ""
+	newArgs := []string{}
+
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--"+name:
+			if i+1 == len(args) {
+				return nil, "", errors.New(T("Option '--{{.Option}}' requires a value",
+					map[string]interface{}{"Option": name}))
+			}
+			value = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--"+name+"="):
+			value = strings.TrimPrefix(args[i], "--"+name+"=")
+		default:
+			newArgs = append(newArgs, args[i])
+		}
+	}
+
+	return newArgs, value, nil
+}
+
+// handleOutputFormat removes the global --output option from args and
+// returns the format it names.
+func handleOutputFormat(args []string) ([]string, string, error) {
+	format := ""
+	newArgs := []string{}
+
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--output":
+			if i+1 == len(args) {
+				return nil, "", errors.New(T("Option '--output' requires a format: {{.Formats}}",
+					map[string]interface{}{"Formats": strings.Join(terminal.OutputFormats, ", ")}))
+			}
+			format = args[i+1]
+			i++
+		case strings.HasPrefix(args[i], "--output="):
+			format = strings.TrimPrefix(args[i], "--output=")
+		default:
+			newArgs = append(newArgs, args[i])
+			continue
+		}
+
+		if !terminal.IsValidOutputFormat(format) {
+			return nil, "", errors.New(T("Invalid output format {{.Format}}; it must be one of {{.Formats}}",
+				map[string]interface{}{"Format": format, "Formats": strings.Join(terminal.OutputFormats, ", ")}))
+		}
+	}
+
+	return newArgs, format, nil
+}
@@ -2,6 +2,8 @@ package cmd_test
 
 import (
 	"bufio"
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"os/exec"
@@ -166,6 +168,93 @@ var _ = Describe("main", func() {
 		})
 	})
 
+	Describe("the global --output option", func() {
+		It("fails for commands that cannot print structured output", func() {
+			output := Cf("create-space", "my-space", "--output", "json")
+			Eventually(output).Should(Exit(1))
+			Expect(output.Out).To(Say("Command create-space does not support --output json"))
+		})
+
+		It("fails for unknown formats", func() {
+			output := Cf("apps", "--output=xml")
+			Eventually(output).Should(Exit(1))
+			Expect(output.Out).To(Say("Invalid output format xml; it must be one of table, json, yaml"))
+		})
+
+		It("is taken from before the command name", func() {
+			output := Cf("--output", "json", "create-space", "my-space")
+			Eventually(output).Should(Exit(1))
+			Expect(output.Out).To(Say("Command create-space does not support --output json"))
+
+			output = Cf("--output", "xml", "apps")
+			Eventually(output).Should(Exit(1))
+			Expect(output.Out).To(Say("Invalid output format xml; it must be one of table, json, yaml"))
+			Expect(output.Out).NotTo(Say("not a registered command"))
+		})
+
+		It("is left in the args of commands that parse their args themselves", func() {
+			output := Cf("set-env", "my-app", "MY_VAR", "--output")
+			Eventually(output).Should(Exit(1))
+			Expect(output.Out).NotTo(Say("Option '--output' requires a format"))
+		})
+	})
+
+	Describe("the global --trace option", func() {
+		var cfHome string
+
+		BeforeEach(func() {
+			var err error
+			cfHome, err = ioutil.TempDir("", "cf-home")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(cfHome)
+		})
+
+		It("is left to commands with a --trace flag of their own", func() {
+			tracePath := filepath.Join(cfHome, "trace.log")
+			output := CfWith_CF_HOME(cfHome, "config", "--trace", tracePath)
+			Eventually(output).Should(Exit(0))
+			Expect(output.Out).NotTo(Say("Incorrect Usage"))
+
+			contents, err := ioutil.ReadFile(filepath.Join(cfHome, ".cf", "config.json"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var config struct{ Trace string }
+			Expect(json.Unmarshal(contents, &config)).To(Succeed())
+			Expect(config.Trace).To(Equal(tracePath))
+		})
+	})
+
+	Describe("the global --org and --space options", func() {
+		It("are left in the args of commands that parse their args themselves", func() {
+			output := Cf("set-env", "my-app", "MY_VAR", "--org")
+			Eventually(output).Should(Exit(1))
+			Expect(output.Err).NotTo(Say("expected argument for flag"))
+			Expect(output.Out).NotTo(Say("Option '--org' requires a value"))
+		})
+
+		It("are taken from before the name of commands that parse their args themselves", func() {
+			for _, option := range []string{"--org", "--context"} {
+				output := Cf(option, "some-value", "set-env", "my-app", "MY_VAR", "my-value")
+				Eventually(output).Should(Exit(1))
+				Expect(output.Out).NotTo(Say("not a registered command"))
+			}
+		})
+
+		It("are told apart from the --all-in-space and --all-in-org flags of events", func() {
+			output := Cf("events", "--all-in-space", "--type", "audit.app.update")
+			Eventually(output).Should(Exit(1))
+			Expect(output.Out).NotTo(Say("Incorrect Usage"))
+			Expect(output.Out).To(Say("No API endpoint set"))
+
+			output = Cf("events", "--all-in-space", "--space")
+			Eventually(output).Should(Exit(1))
+			Expect(output.Err).To(Say("expected argument for flag `--space'"))
+		})
+	})
+
 	It("can print help menu by executing only the command `cf`", func() {
 		output := Cf()
 		Eventually(output.Out.Contents).Should(ContainSubstring("Cloud Foundry command line tool"))
@@ -244,6 +333,25 @@ var _ = Describe("main", func() {
 			Eventually(output.Out).Should(Say("FOO"))
 		})
 
+		It("Passes the global --org, --space, --context and --non-interactive options to a plugin untouched", func() {
+			for _, option := range []string{"--org", "--space", "--context", "--non-interactive"} {
+				output := Cf("my-say", option)
+				Eventually(output).Should(Exit(0))
+				Expect(output.Out).To(Say(option))
+				Expect(output.Out).NotTo(Say("requires a value"))
+			}
+		})
+
+		It("Takes the global options given before the name of a plugin command", func() {
+			output := Cf("--non-interactive", "my-say", "hi")
+			Eventually(output).Should(Exit(0))
+			Expect(output.Out).To(Say("hi"))
+
+			output = Cf("--context", "prod", "my-say", "hi")
+			Eventually(output).Should(Exit())
+			Expect(output.Out).NotTo(Say("not a registered command"))
+		})
+
 		It("Calls a plugin that calls core commands", func() {
 			output := Cf("awesomeness")
 			Eventually(output.Out).Should(Say("my-say")) //look for another plugin
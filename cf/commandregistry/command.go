@@ -24,4 +24,8 @@ type CommandMetadata struct {
 	TotalArgs       int //Optional: number of required arguments to skip for flag verification
 	Hidden          bool
 	Examples        []string
+
+	// StructuredOutput is set by commands that can print what they show as
+	// JSON or YAML when given the global --output option.
+	StructuredOutput bool
 }
@@ -19,6 +19,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/configuration/confighelpers"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
+	"code.cloudfoundry.org/cli/cf/hooks"
 	"code.cloudfoundry.org/cli/cf/manifest"
 	"code.cloudfoundry.org/cli/cf/net"
 	"code.cloudfoundry.org/cli/cf/terminal"
@@ -30,6 +31,7 @@ import (
 
 type Dependency struct {
 	UI                 terminal.UI
+	Picker             terminal.Picker
 	Config             coreconfig.Repository
 	RepoLocator        api.RepositoryLocator
 	PluginConfig       pluginconfig.PluginConfiguration
@@ -47,9 +49,18 @@ type Dependency struct {
 	WordGenerator      generator.WordGenerator
 	AppZipper          appfiles.Zipper
 	AppFiles           appfiles.AppFiles
+	AppFilesHashCache  appfiles.HashCache
 	PushActor          actors.PushActor
 	RouteActor         actors.RouteActor
-	ChecksumUtil       utils.Sha1Checksum
+	BlueGreenDeployer  actors.BlueGreenDeployer
+	TaskActor          actors.TaskActor
+	DeploymentActor    actors.DeploymentActor
+	MetadataActor      actors.MetadataActor
+	CopySourceActor    actors.CopySourceActor
+	ContextRepoLocator func(name string) (coreconfig.Repository, api.RepositoryLocator, error)
+	RenameChecker      actors.RenameChecker
+	HookRunner         hooks.Runner
+	ChecksumUtil       utils.Sha256Checksum
 	WildcardDependency interface{} //use for injecting fakes
 	Logger             trace.Printer
 }
@@ -66,12 +77,28 @@ type PluginModels struct {
 	Services      *[]plugin_models.GetServices_Model
 	Service       *plugin_models.GetService_Model
 	OauthToken    *plugin_models.GetOauthToken_Model
+	PushedApps    *[]plugin_models.PushAppModel
 }
 
 func NewDependency(writer io.Writer, logger trace.Printer, envDialTimeout string) Dependency {
+	return NewDependencyWithConfig(writer, logger, envDialTimeout, "", os.Getenv("CF_CONTEXT"))
+}
+
+// NewDependencyWithConfig is NewDependency reading the config at configPath,
+// or at the default path if it is empty, such as the config of a sandboxed
+// plugin.
+func NewDependencyWithConfig(writer io.Writer, logger trace.Printer, envDialTimeout string, configPath string, context string) Dependency {
 	deps := Dependency{}
 	deps.TeePrinter = terminal.NewTeePrinter(writer)
 	deps.UI = terminal.NewUI(os.Stdin, writer, deps.TeePrinter, logger)
+	deps.Picker = terminal.NewPicker(os.Stdin, os.Stdout)
+
+	// set by the global --non-interactive option too, so that plugins and the
+	// commands they run are non-interactive as well
+	if os.Getenv("CF_NON_INTERACTIVE") == "true" {
+		deps.UI = terminal.NewNonInteractiveUI(deps.UI, os.Exit)
+		deps.Picker = terminal.NewNonInteractivePicker()
+	}
 
 	errorHandler := func(err error) {
 		if err != nil {
@@ -79,11 +106,14 @@ func NewDependency(writer io.Writer, logger trace.Printer, envDialTimeout string
 		}
 	}
 
-	configPath, err := confighelpers.DefaultFilePath()
-	if err != nil {
-		errorHandler(err)
+	if configPath == "" {
+		var err error
+		configPath, err = confighelpers.DefaultFilePath()
+		if err != nil {
+			errorHandler(err)
+		}
 	}
-	deps.Config = coreconfig.NewRepositoryFromFilepath(configPath, errorHandler)
+	deps.Config = coreconfig.NewContextRepositoryFromFilepath(configPath, context, errorHandler)
 
 	deps.ManifestRepo = manifest.NewDiskRepository()
 	deps.AppManifest = manifest.NewGenerator()
@@ -98,13 +128,34 @@ func NewDependency(writer io.Writer, logger trace.Printer, envDialTimeout string
 	terminal.UserAskedForColors = deps.Config.ColorEnabled()
 	terminal.InitColorSupport()
 
-	deps.Gateways = map[string]net.Gateway{
-		"cloud-controller": net.NewCloudControllerGateway(deps.Config, time.Now, deps.UI, logger, envDialTimeout),
-		"uaa":              net.NewUAAGateway(deps.Config, deps.UI, logger, envDialTimeout),
-		"routing-api":      net.NewRoutingAPIGateway(deps.Config, time.Now, deps.UI, logger, envDialTimeout),
+	newGateways := func(config coreconfig.Reader) map[string]net.Gateway {
+		return map[string]net.Gateway{
+			"cloud-controller": net.NewCloudControllerGateway(config, time.Now, deps.UI, logger, envDialTimeout),
+			"uaa":              net.NewUAAGateway(config, deps.UI, logger, envDialTimeout),
+			"routing-api":      net.NewRoutingAPIGateway(config, time.Now, deps.UI, logger, envDialTimeout),
+			"networking":       net.NewNetworkingGateway(config, time.Now, deps.UI, logger, envDialTimeout),
+		}
 	}
+	deps.Gateways = newGateways(deps.Config)
 	deps.RepoLocator = api.NewRepositoryLocator(deps.Config, deps.Gateways, logger)
 
+	// commands that work with a second target, such as copy-source, reach it
+	// through a context saved with target-save
+	deps.ContextRepoLocator = func(name string) (coreconfig.Repository, api.RepositoryLocator, error) {
+		var configErr error
+		config := coreconfig.NewContextRepositoryFromFilepath(configPath, name, func(err error) {
+			configErr = err
+		})
+
+		// the config, and so the context, is loaded on first use
+		config.APIEndpoint()
+		if configErr != nil {
+			return nil, api.RepositoryLocator{}, configErr
+		}
+
+		return config, api.NewRepositoryLocator(config, newGateways(config), logger), nil
+	}
+
 	deps.PluginModels = &PluginModels{Application: nil}
 
 	deps.PlanBuilder = planbuilder.NewBuilder(
@@ -143,11 +194,29 @@ func NewDependency(writer io.Writer, logger trace.Printer, envDialTimeout string
 
 	deps.AppZipper = appfiles.ApplicationZipper{}
 	deps.AppFiles = appfiles.ApplicationFiles{}
+	deps.AppFilesHashCache = appfiles.NewHashCache(filepath.Join(filepath.Dir(configPath), "hashes"))
 
 	deps.RouteActor = actors.NewRouteActor(deps.UI, deps.RepoLocator.GetRouteRepository(), deps.RepoLocator.GetDomainRepository())
-	deps.PushActor = actors.NewPushActor(deps.RepoLocator.GetApplicationBitsRepository(), deps.AppZipper, deps.AppFiles, deps.RouteActor)
+	uploadStatePath := filepath.Join(filepath.Dir(configPath), "uploads")
+	deps.PushActor = actors.NewPushActor(deps.RepoLocator.GetApplicationBitsRepository(), deps.AppZipper, deps.AppFiles, deps.RouteActor, actors.NewUploadStateStore(uploadStatePath))
+
+	deps.BlueGreenDeployer = actors.NewBlueGreenDeployer(deps.UI, deps.RepoLocator.GetApplicationRepository(), deps.RepoLocator.GetRouteRepository())
+
+	deps.TaskActor = actors.NewTaskActor(deps.RepoLocator.GetTaskRepository())
+	// an invalid CF_STARTUP_TIMEOUT is reported by the start command
+	deploymentTimeout := actors.DefaultStartupTimeout
+	if timeouts, err := actors.StartTimeoutsFromEnv(); err == nil {
+		deploymentTimeout = timeouts.Startup
+	}
+	deps.DeploymentActor = actors.NewDeploymentActor(deps.RepoLocator.GetDeploymentRepository(), deps.RepoLocator.GetBuildRepository(), 2*time.Second, deploymentTimeout)
+	deps.MetadataActor = actors.NewMetadataActor(deps.RepoLocator.GetMetadataRepository(), deps.Config)
+	deps.CopySourceActor = actors.NewCopySourceActor(deps.RepoLocator.GetCopyApplicationSourceRepository(), deps.RepoLocator.GetAppSummaryRepository())
+
+	deps.RenameChecker = actors.NewRenameChecker(deps.PluginConfig)
+
+	deps.HookRunner = hooks.NewRunner()
 
-	deps.ChecksumUtil = utils.NewSha1Checksum("")
+	deps.ChecksumUtil = utils.NewSha256Checksum("")
 
 	deps.Logger = logger
 
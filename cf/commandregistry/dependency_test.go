@@ -18,6 +18,7 @@ var _ = Describe("Dependency", func() {
 		dependency = commandregistry.NewDependency(os.Stdout, fakeLogger, "")
 
 		Expect(dependency.UI).ToNot(BeNil())
+		Expect(dependency.Picker).ToNot(BeNil())
 		Expect(dependency.Config).ToNot(BeNil())
 		Expect(dependency.RepoLocator).ToNot(BeNil())
 		Expect(dependency.PluginConfig).ToNot(BeNil())
@@ -38,4 +39,20 @@ var _ = Describe("Dependency", func() {
 		Expect(dependency.PushActor).ToNot(BeNil())
 		Expect(dependency.ChecksumUtil).ToNot(BeNil())
 	})
+
+	Context("when CF_NON_INTERACTIVE is true", func() {
+		BeforeEach(func() {
+			os.Setenv("CF_NON_INTERACTIVE", "true")
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("CF_NON_INTERACTIVE")
+		})
+
+		It("does not let the user pick from lists", func() {
+			dependency = commandregistry.NewDependency(os.Stdout, new(tracefakes.FakePrinter), "")
+
+			Expect(dependency.Picker.Interactive()).To(BeFalse())
+		})
+	})
 })
@@ -2,14 +2,20 @@ package application
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/plugin/models"
 
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
 	"code.cloudfoundry.org/cli/cf/api"
 	"code.cloudfoundry.org/cli/cf/api/appinstances"
+	"code.cloudfoundry.org/cli/cf/api/deployments"
+	"code.cloudfoundry.org/cli/cf/api/processes"
+	"code.cloudfoundry.org/cli/cf/api/sidecars"
 	"code.cloudfoundry.org/cli/cf/api/stacks"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -32,7 +38,11 @@ type ShowApp struct {
 	config           coreconfig.Reader
 	appSummaryRepo   api.AppSummaryRepository
 	appInstancesRepo appinstances.Repository
+	processRepo      processes.Repository
+	sidecarRepo      sidecars.Repository
+	deploymentRepo   deployments.Repository
 	stackRepo        stacks.StackRepository
+	metadataActor    actors.MetadataActor
 	appReq           requirements.ApplicationRequirement
 	pluginAppModel   *plugin_models.GetAppModel
 	pluginCall       bool
@@ -50,9 +60,14 @@ func (cmd *ShowApp) MetaData() commandregistry.CommandMetadata {
 		Name:        "app",
 		Description: T("Display health and status for app"),
 		Usage: []string{
-			T("CF_NAME app APP_NAME"),
+			T("CF_NAME app APP_NAME [--guid]"),
 		},
-		Flags: fs,
+		Examples: []string{
+			"CF_NAME app my-app",
+			"CF_NAME app my-app --output json",
+		},
+		Flags:            fs,
+		StructuredOutput: true,
 	}
 }
 
@@ -78,7 +93,11 @@ func (cmd *ShowApp) SetDependency(deps commandregistry.Dependency, pluginCall bo
 	cmd.config = deps.Config
 	cmd.appSummaryRepo = deps.RepoLocator.GetAppSummaryRepository()
 	cmd.appInstancesRepo = deps.RepoLocator.GetAppInstancesRepository()
+	cmd.processRepo = deps.RepoLocator.GetProcessRepository()
+	cmd.sidecarRepo = deps.RepoLocator.GetSidecarRepository()
+	cmd.deploymentRepo = deps.RepoLocator.GetDeploymentRepository()
 	cmd.stackRepo = deps.RepoLocator.GetStackRepository()
+	cmd.metadataActor = deps.MetadataActor
 
 	cmd.pluginAppModel = deps.PluginModels.Application
 	cmd.pluginCall = pluginCall
@@ -131,7 +150,36 @@ func (cmd *ShowApp) ShowApp(app models.Application, orgName, spaceName string) e
 		cmd.populatePluginModel(application, app.Stack, instances)
 	}
 
+	details, err := cmd.getDetails(app.GUID, application, instances, appIsStopped)
+	if err != nil {
+		return err
+	}
+
 	cmd.ui.Ok()
+
+	var urls []string
+	for _, route := range application.Routes {
+		urls = append(urls, route.URL())
+	}
+
+	stackName := "unknown"
+	appStack, err := cmd.stackRepo.FindByGUID(application.ApplicationFields.StackGUID)
+	if appStack.Name != "" && err == nil {
+		stackName = appStack.Name
+	}
+
+	buildpack := "unknown"
+	if app.Buildpack != "" {
+		buildpack = app.Buildpack
+	} else if app.DetectedBuildpack != "" {
+		buildpack = app.DetectedBuildpack
+	}
+
+	if printer, ok := cmd.ui.(terminal.DataPrinter); ok {
+		printer.SetData(appData(application, urls, stackName, buildpack, details))
+		return nil
+	}
+
 	cmd.ui.Say("\n%s %s", terminal.HeaderColor(T("requested state:")), uihelpers.ColoredAppState(application.ApplicationFields))
 	cmd.ui.Say("%s %s", terminal.HeaderColor(T("instances:")), uihelpers.ColoredAppInstances(application.ApplicationFields))
 
@@ -151,11 +199,6 @@ func (cmd *ShowApp) ShowApp(app models.Application, orgName, spaceName string) e
 			"FormattedMemory": formatters.ByteSize(application.Memory * formatters.MEGABYTE),
 			"InstanceCount":   application.InstanceCount}))
 
-	var urls []string
-	for _, route := range application.Routes {
-		urls = append(urls, route.URL())
-	}
-
 	cmd.ui.Say("%s %s", terminal.HeaderColor(T("urls:")), strings.Join(urls, ", "))
 	var lastUpdated string
 	if application.PackageUpdatedAt != nil {
@@ -164,31 +207,181 @@ func (cmd *ShowApp) ShowApp(app models.Application, orgName, spaceName string) e
 		lastUpdated = "unknown"
 	}
 	cmd.ui.Say("%s %s", terminal.HeaderColor(T("last uploaded:")), lastUpdated)
-
-	appStack, err := cmd.stackRepo.FindByGUID(application.ApplicationFields.StackGUID)
-	if appStack.Name != "" && err == nil {
-		cmd.ui.Say("%s %s", terminal.HeaderColor(T("stack:")), appStack.Name)
-	} else {
-		cmd.ui.Say("%s %s", terminal.HeaderColor(T("stack:")), "unknown")
+	cmd.ui.Say("%s %s", terminal.HeaderColor(T("stack:")), stackName)
+	cmd.ui.Say("%s %s", terminal.HeaderColor(T("buildpack:")), buildpack)
+
+	if details.lastDeployment != nil {
+		cmd.ui.Say(T("{{.LastDeployment}} {{.Status}}, {{.Strategy}} strategy, created {{.CreatedAt}}",
+			map[string]interface{}{
+				"LastDeployment": terminal.HeaderColor(T("last deployment:")),
+				"Status":         details.lastDeployment.StatusDescription(),
+				"Strategy":       details.lastDeployment.Strategy,
+				"CreatedAt":      details.lastDeployment.CreatedAt.Local().Format("2006-01-02T15:04:05.00-0700"),
+			}))
 	}
-
-	if app.Buildpack != "" {
-		cmd.ui.Say("%s %s\n", terminal.HeaderColor(T("buildpack:")), app.Buildpack)
-	} else if app.DetectedBuildpack != "" {
-		cmd.ui.Say("%s %s\n", terminal.HeaderColor(T("buildpack:")), app.DetectedBuildpack)
-	} else {
-		cmd.ui.Say("%s %s\n", terminal.HeaderColor(T("buildpack:")), "unknown")
+	if len(details.metadata.Labels) > 0 {
+		cmd.ui.Say("%s %s", terminal.HeaderColor(T("labels:")), keyValues(details.metadata.Labels))
+	}
+	if len(details.metadata.Annotations) > 0 {
+		cmd.ui.Say("%s %s", terminal.HeaderColor(T("annotations:")), keyValues(details.metadata.Annotations))
 	}
+	cmd.ui.Say("")
 
 	if appIsStopped {
 		cmd.ui.Say(T("There are no running instances of this app."))
 		return nil
 	}
 
-	table := cmd.ui.Table([]string{"", T("state"), T("since"), T("cpu"), T("memory"), T("disk"), T("details")})
+	for i, process := range details.processes {
+		if i > 0 {
+			cmd.ui.Say("")
+		}
+
+		err = cmd.showProcess(process, details.sidecars)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appDetails is what cf app shows about an app besides its v2 summary. Each
+// part is only fetched from CC APIs that know about it.
+type appDetails struct {
+	processes      []appProcess
+	sidecars       []models.Sidecar
+	lastDeployment *models.Deployment
+	metadata       models.Metadata
+}
+
+type appProcess struct {
+	models.Process
+	instances []models.AppInstanceFields
+}
+
+func (cmd *ShowApp) getDetails(appGUID string, application models.Application, instances []models.AppInstanceFields, appIsStopped bool) (appDetails, error) {
+	var (
+		details appDetails
+		err     error
+	)
+
+	if !appIsStopped {
+		details.processes, err = cmd.getProcesses(appGUID, application, instances)
+		if err != nil {
+			return appDetails{}, err
+		}
+	}
+
+	if cmd.config.IsMinAPIVersion(cf.SidecarsMinimumAPIVersion) {
+		details.sidecars, err = cmd.sidecarRepo.ListSidecars(appGUID)
+		if err != nil {
+			return appDetails{}, err
+		}
+	}
+
+	if cmd.config.IsMinAPIVersion(cf.DeploymentsMinimumAPIVersion) {
+		deployments, err := cmd.deploymentRepo.ListDeployments(appGUID)
+		if err != nil {
+			return appDetails{}, err
+		}
+		if len(deployments) > 0 {
+			details.lastDeployment = &deployments[0]
+		}
+	}
+
+	if cmd.config.IsMinAPIVersion(cf.MetadataMinimumAPIVersion) {
+		details.metadata, err = cmd.metadataActor.GetMetadata("app", appGUID)
+		if err != nil {
+			return appDetails{}, err
+		}
+	}
+
+	return details, nil
+}
+
+// getProcesses returns the process types of the app with their instances.
+// The v2 API only knows about the web process of an app, so any other
+// process types, such as workers from a Procfile, and whether instances pass
+// their readiness checks come from the v3 processes API when it is there.
+func (cmd *ShowApp) getProcesses(appGUID string, application models.Application, instances []models.AppInstanceFields) ([]appProcess, error) {
+	if !cmd.config.IsMinAPIVersion(cf.ProcessTypesMinimumAPIVersion) {
+		web := models.Process{Type: "web", Instances: application.InstanceCount, MemoryInMB: application.Memory, DiskInMB: application.DiskQuota}
+		return []appProcess{{Process: web, instances: instances}}, nil
+	}
+
+	processes, err := cmd.processRepo.ListProcesses(appGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	appProcesses := []appProcess{}
+	for _, process := range processes {
+		if process.Type == "web" {
+			if process.ReadinessHealthCheckType != "" {
+				err = cmd.addReadiness(process, instances)
+				if err != nil {
+					return nil, err
+				}
+			}
+			appProcesses = append(appProcesses, appProcess{Process: process, instances: instances})
+			continue
+		}
+
+		processInstances, err := cmd.processRepo.GetProcessInstances(process.GUID)
+		if err != nil {
+			return nil, err
+		}
+		appProcesses = append(appProcesses, appProcess{Process: process, instances: processInstances})
+	}
+	return appProcesses, nil
+}
+
+// addReadiness sets whether each of the instances of the web process passes
+// its readiness check, matching the v3 stats of the process by index.
+func (cmd *ShowApp) addReadiness(web models.Process, instances []models.AppInstanceFields) error {
+	webInstances, err := cmd.processRepo.GetProcessInstances(web.GUID)
+	if err != nil {
+		return err
+	}
+
+	for index := range instances {
+		if index < len(webInstances) {
+			instances[index].Routable = webInstances[index].Routable
+		}
+	}
+	return nil
+}
+
+func (cmd *ShowApp) showProcess(process appProcess, sidecars []models.Sidecar) error {
+	cmd.ui.Say("%s %s", terminal.HeaderColor(T("type:")), process.Type)
+	if names := sidecarNames(process.Type, sidecars); len(names) > 0 {
+		cmd.ui.Say("%s %s", terminal.HeaderColor(T("sidecars:")), strings.Join(names, ", "))
+	}
+	cmd.ui.Say("%s %d/%d", terminal.HeaderColor(T("instances:")), runningInstances(process.instances), process.Instances)
+	cmd.ui.Say(T("{{.Usage}} {{.FormattedMemory}} x {{.InstanceCount}} instances",
+		map[string]interface{}{
+			"Usage":           terminal.HeaderColor(T("usage:")),
+			"FormattedMemory": formatters.ByteSize(process.MemoryInMB * formatters.MEGABYTE),
+			"InstanceCount":   process.Instances}))
+
+	if len(process.instances) == 0 {
+		return nil
+	}
+
+	cmd.ui.Say("")
+	return cmd.printInstances(process.instances, process.ReadinessHealthCheckType != "")
+}
+
+func (cmd *ShowApp) printInstances(instances []models.AppInstanceFields, showReadiness bool) error {
+	headers := []string{"", T("state"), T("since"), T("cpu"), T("memory"), T("disk"), T("details")}
+	if showReadiness {
+		headers = append(headers, T("readiness"))
+	}
+	table := cmd.ui.Table(headers)
 
 	for index, instance := range instances {
-		table.Add(
+		row := []string{
 			fmt.Sprintf("#%d", index),
 			uihelpers.ColoredInstanceState(instance),
 			instance.Since.Format("2006-01-02 03:04:05 PM"),
@@ -202,17 +395,155 @@ func (cmd *ShowApp) ShowApp(app models.Application, orgName, spaceName string) e
 					"DiskUsage": formatters.ByteSize(instance.DiskUsage),
 					"DiskQuota": formatters.ByteSize(instance.DiskQuota)})),
 			fmt.Sprintf("%s", instance.Details),
-		)
+		}
+		if showReadiness {
+			row = append(row, readiness(instance))
+		}
+		table.Add(row...)
 	}
 
-	err = table.Print()
-	if err != nil {
-		return err
+	return table.Print()
+}
+
+func readiness(instance models.AppInstanceFields) string {
+	switch {
+	case instance.Routable == nil:
+		return ""
+	case *instance.Routable:
+		return T("ready")
+	default:
+		return T("not ready")
 	}
-	if err != nil {
-		return err
+}
+
+func runningInstances(instances []models.AppInstanceFields) int {
+	running := 0
+	for _, instance := range instances {
+		if instance.State == models.InstanceRunning {
+			running++
+		}
+	}
+	return running
+}
+
+// sidecarNames names the sidecars that run next to the process type.
+func sidecarNames(processType string, sidecars []models.Sidecar) []string {
+	names := []string{}
+	for _, sidecar := range sidecars {
+		for _, sidecarProcessType := range sidecar.ProcessTypes {
+			if sidecarProcessType == processType {
+				names = append(names, sidecar.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// keyValues formats labels or annotations as key=value pairs sorted by key.
+func keyValues(values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + values[key]
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// appData is what cf app --output json and yaml print: the summary of the app
+// together with its processes, sidecars, last deployment and metadata.
+func appData(application models.Application, urls []string, stackName string, buildpack string, details appDetails) map[string]interface{} {
+	if urls == nil {
+		urls = []string{}
+	}
+
+	processes := []map[string]interface{}{}
+	for _, process := range details.processes {
+		instances := []map[string]interface{}{}
+		for index, instance := range process.instances {
+			instanceData := map[string]interface{}{
+				"index":        index,
+				"state":        string(instance.State),
+				"since":        instance.Since,
+				"cpu":          instance.CPUUsage,
+				"memory_usage": instance.MemUsage,
+				"memory_quota": instance.MemQuota,
+				"disk_usage":   instance.DiskUsage,
+				"disk_quota":   instance.DiskQuota,
+				"details":      instance.Details,
+			}
+			if instance.Routable != nil {
+				instanceData["routable"] = *instance.Routable
+			}
+			instances = append(instances, instanceData)
+		}
+
+		processes = append(processes, map[string]interface{}{
+			"type":              process.Type,
+			"instances":         process.Instances,
+			"running_instances": runningInstances(process.instances),
+			"memory_in_mb":      process.MemoryInMB,
+			"disk_in_mb":        process.DiskInMB,
+			"sidecars":          sidecarNames(process.Type, details.sidecars),
+			"instance_details":  instances,
+		})
+	}
+
+	sidecars := []map[string]interface{}{}
+	for _, sidecar := range details.sidecars {
+		sidecars = append(sidecars, map[string]interface{}{
+			"name":          sidecar.Name,
+			"command":       sidecar.Command,
+			"process_types": sidecar.ProcessTypes,
+			"memory_in_mb":  sidecar.MemoryInMB,
+		})
+	}
+
+	var lastDeployment map[string]interface{}
+	if details.lastDeployment != nil {
+		lastDeployment = map[string]interface{}{
+			"guid":         details.lastDeployment.GUID,
+			"status":       details.lastDeployment.StatusDescription(),
+			"strategy":     details.lastDeployment.Strategy,
+			"droplet_guid": details.lastDeployment.DropletGUID,
+			"created_at":   details.lastDeployment.CreatedAt,
+		}
+	}
+
+	labels := details.metadata.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	annotations := details.metadata.Annotations
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	return map[string]interface{}{
+		"name":              application.Name,
+		"guid":              application.GUID,
+		"requested_state":   application.State,
+		"instances":         application.InstanceCount,
+		"running_instances": application.RunningInstances,
+		"memory_in_mb":      application.Memory,
+		"disk_in_mb":        application.DiskQuota,
+		"routes":            urls,
+		"last_uploaded":     application.PackageUpdatedAt,
+		"stack":             stackName,
+		"buildpack":         buildpack,
+		"processes":         processes,
+		"sidecars":          sidecars,
+		"last_deployment":   lastDeployment,
+		"metadata": map[string]interface{}{
+			"labels":      labels,
+			"annotations": annotations,
+		},
 	}
-	return nil
 }
 
 func (cmd *ShowApp) populatePluginModel(
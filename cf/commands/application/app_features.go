@@ -0,0 +1,91 @@
+package application
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api/appfeatures"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type AppFeatures struct {
+	ui             terminal.UI
+	config         coreconfig.Reader
+	appFeatureRepo appfeatures.Repository
+	appReq         requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&AppFeatures{})
+}
+
+func (cmd *AppFeatures) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "app-features",
+		Description: T("List the features of an app and whether they are enabled"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME app-features %s", T("APP_NAME")),
+		},
+	}
+}
+
+func (cmd *AppFeatures) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("app-features"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("app-features", cf.AppFeaturesMinimumAPIVersion),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *AppFeatures) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.appFeatureRepo = deps.RepoLocator.GetAppFeatureRepository()
+	return cmd
+}
+
+func (cmd *AppFeatures) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say(T("Getting features of app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	features, err := cmd.appFeatureRepo.ListAppFeatures(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	table := cmd.ui.Table([]string{T("name"), T("enabled"), T("description")})
+	for _, feature := range features {
+		enabled := T("disabled")
+		if feature.Enabled {
+			enabled = T("enabled")
+		}
+		table.Add(feature.Name, enabled, feature.Description)
+	}
+
+	return table.Print()
+}
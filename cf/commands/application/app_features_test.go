@@ -0,0 +1,125 @@
+package application_test
+
+import (
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/appfeatures/appfeaturesfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("app feature commands", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		appFeatureRepo      *appfeaturesfakes.FakeRepository
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	runCommand := func(name string, args ...string) bool {
+		updateCommandDependency := func(pluginCall bool) {
+			deps.UI = ui
+			deps.Config = config
+			deps.RepoLocator = api.RepositoryLocator{}.SetAppFeatureRepository(appFeatureRepo)
+			commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand(name).SetDependency(deps, pluginCall))
+		}
+		return testcmd.RunCLICommand(name, args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		appFeatureRepo = new(appfeaturesfakes.FakeRepository)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+	})
+
+	Describe("app-features", func() {
+		It("fails with usage when not given an app name", func() {
+			Expect(runCommand("app-features")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires an argument"},
+			))
+		})
+
+		It("requires an API with app features", func() {
+			runCommand("app-features", "my-app")
+			command, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+			Expect(command).To(Equal("app-features"))
+			Expect(version).To(Equal(cf.AppFeaturesMinimumAPIVersion))
+		})
+
+		It("lists the features of the app", func() {
+			appFeatureRepo.ListAppFeaturesReturns([]models.AppFeature{
+				{Name: "ssh", Description: "Enable SSHing into the app.", Enabled: true},
+				{Name: "revisions", Description: "Enable versioning of an application", Enabled: false},
+			}, nil)
+
+			Expect(runCommand("app-features", "my-app")).To(BeTrue())
+			Expect(appFeatureRepo.ListAppFeaturesArgsForCall(0)).To(Equal("my-app-guid"))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Getting features of app my-app in org my-org / space my-space as my-user..."},
+				[]string{"OK"},
+				[]string{"name", "enabled", "description"},
+				[]string{"ssh", "enabled", "Enable SSHing into the app."},
+				[]string{"revisions", "disabled", "Enable versioning of an application"},
+			))
+		})
+	})
+
+	Describe("enable-app-feature", func() {
+		It("fails with usage when not given an app name and a feature", func() {
+			Expect(runCommand("enable-app-feature", "my-app")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires APP_NAME and FEATURE_NAME as arguments"},
+			))
+		})
+
+		It("enables the feature", func() {
+			Expect(runCommand("enable-app-feature", "my-app", "ssh")).To(BeTrue())
+
+			appGUID, name, enabled := appFeatureRepo.UpdateAppFeatureArgsForCall(0)
+			Expect(appGUID).To(Equal("my-app-guid"))
+			Expect(name).To(Equal("ssh"))
+			Expect(enabled).To(BeTrue())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Enabling feature ssh of app my-app in org my-org / space my-space as my-user..."},
+				[]string{"OK"},
+			))
+		})
+	})
+
+	Describe("disable-app-feature", func() {
+		It("disables the feature", func() {
+			Expect(runCommand("disable-app-feature", "my-app", "revisions")).To(BeTrue())
+
+			_, name, enabled := appFeatureRepo.UpdateAppFeatureArgsForCall(0)
+			Expect(name).To(Equal("revisions"))
+			Expect(enabled).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Disabling feature revisions of app my-app"},
+				[]string{"OK"},
+			))
+		})
+	})
+})
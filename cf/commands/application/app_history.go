@@ -0,0 +1,192 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api/appevents"
+	"code.cloudfoundry.org/cli/cf/api/droplets"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+// appHistoryEventLimit is how many of the most recent events of the app are
+// searched for the pushes and restages that made its droplets.
+const appHistoryEventLimit = 200
+
+// deploymentEvents are the events that lead to a new droplet.
+var deploymentEvents = map[string]string{
+	"audit.app.upload-bits": "push",
+	"audit.app.restage":     "restage",
+}
+
+type AppHistory struct {
+	ui          terminal.UI
+	config      coreconfig.Reader
+	appReq      requirements.ApplicationRequirement
+	eventsRepo  appevents.Repository
+	dropletRepo droplets.Repository
+}
+
+// appRevision is one droplet of the app, or one push when the droplets
+// themselves cannot be listed.
+type appRevision struct {
+	droplet models.Droplet
+	event   *models.EventFields
+	current bool
+}
+
+func init() {
+	commandregistry.Register(&AppHistory{})
+}
+
+func (cmd *AppHistory) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "app-history",
+		Description: T("Show the droplets an app was pushed with, and who pushed them"),
+		Usage: []string{
+			"CF_NAME app-history ",
+			T("APP_NAME"),
+		},
+	}
+}
+
+func (cmd *AppHistory) Requirements(requirementsFactory requirements.Factory, c flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(c.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("app-history"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(c.Args()), 1)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(c.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *AppHistory) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.eventsRepo = deps.RepoLocator.GetAppEventsRepository()
+	cmd.dropletRepo = deps.RepoLocator.GetDropletRepository()
+	return cmd
+}
+
+func (cmd *AppHistory) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say(T("Getting history for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
+		map[string]interface{}{
+			"AppName":   terminal.EntityNameColor(app.Name),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+	events, err := cmd.eventsRepo.RecentEvents(app.GUID, appHistoryEventLimit)
+	if err != nil {
+		return errors.New(T("Failed fetching events.\n{{.APIErr}}",
+			map[string]interface{}{"APIErr": err.Error()}))
+	}
+
+	var revisions []appRevision
+	if cmd.config.IsMinAPIVersion(cf.DropletHistoryMinimumAPIVersion) {
+		revisions, err = cmd.dropletRevisions(app, events)
+		if err != nil {
+			return err
+		}
+	} else {
+		cmd.ui.Warn(T("Droplets of earlier pushes are only kept from CC API version {{.MinVersion}}; showing pushes from the app events instead.",
+			map[string]interface{}{"MinVersion": cf.DropletHistoryMinimumAPIVersion.String()}))
+		revisions = eventRevisions(events)
+	}
+
+	if len(revisions) == 0 {
+		cmd.ui.Say(T("No history for app {{.AppName}}",
+			map[string]interface{}{"AppName": terminal.EntityNameColor(app.Name)}))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{"", T("created"), T("droplet"), T("buildpack"), T("stack"), T("event"), T("actor")})
+	for _, revision := range revisions {
+		current := ""
+		if revision.current {
+			current = "*"
+		}
+
+		created := revision.droplet.CreatedAt
+		eventName, actor := "", ""
+		if revision.event != nil {
+			if created.IsZero() {
+				created = revision.event.Timestamp
+			}
+			eventName = deploymentEvents[revision.event.Name]
+			actor = revision.event.ActorName
+			if actor == "" {
+				actor = revision.event.Actor
+			}
+		}
+
+		table.Add(
+			current,
+			created.Local().Format("2006-01-02T15:04:05.00-0700"),
+			revision.droplet.GUID,
+			revision.droplet.Buildpack,
+			revision.droplet.Stack,
+			eventName,
+			actor,
+		)
+	}
+
+	return table.Print()
+}
+
+// dropletRevisions pairs each staged droplet of the app with the latest push
+// or restage that happened before it was created.
+func (cmd *AppHistory) dropletRevisions(app models.Application, events []models.EventFields) ([]appRevision, error) {
+	appDroplets, err := cmd.dropletRepo.ListStagedDroplets(app.GUID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentGUID, err := cmd.dropletRepo.GetCurrentDropletGUID(app.GUID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]appRevision, 0, len(appDroplets))
+	for _, droplet := range appDroplets {
+		revision := appRevision{droplet: droplet, current: droplet.GUID == currentGUID}
+
+		// events are newest first
+		for i, event := range events {
+			if _, ok := deploymentEvents[event.Name]; ok && !event.Timestamp.After(droplet.CreatedAt) {
+				revision.event = &events[i]
+				break
+			}
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+func eventRevisions(events []models.EventFields) []appRevision {
+	revisions := []appRevision{}
+	for i, event := range events {
+		if _, ok := deploymentEvents[event.Name]; ok {
+			revisions = append(revisions, appRevision{event: &events[i]})
+		}
+	}
+	return revisions
+}
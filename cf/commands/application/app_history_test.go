@@ -0,0 +1,166 @@
+package application_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/application"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/models"
+
+	"code.cloudfoundry.org/cli/cf/api/appevents/appeventsfakes"
+	"code.cloudfoundry.org/cli/cf/api/droplets/dropletsfakes"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig/coreconfigfakes"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("app-history command", func() {
+	var (
+		reqFactory  *requirementsfakes.FakeFactory
+		eventsRepo  *appeventsfakes.FakeAppEventsRepository
+		dropletRepo *dropletsfakes.FakeRepository
+		ui          *testterm.FakeUI
+		config      *coreconfigfakes.FakeRepository
+		deps        commandregistry.Dependency
+		flagContext flags.FlagContext
+
+		applicationRequirement *requirementsfakes.FakeApplicationRequirement
+
+		cmd           *application.AppHistory
+		executeCmdErr error
+
+		firstPush  time.Time
+		restage    time.Time
+		secondPush time.Time
+	)
+
+	BeforeEach(func() {
+		cmd = &application.AppHistory{}
+
+		ui = new(testterm.FakeUI)
+		eventsRepo = new(appeventsfakes.FakeAppEventsRepository)
+		dropletRepo = new(dropletsfakes.FakeRepository)
+		config = new(coreconfigfakes.FakeRepository)
+
+		config.OrganizationFieldsReturns(models.OrganizationFields{Name: "my-org"})
+		config.SpaceFieldsReturns(models.SpaceFields{Name: "my-space"})
+		config.UsernameReturns("my-user")
+		config.IsMinAPIVersionReturns(true)
+
+		deps = commandregistry.Dependency{
+			UI:          ui,
+			RepoLocator: api.RepositoryLocator{}.SetAppEventsRepository(eventsRepo).SetDropletRepository(dropletRepo),
+			Config:      config,
+		}
+
+		flagContext = flags.NewFlagContext(cmd.MetaData().Flags)
+
+		reqFactory = new(requirementsfakes.FakeFactory)
+		reqFactory.NewLoginRequirementReturns(&passingRequirement{Name: "login-requirement"})
+		reqFactory.NewTargetedSpaceRequirementReturns(&passingRequirement{Name: "targeted-space-requirement"})
+		applicationRequirement = new(requirementsfakes.FakeApplicationRequirement)
+		applicationRequirement.GetApplicationReturns(models.Application{
+			ApplicationFields: models.ApplicationFields{
+				Name: "my-app",
+				GUID: "my-app-guid",
+			},
+		})
+		reqFactory.NewApplicationRequirementReturns(applicationRequirement)
+
+		firstPush = time.Date(2016, 11, 1, 10, 0, 0, 0, time.UTC)
+		restage = time.Date(2016, 11, 2, 10, 0, 0, 0, time.UTC)
+		secondPush = time.Date(2016, 11, 3, 10, 0, 0, 0, time.UTC)
+
+		eventsRepo.RecentEventsReturns([]models.EventFields{
+			{Name: "audit.app.update", Timestamp: secondPush.Add(time.Minute), Actor: "ops-guid", ActorName: "ops"},
+			{Name: "audit.app.upload-bits", Timestamp: secondPush, Actor: "dev-2-guid", ActorName: "dev-2"},
+			{Name: "audit.app.restage", Timestamp: restage, Actor: "ops-guid"},
+			{Name: "audit.app.upload-bits", Timestamp: firstPush, Actor: "dev-1-guid", ActorName: "dev-1"},
+		}, nil)
+
+		dropletRepo.ListStagedDropletsReturns([]models.Droplet{
+			{GUID: "droplet-3-guid", Buildpack: "ruby_buildpack", Stack: "cflinuxfs2", CreatedAt: secondPush.Add(30 * time.Second)},
+			{GUID: "droplet-2-guid", Buildpack: "ruby_buildpack", Stack: "cflinuxfs2", CreatedAt: restage.Add(30 * time.Second)},
+			{GUID: "droplet-1-guid", Buildpack: "go_buildpack", Stack: "cflinuxfs2", CreatedAt: firstPush.Add(30 * time.Second)},
+		}, nil)
+		dropletRepo.GetCurrentDropletGUIDReturns("droplet-3-guid", nil)
+	})
+
+	Describe("Requirements", func() {
+		It("fails when not provided exactly 1 argument", func() {
+			cmd.SetDependency(deps, false)
+			Expect(flagContext.Parse("too", "many")).To(Succeed())
+
+			_, err := cmd.Requirements(reqFactory, flagContext)
+			Expect(err).To(HaveOccurred())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires an argument"},
+			))
+		})
+	})
+
+	Describe("Execute", func() {
+		BeforeEach(func() {
+			Expect(flagContext.Parse("my-app")).To(Succeed())
+			cmd.SetDependency(deps, false)
+			_, err := cmd.Requirements(reqFactory, flagContext)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			executeCmdErr = cmd.Execute(flagContext)
+		})
+
+		It("lists each droplet with the push or restage that made it", func() {
+			Expect(executeCmdErr).NotTo(HaveOccurred())
+
+			appGUID, _ := eventsRepo.RecentEventsArgsForCall(0)
+			Expect(appGUID).To(Equal("my-app-guid"))
+			Expect(dropletRepo.ListStagedDropletsArgsForCall(0)).To(Equal("my-app-guid"))
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Getting history for app", "my-app", "my-org", "my-space", "my-user"},
+				[]string{"created", "droplet", "buildpack", "stack", "event", "actor"},
+				[]string{"*", "droplet-3-guid", "ruby_buildpack", "cflinuxfs2", "push", "dev-2"},
+				[]string{"droplet-2-guid", "ruby_buildpack", "restage", "ops-guid"},
+				[]string{"droplet-1-guid", "go_buildpack", "push", "dev-1"},
+			))
+		})
+
+		Context("when the app has no droplets", func() {
+			BeforeEach(func() {
+				dropletRepo.ListStagedDropletsReturns([]models.Droplet{}, nil)
+			})
+
+			It("tells the user", func() {
+				Expect(executeCmdErr).NotTo(HaveOccurred())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"No history for app", "my-app"}))
+			})
+		})
+
+		Context("when the API does not keep earlier droplets", func() {
+			BeforeEach(func() {
+				config.IsMinAPIVersionReturns(false)
+			})
+
+			It("lists the pushes and restages from the events", func() {
+				Expect(executeCmdErr).NotTo(HaveOccurred())
+				Expect(dropletRepo.ListStagedDropletsCallCount()).To(BeZero())
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"showing pushes from the app events instead"},
+					[]string{secondPush.Local().Format(TIMESTAMP_FORMAT), "push", "dev-2"},
+					[]string{restage.Local().Format(TIMESTAMP_FORMAT), "restage", "ops-guid"},
+					[]string{firstPush.Local().Format(TIMESTAMP_FORMAT), "push", "dev-1"},
+				))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"ops", "audit.app.update"}))
+			})
+		})
+	})
+})
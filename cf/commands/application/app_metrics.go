@@ -0,0 +1,170 @@
+package application
+
+import (
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/metrics"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/formatters"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/cf/uihelpers"
+)
+
+type AppMetrics struct {
+	ui          terminal.UI
+	config      coreconfig.Reader
+	metricsRepo metrics.Repository
+	appReq      requirements.ApplicationRequirement
+
+	WatchInterval time.Duration
+}
+
+func init() {
+	commandregistry.Register(&AppMetrics{})
+}
+
+func (cmd *AppMetrics) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["watch"] = &flags.BoolFlag{Name: "watch", ShortName: "w", Usage: T("Refresh the metrics every 5 seconds until interrupted")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "app-metrics",
+		Description: T("Show CPU, memory and disk usage of the instances of an app"),
+		Usage: []string{
+			T("CF_NAME app-metrics APP_NAME [--watch]"),
+		},
+		Examples: []string{
+			"CF_NAME app-metrics my-app",
+			"CF_NAME app-metrics my-app --watch --output json",
+		},
+		Flags:            fs,
+		StructuredOutput: true,
+	}
+}
+
+func (cmd *AppMetrics) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("app-metrics"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *AppMetrics) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.metricsRepo = deps.RepoLocator.GetMetricsRepository()
+	cmd.WatchInterval = 5 * time.Second
+	return cmd
+}
+
+func (cmd *AppMetrics) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say(T("Getting metrics for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	printer, structured := cmd.ui.(terminal.DataPrinter)
+
+	for first := true; ; first = false {
+		instanceMetrics, err := cmd.metricsRepo.GetAppMetrics(app.GUID)
+		if err != nil {
+			return err
+		}
+
+		if structured {
+			printer.SetData(metricsData(instanceMetrics))
+			// Each refresh is printed as it happens, since watching only
+			// ends when the CLI is interrupted.
+			if c.Bool("watch") {
+				err = printer.Flush()
+			}
+		} else {
+			if first {
+				cmd.ui.Ok()
+			} else {
+				cmd.ui.Say(T("Refreshed metrics for app {{.AppName}} at {{.Time}}",
+					map[string]interface{}{
+						"AppName": terminal.EntityNameColor(app.Name),
+						"Time":    time.Now().Format(time.Kitchen),
+					}))
+			}
+			cmd.ui.Say("")
+			err = cmd.printMetricsTable(instanceMetrics)
+		}
+		if err != nil {
+			return err
+		}
+
+		if !c.Bool("watch") {
+			return nil
+		}
+
+		time.Sleep(cmd.WatchInterval)
+		cmd.ui.Say("")
+	}
+}
+
+func (cmd *AppMetrics) printMetricsTable(instanceMetrics []models.AppInstanceMetrics) error {
+	if len(instanceMetrics) == 0 {
+		cmd.ui.Say(T("There are no running instances of this app."))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{"", T("state"), T("uptime"), T("cpu"), T("memory"), T("disk")})
+	for _, instance := range instanceMetrics {
+		table.Add(
+			fmt.Sprintf("#%d", instance.Index),
+			uihelpers.ColoredInstanceState(models.AppInstanceFields{State: instance.State}),
+			instance.Uptime.String(),
+			fmt.Sprintf("%.1f%%", instance.CPUUsage*100),
+			fmt.Sprintf(T("{{.MemUsage}} of {{.MemQuota}}",
+				map[string]interface{}{
+					"MemUsage": formatters.ByteSize(instance.MemUsage),
+					"MemQuota": formatters.ByteSize(instance.MemQuota)})),
+			fmt.Sprintf(T("{{.DiskUsage}} of {{.DiskQuota}}",
+				map[string]interface{}{
+					"DiskUsage": formatters.ByteSize(instance.DiskUsage),
+					"DiskQuota": formatters.ByteSize(instance.DiskQuota)})),
+		)
+	}
+
+	return table.Print()
+}
+
+func metricsData(instanceMetrics []models.AppInstanceMetrics) []map[string]interface{} {
+	data := []map[string]interface{}{}
+	for _, instance := range instanceMetrics {
+		data = append(data, map[string]interface{}{
+			"index":          instance.Index,
+			"state":          string(instance.State),
+			"uptime_seconds": int64(instance.Uptime / time.Second),
+			"cpu":            instance.CPUUsage,
+			"memory":         instance.MemUsage,
+			"memory_quota":   instance.MemQuota,
+			"disk":           instance.DiskUsage,
+			"disk_quota":     instance.DiskQuota,
+		})
+	}
+	return data
+}
@@ -0,0 +1,183 @@
+package application_test
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/metrics/metricsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/application"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("app-metrics command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		metricsRepo         *metricsfakes.FakeRepository
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = api.RepositoryLocator{}.SetMetricsRepository(metricsRepo)
+		cmd := commandregistry.Commands.FindCommand("app-metrics").SetDependency(deps, pluginCall).(*application.AppMetrics)
+		cmd.WatchInterval = time.Millisecond
+		commandregistry.Commands.SetCommand(cmd)
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("app-metrics", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	runFormattedCommand := func(args ...string) (terminal.UI, error) {
+		formattedUI := terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+		deps.UI = formattedUI
+		deps.Config = config
+		deps.RepoLocator = api.RepositoryLocator{}.SetMetricsRepository(metricsRepo)
+
+		cmd := &application.AppMetrics{}
+		cmd.SetDependency(deps, false)
+		cmd.WatchInterval = time.Millisecond
+		fc := flags.NewFlagContext(cmd.MetaData().Flags)
+		Expect(fc.Parse(args...)).To(Succeed())
+		_, err := cmd.Requirements(requirementsFactory, fc)
+		Expect(err).NotTo(HaveOccurred())
+		return formattedUI, cmd.Execute(fc)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		metricsRepo = new(metricsfakes.FakeRepository)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+
+		metricsRepo.GetAppMetricsReturns([]models.AppInstanceMetrics{
+			{
+				Index:     0,
+				State:     models.InstanceRunning,
+				Uptime:    3723 * time.Second,
+				CPUUsage:  0.0125,
+				MemUsage:  19 * 1024 * 1024,
+				MemQuota:  64 * 1024 * 1024,
+				DiskUsage: 53 * 1024 * 1024,
+				DiskQuota: 1024 * 1024 * 1024,
+			},
+			{
+				Index:     1,
+				State:     models.InstanceStarting,
+				Uptime:    5 * time.Second,
+				MemQuota:  64 * 1024 * 1024,
+				DiskQuota: 1024 * 1024 * 1024,
+			},
+		}, nil)
+	})
+
+	It("fails with usage when not given an app name", func() {
+		runCommand()
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Requires an argument"},
+		))
+	})
+
+	It("shows the metrics of each instance", func() {
+		Expect(runCommand("my-app")).To(BeTrue())
+
+		Expect(metricsRepo.GetAppMetricsArgsForCall(0)).To(Equal("my-app-guid"))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Getting metrics for app", "my-app", "my-org", "my-space", "my-user"},
+			[]string{"OK"},
+			[]string{"state", "uptime", "cpu", "memory", "disk"},
+			[]string{"#0", "running", "1h2m3s", "1.2%", "19M of 64M", "53M of 1G"},
+			[]string{"#1", "starting", "5s", "0.0%", "0 of 64M", "0 of 1G"},
+		))
+	})
+
+	It("says when there are no running instances", func() {
+		metricsRepo.GetAppMetricsReturns([]models.AppInstanceMetrics{}, nil)
+
+		Expect(runCommand("my-app")).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"There are no running instances of this app."}))
+	})
+
+	It("prints the metrics as a JSON array with --output json", func() {
+		formattedUI, err := runFormattedCommand("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+		Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`[
+			{"index": 0, "state": "running", "uptime_seconds": 3723, "cpu": 0.0125, "memory": 19922944, "memory_quota": 67108864, "disk": 55574528, "disk_quota": 1073741824},
+			{"index": 1, "state": "starting", "uptime_seconds": 5, "cpu": 0, "memory": 0, "memory_quota": 67108864, "disk": 0, "disk_quota": 1073741824}
+		]`))
+	})
+
+	It("fails when the metrics cannot be read", func() {
+		metricsRepo.GetAppMetricsReturns(nil, errors.New("metrics unavailable"))
+
+		Expect(runCommand("my-app")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"metrics unavailable"},
+		))
+	})
+
+	Context("with --watch", func() {
+		BeforeEach(func() {
+			calls := 0
+			metricsRepo.GetAppMetricsStub = func(string) ([]models.AppInstanceMetrics, error) {
+				calls++
+				if calls > 2 {
+					return nil, errors.New("app deleted")
+				}
+				return []models.AppInstanceMetrics{{Index: 0, State: models.InstanceRunning}}, nil
+			}
+		})
+
+		It("refreshes the metrics until they cannot be read", func() {
+			Expect(runCommand("--watch", "my-app")).To(BeFalse())
+
+			Expect(metricsRepo.GetAppMetricsCallCount()).To(Equal(3))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"#0", "running"},
+				[]string{"Refreshed metrics for app", "my-app"},
+				[]string{"#0", "running"},
+				[]string{"FAILED"},
+				[]string{"app deleted"},
+			))
+		})
+
+		It("prints a JSON array for each refresh with --output json", func() {
+			_, err := runFormattedCommand("--watch", "my-app")
+			Expect(err).To(MatchError("app deleted"))
+
+			output := strings.Join(ui.Outputs(), "\n")
+			Expect(strings.Count(output, `"index": 0`)).To(Equal(2))
+			Expect(output).NotTo(ContainSubstring("Refreshed metrics"))
+		})
+	})
+})
@@ -2,10 +2,14 @@ package application_test
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
 	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/deployments/deploymentsfakes"
 	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/api/sidecars/sidecarsfakes"
 	"code.cloudfoundry.org/cli/cf/api/stacks/stacksfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/commands/application"
@@ -15,6 +19,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
 	"code.cloudfoundry.org/cli/plugin/models"
 
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
@@ -22,6 +27,7 @@ import (
 
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
 	"code.cloudfoundry.org/cli/cf/api/appinstances/appinstancesfakes"
+	"code.cloudfoundry.org/cli/cf/api/processes/processesfakes"
 
 	. "code.cloudfoundry.org/cli/testhelpers/matchers"
 
@@ -35,6 +41,10 @@ var _ = Describe("App", func() {
 		appSummaryRepo   *apifakes.FakeAppSummaryRepository
 		appInstancesRepo *appinstancesfakes.FakeAppInstancesRepository
 		stackRepo        *stacksfakes.FakeStackRepository
+		processRepo      *processesfakes.FakeRepository
+		sidecarRepo      *sidecarsfakes.FakeRepository
+		deploymentRepo   *deploymentsfakes.FakeRepository
+		metadataActor    *actorsfakes.FakeMetadataActor
 		getAppModel      *plugin_models.GetAppModel
 
 		cmd         commandregistry.Command
@@ -62,10 +72,18 @@ var _ = Describe("App", func() {
 		repoLocator = repoLocator.SetAppInstancesRepository(appInstancesRepo)
 		stackRepo = new(stacksfakes.FakeStackRepository)
 		repoLocator = repoLocator.SetStackRepository(stackRepo)
+		processRepo = new(processesfakes.FakeRepository)
+		repoLocator = repoLocator.SetProcessRepository(processRepo)
+		sidecarRepo = new(sidecarsfakes.FakeRepository)
+		repoLocator = repoLocator.SetSidecarRepository(sidecarRepo)
+		deploymentRepo = new(deploymentsfakes.FakeRepository)
+		repoLocator = repoLocator.SetDeploymentRepository(deploymentRepo)
+		metadataActor = new(actorsfakes.FakeMetadataActor)
 
 		deps = commandregistry.Dependency{
-			UI:     ui,
-			Config: testconfig.NewRepositoryWithDefaults(),
+			UI:            ui,
+			Config:        testconfig.NewRepositoryWithDefaults(),
+			MetadataActor: metadataActor,
 			PluginModels: &commandregistry.PluginModels{
 				Application: getAppModel,
 			},
@@ -227,6 +245,212 @@ var _ = Describe("App", func() {
 			))
 		})
 
+		It("does not look for other process types on CC APIs without them", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processRepo.ListProcessesCallCount()).To(BeZero())
+		})
+
+		Context("when the CC API knows about the process types of apps", func() {
+			BeforeEach(func() {
+				deps.Config.SetAPIVersion("2.75.0")
+				processRepo.ListProcessesReturns([]models.Process{
+					{GUID: "web-guid", Type: "web", Instances: 1, MemoryInMB: 1024},
+					{GUID: "worker-guid", Type: "worker", Instances: 2, MemoryInMB: 256},
+				}, nil)
+				processRepo.GetProcessInstancesReturns([]models.AppInstanceFields{
+					{
+						State:     models.InstanceRunning,
+						Since:     time.Date(2015, time.November, 19, 2, 1, 17, 0, time.UTC),
+						CPUUsage:  float64(0.5),
+						DiskUsage: int64(64 * formatters.MEGABYTE),
+						DiskQuota: int64(1 * formatters.GIGABYTE),
+						MemUsage:  int64(100 * formatters.MEGABYTE),
+						MemQuota:  int64(256 * formatters.MEGABYTE),
+					},
+					{
+						State: models.InstanceCrashed,
+					},
+				}, nil)
+			})
+
+			It("prints a section with an instance table for each process type", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(processRepo.GetProcessInstancesCallCount()).To(Equal(1))
+				Expect(processRepo.GetProcessInstancesArgsForCall(0)).To(Equal("worker-guid"))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"type: web"},
+					[]string{"instances: 1/1"},
+					[]string{"usage: 1G x 1 instances"},
+					[]string{"#0", "running", "2015-11-19 01:01:17 AM", "25.0%", "24M of 32M", "1G of 2G"},
+					[]string{"type: worker"},
+					[]string{"instances: 1/2"},
+					[]string{"usage: 256M x 2 instances"},
+					[]string{"#0", "running", "2015-11-19 02:01:17 AM", "50.0%", "100M of 256M", "64M of 1G"},
+					[]string{"#1", "crashed"},
+				))
+			})
+
+			It("does not show a readiness column when no process has a readiness check", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"readiness"}))
+			})
+
+			Context("when the web process has a readiness check", func() {
+				BeforeEach(func() {
+					routable := false
+					processRepo.ListProcessesReturns([]models.Process{
+						{GUID: "web-guid", Type: "web", Instances: 1, MemoryInMB: 1024, ReadinessHealthCheckType: "http"},
+					}, nil)
+					processRepo.GetProcessInstancesReturns([]models.AppInstanceFields{
+						{State: models.InstanceRunning, Routable: &routable},
+					}, nil)
+				})
+
+				It("shows whether each web instance passes its readiness check", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(processRepo.GetProcessInstancesArgsForCall(0)).To(Equal("web-guid"))
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"state", "since", "cpu", "memory", "disk", "details", "readiness"},
+						[]string{"#0", "running", "2015-11-19 01:01:17 AM", "25.0%", "24M of 32M", "1G of 2G", "not ready"},
+					))
+				})
+			})
+
+			Context("when the app has sidecars", func() {
+				BeforeEach(func() {
+					deps.Config.SetAPIVersion("2.135.0")
+					sidecarRepo.ListSidecarsReturns([]models.Sidecar{
+						{Name: "log-shipper", ProcessTypes: []string{"web", "worker"}},
+						{Name: "proxy", ProcessTypes: []string{"web"}},
+					}, nil)
+				})
+
+				It("lists the sidecars of each process type", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(sidecarRepo.ListSidecarsArgsForCall(0)).To(Equal("fake-app-guid"))
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"type: web"},
+						[]string{"sidecars: log-shipper, proxy"},
+						[]string{"type: worker"},
+						[]string{"sidecars: log-shipper"},
+					))
+				})
+			})
+
+			Context("when listing the processes fails", func() {
+				BeforeEach(func() {
+					processRepo.ListProcessesReturns(nil, errors.New("process-error"))
+				})
+
+				It("returns the error", func() {
+					Expect(err).To(MatchError("process-error"))
+				})
+			})
+		})
+
+		Context("when the CC API knows about deployments and metadata", func() {
+			BeforeEach(func() {
+				deps.Config.SetAPIVersion("2.133.0")
+				deploymentRepo.ListDeploymentsReturns([]models.Deployment{
+					{GUID: "new-deployment-guid", StatusValue: "ACTIVE", StatusReason: "DEPLOYING", Strategy: "rolling"},
+					{GUID: "old-deployment-guid", StatusValue: "FINALIZED", StatusReason: "DEPLOYED", Strategy: "rolling"},
+				}, nil)
+				metadataActor.GetMetadataReturns(models.Metadata{
+					Labels:      map[string]string{"tier": "web", "env": "prod"},
+					Annotations: map[string]string{"owner": "billing"},
+				}, nil)
+			})
+
+			It("shows the last deployment and the metadata of the app", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deploymentRepo.ListDeploymentsArgsForCall(0)).To(Equal("fake-app-guid"))
+				resourceType, guid := metadataActor.GetMetadataArgsForCall(0)
+				Expect(resourceType).To(Equal("app"))
+				Expect(guid).To(Equal("fake-app-guid"))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"buildpack:"},
+					[]string{"last deployment: ACTIVE (DEPLOYING), rolling strategy, created"},
+					[]string{"labels: env=prod, tier=web"},
+					[]string{"annotations: owner=billing"},
+				))
+			})
+
+			It("does not fetch sidecars from CC APIs without them", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sidecarRepo.ListSidecarsCallCount()).To(BeZero())
+			})
+
+			Context("when the metadata cannot be fetched", func() {
+				BeforeEach(func() {
+					metadataActor.GetMetadataReturns(models.Metadata{}, errors.New("metadata-error"))
+				})
+
+				It("returns the error", func() {
+					Expect(err).To(MatchError("metadata-error"))
+				})
+			})
+		})
+
+		Context("when --output json is given", func() {
+			var formattedUI terminal.UI
+
+			BeforeEach(func() {
+				deps.Config.SetAPIVersion("2.135.0")
+				processRepo.ListProcessesReturns([]models.Process{
+					{GUID: "web-guid", Type: "web", Instances: 1, MemoryInMB: 1024},
+				}, nil)
+				sidecarRepo.ListSidecarsReturns([]models.Sidecar{
+					{Name: "proxy", Command: "./proxy", ProcessTypes: []string{"web"}, MemoryInMB: 64},
+				}, nil)
+				deploymentRepo.ListDeploymentsReturns([]models.Deployment{
+					{GUID: "deployment-guid", StatusValue: "FINALIZED", StatusReason: "DEPLOYED", Strategy: "rolling"},
+				}, nil)
+				metadataActor.GetMetadataReturns(models.Metadata{Labels: map[string]string{"env": "prod"}}, nil)
+
+				formattedUI = terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+				deps.UI = formattedUI
+				cmd.SetDependency(deps, false)
+			})
+
+			It("prints the app with its processes, sidecars, last deployment and metadata as one object", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ui.Outputs()).To(BeEmpty())
+
+				Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+				var data map[string]interface{}
+				Expect(json.Unmarshal([]byte(strings.Join(ui.Outputs(), "\n")), &data)).To(Succeed())
+				Expect(data["name"]).To(Equal("fake-app-name"))
+				Expect(data["guid"]).To(Equal("fake-app-guid"))
+				Expect(data["requested_state"]).To(Equal("started"))
+				Expect(data["routes"]).To(Equal([]interface{}{"fake-route-host.fake-route-domain-name"}))
+				Expect(data["stack"]).To(Equal("fake-stack-name"))
+				Expect(data["metadata"]).To(Equal(map[string]interface{}{
+					"labels":      map[string]interface{}{"env": "prod"},
+					"annotations": map[string]interface{}{},
+				}))
+
+				processes := data["processes"].([]interface{})
+				Expect(processes).To(HaveLen(1))
+				web := processes[0].(map[string]interface{})
+				Expect(web["type"]).To(Equal("web"))
+				Expect(web["running_instances"]).To(BeNumerically("==", 1))
+				Expect(web["sidecars"]).To(Equal([]interface{}{"proxy"}))
+				Expect(web["instance_details"]).To(HaveLen(1))
+
+				Expect(data["sidecars"]).To(Equal([]interface{}{map[string]interface{}{
+					"name":          "proxy",
+					"command":       "./proxy",
+					"process_types": []interface{}{"web"},
+					"memory_in_mb":  float64(64),
+				}}))
+
+				lastDeployment := data["last_deployment"].(map[string]interface{})
+				Expect(lastDeployment["guid"]).To(Equal("deployment-guid"))
+				Expect(lastDeployment["status"]).To(Equal("FINALIZED (DEPLOYED)"))
+			})
+		})
+
 		Context("when getting the application summary fails because the app is stopped", func() {
 			BeforeEach(func() {
 				getAppSummaryModel.RunningInstances = 0
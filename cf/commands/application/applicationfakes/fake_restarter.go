@@ -12,6 +12,26 @@ import (
 )
 
 type FakeRestarter struct {
+	ApplicationRestartStub        func(app models.Application, orgName string, spaceName string) error
+	applicationRestartMutex       sync.RWMutex
+	applicationRestartArgsForCall []struct {
+		app       models.Application
+		orgName   string
+		spaceName string
+	}
+	applicationRestartReturns struct {
+		result1 error
+	}
+	ApplicationDeployStub        func(app models.Application, dropletGUID string, maxInFlight *int) error
+	applicationDeployMutex       sync.RWMutex
+	applicationDeployArgsForCall []struct {
+		app         models.Application
+		dropletGUID string
+		maxInFlight *int
+	}
+	applicationDeployReturns struct {
+		result1 error
+	}
 	MetaDataStub        func() commandregistry.CommandMetadata
 	metaDataMutex       sync.RWMutex
 	metaDataArgsForCall []struct{}
@@ -45,18 +65,78 @@ type FakeRestarter struct {
 	executeReturns struct {
 		result1 error
 	}
-	ApplicationRestartStub        func(app models.Application, orgName string, spaceName string) error
-	applicationRestartMutex       sync.RWMutex
-	applicationRestartArgsForCall []struct {
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRestarter) ApplicationRestart(app models.Application, orgName string, spaceName string) error {
+	fake.applicationRestartMutex.Lock()
+	fake.applicationRestartArgsForCall = append(fake.applicationRestartArgsForCall, struct {
 		app       models.Application
 		orgName   string
 		spaceName string
+	}{app, orgName, spaceName})
+	fake.recordInvocation("ApplicationRestart", []interface{}{app, orgName, spaceName})
+	fake.applicationRestartMutex.Unlock()
+	if fake.ApplicationRestartStub != nil {
+		return fake.ApplicationRestartStub(app, orgName, spaceName)
+	} else {
+		return fake.applicationRestartReturns.result1
 	}
-	applicationRestartReturns struct {
+}
+
+func (fake *FakeRestarter) ApplicationRestartCallCount() int {
+	fake.applicationRestartMutex.RLock()
+	defer fake.applicationRestartMutex.RUnlock()
+	return len(fake.applicationRestartArgsForCall)
+}
+
+func (fake *FakeRestarter) ApplicationRestartArgsForCall(i int) (models.Application, string, string) {
+	fake.applicationRestartMutex.RLock()
+	defer fake.applicationRestartMutex.RUnlock()
+	return fake.applicationRestartArgsForCall[i].app, fake.applicationRestartArgsForCall[i].orgName, fake.applicationRestartArgsForCall[i].spaceName
+}
+
+func (fake *FakeRestarter) ApplicationRestartReturns(result1 error) {
+	fake.ApplicationRestartStub = nil
+	fake.applicationRestartReturns = struct {
 		result1 error
+	}{result1}
+}
+
+func (fake *FakeRestarter) ApplicationDeploy(app models.Application, dropletGUID string, maxInFlight *int) error {
+	fake.applicationDeployMutex.Lock()
+	fake.applicationDeployArgsForCall = append(fake.applicationDeployArgsForCall, struct {
+		app         models.Application
+		dropletGUID string
+		maxInFlight *int
+	}{app, dropletGUID, maxInFlight})
+	fake.recordInvocation("ApplicationDeploy", []interface{}{app, dropletGUID, maxInFlight})
+	fake.applicationDeployMutex.Unlock()
+	if fake.ApplicationDeployStub != nil {
+		return fake.ApplicationDeployStub(app, dropletGUID, maxInFlight)
+	} else {
+		return fake.applicationDeployReturns.result1
 	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRestarter) ApplicationDeployCallCount() int {
+	fake.applicationDeployMutex.RLock()
+	defer fake.applicationDeployMutex.RUnlock()
+	return len(fake.applicationDeployArgsForCall)
+}
+
+func (fake *FakeRestarter) ApplicationDeployArgsForCall(i int) (models.Application, string, *int) {
+	fake.applicationDeployMutex.RLock()
+	defer fake.applicationDeployMutex.RUnlock()
+	return fake.applicationDeployArgsForCall[i].app, fake.applicationDeployArgsForCall[i].dropletGUID, fake.applicationDeployArgsForCall[i].maxInFlight
+}
+
+func (fake *FakeRestarter) ApplicationDeployReturns(result1 error) {
+	fake.ApplicationDeployStub = nil
+	fake.applicationDeployReturns = struct {
+		result1 error
+	}{result1}
 }
 
 func (fake *FakeRestarter) MetaData() commandregistry.CommandMetadata {
@@ -186,44 +266,13 @@ func (fake *FakeRestarter) ExecuteReturns(result1 error) {
 	}{result1}
 }
 
-func (fake *FakeRestarter) ApplicationRestart(app models.Application, orgName string, spaceName string) error {
-	fake.applicationRestartMutex.Lock()
-	fake.applicationRestartArgsForCall = append(fake.applicationRestartArgsForCall, struct {
-		app       models.Application
-		orgName   string
-		spaceName string
-	}{app, orgName, spaceName})
-	fake.recordInvocation("ApplicationRestart", []interface{}{app, orgName, spaceName})
-	fake.applicationRestartMutex.Unlock()
-	if fake.ApplicationRestartStub != nil {
-		return fake.ApplicationRestartStub(app, orgName, spaceName)
-	} else {
-		return fake.applicationRestartReturns.result1
-	}
-}
-
-func (fake *FakeRestarter) ApplicationRestartCallCount() int {
-	fake.applicationRestartMutex.RLock()
-	defer fake.applicationRestartMutex.RUnlock()
-	return len(fake.applicationRestartArgsForCall)
-}
-
-func (fake *FakeRestarter) ApplicationRestartArgsForCall(i int) (models.Application, string, string) {
-	fake.applicationRestartMutex.RLock()
-	defer fake.applicationRestartMutex.RUnlock()
-	return fake.applicationRestartArgsForCall[i].app, fake.applicationRestartArgsForCall[i].orgName, fake.applicationRestartArgsForCall[i].spaceName
-}
-
-func (fake *FakeRestarter) ApplicationRestartReturns(result1 error) {
-	fake.ApplicationRestartStub = nil
-	fake.applicationRestartReturns = struct {
-		result1 error
-	}{result1}
-}
-
 func (fake *FakeRestarter) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.applicationRestartMutex.RLock()
+	defer fake.applicationRestartMutex.RUnlock()
+	fake.applicationDeployMutex.RLock()
+	defer fake.applicationDeployMutex.RUnlock()
 	fake.metaDataMutex.RLock()
 	defer fake.metaDataMutex.RUnlock()
 	fake.setDependencyMutex.RLock()
@@ -232,8 +281,6 @@ func (fake *FakeRestarter) Invocations() map[string][][]interface{} {
 	defer fake.requirementsMutex.RUnlock()
 	fake.executeMutex.RLock()
 	defer fake.executeMutex.RUnlock()
-	fake.applicationRestartMutex.RLock()
-	defer fake.applicationRestartMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -12,6 +12,32 @@ import (
 )
 
 type FakeStarter struct {
+	SetStartTimeoutInSecondsStub        func(timeout int)
+	setStartTimeoutInSecondsMutex       sync.RWMutex
+	setStartTimeoutInSecondsArgsForCall []struct {
+		timeout int
+	}
+	SetStagingTimeoutInSecondsStub        func(timeout int)
+	setStagingTimeoutInSecondsMutex       sync.RWMutex
+	setStagingTimeoutInSecondsArgsForCall []struct {
+		timeout int
+	}
+	SetStagingStateListenerStub        func(listener func(appName string, state string))
+	setStagingStateListenerMutex       sync.RWMutex
+	setStagingStateListenerArgsForCall []struct {
+		listener func(appName string, state string)
+	}
+	ApplicationStartStub        func(app models.Application, orgName string, spaceName string) (updatedApp models.Application, err error)
+	applicationStartMutex       sync.RWMutex
+	applicationStartArgsForCall []struct {
+		app       models.Application
+		orgName   string
+		spaceName string
+	}
+	applicationStartReturns struct {
+		result1 models.Application
+		result2 error
+	}
 	MetaDataStub        func() commandregistry.CommandMetadata
 	metaDataMutex       sync.RWMutex
 	metaDataArgsForCall []struct{}
@@ -45,24 +71,116 @@ type FakeStarter struct {
 	executeReturns struct {
 		result1 error
 	}
-	SetStartTimeoutInSecondsStub        func(timeout int)
-	setStartTimeoutInSecondsMutex       sync.RWMutex
-	setStartTimeoutInSecondsArgsForCall []struct {
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeStarter) SetStartTimeoutInSeconds(timeout int) {
+	fake.setStartTimeoutInSecondsMutex.Lock()
+	fake.setStartTimeoutInSecondsArgsForCall = append(fake.setStartTimeoutInSecondsArgsForCall, struct {
 		timeout int
+	}{timeout})
+	fake.recordInvocation("SetStartTimeoutInSeconds", []interface{}{timeout})
+	fake.setStartTimeoutInSecondsMutex.Unlock()
+	if fake.SetStartTimeoutInSecondsStub != nil {
+		fake.SetStartTimeoutInSecondsStub(timeout)
 	}
-	ApplicationStartStub        func(app models.Application, orgName string, spaceName string) (updatedApp models.Application, err error)
-	applicationStartMutex       sync.RWMutex
-	applicationStartArgsForCall []struct {
+}
+
+func (fake *FakeStarter) SetStartTimeoutInSecondsCallCount() int {
+	fake.setStartTimeoutInSecondsMutex.RLock()
+	defer fake.setStartTimeoutInSecondsMutex.RUnlock()
+	return len(fake.setStartTimeoutInSecondsArgsForCall)
+}
+
+func (fake *FakeStarter) SetStartTimeoutInSecondsArgsForCall(i int) int {
+	fake.setStartTimeoutInSecondsMutex.RLock()
+	defer fake.setStartTimeoutInSecondsMutex.RUnlock()
+	return fake.setStartTimeoutInSecondsArgsForCall[i].timeout
+}
+
+func (fake *FakeStarter) SetStagingTimeoutInSeconds(timeout int) {
+	fake.setStagingTimeoutInSecondsMutex.Lock()
+	fake.setStagingTimeoutInSecondsArgsForCall = append(fake.setStagingTimeoutInSecondsArgsForCall, struct {
+		timeout int
+	}{timeout})
+	fake.recordInvocation("SetStagingTimeoutInSeconds", []interface{}{timeout})
+	fake.setStagingTimeoutInSecondsMutex.Unlock()
+	if fake.SetStagingTimeoutInSecondsStub != nil {
+		fake.SetStagingTimeoutInSecondsStub(timeout)
+	}
+}
+
+func (fake *FakeStarter) SetStagingTimeoutInSecondsCallCount() int {
+	fake.setStagingTimeoutInSecondsMutex.RLock()
+	defer fake.setStagingTimeoutInSecondsMutex.RUnlock()
+	return len(fake.setStagingTimeoutInSecondsArgsForCall)
+}
+
+func (fake *FakeStarter) SetStagingTimeoutInSecondsArgsForCall(i int) int {
+	fake.setStagingTimeoutInSecondsMutex.RLock()
+	defer fake.setStagingTimeoutInSecondsMutex.RUnlock()
+	return fake.setStagingTimeoutInSecondsArgsForCall[i].timeout
+}
+
+func (fake *FakeStarter) SetStagingStateListener(listener func(appName string, state string)) {
+	fake.setStagingStateListenerMutex.Lock()
+	fake.setStagingStateListenerArgsForCall = append(fake.setStagingStateListenerArgsForCall, struct {
+		listener func(appName string, state string)
+	}{listener})
+	fake.recordInvocation("SetStagingStateListener", []interface{}{listener})
+	fake.setStagingStateListenerMutex.Unlock()
+	if fake.SetStagingStateListenerStub != nil {
+		fake.SetStagingStateListenerStub(listener)
+	}
+}
+
+func (fake *FakeStarter) SetStagingStateListenerCallCount() int {
+	fake.setStagingStateListenerMutex.RLock()
+	defer fake.setStagingStateListenerMutex.RUnlock()
+	return len(fake.setStagingStateListenerArgsForCall)
+}
+
+func (fake *FakeStarter) SetStagingStateListenerArgsForCall(i int) func(appName string, state string) {
+	fake.setStagingStateListenerMutex.RLock()
+	defer fake.setStagingStateListenerMutex.RUnlock()
+	return fake.setStagingStateListenerArgsForCall[i].listener
+}
+
+func (fake *FakeStarter) ApplicationStart(app models.Application, orgName string, spaceName string) (updatedApp models.Application, err error) {
+	fake.applicationStartMutex.Lock()
+	fake.applicationStartArgsForCall = append(fake.applicationStartArgsForCall, struct {
 		app       models.Application
 		orgName   string
 		spaceName string
+	}{app, orgName, spaceName})
+	fake.recordInvocation("ApplicationStart", []interface{}{app, orgName, spaceName})
+	fake.applicationStartMutex.Unlock()
+	if fake.ApplicationStartStub != nil {
+		return fake.ApplicationStartStub(app, orgName, spaceName)
+	} else {
+		return fake.applicationStartReturns.result1, fake.applicationStartReturns.result2
 	}
-	applicationStartReturns struct {
+}
+
+func (fake *FakeStarter) ApplicationStartCallCount() int {
+	fake.applicationStartMutex.RLock()
+	defer fake.applicationStartMutex.RUnlock()
+	return len(fake.applicationStartArgsForCall)
+}
+
+func (fake *FakeStarter) ApplicationStartArgsForCall(i int) (models.Application, string, string) {
+	fake.applicationStartMutex.RLock()
+	defer fake.applicationStartMutex.RUnlock()
+	return fake.applicationStartArgsForCall[i].app, fake.applicationStartArgsForCall[i].orgName, fake.applicationStartArgsForCall[i].spaceName
+}
+
+func (fake *FakeStarter) ApplicationStartReturns(result1 models.Application, result2 error) {
+	fake.ApplicationStartStub = nil
+	fake.applicationStartReturns = struct {
 		result1 models.Application
 		result2 error
-	}
-	invocations      map[string][][]interface{}
-	invocationsMutex sync.RWMutex
+	}{result1, result2}
 }
 
 func (fake *FakeStarter) MetaData() commandregistry.CommandMetadata {
@@ -192,69 +310,17 @@ func (fake *FakeStarter) ExecuteReturns(result1 error) {
 	}{result1}
 }
 
-func (fake *FakeStarter) SetStartTimeoutInSeconds(timeout int) {
-	fake.setStartTimeoutInSecondsMutex.Lock()
-	fake.setStartTimeoutInSecondsArgsForCall = append(fake.setStartTimeoutInSecondsArgsForCall, struct {
-		timeout int
-	}{timeout})
-	fake.recordInvocation("SetStartTimeoutInSeconds", []interface{}{timeout})
-	fake.setStartTimeoutInSecondsMutex.Unlock()
-	if fake.SetStartTimeoutInSecondsStub != nil {
-		fake.SetStartTimeoutInSecondsStub(timeout)
-	}
-}
-
-func (fake *FakeStarter) SetStartTimeoutInSecondsCallCount() int {
-	fake.setStartTimeoutInSecondsMutex.RLock()
-	defer fake.setStartTimeoutInSecondsMutex.RUnlock()
-	return len(fake.setStartTimeoutInSecondsArgsForCall)
-}
-
-func (fake *FakeStarter) SetStartTimeoutInSecondsArgsForCall(i int) int {
+func (fake *FakeStarter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
 	fake.setStartTimeoutInSecondsMutex.RLock()
 	defer fake.setStartTimeoutInSecondsMutex.RUnlock()
-	return fake.setStartTimeoutInSecondsArgsForCall[i].timeout
-}
-
-func (fake *FakeStarter) ApplicationStart(app models.Application, orgName string, spaceName string) (updatedApp models.Application, err error) {
-	fake.applicationStartMutex.Lock()
-	fake.applicationStartArgsForCall = append(fake.applicationStartArgsForCall, struct {
-		app       models.Application
-		orgName   string
-		spaceName string
-	}{app, orgName, spaceName})
-	fake.recordInvocation("ApplicationStart", []interface{}{app, orgName, spaceName})
-	fake.applicationStartMutex.Unlock()
-	if fake.ApplicationStartStub != nil {
-		return fake.ApplicationStartStub(app, orgName, spaceName)
-	} else {
-		return fake.applicationStartReturns.result1, fake.applicationStartReturns.result2
-	}
-}
-
-func (fake *FakeStarter) ApplicationStartCallCount() int {
+	fake.setStagingTimeoutInSecondsMutex.RLock()
+	defer fake.setStagingTimeoutInSecondsMutex.RUnlock()
+	fake.setStagingStateListenerMutex.RLock()
+	defer fake.setStagingStateListenerMutex.RUnlock()
 	fake.applicationStartMutex.RLock()
 	defer fake.applicationStartMutex.RUnlock()
-	return len(fake.applicationStartArgsForCall)
-}
-
-func (fake *FakeStarter) ApplicationStartArgsForCall(i int) (models.Application, string, string) {
-	fake.applicationStartMutex.RLock()
-	defer fake.applicationStartMutex.RUnlock()
-	return fake.applicationStartArgsForCall[i].app, fake.applicationStartArgsForCall[i].orgName, fake.applicationStartArgsForCall[i].spaceName
-}
-
-func (fake *FakeStarter) ApplicationStartReturns(result1 models.Application, result2 error) {
-	fake.ApplicationStartStub = nil
-	fake.applicationStartReturns = struct {
-		result1 models.Application
-		result2 error
-	}{result1, result2}
-}
-
-func (fake *FakeStarter) Invocations() map[string][][]interface{} {
-	fake.invocationsMutex.RLock()
-	defer fake.invocationsMutex.RUnlock()
 	fake.metaDataMutex.RLock()
 	defer fake.metaDataMutex.RUnlock()
 	fake.setDependencyMutex.RLock()
@@ -263,10 +329,6 @@ func (fake *FakeStarter) Invocations() map[string][][]interface{} {
 	defer fake.requirementsMutex.RUnlock()
 	fake.executeMutex.RLock()
 	defer fake.executeMutex.RUnlock()
-	fake.setStartTimeoutInSecondsMutex.RLock()
-	defer fake.setStartTimeoutInSecondsMutex.RUnlock()
-	fake.applicationStartMutex.RLock()
-	defer fake.applicationStartMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,224 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/applications"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type ApplyManifest struct {
+	ui             terminal.UI
+	config         coreconfig.Reader
+	manifestRepo   manifest.Repository
+	appSummaryRepo api.AppSummaryRepository
+	appRepo        applications.Repository
+	actor          actors.PushActor
+	pusher         commandregistry.Command
+}
+
+func init() {
+	commandregistry.Register(&ApplyManifest{})
+}
+
+func (cmd *ApplyManifest) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["f"] = &flags.StringFlag{ShortName: "f", Usage: T("Path to the manifest of the space")}
+	fs["force"] = &flags.BoolFlag{Name: "force", Usage: T("Delete the apps that are not in the manifest without asking for confirmation")}
+	fs["lenient"] = &flags.BoolFlag{Name: "lenient", Usage: T("Warn about manifest attributes that push does not know instead of failing")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "apply-manifest",
+		Description: T("Make the apps in the targeted space match a manifest, creating, updating and deleting apps"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME apply-manifest -f %s [--force] [--lenient]", T("MANIFEST_PATH")),
+			"\n\n",
+			T("Apps in the manifest are pushed as with 'CF_NAME push -f'. Apps in the space that are not in the manifest are deleted, after confirmation."),
+			"\n\n",
+			T("An app that already has the instances, memory, disk, buildpack, environment variables and routes the manifest declares is not pushed again. Use 'CF_NAME push' to deploy new app bits."),
+		},
+		Examples: []string{
+			"CF_NAME apply-manifest -f space-manifest.yml",
+			"CF_NAME apply-manifest -f space-manifest.yml --force",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *ApplyManifest) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	usageReq := requirementsFactory.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+		T("Requires the -f option and no arguments"),
+		func() bool {
+			return len(fc.Args()) != 0 || fc.String("f") == ""
+		},
+	)
+
+	reqs := []requirements.Requirement{
+		usageReq,
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+	}
+
+	return reqs, nil
+}
+
+func (cmd *ApplyManifest) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.manifestRepo = deps.ManifestRepo
+	cmd.appSummaryRepo = deps.RepoLocator.GetAppSummaryRepository()
+	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
+	cmd.actor = deps.PushActor
+
+	pusher := commandregistry.Commands.FindCommand("push")
+	cmd.pusher = pusher.SetDependency(deps, false)
+
+	return cmd
+}
+
+func (cmd *ApplyManifest) Execute(c flags.FlagContext) error {
+	m, err := cmd.manifestRepo.ReadManifest(c.String("f"))
+	if err != nil {
+		return errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+
+	warnings, err := m.CheckSchema(c.Bool("lenient"))
+	if err != nil {
+		return errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+	for _, warning := range warnings {
+		cmd.ui.Warn(warning.Error())
+	}
+
+	declared, err := m.Applications()
+	if err != nil {
+		return errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+
+	errs := cmd.actor.ValidateAppParams(declared)
+	for _, app := range declared {
+		if app.Name == nil {
+			errs = append(errs, errors.New(T("Every app in the manifest of a space must have a name")))
+			break
+		}
+	}
+	if len(errs) > 0 {
+		errStr := T("Invalid application configuration") + ":"
+		for _, e := range errs {
+			errStr = fmt.Sprintf("%s\n%s", errStr, e.Error())
+		}
+		return errors.New(errStr)
+	}
+
+	cmd.ui.Say(T("Applying manifest {{.Path}} to org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"Path":      terminal.EntityNameColor(m.Path),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	existing, err := cmd.appSummaryRepo.GetSummariesInCurrentSpace()
+	if err != nil {
+		return err
+	}
+
+	plan := actors.PlanManifest(declared, existing)
+	err = cmd.sayPlan(plan)
+	if err != nil {
+		return err
+	}
+
+	if !plan.HasChanges() {
+		cmd.ui.Ok()
+		cmd.ui.Say(T("Space already matches manifest {{.Path}}", map[string]interface{}{"Path": m.Path}))
+		return nil
+	}
+
+	if len(plan.Delete) > 0 && !c.Bool("force") {
+		names := []string{}
+		for _, app := range plan.Delete {
+			names = append(names, app.Name)
+		}
+
+		confirmed := cmd.ui.Confirm(T("Really delete the apps {{.AppNames}}, which are not in the manifest?{{.Prompt}}",
+			map[string]interface{}{
+				"AppNames": strings.Join(names, ", "),
+				"Prompt":   terminal.PromptColor(">"),
+			}))
+		if !confirmed {
+			cmd.ui.Say(T("Manifest not applied"))
+			return nil
+		}
+	}
+
+	for _, name := range append(plan.Create, plan.Update...) {
+		pushContext := flags.NewFlagContext(cmd.pusher.MetaData().Flags)
+		pushArgs := []string{"-f", m.Path}
+		if c.Bool("lenient") {
+			pushArgs = append(pushArgs, "--lenient")
+		}
+
+		err = pushContext.Parse(append(pushArgs, name)...)
+		if err != nil {
+			return err
+		}
+
+		err = cmd.pusher.Execute(pushContext)
+		if err != nil {
+			return err
+		}
+	}
+
+	// apps are deleted last, so the apps that replace them are already running
+	for _, app := range plan.Delete {
+		cmd.ui.Say(T("Deleting app {{.AppName}}...", map[string]interface{}{"AppName": terminal.EntityNameColor(app.Name)}))
+
+		err = cmd.appRepo.Delete(app.GUID)
+		if err != nil {
+			return err
+		}
+
+		cmd.ui.Ok()
+	}
+
+	cmd.ui.Say("")
+	cmd.ui.Ok()
+	cmd.ui.Say(T("Space matches manifest {{.Path}}", map[string]interface{}{"Path": m.Path}))
+	return nil
+}
+
+func (cmd *ApplyManifest) sayPlan(plan actors.ManifestPlan) error {
+	cmd.ui.Say("")
+
+	table := cmd.ui.Table([]string{T("app"), T("change")})
+	for _, name := range plan.Create {
+		table.Add(name, terminal.SuccessColor(T("create")))
+	}
+	for _, name := range plan.Update {
+		table.Add(name, T("update"))
+	}
+	for _, name := range plan.Unchanged {
+		table.Add(name, T("unchanged"))
+	}
+	for _, app := range plan.Delete {
+		table.Add(app.Name, terminal.FailureColor(T("delete")))
+	}
+	err := table.Print()
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say("")
+	return nil
+}
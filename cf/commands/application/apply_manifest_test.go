@@ -0,0 +1,292 @@
+package application_test
+
+import (
+	"errors"
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commandregistry/commandregistryfakes"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/manifest/manifestfakes"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	"code.cloudfoundry.org/cli/utils/generic"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("apply-manifest command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		manifestRepo        *manifestfakes.FakeRepository
+		appSummaryRepo      *apifakes.FakeAppSummaryRepository
+		appRepo             *applicationsfakes.FakeRepository
+		pushActor           *actorsfakes.FakePushActor
+		pusher              *commandregistryfakes.FakeCommand
+		originalPush        commandregistry.Command
+		deps                commandregistry.Dependency
+		pushContext         flags.FlagContext
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = testconfig.NewRepositoryWithDefaults()
+		deps.ManifestRepo = manifestRepo
+		deps.PushActor = pushActor
+		deps.RepoLocator = deps.RepoLocator.SetAppSummaryRepository(appSummaryRepo)
+		deps.RepoLocator = deps.RepoLocator.SetApplicationRepository(appRepo)
+
+		//inject fake 'push' into registry
+		commandregistry.Register(pusher)
+
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("apply-manifest").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("apply-manifest", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	existingApp := func(name string) models.Application {
+		app := models.Application{}
+		app.Name = name
+		app.GUID = name + "-guid"
+		return app
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		deps = commandregistry.NewDependency(os.Stdout, new(tracefakes.FakePrinter), "")
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewUsageRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+
+		manifestRepo = new(manifestfakes.FakeRepository)
+		manifestRepo.ReadManifestReturns(&manifest.Manifest{
+			Path: "space-manifest.yml",
+			Data: generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{"name": "new-app"}),
+					generic.NewMap(map[interface{}]interface{}{"name": "kept-app"}),
+				},
+			}),
+		}, nil)
+
+		appSummaryRepo = new(apifakes.FakeAppSummaryRepository)
+		appSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.Application{
+			existingApp("kept-app"),
+			existingApp("old-app"),
+		}, nil)
+
+		appRepo = new(applicationsfakes.FakeRepository)
+		pushActor = new(actorsfakes.FakePushActor)
+
+		//save original command and restore later
+		originalPush = commandregistry.Commands.FindCommand("push")
+
+		//setup fakes to correctly interact with commandregistry
+		pusher = new(commandregistryfakes.FakeCommand)
+		pusher.SetDependencyStub = func(_ commandregistry.Dependency, _ bool) commandregistry.Command {
+			return pusher
+		}
+		pusher.MetaDataReturns(originalPush.MetaData())
+		pusher.ExecuteStub = func(context flags.FlagContext) error {
+			pushContext = context
+			return nil
+		}
+	})
+
+	AfterEach(func() {
+		commandregistry.Register(originalPush)
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage when the manifest is not given", func() {
+			requirementsFactory.NewUsageRequirementReturns(requirements.Failing{Message: "Incorrect Usage"})
+			Expect(runCommand()).To(BeFalse())
+		})
+
+		It("fails when not logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(runCommand("-f", "space-manifest.yml")).To(BeFalse())
+		})
+
+		It("fails when a space is not targeted", func() {
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Failing{Message: "not targeting space"})
+			Expect(runCommand("-f", "space-manifest.yml")).To(BeFalse())
+		})
+	})
+
+	It("shows the plan, pushes the manifest and deletes the apps it does not declare once confirmed", func() {
+		ui.Inputs = []string{"y"}
+
+		Expect(runCommand("-f", "space-manifest.yml")).To(BeTrue())
+		Expect(manifestRepo.ReadManifestArgsForCall(0)).To(Equal("space-manifest.yml"))
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Applying manifest space-manifest.yml to org my-org / space my-space as my-user..."},
+			[]string{"app", "change"},
+			[]string{"new-app", "create"},
+			[]string{"kept-app", "unchanged"},
+			[]string{"old-app", "delete"},
+			[]string{"Deleting app old-app..."},
+			[]string{"Space matches manifest space-manifest.yml"},
+		))
+		Expect(ui.Prompts).To(ContainSubstrings(
+			[]string{"Really delete the apps old-app, which are not in the manifest?"},
+		))
+
+		Expect(pusher.ExecuteCallCount()).To(Equal(1))
+		Expect(pushContext.String("f")).To(Equal("space-manifest.yml"))
+		Expect(pushContext.Args()).To(Equal([]string{"new-app"}))
+
+		Expect(appRepo.DeleteCallCount()).To(Equal(1))
+		Expect(appRepo.DeleteArgsForCall(0)).To(Equal("old-app-guid"))
+	})
+
+	It("pushes the apps whose declared attributes differ from the space", func() {
+		manifestRepo.ReadManifestReturns(&manifest.Manifest{
+			Path: "space-manifest.yml",
+			Data: generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{"name": "kept-app", "instances": 3}),
+				},
+			}),
+		}, nil)
+		keptApp := existingApp("kept-app")
+		keptApp.InstanceCount = 1
+		appSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.Application{keptApp}, nil)
+
+		Expect(runCommand("-f", "space-manifest.yml")).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"kept-app", "update"}))
+		Expect(pusher.ExecuteCallCount()).To(Equal(1))
+		Expect(pushContext.Args()).To(Equal([]string{"kept-app"}))
+	})
+
+	It("does not push the apps that already match the manifest", func() {
+		manifestRepo.ReadManifestReturns(&manifest.Manifest{
+			Path: "space-manifest.yml",
+			Data: generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{"name": "kept-app", "instances": 3}),
+				},
+			}),
+		}, nil)
+		keptApp := existingApp("kept-app")
+		keptApp.InstanceCount = 3
+		appSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.Application{keptApp}, nil)
+
+		Expect(runCommand("-f", "space-manifest.yml")).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"kept-app", "unchanged"},
+			[]string{"Space already matches manifest space-manifest.yml"},
+		))
+		Expect(pusher.ExecuteCallCount()).To(BeZero())
+		Expect(appRepo.DeleteCallCount()).To(BeZero())
+	})
+
+	It("changes nothing when the deletion is not confirmed", func() {
+		ui.Inputs = []string{"n"}
+
+		Expect(runCommand("-f", "space-manifest.yml")).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"Manifest not applied"}))
+		Expect(pusher.ExecuteCallCount()).To(BeZero())
+		Expect(appRepo.DeleteCallCount()).To(BeZero())
+	})
+
+	It("does not ask for confirmation with --force", func() {
+		Expect(runCommand("-f", "space-manifest.yml", "--force")).To(BeTrue())
+		Expect(ui.Prompts).To(BeEmpty())
+		Expect(appRepo.DeleteCallCount()).To(Equal(1))
+	})
+
+	It("does not ask for confirmation when no app is deleted", func() {
+		appSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.Application{existingApp("kept-app")}, nil)
+
+		Expect(runCommand("-f", "space-manifest.yml")).To(BeTrue())
+		Expect(ui.Prompts).To(BeEmpty())
+		Expect(pusher.ExecuteCallCount()).To(Equal(1))
+		Expect(appRepo.DeleteCallCount()).To(BeZero())
+	})
+
+	It("does not push when the manifest declares no apps", func() {
+		manifestRepo.ReadManifestReturns(&manifest.Manifest{
+			Path: "space-manifest.yml",
+			Data: generic.NewMap(map[interface{}]interface{}{"applications": []interface{}{}}),
+		}, nil)
+
+		Expect(runCommand("-f", "space-manifest.yml", "--force")).To(BeTrue())
+		Expect(pusher.ExecuteCallCount()).To(BeZero())
+		Expect(appRepo.DeleteCallCount()).To(Equal(2))
+	})
+
+	It("fails when an app in the manifest has no name", func() {
+		manifestRepo.ReadManifestReturns(&manifest.Manifest{
+			Path: "space-manifest.yml",
+			Data: generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{"memory": "256M"}),
+				},
+			}),
+		}, nil)
+
+		Expect(runCommand("-f", "space-manifest.yml")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Every app in the manifest of a space must have a name"},
+		))
+		Expect(appSummaryRepo.GetSummariesInCurrentSpaceCallCount()).To(BeZero())
+	})
+
+	It("fails without deleting anything when the push fails", func() {
+		pusher.ExecuteStub = nil
+		pusher.ExecuteReturns(errors.New("push-error"))
+
+		Expect(runCommand("-f", "space-manifest.yml", "--force")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"push-error"}))
+		Expect(appRepo.DeleteCallCount()).To(BeZero())
+	})
+
+	It("fails when the manifest does not match the schema", func() {
+		manifestRepo.ReadManifestReturns(&manifest.Manifest{
+			Path: "space-manifest.yml",
+			Data: generic.NewMap(map[interface{}]interface{}{"applications": []interface{}{}}),
+			Problems: []manifest.SchemaProblem{
+				{Kind: manifest.UnknownAttribute, File: "space-manifest.yml", Line: 3, Column: 3, Message: "Unknown attribute 'applications[0].memroy'"},
+			},
+		}, nil)
+
+		Expect(runCommand("-f", "space-manifest.yml", "--force")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"space-manifest.yml:3:3: Unknown attribute 'applications[0].memroy'"},
+		))
+		Expect(appSummaryRepo.GetSummariesInCurrentSpaceCallCount()).To(BeZero())
+	})
+
+	It("passes --lenient on to push", func() {
+		Expect(runCommand("-f", "space-manifest.yml", "--force", "--lenient")).To(BeTrue())
+		Expect(pushContext.Bool("lenient")).To(BeTrue())
+	})
+
+	It("fails when the manifest cannot be read", func() {
+		manifestRepo.ReadManifestReturns(nil, errors.New("read-error"))
+
+		Expect(runCommand("-f", "space-manifest.yml")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Error reading manifest file"},
+			[]string{"read-error"},
+		))
+	})
+})
@@ -1,9 +1,15 @@
 package application
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
@@ -11,6 +17,7 @@ import (
 	"code.cloudfoundry.org/cli/plugin/models"
 
 	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/appinstances"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/formatters"
 	"code.cloudfoundry.org/cli/cf/requirements"
@@ -18,10 +25,16 @@ import (
 	"code.cloudfoundry.org/cli/cf/uihelpers"
 )
 
+// appStatsConcurrency is how many apps cf apps --stats fetches the instance
+// stats of at the same time.
+const appStatsConcurrency = 8
+
 type ListApps struct {
-	ui             terminal.UI
-	config         coreconfig.Reader
-	appSummaryRepo api.AppSummaryRepository
+	ui               terminal.UI
+	config           coreconfig.Reader
+	appSummaryRepo   api.AppSummaryRepository
+	appInstancesRepo appinstances.Repository
+	metadataActor    actors.MetadataActor
 
 	pluginAppModels *[]plugin_models.GetAppsModel
 	pluginCall      bool
@@ -32,13 +45,28 @@ func init() {
 }
 
 func (cmd *ListApps) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["name-filter"] = &flags.StringFlag{Name: "name-filter", Usage: T("Only list apps whose name matches this regular expression")}
+	fs["state"] = &flags.StringFlag{Name: "state", Usage: T("Only list apps in this requested state (started or stopped)")}
+	fs["buildpack"] = &flags.StringFlag{Name: "buildpack", Usage: T("Only list apps using this buildpack, either specified or detected")}
+	fs["stats"] = &flags.BoolFlag{Name: "stats", Usage: T("Also show the CPU, memory and disk usage of the running instances of each app")}
+	fs["labels"] = &flags.StringFlag{Name: "labels", Usage: T("Only list apps whose labels match this selector, such as 'env=prod,tier!=web'")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "apps",
 		ShortName:   "a",
 		Description: T("List all apps in the target space"),
 		Usage: []string{
-			"CF_NAME apps",
+			"CF_NAME apps [--name-filter REGEX] [--state (started | stopped)] [--buildpack BUILDPACK] [--labels SELECTOR] [--stats]",
+		},
+		Examples: []string{
+			`CF_NAME apps --name-filter "^billing-"`,
+			"CF_NAME apps --state stopped --buildpack ruby_buildpack",
+			"CF_NAME apps --state started --stats",
+			"CF_NAME apps --labels 'env in (prod,staging),!deprecated'",
 		},
+		Flags:            fs,
+		StructuredOutput: true,
 	}
 }
 
@@ -56,6 +84,10 @@ func (cmd *ListApps) Requirements(requirementsFactory requirements.Factory, fc f
 		requirementsFactory.NewTargetedSpaceRequirement(),
 	}
 
+	if fc.IsSet("labels") {
+		reqs = append(reqs, requirementsFactory.NewMinAPIVersionRequirement("Option '--labels'", cf.MetadataMinimumAPIVersion))
+	}
+
 	return reqs, nil
 }
 
@@ -63,12 +95,19 @@ func (cmd *ListApps) SetDependency(deps commandregistry.Dependency, pluginCall b
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.appSummaryRepo = deps.RepoLocator.GetAppSummaryRepository()
+	cmd.appInstancesRepo = deps.RepoLocator.GetAppInstancesRepository()
+	cmd.metadataActor = deps.MetadataActor
 	cmd.pluginAppModels = deps.PluginModels.AppsSummary
 	cmd.pluginCall = pluginCall
 	return cmd
 }
 
 func (cmd *ListApps) Execute(c flags.FlagContext) error {
+	filter, err := newAppFilter(c)
+	if err != nil {
+		return err
+	}
+
 	cmd.ui.Say(T("Getting apps in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
 		map[string]interface{}{
 			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
@@ -81,6 +120,13 @@ func (cmd *ListApps) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	if c.IsSet("labels") {
+		filter.guids, err = cmd.metadataActor.MatchingGUIDs("app", c.String("labels"))
+		if err != nil {
+			return err
+		}
+	}
+
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
@@ -89,7 +135,15 @@ func (cmd *ListApps) Execute(c flags.FlagContext) error {
 		return nil
 	}
 
-	table := cmd.ui.Table([]string{
+	if filter.active() {
+		apps = filter.apply(apps)
+		if len(apps) == 0 {
+			cmd.ui.Say(T("No apps found matching the given filters"))
+			return nil
+		}
+	}
+
+	headers := []string{
 		T("name"),
 		T("requested state"),
 		T("instances"),
@@ -97,10 +151,21 @@ func (cmd *ListApps) Execute(c flags.FlagContext) error {
 		T("disk"),
 		// Hide this column #117189491
 		// T("app ports"),
-		T("urls"),
-	})
+	}
+
+	fieldKeys := []string{"name", "requested_state", "instances", "memory", "disk"}
+
+	var usages []appUsage
+	if c.Bool("stats") {
+		headers = append(headers, T("cpu"), T("memory usage"), T("disk usage"))
+		fieldKeys = append(fieldKeys, "cpu", "memory_usage", "disk_usage")
+		usages = cmd.fetchUsages(apps)
+	}
 
-	for _, application := range apps {
+	table := cmd.ui.Table(append(headers, T("urls")))
+	table.Table.SetFieldKeys(append(fieldKeys, "urls")...)
+
+	for i, application := range apps {
 		var urls []string
 		for _, route := range application.Routes {
 			urls = append(urls, route.URL())
@@ -111,28 +176,190 @@ func (cmd *ListApps) Execute(c flags.FlagContext) error {
 			appPorts[i] = strconv.Itoa(p)
 		}
 
-		table.Add(
+		row := []string{
 			application.Name,
 			uihelpers.ColoredAppState(application.ApplicationFields),
 			uihelpers.ColoredAppInstances(application.ApplicationFields),
-			formatters.ByteSize(application.Memory*formatters.MEGABYTE),
-			formatters.ByteSize(application.DiskQuota*formatters.MEGABYTE),
+			formatters.ByteSize(application.Memory * formatters.MEGABYTE),
+			formatters.ByteSize(application.DiskQuota * formatters.MEGABYTE),
 			// Hide this column #117189491
 			// strings.Join(appPorts, ", "),
-			strings.Join(urls, ", "),
-		)
+		}
+		if usages != nil {
+			row = append(row, usages[i].columns()...)
+		}
+
+		table.Add(append(row, strings.Join(urls, ", "))...)
 	}
 
 	err = table.Print()
 	if err != nil {
 		return err
 	}
+
+	failed := []string{}
+	for i, usage := range usages {
+		if usage.err != nil {
+			failed = append(failed, apps[i].Name)
+		}
+	}
+	if len(failed) > 0 {
+		cmd.ui.Warn(T("Could not fetch the usage of apps: {{.AppNames}}",
+			map[string]interface{}{"AppNames": strings.Join(failed, ", ")}))
+	}
+
 	if cmd.pluginCall {
 		cmd.populatePluginModel(apps)
 	}
 	return nil
 }
 
+// appUsage is the usage of the running instances of an app: the mean CPU
+// usage across them, and the memory and disk they use in total.
+type appUsage struct {
+	fetched bool
+	running int
+	cpu     float64
+	mem     int64
+	disk    int64
+	err     error
+}
+
+// fetchUsages fetches the instance stats of the started apps, up to
+// appStatsConcurrency apps at the same time. Stopped apps have no instances
+// to ask, so they are left out.
+func (cmd *ListApps) fetchUsages(apps []models.Application) []appUsage {
+	usages := make([]appUsage, len(apps))
+
+	var wg sync.WaitGroup
+	work := make(chan int)
+	for i := 0; i < appStatsConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range work {
+				usages[index] = cmd.fetchUsage(apps[index].GUID)
+			}
+		}()
+	}
+
+	for i, app := range apps {
+		if app.State == models.ApplicationStateStarted {
+			work <- i
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	return usages
+}
+
+func (cmd *ListApps) fetchUsage(appGUID string) appUsage {
+	instances, err := cmd.appInstancesRepo.GetInstances(appGUID)
+	if err != nil {
+		return appUsage{fetched: true, err: err}
+	}
+
+	usage := appUsage{fetched: true}
+	for _, instance := range instances {
+		if instance.State != models.InstanceRunning {
+			continue
+		}
+		usage.running++
+		usage.cpu += instance.CPUUsage
+		usage.mem += instance.MemUsage
+		usage.disk += instance.DiskUsage
+	}
+	if usage.running > 0 {
+		usage.cpu /= float64(usage.running)
+	}
+
+	return usage
+}
+
+// columns are the cells of the usage columns: "-" for an app without running
+// instances and "?" for one whose stats could not be fetched.
+func (usage appUsage) columns() []string {
+	switch {
+	case usage.err != nil:
+		return []string{"?", "?", "?"}
+	case !usage.fetched || usage.running == 0:
+		return []string{"-", "-", "-"}
+	default:
+		return []string{
+			fmt.Sprintf("%.1f%%", usage.cpu*100),
+			formatters.ByteSize(usage.mem),
+			formatters.ByteSize(usage.disk),
+		}
+	}
+}
+
+// appFilter selects apps by name, state, buildpack and labels. The space
+// summary endpoint does not accept query filters, so the filtering is done
+// here once the summaries have been fetched. Labels are matched by the v3 API,
+// which returns the guids of the apps that match the selector.
+type appFilter struct {
+	name      *regexp.Regexp
+	state     string
+	buildpack string
+	guids     map[string]bool
+}
+
+func newAppFilter(c flags.FlagContext) (appFilter, error) {
+	var filter appFilter
+
+	if c.IsSet("name-filter") {
+		name, err := regexp.Compile(c.String("name-filter"))
+		if err != nil {
+			return appFilter{}, errors.New(T("Invalid value {{.Value}} for --name-filter: {{.Err}}",
+				map[string]interface{}{"Value": c.String("name-filter"), "Err": err.Error()}))
+		}
+		filter.name = name
+	}
+
+	if c.IsSet("state") {
+		filter.state = strings.ToLower(c.String("state"))
+		if filter.state != models.ApplicationStateStarted && filter.state != models.ApplicationStateStopped {
+			return appFilter{}, errors.New(T("Invalid value {{.Value}} for --state; it must be started or stopped",
+				map[string]interface{}{"Value": c.String("state")}))
+		}
+	}
+
+	filter.buildpack = c.String("buildpack")
+
+	return filter, nil
+}
+
+func (filter appFilter) active() bool {
+	return filter.name != nil || filter.state != "" || filter.buildpack != "" || filter.guids != nil
+}
+
+func (filter appFilter) apply(apps []models.Application) []models.Application {
+	filtered := []models.Application{}
+	for _, app := range apps {
+		if filter.matches(app) {
+			filtered = append(filtered, app)
+		}
+	}
+	return filtered
+}
+
+func (filter appFilter) matches(app models.Application) bool {
+	if filter.name != nil && !filter.name.MatchString(app.Name) {
+		return false
+	}
+	if filter.state != "" && app.State != filter.state {
+		return false
+	}
+	if filter.buildpack != "" && app.BuildpackURL != filter.buildpack && app.DetectedBuildpack != filter.buildpack {
+		return false
+	}
+	if filter.guids != nil && !filter.guids[app.GUID] {
+		return false
+	}
+	return true
+}
+
 func (cmd *ListApps) populatePluginModel(apps []models.Application) {
 	for _, app := range apps {
 		appModel := plugin_models.GetAppsModel{}
@@ -1,7 +1,13 @@
 package application_test
 
 import (
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/appinstances/appinstancesfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/models"
@@ -27,6 +33,8 @@ var _ = Describe("list-apps command", func() {
 		ui                  *testterm.FakeUI
 		configRepo          coreconfig.Repository
 		appSummaryRepo      *apifakes.OldFakeAppSummaryRepo
+		appInstancesRepo    *appinstancesfakes.FakeRepository
+		metadataActor       *actorsfakes.FakeMetadataActor
 		requirementsFactory *requirementsfakes.FakeFactory
 		deps                commandregistry.Dependency
 	)
@@ -34,18 +42,22 @@ var _ = Describe("list-apps command", func() {
 	updateCommandDependency := func(pluginCall bool) {
 		deps.UI = ui
 		deps.Config = configRepo
-		deps.RepoLocator = deps.RepoLocator.SetAppSummaryRepository(appSummaryRepo)
+		deps.RepoLocator = deps.RepoLocator.SetAppSummaryRepository(appSummaryRepo).SetAppInstancesRepository(appInstancesRepo)
+		deps.MetadataActor = metadataActor
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("apps").SetDependency(deps, pluginCall))
 	}
 
 	BeforeEach(func() {
 		ui = &testterm.FakeUI{}
 		appSummaryRepo = new(apifakes.OldFakeAppSummaryRepo)
+		appInstancesRepo = new(appinstancesfakes.FakeRepository)
+		metadataActor = new(actorsfakes.FakeMetadataActor)
 		configRepo = testconfig.NewRepositoryWithDefaults()
 		requirementsFactory = new(requirementsfakes.FakeFactory)
 
 		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
 		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
 
 		app1Routes := []models.RouteSummary{
 			{
@@ -151,6 +163,25 @@ var _ = Describe("list-apps command", func() {
 
 			Expect(testcmd.RunRequirements(reqs)).NotTo(HaveOccurred())
 		})
+
+		It("requires an API with metadata when --labels is given", func() {
+			flagContext.Parse("--labels", "env=prod")
+
+			_, err := cmd.Requirements(requirementsFactory, flagContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(requirementsFactory.NewMinAPIVersionRequirementCallCount()).To(Equal(1))
+			option, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+			Expect(option).To(Equal("Option '--labels'"))
+			Expect(version).To(Equal(cf.MetadataMinimumAPIVersion))
+		})
+
+		It("does not require an API with metadata without --labels", func() {
+			_, err := cmd.Requirements(requirementsFactory, flagContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(requirementsFactory.NewMinAPIVersionRequirementCallCount()).To(Equal(0))
+		})
 	})
 
 	Describe("when invoked by a plugin", func() {
@@ -240,5 +271,176 @@ var _ = Describe("list-apps command", func() {
 				))
 			})
 		})
+
+		Context("when filters are given", func() {
+			BeforeEach(func() {
+				apps := appSummaryRepo.GetSummariesInCurrentSpaceApps
+				apps[0].BuildpackURL = "https://github.com/cloudfoundry/ruby-buildpack"
+				apps[1].State = "stopped"
+				apps[1].DetectedBuildpack = "ruby_buildpack"
+			})
+
+			It("lists only the apps whose name matches --name-filter", func() {
+				runCommand("--name-filter", "-1$")
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Application-1", "started"},
+				))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings(
+					[]string{"Application-2"},
+				))
+			})
+
+			It("lists only the apps in the requested --state", func() {
+				runCommand("--state", "STOPPED")
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Application-2", "stopped"},
+				))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings(
+					[]string{"Application-1"},
+				))
+			})
+
+			It("matches --buildpack against the specified and the detected buildpack", func() {
+				runCommand("--buildpack", "ruby_buildpack")
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Application-2"},
+				))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings(
+					[]string{"Application-1"},
+				))
+
+				ui = &testterm.FakeUI{}
+				runCommand("--buildpack", "https://github.com/cloudfoundry/ruby-buildpack")
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Application-1"},
+				))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings(
+					[]string{"Application-2"},
+				))
+			})
+
+			It("tells the user when no apps match", func() {
+				runCommand("--name-filter", "nope", "--state", "started")
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"OK"},
+					[]string{"No apps found matching the given filters"},
+				))
+			})
+
+			It("fails when --name-filter is not a valid regular expression", func() {
+				runCommand("--name-filter", "(")
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Invalid value ( for --name-filter"},
+				))
+			})
+
+			It("lists only the apps whose labels match --labels", func() {
+				metadataActor.MatchingGUIDsReturns(map[string]bool{"Application-2-guid": true}, nil)
+
+				runCommand("--labels", "env=prod")
+
+				resourceType, selector := metadataActor.MatchingGUIDsArgsForCall(0)
+				Expect(resourceType).To(Equal("app"))
+				Expect(selector).To(Equal("env=prod"))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Application-2"},
+				))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings(
+					[]string{"Application-1"},
+				))
+			})
+
+			It("fails when the label selector is rejected", func() {
+				metadataActor.MatchingGUIDsReturns(nil, errors.New("invalid label selector"))
+
+				runCommand("--labels", "env==")
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"invalid label selector"},
+				))
+			})
+
+			It("fails when --state is not started or stopped", func() {
+				runCommand("--state", "crashed")
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Invalid value crashed for --state; it must be started or stopped"},
+				))
+			})
+		})
+
+		Context("when --stats is given", func() {
+			BeforeEach(func() {
+				apps := appSummaryRepo.GetSummariesInCurrentSpaceApps
+				apps[1].State = "stopped"
+				apps[1].RunningInstances = 0
+
+				appInstancesRepo.GetInstancesReturns([]models.AppInstanceFields{
+					{State: models.InstanceRunning, CPUUsage: 0.25, MemUsage: 128 * 1024 * 1024, DiskUsage: 256 * 1024 * 1024},
+					{State: models.InstanceRunning, CPUUsage: 0.15, MemUsage: 64 * 1024 * 1024, DiskUsage: 256 * 1024 * 1024},
+					{State: models.InstanceCrashed},
+				}, nil)
+			})
+
+			It("adds the usage of the running instances of the started apps", func() {
+				runCommand("--stats")
+
+				Expect(appInstancesRepo.GetInstancesCallCount()).To(Equal(1))
+				Expect(appInstancesRepo.GetInstancesArgsForCall(0)).To(Equal("Application-1-guid"))
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"name", "requested state", "instances", "memory", "disk", "cpu", "memory usage", "disk usage", "urls"},
+					[]string{"Application-1", "started", "512M", "1G", "20.0%", "192M", "512M", "app1.cfapps.io"},
+					[]string{"Application-2", "stopped", "256M", "1G", "-", "app2.cfapps.io"},
+				))
+			})
+
+			It("shows a '?' and warns when the stats of an app cannot be fetched", func() {
+				appInstancesRepo.GetInstancesReturns(nil, errors.New("stats unavailable"))
+
+				runCommand("--stats")
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Application-1", "started", "?", "?", "?", "app1.cfapps.io"},
+					[]string{"Could not fetch the usage of apps: Application-1"},
+				))
+			})
+
+			It("fetches the stats of many apps", func() {
+				apps := []models.Application{}
+				for i := 0; i < 20; i++ {
+					app := models.Application{}
+					app.Name = fmt.Sprintf("app-%d", i)
+					app.GUID = fmt.Sprintf("app-%d-guid", i)
+					app.State = "started"
+					apps = append(apps, app)
+				}
+				appSummaryRepo.GetSummariesInCurrentSpaceApps = apps
+
+				runCommand("--stats")
+
+				Expect(appInstancesRepo.GetInstancesCallCount()).To(Equal(20))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"app-0", "20.0%"},
+					[]string{"app-19", "20.0%"},
+				))
+			})
+		})
+
+		It("does not fetch instance stats without --stats", func() {
+			runCommand()
+
+			Expect(appInstancesRepo.GetInstancesCallCount()).To(BeZero())
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"cpu"}))
+		})
 	})
 })
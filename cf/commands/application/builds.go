@@ -0,0 +1,117 @@
+package application
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api/builds"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type Builds struct {
+	ui        terminal.UI
+	config    coreconfig.Reader
+	buildRepo builds.Repository
+	appReq    requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&Builds{})
+}
+
+func (cmd *Builds) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "builds",
+		Description: T("List the builds of an app, newest first"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME builds %s", T("APP_NAME")),
+		},
+		StructuredOutput: true,
+	}
+}
+
+func (cmd *Builds) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("builds"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("builds", cf.BuildsMinimumAPIVersion),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *Builds) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.buildRepo = deps.RepoLocator.GetBuildRepository()
+	return cmd
+}
+
+func (cmd *Builds) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say(T("Getting builds for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	appBuilds, err := cmd.buildRepo.ListBuilds(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	if printer, ok := cmd.ui.(terminal.DataPrinter); ok {
+		data := []map[string]interface{}{}
+		for _, build := range appBuilds {
+			data = append(data, map[string]interface{}{
+				"guid":         build.GUID,
+				"state":        build.State,
+				"error":        build.Error,
+				"package_guid": build.PackageGUID,
+				"droplet_guid": build.DropletGUID,
+				"created_at":   build.CreatedAt,
+			})
+		}
+		printer.SetData(data)
+		return nil
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(appBuilds) == 0 {
+		cmd.ui.Say(T("No builds found"))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{T("guid"), T("state"), T("package guid"), T("droplet guid"), T("created"), T("error")})
+	table.Table.SetFieldKeys("guid", "state", "package_guid", "droplet_guid", "created", "error")
+	for _, build := range appBuilds {
+		table.Add(
+			build.GUID,
+			build.State,
+			build.PackageGUID,
+			build.DropletGUID,
+			build.CreatedAt.Local().Format("2006-01-02T15:04:05.00-0700"),
+			build.Error,
+		)
+	}
+
+	return table.Print()
+}
@@ -0,0 +1,132 @@
+package application_test
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api/builds/buildsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/application"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("builds command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		buildRepo           *buildsfakes.FakeRepository
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = deps.RepoLocator.SetBuildRepository(buildRepo)
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("builds").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("builds", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		buildRepo = new(buildsfakes.FakeRepository)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+
+		buildRepo.ListBuildsReturns([]models.Build{
+			{GUID: "build-2-guid", State: models.BuildStateFailed, Error: "NoAppDetectedError", PackageGUID: "package-2-guid", CreatedAt: time.Date(2016, 11, 2, 15, 4, 5, 0, time.UTC)},
+			{GUID: "build-1-guid", State: models.BuildStateStaged, PackageGUID: "package-1-guid", DropletGUID: "droplet-guid", CreatedAt: time.Date(2016, 11, 1, 15, 4, 5, 0, time.UTC)},
+		}, nil)
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage when not given an app name", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires an argument"},
+			))
+		})
+
+		It("requires an API with builds", func() {
+			runCommand("my-app")
+			command, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+			Expect(command).To(Equal("builds"))
+			Expect(version).To(Equal(cf.BuildsMinimumAPIVersion))
+		})
+	})
+
+	It("lists the builds of the app", func() {
+		Expect(runCommand("my-app")).To(BeTrue())
+
+		Expect(buildRepo.ListBuildsArgsForCall(0)).To(Equal("my-app-guid"))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Getting builds for app my-app in org my-org / space my-space as my-user..."},
+			[]string{"OK"},
+			[]string{"guid", "state", "package guid", "droplet guid", "created", "error"},
+			[]string{"build-2-guid", "FAILED", "package-2-guid", "NoAppDetectedError"},
+			[]string{"build-1-guid", "STAGED", "package-1-guid", "droplet-guid"},
+		))
+	})
+
+	It("says so when the app has no builds", func() {
+		buildRepo.ListBuildsReturns([]models.Build{}, nil)
+
+		Expect(runCommand("my-app")).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"No builds found"}))
+	})
+
+	It("fails when the builds cannot be listed", func() {
+		buildRepo.ListBuildsReturns(nil, errors.New("builds are unavailable"))
+
+		Expect(runCommand("my-app")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"builds are unavailable"}))
+	})
+
+	It("prints the builds as JSON with --output json", func() {
+		formattedUI := terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+		deps.UI = formattedUI
+		deps.Config = config
+		deps.RepoLocator = deps.RepoLocator.SetBuildRepository(buildRepo)
+
+		cmd := &application.Builds{}
+		cmd.SetDependency(deps, false)
+		fc := flags.NewFlagContext(cmd.MetaData().Flags)
+		Expect(fc.Parse("my-app")).To(Succeed())
+		_, err := cmd.Requirements(requirementsFactory, fc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cmd.Execute(fc)).To(Succeed())
+		Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+		Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`[
+			{"guid": "build-2-guid", "state": "FAILED", "error": "NoAppDetectedError", "package_guid": "package-2-guid", "droplet_guid": "", "created_at": "2016-11-02T15:04:05Z"},
+			{"guid": "build-1-guid", "state": "STAGED", "error": "", "package_guid": "package-1-guid", "droplet_guid": "droplet-guid", "created_at": "2016-11-01T15:04:05Z"}
+		]`))
+	})
+})
@@ -0,0 +1,90 @@
+package application
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type CancelDeployment struct {
+	ui              terminal.UI
+	config          coreconfig.Reader
+	deploymentActor actors.DeploymentActor
+	appReq          requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&CancelDeployment{})
+}
+
+func (cmd *CancelDeployment) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "cancel-deployment",
+		Description: T("Cancel the deployment in progress for an app, rolling it back to the droplet it ran before"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME cancel-deployment %s", T("APP_NAME")),
+		},
+	}
+}
+
+func (cmd *CancelDeployment) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("cancel-deployment"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("cancel-deployment", cf.DeploymentsMinimumAPIVersion),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *CancelDeployment) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.deploymentActor = deps.DeploymentActor
+	return cmd
+}
+
+func (cmd *CancelDeployment) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say(T("Canceling deployment for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	_, err := cmd.deploymentActor.CancelDeployment(app.GUID)
+	if err != nil {
+		return deploymentInProgressError(err, app.Name)
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
+
+// deploymentInProgressError explains that the app has no deployment to act
+// on, passing any other error through.
+func deploymentInProgressError(err error, appName string) error {
+	if _, ok := err.(*errors.ModelNotFoundError); ok {
+		return errors.New(T("App {{.AppName}} has no deployment in progress", map[string]interface{}{"AppName": appName}))
+	}
+	return err
+}
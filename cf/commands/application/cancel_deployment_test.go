@@ -0,0 +1,90 @@
+package application_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	cferrors "code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("cancel-deployment command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		deploymentActor     *actorsfakes.FakeDeploymentActor
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.DeploymentActor = deploymentActor
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("cancel-deployment").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("cancel-deployment", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		deploymentActor = new(actorsfakes.FakeDeploymentActor)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+	})
+
+	It("fails with usage when not given an app name", func() {
+		runCommand()
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Requires an argument"},
+		))
+	})
+
+	It("cancels the deployment in progress", func() {
+		deploymentActor.CancelDeploymentReturns(models.Deployment{GUID: "deployment-guid"}, nil)
+
+		Expect(runCommand("my-app")).To(BeTrue())
+		Expect(deploymentActor.CancelDeploymentArgsForCall(0)).To(Equal("my-app-guid"))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Canceling deployment for app", "my-app", "my-org", "my-space", "my-user"},
+			[]string{"OK"},
+		))
+	})
+
+	It("fails when the app has no deployment in progress", func() {
+		deploymentActor.CancelDeploymentReturns(models.Deployment{}, cferrors.NewModelNotFoundError("Deployment", "in progress"))
+
+		Expect(runCommand("my-app")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"App my-app has no deployment in progress"}))
+	})
+
+	It("fails when the deployment cannot be canceled", func() {
+		deploymentActor.CancelDeploymentReturns(models.Deployment{}, errors.New("cancel-error"))
+
+		Expect(runCommand("my-app")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"cancel-error"}))
+	})
+})
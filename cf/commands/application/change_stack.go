@@ -0,0 +1,168 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/api/applications"
+	"code.cloudfoundry.org/cli/cf/api/stacks"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type ChangeStack struct {
+	ui              terminal.UI
+	config          coreconfig.Reader
+	appRepo         applications.Repository
+	stackRepo       stacks.StackRepository
+	deploymentActor actors.DeploymentActor
+	restarter       Restarter
+	appReq          requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&ChangeStack{})
+}
+
+func (cmd *ChangeStack) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["max-in-flight"] = &flags.IntFlag{Name: "max-in-flight", Usage: T("Number of instances the rolling deployment replaces at the same time (Default: 1)")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "change-stack",
+		Description: T("Move an app to another stack, restaging it and replacing its instances a few at a time"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME change-stack %s %s [--max-in-flight %s]", T("APP_NAME"), T("NEW_STACK"), T("NUM")),
+			"\n\n",
+			T("The app keeps running on its current stack while it stages on the new one. If staging fails, the app is moved back to its current stack."),
+		},
+		Examples: []string{
+			"CF_NAME change-stack my-app cflinuxfs4",
+			"CF_NAME change-stack my-app cflinuxfs4 --max-in-flight 2",
+		},
+		Flags:     fs,
+		TotalArgs: 2,
+	}
+}
+
+func (cmd *ChangeStack) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires APP_NAME and NEW_STACK as arguments\n\n") + commandregistry.Commands.CommandUsage("change-stack"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 2)
+	}
+
+	if fc.IsSet("max-in-flight") && fc.Int("max-in-flight") < 1 {
+		cmd.ui.Failed(T("Incorrect Usage: Invalid value for --max-in-flight {{.MaxInFlight}}; it must be at least 1", map[string]interface{}{"MaxInFlight": fc.Int("max-in-flight")}))
+		return nil, fmt.Errorf("Incorrect usage: invalid --max-in-flight %d", fc.Int("max-in-flight"))
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("change-stack", cf.DeploymentsMinimumAPIVersion),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *ChangeStack) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
+	cmd.stackRepo = deps.RepoLocator.GetStackRepository()
+	cmd.deploymentActor = deps.DeploymentActor
+
+	//get restart for dependency
+	restarter := commandregistry.Commands.FindCommand("restart")
+	restarter = restarter.SetDependency(deps, false)
+	cmd.restarter = restarter.(Restarter)
+
+	return cmd
+}
+
+func (cmd *ChangeStack) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+	stackName := c.Args()[1]
+
+	newStack, err := cmd.stackRepo.FindByName(stackName)
+	if err != nil {
+		return err
+	}
+
+	if app.Stack != nil && app.Stack.GUID == newStack.GUID {
+		cmd.ui.Say(T("App {{.AppName}} is already on stack {{.Stack}}.", map[string]interface{}{
+			"AppName": terminal.EntityNameColor(app.Name),
+			"Stack":   terminal.EntityNameColor(newStack.Name),
+		}))
+		return nil
+	}
+
+	cmd.ui.Say(T("Changing the stack of app {{.AppName}} to {{.Stack}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"AppName":   terminal.EntityNameColor(app.Name),
+			"Stack":     terminal.EntityNameColor(newStack.Name),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	_, err = cmd.appRepo.Update(app.GUID, models.AppParams{StackGUID: &newStack.GUID})
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	dropletGUID, err := stageNewDroplet(cmd.ui, cmd.deploymentActor, app)
+	if err != nil {
+		return cmd.restoreStack(app, err)
+	}
+
+	var maxInFlight *int
+	if c.IsSet("max-in-flight") {
+		value := c.Int("max-in-flight")
+		maxInFlight = &value
+	}
+
+	return cmd.restarter.ApplicationDeploy(app, dropletGUID, maxInFlight)
+}
+
+// restoreStack moves the app back to the stack it was on after staging on
+// the new stack failed. The app never stopped running on its old droplet, so
+// nothing else needs undoing.
+func (cmd *ChangeStack) restoreStack(app models.Application, stagingErr error) error {
+	if app.Stack == nil {
+		return stagingErr
+	}
+
+	cmd.ui.Say(T("Moving app {{.AppName}} back to stack {{.Stack}}...", map[string]interface{}{
+		"AppName": terminal.EntityNameColor(app.Name),
+		"Stack":   terminal.EntityNameColor(app.Stack.Name),
+	}))
+
+	_, err := cmd.appRepo.Update(app.GUID, models.AppParams{StackGUID: &app.Stack.GUID})
+	if err != nil {
+		return errors.New(T("{{.StagingError}}\nMoving app {{.AppName}} back to stack {{.Stack}} failed too: {{.Err}}", map[string]interface{}{
+			"StagingError": stagingErr.Error(),
+			"AppName":      app.Name,
+			"Stack":        app.Stack.Name,
+			"Err":          err.Error(),
+		}))
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	return stagingErr
+}
@@ -0,0 +1,218 @@
+package application_test
+
+import (
+	"errors"
+	"os"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
+	"code.cloudfoundry.org/cli/cf/api/stacks/stacksfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/application/applicationfakes"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("change-stack command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		restarter           *applicationfakes.FakeRestarter
+		appRepo             *applicationsfakes.FakeRepository
+		stackRepo           *stacksfakes.FakeStackRepository
+		deploymentActor     *actorsfakes.FakeDeploymentActor
+		config              coreconfig.Repository
+		app                 models.Application
+		originalRestart     commandregistry.Command
+		deps                commandregistry.Dependency
+		applicationReq      *requirementsfakes.FakeApplicationRequirement
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = deps.RepoLocator.SetApplicationRepository(appRepo)
+		deps.RepoLocator = deps.RepoLocator.SetStackRepository(stackRepo)
+		deps.DeploymentActor = deploymentActor
+
+		//inject fake 'restarter' into registry
+		commandregistry.Register(restarter)
+
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("change-stack").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("change-stack", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		deps = commandregistry.NewDependency(os.Stdout, new(tracefakes.FakePrinter), "")
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		restarter = new(applicationfakes.FakeRestarter)
+		appRepo = new(applicationsfakes.FakeRepository)
+		stackRepo = new(stacksfakes.FakeStackRepository)
+		deploymentActor = new(actorsfakes.FakeDeploymentActor)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app = models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		app.Stack = &models.Stack{GUID: "old-stack-guid", Name: "cflinuxfs3"}
+
+		applicationReq = new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+
+		stackRepo.FindByNameReturns(models.Stack{GUID: "new-stack-guid", Name: "cflinuxfs4"}, nil)
+		deploymentActor.StageLatestPackageReturns(models.Build{State: models.BuildStateStaged, DropletGUID: "new-droplet-guid"}, nil)
+
+		//save original command and restore later
+		originalRestart = commandregistry.Commands.FindCommand("restart")
+
+		//setup fakes to correctly interact with commandregistry
+		restarter.SetDependencyStub = func(_ commandregistry.Dependency, _ bool) commandregistry.Command {
+			return restarter
+		}
+		restarter.MetaDataReturns(commandregistry.CommandMetadata{Name: "restart"})
+	})
+
+	AfterEach(func() {
+		commandregistry.Register(originalRestart)
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage when not provided exactly two args", func() {
+			Expect(runCommand("my-app")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires APP_NAME and NEW_STACK as arguments"},
+			))
+		})
+
+		It("fails when not logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(runCommand("my-app", "cflinuxfs4")).To(BeFalse())
+		})
+
+		It("requires an API that supports deployments", func() {
+			runCommand("my-app", "cflinuxfs4")
+
+			Expect(requirementsFactory.NewMinAPIVersionRequirementCallCount()).To(Equal(1))
+			feature, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+			Expect(feature).To(Equal("change-stack"))
+			Expect(version).To(Equal(cf.DeploymentsMinimumAPIVersion))
+		})
+
+		It("fails with usage when --max-in-flight is less than 1", func() {
+			Expect(runCommand("my-app", "cflinuxfs4", "--max-in-flight", "0")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "--max-in-flight 0"},
+			))
+		})
+	})
+
+	It("moves the app to the new stack, stages it and deploys the new droplet", func() {
+		Expect(runCommand("my-app", "cflinuxfs4", "--max-in-flight", "2")).To(BeTrue())
+
+		Expect(stackRepo.FindByNameArgsForCall(0)).To(Equal("cflinuxfs4"))
+
+		Expect(appRepo.UpdateCallCount()).To(Equal(1))
+		appGUID, params := appRepo.UpdateArgsForCall(0)
+		Expect(appGUID).To(Equal("my-app-guid"))
+		Expect(*params.StackGUID).To(Equal("new-stack-guid"))
+
+		Expect(deploymentActor.StageLatestPackageArgsForCall(0)).To(Equal("my-app-guid"))
+
+		Expect(restarter.ApplicationDeployCallCount()).To(Equal(1))
+		deployedApp, dropletGUID, maxInFlight := restarter.ApplicationDeployArgsForCall(0)
+		Expect(deployedApp.GUID).To(Equal("my-app-guid"))
+		Expect(dropletGUID).To(Equal("new-droplet-guid"))
+		Expect(*maxInFlight).To(Equal(2))
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Changing the stack of app", "my-app", "cflinuxfs4", "my-org", "my-space", "my-user"},
+			[]string{"OK"},
+			[]string{"Staging app", "my-app"},
+		))
+	})
+
+	It("does nothing when the app is already on the stack", func() {
+		stackRepo.FindByNameReturns(models.Stack{GUID: "old-stack-guid", Name: "cflinuxfs3"}, nil)
+
+		Expect(runCommand("my-app", "cflinuxfs3")).To(BeTrue())
+
+		Expect(appRepo.UpdateCallCount()).To(BeZero())
+		Expect(deploymentActor.StageLatestPackageCallCount()).To(BeZero())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"App", "my-app", "is already on stack", "cflinuxfs3"},
+		))
+	})
+
+	It("fails when the stack cannot be found", func() {
+		stackRepo.FindByNameReturns(models.Stack{}, errors.New("Stack cflinuxfs9 not found"))
+
+		Expect(runCommand("my-app", "cflinuxfs9")).To(BeFalse())
+
+		Expect(appRepo.UpdateCallCount()).To(BeZero())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Stack cflinuxfs9 not found"},
+		))
+	})
+
+	Context("when staging on the new stack fails", func() {
+		BeforeEach(func() {
+			deploymentActor.StageLatestPackageReturns(models.Build{State: models.BuildStateFailed, Error: "NoCompatibleCell"}, nil)
+		})
+
+		It("moves the app back to its old stack and does not deploy", func() {
+			Expect(runCommand("my-app", "cflinuxfs4")).To(BeFalse())
+
+			Expect(appRepo.UpdateCallCount()).To(Equal(2))
+			appGUID, params := appRepo.UpdateArgsForCall(1)
+			Expect(appGUID).To(Equal("my-app-guid"))
+			Expect(*params.StackGUID).To(Equal("old-stack-guid"))
+
+			Expect(restarter.ApplicationDeployCallCount()).To(BeZero())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Moving app", "my-app", "back to stack", "cflinuxfs3"},
+				[]string{"FAILED"},
+				[]string{"App my-app failed to stage: NoCompatibleCell"},
+			))
+		})
+
+		Context("when moving the app back fails too", func() {
+			BeforeEach(func() {
+				appRepo.UpdateStub = func(_ string, params models.AppParams) (models.Application, error) {
+					if *params.StackGUID == "old-stack-guid" {
+						return models.Application{}, errors.New("update failed")
+					}
+					return app, nil
+				}
+			})
+
+			It("reports both errors", func() {
+				Expect(runCommand("my-app", "cflinuxfs4")).To(BeFalse())
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"App my-app failed to stage: NoCompatibleCell"},
+					[]string{"Moving app my-app back to stack cflinuxfs3 failed too: update failed"},
+				))
+			})
+		})
+	})
+})
@@ -0,0 +1,79 @@
+package application
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type ContinueDeployment struct {
+	ui              terminal.UI
+	config          coreconfig.Reader
+	deploymentActor actors.DeploymentActor
+	appReq          requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&ContinueDeployment{})
+}
+
+func (cmd *ContinueDeployment) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "continue-deployment",
+		Description: T("Continue the paused canary deployment of an app, replacing the rest of its instances"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME continue-deployment %s", T("APP_NAME")),
+		},
+	}
+}
+
+func (cmd *ContinueDeployment) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("continue-deployment"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("continue-deployment", cf.CanaryDeploymentsMinimumAPIVersion),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *ContinueDeployment) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.deploymentActor = deps.DeploymentActor
+	return cmd
+}
+
+func (cmd *ContinueDeployment) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say(T("Continuing deployment for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	deployment, err := cmd.deploymentActor.ContinueDeployment(app.GUID)
+	if err != nil {
+		return deploymentInProgressError(err, app.Name)
+	}
+
+	return watchDeployment(cmd.ui, cmd.deploymentActor, app, deployment, nil)
+}
@@ -0,0 +1,108 @@
+package application_test
+
+import (
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	cferrors "code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("continue-deployment command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		deploymentActor     *actorsfakes.FakeDeploymentActor
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.DeploymentActor = deploymentActor
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("continue-deployment").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("continue-deployment", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		deploymentActor = new(actorsfakes.FakeDeploymentActor)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+
+		deploymentActor.ContinueDeploymentReturns(models.Deployment{GUID: "deployment-guid", StatusValue: "ACTIVE", StatusReason: "PAUSED"}, nil)
+		deploymentActor.WaitForDeploymentStub = func(deployment models.Deployment, progress func(models.Deployment)) (models.Deployment, error) {
+			deployment.StatusReason = "DEPLOYING"
+			progress(deployment)
+			return models.Deployment{GUID: "deployment-guid", StatusValue: "FINALIZED", StatusReason: "DEPLOYED"}, nil
+		}
+	})
+
+	It("fails with usage when not given an app name", func() {
+		runCommand()
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Requires an argument"},
+		))
+	})
+
+	It("requires an API with canary deployments", func() {
+		runCommand("my-app")
+		command, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+		Expect(command).To(Equal("continue-deployment"))
+		Expect(version).To(Equal(cf.CanaryDeploymentsMinimumAPIVersion))
+	})
+
+	It("continues the paused deployment and waits for it to finish", func() {
+		Expect(runCommand("my-app")).To(BeTrue())
+		Expect(deploymentActor.ContinueDeploymentArgsForCall(0)).To(Equal("my-app-guid"))
+
+		deployment, _ := deploymentActor.WaitForDeploymentArgsForCall(0)
+		Expect(deployment.GUID).To(Equal("deployment-guid"))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Continuing deployment for app", "my-app", "my-org", "my-space", "my-user"},
+			[]string{"Waiting for app", "my-app", "to deploy"},
+			[]string{"Deployment", "ACTIVE (DEPLOYING)"},
+			[]string{"OK"},
+		))
+	})
+
+	It("fails when the deployment is canceled while it runs", func() {
+		deploymentActor.WaitForDeploymentStub = nil
+		deploymentActor.WaitForDeploymentReturns(models.Deployment{GUID: "deployment-guid", StatusValue: "FINALIZED", StatusReason: "CANCELED"}, nil)
+
+		Expect(runCommand("my-app")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"Deployment of app my-app did not finish: CANCELED"}))
+	})
+
+	It("fails when the app has no deployment in progress", func() {
+		deploymentActor.ContinueDeploymentReturns(models.Deployment{}, cferrors.NewModelNotFoundError("Deployment", "in progress"))
+
+		Expect(runCommand("my-app")).To(BeFalse())
+		Expect(deploymentActor.WaitForDeploymentCallCount()).To(BeZero())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"App my-app has no deployment in progress"}))
+	})
+})
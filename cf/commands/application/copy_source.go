@@ -3,10 +3,12 @@ package application
 import (
 	"errors"
 	"fmt"
+	"strings"
 
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/api"
 	"code.cloudfoundry.org/cli/cf/api/applications"
 	"code.cloudfoundry.org/cli/cf/api/authentication"
-	"code.cloudfoundry.org/cli/cf/api/copyapplicationsource"
 	"code.cloudfoundry.org/cli/cf/api/organizations"
 	"code.cloudfoundry.org/cli/cf/api/spaces"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
@@ -19,14 +21,15 @@ import (
 )
 
 type CopySource struct {
-	ui                terminal.UI
-	config            coreconfig.Reader
-	authRepo          authentication.Repository
-	appRepo           applications.Repository
-	orgRepo           organizations.OrganizationRepository
-	spaceRepo         spaces.SpaceRepository
-	copyAppSourceRepo copyapplicationsource.Repository
-	appRestart        Restarter
+	ui              terminal.UI
+	config          coreconfig.Reader
+	authRepo        authentication.Repository
+	appRepo         applications.Repository
+	orgRepo         organizations.OrganizationRepository
+	spaceRepo       spaces.SpaceRepository
+	copySourceActor actors.CopySourceActor
+	appRestart      Restarter
+	deps            commandregistry.Dependency
 }
 
 func init() {
@@ -38,12 +41,20 @@ func (cmd *CopySource) MetaData() commandregistry.CommandMetadata {
 	fs["no-restart"] = &flags.BoolFlag{Name: "no-restart", Usage: T("Override restart of the application in target environment after copy-source completes")}
 	fs["o"] = &flags.StringFlag{ShortName: "o", Usage: T("Org that contains the target application")}
 	fs["s"] = &flags.StringFlag{ShortName: "s", Usage: T("Space that contains the target application")}
+	fs["target-context"] = &flags.StringFlag{Name: "target-context", Usage: T("Context saved with target-save that the target application is in, such as another foundation")}
+	fs["copy-env"] = &flags.BoolFlag{Name: "copy-env", Usage: T("Copy the environment variables of the source application over those of the target application")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "copy-source",
 		Description: T("Copies the source code of an application to another existing application (and restarts that application)"),
 		Usage: []string{
-			T("   CF_NAME copy-source SOURCE-APP TARGET-APP [-s TARGET-SPACE [-o TARGET-ORG]] [--no-restart]\n"),
+			T("   CF_NAME copy-source SOURCE-APP TARGET-APP [-s TARGET-SPACE [-o TARGET-ORG]] [--target-context NAME] [--copy-env] [--no-restart]\n"),
+			"\n",
+			T("   The services bound to the source application are listed after the copy, since they are never copied.\n"),
+		},
+		Examples: []string{
+			"CF_NAME copy-source my-app my-app-copy -o other-org -s staging --copy-env",
+			"CF_NAME copy-source my-app my-app --target-context prod --no-restart",
 		},
 		Flags: fs,
 	}
@@ -73,7 +84,8 @@ func (cmd *CopySource) SetDependency(deps commandregistry.Dependency, pluginCall
 	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
 	cmd.orgRepo = deps.RepoLocator.GetOrganizationRepository()
 	cmd.spaceRepo = deps.RepoLocator.GetSpaceRepository()
-	cmd.copyAppSourceRepo = deps.RepoLocator.GetCopyApplicationSourceRepository()
+	cmd.copySourceActor = deps.CopySourceActor
+	cmd.deps = deps
 
 	//get command from registry for dependency
 	commandDep := commandregistry.Commands.FindCommand("restart")
@@ -104,9 +116,51 @@ func (cmd *CopySource) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	// the target app is looked up, and restarted, with the dependencies of
+	// the target context when there is one
+	targetDeps := cmd.deps
+	targetConfig := cmd.config
+	appRepo, orgRepo, spaceRepo := cmd.appRepo, cmd.orgRepo, cmd.spaceRepo
+	target := actors.CopyTarget{
+		AppRepo:        cmd.appRepo,
+		AppSummaryRepo: cmd.deps.RepoLocator.GetAppSummaryRepository(),
+		AppBitsRepo:    cmd.deps.RepoLocator.GetApplicationBitsRepository(),
+	}
+
+	if c.IsSet("target-context") {
+		var (
+			contextConfig  coreconfig.Repository
+			contextLocator api.RepositoryLocator
+		)
+		contextConfig, contextLocator, err = cmd.deps.ContextRepoLocator(c.String("target-context"))
+		if err != nil {
+			return err
+		}
+
+		_, err = contextLocator.GetAuthenticationRepository().RefreshAuthToken()
+		if err != nil {
+			return err
+		}
+
+		targetDeps.Config = contextConfig
+		targetDeps.RepoLocator = contextLocator
+		targetDeps.MetadataActor = actors.NewMetadataActor(contextLocator.GetMetadataRepository(), contextConfig)
+		targetConfig = contextConfig
+
+		appRepo = contextLocator.GetApplicationRepository()
+		orgRepo = contextLocator.GetOrganizationRepository()
+		spaceRepo = contextLocator.GetSpaceRepository()
+		target = actors.CopyTarget{
+			AppRepo:         appRepo,
+			AppSummaryRepo:  contextLocator.GetAppSummaryRepository(),
+			AppBitsRepo:     contextLocator.GetApplicationBitsRepository(),
+			OtherFoundation: contextConfig.APIEndpoint() != cmd.config.APIEndpoint(),
+		}
+	}
+
 	var targetOrgName, targetSpaceName, spaceGUID, copyStr string
 	if targetOrg != "" && targetSpace != "" {
-		spaceGUID, err = cmd.findSpaceGUID(targetOrg, targetSpace)
+		spaceGUID, err = findSpaceGUID(orgRepo, targetOrg, targetSpace)
 		if err != nil {
 			return err
 		}
@@ -115,22 +169,22 @@ func (cmd *CopySource) Execute(c flags.FlagContext) error {
 		targetSpaceName = targetSpace
 	} else if targetSpace != "" {
 		var space models.Space
-		space, err = cmd.spaceRepo.FindByName(targetSpace)
+		space, err = spaceRepo.FindByName(targetSpace)
 		if err != nil {
 			return err
 		}
 		spaceGUID = space.GUID
-		targetOrgName = cmd.config.OrganizationFields().Name
+		targetOrgName = targetConfig.OrganizationFields().Name
 		targetSpaceName = targetSpace
 	} else {
-		spaceGUID = cmd.config.SpaceFields().GUID
-		targetOrgName = cmd.config.OrganizationFields().Name
-		targetSpaceName = cmd.config.SpaceFields().Name
+		spaceGUID = targetConfig.SpaceFields().GUID
+		targetOrgName = targetConfig.OrganizationFields().Name
+		targetSpaceName = targetConfig.SpaceFields().Name
 	}
 
-	copyStr = buildCopyString(sourceAppName, targetAppName, targetOrgName, targetSpaceName, cmd.config.Username())
+	copyStr = buildCopyString(sourceAppName, targetAppName, targetOrgName, targetSpaceName, targetConfig.Username())
 
-	targetApp, err := cmd.appRepo.ReadFromSpace(targetAppName, spaceGUID)
+	targetApp, err := appRepo.ReadFromSpace(targetAppName, spaceGUID)
 	if err != nil {
 		return err
 	}
@@ -139,21 +193,61 @@ func (cmd *CopySource) Execute(c flags.FlagContext) error {
 	cmd.ui.Say(T("Note: this may take some time"))
 	cmd.ui.Say("")
 
-	err = cmd.copyAppSourceRepo.CopyApplication(sourceApp.GUID, targetApp.GUID)
+	report, err := cmd.copySourceActor.CopySource(sourceApp.GUID, targetApp.GUID, target, c.Bool("copy-env"))
+	if err != nil {
+		return err
+	}
+
+	err = cmd.sayReport(report, sourceAppName, targetAppName)
 	if err != nil {
 		return err
 	}
 
 	if !c.Bool("no-restart") {
-		cmd.appRestart.ApplicationRestart(targetApp, targetOrgName, targetSpaceName)
+		appRestart := cmd.appRestart
+		if c.IsSet("target-context") {
+			appRestart = commandregistry.Commands.FindCommand("restart").SetDependency(targetDeps, false).(Restarter)
+		}
+		appRestart.ApplicationRestart(targetApp, targetOrgName, targetSpaceName)
 	}
 
 	cmd.ui.Ok()
 	return nil
 }
 
-func (cmd *CopySource) findSpaceGUID(targetOrg, targetSpace string) (string, error) {
-	org, err := cmd.orgRepo.FindByName(targetOrg)
+func (cmd *CopySource) sayReport(report actors.CopyReport, sourceAppName, targetAppName string) error {
+	if len(report.EnvVars) > 0 {
+		cmd.ui.Say(T("Copied environment variables: {{.Names}}", map[string]interface{}{
+			"Names": strings.Join(report.EnvVars, ", "),
+		}))
+		cmd.ui.Say("")
+	}
+
+	if len(report.Services) == 0 {
+		return nil
+	}
+
+	cmd.ui.Say(T("Services bound to {{.SourceApp}}, which are not copied:", map[string]interface{}{
+		"SourceApp": terminal.EntityNameColor(sourceAppName),
+	}))
+	table := cmd.ui.Table([]string{T("service"), T("bound to {{.TargetApp}}", map[string]interface{}{"TargetApp": targetAppName})})
+	for _, service := range report.Services {
+		bound := T("no")
+		if service.BoundToTarget {
+			bound = T("yes")
+		}
+		table.Add(service.Name, bound)
+	}
+	err := table.Print()
+	if err != nil {
+		return err
+	}
+	cmd.ui.Say("")
+	return nil
+}
+
+func findSpaceGUID(orgRepo organizations.OrganizationRepository, targetOrg, targetSpace string) (string, error) {
+	org, err := orgRepo.FindByName(targetOrg)
 	if err != nil {
 		return "", err
 	}
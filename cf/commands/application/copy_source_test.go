@@ -1,6 +1,12 @@
 package application_test
 
 import (
+	"io"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/applicationbits/applicationbitsfakes"
 	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
 	"code.cloudfoundry.org/cli/cf/api/authentication/authenticationfakes"
 	"code.cloudfoundry.org/cli/cf/api/copyapplicationsource/copyapplicationsourcefakes"
@@ -32,6 +38,8 @@ var _ = Describe("CopySource", func() {
 		authRepo            *authenticationfakes.FakeRepository
 		appRepo             *applicationsfakes.FakeRepository
 		copyAppSourceRepo   *copyapplicationsourcefakes.FakeRepository
+		appSummaryRepo      *apifakes.FakeAppSummaryRepository
+		appBitsRepo         *applicationbitsfakes.FakeRepository
 		spaceRepo           *spacesfakes.FakeSpaceRepository
 		orgRepo             *organizationsfakes.FakeOrganizationRepository
 		appRestarter        *applicationfakes.FakeRestarter
@@ -46,6 +54,9 @@ var _ = Describe("CopySource", func() {
 		deps.RepoLocator = deps.RepoLocator.SetCopyApplicationSourceRepository(copyAppSourceRepo)
 		deps.RepoLocator = deps.RepoLocator.SetSpaceRepository(spaceRepo)
 		deps.RepoLocator = deps.RepoLocator.SetOrganizationRepository(orgRepo)
+		deps.RepoLocator = deps.RepoLocator.SetAppSummaryRepository(appSummaryRepo)
+		deps.RepoLocator = deps.RepoLocator.SetApplicationBitsRepository(appBitsRepo)
+		deps.CopySourceActor = actors.NewCopySourceActor(copyAppSourceRepo, appSummaryRepo)
 		deps.Config = config
 
 		//inject fake 'command dependency' into registry
@@ -60,6 +71,8 @@ var _ = Describe("CopySource", func() {
 		authRepo = new(authenticationfakes.FakeRepository)
 		appRepo = new(applicationsfakes.FakeRepository)
 		copyAppSourceRepo = new(copyapplicationsourcefakes.FakeRepository)
+		appSummaryRepo = new(apifakes.FakeAppSummaryRepository)
+		appBitsRepo = new(applicationbitsfakes.FakeRepository)
 		spaceRepo = new(spacesfakes.FakeSpaceRepository)
 		orgRepo = new(organizationsfakes.FakeOrganizationRepository)
 		config = testconfig.NewRepositoryWithDefaults()
@@ -319,6 +332,147 @@ var _ = Describe("CopySource", func() {
 					})
 				})
 
+				Describe("when the --copy-env flag is passed", func() {
+					BeforeEach(func() {
+						appSummaryRepo.GetSummaryStub = func(appGUID string) (models.Application, error) {
+							app := models.Application{}
+							app.GUID = appGUID
+							if appGUID == "source-app-guid" {
+								app.EnvironmentVars = map[string]interface{}{"LOG_LEVEL": "debug"}
+							}
+							return app, nil
+						}
+					})
+
+					It("copies the environment variables of the source application to the target application", func() {
+						runCommand("--copy-env", "source-app", "target-app")
+
+						appGUID, params := appRepo.UpdateArgsForCall(0)
+						Expect(appGUID).To(Equal("target-app-guid"))
+						Expect(*params.EnvironmentVars).To(Equal(map[string]interface{}{"LOG_LEVEL": "debug"}))
+						Expect(ui.Outputs()).To(ContainSubstrings(
+							[]string{"Copied environment variables: LOG_LEVEL"},
+							[]string{"OK"},
+						))
+					})
+				})
+
+				It("lists the services bound to the source application", func() {
+					appSummaryRepo.GetSummaryStub = func(appGUID string) (models.Application, error) {
+						app := models.Application{}
+						app.GUID = appGUID
+						app.Services = []models.ServicePlanSummary{{Name: "my-db"}}
+						if appGUID == "source-app-guid" {
+							app.Services = append(app.Services, models.ServicePlanSummary{Name: "my-cache"})
+						}
+						return app, nil
+					}
+
+					runCommand("source-app", "target-app")
+
+					Expect(appRepo.UpdateCallCount()).To(BeZero())
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Services bound to source-app, which are not copied:"},
+						[]string{"service", "bound to target-app"},
+						[]string{"my-db", "yes"},
+						[]string{"my-cache", "no"},
+						[]string{"OK"},
+					))
+				})
+
+				Describe("when the --target-context flag is passed", func() {
+					var (
+						targetConfig      coreconfig.Repository
+						targetAuthRepo    *authenticationfakes.FakeRepository
+						targetAppRepo     *applicationsfakes.FakeRepository
+						targetSummaryRepo *apifakes.FakeAppSummaryRepository
+						targetBitsRepo    *applicationbitsfakes.FakeRepository
+						contextName       string
+					)
+
+					BeforeEach(func() {
+						targetConfig = testconfig.NewRepositoryWithDefaults()
+						targetConfig.SetAPIEndpoint("https://api.other-foundation.example.com")
+						targetConfig.SetSpaceFields(models.SpaceFields{Name: "prod-space", GUID: "prod-space-guid"})
+						targetConfig.SetOrganizationFields(models.OrganizationFields{Name: "prod-org"})
+
+						targetAuthRepo = new(authenticationfakes.FakeRepository)
+						targetAppRepo = new(applicationsfakes.FakeRepository)
+						targetAppRepo.ReadFromSpaceReturns(targetApp, nil)
+						targetSummaryRepo = new(apifakes.FakeAppSummaryRepository)
+						targetBitsRepo = new(applicationbitsfakes.FakeRepository)
+
+						copyAppSourceRepo.DownloadApplicationStub = func(appGUID string, dest io.Writer) error {
+							_, err := dest.Write([]byte("app-bits-zip"))
+							return err
+						}
+
+						deps.ContextRepoLocator = func(name string) (coreconfig.Repository, api.RepositoryLocator, error) {
+							contextName = name
+							locator := api.RepositoryLocator{}.
+								SetAuthenticationRepository(targetAuthRepo).
+								SetApplicationRepository(targetAppRepo).
+								SetAppSummaryRepository(targetSummaryRepo).
+								SetApplicationBitsRepository(targetBitsRepo)
+							return targetConfig, locator, nil
+						}
+					})
+
+					It("copies the bits to the target application on the foundation of the context", func() {
+						runCommand("--target-context", "prod", "source-app", "target-app")
+
+						Expect(contextName).To(Equal("prod"))
+						Expect(targetAuthRepo.RefreshAuthTokenCallCount()).To(Equal(1))
+
+						targetAppName, spaceGUID := targetAppRepo.ReadFromSpaceArgsForCall(0)
+						Expect(targetAppName).To(Equal("target-app"))
+						Expect(spaceGUID).To(Equal("prod-space-guid"))
+						Expect(appRepo.ReadFromSpaceCallCount()).To(BeZero())
+
+						Expect(copyAppSourceRepo.CopyApplicationCallCount()).To(BeZero())
+						appGUID, _ := copyAppSourceRepo.DownloadApplicationArgsForCall(0)
+						Expect(appGUID).To(Equal("source-app-guid"))
+						var uploadedGUID string
+						uploadedGUID, _, _ = targetBitsRepo.UploadBitsArgsForCall(0)
+						Expect(uploadedGUID).To(Equal("target-app-guid"))
+
+						appArg, orgName, spaceName := appRestarter.ApplicationRestartArgsForCall(0)
+						Expect(appArg).To(Equal(targetApp))
+						Expect(orgName).To(Equal("prod-org"))
+						Expect(spaceName).To(Equal("prod-space"))
+
+						Expect(ui.Outputs()).To(ContainSubstrings(
+							[]string{"Copying source from app source-app to target app target-app in org prod-org / space prod-space as my-user..."},
+							[]string{"OK"},
+						))
+					})
+
+					It("copies the bits through the Cloud Controller when the context is on the same foundation", func() {
+						targetConfig.SetAPIEndpoint(config.APIEndpoint())
+
+						runCommand("--target-context", "prod", "source-app", "target-app")
+
+						sourceAppGUID, targetAppGUID := copyAppSourceRepo.CopyApplicationArgsForCall(0)
+						Expect(sourceAppGUID).To(Equal("source-app-guid"))
+						Expect(targetAppGUID).To(Equal("target-app-guid"))
+						Expect(targetBitsRepo.UploadBitsCallCount()).To(BeZero())
+					})
+
+					It("fails when the context cannot be loaded", func() {
+						deps.ContextRepoLocator = func(name string) (coreconfig.Repository, api.RepositoryLocator, error) {
+							return nil, api.RepositoryLocator{}, errors.New("context prod not found; save it first with target-save")
+						}
+
+						runCommand("--target-context", "prod", "source-app", "target-app")
+
+						Expect(ui.Outputs()).To(ContainSubstrings(
+							[]string{"FAILED"},
+							[]string{"context prod not found"},
+						))
+						Expect(copyAppSourceRepo.DownloadApplicationCallCount()).To(BeZero())
+					})
+				})
+
 				Describe("when the --no-restart flag is passed", func() {
 					It("does not restart the target application", func() {
 						runCommand("--no-restart", "source-app", "target-app")
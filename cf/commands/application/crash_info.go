@@ -0,0 +1,148 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/api/appevents"
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+// crashInfoEventLimit is how many of the most recent crashes of the app are
+// reported.
+const crashInfoEventLimit = 5
+
+type CrashInfo struct {
+	ui         terminal.UI
+	config     coreconfig.Reader
+	appReq     requirements.ApplicationRequirement
+	eventsRepo appevents.Repository
+	logsRepo   logs.Repository
+}
+
+func init() {
+	commandregistry.Register(&CrashInfo{})
+}
+
+func (cmd *CrashInfo) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "crash-info",
+		Description: T("Show the recent crashes of an app with the exit description and last logs of each crashed instance"),
+		Usage: []string{
+			"CF_NAME crash-info ",
+			T("APP_NAME"),
+		},
+	}
+}
+
+func (cmd *CrashInfo) Requirements(requirementsFactory requirements.Factory, c flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(c.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("crash-info"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(c.Args()), 1)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(c.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *CrashInfo) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.eventsRepo = deps.RepoLocator.GetAppEventsRepository()
+	cmd.logsRepo = deps.RepoLocator.GetLogsRepository()
+	return cmd
+}
+
+func (cmd *CrashInfo) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say(T("Getting crash info for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
+		map[string]interface{}{
+			"AppName":   terminal.EntityNameColor(app.Name),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+	events, err := cmd.eventsRepo.ListEvents(appevents.Query{
+		ActeeGUID: app.GUID,
+		Types:     []string{"app.crash", "audit.app.process.crash"},
+		Limit:     crashInfoEventLimit,
+	})
+	if err != nil {
+		return errors.New(T("Failed fetching events.\n{{.APIErr}}",
+			map[string]interface{}{"APIErr": err.Error()}))
+	}
+
+	if len(events) == 0 {
+		cmd.ui.Say(T("No crashes for app {{.AppName}}",
+			map[string]interface{}{"AppName": terminal.EntityNameColor(app.Name)}))
+		return nil
+	}
+
+	messages, logsErr := cmd.logsRepo.RecentLogsFor(app.GUID)
+	if logsErr != nil {
+		cmd.ui.Warn(T("Could not fetch the recent logs of the app; the report leaves them out.\n{{.Err}}",
+			map[string]interface{}{"Err": logsErr.Error()}))
+	}
+
+	cmd.ui.Say("%s %s", terminal.HeaderColor(T("app:")), app.Name)
+	cmd.ui.Say("%s %s", terminal.HeaderColor(T("guid:")), app.GUID)
+	cmd.ui.Say("%s %s / %s", terminal.HeaderColor(T("org / space:")), cmd.config.OrganizationFields().Name, cmd.config.SpaceFields().Name)
+	cmd.ui.Say("%s %s", terminal.HeaderColor(T("api endpoint:")), cmd.config.APIEndpoint())
+	cmd.ui.Say("%s %s", terminal.HeaderColor(T("state:")), T("{{.State}}, {{.Running}} of {{.Total}} instances running",
+		map[string]interface{}{"State": app.State, "Running": app.RunningInstances, "Total": app.InstanceCount}))
+	cmd.ui.Say("%s %s", terminal.HeaderColor(T("report time:")), time.Now().Local().Format("2006-01-02T15:04:05.00-0700"))
+
+	for _, event := range events {
+		if event.Crash == nil {
+			continue
+		}
+		crash := event.Crash
+
+		cmd.ui.Say("")
+		cmd.ui.Say(terminal.HeaderColor(T("crash of instance {{.Index}} at {{.Time}}",
+			map[string]interface{}{
+				"Index": crash.InstanceIndex,
+				"Time":  event.Timestamp.Local().Format("2006-01-02T15:04:05.00-0700"),
+			})))
+		cmd.ui.Say("%s %d", terminal.HeaderColor(T("exit status:")), crash.ExitStatus)
+		cmd.ui.Say("%s %s", terminal.HeaderColor(T("exit description:")), crash.ExitDescription)
+		if crash.Reason != "" {
+			cmd.ui.Say("%s %s", terminal.HeaderColor(T("reason:")), crash.Reason)
+		}
+
+		if logsErr != nil {
+			continue
+		}
+
+		// Event timestamps only have a precision of seconds, so the logs of
+		// the second the instance crashed in are kept too.
+		lines := actors.LastInstanceLogs(messages, []int{crash.InstanceIndex}, event.Timestamp.Add(time.Second))
+		if len(lines) == 0 {
+			cmd.ui.Say(T("No recent logs of instance {{.Index}}", map[string]interface{}{"Index": crash.InstanceIndex}))
+			continue
+		}
+
+		cmd.ui.Say(terminal.HeaderColor(T("last logs of instance {{.Index}}:", map[string]interface{}{"Index": crash.InstanceIndex})))
+		for _, line := range lines {
+			cmd.ui.Say("   %s", line)
+		}
+	}
+
+	return nil
+}
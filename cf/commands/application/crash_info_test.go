@@ -0,0 +1,197 @@
+package application_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/application"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/models"
+
+	"code.cloudfoundry.org/cli/cf/api/appevents/appeventsfakes"
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	"code.cloudfoundry.org/cli/cf/api/logs/logsfakes"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig/coreconfigfakes"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testlogs "code.cloudfoundry.org/cli/testhelpers/logs"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	"github.com/cloudfoundry/loggregatorlib/logmessage"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("crash-info command", func() {
+	var (
+		reqFactory  *requirementsfakes.FakeFactory
+		eventsRepo  *appeventsfakes.FakeAppEventsRepository
+		logsRepo    *logsfakes.FakeRepository
+		ui          *testterm.FakeUI
+		config      *coreconfigfakes.FakeRepository
+		deps        commandregistry.Dependency
+		flagContext flags.FlagContext
+
+		applicationRequirement *requirementsfakes.FakeApplicationRequirement
+
+		cmd           *application.CrashInfo
+		executeCmdErr error
+
+		crashedAt time.Time
+	)
+
+	BeforeEach(func() {
+		cmd = &application.CrashInfo{}
+
+		ui = new(testterm.FakeUI)
+		eventsRepo = new(appeventsfakes.FakeAppEventsRepository)
+		logsRepo = new(logsfakes.FakeRepository)
+		config = new(coreconfigfakes.FakeRepository)
+
+		config.OrganizationFieldsReturns(models.OrganizationFields{Name: "my-org"})
+		config.SpaceFieldsReturns(models.SpaceFields{Name: "my-space"})
+		config.UsernameReturns("my-user")
+		config.APIEndpointReturns("https://api.example.com")
+
+		deps = commandregistry.Dependency{
+			UI:          ui,
+			RepoLocator: api.RepositoryLocator{}.SetAppEventsRepository(eventsRepo).SetLogsRepository(logsRepo),
+			Config:      config,
+		}
+
+		flagContext = flags.NewFlagContext(cmd.MetaData().Flags)
+
+		reqFactory = new(requirementsfakes.FakeFactory)
+		reqFactory.NewLoginRequirementReturns(&passingRequirement{Name: "login-requirement"})
+		reqFactory.NewTargetedSpaceRequirementReturns(&passingRequirement{Name: "targeted-space-requirement"})
+		applicationRequirement = new(requirementsfakes.FakeApplicationRequirement)
+		applicationRequirement.GetApplicationReturns(models.Application{
+			ApplicationFields: models.ApplicationFields{
+				Name:             "my-app",
+				GUID:             "my-app-guid",
+				State:            "started",
+				InstanceCount:    2,
+				RunningInstances: 1,
+			},
+		})
+		reqFactory.NewApplicationRequirementReturns(applicationRequirement)
+
+		crashedAt = time.Date(2016, 11, 1, 10, 0, 0, 0, time.UTC)
+
+		eventsRepo.ListEventsReturns([]models.EventFields{
+			{
+				Name:      "app.crash",
+				Timestamp: crashedAt,
+				Crash: &models.CrashFields{
+					InstanceIndex:   1,
+					ExitStatus:      137,
+					ExitDescription: "out of memory",
+					Reason:          "CRASHED",
+				},
+			},
+		}, nil)
+
+		logsRepo.RecentLogsForReturns([]logs.Loggable{
+			testlogs.NewLogMessage("Staging complete", "my-app-guid", "STG", "0", logmessage.LogMessage_OUT, crashedAt.Add(-time.Hour)),
+			testlogs.NewLogMessage("instance 0 is fine", "my-app-guid", "APP", "0", logmessage.LogMessage_OUT, crashedAt.Add(-time.Minute)),
+			testlogs.NewLogMessage("allocating cache", "my-app-guid", "APP", "1", logmessage.LogMessage_OUT, crashedAt.Add(-time.Minute)),
+			testlogs.NewLogMessage("fatal: cannot allocate memory", "my-app-guid", "APP", "1", logmessage.LogMessage_ERR, crashedAt.Add(500*time.Millisecond)),
+			testlogs.NewLogMessage("replacement instance started", "my-app-guid", "APP", "1", logmessage.LogMessage_OUT, crashedAt.Add(time.Minute)),
+		}, nil)
+	})
+
+	Describe("Requirements", func() {
+		It("fails when not provided exactly 1 argument", func() {
+			cmd.SetDependency(deps, false)
+			Expect(flagContext.Parse("too", "many")).To(Succeed())
+
+			_, err := cmd.Requirements(reqFactory, flagContext)
+			Expect(err).To(HaveOccurred())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires an argument"},
+			))
+		})
+	})
+
+	Describe("Execute", func() {
+		BeforeEach(func() {
+			Expect(flagContext.Parse("my-app")).To(Succeed())
+			cmd.SetDependency(deps, false)
+			_, err := cmd.Requirements(reqFactory, flagContext)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			executeCmdErr = cmd.Execute(flagContext)
+		})
+
+		It("reports each crash with the logs the instance wrote before it crashed", func() {
+			Expect(executeCmdErr).NotTo(HaveOccurred())
+
+			query := eventsRepo.ListEventsArgsForCall(0)
+			Expect(query.ActeeGUID).To(Equal("my-app-guid"))
+			Expect(query.Types).To(ConsistOf("app.crash", "audit.app.process.crash"))
+			Expect(logsRepo.RecentLogsForArgsForCall(0)).To(Equal("my-app-guid"))
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Getting crash info for app", "my-app", "my-org", "my-space", "my-user"},
+				[]string{"app:", "my-app"},
+				[]string{"guid:", "my-app-guid"},
+				[]string{"org / space:", "my-org / my-space"},
+				[]string{"api endpoint:", "https://api.example.com"},
+				[]string{"state:", "started, 1 of 2 instances running"},
+				[]string{"crash of instance 1 at", crashedAt.Local().Format(TIMESTAMP_FORMAT)},
+				[]string{"exit status:", "137"},
+				[]string{"exit description:", "out of memory"},
+				[]string{"reason:", "CRASHED"},
+				[]string{"last logs of instance 1:"},
+				[]string{"allocating cache"},
+				[]string{"fatal: cannot allocate memory"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"instance 0 is fine"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Staging complete"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"replacement instance started"}))
+		})
+
+		Context("when the app has not crashed", func() {
+			BeforeEach(func() {
+				eventsRepo.ListEventsReturns([]models.EventFields{}, nil)
+			})
+
+			It("tells the user", func() {
+				Expect(executeCmdErr).NotTo(HaveOccurred())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"No crashes for app", "my-app"}))
+				Expect(logsRepo.RecentLogsForCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the logs cannot be fetched", func() {
+			BeforeEach(func() {
+				logsRepo.RecentLogsForReturns(nil, errors.New("logs unavailable"))
+			})
+
+			It("warns and reports the crashes without them", func() {
+				Expect(executeCmdErr).NotTo(HaveOccurred())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Could not fetch the recent logs of the app"},
+					[]string{"logs unavailable"},
+					[]string{"exit description:", "out of memory"},
+				))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"last logs of instance"}))
+			})
+		})
+
+		Context("when the events cannot be fetched", func() {
+			BeforeEach(func() {
+				eventsRepo.ListEventsReturns(nil, errors.New("events unavailable"))
+			})
+
+			It("returns an error", func() {
+				Expect(executeCmdErr).To(HaveOccurred())
+				Expect(executeCmdErr.Error()).To(ContainSubstring("events unavailable"))
+			})
+		})
+	})
+})
@@ -0,0 +1,86 @@
+package application
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api/builds"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type CreateBuild struct {
+	ui        terminal.UI
+	config    coreconfig.Reader
+	buildRepo builds.Repository
+}
+
+func init() {
+	commandregistry.Register(&CreateBuild{})
+}
+
+func (cmd *CreateBuild) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "create-build",
+		Description: T("Start staging a package into a new droplet, without waiting for staging to finish"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME create-build %s", T("PACKAGE_GUID")),
+			"\n\n",
+			T("Use logs-build to follow staging and set-droplet to run an app on the droplet staged."),
+		},
+		Examples: []string{
+			"CF_NAME create-build 3b4c5d6e-7f80-4a21-9b2c-5d6e7f8a9b10",
+		},
+		TotalArgs: 1,
+	}
+}
+
+func (cmd *CreateBuild) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires PACKAGE_GUID as an argument\n\n") + commandregistry.Commands.CommandUsage("create-build"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("create-build", cf.BuildsMinimumAPIVersion),
+	}
+
+	return reqs, nil
+}
+
+func (cmd *CreateBuild) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.buildRepo = deps.RepoLocator.GetBuildRepository()
+	return cmd
+}
+
+func (cmd *CreateBuild) Execute(c flags.FlagContext) error {
+	packageGUID := c.Args()[0]
+
+	cmd.ui.Say(T("Creating build for package {{.PackageGUID}} as {{.Username}}...",
+		map[string]interface{}{
+			"PackageGUID": terminal.EntityNameColor(packageGUID),
+			"Username":    terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	build, err := cmd.buildRepo.CreateBuild(packageGUID)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Build guid: {{.BuildGUID}}", map[string]interface{}{"BuildGUID": terminal.EntityNameColor(build.GUID)}))
+	cmd.ui.Say(T("State: {{.State}}", map[string]interface{}{"State": build.State}))
+	cmd.ui.Say(T("TIP: Use '{{.Command}}' to follow staging", map[string]interface{}{
+		"Command": terminal.CommandColor(cf.Name + " logs-build " + build.GUID),
+	}))
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package application_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api/builds/buildsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("create-build command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		buildRepo           *buildsfakes.FakeRepository
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = deps.RepoLocator.SetBuildRepository(buildRepo)
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("create-build").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("create-build", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		buildRepo = new(buildsfakes.FakeRepository)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+
+		buildRepo.CreateBuildReturns(models.Build{GUID: "build-guid", State: models.BuildStateStaging}, nil)
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage when not given a package guid", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires PACKAGE_GUID as an argument"},
+			))
+		})
+
+		It("fails when not logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(runCommand("package-guid")).To(BeFalse())
+		})
+
+		It("requires an API with builds", func() {
+			runCommand("package-guid")
+			command, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+			Expect(command).To(Equal("create-build"))
+			Expect(version).To(Equal(cf.BuildsMinimumAPIVersion))
+		})
+	})
+
+	It("creates the build without waiting for staging", func() {
+		Expect(runCommand("package-guid")).To(BeTrue())
+
+		Expect(buildRepo.CreateBuildArgsForCall(0)).To(Equal("package-guid"))
+		Expect(buildRepo.GetBuildCallCount()).To(BeZero())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Creating build for package package-guid as my-user..."},
+			[]string{"OK"},
+			[]string{"Build guid: build-guid"},
+			[]string{"State: STAGING"},
+			[]string{"TIP", "cf logs-build build-guid"},
+		))
+	})
+
+	It("fails when the build cannot be created", func() {
+		buildRepo.CreateBuildReturns(models.Build{}, errors.New("package is not ready"))
+
+		Expect(runCommand("package-guid")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"package is not ready"}))
+	})
+})
@@ -0,0 +1,149 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api/packages"
+	"code.cloudfoundry.org/cli/cf/appfiles"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type CreatePackage struct {
+	ui          terminal.UI
+	config      coreconfig.Reader
+	packageRepo packages.Repository
+	zipper      appfiles.Zipper
+	appReq      requirements.ApplicationRequirement
+
+	UploadPollInterval time.Duration
+}
+
+func init() {
+	commandregistry.Register(&CreatePackage{})
+}
+
+func (cmd *CreatePackage) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["path"] = &flags.StringFlag{ShortName: "p", Name: "path", Usage: T("Path to app directory or to a zip file of the contents of the app directory (Default: the current directory)")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "create-package",
+		Description: T("Upload app files into a new package of an app, without staging or restarting it"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME create-package %s [-p %s]", T("APP_NAME"), T("PATH")),
+			"\n\n",
+			T("Use stage-package to stage the new package into a droplet."),
+		},
+		Examples: []string{
+			"CF_NAME create-package my-app",
+			"CF_NAME create-package my-app -p build/my-app.zip",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *CreatePackage) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("create-package"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("create-package", cf.PackagesMinimumAPIVersion),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *CreatePackage) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.packageRepo = deps.RepoLocator.GetPackageRepository()
+	cmd.zipper = deps.AppZipper
+	cmd.UploadPollInterval = time.Second
+	return cmd
+}
+
+func (cmd *CreatePackage) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	path := c.String("path")
+	if path == "" {
+		var err error
+		path, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.ui.Say(T("Uploading {{.Path}} into a new package of app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"Path":      terminal.EntityNameColor(path),
+			"AppName":   terminal.EntityNameColor(app.Name),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	zipFile, err := ioutil.TempFile("", "uploads")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		zipFile.Close()
+		os.Remove(zipFile.Name())
+	}()
+
+	err = cmd.zipper.Zip(path, zipFile, false)
+	if err != nil {
+		return fmt.Errorf("%s: %s", T("Error zipping application"), err.Error())
+	}
+
+	pkg, err := cmd.packageRepo.CreatePackage(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.packageRepo.UploadBits(pkg.GUID, zipFile)
+	if err != nil {
+		return err
+	}
+
+	for pkg.State != models.PackageStateReady {
+		if pkg.State == models.PackageStateFailed || pkg.State == models.PackageStateExpired {
+			return errors.New(T("Processing the uploaded app files failed; the package is {{.State}}", map[string]interface{}{"State": pkg.State}))
+		}
+
+		time.Sleep(cmd.UploadPollInterval)
+
+		pkg, err = cmd.packageRepo.GetPackage(pkg.GUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Package guid: {{.PackageGUID}}", map[string]interface{}{"PackageGUID": terminal.EntityNameColor(pkg.GUID)}))
+	cmd.ui.Say(T("TIP: Use '{{.Command}}' to stage the package into a droplet", map[string]interface{}{
+		"Command": terminal.CommandColor(cf.Name + " stage-package " + app.Name + " " + pkg.GUID),
+	}))
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package application_test
+
+import (
+	"errors"
+	"os"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/packages/packagesfakes"
+	"code.cloudfoundry.org/cli/cf/appfiles/appfilesfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/application"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("create-package command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		packageRepo         *packagesfakes.FakeRepository
+		zipper              *appfilesfakes.FakeZipper
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+		states              []string
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = api.RepositoryLocator{}.SetPackageRepository(packageRepo)
+		deps.AppZipper = zipper
+		cmd := commandregistry.Commands.FindCommand("create-package").SetDependency(deps, pluginCall).(*application.CreatePackage)
+		cmd.UploadPollInterval = 0
+		commandregistry.Commands.SetCommand(cmd)
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("create-package", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		packageRepo = new(packagesfakes.FakeRepository)
+		zipper = new(appfilesfakes.FakeZipper)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+
+		packageRepo.CreatePackageReturns(models.Package{GUID: "new-package-guid", Type: "bits", State: "AWAITING_UPLOAD"}, nil)
+		states = []string{"PROCESSING_UPLOAD", "READY"}
+		packageRepo.GetPackageStub = func(packageGUID string) (models.Package, error) {
+			state := states[packageRepo.GetPackageCallCount()-1]
+			return models.Package{GUID: packageGUID, Type: "bits", State: state}, nil
+		}
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage when not given an app name", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires an argument"},
+			))
+		})
+
+		It("requires an API with v3 packages", func() {
+			runCommand("my-app", "-p", "my-app.zip")
+			command, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+			Expect(command).To(Equal("create-package"))
+			Expect(version).To(Equal(cf.PackagesMinimumAPIVersion))
+		})
+	})
+
+	It("zips the path, uploads it into a new package and waits for the package to be ready", func() {
+		Expect(runCommand("my-app", "-p", "my-app.zip")).To(BeTrue())
+
+		path, _, preserveSymlinks := zipper.ZipArgsForCall(0)
+		Expect(path).To(Equal("my-app.zip"))
+		Expect(preserveSymlinks).To(BeFalse())
+
+		Expect(packageRepo.CreatePackageArgsForCall(0)).To(Equal("my-app-guid"))
+		packageGUID, _ := packageRepo.UploadBitsArgsForCall(0)
+		Expect(packageGUID).To(Equal("new-package-guid"))
+		Expect(packageRepo.GetPackageCallCount()).To(Equal(2))
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Uploading my-app.zip into a new package of app my-app in org my-org / space my-space as my-user..."},
+			[]string{"OK"},
+			[]string{"Package guid: new-package-guid"},
+			[]string{"TIP", "cf stage-package my-app new-package-guid"},
+		))
+	})
+
+	It("takes the path from --path", func() {
+		Expect(runCommand("my-app", "--path", "my-app.zip")).To(BeTrue())
+
+		path, _, _ := zipper.ZipArgsForCall(0)
+		Expect(path).To(Equal("my-app.zip"))
+	})
+
+	It("zips the current directory when no path is given", func() {
+		Expect(runCommand("my-app")).To(BeTrue())
+
+		wd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		path, _, _ := zipper.ZipArgsForCall(0)
+		Expect(path).To(Equal(wd))
+	})
+
+	It("fails without creating a package when zipping fails", func() {
+		zipper.ZipReturns(errors.New("no such directory"))
+
+		Expect(runCommand("my-app", "-p", "missing")).To(BeFalse())
+
+		Expect(packageRepo.CreatePackageCallCount()).To(BeZero())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"Error zipping application", "no such directory"}))
+	})
+
+	It("fails when processing the upload fails", func() {
+		states = []string{"PROCESSING_UPLOAD", "FAILED"}
+
+		Expect(runCommand("my-app", "-p", "my-app.zip")).To(BeFalse())
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Processing the uploaded app files failed; the package is FAILED"},
+		))
+	})
+})
@@ -0,0 +1,98 @@
+package application
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type Deployments struct {
+	ui              terminal.UI
+	config          coreconfig.Reader
+	deploymentActor actors.DeploymentActor
+	appReq          requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&Deployments{})
+}
+
+func (cmd *Deployments) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "deployments",
+		Description: T("List the deployments of an app, newest first"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME deployments %s", T("APP_NAME")),
+		},
+	}
+}
+
+func (cmd *Deployments) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("deployments"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("deployments", cf.DeploymentsMinimumAPIVersion),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *Deployments) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.deploymentActor = deps.DeploymentActor
+	return cmd
+}
+
+func (cmd *Deployments) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say(T("Getting deployments for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	deployments, err := cmd.deploymentActor.ListDeployments(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(deployments) == 0 {
+		cmd.ui.Say(T("No deployments found"))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{T("guid"), T("status"), T("strategy"), T("droplet"), T("created")})
+	for _, deployment := range deployments {
+		table.Add(
+			deployment.GUID,
+			deployment.StatusDescription(),
+			deployment.Strategy,
+			deployment.DropletGUID,
+			deployment.CreatedAt.Local().Format("2006-01-02T15:04:05.00-0700"),
+		)
+	}
+
+	return table.Print()
+}
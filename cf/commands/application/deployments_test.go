@@ -0,0 +1,94 @@
+package application_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("deployments command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		deploymentActor     *actorsfakes.FakeDeploymentActor
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.DeploymentActor = deploymentActor
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("deployments").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("deployments", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		deploymentActor = new(actorsfakes.FakeDeploymentActor)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+	})
+
+	It("fails with usage when not given an app name", func() {
+		runCommand()
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Requires an argument"},
+		))
+	})
+
+	It("requires an API with deployments", func() {
+		runCommand("my-app")
+		command, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+		Expect(command).To(Equal("deployments"))
+		Expect(version).To(Equal(cf.DeploymentsMinimumAPIVersion))
+	})
+
+	It("lists the deployments of the app", func() {
+		deploymentActor.ListDeploymentsReturns([]models.Deployment{
+			{GUID: "deployment-2-guid", StatusValue: "ACTIVE", StatusReason: "DEPLOYING", Strategy: "rolling", DropletGUID: "droplet-2-guid", CreatedAt: time.Date(2016, 11, 2, 10, 0, 0, 0, time.UTC)},
+			{GUID: "deployment-1-guid", State: "DEPLOYED", Strategy: "rolling", DropletGUID: "droplet-1-guid", CreatedAt: time.Date(2016, 11, 1, 10, 0, 0, 0, time.UTC)},
+		}, nil)
+
+		Expect(runCommand("my-app")).To(BeTrue())
+		Expect(deploymentActor.ListDeploymentsArgsForCall(0)).To(Equal("my-app-guid"))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Getting deployments for app", "my-app", "my-org", "my-space", "my-user"},
+			[]string{"OK"},
+			[]string{"guid", "status", "strategy", "droplet", "created"},
+			[]string{"deployment-2-guid", "ACTIVE (DEPLOYING)", "rolling", "droplet-2-guid"},
+			[]string{"deployment-1-guid", "DEPLOYED", "rolling", "droplet-1-guid"},
+		))
+	})
+
+	It("tells the user when the app has no deployments", func() {
+		Expect(runCommand("my-app")).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"No deployments found"}))
+	})
+})
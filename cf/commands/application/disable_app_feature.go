@@ -0,0 +1,89 @@
+package application
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api/appfeatures"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type DisableAppFeature struct {
+	ui             terminal.UI
+	config         coreconfig.Reader
+	appFeatureRepo appfeatures.Repository
+	appReq         requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&DisableAppFeature{})
+}
+
+func (cmd *DisableAppFeature) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "disable-app-feature",
+		Description: T("Disable a feature of an app"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME disable-app-feature %s %s", T("APP_NAME"), T("FEATURE_NAME")),
+			"\n\n",
+			T("Features: ssh, revisions. Use app-features to see which features are enabled."),
+		},
+		Examples: []string{
+			"CF_NAME disable-app-feature my-app ssh",
+			"CF_NAME disable-app-feature my-app revisions",
+		},
+		TotalArgs: 2,
+	}
+}
+
+func (cmd *DisableAppFeature) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires APP_NAME and FEATURE_NAME as arguments\n\n") + commandregistry.Commands.CommandUsage("disable-app-feature"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 2)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("disable-app-feature", cf.AppFeaturesMinimumAPIVersion),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *DisableAppFeature) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.appFeatureRepo = deps.RepoLocator.GetAppFeatureRepository()
+	return cmd
+}
+
+func (cmd *DisableAppFeature) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+	featureName := c.Args()[1]
+
+	cmd.ui.Say(T("Disabling feature {{.FeatureName}} of app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"FeatureName": terminal.EntityNameColor(featureName),
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":    terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	_, err := cmd.appFeatureRepo.UpdateAppFeature(app.GUID, featureName, false)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
@@ -0,0 +1,121 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api/droplets"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type DownloadDroplet struct {
+	ui          terminal.UI
+	config      coreconfig.Reader
+	dropletRepo droplets.Repository
+	appReq      requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&DownloadDroplet{})
+}
+
+func (cmd *DownloadDroplet) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["droplet"] = &flags.StringFlag{Name: "droplet", Usage: T("Guid of the droplet to download (Default: the droplet the app is running on)")}
+	fs["o"] = &flags.StringFlag{ShortName: "o", Usage: T("Path of the file to save the droplet to (Default: droplet_DROPLET_GUID.tgz in the current directory)")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "download-droplet",
+		Description: T("Download the droplet of an app as a gzipped tarball"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME download-droplet %s [--droplet %s] [-o %s]", T("APP_NAME"), T("DROPLET_GUID"), T("PATH")),
+		},
+		Examples: []string{
+			"CF_NAME download-droplet my-app -o my-app.tgz",
+			"CF_NAME download-droplet my-app --droplet 7f8a9b2c-5d6e-4f10-8a21-3b4c5d6e7f80",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *DownloadDroplet) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("download-droplet"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("download-droplet", cf.DropletHistoryMinimumAPIVersion),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *DownloadDroplet) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.dropletRepo = deps.RepoLocator.GetDropletRepository()
+	return cmd
+}
+
+func (cmd *DownloadDroplet) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	dropletGUID := c.String("droplet")
+	if dropletGUID == "" {
+		var err error
+		dropletGUID, err = cmd.dropletRepo.GetCurrentDropletGUID(app.GUID)
+		if err != nil {
+			return err
+		}
+
+		if dropletGUID == "" {
+			return errors.New(T("App {{.AppName}} has no droplet to download; push or stage it first", map[string]interface{}{"AppName": app.Name}))
+		}
+	}
+
+	path := c.String("o")
+	if path == "" {
+		path = fmt.Sprintf("droplet_%s.tgz", dropletGUID)
+	}
+
+	cmd.ui.Say(T("Downloading droplet {{.DropletGUID}} of app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"DropletGUID": terminal.EntityNameColor(dropletGUID),
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":    terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.dropletRepo.DownloadDroplet(dropletGUID, file)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say(T("Droplet saved to {{.Path}}", map[string]interface{}{"Path": terminal.EntityNameColor(path)}))
+	return nil
+}
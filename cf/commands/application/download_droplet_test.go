@@ -0,0 +1,148 @@
+package application_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/droplets/dropletsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("download-droplet command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		dropletRepo         *dropletsfakes.FakeRepository
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+		dir                 string
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = api.RepositoryLocator{}.SetDropletRepository(dropletRepo)
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("download-droplet").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("download-droplet", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		dropletRepo = new(dropletsfakes.FakeRepository)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+
+		dropletRepo.GetCurrentDropletGUIDReturns("current-droplet-guid", nil)
+		dropletRepo.DownloadDropletStub = func(dropletGUID string, dest io.Writer) error {
+			_, err := fmt.Fprintf(dest, "tarball of %s", dropletGUID)
+			return err
+		}
+
+		var err error
+		dir, err = ioutil.TempDir("", "download-droplet")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage when not provided exactly one arg", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires an argument"},
+			))
+		})
+
+		It("requires an API that keeps droplets", func() {
+			runCommand("my-app", "-o", filepath.Join(dir, "my-app.tgz"))
+
+			Expect(requirementsFactory.NewMinAPIVersionRequirementCallCount()).To(Equal(1))
+			feature, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+			Expect(feature).To(Equal("download-droplet"))
+			Expect(version).To(Equal(cf.DropletHistoryMinimumAPIVersion))
+		})
+	})
+
+	It("saves the current droplet of the app to the given path", func() {
+		path := filepath.Join(dir, "my-app.tgz")
+		Expect(runCommand("my-app", "-o", path)).To(BeTrue())
+
+		Expect(dropletRepo.GetCurrentDropletGUIDArgsForCall(0)).To(Equal("my-app-guid"))
+		guid, _ := dropletRepo.DownloadDropletArgsForCall(0)
+		Expect(guid).To(Equal("current-droplet-guid"))
+
+		contents, err := ioutil.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).To(Equal("tarball of current-droplet-guid"))
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Downloading droplet current-droplet-guid of app my-app in org my-org / space my-space as my-user..."},
+			[]string{"OK"},
+			[]string{"Droplet saved to", path},
+		))
+	})
+
+	It("downloads the droplet given with --droplet", func() {
+		path := filepath.Join(dir, "my-app.tgz")
+		Expect(runCommand("my-app", "--droplet", "old-droplet-guid", "-o", path)).To(BeTrue())
+
+		Expect(dropletRepo.GetCurrentDropletGUIDCallCount()).To(BeZero())
+		guid, _ := dropletRepo.DownloadDropletArgsForCall(0)
+		Expect(guid).To(Equal("old-droplet-guid"))
+	})
+
+	It("fails when the app has no droplet", func() {
+		dropletRepo.GetCurrentDropletGUIDReturns("", nil)
+
+		Expect(runCommand("my-app", "-o", filepath.Join(dir, "my-app.tgz"))).To(BeFalse())
+
+		Expect(dropletRepo.DownloadDropletCallCount()).To(BeZero())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"App my-app has no droplet to download"},
+		))
+	})
+
+	It("removes the file when the download fails", func() {
+		dropletRepo.DownloadDropletStub = nil
+		dropletRepo.DownloadDropletReturns(errors.New("download failed"))
+		path := filepath.Join(dir, "my-app.tgz")
+
+		Expect(runCommand("my-app", "-o", path)).To(BeFalse())
+
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"download failed"}))
+		_, err := os.Stat(path)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
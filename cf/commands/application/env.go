@@ -12,14 +12,26 @@ import (
 	"code.cloudfoundry.org/cli/cf/api/applications"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
 )
 
 type Env struct {
-	ui      terminal.UI
-	config  coreconfig.Reader
-	appRepo applications.Repository
+	ui           terminal.UI
+	config       coreconfig.Reader
+	appRepo      applications.Repository
+	manifestRepo manifest.Repository
+}
+
+// envDrift is a user-provided env variable of an app in a manifest that is
+// not set on the app, or is set to another value.
+type envDrift struct {
+	Name          string `json:"name"`
+	ManifestValue string `json:"manifest_value"`
+	AppValue      string `json:"app_value,omitempty"`
+	Missing       bool   `json:"missing"`
 }
 
 func init() {
@@ -27,13 +39,22 @@ func init() {
 }
 
 func (cmd *Env) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["diff"] = &flags.StringFlag{Name: "diff", Usage: T("Show the env variables of the app in this manifest that are not set on the app, or are set to another value")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "env",
 		ShortName:   "e",
 		Description: T("Show all env variables for an app"),
 		Usage: []string{
-			T("CF_NAME env APP_NAME"),
+			T("CF_NAME env APP_NAME [--diff MANIFEST_PATH]"),
 		},
+		Examples: []string{
+			"CF_NAME env my-app --output json",
+			"CF_NAME env my-app --diff manifest.yml",
+		},
+		Flags:            fs,
+		StructuredOutput: true,
 	}
 }
 
@@ -55,6 +76,7 @@ func (cmd *Env) SetDependency(deps commandregistry.Dependency, pluginCall bool)
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
+	cmd.manifestRepo = deps.ManifestRepo
 	return cmd
 }
 
@@ -76,6 +98,21 @@ func (cmd *Env) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	if c.IsSet("diff") {
+		return cmd.diffManifest(app.Name, env.Environment, c.String("diff"))
+	}
+
+	if printer, ok := cmd.ui.(terminal.DataPrinter); ok {
+		printer.SetData(map[string]interface{}{
+			"system_env_json":      env.System,
+			"application_env_json": env.Application,
+			"environment_json":     env.Environment,
+			"running_env_json":     env.Running,
+			"staging_env_json":     env.Staging,
+		})
+		return nil
+	}
+
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
@@ -181,3 +218,95 @@ func (cmd *Env) displayStagingEnvironment(envVars map[string]interface{}) {
 		cmd.ui.Say("%s: %v", key, envVars[key])
 	}
 }
+
+// diffManifest shows the drift between the env variables of the app in the
+// manifest at path and the user-provided env variables of the running app.
+func (cmd *Env) diffManifest(appName string, appEnv map[string]interface{}, path string) error {
+	m, err := cmd.manifestRepo.ReadManifest(path)
+	if err != nil {
+		return errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+
+	manifestEnv, err := manifestAppEnv(m, appName)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(manifestEnv))
+	for name := range manifestEnv {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	drift := []envDrift{}
+	for _, name := range names {
+		manifestValue := fmt.Sprintf("%v", manifestEnv[name])
+		appValue, set := appEnv[name]
+		switch {
+		case !set:
+			drift = append(drift, envDrift{Name: name, ManifestValue: manifestValue, Missing: true})
+		case fmt.Sprintf("%v", appValue) != manifestValue:
+			drift = append(drift, envDrift{Name: name, ManifestValue: manifestValue, AppValue: fmt.Sprintf("%v", appValue)})
+		}
+	}
+
+	if printer, ok := cmd.ui.(terminal.DataPrinter); ok {
+		printer.SetData(map[string]interface{}{
+			"manifest": m.Path,
+			"drift":    drift,
+		})
+		return nil
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(drift) == 0 {
+		cmd.ui.Say(T("The env variables of app {{.AppName}} match manifest {{.Path}}",
+			map[string]interface{}{"AppName": appName, "Path": m.Path}))
+		return nil
+	}
+
+	cmd.ui.Say(T("Env variables in manifest {{.Path}} that differ on app {{.AppName}}:",
+		map[string]interface{}{"AppName": appName, "Path": m.Path}))
+	table := cmd.ui.Table([]string{T("name"), T("manifest"), T("app")})
+	table.Table.SetFieldKeys("name", "manifest", "app")
+	for _, d := range drift {
+		appValue := d.AppValue
+		if d.Missing {
+			appValue = terminal.WarningColor(T("(not set)"))
+		}
+		table.Add(d.Name, d.ManifestValue, appValue)
+	}
+	return table.Print()
+}
+
+// manifestAppEnv returns the env variables of the app called appName in m, or
+// of the only app in m when it has no name.
+func manifestAppEnv(m *manifest.Manifest, appName string) (map[string]interface{}, error) {
+	apps, err := m.Applications()
+	if err != nil {
+		return nil, errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+
+	var found *models.AppParams
+	for i := range apps {
+		if apps[i].Name != nil && *apps[i].Name == appName {
+			found = &apps[i]
+			break
+		}
+	}
+	if found == nil && len(apps) == 1 && apps[0].Name == nil {
+		found = &apps[0]
+	}
+
+	if found == nil {
+		return nil, errors.New(T("App {{.AppName}} not found in manifest {{.Path}}",
+			map[string]interface{}{"AppName": appName, "Path": m.Path}))
+	}
+
+	if found.EnvironmentVars == nil {
+		return map[string]interface{}{}, nil
+	}
+	return *found.EnvironmentVars, nil
+}
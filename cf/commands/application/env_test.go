@@ -1,16 +1,23 @@
 package application_test
 
 import (
+	"strings"
+
 	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/manifest/manifestfakes"
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
 	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	"code.cloudfoundry.org/cli/utils/generic"
 
 	. "code.cloudfoundry.org/cli/testhelpers/matchers"
 	. "github.com/onsi/ginkgo"
@@ -22,6 +29,7 @@ var _ = Describe("env command", func() {
 		ui                  *testterm.FakeUI
 		app                 models.Application
 		appRepo             *applicationsfakes.FakeRepository
+		manifestRepo        *manifestfakes.FakeRepository
 		configRepo          coreconfig.Repository
 		requirementsFactory *requirementsfakes.FakeFactory
 		deps                commandregistry.Dependency
@@ -31,6 +39,7 @@ var _ = Describe("env command", func() {
 		deps.UI = ui
 		deps.Config = configRepo
 		deps.RepoLocator = deps.RepoLocator.SetApplicationRepository(appRepo)
+		deps.ManifestRepo = manifestRepo
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("env").SetDependency(deps, pluginCall))
 	}
 
@@ -41,6 +50,7 @@ var _ = Describe("env command", func() {
 		app.Name = "my-app"
 		appRepo = new(applicationsfakes.FakeRepository)
 		appRepo.ReadReturns(app, nil)
+		manifestRepo = new(manifestfakes.FakeRepository)
 
 		configRepo = testconfig.NewRepositoryWithDefaults()
 		requirementsFactory = new(requirementsfakes.FakeFactory)
@@ -217,6 +227,135 @@ var _ = Describe("env command", func() {
 		})
 	})
 
+	Context("when the output is formatted as json", func() {
+		It("prints the environment of the app instead of the text", func() {
+			appRepo.ReadEnvReturns(&models.Environment{
+				Environment: map[string]interface{}{"my-key": "my-value"},
+				Running:     map[string]interface{}{"running": true},
+			}, nil)
+
+			formattedUI := terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+			deps.UI = formattedUI
+			deps.Config = configRepo
+			deps.RepoLocator = deps.RepoLocator.SetApplicationRepository(appRepo)
+			cmd := commandregistry.Commands.FindCommand("env").SetDependency(deps, false)
+
+			flagContext := flags.NewFlagContext(cmd.MetaData().Flags)
+			Expect(flagContext.Parse("my-app")).To(Succeed())
+			Expect(cmd.Execute(flagContext)).To(Succeed())
+			Expect(ui.Outputs()).To(BeEmpty())
+
+			Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+			Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`{
+				"system_env_json": null,
+				"application_env_json": null,
+				"environment_json": {"my-key": "my-value"},
+				"running_env_json": {"running": true},
+				"staging_env_json": null
+			}`))
+		})
+	})
+
+	Describe("--diff", func() {
+		BeforeEach(func() {
+			appRepo.ReadEnvReturns(&models.Environment{
+				Environment: map[string]interface{}{
+					"SAME":     "value",
+					"CHANGED":  "new-value",
+					"PORT":     float64(8080),
+					"APP_ONLY": "ignored",
+				},
+			}, nil)
+		})
+
+		manifestWithEnv := func(app map[interface{}]interface{}) *manifest.Manifest {
+			return &manifest.Manifest{
+				Path: "manifest.yml",
+				Data: generic.NewMap(map[interface{}]interface{}{
+					"applications": []interface{}{generic.NewMap(app)},
+				}),
+			}
+		}
+
+		It("shows the env variables of the manifest that are missing or differ on the app", func() {
+			manifestRepo.ReadManifestReturns(manifestWithEnv(map[interface{}]interface{}{
+				"name": "my-app",
+				"env": map[interface{}]interface{}{
+					"SAME":    "value",
+					"CHANGED": "old-value",
+					"PORT":    8080,
+					"MISSING": "expected",
+				},
+			}), nil)
+
+			Expect(runCommand("my-app", "--diff", "manifest.yml")).To(BeTrue())
+			Expect(manifestRepo.ReadManifestArgsForCall(0)).To(Equal("manifest.yml"))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Env variables in manifest manifest.yml that differ on app my-app"},
+				[]string{"name", "manifest", "app"},
+				[]string{"CHANGED", "old-value", "new-value"},
+				[]string{"MISSING", "expected", "(not set)"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"SAME"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"PORT"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"APP_ONLY"}))
+		})
+
+		It("says so when the app matches the manifest", func() {
+			manifestRepo.ReadManifestReturns(manifestWithEnv(map[interface{}]interface{}{
+				"name": "my-app",
+				"env":  map[interface{}]interface{}{"SAME": "value"},
+			}), nil)
+
+			Expect(runCommand("my-app", "--diff", "manifest.yml")).To(BeTrue())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"The env variables of app my-app match manifest manifest.yml"},
+			))
+		})
+
+		It("fails when the app is not in the manifest", func() {
+			manifestRepo.ReadManifestReturns(manifestWithEnv(map[interface{}]interface{}{
+				"name": "other-app",
+			}), nil)
+
+			Expect(runCommand("my-app", "--diff", "manifest.yml")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"App my-app not found in manifest manifest.yml"},
+			))
+		})
+
+		It("prints the drift as json when the output is formatted as json", func() {
+			manifestRepo.ReadManifestReturns(manifestWithEnv(map[interface{}]interface{}{
+				"name": "my-app",
+				"env": map[interface{}]interface{}{
+					"CHANGED": "old-value",
+					"MISSING": "expected",
+				},
+			}), nil)
+
+			formattedUI := terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+			deps.UI = formattedUI
+			deps.Config = configRepo
+			deps.ManifestRepo = manifestRepo
+			deps.RepoLocator = deps.RepoLocator.SetApplicationRepository(appRepo)
+			cmd := commandregistry.Commands.FindCommand("env").SetDependency(deps, false)
+
+			flagContext := flags.NewFlagContext(cmd.MetaData().Flags)
+			Expect(flagContext.Parse("my-app", "--diff", "manifest.yml")).To(Succeed())
+			Expect(cmd.Execute(flagContext)).To(Succeed())
+
+			Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+			Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`{
+				"manifest": "manifest.yml",
+				"drift": [
+					{"name": "CHANGED", "manifest_value": "old-value", "app_value": "new-value", "missing": false},
+					{"name": "MISSING", "manifest_value": "expected", "missing": true}
+				]
+			}`))
+		})
+	})
+
 	Context("when reading the environment variables returns an error", func() {
 		It("tells you about that error", func() {
 			appRepo.ReadEnvReturns(nil, errors.New("BOO YOU CANT DO THAT; GO HOME; you're drunk"))
@@ -3,12 +3,15 @@ package application
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf/api/appevents"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
 )
@@ -18,6 +21,8 @@ type Events struct {
 	config     coreconfig.Reader
 	appReq     requirements.ApplicationRequirement
 	eventsRepo appevents.Repository
+
+	FollowInterval time.Duration
 }
 
 func init() {
@@ -25,17 +30,53 @@ func init() {
 }
 
 func (cmd *Events) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["follow"] = &flags.BoolFlag{Name: "follow", ShortName: "f", Usage: T("Keep checking for new events every 5 seconds until interrupted")}
+	fs["type"] = &flags.StringFlag{Name: "type", Usage: T("Only show events of a comma-separated list of types, e.g. audit.app.update,audit.app.restage")}
+	fs["all-in-space"] = &flags.BoolFlag{Name: "all-in-space", Usage: T("Show the events of everything in the targeted space")}
+	fs["all-in-org"] = &flags.BoolFlag{Name: "all-in-org", Usage: T("Show the events of everything in the targeted org")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "events",
 		Description: T("Show recent app events"),
 		Usage: []string{
 			"CF_NAME events ",
 			T("APP_NAME"),
+			" [--follow] [--type TYPES]\n   CF_NAME events --all-in-space|--all-in-org [--follow] [--type TYPES]",
+		},
+		Examples: []string{
+			"CF_NAME events my-app --follow",
+			"CF_NAME events --all-in-space --type audit.app.create,audit.app.delete-request",
 		},
+		Flags: fs,
 	}
 }
 
 func (cmd *Events) Requirements(requirementsFactory requirements.Factory, c flags.FlagContext) ([]requirements.Requirement, error) {
+	if c.Bool("all-in-space") && c.Bool("all-in-org") {
+		cmd.ui.Failed(T("Incorrect Usage: --all-in-space and --all-in-org cannot be used together.\n\n") + commandregistry.Commands.CommandUsage("events"))
+		return nil, fmt.Errorf("Incorrect usage: --all-in-space and --all-in-org cannot be used together")
+	}
+
+	if c.Bool("all-in-space") || c.Bool("all-in-org") {
+		if len(c.Args()) != 0 {
+			cmd.ui.Failed(T("Incorrect Usage: APP_NAME cannot be given with --all-in-space or --all-in-org.\n\n") + commandregistry.Commands.CommandUsage("events"))
+			return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(c.Args()), 0)
+		}
+
+		if c.Bool("all-in-org") {
+			return []requirements.Requirement{
+				requirementsFactory.NewLoginRequirement(),
+				requirementsFactory.NewTargetedOrgRequirement(),
+			}, nil
+		}
+
+		return []requirements.Requirement{
+			requirementsFactory.NewLoginRequirement(),
+			requirementsFactory.NewTargetedSpaceRequirement(),
+		}, nil
+	}
+
 	if len(c.Args()) != 1 {
 		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("events"))
 		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(c.Args()), 1)
@@ -56,39 +97,83 @@ func (cmd *Events) SetDependency(deps commandregistry.Dependency, pluginCall boo
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.eventsRepo = deps.RepoLocator.GetAppEventsRepository()
+	cmd.FollowInterval = 5 * time.Second
 	return cmd
 }
 
 func (cmd *Events) Execute(c flags.FlagContext) error {
-	app := cmd.appReq.GetApplication()
+	query := appevents.Query{Limit: 50}
+	if c.IsSet("type") {
+		for _, eventType := range strings.Split(c.String("type"), ",") {
+			if eventType = strings.TrimSpace(eventType); eventType != "" {
+				query.Types = append(query.Types, eventType)
+			}
+		}
+	}
+
+	var table *terminal.UITable
+	noEventsMessage := ""
+
+	switch {
+	case c.Bool("all-in-org"):
+		cmd.ui.Say(T("Getting events in org {{.OrgName}} as {{.Username}}...\n",
+			map[string]interface{}{
+				"OrgName":  terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"Username": terminal.EntityNameColor(cmd.config.Username())}))
 
-	cmd.ui.Say(T("Getting events for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
-		map[string]interface{}{
-			"AppName":   terminal.EntityNameColor(app.Name),
-			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
-			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
-			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+		query.OrganizationGUID = cmd.config.OrganizationFields().GUID
+		table = cmd.ui.Table([]string{T("time"), T("event"), T("target"), T("actor"), T("description")})
+		noEventsMessage = T("No events found")
+	case c.Bool("all-in-space"):
+		cmd.ui.Say(T("Getting events in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
+			map[string]interface{}{
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
 
-	table := cmd.ui.Table([]string{T("time"), T("event"), T("actor"), T("description")})
+		query.SpaceGUID = cmd.config.SpaceFields().GUID
+		table = cmd.ui.Table([]string{T("time"), T("event"), T("target"), T("actor"), T("description")})
+		noEventsMessage = T("No events found")
+	default:
+		app := cmd.appReq.GetApplication()
+
+		cmd.ui.Say(T("Getting events for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
+			map[string]interface{}{
+				"AppName":   terminal.EntityNameColor(app.Name),
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+		query.ActeeGUID = app.GUID
+		table = cmd.ui.Table([]string{T("time"), T("event"), T("actor"), T("description")})
+		noEventsMessage = T("No events for app {{.AppName}}",
+			map[string]interface{}{"AppName": terminal.EntityNameColor(app.Name)})
+	}
 
-	events, err := cmd.eventsRepo.RecentEvents(app.GUID, 50)
+	var (
+		events []models.EventFields
+		err    error
+	)
+	if query.ActeeGUID != "" && len(query.Types) == 0 {
+		events, err = cmd.eventsRepo.RecentEvents(query.ActeeGUID, query.Limit)
+	} else {
+		events, err = cmd.eventsRepo.ListEvents(query)
+	}
 	if err != nil {
 		return errors.New(T("Failed fetching events.\n{{.APIErr}}",
 			map[string]interface{}{"APIErr": err.Error()}))
 	}
 
-	for _, event := range events {
-		actor := event.ActorName
-		if actor == "" {
-			actor = event.Actor
+	if c.Bool("follow") {
+		// Recent events come newest first; followed events are added
+		// below them as they happen, so show the recent ones oldest first.
+		for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+			events[i], events[j] = events[j], events[i]
 		}
+	}
 
-		table.Add(
-			event.Timestamp.Local().Format("2006-01-02T15:04:05.00-0700"),
-			event.Name,
-			actor,
-			event.Description,
-		)
+	for _, event := range events {
+		cmd.addEvent(table, event, query.ActeeGUID == "")
 	}
 
 	err = table.Print()
@@ -96,10 +181,78 @@ func (cmd *Events) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	if c.Bool("follow") {
+		return cmd.followEvents(table, query, events)
+	}
+
 	if len(events) == 0 {
-		cmd.ui.Say(T("No events for app {{.AppName}}",
-			map[string]interface{}{"AppName": terminal.EntityNameColor(app.Name)}))
+		cmd.ui.Say(noEventsMessage)
 		return nil
 	}
 	return nil
 }
+
+// followEvents polls for the events after the last of events, the most
+// recent one, until it fails. Events are asked for from the time of the most recent one
+// inclusively, so the ones already shown at that time are skipped.
+func (cmd *Events) followEvents(table *terminal.UITable, query appevents.Query, events []models.EventFields) error {
+	query.Limit = 0
+	query.Since = time.Now()
+	shown := map[string]bool{}
+
+	if len(events) > 0 {
+		query.Since = events[len(events)-1].Timestamp
+		for _, event := range events {
+			if event.Timestamp.Equal(query.Since) {
+				shown[event.GUID] = true
+			}
+		}
+	}
+
+	for {
+		time.Sleep(cmd.FollowInterval)
+
+		newEvents, err := cmd.eventsRepo.ListEvents(query)
+		if err != nil {
+			return errors.New(T("Failed fetching events.\n{{.APIErr}}",
+				map[string]interface{}{"APIErr": err.Error()}))
+		}
+
+		for _, event := range newEvents {
+			if shown[event.GUID] {
+				continue
+			}
+
+			if event.Timestamp.After(query.Since) {
+				query.Since = event.Timestamp
+				shown = map[string]bool{}
+			}
+			shown[event.GUID] = true
+
+			cmd.addEvent(table, event, query.ActeeGUID == "")
+		}
+
+		err = table.Print()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (cmd *Events) addEvent(table *terminal.UITable, event models.EventFields, showTarget bool) {
+	actor := event.ActorName
+	if actor == "" {
+		actor = event.Actor
+	}
+
+	row := []string{
+		event.Timestamp.Local().Format("2006-01-02T15:04:05.00-0700"),
+		event.Name,
+	}
+	if showTarget {
+		row = append(row, event.ActeeName)
+	}
+	row = append(row, actor, event.Description)
+
+	table.Add(row...)
+}
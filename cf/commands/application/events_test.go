@@ -2,6 +2,7 @@ package application_test
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf/api"
@@ -11,6 +12,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 
+	"code.cloudfoundry.org/cli/cf/api/appevents"
 	"code.cloudfoundry.org/cli/cf/api/appevents/appeventsfakes"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig/coreconfigfakes"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
@@ -112,6 +114,55 @@ var _ = Describe("events command", func() {
 		})
 	})
 
+	Describe("Requirements with --all-in-space or --all-in-org", func() {
+		var targetedOrgRequirement *requirementsfakes.FakeTargetedOrgRequirement
+
+		BeforeEach(func() {
+			cmd.SetDependency(deps, false)
+			targetedOrgRequirement = new(requirementsfakes.FakeTargetedOrgRequirement)
+			reqFactory.NewTargetedOrgRequirementReturns(targetedOrgRequirement)
+		})
+
+		It("requires a targeted space for --all-in-space", func() {
+			err := flagContext.Parse("--all-in-space")
+			Expect(err).NotTo(HaveOccurred())
+			actualRequirements, err := cmd.Requirements(reqFactory, flagContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(actualRequirements).To(ConsistOf(loginRequirement, targetedSpaceRequirement))
+			Expect(reqFactory.NewApplicationRequirementCallCount()).To(Equal(0))
+		})
+
+		It("requires a targeted org for --all-in-org", func() {
+			err := flagContext.Parse("--all-in-org")
+			Expect(err).NotTo(HaveOccurred())
+			actualRequirements, err := cmd.Requirements(reqFactory, flagContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(actualRequirements).To(ConsistOf(loginRequirement, targetedOrgRequirement))
+		})
+
+		It("fails with usage when given an app name", func() {
+			err := flagContext.Parse("--all-in-space", "my-app")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cmd.Requirements(reqFactory, flagContext)
+			Expect(err).To(HaveOccurred())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "APP_NAME cannot be given with --all-in-space or --all-in-org"},
+			))
+		})
+
+		It("fails with usage when given both --all-in-space and --all-in-org", func() {
+			err := flagContext.Parse("--all-in-space", "--all-in-org")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = cmd.Requirements(reqFactory, flagContext)
+			Expect(err).To(HaveOccurred())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "--all-in-space and --all-in-org cannot be used together"},
+			))
+		})
+	})
+
 	Describe("Execute", func() {
 		var executeCmdErr error
 
@@ -219,4 +270,158 @@ var _ = Describe("events command", func() {
 			})
 		})
 	})
+
+	Describe("Execute with filters", func() {
+		var (
+			executeCmdErr error
+			args          []string
+			timestamp     time.Time
+		)
+
+		BeforeEach(func() {
+			applicationRequirement.GetApplicationReturns(models.Application{
+				ApplicationFields: models.ApplicationFields{
+					Name: "my-app",
+					GUID: "my-app-guid",
+				},
+			})
+			config.OrganizationFieldsReturns(models.OrganizationFields{Name: "my-org", GUID: "my-org-guid"})
+			config.SpaceFieldsReturns(models.SpaceFields{Name: "my-space", GUID: "my-space-guid"})
+
+			var err error
+			timestamp, err = time.Parse(TIMESTAMP_FORMAT, "2000-01-01T00:01:11.00-0000")
+			Expect(err).NotTo(HaveOccurred())
+
+			eventsRepo.ListEventsReturns([]models.EventFields{
+				{
+					GUID:      "event-guid-1",
+					Name:      "audit.app.create",
+					Timestamp: timestamp,
+					ActorName: "George Clooney",
+					ActeeName: "other-app",
+				},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			err := flagContext.Parse(args...)
+			Expect(err).NotTo(HaveOccurred())
+
+			cmd.SetDependency(deps, false)
+			cmd.FollowInterval = time.Millisecond
+			cmd.Requirements(reqFactory, flagContext)
+			executeCmdErr = cmd.Execute(flagContext)
+		})
+
+		Context("with --type", func() {
+			BeforeEach(func() {
+				args = []string{"my-app", "--type", "audit.app.create, audit.app.update"}
+			})
+
+			It("lists the events of the app with those types", func() {
+				Expect(executeCmdErr).NotTo(HaveOccurred())
+				Expect(eventsRepo.RecentEventsCallCount()).To(Equal(0))
+				Expect(eventsRepo.ListEventsArgsForCall(0)).To(Equal(appevents.Query{
+					ActeeGUID: "my-app-guid",
+					Types:     []string{"audit.app.create", "audit.app.update"},
+					Limit:     50,
+				}))
+			})
+		})
+
+		Context("with --all-in-space", func() {
+			BeforeEach(func() {
+				args = []string{"--all-in-space"}
+			})
+
+			It("lists the events in the targeted space with what they act on", func() {
+				Expect(executeCmdErr).NotTo(HaveOccurred())
+				Expect(eventsRepo.ListEventsArgsForCall(0)).To(Equal(appevents.Query{
+					SpaceGUID: "my-space-guid",
+					Limit:     50,
+				}))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Getting events in org", "my-org", "space", "my-space", "my-user"},
+					[]string{"time", "event", "target", "actor", "description"},
+					[]string{"audit.app.create", "other-app", "George Clooney"},
+				))
+			})
+
+			It("says when there are no events", func() {
+				eventsRepo.ListEventsReturns([]models.EventFields{}, nil)
+				ui.ClearOutputs()
+				Expect(cmd.Execute(flagContext)).To(Succeed())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"No events found"}))
+			})
+		})
+
+		Context("with --all-in-org", func() {
+			BeforeEach(func() {
+				args = []string{"--all-in-org"}
+			})
+
+			It("lists the events in the targeted org", func() {
+				Expect(executeCmdErr).NotTo(HaveOccurred())
+				Expect(eventsRepo.ListEventsArgsForCall(0)).To(Equal(appevents.Query{
+					OrganizationGUID: "my-org-guid",
+					Limit:            50,
+				}))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Getting events in org", "my-org", "my-user"},
+				))
+			})
+		})
+
+		Context("with --follow", func() {
+			var laterTimestamp time.Time
+
+			BeforeEach(func() {
+				args = []string{"my-app", "--follow"}
+				laterTimestamp = timestamp.Add(time.Minute)
+
+				eventsRepo.RecentEventsReturns([]models.EventFields{
+					{GUID: "event-guid-2", Name: "audit.app.update", Timestamp: timestamp},
+					{GUID: "event-guid-1", Name: "audit.app.create", Timestamp: timestamp.Add(-time.Minute)},
+				}, nil)
+
+				polls := 0
+				eventsRepo.ListEventsStub = func(query appevents.Query) ([]models.EventFields, error) {
+					polls++
+					switch polls {
+					case 1:
+						return []models.EventFields{
+							{GUID: "event-guid-2", Name: "audit.app.update", Timestamp: timestamp},
+							{GUID: "event-guid-3", Name: "audit.app.restage", Timestamp: laterTimestamp},
+						}, nil
+					case 2:
+						return []models.EventFields{
+							{GUID: "event-guid-3", Name: "audit.app.restage", Timestamp: laterTimestamp},
+						}, nil
+					default:
+						return nil, errors.New("connection lost")
+					}
+				}
+			})
+
+			It("shows recent events oldest first, then new events as they happen", func() {
+				Expect(executeCmdErr).To(MatchError(ContainSubstring("connection lost")))
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"audit.app.create"},
+					[]string{"audit.app.update"},
+					[]string{"audit.app.restage"},
+				))
+				Expect(strings.Count(strings.Join(ui.Outputs(), "\n"), "audit.app.update")).To(Equal(1))
+				Expect(strings.Count(strings.Join(ui.Outputs(), "\n"), "audit.app.restage")).To(Equal(1))
+			})
+
+			It("asks for the events since the most recent one", func() {
+				Expect(eventsRepo.ListEventsArgsForCall(0)).To(Equal(appevents.Query{
+					ActeeGUID: "my-app-guid",
+					Since:     timestamp,
+				}))
+				Expect(eventsRepo.ListEventsArgsForCall(1).Since).To(Equal(laterTimestamp))
+			})
+		})
+	})
 })
@@ -1,7 +1,11 @@
 package application
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf/api/logs"
@@ -20,6 +24,7 @@ type Logs struct {
 	logsRepo logs.Repository
 	config   coreconfig.Reader
 	appReq   requirements.ApplicationRequirement
+	filter   logs.Filter
 }
 
 func init() {
@@ -29,12 +34,24 @@ func init() {
 func (cmd *Logs) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["recent"] = &flags.BoolFlag{Name: "recent", Usage: T("Dump recent logs instead of tailing")}
+	fs["filter"] = &flags.StringFlag{Name: "filter", Usage: T("Only show log messages matching a regular expression")}
+	fs["source-type"] = &flags.StringFlag{Name: "source-type", Usage: T("Only show log messages from a source type: app, rtr or stg")}
+	fs["instance"] = &flags.IntFlag{Name: "instance", Usage: T("Only show log messages from an app instance index")}
+	fs["json"] = &flags.BoolFlag{Name: "json", Usage: T("Print each log message as a JSON object")}
+	fs["since"] = &flags.StringFlag{Name: "since", Usage: T("Only show recent log messages since a duration ago (e.g. 1h, 30m) or a time (e.g. 2016-11-02T15:04:05Z)")}
+	fs["until"] = &flags.StringFlag{Name: "until", Usage: T("Only show recent log messages until a duration ago (e.g. 10m) or a time (e.g. 2016-11-02T16:04:05Z)")}
+	fs["lines"] = &flags.IntFlag{Name: "lines", Usage: T("Only show the last number of recent log messages")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "logs",
 		Description: T("Tail or show recent logs for an app"),
 		Usage: []string{
-			T("CF_NAME logs APP_NAME"),
+			T("CF_NAME logs APP_NAME [--recent [--since DURATION|TIME] [--until DURATION|TIME] [--lines NUMBER]] [--filter REGEX] [--source-type app|rtr|stg] [--instance INDEX] [--json]"),
+		},
+		Examples: []string{
+			"CF_NAME logs my-app --filter 'ERROR|WARN'",
+			"CF_NAME logs my-app --recent --source-type rtr --json",
+			"CF_NAME logs my-app --recent --since 1h --until 30m --lines 100",
 		},
 		Flags: fs,
 	}
@@ -46,6 +63,13 @@ func (cmd *Logs) Requirements(requirementsFactory requirements.Factory, fc flags
 		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
 	}
 
+	filter, err := logsFilter(fc, time.Now())
+	if err != nil {
+		cmd.ui.Failed(fmt.Sprintf(T("Incorrect Usage:")+" %s\n\n%s", err.Error(), commandregistry.Commands.CommandUsage("logs")))
+		return nil, fmt.Errorf("Incorrect usage: %s", err.Error())
+	}
+	cmd.filter = filter
+
 	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
 
 	reqs := []requirements.Requirement{
@@ -64,14 +88,99 @@ func (cmd *Logs) SetDependency(deps commandregistry.Dependency, pluginCall bool)
 	return cmd
 }
 
+func logsFilter(fc flags.FlagContext, now time.Time) (logs.Filter, error) {
+	filter := logs.Filter{}
+
+	if !fc.Bool("recent") && (fc.IsSet("since") || fc.IsSet("until") || fc.IsSet("lines")) {
+		return logs.Filter{}, errors.New(T("Flags 'since', 'until' and 'lines' can only be used with 'recent'"))
+	}
+
+	for _, name := range []string{"since", "until"} {
+		if !fc.IsSet(name) {
+			continue
+		}
+
+		t, err := parseLogsTime(fc.String(name), now)
+		if err != nil {
+			return logs.Filter{}, errors.New(T("Value for flag '{{.Flag}}' must be a duration (e.g. 1h) or a time (e.g. 2016-11-02T15:04:05Z)",
+				map[string]interface{}{"Flag": name}))
+		}
+
+		if name == "since" {
+			filter.Since = t
+		} else {
+			filter.Until = t
+		}
+	}
+
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && filter.Until.Before(filter.Since) {
+		return logs.Filter{}, errors.New(T("Value for flag 'until' must not be before the value for flag 'since'"))
+	}
+
+	if fc.IsSet("lines") {
+		if fc.Int("lines") < 1 {
+			return logs.Filter{}, errors.New(T("Value for flag 'lines' must be a positive number"))
+		}
+		filter.Lines = fc.Int("lines")
+	}
+
+	if fc.IsSet("filter") {
+		pattern, err := regexp.Compile(fc.String("filter"))
+		if err != nil {
+			return logs.Filter{}, errors.New(T("Invalid regular expression for flag 'filter': {{.Err}}",
+				map[string]interface{}{"Err": err.Error()}))
+		}
+		filter.Pattern = pattern
+	}
+
+	if fc.IsSet("source-type") {
+		sourceType := strings.ToLower(fc.String("source-type"))
+		if sourceType != "app" && sourceType != "rtr" && sourceType != "stg" {
+			return logs.Filter{}, errors.New(T("Value for flag 'source-type' must be app, rtr or stg"))
+		}
+		filter.SourceType = sourceType
+	}
+
+	if fc.IsSet("instance") {
+		if fc.Int("instance") < 0 {
+			return logs.Filter{}, errors.New(T("Value for flag 'instance' must not be negative"))
+		}
+		filter.SourceInstance = strconv.Itoa(fc.Int("instance"))
+	}
+
+	return filter, nil
+}
+
+// parseLogsTime parses a duration before now, such as 1h, or an RFC 3339
+// time.
+func parseLogsTime(value string, now time.Time) (time.Time, error) {
+	duration, err := time.ParseDuration(value)
+	if err == nil {
+		return now.Add(-duration), nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
 func (cmd *Logs) Execute(c flags.FlagContext) error {
 	app := cmd.appReq.GetApplication()
+	cmd.logsRepo = logs.NewFilteringRepository(cmd.logsRepo, cmd.filter)
+
+	printLog := func(msg logs.Loggable) {
+		cmd.ui.Say("%s", msg.ToLog(time.Local))
+	}
+	if c.Bool("json") {
+		printLog = func(msg logs.Loggable) {
+			envelope, _ := json.Marshal(msg.ToEnvelope())
+			cmd.ui.Say("%s", envelope)
+		}
+	}
 
 	var err error
 	if c.Bool("recent") {
-		err = cmd.recentLogsFor(app)
+		err = cmd.recentLogsFor(app, !c.Bool("json"), printLog)
 	} else {
-		err = cmd.tailLogsFor(app)
+		err = cmd.tailLogsFor(app, !c.Bool("json"), printLog)
 	}
 	if err != nil {
 		return err
@@ -79,13 +188,15 @@ func (cmd *Logs) Execute(c flags.FlagContext) error {
 	return nil
 }
 
-func (cmd *Logs) recentLogsFor(app models.Application) error {
-	cmd.ui.Say(T("Connected, dumping recent logs for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
-		map[string]interface{}{
-			"AppName":   terminal.EntityNameColor(app.Name),
-			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
-			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
-			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+func (cmd *Logs) recentLogsFor(app models.Application, sayConnected bool, printLog func(logs.Loggable)) error {
+	if sayConnected {
+		cmd.ui.Say(T("Connected, dumping recent logs for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
+			map[string]interface{}{
+				"AppName":   terminal.EntityNameColor(app.Name),
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+	}
 
 	messages, err := cmd.logsRepo.RecentLogsFor(app.GUID)
 	if err != nil {
@@ -93,13 +204,16 @@ func (cmd *Logs) recentLogsFor(app models.Application) error {
 	}
 
 	for _, msg := range messages {
-		cmd.ui.Say("%s", msg.ToLog(time.Local))
+		printLog(msg)
 	}
 	return nil
 }
 
-func (cmd *Logs) tailLogsFor(app models.Application) error {
+func (cmd *Logs) tailLogsFor(app models.Application, sayConnected bool, printLog func(logs.Loggable)) error {
 	onConnect := func() {
+		if !sayConnected {
+			return
+		}
 		cmd.ui.Say(T("Connected, tailing logs for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...\n",
 			map[string]interface{}{
 				"AppName":   terminal.EntityNameColor(app.Name),
@@ -119,7 +233,7 @@ func (cmd *Logs) tailLogsFor(app models.Application) error {
 			if !ok {
 				return nil
 			}
-			cmd.ui.Say("%s", msg.ToLog(time.Local))
+			printLog(msg)
 		case err := <-e:
 			return cmd.handleError(err)
 		}
@@ -0,0 +1,162 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/api/builds"
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type LogsBuild struct {
+	ui              terminal.UI
+	config          coreconfig.Reader
+	buildRepo       builds.Repository
+	logsRepo        logs.Repository
+	deploymentActor actors.DeploymentActor
+}
+
+func init() {
+	commandregistry.Register(&LogsBuild{})
+}
+
+func (cmd *LogsBuild) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "logs-build",
+		Description: T("Show the staging logs of a build, following them until staging finishes"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME logs-build %s", T("BUILD_GUID")),
+		},
+		Examples: []string{
+			"CF_NAME logs-build 8d2e1f3a-5b6c-4d7e-9f80-1a2b3c4d5e6f",
+		},
+		TotalArgs: 1,
+	}
+}
+
+func (cmd *LogsBuild) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires BUILD_GUID as an argument\n\n") + commandregistry.Commands.CommandUsage("logs-build"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("logs-build", cf.BuildsMinimumAPIVersion),
+	}
+
+	return reqs, nil
+}
+
+func (cmd *LogsBuild) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.buildRepo = deps.RepoLocator.GetBuildRepository()
+	cmd.logsRepo = deps.RepoLocator.GetLogsRepository()
+	cmd.deploymentActor = deps.DeploymentActor
+	return cmd
+}
+
+type buildResult struct {
+	build models.Build
+	err   error
+}
+
+func (cmd *LogsBuild) Execute(c flags.FlagContext) error {
+	build, err := cmd.buildRepo.GetBuild(c.Args()[0])
+	if err != nil {
+		return err
+	}
+
+	// staging logs go to the log stream of the app the build belongs to
+	logsRepo := logs.NewFilteringRepository(cmd.logsRepo, logs.Filter{SourceType: "stg", Since: build.CreatedAt})
+
+	if build.State == models.BuildStateStaging {
+		build, err = cmd.tailStagingLogs(logsRepo, build)
+	} else {
+		err = cmd.dumpStagingLogs(logsRepo, build)
+	}
+	if err != nil {
+		return err
+	}
+
+	if build.State != models.BuildStateStaged {
+		return errors.New(T("Build {{.BuildGUID}} failed to stage: {{.Error}}", map[string]interface{}{
+			"BuildGUID": build.GUID,
+			"Error":     build.Error,
+		}))
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Droplet guid: {{.DropletGUID}}", map[string]interface{}{"DropletGUID": terminal.EntityNameColor(build.DropletGUID)}))
+	return nil
+}
+
+func (cmd *LogsBuild) dumpStagingLogs(logsRepo logs.Repository, build models.Build) error {
+	cmd.ui.Say(T("Dumping staging logs for build {{.BuildGUID}} as {{.Username}}...\n",
+		map[string]interface{}{
+			"BuildGUID": terminal.EntityNameColor(build.GUID),
+			"Username":  terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	messages, err := logsRepo.RecentLogsFor(build.AppGUID)
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range messages {
+		cmd.ui.Say("%s", msg.ToLog(time.Local))
+	}
+	return nil
+}
+
+// tailStagingLogs prints the staging logs of the build until it finishes
+// staging, and returns the build as it finished.
+func (cmd *LogsBuild) tailStagingLogs(logsRepo logs.Repository, build models.Build) (models.Build, error) {
+	onConnect := func() {
+		cmd.ui.Say(T("Connected, tailing staging logs for build {{.BuildGUID}} as {{.Username}}...\n",
+			map[string]interface{}{
+				"BuildGUID": terminal.EntityNameColor(build.GUID),
+				"Username":  terminal.EntityNameColor(cmd.config.Username()),
+			}))
+	}
+
+	done := make(chan buildResult, 1)
+	go func() {
+		finished, err := cmd.deploymentActor.WaitForBuild(build)
+		done <- buildResult{build: finished, err: err}
+		logsRepo.Close()
+	}()
+
+	c := make(chan logs.Loggable)
+	e := make(chan error)
+
+	go logsRepo.TailLogsFor(build.AppGUID, onConnect, c, e)
+
+	for tailing := true; tailing; {
+		select {
+		case msg, ok := <-c:
+			if !ok {
+				tailing = false
+				continue
+			}
+			cmd.ui.Say("%s", msg.ToLog(time.Local))
+		case err := <-e:
+			return models.Build{}, err
+		}
+	}
+
+	result := <-done
+	return result.build, result.err
+}
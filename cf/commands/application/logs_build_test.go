@@ -0,0 +1,172 @@
+package application_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+	"code.cloudfoundry.org/cli/cf/api/builds/buildsfakes"
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	"code.cloudfoundry.org/cli/cf/api/logs/logsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testlogs "code.cloudfoundry.org/cli/testhelpers/logs"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	"github.com/cloudfoundry/loggregatorlib/logmessage"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("logs-build command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		buildRepo           *buildsfakes.FakeRepository
+		logsRepo            *logsfakes.FakeRepository
+		deploymentActor     *actorsfakes.FakeDeploymentActor
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+		createdAt           time.Time
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = deps.RepoLocator.SetBuildRepository(buildRepo).SetLogsRepository(logsRepo)
+		deps.DeploymentActor = deploymentActor
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("logs-build").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("logs-build", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		buildRepo = new(buildsfakes.FakeRepository)
+		logsRepo = new(logsfakes.FakeRepository)
+		deploymentActor = new(actorsfakes.FakeDeploymentActor)
+		config = testconfig.NewRepositoryWithDefaults()
+		createdAt = time.Now().Add(-time.Minute)
+
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage when not given a build guid", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires BUILD_GUID as an argument"},
+			))
+		})
+
+		It("requires an API with builds", func() {
+			buildRepo.GetBuildReturns(models.Build{}, errors.New("not found"))
+
+			runCommand("build-guid")
+			command, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+			Expect(command).To(Equal("logs-build"))
+			Expect(version).To(Equal(cf.BuildsMinimumAPIVersion))
+		})
+	})
+
+	Context("when the build is staging", func() {
+		BeforeEach(func() {
+			buildRepo.GetBuildReturns(models.Build{GUID: "build-guid", State: models.BuildStateStaging, AppGUID: "app-guid", CreatedAt: createdAt}, nil)
+			deploymentActor.WaitForBuildReturns(models.Build{GUID: "build-guid", State: models.BuildStateStaged, DropletGUID: "droplet-guid"}, nil)
+
+			logsRepo.TailLogsForStub = func(appGUID string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+				onConnect()
+				go func() {
+					logChan <- testlogs.NewLogMessage("Downloading buildpacks", appGUID, "STG", "0", logmessage.LogMessage_OUT, time.Now())
+					logChan <- testlogs.NewLogMessage("GET /", appGUID, "RTR", "0", logmessage.LogMessage_OUT, time.Now())
+					logChan <- testlogs.NewLogMessage("Old staging", appGUID, "STG", "0", logmessage.LogMessage_OUT, createdAt.Add(-time.Hour))
+					close(logChan)
+				}()
+			}
+		})
+
+		It("tails the staging logs of the app until the build finishes", func() {
+			Expect(runCommand("build-guid")).To(BeTrue())
+
+			Expect(buildRepo.GetBuildArgsForCall(0)).To(Equal("build-guid"))
+			appGUID, _, _, _ := logsRepo.TailLogsForArgsForCall(0)
+			Expect(appGUID).To(Equal("app-guid"))
+			Expect(deploymentActor.WaitForBuildArgsForCall(0).GUID).To(Equal("build-guid"))
+			Eventually(logsRepo.CloseCallCount).Should(Equal(1))
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Connected, tailing staging logs for build build-guid as my-user..."},
+				[]string{"Downloading buildpacks"},
+				[]string{"OK"},
+				[]string{"Droplet guid: droplet-guid"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"GET /"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Old staging"}))
+		})
+
+		It("fails when the build fails to stage", func() {
+			deploymentActor.WaitForBuildReturns(models.Build{GUID: "build-guid", State: models.BuildStateFailed, Error: "NoAppDetectedError"}, nil)
+
+			Expect(runCommand("build-guid")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Downloading buildpacks"},
+				[]string{"FAILED"},
+				[]string{"Build build-guid failed to stage: NoAppDetectedError"},
+			))
+		})
+
+		It("fails when the logs cannot be tailed", func() {
+			logsRepo.TailLogsForStub = func(appGUID string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+				errChan <- errors.New("log server unavailable")
+			}
+
+			Expect(runCommand("build-guid")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"log server unavailable"}))
+		})
+	})
+
+	Context("when the build has finished staging", func() {
+		BeforeEach(func() {
+			buildRepo.GetBuildReturns(models.Build{GUID: "build-guid", State: models.BuildStateStaged, AppGUID: "app-guid", DropletGUID: "droplet-guid", CreatedAt: createdAt}, nil)
+			logsRepo.RecentLogsForReturns([]logs.Loggable{
+				testlogs.NewLogMessage("Old staging", "app-guid", "STG", "0", logmessage.LogMessage_OUT, createdAt.Add(-time.Hour)),
+				testlogs.NewLogMessage("Uploading droplet", "app-guid", "STG", "0", logmessage.LogMessage_OUT, createdAt.Add(time.Second)),
+				testlogs.NewLogMessage("GET /", "app-guid", "RTR", "0", logmessage.LogMessage_OUT, createdAt.Add(time.Second)),
+			}, nil)
+		})
+
+		It("dumps the recent staging logs of the build", func() {
+			Expect(runCommand("build-guid")).To(BeTrue())
+
+			Expect(logsRepo.RecentLogsForArgsForCall(0)).To(Equal("app-guid"))
+			Expect(logsRepo.TailLogsForCallCount()).To(BeZero())
+			Expect(deploymentActor.WaitForBuildCallCount()).To(BeZero())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Dumping staging logs for build build-guid as my-user..."},
+				[]string{"Uploading droplet"},
+				[]string{"OK"},
+				[]string{"Droplet guid: droplet-guid"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Old staging"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"GET /"}))
+		})
+	})
+
+	It("fails when the build cannot be found", func() {
+		buildRepo.GetBuildReturns(models.Build{}, errors.New("build not found"))
+
+		Expect(runCommand("build-guid")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"build not found"}))
+	})
+})
@@ -1,6 +1,7 @@
 package application_test
 
 import (
+	"fmt"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf/api/logs"
@@ -75,7 +76,8 @@ var _ = Describe("logs command", func() {
 
 	Context("when logged in", func() {
 		var (
-			app models.Application
+			app         models.Application
+			currentTime time.Time
 		)
 
 		BeforeEach(func() {
@@ -86,7 +88,7 @@ var _ = Describe("logs command", func() {
 			app.Name = "my-app"
 			app.GUID = "my-app-guid"
 
-			currentTime := time.Now()
+			currentTime = time.Now()
 			recentLogs := []logs.Loggable{
 				testlogs.NewLogMessage("Log Line 1", app.GUID, "DEA", "1", logmessage.LogMessage_ERR, currentTime),
 				testlogs.NewLogMessage("Log Line 2", app.GUID, "DEA", "1", logmessage.LogMessage_ERR, currentTime),
@@ -148,6 +150,125 @@ var _ = Describe("logs command", func() {
 			))
 		})
 
+		Context("when filtering", func() {
+			BeforeEach(func() {
+				logsRepo.RecentLogsForReturns([]logs.Loggable{
+					testlogs.NewLogMessage("GET /index 200", app.GUID, "RTR", "0", logmessage.LogMessage_OUT, time.Now()),
+					testlogs.NewLogMessage("ERROR database unreachable", app.GUID, "APP/PROC/WEB", "0", logmessage.LogMessage_ERR, time.Now()),
+					testlogs.NewLogMessage("ERROR disk full", app.GUID, "APP/PROC/WEB", "1", logmessage.LogMessage_ERR, time.Now()),
+				}, nil)
+			})
+
+			It("only shows the messages matching --filter, --source-type and --instance", func() {
+				Expect(runCommand("--recent", "--filter", "^ERROR", "--source-type", "app", "--instance", "1", "my-app")).To(BeTrue())
+
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"ERROR disk full"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"GET /index 200"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"ERROR database unreachable"}))
+			})
+
+			It("filters tailed logs", func() {
+				Expect(runCommand("--filter", "Line", "my-app")).To(BeTrue())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Log Line 1"}))
+			})
+
+			It("fails with usage when the filter is not a valid regular expression", func() {
+				Expect(runCommand("--filter", "(", "my-app")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Incorrect Usage", "Invalid regular expression for flag 'filter'"},
+				))
+			})
+
+			It("fails with usage when the source type is unknown", func() {
+				Expect(runCommand("--source-type", "cell", "my-app")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Incorrect Usage", "Value for flag 'source-type' must be app, rtr or stg"},
+				))
+			})
+
+			It("fails with usage when the instance is negative", func() {
+				Expect(runCommand("--instance", "-1", "my-app")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Incorrect Usage", "Value for flag 'instance' must not be negative"},
+				))
+			})
+		})
+
+		Context("when limiting recent logs", func() {
+			BeforeEach(func() {
+				logsRepo.RecentLogsForReturns([]logs.Loggable{
+					testlogs.NewLogMessage("Two hours ago", app.GUID, "APP/PROC/WEB", "0", logmessage.LogMessage_OUT, time.Now().Add(-2*time.Hour)),
+					testlogs.NewLogMessage("Half an hour ago", app.GUID, "APP/PROC/WEB", "0", logmessage.LogMessage_OUT, time.Now().Add(-30*time.Minute)),
+					testlogs.NewLogMessage("A minute ago", app.GUID, "APP/PROC/WEB", "0", logmessage.LogMessage_OUT, time.Now().Add(-1*time.Minute)),
+				}, nil)
+			})
+
+			It("only shows the messages between --since and --until", func() {
+				Expect(runCommand("--recent", "--since", "1h", "--until", "10m", "my-app")).To(BeTrue())
+
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Half an hour ago"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Two hours ago"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"A minute ago"}))
+			})
+
+			It("accepts times for --since and --until", func() {
+				since := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+				Expect(runCommand("--recent", "--since", since, "my-app")).To(BeTrue())
+
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Half an hour ago"}, []string{"A minute ago"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Two hours ago"}))
+			})
+
+			It("only shows the last --lines messages", func() {
+				Expect(runCommand("--recent", "--lines", "1", "my-app")).To(BeTrue())
+
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"A minute ago"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Half an hour ago"}))
+			})
+
+			It("fails with usage when the flags are used without --recent", func() {
+				Expect(runCommand("--since", "1h", "my-app")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Incorrect Usage", "Flags 'since', 'until' and 'lines' can only be used with 'recent'"},
+				))
+			})
+
+			It("fails with usage when --since is neither a duration nor a time", func() {
+				Expect(runCommand("--recent", "--since", "yesterday", "my-app")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Incorrect Usage", "Value for flag 'since' must be a duration (e.g. 1h) or a time"},
+				))
+			})
+
+			It("fails with usage when --until is before --since", func() {
+				Expect(runCommand("--recent", "--since", "10m", "--until", "1h", "my-app")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Incorrect Usage", "Value for flag 'until' must not be before the value for flag 'since'"},
+				))
+			})
+
+			It("fails with usage when --lines is not positive", func() {
+				Expect(runCommand("--recent", "--lines", "0", "my-app")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Incorrect Usage", "Value for flag 'lines' must be a positive number"},
+				))
+			})
+		})
+
+		It("prints each message as a JSON object with --json", func() {
+			Expect(runCommand("--recent", "--json", "my-app")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(HaveLen(2))
+			Expect(ui.Outputs()[0]).To(MatchJSON(fmt.Sprintf(`{
+				"timestamp": %q,
+				"app_guid": "my-app-guid",
+				"source_type": "DEA",
+				"source_instance": "1",
+				"message_type": "ERR",
+				"message": "Log Line 1"
+			}`, currentTime.UTC().Format(time.RFC3339Nano))))
+		})
+
 		Context("when the loggregator server has an invalid cert", func() {
 			Context("when the skip-ssl-validation flag is not set", func() {
 				It("fails and informs the user about the skip-ssl-validation flag", func() {
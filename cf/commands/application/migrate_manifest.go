@@ -0,0 +1,433 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/utils/generic"
+	"gopkg.in/yaml.v2"
+)
+
+// routeAttributes are the deprecated attributes that routes replaces
+var routeAttributes = []string{"host", "hosts", "domain", "domains", "no-hostname"}
+
+type MigrateManifest struct {
+	ui           terminal.UI
+	manifestRepo manifest.Repository
+}
+
+func init() {
+	commandregistry.Register(&MigrateManifest{})
+}
+
+func (cmd *MigrateManifest) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["o"] = &flags.StringFlag{ShortName: "o", Usage: T("Path to write the migrated manifest to")}
+	fs["d"] = &flags.StringFlag{ShortName: "d", Usage: T("Domain for the routes of apps that have hosts but no domain, usually the default shared domain")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "migrate-manifest",
+		Description: T("Rewrite a manifest without deprecated attributes or inherited manifests"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME migrate-manifest %s -o %s [-d %s]", T("MANIFEST_PATH"), T("NEW_MANIFEST_PATH"), T("DOMAIN")),
+			"\n\n",
+			T("The host, hosts, domain, domains and no-hostname attributes of each app are replaced with the routes they stand for, and the manifests it inherits from are merged into it."),
+			" ",
+			T("Relative app paths are rewritten to point at the same directories from the new manifest."),
+		},
+		Examples: []string{
+			"CF_NAME migrate-manifest manifest.yml -o manifest.yml",
+			"CF_NAME migrate-manifest old.yml -o new.yml -d apps.example.com",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *MigrateManifest) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	usageReq := requirementsFactory.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+		T("Requires the path of a manifest and the -o option"),
+		func() bool {
+			return len(fc.Args()) != 1 || fc.String("o") == ""
+		},
+	)
+
+	reqs := []requirements.Requirement{
+		usageReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *MigrateManifest) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.manifestRepo = deps.ManifestRepo
+	return cmd
+}
+
+func (cmd *MigrateManifest) Execute(c flags.FlagContext) error {
+	m, err := cmd.manifestRepo.ReadManifest(c.Args()[0])
+	if err != nil {
+		return errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+
+	outputPath := c.String("o")
+	cmd.ui.Say(T("Migrating manifest {{.Path}} to {{.OutputPath}}...",
+		map[string]interface{}{
+			"Path":       terminal.EntityNameColor(m.Path),
+			"OutputPath": terminal.EntityNameColor(outputPath),
+		}))
+
+	changes := []string{}
+	for _, inherited := range m.Inherited {
+		changes = append(changes, T("Merged inherited manifest {{.Path}}", map[string]interface{}{"Path": inherited}))
+	}
+
+	migrated, appChanges, err := migrateManifestData(m.Data, c.String("d"))
+	if err != nil {
+		return errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+	changes = append(changes, appChanges...)
+
+	pathChanges, err := rewriteAppPaths(migrated, m.Path, outputPath)
+	if err != nil {
+		return err
+	}
+	changes = append(changes, pathChanges...)
+
+	contents, err := yaml.Marshal(orderedManifestValue(migrated, true))
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(outputPath, append([]byte("---\n"), contents...), 0644)
+	if err != nil {
+		return errors.New(T("Error writing manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(changes) == 0 {
+		cmd.ui.Say(T("Nothing to migrate"))
+		return nil
+	}
+
+	for _, change := range changes {
+		cmd.ui.Say("  - " + change)
+	}
+	return nil
+}
+
+// migrateManifestData returns a copy of the manifest data without deprecated
+// route attributes, along with a description of each change. The attributes
+// at the top level apply to every app, so they are moved into the apps.
+func migrateManifestData(data generic.Map, defaultDomain string) (generic.Map, []string, error) {
+	changes := []string{}
+	globals := data.Except([]interface{}{"applications"})
+	migrated := generic.NewMap()
+
+	generic.Each(globals, func(key, value interface{}) {
+		name := fmt.Sprintf("%v", key)
+		switch {
+		case !manifest.IsTopLevelAttribute(name):
+			changes = append(changes, T("Removed attribute '{{.Name}}', which push does not use; its YAML anchors were expanded where they were used",
+				map[string]interface{}{"Name": name}))
+		case !isRouteAttribute(name):
+			migrated.Set(key, value)
+		}
+	})
+
+	if !data.Has("applications") {
+		appChanges, err := migrateApp(migrated, globals, defaultDomain)
+		if err != nil {
+			return nil, nil, err
+		}
+		return migrated, append(changes, appChanges...), nil
+	}
+
+	appMaps, ok := data.Get("applications").([]interface{})
+	if !ok {
+		return nil, nil, errors.New(T("Expected applications to be a list"))
+	}
+
+	apps := []interface{}{}
+	for _, appData := range appMaps {
+		if !generic.IsMappable(appData) {
+			apps = append(apps, appData)
+			continue
+		}
+
+		app := generic.DeepMerge(generic.NewMap(appData))
+		appChanges, err := migrateApp(app, globals, defaultDomain)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		apps = append(apps, app)
+		changes = append(changes, appChanges...)
+	}
+	migrated.Set("applications", apps)
+
+	return migrated, changes, nil
+}
+
+// rewriteAppPaths rewrites the relative paths in the migrated manifest data,
+// which push resolves against the directory of the manifest, so that they
+// point at the same app directories from the directory of outputPath. A
+// path that cannot be made relative to it is made absolute.
+func rewriteAppPaths(data generic.Map, manifestPath string, outputPath string) ([]string, error) {
+	manifestDir, err := filepath.Abs(filepath.Dir(manifestPath))
+	if err != nil {
+		return nil, err
+	}
+	outputDir, err := filepath.Abs(filepath.Dir(outputPath))
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []string{}
+	rewrite := func(attributes generic.Map) {
+		path, ok := attributes.Get("path").(string)
+		if !ok || path == "" || filepath.IsAbs(path) {
+			return
+		}
+
+		newPath := filepath.Join(manifestDir, path)
+		if relPath, relErr := filepath.Rel(outputDir, newPath); relErr == nil {
+			newPath = relPath
+		}
+		if filepath.Clean(path) == newPath {
+			return
+		}
+
+		attributes.Set("path", newPath)
+		changes = append(changes, T("Rewrote path {{.Path}} as {{.NewPath}}, so it points at the same directory from {{.OutputPath}}",
+			map[string]interface{}{
+				"Path":       path,
+				"NewPath":    newPath,
+				"OutputPath": outputPath,
+			}))
+	}
+
+	rewrite(data)
+	if apps, ok := data.Get("applications").([]interface{}); ok {
+		for _, app := range apps {
+			if appMap, ok := app.(generic.Map); ok {
+				rewrite(appMap)
+			}
+		}
+	}
+
+	return changes, nil
+}
+
+// propertySentinel stands in for the start of a ${property} in the route
+// attributes while they are read, so that properties such as ${random-word}
+// end up in the routes instead of being expanded once by the migration.
+const propertySentinel = "\x00{"
+
+// migrateApp replaces the route attributes of app, along with those it
+// inherits from globals, with routes. It works out the routes the same way
+// push does, and leaves the attributes alone when routes cannot say the
+// same thing.
+func migrateApp(app generic.Map, globals generic.Map, defaultDomain string) ([]string, error) {
+	effective := generic.DeepMerge(globals, app)
+
+	used := []string{}
+	for _, name := range routeAttributes {
+		if effective.Has(name) {
+			used = append(used, name)
+			effective.Set(name, protectProperties(effective.Get(name)))
+		}
+		app.Delete(name)
+	}
+	if len(used) == 0 {
+		return nil, nil
+	}
+
+	apps, err := manifest.Manifest{Data: effective}.Applications()
+	if err != nil {
+		return nil, err
+	}
+	params := apps[0]
+
+	appName := T("(no name)")
+	if params.Name != nil {
+		appName = *params.Name
+	}
+	details := map[string]interface{}{
+		"AppName":    appName,
+		"Attributes": strings.Join(used, ", "),
+	}
+
+	if len(params.Routes) > 0 || params.NoRoute {
+		return []string{T("App {{.AppName}}: removed {{.Attributes}}, which push ignores since the app has routes or no-route", details)}, nil
+	}
+
+	domains := params.Domains
+	if len(domains) == 0 && defaultDomain != "" {
+		domains = []string{defaultDomain}
+	}
+
+	var reason string
+	switch {
+	case len(domains) == 0:
+		reason = T("App {{.AppName}}: kept {{.Attributes}}, since the app has no domain; give the default domain with -d to migrate them", details)
+	case params.IsHostEmpty() && !params.IsNoHostnameTrue() && params.UseRandomRoute:
+		reason = T("App {{.AppName}}: kept {{.Attributes}}, since routes cannot give a random hostname", details)
+	case params.IsHostEmpty() && !params.IsNoHostnameTrue() && params.Name == nil:
+		reason = T("App {{.AppName}}: kept {{.Attributes}}, since the app has no name to use as its hostname", details)
+	}
+	if reason != "" {
+		setRouteAttributes(app, params)
+		return []string{reason}, nil
+	}
+
+	routes := appRoutes(params, domains)
+	manifestRoutes := []interface{}{}
+	for _, route := range routes {
+		manifestRoutes = append(manifestRoutes, map[interface{}]interface{}{"route": route})
+	}
+	app.Set("routes", manifestRoutes)
+
+	details["Routes"] = strings.Join(routes, ", ")
+	return []string{T("App {{.AppName}}: replaced {{.Attributes}} with routes {{.Routes}}", details)}, nil
+}
+
+func protectProperties(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		return strings.Replace(value, "${", propertySentinel, -1)
+	case []interface{}:
+		protected := []interface{}{}
+		for _, item := range value {
+			protected = append(protected, protectProperties(item))
+		}
+		return protected
+	default:
+		return value
+	}
+}
+
+func restoreProperties(values []string) []string {
+	restored := []string{}
+	for _, value := range values {
+		restored = append(restored, strings.Replace(value, propertySentinel, "${", -1))
+	}
+	return restored
+}
+
+// appRoutes returns the routes push maps for the hosts of params on domains.
+func appRoutes(params models.AppParams, domains []string) []string {
+	hosts := params.Hosts
+	switch {
+	case params.IsNoHostnameTrue():
+		hosts = []string{""}
+	case params.IsHostEmpty():
+		hosts = []string{hostNameForString(*params.Name)}
+	}
+
+	routes := []string{}
+	seen := map[string]bool{}
+	for _, domain := range domains {
+		for _, host := range hosts {
+			route := domain
+			if host != "" {
+				route = host + "." + domain
+			}
+			if !seen[route] {
+				routes = append(routes, route)
+				seen[route] = true
+			}
+		}
+	}
+	return restoreProperties(routes)
+}
+
+// setRouteAttributes writes the route attributes app inherited into it, as
+// the top level of the migrated manifest no longer has them.
+func setRouteAttributes(app generic.Map, params models.AppParams) {
+	if len(params.Hosts) > 0 {
+		app.Set("hosts", stringsToInterfaces(restoreProperties(params.Hosts)))
+	}
+	if len(params.Domains) > 0 {
+		app.Set("domains", stringsToInterfaces(restoreProperties(params.Domains)))
+	}
+	if params.IsNoHostnameTrue() {
+		app.Set("no-hostname", true)
+	}
+}
+
+func isRouteAttribute(name string) bool {
+	for _, attribute := range routeAttributes {
+		if attribute == name {
+			return true
+		}
+	}
+	return false
+}
+
+func stringsToInterfaces(values []string) []interface{} {
+	result := []interface{}{}
+	for _, value := range values {
+		result = append(result, value)
+	}
+	return result
+}
+
+// orderedManifestValue converts the maps in value to YAML map slices so the
+// manifest is written in a stable order: the name of an app comes first, then
+// its other attributes in alphabetical order, and the applications come last
+// at the top level.
+func orderedManifestValue(value interface{}, topLevel bool) interface{} {
+	if items, ok := value.([]interface{}); ok {
+		ordered := []interface{}{}
+		for _, item := range items {
+			ordered = append(ordered, orderedManifestValue(item, false))
+		}
+		return ordered
+	}
+
+	if !generic.IsMappable(value) {
+		return value
+	}
+
+	keys := []string{}
+	values := map[string]interface{}{}
+	generic.Each(generic.NewMap(value), func(key, val interface{}) {
+		name := fmt.Sprintf("%v", key)
+		keys = append(keys, name)
+		values[name] = val
+	})
+	sort.Strings(keys)
+
+	first, last := "name", ""
+	if topLevel {
+		first, last = "", "applications"
+	}
+
+	ordered := yaml.MapSlice{}
+	if val, ok := values[first]; ok {
+		ordered = append(ordered, yaml.MapItem{Key: first, Value: orderedManifestValue(val, false)})
+	}
+	for _, key := range keys {
+		if key != first && key != last {
+			ordered = append(ordered, yaml.MapItem{Key: key, Value: orderedManifestValue(values[key], false)})
+		}
+	}
+	if val, ok := values[last]; ok {
+		ordered = append(ordered, yaml.MapItem{Key: last, Value: orderedManifestValue(val, false)})
+	}
+	return ordered
+}
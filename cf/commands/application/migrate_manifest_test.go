@@ -0,0 +1,280 @@
+package application_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/manifest/manifestfakes"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	"code.cloudfoundry.org/cli/utils/generic"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("migrate-manifest command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		manifestRepo        *manifestfakes.FakeRepository
+		deps                commandregistry.Dependency
+		dir                 string
+		outputPath          string
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.ManifestRepo = manifestRepo
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("migrate-manifest").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("migrate-manifest", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	readOutput := func() string {
+		contents, err := ioutil.ReadFile(outputPath)
+		Expect(err).NotTo(HaveOccurred())
+		return string(contents)
+	}
+
+	manifestWithData := func(data map[interface{}]interface{}) *manifest.Manifest {
+		return &manifest.Manifest{
+			Path: "old.yml",
+			Data: generic.NewMap(data),
+		}
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewUsageRequirementReturns(requirements.Passing{})
+		manifestRepo = new(manifestfakes.FakeRepository)
+
+		var err error
+		dir, err = ioutil.TempDir("", "migrate-manifest")
+		Expect(err).NotTo(HaveOccurred())
+		outputPath = filepath.Join(dir, "new.yml")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage without the path of a manifest or -o", func() {
+			requirementsFactory.NewUsageRequirementReturns(requirements.Failing{Message: "Incorrect Usage"})
+			Expect(runCommand()).To(BeFalse())
+		})
+
+		It("does not require the user to be logged in", func() {
+			manifestRepo.ReadManifestReturns(manifestWithData(map[interface{}]interface{}{"name": "app1"}), nil)
+
+			Expect(runCommand("old.yml", "-o", outputPath)).To(BeTrue())
+			Expect(requirementsFactory.NewLoginRequirementCallCount()).To(BeZero())
+		})
+	})
+
+	It("replaces hosts and domains, including those at the top level, with routes", func() {
+		manifestRepo.ReadManifestReturns(manifestWithData(map[interface{}]interface{}{
+			"domain": "example.com",
+			"memory": "256M",
+			"applications": []interface{}{
+				map[interface{}]interface{}{
+					"name":  "app1",
+					"hosts": []interface{}{"app1", "www"},
+				},
+				map[interface{}]interface{}{
+					"name":    "app2",
+					"domains": []interface{}{"example.org"},
+				},
+				map[interface{}]interface{}{
+					"name":        "app3",
+					"no-hostname": true,
+				},
+			},
+		}), nil)
+
+		Expect(runCommand("old.yml", "-o", outputPath)).To(BeTrue())
+		Expect(manifestRepo.ReadManifestArgsForCall(0)).To(Equal("old.yml"))
+
+		Expect(readOutput()).To(Equal(`---
+memory: 256M
+applications:
+- name: app1
+  routes:
+  - route: app1.example.com
+  - route: www.example.com
+- name: app2
+  routes:
+  - route: app2.example.org
+  - route: app2.example.com
+- name: app3
+  routes:
+  - route: example.com
+`))
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Migrating manifest old.yml to " + outputPath + "..."},
+			[]string{"OK"},
+			[]string{"App app1: replaced hosts, domain with routes app1.example.com, www.example.com"},
+			[]string{"App app2: replaced domain, domains with routes app2.example.org, app2.example.com"},
+			[]string{"App app3: replaced domain, no-hostname with routes example.com"},
+		))
+	})
+
+	It("keeps properties such as ${random-word} in the routes", func() {
+		manifestRepo.ReadManifestReturns(manifestWithData(map[interface{}]interface{}{
+			"name":   "app1",
+			"host":   "app1-${random-word}",
+			"domain": "example.com",
+		}), nil)
+
+		Expect(runCommand("old.yml", "-o", outputPath)).To(BeTrue())
+		Expect(readOutput()).To(Equal(`---
+name: app1
+routes:
+- route: app1-${random-word}.example.com
+`))
+	})
+
+	Context("when an app has hosts but no domain", func() {
+		BeforeEach(func() {
+			manifestRepo.ReadManifestReturns(manifestWithData(map[interface{}]interface{}{
+				"host": "shared",
+				"applications": []interface{}{
+					map[interface{}]interface{}{"name": "app1"},
+				},
+			}), nil)
+		})
+
+		It("keeps the attributes in the app and says why", func() {
+			Expect(runCommand("old.yml", "-o", outputPath)).To(BeTrue())
+			Expect(readOutput()).To(Equal(`---
+applications:
+- name: app1
+  hosts:
+  - shared
+`))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"App app1: kept host, since the app has no domain; give the default domain with -d to migrate them"},
+			))
+		})
+
+		It("uses the domain given with -d", func() {
+			Expect(runCommand("old.yml", "-o", outputPath, "-d", "apps.example.com")).To(BeTrue())
+			Expect(readOutput()).To(ContainSubstring("- route: shared.apps.example.com"))
+		})
+	})
+
+	It("removes the attributes that push ignores because the app has routes", func() {
+		manifestRepo.ReadManifestReturns(manifestWithData(map[interface{}]interface{}{
+			"name":   "app1",
+			"host":   "app1",
+			"routes": []interface{}{map[interface{}]interface{}{"route": "app1.example.com"}},
+		}), nil)
+
+		Expect(runCommand("old.yml", "-o", outputPath)).To(BeTrue())
+		Expect(readOutput()).NotTo(ContainSubstring("host"))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"App app1: removed host, which push ignores since the app has routes or no-route"},
+		))
+	})
+
+	It("reports the inherited manifests it merged and drops attributes that only held anchors", func() {
+		m := manifestWithData(map[interface{}]interface{}{
+			"sizes": map[interface{}]interface{}{"large": map[interface{}]interface{}{"memory": "1G"}},
+			"applications": []interface{}{
+				map[interface{}]interface{}{"name": "app1", "memory": "1G"},
+			},
+		})
+		m.Inherited = []string{"base.yml"}
+		manifestRepo.ReadManifestReturns(m, nil)
+
+		Expect(runCommand("old.yml", "-o", outputPath)).To(BeTrue())
+		Expect(readOutput()).To(Equal(`---
+applications:
+- name: app1
+  memory: 1G
+`))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Merged inherited manifest base.yml"},
+			[]string{"Removed attribute 'sizes', which push does not use"},
+		))
+	})
+
+	It("rewrites relative paths so they point at the same directories from the new manifest", func() {
+		m := manifestWithData(map[interface{}]interface{}{
+			"applications": []interface{}{
+				map[interface{}]interface{}{"name": "app1", "path": "../app1"},
+				map[interface{}]interface{}{"name": "app2", "path": "/srv/app2"},
+			},
+		})
+		m.Path = filepath.Join(dir, "config", "old.yml")
+		manifestRepo.ReadManifestReturns(m, nil)
+
+		Expect(runCommand(m.Path, "-o", outputPath)).To(BeTrue())
+		Expect(readOutput()).To(Equal(`---
+applications:
+- name: app1
+  path: app1
+- name: app2
+  path: /srv/app2
+`))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Rewrote path ../app1 as app1, so it points at the same directory from " + outputPath},
+		))
+	})
+
+	It("keeps relative paths when the new manifest is in the same directory", func() {
+		m := manifestWithData(map[interface{}]interface{}{
+			"path": "app",
+			"applications": []interface{}{
+				map[interface{}]interface{}{"name": "app1", "path": "./app1"},
+			},
+		})
+		m.Path = filepath.Join(dir, "old.yml")
+		manifestRepo.ReadManifestReturns(m, nil)
+
+		Expect(runCommand(m.Path, "-o", outputPath)).To(BeTrue())
+		Expect(readOutput()).To(Equal(`---
+path: app
+applications:
+- name: app1
+  path: ./app1
+`))
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"Nothing to migrate"}))
+	})
+
+	It("says when there is nothing to migrate", func() {
+		manifestRepo.ReadManifestReturns(manifestWithData(map[interface{}]interface{}{
+			"applications": []interface{}{
+				map[interface{}]interface{}{"name": "app1"},
+			},
+		}), nil)
+
+		Expect(runCommand("old.yml", "-o", outputPath)).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"Nothing to migrate"}))
+		Expect(readOutput()).To(Equal("---\napplications:\n- name: app1\n"))
+	})
+
+	It("fails when the manifest cannot be read", func() {
+		manifestRepo.ReadManifestReturns(manifest.NewEmptyManifest(), errors.New("read-error"))
+
+		Expect(runCommand("old.yml", "-o", outputPath)).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Error reading manifest file"},
+			[]string{"read-error"},
+		))
+		_, err := os.Stat(outputPath)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,97 @@
+package application
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api/packages"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type Packages struct {
+	ui          terminal.UI
+	config      coreconfig.Reader
+	packageRepo packages.Repository
+	appReq      requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&Packages{})
+}
+
+func (cmd *Packages) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "packages",
+		Description: T("List the packages of an app, newest first"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME packages %s", T("APP_NAME")),
+		},
+	}
+}
+
+func (cmd *Packages) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("packages"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("packages", cf.PackagesMinimumAPIVersion),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *Packages) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.packageRepo = deps.RepoLocator.GetPackageRepository()
+	return cmd
+}
+
+func (cmd *Packages) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say(T("Getting packages for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	pkgs, err := cmd.packageRepo.ListPackages(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(pkgs) == 0 {
+		cmd.ui.Say(T("No packages found"))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{T("guid"), T("type"), T("state"), T("created")})
+	for _, pkg := range pkgs {
+		table.Add(
+			pkg.GUID,
+			pkg.Type,
+			pkg.State,
+			pkg.CreatedAt.Local().Format("2006-01-02T15:04:05.00-0700"),
+		)
+	}
+
+	return table.Print()
+}
@@ -0,0 +1,97 @@
+package application_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/packages/packagesfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("packages command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		packageRepo         *packagesfakes.FakeRepository
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = api.RepositoryLocator{}.SetPackageRepository(packageRepo)
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("packages").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("packages", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		packageRepo = new(packagesfakes.FakeRepository)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+	})
+
+	It("fails with usage when not given an app name", func() {
+		runCommand()
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Requires an argument"},
+		))
+	})
+
+	It("requires an API with v3 packages", func() {
+		runCommand("my-app")
+		command, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+		Expect(command).To(Equal("packages"))
+		Expect(version).To(Equal(cf.PackagesMinimumAPIVersion))
+	})
+
+	It("lists the packages of the app", func() {
+		packageRepo.ListPackagesReturns([]models.Package{
+			{GUID: "package-2-guid", Type: "bits", State: "READY", CreatedAt: time.Date(2016, 11, 2, 10, 0, 0, 0, time.UTC)},
+			{GUID: "package-1-guid", Type: "bits", State: "FAILED", CreatedAt: time.Date(2016, 11, 1, 10, 0, 0, 0, time.UTC)},
+		}, nil)
+
+		Expect(runCommand("my-app")).To(BeTrue())
+		Expect(packageRepo.ListPackagesArgsForCall(0)).To(Equal("my-app-guid"))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Getting packages for app my-app in org my-org / space my-space as my-user..."},
+			[]string{"OK"},
+			[]string{"guid", "type", "state", "created"},
+			[]string{"package-2-guid", "bits", "READY"},
+			[]string{"package-1-guid", "bits", "FAILED"},
+		))
+	})
+
+	It("says when the app has no packages", func() {
+		packageRepo.ListPackagesReturns([]models.Package{}, nil)
+
+		Expect(runCommand("my-app")).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"No packages found"}))
+	})
+})
@@ -1,12 +1,16 @@
 package application
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
@@ -16,6 +20,9 @@ import (
 	"code.cloudfoundry.org/cli/cf/api"
 	"code.cloudfoundry.org/cli/cf/api/applications"
 	"code.cloudfoundry.org/cli/cf/api/authentication"
+	"code.cloudfoundry.org/cli/cf/api/metadata"
+	"code.cloudfoundry.org/cli/cf/api/processes"
+	"code.cloudfoundry.org/cli/cf/api/sidecars"
 	"code.cloudfoundry.org/cli/cf/api/stacks"
 	"code.cloudfoundry.org/cli/cf/appfiles"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
@@ -23,31 +30,50 @@ import (
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/errors"
 	"code.cloudfoundry.org/cli/cf/formatters"
+	"code.cloudfoundry.org/cli/cf/hooks"
 	"code.cloudfoundry.org/cli/cf/manifest"
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/cf/uihelpers"
+	"code.cloudfoundry.org/cli/plugin/models"
 	"code.cloudfoundry.org/cli/utils/words/generator"
 )
 
 type Push struct {
-	ui            terminal.UI
-	config        coreconfig.Reader
-	manifestRepo  manifest.Repository
-	appStarter    Starter
-	appStopper    Stopper
-	serviceBinder service.Binder
-	appRepo       applications.Repository
-	domainRepo    api.DomainRepository
-	routeRepo     api.RouteRepository
-	serviceRepo   api.ServiceRepository
-	stackRepo     stacks.StackRepository
-	authRepo      authentication.Repository
-	wordGenerator generator.WordGenerator
-	actor         actors.PushActor
-	routeActor    actors.RouteActor
-	zipper        appfiles.Zipper
-	appfiles      appfiles.AppFiles
+	ui               terminal.UI
+	config           coreconfig.Reader
+	manifestRepo     manifest.Repository
+	appStarter       Starter
+	appStopper       Stopper
+	serviceBinder    service.Binder
+	appRepo          applications.Repository
+	domainRepo       api.DomainRepository
+	routeRepo        api.RouteRepository
+	serviceRepo      api.ServiceRepository
+	stackRepo        stacks.StackRepository
+	authRepo         authentication.Repository
+	sidecarRepo      sidecars.Repository
+	processRepo      processes.Repository
+	metadataRepo     metadata.Repository
+	wordGenerator    generator.WordGenerator
+	actor            actors.PushActor
+	routeActor       actors.RouteActor
+	deploymentActor  actors.DeploymentActor
+	zipper           appfiles.Zipper
+	appfiles         appfiles.AppFiles
+	hashCache        appfiles.HashCache
+	hookRunner       hooks.Runner
+	pluginPushedApps *[]plugin_models.PushAppModel
+	pluginCall       bool
+	useRouteActor    bool
+	preserveSymlinks bool
+	useHashCache     bool
+
+	// deps is kept to set the command up again with a silent UI when
+	// --output json is given
+	deps   commandregistry.Dependency
+	output uihelpers.PushOutput
 }
 
 func init() {
@@ -56,25 +82,48 @@ func init() {
 
 func (cmd *Push) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
-	fs["b"] = &flags.StringFlag{ShortName: "b", Usage: T("Custom buildpack by name (e.g. my-buildpack) or Git URL (e.g. 'https://github.com/cloudfoundry/java-buildpack.git') or Git URL with a branch or tag (e.g. 'https://github.com/cloudfoundry/java-buildpack.git#v3.3.0' for 'v3.3.0' tag). To use built-in buildpacks only, specify 'default' or 'null'")}
+	fs["b"] = &flags.StringSliceFlag{ShortName: "b", Usage: T("Custom buildpack by name (e.g. my-buildpack) or Git URL (e.g. 'https://github.com/cloudfoundry/java-buildpack.git') or Git URL with a branch or tag (e.g. 'https://github.com/cloudfoundry/java-buildpack.git#v3.3.0' for 'v3.3.0' tag). To use built-in buildpacks only, specify 'default' or 'null'. Specify multiple times to use multiple buildpacks, the last one being the final buildpack")}
 	fs["c"] = &flags.StringFlag{ShortName: "c", Usage: T("Startup command, set to null to reset to default start command")}
 	fs["d"] = &flags.StringFlag{ShortName: "d", Usage: T("Domain (e.g. example.com)")}
 	fs["f"] = &flags.StringFlag{ShortName: "f", Usage: T("Path to manifest")}
 	fs["i"] = &flags.IntFlag{ShortName: "i", Usage: T("Number of instances")}
 	fs["k"] = &flags.StringFlag{ShortName: "k", Usage: T("Disk limit (e.g. 256M, 1024M, 1G)")}
 	fs["m"] = &flags.StringFlag{ShortName: "m", Usage: T("Memory limit (e.g. 256M, 1024M, 1G)")}
+	fs["instance-steps"] = &flags.StringFlag{Name: "instance-steps", Usage: T("Comma separated percentages of instances a canary deployment replaces, pausing after each step (e.g. '1,10,50,100')")}
+	fs["max-in-flight"] = &flags.IntFlag{Name: "max-in-flight", Usage: T("Number of instances a deployment replaces at the same time (Default: 1)")}
+	fs["lifecycle"] = &flags.StringFlag{Name: "lifecycle", Usage: T("Lifecycle to stage the app with: 'buildpack' (the default) or 'cnb' for Cloud Native Buildpacks. Credentials for private buildpack registries are read as JSON from environment variable CNB_CREDENTIALS (e.g. '{\"registry.example.com\": {\"username\": \"user\", \"password\": \"pass\"}}')")}
 	fs["hostname"] = &flags.StringFlag{Name: "hostname", ShortName: "n", Usage: T("Hostname (e.g. my-subdomain)")}
 	fs["p"] = &flags.StringFlag{ShortName: "p", Usage: T("Path to app directory or to a zip file of the contents of the app directory")}
-	fs["s"] = &flags.StringFlag{ShortName: "s", Usage: T("Stack to use (a stack is a pre-built file system, including an operating system, that can run apps)")}
+	fs["stack"] = &flags.StringFlag{Name: "stack", ShortName: "s", Usage: T("Stack to use (a stack is a pre-built file system, including an operating system, that can run apps)")}
 	fs["t"] = &flags.StringFlag{ShortName: "t", Usage: T("Maximum time (in seconds) for CLI to wait for application start, other server side timeouts may apply")}
 	fs["docker-image"] = &flags.StringFlag{Name: "docker-image", ShortName: "o", Usage: T("Docker-image to be used (e.g. user/docker-image-name)")}
-	fs["health-check-type"] = &flags.StringFlag{Name: "health-check-type", ShortName: "u", Usage: T("Application health check type (e.g. 'port' or 'none')")}
+	fs["docker-username"] = &flags.StringFlag{Name: "docker-username", Usage: T("Repository username; used with password from environment variable CF_DOCKER_PASSWORD")}
+	fs["dry-run"] = &flags.BoolFlag{Name: "dry-run", Usage: T("Show the files, routes, environment variables and services the push would change, without changing anything")}
+	fs["health-check-type"] = &flags.StringFlag{Name: "health-check-type", ShortName: "u", Usage: T("Application health check type: 'port', 'process', 'http' or 'none'")}
+	fs["health-check-http-endpoint"] = &flags.StringFlag{Name: "health-check-http-endpoint", Usage: T("Path the 'http' health check of the web process requests (e.g. '/health')")}
+	fs["health-check-invocation-timeout"] = &flags.IntFlag{Name: "health-check-invocation-timeout", Usage: T("Time (in seconds) each health check of the web process may take before it fails")}
+	fs["readiness-health-check-type"] = &flags.StringFlag{Name: "readiness-health-check-type", Usage: T("Readiness check type of the web process, which decides whether its instances get traffic: 'port', 'process' or 'http'")}
+	fs["readiness-health-check-http-endpoint"] = &flags.StringFlag{Name: "readiness-health-check-http-endpoint", Usage: T("Path the 'http' readiness check of the web process requests (e.g. '/ready')")}
+	fs["readiness-health-check-interval"] = &flags.IntFlag{Name: "readiness-health-check-interval", Usage: T("Time (in seconds) between readiness checks of the web process")}
 	fs["no-hostname"] = &flags.BoolFlag{Name: "no-hostname", Usage: T("Map the root domain to this app")}
+	fs["lenient"] = &flags.BoolFlag{Name: "lenient", Usage: T("Warn about manifest attributes that push does not know instead of failing")}
 	fs["no-manifest"] = &flags.BoolFlag{Name: "no-manifest", Usage: T("Ignore manifest file")}
 	fs["no-route"] = &flags.BoolFlag{Name: "no-route", Usage: T("Do not map a route to this app and remove routes from previous pushes of this app")}
+	fs["no-hash-cache"] = &flags.BoolFlag{Name: "no-hash-cache", Usage: T("Hash every app file again instead of reusing the digests of files that have not changed since the last push")}
 	fs["no-start"] = &flags.BoolFlag{Name: "no-start", Usage: T("Do not start an app after pushing")}
+	fs["output"] = &flags.StringFlag{Name: "output", Usage: T("Output format: 'text' (the default) or 'json', which prints one JSON event per line instead of the usual output")}
+	fs["pre-push-script"] = &flags.StringFlag{Name: "pre-push-script", Usage: T("Command to run on this machine in the app directory before the app is created or updated (e.g. 'npm run build'); the push stops if it fails")}
+	fs["post-push-script"] = &flags.StringFlag{Name: "post-push-script", Usage: T("Command to run on this machine in the app directory once the app has been pushed and started (e.g. './smoke-test.sh')")}
+	fs["preserve-symlinks"] = &flags.BoolFlag{Name: "preserve-symlinks", Usage: T("Upload symbolic links in the app directory as links instead of leaving them out. Links must point to a file or directory inside the app directory")}
+	fs["parallel"] = &flags.IntFlag{Name: "parallel", Usage: T("Number of apps from the manifest to create, upload and bind at the same time. The apps are still started one at a time")}
 	fs["random-route"] = &flags.BoolFlag{Name: "random-route", Usage: T("Create a random route for this app")}
+	fs["random-route-strategy"] = &flags.StringFlag{Name: "random-route-strategy", Usage: T("How to generate the hostname of a random route: 'word' (adjective-noun, the default), 'uuid' or 'timestamp'")}
+	fs["retries"] = &flags.IntFlag{Name: "retries", Usage: T("Number of times to retry a request, such as an upload, when the API is temporarily unavailable (Default: 3)")}
 	fs["route-path"] = &flags.StringFlag{Name: "route-path", Usage: T("Path for the route")}
+	fs["strategy"] = &flags.StringFlag{Name: "strategy", Usage: T("Deployment strategy for an app that is already running: 'rolling' replaces its instances a few at a time, 'canary' replaces a few first and pauses until the deployment is continued")}
+	fs["wait"] = &flags.BoolFlag{Name: "wait", Usage: T("Continue a canary deployment after each step without asking")}
+	fs["staging-timeout"] = &flags.IntFlag{Name: "staging-timeout", Usage: T("Maximum time (in seconds) for CLI to wait for staging to make progress; the wait starts again whenever staging logs a line")}
+	fs["startup-timeout"] = &flags.IntFlag{Name: "startup-timeout", Usage: T("Maximum time (in seconds) for CLI to wait for an instance to run once staging has finished, without changing the health check timeout of the app")}
 	// Hidden:true to hide app-ports for release #117189491
 	fs["app-ports"] = &flags.StringFlag{Name: "app-ports", Usage: T("Comma delimited list of ports the application may listen on"), Hidden: true}
 
@@ -90,7 +139,8 @@ func (cmd *Push) MetaData() commandregistry.CommandMetadata {
 			fmt.Sprintf("[-c %s] ", T("COMMAND")),
 			fmt.Sprintf("[-d %s] ", T("DOMAIN")),
 			fmt.Sprintf("[-f %s] ", T("MANIFEST_PATH")),
-			fmt.Sprintf("[--docker-image %s]", T("DOCKER_IMAGE")),
+			fmt.Sprintf("[--docker-image %s] ", T("DOCKER_IMAGE")),
+			fmt.Sprintf("[--docker-username %s]", T("USERNAME")),
 			"\n   ",
 			fmt.Sprintf("[-i %s] ", T("NUM_INSTANCES")),
 			fmt.Sprintf("[-k %s] ", T("DISK")),
@@ -101,15 +151,38 @@ func (cmd *Push) MetaData() commandregistry.CommandMetadata {
 			fmt.Sprintf("[-t %s] ", T("TIMEOUT")),
 			fmt.Sprintf("[-u %s] ", T("HEALTH_CHECK_TYPE")),
 			fmt.Sprintf("[--route-path %s] ", T("ROUTE_PATH")),
+			fmt.Sprintf("[--lifecycle %s] ", T("LIFECYCLE")),
+			"\n   ",
+			fmt.Sprintf("[--health-check-http-endpoint %s] ", T("ENDPOINT")),
+			fmt.Sprintf("[--health-check-invocation-timeout %s] ", T("TIMEOUT")),
+			"\n   ",
+			fmt.Sprintf("[--readiness-health-check-type %s] ", T("READINESS_HEALTH_CHECK_TYPE")),
+			fmt.Sprintf("[--readiness-health-check-http-endpoint %s] ", T("ENDPOINT")),
+			fmt.Sprintf("[--readiness-health-check-interval %s] ", T("INTERVAL")),
 			"\n   ",
 			// Commented to hide app-ports for release #117189491
 			// fmt.Sprintf("[--app-ports %s] ", T("APP_PORTS")),
-			"[--no-hostname] [--no-manifest] [--no-route] [--no-start] [--random-route]\n",
+			fmt.Sprintf("[--strategy %s] ", T("STRATEGY")),
+			fmt.Sprintf("[--max-in-flight %s] ", T("NUM")),
+			fmt.Sprintf("[--instance-steps %s] ", T("PERCENTAGES")),
+			"[--wait]",
+			"\n   ",
+			"[--no-hostname] [--no-manifest] [--lenient] [--no-route] [--no-start] [--random-route] [--dry-run]",
+			"\n   ",
+			fmt.Sprintf("[--preserve-symlinks] [--no-hash-cache] [--parallel %s] ", T("NUM_APPS")),
+			fmt.Sprintf("[--random-route-strategy %s] ", T("STRATEGY")),
+			fmt.Sprintf("[--staging-timeout %s] ", T("TIMEOUT")),
+			fmt.Sprintf("[--startup-timeout %s] ", T("TIMEOUT")),
+			fmt.Sprintf("[--retries %s] ", T("NUM_RETRIES")),
+			fmt.Sprintf("[--output %s] ", T("FORMAT")),
+			"\n   ",
+			fmt.Sprintf("[--pre-push-script %s] ", T("COMMAND")),
+			fmt.Sprintf("[--post-push-script %s]\n", T("COMMAND")),
 			"\n   ",
 			T("Push multiple apps with a manifest"),
 			":\n   ",
 			"CF_NAME push ",
-			fmt.Sprintf("[-f %s] ", T("MANIFEST_PATH")),
+			fmt.Sprintf("[-f %s] [--lenient]", T("MANIFEST_PATH")),
 		},
 		Flags: fs,
 	}
@@ -134,6 +207,25 @@ func (cmd *Push) Requirements(requirementsFactory requirements.Factory, fc flags
 		reqs = append(reqs, requirementsFactory.NewMinAPIVersionRequirement("Option '--app-ports'", cf.MultipleAppPortsMinimumAPIVersion))
 	}
 
+	if fc.String("docker-username") != "" {
+		reqs = append(reqs, requirementsFactory.NewMinAPIVersionRequirement("Option '--docker-username'", cf.DockerCredentialsMinimumAPIVersion))
+	}
+
+	if len(fc.StringSlice("b")) > 1 {
+		reqs = append(reqs, requirementsFactory.NewMinAPIVersionRequirement("Multiple '-b' options", cf.MultipleBuildpacksMinimumAPIVersion))
+	}
+
+	if fc.String("lifecycle") == models.AppLifecycleCNB {
+		reqs = append(reqs, requirementsFactory.NewMinAPIVersionRequirement("Option '--lifecycle cnb'", cf.CNBLifecycleMinimumAPIVersion))
+	}
+
+	switch fc.String("strategy") {
+	case models.DeploymentStrategyRolling:
+		reqs = append(reqs, requirementsFactory.NewMinAPIVersionRequirement("Option '--strategy rolling'", cf.DeploymentsMinimumAPIVersion))
+	case models.DeploymentStrategyCanary:
+		reqs = append(reqs, requirementsFactory.NewMinAPIVersionRequirement("Option '--strategy canary'", cf.CanaryDeploymentsMinimumAPIVersion))
+	}
+
 	reqs = append(reqs, []requirements.Requirement{
 		requirementsFactory.NewLoginRequirement(),
 		requirementsFactory.NewTargetedSpaceRequirement(),
@@ -143,6 +235,7 @@ func (cmd *Push) Requirements(requirementsFactory requirements.Factory, fc flags
 }
 
 func (cmd *Push) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.deps = deps
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.manifestRepo = deps.ManifestRepo
@@ -168,152 +261,1040 @@ func (cmd *Push) SetDependency(deps commandregistry.Dependency, pluginCall bool)
 	cmd.serviceRepo = deps.RepoLocator.GetServiceRepository()
 	cmd.stackRepo = deps.RepoLocator.GetStackRepository()
 	cmd.authRepo = deps.RepoLocator.GetAuthenticationRepository()
+	cmd.sidecarRepo = deps.RepoLocator.GetSidecarRepository()
+	cmd.processRepo = deps.RepoLocator.GetProcessRepository()
+	cmd.metadataRepo = deps.RepoLocator.GetMetadataRepository()
 	cmd.wordGenerator = deps.WordGenerator
 	cmd.actor = deps.PushActor
 	cmd.routeActor = deps.RouteActor
+	cmd.deploymentActor = deps.DeploymentActor
 	cmd.zipper = deps.AppZipper
 	cmd.appfiles = deps.AppFiles
+	cmd.hashCache = deps.AppFilesHashCache
+	cmd.hookRunner = deps.HookRunner
+
+	cmd.pluginCall = pluginCall
+	if pluginCall {
+		cmd.pluginPushedApps = deps.PluginModels.PushedApps
+	}
+
+	return cmd
+}
+
+func (cmd *Push) Execute(c flags.FlagContext) error {
+	cmd.output = nil
+
+	switch c.String("output") {
+	case "", uihelpers.PushOutputText:
+	case uihelpers.PushOutputJSON:
+		if c.Bool("dry-run") {
+			return errors.New(T("Option '--output json' cannot be used with '--dry-run'"))
+		}
+		cmd.writeOutputAsJSON()
+	default:
+		return errors.New(T("Invalid output format {{.Format}}; it must be 'text' or 'json'",
+			map[string]interface{}{"Format": c.String("output")}))
+	}
+
+	err := cmd.push(c)
+	if err != nil && cmd.output != nil {
+		cmd.output.PushFailed(err)
+	}
+	return err
+}
+
+// writeOutputAsJSON silences the human readable output of the push, and of
+// the commands it runs, and reports its progress as JSON events instead.
+func (cmd *Push) writeOutputAsJSON() {
+	output := uihelpers.NewJSONPushOutput(cmd.ui.Writer())
+
+	deps := cmd.deps
+	deps.UI = terminal.NewSilentUI(cmd.ui)
+	cmd.SetDependency(deps, cmd.pluginCall)
+
+	cmd.routeActor = actors.NewRouteActor(cmd.ui, cmd.routeRepo, cmd.domainRepo)
+	cmd.useRouteActor = true
+	cmd.appStarter.SetStagingStateListener(output.StagingStateChanged)
+	cmd.output = output
+}
+
+// appPushed reports the routes of an app that has been pushed, and adds the
+// app to the apps a plugin that called push is given.
+func (cmd *Push) appPushed(app models.Application) error {
+	if cmd.output == nil && cmd.pluginPushedApps == nil {
+		return nil
+	}
+
+	pushedApp, err := cmd.appRepo.Read(app.Name)
+	if err != nil {
+		return err
+	}
+
+	routes := []string{}
+	for _, route := range pushedApp.Routes {
+		routes = append(routes, route.URL())
+	}
+
+	if cmd.output != nil {
+		cmd.output.AppPushed(app.Name, routes)
+	}
+
+	if cmd.pluginPushedApps != nil {
+		*cmd.pluginPushedApps = append(*cmd.pluginPushedApps, plugin_models.PushAppModel{
+			Name:   pushedApp.Name,
+			Guid:   pushedApp.GUID,
+			State:  pushedApp.State,
+			Routes: routes,
+		})
+	}
+	return nil
+}
+
+const (
+	prePushHook  = "pre-push"
+	postPushHook = "post-push"
+
+	defaultHookTimeout = 10 * time.Minute
+)
+
+// runPostPushHook runs the post-push script of an app that has been pushed
+// and started, reading the app again so the script is told its routes.
+func (cmd *Push) runPostPushHook(app models.Application, appParams models.AppParams) error {
+	if appParams.PostPushScript == nil || *appParams.PostPushScript == "" {
+		return nil
+	}
+
+	pushedApp, err := cmd.appRepo.Read(app.Name)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.runHook(postPushHook, appParams.PostPushScript, appParams, pushedApp)
+	if err != nil {
+		return errors.New(T("Post-push hook of app {{.AppName}} failed: {{.Error}}\nThe app has been pushed.",
+			map[string]interface{}{"AppName": app.Name, "Error": err.Error()}))
+	}
+	return nil
+}
+
+// runHook runs script, if there is one, in the directory of the app. The
+// script learns about the app from CF_APP_NAME, CF_APP_GUID and
+// CF_APP_ROUTES, the last being a comma separated list of its route URLs;
+// CF_APP_GUID is empty when the pre-push script runs before the app is
+// created.
+func (cmd *Push) runHook(hook string, script *string, appParams models.AppParams, app models.Application) error {
+	if script == nil || *script == "" {
+		return nil
+	}
+
+	dir := ""
+	if appParams.Path != nil {
+		dir = *appParams.Path
+		if cmd.zipper.IsZipFile(dir) {
+			dir = filepath.Dir(dir)
+		}
+	}
+
+	timeout := defaultHookTimeout
+	if appParams.HookTimeout != nil {
+		timeout = time.Duration(*appParams.HookTimeout) * time.Second
+	}
+
+	routes := []string{}
+	for _, route := range app.Routes {
+		routes = append(routes, route.URL())
+	}
+
+	cmd.ui.Say(T("Running {{.Hook}} hook of app {{.AppName}}...",
+		map[string]interface{}{
+			"Hook":    hook,
+			"AppName": terminal.EntityNameColor(*appParams.Name),
+		}))
+
+	err := cmd.hookRunner.Run(hooks.Hook{
+		Script: *script,
+		Dir:    dir,
+		Env: []string{
+			"CF_HOOK=" + hook,
+			"CF_APP_NAME=" + *appParams.Name,
+			"CF_APP_GUID=" + app.GUID,
+			"CF_APP_ROUTES=" + strings.Join(routes, ","),
+			"CF_ORG=" + cmd.config.OrganizationFields().Name,
+			"CF_SPACE=" + cmd.config.SpaceFields().Name,
+		},
+		Timeout: timeout,
+		Output:  cmd.ui.Writer(),
+	})
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return nil
+}
+
+func (cmd *Push) push(c flags.FlagContext) error {
+	cmd.preserveSymlinks = c.Bool("preserve-symlinks")
+	cmd.useHashCache = !c.Bool("no-hash-cache")
+
+	appsFromManifest, err := cmd.getAppParamsFromManifest(c)
+	if err != nil {
+		return err
+	}
+
+	for i := range appsFromManifest {
+		if appsFromManifest[i].DockerUsername != nil && appsFromManifest[i].DockerPassword == nil {
+			appsFromManifest[i].DockerPassword = dockerPasswordFromEnv()
+		}
+
+		if isCNB(appsFromManifest[i]) {
+			appsFromManifest[i].CNBCredentials, err = cnbCredentialsFromEnv()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	errs := cmd.actor.ValidateAppParams(appsFromManifest)
+	if len(errs) > 0 {
+		errStr := T("Invalid application configuration") + ":"
+
+		for _, e := range errs {
+			errStr = fmt.Sprintf("%s\n%s", errStr, e.Error())
+		}
+
+		return fmt.Errorf("%s", errStr)
+	}
+
+	for _, app := range appsFromManifest {
+		if app.Sidecars != nil {
+			err = requirements.NewMinAPIVersionRequirement(cmd.config, T("Manifest key 'sidecars'"), cf.SidecarsMinimumAPIVersion).Execute()
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	for _, app := range appsFromManifest {
+		if app.Metadata != nil {
+			err = requirements.NewMinAPIVersionRequirement(cmd.config, T("Manifest key 'metadata'"), cf.MetadataMinimumAPIVersion).Execute()
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	for _, app := range appsFromManifest {
+		if app.Buildpacks != nil {
+			err = requirements.NewMinAPIVersionRequirement(cmd.config, T("Manifest key 'buildpacks'"), cf.MultipleBuildpacksMinimumAPIVersion).Execute()
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	for _, app := range appsFromManifest {
+		if isCNB(app) {
+			err = requirements.NewMinAPIVersionRequirement(cmd.config, T("Manifest key 'lifecycle: cnb'"), cf.CNBLifecycleMinimumAPIVersion).Execute()
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	appFromContext, err := cmd.getAppParamsFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = pushDeploymentParams(c)
+	if err != nil {
+		return err
+	}
+
+	if c.IsSet("staging-timeout") && c.Int("staging-timeout") < 1 {
+		return errors.New(T("Invalid staging timeout {{.Timeout}}; it must be at least 1 second",
+			map[string]interface{}{"Timeout": c.Int("staging-timeout")}))
+	}
+
+	if c.IsSet("startup-timeout") && c.Int("startup-timeout") < 1 {
+		return errors.New(T("Invalid startup timeout {{.Timeout}}; it must be at least 1 second",
+			map[string]interface{}{"Timeout": c.Int("startup-timeout")}))
+	}
+
+	if c.IsSet("retries") {
+		if c.Int("retries") < 0 {
+			return errors.New(T("Invalid number of retries {{.Retries}}; it must not be negative",
+				map[string]interface{}{"Retries": c.Int("retries")}))
+		}
+
+		gateway := cmd.deps.Gateways["cloud-controller"]
+		gateway.SetMaxRetries(c.Int("retries"))
+	}
+
+	err = cmd.ValidateContextAndAppParams(appsFromManifest, appFromContext)
+	if err != nil {
+		return err
+	}
+
+	appSet, err := cmd.createAppSetFromContextAndManifest(appFromContext, appsFromManifest)
+	if err != nil {
+		return err
+	}
+
+	for _, appParams := range appSet {
+		if len(processHealthChecks(appParams)) > 0 || len(processScales(appParams)) > 0 {
+			err = requirements.NewMinAPIVersionRequirement(cmd.config, T("Process health check configuration"), cf.ProcessTypesMinimumAPIVersion).Execute()
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	for _, appParams := range appSet {
+		if hasReadinessHealthChecks(appParams) {
+			err = requirements.NewMinAPIVersionRequirement(cmd.config, T("Readiness health check configuration"), cf.ReadinessHealthChecksMinimumAPIVersion).Execute()
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	_, err = cmd.authRepo.RefreshAuthToken()
+	if err != nil {
+		return err
+	}
+
+	for _, appParams := range appSet {
+		if appParams.Name == nil {
+			return errors.New(T("Error: No name found for app"))
+		}
+	}
+
+	if c.Int("parallel") > 1 && len(appSet) > 1 && !c.Bool("dry-run") {
+		return cmd.pushAppsInParallel(appSet, appFromContext, c, c.Int("parallel"))
+	}
+
+	for _, appParams := range appSet {
+		err = cmd.fetchStackGUID(&appParams)
+		if err != nil {
+			return err
+		}
+
+		if appParams.DockerImage != nil {
+			diego := true
+			appParams.Diego = &diego
+		}
+
+		if c.Bool("dry-run") {
+			err = cmd.planPush(appParams, appFromContext, c)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		app, err := cmd.prepareApp(appParams, appFromContext, c)
+		if err != nil {
+			return err
+		}
+
+		err = cmd.restart(app, appParams, c)
+		if err != nil {
+			return errors.New(
+				T("Error restarting application: {{.Error}}",
+					map[string]interface{}{
+						"Error": err.Error(),
+					}),
+			)
+		}
+
+		err = cmd.appPushed(app)
+		if err != nil {
+			return err
+		}
+
+		err = cmd.runPostPushHook(app, appParams)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prepareApp creates or updates the app, maps its routes, uploads its bits
+// and binds it to its services, leaving only the restart to be done.
+func (cmd *Push) prepareApp(appParams models.AppParams, appFromContext models.AppParams, c flags.FlagContext) (models.Application, error) {
+	var err error
+	var app, existingApp models.Application
+	lifecycle, cnb := cnbLifecycle(&appParams)
+	existingApp, err = cmd.appRepo.Read(*appParams.Name)
+	if err == nil && cnb {
+		lifecycleErr := cmd.checkCNBLifecycle(existingApp)
+		if lifecycleErr != nil {
+			return models.Application{}, lifecycleErr
+		}
+	}
+
+	if _, notFound := err.(*errors.ModelNotFoundError); err == nil || notFound {
+		hookErr := cmd.runHook(prePushHook, appParams.PrePushScript, appParams, existingApp)
+		if hookErr != nil {
+			return models.Application{}, errors.New(T("Pre-push hook of app {{.AppName}} failed: {{.Error}}",
+				map[string]interface{}{"AppName": *appParams.Name, "Error": hookErr.Error()}))
+		}
+	}
+
+	switch err.(type) {
+	case nil:
+		cmd.ui.Say(T("Updating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+			map[string]interface{}{
+				"AppName":   terminal.EntityNameColor(existingApp.Name),
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+		if appParams.EnvironmentVars != nil {
+			for key, val := range existingApp.EnvironmentVars {
+				if _, ok := (*appParams.EnvironmentVars)[key]; !ok {
+					(*appParams.EnvironmentVars)[key] = val
+				}
+			}
+		}
+
+		app, err = cmd.appRepo.Update(existingApp.GUID, appParams)
+		if err != nil {
+			return models.Application{}, err
+		}
+
+		if cnb {
+			err = cmd.appRepo.SetLifecycle(app.GUID, lifecycle)
+			if err != nil {
+				return models.Application{}, err
+			}
+		}
+	case *errors.ModelNotFoundError:
+		spaceGUID := cmd.config.SpaceFields().GUID
+		appParams.SpaceGUID = &spaceGUID
+
+		cmd.ui.Say(T("Creating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+			map[string]interface{}{
+				"AppName":   terminal.EntityNameColor(*appParams.Name),
+				"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+		if cnb {
+			app, err = cmd.createCNBApp(appParams, lifecycle)
+		} else {
+			app, err = cmd.appRepo.Create(appParams)
+		}
+		if err != nil {
+			return models.Application{}, err
+		}
+	default:
+		return models.Application{}, err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if appParams.Buildpacks != nil {
+		cmd.ui.Say(T("Setting buildpacks of app {{.AppName}} to {{.Buildpacks}}...", map[string]interface{}{
+			"AppName":    terminal.EntityNameColor(app.Name),
+			"Buildpacks": terminal.EntityNameColor(strings.Join(appParams.Buildpacks, ", ")),
+		}))
+
+		err = cmd.appRepo.SetLifecycle(app.GUID, models.AppLifecycle{
+			Type:       models.AppLifecycleBuildpack,
+			Buildpacks: appParams.Buildpacks,
+		})
+		if err != nil {
+			return models.Application{}, err
+		}
+
+		cmd.ui.Ok()
+		cmd.ui.Say("")
+	}
+
+	err = cmd.updateRoutes(app, appParams, appFromContext)
+	if err != nil {
+		return models.Application{}, err
+	}
+
+	if appParams.DockerImage == nil {
+		err = cmd.actor.ProcessPath(*appParams.Path, cmd.processPathCallback(*appParams.Path, app))
+		if err != nil {
+			return models.Application{}, errors.New(
+				T("Error processing app files: {{.Error}}",
+					map[string]interface{}{
+						"Error": err.Error(),
+					}),
+			)
+		}
+	}
+
+	if appParams.ServicesToBind != nil {
+		err = cmd.bindAppToServices(appParams.ServicesToBind, appParams.ServiceBindingNames, app)
+		if err != nil {
+			return models.Application{}, err
+		}
+	}
+
+	if appParams.Sidecars != nil {
+		err = cmd.updateSidecars(app, appParams.Sidecars)
+		if err != nil {
+			return models.Application{}, err
+		}
+	}
+
+	if healthChecks := processHealthChecks(appParams); len(healthChecks) > 0 {
+		err = cmd.updateProcessHealthChecks(app, healthChecks)
+		if err != nil {
+			return models.Application{}, err
+		}
+	}
+
+	if scales := processScales(appParams); len(scales) > 0 {
+		err = cmd.scaleProcesses(app, scales)
+		if err != nil {
+			return models.Application{}, err
+		}
+	}
+
+	if appParams.Metadata != nil {
+		err = cmd.updateMetadata(app, *appParams.Metadata)
+		if err != nil {
+			return models.Application{}, err
+		}
+	}
+
+	return app, nil
+}
+
+// createCNBApp creates the app through the v3 apps API, which is the only
+// one that takes the cnb lifecycle, and then sets the rest of its params
+// through the v2 apps API like any other app.
+func (cmd *Push) createCNBApp(appParams models.AppParams, lifecycle models.AppLifecycle) (models.Application, error) {
+	app, err := cmd.appRepo.CreateWithLifecycle(*appParams.Name, *appParams.SpaceGUID, lifecycle)
+	if err != nil {
+		return models.Application{}, err
+	}
+
+	return cmd.appRepo.Update(app.GUID, appParams)
+}
+
+func isCNB(appParams models.AppParams) bool {
+	return appParams.Lifecycle != nil && *appParams.Lifecycle == models.AppLifecycleCNB
+}
+
+// cnbLifecycle returns the lifecycle of an app pushed with the cnb lifecycle.
+// The buildpacks of such an app can only be set through its lifecycle, so
+// they are taken out of the params the v2 apps API gets.
+// checkCNBLifecycle fails unless the existing app is already a cnb app, since
+// the Cloud Controller does not let the lifecycle type of an app change.
+func (cmd *Push) checkCNBLifecycle(app models.Application) error {
+	current, err := cmd.appRepo.GetLifecycle(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	if current.Type == models.AppLifecycleCNB {
+		return nil
+	}
+
+	return errors.New(T("App {{.AppName}} uses the {{.Lifecycle}} lifecycle, which cannot be changed to cnb. Delete the app and push it again, or push it under a new name.",
+		map[string]interface{}{
+			"AppName":   app.Name,
+			"Lifecycle": current.Type,
+		}))
+}
+
+func cnbLifecycle(appParams *models.AppParams) (models.AppLifecycle, bool) {
+	if !isCNB(*appParams) {
+		return models.AppLifecycle{}, false
+	}
+
+	buildpacks := appParams.Buildpacks
+	if appParams.BuildpackURL != nil && *appParams.BuildpackURL != "" {
+		buildpacks = []string{*appParams.BuildpackURL}
+	}
+	appParams.BuildpackURL = nil
+	appParams.Buildpacks = nil
+
+	return models.AppLifecycle{
+		Type:        models.AppLifecycleCNB,
+		Buildpacks:  buildpacks,
+		Credentials: appParams.CNBCredentials,
+	}, true
+}
+
+// processHealthChecks returns the health and readiness checks to set through
+// the processes API: the ones of the web process, when it has settings the v2
+// apps API cannot set, followed by the ones in the 'processes' section of the
+// manifest.
+func processHealthChecks(appParams models.AppParams) []models.ProcessParams {
+	healthChecks := []models.ProcessParams{}
+
+	web := models.ProcessParams{
+		Type:                             "web",
+		ReadinessHealthCheckType:         appParams.ReadinessHealthCheckType,
+		ReadinessHealthCheckHTTPEndpoint: appParams.ReadinessHealthCheckHTTPEndpoint,
+		ReadinessHealthCheckInterval:     appParams.ReadinessHealthCheckInterval,
+	}
+	if appParams.HealthCheckHTTPEndpoint != nil || appParams.HealthCheckInvocationTimeout != nil {
+		web.HealthCheckType = appParams.HealthCheckType
+		web.HealthCheckHTTPEndpoint = appParams.HealthCheckHTTPEndpoint
+		web.HealthCheckInvocationTimeout = appParams.HealthCheckInvocationTimeout
+	}
+	if web.HasHealthCheck() || web.HasReadinessHealthCheck() {
+		healthChecks = append(healthChecks, web)
+	}
+
+	for _, process := range appParams.Processes {
+		if process.HasHealthCheck() || process.HasReadinessHealthCheck() {
+			healthChecks = append(healthChecks, process)
+		}
+	}
+
+	return healthChecks
+}
+
+// processScales returns the processes in the 'processes' section of the
+// manifest that have their instances, memory or disk set.
+func processScales(appParams models.AppParams) []models.ProcessParams {
+	scales := []models.ProcessParams{}
+	for _, process := range appParams.Processes {
+		if process.HasScale() {
+			scales = append(scales, process)
+		}
+	}
+	return scales
+}
+
+func hasReadinessHealthChecks(appParams models.AppParams) bool {
+	for _, process := range processHealthChecks(appParams) {
+		if process.HasReadinessHealthCheck() {
+			return true
+		}
+	}
+	return false
+}
+
+// updateProcessHealthChecks sets the health and readiness checks of the
+// processes of the app, matching them by type. Process types other than web
+// only exist once the app has staged with them, so their checks are skipped
+// with a warning until then.
+func (cmd *Push) updateProcessHealthChecks(app models.Application, healthChecks []models.ProcessParams) error {
+	existingProcesses, err := cmd.processRepo.ListProcesses(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	existingGUIDs := map[string]string{}
+	for _, process := range existingProcesses {
+		existingGUIDs[process.Type] = process.GUID
+	}
+
+	for _, healthCheck := range healthChecks {
+		guid, ok := existingGUIDs[healthCheck.Type]
+		if !ok {
+			cmd.ui.Warn(T("Process {{.ProcessType}} of app {{.AppName}} does not exist yet, so its health check was not set. Push the app again once it has staged.", map[string]interface{}{
+				"ProcessType": healthCheck.Type,
+				"AppName":     app.Name,
+			}))
+			continue
+		}
+
+		if healthCheck.HasHealthCheck() {
+			cmd.ui.Say(T("Updating health check of process {{.ProcessType}} of app {{.AppName}}...", map[string]interface{}{
+				"ProcessType": terminal.EntityNameColor(healthCheck.Type),
+				"AppName":     terminal.EntityNameColor(app.Name),
+			}))
+			_, err = cmd.processRepo.UpdateProcessHealthCheck(guid, healthCheck.HealthCheck())
+			if err != nil {
+				return err
+			}
+		}
+
+		if healthCheck.HasReadinessHealthCheck() {
+			cmd.ui.Say(T("Updating readiness check of process {{.ProcessType}} of app {{.AppName}}...", map[string]interface{}{
+				"ProcessType": terminal.EntityNameColor(healthCheck.Type),
+				"AppName":     terminal.EntityNameColor(app.Name),
+			}))
+			_, err = cmd.processRepo.UpdateProcessReadinessHealthCheck(guid, healthCheck.ReadinessHealthCheck())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return nil
+}
+
+// scaleProcesses sets the instances, memory and disk of the processes of the
+// app, matching them by type. Like their health checks, process types other
+// than web are skipped with a warning until the app has staged with them.
+func (cmd *Push) scaleProcesses(app models.Application, scales []models.ProcessParams) error {
+	existingProcesses, err := cmd.processRepo.ListProcesses(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	existingTypes := map[string]bool{}
+	for _, process := range existingProcesses {
+		existingTypes[process.Type] = true
+	}
+
+	for _, process := range scales {
+		if !existingTypes[process.Type] {
+			cmd.ui.Warn(T("Process {{.ProcessType}} of app {{.AppName}} does not exist yet, so it was not scaled. Push the app again once it has staged.", map[string]interface{}{
+				"ProcessType": process.Type,
+				"AppName":     app.Name,
+			}))
+			continue
+		}
+
+		cmd.ui.Say(T("Scaling process {{.ProcessType}} of app {{.AppName}}...", map[string]interface{}{
+			"ProcessType": terminal.EntityNameColor(process.Type),
+			"AppName":     terminal.EntityNameColor(app.Name),
+		}))
+		_, err = cmd.processRepo.ScaleProcess(app.GUID, process.Type, process.Scale())
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return nil
+}
+
+// updateMetadata sets the labels and annotations of the app from the
+// manifest. Labels and annotations the app has that are not in the manifest
+// are left alone.
+func (cmd *Push) updateMetadata(app models.Application, appMetadata models.Metadata) error {
+	cmd.ui.Say(T("Updating metadata of app {{.AppName}}...", map[string]interface{}{
+		"AppName": terminal.EntityNameColor(app.Name),
+	}))
+
+	update := models.MetadataUpdate{
+		Labels:      map[string]*string{},
+		Annotations: map[string]*string{},
+	}
+	for key, value := range appMetadata.Labels {
+		value := value
+		update.Labels[key] = &value
+	}
+	for key, value := range appMetadata.Annotations {
+		value := value
+		update.Annotations[key] = &value
+	}
+
+	err := cmd.metadataRepo.UpdateMetadata("/v3/apps", app.GUID, update)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return nil
+}
+
+// updateSidecars creates the sidecars in the manifest that the app does not
+// have yet and updates the ones it has, matching them by name. Sidecars the
+// app has that are not in the manifest are left alone.
+func (cmd *Push) updateSidecars(app models.Application, manifestSidecars []models.Sidecar) error {
+	existingSidecars, err := cmd.sidecarRepo.ListSidecars(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	existingGUIDs := map[string]string{}
+	for _, sidecar := range existingSidecars {
+		existingGUIDs[sidecar.Name] = sidecar.GUID
+	}
+
+	for _, sidecar := range manifestSidecars {
+		if guid, ok := existingGUIDs[sidecar.Name]; ok {
+			cmd.ui.Say(T("Updating sidecar {{.SidecarName}} of app {{.AppName}}...", map[string]interface{}{
+				"SidecarName": terminal.EntityNameColor(sidecar.Name),
+				"AppName":     terminal.EntityNameColor(app.Name),
+			}))
+			_, err = cmd.sidecarRepo.UpdateSidecar(guid, sidecar)
+		} else {
+			cmd.ui.Say(T("Creating sidecar {{.SidecarName}} of app {{.AppName}}...", map[string]interface{}{
+				"SidecarName": terminal.EntityNameColor(sidecar.Name),
+				"AppName":     terminal.EntityNameColor(app.Name),
+			}))
+			_, err = cmd.sidecarRepo.CreateSidecar(app.GUID, sidecar)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return nil
+}
+
+// pushAppsInParallel creates or updates, maps routes for, uploads and binds up
+// to parallel apps at the same time, each with its own copy of the command
+// whose output is labelled with the app's name. The apps are only started,
+// one after the other, once all of them have been prepared, since starting an
+// app streams its staging logs and waits on its instances.
+func (cmd *Push) pushAppsInParallel(appSet []models.AppParams, appFromContext models.AppParams, c flags.FlagContext, parallel int) error {
+	apps := make([]models.Application, len(appSet))
+	errs := make([]error, len(appSet))
+
+	var wg sync.WaitGroup
+	work := make(chan int)
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range work {
+				apps[index], errs[index] = cmd.prepareAppWithPrefixedUI(&appSet[index], appFromContext, c)
+			}
+		}()
+	}
+
+	for i := range appSet {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+
+	failures := []string{}
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", *appSet[i].Name, err.Error()))
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New(T("Error pushing apps:\n{{.Errors}}",
+			map[string]interface{}{"Errors": strings.Join(failures, "\n")}))
+	}
+
+	for i, app := range apps {
+		err := cmd.restart(app, appSet[i], c)
+		if err != nil {
+			return errors.New(
+				T("Error restarting application: {{.Error}}",
+					map[string]interface{}{
+						"Error": err.Error(),
+					}),
+			)
+		}
 
-	return cmd
+		err = cmd.appPushed(app)
+		if err != nil {
+			return err
+		}
+
+		err = cmd.runPostPushHook(app, appSet[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func (cmd *Push) Execute(c flags.FlagContext) error {
-	appsFromManifest, err := cmd.getAppParamsFromManifest(c)
+func (cmd *Push) prepareAppWithPrefixedUI(appParams *models.AppParams, appFromContext models.AppParams, c flags.FlagContext) (models.Application, error) {
+	appCmd := *cmd
+	appCmd.ui = terminal.NewPrefixedUI(cmd.ui, *appParams.Name)
+	appCmd.routeActor = actors.NewRouteActor(appCmd.ui, cmd.routeRepo, cmd.domainRepo)
+	appCmd.useRouteActor = true
+
+	err := appCmd.fetchStackGUID(appParams)
 	if err != nil {
-		return err
+		return models.Application{}, err
 	}
 
-	errs := cmd.actor.ValidateAppParams(appsFromManifest)
-	if len(errs) > 0 {
-		errStr := T("Invalid application configuration") + ":"
+	if appParams.DockerImage != nil {
+		diego := true
+		appParams.Diego = &diego
+	}
 
-		for _, e := range errs {
-			errStr = fmt.Sprintf("%s\n%s", errStr, e.Error())
-		}
+	return appCmd.prepareApp(*appParams, appFromContext, c)
+}
 
-		return fmt.Errorf("%s", errStr)
+// planPush prints what pushing appParams would change without creating or
+// updating the app, its routes or its service bindings, and without
+// uploading any bits.
+func (cmd *Push) planPush(appParams models.AppParams, appParamsFromContext models.AppParams, c flags.FlagContext) error {
+	appExists := true
+	app, err := cmd.appRepo.Read(*appParams.Name)
+	switch err.(type) {
+	case nil:
+	case *errors.ModelNotFoundError:
+		appExists = false
+		app = models.Application{}
+		app.Name = *appParams.Name
+	default:
+		return err
 	}
 
-	appFromContext, err := cmd.getAppParamsFromContext(c)
-	if err != nil {
-		return err
+	cmd.ui.Say(T("Planning push of app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}} (dry run)...",
+		map[string]interface{}{
+			"AppName":   terminal.EntityNameColor(app.Name),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+	cmd.ui.Say("")
+
+	if appExists {
+		cmd.ui.Say(T("App {{.AppName}} would be updated", map[string]interface{}{"AppName": terminal.EntityNameColor(app.Name)}))
+	} else {
+		cmd.ui.Say(T("App {{.AppName}} would be created", map[string]interface{}{"AppName": terminal.EntityNameColor(app.Name)}))
 	}
 
-	err = cmd.ValidateContextAndAppParams(appsFromManifest, appFromContext)
+	var diff actors.PushDiff
+	if appParams.DockerImage == nil {
+		err = cmd.actor.ProcessPath(*appParams.Path, func(appDir string) error {
+			localFiles, filesErr := cmd.appfiles.AppFilesInDir(appDir, cmd.preserveSymlinks, cmd.hashCacheFor(*appParams.Path))
+			if filesErr != nil {
+				return filesErr
+			}
+
+			diff, filesErr = cmd.actor.DiffApp(app, appParams, localFiles)
+			return filesErr
+		})
+	} else {
+		diff, err = cmd.actor.DiffApp(app, appParams, nil)
+	}
 	if err != nil {
-		return err
+		return errors.New(
+			T("Error processing app files: {{.Error}}",
+				map[string]interface{}{
+					"Error": err.Error(),
+				}),
+		)
 	}
 
-	appSet, err := cmd.createAppSetFromContextAndManifest(appFromContext, appsFromManifest)
+	routeChanges, err := cmd.planRoutes(app, appParams, appParamsFromContext)
 	if err != nil {
 		return err
 	}
 
-	_, err = cmd.authRepo.RefreshAuthToken()
+	servicesToBind, err := cmd.planServiceBindings(appParams.ServicesToBind, app)
 	if err != nil {
 		return err
 	}
 
-	for _, appParams := range appSet {
-		if appParams.Name == nil {
-			return errors.New(T("Error: No name found for app"))
+	if appParams.DockerImage == nil {
+		cmd.ui.Say("")
+		cmd.ui.Say(T("Files:"))
+		filesToUpload := []string{}
+		for _, file := range diff.FilesToUpload {
+			filesToUpload = append(filesToUpload, file.Path)
 		}
+		cmd.sayPlannedChanges(T("upload"), filesToUpload)
+		cmd.ui.Say(T("  {{.UploadCount}} files to upload, {{.MatchedCount}} files already present",
+			map[string]interface{}{
+				"UploadCount":  len(diff.FilesToUpload),
+				"MatchedCount": len(diff.MatchedFiles),
+			}))
+	}
 
-		err = cmd.fetchStackGUID(&appParams)
-		if err != nil {
-			return err
-		}
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Routes:"))
+	cmd.sayPlannedChanges(T("create"), routeChanges.Created)
+	cmd.sayPlannedChanges(T("bind"), routeChanges.Bound)
+	cmd.sayPlannedChanges(T("unbind"), routeChanges.Unbound)
 
-		if c.IsSet("docker-image") {
-			diego := true
-			appParams.Diego = &diego
-		}
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Environment variables:"))
+	cmd.sayPlannedChanges(T("add"), diff.EnvVarsAdded)
+	cmd.sayPlannedChanges(T("change"), diff.EnvVarsChanged)
 
-		var app, existingApp models.Application
-		existingApp, err = cmd.appRepo.Read(*appParams.Name)
-		switch err.(type) {
-		case nil:
-			cmd.ui.Say(T("Updating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
-				map[string]interface{}{
-					"AppName":   terminal.EntityNameColor(existingApp.Name),
-					"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
-					"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
-					"Username":  terminal.EntityNameColor(cmd.config.Username())}))
-
-			if appParams.EnvironmentVars != nil {
-				for key, val := range existingApp.EnvironmentVars {
-					if _, ok := (*appParams.EnvironmentVars)[key]; !ok {
-						(*appParams.EnvironmentVars)[key] = val
-					}
-				}
-			}
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Services:"))
+	cmd.sayPlannedChanges(T("bind"), servicesToBind)
 
-			app, err = cmd.appRepo.Update(existingApp.GUID, appParams)
-			if err != nil {
-				return err
-			}
-		case *errors.ModelNotFoundError:
-			spaceGUID := cmd.config.SpaceFields().GUID
-			appParams.SpaceGUID = &spaceGUID
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Dry run complete, nothing was changed."))
+	cmd.ui.Say("")
+	return nil
+}
 
-			cmd.ui.Say(T("Creating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
-				map[string]interface{}{
-					"AppName":   terminal.EntityNameColor(*appParams.Name),
-					"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
-					"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
-					"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+func (cmd *Push) planRoutes(app models.Application, appParams models.AppParams, appParamsFromContext models.AppParams) (*actors.RouteChanges, error) {
+	routeActor := cmd.routeActor
+	defer func() {
+		cmd.routeActor = routeActor
+		cmd.useRouteActor = false
+	}()
 
-			app, err = cmd.appRepo.Create(appParams)
-			if err != nil {
-				return err
-			}
-		default:
-			return err
-		}
+	var routeChanges *actors.RouteChanges
+	cmd.routeActor, routeChanges = actors.NewDryRunRouteActor(cmd.routeRepo, cmd.domainRepo, app)
+	cmd.useRouteActor = true
 
-		cmd.ui.Ok()
-		cmd.ui.Say("")
+	err := cmd.updateRoutes(app, appParams, appParamsFromContext)
+	if err != nil {
+		return nil, err
+	}
+
+	return routeChanges, nil
+}
 
-		err = cmd.updateRoutes(app, appParams, appFromContext)
+func (cmd *Push) planServiceBindings(services []string, app models.Application) ([]string, error) {
+	var servicesToBind []string
+	for _, serviceName := range services {
+		serviceInstance, err := cmd.serviceRepo.FindInstanceByName(serviceName)
 		if err != nil {
-			return err
+			return nil, errors.New(T("Could not find service {{.ServiceName}} to bind to {{.AppName}}",
+				map[string]interface{}{"ServiceName": serviceName, "AppName": app.Name}))
 		}
 
-		if c.String("docker-image") == "" {
-			err = cmd.actor.ProcessPath(*appParams.Path, cmd.processPathCallback(*appParams.Path, app))
-			if err != nil {
-				return errors.New(
-					T("Error processing app files: {{.Error}}",
-						map[string]interface{}{
-							"Error": err.Error(),
-						}),
-				)
-			}
+		if !isBoundToApp(serviceInstance, app) {
+			servicesToBind = append(servicesToBind, serviceInstance.Name)
 		}
+	}
+	return servicesToBind, nil
+}
 
-		if appParams.ServicesToBind != nil {
-			err = cmd.bindAppToServices(appParams.ServicesToBind, app)
-			if err != nil {
-				return err
-			}
-		}
+func isBoundToApp(serviceInstance models.ServiceInstance, app models.Application) bool {
+	if app.GUID == "" {
+		return false
+	}
 
-		err = cmd.restart(app, appParams, c)
-		if err != nil {
-			return errors.New(
-				T("Error restarting application: {{.Error}}",
-					map[string]interface{}{
-						"Error": err.Error(),
-					}),
-			)
+	for _, binding := range serviceInstance.ServiceBindings {
+		if binding.AppGUID == app.GUID {
+			return true
 		}
 	}
-	return nil
+	return false
+}
+
+func (cmd *Push) sayPlannedChanges(action string, names []string) {
+	for _, name := range names {
+		cmd.ui.Say("  %s %s", action, terminal.EntityNameColor(name))
+	}
+}
+
+// hashCacheFor returns the cache of app file digests to use for the app at
+// path. Zip files are extracted to a new directory on every push, so there is
+// nothing to reuse for them.
+func (cmd *Push) hashCacheFor(path string) appfiles.HashCache {
+	if !cmd.useHashCache || cmd.zipper.IsZipFile(path) {
+		return nil
+	}
+	return cmd.hashCache
 }
 
 func (cmd *Push) processPathCallback(path string, app models.Application) func(string) error {
 	return func(appDir string) error {
-		localFiles, err := cmd.appfiles.AppFilesInDir(appDir)
+		localFiles, err := cmd.appfiles.AppFilesInDir(appDir, cmd.preserveSymlinks, cmd.hashCacheFor(path))
 		if err != nil {
 			return errors.New(
 				T("Error processing app files in '{{.Path}}': {{.Error}}",
@@ -334,7 +1315,7 @@ func (cmd *Push) processPathCallback(path string, app models.Application) func(s
 		cmd.ui.Say(T("Uploading {{.AppName}}...",
 			map[string]interface{}{"AppName": terminal.EntityNameColor(app.Name)}))
 
-		err = cmd.uploadApp(app.GUID, appDir, path, localFiles)
+		err = cmd.uploadApp(app, appDir, path, localFiles)
 		if err != nil {
 			return errors.New(T("Error uploading application.\n{{.APIErr}}",
 				map[string]interface{}{"APIErr": err.Error()}))
@@ -360,8 +1341,18 @@ func (cmd *Push) updateRoutes(app models.Application, appParams models.AppParams
 			}
 		}
 	case len(appParams.Routes) > 0:
+		mapManifestRoute := cmd.actor.MapManifestRoute
+		if cmd.useRouteActor {
+			mapManifestRoute = cmd.routeActor.FindAndBindRoute
+		}
+
+		routeParams := appParamsFromContext
+		if routeParams.RandomRouteStrategy == nil {
+			routeParams.RandomRouteStrategy = appParams.RandomRouteStrategy
+		}
+
 		for _, manifestRoute := range appParams.Routes {
-			err := cmd.actor.MapManifestRoute(manifestRoute.Route, app, appParamsFromContext)
+			err := mapManifestRoute(manifestRoute.Route, app, routeParams)
 			if err != nil {
 				return err
 			}
@@ -407,6 +1398,7 @@ func (cmd *Push) processDomainsAndBindRoutes(
 		err := cmd.createAndBindRoute(
 			nil,
 			appParams.UseRandomRoute,
+			appParams.RandomRouteStrategy,
 			appParams.UseRandomPort,
 			app,
 			appParams.IsNoHostnameTrue(),
@@ -421,6 +1413,7 @@ func (cmd *Push) processDomainsAndBindRoutes(
 			err := cmd.createAndBindRoute(
 				&host,
 				appParams.UseRandomRoute,
+				appParams.RandomRouteStrategy,
 				appParams.UseRandomPort,
 				app,
 				appParams.IsNoHostnameTrue(),
@@ -438,6 +1431,7 @@ func (cmd *Push) processDomainsAndBindRoutes(
 func (cmd *Push) createAndBindRoute(
 	host *string,
 	UseRandomRoute bool,
+	randomRouteStrategy *string,
 	UseRandomPort bool,
 	app models.Application,
 	noHostName bool,
@@ -452,7 +1446,7 @@ func (cmd *Push) createAndBindRoute(
 		case UseRandomPort:
 			//do nothing
 		case UseRandomRoute:
-			hostname = hostNameForString(app.Name) + "-" + cmd.wordGenerator.Babble()
+			hostname = hostNameForString(app.Name) + "-" + cmd.randomRouteGenerator(randomRouteStrategy).Babble()
 		default:
 			hostname = hostNameForString(app.Name)
 		}
@@ -481,6 +1475,20 @@ func hostNameForString(name string) string {
 	return name
 }
 
+// randomRouteGenerator returns the generator for the hostnames of random
+// routes. Strategies were checked when the manifest and flags were read.
+func (cmd *Push) randomRouteGenerator(strategy *string) generator.WordGenerator {
+	if strategy == nil || *strategy == generator.WordStrategy {
+		return cmd.wordGenerator
+	}
+
+	wordGenerator, err := generator.NewGenerator(*strategy)
+	if err != nil {
+		return cmd.wordGenerator
+	}
+	return wordGenerator
+}
+
 func (cmd *Push) findDomain(domainName *string) (models.DomainFields, error) {
 	domain, err := cmd.domainRepo.FirstOrDefault(cmd.config.OrganizationFields().GUID, domainName)
 	if err != nil {
@@ -490,7 +1498,7 @@ func (cmd *Push) findDomain(domainName *string) (models.DomainFields, error) {
 	return domain, nil
 }
 
-func (cmd *Push) bindAppToServices(services []string, app models.Application) error {
+func (cmd *Push) bindAppToServices(services []string, bindingNames map[string]string, app models.Application) error {
 	for _, serviceName := range services {
 		serviceInstance, err := cmd.serviceRepo.FindInstanceByName(serviceName)
 
@@ -507,7 +1515,7 @@ func (cmd *Push) bindAppToServices(services []string, app models.Application) er
 				"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
 				"Username":    terminal.EntityNameColor(cmd.config.Username())}))
 
-		err = cmd.serviceBinder.BindApplication(app, serviceInstance, nil)
+		err = cmd.serviceBinder.BindApplication(app, serviceInstance, bindingNames[serviceName], nil)
 
 		switch httpErr := err.(type) {
 		case errors.HTTPError:
@@ -546,6 +1554,12 @@ func (cmd *Push) fetchStackGUID(appParams *models.AppParams) error {
 }
 
 func (cmd *Push) restart(app models.Application, params models.AppParams, c flags.FlagContext) error {
+	deployment, _ := pushDeploymentParams(c)
+	if deployment != nil && app.State != T("stopped") {
+		cmd.ui.Say("")
+		return cmd.deployApp(app, *deployment, c.Bool("wait"))
+	}
+
 	if app.State != T("stopped") {
 		cmd.ui.Say("")
 		app, _ = cmd.appStopper.ApplicationStop(app, cmd.config.OrganizationFields().Name, cmd.config.SpaceFields().Name)
@@ -561,6 +1575,14 @@ func (cmd *Push) restart(app models.Application, params models.AppParams, c flag
 		cmd.appStarter.SetStartTimeoutInSeconds(*params.HealthCheckTimeout)
 	}
 
+	if c.IsSet("startup-timeout") {
+		cmd.appStarter.SetStartTimeoutInSeconds(c.Int("startup-timeout"))
+	}
+
+	if c.IsSet("staging-timeout") {
+		cmd.appStarter.SetStagingTimeoutInSeconds(c.Int("staging-timeout"))
+	}
+
 	_, err := cmd.appStarter.ApplicationStart(app, cmd.config.OrganizationFields().Name, cmd.config.SpaceFields().Name)
 	if err != nil {
 		return err
@@ -569,6 +1591,80 @@ func (cmd *Push) restart(app models.Application, params models.AppParams, c flag
 	return nil
 }
 
+// deployApp replaces the instances of a running app with ones running the
+// bits just pushed, so that it keeps serving traffic. Canary deployments ask
+// before each step unless autoContinue is set.
+func (cmd *Push) deployApp(app models.Application, params models.DeploymentParams, autoContinue bool) error {
+	dropletGUID, err := stageNewDroplet(cmd.ui, cmd.deploymentActor, app)
+	if err != nil {
+		return err
+	}
+
+	params.DropletGUID = dropletGUID
+	deployment, err := cmd.deploymentActor.Deploy(app.GUID, params)
+	if err != nil {
+		return err
+	}
+
+	return watchDeployment(cmd.ui, cmd.deploymentActor, app, deployment, func(models.Deployment) bool {
+		return autoContinue || cmd.ui.Confirm(T("Canary instances of app {{.AppName}} are running. Continue the deployment?",
+			map[string]interface{}{"AppName": app.Name}))
+	})
+}
+
+// pushDeploymentParams validates the deployment flags of push, which besides
+// the strategy take the steps of canary deployments and --wait. Apps that are
+// not running yet are started as usual whatever the strategy.
+func pushDeploymentParams(c flags.FlagContext) (*models.DeploymentParams, error) {
+	deployment, err := deploymentParams(c, []string{models.DeploymentStrategyRolling, models.DeploymentStrategyCanary})
+	if err != nil {
+		return nil, err
+	}
+
+	if deployment == nil || deployment.Strategy != models.DeploymentStrategyCanary {
+		if c.IsSet("instance-steps") {
+			return nil, errors.New(T("--instance-steps can only be used with --strategy canary"))
+		}
+		if c.Bool("wait") {
+			return nil, errors.New(T("--wait can only be used with --strategy canary"))
+		}
+	}
+
+	if deployment == nil {
+		return nil, nil
+	}
+
+	if c.Bool("no-start") {
+		return nil, errors.New(T("--strategy cannot be used with --no-start"))
+	}
+
+	if deployment.Strategy == models.DeploymentStrategyCanary && !c.Bool("wait") && c.String("output") == uihelpers.PushOutputJSON {
+		return nil, errors.New(T("--strategy canary needs --wait with '--output json', which cannot ask whether to continue"))
+	}
+
+	if c.IsSet("instance-steps") {
+		deployment.InstanceSteps, err = parseInstanceSteps(c.String("instance-steps"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return deployment, nil
+}
+
+func parseInstanceSteps(value string) ([]int, error) {
+	steps := []int{}
+	for _, field := range strings.Split(value, ",") {
+		step, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || step < 1 || step > 100 || (len(steps) > 0 && step <= steps[len(steps)-1]) {
+			return nil, errors.New(T("Invalid instance steps {{.Steps}}; they must be increasing percentages from 1 to 100, separated by commas",
+				map[string]interface{}{"Steps": value}))
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
 func (cmd *Push) getAppParamsFromManifest(c flags.FlagContext) ([]models.AppParams, error) {
 	if c.Bool("no-manifest") {
 		return []models.AppParams{}, nil
@@ -594,6 +1690,14 @@ func (cmd *Push) getAppParamsFromManifest(c flags.FlagContext) ([]models.AppPara
 		return nil, errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
 	}
 
+	warnings, err := m.CheckSchema(c.Bool("lenient"))
+	if err != nil {
+		return nil, errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+	for _, warning := range warnings {
+		cmd.ui.Warn(warning.Error())
+	}
+
 	apps, err := m.Applications()
 	if err != nil {
 		return nil, errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
@@ -694,6 +1798,18 @@ func (cmd *Push) getAppParamsFromContext(c flags.FlagContext) (models.AppParams,
 		appParams.RoutePath = &routePath
 	}
 
+	if c.String("random-route-strategy") != "" {
+		strategy := c.String("random-route-strategy")
+		if !generator.IsValidStrategy(strategy) {
+			return models.AppParams{}, errors.New(T("Invalid random route strategy {{.Strategy}}; it must be one of {{.Strategies}}",
+				map[string]interface{}{
+					"Strategy":   strategy,
+					"Strategies": strings.Join(generator.Strategies, ", "),
+				}))
+		}
+		appParams.RandomRouteStrategy = &strategy
+	}
+
 	if c.String("app-ports") != "" {
 		appPortStrings := strings.Split(c.String("app-ports"), ",")
 		appPorts := make([]int, len(appPortStrings))
@@ -711,12 +1827,23 @@ func (cmd *Push) getAppParamsFromContext(c flags.FlagContext) (models.AppParams,
 		appParams.AppPorts = &appPorts
 	}
 
-	if c.String("b") != "" {
-		buildpack := c.String("b")
+	switch buildpacks := c.StringSlice("b"); len(buildpacks) {
+	case 0:
+	case 1:
+		buildpack := buildpacks[0]
 		if buildpack == "null" || buildpack == "default" {
 			buildpack = ""
 		}
 		appParams.BuildpackURL = &buildpack
+	default:
+		for _, buildpack := range buildpacks {
+			if buildpack == "null" || buildpack == "default" {
+				return models.AppParams{}, errors.New(T("Incorrect Usage: '-b {{.Buildpack}}' cannot be combined with other buildpacks", map[string]interface{}{
+					"Buildpack": buildpack,
+				}))
+			}
+		}
+		appParams.Buildpacks = buildpacks
 	}
 
 	if c.String("c") != "" {
@@ -758,18 +1885,65 @@ func (cmd *Push) getAppParamsFromContext(c flags.FlagContext) (models.AppParams,
 		appParams.Memory = &memory
 	}
 
+	if c.String("lifecycle") != "" {
+		lifecycle := c.String("lifecycle")
+		if lifecycle != models.AppLifecycleBuildpack && lifecycle != models.AppLifecycleCNB {
+			return models.AppParams{}, errors.New(T("Incorrect Usage: '--lifecycle' must be 'buildpack' or 'cnb'"))
+		}
+		appParams.Lifecycle = &lifecycle
+
+		if lifecycle == models.AppLifecycleCNB {
+			credentials, err := cnbCredentialsFromEnv()
+			if err != nil {
+				return models.AppParams{}, err
+			}
+			appParams.CNBCredentials = credentials
+		}
+	}
+
 	if c.String("docker-image") != "" {
+		if len(c.StringSlice("b")) > 0 {
+			return models.AppParams{}, errors.New(T("Incorrect Usage: '--docker-image, -o' and '-b' cannot be used together"))
+		}
+
+		if c.String("lifecycle") != "" {
+			return models.AppParams{}, errors.New(T("Incorrect Usage: '--docker-image, -o' and '--lifecycle' cannot be used together"))
+		}
+
 		dockerImage := c.String("docker-image")
 		appParams.DockerImage = &dockerImage
 	}
 
+	if c.String("docker-username") != "" {
+		if c.String("docker-image") == "" {
+			return models.AppParams{}, errors.New(T("Incorrect Usage: '--docker-image, -o' must be provided when using '--docker-username'"))
+		}
+
+		dockerUsername := c.String("docker-username")
+		appParams.DockerUsername = &dockerUsername
+		appParams.DockerPassword = dockerPasswordFromEnv()
+		if appParams.DockerPassword == nil {
+			return models.AppParams{}, errors.New(T("Environment variable CF_DOCKER_PASSWORD not set."))
+		}
+	}
+
 	if c.String("p") != "" {
 		path := c.String("p")
 		appParams.Path = &path
 	}
 
-	if c.String("s") != "" {
-		stackName := c.String("s")
+	if c.String("pre-push-script") != "" {
+		script := c.String("pre-push-script")
+		appParams.PrePushScript = &script
+	}
+
+	if c.String("post-push-script") != "" {
+		script := c.String("post-push-script")
+		appParams.PostPushScript = &script
+	}
+
+	if c.String("stack") != "" {
+		stackName := c.String("stack")
 		appParams.StackName = &stackName
 	}
 
@@ -784,7 +1958,7 @@ func (cmd *Push) getAppParamsFromContext(c flags.FlagContext) (models.AppParams,
 	}
 
 	if healthCheckType := c.String("u"); healthCheckType != "" {
-		if healthCheckType != "port" && healthCheckType != "none" {
+		if !isHealthCheckType(healthCheckType) {
 			return models.AppParams{}, fmt.Errorf("Error: %s", fmt.Errorf(T("Invalid health-check-type param: {{.healthCheckType}}",
 				map[string]interface{}{"healthCheckType": healthCheckType})))
 		}
@@ -792,9 +1966,111 @@ func (cmd *Push) getAppParamsFromContext(c flags.FlagContext) (models.AppParams,
 		appParams.HealthCheckType = &healthCheckType
 	}
 
+	if c.IsSet("health-check-http-endpoint") {
+		endpoint := c.String("health-check-http-endpoint")
+		if !strings.HasPrefix(endpoint, "/") {
+			return models.AppParams{}, fmt.Errorf("Error: %s", fmt.Errorf(T("Invalid health-check-http-endpoint param: {{.Endpoint}}; it must be a path starting with /",
+				map[string]interface{}{"Endpoint": endpoint})))
+		}
+		if appParams.HealthCheckType != nil && *appParams.HealthCheckType != "http" {
+			return models.AppParams{}, fmt.Errorf("Error: %s", errors.New(T("The health-check-http-endpoint param can only be used with a health-check-type of http")))
+		}
+
+		appParams.HealthCheckHTTPEndpoint = &endpoint
+	}
+
+	if c.IsSet("health-check-invocation-timeout") {
+		invocationTimeout := c.Int("health-check-invocation-timeout")
+		if invocationTimeout < 1 {
+			return models.AppParams{}, fmt.Errorf("Error: %s", fmt.Errorf(T("Invalid health-check-invocation-timeout param: {{.Timeout}}; it must be a positive number of seconds",
+				map[string]interface{}{"Timeout": invocationTimeout})))
+		}
+
+		appParams.HealthCheckInvocationTimeout = &invocationTimeout
+	}
+
+	if c.IsSet("readiness-health-check-type") {
+		readinessType := c.String("readiness-health-check-type")
+		if !isReadinessHealthCheckType(readinessType) {
+			return models.AppParams{}, fmt.Errorf("Error: %s", fmt.Errorf(T("Invalid readiness-health-check-type param: {{.ReadinessHealthCheckType}}",
+				map[string]interface{}{"ReadinessHealthCheckType": readinessType})))
+		}
+
+		appParams.ReadinessHealthCheckType = &readinessType
+	}
+
+	if c.IsSet("readiness-health-check-http-endpoint") {
+		endpoint := c.String("readiness-health-check-http-endpoint")
+		if !strings.HasPrefix(endpoint, "/") {
+			return models.AppParams{}, fmt.Errorf("Error: %s", fmt.Errorf(T("Invalid readiness-health-check-http-endpoint param: {{.Endpoint}}; it must be a path starting with /",
+				map[string]interface{}{"Endpoint": endpoint})))
+		}
+		if appParams.ReadinessHealthCheckType != nil && *appParams.ReadinessHealthCheckType != "http" {
+			return models.AppParams{}, fmt.Errorf("Error: %s", errors.New(T("The readiness-health-check-http-endpoint param can only be used with a readiness-health-check-type of http")))
+		}
+
+		appParams.ReadinessHealthCheckHTTPEndpoint = &endpoint
+	}
+
+	if c.IsSet("readiness-health-check-interval") {
+		interval := c.Int("readiness-health-check-interval")
+		if interval < 1 {
+			return models.AppParams{}, fmt.Errorf("Error: %s", fmt.Errorf(T("Invalid readiness-health-check-interval param: {{.Interval}}; it must be a positive number of seconds",
+				map[string]interface{}{"Interval": interval})))
+		}
+
+		appParams.ReadinessHealthCheckInterval = &interval
+	}
+
 	return appParams, nil
 }
 
+func isReadinessHealthCheckType(readinessType string) bool {
+	for _, t := range actors.ReadinessHealthCheckTypes {
+		if t == readinessType {
+			return true
+		}
+	}
+	return false
+}
+
+func isHealthCheckType(healthCheckType string) bool {
+	for _, t := range actors.HealthCheckTypes {
+		if t == healthCheckType {
+			return true
+		}
+	}
+	return false
+}
+
+// dockerPasswordFromEnv returns the password for a private docker registry
+// from CF_DOCKER_PASSWORD, so it does not have to be stored in a manifest or
+// given on the command line.
+func dockerPasswordFromEnv() *string {
+	password := os.Getenv("CF_DOCKER_PASSWORD")
+	if password == "" {
+		return nil
+	}
+	return &password
+}
+
+// cnbCredentialsFromEnv reads the credentials of private buildpack
+// registries, keyed by registry, from the CNB_CREDENTIALS environment
+// variable.
+func cnbCredentialsFromEnv() (map[string]models.RegistryCredentials, error) {
+	value := os.Getenv("CNB_CREDENTIALS")
+	if value == "" {
+		return nil, nil
+	}
+
+	credentials := map[string]models.RegistryCredentials{}
+	err := json.Unmarshal([]byte(value), &credentials)
+	if err != nil {
+		return nil, errors.New(T("Environment variable CNB_CREDENTIALS must be a JSON object of registries to their username and password: {{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+	return credentials, nil
+}
+
 func (cmd Push) ValidateContextAndAppParams(appsFromManifest []models.AppParams, appFromContext models.AppParams) error {
 	if appFromContext.NoHostname != nil && *appFromContext.NoHostname {
 		for _, app := range appsFromManifest {
@@ -807,15 +2083,14 @@ func (cmd Push) ValidateContextAndAppParams(appsFromManifest []models.AppParams,
 	return nil
 }
 
-func (cmd *Push) uploadApp(appGUID, appDir, appDirOrZipFile string, localFiles []models.AppFileFields) error {
-	uploadDir, err := ioutil.TempDir("", "apps")
+func (cmd *Push) uploadApp(app models.Application, appDir, appDirOrZipFile string, localFiles []models.AppFileFields) error {
+	remoteFiles, filesToUpload, err := cmd.actor.GatherFiles(app.GUID, localFiles, appDir)
 	if err != nil {
 		return err
 	}
 
-	remoteFiles, hasFileToUpload, err := cmd.actor.GatherFiles(localFiles, appDir, uploadDir)
-	if err != nil {
-		return err
+	if cmd.output != nil {
+		cmd.output.FilesMatched(app.Name, len(localFiles), len(filesToUpload))
 	}
 
 	zipFile, err := ioutil.TempFile("", "uploads")
@@ -827,8 +2102,9 @@ func (cmd *Push) uploadApp(appGUID, appDir, appDirOrZipFile string, localFiles [
 		os.Remove(zipFile.Name())
 	}()
 
-	if hasFileToUpload {
-		err = cmd.zipper.Zip(uploadDir, zipFile)
+	var zipFileSize int64
+	if len(filesToUpload) > 0 {
+		err = cmd.zipper.ZipFiles(appDir, filesToUpload, zipFile)
 		if err != nil {
 			if emptyDirErr, ok := err.(*errors.EmptyDirError); ok {
 				return emptyDirErr
@@ -836,26 +2112,29 @@ func (cmd *Push) uploadApp(appGUID, appDir, appDirOrZipFile string, localFiles [
 			return fmt.Errorf("%s: %s", T("Error zipping application"), err.Error())
 		}
 
-		var zipFileSize int64
 		zipFileSize, err = cmd.zipper.GetZipSize(zipFile)
 		if err != nil {
 			return err
 		}
 
-		zipFileCount := cmd.appfiles.CountFiles(uploadDir)
-		if zipFileCount > 0 {
-			cmd.ui.Say(T("Uploading app files from: {{.Path}}", map[string]interface{}{"Path": appDir}))
-			cmd.ui.Say(T("Uploading {{.ZipFileBytes}}, {{.FileCount}} files",
-				map[string]interface{}{
-					"ZipFileBytes": formatters.ByteSize(zipFileSize),
-					"FileCount":    zipFileCount}))
-		}
+		cmd.ui.Say(T("Uploading app files from: {{.Path}}", map[string]interface{}{"Path": appDir}))
+		cmd.ui.Say(T("Uploading {{.ZipFileBytes}}, {{.FileCount}} files",
+			map[string]interface{}{
+				"ZipFileBytes": formatters.ByteSize(zipFileSize),
+				"FileCount":    len(filesToUpload)}))
 	}
 
-	err = os.RemoveAll(uploadDir)
+	if zipFileSize > actors.DefaultUploadChunkSize {
+		err = cmd.actor.UploadAppInChunks(app.GUID, zipFile, remoteFiles, actors.DefaultUploadChunkSize, actors.DefaultUploadConcurrency)
+	} else {
+		err = cmd.actor.UploadApp(app.GUID, zipFile, remoteFiles)
+	}
 	if err != nil {
 		return err
 	}
 
-	return cmd.actor.UploadApp(appGUID, zipFile, remoteFiles)
+	if cmd.output != nil {
+		cmd.output.BytesUploaded(app.Name, zipFileSize)
+	}
+	return nil
 }
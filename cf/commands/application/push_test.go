@@ -3,14 +3,20 @@ package application_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"syscall"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
 	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
 	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
 	"code.cloudfoundry.org/cli/cf/api/authentication/authenticationfakes"
+	"code.cloudfoundry.org/cli/cf/api/metadata/metadatafakes"
+	"code.cloudfoundry.org/cli/cf/api/processes/processesfakes"
 	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/api/sidecars/sidecarsfakes"
 	"code.cloudfoundry.org/cli/cf/api/stacks/stacksfakes"
 	"code.cloudfoundry.org/cli/cf/appfiles/appfilesfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
@@ -20,6 +26,8 @@ import (
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/errors"
 	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/hooks"
+	"code.cloudfoundry.org/cli/cf/hooks/hooksfakes"
 	"code.cloudfoundry.org/cli/cf/manifest"
 	"code.cloudfoundry.org/cli/cf/manifest/manifestfakes"
 	"code.cloudfoundry.org/cli/cf/models"
@@ -28,6 +36,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/terminal"
 	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
 	"code.cloudfoundry.org/cli/cf/trace"
+	"code.cloudfoundry.org/cli/plugin/models"
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
 	"code.cloudfoundry.org/cli/utils/generic"
 	"code.cloudfoundry.org/cli/utils/words/generator/generatorfakes"
@@ -53,9 +62,13 @@ var _ = Describe("Push Command", func() {
 		wordGenerator              *generatorfakes.FakeWordGenerator
 		requirementsFactory        *requirementsfakes.FakeFactory
 		authRepo                   *authenticationfakes.FakeRepository
+		sidecarRepo                *sidecarsfakes.FakeRepository
+		processRepo                *processesfakes.FakeRepository
+		metadataRepo               *metadatafakes.FakeRepository
 		actor                      *actorsfakes.FakePushActor
 		routeActor                 *actorsfakes.FakeRouteActor
 		appfiles                   *appfilesfakes.FakeAppFiles
+		hashCache                  *appfilesfakes.FakeHashCache
 		zipper                     *appfilesfakes.FakeZipper
 		deps                       commandregistry.Dependency
 		flagContext                flags.FlagContext
@@ -93,16 +106,18 @@ var _ = Describe("Push Command", func() {
 		routeActor = new(actorsfakes.FakeRouteActor)
 		zipper = new(appfilesfakes.FakeZipper)
 		appfiles = new(appfilesfakes.FakeAppFiles)
+		hashCache = new(appfilesfakes.FakeHashCache)
 
 		deps = commandregistry.Dependency{
-			UI:            ui,
-			Config:        configRepo,
-			ManifestRepo:  manifestRepo,
-			WordGenerator: wordGenerator,
-			PushActor:     actor,
-			RouteActor:    routeActor,
-			AppZipper:     zipper,
-			AppFiles:      appfiles,
+			UI:                ui,
+			Config:            configRepo,
+			ManifestRepo:      manifestRepo,
+			WordGenerator:     wordGenerator,
+			PushActor:         actor,
+			RouteActor:        routeActor,
+			AppZipper:         zipper,
+			AppFiles:          appfiles,
+			AppFilesHashCache: hashCache,
 		}
 
 		appRepo = new(applicationsfakes.FakeRepository)
@@ -111,12 +126,18 @@ var _ = Describe("Push Command", func() {
 		serviceRepo = new(apifakes.FakeServiceRepository)
 		stackRepo = new(stacksfakes.FakeStackRepository)
 		authRepo = new(authenticationfakes.FakeRepository)
+		sidecarRepo = new(sidecarsfakes.FakeRepository)
+		processRepo = new(processesfakes.FakeRepository)
+		metadataRepo = new(metadatafakes.FakeRepository)
 		deps.RepoLocator = deps.RepoLocator.SetApplicationRepository(appRepo)
 		deps.RepoLocator = deps.RepoLocator.SetDomainRepository(domainRepo)
 		deps.RepoLocator = deps.RepoLocator.SetRouteRepository(routeRepo)
 		deps.RepoLocator = deps.RepoLocator.SetServiceRepository(serviceRepo)
 		deps.RepoLocator = deps.RepoLocator.SetStackRepository(stackRepo)
 		deps.RepoLocator = deps.RepoLocator.SetAuthenticationRepository(authRepo)
+		deps.RepoLocator = deps.RepoLocator.SetSidecarRepository(sidecarRepo)
+		deps.RepoLocator = deps.RepoLocator.SetProcessRepository(processRepo)
+		deps.RepoLocator = deps.RepoLocator.SetMetadataRepository(metadataRepo)
 
 		//setup fake commands (counterfeiter) to correctly interact with commandregistry
 		starter = new(applicationfakes.FakeStarter)
@@ -194,6 +215,66 @@ var _ = Describe("Push Command", func() {
 			})
 		})
 
+		Context("when --docker-username is passed in", func() {
+			BeforeEach(func() {
+				err := flagContext.Parse("app-name", "--docker-image", "some-image", "--docker-username", "some-user")
+				Expect(err).NotTo(HaveOccurred())
+
+				reqs, err = cmd.Requirements(requirementsFactory, flagContext)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a minAPIVersionRequirement", func() {
+				Expect(requirementsFactory.NewMinAPIVersionRequirementCallCount()).To(Equal(1))
+
+				option, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+				Expect(option).To(Equal("Option '--docker-username'"))
+				Expect(version).To(Equal(cf.DockerCredentialsMinimumAPIVersion))
+
+				Expect(reqs).To(ContainElement(minVersionReq))
+			})
+		})
+
+		Context("when -b is passed more than once", func() {
+			BeforeEach(func() {
+				err := flagContext.Parse("app-name", "-b", "go_buildpack", "-b", "binary_buildpack")
+				Expect(err).NotTo(HaveOccurred())
+
+				reqs, err = cmd.Requirements(requirementsFactory, flagContext)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a minAPIVersionRequirement", func() {
+				Expect(requirementsFactory.NewMinAPIVersionRequirementCallCount()).To(Equal(1))
+
+				option, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+				Expect(option).To(Equal("Multiple '-b' options"))
+				Expect(version).To(Equal(cf.MultipleBuildpacksMinimumAPIVersion))
+
+				Expect(reqs).To(ContainElement(minVersionReq))
+			})
+		})
+
+		Context("when --lifecycle cnb is passed in", func() {
+			BeforeEach(func() {
+				err := flagContext.Parse("app-name", "--lifecycle", "cnb")
+				Expect(err).NotTo(HaveOccurred())
+
+				reqs, err = cmd.Requirements(requirementsFactory, flagContext)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a minAPIVersionRequirement", func() {
+				Expect(requirementsFactory.NewMinAPIVersionRequirementCallCount()).To(Equal(1))
+
+				option, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+				Expect(option).To(Equal("Option '--lifecycle cnb'"))
+				Expect(version).To(Equal(cf.CNBLifecycleMinimumAPIVersion))
+
+				Expect(reqs).To(ContainElement(minVersionReq))
+			})
+		})
+
 		Context("when --app-ports is passed in", func() {
 			BeforeEach(func() {
 				err := flagContext.Parse("app-name", "--app-ports", "the-app-port")
@@ -213,17 +294,39 @@ var _ = Describe("Push Command", func() {
 				Expect(reqs).To(ContainElement(minVersionReq))
 			})
 		})
+
+		Context("when --strategy canary is passed in", func() {
+			BeforeEach(func() {
+				err := flagContext.Parse("app-name", "--strategy", "canary")
+				Expect(err).NotTo(HaveOccurred())
+
+				reqs, err = cmd.Requirements(requirementsFactory, flagContext)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a minAPIVersionRequirement", func() {
+				Expect(requirementsFactory.NewMinAPIVersionRequirementCallCount()).To(Equal(1))
+
+				option, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+				Expect(option).To(Equal("Option '--strategy canary'"))
+				Expect(version).To(Equal(cf.CanaryDeploymentsMinimumAPIVersion))
+
+				Expect(reqs).To(ContainElement(minVersionReq))
+			})
+		})
 	})
 
 	Describe("Execute", func() {
 		var (
 			executeErr     error
 			args           []string
+			pluginCall     bool
 			uiWithContents terminal.UI
 			output         *gbytes.Buffer
 		)
 
 		BeforeEach(func() {
+			pluginCall = false
 			output = gbytes.NewBuffer()
 			uiWithContents = terminal.NewUI(gbytes.NewBuffer(), output, terminal.NewTeePrinter(output), trace.NewWriterPrinter(output, false))
 
@@ -269,7 +372,7 @@ var _ = Describe("Push Command", func() {
 				nil,
 			)
 
-			zipper.ZipReturns(nil)
+			zipper.ZipFilesReturns(nil)
 			zipper.GetZipSizeReturns(9001, nil)
 		})
 
@@ -278,7 +381,7 @@ var _ = Describe("Push Command", func() {
 		})
 
 		JustBeforeEach(func() {
-			cmd.SetDependency(deps, false)
+			cmd.SetDependency(deps, pluginCall)
 
 			err := flagContext.Parse(args...)
 			Expect(err).NotTo(HaveOccurred())
@@ -339,6 +442,54 @@ var _ = Describe("Push Command", func() {
 				})
 			})
 
+			Context("when the --dry-run flag is passed", func() {
+				BeforeEach(func() {
+					deps.UI = uiWithContents
+					args = []string{"--dry-run", "app-name"}
+
+					routeRepo.FindReturns(models.Route{}, errors.NewModelNotFoundError("Route", "manifest-host.foo.cf-app.com"))
+					actor.DiffAppReturns(actors.PushDiff{
+						FilesToUpload:  []models.AppFileFields{{Path: "some-path"}},
+						EnvVarsAdded:   []string{"FOO"},
+						EnvVarsChanged: []string{"PATH"},
+					}, nil)
+				})
+
+				It("does not change the app, its routes or its bits", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					Expect(appRepo.CreateCallCount()).To(Equal(0))
+					Expect(appRepo.UpdateCallCount()).To(Equal(0))
+					Expect(routeRepo.CreateCallCount()).To(Equal(0))
+					Expect(routeRepo.BindCallCount()).To(Equal(0))
+					Expect(actor.GatherFilesCallCount()).To(Equal(0))
+					Expect(actor.UploadAppCallCount()).To(Equal(0))
+					Expect(starter.ApplicationStartCallCount()).To(Equal(0))
+				})
+
+				It("diffs the app against its local files", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					Expect(actor.DiffAppCallCount()).To(Equal(1))
+					app, params, localFiles := actor.DiffAppArgsForCall(0)
+					Expect(app.Name).To(Equal("app-name"))
+					Expect(*params.Name).To(Equal("app-name"))
+					Expect(localFiles).To(Equal([]models.AppFileFields{{Path: "some-path"}}))
+				})
+
+				It("prints the planned changes", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					totalOutput := terminal.Decolorize(string(output.Contents()))
+					Expect(totalOutput).To(ContainSubstring("Planning push of app app-name in org my-org / space my-space as my-user (dry run)..."))
+					Expect(totalOutput).To(ContainSubstring("App app-name would be created"))
+					Expect(totalOutput).To(ContainSubstring("Files:\n  upload some-path\n  1 files to upload, 0 files already present"))
+					Expect(totalOutput).To(ContainSubstring("Routes:\n  create manifest-host.foo.cf-app.com\n  bind manifest-host.foo.cf-app.com"))
+					Expect(totalOutput).To(ContainSubstring("Environment variables:\n  add FOO\n  change PATH"))
+					Expect(totalOutput).To(ContainSubstring("Dry run complete, nothing was changed."))
+				})
+			})
+
 			Context("when given a bad path", func() {
 				BeforeEach(func() {
 					actor.ProcessPathStub = func(dirOrZipFile string, f func(string) error) error {
@@ -395,7 +546,7 @@ var _ = Describe("Push Command", func() {
 
 				Context("when pushing the app", func() {
 					BeforeEach(func() {
-						actor.GatherFilesReturns([]resources.AppFileResource{}, false, errors.New("failed to get file mode"))
+						actor.GatherFilesReturns([]resources.AppFileResource{}, nil, errors.New("failed to get file mode"))
 					})
 
 					It("notifies users about the error actor.GatherFiles() returns", func() {
@@ -636,6 +787,112 @@ var _ = Describe("Push Command", func() {
 						Expect(orgName).To(Equal(configRepo.OrganizationFields().Name))
 						Expect(spaceName).To(Equal(configRepo.SpaceFields().Name))
 						Expect(starter.SetStartTimeoutInSecondsArgsForCall(0)).To(Equal(111))
+						Expect(starter.SetStagingTimeoutInSecondsCallCount()).To(BeZero())
+					})
+
+					Context("when --staging-timeout is given", func() {
+						BeforeEach(func() {
+							args = []string{"--staging-timeout", "90", "app-name"}
+						})
+
+						It("sets the staging timeout of the starter", func() {
+							Expect(executeErr).NotTo(HaveOccurred())
+							Expect(starter.SetStagingTimeoutInSecondsArgsForCall(0)).To(Equal(90))
+						})
+					})
+
+					Context("when --startup-timeout is given", func() {
+						BeforeEach(func() {
+							args = []string{"-t", "60", "--startup-timeout", "300", "app-name"}
+						})
+
+						It("waits for the startup timeout rather than the health check timeout", func() {
+							Expect(executeErr).NotTo(HaveOccurred())
+							calls := starter.SetStartTimeoutInSecondsCallCount()
+							Expect(starter.SetStartTimeoutInSecondsArgsForCall(calls - 1)).To(Equal(300))
+
+							appParam := appRepo.CreateArgsForCall(0)
+							Expect(*appParam.HealthCheckTimeout).To(Equal(60))
+						})
+					})
+
+					Context("when --output json is given", func() {
+						BeforeEach(func() {
+							args = []string{"--output", "json", "app-name"}
+
+							appRepo.ReadStub = func(name string) (models.Application, error) {
+								if appRepo.ReadCallCount() == 1 {
+									return models.Application{}, errors.NewModelNotFoundError("App", name)
+								}
+
+								app := models.Application{}
+								app.Name = name
+								app.Routes = []models.RouteSummary{
+									{Host: "app-name", Domain: models.DomainFields{Name: "foo.cf-app.com"}},
+								}
+								return app, nil
+							}
+
+							starter.ApplicationStartStub = func(app models.Application, _ string, _ string) (models.Application, error) {
+								listener := starter.SetStagingStateListenerArgsForCall(0)
+								listener(app.Name, "PENDING")
+								listener(app.Name, "STAGED")
+								return app, nil
+							}
+						})
+
+						It("prints the progress of the push as JSON events instead of text", func() {
+							Expect(executeErr).NotTo(HaveOccurred())
+
+							lines := strings.Split(strings.TrimSpace(string(output.Contents())), "\n")
+							Expect(lines).To(Equal([]string{
+								`{"app":"app-name","event":"files_matched","files":1,"files_to_upload":0}`,
+								`{"app":"app-name","bytes":0,"event":"bytes_uploaded"}`,
+								`{"app":"app-name","event":"staging_state","state":"PENDING"}`,
+								`{"app":"app-name","event":"staging_state","state":"STAGED"}`,
+								`{"app":"app-name","event":"app_pushed","routes":["app-name.foo.cf-app.com"]}`,
+							}))
+						})
+
+						Context("when the push fails", func() {
+							BeforeEach(func() {
+								actor.UploadAppReturns(errors.New("upload-error"))
+							})
+
+							It("prints the error as a JSON event", func() {
+								Expect(executeErr).To(HaveOccurred())
+								Expect(string(output.Contents())).To(ContainSubstring(`{"error":"Error processing app files: Error uploading application.\nupload-error","event":"push_failed"}`))
+							})
+						})
+					})
+
+					Context("when an unknown output format is given", func() {
+						BeforeEach(func() {
+							args = []string{"--output", "yaml", "app-name"}
+						})
+
+						It("fails without pushing", func() {
+							Expect(executeErr).To(MatchError("Invalid output format yaml; it must be 'text' or 'json'"))
+							Expect(appRepo.CreateCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when the zipped app is larger than a single upload chunk", func() {
+					BeforeEach(func() {
+						actor.GatherFilesReturns([]resources.AppFileResource{}, []models.AppFileFields{{Path: "some-path"}}, nil)
+						zipper.GetZipSizeReturns(actors.DefaultUploadChunkSize+1, nil)
+					})
+
+					It("uploads the app in chunks", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						Expect(actor.UploadAppCallCount()).To(Equal(0))
+						Expect(actor.UploadAppInChunksCallCount()).To(Equal(1))
+						appGUID, _, _, chunkSize, maxConcurrency := actor.UploadAppInChunksArgsForCall(0)
+						Expect(appGUID).To(Equal("app-name-guid"))
+						Expect(chunkSize).To(Equal(actors.DefaultUploadChunkSize))
+						Expect(maxConcurrency).To(Equal(actors.DefaultUploadConcurrency))
 					})
 				})
 
@@ -816,6 +1073,95 @@ var _ = Describe("Push Command", func() {
 							Expect(*params.DockerImage).To(Equal("sample/dockerImage"))
 						})
 					})
+
+					Context("when --docker-username is passed", func() {
+						var oldPassword string
+
+						BeforeEach(func() {
+							oldPassword = os.Getenv("CF_DOCKER_PASSWORD")
+							args = []string{"testApp", "--docker-image", "sample/dockerImage", "--docker-username", "some-user"}
+						})
+
+						AfterEach(func() {
+							os.Setenv("CF_DOCKER_PASSWORD", oldPassword)
+						})
+
+						Context("when CF_DOCKER_PASSWORD is set", func() {
+							BeforeEach(func() {
+								os.Setenv("CF_DOCKER_PASSWORD", "some-password")
+							})
+
+							It("creates the app with the docker credentials", func() {
+								Expect(executeErr).NotTo(HaveOccurred())
+
+								params := appRepo.CreateArgsForCall(0)
+								Expect(*params.DockerUsername).To(Equal("some-user"))
+								Expect(*params.DockerPassword).To(Equal("some-password"))
+							})
+						})
+
+						Context("when CF_DOCKER_PASSWORD is not set", func() {
+							BeforeEach(func() {
+								os.Setenv("CF_DOCKER_PASSWORD", "")
+							})
+
+							It("returns an error", func() {
+								Expect(executeErr).To(MatchError("Environment variable CF_DOCKER_PASSWORD not set."))
+								Expect(appRepo.CreateCallCount()).To(BeZero())
+							})
+						})
+
+						Context("when --docker-image is not passed", func() {
+							BeforeEach(func() {
+								os.Setenv("CF_DOCKER_PASSWORD", "some-password")
+								args = []string{"testApp", "--docker-username", "some-user"}
+							})
+
+							It("returns an error", func() {
+								Expect(executeErr).To(MatchError("Incorrect Usage: '--docker-image, -o' must be provided when using '--docker-username'"))
+							})
+						})
+					})
+				})
+
+				Context("when the manifest specifies a docker image", func() {
+					BeforeEach(func() {
+						deps.UI = uiWithContents
+						m := &manifest.Manifest{
+							Path: "manifest.yml",
+							Data: generic.NewMap(map[interface{}]interface{}{
+								"applications": []interface{}{
+									generic.NewMap(map[interface{}]interface{}{
+										"name": "docker-app",
+										"docker": map[interface{}]interface{}{
+											"image":    "sample/dockerImage",
+											"username": "some-user",
+											"password": "some-password",
+										},
+									}),
+								},
+							}),
+						}
+						manifestRepo.ReadManifestReturns(m, nil)
+						args = []string{}
+					})
+
+					It("creates a diego app from the image with the credentials", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						params := appRepo.CreateArgsForCall(0)
+						Expect(*params.DockerImage).To(Equal("sample/dockerImage"))
+						Expect(*params.DockerUsername).To(Equal("some-user"))
+						Expect(*params.DockerPassword).To(Equal("some-password"))
+						Expect(*params.Diego).To(BeTrue())
+					})
+
+					It("does not process or upload app bits", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						Expect(actor.ProcessPathCallCount()).To(BeZero())
+						Expect(actor.UploadAppCallCount()).To(BeZero())
+					})
 				})
 
 				Context("when health-check-type '-u' or '--health-check-type' is set", func() {
@@ -831,6 +1177,39 @@ var _ = Describe("Push Command", func() {
 						})
 					})
 
+					Context("when --health-check-http-endpoint is not a path", func() {
+						BeforeEach(func() {
+							args = []string{"app-name", "-u", "http", "--health-check-http-endpoint", "health"}
+						})
+
+						It("returns an error", func() {
+							Expect(executeErr).To(HaveOccurred())
+							Expect(executeErr.Error()).To(ContainSubstring("Invalid health-check-http-endpoint param: health"))
+						})
+					})
+
+					Context("when --health-check-http-endpoint is given with a type other than 'http'", func() {
+						BeforeEach(func() {
+							args = []string{"app-name", "-u", "port", "--health-check-http-endpoint", "/health"}
+						})
+
+						It("returns an error", func() {
+							Expect(executeErr).To(HaveOccurred())
+							Expect(executeErr.Error()).To(ContainSubstring("The health-check-http-endpoint param can only be used with a health-check-type of http"))
+						})
+					})
+
+					Context("when --health-check-invocation-timeout is not positive", func() {
+						BeforeEach(func() {
+							args = []string{"app-name", "--health-check-invocation-timeout", "0"}
+						})
+
+						It("returns an error", func() {
+							Expect(executeErr).To(HaveOccurred())
+							Expect(executeErr.Error()).To(ContainSubstring("Invalid health-check-invocation-timeout param: 0"))
+						})
+					})
+
 					Context("when the value is 'port'", func() {
 						BeforeEach(func() {
 							args = []string{"app-name", "--health-check-type", "port"}
@@ -909,6 +1288,47 @@ var _ = Describe("Push Command", func() {
 								Expect(host).To(Equal("app-name-random-host"))
 							})
 						})
+
+						Context("when a random-route strategy is given as a flag", func() {
+							BeforeEach(func() {
+								args = []string{"--random-route", "--random-route-strategy", "timestamp", "app-name"}
+							})
+
+							It("generates the hostname with that strategy", func() {
+								Expect(executeErr).NotTo(HaveOccurred())
+
+								Expect(routeActor.FindOrCreateRouteCallCount()).To(Equal(1))
+								host, _, _, _, _ := routeActor.FindOrCreateRouteArgsForCall(0)
+								Expect(host).To(MatchRegexp(`^app-name-\d{8}-\d{6}-\d{3}$`))
+							})
+						})
+
+						Context("when a random-route strategy is set in the manifest", func() {
+							BeforeEach(func() {
+								manifestApp.Set("random-route", true)
+								manifestApp.Set("random-route-strategy", "uuid")
+								args = []string{"app-name"}
+							})
+
+							It("generates the hostname with that strategy", func() {
+								Expect(executeErr).NotTo(HaveOccurred())
+
+								Expect(routeActor.FindOrCreateRouteCallCount()).To(Equal(1))
+								host, _, _, _, _ := routeActor.FindOrCreateRouteArgsForCall(0)
+								Expect(host).To(MatchRegexp(`^app-name-[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`))
+							})
+						})
+
+						Context("when the random-route strategy flag is not a known strategy", func() {
+							BeforeEach(func() {
+								args = []string{"--random-route", "--random-route-strategy", "dice", "app-name"}
+							})
+
+							It("returns an error", func() {
+								Expect(executeErr).To(MatchError("Invalid random route strategy dice; it must be one of word, uuid, timestamp"))
+								Expect(routeActor.FindOrCreateRouteCallCount()).To(BeZero())
+							})
+						})
 					})
 
 					Context("for tcp routes", func() {
@@ -988,34 +1408,130 @@ var _ = Describe("Push Command", func() {
 					It("includes the app files in dir", func() {
 						Expect(executeErr).NotTo(HaveOccurred())
 
-						actualLocalFiles, _, _ := actor.GatherFilesArgsForCall(0)
+						_, actualLocalFiles, _ := actor.GatherFilesArgsForCall(0)
 						Expect(actualLocalFiles).To(Equal(expectedLocalFiles))
 					})
 				})
 
-				Context("when there are no app files to process", func() {
+				Context("when --preserve-symlinks is passed", func() {
 					BeforeEach(func() {
-						deps.UI = uiWithContents
-						appfiles.AppFilesInDirReturns([]models.AppFileFields{}, nil)
-						args = []string{"-p", "../some/path-to/an-app/file.zip", "app-with-path"}
+						args = []string{"--preserve-symlinks", "app-with-symlinks"}
 					})
 
-					It("errors", func() {
-						Expect(executeErr).To(HaveOccurred())
-						Expect(executeErr.Error()).To(ContainSubstring("No app files found in '../some/path-to/an-app/file.zip'"))
+					It("lists the app files keeping symbolic links", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						_, preserveSymlinks, _ := appfiles.AppFilesInDirArgsForCall(0)
+						Expect(preserveSymlinks).To(BeTrue())
 					})
 				})
 
-				Context("when there is an error getting app files", func() {
+				Context("when --preserve-symlinks is not passed", func() {
 					BeforeEach(func() {
-						deps.UI = uiWithContents
-						appfiles.AppFilesInDirReturns([]models.AppFileFields{}, errors.New("some error"))
-						args = []string{"-p", "../some/path-to/an-app/file.zip", "app-with-path"}
+						args = []string{"app-without-symlinks"}
 					})
 
-					It("prints a message", func() {
-						Expect(executeErr).To(HaveOccurred())
-						Expect(executeErr.Error()).To(ContainSubstring("Error processing app files in '../some/path-to/an-app/file.zip': some error"))
+					It("lists the app files without symbolic links", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						_, preserveSymlinks, _ := appfiles.AppFilesInDirArgsForCall(0)
+						Expect(preserveSymlinks).To(BeFalse())
+					})
+				})
+
+				Context("when pushing an app directory", func() {
+					BeforeEach(func() {
+						args = []string{"-p", "../some/path-to/an-app", "app-with-path"}
+					})
+
+					It("reuses the digests of unchanged files from the hash cache", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						_, _, actualHashCache := appfiles.AppFilesInDirArgsForCall(0)
+						Expect(actualHashCache).To(Equal(hashCache))
+					})
+
+					Context("when --no-hash-cache is passed", func() {
+						BeforeEach(func() {
+							args = []string{"--no-hash-cache", "-p", "../some/path-to/an-app", "app-with-path"}
+						})
+
+						It("hashes every file again", func() {
+							Expect(executeErr).NotTo(HaveOccurred())
+
+							_, _, actualHashCache := appfiles.AppFilesInDirArgsForCall(0)
+							Expect(actualHashCache).To(BeNil())
+						})
+					})
+				})
+
+				Context("when pushing a zip file", func() {
+					BeforeEach(func() {
+						zipper.IsZipFileReturns(true)
+						args = []string{"-p", "../some/path-to/an-app/file.zip", "app-with-path"}
+					})
+
+					It("does not use the hash cache", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						_, _, actualHashCache := appfiles.AppFilesInDirArgsForCall(0)
+						Expect(actualHashCache).To(BeNil())
+					})
+				})
+
+				Context("when some of the app files need to be uploaded", func() {
+					var filesToUpload []models.AppFileFields
+
+					BeforeEach(func() {
+						filesToUpload = []models.AppFileFields{{Path: "app.rb"}, {Path: "lib/link", Symlink: true}}
+						actor.GatherFilesReturns(nil, filesToUpload, nil)
+						args = []string{"-p", "../some/path-to/an-app", "app-with-path"}
+					})
+
+					It("zips them straight from the app directory", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						Expect(zipper.ZipFilesCallCount()).To(Equal(1))
+						appDir, files, _ := zipper.ZipFilesArgsForCall(0)
+						Expect(appDir).To(Equal("../some/path-to/an-app"))
+						Expect(files).To(Equal(filesToUpload))
+					})
+				})
+
+				Context("when all of the app files are already on the cloud controller", func() {
+					BeforeEach(func() {
+						actor.GatherFilesReturns(nil, []models.AppFileFields{}, nil)
+					})
+
+					It("does not zip anything", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+						Expect(zipper.ZipFilesCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when there are no app files to process", func() {
+					BeforeEach(func() {
+						deps.UI = uiWithContents
+						appfiles.AppFilesInDirReturns([]models.AppFileFields{}, nil)
+						args = []string{"-p", "../some/path-to/an-app/file.zip", "app-with-path"}
+					})
+
+					It("errors", func() {
+						Expect(executeErr).To(HaveOccurred())
+						Expect(executeErr.Error()).To(ContainSubstring("No app files found in '../some/path-to/an-app/file.zip'"))
+					})
+				})
+
+				Context("when there is an error getting app files", func() {
+					BeforeEach(func() {
+						deps.UI = uiWithContents
+						appfiles.AppFilesInDirReturns([]models.AppFileFields{}, errors.New("some error"))
+						args = []string{"-p", "../some/path-to/an-app/file.zip", "app-with-path"}
+					})
+
+					It("prints a message", func() {
+						Expect(executeErr).To(HaveOccurred())
+						Expect(executeErr.Error()).To(ContainSubstring("Error processing app files in '../some/path-to/an-app/file.zip': some error"))
 					})
 				})
 
@@ -1027,7 +1543,7 @@ var _ = Describe("Push Command", func() {
 					It("pushes the contents of the app directory or zip file specified", func() {
 						Expect(executeErr).NotTo(HaveOccurred())
 
-						_, appDir, _ := actor.GatherFilesArgsForCall(0)
+						_, _, appDir := actor.GatherFilesArgsForCall(0)
 						Expect(appDir).To(Equal("../some/path-to/an-app/file.zip"))
 					})
 				})
@@ -1063,7 +1579,7 @@ var _ = Describe("Push Command", func() {
 						Expect(executeErr).NotTo(HaveOccurred())
 
 						dir, _ := os.Getwd()
-						_, appDir, _ := actor.GatherFilesArgsForCall(0)
+						_, _, appDir := actor.GatherFilesArgsForCall(0)
 						Expect(appDir).To(Equal(dir))
 					})
 				})
@@ -1171,6 +1687,53 @@ var _ = Describe("Push Command", func() {
 					})
 				})
 
+				Context("when the manifest does not match the schema", func() {
+					BeforeEach(func() {
+						deps.UI = uiWithContents
+						manifestRepo.ReadManifestReturns(
+							&manifest.Manifest{
+								Path: "manifest.yml",
+								Data: generic.NewMap(map[interface{}]interface{}{
+									"applications": []interface{}{
+										generic.NewMap(map[interface{}]interface{}{
+											"name":   "manifest-app-name",
+											"memroy": "128M",
+											"host":   "manifest-host",
+										}),
+									},
+								}),
+								Problems: []manifest.SchemaProblem{
+									{Kind: manifest.UnknownAttribute, File: "manifest.yml", Line: 4, Column: 3, Message: "Unknown attribute 'applications[0].memroy'"},
+									{Kind: manifest.DeprecatedAttribute, File: "manifest.yml", Line: 5, Column: 3, Message: "Attribute 'applications[0].host' is deprecated; use 'routes' instead"},
+								},
+							},
+							nil,
+						)
+						args = []string{"--no-route"}
+					})
+
+					It("fails on unknown attributes", func() {
+						Expect(executeErr).To(HaveOccurred())
+						Expect(executeErr.Error()).To(Equal("Error reading manifest file:\nmanifest.yml:4:3: Unknown attribute 'applications[0].memroy'"))
+						Expect(appRepo.CreateCallCount()).To(BeZero())
+					})
+
+					Context("when the --lenient flag is passed", func() {
+						BeforeEach(func() {
+							args = []string{"--no-route", "--lenient"}
+						})
+
+						It("warns about unknown and deprecated attributes and pushes", func() {
+							Expect(executeErr).NotTo(HaveOccurred())
+
+							fullOutput := terminal.Decolorize(string(output.Contents()))
+							Expect(fullOutput).To(ContainSubstring("manifest.yml:4:3: Unknown attribute 'applications[0].memroy'"))
+							Expect(fullOutput).To(ContainSubstring("manifest.yml:5:3: Attribute 'applications[0].host' is deprecated; use 'routes' instead"))
+							Expect(appRepo.CreateCallCount()).To(Equal(1))
+						})
+					})
+				})
+
 				Context("when the no-route option is set", func() {
 					Context("when provided the --no-route-flag", func() {
 						BeforeEach(func() {
@@ -1353,6 +1916,55 @@ var _ = Describe("Push Command", func() {
 						})
 					})
 
+					Context("when --parallel is passed", func() {
+						BeforeEach(func() {
+							args = []string{"--parallel", "2"}
+						})
+
+						It("prepares every app with its output labelled by app name", func() {
+							Expect(executeErr).NotTo(HaveOccurred())
+
+							Expect(appRepo.CreateCallCount()).To(Equal(2))
+							names := []string{*appRepo.CreateArgsForCall(0).Name, *appRepo.CreateArgsForCall(1).Name}
+							Expect(names).To(ConsistOf("app1", "app2"))
+
+							totalOutput := terminal.Decolorize(string(output.Contents()))
+							Expect(totalOutput).To(ContainSubstring("[app1] Creating app app1"))
+							Expect(totalOutput).To(ContainSubstring("[app2] Creating app app2"))
+							Expect(totalOutput).To(ContainSubstring("[app1] OK"))
+						})
+
+						It("starts the apps one at a time once all of them are prepared", func() {
+							Expect(executeErr).NotTo(HaveOccurred())
+
+							Expect(starter.ApplicationStartCallCount()).To(Equal(2))
+							firstApp, _, _ := starter.ApplicationStartArgsForCall(0)
+							secondApp, _, _ := starter.ApplicationStartArgsForCall(1)
+							Expect(firstApp.Name).To(Equal("app1"))
+							Expect(secondApp.Name).To(Equal("app2"))
+						})
+
+						Context("when preparing one of the apps fails", func() {
+							BeforeEach(func() {
+								appRepo.CreateStub = func(params models.AppParams) (models.Application, error) {
+									if *params.Name == "app2" {
+										return models.Application{}, errors.New("create failed")
+									}
+									a := models.Application{}
+									a.GUID = "app1-guid"
+									a.Name = "app1"
+									return a, nil
+								}
+							})
+
+							It("returns the error for that app and does not start any app", func() {
+								Expect(executeErr).To(HaveOccurred())
+								Expect(executeErr.Error()).To(ContainSubstring("app2: create failed"))
+								Expect(starter.ApplicationStartCallCount()).To(BeZero())
+							})
+						})
+					})
+
 					Context("when the given app is not in the manifest", func() {
 						BeforeEach(func() {
 							args = []string{"non-existant-app"}
@@ -1449,6 +2061,68 @@ var _ = Describe("Push Command", func() {
 				})
 			})
 
+			Context("when the -b flag is provided more than once", func() {
+				BeforeEach(func() {
+					args = []string{"-b", "go_buildpack", "-b", "binary_buildpack", "existing-app"}
+				})
+
+				It("sets the app's buildpacks instead of its buildpack", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					_, params := appRepo.UpdateArgsForCall(0)
+					Expect(params.BuildpackURL).To(BeNil())
+
+					Expect(appRepo.SetLifecycleCallCount()).To(Equal(1))
+					appGUID, lifecycle := appRepo.SetLifecycleArgsForCall(0)
+					Expect(appGUID).To(Equal(existingApp.GUID))
+					Expect(lifecycle).To(Equal(models.AppLifecycle{
+						Type:       models.AppLifecycleBuildpack,
+						Buildpacks: []string{"go_buildpack", "binary_buildpack"},
+					}))
+				})
+
+				Context("when one of them is 'default'", func() {
+					BeforeEach(func() {
+						args = []string{"-b", "default", "-b", "binary_buildpack", "existing-app"}
+					})
+
+					It("returns an error", func() {
+						Expect(executeErr).To(MatchError("Incorrect Usage: '-b default' cannot be combined with other buildpacks"))
+						Expect(appRepo.UpdateCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the -b flag is provided with --docker-image", func() {
+				BeforeEach(func() {
+					args = []string{"-b", "go_buildpack", "-o", "some-image", "existing-app"}
+				})
+
+				It("returns an error", func() {
+					Expect(executeErr).To(MatchError("Incorrect Usage: '--docker-image, -o' and '-b' cannot be used together"))
+				})
+			})
+
+			Context("when the --lifecycle flag is not a known lifecycle", func() {
+				BeforeEach(func() {
+					args = []string{"--lifecycle", "kpack", "existing-app"}
+				})
+
+				It("returns an error", func() {
+					Expect(executeErr).To(MatchError("Incorrect Usage: '--lifecycle' must be 'buildpack' or 'cnb'"))
+				})
+			})
+
+			Context("when the --lifecycle flag is provided with --docker-image", func() {
+				BeforeEach(func() {
+					args = []string{"--lifecycle", "cnb", "-o", "some-image", "existing-app"}
+				})
+
+				It("returns an error", func() {
+					Expect(executeErr).To(MatchError("Incorrect Usage: '--docker-image, -o' and '--lifecycle' cannot be used together"))
+				})
+			})
+
 			Context("when the -c flag is provided as 'null'", func() {
 				BeforeEach(func() {
 					args = []string{"-c", "null", "existing-app"}
@@ -1552,6 +2226,20 @@ var _ = Describe("Push Command", func() {
 					Expect(*params.BuildpackURL).To(Equal("https://github.com/heroku/heroku-buildpack-different.git"))
 					Expect(*params.StackGUID).To(Equal("differentStack-guid"))
 				})
+
+				Context("when the stack is given with --stack", func() {
+					BeforeEach(func() {
+						args = []string{"--stack", "differentStack", "existing-app"}
+					})
+
+					It("updates the stack of the app", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						Expect(stackRepo.FindByNameArgsForCall(0)).To(Equal("differentStack"))
+						_, params := appRepo.UpdateArgsForCall(0)
+						Expect(*params.StackGUID).To(Equal("differentStack-guid"))
+					})
+				})
 			})
 
 			Context("when the app has a route bound", func() {
@@ -1705,6 +2393,35 @@ var _ = Describe("Push Command", func() {
 					})
 				})
 
+				Context("when a service is given a binding name", func() {
+					BeforeEach(func() {
+						appRepo.ReadReturns(models.Application{}, errors.NewModelNotFoundError("App", "the-app"))
+						manifestRepo.ReadManifestReturns(&manifest.Manifest{
+							Data: generic.NewMap(map[interface{}]interface{}{
+								"applications": []interface{}{
+									generic.NewMap(map[interface{}]interface{}{
+										"name": "app1",
+										"services": []interface{}{
+											"app1-service",
+											map[interface{}]interface{}{"name": "named-service", "binding_name": "my-binding"},
+										},
+									}),
+								},
+							}),
+						}, nil)
+					})
+
+					It("binds that service under its binding name", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						Expect(serviceBinder.InstancesToBindTo).To(HaveLen(2))
+						Expect(serviceBinder.InstancesToBindTo[0].Name).To(Equal("app1-service"))
+						Expect(serviceBinder.BindingNames[0]).To(BeEmpty())
+						Expect(serviceBinder.InstancesToBindTo[1].Name).To(Equal("named-service"))
+						Expect(serviceBinder.BindingNames[1]).To(Equal("my-binding"))
+					})
+				})
+
 				Context("when the service instance can't be found", func() {
 					BeforeEach(func() {
 						serviceRepo.FindInstanceByNameReturns(models.ServiceInstance{}, errors.New("Error finding instance"))
@@ -1729,12 +2446,48 @@ var _ = Describe("Push Command", func() {
 				})
 			})
 
+			Context("when the staging timeout is not positive", func() {
+				BeforeEach(func() {
+					args = []string{"--staging-timeout", "0", "app-name"}
+				})
+
+				It("fails without pushing", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("Invalid staging timeout 0; it must be at least 1 second"))
+					Expect(appRepo.CreateCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the startup timeout is not positive", func() {
+				BeforeEach(func() {
+					args = []string{"--startup-timeout", "0", "app-name"}
+				})
+
+				It("fails without pushing", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("Invalid startup timeout 0; it must be at least 1 second"))
+					Expect(appRepo.CreateCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the number of retries is negative", func() {
+				BeforeEach(func() {
+					args = []string{"--retries", "-1", "app-name"}
+				})
+
+				It("fails without pushing", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("Invalid number of retries -1; it must not be negative"))
+					Expect(appRepo.CreateCallCount()).To(BeZero())
+				})
+			})
+
 			Context("displaying information about files being uploaded", func() {
 				BeforeEach(func() {
-					appfiles.CountFilesReturns(11)
-					zipper.ZipReturns(nil)
+					filesToUpload := make([]models.AppFileFields, 11)
+					zipper.ZipFilesReturns(nil)
 					zipper.GetZipSizeReturns(6100000, nil)
-					actor.GatherFilesReturns([]resources.AppFileResource{{Path: "path/to/app"}, {Path: "bar"}}, true, nil)
+					actor.GatherFilesReturns([]resources.AppFileResource{{Path: "path/to/app"}, {Path: "bar"}}, filesToUpload, nil)
 					args = []string{"appName"}
 				})
 
@@ -1921,5 +2674,919 @@ var _ = Describe("Push Command", func() {
 				})
 			})
 		})
+
+		Context("when sidecars are specified in the manifest", func() {
+			BeforeEach(func() {
+				m := &manifest.Manifest{
+					Path: "manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{
+						"applications": []interface{}{
+							generic.NewMap(map[interface{}]interface{}{
+								"name": "manifest-app-name",
+								"sidecars": []interface{}{
+									map[interface{}]interface{}{"name": "proxy", "command": "./proxy", "memory": "64M"},
+									map[interface{}]interface{}{"name": "shipper", "command": "./ship-logs"},
+								},
+							}),
+						},
+					}),
+				}
+				manifestRepo.ReadManifestReturns(m, nil)
+
+				appRepo.ReadReturns(models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "manifest-app-name", GUID: "app-guid"},
+				}, nil)
+				appRepo.UpdateReturns(models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "manifest-app-name", GUID: "app-guid"},
+				}, nil)
+				sidecarRepo.ListSidecarsReturns([]models.Sidecar{
+					{GUID: "proxy-guid", Name: "proxy"},
+					{GUID: "other-guid", Name: "other"},
+				}, nil)
+
+				args = []string{}
+			})
+
+			Context("when the API supports sidecars", func() {
+				BeforeEach(func() {
+					configRepo.SetAPIVersion(cf.SidecarsMinimumAPIVersion.String())
+				})
+
+				It("updates the sidecars the app has and creates the others", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(sidecarRepo.ListSidecarsArgsForCall(0)).To(Equal("app-guid"))
+
+					Expect(sidecarRepo.UpdateSidecarCallCount()).To(Equal(1))
+					guid, sidecar := sidecarRepo.UpdateSidecarArgsForCall(0)
+					Expect(guid).To(Equal("proxy-guid"))
+					Expect(sidecar).To(Equal(models.Sidecar{Name: "proxy", Command: "./proxy", ProcessTypes: []string{"web"}, MemoryInMB: 64}))
+
+					Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(1))
+					appGUID, sidecar := sidecarRepo.CreateSidecarArgsForCall(0)
+					Expect(appGUID).To(Equal("app-guid"))
+					Expect(sidecar.Name).To(Equal("shipper"))
+				})
+
+				Context("when a sidecar cannot be created", func() {
+					BeforeEach(func() {
+						sidecarRepo.CreateSidecarReturns(models.Sidecar{}, errors.New("create failed"))
+					})
+
+					It("fails", func() {
+						Expect(executeErr).To(MatchError("create failed"))
+					})
+				})
+			})
+
+			Context("when the API does not support sidecars", func() {
+				BeforeEach(func() {
+					configRepo.SetAPIVersion("2.65.0")
+				})
+
+				It("fails before creating the app", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("Manifest key 'sidecars' requires CF API version"))
+					Expect(appRepo.UpdateCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when buildpacks are specified in the manifest", func() {
+			BeforeEach(func() {
+				m := &manifest.Manifest{
+					Path: "manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{
+						"applications": []interface{}{
+							generic.NewMap(map[interface{}]interface{}{
+								"name":       "manifest-app-name",
+								"buildpacks": []interface{}{"go_buildpack", "binary_buildpack"},
+							}),
+						},
+					}),
+				}
+				manifestRepo.ReadManifestReturns(m, nil)
+
+				appRepo.ReadReturns(models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "manifest-app-name", GUID: "app-guid"},
+				}, nil)
+				appRepo.UpdateReturns(models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "manifest-app-name", GUID: "app-guid"},
+				}, nil)
+
+				args = []string{}
+			})
+
+			Context("when the API supports multiple buildpacks", func() {
+				BeforeEach(func() {
+					configRepo.SetAPIVersion(cf.MultipleBuildpacksMinimumAPIVersion.String())
+				})
+
+				It("sets the buildpacks of the app", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					Expect(appRepo.SetLifecycleCallCount()).To(Equal(1))
+					appGUID, lifecycle := appRepo.SetLifecycleArgsForCall(0)
+					Expect(appGUID).To(Equal("app-guid"))
+					Expect(lifecycle.Buildpacks).To(Equal([]string{"go_buildpack", "binary_buildpack"}))
+				})
+
+				Context("when a single -b flag is provided", func() {
+					BeforeEach(func() {
+						args = []string{"-b", "ruby_buildpack"}
+					})
+
+					It("uses the buildpack from the flag instead", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+						Expect(appRepo.SetLifecycleCallCount()).To(BeZero())
+
+						_, params := appRepo.UpdateArgsForCall(0)
+						Expect(*params.BuildpackURL).To(Equal("ruby_buildpack"))
+					})
+				})
+
+				Context("when setting the buildpacks fails", func() {
+					BeforeEach(func() {
+						appRepo.SetLifecycleReturns(errors.New("set failed"))
+					})
+
+					It("fails", func() {
+						Expect(executeErr).To(MatchError("set failed"))
+					})
+				})
+			})
+
+			Context("when the API does not support multiple buildpacks", func() {
+				BeforeEach(func() {
+					configRepo.SetAPIVersion("2.65.0")
+				})
+
+				It("fails before updating the app", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("Manifest key 'buildpacks' requires CF API version"))
+					Expect(appRepo.UpdateCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the cnb lifecycle is specified in the manifest", func() {
+			BeforeEach(func() {
+				m := &manifest.Manifest{
+					Path: "manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{
+						"applications": []interface{}{
+							generic.NewMap(map[interface{}]interface{}{
+								"name":       "manifest-app-name",
+								"lifecycle":  "cnb",
+								"buildpacks": []interface{}{"docker://registry.example.com/go-cnb"},
+							}),
+						},
+					}),
+				}
+				manifestRepo.ReadManifestReturns(m, nil)
+
+				appRepo.ReadReturns(models.Application{}, errors.NewModelNotFoundError("App", "manifest-app-name"))
+				appRepo.CreateWithLifecycleReturns(models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "manifest-app-name", GUID: "app-guid"},
+				}, nil)
+				appRepo.UpdateReturns(models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "manifest-app-name", GUID: "app-guid"},
+				}, nil)
+
+				os.Setenv("CNB_CREDENTIALS", `{"registry.example.com": {"username": "some-user", "password": "some-password"}}`)
+
+				args = []string{}
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("CNB_CREDENTIALS")
+			})
+
+			Context("when the API supports the cnb lifecycle", func() {
+				BeforeEach(func() {
+					configRepo.SetAPIVersion(cf.CNBLifecycleMinimumAPIVersion.String())
+				})
+
+				It("creates the app with the cnb lifecycle and the credentials from the environment", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+
+					Expect(appRepo.CreateCallCount()).To(BeZero())
+					Expect(appRepo.CreateWithLifecycleCallCount()).To(Equal(1))
+					name, _, lifecycle := appRepo.CreateWithLifecycleArgsForCall(0)
+					Expect(name).To(Equal("manifest-app-name"))
+					Expect(lifecycle).To(Equal(models.AppLifecycle{
+						Type:       models.AppLifecycleCNB,
+						Buildpacks: []string{"docker://registry.example.com/go-cnb"},
+						Credentials: map[string]models.RegistryCredentials{
+							"registry.example.com": {Username: "some-user", Password: "some-password"},
+						},
+					}))
+
+					Expect(appRepo.UpdateCallCount()).To(Equal(1))
+					appGUID, params := appRepo.UpdateArgsForCall(0)
+					Expect(appGUID).To(Equal("app-guid"))
+					Expect(params.BuildpackURL).To(BeNil())
+					Expect(appRepo.SetLifecycleCallCount()).To(BeZero())
+				})
+
+				Context("when the app exists", func() {
+					BeforeEach(func() {
+						appRepo.ReadReturns(models.Application{
+							ApplicationFields: models.ApplicationFields{Name: "manifest-app-name", GUID: "app-guid"},
+						}, nil)
+						appRepo.GetLifecycleReturns(models.AppLifecycle{Type: models.AppLifecycleCNB}, nil)
+					})
+
+					It("sets the lifecycle of the app", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+
+						Expect(appRepo.GetLifecycleArgsForCall(0)).To(Equal("app-guid"))
+						Expect(appRepo.CreateWithLifecycleCallCount()).To(BeZero())
+						Expect(appRepo.SetLifecycleCallCount()).To(Equal(1))
+						appGUID, lifecycle := appRepo.SetLifecycleArgsForCall(0)
+						Expect(appGUID).To(Equal("app-guid"))
+						Expect(lifecycle.Type).To(Equal(models.AppLifecycleCNB))
+						Expect(lifecycle.Buildpacks).To(Equal([]string{"docker://registry.example.com/go-cnb"}))
+					})
+
+					Context("when it uses the buildpack lifecycle", func() {
+						BeforeEach(func() {
+							appRepo.GetLifecycleReturns(models.AppLifecycle{Type: models.AppLifecycleBuildpack}, nil)
+						})
+
+						It("fails before changing the app", func() {
+							Expect(executeErr).To(MatchError("App manifest-app-name uses the buildpack lifecycle, which cannot be changed to cnb. Delete the app and push it again, or push it under a new name."))
+							Expect(appRepo.UpdateCallCount()).To(BeZero())
+							Expect(appRepo.SetLifecycleCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when its lifecycle cannot be read", func() {
+						BeforeEach(func() {
+							appRepo.GetLifecycleReturns(models.AppLifecycle{}, errors.New("lifecycle-error"))
+						})
+
+						It("fails before changing the app", func() {
+							Expect(executeErr).To(MatchError("lifecycle-error"))
+							Expect(appRepo.UpdateCallCount()).To(BeZero())
+						})
+					})
+				})
+
+				Context("when the credentials in the environment are not JSON", func() {
+					BeforeEach(func() {
+						os.Setenv("CNB_CREDENTIALS", "some-user:some-password")
+					})
+
+					It("fails before creating the app", func() {
+						Expect(executeErr).To(HaveOccurred())
+						Expect(executeErr.Error()).To(ContainSubstring("Environment variable CNB_CREDENTIALS must be a JSON object"))
+						Expect(appRepo.CreateWithLifecycleCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the API does not support the cnb lifecycle", func() {
+				BeforeEach(func() {
+					configRepo.SetAPIVersion(cf.MultipleBuildpacksMinimumAPIVersion.String())
+				})
+
+				It("fails before creating the app", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("Manifest key 'lifecycle: cnb' requires CF API version"))
+					Expect(appRepo.CreateWithLifecycleCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when process health checks are specified", func() {
+			BeforeEach(func() {
+				m := &manifest.Manifest{
+					Path: "manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{
+						"applications": []interface{}{
+							generic.NewMap(map[interface{}]interface{}{
+								"name": "manifest-app-name",
+								"processes": []interface{}{
+									map[interface{}]interface{}{"type": "worker", "health-check-type": "process"},
+									map[interface{}]interface{}{"type": "clock", "health-check-type": "none"},
+								},
+							}),
+						},
+					}),
+				}
+				manifestRepo.ReadManifestReturns(m, nil)
+
+				appRepo.ReadReturns(models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "manifest-app-name", GUID: "app-guid"},
+				}, nil)
+				appRepo.UpdateReturns(models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "manifest-app-name", GUID: "app-guid"},
+				}, nil)
+				processRepo.ListProcessesReturns([]models.Process{
+					{GUID: "web-guid", Type: "web"},
+					{GUID: "worker-guid", Type: "worker"},
+				}, nil)
+
+				args = []string{"-u", "http", "--health-check-http-endpoint", "/health", "--health-check-invocation-timeout", "5"}
+			})
+
+			Context("when the API supports the processes API", func() {
+				BeforeEach(func() {
+					configRepo.SetAPIVersion(cf.ProcessTypesMinimumAPIVersion.String())
+				})
+
+				It("updates the health checks of the processes the app has", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(processRepo.ListProcessesArgsForCall(0)).To(Equal("app-guid"))
+					Expect(processRepo.UpdateProcessHealthCheckCallCount()).To(Equal(2))
+
+					guid, healthCheck := processRepo.UpdateProcessHealthCheckArgsForCall(0)
+					Expect(guid).To(Equal("web-guid"))
+					Expect(*healthCheck.Type).To(Equal("http"))
+					Expect(*healthCheck.HTTPEndpoint).To(Equal("/health"))
+					Expect(*healthCheck.InvocationTimeout).To(Equal(5))
+
+					guid, healthCheck = processRepo.UpdateProcessHealthCheckArgsForCall(1)
+					Expect(guid).To(Equal("worker-guid"))
+					Expect(*healthCheck.Type).To(Equal("process"))
+					Expect(healthCheck.HTTPEndpoint).To(BeNil())
+				})
+
+				It("warns about the process types the app does not have yet", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(ui.WarnCallCount()).To(Equal(1))
+					message, _ := ui.WarnArgsForCall(0)
+					Expect(message).To(ContainSubstring("Process clock of app manifest-app-name does not exist yet"))
+				})
+
+				Context("when a health check cannot be updated", func() {
+					BeforeEach(func() {
+						processRepo.UpdateProcessHealthCheckReturns(models.Process{}, errors.New("update failed"))
+					})
+
+					It("fails", func() {
+						Expect(executeErr).To(MatchError("update failed"))
+					})
+				})
+			})
+
+			Context("when the API does not support the processes API", func() {
+				BeforeEach(func() {
+					configRepo.SetAPIVersion("2.65.0")
+				})
+
+				It("fails before creating the app", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("Process health check configuration requires CF API version"))
+					Expect(appRepo.UpdateCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when processes are scaled in the manifest", func() {
+			BeforeEach(func() {
+				manifestRepo.ReadManifestReturns(&manifest.Manifest{
+					Path: "manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{
+						"applications": []interface{}{
+							generic.NewMap(map[interface{}]interface{}{
+								"name": "manifest-app-name",
+								"processes": []interface{}{
+									map[interface{}]interface{}{"type": "worker", "instances": 3, "memory": "256M", "disk_quota": "512M"},
+									map[interface{}]interface{}{"type": "clock", "instances": 1},
+								},
+							}),
+						},
+					}),
+				}, nil)
+
+				appRepo.ReadReturns(models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "manifest-app-name", GUID: "app-guid"},
+				}, nil)
+				appRepo.UpdateReturns(models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "manifest-app-name", GUID: "app-guid"},
+				}, nil)
+				processRepo.ListProcessesReturns([]models.Process{
+					{GUID: "web-guid", Type: "web"},
+					{GUID: "worker-guid", Type: "worker"},
+				}, nil)
+
+				args = []string{}
+			})
+
+			Context("when the API supports the processes API", func() {
+				BeforeEach(func() {
+					configRepo.SetAPIVersion(cf.ProcessTypesMinimumAPIVersion.String())
+				})
+
+				It("scales the processes the app has", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(processRepo.ScaleProcessCallCount()).To(Equal(1))
+
+					appGUID, processType, params := processRepo.ScaleProcessArgsForCall(0)
+					Expect(appGUID).To(Equal("app-guid"))
+					Expect(processType).To(Equal("worker"))
+					Expect(*params.Instances).To(Equal(3))
+					Expect(*params.MemoryInMB).To(Equal(int64(256)))
+					Expect(*params.DiskInMB).To(Equal(int64(512)))
+				})
+
+				It("warns about the process types the app does not have yet", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(ui.WarnCallCount()).To(Equal(1))
+					message, _ := ui.WarnArgsForCall(0)
+					Expect(message).To(ContainSubstring("Process clock of app manifest-app-name does not exist yet, so it was not scaled"))
+				})
+
+				Context("when a process cannot be scaled", func() {
+					BeforeEach(func() {
+						processRepo.ScaleProcessReturns(models.Process{}, errors.New("scale failed"))
+					})
+
+					It("fails", func() {
+						Expect(executeErr).To(MatchError("scale failed"))
+					})
+				})
+			})
+
+			Context("when the API does not support the processes API", func() {
+				BeforeEach(func() {
+					configRepo.SetAPIVersion("2.65.0")
+				})
+
+				It("fails before creating the app", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("Process health check configuration requires CF API version"))
+					Expect(appRepo.UpdateCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when metadata is specified in the manifest", func() {
+			BeforeEach(func() {
+				manifestRepo.ReadManifestReturns(&manifest.Manifest{
+					Path: "manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{
+						"applications": []interface{}{
+							generic.NewMap(map[interface{}]interface{}{
+								"name": "manifest-app-name",
+								"metadata": map[interface{}]interface{}{
+									"labels":      map[interface{}]interface{}{"team": "payments"},
+									"annotations": map[interface{}]interface{}{"contact": "payments@example.com"},
+								},
+							}),
+						},
+					}),
+				}, nil)
+
+				appRepo.ReadReturns(models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "manifest-app-name", GUID: "app-guid"},
+				}, nil)
+				appRepo.UpdateReturns(models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "manifest-app-name", GUID: "app-guid"},
+				}, nil)
+
+				args = []string{}
+			})
+
+			Context("when the API supports metadata", func() {
+				BeforeEach(func() {
+					configRepo.SetAPIVersion(cf.MetadataMinimumAPIVersion.String())
+				})
+
+				It("sets the labels and annotations of the app", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(metadataRepo.UpdateMetadataCallCount()).To(Equal(1))
+
+					resourcePath, guid, update := metadataRepo.UpdateMetadataArgsForCall(0)
+					Expect(resourcePath).To(Equal("/v3/apps"))
+					Expect(guid).To(Equal("app-guid"))
+					Expect(*update.Labels["team"]).To(Equal("payments"))
+					Expect(*update.Annotations["contact"]).To(Equal("payments@example.com"))
+				})
+
+				Context("when the metadata cannot be updated", func() {
+					BeforeEach(func() {
+						metadataRepo.UpdateMetadataReturns(errors.New("update failed"))
+					})
+
+					It("fails", func() {
+						Expect(executeErr).To(MatchError("update failed"))
+					})
+				})
+			})
+
+			Context("when the API does not support metadata", func() {
+				BeforeEach(func() {
+					configRepo.SetAPIVersion("2.65.0")
+				})
+
+				It("fails before creating the app", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("Manifest key 'metadata' requires CF API version"))
+					Expect(appRepo.UpdateCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when readiness checks are specified", func() {
+			BeforeEach(func() {
+				manifestRepo.ReadManifestReturns(&manifest.Manifest{
+					Path: "manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{
+						"applications": []interface{}{
+							generic.NewMap(map[interface{}]interface{}{"name": "app-name"}),
+						},
+					}),
+				}, nil)
+
+				appRepo.ReadReturns(models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "app-name", GUID: "app-guid"},
+				}, nil)
+				appRepo.UpdateReturns(models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "app-name", GUID: "app-guid"},
+				}, nil)
+				processRepo.ListProcessesReturns([]models.Process{
+					{GUID: "web-guid", Type: "web"},
+				}, nil)
+
+				args = []string{"--readiness-health-check-type", "http", "--readiness-health-check-http-endpoint", "/ready", "--readiness-health-check-interval", "10"}
+			})
+
+			Context("when the API supports readiness checks", func() {
+				BeforeEach(func() {
+					configRepo.SetAPIVersion(cf.ReadinessHealthChecksMinimumAPIVersion.String())
+				})
+
+				It("updates only the readiness check of the web process", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(processRepo.UpdateProcessHealthCheckCallCount()).To(BeZero())
+					Expect(processRepo.UpdateProcessReadinessHealthCheckCallCount()).To(Equal(1))
+
+					guid, healthCheck := processRepo.UpdateProcessReadinessHealthCheckArgsForCall(0)
+					Expect(guid).To(Equal("web-guid"))
+					Expect(*healthCheck.Type).To(Equal("http"))
+					Expect(*healthCheck.HTTPEndpoint).To(Equal("/ready"))
+					Expect(*healthCheck.Interval).To(Equal(10))
+				})
+			})
+
+			Context("when the API does not support readiness checks", func() {
+				BeforeEach(func() {
+					configRepo.SetAPIVersion(cf.SidecarsMinimumAPIVersion.String())
+				})
+
+				It("fails before creating the app", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("Readiness health check configuration requires CF API version"))
+					Expect(appRepo.UpdateCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the readiness check type is not valid", func() {
+				BeforeEach(func() {
+					args = []string{"--readiness-health-check-type", "none"}
+				})
+
+				It("returns an error", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("Invalid readiness-health-check-type param: none"))
+				})
+			})
+		})
+
+		Context("when a deployment strategy is given", func() {
+			var deploymentActor *actorsfakes.FakeDeploymentActor
+
+			BeforeEach(func() {
+				manifestRepo.ReadManifestReturns(&manifest.Manifest{
+					Path: "manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{
+						"applications": []interface{}{
+							generic.NewMap(map[interface{}]interface{}{"name": "app-name"}),
+						},
+					}),
+				}, nil)
+
+				runningApp := models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "app-name", GUID: "app-guid", State: "started"},
+				}
+				appRepo.ReadReturns(runningApp, nil)
+				appRepo.UpdateReturns(runningApp, nil)
+
+				deploymentActor = new(actorsfakes.FakeDeploymentActor)
+				deploymentActor.StageLatestPackageReturns(models.Build{GUID: "build-guid", State: models.BuildStateStaged, DropletGUID: "droplet-guid"}, nil)
+				deploymentActor.DeployReturns(models.Deployment{GUID: "deployment-guid", StatusValue: "ACTIVE", StatusReason: "DEPLOYING"}, nil)
+				deploymentActor.WaitForDeploymentStub = func(deployment models.Deployment, progress func(models.Deployment)) (models.Deployment, error) {
+					if deploymentActor.WaitForDeploymentCallCount() == 1 {
+						return models.Deployment{GUID: "deployment-guid", StatusValue: "ACTIVE", StatusReason: "PAUSED"}, nil
+					}
+					return models.Deployment{GUID: "deployment-guid", StatusValue: "FINALIZED", StatusReason: "DEPLOYED"}, nil
+				}
+				deploymentActor.ContinueDeploymentReturns(models.Deployment{GUID: "deployment-guid", StatusValue: "ACTIVE", StatusReason: "PAUSED"}, nil)
+				deps.DeploymentActor = deploymentActor
+			})
+
+			Context("when pushing a canary deployment", func() {
+				BeforeEach(func() {
+					args = []string{"--strategy", "canary", "--instance-steps", "10, 50,100"}
+				})
+
+				It("stages the new bits and deploys them without stopping the app", func() {
+					Expect(stopper.ApplicationStopCallCount()).To(BeZero())
+					Expect(starter.ApplicationStartCallCount()).To(BeZero())
+					Expect(deploymentActor.StageLatestPackageArgsForCall(0)).To(Equal("app-guid"))
+
+					appGUID, params := deploymentActor.DeployArgsForCall(0)
+					Expect(appGUID).To(Equal("app-guid"))
+					Expect(params).To(Equal(models.DeploymentParams{
+						DropletGUID:   "droplet-guid",
+						Strategy:      "canary",
+						InstanceSteps: []int{10, 50, 100},
+					}))
+				})
+
+				Context("when the user confirms at the pause", func() {
+					BeforeEach(func() {
+						ui.ConfirmReturns(true)
+					})
+
+					It("continues the deployment and waits for it to finish", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+						Expect(ui.ConfirmCallCount()).To(Equal(1))
+						Expect(deploymentActor.ContinueDeploymentArgsForCall(0)).To(Equal("app-guid"))
+						Expect(deploymentActor.WaitForDeploymentCallCount()).To(Equal(2))
+					})
+				})
+
+				Context("when the user does not confirm", func() {
+					BeforeEach(func() {
+						ui.ConfirmReturns(false)
+					})
+
+					It("leaves the deployment paused", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+						Expect(deploymentActor.ContinueDeploymentCallCount()).To(BeZero())
+						Expect(ui.SayArgsForCall(ui.SayCallCount() - 1)).To(ContainSubstring("is paused"))
+					})
+				})
+
+				Context("when --wait is given", func() {
+					BeforeEach(func() {
+						args = append(args, "--wait")
+					})
+
+					It("continues without asking", func() {
+						Expect(executeErr).NotTo(HaveOccurred())
+						Expect(ui.ConfirmCallCount()).To(BeZero())
+						Expect(deploymentActor.ContinueDeploymentCallCount()).To(Equal(1))
+					})
+				})
+			})
+
+			Context("when the app is not running yet", func() {
+				BeforeEach(func() {
+					stoppedApp := models.Application{
+						ApplicationFields: models.ApplicationFields{Name: "app-name", GUID: "app-guid", State: "stopped"},
+					}
+					appRepo.ReadReturns(stoppedApp, nil)
+					appRepo.UpdateReturns(stoppedApp, nil)
+					args = []string{"--strategy", "rolling"}
+				})
+
+				It("starts the app as usual", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(deploymentActor.DeployCallCount()).To(BeZero())
+					Expect(starter.ApplicationStartCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when staging fails", func() {
+				BeforeEach(func() {
+					deploymentActor.StageLatestPackageReturns(models.Build{GUID: "build-guid", State: models.BuildStateFailed, Error: "no buildpack"}, nil)
+					args = []string{"--strategy", "rolling"}
+				})
+
+				It("does not deploy", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("App app-name failed to stage: no buildpack"))
+					Expect(deploymentActor.DeployCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the instance steps are not increasing", func() {
+				BeforeEach(func() {
+					args = []string{"--strategy", "canary", "--instance-steps", "50,10"}
+				})
+
+				It("fails before updating the app", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("Invalid instance steps 50,10"))
+					Expect(appRepo.UpdateCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when --instance-steps is given without a canary strategy", func() {
+				BeforeEach(func() {
+					args = []string{"--strategy", "rolling", "--instance-steps", "10"}
+				})
+
+				It("returns an error", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("--instance-steps can only be used with --strategy canary"))
+				})
+			})
+
+			Context("when --no-start is given", func() {
+				BeforeEach(func() {
+					args = []string{"--strategy", "rolling", "--no-start"}
+				})
+
+				It("returns an error", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("--strategy cannot be used with --no-start"))
+				})
+			})
+		})
+
+		Context("when hooks are specified", func() {
+			var (
+				hookRunner        *hooksfakes.FakeRunner
+				updatesBeforeHook []int
+			)
+
+			BeforeEach(func() {
+				manifestRepo.ReadManifestReturns(&manifest.Manifest{
+					Path: "manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{
+						"applications": []interface{}{
+							generic.NewMap(map[interface{}]interface{}{
+								"name": "app-name",
+								"path": "/some/app",
+								"hooks": map[interface{}]interface{}{
+									"pre-push":  "npm run build",
+									"post-push": "./smoke-test.sh",
+									"timeout":   30,
+								},
+							}),
+						},
+					}),
+				}, nil)
+
+				app := models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "app-name", GUID: "app-guid"},
+					Routes: []models.RouteSummary{
+						{Host: "app-name", Domain: models.DomainFields{Name: "example.com"}},
+					},
+				}
+				appRepo.ReadReturns(app, nil)
+				appRepo.UpdateReturns(app, nil)
+
+				updatesBeforeHook = []int{}
+				hookRunner = new(hooksfakes.FakeRunner)
+				hookRunner.RunStub = func(hooks.Hook) error {
+					updatesBeforeHook = append(updatesBeforeHook, appRepo.UpdateCallCount())
+					return nil
+				}
+				deps.HookRunner = hookRunner
+
+				args = []string{}
+			})
+
+			It("runs the pre-push script before updating the app and the post-push script once it has started", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+				Expect(hookRunner.RunCallCount()).To(Equal(2))
+				Expect(updatesBeforeHook).To(Equal([]int{0, 1}))
+				Expect(starter.ApplicationStartCallCount()).To(Equal(1))
+
+				preHook := hookRunner.RunArgsForCall(0)
+				Expect(preHook.Script).To(Equal("npm run build"))
+				Expect(preHook.Dir).To(Equal("/some/app"))
+				Expect(preHook.Timeout).To(Equal(30 * time.Second))
+				Expect(preHook.Env).To(ConsistOf(
+					"CF_HOOK=pre-push",
+					"CF_APP_NAME=app-name",
+					"CF_APP_GUID=app-guid",
+					"CF_APP_ROUTES=app-name.example.com",
+					"CF_ORG=my-org",
+					"CF_SPACE=my-space",
+				))
+
+				postHook := hookRunner.RunArgsForCall(1)
+				Expect(postHook.Script).To(Equal("./smoke-test.sh"))
+				Expect(postHook.Env).To(ContainElement("CF_HOOK=post-push"))
+			})
+
+			Context("when the scripts are given as flags", func() {
+				BeforeEach(func() {
+					args = []string{"--pre-push-script", "make assets", "--post-push-script", "make smoke"}
+				})
+
+				It("runs them instead of those in the manifest", func() {
+					Expect(executeErr).NotTo(HaveOccurred())
+					Expect(hookRunner.RunArgsForCall(0).Script).To(Equal("make assets"))
+					Expect(hookRunner.RunArgsForCall(1).Script).To(Equal("make smoke"))
+				})
+			})
+
+			Context("when the app does not exist yet", func() {
+				BeforeEach(func() {
+					appRepo.ReadReturns(models.Application{}, errors.NewModelNotFoundError("App", "app-name"))
+					appRepo.CreateReturns(models.Application{
+						ApplicationFields: models.ApplicationFields{Name: "app-name", GUID: "app-guid"},
+					}, nil)
+				})
+
+				It("runs the pre-push script without a GUID or routes before creating the app", func() {
+					Expect(hookRunner.RunCallCount()).To(BeNumerically(">=", 1))
+
+					preHook := hookRunner.RunArgsForCall(0)
+					Expect(preHook.Env).To(ContainElement("CF_APP_GUID="))
+					Expect(preHook.Env).To(ContainElement("CF_APP_ROUTES="))
+					Expect(appRepo.CreateCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the pre-push script fails", func() {
+				BeforeEach(func() {
+					hookRunner.RunStub = nil
+					hookRunner.RunReturns(errors.New("exit status 1"))
+				})
+
+				It("does not push the app", func() {
+					Expect(executeErr).To(MatchError("Pre-push hook of app app-name failed: exit status 1"))
+					Expect(hookRunner.RunCallCount()).To(Equal(1))
+					Expect(appRepo.UpdateCallCount()).To(BeZero())
+					Expect(actor.ProcessPathCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the post-push script fails", func() {
+				BeforeEach(func() {
+					hookRunner.RunStub = func(hook hooks.Hook) error {
+						if strings.Contains(hook.Script, "smoke") {
+							return errors.New("exit status 2")
+						}
+						return nil
+					}
+				})
+
+				It("fails, saying that the app has been pushed", func() {
+					Expect(executeErr).To(HaveOccurred())
+					Expect(executeErr.Error()).To(ContainSubstring("Post-push hook of app app-name failed: exit status 2"))
+					Expect(executeErr.Error()).To(ContainSubstring("The app has been pushed."))
+					Expect(starter.ApplicationStartCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the push is a dry run", func() {
+				BeforeEach(func() {
+					args = []string{"--dry-run"}
+				})
+
+				It("does not run the scripts", func() {
+					Expect(hookRunner.RunCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when a plugin pushes the apps", func() {
+			var pushedApps []plugin_models.PushAppModel
+
+			BeforeEach(func() {
+				manifestRepo.ReadManifestReturns(&manifest.Manifest{
+					Path: "manifest.yml",
+					Data: generic.NewMap(map[interface{}]interface{}{
+						"applications": []interface{}{
+							generic.NewMap(map[interface{}]interface{}{"name": "app-name"}),
+						},
+					}),
+				}, nil)
+
+				app := models.Application{
+					ApplicationFields: models.ApplicationFields{Name: "app-name", GUID: "app-guid", State: "started"},
+					Routes: []models.RouteSummary{
+						{Host: "app-name", Domain: models.DomainFields{Name: "example.com"}},
+					},
+				}
+				appRepo.ReadReturns(app, nil)
+				appRepo.UpdateReturns(app, nil)
+
+				pushedApps = []plugin_models.PushAppModel{}
+				deps.PluginModels = &commandregistry.PluginModels{PushedApps: &pushedApps}
+				pluginCall = true
+				args = []string{}
+			})
+
+			It("gives the plugin the apps it pushed", func() {
+				Expect(executeErr).NotTo(HaveOccurred())
+				Expect(pushedApps).To(Equal([]plugin_models.PushAppModel{{
+					Name:   "app-name",
+					Guid:   "app-guid",
+					State:  "started",
+					Routes: []string{"app-name.example.com"},
+				}}))
+			})
+		})
 	})
 })
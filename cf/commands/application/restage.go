@@ -3,6 +3,8 @@ package application
 import (
 	"fmt"
 
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
 	"code.cloudfoundry.org/cli/cf/api/applications"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -18,7 +20,9 @@ type Restage struct {
 	ui                terminal.UI
 	config            coreconfig.Reader
 	appRepo           applications.Repository
+	deploymentActor   actors.DeploymentActor
 	appStagingWatcher StagingWatcher
+	restarter         Restarter
 }
 
 func init() {
@@ -31,8 +35,13 @@ func (cmd *Restage) MetaData() commandregistry.CommandMetadata {
 		ShortName:   "rg",
 		Description: T("Restage an app"),
 		Usage: []string{
-			T("CF_NAME restage APP_NAME"),
+			T("CF_NAME restage APP_NAME [--strategy rolling [--max-in-flight NUM]]"),
 		},
+		Examples: []string{
+			"CF_NAME restage my-app",
+			"CF_NAME restage my-app --strategy rolling --max-in-flight 2",
+		},
+		Flags: deploymentFlags(),
 	}
 }
 
@@ -42,11 +51,21 @@ func (cmd *Restage) Requirements(requirementsFactory requirements.Factory, fc fl
 		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
 	}
 
+	deployment, err := deploymentParams(fc, []string{models.DeploymentStrategyRolling})
+	if err != nil {
+		cmd.ui.Failed(fmt.Sprintf(T("Incorrect Usage:")+" %s\n\n%s", err.Error(), commandregistry.Commands.CommandUsage("restage")))
+		return nil, err
+	}
+
 	reqs := []requirements.Requirement{
 		requirementsFactory.NewLoginRequirement(),
 		requirementsFactory.NewTargetedSpaceRequirement(),
 	}
 
+	if deployment != nil {
+		reqs = append(reqs, requirementsFactory.NewMinAPIVersionRequirement("Option '--strategy'", cf.DeploymentsMinimumAPIVersion))
+	}
+
 	return reqs, nil
 }
 
@@ -54,12 +73,17 @@ func (cmd *Restage) SetDependency(deps commandregistry.Dependency, pluginCall bo
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
+	cmd.deploymentActor = deps.DeploymentActor
 
 	//get command from registry for dependency
 	commandDep := commandregistry.Commands.FindCommand("start")
 	commandDep = commandDep.SetDependency(deps, false)
 	cmd.appStagingWatcher = commandDep.(StagingWatcher)
 
+	commandDep = commandregistry.Commands.FindCommand("restart")
+	commandDep = commandDep.SetDependency(deps, false)
+	cmd.restarter = commandDep.(Restarter)
+
 	return cmd
 }
 
@@ -77,6 +101,15 @@ func (cmd *Restage) Execute(c flags.FlagContext) error {
 			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
 		}))
 
+	deployment, err := deploymentParams(c, []string{models.DeploymentStrategyRolling})
+	if err != nil {
+		return err
+	}
+
+	if deployment != nil {
+		return cmd.rollingRestage(app, deployment.MaxInFlight)
+	}
+
 	app.PackageState = ""
 
 	_, err = cmd.appStagingWatcher.WatchStaging(app, cmd.config.OrganizationFields().Name, cmd.config.SpaceFields().Name, func(app models.Application) (models.Application, error) {
@@ -93,3 +126,35 @@ func (cmd *Restage) Execute(c flags.FlagContext) error {
 	}
 	return nil
 }
+
+// rollingRestage stages the newest package of the app into a new droplet
+// while the app keeps running on its current one, then deploys the new
+// droplet a few instances at a time.
+func (cmd *Restage) rollingRestage(app models.Application, maxInFlight *int) error {
+	dropletGUID, err := stageNewDroplet(cmd.ui, cmd.deploymentActor, app)
+	if err != nil {
+		return err
+	}
+
+	return cmd.restarter.ApplicationDeploy(app, dropletGUID, maxInFlight)
+}
+
+// stageNewDroplet stages the newest package of the app without stopping it,
+// returning the guid of the droplet to deploy.
+func stageNewDroplet(ui terminal.UI, deploymentActor actors.DeploymentActor, app models.Application) (string, error) {
+	ui.Say(T("Staging app {{.AppName}}...", map[string]interface{}{"AppName": terminal.EntityNameColor(app.Name)}))
+
+	build, err := deploymentActor.StageLatestPackage(app.GUID)
+	if err != nil {
+		return "", err
+	}
+
+	if build.State != models.BuildStateStaged {
+		return "", errors.New(T("App {{.AppName}} failed to stage: {{.Error}}", map[string]interface{}{
+			"AppName": app.Name,
+			"Error":   build.Error,
+		}))
+	}
+
+	return build.DropletGUID, nil
+}
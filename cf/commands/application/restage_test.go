@@ -1,8 +1,10 @@
 package application_test
 
 import (
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
 	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/application/applicationfakes"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/errors"
 	"code.cloudfoundry.org/cli/cf/flags"
@@ -25,17 +27,22 @@ var _ = Describe("restage command", func() {
 		configRepo          coreconfig.Repository
 		requirementsFactory *requirementsfakes.FakeFactory
 		stagingWatcher      *fakeStagingWatcher
+		restarter           *applicationfakes.FakeRestarter
+		deploymentActor     *actorsfakes.FakeDeploymentActor
 		OriginalCommand     commandregistry.Command
+		originalRestart     commandregistry.Command
 		deps                commandregistry.Dependency
 	)
 
 	updateCommandDependency := func(pluginCall bool) {
 		deps.UI = ui
 		deps.RepoLocator = deps.RepoLocator.SetApplicationRepository(appRepo)
+		deps.DeploymentActor = deploymentActor
 		deps.Config = configRepo
 
 		//inject fake 'command dependency' into registry
 		commandregistry.Register(stagingWatcher)
+		commandregistry.Register(restarter)
 
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("restage").SetDependency(deps, pluginCall))
 	}
@@ -56,12 +63,20 @@ var _ = Describe("restage command", func() {
 
 		//save original command and restore later
 		OriginalCommand = commandregistry.Commands.FindCommand("start")
+		originalRestart = commandregistry.Commands.FindCommand("restart")
 
 		stagingWatcher = &fakeStagingWatcher{}
+		restarter = new(applicationfakes.FakeRestarter)
+		restarter.SetDependencyStub = func(_ commandregistry.Dependency, _ bool) commandregistry.Command {
+			return restarter
+		}
+		restarter.MetaDataReturns(commandregistry.CommandMetadata{Name: "restart"})
+		deploymentActor = new(actorsfakes.FakeDeploymentActor)
 	})
 
 	AfterEach(func() {
 		commandregistry.Register(OriginalCommand)
+		commandregistry.Register(originalRestart)
 	})
 
 	runCommand := func(args ...string) bool {
@@ -126,6 +141,34 @@ var _ = Describe("restage command", func() {
 			Expect(stagingWatcher.orgName).To(Equal(configRepo.OrganizationFields().Name))
 			Expect(stagingWatcher.spaceName).To(Equal(configRepo.SpaceFields().Name))
 		})
+
+		Context("with a rolling strategy", func() {
+			BeforeEach(func() {
+				requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+				deploymentActor.StageLatestPackageReturns(models.Build{GUID: "build-guid", State: models.BuildStateStaged, DropletGUID: "droplet-guid"}, nil)
+			})
+
+			It("stages a new droplet and deploys it without stopping the app", func() {
+				Expect(runCommand("my-app", "--strategy", "rolling", "--max-in-flight", "3")).To(BeTrue())
+				Expect(appRepo.CreateRestageRequestCallCount()).To(BeZero())
+
+				Expect(deploymentActor.StageLatestPackageArgsForCall(0)).To(Equal("the-app-guid"))
+
+				deployedApp, dropletGUID, maxInFlight := restarter.ApplicationDeployArgsForCall(0)
+				Expect(deployedApp).To(Equal(app))
+				Expect(dropletGUID).To(Equal("droplet-guid"))
+				Expect(*maxInFlight).To(Equal(3))
+			})
+
+			It("fails without deploying when staging fails", func() {
+				deploymentActor.StageLatestPackageReturns(models.Build{GUID: "build-guid", State: models.BuildStateFailed, Error: "buildpack compile failed"}, nil)
+				Expect(runCommand("my-app", "--strategy", "rolling")).To(BeFalse())
+				Expect(restarter.ApplicationDeployCallCount()).To(BeZero())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"App my-app failed to stage: buildpack compile failed"},
+				))
+			})
+		})
 	})
 })
 
@@ -2,9 +2,13 @@ package application
 
 import (
 	"fmt"
+	"strings"
 
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/cf/models"
@@ -17,14 +21,16 @@ import (
 type Restarter interface {
 	commandregistry.Command
 	ApplicationRestart(app models.Application, orgName string, spaceName string) error
+	ApplicationDeploy(app models.Application, dropletGUID string, maxInFlight *int) error
 }
 
 type Restart struct {
-	ui      terminal.UI
-	config  coreconfig.Reader
-	starter Starter
-	stopper Stopper
-	appReq  requirements.ApplicationRequirement
+	ui              terminal.UI
+	config          coreconfig.Reader
+	starter         Starter
+	stopper         Stopper
+	appReq          requirements.ApplicationRequirement
+	deploymentActor actors.DeploymentActor
 }
 
 func init() {
@@ -37,9 +43,62 @@ func (cmd *Restart) MetaData() commandregistry.CommandMetadata {
 		ShortName:   "rs",
 		Description: T("Restart an app"),
 		Usage: []string{
-			T("CF_NAME restart APP_NAME"),
+			T("CF_NAME restart APP_NAME [--strategy rolling [--max-in-flight NUM]]"),
 		},
+		Examples: []string{
+			"CF_NAME restart my-app",
+			"CF_NAME restart my-app --strategy rolling --max-in-flight 2",
+		},
+		Flags: deploymentFlags(),
+	}
+}
+
+// deploymentFlags are the flags of the commands that can replace the
+// instances of an app a few at a time through a deployment.
+func deploymentFlags() map[string]flags.FlagSet {
+	fs := make(map[string]flags.FlagSet)
+	fs["strategy"] = &flags.StringFlag{Name: "strategy", Usage: T("Deployment strategy: 'rolling' replaces the instances of the app a few at a time, so it keeps serving traffic")}
+	fs["max-in-flight"] = &flags.IntFlag{Name: "max-in-flight", Usage: T("Number of instances a rolling deployment replaces at the same time (Default: 1)")}
+	return fs
+}
+
+// deploymentParams validates the deployment flags against the strategies the
+// command supports, returning nil when no strategy was asked for.
+func deploymentParams(fc flags.FlagContext, strategies []string) (*models.DeploymentParams, error) {
+	strategy := fc.String("strategy")
+	if strategy == "" {
+		if fc.IsSet("max-in-flight") {
+			return nil, errors.New(T("--max-in-flight can only be used with --strategy {{.Strategies}}",
+				map[string]interface{}{"Strategies": strings.Join(strategies, T(" or "))}))
+		}
+		return nil, nil
+	}
+
+	if !stringInSlice(strategy, strategies) {
+		return nil, errors.New(T("Invalid deployment strategy {{.Strategy}}; it must be one of {{.Strategies}}",
+			map[string]interface{}{"Strategy": strategy, "Strategies": strings.Join(strategies, ", ")}))
+	}
+
+	params := &models.DeploymentParams{Strategy: strategy}
+
+	if fc.IsSet("max-in-flight") {
+		maxInFlight := fc.Int("max-in-flight")
+		if maxInFlight < 1 {
+			return nil, errors.New(T("Invalid value for --max-in-flight {{.MaxInFlight}}; it must be at least 1", map[string]interface{}{"MaxInFlight": maxInFlight}))
+		}
+		params.MaxInFlight = &maxInFlight
+	}
+
+	return params, nil
+}
+
+func stringInSlice(value string, values []string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
 	}
+	return false
 }
 
 func (cmd *Restart) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
@@ -48,20 +107,31 @@ func (cmd *Restart) Requirements(requirementsFactory requirements.Factory, fc fl
 		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
 	}
 
+	deployment, err := deploymentParams(fc, []string{models.DeploymentStrategyRolling})
+	if err != nil {
+		cmd.ui.Failed(fmt.Sprintf(T("Incorrect Usage:")+" %s\n\n%s", err.Error(), commandregistry.Commands.CommandUsage("restart")))
+		return nil, err
+	}
+
 	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
 
 	reqs := []requirements.Requirement{
 		requirementsFactory.NewLoginRequirement(),
 		requirementsFactory.NewTargetedSpaceRequirement(),
-		cmd.appReq,
 	}
 
+	if deployment != nil {
+		reqs = append(reqs, requirementsFactory.NewMinAPIVersionRequirement("Option '--strategy'", cf.DeploymentsMinimumAPIVersion))
+	}
+
+	reqs = append(reqs, cmd.appReq)
 	return reqs, nil
 }
 
 func (cmd *Restart) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
+	cmd.deploymentActor = deps.DeploymentActor
 
 	//get start for dependency
 	starter := commandregistry.Commands.FindCommand("start")
@@ -78,6 +148,23 @@ func (cmd *Restart) SetDependency(deps commandregistry.Dependency, pluginCall bo
 
 func (cmd *Restart) Execute(c flags.FlagContext) error {
 	app := cmd.appReq.GetApplication()
+
+	deployment, err := deploymentParams(c, []string{models.DeploymentStrategyRolling})
+	if err != nil {
+		return err
+	}
+
+	if deployment != nil {
+		cmd.ui.Say(T("Restarting app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+			map[string]interface{}{
+				"AppName":     terminal.EntityNameColor(app.Name),
+				"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+			}))
+		return cmd.ApplicationDeploy(app, "", deployment.MaxInFlight)
+	}
+
 	return cmd.ApplicationRestart(app, cmd.config.OrganizationFields().Name, cmd.config.SpaceFields().Name)
 }
 
@@ -95,3 +182,73 @@ func (cmd *Restart) ApplicationRestart(app models.Application, orgName, spaceNam
 	}
 	return nil
 }
+
+// ApplicationDeploy replaces the instances of the app a few at a time with
+// ones running the droplet, or the current droplet when none is given, and
+// waits for the deployment to finish, showing how far it has got.
+func (cmd *Restart) ApplicationDeploy(app models.Application, dropletGUID string, maxInFlight *int) error {
+	deployment, err := cmd.deploymentActor.Deploy(app.GUID, models.DeploymentParams{
+		DropletGUID: dropletGUID,
+		Strategy:    models.DeploymentStrategyRolling,
+		MaxInFlight: maxInFlight,
+	})
+	if err != nil {
+		return err
+	}
+
+	return watchDeployment(cmd.ui, cmd.deploymentActor, app, deployment, nil)
+}
+
+// watchDeployment waits for the deployment of the app to finish, showing each
+// change of its status, and fails unless it replaced every instance. When a
+// canary deployment pauses, it is continued if advance agrees; otherwise it is
+// left paused for continue-deployment or cancel-deployment.
+func watchDeployment(ui terminal.UI, deploymentActor actors.DeploymentActor, app models.Application, deployment models.Deployment, advance func(models.Deployment) bool) error {
+	ui.Say(T("Waiting for app {{.AppName}} to deploy...", map[string]interface{}{"AppName": terminal.EntityNameColor(app.Name)}))
+
+	for {
+		var err error
+		deployment, err = deploymentActor.WaitForDeployment(deployment, func(deployment models.Deployment) {
+			ui.Say(T("Deployment {{.Status}}", map[string]interface{}{"Status": terminal.EntityNameColor(deployment.StatusDescription())}))
+		})
+		if err == actors.ErrDeploymentTimeout {
+			return errors.New(T("Timed out waiting for app {{.AppName}} to deploy. The deployment goes on; use '{{.Command}}' to check on it, or set CF_STARTUP_TIMEOUT to wait longer.",
+				map[string]interface{}{
+					"AppName": app.Name,
+					"Command": terminal.CommandColor(cf.Name + " deployments " + app.Name),
+				}))
+		}
+		if err != nil {
+			return err
+		}
+
+		if !deployment.IsPaused() {
+			break
+		}
+
+		if advance == nil || !advance(deployment) {
+			ui.Say(T("Deployment of app {{.AppName}} is paused. Use '{{.ContinueCommand}}' to go on or '{{.CancelCommand}}' to roll back.",
+				map[string]interface{}{
+					"AppName":         terminal.EntityNameColor(app.Name),
+					"ContinueCommand": terminal.CommandColor(cf.Name + " continue-deployment " + app.Name),
+					"CancelCommand":   terminal.CommandColor(cf.Name + " cancel-deployment " + app.Name),
+				}))
+			return nil
+		}
+
+		deployment, err = deploymentActor.ContinueDeployment(app.GUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !deployment.Succeeded() {
+		return errors.New(T("Deployment of app {{.AppName}} did not finish: {{.Outcome}}", map[string]interface{}{
+			"AppName": app.Name,
+			"Outcome": deployment.Outcome(),
+		}))
+	}
+
+	ui.Ok()
+	return nil
+}
@@ -1,8 +1,13 @@
 package application_test
 
 import (
+	"errors"
 	"os"
 
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+
 	"code.cloudfoundry.org/cli/cf/commands/application/applicationfakes"
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
@@ -31,6 +36,7 @@ var _ = Describe("restart command", func() {
 		originalStart       commandregistry.Command
 		deps                commandregistry.Dependency
 		applicationReq      *requirementsfakes.FakeApplicationRequirement
+		deploymentActor     *actorsfakes.FakeDeploymentActor
 	)
 
 	updateCommandDependency := func(pluginCall bool) {
@@ -41,6 +47,7 @@ var _ = Describe("restart command", func() {
 		commandregistry.Register(starter)
 		commandregistry.Register(stopper)
 
+		deps.DeploymentActor = deploymentActor
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("restart").SetDependency(deps, pluginCall))
 	}
 
@@ -55,6 +62,7 @@ var _ = Describe("restart command", func() {
 		starter = new(applicationfakes.FakeStarter)
 		stopper = new(applicationfakes.FakeStopper)
 		config = testconfig.NewRepositoryWithDefaults()
+		deploymentActor = new(actorsfakes.FakeDeploymentActor)
 
 		app = models.Application{}
 		app.Name = "my-app"
@@ -129,5 +137,82 @@ var _ = Describe("restart command", func() {
 			Expect(orgName).To(Equal(config.OrganizationFields().Name))
 			Expect(spaceName).To(Equal(config.SpaceFields().Name))
 		})
+
+		It("fails with usage when the strategy is not rolling", func() {
+			Expect(runCommand("my-app", "--strategy", "blue-green")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Invalid deployment strategy blue-green"},
+			))
+		})
+
+		It("fails with usage when --max-in-flight is given without a rolling strategy", func() {
+			Expect(runCommand("my-app", "--max-in-flight", "2")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "--max-in-flight can only be used with --strategy rolling"},
+			))
+		})
+
+		Context("with a rolling strategy", func() {
+			BeforeEach(func() {
+				requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+				deploymentActor.DeployReturns(models.Deployment{GUID: "deployment-guid", StatusValue: "ACTIVE"}, nil)
+				deploymentActor.WaitForDeploymentStub = func(deployment models.Deployment, progress func(models.Deployment)) (models.Deployment, error) {
+					deployment.StatusReason = "DEPLOYING"
+					progress(deployment)
+					return models.Deployment{GUID: "deployment-guid", StatusValue: "FINALIZED", StatusReason: "DEPLOYED"}, nil
+				}
+			})
+
+			It("requires an API with deployments", func() {
+				runCommand("my-app", "--strategy", "rolling")
+				option, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+				Expect(option).To(Equal("Option '--strategy'"))
+				Expect(version).To(Equal(cf.DeploymentsMinimumAPIVersion))
+			})
+
+			It("deploys the current droplet instead of stopping the app, and waits for the deployment", func() {
+				Expect(runCommand("my-app", "--strategy", "rolling", "--max-in-flight", "2")).To(BeTrue())
+				Expect(stopper.ApplicationStopCallCount()).To(BeZero())
+
+				appGUID, params := deploymentActor.DeployArgsForCall(0)
+				Expect(appGUID).To(Equal("my-app-guid"))
+				Expect(params.DropletGUID).To(BeEmpty())
+				Expect(params.Strategy).To(Equal("rolling"))
+				Expect(*params.MaxInFlight).To(Equal(2))
+
+				deployment, _ := deploymentActor.WaitForDeploymentArgsForCall(0)
+				Expect(deployment.GUID).To(Equal("deployment-guid"))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Restarting app", "my-app"},
+					[]string{"Waiting for app", "my-app", "to deploy"},
+					[]string{"Deployment", "ACTIVE (DEPLOYING)"},
+					[]string{"OK"},
+				))
+			})
+
+			It("fails when the deployment is cancelled", func() {
+				deploymentActor.WaitForDeploymentStub = nil
+				deploymentActor.WaitForDeploymentReturns(models.Deployment{GUID: "deployment-guid", StatusValue: "FINALIZED", StatusReason: "CANCELED"}, nil)
+				Expect(runCommand("my-app", "--strategy", "rolling")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Deployment of app my-app did not finish: CANCELED"},
+				))
+			})
+
+			It("fails when the deployment does not finish within the startup timeout", func() {
+				deploymentActor.WaitForDeploymentStub = nil
+				deploymentActor.WaitForDeploymentReturns(models.Deployment{GUID: "deployment-guid", StatusValue: "ACTIVE", StatusReason: "DEPLOYING"}, actors.ErrDeploymentTimeout)
+				Expect(runCommand("my-app", "--strategy", "rolling")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Timed out waiting for app my-app to deploy", "deployments my-app", "CF_STARTUP_TIMEOUT"},
+				))
+			})
+
+			It("fails when the deployment cannot be created", func() {
+				deploymentActor.DeployReturns(models.Deployment{}, errors.New("deployment-error"))
+				Expect(runCommand("my-app", "--strategy", "rolling")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"deployment-error"}))
+			})
+		})
 	})
 })
@@ -0,0 +1,116 @@
+package application
+
+import (
+	"fmt"
+	"strconv"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api/revisions"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type Revisions struct {
+	ui           terminal.UI
+	config       coreconfig.Reader
+	revisionRepo revisions.Repository
+	appReq       requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&Revisions{})
+}
+
+func (cmd *Revisions) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "revisions",
+		Description: T("List the revisions of an app, newest first"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME revisions %s", T("APP_NAME")),
+			"\n\n",
+			T("Revisions are only recorded for apps with the revisions app feature enabled. Use rollback --revision to deploy an earlier revision."),
+		},
+	}
+}
+
+func (cmd *Revisions) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("revisions"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("revisions", cf.RevisionsMinimumAPIVersion),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *Revisions) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.revisionRepo = deps.RepoLocator.GetRevisionRepository()
+	return cmd
+}
+
+func (cmd *Revisions) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say(T("Getting revisions for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	allRevisions, err := cmd.revisionRepo.ListRevisions(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	deployedRevisions, err := cmd.revisionRepo.ListDeployedRevisions(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(allRevisions) == 0 {
+		cmd.ui.Say(T("No revisions found"))
+		return nil
+	}
+
+	deployed := map[string]bool{}
+	for _, revision := range deployedRevisions {
+		deployed[revision.GUID] = true
+	}
+
+	table := cmd.ui.Table([]string{T("revision"), T("description"), T("deployable"), T("droplet"), T("created")})
+	for _, revision := range allRevisions {
+		version := strconv.Itoa(revision.Version)
+		if deployed[revision.GUID] {
+			version += " " + T("(deployed)")
+		}
+
+		table.Add(
+			version,
+			revision.Description,
+			strconv.FormatBool(revision.Deployable),
+			revision.DropletGUID,
+			revision.CreatedAt.Local().Format("2006-01-02T15:04:05.00-0700"),
+		)
+	}
+
+	return table.Print()
+}
@@ -0,0 +1,99 @@
+package application_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/revisions/revisionsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("revisions command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		revisionRepo        *revisionsfakes.FakeRepository
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = api.RepositoryLocator{}.SetRevisionRepository(revisionRepo)
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("revisions").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("revisions", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		revisionRepo = new(revisionsfakes.FakeRepository)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+	})
+
+	It("fails with usage when not given an app name", func() {
+		runCommand()
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Requires an argument"},
+		))
+	})
+
+	It("requires an API with revisions", func() {
+		runCommand("my-app")
+		command, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+		Expect(command).To(Equal("revisions"))
+		Expect(version).To(Equal(cf.RevisionsMinimumAPIVersion))
+	})
+
+	It("lists the revisions of the app and marks the deployed one", func() {
+		revisionRepo.ListRevisionsReturns([]models.Revision{
+			{GUID: "revision-2-guid", Version: 2, Description: "New environment variables deployed.", Deployable: true, DropletGUID: "droplet-1-guid", CreatedAt: time.Date(2016, 11, 2, 10, 0, 0, 0, time.UTC)},
+			{GUID: "revision-1-guid", Version: 1, Description: "Initial revision.", Deployable: false, DropletGUID: "droplet-0-guid", CreatedAt: time.Date(2016, 11, 1, 10, 0, 0, 0, time.UTC)},
+		}, nil)
+		revisionRepo.ListDeployedRevisionsReturns([]models.Revision{{GUID: "revision-2-guid", Version: 2}}, nil)
+
+		Expect(runCommand("my-app")).To(BeTrue())
+		Expect(revisionRepo.ListRevisionsArgsForCall(0)).To(Equal("my-app-guid"))
+		Expect(revisionRepo.ListDeployedRevisionsArgsForCall(0)).To(Equal("my-app-guid"))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Getting revisions for app my-app in org my-org / space my-space as my-user..."},
+			[]string{"OK"},
+			[]string{"revision", "description", "deployable", "droplet", "created"},
+			[]string{"2 (deployed)", "New environment variables deployed.", "true", "droplet-1-guid"},
+			[]string{"1", "Initial revision.", "false", "droplet-0-guid"},
+		))
+	})
+
+	It("says when the app has no revisions", func() {
+		revisionRepo.ListRevisionsReturns([]models.Revision{}, nil)
+
+		Expect(runCommand("my-app")).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"No revisions found"}))
+	})
+})
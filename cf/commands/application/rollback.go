@@ -0,0 +1,269 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/api/droplets"
+	"code.cloudfoundry.org/cli/cf/api/revisions"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type Rollback struct {
+	ui              terminal.UI
+	config          coreconfig.Reader
+	dropletRepo     droplets.Repository
+	revisionRepo    revisions.Repository
+	deploymentActor actors.DeploymentActor
+	restarter       Restarter
+	appReq          requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&Rollback{})
+}
+
+func (cmd *Rollback) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["droplet"] = &flags.StringFlag{Name: "droplet", Usage: T("Guid of the droplet to roll back to")}
+	fs["revision"] = &flags.IntFlag{Name: "revision", Usage: T("Number of the revision to roll back to, as listed by revisions")}
+	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Roll back to the previous droplet without asking")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "rollback",
+		Description: T("Restart an app on a droplet from an earlier push"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME rollback %s [--droplet %s | --revision %s] [-f]", T("APP_NAME"), T("DROPLET_GUID"), T("REVISION")),
+			"\n\n",
+			T("Rolling back to a revision also restores the environment variables the app had then, and replaces its instances a few at a time."),
+		},
+		Examples: []string{
+			"CF_NAME rollback my-app",
+			"CF_NAME rollback my-app -f",
+			"CF_NAME rollback my-app --droplet 7f8a9b2c-5d6e-4f10-8a21-3b4c5d6e7f80",
+			"CF_NAME rollback my-app --revision 3",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *Rollback) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("rollback"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	if fc.IsSet("droplet") && fc.IsSet("revision") {
+		cmd.ui.Failed(T("Incorrect Usage. Specify either --droplet or --revision\n\n") + commandregistry.Commands.CommandUsage("rollback"))
+		return nil, fmt.Errorf("Incorrect usage: --droplet and --revision cannot be combined")
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+	}
+
+	if fc.IsSet("revision") {
+		reqs = append(reqs, requirementsFactory.NewMinAPIVersionRequirement("Option '--revision'", cf.RevisionsMinimumAPIVersion))
+	}
+
+	reqs = append(reqs, cmd.appReq)
+
+	return reqs, nil
+}
+
+func (cmd *Rollback) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.dropletRepo = deps.RepoLocator.GetDropletRepository()
+	cmd.revisionRepo = deps.RepoLocator.GetRevisionRepository()
+	cmd.deploymentActor = deps.DeploymentActor
+
+	//get restart for dependency
+	restarter := commandregistry.Commands.FindCommand("restart")
+	restarter = restarter.SetDependency(deps, false)
+	cmd.restarter = restarter.(Restarter)
+
+	return cmd
+}
+
+func (cmd *Rollback) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	if c.IsSet("revision") {
+		return cmd.rollbackToRevision(app, c.Int("revision"))
+	}
+
+	// The v2 API only knows about the droplet an app is running on, so there
+	// is nothing to roll back to on older Cloud Controllers.
+	if !cmd.config.IsMinAPIVersion(cf.DropletHistoryMinimumAPIVersion) {
+		return errors.New(T("Rolling back requires CC API version {{.MinVersion}} or later, which keeps the droplets of earlier pushes. Your target is {{.APIVersion}}.",
+			map[string]interface{}{
+				"MinVersion": cf.DropletHistoryMinimumAPIVersion.String(),
+				"APIVersion": cmd.config.APIVersion(),
+			}))
+	}
+
+	previousDroplets, err := cmd.previousDroplets(app)
+	if err != nil {
+		return err
+	}
+
+	droplet, err := cmd.selectDroplet(app, previousDroplets, c)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(T("Rolling back app {{.AppName}} to droplet {{.DropletGUID}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"DropletGUID": terminal.EntityNameColor(droplet.GUID),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":    terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	err = cmd.dropletRepo.SetCurrentDroplet(app.GUID, droplet.GUID)
+	if err != nil {
+		return errors.New(T("Error rolling back app {{.AppName}}: {{.Err}}",
+			map[string]interface{}{"AppName": app.Name, "Err": err.Error()}))
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	return cmd.restarter.ApplicationRestart(app, cmd.config.OrganizationFields().Name, cmd.config.SpaceFields().Name)
+}
+
+// rollbackToRevision deploys an earlier revision of the app. Unlike setting
+// the droplet, this brings back the environment variables of the revision too,
+// and the app keeps serving traffic while its instances are replaced.
+func (cmd *Rollback) rollbackToRevision(app models.Application, version int) error {
+	allRevisions, err := cmd.revisionRepo.ListRevisions(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	var revision *models.Revision
+	for i := range allRevisions {
+		if allRevisions[i].Version == version {
+			revision = &allRevisions[i]
+			break
+		}
+	}
+
+	if revision == nil {
+		return errors.New(T("Revision {{.Revision}} of app {{.AppName}} not found",
+			map[string]interface{}{"Revision": version, "AppName": app.Name}))
+	}
+
+	if !revision.Deployable {
+		return errors.New(T("Revision {{.Revision}} of app {{.AppName}} cannot be deployed; its droplet is no longer available",
+			map[string]interface{}{"Revision": version, "AppName": app.Name}))
+	}
+
+	cmd.ui.Say(T("Rolling back app {{.AppName}} to revision {{.Revision}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"AppName":   terminal.EntityNameColor(app.Name),
+			"Revision":  terminal.EntityNameColor(strconv.Itoa(version)),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	deployment, err := cmd.deploymentActor.Deploy(app.GUID, models.DeploymentParams{
+		RevisionGUID: revision.GUID,
+		Strategy:     models.DeploymentStrategyRolling,
+	})
+	if err != nil {
+		return errors.New(T("Error rolling back app {{.AppName}}: {{.Err}}",
+			map[string]interface{}{"AppName": app.Name, "Err": err.Error()}))
+	}
+
+	return watchDeployment(cmd.ui, cmd.deploymentActor, app, deployment, nil)
+}
+
+// previousDroplets returns the staged droplets of the app other than the one
+// it is running on, newest first.
+func (cmd *Rollback) previousDroplets(app models.Application) ([]models.Droplet, error) {
+	allDroplets, err := cmd.dropletRepo.ListStagedDroplets(app.GUID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentGUID, err := cmd.dropletRepo.GetCurrentDropletGUID(app.GUID)
+	if err != nil {
+		return nil, err
+	}
+
+	previousDroplets := []models.Droplet{}
+	for _, droplet := range allDroplets {
+		if droplet.GUID != currentGUID {
+			previousDroplets = append(previousDroplets, droplet)
+		}
+	}
+
+	if len(previousDroplets) == 0 {
+		return nil, errors.New(T("App {{.AppName}} has no earlier droplet to roll back to",
+			map[string]interface{}{"AppName": app.Name}))
+	}
+
+	return previousDroplets, nil
+}
+
+func (cmd *Rollback) selectDroplet(app models.Application, previousDroplets []models.Droplet, c flags.FlagContext) (models.Droplet, error) {
+	if c.IsSet("droplet") {
+		for _, droplet := range previousDroplets {
+			if droplet.GUID == c.String("droplet") {
+				return droplet, nil
+			}
+		}
+
+		return models.Droplet{}, errors.New(T("Droplet {{.DropletGUID}} is not an earlier staged droplet of app {{.AppName}}",
+			map[string]interface{}{"DropletGUID": c.String("droplet"), "AppName": app.Name}))
+	}
+
+	if c.Bool("f") {
+		return previousDroplets[0], nil
+	}
+
+	table := cmd.ui.Table([]string{"#", T("droplet"), T("created"), T("buildpack"), T("stack")})
+	for i, droplet := range previousDroplets {
+		table.Add(
+			strconv.Itoa(i+1),
+			droplet.GUID,
+			droplet.CreatedAt.Local().Format("2006-01-02T15:04:05.00-0700"),
+			droplet.Buildpack,
+			droplet.Stack,
+		)
+	}
+	err := table.Print()
+	if err != nil {
+		return models.Droplet{}, err
+	}
+	cmd.ui.Say("")
+
+	answer := cmd.ui.Ask(T("Select a droplet to roll back to (or press enter for the previous droplet)"))
+	if answer == "" {
+		return previousDroplets[0], nil
+	}
+
+	index, err := strconv.Atoi(answer)
+	if err != nil || index < 1 || index > len(previousDroplets) {
+		return models.Droplet{}, errors.New(T("Invalid selection {{.Selection}}; enter a number from 1 to {{.Count}}",
+			map[string]interface{}{"Selection": answer, "Count": len(previousDroplets)}))
+	}
+
+	return previousDroplets[index-1], nil
+}
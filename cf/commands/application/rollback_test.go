@@ -0,0 +1,283 @@
+package application_test
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+	"code.cloudfoundry.org/cli/cf/api/droplets/dropletsfakes"
+	"code.cloudfoundry.org/cli/cf/api/revisions/revisionsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/application/applicationfakes"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("rollback command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		restarter           *applicationfakes.FakeRestarter
+		dropletRepo         *dropletsfakes.FakeRepository
+		revisionRepo        *revisionsfakes.FakeRepository
+		deploymentActor     *actorsfakes.FakeDeploymentActor
+		config              coreconfig.Repository
+		app                 models.Application
+		originalRestart     commandregistry.Command
+		deps                commandregistry.Dependency
+		applicationReq      *requirementsfakes.FakeApplicationRequirement
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = deps.RepoLocator.SetDropletRepository(dropletRepo)
+		deps.RepoLocator = deps.RepoLocator.SetRevisionRepository(revisionRepo)
+		deps.DeploymentActor = deploymentActor
+
+		//inject fake 'restarter' into registry
+		commandregistry.Register(restarter)
+
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("rollback").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("rollback", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		deps = commandregistry.NewDependency(os.Stdout, new(tracefakes.FakePrinter), "")
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		restarter = new(applicationfakes.FakeRestarter)
+		dropletRepo = new(dropletsfakes.FakeRepository)
+		revisionRepo = new(revisionsfakes.FakeRepository)
+		deploymentActor = new(actorsfakes.FakeDeploymentActor)
+		config = testconfig.NewRepositoryWithDefaults()
+		config.SetAPIVersion("2.75.0")
+
+		app = models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+
+		applicationReq = new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+
+		//save original command and restore later
+		originalRestart = commandregistry.Commands.FindCommand("restart")
+
+		//setup fakes to correctly interact with commandregistry
+		restarter.SetDependencyStub = func(_ commandregistry.Dependency, _ bool) commandregistry.Command {
+			return restarter
+		}
+		restarter.MetaDataReturns(commandregistry.CommandMetadata{Name: "restart"})
+	})
+
+	AfterEach(func() {
+		commandregistry.Register(originalRestart)
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage when not provided exactly one arg", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+			runCommand()
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires an argument"},
+			))
+		})
+
+		It("fails when not logged in", func() {
+			requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(runCommand("my-app")).To(BeFalse())
+		})
+
+		It("fails with usage when given both --droplet and --revision", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+			Expect(runCommand("my-app", "--droplet", "droplet-1-guid", "--revision", "2")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Specify either --droplet or --revision"},
+			))
+		})
+
+		It("requires an API with revisions when --revision is given", func() {
+			requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+			requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Failing{Message: "too old"})
+
+			Expect(runCommand("my-app", "--revision", "2")).To(BeFalse())
+
+			feature, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+			Expect(feature).To(Equal("Option '--revision'"))
+			Expect(version).To(Equal(cf.RevisionsMinimumAPIVersion))
+		})
+	})
+
+	Context("when logged in, targeting a space, and an app name is provided", func() {
+		BeforeEach(func() {
+			requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+
+			dropletRepo.ListStagedDropletsReturns([]models.Droplet{
+				{GUID: "droplet-3-guid", State: "STAGED", CreatedAt: time.Date(2016, 11, 3, 0, 0, 0, 0, time.UTC)},
+				{GUID: "droplet-2-guid", State: "STAGED", Buildpack: "ruby_buildpack", CreatedAt: time.Date(2016, 11, 2, 0, 0, 0, 0, time.UTC)},
+				{GUID: "droplet-1-guid", State: "STAGED", CreatedAt: time.Date(2016, 11, 1, 0, 0, 0, 0, time.UTC)},
+			}, nil)
+			dropletRepo.GetCurrentDropletGUIDReturns("droplet-3-guid", nil)
+		})
+
+		It("lists the earlier droplets and rolls back to the previous one by default", func() {
+			ui.Inputs = []string{""}
+			Expect(runCommand("my-app")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"1", "droplet-2-guid", "ruby_buildpack"},
+				[]string{"2", "droplet-1-guid"},
+				[]string{"Rolling back app my-app to droplet droplet-2-guid"},
+				[]string{"OK"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"droplet-3-guid"}))
+
+			appGUID, dropletGUID := dropletRepo.SetCurrentDropletArgsForCall(0)
+			Expect(appGUID).To(Equal("my-app-guid"))
+			Expect(dropletGUID).To(Equal("droplet-2-guid"))
+
+			Expect(restarter.ApplicationRestartCallCount()).To(Equal(1))
+			restartedApp, orgName, spaceName := restarter.ApplicationRestartArgsForCall(0)
+			Expect(restartedApp).To(Equal(app))
+			Expect(orgName).To(Equal(config.OrganizationFields().Name))
+			Expect(spaceName).To(Equal(config.SpaceFields().Name))
+		})
+
+		It("rolls back to the droplet the user picks", func() {
+			ui.Inputs = []string{"2"}
+			Expect(runCommand("my-app")).To(BeTrue())
+
+			_, dropletGUID := dropletRepo.SetCurrentDropletArgsForCall(0)
+			Expect(dropletGUID).To(Equal("droplet-1-guid"))
+		})
+
+		It("fails when the user picks a droplet that is not listed", func() {
+			ui.Inputs = []string{"3"}
+			Expect(runCommand("my-app")).To(BeFalse())
+
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Invalid selection 3; enter a number from 1 to 2"}))
+			Expect(dropletRepo.SetCurrentDropletCallCount()).To(BeZero())
+		})
+
+		It("does not ask when -f is given", func() {
+			Expect(runCommand("my-app", "-f")).To(BeTrue())
+
+			Expect(ui.Prompts).To(BeEmpty())
+			_, dropletGUID := dropletRepo.SetCurrentDropletArgsForCall(0)
+			Expect(dropletGUID).To(Equal("droplet-2-guid"))
+		})
+
+		It("rolls back to the droplet given with --droplet", func() {
+			Expect(runCommand("my-app", "--droplet", "droplet-1-guid")).To(BeTrue())
+
+			_, dropletGUID := dropletRepo.SetCurrentDropletArgsForCall(0)
+			Expect(dropletGUID).To(Equal("droplet-1-guid"))
+		})
+
+		It("fails when --droplet is the current droplet", func() {
+			Expect(runCommand("my-app", "--droplet", "droplet-3-guid")).To(BeFalse())
+
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Droplet droplet-3-guid is not an earlier staged droplet of app my-app"}))
+			Expect(dropletRepo.SetCurrentDropletCallCount()).To(BeZero())
+		})
+
+		Context("when the app has no earlier droplets", func() {
+			BeforeEach(func() {
+				dropletRepo.ListStagedDropletsReturns([]models.Droplet{{GUID: "droplet-3-guid"}}, nil)
+			})
+
+			It("fails", func() {
+				Expect(runCommand("my-app", "-f")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"App my-app has no earlier droplet to roll back to"}))
+			})
+		})
+
+		Context("when setting the current droplet fails", func() {
+			BeforeEach(func() {
+				dropletRepo.SetCurrentDropletReturns(errors.New("patch-error"))
+			})
+
+			It("fails without restarting the app", func() {
+				Expect(runCommand("my-app", "-f")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Error rolling back app my-app: patch-error"}))
+				Expect(restarter.ApplicationRestartCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when --revision is given", func() {
+			BeforeEach(func() {
+				requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+				revisionRepo.ListRevisionsReturns([]models.Revision{
+					{GUID: "revision-3-guid", Version: 3, Deployable: true},
+					{GUID: "revision-2-guid", Version: 2, Description: "New environment variables deployed.", Deployable: true},
+					{GUID: "revision-1-guid", Version: 1, Deployable: false},
+				}, nil)
+				deploymentActor.DeployReturns(models.Deployment{GUID: "deployment-guid"}, nil)
+				deploymentActor.WaitForDeploymentReturns(models.Deployment{GUID: "deployment-guid", StatusValue: "FINAL", StatusReason: "DEPLOYED"}, nil)
+			})
+
+			It("deploys the revision without touching the droplet", func() {
+				Expect(runCommand("my-app", "--revision", "2")).To(BeTrue())
+
+				Expect(revisionRepo.ListRevisionsArgsForCall(0)).To(Equal("my-app-guid"))
+				appGUID, params := deploymentActor.DeployArgsForCall(0)
+				Expect(appGUID).To(Equal("my-app-guid"))
+				Expect(params).To(Equal(models.DeploymentParams{RevisionGUID: "revision-2-guid", Strategy: models.DeploymentStrategyRolling}))
+
+				Expect(dropletRepo.SetCurrentDropletCallCount()).To(BeZero())
+				Expect(restarter.ApplicationRestartCallCount()).To(BeZero())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Rolling back app my-app to revision 2 in org my-org / space my-space as my-user..."},
+					[]string{"Waiting for app my-app to deploy..."},
+					[]string{"OK"},
+				))
+			})
+
+			It("fails when the revision does not exist", func() {
+				Expect(runCommand("my-app", "--revision", "7")).To(BeFalse())
+
+				Expect(deploymentActor.DeployCallCount()).To(BeZero())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Revision 7 of app my-app not found"}))
+			})
+
+			It("fails when the revision is not deployable", func() {
+				Expect(runCommand("my-app", "--revision", "1")).To(BeFalse())
+
+				Expect(deploymentActor.DeployCallCount()).To(BeZero())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Revision 1 of app my-app cannot be deployed"}))
+			})
+		})
+
+		Context("when the API is older than the droplet history API", func() {
+			BeforeEach(func() {
+				config.SetAPIVersion("2.65.0")
+			})
+
+			It("fails without looking for droplets", func() {
+				Expect(runCommand("my-app", "-f")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Rolling back requires CC API version 2.75.0 or later"}))
+				Expect(dropletRepo.ListStagedDropletsCallCount()).To(BeZero())
+			})
+		})
+	})
+})
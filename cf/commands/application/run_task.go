@@ -0,0 +1,136 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/formatters"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type RunTask struct {
+	ui        terminal.UI
+	config    coreconfig.Reader
+	taskActor actors.TaskActor
+	appReq    requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&RunTask{})
+}
+
+func (cmd *RunTask) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["name"] = &flags.StringFlag{Name: "name", Usage: T("Name to give the task (generated if omitted)")}
+	fs["memory"] = &flags.StringFlag{Name: "memory", ShortName: "m", Usage: T("Memory limit (e.g. 256M, 1024M, 1G)")}
+	fs["disk"] = &flags.StringFlag{Name: "disk", ShortName: "k", Usage: T("Disk limit (e.g. 256M, 1024M, 1G)")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "run-task",
+		ShortName:   "rt",
+		Description: T("Run a one-off task on an app"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME run-task %s %s [--name %s] [-m %s] [-k %s]", T("APP_NAME"), T("COMMAND"), T("TASK_NAME"), T("MEMORY"), T("DISK")),
+		},
+		Examples: []string{
+			`CF_NAME run-task my-app "bundle exec rake db:migrate" --name migrate`,
+			`CF_NAME run-task my-app "python report.py" -m 512M -k 2G`,
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *RunTask) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires APP_NAME and COMMAND as arguments\n\n") + commandregistry.Commands.CommandUsage("run-task"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 2)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("run-task", cf.TasksMinimumAPIVersion),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *RunTask) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.taskActor = deps.TaskActor
+	return cmd
+}
+
+func (cmd *RunTask) Execute(c flags.FlagContext) error {
+	params := models.TaskParams{
+		Name:    c.String("name"),
+		Command: c.Args()[1],
+	}
+
+	var err error
+	if c.String("m") != "" {
+		params.MemoryInMB, err = formatters.ToMegabytes(c.String("m"))
+		if err != nil {
+			return errors.New(T("Invalid memory limit: {{.Memory}}\n{{.ErrorDescription}}",
+				map[string]interface{}{
+					"Memory":           c.String("m"),
+					"ErrorDescription": err,
+				}))
+		}
+	}
+	if c.String("k") != "" {
+		params.DiskInMB, err = formatters.ToMegabytes(c.String("k"))
+		if err != nil {
+			return errors.New(T("Invalid disk quota: {{.DiskQuota}}\n{{.ErrorDescription}}",
+				map[string]interface{}{
+					"DiskQuota":        c.String("k"),
+					"ErrorDescription": err,
+				}))
+		}
+	}
+
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say(T("Creating task for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	task, err := cmd.taskActor.RunTask(app.GUID, params)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Task has been submitted successfully for execution."))
+
+	table := cmd.ui.Table([]string{"", ""})
+	table.Add(T("task name:"), task.Name)
+	table.Add(T("task id:"), strconv.Itoa(task.SequenceID))
+	err = table.Print()
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say("")
+	cmd.ui.Say(T("TIP: Use '{{.Command}}' to follow the output of the task",
+		map[string]interface{}{"Command": terminal.CommandColor(cf.Name + " logs " + app.Name)}))
+	return nil
+}
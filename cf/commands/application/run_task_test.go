@@ -0,0 +1,118 @@
+package application_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("run-task command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		taskActor           *actorsfakes.FakeTaskActor
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+		app                 models.Application
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.TaskActor = taskActor
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("run-task").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("run-task", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		taskActor = new(actorsfakes.FakeTaskActor)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app = models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage when not given an app name and a command", func() {
+			runCommand("my-app")
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires APP_NAME and COMMAND as arguments"},
+			))
+		})
+
+		It("fails when the CC API does not support tasks", func() {
+			requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Failing{Message: "too old"})
+			Expect(runCommand("my-app", "rake db:migrate")).To(BeFalse())
+
+			feature, _ := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+			Expect(feature).To(Equal("run-task"))
+		})
+	})
+
+	It("runs the task with the given name and limits", func() {
+		taskActor.RunTaskReturns(models.Task{Name: "migrate", SequenceID: 3}, nil)
+
+		Expect(runCommand("my-app", "rake db:migrate", "--name", "migrate", "-m", "512M", "--disk", "1G")).To(BeTrue())
+
+		appGUID, params := taskActor.RunTaskArgsForCall(0)
+		Expect(appGUID).To(Equal("my-app-guid"))
+		Expect(params).To(Equal(models.TaskParams{
+			Name:       "migrate",
+			Command:    "rake db:migrate",
+			MemoryInMB: 512,
+			DiskInMB:   1024,
+		}))
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Creating task for app", "my-app", "my-org", "my-space", "my-user"},
+			[]string{"OK"},
+			[]string{"Task has been submitted successfully for execution."},
+			[]string{"task name:", "migrate"},
+			[]string{"task id:", "3"},
+			[]string{"TIP: Use 'cf logs my-app' to follow the output of the task"},
+		))
+	})
+
+	It("fails when the memory limit is invalid", func() {
+		Expect(runCommand("my-app", "rake db:migrate", "-m", "lots")).To(BeFalse())
+		Expect(taskActor.RunTaskCallCount()).To(BeZero())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Invalid memory limit: lots"},
+		))
+	})
+
+	It("tells the user when the task cannot be created", func() {
+		taskActor.RunTaskReturns(models.Task{}, errors.New("Task must have a droplet."))
+
+		Expect(runCommand("my-app", "rake db:migrate")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Task must have a droplet."},
+		))
+	})
+})
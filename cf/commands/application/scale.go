@@ -3,8 +3,11 @@ package application
 import (
 	"errors"
 	"fmt"
+	"strings"
 
+	"code.cloudfoundry.org/cli/cf"
 	"code.cloudfoundry.org/cli/cf/api/applications"
+	"code.cloudfoundry.org/cli/cf/api/processes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/flags"
@@ -16,11 +19,12 @@ import (
 )
 
 type Scale struct {
-	ui        terminal.UI
-	config    coreconfig.Reader
-	restarter Restarter
-	appReq    requirements.ApplicationRequirement
-	appRepo   applications.Repository
+	ui          terminal.UI
+	config      coreconfig.Reader
+	restarter   Restarter
+	appReq      requirements.ApplicationRequirement
+	appRepo     applications.Repository
+	processRepo processes.Repository
 }
 
 func init() {
@@ -33,12 +37,17 @@ func (cmd *Scale) MetaData() commandregistry.CommandMetadata {
 	fs["k"] = &flags.StringFlag{ShortName: "k", Usage: T("Disk limit (e.g. 256M, 1024M, 1G)")}
 	fs["m"] = &flags.StringFlag{ShortName: "m", Usage: T("Memory limit (e.g. 256M, 1024M, 1G)")}
 	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force restart of app without prompt")}
+	fs["process"] = &flags.StringFlag{Name: "process", Usage: T("Process type to scale, such as worker, for apps with several process types")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "scale",
 		Description: T("Change or view the instance count, disk space limit, and memory limit for an app"),
 		Usage: []string{
-			T("CF_NAME scale APP_NAME [-i INSTANCES] [-k DISK] [-m MEMORY] [-f]"),
+			T("CF_NAME scale APP_NAME [--process PROCESS_TYPE] [-i INSTANCES] [-k DISK] [-m MEMORY] [-f]"),
+		},
+		Examples: []string{
+			"CF_NAME scale my-app -i 5",
+			"CF_NAME scale my-app --process worker -i 3 -m 256M",
 		},
 		Flags: fs,
 	}
@@ -58,6 +67,10 @@ func (cmd *Scale) Requirements(requirementsFactory requirements.Factory, fc flag
 		cmd.appReq,
 	}
 
+	if fc.IsSet("process") {
+		reqs = append(reqs, requirementsFactory.NewMinAPIVersionRequirement("Option '--process'", cf.ProcessTypesMinimumAPIVersion))
+	}
+
 	return reqs, nil
 }
 
@@ -65,6 +78,7 @@ func (cmd *Scale) SetDependency(deps commandregistry.Dependency, pluginCall bool
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
+	cmd.processRepo = deps.RepoLocator.GetProcessRepository()
 
 	//get command from registry for dependency
 	commandDep := commandregistry.Commands.FindCommand("restart")
@@ -78,6 +92,10 @@ var bytesInAMegabyte int64 = 1024 * 1024
 
 func (cmd *Scale) Execute(c flags.FlagContext) error {
 	currentApp := cmd.appReq.GetApplication()
+	if c.IsSet("process") {
+		return cmd.scaleProcess(c, currentApp, c.String("process"))
+	}
+
 	if !anyFlagsSet(c) {
 		cmd.ui.Say(T("Showing current scale of app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
 			map[string]interface{}{
@@ -158,6 +176,120 @@ func (cmd *Scale) Execute(c flags.FlagContext) error {
 	return nil
 }
 
+// scaleProcess scales a single process type of the app through the v3
+// processes API, leaving its other process types as they are.
+func (cmd *Scale) scaleProcess(c flags.FlagContext, currentApp models.Application, processType string) error {
+	process, err := cmd.findProcess(currentApp, processType)
+	if err != nil {
+		return err
+	}
+
+	if !anyFlagsSet(c) {
+		cmd.ui.Say(T("Showing current scale of process {{.ProcessType}} of app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+			map[string]interface{}{
+				"ProcessType": terminal.EntityNameColor(processType),
+				"AppName":     terminal.EntityNameColor(currentApp.Name),
+				"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+			}))
+		cmd.ui.Ok()
+		cmd.ui.Say("")
+
+		cmd.ui.Say("%s %s", terminal.HeaderColor(T("memory:")), formatters.ByteSize(process.MemoryInMB*bytesInAMegabyte))
+		cmd.ui.Say("%s %s", terminal.HeaderColor(T("disk:")), formatters.ByteSize(process.DiskInMB*bytesInAMegabyte))
+		cmd.ui.Say("%s %d", terminal.HeaderColor(T("instances:")), process.Instances)
+
+		return nil
+	}
+
+	params := models.ProcessScaleParams{}
+	shouldRestart := false
+
+	if c.String("m") != "" {
+		memory, err := formatters.ToMegabytes(c.String("m"))
+		if err != nil {
+			return errors.New(T("Invalid memory limit: {{.Memory}}\n{{.ErrorDescription}}",
+				map[string]interface{}{
+					"Memory":           c.String("m"),
+					"ErrorDescription": err,
+				}))
+		}
+		params.MemoryInMB = &memory
+		shouldRestart = true
+	}
+
+	if c.String("k") != "" {
+		diskQuota, err := formatters.ToMegabytes(c.String("k"))
+		if err != nil {
+			return errors.New(T("Invalid disk quota: {{.DiskQuota}}\n{{.ErrorDescription}}",
+				map[string]interface{}{
+					"DiskQuota":        c.String("k"),
+					"ErrorDescription": err,
+				}))
+		}
+		params.DiskInMB = &diskQuota
+		shouldRestart = true
+	}
+
+	if c.IsSet("i") {
+		instances := c.Int("i")
+		params.Instances = &instances
+	}
+
+	// New memory and disk limits only take effect once the app restarts,
+	// so only scaling instances is free.
+	if shouldRestart && !cmd.confirmRestart(c, currentApp.Name) {
+		return nil
+	}
+
+	cmd.ui.Say(T("Scaling process {{.ProcessType}} of app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"ProcessType": terminal.EntityNameColor(processType),
+			"AppName":     terminal.EntityNameColor(currentApp.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	_, err = cmd.processRepo.ScaleProcess(currentApp.GUID, processType, params)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+
+	if shouldRestart {
+		err = cmd.restarter.ApplicationRestart(currentApp, cmd.config.OrganizationFields().Name, cmd.config.SpaceFields().Name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cmd *Scale) findProcess(app models.Application, processType string) (models.Process, error) {
+	processes, err := cmd.processRepo.ListProcesses(app.GUID)
+	if err != nil {
+		return models.Process{}, err
+	}
+
+	types := []string{}
+	for _, process := range processes {
+		if process.Type == processType {
+			return process, nil
+		}
+		types = append(types, process.Type)
+	}
+
+	return models.Process{}, errors.New(T("App {{.AppName}} has no process type {{.ProcessType}}; its process types are: {{.ProcessTypes}}",
+		map[string]interface{}{
+			"AppName":      app.Name,
+			"ProcessType":  processType,
+			"ProcessTypes": strings.Join(types, ", "),
+		}))
+}
+
 func (cmd *Scale) confirmRestart(context flags.FlagContext, appName string) bool {
 	if context.Bool("f") {
 		return true
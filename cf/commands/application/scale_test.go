@@ -1,7 +1,10 @@
 package application_test
 
 import (
+	"errors"
+
 	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
+	"code.cloudfoundry.org/cli/cf/api/processes/processesfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/commands/application/applicationfakes"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -22,6 +25,7 @@ var _ = Describe("scale command", func() {
 		requirementsFactory *requirementsfakes.FakeFactory
 		restarter           *applicationfakes.FakeRestarter
 		appRepo             *applicationsfakes.FakeRepository
+		processRepo         *processesfakes.FakeRepository
 		ui                  *testterm.FakeUI
 		config              coreconfig.Repository
 		app                 models.Application
@@ -32,6 +36,7 @@ var _ = Describe("scale command", func() {
 	updateCommandDependency := func(pluginCall bool) {
 		deps.UI = ui
 		deps.RepoLocator = deps.RepoLocator.SetApplicationRepository(appRepo)
+		deps.RepoLocator = deps.RepoLocator.SetProcessRepository(processRepo)
 		deps.Config = config
 
 		//inject fake 'command dependency' into registry
@@ -56,6 +61,7 @@ var _ = Describe("scale command", func() {
 		restarter.MetaDataReturns(commandregistry.CommandMetadata{Name: "restart"})
 
 		appRepo = new(applicationsfakes.FakeRepository)
+		processRepo = new(processesfakes.FakeRepository)
 		ui = new(testterm.FakeUI)
 		config = testconfig.NewRepositoryWithDefaults()
 
@@ -102,6 +108,15 @@ var _ = Describe("scale command", func() {
 		It("does not require any flags", func() {
 			Expect(testcmd.RunCLICommand("scale", []string{"my-app"}, requirementsFactory, updateCommandDependency, false, ui)).To(BeTrue())
 		})
+
+		It("requires a CC API that knows about process types when --process is given", func() {
+			requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Failing{Message: "too old"})
+
+			Expect(testcmd.RunCLICommand("scale", []string{"--process", "worker", "my-app"}, requirementsFactory, updateCommandDependency, false, ui)).To(BeFalse())
+
+			feature, _ := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+			Expect(feature).To(Equal("Option '--process'"))
+		})
 	})
 
 	Describe("scaling an app", func() {
@@ -196,4 +211,97 @@ var _ = Describe("scale command", func() {
 			})
 		})
 	})
+
+	Describe("scaling a process type of an app", func() {
+		BeforeEach(func() {
+			requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+			processRepo.ListProcessesReturns([]models.Process{
+				{GUID: "web-guid", Type: "web", Instances: 42, MemoryInMB: 256, DiskInMB: 1024},
+				{GUID: "worker-guid", Type: "worker", Instances: 1, MemoryInMB: 128, DiskInMB: 512},
+			}, nil)
+		})
+
+		It("prints the limits of the process when no flags are specified", func() {
+			testcmd.RunCLICommand("scale", []string{"--process", "worker", "my-app"}, requirementsFactory, updateCommandDependency, false, ui)
+
+			Expect(processRepo.ListProcessesArgsForCall(0)).To(Equal("my-app-guid"))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Showing current scale of process worker of app my-app"},
+				[]string{"OK"},
+				[]string{"memory", "128M"},
+				[]string{"disk", "512M"},
+				[]string{"instances", "1"},
+			))
+			Expect(processRepo.ScaleProcessCallCount()).To(BeZero())
+		})
+
+		It("scales the instances of the process without restarting the app", func() {
+			testcmd.RunCLICommand("scale", []string{"--process", "worker", "-i", "3", "my-app"}, requirementsFactory, updateCommandDependency, false, ui)
+
+			appGUID, processType, params := processRepo.ScaleProcessArgsForCall(0)
+			Expect(appGUID).To(Equal("my-app-guid"))
+			Expect(processType).To(Equal("worker"))
+			Expect(*params.Instances).To(Equal(3))
+			Expect(params.MemoryInMB).To(BeNil())
+			Expect(params.DiskInMB).To(BeNil())
+
+			Expect(ui.Prompts).To(BeEmpty())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Scaling process worker of app my-app in org my-org / space my-space as my-user..."},
+				[]string{"OK"},
+			))
+			Expect(appRepo.UpdateCallCount()).To(BeZero())
+			Expect(restarter.ApplicationRestartCallCount()).To(BeZero())
+		})
+
+		It("asks before changing the memory and disk limits of the process", func() {
+			ui.Inputs = []string{"yes"}
+			testcmd.RunCLICommand("scale", []string{"--process", "worker", "-m", "256M", "-k", "1G", "my-app"}, requirementsFactory, updateCommandDependency, false, ui)
+
+			Expect(ui.Prompts).To(ContainSubstrings([]string{"This will cause the app to restart", "my-app"}))
+			_, _, params := processRepo.ScaleProcessArgsForCall(0)
+			Expect(*params.MemoryInMB).To(Equal(int64(256)))
+			Expect(*params.DiskInMB).To(Equal(int64(1024)))
+			Expect(params.Instances).To(BeNil())
+		})
+
+		It("restarts the app after changing the limits of the process", func() {
+			ui.Inputs = []string{"yes"}
+			testcmd.RunCLICommand("scale", []string{"--process", "worker", "-m", "256M", "my-app"}, requirementsFactory, updateCommandDependency, false, ui)
+
+			Expect(processRepo.ScaleProcessCallCount()).To(Equal(1))
+			Expect(restarter.ApplicationRestartCallCount()).To(Equal(1))
+			restartedApp, orgName, spaceName := restarter.ApplicationRestartArgsForCall(0)
+			Expect(restartedApp.GUID).To(Equal("my-app-guid"))
+			Expect(orgName).To(Equal("my-org"))
+			Expect(spaceName).To(Equal("my-space"))
+		})
+
+		It("does not restart the app when scaling the process fails", func() {
+			ui.Inputs = []string{"yes"}
+			processRepo.ScaleProcessReturns(models.Process{}, errors.New("scale failed"))
+			testcmd.RunCLICommand("scale", []string{"--process", "worker", "-m", "256M", "my-app"}, requirementsFactory, updateCommandDependency, false, ui)
+
+			Expect(restarter.ApplicationRestartCallCount()).To(BeZero())
+		})
+
+		It("does not scale the process when the user does not confirm", func() {
+			ui.Inputs = []string{"no"}
+			testcmd.RunCLICommand("scale", []string{"--process", "worker", "-m", "256M", "my-app"}, requirementsFactory, updateCommandDependency, false, ui)
+
+			Expect(processRepo.ScaleProcessCallCount()).To(BeZero())
+			Expect(restarter.ApplicationRestartCallCount()).To(BeZero())
+		})
+
+		It("fails when the app has no such process type", func() {
+			passed := testcmd.RunCLICommand("scale", []string{"--process", "clock", "-i", "1", "my-app"}, requirementsFactory, updateCommandDependency, false, ui)
+
+			Expect(passed).To(BeFalse())
+			Expect(processRepo.ScaleProcessCallCount()).To(BeZero())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"App my-app has no process type clock; its process types are: web, worker"},
+			))
+		})
+	})
 })
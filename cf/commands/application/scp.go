@@ -0,0 +1,179 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/net"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	sshCmd "code.cloudfoundry.org/cli/cf/ssh"
+	"code.cloudfoundry.org/cli/cf/ssh/options"
+	sshTerminal "code.cloudfoundry.org/cli/cf/ssh/terminal"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type SCP struct {
+	ui            terminal.UI
+	config        coreconfig.Reader
+	gateway       net.Gateway
+	appReq        requirements.ApplicationRequirement
+	sshCodeGetter commands.SSHCodeGetter
+	secureShell   sshCmd.SecureShell
+
+	localPath  string
+	remotePath string
+	upload     bool
+}
+
+func init() {
+	commandregistry.Register(&SCP{})
+}
+
+func (cmd *SCP) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["app-instance-index"] = &flags.IntFlag{Name: "app-instance-index", ShortName: "i", Usage: T("Application instance index")}
+	fs["recursive"] = &flags.BoolFlag{Name: "recursive", ShortName: "r", Usage: T("Copy directories and their contents")}
+	fs["skip-host-validation"] = &flags.BoolFlag{Name: "skip-host-validation", ShortName: "k", Usage: T("Skip host key validation")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "scp",
+		Description: T("Copy files to or from an application container instance"),
+		Usage: []string{
+			T("CF_NAME scp [-i app-instance-index] [-r] [--skip-host-validation] SOURCE TARGET"),
+			"\n\n",
+			T("One of SOURCE and TARGET is a path in the app instance, written as APP_NAME:PATH. The other is a local path."),
+			"\n\n",
+			T("Files are copied with the scp protocol. For an interactive SFTP session, connect an SFTP client to the SSH endpoint with a one-time passcode from CF_NAME ssh-code."),
+		},
+		Examples: []string{
+			"CF_NAME scp my-app:/home/vcap/logs/app.log .",
+			"CF_NAME scp -i 1 config.yml my-app:/home/vcap/app/config.yml",
+			"CF_NAME scp -r my-app:/home/vcap/app/public ./public",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *SCP) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires SOURCE and TARGET as arguments") + "\n\n" + commandregistry.Commands.CommandUsage("scp"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 2)
+	}
+
+	if fc.IsSet("i") && fc.Int("i") < 0 {
+		cmd.ui.Failed(fmt.Sprintf(T("Incorrect Usage:")+" %s\n\n%s", T("Value for flag 'app-instance-index' cannot be negative"), commandregistry.Commands.CommandUsage("scp")))
+		return nil, fmt.Errorf("Incorrect usage: app-instance-index cannot be negative")
+	}
+
+	sourceApp, sourcePath, sourceIsRemote := parseSCPArgument(fc.Args()[0])
+	targetApp, targetPath, targetIsRemote := parseSCPArgument(fc.Args()[1])
+	if sourceIsRemote == targetIsRemote {
+		cmd.ui.Failed(T("Incorrect Usage. Exactly one of SOURCE and TARGET must be a path in an app, written as APP_NAME:PATH") + "\n\n" + commandregistry.Commands.CommandUsage("scp"))
+		return nil, errors.New("Incorrect usage: exactly one of SOURCE and TARGET must be remote")
+	}
+
+	appName := sourceApp
+	cmd.upload = false
+	cmd.remotePath, cmd.localPath = sourcePath, targetPath
+	if targetIsRemote {
+		appName = targetApp
+		cmd.upload = true
+		cmd.localPath, cmd.remotePath = sourcePath, targetPath
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(appName)
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *SCP) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.gateway = deps.Gateways["cloud-controller"]
+
+	if deps.WildcardDependency != nil {
+		cmd.secureShell = deps.WildcardDependency.(sshCmd.SecureShell)
+	}
+
+	//get ssh-code for dependency
+	sshCodeGetter := commandregistry.Commands.FindCommand("ssh-code")
+	sshCodeGetter = sshCodeGetter.SetDependency(deps, false)
+	cmd.sshCodeGetter = sshCodeGetter.(commands.SSHCodeGetter)
+
+	return cmd
+}
+
+func (cmd *SCP) Execute(fc flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+	info, err := getSSHEndpointInfo(cmd.gateway, cmd.config)
+	if err != nil {
+		return errors.New(T("Error getting SSH info:") + err.Error())
+	}
+
+	sshAuthCode, err := cmd.sshCodeGetter.Get()
+	if err != nil {
+		return errors.New(T("Error getting one time auth code: ") + err.Error())
+	}
+
+	//init secureShell if it is not already set by SetDependency() with fakes
+	if cmd.secureShell == nil {
+		cmd.secureShell = sshCmd.NewSecureShell(
+			sshCmd.DefaultSecureDialer(),
+			sshTerminal.DefaultHelper(),
+			sshCmd.DefaultListenerFactory(),
+			30*time.Second,
+			app,
+			info.SSHEndpointFingerprint,
+			info.SSHEndpoint,
+			sshAuthCode,
+		)
+	}
+
+	opts := &options.SSHOptions{
+		AppName:            app.Name,
+		Index:              uint(fc.Int("i")),
+		SkipHostValidation: fc.Bool("k"),
+	}
+
+	err = cmd.secureShell.Connect(opts)
+	if err != nil {
+		return errors.New(T("Error opening SSH connection: ") + err.Error())
+	}
+	defer cmd.secureShell.Close()
+
+	if cmd.upload {
+		err = cmd.secureShell.Upload(cmd.localPath, cmd.remotePath, fc.Bool("r"), cmd.ui.Writer())
+	} else {
+		err = cmd.secureShell.Download(cmd.remotePath, cmd.localPath, fc.Bool("r"), cmd.ui.Writer())
+	}
+	if err != nil {
+		return errors.New(T("Error copying files: ") + err.Error())
+	}
+
+	return nil
+}
+
+// parseSCPArgument splits an APP_NAME:PATH argument. An argument without an
+// app name, such as a plain or absolute local path or a Windows drive letter,
+// is local.
+func parseSCPArgument(arg string) (string, string, bool) {
+	parts := strings.SplitN(arg, ":", 2)
+	if len(parts) != 2 || len(parts[0]) < 2 || strings.ContainsAny(parts[0], `/\`) {
+		return "", arg, false
+	}
+
+	return parts[0], parts[1], true
+}
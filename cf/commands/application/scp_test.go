@@ -0,0 +1,173 @@
+package application_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/commandsfakes"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/ssh/sshfakes"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testnet "code.cloudfoundry.org/cli/testhelpers/net"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("scp command", func() {
+	var (
+		ui *testterm.FakeUI
+
+		sshCodeGetter         *commandsfakes.FakeSSHCodeGetter
+		originalSSHCodeGetter commandregistry.Command
+
+		requirementsFactory *requirementsfakes.FakeFactory
+		applicationReq      *requirementsfakes.FakeApplicationRequirement
+		configRepo          coreconfig.Repository
+		deps                commandregistry.Dependency
+		fakeSecureShell     *sshfakes.FakeSecureShell
+		testServer          *httptest.Server
+	)
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		app.State = "started"
+		app.Diego = true
+		applicationReq = new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+
+		originalSSHCodeGetter = commandregistry.Commands.FindCommand("ssh-code")
+		sshCodeGetter = new(commandsfakes.FakeSSHCodeGetter)
+		sshCodeGetter.SetDependencyStub = func(_ commandregistry.Dependency, _ bool) commandregistry.Command {
+			return sshCodeGetter
+		}
+		sshCodeGetter.MetaDataReturns(commandregistry.CommandMetadata{Name: "ssh-code"})
+
+		fakeSecureShell = new(sshfakes.FakeSecureShell)
+		deps.WildcardDependency = fakeSecureShell
+
+		getRequest := apifakes.NewCloudControllerTestRequest(testnet.TestRequest{
+			Method: "GET",
+			Path:   "/v2/info",
+			Response: testnet.TestResponse{
+				Status: http.StatusOK,
+				Body:   getInfoResponseBody,
+			},
+		})
+		testServer, _ = testnet.NewServer([]testnet.TestRequest{getRequest})
+		configRepo.SetAPIEndpoint(testServer.URL)
+		deps.Gateways = map[string]net.Gateway{
+			"cloud-controller": net.NewCloudControllerGateway(configRepo, time.Now, &testterm.FakeUI{}, new(tracefakes.FakePrinter), ""),
+		}
+	})
+
+	AfterEach(func() {
+		commandregistry.Register(originalSSHCodeGetter)
+		testServer.Close()
+	})
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = configRepo
+
+		commandregistry.Register(sshCodeGetter)
+
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("scp").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("scp", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	It("fails with usage when not given a source and a target", func() {
+		Expect(runCommand("my-app:/tmp/file")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Requires SOURCE and TARGET as arguments"},
+		))
+	})
+
+	It("fails with usage when neither path is in an app", func() {
+		Expect(runCommand("local-file", "/tmp/other-file")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Exactly one of SOURCE and TARGET must be a path in an app"},
+		))
+	})
+
+	It("fails with usage when both paths are in an app", func() {
+		Expect(runCommand("my-app:/tmp/file", "other-app:/tmp/file")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Exactly one of SOURCE and TARGET must be a path in an app"},
+		))
+	})
+
+	It("downloads a file from the app instance", func() {
+		Expect(runCommand("-i", "2", "my-app:/home/vcap/logs/app.log", "app.log")).To(BeTrue())
+
+		Expect(requirementsFactory.NewApplicationRequirementArgsForCall(0)).To(Equal("my-app"))
+
+		opts := fakeSecureShell.ConnectArgsForCall(0)
+		Expect(opts.AppName).To(Equal("my-app"))
+		Expect(opts.Index).To(Equal(uint(2)))
+
+		remotePath, localPath, recursive, _ := fakeSecureShell.DownloadArgsForCall(0)
+		Expect(remotePath).To(Equal("/home/vcap/logs/app.log"))
+		Expect(localPath).To(Equal("app.log"))
+		Expect(recursive).To(BeFalse())
+		Expect(fakeSecureShell.CloseCallCount()).To(Equal(1))
+	})
+
+	It("uploads a directory to the app instance with -r", func() {
+		Expect(runCommand("-r", "./public", "my-app:/home/vcap/app/public")).To(BeTrue())
+
+		localPath, remotePath, recursive, _ := fakeSecureShell.UploadArgsForCall(0)
+		Expect(localPath).To(Equal("./public"))
+		Expect(remotePath).To(Equal("/home/vcap/app/public"))
+		Expect(recursive).To(BeTrue())
+	})
+
+	It("treats a Windows drive letter as a local path", func() {
+		Expect(runCommand(`C:\logs`, "my-app:/tmp/logs")).To(BeTrue())
+
+		localPath, _, _, _ := fakeSecureShell.UploadArgsForCall(0)
+		Expect(localPath).To(Equal(`C:\logs`))
+	})
+
+	It("notifies users when the copy fails", func() {
+		fakeSecureShell.DownloadReturns(errors.New("no such file"))
+
+		Expect(runCommand("my-app:/tmp/missing", ".")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Error copying files", "no such file"},
+		))
+	})
+
+	It("notifies users when the connection fails", func() {
+		fakeSecureShell.ConnectReturns(errors.New("dial error"))
+
+		Expect(runCommand("my-app:/tmp/file", ".")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Error opening SSH connection", "dial error"},
+		))
+	})
+})
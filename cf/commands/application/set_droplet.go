@@ -0,0 +1,165 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api/droplets"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type SetDroplet struct {
+	ui                 terminal.UI
+	config             coreconfig.Reader
+	dropletRepo        droplets.Repository
+	appReq             requirements.ApplicationRequirement
+	UploadPollInterval time.Duration
+}
+
+func init() {
+	commandregistry.Register(&SetDroplet{})
+}
+
+func (cmd *SetDroplet) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["droplet-guid"] = &flags.StringFlag{Name: "droplet-guid", Usage: T("Guid of a staged droplet of the app")}
+	fs["file"] = &flags.StringFlag{Name: "file", Usage: T("Path of a droplet tarball to upload, such as one saved by download-droplet")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "set-droplet",
+		Description: T("Set the droplet an app runs on, uploading it first when it comes from a file"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME set-droplet %s (--droplet-guid %s | --file %s)", T("APP_NAME"), T("DROPLET_GUID"), T("PATH")),
+			"\n\n",
+			T("The app runs on the droplet from its next start; restart it to use the droplet right away."),
+		},
+		Examples: []string{
+			"CF_NAME set-droplet my-app --droplet-guid 7f8a9b2c-5d6e-4f10-8a21-3b4c5d6e7f80",
+			"CF_NAME set-droplet my-app --file my-app.tgz",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *SetDroplet) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("set-droplet"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	if (fc.String("droplet-guid") == "") == (fc.String("file") == "") {
+		cmd.ui.Failed(T("Incorrect Usage. Specify either --droplet-guid or --file\n\n") + commandregistry.Commands.CommandUsage("set-droplet"))
+		return nil, errors.New("Incorrect usage: exactly one of --droplet-guid and --file is required")
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+	}
+
+	if fc.String("file") != "" {
+		reqs = append(reqs, requirementsFactory.NewMinAPIVersionRequirement("Option '--file'", cf.DropletUploadMinimumAPIVersion))
+	} else {
+		reqs = append(reqs, requirementsFactory.NewMinAPIVersionRequirement("set-droplet", cf.DropletHistoryMinimumAPIVersion))
+	}
+
+	reqs = append(reqs, cmd.appReq)
+
+	return reqs, nil
+}
+
+func (cmd *SetDroplet) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.dropletRepo = deps.RepoLocator.GetDropletRepository()
+	cmd.UploadPollInterval = time.Second
+	return cmd
+}
+
+func (cmd *SetDroplet) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	dropletGUID := c.String("droplet-guid")
+	if path := c.String("file"); path != "" {
+		var err error
+		dropletGUID, err = cmd.uploadDroplet(app, path)
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.ui.Say(T("Setting droplet {{.DropletGUID}} of app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"DropletGUID": terminal.EntityNameColor(dropletGUID),
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":    terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	err := cmd.dropletRepo.SetCurrentDroplet(app.GUID, dropletGUID)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	cmd.ui.Say(T("TIP: Use '{{.Command}}' to run the app on the new droplet", map[string]interface{}{
+		"Command": terminal.CommandColor(cf.Name + " restart " + app.Name),
+	}))
+	return nil
+}
+
+// uploadDroplet creates a droplet for the app from the tarball at path and
+// waits for the Cloud Controller to finish processing the upload, returning
+// the guid of the new droplet.
+func (cmd *SetDroplet) uploadDroplet(app models.Application, path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	cmd.ui.Say(T("Uploading droplet {{.Path}} for app {{.AppName}}...", map[string]interface{}{
+		"Path":    terminal.EntityNameColor(path),
+		"AppName": terminal.EntityNameColor(app.Name),
+	}))
+
+	droplet, err := cmd.dropletRepo.CreateDroplet(app.GUID)
+	if err != nil {
+		return "", err
+	}
+
+	err = cmd.dropletRepo.UploadDroplet(droplet.GUID, file)
+	if err != nil {
+		return "", err
+	}
+
+	for droplet.State != models.DropletStateStaged {
+		if droplet.State == models.DropletStateFailed || droplet.State == models.DropletStateExpired {
+			return "", errors.New(T("Processing the uploaded droplet failed; the droplet is {{.State}}", map[string]interface{}{"State": droplet.State}))
+		}
+
+		time.Sleep(cmd.UploadPollInterval)
+
+		droplet, err = cmd.dropletRepo.GetDroplet(droplet.GUID)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return droplet.GUID, nil
+}
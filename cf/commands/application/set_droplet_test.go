@@ -0,0 +1,184 @@
+package application_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/droplets/dropletsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/application"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("set-droplet command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		dropletRepo         *dropletsfakes.FakeRepository
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = api.RepositoryLocator{}.SetDropletRepository(dropletRepo)
+		cmd := commandregistry.Commands.FindCommand("set-droplet").SetDependency(deps, pluginCall).(*application.SetDroplet)
+		cmd.UploadPollInterval = 0
+		commandregistry.Commands.SetCommand(cmd)
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("set-droplet", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		dropletRepo = new(dropletsfakes.FakeRepository)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage when not provided exactly one arg", func() {
+			Expect(runCommand("--droplet-guid", "some-guid")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires an argument"},
+			))
+		})
+
+		It("fails with usage when neither --droplet-guid nor --file is given", func() {
+			Expect(runCommand("my-app")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Specify either --droplet-guid or --file"},
+			))
+		})
+
+		It("fails with usage when both --droplet-guid and --file are given", func() {
+			Expect(runCommand("my-app", "--droplet-guid", "some-guid", "--file", "my-app.tgz")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Specify either --droplet-guid or --file"},
+			))
+		})
+
+		It("requires an API that can upload droplets when --file is given", func() {
+			runCommand("my-app", "--file", "my-app.tgz")
+
+			Expect(requirementsFactory.NewMinAPIVersionRequirementCallCount()).To(Equal(1))
+			feature, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+			Expect(feature).To(Equal("Option '--file'"))
+			Expect(version).To(Equal(cf.DropletUploadMinimumAPIVersion))
+		})
+	})
+
+	It("sets the droplet given with --droplet-guid", func() {
+		Expect(runCommand("my-app", "--droplet-guid", "some-droplet-guid")).To(BeTrue())
+
+		Expect(dropletRepo.CreateDropletCallCount()).To(BeZero())
+		appGUID, dropletGUID := dropletRepo.SetCurrentDropletArgsForCall(0)
+		Expect(appGUID).To(Equal("my-app-guid"))
+		Expect(dropletGUID).To(Equal("some-droplet-guid"))
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Setting droplet some-droplet-guid of app my-app in org my-org / space my-space as my-user..."},
+			[]string{"OK"},
+			[]string{"TIP", "cf restart my-app"},
+		))
+	})
+
+	Context("when --file is given", func() {
+		var (
+			dir    string
+			path   string
+			states []string
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "set-droplet")
+			Expect(err).NotTo(HaveOccurred())
+			path = filepath.Join(dir, "my-app.tgz")
+			Expect(ioutil.WriteFile(path, []byte("droplet-tarball"), 0600)).To(Succeed())
+
+			dropletRepo.CreateDropletReturns(models.Droplet{GUID: "new-droplet-guid", State: "AWAITING_UPLOAD"}, nil)
+			states = []string{"PROCESSING_UPLOAD", "STAGED"}
+			dropletRepo.GetDropletStub = func(dropletGUID string) (models.Droplet, error) {
+				state := states[dropletRepo.GetDropletCallCount()-1]
+				return models.Droplet{GUID: dropletGUID, State: state}, nil
+			}
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("uploads the file into a new droplet, waits for it to be processed and sets it", func() {
+			Expect(runCommand("my-app", "--file", path)).To(BeTrue())
+
+			Expect(dropletRepo.CreateDropletArgsForCall(0)).To(Equal("my-app-guid"))
+			dropletGUID, file := dropletRepo.UploadDropletArgsForCall(0)
+			Expect(dropletGUID).To(Equal("new-droplet-guid"))
+			Expect(file.Name()).To(Equal(path))
+
+			Expect(dropletRepo.GetDropletCallCount()).To(Equal(2))
+			_, dropletGUID = dropletRepo.SetCurrentDropletArgsForCall(0)
+			Expect(dropletGUID).To(Equal("new-droplet-guid"))
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Uploading droplet", path, "for app my-app..."},
+				[]string{"OK"},
+				[]string{"Setting droplet new-droplet-guid of app my-app"},
+			))
+		})
+
+		It("fails without setting the droplet when processing the upload fails", func() {
+			states = []string{"PROCESSING_UPLOAD", "FAILED"}
+
+			Expect(runCommand("my-app", "--file", path)).To(BeFalse())
+
+			Expect(dropletRepo.SetCurrentDropletCallCount()).To(BeZero())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Processing the uploaded droplet failed; the droplet is FAILED"},
+			))
+		})
+
+		It("fails when the upload fails", func() {
+			dropletRepo.UploadDropletReturns(errors.New("upload failed"))
+
+			Expect(runCommand("my-app", "--file", path)).To(BeFalse())
+
+			Expect(dropletRepo.SetCurrentDropletCallCount()).To(BeZero())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"upload failed"}))
+		})
+
+		It("fails when the file does not exist", func() {
+			Expect(runCommand("my-app", "--file", filepath.Join(dir, "missing.tgz"))).To(BeFalse())
+
+			Expect(dropletRepo.CreateDropletCallCount()).To(BeZero())
+		})
+	})
+})
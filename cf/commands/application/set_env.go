@@ -1,7 +1,11 @@
 package application
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
 
 	"code.cloudfoundry.org/cli/cf"
 	"code.cloudfoundry.org/cli/cf/api/applications"
@@ -12,6 +16,8 @@ import (
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/cf/util"
+	"gopkg.in/yaml.v2"
 )
 
 type SetEnv struct {
@@ -32,6 +38,15 @@ func (cmd *SetEnv) MetaData() commandregistry.CommandMetadata {
 		Description: T("Set an env variable for an app"),
 		Usage: []string{
 			T("CF_NAME set-env APP_NAME ENV_VAR_NAME ENV_VAR_VALUE"),
+			"\n   ",
+			T("CF_NAME set-env APP_NAME --from-file PATH_TO_DOTENV_FILE"),
+			"\n   ",
+			T("CF_NAME set-env APP_NAME --from-yaml PATH_TO_YAML_FILE"),
+		},
+		Examples: []string{
+			"CF_NAME set-env my-app LOG_LEVEL debug",
+			"CF_NAME set-env my-app --from-file production.env",
+			"CF_NAME set-env my-app --from-yaml vars.yml   " + T("(a variable set to null in the file is removed from the app)"),
 		},
 		SkipFlagParsing: true,
 	}
@@ -62,6 +77,13 @@ func (cmd *SetEnv) SetDependency(deps commandregistry.Dependency, pluginCall boo
 }
 
 func (cmd *SetEnv) Execute(c flags.FlagContext) error {
+	switch c.Args()[1] {
+	case "--from-file":
+		return cmd.setFromFile(c.Args()[2], util.ParseDotEnv)
+	case "--from-yaml":
+		return cmd.setFromFile(c.Args()[2], parseEnvYAML)
+	}
+
 	varName := c.Args()[1]
 	varValue := c.Args()[2]
 	app := cmd.appReq.GetApplication()
@@ -92,3 +114,121 @@ func (cmd *SetEnv) Execute(c flags.FlagContext) error {
 		map[string]interface{}{"Command": terminal.CommandColor(cf.Name + " restage " + app.Name)}))
 	return nil
 }
+
+// envFileParser returns the env variables in a file, with nil for variables
+// that the file removes.
+type envFileParser func(path string, contents []byte) (map[string]*string, error)
+
+// setFromFile sets every env variable in the file at path with a single
+// update of the app.
+func (cmd *SetEnv) setFromFile(path string, parse envFileParser) error {
+	app := cmd.appReq.GetApplication()
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.New(T("Error reading env file {{.Path}}: {{.Err}}",
+			map[string]interface{}{"Path": path, "Err": err.Error()}))
+	}
+
+	vars, err := parse(path, contents)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(T("Setting env variables from {{.Path}} for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"Path":        terminal.EntityNameColor(path),
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username())}))
+
+	envParams := map[string]interface{}{}
+	for name, value := range app.EnvironmentVars {
+		envParams[name] = value
+	}
+
+	var added, changed, removed []string
+	for name, value := range vars {
+		current, exists := envParams[name]
+		switch {
+		case value == nil:
+			if exists {
+				delete(envParams, name)
+				removed = append(removed, name)
+			}
+		case !exists:
+			envParams[name] = *value
+			added = append(added, name)
+		case fmt.Sprintf("%v", current) != *value:
+			envParams[name] = *value
+			changed = append(changed, name)
+		}
+	}
+
+	if len(added)+len(changed)+len(removed) == 0 {
+		cmd.ui.Ok()
+		cmd.ui.Say(T("The env variables of app {{.AppName}} are already set as in {{.Path}}",
+			map[string]interface{}{"AppName": app.Name, "Path": path}))
+		return nil
+	}
+
+	_, err = cmd.appRepo.Update(app.GUID, models.AppParams{EnvironmentVars: &envParams})
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	table := cmd.ui.Table([]string{"", ""})
+	for _, group := range []struct {
+		label string
+		names []string
+	}{
+		{T("added:"), added},
+		{T("changed:"), changed},
+		{T("removed:"), removed},
+	} {
+		if len(group.names) > 0 {
+			sort.Strings(group.names)
+			table.Add(group.label, strings.Join(group.names, ", "))
+		}
+	}
+	err = table.Print()
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say("")
+	cmd.ui.Say(T("TIP: Use '{{.Command}}' to ensure your env variable changes take effect",
+		map[string]interface{}{"Command": terminal.CommandColor(cf.Name + " restage " + app.Name)}))
+	return nil
+}
+
+// parseEnvYAML reads a YAML map of env variables. Numbers and booleans are set
+// as strings, and variables set to null are removed.
+func parseEnvYAML(path string, contents []byte) (map[string]*string, error) {
+	yamlVars := map[string]interface{}{}
+	err := yaml.Unmarshal(contents, &yamlVars)
+	if err != nil {
+		return nil, errors.New(T("Error reading env file {{.Path}}: {{.Err}}",
+			map[string]interface{}{"Path": path, "Err": err.Error()}))
+	}
+
+	vars := map[string]*string{}
+	for name, value := range yamlVars {
+		switch value.(type) {
+		case nil:
+			vars[name] = nil
+		case string, int, int64, float64, bool:
+			stringValue := fmt.Sprintf("%v", value)
+			vars[name] = &stringValue
+		default:
+			return nil, errors.New(T("Value of {{.Name}} in {{.Path}} must be a string, number or boolean",
+				map[string]interface{}{"Name": name, "Path": path}))
+		}
+	}
+
+	return vars, nil
+}
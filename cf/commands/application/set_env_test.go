@@ -2,6 +2,8 @@ package application_test
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
 
 	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
@@ -182,5 +184,127 @@ var _ = Describe("set-env command", func() {
 				[]string{"TIP: Use 'cf restage my-app' to ensure your env variable changes take effect"},
 			))
 		})
+
+		Describe("setting many variables from a file", func() {
+			var envFile *os.File
+
+			writeEnvFile := func(contents string) string {
+				var err error
+				envFile, err = ioutil.TempFile("", "set-env")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = envFile.WriteString(contents)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(envFile.Close()).To(Succeed())
+				return envFile.Name()
+			}
+
+			BeforeEach(func() {
+				envFile = nil
+			})
+
+			AfterEach(func() {
+				if envFile != nil {
+					os.Remove(envFile.Name())
+				}
+			})
+
+			Context("with --from-file", func() {
+				It("sets the variables in a dotenv file with one update", func() {
+					path := writeEnvFile(`# production settings
+export DATABASE_URL=mysql://example.com/my-db
+foo=baz # overrides bar
+GREETING="hello\nworld"
+RAW='$HOME stays as it is'
+
+EMPTY=
+`)
+					runCommand("my-app", "--from-file", path)
+
+					Expect(appRepo.UpdateCallCount()).To(Equal(1))
+					appGUID, params := appRepo.UpdateArgsForCall(0)
+					Expect(appGUID).To(Equal(app.GUID))
+					Expect(*params.EnvironmentVars).To(Equal(map[string]interface{}{
+						"DATABASE_URL": "mysql://example.com/my-db",
+						"foo":          "baz",
+						"GREETING":     "hello\nworld",
+						"RAW":          "$HOME stays as it is",
+						"EMPTY":        "",
+					}))
+
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Setting env variables from", path, "my-app", "my-org", "my-space", "my-user"},
+						[]string{"OK"},
+						[]string{"added:", "DATABASE_URL, EMPTY, GREETING, RAW"},
+						[]string{"changed:", "foo"},
+						[]string{"TIP: Use 'cf restage my-app'"},
+					))
+					Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"mysql://example.com/my-db"}))
+				})
+
+				It("fails with the line number of a malformed line without printing its value", func() {
+					path := writeEnvFile("GOOD=value\nsecret-value-without-a-name\n")
+					runCommand("my-app", "--from-file", path)
+
+					Expect(appRepo.UpdateCallCount()).To(BeZero())
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"FAILED"},
+						[]string{"Invalid line 2 in", path},
+					))
+					Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"secret-value-without-a-name"}))
+				})
+
+				It("fails when the file cannot be read", func() {
+					runCommand("my-app", "--from-file", "/non/existent/file.env")
+
+					Expect(appRepo.UpdateCallCount()).To(BeZero())
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"FAILED"},
+						[]string{"Error reading env file /non/existent/file.env"},
+					))
+				})
+
+				It("does not update the app when nothing changed", func() {
+					path := writeEnvFile("foo=bar\n")
+					runCommand("my-app", "--from-file", path)
+
+					Expect(appRepo.UpdateCallCount()).To(BeZero())
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"OK"},
+						[]string{"The env variables of app my-app are already set as in", path},
+					))
+				})
+			})
+
+			Context("with --from-yaml", func() {
+				It("sets scalar values as strings and removes null values", func() {
+					path := writeEnvFile("foo: ~\nWORKERS: 4\nDEBUG: false\nNAME: my-app\n")
+					runCommand("my-app", "--from-yaml", path)
+
+					Expect(appRepo.UpdateCallCount()).To(Equal(1))
+					_, params := appRepo.UpdateArgsForCall(0)
+					Expect(*params.EnvironmentVars).To(Equal(map[string]interface{}{
+						"WORKERS": "4",
+						"DEBUG":   "false",
+						"NAME":    "my-app",
+					}))
+
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"added:", "DEBUG, NAME, WORKERS"},
+						[]string{"removed:", "foo"},
+					))
+				})
+
+				It("fails when a value is not a scalar", func() {
+					path := writeEnvFile("NESTED:\n  key: value\n")
+					runCommand("my-app", "--from-yaml", path)
+
+					Expect(appRepo.UpdateCallCount()).To(BeZero())
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"FAILED"},
+						[]string{"Value of NESTED in", path, "must be a string, number or boolean"},
+					))
+				})
+			})
+		})
 	})
 })
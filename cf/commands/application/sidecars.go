@@ -0,0 +1,115 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api/sidecars"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/formatters"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type Sidecars struct {
+	ui          terminal.UI
+	config      coreconfig.Reader
+	sidecarRepo sidecars.Repository
+	appReq      requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&Sidecars{})
+}
+
+func (cmd *Sidecars) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "sidecars",
+		Description: T("List the sidecars of an app"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME sidecars %s", T("APP_NAME")),
+			"\n\n",
+			T("Sidecars are defined in the 'sidecars' section of an app in the manifest and are created or updated by push:"),
+			"\n\n",
+			"   applications:\n",
+			"   - name: my-app\n",
+			"     memory: 512M\n",
+			"     sidecars:\n",
+			"     - name: proxy\n",
+			"       command: ./proxy\n",
+			"       process_types: [web]\n",
+			"       memory: 64M",
+		},
+	}
+}
+
+func (cmd *Sidecars) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("sidecars"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("sidecars", cf.SidecarsMinimumAPIVersion),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *Sidecars) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.sidecarRepo = deps.RepoLocator.GetSidecarRepository()
+	return cmd
+}
+
+func (cmd *Sidecars) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say(T("Getting sidecars for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	appSidecars, err := cmd.sidecarRepo.ListSidecars(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(appSidecars) == 0 {
+		cmd.ui.Say(T("No sidecars found"))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{T("name"), T("process types"), T("memory"), T("command")})
+	for _, sidecar := range appSidecars {
+		memory := ""
+		if sidecar.MemoryInMB > 0 {
+			memory = formatters.ByteSize(sidecar.MemoryInMB * formatters.MEGABYTE)
+		}
+
+		table.Add(
+			sidecar.Name,
+			strings.Join(sidecar.ProcessTypes, ", "),
+			memory,
+			sidecar.Command,
+		)
+	}
+
+	return table.Print()
+}
@@ -0,0 +1,101 @@
+package application_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/sidecars/sidecarsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("sidecars command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		sidecarRepo         *sidecarsfakes.FakeRepository
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = api.RepositoryLocator{}.SetSidecarRepository(sidecarRepo)
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("sidecars").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("sidecars", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		sidecarRepo = new(sidecarsfakes.FakeRepository)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+	})
+
+	It("fails with usage when not given an app name", func() {
+		runCommand()
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Requires an argument"},
+		))
+	})
+
+	It("fails when the targeted API does not support sidecars", func() {
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Failing{Message: "sidecars requires CF API version 2.135.0+"})
+		Expect(runCommand("my-app")).To(BeFalse())
+	})
+
+	It("lists the sidecars of the app", func() {
+		sidecarRepo.ListSidecarsReturns([]models.Sidecar{
+			{Name: "proxy", Command: "./proxy", ProcessTypes: []string{"web"}, MemoryInMB: 64},
+			{Name: "shipper", Command: "./ship-logs", ProcessTypes: []string{"web", "worker"}},
+		}, nil)
+
+		Expect(runCommand("my-app")).To(BeTrue())
+		Expect(sidecarRepo.ListSidecarsArgsForCall(0)).To(Equal("my-app-guid"))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Getting sidecars for app", "my-app", "my-org", "my-space", "my-user"},
+			[]string{"OK"},
+			[]string{"name", "process types", "memory", "command"},
+			[]string{"proxy", "web", "64M", "./proxy"},
+			[]string{"shipper", "web, worker", "./ship-logs"},
+		))
+	})
+
+	It("says when the app has no sidecars", func() {
+		sidecarRepo.ListSidecarsReturns([]models.Sidecar{}, nil)
+
+		Expect(runCommand("my-app")).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"No sidecars found"}))
+	})
+
+	It("fails when the sidecars cannot be listed", func() {
+		sidecarRepo.ListSidecarsReturns(nil, errors.New("list failed"))
+
+		Expect(runCommand("my-app")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"FAILED"}, []string{"list failed"}))
+	})
+})
@@ -3,6 +3,8 @@ package application
 import (
 	"fmt"
 
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api/appfeatures"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/flags"
@@ -12,9 +14,10 @@ import (
 )
 
 type SSHEnabled struct {
-	ui     terminal.UI
-	config coreconfig.Reader
-	appReq requirements.ApplicationRequirement
+	ui             terminal.UI
+	config         coreconfig.Reader
+	appFeatureRepo appfeatures.Repository
+	appReq         requirements.ApplicationRequirement
 }
 
 func init() {
@@ -51,16 +54,33 @@ func (cmd *SSHEnabled) Requirements(requirementsFactory requirements.Factory, fc
 func (cmd *SSHEnabled) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
+	cmd.appFeatureRepo = deps.RepoLocator.GetAppFeatureRepository()
 	return cmd
 }
 
 func (cmd *SSHEnabled) Execute(fc flags.FlagContext) error {
 	app := cmd.appReq.GetApplication()
 
-	if app.EnableSSH {
+	// Newer APIs also account for SSH being disabled for the whole space or
+	// foundation, which the enable_ssh setting of the app does not show.
+	enabled := app.EnableSSH
+	var reason string
+	if cmd.config.IsMinAPIVersion(cf.AppFeaturesMinimumAPIVersion) {
+		sshEnabled, err := cmd.appFeatureRepo.GetSSHEnabled(app.GUID)
+		if err != nil {
+			return err
+		}
+		enabled = sshEnabled.Enabled
+		reason = sshEnabled.Reason
+	}
+
+	if enabled {
 		cmd.ui.Say(fmt.Sprintf(T("ssh support is enabled for")+" '%s'", app.Name))
 	} else {
 		cmd.ui.Say(fmt.Sprintf(T("ssh support is disabled for")+" '%s'", app.Name))
+		if reason != "" {
+			cmd.ui.Say(T("Reason: {{.Reason}}", map[string]interface{}{"Reason": reason}))
+		}
 	}
 
 	cmd.ui.Say("")
@@ -1,6 +1,10 @@
 package application_test
 
 import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api/appfeatures/appfeaturesfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/models"
@@ -20,6 +24,7 @@ var _ = Describe("disable-ssh command", func() {
 		ui                  *testterm.FakeUI
 		requirementsFactory *requirementsfakes.FakeFactory
 		configRepo          coreconfig.Repository
+		appFeatureRepo      *appfeaturesfakes.FakeRepository
 		deps                commandregistry.Dependency
 	)
 
@@ -27,11 +32,13 @@ var _ = Describe("disable-ssh command", func() {
 		ui = &testterm.FakeUI{}
 		configRepo = testconfig.NewRepositoryWithDefaults()
 		requirementsFactory = new(requirementsfakes.FakeFactory)
+		appFeatureRepo = new(appfeaturesfakes.FakeRepository)
 	})
 
 	updateCommandDependency := func(pluginCall bool) {
 		deps.UI = ui
 		deps.Config = configRepo
+		deps.RepoLocator = deps.RepoLocator.SetAppFeatureRepository(appFeatureRepo)
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("ssh-enabled").SetDependency(deps, pluginCall))
 	}
 
@@ -105,6 +112,42 @@ var _ = Describe("disable-ssh command", func() {
 			})
 		})
 
+		Context("when the API reports whether ssh is allowed for the app", func() {
+			BeforeEach(func() {
+				configRepo.SetAPIVersion(cf.AppFeaturesMinimumAPIVersion.String())
+				app.EnableSSH = true
+				applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+				applicationReq.GetApplicationReturns(app)
+				requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+			})
+
+			It("reports ssh as disabled along with the reason", func() {
+				appFeatureRepo.GetSSHEnabledReturns(models.SSHEnabled{Enabled: false, Reason: "Disabled for space my-space"}, nil)
+
+				Expect(runCommand("my-app")).To(BeTrue())
+
+				Expect(appFeatureRepo.GetSSHEnabledArgsForCall(0)).To(Equal("my-app-guid"))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"ssh support is disabled for 'my-app'"},
+					[]string{"Reason: Disabled for space my-space"},
+				))
+			})
+
+			It("reports ssh as enabled", func() {
+				appFeatureRepo.GetSSHEnabledReturns(models.SSHEnabled{Enabled: true}, nil)
+
+				Expect(runCommand("my-app")).To(BeTrue())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"ssh support is enabled for 'my-app'"}))
+			})
+
+			It("fails when the API cannot be asked", func() {
+				appFeatureRepo.GetSSHEnabledReturns(models.SSHEnabled{}, errors.New("boom"))
+
+				Expect(runCommand("my-app")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"boom"}))
+			})
+		})
+
 	})
 
 })
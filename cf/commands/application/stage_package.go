@@ -0,0 +1,104 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type StagePackage struct {
+	ui              terminal.UI
+	config          coreconfig.Reader
+	deploymentActor actors.DeploymentActor
+	appReq          requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&StagePackage{})
+}
+
+func (cmd *StagePackage) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "stage-package",
+		Description: T("Stage a package of an app into a new droplet, without changing the droplet the app runs"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME stage-package %s %s", T("APP_NAME"), T("PACKAGE_GUID")),
+			"\n\n",
+			T("Use set-droplet to run the app on the new droplet."),
+		},
+		Examples: []string{
+			"CF_NAME stage-package my-app 3b4c5d6e-7f80-4a21-9b2c-5d6e7f8a9b10",
+		},
+		TotalArgs: 2,
+	}
+}
+
+func (cmd *StagePackage) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires APP_NAME and PACKAGE_GUID as arguments\n\n") + commandregistry.Commands.CommandUsage("stage-package"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 2)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("stage-package", cf.BuildsMinimumAPIVersion),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *StagePackage) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.deploymentActor = deps.DeploymentActor
+	return cmd
+}
+
+func (cmd *StagePackage) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+	packageGUID := c.Args()[1]
+
+	cmd.ui.Say(T("Staging package {{.PackageGUID}} of app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"PackageGUID": terminal.EntityNameColor(packageGUID),
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":    terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	build, err := cmd.deploymentActor.StagePackage(packageGUID)
+	if err != nil {
+		return err
+	}
+
+	if build.State != models.BuildStateStaged {
+		return errors.New(T("Package {{.PackageGUID}} of app {{.AppName}} failed to stage: {{.Error}}", map[string]interface{}{
+			"PackageGUID": packageGUID,
+			"AppName":     app.Name,
+			"Error":       build.Error,
+		}))
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Droplet guid: {{.DropletGUID}}", map[string]interface{}{"DropletGUID": terminal.EntityNameColor(build.DropletGUID)}))
+	cmd.ui.Say(T("TIP: Use '{{.Command}}' to run the app on the new droplet", map[string]interface{}{
+		"Command": terminal.CommandColor(cf.Name + " set-droplet " + app.Name + " --droplet-guid " + build.DropletGUID),
+	}))
+
+	return nil
+}
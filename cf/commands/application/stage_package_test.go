@@ -0,0 +1,104 @@
+package application_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("stage-package command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		deploymentActor     *actorsfakes.FakeDeploymentActor
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.DeploymentActor = deploymentActor
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("stage-package").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("stage-package", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		deploymentActor = new(actorsfakes.FakeDeploymentActor)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+
+		deploymentActor.StagePackageReturns(models.Build{State: models.BuildStateStaged, DropletGUID: "new-droplet-guid"}, nil)
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage when not given an app name and a package guid", func() {
+			Expect(runCommand("my-app")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires APP_NAME and PACKAGE_GUID as arguments"},
+			))
+		})
+
+		It("requires an API with builds", func() {
+			runCommand("my-app", "package-guid")
+			command, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+			Expect(command).To(Equal("stage-package"))
+			Expect(version).To(Equal(cf.BuildsMinimumAPIVersion))
+		})
+	})
+
+	It("stages the package and prints the new droplet", func() {
+		Expect(runCommand("my-app", "package-guid")).To(BeTrue())
+
+		Expect(deploymentActor.StagePackageArgsForCall(0)).To(Equal("package-guid"))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Staging package package-guid of app my-app in org my-org / space my-space as my-user..."},
+			[]string{"OK"},
+			[]string{"Droplet guid: new-droplet-guid"},
+			[]string{"TIP", "cf set-droplet my-app --droplet-guid new-droplet-guid"},
+		))
+	})
+
+	It("fails when staging fails", func() {
+		deploymentActor.StagePackageReturns(models.Build{State: models.BuildStateFailed, Error: "NoAppDetectedError"}, nil)
+
+		Expect(runCommand("my-app", "package-guid")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Package package-guid of app my-app failed to stage: NoAppDetectedError"},
+		))
+	})
+
+	It("fails when the build cannot be created", func() {
+		deploymentActor.StagePackageReturns(models.Build{}, errors.New("package is not ready"))
+
+		Expect(runCommand("my-app", "package-guid")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"package is not ready"}))
+	})
+})
@@ -3,9 +3,6 @@ package application
 import (
 	"errors"
 	"fmt"
-	"os"
-	"sort"
-	"strconv"
 	"strings"
 	"time"
 
@@ -14,6 +11,7 @@ import (
 	"sync/atomic"
 
 	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
 	"code.cloudfoundry.org/cli/cf/api/appinstances"
 	"code.cloudfoundry.org/cli/cf/api/applications"
 	"code.cloudfoundry.org/cli/cf/api/logs"
@@ -26,13 +24,17 @@ import (
 	"code.cloudfoundry.org/cli/cf/terminal"
 )
 
+const LogMessageTypeStaging = "STG"
+
 const (
-	DefaultStagingTimeout = 15 * time.Minute
-	DefaultStartupTimeout = 5 * time.Minute
-	DefaultPingerThrottle = 5 * time.Second
-)
+	// StagingLogBufferSize is how many staging log messages are held while
+	// the terminal catches up with a burst of output from the log server.
+	StagingLogBufferSize = 1024
 
-const LogMessageTypeStaging = "STG"
+	// MaxStagingLogReconnects is how many times a dropped connection to the
+	// log server is reestablished while an app is staging.
+	MaxStagingLogReconnects = 3
+)
 
 //go:generate counterfeiter . StagingWatcher
 
@@ -45,6 +47,8 @@ type StagingWatcher interface {
 type Starter interface {
 	commandregistry.Command
 	SetStartTimeoutInSeconds(timeout int)
+	SetStagingTimeoutInSeconds(timeout int)
+	SetStagingStateListener(listener func(appName string, state string))
 	ApplicationStart(app models.Application, orgName string, spaceName string) (updatedApp models.Application, err error)
 }
 
@@ -58,9 +62,12 @@ type Start struct {
 	appInstancesRepo appinstances.Repository
 
 	LogServerConnectionTimeout time.Duration
-	StartupTimeout             time.Duration
-	StagingTimeout             time.Duration
-	PingerThrottle             time.Duration
+
+	// Monitor waits for the app to stage and for an instance to run; its
+	// timeouts and poll interval can be changed once dependencies are set.
+	Monitor *actors.StartMonitor
+
+	stagingStateListener func(appName string, state string)
 }
 
 func init() {
@@ -102,29 +109,12 @@ func (cmd *Start) SetDependency(deps commandregistry.Dependency, pluginCall bool
 	cmd.appInstancesRepo = deps.RepoLocator.GetAppInstancesRepository()
 	cmd.logRepo = deps.RepoLocator.GetLogsRepository()
 	cmd.LogServerConnectionTimeout = 20 * time.Second
-	cmd.PingerThrottle = DefaultPingerThrottle
-
-	if os.Getenv("CF_STAGING_TIMEOUT") != "" {
-		duration, err := strconv.ParseInt(os.Getenv("CF_STAGING_TIMEOUT"), 10, 64)
-		if err != nil {
-			cmd.ui.Failed(T("invalid value for env var CF_STAGING_TIMEOUT\n{{.Err}}",
-				map[string]interface{}{"Err": err}))
-		}
-		cmd.StagingTimeout = time.Duration(duration) * time.Minute
-	} else {
-		cmd.StagingTimeout = DefaultStagingTimeout
-	}
 
-	if os.Getenv("CF_STARTUP_TIMEOUT") != "" {
-		duration, err := strconv.ParseInt(os.Getenv("CF_STARTUP_TIMEOUT"), 10, 64)
-		if err != nil {
-			cmd.ui.Failed(T("invalid value for env var CF_STARTUP_TIMEOUT\n{{.Err}}",
-				map[string]interface{}{"Err": err}))
-		}
-		cmd.StartupTimeout = time.Duration(duration) * time.Minute
-	} else {
-		cmd.StartupTimeout = DefaultStartupTimeout
+	timeouts, err := actors.StartTimeoutsFromEnv()
+	if err != nil {
+		cmd.ui.Failed(err.Error())
 	}
+	cmd.Monitor = actors.NewStartMonitor(cmd.appRepo, cmd.appInstancesRepo, cmd.logRepo, timeouts)
 
 	appCommand := commandregistry.Commands.FindCommand("app")
 	appCommand = appCommand.SetDependency(deps, false)
@@ -187,7 +177,7 @@ func (cmd *Start) WatchStaging(app models.Application, orgName, spaceName string
 	cmd.ui.Say("")
 
 	if !isStaged {
-		return models.Application{}, fmt.Errorf("%s failed to stage within %f minutes", app.Name, cmd.StagingTimeout.Minutes())
+		return models.Application{}, fmt.Errorf("%s failed to stage within %f minutes of its last staging log output", app.Name, cmd.Monitor.Timeouts.Staging.Minutes())
 	}
 
 	if app.InstanceCount > 0 {
@@ -231,7 +221,17 @@ func (cmd *Start) WatchStaging(app models.Application, orgName, spaceName string
 }
 
 func (cmd *Start) SetStartTimeoutInSeconds(timeout int) {
-	cmd.StartupTimeout = time.Duration(timeout) * time.Second
+	cmd.Monitor.Timeouts.Startup = time.Duration(timeout) * time.Second
+}
+
+func (cmd *Start) SetStagingTimeoutInSeconds(timeout int) {
+	cmd.Monitor.Timeouts.Staging = time.Duration(timeout) * time.Second
+}
+
+// SetStagingStateListener sets a function that is called with the package
+// state of the app every time it changes while the app is staging.
+func (cmd *Start) SetStagingStateListener(listener func(appName string, state string)) {
+	cmd.stagingStateListener = listener
 }
 
 type ConnectionType int
@@ -243,25 +243,75 @@ const (
 	StoppedTrying
 )
 
+// TailStagingLogs prints the staging logs of the app until told to stop on
+// stopChan. Messages are buffered so that bursts from the log server are not
+// dropped or cut short, and a connection that drops while the app is staging
+// is reestablished up to MaxStagingLogReconnects times. Every staging message
+// counts as staging activity, which holds off the staging timeout.
 func (cmd *Start) TailStagingLogs(app models.Application, stopChan chan bool, startWait, doneWait *sync.WaitGroup) {
 	var connectionStatus atomic.Value
 	connectionStatus.Store(NoConnection)
 
+	var startedOnce sync.Once
+	started := func() {
+		startedOnce.Do(startWait.Done)
+	}
+
 	onConnect := func() {
 		if connectionStatus.Load() != StoppedTrying {
 			connectionStatus.Store(ConnectionWasEstablished)
-			startWait.Done()
+			started()
 		}
 	}
 
 	timer := time.NewTimer(cmd.LogServerConnectionTimeout)
 
-	c := make(chan logs.Loggable)
-	e := make(chan error)
+	var (
+		c chan logs.Loggable
+		e chan error
+	)
+	tail := func() {
+		c = make(chan logs.Loggable, StagingLogBufferSize)
+		e = make(chan error, 1)
+		go cmd.logRepo.TailLogsFor(app.GUID, onConnect, c, e)
+	}
 
 	defer doneWait.Done()
 
-	go cmd.logRepo.TailLogsFor(app.GUID, onConnect, c, e)
+	sayStagingLog := func(msg logs.Loggable) {
+		if msg.GetSourceName() == LogMessageTypeStaging {
+			cmd.Monitor.RecordStagingActivity()
+			cmd.ui.Say(msg.ToSimpleLog())
+		}
+	}
+
+	reconnects := 0
+	reconnect := func() bool {
+		if connectionStatus.Load() != ConnectionWasEstablished || reconnects >= MaxStagingLogReconnects {
+			return false
+		}
+
+		// print what the dropped connection had already delivered
+		for drained := false; !drained; {
+			select {
+			case msg, ok := <-c:
+				if ok {
+					sayStagingLog(msg)
+				} else {
+					drained = true
+				}
+			default:
+				drained = true
+			}
+		}
+
+		reconnects++
+		cmd.ui.Warn(T("Lost connection to log server, reconnecting..."))
+		tail()
+		return true
+	}
+
+	tail()
 
 	for {
 		select {
@@ -269,26 +319,33 @@ func (cmd *Start) TailStagingLogs(app models.Application, stopChan chan bool, st
 			if connectionStatus.Load() == NoConnection {
 				connectionStatus.Store(StoppedTrying)
 				cmd.ui.Warn("timeout connecting to log server, no log will be shown")
-				startWait.Done()
+				started()
 				return
 			}
 		case msg, ok := <-c:
 			if !ok {
+				if reconnect() {
+					continue
+				}
 				return
-			} else if msg.GetSourceName() == LogMessageTypeStaging {
-				cmd.ui.Say(msg.ToSimpleLog())
 			}
+			sayStagingLog(msg)
 
 		case err, ok := <-e:
 			if ok {
 				if connectionStatus.Load() != ConnectionWasClosed {
+					if reconnect() {
+						continue
+					}
+
 					cmd.ui.Warn(T("Warning: error tailing logs"))
 					cmd.ui.Say("%s", err)
-					if connectionStatus.Load() == NoConnection {
-						startWait.Done()
-					}
+					started()
 					return
 				}
+			} else {
+				// a closed error channel would otherwise be selected forever
+				e = nil
 			}
 
 		case <-stopChan:
@@ -303,23 +360,11 @@ func (cmd *Start) TailStagingLogs(app models.Application, stopChan chan bool, st
 }
 
 func (cmd *Start) waitForInstancesToStage(app models.Application) (bool, error) {
-	stagingStartTime := time.Now()
-
-	var err error
-
-	if cmd.StagingTimeout == 0 {
-		app, err = cmd.appRepo.GetApp(app.GUID)
-	} else {
-		for app.PackageState != "STAGED" && app.PackageState != "FAILED" && time.Since(stagingStartTime) < cmd.StagingTimeout {
-			app, err = cmd.appRepo.GetApp(app.GUID)
-			if err != nil {
-				break
-			}
-
-			time.Sleep(cmd.PingerThrottle)
+	app, err := cmd.Monitor.WaitForStaging(app, func(app models.Application) {
+		if cmd.stagingStateListener != nil {
+			cmd.stagingStateListener(app.Name, app.PackageState)
 		}
-	}
-
+	})
 	if err != nil {
 		return false, err
 	}
@@ -346,126 +391,76 @@ Use '{{.Command}}' for more in depth log information.`,
 				"Command": terminal.CommandColor(fmt.Sprintf("%s logs %s --recent", cf.Name, app.Name))}))
 	}
 
-	if time.Since(stagingStartTime) >= cmd.StagingTimeout {
-		return false, nil
-	}
-
-	return true, nil
+	return app.PackageState == "STAGED", nil
 }
 
 func (cmd *Start) waitForOneRunningInstance(app models.Application) error {
-	timer := time.NewTimer(cmd.StartupTimeout)
-
-	for {
-		select {
-		case <-timer.C:
-			tipMsg := T("Start app timeout\n\nTIP: Application must be listening on the right port. Instead of hard coding the port, use the $PORT environment variable.") + "\n\n"
-			tipMsg += T("Use '{{.Command}}' for more information", map[string]interface{}{"Command": terminal.CommandColor(fmt.Sprintf("%s logs %s --recent", cf.Name, app.Name))})
-
-			return errors.New(tipMsg)
-
-		default:
-			count, err := cmd.fetchInstanceCount(app.GUID)
-			if err != nil {
-				cmd.ui.Warn("Could not fetch instance count: %s", err.Error())
-				time.Sleep(cmd.PingerThrottle)
-				continue
-			}
-
-			cmd.ui.Say(instancesDetails(count))
-
-			if count.running > 0 {
-				return nil
-			}
+	counts, err := cmd.Monitor.WaitForRunningInstance(app,
+		func(counts actors.InstanceCounts) {
+			cmd.ui.Say(instancesDetails(counts))
+		},
+		func(err error) {
+			cmd.ui.Warn("Could not fetch instance count: %s", err.Error())
+		},
+	)
 
-			if count.flapping > 0 || count.crashed > 0 {
-				return fmt.Errorf(T("Start unsuccessful\n\nTIP: use '{{.Command}}' for more information",
-					map[string]interface{}{"Command": terminal.CommandColor(fmt.Sprintf("%s logs %s --recent", cf.Name, app.Name))}))
-			}
+	if err == actors.ErrStartupTimeout {
+		tipMsg := T("Start app timeout\n\nTIP: Application must be listening on the right port. Instead of hard coding the port, use the $PORT environment variable.") + "\n\n"
+		tipMsg += T("Use '{{.Command}}' for more information", map[string]interface{}{"Command": terminal.CommandColor(fmt.Sprintf("%s logs %s --recent", cf.Name, app.Name))})
 
-			time.Sleep(cmd.PingerThrottle)
-		}
+		return errors.New(tipMsg)
 	}
-}
-
-type instanceCount struct {
-	running         int
-	starting        int
-	startingDetails map[string]struct{}
-	flapping        int
-	down            int
-	crashed         int
-	total           int
-}
 
-func (cmd Start) fetchInstanceCount(appGUID string) (instanceCount, error) {
-	count := instanceCount{
-		startingDetails: make(map[string]struct{}),
+	if err != nil {
+		return err
 	}
 
-	instances, apiErr := cmd.appInstancesRepo.GetInstances(appGUID)
-	if apiErr != nil {
-		return instanceCount{}, apiErr
+	if !counts.Failed() {
+		return nil
 	}
 
-	count.total = len(instances)
+	message := T("Start unsuccessful")
 
-	for _, inst := range instances {
-		switch inst.State {
-		case models.InstanceRunning:
-			count.running++
-		case models.InstanceStarting:
-			count.starting++
-			if inst.Details != "" {
-				count.startingDetails[inst.Details] = struct{}{}
-			}
-		case models.InstanceFlapping:
-			count.flapping++
-		case models.InstanceDown:
-			count.down++
-		case models.InstanceCrashed:
-			count.crashed++
-		}
+	// the logs only help, so failing to fetch them must not hide the crash
+	crashLogs, err := cmd.Monitor.CrashLogs(app.GUID, counts.FailedIndexes)
+	if err == nil && len(crashLogs) > 0 {
+		message += "\n\n" + T("Last logs of the failed instances:") + "\n" + strings.Join(crashLogs, "\n")
 	}
 
-	return count, nil
+	return errors.New(message + "\n\n" + T("TIP: use '{{.Command}}' for more information",
+		map[string]interface{}{"Command": terminal.CommandColor(fmt.Sprintf("%s logs %s --recent", cf.Name, app.Name))}))
 }
 
-func instancesDetails(count instanceCount) string {
+func instancesDetails(count actors.InstanceCounts) string {
 	details := []string{fmt.Sprintf(T("{{.RunningCount}} of {{.TotalCount}} instances running",
-		map[string]interface{}{"RunningCount": count.running, "TotalCount": count.total}))}
+		map[string]interface{}{"RunningCount": count.Running, "TotalCount": count.Total}))}
 
-	if count.starting > 0 {
-		if len(count.startingDetails) == 0 {
+	if count.Starting > 0 {
+		if len(count.StartingDetails) == 0 {
 			details = append(details, fmt.Sprintf(T("{{.StartingCount}} starting",
-				map[string]interface{}{"StartingCount": count.starting})))
+				map[string]interface{}{"StartingCount": count.Starting})))
 		} else {
-			info := []string{}
-			for d := range count.startingDetails {
-				info = append(info, d)
-			}
-			sort.Strings(info)
 			details = append(details, fmt.Sprintf(T("{{.StartingCount}} starting ({{.Details}})",
 				map[string]interface{}{
-					"StartingCount": count.starting,
-					"Details":       strings.Join(info, ", "),
+					"StartingCount": count.Starting,
+					"Details":       strings.Join(count.StartingDetails, ", "),
 				})))
 		}
 	}
 
-	if count.down > 0 {
+	if count.Down > 0 {
 		details = append(details, fmt.Sprintf(T("{{.DownCount}} down",
-			map[string]interface{}{"DownCount": count.down})))
+			map[string]interface{}{"DownCount": count.Down})))
 	}
 
-	if count.flapping > 0 {
+	if count.Flapping > 0 {
 		details = append(details, fmt.Sprintf(T("{{.FlappingCount}} failing",
-			map[string]interface{}{"FlappingCount": count.flapping})))
+			map[string]interface{}{"FlappingCount": count.Flapping})))
 	}
 
-	if count.crashed > 0 {
+	if count.Crashed > 0 {
 		details = append(details, fmt.Sprintf(T("{{.CrashedCount}} crashed",
-			map[string]interface{}{"CrashedCount": count.crashed})))
+			map[string]interface{}{"CrashedCount": count.Crashed})))
 	}
 
 	return strings.Join(details, ", ")
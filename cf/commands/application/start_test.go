@@ -1,6 +1,7 @@
 package application_test
 
 import (
+	"fmt"
 	"os"
 	"time"
 
@@ -173,9 +174,9 @@ var _ = Describe("start command", func() {
 	callStart := func(args []string) bool {
 		updateCommandDependency(logRepo)
 		cmd := commandregistry.Commands.FindCommand("start").(*Start)
-		cmd.StagingTimeout = 100 * time.Millisecond
-		cmd.StartupTimeout = 500 * time.Millisecond
-		cmd.PingerThrottle = 10 * time.Millisecond
+		cmd.Monitor.Timeouts.Staging = 100 * time.Millisecond
+		cmd.Monitor.Timeouts.Startup = 500 * time.Millisecond
+		cmd.Monitor.PollInterval = 10 * time.Millisecond
 		commandregistry.Register(cmd)
 		return testcmd.RunCLICommandWithoutDependency("start", args, requirementsFactory, ui)
 	}
@@ -187,9 +188,9 @@ var _ = Describe("start command", func() {
 
 		cmd := commandregistry.Commands.FindCommand("start").(*Start)
 		cmd.LogServerConnectionTimeout = 100 * time.Millisecond
-		cmd.StagingTimeout = 100 * time.Millisecond
-		cmd.StartupTimeout = 200 * time.Millisecond
-		cmd.PingerThrottle = 10 * time.Millisecond
+		cmd.Monitor.Timeouts.Staging = 100 * time.Millisecond
+		cmd.Monitor.Timeouts.Startup = 200 * time.Millisecond
+		cmd.Monitor.PollInterval = 10 * time.Millisecond
 		commandregistry.Register(cmd)
 
 		return testcmd.RunCLICommandWithoutDependency("start", args, requirementsFactory, ui)
@@ -227,8 +228,8 @@ var _ = Describe("start command", func() {
 		It("has sane default timeout values", func() {
 			updateCommandDependency(logRepo)
 			cmd := commandregistry.Commands.FindCommand("start").(*Start)
-			Expect(cmd.StagingTimeout).To(Equal(15 * time.Minute))
-			Expect(cmd.StartupTimeout).To(Equal(5 * time.Minute))
+			Expect(cmd.Monitor.Timeouts.Staging).To(Equal(15 * time.Minute))
+			Expect(cmd.Monitor.Timeouts.Startup).To(Equal(5 * time.Minute))
 		})
 
 		It("can read timeout values from environment variables", func() {
@@ -244,8 +245,8 @@ var _ = Describe("start command", func() {
 
 			updateCommandDependency(logRepo)
 			cmd := commandregistry.Commands.FindCommand("start").(*Start)
-			Expect(cmd.StagingTimeout).To(Equal(6 * time.Minute))
-			Expect(cmd.StartupTimeout).To(Equal(3 * time.Minute))
+			Expect(cmd.Monitor.Timeouts.Staging).To(Equal(6 * time.Minute))
+			Expect(cmd.Monitor.Timeouts.Startup).To(Equal(3 * time.Minute))
 		})
 
 		Describe("when the staging timeout is zero seconds", func() {
@@ -270,9 +271,9 @@ var _ = Describe("start command", func() {
 
 				updateCommandDependency(logRepo)
 				cmd := commandregistry.Commands.FindCommand("start").(*Start)
-				cmd.StagingTimeout = 0
-				cmd.PingerThrottle = 1
-				cmd.StartupTimeout = 1
+				cmd.Monitor.Timeouts.Staging = 0
+				cmd.Monitor.PollInterval = 1
+				cmd.Monitor.Timeouts.Startup = 1
 				commandregistry.Register(cmd)
 			})
 
@@ -334,7 +335,7 @@ var _ = Describe("start command", func() {
 
 			updateCommandDependency(logRepo)
 			cmd := commandregistry.Commands.FindCommand("start").(*Start)
-			cmd.PingerThrottle = 10 * time.Millisecond
+			cmd.Monitor.PollInterval = 10 * time.Millisecond
 
 			//defaultAppForStart.State = "started"
 			cmd.ApplicationStart(defaultAppForStart, "some-org", "some-space")
@@ -509,6 +510,126 @@ var _ = Describe("start command", func() {
 			))
 		})
 
+		It("keeps waiting for an app to stage while it logs staging output", func() {
+			stagingDone := make(chan struct{})
+
+			logRepo.TailLogsForStub = func(appGUID string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+				onConnect()
+
+				go func() {
+					for i := 0; ; i++ {
+						select {
+						case <-stagingDone:
+							close(logChan)
+							return
+						case <-time.After(20 * time.Millisecond):
+							logChan <- testlogs.NewLogMessage(fmt.Sprintf("Staging line %d", i), appGUID, LogMessageTypeStaging, "1", logmessage.LogMessage_OUT, time.Now())
+						}
+					}
+				}()
+			}
+
+			logRepo.CloseStub = func() {
+				close(stagingDone)
+			}
+
+			stagingStarted := time.Now()
+			stagedApp := defaultAppForStart
+			stagedApp.PackageState = "STAGED"
+			pendingApp := defaultAppForStart
+			pendingApp.PackageState = "PENDING"
+
+			appRepo.UpdateReturns(pendingApp, nil)
+			appRepo.GetAppStub = func(string) (models.Application, error) {
+				// well past the 100ms staging timeout
+				if time.Since(stagingStarted) < 300*time.Millisecond {
+					return pendingApp, nil
+				}
+				return stagedApp, nil
+			}
+			appInstancesRepo.GetInstancesStub = getInstance
+
+			applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+			applicationReq.GetApplicationReturns(pendingApp)
+			requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+
+			callStart([]string{"my-app"})
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Staging line 0"},
+				[]string{"App started"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"failed to stage"}))
+		})
+
+		It("tells the staging state listener when the package state of the app changes", func() {
+			states := []string{}
+			cmd := commandregistry.Commands.FindCommand("start").(*Start)
+			cmd.SetStagingStateListener(func(appName string, state string) {
+				states = append(states, appName+" "+state)
+			})
+			defer cmd.SetStagingStateListener(nil)
+
+			pendingApp := defaultAppForStart
+			pendingApp.PackageState = "PENDING"
+			stagedApp := defaultAppForStart
+			stagedApp.PackageState = "STAGED"
+
+			appRepo.UpdateReturns(pendingApp, nil)
+			appRepo.GetAppStub = func(string) (models.Application, error) {
+				if appRepo.GetAppCallCount() < 3 {
+					return pendingApp, nil
+				}
+				return stagedApp, nil
+			}
+			appInstancesRepo.GetInstancesStub = getInstance
+
+			applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+			applicationReq.GetApplicationReturns(pendingApp)
+			requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+
+			callStart([]string{"my-app"})
+
+			Expect(states).To(Equal([]string{"my-app PENDING", "my-app STAGED"}))
+		})
+
+		It("reconnects to the log server when the connection drops while staging", func() {
+			closeWait := sync.WaitGroup{}
+			closeWait.Add(1)
+
+			logRepo.TailLogsForStub = func(appGUID string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+				onConnect()
+
+				if logRepo.TailLogsForCallCount() == 1 {
+					logChan <- testlogs.NewLogMessage("Before drop", appGUID, LogMessageTypeStaging, "1", logmessage.LogMessage_OUT, time.Now())
+					errChan <- errors.New("connection reset")
+					close(logChan)
+					return
+				}
+
+				go func() {
+					logChan <- testlogs.NewLogMessage("After reconnect", appGUID, LogMessageTypeStaging, "1", logmessage.LogMessage_OUT, time.Now())
+					closeWait.Wait()
+					close(logChan)
+				}()
+			}
+
+			logRepo.CloseStub = func() {
+				closeWait.Done()
+			}
+
+			ui, _, _ := startAppWithInstancesAndErrors(defaultAppForStart, requirementsFactory)
+
+			Expect(logRepo.TailLogsForCallCount()).To(Equal(2))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Before drop"},
+				[]string{"Lost connection to log server, reconnecting..."},
+				[]string{"After reconnect"},
+				[]string{"App started"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"error tailing logs"}))
+		})
+
 		It("displays an error message when staging fails", func() {
 			defaultAppForStart.PackageState = "FAILED"
 			defaultAppForStart.StagingFailedReason = "AWWW, FAILED"
@@ -623,6 +744,33 @@ var _ = Describe("start command", func() {
 					[]string{"Start unsuccessful"},
 				))
 			})
+
+			It("shows the last logs of the crashed instance", func() {
+				crashed := models.AppInstanceFields{State: models.InstanceCrashed}
+				starting := models.AppInstanceFields{State: models.InstanceStarting}
+				defaultInstanceResponses = [][]models.AppInstanceFields{
+					{starting, crashed},
+				}
+				defaultInstanceErrorCodes = []string{""}
+
+				currentTime := time.Now()
+				logRepo.RecentLogsForReturns([]logs.Loggable{
+					testlogs.NewLogMessage("Staging complete", defaultAppForStart.GUID, "STG", "0", logmessage.LogMessage_OUT, currentTime),
+					testlogs.NewLogMessage("instance 0 is fine", defaultAppForStart.GUID, "APP", "0", logmessage.LogMessage_OUT, currentTime),
+					testlogs.NewLogMessage("panic: database unreachable", defaultAppForStart.GUID, "APP", "1", logmessage.LogMessage_ERR, currentTime),
+				}, nil)
+
+				ui, _, _ := startAppWithInstancesAndErrors(defaultAppForStart, requirementsFactory)
+
+				Expect(logRepo.RecentLogsForArgsForCall(0)).To(Equal(defaultAppForStart.GUID))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Start unsuccessful"},
+					[]string{"Last logs of the failed instances"},
+					[]string{"panic: database unreachable"},
+				))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"instance 0 is fine"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Staging complete"}))
+			})
 		})
 
 		Context("when an app instance is starting", func() {
@@ -0,0 +1,99 @@
+package application
+
+import (
+	"fmt"
+	"strconv"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type Tasks struct {
+	ui        terminal.UI
+	config    coreconfig.Reader
+	taskActor actors.TaskActor
+	appReq    requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&Tasks{})
+}
+
+func (cmd *Tasks) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "tasks",
+		Description: T("List the tasks of an app"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME tasks %s", T("APP_NAME")),
+		},
+	}
+}
+
+func (cmd *Tasks) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("tasks"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("tasks", cf.TasksMinimumAPIVersion),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *Tasks) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.taskActor = deps.TaskActor
+	return cmd
+}
+
+func (cmd *Tasks) Execute(c flags.FlagContext) error {
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say(T("Getting tasks for app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	tasks, err := cmd.taskActor.ListTasks(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(tasks) == 0 {
+		cmd.ui.Say(T("No tasks found"))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{T("id"), T("name"), T("state"), T("start time"), T("command")})
+	for _, task := range tasks {
+		table.Add(
+			strconv.Itoa(task.SequenceID),
+			task.Name,
+			task.State,
+			task.CreatedAt.Local().Format("2006-01-02T15:04:05.00-0700"),
+			task.Command,
+		)
+	}
+
+	return table.Print()
+}
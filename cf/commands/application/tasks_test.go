@@ -0,0 +1,86 @@
+package application_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("tasks command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		taskActor           *actorsfakes.FakeTaskActor
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.TaskActor = taskActor
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("tasks").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("tasks", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		taskActor = new(actorsfakes.FakeTaskActor)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+	})
+
+	It("fails with usage when not given an app name", func() {
+		runCommand()
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Requires an argument"},
+		))
+	})
+
+	It("lists the tasks of the app", func() {
+		taskActor.ListTasksReturns([]models.Task{
+			{SequenceID: 2, Name: "seed", State: "RUNNING", Command: "rake db:seed", CreatedAt: time.Date(2016, 11, 2, 10, 0, 0, 0, time.UTC)},
+			{SequenceID: 1, Name: "migrate", State: "SUCCEEDED", Command: "rake db:migrate", CreatedAt: time.Date(2016, 11, 1, 10, 0, 0, 0, time.UTC)},
+		}, nil)
+
+		Expect(runCommand("my-app")).To(BeTrue())
+		Expect(taskActor.ListTasksArgsForCall(0)).To(Equal("my-app-guid"))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Getting tasks for app", "my-app", "my-org", "my-space", "my-user"},
+			[]string{"OK"},
+			[]string{"id", "name", "state", "start time", "command"},
+			[]string{"2", "seed", "RUNNING", "rake db:seed"},
+			[]string{"1", "migrate", "SUCCEEDED", "rake db:migrate"},
+		))
+	})
+
+	It("tells the user when the app has no tasks", func() {
+		Expect(runCommand("my-app")).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"No tasks found"}))
+	})
+})
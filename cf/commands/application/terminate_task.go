@@ -0,0 +1,92 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type TerminateTask struct {
+	ui        terminal.UI
+	config    coreconfig.Reader
+	taskActor actors.TaskActor
+	appReq    requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&TerminateTask{})
+}
+
+func (cmd *TerminateTask) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "terminate-task",
+		Description: T("Terminate a running task of an app"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME terminate-task %s %s", T("APP_NAME"), T("TASK_ID")),
+		},
+		Examples: []string{
+			"CF_NAME terminate-task my-app 3",
+		},
+	}
+}
+
+func (cmd *TerminateTask) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires APP_NAME and TASK_ID as arguments\n\n") + commandregistry.Commands.CommandUsage("terminate-task"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 2)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement("terminate-task", cf.TasksMinimumAPIVersion),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *TerminateTask) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.taskActor = deps.TaskActor
+	return cmd
+}
+
+func (cmd *TerminateTask) Execute(c flags.FlagContext) error {
+	sequenceID, err := strconv.Atoi(c.Args()[1])
+	if err != nil || sequenceID < 1 {
+		return errors.New(T("Invalid task ID {{.TaskID}}; use the id shown by '{{.Command}}'",
+			map[string]interface{}{"TaskID": c.Args()[1], "Command": cf.Name + " tasks"}))
+	}
+
+	app := cmd.appReq.GetApplication()
+
+	cmd.ui.Say(T("Terminating task {{.TaskID}} of app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"TaskID":      terminal.EntityNameColor(strconv.Itoa(sequenceID)),
+			"AppName":     terminal.EntityNameColor(app.Name),
+			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":   terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	_, err = cmd.taskActor.TerminateTask(app.GUID, sequenceID)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
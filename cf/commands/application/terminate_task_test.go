@@ -0,0 +1,96 @@
+package application_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	cferrors "code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("terminate-task command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		taskActor           *actorsfakes.FakeTaskActor
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.TaskActor = taskActor
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("terminate-task").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("terminate-task", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		taskActor = new(actorsfakes.FakeTaskActor)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		app := models.Application{}
+		app.Name = "my-app"
+		app.GUID = "my-app-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(app)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+	})
+
+	It("fails with usage when not given an app name and a task id", func() {
+		runCommand("my-app")
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Requires APP_NAME and TASK_ID as arguments"},
+		))
+	})
+
+	It("terminates the task with the id", func() {
+		taskActor.TerminateTaskReturns(models.Task{SequenceID: 3, State: "CANCELING"}, nil)
+
+		Expect(runCommand("my-app", "3")).To(BeTrue())
+
+		appGUID, sequenceID := taskActor.TerminateTaskArgsForCall(0)
+		Expect(appGUID).To(Equal("my-app-guid"))
+		Expect(sequenceID).To(Equal(3))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Terminating task 3 of app my-app in org my-org / space my-space as my-user..."},
+			[]string{"OK"},
+		))
+	})
+
+	It("fails when the task id is not a number", func() {
+		Expect(runCommand("my-app", "migrate")).To(BeFalse())
+		Expect(taskActor.TerminateTaskCallCount()).To(BeZero())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Invalid task ID migrate; use the id shown by 'cf tasks'"},
+		))
+	})
+
+	It("tells the user when the app has no such task", func() {
+		taskActor.TerminateTaskReturns(models.Task{}, cferrors.NewModelNotFoundError("Task", "3"))
+
+		Expect(runCommand("my-app", "3")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Task 3 not found"},
+		))
+	})
+})
@@ -0,0 +1,407 @@
+package application
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	gonet "net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/applicationbits"
+	"code.cloudfoundry.org/cli/cf/api/applications"
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	cferrors "code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	sshCmd "code.cloudfoundry.org/cli/cf/ssh"
+	"code.cloudfoundry.org/cli/cf/ssh/options"
+	sshTerminal "code.cloudfoundry.org/cli/cf/ssh/terminal"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+const (
+	// TunnelAppName is the app pushed to forward ports to service instances
+	// when tunnel is not given an app. It is reused by later tunnels in the
+	// same space.
+	TunnelAppName = "cf-tunnel"
+
+	// TunnelServiceKeyName prefixes the name of the service key each tunnel
+	// creates to read the host and port of a service instance from. The key is
+	// deleted when the tunnel closes.
+	TunnelServiceKeyName = "cf-tunnel"
+)
+
+type Tunnel struct {
+	ui             terminal.UI
+	config         coreconfig.Reader
+	gateway        net.Gateway
+	appRepo        applications.Repository
+	appBitsRepo    applicationbits.Repository
+	serviceKeyRepo api.ServiceKeyRepository
+	appStarter     Starter
+	sshCodeGetter  commands.SSHCodeGetter
+	secureShell    sshCmd.SecureShell
+
+	serviceInstanceReq requirements.ServiceInstanceRequirement
+}
+
+func init() {
+	commandregistry.Register(&Tunnel{})
+}
+
+func (cmd *Tunnel) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["local-port"] = &flags.IntFlag{Name: "local-port", ShortName: "p", Usage: T("Local port to forward to the service instance (Default: the port of the service instance)")}
+	fs["app"] = &flags.StringFlag{Name: "app", Usage: T("Running app to tunnel through, instead of pushing or reusing the {{.AppName}} app", map[string]interface{}{"AppName": TunnelAppName})}
+	fs["skip-host-validation"] = &flags.BoolFlag{Name: "skip-host-validation", ShortName: "k", Usage: T("Skip host key validation")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "tunnel",
+		Description: T("Forward a local port to a service instance through an SSH tunnel"),
+		Usage: []string{
+			T("CF_NAME tunnel SERVICE_INSTANCE [--local-port PORT] [--app APP_NAME] [--skip-host-validation]"),
+			"\n\n",
+			T("The host and port of the service instance are read from a service key that the tunnel creates, and deletes when it closes. Unless --app is given, the tunnel runs through the '{{.AppName}}' app, which is pushed to the space if it does not exist.",
+				map[string]interface{}{"AppName": TunnelAppName}),
+		},
+		Examples: []string{
+			"CF_NAME tunnel my-db --local-port 5432",
+			"CF_NAME tunnel my-cache --app my-app",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *Tunnel) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires SERVICE_INSTANCE as argument") + "\n\n" + commandregistry.Commands.CommandUsage("tunnel"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	if fc.IsSet("local-port") && (fc.Int("local-port") < 1 || fc.Int("local-port") > 65535) {
+		cmd.ui.Failed(fmt.Sprintf(T("Incorrect Usage:")+" %s\n\n%s", T("Value for flag 'local-port' must be between 1 and 65535"), commandregistry.Commands.CommandUsage("tunnel")))
+		return nil, errors.New("Incorrect usage: local-port out of range")
+	}
+
+	cmd.serviceInstanceReq = requirementsFactory.NewServiceInstanceRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		cmd.serviceInstanceReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *Tunnel) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.gateway = deps.Gateways["cloud-controller"]
+	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
+	cmd.appBitsRepo = deps.RepoLocator.GetApplicationBitsRepository()
+	cmd.serviceKeyRepo = deps.RepoLocator.GetServiceKeyRepository()
+
+	if deps.WildcardDependency != nil {
+		cmd.secureShell = deps.WildcardDependency.(sshCmd.SecureShell)
+	}
+
+	appCommand := commandregistry.Commands.FindCommand("start")
+	appCommand = appCommand.SetDependency(deps, false)
+	cmd.appStarter = appCommand.(Starter)
+
+	//get ssh-code for dependency
+	sshCodeGetter := commandregistry.Commands.FindCommand("ssh-code")
+	sshCodeGetter = sshCodeGetter.SetDependency(deps, false)
+	cmd.sshCodeGetter = sshCodeGetter.(commands.SSHCodeGetter)
+
+	return cmd
+}
+
+func (cmd *Tunnel) Execute(fc flags.FlagContext) error {
+	serviceInstance := cmd.serviceInstanceReq.GetServiceInstance()
+
+	serviceKey, err := cmd.createServiceKey(serviceInstance)
+	if err != nil {
+		return err
+	}
+	defer cmd.deleteServiceKey(serviceKey)
+
+	host, port := addressFromCredentials(serviceKey.Credentials)
+	if host == "" || port == "" {
+		return errors.New(T("Could not find a host and port in the credentials of service instance {{.ServiceInstance}}",
+			map[string]interface{}{"ServiceInstance": serviceInstance.Name}))
+	}
+
+	localPort := port
+	if fc.IsSet("local-port") {
+		localPort = strconv.Itoa(fc.Int("local-port"))
+	}
+
+	var app models.Application
+	if fc.IsSet("app") {
+		app, err = cmd.appRepo.Read(fc.String("app"))
+	} else {
+		app, err = cmd.findOrPushTunnelApp()
+	}
+	if err != nil {
+		return err
+	}
+
+	info, err := getSSHEndpointInfo(cmd.gateway, cmd.config)
+	if err != nil {
+		return errors.New(T("Error getting SSH info:") + err.Error())
+	}
+
+	sshAuthCode, err := cmd.sshCodeGetter.Get()
+	if err != nil {
+		return errors.New(T("Error getting one time auth code: ") + err.Error())
+	}
+
+	//init secureShell if it is not already set by SetDependency() with fakes
+	if cmd.secureShell == nil {
+		cmd.secureShell = sshCmd.NewSecureShell(
+			sshCmd.DefaultSecureDialer(),
+			sshTerminal.DefaultHelper(),
+			sshCmd.DefaultListenerFactory(),
+			30*time.Second,
+			app,
+			info.SSHEndpointFingerprint,
+			info.SSHEndpoint,
+			sshAuthCode,
+		)
+	}
+
+	opts := &options.SSHOptions{
+		AppName:             app.Name,
+		SkipHostValidation:  fc.Bool("k"),
+		SkipRemoteExecution: true,
+		ForwardSpecs: []options.ForwardSpec{{
+			ListenAddress:  "localhost:" + localPort,
+			ConnectAddress: host + ":" + port,
+		}},
+	}
+
+	err = cmd.secureShell.Connect(opts)
+	if err != nil {
+		return errors.New(T("Error opening SSH connection: ") + err.Error())
+	}
+	defer cmd.secureShell.Close()
+
+	err = cmd.secureShell.LocalPortForward()
+	if err != nil {
+		return errors.New(T("Error forwarding port: ") + err.Error())
+	}
+
+	cmd.ui.Say(T("Forwarding localhost:{{.LocalPort}} to service instance {{.ServiceInstance}} through app {{.AppName}}. Press Ctrl-C to stop.",
+		map[string]interface{}{
+			"LocalPort":       localPort,
+			"ServiceInstance": terminal.EntityNameColor(serviceInstance.Name),
+			"AppName":         terminal.EntityNameColor(app.Name),
+		}))
+
+	err = cmd.secureShell.Wait()
+	if err != nil {
+		return errors.New(T("Error: ") + err.Error())
+	}
+	return nil
+}
+
+// createServiceKey creates a service key for the tunnel, so that it has
+// credentials of its own to read the host and port of the service instance
+// from. The name of the key is unique, so that closing one tunnel does not
+// revoke the credentials of another.
+func (cmd *Tunnel) createServiceKey(serviceInstance models.ServiceInstance) (models.ServiceKey, error) {
+	keyName := fmt.Sprintf("%s-%d", TunnelServiceKeyName, time.Now().UnixNano())
+
+	cmd.ui.Say(T("Creating service key {{.ServiceKeyName}} for service instance {{.ServiceInstanceName}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"ServiceKeyName":      terminal.EntityNameColor(keyName),
+			"ServiceInstanceName": terminal.EntityNameColor(serviceInstance.Name),
+			"CurrentUser":         terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	err := cmd.serviceKeyRepo.CreateServiceKey(serviceInstance.GUID, keyName, nil)
+	if err != nil {
+		return models.ServiceKey{}, err
+	}
+
+	serviceKey, err := cmd.serviceKeyRepo.GetServiceKey(serviceInstance.GUID, keyName)
+	if err != nil {
+		return models.ServiceKey{}, err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	return serviceKey, nil
+}
+
+// deleteServiceKey deletes the service key of the tunnel once it closes. A
+// failure only warns, since the tunnel itself has done its job.
+func (cmd *Tunnel) deleteServiceKey(serviceKey models.ServiceKey) {
+	if serviceKey.Fields.GUID == "" {
+		return
+	}
+
+	err := cmd.serviceKeyRepo.DeleteServiceKey(serviceKey.Fields.GUID)
+	if err != nil {
+		cmd.ui.Warn(T("Could not delete service key {{.KeyName}}: {{.Error}}",
+			map[string]interface{}{
+				"KeyName": serviceKey.Fields.Name,
+				"Error":   err.Error(),
+			}))
+	}
+}
+
+// addressFromCredentials looks for the host and port of a service in the
+// credential keys brokers commonly use: hostname or host and port, or a uri
+// or url.
+func addressFromCredentials(credentials map[string]interface{}) (string, string) {
+	host := credentialString(credentials, "hostname", "host")
+	port := credentialString(credentials, "port")
+	if host != "" && port != "" {
+		return host, port
+	}
+
+	serviceURL, err := url.Parse(credentialString(credentials, "uri", "url"))
+	if err != nil {
+		return "", ""
+	}
+
+	host, port, err = gonet.SplitHostPort(serviceURL.Host)
+	if err != nil {
+		return "", ""
+	}
+	return host, port
+}
+
+func credentialString(credentials map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		switch value := credentials[key].(type) {
+		case string:
+			if value != "" {
+				return value
+			}
+		case float64:
+			return strconv.FormatFloat(value, 'f', -1, 64)
+		case int:
+			return strconv.Itoa(value)
+		}
+	}
+	return ""
+}
+
+// findOrPushTunnelApp returns the tunnel app of the space, pushing it if it
+// does not exist and starting it if it is stopped. It is a binary buildpack
+// app without a route that does nothing but sleep.
+func (cmd *Tunnel) findOrPushTunnelApp() (models.Application, error) {
+	app, err := cmd.appRepo.Read(TunnelAppName)
+	switch err.(type) {
+	case nil:
+	case *cferrors.ModelNotFoundError:
+		app, err = cmd.pushTunnelApp()
+		if err != nil {
+			return models.Application{}, err
+		}
+	default:
+		return models.Application{}, err
+	}
+
+	if strings.ToLower(app.State) == models.ApplicationStateStarted {
+		return app, nil
+	}
+
+	return cmd.appStarter.ApplicationStart(app, cmd.config.OrganizationFields().Name, cmd.config.SpaceFields().Name)
+}
+
+func (cmd *Tunnel) pushTunnelApp() (models.Application, error) {
+	cmd.ui.Say(T("Creating app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"AppName":   terminal.EntityNameColor(TunnelAppName),
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
+
+	name := TunnelAppName
+	spaceGUID := cmd.config.SpaceFields().GUID
+	buildpack := "binary_buildpack"
+	command := "sleep infinity"
+	healthCheckType := "none"
+	memory := int64(32)
+	instances := 1
+	diego := true
+	enableSSH := true
+
+	app, err := cmd.appRepo.Create(models.AppParams{
+		Name:            &name,
+		SpaceGUID:       &spaceGUID,
+		BuildpackURL:    &buildpack,
+		Command:         &command,
+		HealthCheckType: &healthCheckType,
+		Memory:          &memory,
+		InstanceCount:   &instances,
+		Diego:           &diego,
+		EnableSSH:       &enableSSH,
+	})
+	if err != nil {
+		return models.Application{}, err
+	}
+
+	zipFile, err := tunnelAppZip()
+	if err != nil {
+		return models.Application{}, err
+	}
+	defer os.Remove(zipFile.Name())
+	defer zipFile.Close()
+
+	err = cmd.appBitsRepo.UploadBits(app.GUID, zipFile, []resources.AppFileResource{})
+	if err != nil {
+		return models.Application{}, err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	return app, nil
+}
+
+// tunnelAppZip returns the bits of the tunnel app: a single file, since the
+// binary buildpack only needs its start command.
+func tunnelAppZip() (*os.File, error) {
+	zipFile, err := ioutil.TempFile("", "cf-tunnel")
+	if err != nil {
+		return nil, err
+	}
+
+	writer := zip.NewWriter(zipFile)
+	file, err := writer.Create("README")
+	if err == nil {
+		_, err = file.Write([]byte("Forwards ports to service instances for cf tunnel.\n"))
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err == nil {
+		_, err = zipFile.Seek(0, 0)
+	}
+	if err != nil {
+		zipFile.Close()
+		os.Remove(zipFile.Name())
+		return nil, err
+	}
+
+	return zipFile, nil
+}
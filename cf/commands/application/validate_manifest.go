@@ -0,0 +1,227 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type ValidateManifest struct {
+	ui           terminal.UI
+	manifestRepo manifest.Repository
+	actor        actors.PushActor
+}
+
+func init() {
+	commandregistry.Register(&ValidateManifest{})
+}
+
+func (cmd *ValidateManifest) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["f"] = &flags.StringFlag{ShortName: "f", Usage: T("Path to manifest")}
+	fs["lenient"] = &flags.BoolFlag{Name: "lenient", Usage: T("Warn about manifest attributes that push does not know instead of failing")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "validate-manifest",
+		Description: T("Check an app manifest for problems without contacting Cloud Foundry"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME validate-manifest [-f %s] [--lenient]", T("MANIFEST_PATH")),
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *ValidateManifest) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	usageReq := requirementsFactory.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+		T("No argument required"),
+		func() bool {
+			return len(fc.Args()) != 0
+		},
+	)
+
+	reqs := []requirements.Requirement{
+		usageReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *ValidateManifest) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.manifestRepo = deps.ManifestRepo
+	cmd.actor = deps.PushActor
+	return cmd
+}
+
+func (cmd *ValidateManifest) Execute(c flags.FlagContext) error {
+	path := c.String("f")
+	if path == "" {
+		var err error
+		path, err = os.Getwd()
+		if err != nil {
+			return errors.New(fmt.Sprint(T("Could not determine the current working directory!"), err))
+		}
+	}
+
+	m, err := cmd.manifestRepo.ReadManifest(path)
+	if err != nil {
+		return errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+
+	cmd.ui.Say(T("Validating manifest {{.Path}}...", map[string]interface{}{"Path": terminal.EntityNameColor(m.Path)}))
+	cmd.ui.Say("")
+
+	schemaErrors := []string{}
+	for _, problem := range m.Problems {
+		if !problem.IsError(c.Bool("lenient")) {
+			cmd.ui.Warn(problem.Error())
+			continue
+		}
+		schemaErrors = append(schemaErrors, problem.Error())
+	}
+
+	// apps cannot be read reliably from a manifest that does not match the
+	// schema, so they are only checked once it does
+	if len(schemaErrors) > 0 {
+		for _, schemaError := range schemaErrors {
+			cmd.ui.Say("  - " + schemaError)
+		}
+		cmd.ui.Say("")
+
+		return errors.New(T("Found {{.Count}} problem(s) in manifest {{.Path}}",
+			map[string]interface{}{"Count": len(schemaErrors), "Path": m.Path}))
+	}
+
+	apps, err := m.Applications()
+	if err != nil {
+		return errors.New(T("Error reading manifest file:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+	}
+
+	problems := cmd.validateApps(apps)
+
+	problemCount := 0
+	for i, app := range apps {
+		name := T("(no name)")
+		if app.Name != nil {
+			name = *app.Name
+		}
+		cmd.ui.Say(terminal.EntityNameColor(name))
+
+		if len(problems[i]) == 0 {
+			cmd.ui.Say("  " + terminal.SuccessColor(T("OK")))
+			continue
+		}
+
+		for _, problem := range problems[i] {
+			cmd.ui.Say("  - " + problem)
+		}
+		problemCount += len(problems[i])
+	}
+	cmd.ui.Say("")
+
+	if problemCount > 0 {
+		return errors.New(T("Found {{.Count}} problem(s) in manifest {{.Path}}",
+			map[string]interface{}{"Count": problemCount, "Path": m.Path}))
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say(T("Manifest {{.Path}} is valid", map[string]interface{}{"Path": m.Path}))
+	return nil
+}
+
+// validateApps returns the problems found with each app, in the same order as
+// apps. Besides the checks that push makes before it starts, it looks for
+// settings that only the Cloud Controller would reject and for clashes
+// between apps.
+func (cmd *ValidateManifest) validateApps(apps []models.AppParams) [][]string {
+	problems := make([][]string, len(apps))
+	appNames := map[string]bool{}
+	routeOwners := map[string]string{}
+
+	for i, app := range apps {
+		for _, err := range cmd.actor.ValidateAppParams([]models.AppParams{app}) {
+			problems[i] = append(problems[i], err.Error())
+		}
+
+		if app.Name == nil {
+			problems[i] = append(problems[i], T("App name is a required field"))
+			continue
+		}
+		name := *app.Name
+
+		if appNames[name] {
+			problems[i] = append(problems[i], T("App name {{.AppName}} is used by more than one application",
+				map[string]interface{}{"AppName": name}))
+		}
+		appNames[name] = true
+
+		if app.HealthCheckType != nil && !isHealthCheckType(*app.HealthCheckType) {
+			problems[i] = append(problems[i], T("Invalid health-check-type {{.HealthCheckType}}; it must be one of {{.HealthCheckTypes}}",
+				map[string]interface{}{
+					"HealthCheckType":  *app.HealthCheckType,
+					"HealthCheckTypes": strings.Join(actors.HealthCheckTypes, ", "),
+				}))
+		}
+
+		if app.HealthCheckTimeout != nil && *app.HealthCheckTimeout < 1 {
+			problems[i] = append(problems[i], T("Invalid timeout {{.Timeout}}; it must be a positive number of seconds",
+				map[string]interface{}{"Timeout": *app.HealthCheckTimeout}))
+		}
+
+		if app.InstanceCount != nil && *app.InstanceCount < 0 {
+			problems[i] = append(problems[i], T("Invalid instance count {{.Instances}}; it must not be negative",
+				map[string]interface{}{"Instances": *app.InstanceCount}))
+		}
+
+		for _, route := range manifestRoutes(app) {
+			if owner, ok := routeOwners[route]; ok && owner != name {
+				problems[i] = append(problems[i], T("Route {{.Route}} is also mapped to app {{.AppName}}",
+					map[string]interface{}{"Route": route, "AppName": owner}))
+				continue
+			}
+			routeOwners[route] = name
+		}
+	}
+
+	return problems
+}
+
+// manifestRoutes lists the routes an app asks for that can be worked out
+// from the manifest alone, which excludes routes on the default domain.
+func manifestRoutes(app models.AppParams) []string {
+	routes := []string{}
+
+	for _, route := range app.Routes {
+		routes = append(routes, normalizeRoute(route.Route))
+	}
+
+	for _, domain := range app.Domains {
+		if len(app.Hosts) == 0 && app.IsNoHostnameTrue() {
+			routes = append(routes, normalizeRoute(domain))
+		}
+
+		for _, host := range app.Hosts {
+			route := domain
+			if host != "" {
+				route = host + "." + domain
+			}
+			routes = append(routes, normalizeRoute(route))
+		}
+	}
+
+	return routes
+}
+
+func normalizeRoute(route string) string {
+	return strings.TrimRight(strings.ToLower(strings.TrimSpace(route)), "/")
+}
@@ -0,0 +1,244 @@
+package application_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/manifest/manifestfakes"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	"code.cloudfoundry.org/cli/utils/generic"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("validate-manifest command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		manifestRepo        *manifestfakes.FakeRepository
+		actor               *actorsfakes.FakePushActor
+		deps                commandregistry.Dependency
+	)
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewUsageRequirementReturns(requirements.Passing{})
+		manifestRepo = new(manifestfakes.FakeRepository)
+		actor = new(actorsfakes.FakePushActor)
+	})
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.ManifestRepo = manifestRepo
+		deps.PushActor = actor
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("validate-manifest").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("validate-manifest", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	manifestWithApps := func(apps ...map[interface{}]interface{}) *manifest.Manifest {
+		appList := []interface{}{}
+		for _, app := range apps {
+			appList = append(appList, generic.NewMap(app))
+		}
+
+		return &manifest.Manifest{
+			Path: "manifest.yml",
+			Data: generic.NewMap(map[interface{}]interface{}{
+				"applications": appList,
+			}),
+		}
+	}
+
+	Describe("requirements", func() {
+		It("fails with usage when given arguments", func() {
+			requirementsFactory.NewUsageRequirementReturns(requirements.Failing{Message: "usage"})
+			Expect(runCommand("extra-arg")).To(BeFalse())
+		})
+
+		It("does not require the user to be logged in", func() {
+			manifestRepo.ReadManifestReturns(manifestWithApps(map[interface{}]interface{}{"name": "app1"}), nil)
+
+			Expect(runCommand()).To(BeTrue())
+			Expect(requirementsFactory.NewLoginRequirementCallCount()).To(BeZero())
+		})
+	})
+
+	It("reads the manifest given with -f", func() {
+		manifestRepo.ReadManifestReturns(manifestWithApps(map[interface{}]interface{}{"name": "app1"}), nil)
+
+		runCommand("-f", "some/manifest.yml")
+		Expect(manifestRepo.ReadManifestArgsForCall(0)).To(Equal("some/manifest.yml"))
+	})
+
+	Context("when the manifest is valid", func() {
+		BeforeEach(func() {
+			manifestRepo.ReadManifestReturns(manifestWithApps(
+				map[interface{}]interface{}{"name": "app1", "health-check-type": "port"},
+				map[interface{}]interface{}{"name": "app2"},
+			), nil)
+		})
+
+		It("reports each app as OK", func() {
+			Expect(runCommand()).To(BeTrue())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Validating manifest", "manifest.yml"},
+				[]string{"app1"},
+				[]string{"OK"},
+				[]string{"app2"},
+				[]string{"OK"},
+				[]string{"Manifest manifest.yml is valid"},
+			))
+		})
+
+		It("validates each app's params", func() {
+			runCommand()
+			Expect(actor.ValidateAppParamsCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the manifest cannot be read", func() {
+		BeforeEach(func() {
+			manifestRepo.ReadManifestReturns(manifest.NewEmptyManifest(), errors.New("no manifest"))
+		})
+
+		It("fails", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Error reading manifest file"},
+				[]string{"no manifest"},
+			))
+		})
+	})
+
+	Context("when the manifest does not match the schema", func() {
+		BeforeEach(func() {
+			m := manifestWithApps(map[interface{}]interface{}{"name": "app1", "memroy": "1G", "host": "app1"})
+			m.Problems = []manifest.SchemaProblem{
+				{Kind: manifest.UnknownAttribute, File: "manifest.yml", Line: 4, Column: 3, Message: "Unknown attribute 'applications[0].memroy'"},
+				{Kind: manifest.DeprecatedAttribute, File: "manifest.yml", Line: 5, Column: 3, Message: "Attribute 'applications[0].host' is deprecated; use 'routes' instead"},
+			}
+			manifestRepo.ReadManifestReturns(m, nil)
+		})
+
+		It("lists the problems with their positions and fails", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"manifest.yml:5:3: Attribute 'applications[0].host' is deprecated; use 'routes' instead"},
+				[]string{"- manifest.yml:4:3: Unknown attribute 'applications[0].memroy'"},
+				[]string{"FAILED"},
+				[]string{"Found 1 problem(s) in manifest manifest.yml"},
+			))
+			Expect(actor.ValidateAppParamsCallCount()).To(BeZero())
+		})
+
+		It("only warns about unknown attributes with --lenient", func() {
+			Expect(runCommand("--lenient")).To(BeTrue())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"manifest.yml:4:3: Unknown attribute 'applications[0].memroy'"},
+				[]string{"Manifest manifest.yml is valid"},
+			))
+		})
+	})
+
+	Context("when an app's params are invalid", func() {
+		BeforeEach(func() {
+			manifestRepo.ReadManifestReturns(manifestWithApps(map[interface{}]interface{}{"name": "app1"}), nil)
+			actor.ValidateAppParamsReturns([]error{errors.New("routes and hosts")})
+		})
+
+		It("lists the problem under the app and fails", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"app1"},
+				[]string{"- routes and hosts"},
+				[]string{"FAILED"},
+				[]string{"Found 1 problem(s) in manifest manifest.yml"},
+			))
+		})
+	})
+
+	Context("when an app has an invalid health check type", func() {
+		BeforeEach(func() {
+			manifestRepo.ReadManifestReturns(manifestWithApps(
+				map[interface{}]interface{}{"name": "app1", "health-check-type": "tcp"},
+			), nil)
+		})
+
+		It("reports it", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Invalid health-check-type tcp; it must be one of port, process, http, none"},
+			))
+		})
+	})
+
+	Context("when apps have http and process health checks", func() {
+		BeforeEach(func() {
+			manifestRepo.ReadManifestReturns(manifestWithApps(
+				map[interface{}]interface{}{"name": "app1", "health-check-type": "http"},
+				map[interface{}]interface{}{"name": "app2", "health-check-type": "process"},
+			), nil)
+		})
+
+		It("accepts them", func() {
+			Expect(runCommand()).To(BeTrue())
+			Expect(ui.Outputs()).NotTo(ContainSubstrings(
+				[]string{"Invalid health-check-type"},
+			))
+		})
+	})
+
+	Context("when two apps have the same name", func() {
+		BeforeEach(func() {
+			manifestRepo.ReadManifestReturns(manifestWithApps(
+				map[interface{}]interface{}{"name": "app1"},
+				map[interface{}]interface{}{"name": "app1"},
+			), nil)
+		})
+
+		It("reports it", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"App name app1 is used by more than one application"},
+			))
+		})
+	})
+
+	Context("when two apps ask for the same route", func() {
+		BeforeEach(func() {
+			manifestRepo.ReadManifestReturns(manifestWithApps(
+				map[interface{}]interface{}{
+					"name":   "app1",
+					"routes": []interface{}{map[interface{}]interface{}{"route": "shared.example.com"}},
+				},
+				map[interface{}]interface{}{
+					"name":    "app2",
+					"host":    "Shared",
+					"domains": []interface{}{"example.com"},
+				},
+			), nil)
+		})
+
+		It("reports the collision on the second app", func() {
+			Expect(runCommand()).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"app1"},
+				[]string{"OK"},
+				[]string{"app2"},
+				[]string{"Route shared.example.com is also mapped to app app1"},
+			))
+		})
+	})
+})
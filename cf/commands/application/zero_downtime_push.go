@@ -0,0 +1,196 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+// zeroDowntimePushFlags are passed on to push unchanged.
+var zeroDowntimePushFlags = []string{"b", "c", "f", "i", "k", "m", "p", "s", "t", "health-check-type", "docker-image", "docker-username", "no-manifest"}
+
+type ZeroDowntimePush struct {
+	ui           terminal.UI
+	manifestRepo manifest.Repository
+	pusher       commandregistry.Command
+	deployer     actors.BlueGreenDeployer
+}
+
+func init() {
+	commandregistry.Register(&ZeroDowntimePush{})
+}
+
+func (cmd *ZeroDowntimePush) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["b"] = &flags.StringFlag{ShortName: "b", Usage: T("Custom buildpack by name (e.g. my-buildpack) or Git URL (e.g. 'https://github.com/cloudfoundry/java-buildpack.git') or Git URL with a branch or tag (e.g. 'https://github.com/cloudfoundry/java-buildpack.git#v3.3.0' for 'v3.3.0' tag). To use built-in buildpacks only, specify 'default' or 'null'")}
+	fs["c"] = &flags.StringFlag{ShortName: "c", Usage: T("Startup command, set to null to reset to default start command")}
+	fs["f"] = &flags.StringFlag{ShortName: "f", Usage: T("Path to manifest")}
+	fs["i"] = &flags.IntFlag{ShortName: "i", Usage: T("Number of instances")}
+	fs["k"] = &flags.StringFlag{ShortName: "k", Usage: T("Disk limit (e.g. 256M, 1024M, 1G)")}
+	fs["m"] = &flags.StringFlag{ShortName: "m", Usage: T("Memory limit (e.g. 256M, 1024M, 1G)")}
+	fs["p"] = &flags.StringFlag{ShortName: "p", Usage: T("Path to app directory or to a zip file of the contents of the app directory")}
+	fs["s"] = &flags.StringFlag{ShortName: "s", Usage: T("Stack to use (a stack is a pre-built file system, including an operating system, that can run apps)")}
+	fs["t"] = &flags.StringFlag{ShortName: "t", Usage: T("Maximum time (in seconds) for CLI to wait for application start, other server side timeouts may apply")}
+	fs["docker-image"] = &flags.StringFlag{Name: "docker-image", ShortName: "o", Usage: T("Docker-image to be used (e.g. user/docker-image-name)")}
+	fs["docker-username"] = &flags.StringFlag{Name: "docker-username", Usage: T("Repository username; used with password from environment variable CF_DOCKER_PASSWORD")}
+	fs["health-check-type"] = &flags.StringFlag{Name: "health-check-type", ShortName: "u", Usage: T("Application health check type (e.g. 'port' or 'none')")}
+	fs["no-manifest"] = &flags.BoolFlag{Name: "no-manifest", Usage: T("Ignore manifest file")}
+	fs["keep-old-app"] = &flags.BoolFlag{Name: "keep-old-app", Usage: T("Keep the old version of the app, renamed to APP_NAME-venerable, instead of deleting it")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "zero-downtime-push",
+		Description: T("Push a new version of an app and switch its routes over once it is running"),
+		Usage: []string{
+			fmt.Sprintf("CF_NAME zero-downtime-push %s ", T("APP_NAME")),
+			fmt.Sprintf("[-b %s] ", T("BUILDPACK_NAME")),
+			fmt.Sprintf("[-c %s] ", T("COMMAND")),
+			fmt.Sprintf("[-f %s] ", T("MANIFEST_PATH")),
+			fmt.Sprintf("[--docker-image %s] ", T("DOCKER_IMAGE")),
+			fmt.Sprintf("[--docker-username %s]", T("USERNAME")),
+			"\n   ",
+			fmt.Sprintf("[-i %s] ", T("NUM_INSTANCES")),
+			fmt.Sprintf("[-k %s] ", T("DISK")),
+			fmt.Sprintf("[-m %s] ", T("MEMORY")),
+			fmt.Sprintf("[-p %s] ", T("PATH")),
+			fmt.Sprintf("[-s %s] ", T("STACK")),
+			fmt.Sprintf("[-t %s] ", T("TIMEOUT")),
+			fmt.Sprintf("[-u %s]", T("HEALTH_CHECK_TYPE")),
+			"\n   ",
+			"[--no-manifest] [--keep-old-app]",
+		},
+		Examples: []string{
+			"CF_NAME zero-downtime-push my-app -f manifest.yml",
+			"CF_NAME zero-downtime-push my-app -p ./build --keep-old-app",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *ZeroDowntimePush) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	usageReq := requirementsFactory.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+		T("Requires APP_NAME as an argument"),
+		func() bool {
+			return len(fc.Args()) != 1
+		},
+	)
+
+	reqs := []requirements.Requirement{
+		usageReq,
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+	}
+
+	return reqs, nil
+}
+
+func (cmd *ZeroDowntimePush) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.manifestRepo = deps.ManifestRepo
+	cmd.deployer = deps.BlueGreenDeployer
+
+	pusher := commandregistry.Commands.FindCommand("push")
+	cmd.pusher = pusher.SetDependency(deps, false)
+
+	return cmd
+}
+
+func (cmd *ZeroDowntimePush) Execute(c flags.FlagContext) error {
+	appName := c.Args()[0]
+
+	err := cmd.checkManifest(c)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.deployer.Deploy(appName, cmd.pushFunc(c), c.Bool("keep-old-app"))
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say("")
+	cmd.ui.Ok()
+	cmd.ui.Say(T("App {{.AppName}} was pushed without downtime", map[string]interface{}{"AppName": terminal.EntityNameColor(appName)}))
+	return nil
+}
+
+// checkManifest fails when the manifest push would use describes more than
+// one app. Each push of the new version is given flags, which push does not
+// allow for multi-app manifests. Manifests that cannot be read are left for
+// push to report.
+func (cmd *ZeroDowntimePush) checkManifest(c flags.FlagContext) error {
+	if c.Bool("no-manifest") {
+		return nil
+	}
+
+	path := c.String("f")
+	if path == "" {
+		var err error
+		path, err = os.Getwd()
+		if err != nil {
+			return nil
+		}
+	}
+
+	m, err := cmd.manifestRepo.ReadManifest(path)
+	if err != nil {
+		return nil
+	}
+
+	apps, err := m.Applications()
+	if err != nil {
+		return nil
+	}
+
+	if len(apps) > 1 {
+		return errors.New(T("Manifest {{.Path}} describes more than one app, but zero-downtime-push can only push a single app. Use a manifest that describes only {{.AppName}}, or --no-manifest.",
+			map[string]interface{}{"Path": m.Path, "AppName": c.Args()[0]}))
+	}
+
+	return nil
+}
+
+// pushFunc runs push with the flags given to this command.
+func (cmd *ZeroDowntimePush) pushFunc(c flags.FlagContext) actors.PushFunc {
+	return func(appName string, mapRoutes bool) error {
+		args := []string{appName}
+		for _, name := range zeroDowntimePushFlags {
+			if !c.IsSet(name) {
+				continue
+			}
+
+			flag := "--" + name
+			if len(name) == 1 {
+				flag = "-" + name
+			}
+
+			switch name {
+			case "i":
+				args = append(args, flag, fmt.Sprint(c.Int(name)))
+			case "no-manifest":
+				args = append(args, flag)
+			default:
+				args = append(args, flag, c.String(name))
+			}
+		}
+
+		if !mapRoutes {
+			args = append(args, "--no-route")
+		}
+
+		pushContext := flags.NewFlagContext(cmd.pusher.MetaData().Flags)
+		err := pushContext.Parse(args...)
+		if err != nil {
+			return err
+		}
+
+		return cmd.pusher.Execute(pushContext)
+	}
+}
@@ -0,0 +1,207 @@
+package application_test
+
+import (
+	"errors"
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commandregistry/commandregistryfakes"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/manifest/manifestfakes"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	"code.cloudfoundry.org/cli/utils/generic"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("zero-downtime-push command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		manifestRepo        *manifestfakes.FakeRepository
+		deployer            *actorsfakes.FakeBlueGreenDeployer
+		pusher              *commandregistryfakes.FakeCommand
+		originalPush        commandregistry.Command
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.ManifestRepo = manifestRepo
+		deps.BlueGreenDeployer = deployer
+
+		//inject fake 'push' into registry
+		commandregistry.Register(pusher)
+
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("zero-downtime-push").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("zero-downtime-push", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		deps = commandregistry.NewDependency(os.Stdout, new(tracefakes.FakePrinter), "")
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewUsageRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		manifestRepo = new(manifestfakes.FakeRepository)
+		manifestRepo.ReadManifestReturns(manifest.NewEmptyManifest(), errors.New("no manifest"))
+		deployer = new(actorsfakes.FakeBlueGreenDeployer)
+
+		//save original command and restore later
+		originalPush = commandregistry.Commands.FindCommand("push")
+
+		//setup fakes to correctly interact with commandregistry
+		pusher = new(commandregistryfakes.FakeCommand)
+		pusher.SetDependencyStub = func(_ commandregistry.Dependency, _ bool) commandregistry.Command {
+			return pusher
+		}
+		pusher.MetaDataReturns(originalPush.MetaData())
+	})
+
+	AfterEach(func() {
+		commandregistry.Register(originalPush)
+	})
+
+	Describe("requirements", func() {
+		It("fails when not provided exactly one arg", func() {
+			requirementsFactory.NewUsageRequirementReturns(requirements.Failing{Message: "Incorrect Usage"})
+			Expect(runCommand()).To(BeFalse())
+		})
+
+		It("fails when not logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(runCommand("my-app")).To(BeFalse())
+		})
+
+		It("fails when a space is not targeted", func() {
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Failing{Message: "not targeting space"})
+			Expect(runCommand("my-app")).To(BeFalse())
+		})
+	})
+
+	It("deploys the app and deletes the old version", func() {
+		Expect(runCommand("my-app")).To(BeTrue())
+
+		Expect(deployer.DeployCallCount()).To(Equal(1))
+		appName, _, keepOldApp := deployer.DeployArgsForCall(0)
+		Expect(appName).To(Equal("my-app"))
+		Expect(keepOldApp).To(BeFalse())
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"OK"},
+			[]string{"App my-app was pushed without downtime"},
+		))
+	})
+
+	It("keeps the old version when --keep-old-app is given", func() {
+		runCommand("my-app", "--keep-old-app")
+
+		_, _, keepOldApp := deployer.DeployArgsForCall(0)
+		Expect(keepOldApp).To(BeTrue())
+	})
+
+	Context("when the deployer pushes the app", func() {
+		var pushContext flags.FlagContext
+
+		BeforeEach(func() {
+			deployer.DeployStub = func(appName string, push actors.PushFunc, keepOldApp bool) error {
+				return push("my-app-new", false)
+			}
+			pusher.ExecuteStub = func(context flags.FlagContext) error {
+				pushContext = context
+				return nil
+			}
+		})
+
+		It("runs push with the given flags under the name it is given", func() {
+			Expect(runCommand("my-app", "-f", "manifest.yml", "-i", "3", "-p", "some/path", "--health-check-type", "none", "--no-manifest")).To(BeTrue())
+
+			Expect(pusher.ExecuteCallCount()).To(Equal(1))
+			Expect(pushContext.Args()).To(Equal([]string{"my-app-new"}))
+			Expect(pushContext.String("f")).To(Equal("manifest.yml"))
+			Expect(pushContext.Int("i")).To(Equal(3))
+			Expect(pushContext.String("p")).To(Equal("some/path"))
+			Expect(pushContext.String("health-check-type")).To(Equal("none"))
+			Expect(pushContext.Bool("no-manifest")).To(BeTrue())
+			Expect(pushContext.Bool("no-route")).To(BeTrue())
+			Expect(pushContext.IsSet("m")).To(BeFalse())
+		})
+	})
+
+	Context("when the manifest describes more than one app", func() {
+		BeforeEach(func() {
+			manifestRepo.ReadManifestReturns(&manifest.Manifest{
+				Path: "manifest.yml",
+				Data: generic.NewMap(map[interface{}]interface{}{
+					"applications": []interface{}{
+						generic.NewMap(map[interface{}]interface{}{"name": "my-app"}),
+						generic.NewMap(map[interface{}]interface{}{"name": "my-other-app"}),
+					},
+				}),
+			}, nil)
+		})
+
+		It("fails before deploying anything", func() {
+			Expect(runCommand("my-app", "-f", "manifest.yml")).To(BeFalse())
+
+			Expect(manifestRepo.ReadManifestArgsForCall(0)).To(Equal("manifest.yml"))
+			Expect(deployer.DeployCallCount()).To(Equal(0))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Manifest manifest.yml describes more than one app"},
+			))
+		})
+
+		It("deploys the app when --no-manifest is given", func() {
+			Expect(runCommand("my-app", "--no-manifest")).To(BeTrue())
+
+			Expect(manifestRepo.ReadManifestCallCount()).To(Equal(0))
+			Expect(deployer.DeployCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the manifest describes a single app", func() {
+		BeforeEach(func() {
+			manifestRepo.ReadManifestReturns(&manifest.Manifest{
+				Path: "manifest.yml",
+				Data: generic.NewMap(map[interface{}]interface{}{
+					"applications": []interface{}{
+						generic.NewMap(map[interface{}]interface{}{"name": "my-app"}),
+					},
+				}),
+			}, nil)
+		})
+
+		It("deploys the app", func() {
+			Expect(runCommand("my-app", "-f", "manifest.yml")).To(BeTrue())
+			Expect(deployer.DeployCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the deployment fails", func() {
+		BeforeEach(func() {
+			deployer.DeployReturns(errors.New("deploy-error"))
+		})
+
+		It("fails with the error", func() {
+			Expect(runCommand("my-app")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"deploy-error"},
+			))
+		})
+	})
+})
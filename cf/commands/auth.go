@@ -24,17 +24,22 @@ func init() {
 }
 
 func (cmd *Authenticate) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["client-credentials"] = &flags.BoolFlag{Name: "client-credentials", Usage: T("Use (non-user) service account (also called client credentials)")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "auth",
 		Description: T("Authenticate user non-interactively"),
 		Usage: []string{
-			T("CF_NAME auth USERNAME PASSWORD\n\n"),
+			T("CF_NAME auth USERNAME PASSWORD\n   CF_NAME auth CLIENT_ID CLIENT_SECRET --client-credentials\n\n"),
 			terminal.WarningColor(T("WARNING:\n   Providing your password as a command line option is highly discouraged\n   Your password may be visible to others and may be recorded in your shell history")),
 		},
 		Examples: []string{
 			T("CF_NAME auth name@example.com \"my password\" (use quotes for passwords with a space)"),
 			T("CF_NAME auth name@example.com \"\\\"password\\\"\" (escape quotes if used in password)"),
+			T("CF_NAME auth my-client my-client-secret --client-credentials (authenticate as a client, such as a CI system)"),
 		},
+		Flags: fs,
 	}
 }
 
@@ -66,7 +71,12 @@ func (cmd *Authenticate) Execute(c flags.FlagContext) error {
 		map[string]interface{}{"APIEndpoint": terminal.EntityNameColor(cmd.config.APIEndpoint())}))
 	cmd.ui.Say(T("Authenticating..."))
 
-	err := cmd.authenticator.Authenticate(map[string]string{"username": c.Args()[0], "password": c.Args()[1]})
+	var err error
+	if c.Bool("client-credentials") {
+		err = cmd.authenticator.AuthenticateClientCredentials(c.Args()[0], c.Args()[1])
+	} else {
+		err = cmd.authenticator.Authenticate(map[string]string{"username": c.Args()[0], "password": c.Args()[1]})
+	}
 	if err != nil {
 		return err
 	}
@@ -110,6 +110,32 @@ var _ = Describe("auth command", func() {
 			Expect(authRepo.GetLoginPromptsAndSaveUAAServerURLCallCount()).To(Equal(1))
 		})
 
+		Context("when --client-credentials is provided", func() {
+			It("authenticates as the client", func() {
+				testcmd.RunCLICommand("auth", []string{"some-client", "some-secret", "--client-credentials"}, requirementsFactory, updateCommandDependency, false, ui)
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Authenticating..."},
+					[]string{"OK"},
+				))
+				Expect(authRepo.AuthenticateCallCount()).To(Equal(0))
+				Expect(authRepo.AuthenticateClientCredentialsCallCount()).To(Equal(1))
+				clientID, clientSecret := authRepo.AuthenticateClientCredentialsArgsForCall(0)
+				Expect(clientID).To(Equal("some-client"))
+				Expect(clientSecret).To(Equal("some-secret"))
+			})
+
+			It("fails when the client credentials are rejected", func() {
+				authRepo.AuthenticateClientCredentialsReturns(errors.New("Credentials were rejected, please try again."))
+				testcmd.RunCLICommand("auth", []string{"some-client", "some-secret", "--client-credentials"}, requirementsFactory, updateCommandDependency, false, ui)
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Credentials were rejected"},
+				))
+			})
+		})
+
 		Describe("when authentication fails", func() {
 			BeforeEach(func() {
 				authRepo.AuthenticateReturns(errors.New("Error authenticating."))
@@ -30,6 +30,10 @@ func (cmd *ListBuildpacks) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME buildpacks"),
 		},
+		Examples: []string{
+			"CF_NAME buildpacks --output json",
+		},
+		StructuredOutput: true,
 	}
 }
 
@@ -58,7 +62,8 @@ func (cmd *ListBuildpacks) SetDependency(deps commandregistry.Dependency, plugin
 func (cmd *ListBuildpacks) Execute(c flags.FlagContext) error {
 	cmd.ui.Say(T("Getting buildpacks...\n"))
 
-	table := cmd.ui.Table([]string{"buildpack", T("position"), T("enabled"), T("locked"), T("filename")})
+	table := cmd.ui.Table([]string{"buildpack", T("position"), T("enabled"), T("locked"), T("filename"), T("stack")})
+	table.Table.SetFieldKeys("buildpack", "position", "enabled", "locked", "filename", "stack")
 	noBuildpacks := true
 
 	apiErr := cmd.buildpackRepo.ListBuildpacks(func(buildpack models.Buildpack) bool {
@@ -80,6 +85,7 @@ func (cmd *ListBuildpacks) Execute(c flags.FlagContext) error {
 			enabled,
 			locked,
 			buildpack.Filename,
+			buildpack.Stack,
 		)
 		noBuildpacks = false
 		return true
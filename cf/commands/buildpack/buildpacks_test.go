@@ -1,12 +1,15 @@
 package buildpack_test
 
 import (
+	"strings"
+
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/flags"
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
 	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
 
@@ -90,13 +93,37 @@ var _ = Describe("ListBuildpacks", func() {
 
 			Expect(ui.Outputs()).To(ContainSubstrings(
 				[]string{"Getting buildpacks"},
-				[]string{"buildpack", "position", "enabled"},
+				[]string{"buildpack", "position", "enabled", "locked", "filename", "stack"},
 				[]string{"Buildpack-1", "5", "true", "false"},
 				[]string{"Buildpack-2", "10", "false", "true"},
 				[]string{"Buildpack-3", "15", "true", "false"},
 			))
 		})
 
+		It("prints the buildpacks with their lock state and stack as JSON", func() {
+			p1 := 1
+			t := true
+			f := false
+			buildpackRepo.Buildpacks = []models.Buildpack{
+				{Name: "ruby_buildpack", Position: &p1, Enabled: &t, Locked: &f, Filename: "ruby.zip", Stack: "cflinuxfs2"},
+				{Name: "binary_buildpack", Enabled: &f},
+			}
+			commandUI := terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+			cmd := &buildpack.ListBuildpacks{}
+			cmd.SetDependency(commandregistry.Dependency{
+				UI:          commandUI,
+				RepoLocator: deps.RepoLocator.SetBuildpackRepository(buildpackRepo),
+			}, false)
+
+			Expect(cmd.Execute(flags.NewFlagContext(cmd.MetaData().Flags))).To(Succeed())
+			Expect(commandUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+			Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`[
+				{"buildpack": "ruby_buildpack", "position": "1", "enabled": "true", "locked": "false", "filename": "ruby.zip", "stack": "cflinuxfs2"},
+				{"buildpack": "binary_buildpack", "position": "", "enabled": "false", "locked": "", "filename": "", "stack": ""}
+			]`))
+		})
+
 		It("tells the user if no build packs exist", func() {
 			runCommand()
 			Expect(ui.Outputs()).To(ContainSubstrings(
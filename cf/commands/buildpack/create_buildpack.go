@@ -30,24 +30,29 @@ func (cmd *CreateBuildpack) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["enable"] = &flags.BoolFlag{Name: "enable", Usage: T("Enable the buildpack to be used for staging")}
 	fs["disable"] = &flags.BoolFlag{Name: "disable", Usage: T("Disable the buildpack from being used for staging")}
+	fs["stack"] = &flags.StringFlag{Name: "stack", Usage: T("Stack the buildpack is for, so that buildpacks for other stacks can have the same name")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "create-buildpack",
 		Description: T("Create a buildpack"),
 		Usage: []string{
-			T("CF_NAME create-buildpack BUILDPACK PATH POSITION [--enable|--disable]"),
+			T("CF_NAME create-buildpack BUILDPACK PATH [POSITION] [--enable|--disable] [--stack STACK]"),
 			T("\n\nTIP:\n"),
-			T("   Path should be a zip file, a url to a zip file, or a local directory. Position is a positive integer, sets priority, and is sorted from lowest to highest."),
+			T("   Path should be a zip file, a url to a zip file, or a local directory. Position is a positive integer, sets priority, and is sorted from lowest to highest. Without a position the buildpack is checked after all existing buildpacks."),
+		},
+		Examples: []string{
+			"CF_NAME create-buildpack java-buildpack-offline ./java-buildpack-offline.zip",
+			"CF_NAME create-buildpack ruby_buildpack ./ruby_buildpack-windows.zip 3 --stack windows2012R2",
 		},
 		Flags:     fs,
-		TotalArgs: 3,
+		TotalArgs: 2,
 	}
 }
 
 func (cmd *CreateBuildpack) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
-	if len(fc.Args()) != 3 {
-		cmd.ui.Failed(T("Incorrect Usage. Requires buildpack_name, path and position as arguments\n\n") + commandregistry.Commands.CommandUsage("create-buildpack"))
-		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 3)
+	if len(fc.Args()) != 2 && len(fc.Args()) != 3 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires buildpack_name and path, and optionally position, as arguments\n\n") + commandregistry.Commands.CommandUsage("create-buildpack"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 2)
 	}
 
 	reqs := []requirements.Requirement{
@@ -102,10 +107,19 @@ func (cmd *CreateBuildpack) Execute(c flags.FlagContext) error {
 }
 
 func (cmd CreateBuildpack) createBuildpack(buildpackName string, c flags.FlagContext) (buildpack models.Buildpack, apiErr error) {
-	position, err := strconv.Atoi(c.Args()[2])
-	if err != nil {
-		apiErr = fmt.Errorf(T("Error {{.ErrorDescription}} is being passed in as the argument for 'Position' but 'Position' requires an integer.  For more syntax help, see `cf create-buildpack -h`.", map[string]interface{}{"ErrorDescription": c.Args()[2]}))
-		return
+	var position int
+	if len(c.Args()) == 3 {
+		var err error
+		position, err = strconv.Atoi(c.Args()[2])
+		if err != nil {
+			apiErr = fmt.Errorf(T("Error {{.ErrorDescription}} is being passed in as the argument for 'Position' but 'Position' requires an integer.  For more syntax help, see `cf create-buildpack -h`.", map[string]interface{}{"ErrorDescription": c.Args()[2]}))
+			return
+		}
+	} else {
+		position, apiErr = cmd.nextPosition()
+		if apiErr != nil {
+			return
+		}
 	}
 
 	enabled := c.Bool("enable")
@@ -127,7 +141,19 @@ func (cmd CreateBuildpack) createBuildpack(buildpackName string, c flags.FlagCon
 		enableOption = &disabled
 	}
 
-	buildpack, apiErr = cmd.buildpackRepo.Create(buildpackName, &position, enableOption, nil)
+	buildpack, apiErr = cmd.buildpackRepo.Create(buildpackName, &position, enableOption, nil, c.String("stack"))
 
 	return
 }
+
+// nextPosition returns the position after the last existing buildpack.
+func (cmd CreateBuildpack) nextPosition() (int, error) {
+	position := 1
+	err := cmd.buildpackRepo.ListBuildpacks(func(buildpack models.Buildpack) bool {
+		if buildpack.Position != nil && *buildpack.Position >= position {
+			position = *buildpack.Position + 1
+		}
+		return true
+	})
+	return position, err
+}
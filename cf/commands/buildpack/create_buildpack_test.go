@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/cli/cf"
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
@@ -45,7 +46,7 @@ var _ = Describe("create-buildpack command", func() {
 		Expect(testcmd.RunCLICommand("create-buildpack", []string{"my-buildpack", "my-dir", "0"}, requirementsFactory, updateCommandDependency, false, ui)).To(BeFalse())
 	})
 
-	It("fails with usage when given fewer than three arguments", func() {
+	It("fails with usage when given fewer than two arguments", func() {
 		testcmd.RunCLICommand("create-buildpack", []string{}, requirementsFactory, updateCommandDependency, false, ui)
 		Expect(ui.Outputs()).To(ContainSubstrings(
 			[]string{"Incorrect Usage", "Requires", "arguments"},
@@ -114,6 +115,31 @@ var _ = Describe("create-buildpack command", func() {
 		Expect(ui.Outputs()).ToNot(ContainSubstrings([]string{"FAILED"}))
 	})
 
+	It("puts the buildpack after the existing ones when no position is given", func() {
+		p1, p2 := 1, 7
+		repo.Buildpacks = []models.Buildpack{
+			{Name: "first", Position: &p1},
+			{Name: "second", Position: &p2},
+			{Name: "unpositioned"},
+		}
+
+		Expect(testcmd.RunCLICommand("create-buildpack", []string{"my-buildpack", "my.war"}, requirementsFactory, updateCommandDependency, false, ui)).To(BeTrue())
+
+		Expect(*repo.CreateBuildpack.Position).To(Equal(8))
+	})
+
+	It("puts the first buildpack at position 1 when no position is given", func() {
+		Expect(testcmd.RunCLICommand("create-buildpack", []string{"my-buildpack", "my.war"}, requirementsFactory, updateCommandDependency, false, ui)).To(BeTrue())
+
+		Expect(*repo.CreateBuildpack.Position).To(Equal(1))
+	})
+
+	It("creates the buildpack for the stack given with --stack", func() {
+		Expect(testcmd.RunCLICommand("create-buildpack", []string{"my-buildpack", "my.war", "5", "--stack", "cflinuxfs2"}, requirementsFactory, updateCommandDependency, false, ui)).To(BeTrue())
+
+		Expect(repo.CreateBuildpack.Stack).To(Equal("cflinuxfs2"))
+	})
+
 	It("enables the buildpack when given the --enabled flag", func() {
 		testcmd.RunCLICommand("create-buildpack", []string{"--enable", "my-buildpack", "my.war", "5"}, requirementsFactory, updateCommandDependency, false, ui)
 
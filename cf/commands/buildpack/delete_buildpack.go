@@ -28,12 +28,13 @@ func (cmd *DeleteBuildpack) SetDependency(deps commandregistry.Dependency, plugi
 func (cmd *DeleteBuildpack) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force deletion without confirmation")}
+	fs["stack"] = &flags.StringFlag{Name: "stack", Usage: T("Stack of the buildpack, to pick it from buildpacks with the same name")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "delete-buildpack",
 		Description: T("Delete a buildpack"),
 		Usage: []string{
-			T("CF_NAME delete-buildpack BUILDPACK [-f]"),
+			T("CF_NAME delete-buildpack BUILDPACK [-f] [--stack STACK]"),
 		},
 		Flags: fs,
 	}
@@ -69,7 +70,7 @@ func (cmd *DeleteBuildpack) Execute(c flags.FlagContext) error {
 	}
 
 	cmd.ui.Say(T("Deleting buildpack {{.BuildpackName}}...", map[string]interface{}{"BuildpackName": terminal.EntityNameColor(buildpackName)}))
-	buildpack, err := cmd.buildpackRepo.FindByName(buildpackName)
+	buildpack, err := cmd.buildpackRepo.FindByNameAndStack(buildpackName, c.String("stack"))
 
 	switch err.(type) {
 	case nil: //do nothing
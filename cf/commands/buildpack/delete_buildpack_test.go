@@ -76,6 +76,14 @@ var _ = Describe("delete-buildpack command", func() {
 				))
 			})
 
+			It("deletes the buildpack for the stack given with --stack", func() {
+				runCommand("-f", "my-buildpack", "--stack", "cflinuxfs2")
+
+				Expect(buildpackRepo.FindByNameName).To(Equal("my-buildpack"))
+				Expect(buildpackRepo.FindByNameStack).To(Equal("cflinuxfs2"))
+				Expect(buildpackRepo.DeleteBuildpackGUID).To(Equal("my-buildpack-guid"))
+			})
+
 			Context("when the force flag is provided", func() {
 				It("does not prompt the user to delete the buildback", func() {
 					runCommand("-f", "my-buildpack")
@@ -80,6 +80,14 @@ var _ = Describe("Updating buildpack command", func() {
 		})
 	})
 
+	It("looks the buildpack up for the stack given with --stack", func() {
+		Expect(runCommand(buildpackName, "--stack", "cflinuxfs2")).To(BeTrue())
+
+		name, stack := requirementsFactory.NewBuildpackRequirementArgsForCall(0)
+		Expect(name).To(Equal(buildpackName))
+		Expect(stack).To(Equal("cflinuxfs2"))
+	})
+
 	Context("when a file is provided", func() {
 		It("prints error and do not call create buildpack", func() {
 			bitsRepo.CreateBuildpackZipFileReturns(nil, "", fmt.Errorf("create buildpack error"))
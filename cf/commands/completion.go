@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/completion"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type Completion struct {
+	ui terminal.UI
+}
+
+func init() {
+	commandregistry.Register(&Completion{})
+}
+
+func (cmd *Completion) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "completion",
+		Description: T("Print a script that completes commands, flags and the names of apps, orgs and spaces in a shell"),
+		Usage: []string{
+			T(`CF_NAME completion SHELL
+
+   Prints the completion script for SHELL, which is bash, zsh, fish or powershell. The names of apps, orgs and spaces are looked up with the API of the target and kept for a few minutes.
+
+   To complete in every new shell:
+      bash:       add 'source <(CF_NAME completion bash)' to ~/.bashrc
+      zsh:        add 'source <(CF_NAME completion zsh)' to ~/.zshrc, after compinit
+      fish:       run 'CF_NAME completion fish > ~/.config/fish/completions/CF_NAME.fish'
+      PowerShell: add 'CF_NAME completion powershell | Out-String | Invoke-Expression' to $PROFILE`),
+		},
+		Examples: []string{
+			"CF_NAME completion bash > /etc/bash_completion.d/CF_NAME",
+		},
+		TotalArgs: 1,
+	}
+}
+
+func (cmd *Completion) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires shell name as argument\n\n") + commandregistry.Commands.CommandUsage("completion"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs, nil
+}
+
+func (cmd *Completion) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	return cmd
+}
+
+func (cmd *Completion) Execute(c flags.FlagContext) error {
+	script, err := completion.Script(c.Args()[0])
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(strings.TrimSuffix(script, "\n"))
+	return nil
+}
@@ -0,0 +1,58 @@
+package commands_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("completion command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("completion").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("completion", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	It("fails with usage when not provided exactly one arg", func() {
+		Expect(runCommand()).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Incorrect Usage.", "Requires shell name as argument"},
+		))
+	})
+
+	It("prints the completion script of the shell", func() {
+		Expect(runCommand("bash")).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"__complete"},
+			[]string{"complete -o default -F"},
+		))
+	})
+
+	It("fails when the shell is not supported", func() {
+		Expect(runCommand("tcsh")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Completion scripts can only be generated for bash, zsh, fish, powershell, not 'tcsh'"},
+		))
+	})
+})
@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/configuration/credentialstore"
 	"code.cloudfoundry.org/cli/cf/flags"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
@@ -25,15 +26,19 @@ func init() {
 func (cmd *ConfigCommands) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["async-timeout"] = &flags.IntFlag{Name: "async-timeout", Usage: T("Timeout for async HTTP requests")}
+	fs["keep-alive"] = &flags.IntFlag{Name: "keep-alive", Usage: T("Time in seconds to keep idle connections to the API open for reuse (Default: 90)")}
+	fs["max-idle-connections"] = &flags.IntFlag{Name: "max-idle-connections", Usage: T("Number of idle connections to keep open to each API host (Default: 10)")}
 	fs["trace"] = &flags.StringFlag{Name: "trace", Usage: T("Trace HTTP requests")}
 	fs["color"] = &flags.StringFlag{Name: "color", Usage: T("Enable or disable color")}
 	fs["locale"] = &flags.StringFlag{Name: "locale", Usage: T("Set default locale. If LOCALE is 'CLEAR', previous locale is deleted.")}
+	fs["credential-store"] = &flags.StringFlag{Name: "credential-store", Usage: T("Where to keep tokens: the config file (plaintext), the macOS Keychain (keychain), the Windows Credential Manager (wincred) or the Secret Service (libsecret). Tokens stay in the config file if the store is unavailable.")}
+	fs["plugin-sandbox"] = &flags.StringFlag{Name: "plugin-sandbox", Usage: T("Run plugins with a config of their own and a token derived from yours, which cannot manage users or passwords and is removed from that config when the plugin exits")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "config",
 		Description: T("Write default values to the config"),
 		Usage: []string{
-			T("CF_NAME config [--async-timeout TIMEOUT_IN_MINUTES] [--trace (true | false | path/to/file)] [--color (true | false)] [--locale (LOCALE | CLEAR)]"),
+			T("CF_NAME config [--async-timeout TIMEOUT_IN_MINUTES] [--keep-alive TIMEOUT_IN_SECONDS] [--max-idle-connections NUM_CONNECTIONS]\n   [--trace (true | false | path/to/file)] [--color (true | false)] [--locale (LOCALE | CLEAR)]\n   [--credential-store (plaintext | keychain | wincred | libsecret)] [--plugin-sandbox (true | false)]"),
 		},
 		Flags: fs,
 	}
@@ -51,7 +56,9 @@ func (cmd *ConfigCommands) SetDependency(deps commandregistry.Dependency, plugin
 }
 
 func (cmd *ConfigCommands) Execute(context flags.FlagContext) error {
-	if !context.IsSet("trace") && !context.IsSet("async-timeout") && !context.IsSet("color") && !context.IsSet("locale") {
+	if !context.IsSet("trace") && !context.IsSet("async-timeout") && !context.IsSet("keep-alive") &&
+		!context.IsSet("max-idle-connections") && !context.IsSet("color") && !context.IsSet("locale") &&
+		!context.IsSet("credential-store") && !context.IsSet("plugin-sandbox") {
 		return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
 	}
 
@@ -64,6 +71,24 @@ func (cmd *ConfigCommands) Execute(context flags.FlagContext) error {
 		cmd.config.SetAsyncTimeout(uint(asyncTimeout))
 	}
 
+	if context.IsSet("keep-alive") {
+		keepAlive := context.Int("keep-alive")
+		if keepAlive < 0 {
+			return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
+		}
+
+		cmd.config.SetKeepAliveTimeout(uint(keepAlive))
+	}
+
+	if context.IsSet("max-idle-connections") {
+		maxIdleConnections := context.Int("max-idle-connections")
+		if maxIdleConnections < 0 {
+			return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
+		}
+
+		cmd.config.SetMaxIdleConnections(uint(maxIdleConnections))
+	}
+
 	if context.IsSet("trace") {
 		cmd.config.SetTrace(context.String("trace"))
 	}
@@ -80,6 +105,26 @@ func (cmd *ConfigCommands) Execute(context flags.FlagContext) error {
 		}
 	}
 
+	if context.IsSet("credential-store") {
+		backend := context.String("credential-store")
+		if !isCredentialStoreBackend(backend) {
+			return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
+		}
+
+		cmd.config.SetCredentialStore(backend)
+	}
+
+	if context.IsSet("plugin-sandbox") {
+		switch context.String("plugin-sandbox") {
+		case "true":
+			cmd.config.SetPluginSandbox(true)
+		case "false":
+			cmd.config.SetPluginSandbox(false)
+		default:
+			return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
+		}
+	}
+
 	if context.IsSet("locale") {
 		locale := context.String("locale")
 
@@ -106,3 +151,12 @@ func (cmd *ConfigCommands) Execute(context flags.FlagContext) error {
 	}
 	return nil
 }
+
+func isCredentialStoreBackend(backend string) bool {
+	for _, b := range credentialstore.Backends {
+		if b == backend {
+			return true
+		}
+	}
+	return false
+}
@@ -66,6 +66,36 @@ var _ = Describe("config command", func() {
 		})
 	})
 
+	Context("--keep-alive flag", func() {
+		It("stores the keep alive timeout in seconds", func() {
+			runCommand("--keep-alive", "30")
+			Expect(configRepo.KeepAliveTimeout()).To(Equal(uint(30)))
+		})
+
+		It("fails with usage when a negative timeout is passed", func() {
+			runCommand("--keep-alive", "-1")
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage"},
+			))
+			Expect(configRepo.KeepAliveTimeout()).To(Equal(uint(0)))
+		})
+	})
+
+	Context("--max-idle-connections flag", func() {
+		It("stores the number of idle connections", func() {
+			runCommand("--max-idle-connections", "25")
+			Expect(configRepo.MaxIdleConnections()).To(Equal(uint(25)))
+		})
+
+		It("fails with usage when a negative number is passed", func() {
+			runCommand("--max-idle-connections", "-3")
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage"},
+			))
+			Expect(configRepo.MaxIdleConnections()).To(Equal(uint(0)))
+		})
+	})
+
 	Context("--trace flag", func() {
 		It("stores the trace value when --trace flag is provided", func() {
 			runCommand("--trace", "true")
@@ -96,6 +126,42 @@ var _ = Describe("config command", func() {
 		})
 	})
 
+	Context("--credential-store flag", func() {
+		It("stores the credential store when --credential-store flag is provided", func() {
+			runCommand("--credential-store", "keychain")
+			Expect(configRepo.CredentialStore()).To(Equal("keychain"))
+
+			runCommand("--credential-store", "plaintext")
+			Expect(configRepo.CredentialStore()).To(Equal("plaintext"))
+		})
+
+		It("fails with usage when an unknown store is provided", func() {
+			runCommand("--credential-store", "vault")
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage"},
+			))
+			Expect(configRepo.CredentialStore()).To(BeEmpty())
+		})
+	})
+
+	Context("--plugin-sandbox flag", func() {
+		It("turns the plugin sandbox on and off", func() {
+			runCommand("--plugin-sandbox", "true")
+			Expect(configRepo.PluginSandbox()).To(BeTrue())
+
+			runCommand("--plugin-sandbox", "false")
+			Expect(configRepo.PluginSandbox()).To(BeFalse())
+		})
+
+		It("fails with usage when a value other than true or false is provided", func() {
+			runCommand("--plugin-sandbox", "maybe")
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage"},
+			))
+			Expect(configRepo.PluginSandbox()).To(BeFalse())
+		})
+	})
+
 	Context("--locale flag", func() {
 		It("stores the locale value when --locale [locale] is provided", func() {
 			runCommand("--locale", "zh-Hans")
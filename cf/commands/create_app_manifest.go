@@ -6,11 +6,15 @@ import (
 	"os"
 	"sort"
 
+	"code.cloudfoundry.org/cli/cf"
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
 
+	"code.cloudfoundry.org/cli/cf/actors"
 	"code.cloudfoundry.org/cli/cf/api"
 	"code.cloudfoundry.org/cli/cf/api/appinstances"
+	"code.cloudfoundry.org/cli/cf/api/processes"
+	"code.cloudfoundry.org/cli/cf/api/sidecars"
 	"code.cloudfoundry.org/cli/cf/api/stacks"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -21,13 +25,17 @@ import (
 )
 
 type CreateAppManifest struct {
-	ui               terminal.UI
-	config           coreconfig.Reader
-	appSummaryRepo   api.AppSummaryRepository
-	stackRepo        stacks.StackRepository
-	appInstancesRepo appinstances.Repository
-	appReq           requirements.ApplicationRequirement
-	manifest         manifest.App
+	ui                 terminal.UI
+	config             coreconfig.Reader
+	appSummaryRepo     api.AppSummaryRepository
+	stackRepo          stacks.StackRepository
+	appInstancesRepo   appinstances.Repository
+	serviceBindingRepo api.ServiceBindingRepository
+	processRepo        processes.Repository
+	sidecarRepo        sidecars.Repository
+	metadataActor      actors.MetadataActor
+	appReq             requirements.ApplicationRequirement
+	manifest           manifest.App
 }
 
 func init() {
@@ -70,6 +78,10 @@ func (cmd *CreateAppManifest) SetDependency(deps commandregistry.Dependency, plu
 	cmd.config = deps.Config
 	cmd.appSummaryRepo = deps.RepoLocator.GetAppSummaryRepository()
 	cmd.stackRepo = deps.RepoLocator.GetStackRepository()
+	cmd.serviceBindingRepo = deps.RepoLocator.GetServiceBindingRepository()
+	cmd.processRepo = deps.RepoLocator.GetProcessRepository()
+	cmd.sidecarRepo = deps.RepoLocator.GetSidecarRepository()
+	cmd.metadataActor = deps.MetadataActor
 	cmd.manifest = deps.AppManifest
 	return cmd
 }
@@ -134,9 +146,27 @@ func (cmd *CreateAppManifest) createManifest(app models.Application) error {
 		cmd.manifest.BuildpackURL(app.Name, app.BuildpackURL)
 	}
 
+	if app.DockerImage != "" {
+		cmd.manifest.Docker(app.Name, app.DockerImage, app.DockerUsername)
+	}
+
 	if len(app.Services) > 0 {
+		bindings, err := cmd.serviceBindingRepo.ListAllForApp(app.GUID)
+		if err != nil {
+			return errors.New(T("Error retrieving service bindings: ") + err.Error())
+		}
+
+		bindingNames := map[string]string{}
+		for _, binding := range bindings {
+			bindingNames[binding.ServiceInstanceGUID] = binding.Name
+		}
+
 		for _, service := range app.Services {
-			cmd.manifest.Service(app.Name, service.Name)
+			if bindingName := bindingNames[service.GUID]; bindingName != "" {
+				cmd.manifest.ServiceBinding(app.Name, service.Name, bindingName)
+			} else {
+				cmd.manifest.Service(app.Name, service.Name)
+			}
 		}
 	}
 
@@ -172,6 +202,43 @@ func (cmd *CreateAppManifest) createManifest(app models.Application) error {
 		cmd.manifest.DiskQuota(app.Name, app.DiskQuota)
 	}
 
+	return cmd.addV3Settings(app)
+}
+
+// addV3Settings adds what only the v3 API knows about an app: its process
+// types with their scale and checks, its sidecars and its metadata. Older
+// APIs are left with the settings of the v2 API.
+func (cmd *CreateAppManifest) addV3Settings(app models.Application) error {
+	if cmd.config.IsMinAPIVersion(cf.ProcessTypesMinimumAPIVersion) {
+		appProcesses, err := cmd.processRepo.ListProcesses(app.GUID)
+		if err != nil {
+			return errors.New(T("Error retrieving processes: ") + err.Error())
+		}
+		for _, process := range appProcesses {
+			cmd.manifest.Process(app.Name, process)
+		}
+	} else if app.HealthCheckType != "" {
+		cmd.manifest.Process(app.Name, models.Process{Type: "web", HealthCheckType: app.HealthCheckType})
+	}
+
+	if cmd.config.IsMinAPIVersion(cf.SidecarsMinimumAPIVersion) {
+		appSidecars, err := cmd.sidecarRepo.ListSidecars(app.GUID)
+		if err != nil {
+			return errors.New(T("Error retrieving sidecars: ") + err.Error())
+		}
+		for _, sidecar := range appSidecars {
+			cmd.manifest.Sidecar(app.Name, sidecar)
+		}
+	}
+
+	if cmd.config.IsMinAPIVersion(cf.MetadataMinimumAPIVersion) {
+		metadata, err := cmd.metadataActor.GetMetadata("app", app.GUID)
+		if err != nil {
+			return errors.New(T("Error retrieving metadata: ") + err.Error())
+		}
+		cmd.manifest.Metadata(app.Name, metadata)
+	}
+
 	return nil
 }
 
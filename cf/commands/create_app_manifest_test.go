@@ -12,7 +12,10 @@ import (
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
 
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/processes/processesfakes"
+	"code.cloudfoundry.org/cli/cf/api/sidecars/sidecarsfakes"
 	"code.cloudfoundry.org/cli/cf/api/stacks/stacksfakes"
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
 	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
@@ -31,6 +34,11 @@ var _ = Describe("CreateAppManifest", func() {
 		appSummaryRepo *apifakes.FakeAppSummaryRepository
 		stackRepo      *stacksfakes.FakeStackRepository
 
+		serviceBindingRepo *apifakes.FakeServiceBindingRepository
+		processRepo        *processesfakes.FakeRepository
+		sidecarRepo        *sidecarsfakes.FakeRepository
+		metadataActor      *actorsfakes.FakeMetadataActor
+
 		cmd         commandregistry.Command
 		deps        commandregistry.Dependency
 		factory     *requirementsfakes.FakeFactory
@@ -50,14 +58,22 @@ var _ = Describe("CreateAppManifest", func() {
 		repoLocator := deps.RepoLocator.SetAppSummaryRepository(appSummaryRepo)
 		stackRepo = new(stacksfakes.FakeStackRepository)
 		repoLocator = repoLocator.SetStackRepository(stackRepo)
+		serviceBindingRepo = new(apifakes.FakeServiceBindingRepository)
+		repoLocator = repoLocator.SetServiceBindingRepository(serviceBindingRepo)
+		processRepo = new(processesfakes.FakeRepository)
+		repoLocator = repoLocator.SetProcessRepository(processRepo)
+		sidecarRepo = new(sidecarsfakes.FakeRepository)
+		repoLocator = repoLocator.SetSidecarRepository(sidecarRepo)
+		metadataActor = new(actorsfakes.FakeMetadataActor)
 
 		fakeManifest = new(manifestfakes.FakeApp)
 
 		deps = commandregistry.Dependency{
-			UI:          ui,
-			Config:      configRepo,
-			RepoLocator: repoLocator,
-			AppManifest: fakeManifest,
+			UI:            ui,
+			Config:        configRepo,
+			RepoLocator:   repoLocator,
+			AppManifest:   fakeManifest,
+			MetadataActor: metadataActor,
 		}
 
 		cmd = &commands.CreateAppManifest{}
@@ -133,6 +149,7 @@ var _ = Describe("CreateAppManifest", func() {
 
 			application = models.Application{}
 			application.Name = "app-name"
+			application.GUID = "app-guid"
 		})
 
 		JustBeforeEach(func() {
@@ -330,6 +347,142 @@ var _ = Describe("CreateAppManifest", func() {
 					Expect(name).To(Equal("app-name"))
 					Expect(service).To(Equal("sp2-name"))
 				})
+
+				Context("when a service is bound under a binding name", func() {
+					BeforeEach(func() {
+						application.Services[1].GUID = "sp2-guid"
+						appSummaryRepo.GetSummaryReturns(application, nil)
+						serviceBindingRepo.ListAllForAppReturns([]models.ServiceBindingFields{
+							{ServiceInstanceGUID: "sp2-guid", Name: "sp2-binding"},
+						}, nil)
+					})
+
+					It("sets the binding name of that service", func() {
+						Expect(runCLIErr).NotTo(HaveOccurred())
+						Expect(serviceBindingRepo.ListAllForAppArgsForCall(0)).To(Equal("app-guid"))
+
+						Expect(fakeManifest.ServiceCallCount()).To(Equal(1))
+						Expect(fakeManifest.ServiceBindingCallCount()).To(Equal(1))
+						name, service, bindingName := fakeManifest.ServiceBindingArgsForCall(0)
+						Expect(name).To(Equal("app-name"))
+						Expect(service).To(Equal("sp2-name"))
+						Expect(bindingName).To(Equal("sp2-binding"))
+					})
+				})
+
+				Context("when getting the service bindings fails", func() {
+					BeforeEach(func() {
+						serviceBindingRepo.ListAllForAppReturns(nil, errors.New("bindings-err"))
+					})
+
+					It("fails with error", func() {
+						Expect(runCLIErr).To(HaveOccurred())
+						Expect(runCLIErr.Error()).To(Equal("Error retrieving service bindings: bindings-err"))
+					})
+				})
+			})
+
+			Context("when the app is a docker app", func() {
+				BeforeEach(func() {
+					application.DockerImage = "my-org/my-image"
+					application.DockerUsername = "my-user"
+					appSummaryRepo.GetSummaryReturns(application, nil)
+				})
+
+				It("sets the docker image and username", func() {
+					Expect(runCLIErr).NotTo(HaveOccurred())
+					Expect(fakeManifest.DockerCallCount()).To(Equal(1))
+					name, image, username := fakeManifest.DockerArgsForCall(0)
+					Expect(name).To(Equal("app-name"))
+					Expect(image).To(Equal("my-org/my-image"))
+					Expect(username).To(Equal("my-user"))
+				})
+			})
+
+			Context("when the API does not have the v3 processes API", func() {
+				BeforeEach(func() {
+					application.HealthCheckType = "http"
+					appSummaryRepo.GetSummaryReturns(application, nil)
+				})
+
+				It("sets the health check type of the web process from the app", func() {
+					Expect(runCLIErr).NotTo(HaveOccurred())
+					Expect(processRepo.ListProcessesCallCount()).To(Equal(0))
+					Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(0))
+					Expect(metadataActor.GetMetadataCallCount()).To(Equal(0))
+
+					Expect(fakeManifest.ProcessCallCount()).To(Equal(1))
+					name, process := fakeManifest.ProcessArgsForCall(0)
+					Expect(name).To(Equal("app-name"))
+					Expect(process).To(Equal(models.Process{Type: "web", HealthCheckType: "http"}))
+				})
+			})
+
+			Context("when the API has the v3 processes, sidecars and metadata APIs", func() {
+				var (
+					web    models.Process
+					worker models.Process
+				)
+
+				BeforeEach(func() {
+					configRepo.SetAPIVersion("2.135.0")
+
+					web = models.Process{Type: "web", Instances: 2, MemoryInMB: 1024, HealthCheckType: "http", HealthCheckHTTPEndpoint: "/health"}
+					worker = models.Process{Type: "worker", Instances: 3, MemoryInMB: 256, DiskInMB: 512, HealthCheckType: "process"}
+					processRepo.ListProcessesReturns([]models.Process{web, worker}, nil)
+
+					sidecarRepo.ListSidecarsReturns([]models.Sidecar{
+						{Name: "proxy", Command: "./proxy", ProcessTypes: []string{"web"}, MemoryInMB: 64},
+					}, nil)
+
+					metadataActor.GetMetadataReturns(models.Metadata{
+						Labels:      map[string]string{"team": "payments"},
+						Annotations: map[string]string{"contact": "payments@example.com"},
+					}, nil)
+				})
+
+				It("sets the processes", func() {
+					Expect(runCLIErr).NotTo(HaveOccurred())
+					Expect(processRepo.ListProcessesArgsForCall(0)).To(Equal("app-guid"))
+
+					Expect(fakeManifest.ProcessCallCount()).To(Equal(2))
+					name, process := fakeManifest.ProcessArgsForCall(0)
+					Expect(name).To(Equal("app-name"))
+					Expect(process).To(Equal(web))
+					_, process = fakeManifest.ProcessArgsForCall(1)
+					Expect(process).To(Equal(worker))
+				})
+
+				It("sets the sidecars", func() {
+					Expect(runCLIErr).NotTo(HaveOccurred())
+					Expect(fakeManifest.SidecarCallCount()).To(Equal(1))
+					name, sidecar := fakeManifest.SidecarArgsForCall(0)
+					Expect(name).To(Equal("app-name"))
+					Expect(sidecar.Name).To(Equal("proxy"))
+				})
+
+				It("sets the metadata", func() {
+					Expect(runCLIErr).NotTo(HaveOccurred())
+					resourceType, guid := metadataActor.GetMetadataArgsForCall(0)
+					Expect(resourceType).To(Equal("app"))
+					Expect(guid).To(Equal("app-guid"))
+
+					Expect(fakeManifest.MetadataCallCount()).To(Equal(1))
+					name, metadata := fakeManifest.MetadataArgsForCall(0)
+					Expect(name).To(Equal("app-name"))
+					Expect(metadata.Labels).To(Equal(map[string]string{"team": "payments"}))
+				})
+
+				Context("when getting the processes fails", func() {
+					BeforeEach(func() {
+						processRepo.ListProcessesReturns(nil, errors.New("processes-err"))
+					})
+
+					It("fails with error", func() {
+						Expect(runCLIErr).To(HaveOccurred())
+						Expect(runCLIErr.Error()).To(Equal("Error retrieving processes: processes-err"))
+					})
+				})
 			})
 
 			Context("when the app has a health check timeout", func() {
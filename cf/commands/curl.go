@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"code.cloudfoundry.org/cli/cf/flags"
@@ -40,23 +42,29 @@ func (cmd *Curl) MetaData() commandregistry.CommandMetadata {
 	fs["H"] = &flags.StringSliceFlag{ShortName: "H", Usage: T("Custom headers to include in the request, flag can be specified multiple times")}
 	fs["d"] = &flags.StringFlag{ShortName: "d", Usage: T("HTTP data to include in the request body, or '@' followed by a file name to read the data from")}
 	fs["output"] = &flags.StringFlag{Name: "output", Usage: T("Write curl body to FILE instead of stdout")}
+	fs["fail"] = &flags.BoolFlag{Name: "fail", Usage: T("Exit with an error when the response status is not 2xx")}
+	fs["paginate"] = &flags.BoolFlag{Name: "paginate", Usage: T("Follow the next page links of a paginated GET and print the resources of all pages as one JSON array")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "curl",
 		Description: T("Executes a request to the targeted API endpoint"),
 		Usage: []string{
-			T(`CF_NAME curl PATH [-iv] [-X METHOD] [-H HEADER] [-d DATA] [--output FILE]
+			T(`CF_NAME curl PATH [-iv] [-X METHOD] [-H HEADER]... [-d DATA] [--output FILE] [--fail] [--paginate]
 
    By default 'CF_NAME curl' will perform a GET to the specified PATH. If data
    is provided via -d, a POST will be performed instead, and the Content-Type
    will be set to application/json. You may override headers with -H and the
    request method with -X.
 
+   With --paginate, the next page links of the response are followed and the
+   resources of every page are printed as a single JSON array.
+
    For API documentation, please visit http://apidocs.cloudfoundry.org.`),
 		},
 		Examples: []string{
 			`CF_NAME curl "/v2/apps" -X GET -H "Content-Type: application/x-www-form-urlencoded" -d 'q=name:myapp'`,
 			`CF_NAME curl "/v2/apps" -d @/path/to/file`,
+			`CF_NAME curl "/v2/apps?results-per-page=100" --paginate --fail`,
 		},
 		Flags: fs,
 	}
@@ -106,13 +114,17 @@ func (cmd *Curl) Execute(c flags.FlagContext) error {
 
 	reqHeader := strings.Join(headers, "\n")
 
+	if c.Bool("paginate") {
+		return cmd.paginate(c, method, path, reqHeader)
+	}
+
 	responseHeader, responseBody, apiErr := cmd.curlRepo.Request(method, path, reqHeader, body)
 	if apiErr != nil {
 		return errors.New(T("Error creating request:\n{{.Err}}", map[string]interface{}{"Err": apiErr.Error()}))
 	}
 
 	if trace.LoggingToStdout && !cmd.pluginCall {
-		return nil
+		return cmd.checkStatus(c, responseHeader)
 	}
 
 	if c.Bool("i") {
@@ -135,9 +147,120 @@ func (cmd *Curl) Execute(c flags.FlagContext) error {
 
 		cmd.ui.Say(responseBody)
 	}
+	return cmd.checkStatus(c, responseHeader)
+}
+
+func (cmd *Curl) paginate(c flags.FlagContext, method, path, reqHeader string) error {
+	if method != "" && strings.ToUpper(method) != "GET" {
+		return errors.New(T("--paginate can only be used with GET requests"))
+	}
+
+	resources := []json.RawMessage{}
+	for path != "" {
+		responseHeader, responseBody, err := cmd.curlRepo.Request("GET", path, reqHeader, "")
+		if err != nil {
+			return errors.New(T("Error creating request:\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
+		}
+
+		if c.Bool("i") && !(trace.LoggingToStdout && !cmd.pluginCall) {
+			cmd.ui.Say(responseHeader)
+		}
+
+		err = cmd.checkStatus(c, responseHeader)
+		if err != nil {
+			return err
+		}
+
+		var page paginatedResponse
+		err = json.Unmarshal([]byte(responseBody), &page)
+		if err != nil || page.Resources == nil {
+			return errors.New(T("The response to {{.Path}} is not a paginated list of resources:\n{{.Body}}",
+				map[string]interface{}{"Path": path, "Body": responseBody}))
+		}
+		resources = append(resources, page.Resources...)
+
+		path, err = cmd.relativePath(page.nextURL())
+		if err != nil {
+			return err
+		}
+	}
+
+	if trace.LoggingToStdout && !cmd.pluginCall {
+		return nil
+	}
+
+	responseBody, err := json.MarshalIndent(resources, "", "   ")
+	if err != nil {
+		return err
+	}
+
+	if c.String("output") != "" {
+		err = cmd.writeToFile(string(responseBody), c.String("output"))
+		if err != nil {
+			return errors.New(T("Error creating request:\n{{.Err}}", map[string]interface{}{"Err": err}))
+		}
+		return nil
+	}
+
+	cmd.ui.Say(string(responseBody))
 	return nil
 }
 
+// relativePath turns a next page link into a path on the API endpoint. The
+// v2 API links to a path, the v3 API to a full URL.
+func (cmd *Curl) relativePath(link string) (string, error) {
+	if link == "" || strings.HasPrefix(link, "/") {
+		return link, nil
+	}
+
+	linkURL, err := url.Parse(link)
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(link, cmd.config.APIEndpoint()) {
+		return "", errors.New(T("The next page link {{.URL}} is not on the targeted API endpoint",
+			map[string]interface{}{"URL": link}))
+	}
+	return linkURL.RequestURI(), nil
+}
+
+// checkStatus fails when --fail is given and the response status, from the
+// first line of the response headers, is not 2xx.
+func (cmd *Curl) checkStatus(c flags.FlagContext, responseHeader string) error {
+	if !c.Bool("fail") {
+		return nil
+	}
+
+	statusLine := strings.Fields(strings.SplitN(responseHeader, "\n", 2)[0])
+	if len(statusLine) < 2 {
+		return nil
+	}
+	statusCode, err := strconv.Atoi(statusLine[1])
+	if err != nil || (statusCode >= 200 && statusCode < 300) {
+		return nil
+	}
+
+	return errors.New(T("The server responded with status {{.Status}}",
+		map[string]interface{}{"Status": strings.Join(statusLine[1:], " ")}))
+}
+
+type paginatedResponse struct {
+	Resources  []json.RawMessage `json:"resources"`
+	NextURL    string            `json:"next_url"`
+	Pagination struct {
+		Next struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"pagination"`
+}
+
+func (page paginatedResponse) nextURL() string {
+	if page.NextURL != "" {
+		return page.NextURL
+	}
+	return page.Pagination.Next.Href
+}
+
 func (cmd Curl) writeToFile(responseBody, filePath string) (err error) {
 	if _, err = os.Stat(filePath); os.IsNotExist(err) {
 		err = os.MkdirAll(filepath.Dir(filePath), 0755)
@@ -266,4 +266,128 @@ var _ = Describe("curl command", func() {
 			})
 		})
 	})
+
+	Context("when --fail is provided", func() {
+		It("fails when the response status is not 2xx", func() {
+			curlRepo.ResponseHeader = "HTTP/1.1 404 Not Found\r\nContent-Type: application/json\r\n"
+			curlRepo.ResponseBody = `{"code":10000}`
+			runCurlWithInputs([]string{"--fail", "/v2/nope"})
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"code", "10000"},
+				[]string{"FAILED"},
+				[]string{"The server responded with status 404 Not Found"},
+			))
+		})
+
+		It("succeeds when the response status is 2xx", func() {
+			curlRepo.ResponseHeader = "HTTP/1.1 201 Created\r\n"
+			runCurlWithInputs([]string{"--fail", "/v2/apps", "-d", "{}"})
+
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"FAILED"}))
+		})
+
+		It("does not fail on a non-2xx response without --fail", func() {
+			curlRepo.ResponseHeader = "HTTP/1.1 404 Not Found\r\n"
+			runCurlWithInputs([]string{"/v2/nope"})
+
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"FAILED"}))
+		})
+	})
+
+	Context("when --paginate is provided", func() {
+		var fakeCurlRepo *apifakes.FakeCurlRepository
+
+		BeforeEach(func() {
+			fakeCurlRepo = new(apifakes.FakeCurlRepository)
+			config.SetAPIEndpoint("https://api.example.com")
+		})
+
+		runPaginatedCurl := func(args ...string) bool {
+			return testcmd.RunCLICommand("curl", args, requirementsFactory, func(pluginCall bool) {
+				deps.UI = ui
+				deps.RepoLocator = deps.RepoLocator.SetCurlRepository(fakeCurlRepo)
+				deps.Config = config
+				commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("curl").SetDependency(deps, pluginCall))
+			}, false, ui)
+		}
+
+		It("follows the v2 next_url links and prints all resources as one array", func() {
+			fakeCurlRepo.RequestStub = func(method, path, header, body string) (string, string, error) {
+				switch path {
+				case "/v2/apps":
+					return "HTTP/1.1 200 OK", `{"next_url":"/v2/apps?page=2","resources":[{"name":"app-1"}]}`, nil
+				case "/v2/apps?page=2":
+					return "HTTP/1.1 200 OK", `{"next_url":null,"resources":[{"name":"app-2"}]}`, nil
+				}
+				return "", "", errors.New("unexpected path " + path)
+			}
+
+			runPaginatedCurl("--paginate", "/v2/apps")
+
+			Expect(fakeCurlRepo.RequestCallCount()).To(Equal(2))
+			method, _, _, _ := fakeCurlRepo.RequestArgsForCall(1)
+			Expect(method).To(Equal("GET"))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"["},
+				[]string{`"name": "app-1"`},
+				[]string{`"name": "app-2"`},
+				[]string{"]"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"FAILED"}))
+		})
+
+		It("follows the v3 pagination links on the API endpoint", func() {
+			fakeCurlRepo.RequestStub = func(method, path, header, body string) (string, string, error) {
+				switch path {
+				case "/v3/apps":
+					return "HTTP/1.1 200 OK", `{"pagination":{"next":{"href":"https://api.example.com/v3/apps?page=2"}},"resources":[{"name":"app-1"}]}`, nil
+				case "/v3/apps?page=2":
+					return "HTTP/1.1 200 OK", `{"pagination":{"next":null},"resources":[{"name":"app-2"}]}`, nil
+				}
+				return "", "", errors.New("unexpected path " + path)
+			}
+
+			runPaginatedCurl("--paginate", "/v3/apps")
+
+			Expect(fakeCurlRepo.RequestCallCount()).To(Equal(2))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{`"name": "app-1"`},
+				[]string{`"name": "app-2"`},
+			))
+		})
+
+		It("fails when the response is not a paginated list", func() {
+			fakeCurlRepo.RequestReturns("HTTP/1.1 200 OK", `{"name":"app-1"}`, nil)
+
+			runPaginatedCurl("--paginate", "/v2/apps/some-guid")
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"The response to /v2/apps/some-guid is not a paginated list of resources"},
+			))
+		})
+
+		It("fails when used with a method other than GET", func() {
+			runPaginatedCurl("--paginate", "-X", "DELETE", "/v2/apps")
+
+			Expect(fakeCurlRepo.RequestCallCount()).To(Equal(0))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"--paginate can only be used with GET requests"},
+			))
+		})
+
+		It("stops at the first page that is not 2xx when --fail is provided", func() {
+			fakeCurlRepo.RequestReturns("HTTP/1.1 500 Internal Server Error", `{"error":"boom"}`, nil)
+
+			runPaginatedCurl("--paginate", "--fail", "/v2/apps")
+
+			Expect(fakeCurlRepo.RequestCallCount()).To(Equal(1))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"The server responded with status 500 Internal Server Error"},
+			))
+		})
+	})
 })
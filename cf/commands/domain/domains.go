@@ -77,13 +77,13 @@ func (cmd *ListDomains) Execute(c flags.FlagContext) error {
 
 	for _, domain := range domains {
 		if domain.Shared {
-			table.Add(domain.Name, T("shared"), domain.RouterGroupType)
+			table.Add(domain.Name, T("shared"), domainType(domain))
 		}
 	}
 
 	for _, domain := range domains {
 		if !domain.Shared {
-			table.Add(domain.Name, T("owned"), domain.RouterGroupType)
+			table.Add(domain.Name, T("owned"), domainType(domain))
 		}
 	}
 
@@ -111,3 +111,12 @@ func (cmd *ListDomains) getDomains(orgGUID string) ([]models.DomainFields, error
 
 	return domains, nil
 }
+
+// domainType is the type of router group of domain, or "internal" for a
+// domain of container-to-container routes.
+func domainType(domain models.DomainFields) string {
+	if domain.Internal {
+		return T("internal")
+	}
+	return domain.RouterGroupType
+}
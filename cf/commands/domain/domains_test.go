@@ -197,6 +197,7 @@ var _ = Describe("ListDomains", func() {
 					{Shared: false, Name: "Private-domain2", RouterGroupType: "tcp"},
 					{Shared: true, Name: "Shared-domain1"},
 					{Shared: true, Name: "Shared-domain2", RouterGroupType: "foobar"},
+					{Shared: true, Name: "Internal-domain", Internal: true},
 				}
 			})
 
@@ -217,6 +218,7 @@ var _ = Describe("ListDomains", func() {
 					[]string{"name", "status", "type"},
 					[]string{"Shared-domain1", "shared"},
 					[]string{"Shared-domain2", "shared", "foobar"},
+					[]string{"Internal-domain", "shared", "internal"},
 					[]string{"Private-domain1", "owned"},
 					[]string{"Private-domain2", "owned", "tcp"},
 				))
@@ -1,8 +1,10 @@
 package environmentvariablegroup
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 
 	"code.cloudfoundry.org/cli/cf/api/environmentvariablegroups"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
@@ -12,6 +14,7 @@ import (
 	. "code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/cf/util"
 )
 
 type SetRunningEnvironmentVariableGroup struct {
@@ -25,20 +28,33 @@ func init() {
 }
 
 func (cmd *SetRunningEnvironmentVariableGroup) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["from-file"] = &flags.StringFlag{Name: "from-file", Usage: T("Read the variables from a dotenv file of NAME=VALUE lines instead of JSON")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "set-running-environment-variable-group",
 		Description: T("Pass parameters as JSON to create a running environment variable group"),
 		ShortName:   "srevg",
 		Usage: []string{
 			T(`CF_NAME set-running-environment-variable-group '{"name":"value","name":"value"}'`),
+			"\n   ",
+			T("CF_NAME set-running-environment-variable-group PATH_TO_JSON_FILE"),
+			"\n   ",
+			T("CF_NAME set-running-environment-variable-group --from-file PATH_TO_DOTENV_FILE"),
+		},
+		Examples: []string{
+			`CF_NAME set-running-environment-variable-group '{"LOG_LEVEL":"info"}'`,
+			"CF_NAME set-running-environment-variable-group running-env.json",
+			"CF_NAME set-running-environment-variable-group --from-file running.env",
 		},
+		Flags: fs,
 	}
 }
 
 func (cmd *SetRunningEnvironmentVariableGroup) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
-	if len(fc.Args()) != 1 {
-		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("set-running-environment-variable-group"))
-		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	err := checkEnvironmentVariableGroupArgs(cmd.ui, fc, "set-running-environment-variable-group")
+	if err != nil {
+		return nil, err
 	}
 
 	reqs := []requirements.Requirement{
@@ -58,7 +74,12 @@ func (cmd *SetRunningEnvironmentVariableGroup) Execute(c flags.FlagContext) erro
 	cmd.ui.Say(T("Setting the contents of the running environment variable group as {{.Username}}...", map[string]interface{}{
 		"Username": terminal.EntityNameColor(cmd.config.Username())}))
 
-	err := cmd.environmentVariableGroupRepo.SetRunning(c.Args()[0])
+	contents, err := environmentVariableGroupJSON(c)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.environmentVariableGroupRepo.SetRunning(contents)
 	if err != nil {
 		suggestionText := ""
 
@@ -74,3 +95,56 @@ Your JSON string syntax is invalid.  Proper syntax is this:  cf set-running-envi
 	cmd.ui.Ok()
 	return nil
 }
+
+// checkEnvironmentVariableGroupArgs makes sure the set commands are given
+// either the variables as JSON or a dotenv file with --from-file.
+func checkEnvironmentVariableGroupArgs(ui terminal.UI, fc flags.FlagContext, commandName string) error {
+	required := 1
+	message := T("Incorrect Usage. Requires an argument\n\n")
+	if fc.IsSet("from-file") {
+		required = 0
+		message = T("Incorrect Usage. Pass the variables either as JSON or with --from-file, not both\n\n")
+	}
+
+	if len(fc.Args()) != required {
+		ui.Failed(message + commandregistry.Commands.CommandUsage(commandName))
+		return fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), required)
+	}
+	return nil
+}
+
+// environmentVariableGroupJSON returns the variables to set as the JSON
+// object the API expects. They are read from the dotenv file given with
+// --from-file, or else from the argument, which is either the JSON itself or
+// the path to a file containing it.
+func environmentVariableGroupJSON(fc flags.FlagContext) (string, error) {
+	if !fc.IsSet("from-file") {
+		contents, err := util.GetContentsFromFlagValue(fc.Args()[0])
+		if err != nil {
+			return "", err
+		}
+		return string(contents), nil
+	}
+
+	path := fc.String("from-file")
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	vars, err := util.ParseDotEnv(path, contents)
+	if err != nil {
+		return "", err
+	}
+
+	values := map[string]string{}
+	for name, value := range vars {
+		values[name] = *value
+	}
+
+	body, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
@@ -1,6 +1,10 @@
 package environmentvariablegroup_test
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
 	"code.cloudfoundry.org/cli/cf/api/environmentvariablegroups/environmentvariablegroupsfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -81,5 +85,59 @@ var _ = Describe("set-running-environment-variable-group command", func() {
 				[]string{`Your JSON string syntax is invalid.  Proper syntax is this:  cf set-running-environment-variable-group '{"name":"value","name":"value"}'`},
 			))
 		})
+
+		Context("when the variables are in a file", func() {
+			var dir string
+
+			BeforeEach(func() {
+				var err error
+				dir, err = ioutil.TempDir("", "env-group")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(dir)
+			})
+
+			It("reads the JSON from a file", func() {
+				path := filepath.Join(dir, "running.json")
+				Expect(ioutil.WriteFile(path, []byte(`{"abc":"123"}`), 0600)).To(Succeed())
+
+				Expect(runCommand(path)).To(BeTrue())
+				Expect(environmentVariableGroupRepo.SetRunningArgsForCall(0)).To(Equal(`{"abc":"123"}`))
+			})
+
+			It("reads the variables from a dotenv file with --from-file", func() {
+				path := filepath.Join(dir, "running.env")
+				Expect(ioutil.WriteFile(path, []byte("# shared settings\nLOG_LEVEL=info\nexport GREETING=\"hello world\"\n"), 0600)).To(Succeed())
+
+				Expect(runCommand("--from-file", path)).To(BeTrue())
+				Expect(environmentVariableGroupRepo.SetRunningArgsForCall(0)).To(MatchJSON(`{"LOG_LEVEL":"info","GREETING":"hello world"}`))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Setting the contents of the running environment variable group as my-user..."},
+					[]string{"OK"},
+				))
+			})
+
+			It("fails when the dotenv file has an invalid line", func() {
+				path := filepath.Join(dir, "running.env")
+				Expect(ioutil.WriteFile(path, []byte("LOG_LEVEL info\n"), 0600)).To(Succeed())
+
+				Expect(runCommand("--from-file", path)).To(BeFalse())
+				Expect(environmentVariableGroupRepo.SetRunningCallCount()).To(BeZero())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Invalid line 1 in " + path + "; expected NAME=VALUE"},
+				))
+			})
+
+			It("fails with usage when given both JSON and --from-file", func() {
+				runCommand("--from-file", filepath.Join(dir, "running.env"), `{"abc":"123"}`)
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Incorrect Usage", "either as JSON or with --from-file, not both"},
+				))
+				Expect(environmentVariableGroupRepo.SetRunningCallCount()).To(BeZero())
+			})
+		})
 	})
 })
@@ -2,7 +2,6 @@ package environmentvariablegroup
 
 import (
 	"errors"
-	"fmt"
 
 	"code.cloudfoundry.org/cli/cf/api/environmentvariablegroups"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
@@ -25,20 +24,33 @@ func init() {
 }
 
 func (cmd *SetStagingEnvironmentVariableGroup) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["from-file"] = &flags.StringFlag{Name: "from-file", Usage: T("Read the variables from a dotenv file of NAME=VALUE lines instead of JSON")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "set-staging-environment-variable-group",
 		Description: T("Pass parameters as JSON to create a staging environment variable group"),
 		ShortName:   "ssevg",
 		Usage: []string{
 			T(`CF_NAME set-staging-environment-variable-group '{"name":"value","name":"value"}'`),
+			"\n   ",
+			T("CF_NAME set-staging-environment-variable-group PATH_TO_JSON_FILE"),
+			"\n   ",
+			T("CF_NAME set-staging-environment-variable-group --from-file PATH_TO_DOTENV_FILE"),
+		},
+		Examples: []string{
+			`CF_NAME set-staging-environment-variable-group '{"LOG_LEVEL":"info"}'`,
+			"CF_NAME set-staging-environment-variable-group staging-env.json",
+			"CF_NAME set-staging-environment-variable-group --from-file staging.env",
 		},
+		Flags: fs,
 	}
 }
 
 func (cmd *SetStagingEnvironmentVariableGroup) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
-	if len(fc.Args()) != 1 {
-		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("set-staging-environment-variable-group"))
-		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	err := checkEnvironmentVariableGroupArgs(cmd.ui, fc, "set-staging-environment-variable-group")
+	if err != nil {
+		return nil, err
 	}
 
 	reqs := []requirements.Requirement{
@@ -58,7 +70,12 @@ func (cmd *SetStagingEnvironmentVariableGroup) Execute(c flags.FlagContext) erro
 	cmd.ui.Say(T("Setting the contents of the staging environment variable group as {{.Username}}...", map[string]interface{}{
 		"Username": terminal.EntityNameColor(cmd.config.Username())}))
 
-	err := cmd.environmentVariableGroupRepo.SetStaging(c.Args()[0])
+	contents, err := environmentVariableGroupJSON(c)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.environmentVariableGroupRepo.SetStaging(contents)
 	if err != nil {
 		suggestionText := ""
 
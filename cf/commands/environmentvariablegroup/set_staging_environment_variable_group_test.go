@@ -1,6 +1,10 @@
 package environmentvariablegroup_test
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
 	"code.cloudfoundry.org/cli/cf/api/environmentvariablegroups/environmentvariablegroupsfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -81,5 +85,17 @@ var _ = Describe("set-staging-environment-variable-group command", func() {
 				[]string{`Your JSON string syntax is invalid.  Proper syntax is this:  cf set-staging-environment-variable-group '{"name":"value","name":"value"}'`},
 			))
 		})
+
+		It("reads the variables from a dotenv file with --from-file", func() {
+			dir, err := ioutil.TempDir("", "env-group")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			path := filepath.Join(dir, "staging.env")
+			Expect(ioutil.WriteFile(path, []byte("NPM_CONFIG_LOGLEVEL=error\n"), 0600)).To(Succeed())
+
+			Expect(runCommand("--from-file", path)).To(BeTrue())
+			Expect(environmentVariableGroupRepo.SetStagingArgsForCall(0)).To(MatchJSON(`{"NPM_CONFIG_LOGLEVEL":"error"}`))
+		})
 	})
 })
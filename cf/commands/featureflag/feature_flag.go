@@ -29,6 +29,11 @@ func (cmd *ShowFeatureFlag) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME feature-flag FEATURE_NAME"),
 		},
+		Examples: []string{
+			"CF_NAME feature-flag route_creation",
+			"CF_NAME feature-flag route_creation --output json",
+		},
+		StructuredOutput: true,
 	}
 }
 
@@ -64,11 +69,17 @@ func (cmd *ShowFeatureFlag) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	if printer, ok := cmd.ui.(terminal.DataPrinter); ok {
+		printer.SetData(featureFlagData(flag))
+		return nil
+	}
+
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
-	table := cmd.ui.Table([]string{T("Features"), T("State")})
-	table.Add(flag.Name, cmd.flagBoolToString(flag.Enabled))
+	table := cmd.ui.Table([]string{T("Features"), T("State"), T("Description")})
+	table.Table.SetFieldKeys("features", "state", "description")
+	table.Add(flag.Name, cmd.flagBoolToString(flag.Enabled), flag.Description)
 
 	err = table.Print()
 	if err != nil {
@@ -2,13 +2,17 @@ package featureflag_test
 
 import (
 	"errors"
+	"strings"
 
 	"code.cloudfoundry.org/cli/cf/api/featureflags/featureflagsfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/featureflag"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
 	. "code.cloudfoundry.org/cli/testhelpers/matchers"
@@ -60,8 +64,9 @@ var _ = Describe("feature-flag command", func() {
 	Describe("when logged in", func() {
 		BeforeEach(func() {
 			flag := models.FeatureFlag{
-				Name:    "route_creation",
-				Enabled: false,
+				Name:        "route_creation",
+				Enabled:     false,
+				Description: "Any user can create a route",
 			}
 			flagRepo.FindByNameReturns(flag, nil)
 		})
@@ -70,11 +75,33 @@ var _ = Describe("feature-flag command", func() {
 			runCommand("route_creation")
 			Expect(ui.Outputs()).To(ContainSubstrings(
 				[]string{"Retrieving status of route_creation as my-user..."},
-				[]string{"Feature", "State"},
-				[]string{"route_creation", "disabled"},
+				[]string{"Feature", "State", "Description"},
+				[]string{"route_creation", "disabled", "Any user can create a route"},
 			))
 		})
 
+		It("prints the feature flag as JSON with --output json", func() {
+			formattedUI := terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+			deps.UI = formattedUI
+			deps.Config = configRepo
+			deps.RepoLocator = deps.RepoLocator.SetFeatureFlagRepository(flagRepo)
+
+			cmd := &featureflag.ShowFeatureFlag{}
+			cmd.SetDependency(deps, false)
+			flagContext := flags.NewFlagContext(cmd.MetaData().Flags)
+			Expect(flagContext.Parse("route_creation")).To(Succeed())
+			Expect(cmd.Execute(flagContext)).To(Succeed())
+			Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+			Expect(flagRepo.FindByNameArgsForCall(0)).To(Equal("route_creation"))
+			Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`{
+				"name": "route_creation",
+				"enabled": false,
+				"description": "Any user can create a route",
+				"error_message": ""
+			}`))
+		})
+
 		Context("when an error occurs", func() {
 			BeforeEach(func() {
 				flagRepo.FindByNameReturns(models.FeatureFlag{}, errors.New("An error occurred."))
@@ -6,6 +6,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
 )
@@ -27,6 +28,11 @@ func (cmd *ListFeatureFlags) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME feature-flags"),
 		},
+		Examples: []string{
+			"CF_NAME feature-flags",
+			"CF_NAME feature-flags --output json",
+		},
+		StructuredOutput: true,
 	}
 }
 
@@ -62,15 +68,26 @@ func (cmd *ListFeatureFlags) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	if printer, ok := cmd.ui.(terminal.DataPrinter); ok {
+		data := []map[string]interface{}{}
+		for _, flag := range flags {
+			data = append(data, featureFlagData(flag))
+		}
+		printer.SetData(data)
+		return nil
+	}
+
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
-	table := cmd.ui.Table([]string{T("Features"), T("State")})
+	table := cmd.ui.Table([]string{T("Features"), T("State"), T("Description")})
+	table.Table.SetFieldKeys("features", "state", "description")
 
 	for _, flag := range flags {
 		table.Add(
 			flag.Name,
 			cmd.flagBoolToString(flag.Enabled),
+			flag.Description,
 		)
 	}
 
@@ -87,3 +104,14 @@ func (cmd ListFeatureFlags) flagBoolToString(enabled bool) string {
 	}
 	return "disabled"
 }
+
+// featureFlagData is what feature-flags and feature-flag print for a flag
+// with --output json or yaml.
+func featureFlagData(flag models.FeatureFlag) map[string]interface{} {
+	return map[string]interface{}{
+		"name":          flag.Name,
+		"enabled":       flag.Enabled,
+		"description":   flag.Description,
+		"error_message": flag.ErrorMessage,
+	}
+}
@@ -2,6 +2,7 @@ package featureflag_test
 
 import (
 	"errors"
+	"strings"
 
 	"code.cloudfoundry.org/cli/cf/api/featureflags/featureflagsfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
@@ -11,6 +12,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
 	. "code.cloudfoundry.org/cli/testhelpers/matchers"
@@ -84,6 +86,7 @@ var _ = Describe("feature-flags command", func() {
 					Name:         "user_org_creation",
 					Enabled:      true,
 					ErrorMessage: "error",
+					Description:  "Any user can create an organization",
 				},
 				{
 					Name:    "private_domain_creation",
@@ -109,8 +112,8 @@ var _ = Describe("feature-flags command", func() {
 			runCommand()
 			Expect(ui.Outputs()).To(ContainSubstrings(
 				[]string{"Retrieving status of all flagged features as my-user..."},
-				[]string{"Feature", "State"},
-				[]string{"user_org_creation", "enabled"},
+				[]string{"Feature", "State", "Description"},
+				[]string{"user_org_creation", "enabled", "Any user can create an organization"},
 				[]string{"private_domain_creation", "disabled"},
 				[]string{"app_bits_upload", "enabled"},
 				[]string{"app_scaling", "enabled"},
@@ -118,6 +121,26 @@ var _ = Describe("feature-flags command", func() {
 			))
 		})
 
+		It("prints the feature flags as JSON with --output json", func() {
+			formattedUI := terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+			deps.UI = formattedUI
+			deps.Config = configRepo
+			deps.RepoLocator = deps.RepoLocator.SetFeatureFlagRepository(flagRepo)
+
+			cmd := &featureflag.ListFeatureFlags{}
+			cmd.SetDependency(deps, false)
+			Expect(cmd.Execute(flags.NewFlagContext(cmd.MetaData().Flags))).To(Succeed())
+			Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+			Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`[
+				{"name": "user_org_creation", "enabled": true, "description": "Any user can create an organization", "error_message": "error"},
+				{"name": "private_domain_creation", "enabled": false, "description": "", "error_message": ""},
+				{"name": "app_bits_upload", "enabled": true, "description": "", "error_message": ""},
+				{"name": "app_scaling", "enabled": true, "description": "", "error_message": ""},
+				{"name": "route_creation", "enabled": false, "description": "", "error_message": ""}
+			]`))
+		})
+
 		Context("when an error occurs", func() {
 			BeforeEach(func() {
 				flagRepo.ListReturns(nil, errors.New("An error occurred."))
@@ -2,7 +2,9 @@ package commands
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/flags"
@@ -19,6 +21,7 @@ import (
 
 const maxLoginTries = 3
 const maxChoices = 50
+const ssoCallbackTimeout = 5 * time.Minute
 
 type Login struct {
 	ui            terminal.UI
@@ -27,6 +30,7 @@ type Login struct {
 	endpointRepo  coreconfig.EndpointRepository
 	orgRepo       organizations.OrganizationRepository
 	spaceRepo     spaces.SpaceRepository
+	picker        terminal.Picker
 }
 
 func init() {
@@ -41,6 +45,7 @@ func (cmd *Login) MetaData() commandregistry.CommandMetadata {
 	fs["o"] = &flags.StringFlag{ShortName: "o", Usage: T("Org")}
 	fs["s"] = &flags.StringFlag{ShortName: "s", Usage: T("Space")}
 	fs["sso"] = &flags.BoolFlag{Name: "sso", Usage: T("Use a one-time password to login")}
+	fs["sso-callback"] = &flags.BoolFlag{Name: "sso-callback", Usage: T("Login with single sign-on in a web browser, which hands the login back to the CLI")}
 	fs["skip-ssl-validation"] = &flags.BoolFlag{Name: "skip-ssl-validation", Usage: T("Skip verification of the API endpoint. Not recommended!")}
 
 	return commandregistry.CommandMetadata{
@@ -48,7 +53,7 @@ func (cmd *Login) MetaData() commandregistry.CommandMetadata {
 		ShortName:   "l",
 		Description: T("Log user in"),
 		Usage: []string{
-			T("CF_NAME login [-a API_URL] [-u USERNAME] [-p PASSWORD] [-o ORG] [-s SPACE]\n\n"),
+			T("CF_NAME login [-a API_URL] [-u USERNAME] [-p PASSWORD] [-o ORG] [-s SPACE] [--sso | --sso-callback]\n\n"),
 			terminal.WarningColor(T("WARNING:\n   Providing your password as a command line option is highly discouraged\n   Your password may be visible to others and may be recorded in your shell history")),
 		},
 		Examples: []string{
@@ -57,12 +62,18 @@ func (cmd *Login) MetaData() commandregistry.CommandMetadata {
 			T("CF_NAME login -u name@example.com -p \"my password\" (use quotes for passwords with a space)"),
 			T("CF_NAME login -u name@example.com -p \"\\\"password\\\"\" (escape quotes if used in password)"),
 			T("CF_NAME login --sso (CF_NAME will provide a url to obtain a one-time password to login)"),
+			T("CF_NAME login --sso-callback (CF_NAME will provide a url to login in a web browser, and wait for the login to complete)"),
 		},
 		Flags: fs,
 	}
 }
 
 func (cmd *Login) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if fc.Bool("sso") && fc.Bool("sso-callback") {
+		cmd.ui.Failed(T("Incorrect Usage: --sso and --sso-callback cannot be used together.\n\n") + commandregistry.Commands.CommandUsage("login"))
+		return nil, fmt.Errorf("Incorrect usage: --sso and --sso-callback cannot be used together")
+	}
+
 	reqs := []requirements.Requirement{}
 	return reqs, nil
 }
@@ -74,6 +85,7 @@ func (cmd *Login) SetDependency(deps commandregistry.Dependency, pluginCall bool
 	cmd.endpointRepo = deps.RepoLocator.GetEndpointRepository()
 	cmd.orgRepo = deps.RepoLocator.GetOrganizationRepository()
 	cmd.spaceRepo = deps.RepoLocator.GetSpaceRepository()
+	cmd.picker = deps.Picker
 	return cmd
 }
 
@@ -112,6 +124,11 @@ func (cmd *Login) Execute(c flags.FlagContext) error {
 		if err != nil {
 			return err
 		}
+	} else if c.Bool("sso-callback") {
+		err = cmd.authenticateSSOCallback()
+		if err != nil {
+			return err
+		}
 	} else {
 		err = cmd.authenticate(c)
 		if err != nil {
@@ -129,6 +146,11 @@ func (cmd *Login) Execute(c flags.FlagContext) error {
 		if err != nil {
 			return err
 		}
+
+		cmd.config.SetLastLoginTarget(cmd.config.APIEndpoint(), coreconfig.LoginTarget{
+			OrgName:   cmd.config.OrganizationFields().Name,
+			SpaceName: cmd.config.SpaceFields().Name,
+		})
 	}
 	cmd.ui.NotifyUpdateIfNeeded(cmd.config)
 	return nil
@@ -158,7 +180,10 @@ func (cmd Login) authenticateSSO(c flags.FlagContext) error {
 	}
 
 	credentials := make(map[string]string)
-	passcode := prompts["passcode"]
+	passcode, ok := prompts["passcode"]
+	if !ok {
+		return errors.New(T("The authentication server does not offer a one-time passcode for single sign-on"))
+	}
 
 	for i := 0; i < maxLoginTries; i++ {
 		credentials["passcode"] = cmd.ui.AskForPassword(passcode.DisplayName)
@@ -181,6 +206,47 @@ func (cmd Login) authenticateSSO(c flags.FlagContext) error {
 	return nil
 }
 
+// authenticateSSOCallback logs in through the authorization code flow: the
+// user logs in in a web browser, which is then redirected to a local
+// listener with a code that the CLI exchanges for tokens.
+func (cmd Login) authenticateSSOCallback() error {
+	_, err := cmd.authenticator.GetLoginPromptsAndSaveUAAServerURL()
+	if err != nil {
+		return err
+	}
+
+	listener, err := authentication.NewCallbackListener()
+	if err != nil {
+		return errors.New(T("Unable to listen for the single sign-on callback: {{.Err}}",
+			map[string]interface{}{"Err": err.Error()}))
+	}
+	defer listener.Close()
+
+	authorizationURL, err := cmd.authenticator.AuthorizationCodeURL(listener.RedirectURI(), listener.State())
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(T("Open this URL in a web browser to log in:"))
+	cmd.ui.Say("   %s", authorizationURL)
+	cmd.ui.Say(T("Waiting for the login to complete..."))
+
+	code, err := listener.WaitForCode(ssoCallbackTimeout)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(T("Authenticating..."))
+	err = cmd.authenticator.AuthenticateWithAuthorizationCode(code, listener.RedirectURI())
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return nil
+}
+
 func (cmd Login) authenticate(c flags.FlagContext) error {
 	usernameFlagValue := c.String("u")
 	passwordFlagValue := c.String("p")
@@ -264,7 +330,14 @@ func (cmd Login) setOrganization(c flags.FlagContext) (bool, error) {
 			cmd.targetOrganization(orgs[0])
 			return true, nil
 		default:
-			orgName = cmd.promptForOrgName(orgs)
+			if !cmd.picker.Interactive() {
+				return false, nil
+			}
+
+			orgName, err = cmd.promptForOrgName(orgs)
+			if err != nil {
+				return false, err
+			}
 			if orgName == "" {
 				cmd.ui.Say("")
 				return false, nil
@@ -282,13 +355,14 @@ func (cmd Login) setOrganization(c flags.FlagContext) (bool, error) {
 	return true, nil
 }
 
-func (cmd Login) promptForOrgName(orgs []models.Organization) string {
+func (cmd Login) promptForOrgName(orgs []models.Organization) (string, error) {
 	orgNames := []string{}
 	for _, org := range orgs {
 		orgNames = append(orgNames, org.Name)
 	}
 
-	return cmd.promptForName(orgNames, T("Select an org (or press enter to skip):"), "Org")
+	lastOrgName := cmd.config.LastLoginTarget(cmd.config.APIEndpoint()).OrgName
+	return cmd.promptForName(orgNames, lastOrgName, T("Select an org:"), T("Select an org (or press enter to skip):"), "Org")
 }
 
 func (cmd Login) targetOrganization(org models.Organization) {
@@ -316,8 +390,13 @@ func (cmd Login) setSpace(c flags.FlagContext) error {
 		} else if len(availableSpaces) == 1 {
 			cmd.targetSpace(availableSpaces[0])
 			return nil
+		} else if !cmd.picker.Interactive() {
+			return nil
 		} else {
-			spaceName = cmd.promptForSpaceName(availableSpaces)
+			spaceName, err = cmd.promptForSpaceName(availableSpaces)
+			if err != nil {
+				return err
+			}
 			if spaceName == "" {
 				cmd.ui.Say("")
 				return nil
@@ -335,13 +414,19 @@ func (cmd Login) setSpace(c flags.FlagContext) error {
 	return nil
 }
 
-func (cmd Login) promptForSpaceName(spaces []models.Space) string {
+func (cmd Login) promptForSpaceName(spaces []models.Space) (string, error) {
 	spaceNames := []string{}
 	for _, space := range spaces {
 		spaceNames = append(spaceNames, space.Name)
 	}
 
-	return cmd.promptForName(spaceNames, T("Select a space (or press enter to skip):"), "Space")
+	// the space is only offered first when the org is the last one targeted
+	lastTarget := cmd.config.LastLoginTarget(cmd.config.APIEndpoint())
+	lastSpaceName := ""
+	if lastTarget.OrgName == cmd.config.OrganizationFields().Name {
+		lastSpaceName = lastTarget.SpaceName
+	}
+	return cmd.promptForName(spaceNames, lastSpaceName, T("Select a space:"), T("Select a space (or press enter to skip):"), "Space")
 }
 
 func (cmd Login) targetSpace(space models.Space) {
@@ -350,7 +435,30 @@ func (cmd Login) targetSpace(space models.Space) {
 		map[string]interface{}{"SpaceName": terminal.EntityNameColor(space.Name)}))
 }
 
-func (cmd Login) promptForName(names []string, listPrompt, itemPrompt string) string {
+// promptForName lets the user pick one of names with the arrow keys, starting
+// at lastName, and falls back to a numbered list when the terminal does not
+// allow that. It returns "" when the user skips picking.
+func (cmd Login) promptForName(names []string, lastName, pickPrompt, listPrompt, itemPrompt string) (string, error) {
+	lastIndex := -1
+	for i, name := range names {
+		if name == lastName {
+			lastIndex = i
+		}
+	}
+
+	if len(names) < maxChoices {
+		picked, err := cmd.picker.Pick(pickPrompt, names, lastIndex)
+		if err == nil {
+			if picked < 0 {
+				return "", nil
+			}
+			return names[picked], nil
+		}
+		if err != terminal.ErrPickerUnavailable {
+			return "", err
+		}
+	}
+
 	nameIndex := 0
 	var nameString string
 	for nameIndex < 1 || nameIndex > len(names) {
@@ -362,7 +470,11 @@ func (cmd Login) promptForName(names []string, listPrompt, itemPrompt string) st
 		// only display list if it is shorter than maxChoices
 		if len(names) < maxChoices {
 			for i, name := range names {
-				cmd.ui.Say("%d. %s", i+1, name)
+				if i == lastIndex {
+					cmd.ui.Say("%d. %s %s", i+1, name, T("(last used)"))
+				} else {
+					cmd.ui.Say("%d. %s", i+1, name)
+				}
 			}
 		} else {
 			cmd.ui.Say(T("There are too many options to display, please type in the name."))
@@ -370,16 +482,16 @@ func (cmd Login) promptForName(names []string, listPrompt, itemPrompt string) st
 
 		nameString = cmd.ui.Ask(itemPrompt)
 		if nameString == "" {
-			return ""
+			return "", nil
 		}
 
 		nameIndex, err = strconv.Atoi(nameString)
 
 		if err != nil {
 			nameIndex = 1
-			return nameString
+			return nameString, nil
 		}
 	}
 
-	return names[nameIndex-1]
+	return names[nameIndex-1], nil
 }
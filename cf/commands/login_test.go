@@ -1,6 +1,7 @@
 package commands_test
 
 import (
+	"net/http"
 	"strconv"
 
 	"code.cloudfoundry.org/cli/cf"
@@ -12,6 +13,8 @@ import (
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig/coreconfigfakes"
 	"code.cloudfoundry.org/cli/cf/errors"
 	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
 	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
@@ -30,6 +33,7 @@ var _ = Describe("Login Command", func() {
 		endpointRepo *coreconfigfakes.FakeEndpointRepository
 		orgRepo      *organizationsfakes.FakeOrganizationRepository
 		spaceRepo    *spacesfakes.FakeSpaceRepository
+		picker       *terminalfakes.FakePicker
 
 		org  models.Organization
 		deps commandregistry.Dependency
@@ -40,6 +44,7 @@ var _ = Describe("Login Command", func() {
 
 	updateCommandDependency := func(pluginCall bool) {
 		deps.UI = ui
+		deps.Picker = picker
 		deps.Config = Config
 		deps.RepoLocator = deps.RepoLocator.SetEndpointRepository(endpointRepo)
 		deps.RepoLocator = deps.RepoLocator.SetAuthenticationRepository(authRepo)
@@ -72,6 +77,9 @@ var _ = Describe("Login Command", func() {
 			return nil
 		}
 		endpointRepo = new(coreconfigfakes.FakeEndpointRepository)
+		picker = new(terminalfakes.FakePicker)
+		picker.InteractiveReturns(true)
+		picker.PickReturns(-1, terminal.ErrPickerUnavailable)
 		minCLIVersion = "1.0.0"
 		minRecommendedCLIVersion = "1.0.0"
 
@@ -217,6 +225,131 @@ var _ = Describe("Login Command", func() {
 				Expect(ui.ShowConfigurationCalled).To(BeTrue())
 			})
 
+			It("lets the user pick an org and space with the arrow keys", func() {
+				ui.Inputs = []string{"api.example.com", "user@example.com", "password"}
+				orgRepo.FindByNameReturns(org2, nil)
+				picker.PickStub = func(prompt string, options []string, selected int) (int, error) {
+					return 1, nil
+				}
+
+				testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+				Expect(picker.PickCallCount()).To(Equal(2))
+				prompt, options, selected := picker.PickArgsForCall(0)
+				Expect(prompt).To(Equal("Select an org:"))
+				Expect(options).To(Equal([]string{"some-org", "my-new-org"}))
+				Expect(selected).To(Equal(-1))
+				prompt, options, _ = picker.PickArgsForCall(1)
+				Expect(prompt).To(Equal("Select a space:"))
+				Expect(options).To(Equal([]string{"my-space", "some-space"}))
+
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"1. some-org"}))
+				Expect(orgRepo.FindByNameArgsForCall(0)).To(Equal("my-new-org"))
+				Expect(spaceRepo.FindByNameArgsForCall(0)).To(Equal("some-space"))
+				Expect(Config.SpaceFields().GUID).To(Equal("some-space-guid"))
+			})
+
+			It("skips targeting when the user skips picking", func() {
+				ui.Inputs = []string{"api.example.com", "user@example.com", "password"}
+				picker.PickReturns(-1, nil)
+
+				testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+				Expect(picker.PickCallCount()).To(Equal(1))
+				Expect(orgRepo.FindByNameCallCount()).To(Equal(0))
+				Expect(Config.OrganizationFields().GUID).To(BeEmpty())
+			})
+
+			It("fails when the user interrupts picking", func() {
+				ui.Inputs = []string{"api.example.com", "user@example.com", "password"}
+				picker.PickReturns(-1, terminal.ErrPickInterrupted)
+
+				testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"FAILED"}, []string{"interrupted"}))
+				Expect(Config.OrganizationFields().GUID).To(BeEmpty())
+			})
+
+			It("remembers the org and space targeted for the API", func() {
+				orgRepo.FindByNameReturns(org2, nil)
+				ui.Inputs = []string{"api.example.com", "user@example.com", "password", "2", "1"}
+
+				testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+				Expect(Config.LastLoginTarget("api.example.com")).To(Equal(coreconfig.LoginTarget{
+					OrgName:   "my-new-org",
+					SpaceName: "my-space",
+				}))
+				Expect(Config.LastLoginTarget("api.other.example.com")).To(Equal(coreconfig.LoginTarget{}))
+			})
+
+			Context("when an org and space were targeted when last logging in to the API", func() {
+				BeforeEach(func() {
+					Config.SetLastLoginTarget("api.example.com", coreconfig.LoginTarget{
+						OrgName:   "my-new-org",
+						SpaceName: "some-space",
+					})
+					orgRepo.FindByNameReturns(org2, nil)
+				})
+
+				It("starts picking at the last org and space", func() {
+					ui.Inputs = []string{"api.example.com", "user@example.com", "password"}
+					picker.PickStub = func(prompt string, options []string, selected int) (int, error) {
+						return selected, nil
+					}
+
+					testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+					_, _, selected := picker.PickArgsForCall(0)
+					Expect(selected).To(Equal(1))
+					_, _, selected = picker.PickArgsForCall(1)
+					Expect(selected).To(Equal(1))
+					Expect(Config.SpaceFields().GUID).To(Equal("some-space-guid"))
+				})
+
+				It("marks the last org and space in the numbered list", func() {
+					ui.Inputs = []string{"api.example.com", "user@example.com", "password", "2", "1"}
+
+					testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"1. some-org"},
+						[]string{"2. my-new-org (last used)"},
+						[]string{"1. my-space"},
+						[]string{"2. some-space (last used)"},
+					))
+				})
+			})
+
+			Context("when the input is not a terminal", func() {
+				BeforeEach(func() {
+					picker.InteractiveReturns(false)
+				})
+
+				It("does not ask the user to select an org", func() {
+					ui.Inputs = []string{"api.example.com", "user@example.com", "password"}
+
+					testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+					Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Select an org"}))
+					Expect(picker.PickCallCount()).To(Equal(0))
+					Expect(Config.OrganizationFields().GUID).To(BeEmpty())
+					Expect(Config.AccessToken()).To(Equal("my_access_token"))
+				})
+
+				It("does not ask the user to select a space", func() {
+					Flags = []string{"-o", "my-new-org"}
+					ui.Inputs = []string{"api.example.com", "user@example.com", "password"}
+					orgRepo.FindByNameReturns(org2, nil)
+
+					testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+					Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Select a space"}))
+					Expect(Config.OrganizationFields().GUID).To(Equal("my-new-org-guid"))
+					Expect(Config.SpaceFields().GUID).To(BeEmpty())
+				})
+			})
+
 			It("lets the user specify an org and space using flags", func() {
 				Flags = []string{"-a", "api.example.com", "-u", "user@example.com", "-p", "password", "-o", "my-new-org", "-s", "my-space"}
 
@@ -459,6 +592,83 @@ var _ = Describe("Login Command", func() {
 				})
 			})
 
+			Context("when the user provides the --sso flag and the server does not offer a passcode", func() {
+				BeforeEach(func() {
+					authRepo.GetLoginPromptsAndSaveUAAServerURLReturns(map[string]coreconfig.AuthPrompt{
+						"username": {DisplayName: "Username", Type: coreconfig.AuthPromptTypeText},
+						"password": {DisplayName: "Password", Type: coreconfig.AuthPromptTypePassword},
+					}, nil)
+				})
+
+				It("fails without prompting", func() {
+					Flags = []string{"--sso", "-a", "api.example.com"}
+
+					testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+					Expect(ui.PasswordPrompts).To(BeEmpty())
+					Expect(authRepo.AuthenticateCallCount()).To(Equal(0))
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"FAILED"},
+						[]string{"does not offer a one-time passcode"},
+					))
+				})
+			})
+
+			Context("when the user provides the --sso-callback flag", func() {
+				BeforeEach(func() {
+					authRepo.AuthorizationCodeURLStub = func(redirectURI, state string) (string, error) {
+						// play the part of the browser, redirected back to the CLI
+						// once the user has logged in
+						go http.Get(redirectURI + "?code=some-code&state=" + state)
+						return "https://uaa.example.com/oauth/authorize?some=query", nil
+					}
+				})
+
+				It("logs in with the authorization code from the redirect", func() {
+					Flags = []string{"--sso-callback", "-a", "api.example.com"}
+
+					testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+					Expect(ui.Prompts).To(BeEmpty())
+					Expect(ui.PasswordPrompts).To(BeEmpty())
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Open this URL in a web browser to log in:"},
+						[]string{"https://uaa.example.com/oauth/authorize?some=query"},
+						[]string{"Waiting for the login to complete..."},
+						[]string{"Authenticating..."},
+						[]string{"OK"},
+					))
+
+					Expect(authRepo.AuthenticateWithAuthorizationCodeCallCount()).To(Equal(1))
+					code, redirectURI := authRepo.AuthenticateWithAuthorizationCodeArgsForCall(0)
+					Expect(code).To(Equal("some-code"))
+					redirectURIArg, _ := authRepo.AuthorizationCodeURLArgsForCall(0)
+					Expect(redirectURI).To(Equal(redirectURIArg))
+				})
+
+				It("fails when the authorization code is rejected", func() {
+					authRepo.AuthenticateWithAuthorizationCodeReturns(errors.New("Credentials were rejected, please try again."))
+					Flags = []string{"--sso-callback", "-a", "api.example.com"}
+
+					testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"FAILED"},
+						[]string{"Credentials were rejected"},
+					))
+				})
+
+				It("fails with usage when --sso is also provided", func() {
+					Flags = []string{"--sso-callback", "--sso", "-a", "api.example.com"}
+
+					Expect(testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)).To(BeFalse())
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Incorrect Usage", "--sso and --sso-callback cannot be used together"},
+					))
+					Expect(authRepo.AuthorizationCodeURLCallCount()).To(Equal(0))
+				})
+			})
+
 			It("takes the password from the -p flag", func() {
 				Flags = []string{"-p", "the-password"}
 				ui.Inputs = []string{"api.example.com", "the-username", "the-account-number", "the-pin"}
@@ -0,0 +1,112 @@
+package metadata
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type Labels struct {
+	ui            terminal.UI
+	config        coreconfig.Reader
+	metadataActor actors.MetadataActor
+}
+
+func init() {
+	commandregistry.Register(&Labels{})
+}
+
+func (cmd *Labels) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "labels",
+		Description: T("List all labels (key-value pairs) for a resource"),
+		Usage: []string{
+			T("CF_NAME labels RESOURCE_TYPE RESOURCE_NAME"),
+			"\n\n",
+			T("RESOURCE_TYPES:\n   {{.Types}}", map[string]interface{}{"Types": strings.Join(actors.LabeledResourceTypes(), ", ")}),
+		},
+		Examples: []string{
+			"CF_NAME labels app dora",
+			"CF_NAME labels space my-space",
+		},
+	}
+}
+
+func (cmd *Labels) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires RESOURCE_TYPE and RESOURCE_NAME as arguments\n\n") + commandregistry.Commands.CommandUsage("labels"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 2)
+	}
+
+	return metadataRequirements(requirementsFactory, "labels", fc.Args()[0]), nil
+}
+
+func (cmd *Labels) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.metadataActor = deps.MetadataActor
+	return cmd
+}
+
+func (cmd *Labels) Execute(c flags.FlagContext) error {
+	resourceType, resourceName := c.Args()[0], c.Args()[1]
+
+	cmd.ui.Say(T("Getting labels for {{.ResourceType}} {{.ResourceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"ResourceType": resourceType,
+			"ResourceName": terminal.EntityNameColor(resourceName),
+			"Username":     terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	resource, err := cmd.metadataActor.GetLabels(resourceType, resourceName)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(resource.Metadata.Labels) == 0 {
+		cmd.ui.Say(T("No labels found"))
+		return nil
+	}
+
+	keys := []string{}
+	for key := range resource.Metadata.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	table := cmd.ui.Table([]string{T("key"), T("value")})
+	for _, key := range keys {
+		table.Add(key, resource.Metadata.Labels[key])
+	}
+	return table.Print()
+}
+
+// metadataRequirements targets the org for spaces and the space for apps and
+// service instances, since those are looked up by name within the target.
+func metadataRequirements(requirementsFactory requirements.Factory, commandName string, resourceType string) []requirements.Requirement {
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewMinAPIVersionRequirement(commandName, cf.MetadataMinimumAPIVersion),
+	}
+
+	switch resourceType {
+	case "space":
+		reqs = append(reqs, requirementsFactory.NewTargetedOrgRequirement())
+	case "app", "service-instance":
+		reqs = append(reqs, requirementsFactory.NewTargetedSpaceRequirement())
+	}
+
+	return reqs
+}
@@ -0,0 +1,169 @@
+package metadata_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	_ "code.cloudfoundry.org/cli/cf/commands/metadata"
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("label commands", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		metadataActor       *actorsfakes.FakeMetadataActor
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	runCommand := func(name string, args ...string) bool {
+		updateCommandDependency := func(pluginCall bool) {
+			deps.UI = ui
+			deps.Config = config
+			deps.MetadataActor = metadataActor
+			commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand(name).SetDependency(deps, pluginCall))
+		}
+		return testcmd.RunCLICommand(name, args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		metadataActor = new(actorsfakes.FakeMetadataActor)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedOrgRequirementReturns(new(requirementsfakes.FakeTargetedOrgRequirement))
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+	})
+
+	Describe("labels", func() {
+		It("fails with usage when not given a type and a name", func() {
+			Expect(runCommand("labels", "app")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires RESOURCE_TYPE and RESOURCE_NAME as arguments"},
+			))
+		})
+
+		It("requires an API with metadata", func() {
+			runCommand("labels", "org", "my-org")
+			command, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+			Expect(command).To(Equal("labels"))
+			Expect(version).To(Equal(cf.MetadataMinimumAPIVersion))
+			Expect(requirementsFactory.NewTargetedSpaceRequirementCallCount()).To(Equal(0))
+			Expect(requirementsFactory.NewTargetedOrgRequirementCallCount()).To(Equal(0))
+		})
+
+		It("requires a targeted space for apps", func() {
+			runCommand("labels", "app", "my-app")
+			Expect(requirementsFactory.NewTargetedSpaceRequirementCallCount()).To(Equal(1))
+		})
+
+		It("requires a targeted org for spaces", func() {
+			runCommand("labels", "space", "my-space")
+			Expect(requirementsFactory.NewTargetedOrgRequirementCallCount()).To(Equal(1))
+		})
+
+		It("lists the labels sorted by key", func() {
+			metadataActor.GetLabelsReturns(models.LabeledResource{
+				GUID: "app-guid",
+				Name: "my-app",
+				Metadata: models.Metadata{
+					Labels: map[string]string{"tier": "web", "env": "prod"},
+				},
+			}, nil)
+
+			Expect(runCommand("labels", "app", "my-app")).To(BeTrue())
+
+			resourceType, name := metadataActor.GetLabelsArgsForCall(0)
+			Expect(resourceType).To(Equal("app"))
+			Expect(name).To(Equal("my-app"))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Getting labels for app my-app as my-user..."},
+				[]string{"OK"},
+				[]string{"key", "value"},
+				[]string{"env", "prod"},
+				[]string{"tier", "web"},
+			))
+		})
+
+		It("says when the resource has no labels", func() {
+			metadataActor.GetLabelsReturns(models.LabeledResource{GUID: "app-guid"}, nil)
+
+			Expect(runCommand("labels", "app", "my-app")).To(BeTrue())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"No labels found"}))
+		})
+
+		It("fails when the resource cannot be found", func() {
+			metadataActor.GetLabelsReturns(models.LabeledResource{}, errors.New("app my-app not found"))
+
+			Expect(runCommand("labels", "app", "my-app")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"app my-app not found"},
+			))
+		})
+	})
+
+	Describe("set-label", func() {
+		It("fails with usage when not given a label", func() {
+			Expect(runCommand("set-label", "app", "my-app")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires RESOURCE_TYPE, RESOURCE_NAME and KEY=VALUE as arguments"},
+			))
+		})
+
+		It("sets every label", func() {
+			Expect(runCommand("set-label", "org", "my-org", "env=prod", "owner=a=b")).To(BeTrue())
+
+			resourceType, name, labels := metadataActor.UpdateLabelsArgsForCall(0)
+			Expect(resourceType).To(Equal("org"))
+			Expect(name).To(Equal("my-org"))
+			Expect(labels).To(HaveLen(2))
+			Expect(*labels["env"]).To(Equal("prod"))
+			Expect(*labels["owner"]).To(Equal("a=b"))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Setting labels for org my-org as my-user..."},
+				[]string{"OK"},
+			))
+		})
+
+		It("fails when a label has no value", func() {
+			Expect(runCommand("set-label", "org", "my-org", "env")).To(BeFalse())
+			Expect(metadataActor.UpdateLabelsCallCount()).To(Equal(0))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Label env must be in the form KEY=VALUE"},
+			))
+		})
+	})
+
+	Describe("unset-label", func() {
+		It("removes every key", func() {
+			Expect(runCommand("unset-label", "space", "my-space", "env", "tier")).To(BeTrue())
+
+			resourceType, name, labels := metadataActor.UpdateLabelsArgsForCall(0)
+			Expect(resourceType).To(Equal("space"))
+			Expect(name).To(Equal("my-space"))
+			Expect(labels).To(Equal(map[string]*string{"env": nil, "tier": nil}))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Removing labels for space my-space as my-user..."},
+				[]string{"OK"},
+			))
+		})
+	})
+})
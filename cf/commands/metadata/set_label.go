@@ -0,0 +1,87 @@
+package metadata
+
+import (
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type SetLabel struct {
+	ui            terminal.UI
+	config        coreconfig.Reader
+	metadataActor actors.MetadataActor
+}
+
+func init() {
+	commandregistry.Register(&SetLabel{})
+}
+
+func (cmd *SetLabel) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "set-label",
+		Description: T("Set a label (key-value pairs) for a resource"),
+		Usage: []string{
+			T("CF_NAME set-label RESOURCE_TYPE RESOURCE_NAME KEY=VALUE..."),
+			"\n\n",
+			T("RESOURCE_TYPES:\n   {{.Types}}", map[string]interface{}{"Types": strings.Join(actors.LabeledResourceTypes(), ", ")}),
+		},
+		Examples: []string{
+			"CF_NAME set-label app dora env=production",
+			"CF_NAME set-label org business pci=true public-facing=false",
+		},
+	}
+}
+
+func (cmd *SetLabel) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) < 3 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires RESOURCE_TYPE, RESOURCE_NAME and KEY=VALUE as arguments\n\n") + commandregistry.Commands.CommandUsage("set-label"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 3)
+	}
+
+	return metadataRequirements(requirementsFactory, "set-label", fc.Args()[0]), nil
+}
+
+func (cmd *SetLabel) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.metadataActor = deps.MetadataActor
+	return cmd
+}
+
+func (cmd *SetLabel) Execute(c flags.FlagContext) error {
+	resourceType, resourceName := c.Args()[0], c.Args()[1]
+
+	labels := map[string]*string{}
+	for _, pair := range c.Args()[2:] {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return errors.New(T("Label {{.Label}} must be in the form KEY=VALUE", map[string]interface{}{"Label": pair}))
+		}
+		value := parts[1]
+		labels[parts[0]] = &value
+	}
+
+	cmd.ui.Say(T("Setting labels for {{.ResourceType}} {{.ResourceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"ResourceType": resourceType,
+			"ResourceName": terminal.EntityNameColor(resourceName),
+			"Username":     terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	err := cmd.metadataActor.UpdateLabels(resourceType, resourceName, labels)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return nil
+}
@@ -0,0 +1,81 @@
+package metadata
+
+import (
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type UnsetLabel struct {
+	ui            terminal.UI
+	config        coreconfig.Reader
+	metadataActor actors.MetadataActor
+}
+
+func init() {
+	commandregistry.Register(&UnsetLabel{})
+}
+
+func (cmd *UnsetLabel) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "unset-label",
+		Description: T("Unset a label (key-value pairs) for a resource"),
+		Usage: []string{
+			T("CF_NAME unset-label RESOURCE_TYPE RESOURCE_NAME KEY..."),
+			"\n\n",
+			T("RESOURCE_TYPES:\n   {{.Types}}", map[string]interface{}{"Types": strings.Join(actors.LabeledResourceTypes(), ", ")}),
+		},
+		Examples: []string{
+			"CF_NAME unset-label app dora env",
+			"CF_NAME unset-label org business pci public-facing",
+		},
+	}
+}
+
+func (cmd *UnsetLabel) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) < 3 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires RESOURCE_TYPE, RESOURCE_NAME and KEY as arguments\n\n") + commandregistry.Commands.CommandUsage("unset-label"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 3)
+	}
+
+	return metadataRequirements(requirementsFactory, "unset-label", fc.Args()[0]), nil
+}
+
+func (cmd *UnsetLabel) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.metadataActor = deps.MetadataActor
+	return cmd
+}
+
+func (cmd *UnsetLabel) Execute(c flags.FlagContext) error {
+	resourceType, resourceName := c.Args()[0], c.Args()[1]
+
+	labels := map[string]*string{}
+	for _, key := range c.Args()[2:] {
+		labels[key] = nil
+	}
+
+	cmd.ui.Say(T("Removing labels for {{.ResourceType}} {{.ResourceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"ResourceType": resourceType,
+			"ResourceName": terminal.EntityNameColor(resourceName),
+			"Username":     terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	err := cmd.metadataActor.UpdateLabels(resourceType, resourceName, labels)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return nil
+}
@@ -0,0 +1,169 @@
+package networkpolicy
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/api/applications"
+	"code.cloudfoundry.org/cli/cf/api/networkpolicies"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+const (
+	defaultPolicyPort     = "8080"
+	defaultPolicyProtocol = "tcp"
+)
+
+type AddNetworkPolicy struct {
+	ui                terminal.UI
+	config            coreconfig.Reader
+	appRepo           applications.Repository
+	networkPolicyRepo networkpolicies.Repository
+	appReq            requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&AddNetworkPolicy{})
+}
+
+func (cmd *AddNetworkPolicy) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["port"] = &flags.StringFlag{Name: "port", Usage: T("Port or range of ports the destination app listens on, such as 8080 or 8080-8090 (Default: 8080)")}
+	fs["protocol"] = &flags.StringFlag{Name: "protocol", Usage: T("Protocol to connect with: tcp or udp (Default: tcp)")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "add-network-policy",
+		Description: T("Allow direct network traffic from one app to another"),
+		Usage: []string{
+			T("CF_NAME add-network-policy SOURCE_APP DESTINATION_APP [--port PORT] [--protocol (tcp | udp)]"),
+		},
+		Examples: []string{
+			"CF_NAME add-network-policy frontend backend",
+			"CF_NAME add-network-policy frontend backend --port 8081 --protocol udp",
+			"CF_NAME add-network-policy frontend backend --port 9000-9010",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *AddNetworkPolicy) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires SOURCE_APP and DESTINATION_APP as arguments\n\n") + commandregistry.Commands.CommandUsage("add-network-policy"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 2)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *AddNetworkPolicy) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
+	cmd.networkPolicyRepo = deps.RepoLocator.GetNetworkPolicyRepository()
+	return cmd
+}
+
+func (cmd *AddNetworkPolicy) Execute(c flags.FlagContext) error {
+	sourceApp := cmd.appReq.GetApplication()
+
+	policy, err := policyFromFlags(c, defaultPolicyPort, defaultPolicyProtocol)
+	if err != nil {
+		return err
+	}
+
+	destinationApp, err := cmd.appRepo.Read(c.Args()[1])
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(T("Adding network policy from app {{.SourceApp}} to app {{.DestinationApp}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"SourceApp":      terminal.EntityNameColor(sourceApp.Name),
+			"DestinationApp": terminal.EntityNameColor(destinationApp.Name),
+			"OrgName":        terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":      terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":       terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	policy.SourceGUID = sourceApp.GUID
+	policy.DestinationGUID = destinationApp.GUID
+	err = cmd.networkPolicyRepo.CreatePolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
+
+// policyFromFlags builds a policy, without its apps, from the --port and
+// --protocol flags, falling back to defaultPort and defaultProtocol when the
+// flags are not given.
+func policyFromFlags(c flags.FlagContext, defaultPort string, defaultProtocol string) (models.NetworkPolicy, error) {
+	ports := defaultPort
+	if c.IsSet("port") {
+		ports = c.String("port")
+	}
+
+	protocol := defaultProtocol
+	if c.IsSet("protocol") {
+		protocol = strings.ToLower(c.String("protocol"))
+	}
+	if protocol != "tcp" && protocol != "udp" {
+		return models.NetworkPolicy{}, errors.New(T("Incorrect Usage: --protocol must be tcp or udp, not {{.Protocol}}",
+			map[string]interface{}{"Protocol": protocol}))
+	}
+
+	startPort, endPort, err := parsePortRange(ports)
+	if err != nil {
+		return models.NetworkPolicy{}, err
+	}
+
+	return models.NetworkPolicy{
+		Protocol:  protocol,
+		StartPort: startPort,
+		EndPort:   endPort,
+	}, nil
+}
+
+// parsePortRange parses a port, such as "8080", or a range of ports, such as
+// "8080-8090".
+func parsePortRange(ports string) (int, int, error) {
+	invalid := errors.New(T("Incorrect Usage: --port must be a port between 1 and 65535 or a range of them such as 8080-8090, not {{.Port}}",
+		map[string]interface{}{"Port": ports}))
+
+	bounds := strings.SplitN(ports, "-", 2)
+	start, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+	if err != nil {
+		return 0, 0, invalid
+	}
+
+	end := start
+	if len(bounds) == 2 {
+		end, err = strconv.Atoi(strings.TrimSpace(bounds[1]))
+		if err != nil {
+			return 0, 0, invalid
+		}
+	}
+
+	if start < 1 || end > 65535 || start > end {
+		return 0, 0, invalid
+	}
+	return start, end, nil
+}
@@ -0,0 +1,129 @@
+package networkpolicy_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
+	"code.cloudfoundry.org/cli/cf/api/networkpolicies/networkpoliciesfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("add-network-policy command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		appRepo             *applicationsfakes.FakeRepository
+		networkPolicyRepo   *networkpoliciesfakes.FakeRepository
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = api.RepositoryLocator{}.
+			SetApplicationRepository(appRepo).
+			SetNetworkPolicyRepository(networkPolicyRepo)
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("add-network-policy").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("add-network-policy", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		appRepo = new(applicationsfakes.FakeRepository)
+		networkPolicyRepo = new(networkpoliciesfakes.FakeRepository)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		sourceApp := models.Application{}
+		sourceApp.Name = "frontend"
+		sourceApp.GUID = "frontend-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(sourceApp)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+
+		destinationApp := models.Application{}
+		destinationApp.Name = "backend"
+		destinationApp.GUID = "backend-guid"
+		appRepo.ReadReturns(destinationApp, nil)
+	})
+
+	It("fails with usage when not given two apps", func() {
+		runCommand("frontend")
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Requires SOURCE_APP and DESTINATION_APP as arguments"},
+		))
+	})
+
+	It("allows tcp traffic on port 8080 by default", func() {
+		Expect(runCommand("frontend", "backend")).To(BeTrue())
+
+		Expect(appRepo.ReadArgsForCall(0)).To(Equal("backend"))
+		Expect(networkPolicyRepo.CreatePolicyCallCount()).To(Equal(1))
+		Expect(networkPolicyRepo.CreatePolicyArgsForCall(0)).To(Equal(models.NetworkPolicy{
+			SourceGUID:      "frontend-guid",
+			DestinationGUID: "backend-guid",
+			Protocol:        "tcp",
+			StartPort:       8080,
+			EndPort:         8080,
+		}))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Adding network policy from app", "frontend", "backend", "my-org", "my-space", "my-user"},
+			[]string{"OK"},
+		))
+	})
+
+	It("uses the given port range and protocol", func() {
+		Expect(runCommand("frontend", "backend", "--port", "9000-9010", "--protocol", "UDP")).To(BeTrue())
+
+		policy := networkPolicyRepo.CreatePolicyArgsForCall(0)
+		Expect(policy.Protocol).To(Equal("udp"))
+		Expect(policy.StartPort).To(Equal(9000))
+		Expect(policy.EndPort).To(Equal(9010))
+	})
+
+	DescribeTable("rejects invalid flags without creating a policy",
+		func(flag string, value string, message string) {
+			Expect(runCommand("frontend", "backend", flag, value)).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"FAILED"}, []string{message}))
+			Expect(networkPolicyRepo.CreatePolicyCallCount()).To(BeZero())
+		},
+		Entry("an unknown protocol", "--protocol", "icmp", "--protocol must be tcp or udp, not icmp"),
+		Entry("a port that is not a number", "--port", "http", "--port must be a port between 1 and 65535"),
+		Entry("a port out of range", "--port", "70000", "--port must be a port between 1 and 65535"),
+		Entry("a backwards range", "--port", "9010-9000", "--port must be a port between 1 and 65535"),
+	)
+
+	It("fails when the destination app cannot be found", func() {
+		appRepo.ReadReturns(models.Application{}, errors.New("App backend not found"))
+
+		Expect(runCommand("frontend", "backend")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"FAILED"}, []string{"App backend not found"}))
+		Expect(networkPolicyRepo.CreatePolicyCallCount()).To(BeZero())
+	})
+
+	It("fails when the policy cannot be created", func() {
+		networkPolicyRepo.CreatePolicyReturns(errors.New("create failed"))
+
+		Expect(runCommand("frontend", "backend")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"FAILED"}, []string{"create failed"}))
+	})
+})
@@ -0,0 +1,135 @@
+package networkpolicy
+
+import (
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/networkpolicies"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type NetworkPolicies struct {
+	ui                terminal.UI
+	config            coreconfig.Reader
+	appSummaryRepo    api.AppSummaryRepository
+	networkPolicyRepo networkpolicies.Repository
+}
+
+func init() {
+	commandregistry.Register(&NetworkPolicies{})
+}
+
+func (cmd *NetworkPolicies) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["source"] = &flags.StringFlag{Name: "source", Usage: T("Only list the policies of this source app")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "network-policies",
+		Description: T("List the network policies of the apps in the target space"),
+		Usage: []string{
+			"CF_NAME network-policies [--source SOURCE_APP]",
+		},
+		Flags:            fs,
+		StructuredOutput: true,
+	}
+}
+
+func (cmd *NetworkPolicies) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	usageReq := requirements.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+		T("No argument required"),
+		func() bool {
+			return len(fc.Args()) != 0
+		},
+	)
+
+	reqs := []requirements.Requirement{
+		usageReq,
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+	}
+
+	return reqs, nil
+}
+
+func (cmd *NetworkPolicies) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.appSummaryRepo = deps.RepoLocator.GetAppSummaryRepository()
+	cmd.networkPolicyRepo = deps.RepoLocator.GetNetworkPolicyRepository()
+	return cmd
+}
+
+func (cmd *NetworkPolicies) Execute(c flags.FlagContext) error {
+	cmd.ui.Say(T("Listing network policies in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	apps, err := cmd.appSummaryRepo.GetSummariesInCurrentSpace()
+	if err != nil {
+		return err
+	}
+
+	appNames := map[string]string{}
+	sourceGUIDs := []string{}
+	for _, app := range apps {
+		appNames[app.GUID] = app.Name
+		if !c.IsSet("source") || app.Name == c.String("source") {
+			sourceGUIDs = append(sourceGUIDs, app.GUID)
+		}
+	}
+
+	if c.IsSet("source") && len(sourceGUIDs) == 0 {
+		return errors.NewModelNotFoundError("App", c.String("source"))
+	}
+
+	policies := []models.NetworkPolicy{}
+	if len(sourceGUIDs) > 0 {
+		policies, err = cmd.networkPolicyRepo.ListPolicies(sourceGUIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The policy server also returns the policies that have one of the apps as
+	// their destination; only those whose source is listed are of interest.
+	isSource := map[string]bool{}
+	for _, guid := range sourceGUIDs {
+		isSource[guid] = true
+	}
+
+	sourcePolicies := []models.NetworkPolicy{}
+	for _, policy := range policies {
+		if isSource[policy.SourceGUID] {
+			sourcePolicies = append(sourcePolicies, policy)
+		}
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(sourcePolicies) == 0 {
+		cmd.ui.Say(T("No network policies found"))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{T("source"), T("destination"), T("protocol"), T("ports")})
+	table.Table.SetFieldKeys("source", "destination", "protocol", "ports")
+	for _, policy := range sourcePolicies {
+		destination, found := appNames[policy.DestinationGUID]
+		if !found {
+			destination = policy.DestinationGUID
+		}
+
+		table.Add(appNames[policy.SourceGUID], destination, policy.Protocol, policy.Ports())
+	}
+
+	return table.Print()
+}
@@ -0,0 +1,146 @@
+package networkpolicy_test
+
+import (
+	"errors"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/networkpolicies/networkpoliciesfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("network-policies command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		commandUI           terminal.UI
+		requirementsFactory *requirementsfakes.FakeFactory
+		appSummaryRepo      *apifakes.FakeAppSummaryRepository
+		networkPolicyRepo   *networkpoliciesfakes.FakeRepository
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = commandUI
+		deps.Config = config
+		deps.RepoLocator = api.RepositoryLocator{}.
+			SetAppSummaryRepository(appSummaryRepo).
+			SetNetworkPolicyRepository(networkPolicyRepo)
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("network-policies").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("network-policies", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		commandUI = ui
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		appSummaryRepo = new(apifakes.FakeAppSummaryRepository)
+		networkPolicyRepo = new(networkpoliciesfakes.FakeRepository)
+		config = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+
+		frontend := models.Application{}
+		frontend.Name = "frontend"
+		frontend.GUID = "frontend-guid"
+		backend := models.Application{}
+		backend.Name = "backend"
+		backend.GUID = "backend-guid"
+		appSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.Application{frontend, backend}, nil)
+
+		networkPolicyRepo.ListPoliciesReturns([]models.NetworkPolicy{
+			{SourceGUID: "frontend-guid", DestinationGUID: "backend-guid", Protocol: "tcp", StartPort: 8080, EndPort: 8080},
+			{SourceGUID: "backend-guid", DestinationGUID: "other-space-app-guid", Protocol: "udp", StartPort: 9000, EndPort: 9010},
+			{SourceGUID: "other-space-app-guid", DestinationGUID: "frontend-guid", Protocol: "tcp", StartPort: 8080, EndPort: 8080},
+		}, nil)
+	})
+
+	It("fails with usage when given an argument", func() {
+		updateCommandDependency(false)
+		cmd := commandregistry.Commands.FindCommand("network-policies")
+		flagContext := flags.NewFlagContext(cmd.MetaData().Flags)
+		flagContext.Parse("frontend")
+
+		reqs, err := cmd.Requirements(requirementsFactory, flagContext)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = testcmd.RunRequirements(reqs)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Incorrect Usage"))
+		Expect(err.Error()).To(ContainSubstring("No argument required"))
+	})
+
+	It("lists the policies whose source is in the space", func() {
+		Expect(runCommand()).To(BeTrue())
+
+		Expect(networkPolicyRepo.ListPoliciesArgsForCall(0)).To(Equal([]string{"frontend-guid", "backend-guid"}))
+		Expect(ui.Outputs()).To(BeInDisplayOrder(
+			[]string{"Listing network policies in org", "my-org", "my-space", "my-user"},
+			[]string{"OK"},
+			[]string{"source", "destination", "protocol", "ports"},
+			[]string{"frontend", "backend", "tcp", "8080"},
+			[]string{"backend", "other-space-app-guid", "udp", "9000-9010"},
+		))
+		Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"other-space-app-guid", "frontend"}))
+	})
+
+	It("only lists the policies of the --source app", func() {
+		Expect(runCommand("--source", "backend")).To(BeTrue())
+
+		Expect(networkPolicyRepo.ListPoliciesArgsForCall(0)).To(Equal([]string{"backend-guid"}))
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"backend", "other-space-app-guid", "udp", "9000-9010"}))
+		Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"frontend", "backend", "tcp"}))
+	})
+
+	It("fails when the --source app is not in the space", func() {
+		Expect(runCommand("--source", "missing")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"FAILED"}, []string{"missing", "not found"}))
+		Expect(networkPolicyRepo.ListPoliciesCallCount()).To(BeZero())
+	})
+
+	It("says when there are no policies", func() {
+		networkPolicyRepo.ListPoliciesReturns([]models.NetworkPolicy{}, nil)
+
+		Expect(runCommand()).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"No network policies found"}))
+	})
+
+	It("fails when the policies cannot be listed", func() {
+		networkPolicyRepo.ListPoliciesReturns(nil, errors.New("list failed"))
+
+		Expect(runCommand()).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"FAILED"}, []string{"list failed"}))
+	})
+
+	It("prints the policies as JSON", func() {
+		formattedUI := terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+		commandUI = formattedUI
+
+		Expect(runCommand("--source", "frontend")).To(BeTrue())
+		Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+		Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`[{
+			"source": "frontend",
+			"destination": "backend",
+			"protocol": "tcp",
+			"ports": "8080"
+		}]`))
+	})
+})
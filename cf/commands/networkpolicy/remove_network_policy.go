@@ -0,0 +1,103 @@
+package networkpolicy
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf/api/applications"
+	"code.cloudfoundry.org/cli/cf/api/networkpolicies"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type RemoveNetworkPolicy struct {
+	ui                terminal.UI
+	config            coreconfig.Reader
+	appRepo           applications.Repository
+	networkPolicyRepo networkpolicies.Repository
+	appReq            requirements.ApplicationRequirement
+}
+
+func init() {
+	commandregistry.Register(&RemoveNetworkPolicy{})
+}
+
+func (cmd *RemoveNetworkPolicy) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["port"] = &flags.StringFlag{Name: "port", Usage: T("Port or range of ports of the policy, such as 8080 or 8080-8090 (Default: 8080)")}
+	fs["protocol"] = &flags.StringFlag{Name: "protocol", Usage: T("Protocol of the policy: tcp or udp (Default: tcp)")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "remove-network-policy",
+		Description: T("Stop allowing direct network traffic from one app to another"),
+		Usage: []string{
+			T("CF_NAME remove-network-policy SOURCE_APP DESTINATION_APP [--port PORT] [--protocol (tcp | udp)]"),
+		},
+		Examples: []string{
+			"CF_NAME remove-network-policy frontend backend",
+			"CF_NAME remove-network-policy frontend backend --port 9000-9010 --protocol udp",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *RemoveNetworkPolicy) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires SOURCE_APP and DESTINATION_APP as arguments\n\n") + commandregistry.Commands.CommandUsage("remove-network-policy"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 2)
+	}
+
+	cmd.appReq = requirementsFactory.NewApplicationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+		cmd.appReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *RemoveNetworkPolicy) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.appRepo = deps.RepoLocator.GetApplicationRepository()
+	cmd.networkPolicyRepo = deps.RepoLocator.GetNetworkPolicyRepository()
+	return cmd
+}
+
+func (cmd *RemoveNetworkPolicy) Execute(c flags.FlagContext) error {
+	sourceApp := cmd.appReq.GetApplication()
+
+	policy, err := policyFromFlags(c, defaultPolicyPort, defaultPolicyProtocol)
+	if err != nil {
+		return err
+	}
+
+	destinationApp, err := cmd.appRepo.Read(c.Args()[1])
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(T("Removing network policy from app {{.SourceApp}} to app {{.DestinationApp}} in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"SourceApp":      terminal.EntityNameColor(sourceApp.Name),
+			"DestinationApp": terminal.EntityNameColor(destinationApp.Name),
+			"OrgName":        terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName":      terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":       terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	policy.SourceGUID = sourceApp.GUID
+	policy.DestinationGUID = destinationApp.GUID
+	err = cmd.networkPolicyRepo.DeletePolicy(policy)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
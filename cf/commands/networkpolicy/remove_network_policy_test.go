@@ -0,0 +1,106 @@
+package networkpolicy_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
+	"code.cloudfoundry.org/cli/cf/api/networkpolicies/networkpoliciesfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("remove-network-policy command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		appRepo             *applicationsfakes.FakeRepository
+		networkPolicyRepo   *networkpoliciesfakes.FakeRepository
+		config              coreconfig.Repository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.RepoLocator = api.RepositoryLocator{}.
+			SetApplicationRepository(appRepo).
+			SetNetworkPolicyRepository(networkPolicyRepo)
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("remove-network-policy").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("remove-network-policy", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		appRepo = new(applicationsfakes.FakeRepository)
+		networkPolicyRepo = new(networkpoliciesfakes.FakeRepository)
+		config = testconfig.NewRepositoryWithDefaults()
+
+		sourceApp := models.Application{}
+		sourceApp.Name = "frontend"
+		sourceApp.GUID = "frontend-guid"
+		applicationReq := new(requirementsfakes.FakeApplicationRequirement)
+		applicationReq.GetApplicationReturns(sourceApp)
+		requirementsFactory.NewApplicationRequirementReturns(applicationReq)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+
+		destinationApp := models.Application{}
+		destinationApp.Name = "backend"
+		destinationApp.GUID = "backend-guid"
+		appRepo.ReadReturns(destinationApp, nil)
+	})
+
+	It("fails with usage when not given two apps", func() {
+		runCommand("frontend")
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Incorrect Usage", "Requires SOURCE_APP and DESTINATION_APP as arguments"},
+		))
+	})
+
+	It("removes the policy with the given port and protocol", func() {
+		Expect(runCommand("frontend", "backend", "--port", "9000", "--protocol", "udp")).To(BeTrue())
+
+		Expect(networkPolicyRepo.DeletePolicyCallCount()).To(Equal(1))
+		Expect(networkPolicyRepo.DeletePolicyArgsForCall(0)).To(Equal(models.NetworkPolicy{
+			SourceGUID:      "frontend-guid",
+			DestinationGUID: "backend-guid",
+			Protocol:        "udp",
+			StartPort:       9000,
+			EndPort:         9000,
+		}))
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Removing network policy from app", "frontend", "backend", "my-org", "my-space", "my-user"},
+			[]string{"OK"},
+		))
+	})
+
+	It("removes the tcp policy on port 8080 by default", func() {
+		Expect(runCommand("frontend", "backend")).To(BeTrue())
+
+		policy := networkPolicyRepo.DeletePolicyArgsForCall(0)
+		Expect(policy.Protocol).To(Equal("tcp"))
+		Expect(policy.Ports()).To(Equal("8080"))
+	})
+
+	It("fails when the policy cannot be removed", func() {
+		networkPolicyRepo.DeletePolicyReturns(errors.New("delete failed"))
+
+		Expect(runCommand("frontend", "backend")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"FAILED"}, []string{"delete failed"}))
+	})
+})
@@ -29,13 +29,18 @@ func init() {
 }
 
 func (cmd *ListOrgs) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["max-results"] = &flags.IntFlag{Name: "max-results", Usage: T("Stop after listing this many orgs")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "orgs",
 		ShortName:   "o",
 		Description: T("List all orgs"),
 		Usage: []string{
-			"CF_NAME orgs",
+			"CF_NAME orgs [--max-results NUM]",
 		},
+		Flags:            fs,
+		StructuredOutput: true,
 	}
 }
 
@@ -68,10 +73,20 @@ func (cmd ListOrgs) Execute(fc flags.FlagContext) error {
 	cmd.ui.Say(T("Getting orgs as {{.Username}}...\n",
 		map[string]interface{}{"Username": terminal.EntityNameColor(cmd.config.Username())}))
 
+	limit := orgLimit
+	if fc.IsSet("max-results") {
+		limit = fc.Int("max-results")
+		if limit < 1 {
+			return errors.New(T("Invalid value {{.Value}} for --max-results; it must be at least 1",
+				map[string]interface{}{"Value": limit}))
+		}
+	}
+
 	noOrgs := true
 	table := cmd.ui.Table([]string{T("name")})
+	table.Table.SetFieldKeys("name")
 
-	orgs, err := cmd.orgRepo.ListOrgs(orgLimit)
+	orgs, err := cmd.orgRepo.ListOrgs(limit)
 	if err != nil {
 		return err
 	}
@@ -134,6 +134,21 @@ var _ = Describe("orgs command", func() {
 			Expect(orgRepo.ListOrgsArgsForCall(0)).To(Equal(0))
 		})
 
+		It("asks for at most --max-results organizations", func() {
+			runCommand("--max-results", "2")
+			Expect(orgRepo.ListOrgsArgsForCall(0)).To(Equal(2))
+		})
+
+		It("fails when --max-results is not positive", func() {
+			runCommand("--max-results", "-1")
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Invalid value -1 for --max-results; it must be at least 1"},
+			))
+			Expect(orgRepo.ListOrgsCallCount()).To(BeZero())
+		})
+
 		It("lists orgs", func() {
 			runCommand()
 
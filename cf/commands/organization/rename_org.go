@@ -3,6 +3,7 @@ package organization
 import (
 	"fmt"
 
+	"code.cloudfoundry.org/cli/cf/actors"
 	"code.cloudfoundry.org/cli/cf/api/organizations"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -17,6 +18,7 @@ type RenameOrg struct {
 	config  coreconfig.ReadWriter
 	orgRepo organizations.OrganizationRepository
 	orgReq  requirements.OrganizationRequirement
+	checker actors.RenameChecker
 }
 
 func init() {
@@ -24,12 +26,16 @@ func init() {
 }
 
 func (cmd *RenameOrg) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["path"] = &flags.StringFlag{Name: "path", ShortName: "p", Usage: T("Path to the app directory or manifest to check for references to the old name (Default: current directory)")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "rename-org",
 		Description: T("Rename an org"),
 		Usage: []string{
-			T("CF_NAME rename-org ORG NEW_ORG"),
+			T("CF_NAME rename-org ORG NEW_ORG [-p APP_PATH]"),
 		},
+		Flags: fs,
 	}
 }
 
@@ -53,6 +59,7 @@ func (cmd *RenameOrg) SetDependency(deps commandregistry.Dependency, pluginCall
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.orgRepo = deps.RepoLocator.GetOrganizationRepository()
+	cmd.checker = deps.RenameChecker
 	return cmd
 }
 
@@ -72,9 +79,23 @@ func (cmd *RenameOrg) Execute(c flags.FlagContext) error {
 	}
 	cmd.ui.Ok()
 
+	references := cmd.checker.References(org.Name, c.String("path"))
+	if len(references) > 0 {
+		cmd.ui.Warn(T("These still refer to org {{.OrgName}} and have to be changed by hand:",
+			map[string]interface{}{"OrgName": org.Name}))
+		for _, reference := range references {
+			cmd.ui.Warn("   %s", reference)
+		}
+	}
+
 	if org.GUID == cmd.config.OrganizationFields().GUID {
 		org.Name = newName
 		cmd.config.SetOrganizationFields(org.OrganizationFields)
 	}
+
+	for _, context := range cmd.config.RenameContextOrganization(org.GUID, newName) {
+		cmd.ui.Say(T("Updated org name in saved context {{.Context}}",
+			map[string]interface{}{"Context": terminal.EntityNameColor(context)}))
+	}
 	return nil
 }
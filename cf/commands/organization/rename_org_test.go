@@ -1,6 +1,9 @@
 package organization_test
 
 import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
 	"code.cloudfoundry.org/cli/cf/api/organizations/organizationsfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -22,6 +25,7 @@ var _ = Describe("rename-org command", func() {
 		orgRepo             *organizationsfakes.FakeOrganizationRepository
 		ui                  *testterm.FakeUI
 		configRepo          coreconfig.Repository
+		renameChecker       *actorsfakes.FakeRenameChecker
 		deps                commandregistry.Dependency
 	)
 
@@ -29,6 +33,7 @@ var _ = Describe("rename-org command", func() {
 		deps.UI = ui
 		deps.RepoLocator = deps.RepoLocator.SetOrganizationRepository(orgRepo)
 		deps.Config = configRepo
+		deps.RenameChecker = renameChecker
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("rename-org").SetDependency(deps, pluginCall))
 	}
 
@@ -37,6 +42,7 @@ var _ = Describe("rename-org command", func() {
 		orgRepo = new(organizationsfakes.FakeOrganizationRepository)
 		ui = new(testterm.FakeUI)
 		configRepo = testconfig.NewRepositoryWithDefaults()
+		renameChecker = new(actorsfakes.FakeRenameChecker)
 	})
 
 	var callRenameOrg = func(args []string) bool {
@@ -100,5 +106,52 @@ var _ = Describe("rename-org command", func() {
 				Expect(configRepo.OrganizationFields().Name).To(Equal("the-new-org-name"))
 			})
 		})
+
+		It("warns about references to the old name after renaming", func() {
+			renameChecker.ReferencesReturns([]string{"manifest manifest.yml mentions the-old-org-name on line 3"})
+			callRenameOrg([]string{"the-old-org-name", "the-new-org-name"})
+
+			name, appPath := renameChecker.ReferencesArgsForCall(0)
+			Expect(name).To(Equal("the-old-org-name"))
+			Expect(appPath).To(BeEmpty())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"OK"},
+				[]string{"These still refer to org the-old-org-name"},
+				[]string{"manifest manifest.yml mentions the-old-org-name on line 3"},
+			))
+		})
+
+		It("looks for references in the manifest of the app at --path", func() {
+			callRenameOrg([]string{"--path", "../my-app", "the-old-org-name", "the-new-org-name"})
+
+			_, appPath := renameChecker.ReferencesArgsForCall(0)
+			Expect(appPath).To(Equal("../my-app"))
+		})
+
+		It("does not look for references when the org cannot be renamed", func() {
+			orgRepo.RenameReturns(errors.New("rename failed"))
+			callRenameOrg([]string{"the-old-org-name", "the-new-org-name"})
+
+			Expect(renameChecker.ReferencesCallCount()).To(BeZero())
+		})
+
+		It("renames the org in the saved contexts that target it", func() {
+			configRepo.SetOrganizationFields(models.OrganizationFields{
+				GUID: "the-old-org-guid",
+				Name: "the-old-org-name",
+			})
+			configRepo.SaveContext("prod")
+			configRepo.SetOrganizationFields(models.OrganizationFields{GUID: "other-org-guid", Name: "other-org"})
+
+			callRenameOrg([]string{"the-old-org-name", "the-new-org-name"})
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"OK"},
+				[]string{"Updated org name in saved context", "prod"},
+			))
+			Expect(configRepo.OrganizationFields().Name).To(Equal("other-org"))
+
+			Expect(configRepo.UseContext("prod")).To(BeTrue())
+			Expect(configRepo.OrganizationFields().Name).To(Equal("the-new-org-name"))
+		})
 	})
 })
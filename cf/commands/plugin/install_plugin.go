@@ -30,7 +30,7 @@ type PluginInstall struct {
 	config       coreconfig.Reader
 	pluginConfig pluginconfig.PluginConfiguration
 	pluginRepo   pluginrepo.PluginRepo
-	checksum     utils.Sha1Checksum
+	checksum     utils.Sha256Checksum
 	rpcService   *pluginRPCService.CliRpcService
 }
 
@@ -42,19 +42,24 @@ func (cmd *PluginInstall) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["r"] = &flags.StringFlag{ShortName: "r", Usage: T("Name of a registered repository where the specified plugin is located")}
 	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force install of plugin without confirmation")}
+	fs["version"] = &flags.StringFlag{Name: "version", Usage: T("Version of the plugin to install from the repository, instead of the newest one")}
+	fs["skip-checksum"] = &flags.BoolFlag{Name: "skip-checksum", Usage: T("Install the plugin from the repository even if its SHA256 checksum cannot be verified")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "install-plugin",
 		Description: T("Install CLI plugin"),
 		Usage: []string{
-			T(`CF_NAME install-plugin (LOCAL-PATH/TO/PLUGIN | URL | -r REPO_NAME PLUGIN_NAME) [-f]
+			T(`CF_NAME install-plugin (LOCAL-PATH/TO/PLUGIN | URL | -r REPO_NAME PLUGIN_NAME [--version VERSION] [--skip-checksum]) [-f]
 
-   Prompts for confirmation unless '-f' is provided.`),
+   Prompts for confirmation unless '-f' is provided.
+
+   Plugins installed from a repository are checked against the SHA256 checksum the repository gives for them, unless '--skip-checksum' is provided.`),
 		},
 		Examples: []string{
 			"CF_NAME install-plugin ~/Downloads/plugin-foobar",
 			"CF_NAME install-plugin https://example.com/plugin-foobar_linux_amd64",
 			"CF_NAME install-plugin -r My-Repo plugin-echo",
+			"CF_NAME install-plugin -r My-Repo plugin-echo --version 1.0.2",
 		},
 		Flags:     fs,
 		TotalArgs: 1,
@@ -93,6 +98,10 @@ func (cmd *PluginInstall) SetDependency(deps commandregistry.Dependency, pluginC
 }
 
 func (cmd *PluginInstall) Execute(c flags.FlagContext) error {
+	if c.String("r") == "" && (c.IsSet("version") || c.Bool("skip-checksum")) {
+		return errors.New(T("--version and --skip-checksum can only be used when installing from a repository with -r"))
+	}
+
 	if !cmd.confirmWithUser(
 		c,
 		T("**Attention: Plugins are binaries written by potentially untrusted authors. Install and use plugins at your own risk.**\n\nDo you want to install the plugin {{.Plugin}}? (y or n)",
@@ -119,7 +128,9 @@ func (cmd *PluginInstall) Execute(c flags.FlagContext) error {
 		FileDownloader: fileDownloader,
 		PluginRepo:     cmd.pluginRepo,
 		RepoName:       c.String("r"),
+		SkipChecksum:   c.Bool("skip-checksum"),
 		UI:             cmd.ui,
+		Version:        c.String("version"),
 	}
 	installer := plugininstaller.NewPluginInstaller(deps)
 	pluginSourceFilepath := installer.Install(c.Args()[0])
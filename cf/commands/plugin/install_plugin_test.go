@@ -39,7 +39,7 @@ var _ = Describe("Install", func() {
 		config              coreconfig.Repository
 		pluginConfig        *pluginconfigfakes.FakePluginConfiguration
 		fakePluginRepo      *pluginrepofakes.FakePluginRepo
-		fakeChecksum        *utilsfakes.FakeSha1Checksum
+		fakeChecksum        *utilsfakes.FakeSha256Checksum
 
 		pluginFile *os.File
 		homeDir    string
@@ -71,7 +71,7 @@ var _ = Describe("Install", func() {
 		pluginConfig = new(pluginconfigfakes.FakePluginConfiguration)
 		config = testconfig.NewRepositoryWithDefaults()
 		fakePluginRepo = new(pluginrepofakes.FakePluginRepo)
-		fakeChecksum = new(utilsfakes.FakeSha1Checksum)
+		fakeChecksum = new(utilsfakes.FakeSha256Checksum)
 
 		dir, err := os.Getwd()
 		if err != nil {
@@ -199,6 +199,7 @@ var _ = Describe("Install", func() {
 				Context("when binary is available", func() {
 					var (
 						testServer *httptest.Server
+						result     map[string][]clipr.Plugin
 					)
 
 					BeforeEach(func() {
@@ -208,34 +209,40 @@ var _ = Describe("Install", func() {
 
 						testServer = httptest.NewServer(h)
 
-						fakeChecksum.CheckSha1Returns(true)
+						fakeChecksum.CheckSha256Returns(true)
 
 						p := clipr.Plugin{
-							Name: "plugin1",
+							Name:    "plugin1",
+							Version: "1.0.0",
 							Binaries: []clipr.Binary{
 								{
 									Platform: "osx",
 									Url:      testServer.URL + "/test.exe",
+									Checksum: "abc-sha256",
 								},
 								{
 									Platform: "win64",
 									Url:      testServer.URL + "/test.exe",
+									Checksum: "abc-sha256",
 								},
 								{
 									Platform: "win32",
 									Url:      testServer.URL + "/test.exe",
+									Checksum: "abc-sha256",
 								},
 								{
 									Platform: "linux32",
 									Url:      testServer.URL + "/test.exe",
+									Checksum: "abc-sha256",
 								},
 								{
 									Platform: "linux64",
 									Url:      testServer.URL + "/test.exe",
+									Checksum: "abc-sha256",
 								},
 							},
 						}
-						result := make(map[string][]clipr.Plugin)
+						result = make(map[string][]clipr.Plugin)
 						result["repo1"] = []clipr.Plugin{p}
 
 						config.SetPluginRepo(models.PluginRepo{Name: "repo1", URL: ""})
@@ -246,13 +253,14 @@ var _ = Describe("Install", func() {
 						testServer.Close()
 					})
 
-					It("performs sha1 checksum validation on the downloaded binary", func() {
+					It("performs sha256 checksum validation on the downloaded binary", func() {
 						runCommand("plugin1", "-r", "repo1", "-f")
-						Expect(fakeChecksum.CheckSha1CallCount()).To(Equal(1))
+						Expect(fakeChecksum.CheckSha256CallCount()).To(Equal(1))
+						Expect(fakeChecksum.CheckSha256ArgsForCall(0)).To(Equal("abc-sha256"))
 					})
 
-					It("reports error downloaded file's sha1 does not match the sha1 in metadata", func() {
-						fakeChecksum.CheckSha1Returns(false)
+					It("reports error downloaded file's sha256 does not match the sha256 in metadata", func() {
+						fakeChecksum.CheckSha256Returns(false)
 
 						runCommand("plugin1", "-r", "repo1", "-f")
 						Expect(ui.Outputs()).To(ContainSubstrings(
@@ -262,6 +270,99 @@ var _ = Describe("Install", func() {
 
 					})
 
+					It("does not check the checksum with --skip-checksum", func() {
+						fakeChecksum.CheckSha256Returns(false)
+
+						runCommand("plugin1", "-r", "repo1", "-f", "--skip-checksum")
+						Expect(fakeChecksum.CheckSha256CallCount()).To(BeZero())
+						Expect(ui.Outputs()).To(ContainSubstrings(
+							[]string{"Skipping checksum verification"},
+							[]string{"Installing plugin"},
+						))
+					})
+
+					Context("when the repo does not give a checksum", func() {
+						BeforeEach(func() {
+							for i := range result["repo1"][0].Binaries {
+								result["repo1"][0].Binaries[i].Checksum = ""
+							}
+						})
+
+						It("refuses to install the plugin", func() {
+							runCommand("plugin1", "-r", "repo1", "-f")
+							Expect(ui.Outputs()).To(ContainSubstrings(
+								[]string{"FAILED"},
+								[]string{"Repo 'repo1' does not give a SHA256 checksum for the plugin binary; use --skip-checksum to install it anyway"},
+							))
+							Expect(fakeChecksum.CheckSha256CallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the repo gives a SHA1 checksum", func() {
+						BeforeEach(func() {
+							for i := range result["repo1"][0].Binaries {
+								result["repo1"][0].Binaries[i].Checksum = "2FD4E1C67A2D28FCED849EE1BB76E7391B93EB12"
+							}
+						})
+
+						It("refuses to install the plugin", func() {
+							runCommand("plugin1", "-r", "repo1", "-f")
+							Expect(ui.Outputs()).To(ContainSubstrings(
+								[]string{"FAILED"},
+								[]string{"Repo 'repo1' gives a SHA1 checksum for the plugin binary, which is not verified as it is not secure; use --skip-checksum to install it anyway"},
+							))
+							Expect(fakeChecksum.CheckSha256CallCount()).To(BeZero())
+						})
+
+						It("installs the plugin with --skip-checksum", func() {
+							runCommand("plugin1", "-r", "repo1", "-f", "--skip-checksum")
+							Expect(ui.Outputs()).To(ContainSubstrings(
+								[]string{"Skipping checksum verification"},
+								[]string{"Installing plugin"},
+							))
+						})
+					})
+
+					Context("when the repo has several versions of the plugin", func() {
+						BeforeEach(func() {
+							p := result["repo1"][0]
+							older := p
+							older.Version = "0.9.0"
+							older.Binaries = []clipr.Binary{}
+							for _, binary := range p.Binaries {
+								binary.Checksum = "older-sha256"
+								older.Binaries = append(older.Binaries, binary)
+							}
+							newer := p
+							newer.Version = "1.10.0"
+							newer.Binaries = []clipr.Binary{}
+							for _, binary := range p.Binaries {
+								binary.Checksum = "newer-sha256"
+								newer.Binaries = append(newer.Binaries, binary)
+							}
+							result["repo1"] = []clipr.Plugin{older, newer, p}
+						})
+
+						It("installs the newest version by default", func() {
+							runCommand("plugin1", "-r", "repo1", "-f")
+							Expect(fakeChecksum.CheckSha256ArgsForCall(0)).To(Equal("newer-sha256"))
+						})
+
+						It("installs the version given with --version", func() {
+							runCommand("plugin1", "-r", "repo1", "-f", "--version", "0.9")
+							Expect(fakeChecksum.CheckSha256ArgsForCall(0)).To(Equal("older-sha256"))
+						})
+
+						It("fails when the repo does not have the version given with --version", func() {
+							runCommand("plugin1", "-r", "repo1", "-f", "--version", "2.0.0")
+							Expect(ui.Outputs()).To(ContainSubstrings(
+								[]string{"FAILED"},
+								[]string{"Version 2.0.0 of plugin1 is not available in repo 'repo1'; it has versions 0.9.0, 1.10.0, 1.0.0"},
+							))
+							Expect(fakeChecksum.CheckSha256CallCount()).To(BeZero())
+						})
+					})
+
 					It("downloads and installs binary when it is available and checksum matches", func() {
 						runCommand("plugin1", "-r", "repo1", "-f")
 
@@ -274,6 +375,15 @@ var _ = Describe("Install", func() {
 		})
 
 		Describe("install from plugin repository with no '-r' provided", func() {
+			It("fails when --version or --skip-checksum is given without -r", func() {
+				runCommand("http://127.0.0.1/plugin.exe", "-f", "--version", "1.0.0")
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"--version and --skip-checksum can only be used when installing from a repository with -r"},
+				))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"download binary file from internet address"}))
+			})
+
 			Context("downloads file from internet if path prefix with 'http','ftp' etc...", func() {
 				It("will not try locate file locally", func() {
 					runCommand("http://127.0.0.1/plugin.exe", "-f")
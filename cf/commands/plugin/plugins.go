@@ -3,8 +3,13 @@ package plugin
 import (
 	"fmt"
 	"sort"
+	"strings"
 
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors/plugininstaller"
+	"code.cloudfoundry.org/cli/cf/actors/pluginrepo"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
@@ -15,8 +20,10 @@ import (
 )
 
 type Plugins struct {
-	ui     terminal.UI
-	config pluginconfig.PluginConfiguration
+	ui         terminal.UI
+	config     pluginconfig.PluginConfiguration
+	coreConfig coreconfig.Reader
+	pluginRepo pluginrepo.PluginRepo
 }
 
 func init() {
@@ -26,12 +33,13 @@ func init() {
 func (cmd *Plugins) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["checksum"] = &flags.BoolFlag{Name: "checksum", Usage: T("Compute and show the sha1 value of the plugin binary file")}
+	fs["outdated"] = &flags.BoolFlag{Name: "outdated", Usage: T("Search the plugin repositories for newer versions of the installed plugins")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "plugins",
 		Description: T("List all available plugin commands"),
 		Usage: []string{
-			T("CF_NAME plugins"),
+			T("CF_NAME plugins [--checksum | --outdated]"),
 		},
 		Flags: fs,
 	}
@@ -54,10 +62,16 @@ func (cmd *Plugins) Requirements(requirementsFactory requirements.Factory, fc fl
 func (cmd *Plugins) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
 	cmd.ui = deps.UI
 	cmd.config = deps.PluginConfig
+	cmd.coreConfig = deps.Config
+	cmd.pluginRepo = deps.PluginRepo
 	return cmd
 }
 
 func (cmd *Plugins) Execute(c flags.FlagContext) error {
+	if c.Bool("outdated") {
+		return cmd.listOutdated()
+	}
+
 	var version string
 
 	cmd.ui.Say(T("Listing Installed Plugins..."))
@@ -119,3 +133,42 @@ func (cmd *Plugins) Execute(c flags.FlagContext) error {
 	}
 	return nil
 }
+
+func (cmd *Plugins) listOutdated() error {
+	repos := cmd.coreConfig.PluginRepos()
+	repoNames := []string{}
+	for _, repo := range repos {
+		repoNames = append(repoNames, repo.Name)
+	}
+
+	cmd.ui.Say(T("Searching {{.RepoNames}} for newer versions of installed plugins...",
+		map[string]interface{}{"RepoNames": strings.Join(repoNames, ", ")}))
+
+	repoPlugins, repoErrors := cmd.pluginRepo.GetPlugins(repos)
+	for _, repoError := range repoErrors {
+		cmd.ui.Warn(repoError)
+	}
+
+	updates := plugininstaller.FindUpdates(cmd.config.Plugins(), repoPlugins)
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(updates) == 0 {
+		cmd.ui.Say(T("All installed plugins are up to date"))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{T("Plugin Name"), T("Version"), T("Latest Version"), T("Repository")})
+	for _, update := range updates {
+		table.Add(update.Name, update.InstalledVersion, update.Version, update.RepoName)
+	}
+	err := table.Print()
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Use '{{.Command}}' to update them.", map[string]interface{}{"Command": terminal.CommandColor(cf.Name + " update-plugins")}))
+	return nil
+}
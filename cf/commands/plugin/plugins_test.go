@@ -3,16 +3,21 @@ package plugin_test
 import (
 	"net/rpc"
 
+	"code.cloudfoundry.org/cli/cf/actors/pluginrepo/pluginrepofakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	plugincmd "code.cloudfoundry.org/cli/cf/commands/plugin"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
 	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig/pluginconfigfakes"
 	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
 	"code.cloudfoundry.org/cli/plugin"
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
 	. "code.cloudfoundry.org/cli/testhelpers/matchers"
 	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -23,12 +28,16 @@ var _ = Describe("Plugins", func() {
 		ui                  *testterm.FakeUI
 		requirementsFactory *requirementsfakes.FakeFactory
 		config              *pluginconfigfakes.FakePluginConfiguration
+		fakePluginRepo      *pluginrepofakes.FakePluginRepo
+		coreConfig          coreconfig.Repository
 		deps                commandregistry.Dependency
 	)
 
 	updateCommandDependency := func(pluginCall bool) {
 		deps.UI = ui
 		deps.PluginConfig = config
+		deps.Config = coreConfig
+		deps.PluginRepo = fakePluginRepo
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("plugins").SetDependency(deps, pluginCall))
 	}
 
@@ -36,6 +45,8 @@ var _ = Describe("Plugins", func() {
 		ui = &testterm.FakeUI{}
 		requirementsFactory = new(requirementsfakes.FakeFactory)
 		config = new(pluginconfigfakes.FakePluginConfiguration)
+		fakePluginRepo = new(pluginrepofakes.FakePluginRepo)
+		coreConfig = testconfig.NewRepositoryWithDefaults()
 
 		rpc.DefaultServer = rpc.NewServer()
 	})
@@ -64,6 +75,48 @@ var _ = Describe("Plugins", func() {
 		})
 	})
 
+	Context("If --outdated flag is provided", func() {
+		BeforeEach(func() {
+			config.PluginsReturns(map[string]pluginconfig.PluginMetadata{
+				"Test1": {Version: plugin.VersionType{Major: 1, Minor: 2, Build: 3}},
+				"Test2": {Version: plugin.VersionType{Major: 2, Minor: 0, Build: 0}},
+			})
+			coreConfig.SetPluginRepo(models.PluginRepo{Name: "CF-Community", URL: "https://plugins.cloudfoundry.org"})
+		})
+
+		It("lists the plugins with a newer version in the repos", func() {
+			fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+				"CF-Community": {
+					repoPlugin("Test1", "1.3.0", "sha256"),
+					repoPlugin("Test2", "2.0.0", "sha256"),
+				},
+			}, nil)
+
+			runCommand("--outdated")
+
+			Expect(fakePluginRepo.GetPluginsArgsForCall(0)).To(Equal([]models.PluginRepo{
+				{Name: "CF-Community", URL: "https://plugins.cloudfoundry.org"},
+			}))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Searching CF-Community for newer versions of installed plugins..."},
+				[]string{"OK"},
+				[]string{"Plugin Name", "Version", "Latest Version", "Repository"},
+				[]string{"Test1", "1.2.3", "1.3.0", "CF-Community"},
+				[]string{"Use 'cf update-plugins' to update them."},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Test2"}))
+		})
+
+		It("ignores versions without a binary for this machine", func() {
+			fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+				"CF-Community": {{Name: "Test1", Version: "1.3.0"}},
+			}, nil)
+
+			runCommand("--outdated")
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"All installed plugins are up to date"}))
+		})
+	})
+
 	Context("when arguments are provided", func() {
 		var cmd commandregistry.Command
 		var flagContext flags.FlagContext
@@ -0,0 +1,209 @@
+package plugin
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors/plugininstaller"
+	"code.cloudfoundry.org/cli/cf/actors/pluginrepo"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/utils"
+	"code.cloudfoundry.org/cli/utils/downloader"
+)
+
+type UpdatePlugins struct {
+	ui           terminal.UI
+	config       coreconfig.Reader
+	pluginConfig pluginconfig.PluginConfiguration
+	pluginRepo   pluginrepo.PluginRepo
+	checksum     utils.Sha256Checksum
+	installer    commandregistry.Command
+	uninstaller  commandregistry.Command
+}
+
+func init() {
+	commandregistry.Register(&UpdatePlugins{})
+}
+
+func (cmd *UpdatePlugins) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force update of the plugins without confirmation")}
+	fs["skip-checksum"] = &flags.BoolFlag{Name: "skip-checksum", Usage: T("Update the plugins even if their SHA256 checksums cannot be verified")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "update-plugins",
+		Description: T("Update installed plugins to the newest versions in the plugin repositories"),
+		Usage: []string{
+			T(`CF_NAME update-plugins [PLUGIN_NAME...] [-f] [--skip-checksum]
+
+   Updates all the installed plugins that have a newer version, with a binary for this machine, in any of the plugin repositories, or only the plugins named. The new version of each plugin is downloaded from the repository with the newest version and verified before the installed version is uninstalled.
+
+   Prompts for confirmation unless '-f' is provided.`),
+		},
+		Examples: []string{
+			"CF_NAME update-plugins",
+			"CF_NAME update-plugins plugin-echo -f",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *UpdatePlugins) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	reqs := []requirements.Requirement{}
+	return reqs, nil
+}
+
+func (cmd *UpdatePlugins) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.pluginConfig = deps.PluginConfig
+	cmd.pluginRepo = deps.PluginRepo
+	cmd.checksum = deps.ChecksumUtil
+
+	installer := commandregistry.Commands.FindCommand("install-plugin")
+	cmd.installer = installer.SetDependency(deps, false)
+
+	uninstaller := commandregistry.Commands.FindCommand("uninstall-plugin")
+	cmd.uninstaller = uninstaller.SetDependency(deps, false)
+
+	return cmd
+}
+
+func (cmd *UpdatePlugins) Execute(c flags.FlagContext) error {
+	installed := cmd.pluginConfig.Plugins()
+	if len(c.Args()) > 0 {
+		selected := map[string]pluginconfig.PluginMetadata{}
+		for _, pluginName := range c.Args() {
+			metadata, ok := installed[pluginName]
+			if !ok {
+				return errors.New(T("Plugin name {{.PluginName}} does not exist", map[string]interface{}{"PluginName": pluginName}))
+			}
+			selected[pluginName] = metadata
+		}
+		installed = selected
+	}
+
+	repos := cmd.config.PluginRepos()
+	repoNames := []string{}
+	for _, repo := range repos {
+		repoNames = append(repoNames, repo.Name)
+	}
+
+	cmd.ui.Say(T("Searching {{.RepoNames}} for newer versions of installed plugins...",
+		map[string]interface{}{"RepoNames": strings.Join(repoNames, ", ")}))
+
+	repoPlugins, repoErrors := cmd.pluginRepo.GetPlugins(repos)
+	for _, repoError := range repoErrors {
+		cmd.ui.Warn(repoError)
+	}
+
+	updates := plugininstaller.FindUpdates(installed, repoPlugins)
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(updates) == 0 {
+		cmd.ui.Say(T("All installed plugins are up to date"))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{T("Plugin Name"), T("Version"), T("Latest Version"), T("Repository")})
+	for _, update := range updates {
+		table.Add(update.Name, update.InstalledVersion, update.Version, update.RepoName)
+	}
+	err := table.Print()
+	if err != nil {
+		return err
+	}
+	cmd.ui.Say("")
+
+	if !c.Bool("f") && !cmd.ui.Confirm(T("**Attention: Plugins are binaries written by potentially untrusted authors. Install and use plugins at your own risk.**\n\nDo you want to update these plugins? (y or n)")) {
+		return errors.New(T("Plugin update cancelled"))
+	}
+
+	for _, update := range updates {
+		err = cmd.updatePlugin(update, c.Bool("skip-checksum"))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cmd *UpdatePlugins) updatePlugin(update plugininstaller.PluginUpdate, skipChecksum bool) error {
+	details := map[string]interface{}{
+		"PluginName": terminal.EntityNameColor(update.Name),
+		"Version":    update.Version,
+		"RepoName":   update.RepoName,
+	}
+
+	// the installed plugin is only removed once its update is downloaded and
+	// verified, as it cannot be put back afterwards
+	if update.Checksum == "" && !skipChecksum {
+		cmd.ui.Warn(T("Not updating plugin {{.PluginName}}: repo '{{.RepoName}}' does not give a SHA256 checksum for it; use --skip-checksum to update it anyway", details))
+		return nil
+	}
+
+	cmd.ui.Say(T("Updating plugin {{.PluginName}} to v{{.Version}} from repo '{{.RepoName}}'...", details))
+
+	tempDir, err := ioutil.TempDir("", "cf-plugin-update")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	downloaded := plugininstaller.NewPluginInstaller(&plugininstaller.Context{
+		Checksummer:    cmd.checksum,
+		FileDownloader: downloader.NewDownloader(tempDir),
+		GetPluginRepos: cmd.config.PluginRepos,
+		PluginRepo:     cmd.pluginRepo,
+		RepoName:       update.RepoName,
+		SkipChecksum:   skipChecksum,
+		UI:             cmd.ui,
+		Version:        update.Version,
+	}).Install(update.Name)
+	if _, err = os.Stat(downloaded); err != nil {
+		return errors.New(T("Plugin {{.PluginName}} was not updated, as v{{.Version}} could not be downloaded and verified",
+			map[string]interface{}{
+				"PluginName": update.Name,
+				"Version":    update.Version,
+			}))
+	}
+
+	err = cmd.runCommand(cmd.uninstaller, update.Name)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.runCommand(cmd.installer, downloaded, "-f")
+	if err != nil {
+		return errors.New(T("Plugin {{.PluginName}} was uninstalled, but v{{.Version}} could not be installed: {{.Error}}\nTip: use '{{.Command}}' to install it again",
+			map[string]interface{}{
+				"PluginName": update.Name,
+				"Version":    update.Version,
+				"Error":      err.Error(),
+				"Command":    terminal.CommandColor(cf.Name + " install-plugin -r " + update.RepoName + " " + update.Name),
+			}))
+	}
+
+	cmd.ui.Say("")
+	return nil
+}
+
+func (cmd *UpdatePlugins) runCommand(command commandregistry.Command, args ...string) error {
+	context := flags.NewFlagContext(command.MetaData().Flags)
+	err := context.Parse(args...)
+	if err != nil {
+		return err
+	}
+	return command.Execute(context)
+}
@@ -0,0 +1,263 @@
+package plugin_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/actors/pluginrepo/pluginrepofakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commandregistry/commandregistryfakes"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig/pluginconfigfakes"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/plugin"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	"code.cloudfoundry.org/cli/utils/utilsfakes"
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// repoPlugin returns a plugin as a repo lists it, with a binary for every
+// platform.
+func repoPlugin(name string, version string, checksum string) clipr.Plugin {
+	p := clipr.Plugin{Name: name, Version: version}
+	for _, platform := range clipr.ValidPlatforms {
+		p.Binaries = append(p.Binaries, clipr.Binary{
+			Platform: platform,
+			Url:      "http://example.com/" + name + "_" + platform,
+			Checksum: checksum,
+		})
+	}
+	return p
+}
+
+var _ = Describe("update-plugins", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		config              coreconfig.Repository
+		pluginConfig        *pluginconfigfakes.FakePluginConfiguration
+		fakePluginRepo      *pluginrepofakes.FakePluginRepo
+		fakeChecksum        *utilsfakes.FakeSha256Checksum
+		testServer          *httptest.Server
+		installer           *commandregistryfakes.FakeCommand
+		uninstaller         *commandregistryfakes.FakeCommand
+		originalInstaller   commandregistry.Command
+		originalUninstaller commandregistry.Command
+		installArgs         [][]string
+		uninstallArgs       [][]string
+		deps                commandregistry.Dependency
+	)
+
+	fakeCommand := func(original commandregistry.Command, args *[][]string) *commandregistryfakes.FakeCommand {
+		fake := new(commandregistryfakes.FakeCommand)
+		fake.SetDependencyStub = func(_ commandregistry.Dependency, _ bool) commandregistry.Command {
+			return fake
+		}
+		fake.MetaDataReturns(original.MetaData())
+		fake.ExecuteStub = func(context flags.FlagContext) error {
+			commandArgs := context.Args()
+			for _, name := range []string{"r", "version"} {
+				if context.IsSet(name) {
+					commandArgs = append(commandArgs, name+"="+context.String(name))
+				}
+			}
+			for _, name := range []string{"f", "skip-checksum"} {
+				if context.Bool(name) {
+					commandArgs = append(commandArgs, name)
+				}
+			}
+			*args = append(*args, commandArgs)
+			return nil
+		}
+		return fake
+	}
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.PluginConfig = pluginConfig
+		deps.PluginRepo = fakePluginRepo
+		deps.ChecksumUtil = fakeChecksum
+
+		//inject fake 'install-plugin' and 'uninstall-plugin' into registry
+		commandregistry.Register(installer)
+		commandregistry.Register(uninstaller)
+
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("update-plugins").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("update-plugins", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	// served points the binaries of p at the test server
+	served := func(p clipr.Plugin) clipr.Plugin {
+		for i := range p.Binaries {
+			p.Binaries[i].Url = testServer.URL + "/" + p.Name + "_" + p.Binaries[i].Platform
+		}
+		return p
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		config = testconfig.NewRepositoryWithDefaults()
+		config.SetPluginRepo(models.PluginRepo{Name: "repo1", URL: "http://repo1.example.com"})
+		config.SetPluginRepo(models.PluginRepo{Name: "repo2", URL: "http://repo2.example.com"})
+
+		pluginConfig = new(pluginconfigfakes.FakePluginConfiguration)
+		pluginConfig.PluginsReturns(map[string]pluginconfig.PluginMetadata{
+			"echo":     {Version: plugin.VersionType{Major: 1, Minor: 0, Build: 0}},
+			"current":  {Version: plugin.VersionType{Major: 2, Minor: 0, Build: 0}},
+			"unknown":  {},
+			"unlisted": {Version: plugin.VersionType{Major: 1, Minor: 0, Build: 0}},
+		})
+
+		testServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("plugin-binary"))
+		}))
+
+		fakeChecksum = new(utilsfakes.FakeSha256Checksum)
+		fakeChecksum.CheckSha256Returns(true)
+
+		fakePluginRepo = new(pluginrepofakes.FakePluginRepo)
+		fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+			"repo1": {
+				served(repoPlugin("echo", "1.2.0", "echo-1.2.0-sha256")),
+				repoPlugin("current", "2.0.0", "current-sha256"),
+				repoPlugin("unknown", "1.0.0", "unknown-sha256"),
+			},
+			"repo2": {
+				served(repoPlugin("Echo", "1.10.0", "echo-1.10.0-sha256")),
+				{Name: "current", Version: "3.0.0"},
+			},
+		}, nil)
+
+		installArgs = nil
+		uninstallArgs = nil
+
+		//save original commands and restore later
+		originalInstaller = commandregistry.Commands.FindCommand("install-plugin")
+		originalUninstaller = commandregistry.Commands.FindCommand("uninstall-plugin")
+		installer = fakeCommand(originalInstaller, &installArgs)
+		uninstaller = fakeCommand(originalUninstaller, &uninstallArgs)
+	})
+
+	AfterEach(func() {
+		testServer.Close()
+		commandregistry.Register(originalInstaller)
+		commandregistry.Register(originalUninstaller)
+	})
+
+	It("updates the plugins with a newer version for this machine to the newest version in any repo", func() {
+		Expect(runCommand("-f")).To(BeTrue())
+
+		Expect(fakePluginRepo.GetPluginsArgsForCall(0)).To(HaveLen(2))
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Searching repo1, repo2 for newer versions of installed plugins..."},
+			[]string{"Plugin Name", "Version", "Latest Version", "Repository"},
+			[]string{"echo", "1.0.0", "1.10.0", "repo2"},
+			[]string{"Updating plugin echo to v1.10.0 from repo 'repo2'..."},
+		))
+		Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"current"}))
+		Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"unknown"}))
+		Expect(ui.Prompts).To(BeEmpty())
+
+		Expect(fakeChecksum.CheckSha256CallCount()).To(Equal(1))
+		Expect(fakeChecksum.CheckSha256ArgsForCall(0)).To(Equal("echo-1.10.0-sha256"))
+
+		Expect(uninstallArgs).To(Equal([][]string{{"echo"}}))
+		Expect(installArgs).To(HaveLen(1))
+		Expect(filepath.Base(installArgs[0][0])).To(HavePrefix("Echo_"))
+		Expect(installArgs[0][1:]).To(Equal([]string{"f"}))
+	})
+
+	It("leaves the installed plugin alone when the new version does not match its checksum", func() {
+		fakeChecksum.CheckSha256Returns(false)
+
+		Expect(runCommand("-f")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Updating plugin echo to v1.10.0 from repo 'repo2'..."},
+			[]string{"FAILED"},
+			[]string{"checksum does not match"},
+		))
+		Expect(uninstallArgs).To(BeEmpty())
+		Expect(installArgs).To(BeEmpty())
+	})
+
+	It("asks for confirmation unless -f is given", func() {
+		ui.Inputs = []string{"n"}
+
+		Expect(runCommand()).To(BeFalse())
+		Expect(ui.Prompts).To(ContainSubstrings([]string{"Do you want to update these plugins?"}))
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"Plugin update cancelled"}))
+		Expect(uninstallArgs).To(BeEmpty())
+	})
+
+	It("only updates the plugins given", func() {
+		Expect(runCommand("current", "-f")).To(BeTrue())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"All installed plugins are up to date"}))
+		Expect(uninstallArgs).To(BeEmpty())
+	})
+
+	It("fails when a plugin given is not installed", func() {
+		Expect(runCommand("missing", "-f")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"Plugin name missing does not exist"}))
+		Expect(fakePluginRepo.GetPluginsCallCount()).To(BeZero())
+	})
+
+	Context("when the repo does not give a checksum for the update", func() {
+		BeforeEach(func() {
+			fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+				"repo1": {served(repoPlugin("echo", "1.2.0", ""))},
+			}, nil)
+		})
+
+		It("leaves the plugin installed and warns", func() {
+			Expect(runCommand("-f")).To(BeTrue())
+			Expect(ui.WarnOutputs).To(ContainSubstrings(
+				[]string{"Not updating plugin echo: repo 'repo1' does not give a SHA256 checksum for it; use --skip-checksum to update it anyway"},
+			))
+			Expect(uninstallArgs).To(BeEmpty())
+		})
+
+		It("updates it with --skip-checksum", func() {
+			Expect(runCommand("-f", "--skip-checksum")).To(BeTrue())
+			Expect(fakeChecksum.CheckSha256CallCount()).To(BeZero())
+			Expect(uninstallArgs).To(Equal([][]string{{"echo"}}))
+			Expect(installArgs).To(HaveLen(1))
+			Expect(installArgs[0][1:]).To(Equal([]string{"f"}))
+		})
+	})
+
+	It("says how to reinstall the plugin when the new version cannot be installed", func() {
+		installer.ExecuteStub = nil
+		installer.ExecuteReturns(errors.New("install-error"))
+
+		Expect(runCommand("-f")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Plugin echo was uninstalled, but v1.10.0 could not be installed: install-error"},
+			[]string{"cf install-plugin -r repo2 echo"},
+		))
+	})
+
+	It("warns about the repos it cannot read", func() {
+		fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{}, []string{"repo-error"})
+
+		Expect(runCommand("-f")).To(BeTrue())
+		Expect(ui.WarnOutputs).To(ContainSubstrings([]string{"repo-error"}))
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"All installed plugins are up to date"}))
+	})
+})
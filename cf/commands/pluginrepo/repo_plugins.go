@@ -30,15 +30,17 @@ func init() {
 func (cmd *RepoPlugins) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["r"] = &flags.StringFlag{ShortName: "r", Usage: T("Name of a registered repository")}
+	fs["search"] = &flags.StringFlag{Name: "search", Usage: T("Only list the plugins whose name contains the given text")}
 
 	return commandregistry.CommandMetadata{
 		Name:        T("repo-plugins"),
 		Description: T("List all available plugins in specified repository or in all added repositories"),
 		Usage: []string{
-			T(`CF_NAME repo-plugins [-r REPO_NAME]`),
+			T(`CF_NAME repo-plugins [-r REPO_NAME] [--search NAME]`),
 		},
 		Examples: []string{
 			"CF_NAME repo-plugins -r PrivateRepo",
+			"CF_NAME repo-plugins --search echo",
 		},
 		Flags: fs,
 	}
@@ -83,6 +85,15 @@ func (cmd *RepoPlugins) Execute(c flags.FlagContext) error {
 
 	repoPlugins, repoError := cmd.pluginRepo.GetPlugins(repos)
 
+	search := c.String("search")
+	if search != "" {
+		repoPlugins = searchPlugins(repoPlugins, search)
+		if len(repoPlugins) == 0 {
+			cmd.ui.Say(T("No plugins matching '{{.Search}}' found", map[string]interface{}{"Search": search}))
+			cmd.ui.Say("")
+		}
+	}
+
 	err := cmd.printTable(repoPlugins)
 
 	cmd.printErrors(repoError)
@@ -109,6 +120,21 @@ func (cmd RepoPlugins) printTable(repoPlugins map[string][]clipr.Plugin) error {
 	return nil
 }
 
+// searchPlugins returns the plugins of each repo whose name contains search,
+// ignoring case, leaving out the repos that have none.
+func searchPlugins(repoPlugins map[string][]clipr.Plugin, search string) map[string][]clipr.Plugin {
+	search = strings.ToLower(search)
+	found := make(map[string][]clipr.Plugin)
+	for repoName, plugins := range repoPlugins {
+		for _, p := range plugins {
+			if strings.Contains(strings.ToLower(p.Name), search) {
+				found[repoName] = append(found[repoName], p)
+			}
+		}
+	}
+	return found
+}
+
 func (cmd RepoPlugins) printErrors(repoError []string) {
 	if len(repoError) > 0 {
 		cmd.ui.Say(terminal.ColorizeBold(T("Logged errors:"), 31))
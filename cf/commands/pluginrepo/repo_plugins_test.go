@@ -143,6 +143,38 @@ var _ = Describe("repo-plugins", func() {
 			})
 		})
 
+		Context("when --search is provided", func() {
+			BeforeEach(func() {
+				result := make(map[string][]clipr.Plugin)
+				result["repo1"] = []clipr.Plugin{
+					{Name: "echo-plugin", Description: "none1"},
+					{Name: "other-plugin", Description: "none2"},
+				}
+				result["repo2"] = []clipr.Plugin{
+					{Name: "unrelated", Description: "none3"},
+				}
+				fakePluginRepo.GetPluginsReturns(result, []string{})
+			})
+
+			It("lists the plugins matching the name from all repos, ignoring case", func() {
+				err := callRepoPlugins("--search", "ECHO")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(len(fakePluginRepo.GetPluginsArgsForCall(0))).To(Equal(2))
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"Repository: repo1"}))
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"echo-plugin"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"other-plugin"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"repo2"}))
+			})
+
+			It("says when no plugin matches", func() {
+				err := callRepoPlugins("--search", "missing")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"No plugins matching 'missing' found"}))
+			})
+		})
+
 		Context("If errors are reported back from GetPlugins()", func() {
 			It("informs user about the errors", func() {
 				fakePluginRepo.GetPluginsReturns(nil, []string{
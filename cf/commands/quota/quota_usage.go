@@ -0,0 +1,124 @@
+package quota
+
+import (
+	"fmt"
+	"strconv"
+
+	"code.cloudfoundry.org/cli/cf/api/organizations"
+	"code.cloudfoundry.org/cli/cf/api/resources"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/formatters"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type QuotaUsage struct {
+	ui      terminal.UI
+	config  coreconfig.Reader
+	orgRepo organizations.OrganizationRepository
+	orgReq  requirements.OrganizationRequirement
+}
+
+func init() {
+	commandregistry.Register(&QuotaUsage{})
+}
+
+func (cmd *QuotaUsage) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "quota-usage",
+		Description: T("Show how much of the limits of its quota an org uses"),
+		Usage: []string{
+			T("CF_NAME quota-usage ORG"),
+		},
+		StructuredOutput: true,
+	}
+}
+
+func (cmd *QuotaUsage) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires an argument\n\n") + commandregistry.Commands.CommandUsage("quota-usage"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	cmd.orgReq = requirementsFactory.NewOrganizationRequirement(fc.Args()[0])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		cmd.orgReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *QuotaUsage) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.orgRepo = deps.RepoLocator.GetOrganizationRepository()
+	return cmd
+}
+
+func (cmd *QuotaUsage) Execute(c flags.FlagContext) error {
+	org := cmd.orgReq.GetOrganization()
+	quota := org.QuotaDefinition
+
+	cmd.ui.Say(T("Getting quota usage of org {{.OrgName}} as {{.Username}}...",
+		map[string]interface{}{
+			"OrgName":  terminal.EntityNameColor(org.Name),
+			"Username": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	usage, err := cmd.orgRepo.GetUsage(org.GUID)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	cmd.ui.Say(T("quota: {{.QuotaName}}", map[string]interface{}{"QuotaName": terminal.EntityNameColor(quota.Name)}))
+	cmd.ui.Say("")
+
+	table := cmd.ui.Table([]string{T("resource"), T("used"), T("limit"), T("percent used")})
+	table.Table.SetFieldKeys("resource", "used", "limit", "percent_used")
+
+	memoryLimit := formatters.ByteSize(quota.MemoryLimit * formatters.MEGABYTE)
+	if quota.MemoryLimit == -1 {
+		memoryLimit = T("unlimited")
+	}
+	table.Add(T("memory"), formatters.ByteSize(usage.MemoryInMB*formatters.MEGABYTE), memoryLimit, percentUsed(usage.MemoryInMB, quota.MemoryLimit))
+
+	routesLimit := strconv.Itoa(quota.RoutesLimit)
+	if routesLimit == resources.UnlimitedRoutes {
+		routesLimit = T("unlimited")
+	}
+	table.Add(T("routes"), strconv.Itoa(usage.Routes), routesLimit, percentUsed(int64(usage.Routes), int64(quota.RoutesLimit)))
+
+	servicesLimit := strconv.Itoa(quota.ServicesLimit)
+	if quota.ServicesLimit == -1 {
+		servicesLimit = T("unlimited")
+	}
+	table.Add(T("service instances"), strconv.Itoa(usage.ServiceInstances), servicesLimit, percentUsed(int64(usage.ServiceInstances), int64(quota.ServicesLimit)))
+
+	appInstanceLimit := strconv.Itoa(quota.AppInstanceLimit)
+	if quota.AppInstanceLimit == resources.UnlimitedAppInstances {
+		appInstanceLimit = T("unlimited")
+	}
+	table.Add(T("app instances"), strconv.Itoa(usage.AppInstances), appInstanceLimit, percentUsed(int64(usage.AppInstances), int64(quota.AppInstanceLimit)))
+
+	return table.Print()
+}
+
+// percentUsed is used as a percentage of limit, or unlimited when the limit
+// is -1. A limit of zero allows nothing to be used, so it shows as 0%.
+func percentUsed(used int64, limit int64) string {
+	switch {
+	case limit < 0:
+		return T("unlimited")
+	case limit == 0:
+		return "0%"
+	default:
+		return fmt.Sprintf("%d%%", used*100/limit)
+	}
+}
@@ -0,0 +1,138 @@
+package quota_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/cli/cf/api/organizations/organizationsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+)
+
+var _ = Describe("quota-usage", func() {
+	var (
+		ui                  *testterm.FakeUI
+		commandUI           terminal.UI
+		requirementsFactory *requirementsfakes.FakeFactory
+		orgRequirement      *requirementsfakes.FakeOrganizationRequirement
+		config              coreconfig.Repository
+		orgRepo             *organizationsfakes.FakeOrganizationRepository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = commandUI
+		deps.Config = config
+		deps.RepoLocator = deps.RepoLocator.SetOrganizationRepository(orgRepo)
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("quota-usage").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		commandUI = ui
+		config = testconfig.NewRepositoryWithDefaults()
+		orgRepo = new(organizationsfakes.FakeOrganizationRepository)
+
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		orgRequirement = new(requirementsfakes.FakeOrganizationRequirement)
+		orgRequirement.GetOrganizationReturns(models.Organization{
+			OrganizationFields: models.OrganizationFields{
+				GUID: "my-org-guid",
+				Name: "my-org",
+				QuotaDefinition: models.QuotaFields{
+					Name:             "default",
+					MemoryLimit:      2048,
+					RoutesLimit:      10,
+					ServicesLimit:    -1,
+					AppInstanceLimit: 0,
+				},
+			},
+		})
+		requirementsFactory.NewOrganizationRequirementReturns(orgRequirement)
+
+		orgRepo.GetUsageReturns(models.OrganizationUsage{
+			MemoryInMB:       512,
+			Routes:           5,
+			ServiceInstances: 3,
+			AppInstances:     0,
+		}, nil)
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("quota-usage", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("fails with usage when not given an org", func() {
+			runCommand()
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires", "argument"},
+			))
+		})
+
+		It("requires the user to be logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(runCommand("my-org")).To(BeFalse())
+		})
+
+		It("requires the org to exist", func() {
+			orgRequirement.ExecuteReturns(errors.New("org not found"))
+			Expect(runCommand("my-org")).To(BeFalse())
+			Expect(requirementsFactory.NewOrganizationRequirementArgsForCall(0)).To(Equal("my-org"))
+		})
+	})
+
+	It("compares the usage of the org with the limits of its quota", func() {
+		Expect(runCommand("my-org")).To(HavePassedRequirements())
+		Expect(orgRepo.GetUsageArgsForCall(0)).To(Equal("my-org-guid"))
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Getting quota usage of org", "my-org", "my-user"},
+			[]string{"OK"},
+			[]string{"quota:", "default"},
+			[]string{"resource", "used", "limit", "percent used"},
+			[]string{"memory", "512M", "2G", "25%"},
+			[]string{"routes", "5", "10", "50%"},
+			[]string{"service instances", "3", "unlimited", "unlimited"},
+			[]string{"app instances", "0", "0", "0%"},
+		))
+	})
+
+	It("fails when the usage cannot be fetched", func() {
+		orgRepo.GetUsageReturns(models.OrganizationUsage{}, errors.New("usage-err"))
+
+		Expect(runCommand("my-org")).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"usage-err"},
+		))
+	})
+
+	It("prints the usage as JSON", func() {
+		formattedUI := terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+		commandUI = formattedUI
+
+		Expect(runCommand("my-org")).To(BeTrue())
+		Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+		Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`[
+			{"resource": "memory", "used": "512M", "limit": "2G", "percent_used": "25%"},
+			{"resource": "routes", "used": "5", "limit": "10", "percent_used": "50%"},
+			{"resource": "service instances", "used": "3", "limit": "unlimited", "percent_used": "unlimited"},
+			{"resource": "app instances", "used": "0", "limit": "0", "percent_used": "0%"}
+		]`))
+	})
+})
@@ -3,6 +3,7 @@ package quota
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
@@ -27,12 +28,22 @@ func init() {
 }
 
 func (cmd *ListQuotas) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["fields"] = &flags.StringFlag{Name: "fields", Usage: T("Comma separated list of the columns to show, named in lower case with underscores for spaces, such as name,total_memory")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "quotas",
+		ShortName:   "org-quotas",
 		Description: T("List available usage quotas"),
 		Usage: []string{
-			T("CF_NAME quotas"),
+			T("CF_NAME quotas [--fields FIELDS]"),
+		},
+		Examples: []string{
+			"CF_NAME quotas --fields name,total_memory,app_instances",
+			"CF_NAME org-quotas --output json",
 		},
+		Flags:            fs,
+		StructuredOutput: true,
 	}
 }
 
@@ -67,9 +78,6 @@ func (cmd *ListQuotas) Execute(c flags.FlagContext) error {
 	if err != nil {
 		return err
 	}
-	cmd.ui.Ok()
-	cmd.ui.Say("")
-
 	table := cmd.ui.Table([]string{
 		T("name"),
 		T("total memory"),
@@ -80,6 +88,17 @@ func (cmd *ListQuotas) Execute(c flags.FlagContext) error {
 		T("app instances"),
 		T("route ports"),
 	})
+	table.Table.SetFieldKeys("name", "total_memory", "instance_memory", "routes", "service_instances", "paid_plans", "app_instances", "route_ports")
+
+	if c.IsSet("fields") {
+		err = table.Table.SelectFields(strings.Split(c.String("fields"), ","))
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
 
 	var megabytes string
 	for _, quota := range quotas {
@@ -1,6 +1,8 @@
 package quota_test
 
 import (
+	"strings"
+
 	"code.cloudfoundry.org/cli/cf/api/quotas/quotasfakes"
 	"code.cloudfoundry.org/cli/cf/commands/quota"
 	"code.cloudfoundry.org/cli/cf/errors"
@@ -23,6 +25,7 @@ import (
 var _ = Describe("quotas command", func() {
 	var (
 		ui                  *testterm.FakeUI
+		commandUI           terminal.UI
 		config              coreconfig.Repository
 		quotaRepo           *quotasfakes.FakeQuotaRepository
 		requirementsFactory *requirementsfakes.FakeFactory
@@ -30,7 +33,7 @@ var _ = Describe("quotas command", func() {
 	)
 
 	updateCommandDependency := func(pluginCall bool) {
-		deps.UI = ui
+		deps.UI = commandUI
 		deps.Config = config
 		deps.RepoLocator = deps.RepoLocator.SetQuotaRepository(quotaRepo)
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("quotas").SetDependency(deps, pluginCall))
@@ -38,6 +41,7 @@ var _ = Describe("quotas command", func() {
 
 	BeforeEach(func() {
 		ui = &testterm.FakeUI{}
+		commandUI = ui
 		quotaRepo = new(quotasfakes.FakeQuotaRepository)
 		requirementsFactory = new(requirementsfakes.FakeFactory)
 		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
@@ -176,6 +180,60 @@ var _ = Describe("quotas command", func() {
 		})
 	})
 
+	Describe("selecting and formatting fields", func() {
+		BeforeEach(func() {
+			quotaRepo.FindAllReturns([]models.QuotaFields{
+				{
+					Name:                    "quota-name",
+					MemoryLimit:             1024,
+					InstanceMemoryLimit:     512,
+					RoutesLimit:             111,
+					ServicesLimit:           222,
+					NonBasicServicesAllowed: true,
+					AppInstanceLimit:        -1,
+					ReservedRoutePorts:      "4",
+				},
+			}, nil)
+		})
+
+		It("is also available as org-quotas", func() {
+			Expect(commandregistry.Commands.FindCommand("org-quotas").MetaData().Name).To(Equal("quotas"))
+		})
+
+		It("shows only the columns given with --fields", func() {
+			Expect(runCommand("--fields", "name,routes")).To(HavePassedRequirements())
+			Expect(terminal.Decolorize(ui.Outputs()[3])).To(MatchRegexp(`^name\s+routes$`))
+			Expect(terminal.Decolorize(ui.Outputs()[4])).To(MatchRegexp(`^quota-name\s+111$`))
+		})
+
+		It("fails for an unknown field", func() {
+			runCommand("--fields", "name,colour")
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Unknown field colour"},
+			))
+		})
+
+		It("prints the quotas as JSON", func() {
+			formattedUI := terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+			commandUI = formattedUI
+
+			Expect(runCommand()).To(HavePassedRequirements())
+			Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+			Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`[{
+				"name": "quota-name",
+				"total_memory": "1G",
+				"instance_memory": "512M",
+				"routes": "111",
+				"service_instances": "222",
+				"paid_plans": "allowed",
+				"app_instances": "unlimited",
+				"route_ports": "4"
+			}]`))
+		})
+	})
+
 	Context("when an error occurs fetching quotas", func() {
 		BeforeEach(func() {
 			quotaRepo.FindAllReturns([]models.QuotaFields{}, errors.New("I haz a borken!"))
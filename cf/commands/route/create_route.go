@@ -1,6 +1,7 @@
 package route
 
 import (
+	"errors"
 	"fmt"
 
 	"code.cloudfoundry.org/cli/cf"
@@ -21,11 +22,12 @@ type Creator interface {
 }
 
 type CreateRoute struct {
-	ui        terminal.UI
-	config    coreconfig.Reader
-	routeRepo api.RouteRepository
-	spaceReq  requirements.SpaceRequirement
-	domainReq requirements.DomainRequirement
+	ui             terminal.UI
+	config         coreconfig.Reader
+	routeRepo      api.RouteRepository
+	routingAPIRepo api.RoutingAPIRepository
+	spaceReq       requirements.SpaceRequirement
+	domainReq      requirements.DomainRequirement
 }
 
 func init() {
@@ -53,21 +55,23 @@ func (cmd *CreateRoute) MetaData() commandregistry.CommandMetadata {
 			"      CF_NAME create-route ",
 			fmt.Sprintf("%s ", T("SPACE")),
 			fmt.Sprintf("%s ", T("DOMAIN")),
-			fmt.Sprintf("(--port %s | --random-port)", T("PORT")),
+			fmt.Sprintf("(--port %s | --random-port)\n\n", T("PORT")),
+			fmt.Sprintf("   %s", T("Without SPACE, the route is created in the targeted space.")),
 		},
 		Examples: []string{
 			"CF_NAME create-route my-space example.com                             # example.com",
 			"CF_NAME create-route my-space example.com --hostname myapp            # myapp.example.com",
 			"CF_NAME create-route my-space example.com --hostname myapp --path foo # myapp.example.com/foo",
 			"CF_NAME create-route my-space example.com --port 50000                # example.com:50000",
+			"CF_NAME create-route tcp.example.com --port 60001                     # tcp.example.com:60001 in the targeted space",
 		},
 		Flags: fs,
 	}
 }
 
 func (cmd *CreateRoute) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
-	if len(fc.Args()) != 2 {
-		cmd.ui.Failed(T("Incorrect Usage. Requires SPACE and DOMAIN as arguments\n\n") + commandregistry.Commands.CommandUsage("create-route"))
+	if len(fc.Args()) != 1 && len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires DOMAIN, or SPACE and DOMAIN, as arguments\n\n") + commandregistry.Commands.CommandUsage("create-route"))
 		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 2)
 	}
 
@@ -81,18 +85,25 @@ func (cmd *CreateRoute) Requirements(requirementsFactory requirements.Factory, f
 		return nil, fmt.Errorf("Cannot specify random-port together with port, hostname and/or path.")
 	}
 
-	domainName := fc.Args()[1]
-
-	cmd.spaceReq = requirementsFactory.NewSpaceRequirement(fc.Args()[0])
-	cmd.domainReq = requirementsFactory.NewDomainRequirement(domainName)
-
 	reqs := []requirements.Requirement{
 		requirementsFactory.NewLoginRequirement(),
 		requirementsFactory.NewTargetedOrgRequirement(),
-		cmd.spaceReq,
-		cmd.domainReq,
 	}
 
+	var domainName string
+	if len(fc.Args()) == 1 {
+		domainName = fc.Args()[0]
+		cmd.spaceReq = nil
+		reqs = append(reqs, requirementsFactory.NewTargetedSpaceRequirement())
+	} else {
+		domainName = fc.Args()[1]
+		cmd.spaceReq = requirementsFactory.NewSpaceRequirement(fc.Args()[0])
+		reqs = append(reqs, cmd.spaceReq)
+	}
+
+	cmd.domainReq = requirementsFactory.NewDomainRequirement(domainName)
+	reqs = append(reqs, cmd.domainReq)
+
 	if fc.IsSet("path") {
 		reqs = append(reqs, requirementsFactory.NewMinAPIVersionRequirement("Option '--path'", cf.RoutePathMinimumAPIVersion))
 	}
@@ -112,18 +123,35 @@ func (cmd *CreateRoute) SetDependency(deps commandregistry.Dependency, pluginCal
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.routeRepo = deps.RepoLocator.GetRouteRepository()
+	cmd.routingAPIRepo = deps.RepoLocator.GetRoutingAPIRepository()
 	return cmd
 }
 
 func (cmd *CreateRoute) Execute(c flags.FlagContext) error {
 	hostName := c.String("n")
-	space := cmd.spaceReq.GetSpace()
 	domain := cmd.domainReq.GetDomain()
 	path := c.String("path")
 	port := c.Int("port")
 	randomPort := c.Bool("random-port")
 
-	_, err := cmd.CreateRoute(hostName, path, port, randomPort, domain, space.SpaceFields)
+	space := cmd.config.SpaceFields()
+	if cmd.spaceReq != nil {
+		space = cmd.spaceReq.GetSpace().SpaceFields
+	}
+
+	err := validateInternalRoute(domain, path)
+	if err != nil {
+		return err
+	}
+
+	if c.IsSet("port") {
+		err = cmd.checkPortIsReservable(domain, port)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = cmd.CreateRoute(hostName, path, port, randomPort, domain, space)
 	if err != nil {
 		return err
 	}
@@ -131,6 +159,45 @@ func (cmd *CreateRoute) Execute(c flags.FlagContext) error {
 	return nil
 }
 
+// validateInternalRoute fails for a route with a path on an internal domain:
+// container-to-container traffic is not routed by path.
+func validateInternalRoute(domain models.DomainFields, path string) error {
+	if domain.Internal && path != "" {
+		return errors.New(T("Path not allowed in internal route {{.RouteName}}",
+			map[string]interface{}{"RouteName": domain.Name}))
+	}
+	return nil
+}
+
+// checkPortIsReservable fails when the router group of the TCP domain does not
+// reserve port, so that the user learns which ports it does reserve. When the
+// router group cannot be looked up the Cloud Controller is left to decide.
+func (cmd *CreateRoute) checkPortIsReservable(domain models.DomainFields, port int) error {
+	if domain.RouterGroupGUID == "" || cmd.config.RoutingAPIEndpoint() == "" {
+		return nil
+	}
+
+	var routerGroup models.RouterGroup
+	err := cmd.routingAPIRepo.ListRouterGroups(func(group models.RouterGroup) bool {
+		if group.GUID == domain.RouterGroupGUID {
+			routerGroup = group
+			return false
+		}
+		return true
+	})
+	if err != nil || routerGroup.ReservablePorts == "" || routerGroup.ReservesPort(port) {
+		return nil
+	}
+
+	return errors.New(T("Port {{.Port}} cannot be reserved on domain {{.Domain}}; its router group {{.RouterGroup}} reserves ports {{.Ports}}",
+		map[string]interface{}{
+			"Port":        port,
+			"Domain":      domain.Name,
+			"RouterGroup": routerGroup.Name,
+			"Ports":       routerGroup.ReservablePorts,
+		}))
+}
+
 func (cmd *CreateRoute) CreateRoute(hostName string, path string, port int, randomPort bool, domain models.DomainFields, space models.SpaceFields) (models.Route, error) {
 	cmd.ui.Say(T("Creating route {{.URL}} for org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
 		map[string]interface{}{
@@ -25,9 +25,10 @@ import (
 
 var _ = Describe("CreateRoute", func() {
 	var (
-		ui         *testterm.FakeUI
-		routeRepo  *apifakes.FakeRouteRepository
-		configRepo coreconfig.Repository
+		ui             *testterm.FakeUI
+		routeRepo      *apifakes.FakeRouteRepository
+		routingAPIRepo *apifakes.FakeRoutingAPIRepository
+		configRepo     coreconfig.Repository
 
 		cmd         commandregistry.Command
 		deps        commandregistry.Dependency
@@ -43,7 +44,8 @@ var _ = Describe("CreateRoute", func() {
 		ui = &testterm.FakeUI{}
 		configRepo = testconfig.NewRepositoryWithDefaults()
 		routeRepo = new(apifakes.FakeRouteRepository)
-		repoLocator := deps.RepoLocator.SetRouteRepository(routeRepo)
+		routingAPIRepo = new(apifakes.FakeRoutingAPIRepository)
+		repoLocator := deps.RepoLocator.SetRouteRepository(routeRepo).SetRoutingAPIRepository(routingAPIRepo)
 
 		deps = commandregistry.Dependency{
 			UI:          ui,
@@ -77,9 +79,9 @@ var _ = Describe("CreateRoute", func() {
 	})
 
 	Describe("Requirements", func() {
-		Context("when not provided exactly two args", func() {
+		Context("when not provided one or two args", func() {
 			BeforeEach(func() {
-				err := flagContext.Parse("space-name")
+				err := flagContext.Parse("space-name", "domain-name", "extra")
 				Expect(err).NotTo(HaveOccurred())
 			})
 
@@ -87,7 +89,7 @@ var _ = Describe("CreateRoute", func() {
 				_, err := cmd.Requirements(factory, flagContext)
 				Expect(err).To(HaveOccurred())
 				Expect(ui.Outputs()).To(ContainSubstrings(
-					[]string{"Incorrect Usage. Requires SPACE and DOMAIN as arguments"},
+					[]string{"Incorrect Usage. Requires DOMAIN, or SPACE and DOMAIN, as arguments"},
 					[]string{"NAME"},
 					[]string{"USAGE"},
 				))
@@ -119,6 +121,32 @@ var _ = Describe("CreateRoute", func() {
 			})
 		})
 
+		Context("when provided only a domain", func() {
+			var targetedSpaceRequirement requirements.Requirement
+
+			BeforeEach(func() {
+				targetedSpaceRequirement = &passingRequirement{Name: "targeted-space-requirement"}
+				factory.NewTargetedSpaceRequirementReturns(targetedSpaceRequirement)
+
+				err := flagContext.Parse("domain-name")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a TargetedSpaceRequirement instead of a SpaceRequirement", func() {
+				actualRequirements, err := cmd.Requirements(factory, flagContext)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(factory.NewSpaceRequirementCallCount()).To(Equal(0))
+				Expect(actualRequirements).To(ContainElement(targetedSpaceRequirement))
+			})
+
+			It("returns a DomainRequirement for the domain", func() {
+				actualRequirements, err := cmd.Requirements(factory, flagContext)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(factory.NewDomainRequirementArgsForCall(0)).To(Equal("domain-name"))
+				Expect(actualRequirements).To(ContainElement(domainRequirement))
+			})
+		})
+
 		Context("when the --path option is given", func() {
 			BeforeEach(func() {
 				err := flagContext.Parse("space-name", "domain-name", "--path", "path")
@@ -315,6 +343,21 @@ var _ = Describe("CreateRoute", func() {
 				_, path, _, _, _, _ := routeRepo.CreateInSpaceArgsForCall(0)
 				Expect(path).To(Equal("some-path"))
 			})
+
+			Context("when the domain is internal", func() {
+				BeforeEach(func() {
+					domainRequirement.GetDomainReturns(models.DomainFields{
+						GUID:     "domain-guid",
+						Name:     "apps.internal",
+						Internal: true,
+					})
+				})
+
+				It("returns an error without creating the route", func() {
+					Expect(err).To(MatchError("Path not allowed in internal route apps.internal"))
+					Expect(routeRepo.CreateInSpaceCallCount()).To(BeZero())
+				})
+			})
 		})
 
 		Context("when the --random-port option is given", func() {
@@ -347,6 +390,79 @@ var _ = Describe("CreateRoute", func() {
 			})
 		})
 
+		Context("when only a domain is given", func() {
+			BeforeEach(func() {
+				flagContext = flags.NewFlagContext(cmd.MetaData().Flags)
+				err := flagContext.Parse("domain-name", "--port", "60001")
+				Expect(err).NotTo(HaveOccurred())
+				cmd.Requirements(factory, flagContext)
+			})
+
+			It("creates the route in the targeted space", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(routeRepo.CreateInSpaceCallCount()).To(Equal(1))
+				_, _, _, space, port, _ := routeRepo.CreateInSpaceArgsForCall(0)
+				Expect(space).To(Equal(configRepo.SpaceFields().GUID))
+				Expect(port).To(Equal(60001))
+				Expect(spaceRequirement.GetSpaceCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the --port option is given for a domain of a router group", func() {
+			BeforeEach(func() {
+				domainRequirement.GetDomainReturns(models.DomainFields{
+					GUID:            "domain-guid",
+					Name:            "tcp.example.com",
+					RouterGroupGUID: "router-group-guid",
+				})
+				configRepo.SetRoutingAPIEndpoint("https://routing-api.example.com")
+				routingAPIRepo.ListRouterGroupsStub = func(cb func(models.RouterGroup) bool) error {
+					cb(models.RouterGroup{GUID: "other-guid", Name: "other", ReservablePorts: "9090"})
+					cb(models.RouterGroup{GUID: "router-group-guid", Name: "default-tcp", ReservablePorts: "1024-1033"})
+					return nil
+				}
+			})
+
+			Context("when the router group reserves the port", func() {
+				BeforeEach(func() {
+					err := flagContext.Parse("space-name", "domain-name", "--port", "1030")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("creates the route", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(routeRepo.CreateInSpaceCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the router group does not reserve the port", func() {
+				BeforeEach(func() {
+					err := flagContext.Parse("space-name", "domain-name", "--port", "9090")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("fails with the ports it does reserve", func() {
+					Expect(err).To(MatchError("Port 9090 cannot be reserved on domain tcp.example.com; its router group default-tcp reserves ports 1024-1033"))
+					Expect(routeRepo.CreateInSpaceCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the router groups cannot be listed", func() {
+				BeforeEach(func() {
+					routingAPIRepo.ListRouterGroupsStub = nil
+					routingAPIRepo.ListRouterGroupsReturns(errors.New("routing-api-err"))
+					err := flagContext.Parse("space-name", "domain-name", "--port", "9090")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("leaves it to the Cloud Controller", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(routeRepo.CreateInSpaceCallCount()).To(Equal(1))
+				})
+			})
+		})
+
 		Context("when the --hostname option is given", func() {
 			BeforeEach(func() {
 				err := flagContext.Parse("space-name", "domain-name", "--hostname", "host")
@@ -26,12 +26,17 @@ func init() {
 func (cmd *DeleteOrphanedRoutes) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force deletion without confirmation")}
+	fs["dry-run"] = &flags.BoolFlag{Name: "dry-run", Usage: T("List the orphaned routes that would be deleted without deleting them")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "delete-orphaned-routes",
 		Description: T("Delete all orphaned routes (i.e. those that are not mapped to an app)"),
 		Usage: []string{
-			T("CF_NAME delete-orphaned-routes [-f]"),
+			T("CF_NAME delete-orphaned-routes [-f] [--dry-run]"),
+		},
+		Examples: []string{
+			"CF_NAME delete-orphaned-routes --dry-run",
+			"CF_NAME delete-orphaned-routes -f",
 		},
 		Flags: fs,
 	}
@@ -61,36 +66,59 @@ func (cmd *DeleteOrphanedRoutes) SetDependency(deps commandregistry.Dependency,
 }
 
 func (cmd *DeleteOrphanedRoutes) Execute(c flags.FlagContext) error {
-	force := c.Bool("f")
-	if !force {
-		response := cmd.ui.Confirm(T("Really delete orphaned routes?{{.Prompt}}",
-			map[string]interface{}{"Prompt": terminal.PromptColor(">")}))
-
-		if !response {
-			return nil
-		}
-	}
-
 	cmd.ui.Say(T("Getting routes as {{.Username}} ...\n",
 		map[string]interface{}{"Username": terminal.EntityNameColor(cmd.config.Username())}))
 
+	orphanedRoutes := []models.Route{}
 	err := cmd.routeRepo.ListRoutes(func(route models.Route) bool {
-
 		if len(route.Apps) == 0 {
-			cmd.ui.Say(T("Deleting route {{.Route}}...",
-				map[string]interface{}{"Route": terminal.EntityNameColor(route.URL())}))
-			apiErr := cmd.routeRepo.Delete(route.GUID)
-			if apiErr != nil {
-				cmd.ui.Failed(apiErr.Error())
-				return false
-			}
+			orphanedRoutes = append(orphanedRoutes, route)
 		}
 		return true
 	})
-
 	if err != nil {
 		return errors.New(T("Failed fetching routes.\n{{.Err}}", map[string]interface{}{"Err": err.Error()}))
 	}
+
+	if len(orphanedRoutes) == 0 {
+		cmd.ui.Say(T("No orphaned routes found"))
+		return nil
+	}
+
+	if c.Bool("dry-run") {
+		cmd.ui.Say(T("The following routes would be deleted:"))
+	} else {
+		cmd.ui.Say(T("The following routes will be deleted:"))
+	}
+	for _, route := range orphanedRoutes {
+		cmd.ui.Say("  %s", terminal.EntityNameColor(route.URL()))
+	}
+	cmd.ui.Say("")
+
+	if c.Bool("dry-run") {
+		cmd.ui.Say(T("{{.Count}} orphaned routes found; none were deleted because of --dry-run.",
+			map[string]interface{}{"Count": len(orphanedRoutes)}))
+		return nil
+	}
+
+	if !c.Bool("f") {
+		response := cmd.ui.Confirm(T("Really delete orphaned routes?{{.Prompt}}",
+			map[string]interface{}{"Prompt": terminal.PromptColor(">")}))
+
+		if !response {
+			return nil
+		}
+	}
+
+	for _, route := range orphanedRoutes {
+		cmd.ui.Say(T("Deleting route {{.Route}}...",
+			map[string]interface{}{"Route": terminal.EntityNameColor(route.URL())}))
+		err = cmd.routeRepo.Delete(route.GUID)
+		if err != nil {
+			return err
+		}
+	}
+
 	cmd.ui.Ok()
 	return nil
 }
@@ -1,6 +1,8 @@
 package route_test
 
 import (
+	"errors"
+
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
 	"code.cloudfoundry.org/cli/cf/flags"
 	"code.cloudfoundry.org/cli/cf/models"
@@ -162,5 +164,69 @@ var _ = Describe("delete-orphaned-routes command", func() {
 			Expect(routeRepo.DeleteCallCount()).To(Equal(1))
 			Expect(routeRepo.DeleteArgsForCall(0)).To(Equal("route2-guid"))
 		})
+
+		Context("when there are orphaned routes", func() {
+			BeforeEach(func() {
+				routeRepo.ListRoutesStub = func(cb func(models.Route) bool) error {
+					cb(models.Route{
+						GUID:   "route1-guid",
+						Host:   "hostname-1",
+						Domain: models.DomainFields{Name: "example.com"},
+						Apps:   []models.ApplicationFields{{Name: "dora"}},
+					})
+					cb(models.Route{GUID: "route2-guid", Host: "hostname-2", Domain: models.DomainFields{Name: "example.com"}})
+					cb(models.Route{GUID: "route3-guid", Host: "hostname-3", Domain: models.DomainFields{Name: "example.com"}})
+					return nil
+				}
+			})
+
+			It("lists the routes before asking to delete them", func() {
+				ui, _ := callDeleteOrphanedRoutes("n", []string{}, requirementsFactory, routeRepo)
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"The following routes will be deleted"},
+					[]string{"hostname-2.example.com"},
+					[]string{"hostname-3.example.com"},
+				))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"hostname-1.example.com"}))
+				Expect(ui.Prompts).To(ContainSubstrings([]string{"Really delete orphaned routes"}))
+				Expect(routeRepo.DeleteCallCount()).To(Equal(0))
+			})
+
+			It("only lists the routes with --dry-run", func() {
+				ui, passed := callDeleteOrphanedRoutes("", []string{"--dry-run"}, requirementsFactory, routeRepo)
+				Expect(passed).To(BeTrue())
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"The following routes would be deleted"},
+					[]string{"hostname-2.example.com"},
+					[]string{"hostname-3.example.com"},
+					[]string{"2 orphaned routes found", "none were deleted"},
+				))
+				Expect(ui.Prompts).To(BeEmpty())
+				Expect(routeRepo.DeleteCallCount()).To(Equal(0))
+			})
+
+			It("fails when a route cannot be deleted", func() {
+				routeRepo.DeleteReturns(errors.New("delete-err"))
+
+				ui, passed := callDeleteOrphanedRoutes("", []string{"-f"}, requirementsFactory, routeRepo)
+				Expect(passed).To(BeFalse())
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Deleting route", "hostname-2.example.com"},
+					[]string{"FAILED"},
+					[]string{"delete-err"},
+				))
+				Expect(routeRepo.DeleteCallCount()).To(Equal(1))
+			})
+		})
+
+		It("says so when there are no orphaned routes", func() {
+			ui, _ := callDeleteOrphanedRoutes("y", []string{}, requirementsFactory, routeRepo)
+
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"No orphaned routes found"}))
+			Expect(ui.Prompts).To(BeEmpty())
+		})
 	})
 })
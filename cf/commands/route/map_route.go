@@ -131,6 +131,11 @@ func (cmd *MapRoute) Execute(c flags.FlagContext) error {
 	domain := cmd.domainReq.GetDomain()
 	app := cmd.appReq.GetApplication()
 
+	err := validateInternalRoute(domain, path)
+	if err != nil {
+		return err
+	}
+
 	port := c.Int("port")
 	randomPort := c.Bool("random-port")
 	route, err := cmd.routeCreator.CreateRoute(hostName, path, port, randomPort, domain, cmd.config.SpaceFields())
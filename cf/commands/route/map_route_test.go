@@ -533,6 +533,23 @@ var _ = Describe("MapRoute", func() {
 				_, path, _, _, _, _ := fakeRouteCreator.CreateRouteArgsForCall(0)
 				Expect(path).To(Equal("the-path"))
 			})
+
+			Context("when the domain is internal", func() {
+				BeforeEach(func() {
+					domainRequirement.GetDomainReturns(models.DomainFields{
+						GUID:     "domain-guid",
+						Name:     "apps.internal",
+						Internal: true,
+					})
+				})
+
+				It("returns an error without creating the route", func() {
+					Expect(err).To(MatchError("Path not allowed in internal route apps.internal"))
+					fakeRouteCreator, ok := fakeCreateRouteCmd.(*routefakes.OldFakeRouteCreator)
+					Expect(ok).To(BeTrue())
+					Expect(fakeRouteCreator.CreateRouteCallCount()).To(BeZero())
+				})
+			})
 		})
 	})
 })
@@ -12,8 +12,10 @@ import (
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/cf/uihelpers"
 )
 
 type ListRoutes struct {
@@ -21,6 +23,7 @@ type ListRoutes struct {
 	routeRepo  api.RouteRepository
 	domainRepo api.DomainRepository
 	config     coreconfig.Reader
+	gateway    net.Gateway
 }
 
 func init() {
@@ -30,15 +33,17 @@ func init() {
 func (cmd *ListRoutes) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["orglevel"] = &flags.BoolFlag{Name: "orglevel", Usage: T("List all the routes for all spaces of current organization")}
+	fs["max-results"] = &flags.IntFlag{Name: "max-results", Usage: T("Stop after listing this many routes")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "routes",
 		ShortName:   "r",
 		Description: T("List all routes in the current space or the current organization"),
 		Usage: []string{
-			"CF_NAME routes [--orglevel]",
+			"CF_NAME routes [--orglevel] [--max-results NUM]",
 		},
-		Flags: fs,
+		Flags:            fs,
+		StructuredOutput: true,
 	}
 }
 
@@ -64,12 +69,19 @@ func (cmd *ListRoutes) SetDependency(deps commandregistry.Dependency, pluginCall
 	cmd.config = deps.Config
 	cmd.routeRepo = deps.RepoLocator.GetRouteRepository()
 	cmd.domainRepo = deps.RepoLocator.GetDomainRepository()
+	cmd.gateway = deps.Gateways["cloud-controller"]
 	return cmd
 }
 
 func (cmd *ListRoutes) Execute(c flags.FlagContext) error {
 	orglevel := c.Bool("orglevel")
 
+	maxResults := c.Int("max-results")
+	if c.IsSet("max-results") && maxResults < 1 {
+		return errors.New(T("Invalid value {{.Value}} for --max-results; it must be at least 1",
+			map[string]interface{}{"Value": maxResults}))
+	}
+
 	if orglevel {
 		cmd.ui.Say(T("Getting routes for org {{.OrgName}} as {{.Username}} ...\n",
 			map[string]interface{}{
@@ -85,7 +97,9 @@ func (cmd *ListRoutes) Execute(c flags.FlagContext) error {
 			}))
 	}
 
-	table := cmd.ui.Table([]string{T("space"), T("host"), T("domain"), T("port"), T("path"), T("type"), T("apps"), T("service")})
+	uiTable := cmd.ui.Table([]string{T("space"), T("host"), T("domain"), T("port"), T("path"), T("type"), T("apps"), T("service")})
+	uiTable.Table.SetFieldKeys("space", "host", "domain", "port", "path", "type", "apps", "service")
+	table := uihelpers.NewPagedTable(uiTable)
 
 	d := make(map[string]models.DomainFields)
 	err := cmd.domainRepo.ListDomainsForOrg(cmd.config.OrganizationFields().GUID, func(domain models.DomainFields) bool {
@@ -101,9 +115,7 @@ func (cmd *ListRoutes) Execute(c flags.FlagContext) error {
 		))
 	}
 
-	var routesFound bool
 	cb := func(route models.Route) bool {
-		routesFound = true
 		appNames := []string{}
 		for _, app := range route.Apps {
 			appNames = append(appNames, app.Name)
@@ -126,9 +138,12 @@ func (cmd *ListRoutes) Execute(c flags.FlagContext) error {
 			strings.Join(appNames, ","),
 			route.ServiceInstance.Name,
 		)
-		return true
+		return maxResults == 0 || table.Rows() < maxResults
 	}
 
+	cmd.gateway.SetPageListener(table.PageDone)
+	defer cmd.gateway.SetPageListener(nil)
+
 	if orglevel {
 		err = cmd.routeRepo.ListAllRoutes(cb)
 	} else {
@@ -143,7 +158,7 @@ func (cmd *ListRoutes) Execute(c flags.FlagContext) error {
 		return err
 	}
 
-	if !routesFound {
+	if table.Rows() == 0 {
 		cmd.ui.Say(T("No routes found"))
 	}
 	return nil
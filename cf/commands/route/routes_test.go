@@ -163,6 +163,39 @@ var _ = Describe("routes command", func() {
 		})
 	})
 
+	Context("when --max-results is given", func() {
+		BeforeEach(func() {
+			routeRepo.ListRoutesStub = func(cb func(models.Route) bool) error {
+				for _, host := range []string{"hostname-1", "hostname-2", "hostname-3"} {
+					if !cb(models.Route{Host: host, Domain: models.DomainFields{Name: "example.com"}}) {
+						return nil
+					}
+				}
+				return nil
+			}
+		})
+
+		It("stops listing routes once it has listed that many", func() {
+			runCommand("--max-results", "2")
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"hostname-1"},
+				[]string{"hostname-2"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"hostname-3"}))
+		})
+
+		It("fails when the value is not positive", func() {
+			runCommand("--max-results", "0")
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Invalid value 0 for --max-results; it must be at least 1"},
+			))
+			Expect(routeRepo.ListRoutesCallCount()).To(BeZero())
+		})
+	})
+
 	Context("when there are routes in different spaces", func() {
 		BeforeEach(func() {
 			routeRepo.ListAllRoutesStub = func(cb func(models.Route) bool) error {
@@ -57,12 +57,12 @@ func (cmd *RouterGroups) Execute(c flags.FlagContext) error {
 	cmd.ui.Say(T("Getting router groups as {{.Username}} ...\n",
 		map[string]interface{}{"Username": terminal.EntityNameColor(cmd.config.Username())}))
 
-	table := cmd.ui.Table([]string{T("name"), T("type")})
+	table := cmd.ui.Table([]string{T("name"), T("type"), T("reservable ports")})
 
 	noRouterGroups := true
 	cb := func(group models.RouterGroup) bool {
 		noRouterGroups = false
-		table.Add(group.Name, group.Type)
+		table.Add(group.Name, group.Type, group.ReservablePorts)
 		return true
 	}
 
@@ -98,9 +98,10 @@ var _ = Describe("RouterGroups", func() {
 			BeforeEach(func() {
 				routerGroups := models.RouterGroups{
 					models.RouterGroup{
-						GUID: "guid-0001",
-						Name: "default-router-group",
-						Type: "tcp",
+						GUID:            "guid-0001",
+						Name:            "default-router-group",
+						Type:            "tcp",
+						ReservablePorts: "1024-1033",
 					},
 				}
 				routingAPIRepo.ListRouterGroupsStub = func(cb func(models.RouterGroup) bool) (apiErr error) {
@@ -118,8 +119,8 @@ var _ = Describe("RouterGroups", func() {
 
 				Expect(ui.Outputs()).To(ContainSubstrings(
 					[]string{"Getting router groups", "my-user"},
-					[]string{"name", "type"},
-					[]string{"default-router-group", "tcp"},
+					[]string{"name", "type", "reservable ports"},
+					[]string{"default-router-group", "tcp", "1024-1033"},
 				))
 			})
 		})
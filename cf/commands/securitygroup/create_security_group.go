@@ -1,7 +1,13 @@
 package securitygroup
 
 import (
+	encodingjson "encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
 
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
@@ -12,7 +18,6 @@ import (
 	"code.cloudfoundry.org/cli/cf/errors"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
-	"code.cloudfoundry.org/cli/utils/json"
 )
 
 type CreateSecurityGroup struct {
@@ -74,18 +79,9 @@ func (cmd *CreateSecurityGroup) SetDependency(deps commandregistry.Dependency, p
 func (cmd *CreateSecurityGroup) Execute(context flags.FlagContext) error {
 	name := context.Args()[0]
 	pathToJSONFile := context.Args()[1]
-	rules, err := json.ParseJSONArray(pathToJSONFile)
+	rules, err := parseSecurityGroupRules(pathToJSONFile)
 	if err != nil {
-		return errors.New(T(`Incorrect json format: file: {{.JSONFile}}
-		
-Valid json file example:
-[
-  {
-    "protocol": "tcp",
-    "destination": "10.244.1.18",
-    "ports": "3306"
-  }
-]`, map[string]interface{}{"JSONFile": pathToJSONFile}))
+		return err
 	}
 
 	cmd.ui.Say(T("Creating security group {{.security_group}} as {{.username}}",
@@ -114,3 +110,202 @@ Valid json file example:
 	cmd.ui.Ok()
 	return nil
 }
+
+const validSecurityGroupRulesExample = `[
+  {
+    "protocol": "tcp",
+    "destination": "10.244.1.18",
+    "ports": "3306"
+  }
+]`
+
+// parseSecurityGroupRules reads the rules of a security group from the JSON
+// file at path and checks them the way the Cloud Controller would, so that
+// mistakes are reported with the rule and key they are in before anything is
+// sent.
+func parseSecurityGroupRules(path string) ([]map[string]interface{}, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []map[string]interface{}{}
+	err = encodingjson.Unmarshal(bytes, &rules)
+	if err != nil {
+		detail := err.Error()
+		switch jsonErr := err.(type) {
+		case *encodingjson.SyntaxError:
+			line, column := jsonOffsetPosition(bytes, jsonErr.Offset)
+			detail = T("line {{.Line}}, column {{.Column}}: {{.Error}}",
+				map[string]interface{}{"Line": line, "Column": column, "Error": err.Error()})
+		case *encodingjson.UnmarshalTypeError:
+			line, column := jsonOffsetPosition(bytes, jsonErr.Offset)
+			detail = T("line {{.Line}}, column {{.Column}}: the file must hold a single array of rule objects",
+				map[string]interface{}{"Line": line, "Column": column})
+		}
+
+		return nil, errors.New(T("Incorrect json format: file: {{.JSONFile}}\n{{.Detail}}\n\nValid json file example:\n{{.Example}}",
+			map[string]interface{}{
+				"JSONFile": path,
+				"Detail":   detail,
+				"Example":  validSecurityGroupRulesExample,
+			}))
+	}
+
+	problems := []string{}
+	for i, rule := range rules {
+		for _, problem := range securityGroupRuleProblems(rule) {
+			problems = append(problems, T("rule {{.Number}}: {{.Problem}}",
+				map[string]interface{}{"Number": i + 1, "Problem": problem}))
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.New(T("Invalid security group rules in {{.JSONFile}}:\n{{.Problems}}",
+			map[string]interface{}{
+				"JSONFile": path,
+				"Problems": strings.Join(problems, "\n"),
+			}))
+	}
+
+	return rules, nil
+}
+
+// jsonOffsetPosition turns a byte offset into data into a line and column,
+// both counted from 1.
+func jsonOffsetPosition(data []byte, offset int64) (int, int) {
+	line, column := 1, 1
+	for i := int64(0); i < offset-1 && i < int64(len(data)); i++ {
+		if data[i] == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
+
+// securityGroupRuleProblems lists everything wrong with a single rule: its
+// protocol, destination, ports, ICMP type and code, and any unknown keys.
+func securityGroupRuleProblems(rule map[string]interface{}) []string {
+	problems := []string{}
+
+	protocol, _ := rule["protocol"].(string)
+	validProtocol := protocol == "tcp" || protocol == "udp" || protocol == "icmp" || protocol == "all"
+	switch {
+	case rule["protocol"] == nil:
+		problems = append(problems, T(`"protocol" is required`))
+	case !validProtocol:
+		problems = append(problems, T(`"protocol" must be tcp, udp, icmp or all, not {{.Value}}`,
+			map[string]interface{}{"Value": jsonValue(rule["protocol"])}))
+	}
+
+	destination, ok := rule["destination"].(string)
+	switch {
+	case rule["destination"] == nil:
+		problems = append(problems, T(`"destination" is required`))
+	case !ok || !isValidSecurityGroupDestination(destination):
+		problems = append(problems, T(`"destination" must be an IP address, a CIDR such as 10.0.0.0/24 or a range such as 10.0.0.1-10.0.0.9, not {{.Value}}`,
+			map[string]interface{}{"Value": jsonValue(rule["destination"])}))
+	}
+
+	// Which of ports, type and code a rule needs depends on its protocol.
+	if validProtocol {
+		ports, ok := rule["ports"].(string)
+		switch {
+		case protocol != "tcp" && protocol != "udp":
+			if rule["ports"] != nil {
+				problems = append(problems, T(`"ports" is only allowed for tcp and udp rules`))
+			}
+		case rule["ports"] == nil:
+			problems = append(problems, T(`"ports" is required for tcp and udp rules`))
+		case !ok || !isValidSecurityGroupPorts(ports):
+			problems = append(problems, T(`"ports" must be a port, a list of ports such as 80,443 or a range such as 8000-9000, not {{.Value}}`,
+				map[string]interface{}{"Value": jsonValue(rule["ports"])}))
+		}
+
+		for _, key := range []string{"type", "code"} {
+			switch {
+			case protocol != "icmp":
+				if rule[key] != nil {
+					problems = append(problems, T(`"{{.Key}}" is only allowed for icmp rules`, map[string]interface{}{"Key": key}))
+				}
+			case rule[key] == nil:
+				problems = append(problems, T(`"{{.Key}}" is required for icmp rules`, map[string]interface{}{"Key": key}))
+			default:
+				number, ok := rule[key].(float64)
+				if !ok || number != float64(int(number)) || number < -1 || number > 255 {
+					problems = append(problems, T(`"{{.Key}}" must be a number from -1 to 255, not {{.Value}}`,
+						map[string]interface{}{"Key": key, "Value": jsonValue(rule[key])}))
+				}
+			}
+		}
+	}
+
+	if _, ok := rule["log"].(bool); rule["log"] != nil && !ok {
+		problems = append(problems, T(`"log" must be true or false, not {{.Value}}`,
+			map[string]interface{}{"Value": jsonValue(rule["log"])}))
+	}
+
+	if _, ok := rule["description"].(string); rule["description"] != nil && !ok {
+		problems = append(problems, T(`"description" must be a string, not {{.Value}}`,
+			map[string]interface{}{"Value": jsonValue(rule["description"])}))
+	}
+
+	unknownKeys := []string{}
+	for key := range rule {
+		switch key {
+		case "protocol", "destination", "ports", "type", "code", "log", "description":
+		default:
+			unknownKeys = append(unknownKeys, key)
+		}
+	}
+	sort.Strings(unknownKeys)
+	for _, key := range unknownKeys {
+		problems = append(problems, T(`unknown key "{{.Key}}"`, map[string]interface{}{"Key": key}))
+	}
+
+	return problems
+}
+
+func isValidSecurityGroupDestination(destination string) bool {
+	if net.ParseIP(destination) != nil {
+		return true
+	}
+
+	if _, _, err := net.ParseCIDR(destination); err == nil {
+		return true
+	}
+
+	bounds := strings.Split(destination, "-")
+	return len(bounds) == 2 && net.ParseIP(bounds[0]) != nil && net.ParseIP(bounds[1]) != nil
+}
+
+func isValidSecurityGroupPorts(ports string) bool {
+	for _, part := range strings.Split(ports, ",") {
+		bounds := strings.Split(strings.TrimSpace(part), "-")
+		if len(bounds) > 2 {
+			return false
+		}
+
+		previous := 0
+		for _, bound := range bounds {
+			port, err := strconv.Atoi(strings.TrimSpace(bound))
+			if err != nil || port < 1 || port > 65535 || port < previous {
+				return false
+			}
+			previous = port
+		}
+	}
+	return true
+}
+
+// jsonValue shows value as it was written in the rules file.
+func jsonValue(value interface{}) string {
+	bytes, err := encodingjson.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(bytes)
+}
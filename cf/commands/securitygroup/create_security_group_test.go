@@ -145,5 +145,74 @@ var _ = Describe("create-security-group command", func() {
 				))
 			})
 		})
+
+		Context("when the file specified has a syntax error", func() {
+			BeforeEach(func() {
+				tempFile.Write([]byte("[\n  {\n    \"protocol\": \"tcp\",\n    \"ports\": \"443\"\n    \"destination\": \"10.0.0.1\"\n  }\n]"))
+			})
+
+			It("says where the error is", func() {
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Incorrect json format: file:", tempFile.Name()},
+					[]string{"line 5, column 5:", "invalid character"},
+				))
+				Expect(securityGroupRepo.CreateCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the file specified does not hold an array", func() {
+			BeforeEach(func() {
+				tempFile.Write([]byte(`{"protocol": "tcp", "ports": "443", "destination": "10.0.0.1"}`))
+			})
+
+			It("says that the rules must be an array", func() {
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"line 1, column 1:", "the file must hold a single array of rule objects"},
+				))
+			})
+		})
+
+		Context("when the rules in the file are invalid", func() {
+			BeforeEach(func() {
+				tempFile.Write([]byte(`[
+					{"protocol": "tcp", "ports": "443", "destination": "10.0.0.1"},
+					{"protocol": "tcp", "ports": "80,70000", "destination": "10.0.0.300", "log": "yes"},
+					{"protocol": "icmp", "type": 0, "destination": "10.0.0.0/8", "ports": "22"},
+					{"protocol": "sctp", "destination": "10.0.0.1-10.0.0.9", "port": "22"}
+				]`))
+			})
+
+			It("lists every problem with the rule it is in without creating the security group", func() {
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Invalid security group rules in", tempFile.Name()},
+					[]string{"rule 2:", `"destination" must be an IP address`, `not "10.0.0.300"`},
+					[]string{"rule 2:", `"ports" must be a port`, `not "80,70000"`},
+					[]string{"rule 2:", `"log" must be true or false, not "yes"`},
+					[]string{"rule 3:", `"ports" is only allowed for tcp and udp rules`},
+					[]string{"rule 3:", `"code" is required for icmp rules`},
+					[]string{"rule 4:", `"protocol" must be tcp, udp, icmp or all, not "sctp"`},
+					[]string{"rule 4:", `unknown key "port"`},
+				))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"rule 1:"}))
+				Expect(securityGroupRepo.CreateCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the file specified has icmp and all rules", func() {
+			BeforeEach(func() {
+				tempFile.Write([]byte(`[
+					{"protocol": "icmp", "type": -1, "code": -1, "destination": "0.0.0.0/0", "description": "ping"},
+					{"protocol": "all", "destination": "10.0.0.1-10.0.0.9", "log": true}
+				]`))
+			})
+
+			It("creates the security group", func() {
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"OK"}))
+				Expect(securityGroupRepo.CreateCallCount()).To(Equal(1))
+			})
+		})
 	})
 })
@@ -31,6 +31,7 @@ func (cmd *ShowSecurityGroup) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME security-group SECURITY_GROUP"),
 		},
+		StructuredOutput: true,
 	}
 }
 
@@ -68,6 +69,29 @@ func (cmd *ShowSecurityGroup) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	if printer, ok := cmd.ui.(terminal.DataPrinter); ok {
+		spaces := []map[string]string{}
+		for _, space := range securityGroup.Spaces {
+			spaces = append(spaces, map[string]string{
+				"organization": space.Organization.Name,
+				"space":        space.Name,
+			})
+		}
+
+		rules := securityGroup.Rules
+		if rules == nil {
+			rules = []map[string]interface{}{}
+		}
+
+		printer.SetData(map[string]interface{}{
+			"name":   securityGroup.Name,
+			"guid":   securityGroup.GUID,
+			"rules":  rules,
+			"spaces": spaces,
+		})
+		return nil
+	}
+
 	jsonEncodedBytes, err := json.MarshalIndent(securityGroup.Rules, "\t", "\t")
 	if err != nil {
 		return err
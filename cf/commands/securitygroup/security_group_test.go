@@ -1,12 +1,15 @@
 package securitygroup_test
 
 import (
+	"strings"
+
 	"code.cloudfoundry.org/cli/cf/api/securitygroups/securitygroupsfakes"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/errors"
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
 	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
@@ -20,6 +23,7 @@ import (
 var _ = Describe("security-group command", func() {
 	var (
 		ui                  *testterm.FakeUI
+		commandUI           terminal.UI
 		securityGroupRepo   *securitygroupsfakes.FakeSecurityGroupRepo
 		requirementsFactory *requirementsfakes.FakeFactory
 		configRepo          coreconfig.Repository
@@ -27,7 +31,7 @@ var _ = Describe("security-group command", func() {
 	)
 
 	updateCommandDependency := func(pluginCall bool) {
-		deps.UI = ui
+		deps.UI = commandUI
 		deps.RepoLocator = deps.RepoLocator.SetSecurityGroupRepository(securityGroupRepo)
 		deps.Config = configRepo
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("security-group").SetDependency(deps, pluginCall))
@@ -35,6 +39,7 @@ var _ = Describe("security-group command", func() {
 
 	BeforeEach(func() {
 		ui = &testterm.FakeUI{}
+		commandUI = ui
 		requirementsFactory = new(requirementsfakes.FakeFactory)
 		securityGroupRepo = new(securitygroupsfakes.FakeSecurityGroupRepo)
 		configRepo = testconfig.NewRepositoryWithDefaults()
@@ -110,6 +115,24 @@ var _ = Describe("security-group command", func() {
 				))
 			})
 
+			It("prints the group as JSON", func() {
+				formattedUI := terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+				commandUI = formattedUI
+
+				Expect(runCommand("my-group")).To(BeTrue())
+				Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+				Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`{
+					"name": "my-group",
+					"guid": "group-guid",
+					"rules": [{"just-pretend": "that-this-is-correct"}],
+					"spaces": [
+						{"organization": "org-1", "space": "space-1"},
+						{"organization": "org-2", "space": "space-2"}
+					]
+				}`))
+			})
+
 			It("tells the user if no spaces are assigned", func() {
 				securityGroup := models.SecurityGroup{
 					SecurityGroupFields: models.SecurityGroupFields{
@@ -11,7 +11,6 @@ import (
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
-	"code.cloudfoundry.org/cli/utils/json"
 )
 
 type UpdateSecurityGroup struct {
@@ -66,7 +65,7 @@ func (cmd *UpdateSecurityGroup) Execute(context flags.FlagContext) error {
 	}
 
 	pathToJSONFile := context.Args()[1]
-	rules, err := json.ParseJSONArray(pathToJSONFile)
+	rules, err := parseSecurityGroupRules(pathToJSONFile)
 	if err != nil {
 		return err
 	}
@@ -93,9 +93,24 @@ var _ = Describe("update-security-group command", func() {
 			runCommand("my-group-name", tempFile.Name())
 		})
 
+		Context("when the rules in the file are invalid", func() {
+			BeforeEach(func() {
+				tempFile.Write([]byte(`[{"protocol":"udp","destination":"198.41.191.47/1"}]`))
+			})
+
+			It("fails without updating the security group", func() {
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Invalid security group rules in", tempFile.Name()},
+					[]string{"rule 1:", `"ports" is required for tcp and udp rules`},
+				))
+				Expect(securityGroupRepo.UpdateCallCount()).To(BeZero())
+			})
+		})
+
 		Context("when the file specified has valid json", func() {
 			BeforeEach(func() {
-				tempFile.Write([]byte(`[{"protocol":"udp","port":"8080-9090","destination":"198.41.191.47/1"}]`))
+				tempFile.Write([]byte(`[{"protocol":"udp","ports":"8080-9090","destination":"198.41.191.47/1"}]`))
 			})
 
 			It("displays a message describing what its going to do", func() {
@@ -108,7 +123,7 @@ var _ = Describe("update-security-group command", func() {
 
 			It("updates the security group with those rules, obviously", func() {
 				jsonData := []map[string]interface{}{
-					{"protocol": "udp", "port": "8080-9090", "destination": "198.41.191.47/1"},
+					{"protocol": "udp", "ports": "8080-9090", "destination": "198.41.191.47/1"},
 				}
 
 				_, jsonArg := securityGroupRepo.UpdateArgsForCall(0)
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/errors"
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
 	"code.cloudfoundry.org/cli/cf/util"
@@ -60,8 +61,10 @@ func (cmd *BindRouteService) MetaData() commandregistry.CommandMetadata {
 			``,
 			T(`In Windows PowerShell use double-quoted, escaped JSON: "{\"valid\":\"json\"}"`),
 			T(`In Windows Command Line use single-quoted, escaped JSON: '{\"valid\":\"json\"}'`),
+			`CF_NAME bind-route-service example.com myratelimiter --hostname myapp --output json`,
 		},
-		Flags: fs,
+		Flags:            fs,
+		StructuredOutput: true,
 	}
 }
 
@@ -135,9 +138,11 @@ func (cmd *BindRouteService) Execute(c flags.FlagContext) error {
 			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
 		}))
 
+	alreadyBound := false
 	err = cmd.routeServiceBindingRepo.Bind(serviceInstance.GUID, route.GUID, serviceInstance.IsUserProvided(), parameters)
 	if err != nil {
 		if httpErr, ok := err.(errors.HTTPError); ok && httpErr.ErrorCode() == errors.ServiceInstanceAlreadyBoundToSameRoute {
+			alreadyBound = true
 			cmd.ui.Warn(T("Route {{.URL}} is already bound to service instance {{.ServiceInstanceName}}.",
 				map[string]interface{}{
 					"URL": route.URL(),
@@ -148,6 +153,27 @@ func (cmd *BindRouteService) Execute(c flags.FlagContext) error {
 		}
 	}
 
+	if printer, ok := cmd.ui.(terminal.DataPrinter); ok {
+		printer.SetData(routeServiceBindingData(route, serviceInstance, map[string]interface{}{"already_bound": alreadyBound}))
+		return nil
+	}
+
 	cmd.ui.Ok()
 	return nil
 }
+
+// routeServiceBindingData is what bind-route-service and unbind-route-service
+// print with --output json: the route and service instance, plus the fields
+// of extra.
+func routeServiceBindingData(route models.Route, serviceInstance models.ServiceInstance, extra map[string]interface{}) map[string]interface{} {
+	data := map[string]interface{}{
+		"route":                 route.URL(),
+		"route_guid":            route.GUID,
+		"service_instance":      serviceInstance.Name,
+		"service_instance_guid": serviceInstance.GUID,
+	}
+	for key, value := range extra {
+		data[key] = value
+	}
+	return data
+}
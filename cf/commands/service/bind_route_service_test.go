@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"strings"
 
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/commands/service"
@@ -13,6 +14,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
 	"github.com/blang/semver"
 
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
@@ -157,6 +159,48 @@ var _ = Describe("BindRouteService", func() {
 			runCLIErr = cmd.Execute(flagContext)
 		})
 
+		Context("with --output json", func() {
+			var formattedUI terminal.UI
+
+			BeforeEach(func() {
+				formattedUI = terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+				deps.UI = formattedUI
+				cmd.SetDependency(deps, false)
+
+				routeRepo.FindReturns(models.Route{GUID: "route-guid", Host: "my-app", Domain: fakeDomain}, nil)
+				serviceInstance := models.ServiceInstance{}
+				serviceInstance.GUID = "service-instance-guid"
+				serviceInstance.Name = "my-limiter"
+				serviceInstanceRequirement.GetServiceInstanceReturns(serviceInstance)
+			})
+
+			It("prints the binding", func() {
+				Expect(runCLIErr).NotTo(HaveOccurred())
+				Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+				Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`{
+					"route": "my-app.fake-domain-name",
+					"route_guid": "route-guid",
+					"service_instance": "my-limiter",
+					"service_instance_guid": "service-instance-guid",
+					"already_bound": false
+				}`))
+			})
+
+			Context("when the route is already bound", func() {
+				BeforeEach(func() {
+					routeServiceBindingRepo.BindReturns(errors.NewHTTPError(http.StatusOK, errors.ServiceInstanceAlreadyBoundToSameRoute, "http-err"))
+				})
+
+				It("says so in the JSON", func() {
+					Expect(runCLIErr).NotTo(HaveOccurred())
+					Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+					Expect(strings.Join(ui.Outputs(), "\n")).To(ContainSubstring(`"already_bound": true`))
+				})
+			})
+		})
+
 		It("tries to find the route", func() {
 			Expect(runCLIErr).NotTo(HaveOccurred())
 			Expect(routeRepo.FindCallCount()).To(Equal(1))
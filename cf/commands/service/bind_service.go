@@ -19,7 +19,7 @@ import (
 //go:generate counterfeiter . Binder
 
 type Binder interface {
-	BindApplication(app models.Application, serviceInstance models.ServiceInstance, paramsMap map[string]interface{}) (apiErr error)
+	BindApplication(app models.Application, serviceInstance models.ServiceInstance, bindingName string, paramsMap map[string]interface{}) (apiErr error)
 }
 
 type BindService struct {
@@ -35,7 +35,7 @@ func init() {
 }
 
 func (cmd *BindService) MetaData() commandregistry.CommandMetadata {
-	baseUsage := T("CF_NAME bind-service APP_NAME SERVICE_INSTANCE [-c PARAMETERS_AS_JSON]")
+	baseUsage := T("CF_NAME bind-service APP_NAME SERVICE_INSTANCE [-c PARAMETERS_AS_JSON] [--binding-name BINDING_NAME]")
 	paramsUsage := T(`   Optionally provide service-specific configuration parameters in a valid JSON object in-line:
 
    CF_NAME bind-service APP_NAME SERVICE_INSTANCE -c '{"name":"value","name":"value"}'
@@ -51,6 +51,7 @@ func (cmd *BindService) MetaData() commandregistry.CommandMetadata {
 
 	fs := make(map[string]flags.FlagSet)
 	fs["c"] = &flags.StringFlag{ShortName: "c", Usage: T("Valid JSON object containing service-specific configuration parameters, provided either in-line or in a file. For a list of supported configuration parameters, see documentation for the particular service offering.")}
+	fs["binding-name"] = &flags.StringFlag{Name: "binding-name", Usage: T("Name to expose service instance to app process with (Default: service instance name)")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "bind-service",
@@ -72,6 +73,8 @@ func (cmd *BindService) MetaData() commandregistry.CommandMetadata {
 			`   CF_NAME bind-service myapp mydb -c '{\"permissions\":\"read-only\"}'`,
 			``,
 			`CF_NAME bind-service myapp mydb -c ~/workspace/tmp/instance_config.json`,
+			``,
+			`CF_NAME bind-service myapp mydb --binding-name reports-db`,
 		},
 		Flags: fs,
 	}
@@ -108,22 +111,35 @@ func (cmd *BindService) Execute(c flags.FlagContext) error {
 	app := cmd.appReq.GetApplication()
 	serviceInstance := cmd.serviceInstanceReq.GetServiceInstance()
 	params := c.String("c")
+	bindingName := c.String("binding-name")
 
 	paramsMap, err := json.ParseJSONFromFileOrString(params)
 	if err != nil {
 		return errors.New(T("Invalid configuration provided for -c flag. Please provide a valid JSON object or path to a file containing a valid JSON object."))
 	}
 
-	cmd.ui.Say(T("Binding service {{.ServiceInstanceName}} to app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
-		map[string]interface{}{
-			"ServiceInstanceName": terminal.EntityNameColor(serviceInstance.Name),
-			"AppName":             terminal.EntityNameColor(app.Name),
-			"OrgName":             terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
-			"SpaceName":           terminal.EntityNameColor(cmd.config.SpaceFields().Name),
-			"CurrentUser":         terminal.EntityNameColor(cmd.config.Username()),
-		}))
+	if bindingName == "" {
+		cmd.ui.Say(T("Binding service {{.ServiceInstanceName}} to app {{.AppName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+			map[string]interface{}{
+				"ServiceInstanceName": terminal.EntityNameColor(serviceInstance.Name),
+				"AppName":             terminal.EntityNameColor(app.Name),
+				"OrgName":             terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName":           terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"CurrentUser":         terminal.EntityNameColor(cmd.config.Username()),
+			}))
+	} else {
+		cmd.ui.Say(T("Binding service {{.ServiceInstanceName}} to app {{.AppName}} with binding name {{.BindingName}} in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
+			map[string]interface{}{
+				"ServiceInstanceName": terminal.EntityNameColor(serviceInstance.Name),
+				"AppName":             terminal.EntityNameColor(app.Name),
+				"BindingName":         terminal.EntityNameColor(bindingName),
+				"OrgName":             terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+				"SpaceName":           terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+				"CurrentUser":         terminal.EntityNameColor(cmd.config.Username()),
+			}))
+	}
 
-	err = cmd.BindApplication(app, serviceInstance, paramsMap)
+	err = cmd.serviceBindingRepo.Create(serviceInstance.GUID, app.GUID, bindingName, paramsMap)
 	if err != nil {
 		if httperr, ok := err.(errors.HTTPError); ok && httperr.ErrorCode() == errors.ServiceBindingAppServiceTaken {
 			cmd.ui.Ok()
@@ -144,6 +160,6 @@ func (cmd *BindService) Execute(c flags.FlagContext) error {
 	return nil
 }
 
-func (cmd *BindService) BindApplication(app models.Application, serviceInstance models.ServiceInstance, paramsMap map[string]interface{}) error {
-	return cmd.serviceBindingRepo.Create(serviceInstance.GUID, app.GUID, paramsMap)
+func (cmd *BindService) BindApplication(app models.Application, serviceInstance models.ServiceInstance, bindingName string, paramsMap map[string]interface{}) error {
+	return cmd.serviceBindingRepo.Create(serviceInstance.GUID, app.GUID, bindingName, paramsMap)
 }
@@ -93,9 +93,23 @@ var _ = Describe("bind-service command", func() {
 			))
 
 			Expect(serviceBindingRepo.CreateCallCount()).To(Equal(1))
-			serviceInstanceGUID, applicationGUID, _ := serviceBindingRepo.CreateArgsForCall(0)
+			serviceInstanceGUID, applicationGUID, bindingName, _ := serviceBindingRepo.CreateArgsForCall(0)
 			Expect(serviceInstanceGUID).To(Equal("my-service-guid"))
 			Expect(applicationGUID).To(Equal("my-app-guid"))
+			Expect(bindingName).To(BeEmpty())
+		})
+
+		It("binds with the name given with --binding-name", func() {
+			callBindService([]string{"my-app", "my-service", "--binding-name", "my-binding"})
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Binding service", "my-service", "my-app", "with binding name", "my-binding"},
+				[]string{"OK"},
+			))
+
+			Expect(serviceBindingRepo.CreateCallCount()).To(Equal(1))
+			_, _, bindingName, _ := serviceBindingRepo.CreateArgsForCall(0)
+			Expect(bindingName).To(Equal("my-binding"))
 		})
 
 		It("warns the user when the service instance is already bound to the given app", func() {
@@ -150,7 +164,7 @@ var _ = Describe("bind-service command", func() {
 					))
 
 					Expect(serviceBindingRepo.CreateCallCount()).To(Equal(1))
-					serviceInstanceGUID, applicationGUID, createParams := serviceBindingRepo.CreateArgsForCall(0)
+					serviceInstanceGUID, applicationGUID, _, createParams := serviceBindingRepo.CreateArgsForCall(0)
 					Expect(serviceInstanceGUID).To(Equal("my-service-guid"))
 					Expect(applicationGUID).To(Equal("my-app-guid"))
 					Expect(createParams).To(Equal(map[string]interface{}{"foo": "bar"}))
@@ -202,7 +216,7 @@ var _ = Describe("bind-service command", func() {
 					))
 
 					Expect(serviceBindingRepo.CreateCallCount()).To(Equal(1))
-					serviceInstanceGUID, applicationGUID, createParams := serviceBindingRepo.CreateArgsForCall(0)
+					serviceInstanceGUID, applicationGUID, _, createParams := serviceBindingRepo.CreateArgsForCall(0)
 					Expect(serviceInstanceGUID).To(Equal("my-service-guid"))
 					Expect(applicationGUID).To(Equal("my-app-guid"))
 					Expect(createParams).To(Equal(map[string]interface{}{"foo": "bar"}))
@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf/actors/servicebuilder"
 	"code.cloudfoundry.org/cli/cf/api"
@@ -22,6 +23,8 @@ type CreateService struct {
 	config         coreconfig.Reader
 	serviceRepo    api.ServiceRepository
 	serviceBuilder servicebuilder.ServiceBuilder
+
+	OperationPollInterval time.Duration
 }
 
 func init() {
@@ -32,8 +35,9 @@ func (cmd *CreateService) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["c"] = &flags.StringFlag{ShortName: "c", Usage: T("Valid JSON object containing service-specific configuration parameters, provided either in-line or in a file. For a list of supported configuration parameters, see documentation for the particular service offering.")}
 	fs["t"] = &flags.StringFlag{ShortName: "t", Usage: T("User provided tags")}
+	fs["wait"] = &flags.BoolFlag{Name: "wait", Usage: T("Wait for the broker to finish creating the service instance")}
 
-	baseUsage := T("CF_NAME create-service SERVICE PLAN SERVICE_INSTANCE [-c PARAMETERS_AS_JSON] [-t TAGS]")
+	baseUsage := T("CF_NAME create-service SERVICE PLAN SERVICE_INSTANCE [-c PARAMETERS_AS_JSON] [-t TAGS] [--wait]")
 	paramsUsage := T(`   Optionally provide service-specific configuration parameters in a valid JSON object in-line:
 
    CF_NAME create-service SERVICE PLAN SERVICE_INSTANCE -c '{"name":"value","name":"value"}'
@@ -100,6 +104,7 @@ func (cmd *CreateService) SetDependency(deps commandregistry.Dependency, pluginC
 	cmd.config = deps.Config
 	cmd.serviceRepo = deps.RepoLocator.GetServiceRepository()
 	cmd.serviceBuilder = deps.ServiceBuilder
+	cmd.OperationPollInterval = time.Second
 	return cmd
 }
 
@@ -129,7 +134,11 @@ func (cmd *CreateService) Execute(c flags.FlagContext) error {
 
 	switch err.(type) {
 	case nil:
-		err := printSuccessMessageForServiceInstance(serviceInstanceName, cmd.serviceRepo, cmd.ui)
+		if c.Bool("wait") {
+			err = waitForServiceOperation(serviceInstanceName, cmd.serviceRepo, cmd.ui, cmd.OperationPollInterval)
+		} else {
+			err = printSuccessMessageForServiceInstance(serviceInstanceName, cmd.serviceRepo, cmd.ui)
+		}
 		if err != nil {
 			return err
 		}
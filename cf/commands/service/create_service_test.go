@@ -18,6 +18,7 @@ import (
 	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
 
 	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/service"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -43,7 +44,9 @@ var _ = Describe("create-service command", func() {
 		deps.Config = config
 		deps.RepoLocator = deps.RepoLocator.SetServiceRepository(serviceRepo)
 		deps.ServiceBuilder = serviceBuilder
-		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("create-service").SetDependency(deps, pluginCall))
+		cmd := commandregistry.Commands.FindCommand("create-service").SetDependency(deps, pluginCall).(*service.CreateService)
+		cmd.OperationPollInterval = 0
+		commandregistry.Commands.SetCommand(cmd)
 	}
 
 	BeforeEach(func() {
@@ -234,6 +237,48 @@ var _ = Describe("create-service command", func() {
 			Expect(planGUID).To(Equal("cleardb-spark-guid"))
 		})
 
+		Context("when --wait is given", func() {
+			It("waits for the broker to finish, printing each new description of its progress", func() {
+				descriptions := []string{"allocating VM", "allocating VM", "installing database"}
+				serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
+					instance := serviceInstance
+					call := serviceRepo.FindInstanceByNameCallCount()
+					if call > len(descriptions) {
+						instance.LastOperation.State = "succeeded"
+					} else {
+						instance.LastOperation.Description = descriptions[call-1]
+					}
+					return instance, nil
+				}
+
+				callCreateService([]string{"cleardb", "spark", "my-cleardb-service", "--wait"})
+
+				Expect(serviceRepo.FindInstanceByNameArgsForCall(0)).To(Equal("my-cleardb-service"))
+				Expect(serviceRepo.FindInstanceByNameCallCount()).To(Equal(4))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Creating service instance", "my-cleardb-service"},
+					[]string{"Create in progress: allocating VM"},
+					[]string{"Create in progress: installing database"},
+					[]string{"OK"},
+				))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"to check operation status"}))
+			})
+
+			It("fails when the broker fails to create the instance", func() {
+				failed := serviceInstance
+				failed.LastOperation.State = "failed"
+				failed.LastOperation.Description = "quota exceeded"
+				serviceRepo.FindInstanceByNameReturns(failed, nil)
+
+				callCreateService([]string{"cleardb", "spark", "my-cleardb-service", "--wait"})
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Create of service instance my-cleardb-service failed: quota exceeded"},
+				))
+			})
+		})
+
 		It("fails when service instance could is created but cannot be found", func() {
 			serviceRepo.FindInstanceByNameReturns(models.ServiceInstance{}, errors.New("Error finding instance"))
 			callCreateService([]string{"cleardb", "spark", "fake-service-instance-name"})
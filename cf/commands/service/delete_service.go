@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf/api"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
@@ -18,6 +19,8 @@ type DeleteService struct {
 	config             coreconfig.Reader
 	serviceRepo        api.ServiceRepository
 	serviceInstanceReq requirements.ServiceInstanceRequirement
+
+	OperationPollInterval time.Duration
 }
 
 func init() {
@@ -27,13 +30,14 @@ func init() {
 func (cmd *DeleteService) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force deletion without confirmation")}
+	fs["wait"] = &flags.BoolFlag{Name: "wait", Usage: T("Wait for the broker to finish deleting the service instance")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "delete-service",
 		ShortName:   "ds",
 		Description: T("Delete a service instance"),
 		Usage: []string{
-			T("CF_NAME delete-service SERVICE_INSTANCE [-f]"),
+			T("CF_NAME delete-service SERVICE_INSTANCE [-f] [--wait]"),
 		},
 		Flags: fs,
 	}
@@ -56,6 +60,7 @@ func (cmd *DeleteService) SetDependency(deps commandregistry.Dependency, pluginC
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.serviceRepo = deps.RepoLocator.GetServiceRepository()
+	cmd.OperationPollInterval = time.Second
 	return cmd
 }
 
@@ -93,6 +98,15 @@ func (cmd *DeleteService) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	if c.Bool("wait") {
+		err = waitForServiceOperation(serviceName, cmd.serviceRepo, cmd.ui, cmd.OperationPollInterval)
+		if _, ok := err.(*errors.ModelNotFoundError); ok {
+			cmd.ui.Ok()
+			return nil
+		}
+		return err
+	}
+
 	err = printSuccessMessageForServiceInstance(serviceName, cmd.serviceRepo, cmd.ui)
 	if err != nil {
 		cmd.ui.Ok()
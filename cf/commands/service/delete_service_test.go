@@ -3,6 +3,7 @@ package service_test
 import (
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/service"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/errors"
 	"code.cloudfoundry.org/cli/cf/models"
@@ -31,7 +32,9 @@ var _ = Describe("delete-service command", func() {
 		deps.UI = ui
 		deps.RepoLocator = deps.RepoLocator.SetServiceRepository(serviceRepo)
 		deps.Config = configRepo
-		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("delete-service").SetDependency(deps, pluginCall))
+		cmd := commandregistry.Commands.FindCommand("delete-service").SetDependency(deps, pluginCall).(*service.DeleteService)
+		cmd.OperationPollInterval = 0
+		commandregistry.Commands.SetCommand(cmd)
 	}
 
 	BeforeEach(func() {
@@ -99,6 +102,25 @@ var _ = Describe("delete-service command", func() {
 					})
 				})
 
+				It("waits with --wait until the instance is gone", func() {
+					serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
+						if serviceRepo.FindInstanceByNameCallCount() > 2 {
+							return models.ServiceInstance{}, errors.NewModelNotFoundError("Service instance", name)
+						}
+						return serviceInstance, nil
+					}
+
+					runCommand("-f", "--wait", "my-service")
+
+					Expect(serviceRepo.FindInstanceByNameCallCount()).To(Equal(3))
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Deleting service", "my-service"},
+						[]string{"Delete in progress: delete"},
+						[]string{"OK"},
+					))
+					Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"to check operation status"}))
+				})
+
 				It("skips confirmation when the -f flag is given", func() {
 					runCommand("-f", "foo.com")
 
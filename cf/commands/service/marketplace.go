@@ -10,6 +10,7 @@ import (
 	. "code.cloudfoundry.org/cli/cf/i18n"
 
 	"code.cloudfoundry.org/cli/cf/actors/servicebuilder"
+	"code.cloudfoundry.org/cli/cf/api"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/models"
@@ -18,9 +19,10 @@ import (
 )
 
 type MarketplaceServices struct {
-	ui             terminal.UI
-	config         coreconfig.Reader
-	serviceBuilder servicebuilder.ServiceBuilder
+	ui                terminal.UI
+	config            coreconfig.Reader
+	serviceBuilder    servicebuilder.ServiceBuilder
+	serviceBrokerRepo api.ServiceBrokerRepository
 }
 
 func init() {
@@ -29,7 +31,9 @@ func init() {
 
 func (cmd *MarketplaceServices) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
-	fs["s"] = &flags.StringFlag{ShortName: "s", Usage: T("Show plan details for a particular service offering")}
+	fs["service"] = &flags.StringFlag{Name: "service", ShortName: "s", Usage: T("Show plan details for a particular service offering")}
+	fs["broker"] = &flags.StringFlag{Name: "broker", Usage: T("Only show the service offerings of this service broker")}
+	fs["plan"] = &flags.StringFlag{Name: "plan", Usage: T("Only show the service plans with this name")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "marketplace",
@@ -37,9 +41,14 @@ func (cmd *MarketplaceServices) MetaData() commandregistry.CommandMetadata {
 		Description: T("List available offerings in the marketplace"),
 		Usage: []string{
 			"CF_NAME marketplace ",
-			fmt.Sprintf("[-s %s] ", T("SERVICE")),
+			fmt.Sprintf("[-s %s] [--broker %s] [--plan %s]", T("SERVICE"), T("BROKER"), T("PLAN")),
 		},
-		Flags: fs,
+		Examples: []string{
+			"CF_NAME marketplace -s p-mysql --plan 100mb",
+			"CF_NAME marketplace --broker my-broker --output json",
+		},
+		Flags:            fs,
+		StructuredOutput: true,
 	}
 }
 
@@ -63,17 +72,18 @@ func (cmd *MarketplaceServices) SetDependency(deps commandregistry.Dependency, p
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.serviceBuilder = deps.ServiceBuilder
+	cmd.serviceBrokerRepo = deps.RepoLocator.GetServiceBrokerRepository()
 	return cmd
 }
 
 func (cmd *MarketplaceServices) Execute(c flags.FlagContext) error {
-	serviceName := c.String("s")
+	serviceName := c.String("service")
 
 	var err error
 	if serviceName != "" {
-		err = cmd.marketplaceByService(serviceName)
+		err = cmd.marketplaceByService(serviceName, c.String("broker"), c.String("plan"))
 	} else {
-		err = cmd.marketplace()
+		err = cmd.marketplace(c.String("broker"), c.String("plan"))
 	}
 	if err != nil {
 		return err
@@ -82,7 +92,7 @@ func (cmd *MarketplaceServices) Execute(c flags.FlagContext) error {
 	return nil
 }
 
-func (cmd MarketplaceServices) marketplaceByService(serviceName string) error {
+func (cmd MarketplaceServices) marketplaceByService(serviceName string, brokerName string, planName string) error {
 	var serviceOffering models.ServiceOffering
 	var err error
 
@@ -107,20 +117,35 @@ func (cmd MarketplaceServices) marketplaceByService(serviceName string) error {
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
-	if serviceOffering.GUID == "" {
+	serviceOfferings := models.ServiceOfferings{}
+	if serviceOffering.GUID != "" {
+		serviceOfferings = append(serviceOfferings, serviceOffering)
+	}
+	serviceOfferings, err = cmd.filter(serviceOfferings, brokerName, planName)
+	if err != nil {
+		return err
+	}
+
+	if printer, ok := cmd.ui.(terminal.DataPrinter); ok {
+		printer.SetData(marketplaceData(serviceOfferings))
+		return nil
+	}
+
+	if len(serviceOfferings) == 0 {
 		cmd.ui.Say(T("Service offering not found"))
 		return nil
 	}
 
-	table := cmd.ui.Table([]string{T("service plan"), T("description"), T("free or paid")})
-	for _, plan := range serviceOffering.Plans {
+	table := cmd.ui.Table([]string{T("service plan"), T("description"), T("free or paid"), T("costs")})
+	table.Table.SetFieldKeys("service_plan", "description", "free_or_paid", "costs")
+	for _, plan := range serviceOfferings[0].Plans {
 		var freeOrPaid string
 		if plan.Free {
 			freeOrPaid = "free"
 		} else {
 			freeOrPaid = "paid"
 		}
-		table.Add(plan.Name, plan.Description, freeOrPaid)
+		table.Add(plan.Name, plan.Description, freeOrPaid, strings.Join(planCosts(plan), ", "))
 	}
 
 	err = table.Print()
@@ -130,7 +155,7 @@ func (cmd MarketplaceServices) marketplaceByService(serviceName string) error {
 	return nil
 }
 
-func (cmd MarketplaceServices) marketplace() error {
+func (cmd MarketplaceServices) marketplace(brokerName string, planName string) error {
 	var serviceOfferings models.ServiceOfferings
 	var err error
 
@@ -155,12 +180,24 @@ func (cmd MarketplaceServices) marketplace() error {
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
+	serviceOfferings, err = cmd.filter(serviceOfferings, brokerName, planName)
+	if err != nil {
+		return err
+	}
+
+	if printer, ok := cmd.ui.(terminal.DataPrinter); ok {
+		sort.Sort(serviceOfferings)
+		printer.SetData(marketplaceData(serviceOfferings))
+		return nil
+	}
+
 	if len(serviceOfferings) == 0 {
 		cmd.ui.Say(T("No service offerings found"))
 		return nil
 	}
 
 	table := cmd.ui.Table([]string{T("service"), T("plans"), T("description")})
+	table.Table.SetFieldKeys("service", "plans", "description")
 
 	sort.Sort(serviceOfferings)
 	var paidPlanExists bool
@@ -194,3 +231,87 @@ func (cmd MarketplaceServices) marketplace() error {
 	cmd.ui.Say(T("\nTIP:  Use 'cf marketplace -s SERVICE' to view descriptions of individual plans of a given service."))
 	return nil
 }
+
+// filter leaves out the offerings that are not from the broker named
+// brokerName and the plans not named planName, along with the offerings left
+// without plans. An empty name does not filter.
+func (cmd MarketplaceServices) filter(serviceOfferings models.ServiceOfferings, brokerName string, planName string) (models.ServiceOfferings, error) {
+	var brokerGUID string
+	if brokerName != "" {
+		broker, err := cmd.serviceBrokerRepo.FindByName(brokerName)
+		if err != nil {
+			return nil, err
+		}
+		brokerGUID = broker.GUID
+	}
+
+	filtered := models.ServiceOfferings{}
+	for _, offering := range serviceOfferings {
+		if brokerGUID != "" && offering.BrokerGUID != brokerGUID {
+			continue
+		}
+
+		if planName != "" {
+			plans := []models.ServicePlanFields{}
+			for _, plan := range offering.Plans {
+				if plan.Name == planName {
+					plans = append(plans, plan)
+				}
+			}
+			if len(plans) == 0 {
+				continue
+			}
+			offering.Plans = plans
+		}
+
+		filtered = append(filtered, offering)
+	}
+	return filtered, nil
+}
+
+// planCosts describes each cost of plan, e.g. "USD 10.00 monthly", listing
+// the currencies of a cost in alphabetical order.
+func planCosts(plan models.ServicePlanFields) []string {
+	costs := []string{}
+	for _, cost := range plan.Costs {
+		currencies := make([]string, 0, len(cost.Amount))
+		for currency := range cost.Amount {
+			currencies = append(currencies, currency)
+		}
+		sort.Strings(currencies)
+
+		for _, currency := range currencies {
+			costs = append(costs, fmt.Sprintf("%s %.2f %s", strings.ToUpper(currency), cost.Amount[currency], strings.ToLower(cost.Unit)))
+		}
+	}
+	return costs
+}
+
+func marketplaceData(serviceOfferings models.ServiceOfferings) []map[string]interface{} {
+	data := []map[string]interface{}{}
+	for _, offering := range serviceOfferings {
+		plans := []map[string]interface{}{}
+		for _, plan := range offering.Plans {
+			costs := plan.Costs
+			if costs == nil {
+				costs = []models.ServicePlanCost{}
+			}
+			plans = append(plans, map[string]interface{}{
+				"name":        plan.Name,
+				"guid":        plan.GUID,
+				"description": plan.Description,
+				"free":        plan.Free,
+				"costs":       costs,
+			})
+		}
+
+		data = append(data, map[string]interface{}{
+			"name":        offering.Label,
+			"guid":        offering.GUID,
+			"description": offering.Description,
+			"broker_guid": offering.BrokerGUID,
+			"plans":       plans,
+		})
+	}
+	return data
+}
@@ -1,13 +1,17 @@
 package service_test
 
 import (
+	"strings"
+
 	"code.cloudfoundry.org/cli/cf/actors/servicebuilder/servicebuilderfakes"
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/flags"
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
 	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
@@ -23,6 +27,7 @@ var _ = Describe("marketplace command", func() {
 	var requirementsFactory *requirementsfakes.FakeFactory
 	var config coreconfig.Repository
 	var serviceBuilder *servicebuilderfakes.FakeServiceBuilder
+	var serviceBrokerRepo *apifakes.FakeServiceBrokerRepository
 	var fakeServiceOfferings []models.ServiceOffering
 	var serviceWithAPaidPlan models.ServiceOffering
 	var service2 models.ServiceOffering
@@ -32,11 +37,13 @@ var _ = Describe("marketplace command", func() {
 		deps.UI = ui
 		deps.Config = config
 		deps.ServiceBuilder = serviceBuilder
+		deps.RepoLocator = deps.RepoLocator.SetServiceBrokerRepository(serviceBrokerRepo)
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("marketplace").SetDependency(deps, pluginCall))
 	}
 
 	BeforeEach(func() {
 		serviceBuilder = new(servicebuilderfakes.FakeServiceBuilder)
+		serviceBrokerRepo = new(apifakes.FakeServiceBrokerRepository)
 		ui = &testterm.FakeUI{}
 		requirementsFactory = new(requirementsfakes.FakeFactory)
 		requirementsFactory.NewAPIEndpointRequirementReturns(requirements.Passing{})
@@ -44,11 +51,14 @@ var _ = Describe("marketplace command", func() {
 		serviceWithAPaidPlan = models.ServiceOffering{
 			Plans: []models.ServicePlanFields{
 				{Name: "service-plan-a", Description: "service-plan-a description", Free: true},
-				{Name: "service-plan-b", Description: "service-plan-b description", Free: false},
+				{Name: "service-plan-b", Description: "service-plan-b description", Free: false, Costs: []models.ServicePlanCost{
+					{Amount: map[string]float64{"usd": 10, "eur": 9.5}, Unit: "MONTHLY"},
+				}},
 			},
 			ServiceOfferingFields: models.ServiceOfferingFields{
 				Label:       "zzz-my-service-offering",
 				GUID:        "service-1-guid",
+				BrokerGUID:  "broker-1-guid",
 				Description: "service offering 1 description",
 			}}
 		service2 = models.ServiceOffering{
@@ -58,6 +68,7 @@ var _ = Describe("marketplace command", func() {
 			ServiceOfferingFields: models.ServiceOfferingFields{
 				Label:       "aaa-my-service-offering",
 				Description: "service offering 2 description",
+				BrokerGUID:  "broker-2-guid",
 			},
 		}
 		fakeServiceOfferings = []models.ServiceOffering{serviceWithAPaidPlan, service2}
@@ -158,10 +169,23 @@ var _ = Describe("marketplace command", func() {
 					Expect(ui.Outputs()).To(ContainSubstrings(
 						[]string{"Getting service plan information for service aaa-my-service-offering as my-user..."},
 						[]string{"OK"},
-						[]string{"service plan", "description", "free or paid"},
+						[]string{"service plan", "description", "free or paid", "costs"},
 						[]string{"service-plan-a", "service-plan-a description", "free"},
+						[]string{"service-plan-b", "service-plan-b description", "paid", "EUR 9.50 monthly, USD 10.00 monthly"},
+					))
+				})
+
+				It("only shows the plan passed with --plan", func() {
+					serviceBuilder.GetServiceByNameForSpaceWithPlansReturns(serviceWithAPaidPlan, nil)
+
+					testcmd.RunCLICommand("marketplace", []string{"--service", "zzz-my-service-offering", "--plan", "service-plan-b"}, requirementsFactory, updateCommandDependency, false, ui)
+
+					Expect(ui.Outputs()).To(ContainSubstrings(
 						[]string{"service-plan-b", "service-plan-b description", "paid"},
 					))
+					Expect(ui.Outputs()).NotTo(ContainSubstrings(
+						[]string{"service-plan-a"},
+					))
 				})
 
 				It("informs the user if the service cannot be found", func() {
@@ -175,6 +199,79 @@ var _ = Describe("marketplace command", func() {
 					))
 				})
 			})
+			Context("when the user passes the --broker flag", func() {
+				BeforeEach(func() {
+					serviceBrokerRepo.FindByNameReturns(models.ServiceBroker{GUID: "broker-2-guid", Name: "my-broker"}, nil)
+				})
+
+				It("only lists the service offerings of the broker", func() {
+					testcmd.RunCLICommand("marketplace", []string{"--broker", "my-broker"}, requirementsFactory, updateCommandDependency, false, ui)
+
+					Expect(serviceBrokerRepo.FindByNameArgsForCall(0)).To(Equal("my-broker"))
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"aaa-my-service-offering", "service offering 2 description"},
+					))
+					Expect(ui.Outputs()).NotTo(ContainSubstrings(
+						[]string{"zzz-my-service-offering"},
+					))
+				})
+			})
+
+			Context("when the user passes the --plan flag", func() {
+				It("only lists the service offerings with a plan of that name", func() {
+					testcmd.RunCLICommand("marketplace", []string{"--plan", "service-plan-c"}, requirementsFactory, updateCommandDependency, false, ui)
+
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"aaa-my-service-offering", "service offering 2 description", "service-plan-c"},
+					))
+					Expect(ui.Outputs()).NotTo(ContainSubstrings(
+						[]string{"service-plan-d"},
+					))
+					Expect(ui.Outputs()).NotTo(ContainSubstrings(
+						[]string{"zzz-my-service-offering"},
+					))
+				})
+			})
+
+			Context("when the output is formatted as json", func() {
+				var formattedUI terminal.UI
+
+				BeforeEach(func() {
+					formattedUI = terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+				})
+
+				It("prints the service offerings with their plans and costs", func() {
+					cmd := &service.MarketplaceServices{}
+					deps.UI = formattedUI
+					deps.Config = config
+					deps.ServiceBuilder = serviceBuilder
+					deps.RepoLocator = deps.RepoLocator.SetServiceBrokerRepository(serviceBrokerRepo)
+					cmd.SetDependency(deps, false)
+					flagContext := flags.NewFlagContext(cmd.MetaData().Flags)
+					Expect(flagContext.Parse("--plan", "service-plan-b")).To(Succeed())
+
+					Expect(cmd.Execute(flagContext)).To(Succeed())
+					Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+					Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`[
+						{
+							"name": "zzz-my-service-offering",
+							"guid": "service-1-guid",
+							"description": "service offering 1 description",
+							"broker_guid": "broker-1-guid",
+							"plans": [
+								{
+									"name": "service-plan-b",
+									"guid": "",
+									"description": "service-plan-b description",
+									"free": false,
+									"costs": [{"amount": {"usd": 10, "eur": 9.5}, "unit": "MONTHLY"}]
+								}
+							]
+						}
+					]`))
+				})
+			})
 		})
 
 		Context("when the user doesn't have a space targeted", func() {
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type ListRouteServices struct {
+	ui        terminal.UI
+	config    coreconfig.Reader
+	routeRepo api.RouteRepository
+}
+
+func init() {
+	commandregistry.Register(&ListRouteServices{})
+}
+
+func (cmd *ListRouteServices) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "route-services",
+		Description: T("List the routes in the target space that are bound to a route service"),
+		Usage: []string{
+			"CF_NAME route-services",
+		},
+		StructuredOutput: true,
+	}
+}
+
+func (cmd *ListRouteServices) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	usageReq := requirements.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+		T("No argument required"),
+		func() bool {
+			return len(fc.Args()) != 0
+		},
+	)
+
+	reqs := []requirements.Requirement{
+		usageReq,
+		requirementsFactory.NewLoginRequirement(),
+		requirementsFactory.NewTargetedSpaceRequirement(),
+	}
+
+	return reqs, nil
+}
+
+func (cmd *ListRouteServices) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.routeRepo = deps.RepoLocator.GetRouteRepository()
+	return cmd
+}
+
+func (cmd *ListRouteServices) Execute(c flags.FlagContext) error {
+	cmd.ui.Say(T("Getting route services in org {{.OrgName}} / space {{.SpaceName}} as {{.Username}}...",
+		map[string]interface{}{
+			"OrgName":   terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
+			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
+			"Username":  terminal.EntityNameColor(cmd.config.Username()),
+		}))
+
+	routes := []models.Route{}
+	err := cmd.routeRepo.ListRoutes(func(route models.Route) bool {
+		if route.ServiceInstance.GUID != "" {
+			routes = append(routes, route)
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(routes) == 0 {
+		cmd.ui.Say(T("No routes bound to route services found"))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{T("route"), T("service instance"), T("route service url"), T("apps")})
+	table.Table.SetFieldKeys("route", "service_instance", "route_service_url", "apps")
+	for _, route := range routes {
+		appNames := []string{}
+		for _, app := range route.Apps {
+			appNames = append(appNames, app.Name)
+		}
+
+		table.Add(
+			route.URL(),
+			route.ServiceInstance.Name,
+			route.ServiceInstance.RouteServiceURL,
+			strings.Join(appNames, ", "),
+		)
+	}
+
+	return table.Print()
+}
@@ -0,0 +1,136 @@
+package service_test
+
+import (
+	"errors"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("route-services", func() {
+	var (
+		ui                  *testterm.FakeUI
+		commandUI           terminal.UI
+		configRepo          coreconfig.Repository
+		requirementsFactory *requirementsfakes.FakeFactory
+		routeRepo           *apifakes.FakeRouteRepository
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = commandUI
+		deps.Config = configRepo
+		deps.RepoLocator = deps.RepoLocator.SetRouteRepository(routeRepo)
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("route-services").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("route-services", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		commandUI = ui
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		routeRepo = new(apifakes.FakeRouteRepository)
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+
+		routeRepo.ListRoutesStub = func(cb func(models.Route) bool) error {
+			routes := []models.Route{
+				{
+					GUID:   "route-1-guid",
+					Host:   "my-app",
+					Domain: models.DomainFields{Name: "example.com"},
+					Apps:   []models.ApplicationFields{{Name: "my-app"}, {Name: "my-app-venerable"}},
+					ServiceInstance: models.ServiceInstanceFields{
+						GUID:            "limiter-guid",
+						Name:            "rate-limiter",
+						RouteServiceURL: "https://limiter.example.com",
+					},
+				},
+				{
+					GUID:   "route-2-guid",
+					Host:   "unprotected",
+					Domain: models.DomainFields{Name: "example.com"},
+				},
+			}
+			for _, route := range routes {
+				if !cb(route) {
+					break
+				}
+			}
+			return nil
+		}
+	})
+
+	Describe("requirements", func() {
+		It("fails when not logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(runCommand()).To(BeFalse())
+		})
+
+		It("fails when no space is targeted", func() {
+			requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Failing{Message: "not targeting space"})
+			Expect(runCommand()).To(BeFalse())
+		})
+	})
+
+	It("lists the routes that are bound to a route service", func() {
+		Expect(runCommand()).To(BeTrue())
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Getting route services in org", "my-org", "my-space", "my-user"},
+			[]string{"OK"},
+			[]string{"route", "service instance", "route service url", "apps"},
+			[]string{"my-app.example.com", "rate-limiter", "https://limiter.example.com", "my-app, my-app-venerable"},
+		))
+		Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"unprotected.example.com"}))
+	})
+
+	It("says so when no route is bound to a route service", func() {
+		routeRepo.ListRoutesStub = nil
+		routeRepo.ListRoutesReturns(nil)
+
+		runCommand()
+
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"No routes bound to route services found"}))
+	})
+
+	It("fails when the routes cannot be listed", func() {
+		routeRepo.ListRoutesStub = nil
+		routeRepo.ListRoutesReturns(errors.New("list-err"))
+
+		Expect(runCommand()).To(BeFalse())
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"FAILED"}, []string{"list-err"}))
+	})
+
+	It("prints the routes as JSON", func() {
+		formattedUI := terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+		commandUI = formattedUI
+
+		Expect(runCommand()).To(BeTrue())
+		Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+		Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`[{
+			"route": "my-app.example.com",
+			"service_instance": "rate-limiter",
+			"route_service_url": "https://limiter.example.com",
+			"apps": "my-app, my-app-venerable"
+		}]`))
+	})
+})
@@ -9,11 +9,12 @@ import (
 )
 
 type FakeBinder struct {
-	BindApplicationStub        func(app models.Application, serviceInstance models.ServiceInstance, paramsMap map[string]interface{}) (apiErr error)
+	BindApplicationStub        func(app models.Application, serviceInstance models.ServiceInstance, bindingName string, paramsMap map[string]interface{}) (apiErr error)
 	bindApplicationMutex       sync.RWMutex
 	bindApplicationArgsForCall []struct {
 		app             models.Application
 		serviceInstance models.ServiceInstance
+		bindingName     string
 		paramsMap       map[string]interface{}
 	}
 	bindApplicationReturns struct {
@@ -23,17 +24,18 @@ type FakeBinder struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeBinder) BindApplication(app models.Application, serviceInstance models.ServiceInstance, paramsMap map[string]interface{}) (apiErr error) {
+func (fake *FakeBinder) BindApplication(app models.Application, serviceInstance models.ServiceInstance, bindingName string, paramsMap map[string]interface{}) (apiErr error) {
 	fake.bindApplicationMutex.Lock()
 	fake.bindApplicationArgsForCall = append(fake.bindApplicationArgsForCall, struct {
 		app             models.Application
 		serviceInstance models.ServiceInstance
+		bindingName     string
 		paramsMap       map[string]interface{}
-	}{app, serviceInstance, paramsMap})
-	fake.recordInvocation("BindApplication", []interface{}{app, serviceInstance, paramsMap})
+	}{app, serviceInstance, bindingName, paramsMap})
+	fake.recordInvocation("BindApplication", []interface{}{app, serviceInstance, bindingName, paramsMap})
 	fake.bindApplicationMutex.Unlock()
 	if fake.BindApplicationStub != nil {
-		return fake.BindApplicationStub(app, serviceInstance, paramsMap)
+		return fake.BindApplicationStub(app, serviceInstance, bindingName, paramsMap)
 	} else {
 		return fake.bindApplicationReturns.result1
 	}
@@ -45,10 +47,10 @@ func (fake *FakeBinder) BindApplicationCallCount() int {
 	return len(fake.bindApplicationArgsForCall)
 }
 
-func (fake *FakeBinder) BindApplicationArgsForCall(i int) (models.Application, models.ServiceInstance, map[string]interface{}) {
+func (fake *FakeBinder) BindApplicationArgsForCall(i int) (models.Application, models.ServiceInstance, string, map[string]interface{}) {
 	fake.bindApplicationMutex.RLock()
 	defer fake.bindApplicationMutex.RUnlock()
-	return fake.bindApplicationArgsForCall[i].app, fake.bindApplicationArgsForCall[i].serviceInstance, fake.bindApplicationArgsForCall[i].paramsMap
+	return fake.bindApplicationArgsForCall[i].app, fake.bindApplicationArgsForCall[i].serviceInstance, fake.bindApplicationArgsForCall[i].bindingName, fake.bindApplicationArgsForCall[i].paramsMap
 }
 
 func (fake *FakeBinder) BindApplicationReturns(result1 error) {
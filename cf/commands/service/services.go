@@ -1,11 +1,16 @@
 package service
 
 import (
+	"errors"
+	"fmt"
 	"strings"
 
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/plugin/models"
 
 	"code.cloudfoundry.org/cli/cf/api"
@@ -18,6 +23,7 @@ type ListServices struct {
 	ui                 terminal.UI
 	config             coreconfig.Reader
 	serviceSummaryRepo api.ServiceSummaryRepository
+	metadataActor      actors.MetadataActor
 	pluginModel        *[]plugin_models.GetServices_Model
 	pluginCall         bool
 }
@@ -27,13 +33,24 @@ func init() {
 }
 
 func (cmd *ListServices) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["filter"] = &flags.StringFlag{Name: "filter", Usage: T("Only list the service instances whose last operation matches state=STATE, e.g. 'state=create failed' or 'state=in progress'")}
+	fs["labels"] = &flags.StringFlag{Name: "labels", Usage: T("Only list the service instances whose labels match this selector, such as 'env=prod,tier!=web'")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "services",
 		ShortName:   "s",
 		Description: T("List all service instances in the target space"),
 		Usage: []string{
-			"CF_NAME services",
+			"CF_NAME services [--filter state=STATE] [--labels SELECTOR]",
+		},
+		Examples: []string{
+			"CF_NAME services --filter 'state=create failed'",
+			"CF_NAME services --filter state=failed",
+			"CF_NAME services --labels env=prod",
 		},
+		Flags:            fs,
+		StructuredOutput: true,
 	}
 }
 
@@ -51,6 +68,10 @@ func (cmd *ListServices) Requirements(requirementsFactory requirements.Factory,
 		requirementsFactory.NewTargetedSpaceRequirement(),
 	}
 
+	if fc.IsSet("labels") {
+		reqs = append(reqs, requirementsFactory.NewMinAPIVersionRequirement("Option '--labels'", cf.MetadataMinimumAPIVersion))
+	}
+
 	return reqs, nil
 }
 
@@ -58,12 +79,22 @@ func (cmd *ListServices) SetDependency(deps commandregistry.Dependency, pluginCa
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.serviceSummaryRepo = deps.RepoLocator.GetServiceSummaryRepository()
+	cmd.metadataActor = deps.MetadataActor
 	cmd.pluginModel = deps.PluginModels.Services
 	cmd.pluginCall = pluginCall
 	return cmd
 }
 
 func (cmd *ListServices) Execute(fc flags.FlagContext) error {
+	stateFilter := ""
+	if fc.IsSet("filter") {
+		var err error
+		stateFilter, err = parseStateFilter(fc.String("filter"))
+		if err != nil {
+			return err
+		}
+	}
+
 	cmd.ui.Say(T("Getting services in org {{.OrgName}} / space {{.SpaceName}} as {{.CurrentUser}}...",
 		map[string]interface{}{
 			"OrgName":     terminal.EntityNameColor(cmd.config.OrganizationFields().Name),
@@ -77,6 +108,18 @@ func (cmd *ListServices) Execute(fc flags.FlagContext) error {
 		return err
 	}
 
+	if stateFilter != "" {
+		serviceInstances = filterByLastOperation(serviceInstances, stateFilter)
+	}
+
+	if fc.IsSet("labels") {
+		guids, err := cmd.metadataActor.MatchingGUIDs("service-instance", fc.String("labels"))
+		if err != nil {
+			return err
+		}
+		serviceInstances = filterByGUID(serviceInstances, guids)
+	}
+
 	cmd.ui.Ok()
 	cmd.ui.Say("")
 
@@ -86,6 +129,7 @@ func (cmd *ListServices) Execute(fc flags.FlagContext) error {
 	}
 
 	table := cmd.ui.Table([]string{T("name"), T("service"), T("plan"), T("bound apps"), T("last operation")})
+	table.Table.SetFieldKeys("name", "service", "plan", "bound_apps", "last_operation")
 
 	for _, instance := range serviceInstances {
 		var serviceColumn string
@@ -102,7 +146,7 @@ func (cmd *ListServices) Execute(fc flags.FlagContext) error {
 			instance.Name,
 			serviceColumn,
 			instance.ServicePlan.Name,
-			strings.Join(instance.ApplicationNames, ", "),
+			strings.Join(boundAppNames(instance), ", "),
 			serviceStatus,
 		)
 		if cmd.pluginCall {
@@ -135,3 +179,55 @@ func (cmd *ListServices) Execute(fc flags.FlagContext) error {
 	}
 	return nil
 }
+
+// boundAppNames names the apps bound to instance, followed by the name of the
+// binding in parentheses for the bindings that have one.
+func boundAppNames(instance models.ServiceInstance) []string {
+	names := make([]string, len(instance.ApplicationNames))
+	for i, appName := range instance.ApplicationNames {
+		names[i] = appName
+		if i < len(instance.BindingNames) && instance.BindingNames[i] != "" {
+			names[i] = fmt.Sprintf("%s (%s)", appName, instance.BindingNames[i])
+		}
+	}
+	return names
+}
+
+// parseStateFilter returns the STATE of a state=STATE filter.
+func parseStateFilter(filter string) (string, error) {
+	parts := strings.SplitN(filter, "=", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) != "state" || strings.TrimSpace(parts[1]) == "" {
+		return "", errors.New(T("Invalid filter {{.Filter}}. The supported filter is state=STATE, e.g. 'state=create failed'",
+			map[string]interface{}{"Filter": filter}))
+	}
+	return strings.TrimSpace(parts[1]), nil
+}
+
+// filterByLastOperation keeps the instances whose last operation is state:
+// either the whole operation as shown in the last operation column, such as
+// "create failed", or only its state, such as "failed". User-provided
+// instances have no last operation and are left out.
+func filterByLastOperation(instances []models.ServiceInstance, state string) []models.ServiceInstance {
+	filtered := []models.ServiceInstance{}
+	for _, instance := range instances {
+		if instance.IsUserProvided() || instance.LastOperation.State == "" {
+			continue
+		}
+		status := InstanceStateToStatus(instance.LastOperation.Type, instance.LastOperation.State, false)
+		if strings.EqualFold(status, state) || strings.EqualFold(instance.LastOperation.State, state) {
+			filtered = append(filtered, instance)
+		}
+	}
+	return filtered
+}
+
+// filterByGUID keeps the instances whose guid is in guids.
+func filterByGUID(instances []models.ServiceInstance, guids map[string]bool) []models.ServiceInstance {
+	filtered := []models.ServiceInstance{}
+	for _, instance := range instances {
+		if guids[instance.GUID] {
+			filtered = append(filtered, instance)
+		}
+	}
+	return filtered
+}
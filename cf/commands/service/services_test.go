@@ -3,6 +3,8 @@ package service_test
 import (
 	"os"
 
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/flags"
@@ -30,6 +32,7 @@ var _ = Describe("services", func() {
 		configRepo          coreconfig.Repository
 		requirementsFactory *requirementsfakes.FakeFactory
 		serviceSummaryRepo  *apifakes.OldFakeServiceSummaryRepo
+		metadataActor       *actorsfakes.FakeMetadataActor
 		deps                commandregistry.Dependency
 	)
 
@@ -37,6 +40,7 @@ var _ = Describe("services", func() {
 		deps.UI = ui
 		deps.Config = configRepo
 		deps.RepoLocator = deps.RepoLocator.SetServiceSummaryRepository(serviceSummaryRepo)
+		deps.MetadataActor = metadataActor
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("services").SetDependency(deps, pluginCall))
 	}
 
@@ -48,11 +52,13 @@ var _ = Describe("services", func() {
 		ui = &testterm.FakeUI{}
 		configRepo = testconfig.NewRepositoryWithDefaults()
 		serviceSummaryRepo = new(apifakes.OldFakeServiceSummaryRepo)
+		metadataActor = new(actorsfakes.FakeMetadataActor)
 		targetedOrgRequirement := new(requirementsfakes.FakeTargetedOrgRequirement)
 		requirementsFactory = new(requirementsfakes.FakeFactory)
 		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
 		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
 		requirementsFactory.NewTargetedOrgRequirementReturns(targetedOrgRequirement)
+		requirementsFactory.NewMinAPIVersionRequirementReturns(requirements.Passing{})
 
 		deps = commandregistry.NewDependency(os.Stdout, new(tracefakes.FakePrinter), "")
 	})
@@ -152,6 +158,117 @@ var _ = Describe("services", func() {
 		))
 	})
 
+	It("shows the names of the bindings that have one next to the bound apps", func() {
+		serviceInstance := models.ServiceInstance{}
+		serviceInstance.Name = "my-service-1"
+		serviceInstance.ApplicationNames = []string{"cli1", "cli2"}
+		serviceInstance.BindingNames = []string{"", "reports-db"}
+		serviceInstance.ServicePlan = models.ServicePlanFields{GUID: "spark-guid", Name: "spark"}
+		serviceInstance.ServiceOffering = models.ServiceOfferingFields{Label: "cleardb"}
+
+		serviceSummaryRepo.GetSummariesInCurrentSpaceInstances = []models.ServiceInstance{serviceInstance}
+
+		runCommand()
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"my-service-1", "cleardb", "spark", "cli1, cli2 (reports-db)"},
+		))
+	})
+
+	Describe("--filter", func() {
+		BeforeEach(func() {
+			failed := models.ServiceInstance{}
+			failed.Name = "failed-db"
+			failed.LastOperation.Type = "create"
+			failed.LastOperation.State = "failed"
+			failed.ServicePlan = models.ServicePlanFields{GUID: "spark-guid", Name: "spark"}
+			failed.ServiceOffering = models.ServiceOfferingFields{Label: "cleardb"}
+
+			updateFailed := failed
+			updateFailed.Name = "update-failed-db"
+			updateFailed.LastOperation.Type = "update"
+
+			created := failed
+			created.Name = "created-db"
+			created.LastOperation.State = "succeeded"
+
+			userProvided := models.ServiceInstance{}
+			userProvided.Name = "my-service-provided-by-user"
+
+			serviceSummaryRepo.GetSummariesInCurrentSpaceInstances = []models.ServiceInstance{failed, updateFailed, created, userProvided}
+		})
+
+		It("lists only the instances whose last operation matches", func() {
+			Expect(runCommand("--filter", "state=create failed")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"failed-db", "cleardb", "spark", "create failed"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"update-failed-db"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"created-db"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"my-service-provided-by-user"}))
+		})
+
+		It("matches only the state of the last operation", func() {
+			Expect(runCommand("--filter", "state=failed")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"failed-db", "create failed"},
+				[]string{"update-failed-db", "update failed"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"created-db"}))
+		})
+
+		It("says so when no instance matches", func() {
+			Expect(runCommand("--filter", "state=delete in progress")).To(BeTrue())
+
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"No services found"}))
+		})
+
+		It("fails when the filter is not a state filter", func() {
+			Expect(runCommand("--filter", "plan=spark")).To(BeFalse())
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Invalid filter plan=spark"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Getting services"}))
+		})
+	})
+
+	Describe("--labels", func() {
+		BeforeEach(func() {
+			prod := models.ServiceInstance{}
+			prod.Name = "prod-db"
+			prod.GUID = "prod-db-guid"
+
+			staging := models.ServiceInstance{}
+			staging.Name = "staging-db"
+			staging.GUID = "staging-db-guid"
+
+			serviceSummaryRepo.GetSummariesInCurrentSpaceInstances = []models.ServiceInstance{prod, staging}
+		})
+
+		It("requires an API with metadata", func() {
+			runCommand("--labels", "env=prod")
+
+			option, version := requirementsFactory.NewMinAPIVersionRequirementArgsForCall(0)
+			Expect(option).To(Equal("Option '--labels'"))
+			Expect(version).To(Equal(cf.MetadataMinimumAPIVersion))
+		})
+
+		It("lists only the instances whose labels match", func() {
+			metadataActor.MatchingGUIDsReturns(map[string]bool{"prod-db-guid": true}, nil)
+
+			Expect(runCommand("--labels", "env=prod")).To(BeTrue())
+
+			resourceType, selector := metadataActor.MatchingGUIDsArgsForCall(0)
+			Expect(resourceType).To(Equal("service-instance"))
+			Expect(selector).To(Equal("env=prod"))
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"prod-db"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"staging-db"}))
+		})
+	})
+
 	It("lists no services when none are found", func() {
 		serviceInstances := []models.ServiceInstance{}
 		serviceSummaryRepo.GetSummariesInCurrentSpaceInstances = serviceInstances
@@ -50,8 +50,10 @@ func (cmd *UnbindRouteService) MetaData() commandregistry.CommandMetadata {
 		},
 		Examples: []string{
 			"CF_NAME unbind-route-service example.com myratelimiter --hostname myapp --path foo",
+			"CF_NAME unbind-route-service example.com myratelimiter --hostname myapp -f --output json",
 		},
-		Flags: fs,
+		Flags:            fs,
+		StructuredOutput: true,
 	}
 }
 
@@ -128,16 +130,23 @@ func (cmd *UnbindRouteService) Execute(c flags.FlagContext) error {
 			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
 		}))
 
+	wasBound := true
 	err = cmd.UnbindRoute(route, serviceInstance)
 	if err != nil {
 		httpError, ok := err.(errors.HTTPError)
 		if ok && httpError.ErrorCode() == errors.InvalidRelation {
+			wasBound = false
 			cmd.ui.Warn(T("Route {{.Route}} was not bound to service instance {{.ServiceInstance}}.", map[string]interface{}{"Route": route.URL(), "ServiceInstance": serviceInstance.Name}))
 		} else {
 			return err
 		}
 	}
 
+	if printer, ok := cmd.ui.(terminal.DataPrinter); ok {
+		printer.SetData(routeServiceBindingData(route, serviceInstance, map[string]interface{}{"was_bound": wasBound}))
+		return nil
+	}
+
 	cmd.ui.Ok()
 	return nil
 }
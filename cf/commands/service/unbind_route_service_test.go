@@ -2,6 +2,7 @@ package service_test
 
 import (
 	"net/http"
+	"strings"
 
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/commands/service"
@@ -11,6 +12,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
 	"github.com/blang/semver"
 
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
@@ -155,6 +157,52 @@ var _ = Describe("UnbindRouteService", func() {
 			runCLIErr = cmd.Execute(flagContext)
 		})
 
+		Context("with -f and --output json", func() {
+			var formattedUI terminal.UI
+
+			BeforeEach(func() {
+				formattedUI = terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+				deps.UI = formattedUI
+				cmd.SetDependency(deps, false)
+
+				flagContext = flags.NewFlagContext(cmd.MetaData().Flags)
+				err := flagContext.Parse("domain-name", "service-instance", "-f")
+				Expect(err).NotTo(HaveOccurred())
+
+				routeRepo.FindReturns(models.Route{GUID: "route-guid", Host: "my-app", Domain: models.DomainFields{Name: "example.com"}}, nil)
+				serviceInstance := models.ServiceInstance{}
+				serviceInstance.GUID = "service-instance-guid"
+				serviceInstance.Name = "my-limiter"
+				serviceInstanceRequirement.GetServiceInstanceReturns(serviceInstance)
+			})
+
+			It("prints the unbinding", func() {
+				Expect(runCLIErr).NotTo(HaveOccurred())
+				Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+				Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`{
+					"route": "my-app.example.com",
+					"route_guid": "route-guid",
+					"service_instance": "my-limiter",
+					"service_instance_guid": "service-instance-guid",
+					"was_bound": true
+				}`))
+			})
+
+			Context("when the route was not bound", func() {
+				BeforeEach(func() {
+					routeServiceBindingRepo.UnbindReturns(errors.NewHTTPError(http.StatusOK, errors.InvalidRelation, "http-err"))
+				})
+
+				It("says so in the JSON", func() {
+					Expect(runCLIErr).NotTo(HaveOccurred())
+					Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+					Expect(strings.Join(ui.Outputs(), "\n")).To(ContainSubstring(`"was_bound": false`))
+				})
+			})
+		})
+
 		It("tries to find the route", func() {
 			Expect(runCLIErr).NotTo(HaveOccurred())
 			Expect(routeRepo.FindCallCount()).To(Equal(1))
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf"
 	"code.cloudfoundry.org/cli/cf/actors/planbuilder"
@@ -24,6 +25,8 @@ type UpdateService struct {
 	config      coreconfig.Reader
 	serviceRepo api.ServiceRepository
 	planBuilder planbuilder.PlanBuilder
+
+	OperationPollInterval time.Duration
 }
 
 func init() {
@@ -31,7 +34,7 @@ func init() {
 }
 
 func (cmd *UpdateService) MetaData() commandregistry.CommandMetadata {
-	baseUsage := T("CF_NAME update-service SERVICE_INSTANCE [-p NEW_PLAN] [-c PARAMETERS_AS_JSON] [-t TAGS]")
+	baseUsage := T("CF_NAME update-service SERVICE_INSTANCE [-p NEW_PLAN] [-c PARAMETERS_AS_JSON] [-t TAGS] [--wait]")
 	paramsUsage := T(`   Optionally provide service-specific configuration parameters in a valid JSON object in-line.
    CF_NAME update-service -c '{"name":"value","name":"value"}'
 
@@ -52,6 +55,7 @@ func (cmd *UpdateService) MetaData() commandregistry.CommandMetadata {
 	fs["p"] = &flags.StringFlag{ShortName: "p", Usage: T("Change service plan for a service instance")}
 	fs["c"] = &flags.StringFlag{ShortName: "c", Usage: T("Valid JSON object containing service-specific configuration parameters, provided either in-line or in a file. For a list of supported configuration parameters, see documentation for the particular service offering.")}
 	fs["t"] = &flags.StringFlag{ShortName: "t", Usage: T("User provided tags")}
+	fs["wait"] = &flags.BoolFlag{Name: "wait", Usage: T("Wait for the broker to finish updating the service instance")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "update-service",
@@ -96,6 +100,7 @@ func (cmd *UpdateService) SetDependency(deps commandregistry.Dependency, pluginC
 	cmd.config = deps.Config
 	cmd.serviceRepo = deps.RepoLocator.GetServiceRepository()
 	cmd.planBuilder = deps.PlanBuilder
+	cmd.OperationPollInterval = time.Second
 	return cmd
 }
 
@@ -139,6 +144,11 @@ func (cmd *UpdateService) Execute(c flags.FlagContext) error {
 	if err != nil {
 		return err
 	}
+
+	if c.Bool("wait") {
+		return waitForServiceOperation(serviceInstanceName, cmd.serviceRepo, cmd.ui, cmd.OperationPollInterval)
+	}
+
 	err = printSuccessMessageForServiceInstance(serviceInstanceName, cmd.serviceRepo, cmd.ui)
 	if err != nil {
 		return err
@@ -192,3 +202,53 @@ func printSuccessMessageForServiceInstance(serviceInstanceName string, serviceRe
 
 	return nil
 }
+
+// maxOperationPollInterval caps the time between polls of --wait.
+const maxOperationPollInterval = 30 * time.Second
+
+// waitForServiceOperation polls the service instance until the broker has
+// finished the operation on it, printing each new description of its progress
+// the broker gives. The time between polls starts at interval and doubles up
+// to maxOperationPollInterval, since brokers can take from seconds to hours.
+// An instance that is being deleted can no longer be found once it is gone, so
+// callers deleting it treat a ModelNotFoundError as success.
+func waitForServiceOperation(serviceInstanceName string, serviceRepo api.ServiceRepository, ui terminal.UI, interval time.Duration) error {
+	description := ""
+
+	for {
+		instance, err := serviceRepo.FindInstanceByName(serviceInstanceName)
+		if err != nil {
+			return err
+		}
+
+		operation := instance.ServiceInstanceFields.LastOperation
+		switch operation.State {
+		case "in progress":
+		case "failed":
+			return errors.New(T("{{.Operation}} of service instance {{.ServiceName}} failed: {{.Description}}",
+				map[string]interface{}{
+					"Operation":   strings.Title(operation.Type),
+					"ServiceName": serviceInstanceName,
+					"Description": operation.Description,
+				}))
+		default:
+			ui.Ok()
+			return nil
+		}
+
+		if operation.Description != "" && operation.Description != description {
+			description = operation.Description
+			ui.Say(T("{{.Operation}} in progress: {{.Description}}",
+				map[string]interface{}{
+					"Operation":   strings.Title(operation.Type),
+					"Description": description,
+				}))
+		}
+
+		time.Sleep(interval)
+		interval *= 2
+		if interval > maxOperationPollInterval {
+			interval = maxOperationPollInterval
+		}
+	}
+}
@@ -39,7 +39,9 @@ var _ = Describe("update-service command", func() {
 		deps.RepoLocator = deps.RepoLocator.SetServiceRepository(serviceRepo)
 		deps.Config = config
 		deps.PlanBuilder = planBuilder
-		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("update-service").SetDependency(deps, pluginCall))
+		cmd := commandregistry.Commands.FindCommand("update-service").SetDependency(deps, pluginCall).(*service.UpdateService)
+		cmd.OperationPollInterval = 0
+		commandregistry.Commands.SetCommand(cmd)
 	}
 
 	BeforeEach(func() {
@@ -181,6 +183,31 @@ var _ = Describe("update-service command", func() {
 				Expect(params).To(Equal(map[string]interface{}{"foo": "bar"}))
 			})
 
+			Context("when --wait is given", func() {
+				It("waits for the broker to finish, printing its progress", func() {
+					serviceRepo.FindInstanceByNameStub = func(name string) (models.ServiceInstance, error) {
+						instance := models.ServiceInstance{}
+						instance.Name = name
+						instance.GUID = "my-service-instance-guid"
+						instance.LastOperation = models.LastOperationFields{Type: "update", State: "in progress", Description: "resizing disk"}
+						if serviceRepo.FindInstanceByNameCallCount() > 3 {
+							instance.LastOperation.State = "succeeded"
+						}
+						return instance, nil
+					}
+
+					callUpdateService([]string{"-c", `{"foo": "bar"}`, "--wait", "my-service-instance"})
+
+					Expect(serviceRepo.FindInstanceByNameCallCount()).To(Equal(4))
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Updating service", "my-service-instance"},
+						[]string{"Update in progress: resizing disk"},
+						[]string{"OK"},
+					))
+					Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"to check operation status"}))
+				})
+			})
+
 			Context("that are not valid json", func() {
 				It("returns an error to the UI", func() {
 					callUpdateService([]string{"-p", "flare", "-c", `bad-json`, "my-service-instance"})
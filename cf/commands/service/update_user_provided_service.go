@@ -34,13 +34,14 @@ func (cmd *UpdateUserProvidedService) MetaData() commandregistry.CommandMetadata
 	fs["p"] = &flags.StringFlag{ShortName: "p", Usage: T("Credentials, provided inline or in a file, to be exposed in the VCAP_SERVICES environment variable for bound applications")}
 	fs["l"] = &flags.StringFlag{ShortName: "l", Usage: T("URL to which logs for bound applications will be streamed")}
 	fs["r"] = &flags.StringFlag{ShortName: "r", Usage: T("URL to which requests for bound routes will be forwarded. Scheme for this URL must be https")}
+	fs["merge"] = &flags.BoolFlag{Name: "merge", Usage: T("Merge the credentials given with -p into the existing credentials instead of replacing them. A key with a null value is removed.")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "update-user-provided-service",
 		ShortName:   "uups",
 		Description: T("Update user-provided service instance"),
 		Usage: []string{
-			T(`CF_NAME update-user-provided-service SERVICE_INSTANCE [-p CREDENTIALS] [-l SYSLOG_DRAIN_URL] [-r ROUTE_SERVICE_URL]
+			T(`CF_NAME update-user-provided-service SERVICE_INSTANCE [-p CREDENTIALS] [-l SYSLOG_DRAIN_URL] [-r ROUTE_SERVICE_URL] [--merge]
 
    Pass comma separated credential parameter names to enable interactive mode:
    CF_NAME update-user-provided-service SERVICE_INSTANCE -p "comma, separated, parameter, names"
@@ -49,13 +50,17 @@ func (cmd *UpdateUserProvidedService) MetaData() commandregistry.CommandMetadata
    CF_NAME update-user-provided-service SERVICE_INSTANCE -p '{"key1":"value1","key2":"value2"}'
 
    Specify a path to a file containing JSON:
-   CF_NAME update-user-provided-service SERVICE_INSTANCE -p PATH_TO_FILE`),
+   CF_NAME update-user-provided-service SERVICE_INSTANCE -p PATH_TO_FILE
+
+   Change only some of the credentials, removing the keys set to null:
+   CF_NAME update-user-provided-service SERVICE_INSTANCE -p '{"key1":"value1","key2":null}' --merge`),
 		},
 		Examples: []string{
 			`CF_NAME update-user-provided-service my-db-mine -p '{"username":"admin", "password":"pa55woRD"}'`,
 			"CF_NAME update-user-provided-service my-db-mine -p /path/to/credentials.json",
 			"CF_NAME update-user-provided-service my-drain-service -l syslog://example.com",
 			"CF_NAME update-user-provided-service my-route-service -r https://example.com",
+			`CF_NAME update-user-provided-service my-db-mine -p '{"password":"n3wPa55woRD"}' --merge`,
 		},
 		Flags: fs,
 	}
@@ -98,6 +103,10 @@ func (cmd *UpdateUserProvidedService) Execute(c flags.FlagContext) error {
 	credentials := strings.Trim(c.String("p"), `'"`)
 	routeServiceURL := c.String("r")
 
+	if c.Bool("merge") && !c.IsSet("p") {
+		return errors.New(T("--merge can only be used with -p"))
+	}
+
 	credentialsMap := make(map[string]interface{})
 
 	if c.IsSet("p") {
@@ -123,6 +132,21 @@ func (cmd *UpdateUserProvidedService) Execute(c flags.FlagContext) error {
 			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
 		}))
 
+	if c.Bool("merge") {
+		existing, err := cmd.userProvidedServiceInstanceRepo.Get(serviceInstance.GUID)
+		if err != nil {
+			return err
+		}
+
+		credentialsMap = mergeCredentials(existing.Credentials, credentialsMap)
+		if !c.IsSet("l") {
+			drainURL = existing.SysLogDrainURL
+		}
+		if !c.IsSet("r") {
+			routeServiceURL = existing.RouteServiceURL
+		}
+	}
+
 	serviceInstance.Params = credentialsMap
 	serviceInstance.SysLogDrainURL = drainURL
 	serviceInstance.RouteServiceURL = routeServiceURL
@@ -143,3 +167,31 @@ func (cmd *UpdateUserProvidedService) Execute(c flags.FlagContext) error {
 	}
 	return nil
 }
+
+// mergeCredentials returns the existing credentials with the changes applied
+// on top. Objects present in both are merged key by key, and a key whose
+// value is null in the changes is removed.
+func mergeCredentials(existing map[string]interface{}, changes map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(existing))
+	for key, value := range existing {
+		merged[key] = value
+	}
+
+	for key, value := range changes {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+
+		existingObject, existingIsObject := merged[key].(map[string]interface{})
+		changedObject, changedIsObject := value.(map[string]interface{})
+		if existingIsObject && changedIsObject {
+			merged[key] = mergeCredentials(existingObject, changedObject)
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
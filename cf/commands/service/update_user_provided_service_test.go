@@ -240,6 +240,68 @@ var _ = Describe("UpdateUserProvidedService", func() {
 				})
 			})
 
+			Context("when the --merge flag is passed", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.GetReturns(models.UserProvidedService{
+						Credentials: map[string]interface{}{
+							"user":     "me",
+							"password": "old-secret",
+							"db": map[string]interface{}{
+								"host": "old.example.com",
+								"port": float64(5432),
+							},
+						},
+						SysLogDrainURL:  "syslog://example.com",
+						RouteServiceURL: "https://route.example.com",
+					}, nil)
+					flagContext.Parse("service-instance", "-p", `{"password":"new-secret","user":null,"db":{"host":"new.example.com"}}`, "--merge")
+				})
+
+				It("merges the credentials into the existing ones, removing the keys set to null", func() {
+					Expect(runCLIErr).NotTo(HaveOccurred())
+					Expect(serviceInstanceRepo.GetCallCount()).To(Equal(1))
+
+					Expect(serviceInstanceRepo.UpdateCallCount()).To(Equal(1))
+					serviceInstanceFields := serviceInstanceRepo.UpdateArgsForCall(0)
+					Expect(serviceInstanceFields.Params).To(Equal(map[string]interface{}{
+						"password": "new-secret",
+						"db": map[string]interface{}{
+							"host": "new.example.com",
+							"port": float64(5432),
+						},
+					}))
+				})
+
+				It("keeps the existing syslog drain and route service URLs", func() {
+					Expect(runCLIErr).NotTo(HaveOccurred())
+					serviceInstanceFields := serviceInstanceRepo.UpdateArgsForCall(0)
+					Expect(serviceInstanceFields.SysLogDrainURL).To(Equal("syslog://example.com"))
+					Expect(serviceInstanceFields.RouteServiceURL).To(Equal("https://route.example.com"))
+				})
+
+				Context("when the existing credentials cannot be fetched", func() {
+					BeforeEach(func() {
+						serviceInstanceRepo.GetReturns(models.UserProvidedService{}, errors.New("get-err"))
+					})
+
+					It("fails without updating the service instance", func() {
+						Expect(runCLIErr).To(MatchError("get-err"))
+						Expect(serviceInstanceRepo.UpdateCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the --merge flag is passed without -p", func() {
+				BeforeEach(func() {
+					flagContext.Parse("service-instance", "--merge")
+				})
+
+				It("fails with error", func() {
+					Expect(runCLIErr).To(MatchError("--merge can only be used with -p"))
+					Expect(serviceInstanceRepo.UpdateCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when updating succeeds", func() {
 				BeforeEach(func() {
 					serviceInstanceRepo.UpdateReturns(nil)
@@ -1,7 +1,10 @@
 package serviceaccess
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"strings"
 
 	"code.cloudfoundry.org/cli/cf/actors"
 	"code.cloudfoundry.org/cli/cf/api/authentication"
@@ -27,14 +30,21 @@ func init() {
 
 func (cmd *EnableServiceAccess) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
-	fs["p"] = &flags.StringFlag{ShortName: "p", Usage: T("Enable access to a specified service plan")}
+	fs["p"] = &flags.StringSliceFlag{ShortName: "p", Usage: T("Enable access to a specified service plan, or the plans matching a glob pattern. This flag can be defined more than once.")}
 	fs["o"] = &flags.StringFlag{ShortName: "o", Usage: T("Enable access for a specified organization")}
+	fs["org-file"] = &flags.StringFlag{Name: "org-file", Usage: T("Enable access for the organizations listed in a file, one per line")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "enable-service-access",
 		Description: T("Enable access to a service or service plan for one or all orgs"),
 		Usage: []string{
-			"CF_NAME enable-service-access SERVICE [-p PLAN] [-o ORG]",
+			"CF_NAME enable-service-access SERVICE [-p PLAN]... [-o ORG] [--org-file FILE]",
+			"\n\n",
+			T("   With several plans, a plan pattern such as 'gold-*' or --org-file, the plans and orgs are updated concurrently and the result of each update is listed."),
+		},
+		Examples: []string{
+			"CF_NAME enable-service-access mysql -p small -p medium",
+			"CF_NAME enable-service-access mysql -p 'gold-*' --org-file orgs.txt",
 		},
 		Flags: fs,
 	}
@@ -68,10 +78,17 @@ func (cmd *EnableServiceAccess) Execute(c flags.FlagContext) error {
 	}
 
 	serviceName := c.Args()[0]
-	planName := c.String("p")
+	planNames := c.StringSlice("p")
 	orgName := c.String("o")
 
-	if planName != "" && orgName != "" {
+	planName := ""
+	if len(planNames) == 1 {
+		planName = planNames[0]
+	}
+
+	if len(planNames) > 1 || isPlanPattern(planName) || c.IsSet("org-file") {
+		err = cmd.enablePlansAndOrgsForService(serviceName, planNames, orgName, c.String("org-file"))
+	} else if planName != "" && orgName != "" {
 		err = cmd.enablePlanAndOrgForService(serviceName, planName, orgName)
 	} else if planName != "" {
 		err = cmd.enablePlanForService(serviceName, planName)
@@ -128,3 +145,103 @@ func (cmd *EnableServiceAccess) enableAllPlansForSingleOrgForService(serviceName
 		}))
 	return cmd.actor.UpdateOrgForService(serviceName, orgName, true)
 }
+
+func (cmd *EnableServiceAccess) enablePlansAndOrgsForService(serviceName string, planPatterns []string, orgName string, orgFile string) error {
+	orgNames := []string{}
+	if orgName != "" {
+		orgNames = append(orgNames, orgName)
+	}
+	if orgFile != "" {
+		fileOrgNames, err := readOrgFile(orgFile)
+		if err != nil {
+			return err
+		}
+		orgNames = append(orgNames, fileOrgNames...)
+	}
+	if len(planPatterns) == 0 {
+		planPatterns = []string{"*"}
+	}
+
+	if len(orgNames) > 0 {
+		cmd.ui.Say(T("Enabling access to plans {{.PlanNames}} of service {{.ServiceName}} for {{.OrgCount}} orgs as {{.Username}}...",
+			map[string]interface{}{
+				"PlanNames":   terminal.EntityNameColor(strings.Join(planPatterns, ", ")),
+				"ServiceName": terminal.EntityNameColor(serviceName),
+				"OrgCount":    len(orgNames),
+				"Username":    terminal.EntityNameColor(cmd.config.Username()),
+			}))
+	} else {
+		cmd.ui.Say(T("Enabling access to plans {{.PlanNames}} of service {{.ServiceName}} for all orgs as {{.Username}}...",
+			map[string]interface{}{
+				"PlanNames":   terminal.EntityNameColor(strings.Join(planPatterns, ", ")),
+				"ServiceName": terminal.EntityNameColor(serviceName),
+				"Username":    terminal.EntityNameColor(cmd.config.Username()),
+			}))
+	}
+
+	updates, err := cmd.actor.UpdatePlansAndOrgsForService(serviceName, planPatterns, orgNames, true)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say("")
+	table := cmd.ui.Table([]string{T("plan"), T("org"), T("result")})
+	failed := 0
+	for _, update := range updates {
+		org := update.Org
+		if org == "" {
+			org = T("all")
+		}
+
+		var result string
+		switch {
+		case update.Err != nil:
+			failed++
+			result = terminal.FailureColor(update.Err.Error())
+		case update.AlreadyPublic:
+			result = T("already public")
+		default:
+			result = T("enabled")
+		}
+		table.Add(update.Plan, org, result)
+	}
+	err = table.Print()
+	if err != nil {
+		return err
+	}
+	cmd.ui.Say("")
+
+	if failed > 0 {
+		return errors.New(T("{{.Failed}} of {{.Total}} updates failed",
+			map[string]interface{}{"Failed": failed, "Total": len(updates)}))
+	}
+	return nil
+}
+
+func isPlanPattern(planName string) bool {
+	return strings.ContainsAny(planName, "*?[")
+}
+
+// readOrgFile returns the org names listed in path, one per line. Blank lines
+// and lines starting with # are skipped.
+func readOrgFile(path string) ([]string, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.New(T("Error reading org file {{.Path}}: {{.Err}}",
+			map[string]interface{}{"Path": path, "Err": err.Error()}))
+	}
+
+	orgNames := []string{}
+	for _, line := range strings.Split(string(contents), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		orgNames = append(orgNames, line)
+	}
+
+	if len(orgNames) == 0 {
+		return nil, errors.New(T("The org file {{.Path}} lists no orgs", map[string]interface{}{"Path": path}))
+	}
+	return orgNames, nil
+}
@@ -2,7 +2,10 @@ package serviceaccess_test
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
 
+	"code.cloudfoundry.org/cli/cf/actors"
 	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
 	"code.cloudfoundry.org/cli/cf/api/authentication/authenticationfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
@@ -191,6 +194,108 @@ var _ = Describe("enable-service-access command", func() {
 					Expect(enable).To(BeTrue())
 				})
 			})
+
+			Context("the user provides several plans, a plan pattern or an org file", func() {
+				var orgFile *os.File
+
+				BeforeEach(func() {
+					var err error
+					orgFile, err = ioutil.TempFile("", "org-file")
+					Expect(err).NotTo(HaveOccurred())
+					_, err = orgFile.WriteString("# orgs of the gold customers\norg-1\n\n  org-2  \n")
+					Expect(err).NotTo(HaveOccurred())
+					orgFile.Close()
+				})
+
+				AfterEach(func() {
+					os.Remove(orgFile.Name())
+				})
+
+				It("enables all the plans for all orgs and lists the results", func() {
+					actor.UpdatePlansAndOrgsForServiceReturns([]actors.ServiceAccessUpdate{
+						{Plan: "small"},
+						{Plan: "medium"},
+					}, nil)
+
+					Expect(runCommand([]string{"-p", "small", "-p", "medium", serviceName})).To(BeTrue())
+
+					Expect(actor.UpdatePlansAndOrgsForServiceCallCount()).To(Equal(1))
+					service, plans, orgs, enable := actor.UpdatePlansAndOrgsForServiceArgsForCall(0)
+					Expect(service).To(Equal(serviceName))
+					Expect(plans).To(Equal([]string{"small", "medium"}))
+					Expect(orgs).To(BeEmpty())
+					Expect(enable).To(BeTrue())
+
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Enabling access to plans small, medium of service service for all orgs"},
+						[]string{"plan", "org", "result"},
+						[]string{"small", "all", "enabled"},
+						[]string{"medium", "all", "enabled"},
+						[]string{"OK"},
+					))
+				})
+
+				It("uses a single plan pattern and the orgs of the org file", func() {
+					actor.UpdatePlansAndOrgsForServiceReturns([]actors.ServiceAccessUpdate{
+						{Plan: "gold-1", Org: "my-org"},
+						{Plan: "gold-1", Org: "org-1", AlreadyPublic: true},
+					}, nil)
+
+					Expect(runCommand([]string{"-p", "gold-*", "-o", orgName, "--org-file", orgFile.Name(), serviceName})).To(BeTrue())
+
+					_, plans, orgs, _ := actor.UpdatePlansAndOrgsForServiceArgsForCall(0)
+					Expect(plans).To(Equal([]string{"gold-*"}))
+					Expect(orgs).To(Equal([]string{"my-org", "org-1", "org-2"}))
+
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Enabling access to plans gold-* of service service for 3 orgs"},
+						[]string{"gold-1", "my-org", "enabled"},
+						[]string{"gold-1", "org-1", "already public"},
+						[]string{"OK"},
+					))
+				})
+
+				It("enables all plans when only an org file is given", func() {
+					runCommand([]string{"--org-file", orgFile.Name(), serviceName})
+
+					_, plans, orgs, _ := actor.UpdatePlansAndOrgsForServiceArgsForCall(0)
+					Expect(plans).To(Equal([]string{"*"}))
+					Expect(orgs).To(Equal([]string{"org-1", "org-2"}))
+				})
+
+				It("lists the updates that failed and fails", func() {
+					actor.UpdatePlansAndOrgsForServiceReturns([]actors.ServiceAccessUpdate{
+						{Plan: "small", Org: "org-1"},
+						{Plan: "small", Org: "org-2", Err: errors.New("org-2 not found")},
+					}, nil)
+
+					Expect(runCommand([]string{"-p", "small", "--org-file", orgFile.Name(), serviceName})).To(BeFalse())
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"small", "org-1", "enabled"},
+						[]string{"small", "org-2", "org-2 not found"},
+						[]string{"FAILED"},
+						[]string{"1 of 2 updates failed"},
+					))
+				})
+
+				It("fails when the plans cannot be updated at all", func() {
+					actor.UpdatePlansAndOrgsForServiceReturns(nil, errors.New("No plans of service service match gold-*"))
+
+					Expect(runCommand([]string{"-p", "gold-*", serviceName})).To(BeFalse())
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"FAILED"},
+						[]string{"No plans of service service match gold-*"},
+					))
+				})
+
+				It("fails when the org file cannot be read", func() {
+					Expect(runCommand([]string{"--org-file", "/non/existent/file", serviceName})).To(BeFalse())
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Error reading org file /non/existent/file"},
+					))
+					Expect(actor.UpdatePlansAndOrgsForServiceCallCount()).To(Equal(0))
+				})
+			})
 		})
 	})
 })
@@ -0,0 +1,160 @@
+package servicebroker
+
+import (
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors"
+	"code.cloudfoundry.org/cli/cf/actors/servicebuilder"
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type BrokerCatalogDiff struct {
+	ui                terminal.UI
+	config            coreconfig.Reader
+	serviceBrokerRepo api.ServiceBrokerRepository
+	brokerCatalogRepo api.BrokerCatalogRepository
+	serviceBuilder    servicebuilder.ServiceBuilder
+}
+
+func init() {
+	commandregistry.Register(&BrokerCatalogDiff{})
+}
+
+func (cmd *BrokerCatalogDiff) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["username"] = &flags.StringFlag{Name: "username", Usage: T("Username to fetch the catalog with (Default: the username the broker is registered with)")}
+	fs["password"] = &flags.StringFlag{Name: "password", Usage: T("Password to fetch the catalog with; prompted for when not given")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "broker-catalog-diff",
+		Description: T("Show how the catalog of a service broker differs from its registered services and plans, and what updating the broker would change about service access"),
+		Usage: []string{
+			T("CF_NAME broker-catalog-diff SERVICE_BROKER [--username USERNAME] [--password PASSWORD]"),
+			"\n\n",
+			T("   The catalog is fetched from the broker itself, so its URL has to be reachable from this machine."),
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *BrokerCatalogDiff) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 1 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires SERVICE_BROKER as an argument\n\n") + commandregistry.Commands.CommandUsage("broker-catalog-diff"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
+	}
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+	}
+
+	return reqs, nil
+}
+
+func (cmd *BrokerCatalogDiff) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.serviceBrokerRepo = deps.RepoLocator.GetServiceBrokerRepository()
+	cmd.brokerCatalogRepo = deps.RepoLocator.GetBrokerCatalogRepository()
+	cmd.serviceBuilder = deps.ServiceBuilder
+	return cmd
+}
+
+func (cmd *BrokerCatalogDiff) Execute(c flags.FlagContext) error {
+	serviceBroker, err := cmd.serviceBrokerRepo.FindByName(c.Args()[0])
+	if err != nil {
+		return err
+	}
+
+	username := serviceBroker.Username
+	if c.IsSet("username") {
+		username = c.String("username")
+	}
+	password := c.String("password")
+	if !c.IsSet("password") {
+		password = cmd.ui.AskForPassword(T("Password of the service broker"))
+	}
+
+	cmd.ui.Say(T("Comparing the catalog of service broker {{.Name}} with its registered services as {{.Username}}...",
+		map[string]interface{}{
+			"Name":     terminal.EntityNameColor(serviceBroker.Name),
+			"Username": terminal.EntityNameColor(cmd.config.Username())}))
+
+	catalog, err := cmd.brokerCatalogRepo.GetCatalog(serviceBroker.URL, username, password)
+	if err != nil {
+		return err
+	}
+
+	services, err := cmd.serviceBuilder.GetServicesForBroker(serviceBroker.GUID)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	changes := actors.DiffBrokerCatalog(catalog, services)
+	if len(changes) == 0 {
+		cmd.ui.Say(T("The catalog matches the registered services and plans; updating the broker would change nothing."))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{T("service"), T("plan"), T("change"), T("service access")})
+	for _, change := range changes {
+		table.Add(change.Service, change.Plan, catalogChangeDescription(change), catalogAccessChange(change))
+	}
+	err = table.Print()
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(T("\nTIP: Use '{{.Command}}' to apply the catalog, then '{{.AccessCommand}}' to give access to new plans.",
+		map[string]interface{}{
+			"Command":       terminal.CommandColor(cf.Name + " update-service-broker " + serviceBroker.Name + " --url " + serviceBroker.URL),
+			"AccessCommand": terminal.CommandColor(cf.Name + " enable-service-access"),
+		}))
+	return nil
+}
+
+func catalogChangeDescription(change actors.CatalogChange) string {
+	switch change.Kind {
+	case actors.CatalogAdded:
+		return T("added")
+	case actors.CatalogRemoved:
+		return T("removed")
+	default:
+		return T("changed: {{.Fields}}", map[string]interface{}{"Fields": strings.Join(change.Fields, ", ")})
+	}
+}
+
+// catalogAccessChange describes what updating the broker would mean for the
+// access to the plan of change: new plans are registered without access, and
+// the orgs that can see a removed plan lose it.
+func catalogAccessChange(change actors.CatalogChange) string {
+	if change.Plan == "" {
+		return ""
+	}
+
+	switch change.Kind {
+	case actors.CatalogAdded:
+		return T("none until {{.Command}}",
+			map[string]interface{}{"Command": fmt.Sprintf("%s enable-service-access %s -p %s", cf.Name, change.Service, change.Plan)})
+	case actors.CatalogRemoved:
+		if change.Public {
+			return T("all orgs lose access")
+		}
+		if len(change.OrgNames) > 0 {
+			return T("orgs {{.OrgNames}} lose access", map[string]interface{}{"OrgNames": strings.Join(change.OrgNames, ", ")})
+		}
+		return ""
+	default:
+		return T("unchanged")
+	}
+}
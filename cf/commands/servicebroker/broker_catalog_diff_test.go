@@ -0,0 +1,151 @@
+package servicebroker_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/actors/servicebuilder/servicebuilderfakes"
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+)
+
+var _ = Describe("broker-catalog-diff command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		configRepo          coreconfig.Repository
+		serviceBrokerRepo   *apifakes.FakeServiceBrokerRepository
+		brokerCatalogRepo   *apifakes.FakeBrokerCatalogRepository
+		serviceBuilder      *servicebuilderfakes.FakeServiceBuilder
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.RepoLocator = deps.RepoLocator.SetServiceBrokerRepository(serviceBrokerRepo).SetBrokerCatalogRepository(brokerCatalogRepo)
+		deps.ServiceBuilder = serviceBuilder
+		deps.Config = configRepo
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("broker-catalog-diff").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		serviceBrokerRepo = new(apifakes.FakeServiceBrokerRepository)
+		brokerCatalogRepo = new(apifakes.FakeBrokerCatalogRepository)
+		serviceBuilder = new(servicebuilderfakes.FakeServiceBuilder)
+
+		serviceBrokerRepo.FindByNameReturns(models.ServiceBroker{
+			Name:     "my-broker",
+			GUID:     "my-broker-guid",
+			Username: "registered-user",
+			URL:      "https://broker.example.com",
+		}, nil)
+		serviceBuilder.GetServicesForBrokerReturns([]models.ServiceOffering{
+			{
+				ServiceOfferingFields: models.ServiceOfferingFields{Label: "mysql"},
+				Plans: []models.ServicePlanFields{
+					{Name: "small", Free: true, Public: true},
+					{Name: "legacy", Free: true, OrgNames: []string{"org-1", "org-2"}},
+				},
+			},
+		}, nil)
+		brokerCatalogRepo.GetCatalogReturns(models.BrokerCatalog{
+			Services: []models.BrokerCatalogService{
+				{Name: "mysql", Plans: []models.BrokerCatalogPlan{
+					{Name: "small", Free: false},
+					{Name: "large", Free: true},
+				}},
+			},
+		}, nil)
+	})
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("broker-catalog-diff", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("fails with usage when not given exactly one arg", func() {
+			runCommand()
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires", "argument"},
+			))
+		})
+
+		It("fails when not logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(runCommand("my-broker")).To(BeFalse())
+		})
+	})
+
+	It("shows the differences and what they mean for service access", func() {
+		runCommand("my-broker", "--password", "broker-password")
+
+		Expect(serviceBrokerRepo.FindByNameArgsForCall(0)).To(Equal("my-broker"))
+		url, username, password := brokerCatalogRepo.GetCatalogArgsForCall(0)
+		Expect(url).To(Equal("https://broker.example.com"))
+		Expect(username).To(Equal("registered-user"))
+		Expect(password).To(Equal("broker-password"))
+		Expect(serviceBuilder.GetServicesForBrokerArgsForCall(0)).To(Equal("my-broker-guid"))
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Comparing the catalog of service broker my-broker", "my-user"},
+			[]string{"OK"},
+			[]string{"service", "plan", "change", "service access"},
+			[]string{"mysql", "large", "added", "none until cf enable-service-access mysql -p large"},
+			[]string{"mysql", "legacy", "removed", "orgs org-1, org-2 lose access"},
+			[]string{"mysql", "small", "changed: free", "unchanged"},
+			[]string{"TIP", "cf update-service-broker my-broker --url https://broker.example.com"},
+		))
+	})
+
+	It("prompts for the password when it is not given", func() {
+		ui.Inputs = []string{"prompted-password"}
+
+		runCommand("my-broker", "--username", "other-user")
+
+		_, username, password := brokerCatalogRepo.GetCatalogArgsForCall(0)
+		Expect(username).To(Equal("other-user"))
+		Expect(password).To(Equal("prompted-password"))
+	})
+
+	It("says so when the catalog matches", func() {
+		brokerCatalogRepo.GetCatalogReturns(models.BrokerCatalog{
+			Services: []models.BrokerCatalogService{
+				{Name: "mysql", Plans: []models.BrokerCatalogPlan{
+					{Name: "small", Free: true},
+					{Name: "legacy", Free: true},
+				}},
+			},
+		}, nil)
+
+		runCommand("my-broker", "--password", "broker-password")
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"The catalog matches the registered services and plans"},
+		))
+	})
+
+	It("fails when the catalog cannot be fetched", func() {
+		brokerCatalogRepo.GetCatalogReturns(models.BrokerCatalog{}, errors.New("connection refused"))
+
+		runCommand("my-broker", "--password", "broker-password")
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"connection refused"},
+		))
+	})
+})
@@ -23,18 +23,30 @@ func init() {
 }
 
 func (cmd *UpdateServiceBroker) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["username"] = &flags.StringFlag{Name: "username", Usage: T("New username for the service broker")}
+	fs["password"] = &flags.StringFlag{Name: "password", Usage: T("New password for the service broker")}
+	fs["url"] = &flags.StringFlag{Name: "url", Usage: T("New URL of the service broker")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "update-service-broker",
 		Description: T("Update a service broker"),
 		Usage: []string{
 			T("CF_NAME update-service-broker SERVICE_BROKER USERNAME PASSWORD URL"),
+			"\n   ",
+			T("CF_NAME update-service-broker SERVICE_BROKER [--username USERNAME] [--password PASSWORD] [--url URL]"),
+		},
+		Examples: []string{
+			"CF_NAME update-service-broker my-broker --password n3wPa55",
 		},
+		Flags: fs,
 	}
 }
 
 func (cmd *UpdateServiceBroker) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
-	if len(fc.Args()) != 4 {
-		cmd.ui.Failed(T("Incorrect Usage. Requires SERVICE_BROKER, USERNAME, PASSWORD, URL as arguments\n\n") + commandregistry.Commands.CommandUsage("update-service-broker"))
+	flagsSet := fc.IsSet("username") || fc.IsSet("password") || fc.IsSet("url")
+	if (len(fc.Args()) != 4 || flagsSet) && (len(fc.Args()) != 1 || !flagsSet) {
+		cmd.ui.Failed(T("Incorrect Usage. Requires SERVICE_BROKER, USERNAME, PASSWORD, URL as arguments, or SERVICE_BROKER and at least one of --username, --password and --url\n\n") + commandregistry.Commands.CommandUsage("update-service-broker"))
 		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 4)
 	}
 
@@ -63,9 +75,16 @@ func (cmd *UpdateServiceBroker) Execute(c flags.FlagContext) error {
 			"Name":     terminal.EntityNameColor(serviceBroker.Name),
 			"Username": terminal.EntityNameColor(cmd.config.Username())}))
 
-	serviceBroker.Username = c.Args()[1]
-	serviceBroker.Password = c.Args()[2]
-	serviceBroker.URL = c.Args()[3]
+	if len(c.Args()) == 4 {
+		serviceBroker.Username = c.Args()[1]
+		serviceBroker.Password = c.Args()[2]
+		serviceBroker.URL = c.Args()[3]
+	} else {
+		// Only the properties given are sent; the others are left as they are.
+		serviceBroker.Username = c.String("username")
+		serviceBroker.Password = c.String("password")
+		serviceBroker.URL = c.String("url")
+	}
 
 	err = cmd.repo.Update(serviceBroker)
 
@@ -53,6 +53,24 @@ var _ = Describe("update-service-broker command", func() {
 			))
 		})
 
+		It("fails with usage when flags are combined with all four args", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+
+			runCommand("arg1", "arg2", "arg3", "arg4", "--url", "new-url")
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires", "arguments"},
+			))
+		})
+
+		It("fails with usage when invoked with only the broker and no flags", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+
+			runCommand("arg1")
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "Requires", "arguments"},
+			))
+		})
+
 		It("fails when not logged in", func() {
 			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
 			Expect(runCommand("heeeeeeey", "yooouuuuuuu", "guuuuuuuuys", "ヾ(＠*ー⌒ー*@)ノ")).To(BeFalse())
@@ -87,5 +105,27 @@ var _ = Describe("update-service-broker command", func() {
 
 			Expect(serviceBrokerRepo.UpdateArgsForCall(0)).To(Equal(expectedServiceBroker))
 		})
+
+		It("only updates the properties given as flags", func() {
+			broker := models.ServiceBroker{
+				Name:     "my-found-broker",
+				GUID:     "my-found-broker-guid",
+				Username: "old-username",
+				URL:      "old-url",
+			}
+			serviceBrokerRepo.FindByNameReturns(broker, nil)
+
+			runCommand("my-broker", "--password", "new-password")
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Updating service broker", "my-found-broker", "my-user"},
+				[]string{"OK"},
+			))
+			Expect(serviceBrokerRepo.UpdateArgsForCall(0)).To(Equal(models.ServiceBroker{
+				Name:     "my-found-broker",
+				GUID:     "my-found-broker-guid",
+				Password: "new-password",
+			}))
+		})
 	})
 })
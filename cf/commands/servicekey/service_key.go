@@ -39,8 +39,10 @@ func (cmd *ServiceKey) MetaData() commandregistry.CommandMetadata {
 		},
 		Examples: []string{
 			"CF_NAME service-key mydb mykey",
+			"CF_NAME service-key mydb mykey --output json",
 		},
-		Flags: fs,
+		Flags:            fs,
+		StructuredOutput: true,
 	}
 }
 
@@ -93,6 +95,28 @@ func (cmd *ServiceKey) Execute(c flags.FlagContext) error {
 		}
 	}
 
+	if printer, ok := cmd.ui.(terminal.DataPrinter); ok {
+		if c.Bool("guid") {
+			printer.SetData(map[string]interface{}{"guid": serviceKey.Fields.GUID})
+			return nil
+		}
+
+		if serviceKey.Fields.Name == "" {
+			return errors.New(T("No service key {{.ServiceKeyName}} found for service instance {{.ServiceInstanceName}}",
+				map[string]interface{}{
+					"ServiceKeyName":      serviceKeyName,
+					"ServiceInstanceName": serviceInstance.Name}))
+		}
+
+		printer.SetData(map[string]interface{}{
+			"name":             serviceKey.Fields.Name,
+			"guid":             serviceKey.Fields.GUID,
+			"service_instance": serviceInstance.Name,
+			"credentials":      serviceKey.Credentials,
+		})
+		return nil
+	}
+
 	if c.Bool("guid") {
 		cmd.ui.Say(serviceKey.Fields.GUID)
 	} else {
@@ -0,0 +1,161 @@
+package servicekey
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+
+	. "code.cloudfoundry.org/cli/cf/i18n"
+)
+
+const (
+	shellSh         = "sh"
+	shellPowerShell = "powershell"
+)
+
+// invalidEnvNameChars are the characters that cannot appear in the name of an
+// env variable in every shell.
+var invalidEnvNameChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+type ServiceKeyEnv struct {
+	ui                         terminal.UI
+	serviceKeyRepo             api.ServiceKeyRepository
+	serviceInstanceRequirement requirements.ServiceInstanceRequirement
+}
+
+func init() {
+	commandregistry.Register(&ServiceKeyEnv{})
+}
+
+func (cmd *ServiceKeyEnv) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["shell"] = &flags.StringFlag{Name: "shell", Usage: T("Syntax of the statements: 'sh' for bash, zsh and other POSIX shells, or 'powershell' (Default: 'powershell' on Windows, 'sh' elsewhere)")}
+	fs["prefix"] = &flags.StringFlag{Name: "prefix", Usage: T("Prefix for the names of the env variables, e.g. MYDB_")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "service-key-env",
+		Description: T("Print the credentials of a service key as statements that set env variables in a shell"),
+		Usage: []string{
+			T("CF_NAME service-key-env SERVICE_INSTANCE SERVICE_KEY [--shell (sh | powershell)] [--prefix PREFIX]"),
+			"\n\n",
+			T("   Nested credentials are joined into one name with underscores, e.g. {\"db\": {\"host\": ...}} becomes DB_HOST. Lists are set as JSON."),
+		},
+		Examples: []string{
+			"eval \"$(CF_NAME service-key-env mydb mykey --prefix MYDB_)\"",
+			"CF_NAME service-key-env mydb mykey --shell powershell | Invoke-Expression",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *ServiceKeyEnv) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 2 {
+		cmd.ui.Failed(T("Incorrect Usage. Requires SERVICE_INSTANCE SERVICE_KEY as arguments\n\n") + commandregistry.Commands.CommandUsage("service-key-env"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 2)
+	}
+
+	loginRequirement := requirementsFactory.NewLoginRequirement()
+	cmd.serviceInstanceRequirement = requirementsFactory.NewServiceInstanceRequirement(fc.Args()[0])
+	targetSpaceRequirement := requirementsFactory.NewTargetedSpaceRequirement()
+
+	reqs := []requirements.Requirement{loginRequirement, cmd.serviceInstanceRequirement, targetSpaceRequirement}
+	return reqs, nil
+}
+
+func (cmd *ServiceKeyEnv) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.serviceKeyRepo = deps.RepoLocator.GetServiceKeyRepository()
+	return cmd
+}
+
+// Execute prints only the statements, without the usual progress messages,
+// so that its output can be evaluated by the shell as it is.
+func (cmd *ServiceKeyEnv) Execute(c flags.FlagContext) error {
+	serviceInstance := cmd.serviceInstanceRequirement.GetServiceInstance()
+	serviceKeyName := c.Args()[1]
+
+	shell := shellSh
+	if runtime.GOOS == "windows" {
+		shell = shellPowerShell
+	}
+	if c.IsSet("shell") {
+		shell = strings.ToLower(c.String("shell"))
+	}
+	if shell != shellSh && shell != shellPowerShell {
+		return errors.New(T("Invalid value {{.Value}} for --shell; it must be sh or powershell",
+			map[string]interface{}{"Value": c.String("shell")}))
+	}
+
+	serviceKey, err := cmd.serviceKeyRepo.GetServiceKey(serviceInstance.GUID, serviceKeyName)
+	if _, ok := err.(*errors.NotAuthorizedError); ok || (err == nil && serviceKey.Fields.Name == "") {
+		return errors.New(T("No service key {{.ServiceKeyName}} found for service instance {{.ServiceInstanceName}}",
+			map[string]interface{}{
+				"ServiceKeyName":      serviceKeyName,
+				"ServiceInstanceName": serviceInstance.Name}))
+	}
+	if err != nil {
+		return err
+	}
+
+	env := map[string]string{}
+	err = flattenCredentials(env, strings.ToUpper(invalidEnvNameChars.ReplaceAllString(c.String("prefix"), "_")), serviceKey.Credentials)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		cmd.ui.Say("%s", envStatement(shell, name, env[name]))
+	}
+	return nil
+}
+
+// flattenCredentials adds the credentials to env, naming each after its key in
+// upper case behind prefix. The keys of nested objects are appended to the
+// name of the object; any other value that is not a string is set as JSON.
+func flattenCredentials(env map[string]string, prefix string, credentials map[string]interface{}) error {
+	for key, value := range credentials {
+		name := prefix + strings.ToUpper(invalidEnvNameChars.ReplaceAllString(key, "_"))
+
+		switch value := value.(type) {
+		case map[string]interface{}:
+			err := flattenCredentials(env, name+"_", value)
+			if err != nil {
+				return err
+			}
+		case string:
+			env[name] = value
+		default:
+			jsonBytes, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			env[name] = string(jsonBytes)
+		}
+	}
+	return nil
+}
+
+// envStatement sets the env variable name to value in shell, quoting value so
+// that the shell does not expand anything in it.
+func envStatement(shell string, name string, value string) string {
+	if shell == shellPowerShell {
+		return fmt.Sprintf("$env:%s = '%s'", name, strings.Replace(value, "'", "''", -1))
+	}
+	return fmt.Sprintf("export %s='%s'", name, strings.Replace(value, "'", `'\''`, -1))
+}
@@ -0,0 +1,136 @@
+package servicekey_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("service-key-env command", func() {
+	var (
+		ui                  *testterm.FakeUI
+		config              coreconfig.Repository
+		requirementsFactory *requirementsfakes.FakeFactory
+		serviceKeyRepo      *apifakes.OldFakeServiceKeyRepo
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.RepoLocator = deps.RepoLocator.SetServiceKeyRepository(serviceKeyRepo)
+		deps.Config = config
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("service-key-env").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		config = testconfig.NewRepositoryWithDefaults()
+		serviceInstance := models.ServiceInstance{}
+		serviceInstance.GUID = "fake-service-instance-guid"
+		serviceInstance.Name = "fake-service-instance"
+		serviceKeyRepo = apifakes.NewFakeServiceKeyRepo()
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+		requirementsFactory.NewTargetedSpaceRequirementReturns(requirements.Passing{})
+		serviceInstanceReq := new(requirementsfakes.FakeServiceInstanceRequirement)
+		requirementsFactory.NewServiceInstanceRequirementReturns(serviceInstanceReq)
+		serviceInstanceReq.GetServiceInstanceReturns(serviceInstance)
+
+		serviceKeyRepo.GetServiceKeyMethod.ServiceKey = models.ServiceKey{
+			Fields: models.ServiceKeyFields{
+				Name: "fake-service-key",
+				GUID: "fake-service-key-guid",
+			},
+			Credentials: map[string]interface{}{
+				"username": "fake-username",
+				"password": "it's-secret",
+				"port":     float64(3306),
+				"tls":      map[string]interface{}{"enabled": true, "ca-cert": "fake-cert"},
+				"hosts":    []interface{}{"host-1", "host-2"},
+			},
+		}
+	})
+
+	var callServiceKeyEnv = func(args ...string) bool {
+		return testcmd.RunCLICommand("service-key-env", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("requires two arguments to run", func() {
+			Expect(callServiceKeyEnv("fake-arg-one")).To(BeFalse())
+			Expect(callServiceKeyEnv("fake-arg-one", "fake-arg-two")).To(BeTrue())
+		})
+
+		It("fails when not logged in", func() {
+			requirementsFactory.NewLoginRequirementReturns(requirements.Failing{Message: "not logged in"})
+			Expect(callServiceKeyEnv("fake-service-instance", "fake-service-key")).To(BeFalse())
+		})
+	})
+
+	It("prints only export statements for the credentials, sorted by name", func() {
+		callServiceKeyEnv("--shell", "sh", "fake-service-instance", "fake-service-key")
+
+		Expect(ui.Outputs()).To(Equal([]string{
+			`export HOSTS='["host-1","host-2"]'`,
+			`export PASSWORD='it'\''s-secret'`,
+			`export PORT='3306'`,
+			`export TLS_CA_CERT='fake-cert'`,
+			`export TLS_ENABLED='true'`,
+			`export USERNAME='fake-username'`,
+		}))
+		Expect(serviceKeyRepo.GetServiceKeyMethod.InstanceGUID).To(Equal("fake-service-instance-guid"))
+	})
+
+	It("prints PowerShell statements with a prefix", func() {
+		callServiceKeyEnv("--shell", "powershell", "--prefix", "mydb-", "fake-service-instance", "fake-service-key")
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{`$env:MYDB_PASSWORD = 'it''s-secret'`},
+			[]string{`$env:MYDB_USERNAME = 'fake-username'`},
+		))
+	})
+
+	It("fails when the shell is not supported", func() {
+		callServiceKeyEnv("--shell", "tcsh", "fake-service-instance", "fake-service-key")
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Invalid value tcsh for --shell; it must be sh or powershell"},
+		))
+	})
+
+	It("fails when the key does not exist", func() {
+		serviceKeyRepo.GetServiceKeyMethod.ServiceKey = models.ServiceKey{}
+
+		callServiceKeyEnv("fake-service-instance", "fake-service-key")
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"No service key fake-service-key found for service instance fake-service-instance"},
+		))
+	})
+
+	It("fails when the key cannot be read", func() {
+		serviceKeyRepo.GetServiceKeyMethod.Error = errors.New("api down")
+
+		callServiceKeyEnv("fake-service-instance", "fake-service-key")
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"api down"},
+		))
+	})
+})
@@ -1,12 +1,16 @@
 package servicekey_test
 
 import (
+	"strings"
+
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/flags"
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
 
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
@@ -131,6 +135,51 @@ var _ = Describe("service-key command", func() {
 			})
 		})
 
+		Context("when the output is formatted as json", func() {
+			var (
+				formattedUI terminal.UI
+				cmd         commandregistry.Command
+				flagContext flags.FlagContext
+			)
+
+			BeforeEach(func() {
+				formattedUI = terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+				deps.UI = formattedUI
+				deps.Config = config
+				deps.RepoLocator = deps.RepoLocator.SetServiceKeyRepository(serviceKeyRepo)
+				cmd = commandregistry.Commands.FindCommand("service-key").SetDependency(deps, false)
+				flagContext = flags.NewFlagContext(cmd.MetaData().Flags)
+
+				Expect(flagContext.Parse("fake-service-instance", "fake-service-key")).To(Succeed())
+				_, err := cmd.Requirements(requirementsFactory, flagContext)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("prints the key with its credentials instead of the text", func() {
+				serviceKeyRepo.GetServiceKeyMethod.ServiceKey = models.ServiceKey{
+					Fields:      models.ServiceKeyFields{Name: "fake-service-key", GUID: "fake-service-key-guid"},
+					Credentials: map[string]interface{}{"username": "fake-username", "port": float64(3306)},
+				}
+
+				Expect(cmd.Execute(flagContext)).To(Succeed())
+				Expect(ui.Outputs()).To(BeEmpty())
+
+				Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+				Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`{
+					"name": "fake-service-key",
+					"guid": "fake-service-key-guid",
+					"service_instance": "fake-service-instance",
+					"credentials": {"username": "fake-username", "port": 3306}
+				}`))
+			})
+
+			It("returns an error when the key does not exist", func() {
+				err := cmd.Execute(flagContext)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("No service key fake-service-key found for service instance fake-service-instance"))
+			})
+		})
+
 		Context("when service key does not exist", func() {
 			It("shows no service key is found", func() {
 				callGetServiceKey([]string{"fake-service-instance", "non-exist-service-key"})
@@ -3,6 +3,7 @@ package space
 import (
 	"fmt"
 
+	"code.cloudfoundry.org/cli/cf/actors"
 	"code.cloudfoundry.org/cli/cf/api/spaces"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -17,6 +18,7 @@ type RenameSpace struct {
 	config    coreconfig.ReadWriter
 	spaceRepo spaces.SpaceRepository
 	spaceReq  requirements.SpaceRequirement
+	checker   actors.RenameChecker
 }
 
 func init() {
@@ -24,12 +26,16 @@ func init() {
 }
 
 func (cmd *RenameSpace) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["path"] = &flags.StringFlag{Name: "path", ShortName: "p", Usage: T("Path to the app directory or manifest to check for references to the old name (Default: current directory)")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "rename-space",
 		Description: T("Rename a space"),
 		Usage: []string{
-			T("CF_NAME rename-space SPACE NEW_SPACE"),
+			T("CF_NAME rename-space SPACE NEW_SPACE [-p APP_PATH]"),
 		},
+		Flags: fs,
 	}
 }
 
@@ -54,6 +60,7 @@ func (cmd *RenameSpace) SetDependency(deps commandregistry.Dependency, pluginCal
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.spaceRepo = deps.RepoLocator.GetSpaceRepository()
+	cmd.checker = deps.RenameChecker
 	return cmd
 }
 
@@ -73,11 +80,26 @@ func (cmd *RenameSpace) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	oldName := space.Name
 	if cmd.config.SpaceFields().GUID == space.GUID {
 		space.Name = newName
 		cmd.config.SetSpaceFields(space.SpaceFields)
 	}
 
 	cmd.ui.Ok()
+
+	references := cmd.checker.References(oldName, c.String("path"))
+	if len(references) > 0 {
+		cmd.ui.Warn(T("These still refer to space {{.SpaceName}} and have to be changed by hand:",
+			map[string]interface{}{"SpaceName": oldName}))
+		for _, reference := range references {
+			cmd.ui.Warn("   %s", reference)
+		}
+	}
+
+	for _, context := range cmd.config.RenameContextSpace(space.GUID, newName) {
+		cmd.ui.Say(T("Updated space name in saved context {{.Context}}",
+			map[string]interface{}{"Context": terminal.EntityNameColor(context)}))
+	}
 	return err
 }
@@ -3,6 +3,7 @@ package space_test
 import (
 	"errors"
 
+	"code.cloudfoundry.org/cli/cf/actors/actorsfakes"
 	"code.cloudfoundry.org/cli/cf/api/spaces/spacesfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -24,6 +25,7 @@ var _ = Describe("rename-space command", func() {
 		configRepo          coreconfig.Repository
 		requirementsFactory *requirementsfakes.FakeFactory
 		spaceRepo           *spacesfakes.FakeSpaceRepository
+		renameChecker       *actorsfakes.FakeRenameChecker
 		deps                commandregistry.Dependency
 	)
 
@@ -31,6 +33,7 @@ var _ = Describe("rename-space command", func() {
 		deps.UI = ui
 		deps.RepoLocator = deps.RepoLocator.SetSpaceRepository(spaceRepo)
 		deps.Config = configRepo
+		deps.RenameChecker = renameChecker
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("rename-space").SetDependency(deps, pluginCall))
 	}
 
@@ -38,6 +41,7 @@ var _ = Describe("rename-space command", func() {
 		ui = new(testterm.FakeUI)
 		configRepo = testconfig.NewRepositoryWithDefaults()
 		requirementsFactory = new(requirementsfakes.FakeFactory)
+		renameChecker = new(actorsfakes.FakeRenameChecker)
 		spaceRepo = new(spacesfakes.FakeSpaceRepository)
 	})
 
@@ -111,5 +115,51 @@ var _ = Describe("rename-space command", func() {
 				Expect(configRepo.SpaceFields().Name).To(Equal("my-new-space-name"))
 			})
 		})
+
+		It("warns about references to the old name after renaming", func() {
+			renameChecker.ReferencesReturns([]string{"plugin deployer mentions the-old-space-name in its command promote"})
+			callRenameSpace([]string{"the-old-space-name", "my-new-space"})
+
+			name, appPath := renameChecker.ReferencesArgsForCall(0)
+			Expect(name).To(Equal("the-old-space-name"))
+			Expect(appPath).To(BeEmpty())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"OK"},
+				[]string{"These still refer to space the-old-space-name"},
+				[]string{"plugin deployer mentions the-old-space-name in its command promote"},
+			))
+		})
+
+		It("warns about references to the old name of the targeted space", func() {
+			configRepo.SetSpaceFields(space.SpaceFields)
+			callRenameSpace([]string{"-p", "manifest-prod.yml", "the-old-space-name", "my-new-space"})
+
+			name, appPath := renameChecker.ReferencesArgsForCall(0)
+			Expect(name).To(Equal("the-old-space-name"))
+			Expect(appPath).To(Equal("manifest-prod.yml"))
+		})
+
+		It("does not look for references when the space cannot be renamed", func() {
+			spaceRepo.RenameReturns(errors.New("rename failed"))
+			callRenameSpace([]string{"the-old-space-name", "my-new-space"})
+
+			Expect(renameChecker.ReferencesCallCount()).To(BeZero())
+		})
+
+		It("renames the space in the saved contexts that target it", func() {
+			originalSpace := configRepo.SpaceFields()
+			configRepo.SetSpaceFields(space.SpaceFields)
+			configRepo.SaveContext("dev")
+			configRepo.SetSpaceFields(originalSpace)
+
+			callRenameSpace([]string{"the-old-space-name", "my-new-space"})
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"OK"},
+				[]string{"Updated space name in saved context", "dev"},
+			))
+
+			Expect(configRepo.UseContext("dev")).To(BeTrue())
+			Expect(configRepo.SpaceFields().Name).To(Equal("my-new-space"))
+		})
 	})
 })
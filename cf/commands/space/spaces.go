@@ -9,8 +9,10 @@ import (
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/net"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/cf/uihelpers"
 	"code.cloudfoundry.org/cli/plugin/models"
 )
 
@@ -18,6 +20,7 @@ type ListSpaces struct {
 	ui        terminal.UI
 	config    coreconfig.Reader
 	spaceRepo spaces.SpaceRepository
+	gateway   net.Gateway
 
 	pluginModel *[]plugin_models.GetSpaces_Model
 	pluginCall  bool
@@ -28,12 +31,17 @@ func init() {
 }
 
 func (cmd *ListSpaces) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["max-results"] = &flags.IntFlag{Name: "max-results", Usage: T("Stop after listing this many spaces")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "spaces",
 		Description: T("List all spaces in an org"),
 		Usage: []string{
-			T("CF_NAME spaces"),
+			T("CF_NAME spaces [--max-results NUM]"),
 		},
+		Flags:            fs,
+		StructuredOutput: true,
 	}
 
 }
@@ -59,6 +67,7 @@ func (cmd *ListSpaces) SetDependency(deps commandregistry.Dependency, pluginCall
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.spaceRepo = deps.RepoLocator.GetSpaceRepository()
+	cmd.gateway = deps.Gateways["cloud-controller"]
 	cmd.pluginCall = pluginCall
 	cmd.pluginModel = deps.PluginModels.Spaces
 	return cmd
@@ -71,11 +80,20 @@ func (cmd *ListSpaces) Execute(c flags.FlagContext) error {
 			"CurrentUser":   terminal.EntityNameColor(cmd.config.Username()),
 		}))
 
-	foundSpaces := false
-	table := cmd.ui.Table([]string{T("name")})
+	maxResults := c.Int("max-results")
+	if c.IsSet("max-results") && maxResults < 1 {
+		return errors.New(T("Invalid value {{.Value}} for --max-results; it must be at least 1",
+			map[string]interface{}{"Value": maxResults}))
+	}
+
+	uiTable := cmd.ui.Table([]string{T("name")})
+	uiTable.Table.SetFieldKeys("name")
+	table := uihelpers.NewPagedTable(uiTable)
+	cmd.gateway.SetPageListener(table.PageDone)
+	defer cmd.gateway.SetPageListener(nil)
+
 	err := cmd.spaceRepo.ListSpaces(func(space models.Space) bool {
 		table.Add(space.Name)
-		foundSpaces = true
 
 		if cmd.pluginCall {
 			s := plugin_models.GetSpaces_Model{}
@@ -84,13 +102,8 @@ func (cmd *ListSpaces) Execute(c flags.FlagContext) error {
 			*(cmd.pluginModel) = append(*(cmd.pluginModel), s)
 		}
 
-		return true
+		return maxResults == 0 || table.Rows() < maxResults
 	})
-	err = table.Print()
-	if err != nil {
-		return err
-	}
-
 	if err != nil {
 		return errors.New(T("Failed fetching spaces.\n{{.ErrorDescription}}",
 			map[string]interface{}{
@@ -98,7 +111,12 @@ func (cmd *ListSpaces) Execute(c flags.FlagContext) error {
 			}))
 	}
 
-	if !foundSpaces {
+	err = table.Print()
+	if err != nil {
+		return err
+	}
+
+	if table.Rows() == 0 {
 		cmd.ui.Say(T("No spaces found"))
 	}
 	return nil
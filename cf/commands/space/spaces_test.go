@@ -160,6 +160,28 @@ var _ = Describe("spaces command", func() {
 			))
 		})
 
+		Context("when --max-results is given", func() {
+			It("stops listing spaces once it has listed that many", func() {
+				runCommand("--max-results", "2")
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"space1"},
+					[]string{"space2"},
+				))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"space3"}))
+			})
+
+			It("fails when the value is not positive", func() {
+				runCommand("--max-results", "0")
+
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Invalid value 0 for --max-results; it must be at least 1"},
+				))
+				Expect(spaceRepo.ListSpacesCallCount()).To(BeZero())
+			})
+		})
+
 		Context("when there are no spaces", func() {
 			BeforeEach(func() {
 				spaceRepo.ListSpacesStub = listSpacesStub([]models.Space{})
@@ -3,6 +3,7 @@ package spacequota
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"code.cloudfoundry.org/cli/cf/api/spacequotas"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
@@ -25,12 +26,21 @@ func init() {
 }
 
 func (cmd *ListSpaceQuotas) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["fields"] = &flags.StringFlag{Name: "fields", Usage: T("Comma separated list of the columns to show, named in lower case with underscores for spaces, such as name,total_memory")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "space-quotas",
 		Description: T("List available space resource quotas"),
 		Usage: []string{
-			T("CF_NAME space-quotas"),
+			T("CF_NAME space-quotas [--fields FIELDS]"),
+		},
+		Examples: []string{
+			"CF_NAME space-quotas --fields name,total_memory,app_instances",
+			"CF_NAME space-quotas --output json",
 		},
+		Flags:            fs,
+		StructuredOutput: true,
 	}
 }
 
@@ -67,9 +77,6 @@ func (cmd *ListSpaceQuotas) Execute(c flags.FlagContext) error {
 		return err
 	}
 
-	cmd.ui.Ok()
-	cmd.ui.Say("")
-
 	table := cmd.ui.Table([]string{
 		T("name"),
 		T("total memory"),
@@ -80,6 +87,17 @@ func (cmd *ListSpaceQuotas) Execute(c flags.FlagContext) error {
 		T("app instances"),
 		T("route ports"),
 	})
+	table.Table.SetFieldKeys("name", "total_memory", "instance_memory", "routes", "service_instances", "paid_plans", "app_instances", "route_ports")
+
+	if c.IsSet("fields") {
+		err = table.Table.SelectFields(strings.Split(c.String("fields"), ","))
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
 
 	var megabytes string
 
@@ -1,6 +1,8 @@
 package spacequota_test
 
 import (
+	"strings"
+
 	"code.cloudfoundry.org/cli/cf/api/spacequotas/spacequotasfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -9,6 +11,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
 	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
@@ -22,6 +25,7 @@ import (
 var _ = Describe("quotas command", func() {
 	var (
 		ui                  *testterm.FakeUI
+		commandUI           terminal.UI
 		quotaRepo           *spacequotasfakes.FakeSpaceQuotaRepository
 		configRepo          coreconfig.Repository
 		requirementsFactory *requirementsfakes.FakeFactory
@@ -29,7 +33,7 @@ var _ = Describe("quotas command", func() {
 	)
 
 	updateCommandDependency := func(pluginCall bool) {
-		deps.UI = ui
+		deps.UI = commandUI
 		deps.RepoLocator = deps.RepoLocator.SetSpaceQuotaRepository(quotaRepo)
 		deps.Config = configRepo
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("space-quotas").SetDependency(deps, pluginCall))
@@ -37,6 +41,7 @@ var _ = Describe("quotas command", func() {
 
 	BeforeEach(func() {
 		ui = &testterm.FakeUI{}
+		commandUI = ui
 		quotaRepo = new(spacequotasfakes.FakeSpaceQuotaRepository)
 		requirementsFactory = new(requirementsfakes.FakeFactory)
 		requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
@@ -231,4 +236,54 @@ var _ = Describe("quotas command", func() {
 		})
 	})
 
+	Describe("selecting and formatting fields", func() {
+		BeforeEach(func() {
+			requirementsFactory.NewTargetedOrgRequirementReturns(new(requirementsfakes.FakeTargetedOrgRequirement))
+			quotaRepo.FindByOrgReturns([]models.SpaceQuota{
+				{
+					Name:                    "quota-name",
+					MemoryLimit:             1024,
+					InstanceMemoryLimit:     512,
+					RoutesLimit:             111,
+					ServicesLimit:           222,
+					NonBasicServicesAllowed: true,
+					OrgGUID:                 "my-org-guid",
+					AppInstanceLimit:        -1,
+					ReservedRoutePortsLimit: "6",
+				},
+			}, nil)
+		})
+
+		It("shows only the columns given with --fields", func() {
+			Expect(runCommand("--fields", "name,app_instances")).To(BeTrue())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"name", "app instances"},
+				[]string{"quota-name", "unlimited"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"total memory"}))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"1G"}))
+		})
+
+		It("fails for an unknown field", func() {
+			Expect(runCommand("--fields", "name,colour")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Unknown field colour"},
+			))
+		})
+
+		It("prints the selected fields as JSON", func() {
+			formattedUI := terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+			commandUI = formattedUI
+
+			Expect(runCommand("--fields", "name,total_memory")).To(BeTrue())
+			Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+			Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`[{
+				"name": "quota-name",
+				"total_memory": "1G"
+			}]`))
+		})
+	})
+
 })
@@ -27,6 +27,10 @@ func (cmd *ListStacks) MetaData() commandregistry.CommandMetadata {
 		Usage: []string{
 			T("CF_NAME stacks"),
 		},
+		Examples: []string{
+			"CF_NAME stacks --output json",
+		},
+		StructuredOutput: true,
 	}
 }
 
@@ -68,6 +72,7 @@ func (cmd *ListStacks) Execute(c flags.FlagContext) error {
 	cmd.ui.Say("")
 
 	table := cmd.ui.Table([]string{T("name"), T("description")})
+	table.Table.SetFieldKeys("name", "description")
 
 	for _, stack := range stacks {
 		table.Add(stack.Name, stack.Description)
@@ -1,6 +1,8 @@
 package commands_test
 
 import (
+	"strings"
+
 	"code.cloudfoundry.org/cli/cf/api/stacks/stacksfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -8,6 +10,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
 	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
@@ -93,4 +96,26 @@ var _ = Describe("stacks command", func() {
 			[]string{"Stack-2", "Stack 2 Description"},
 		))
 	})
+
+	It("prints the stacks as JSON", func() {
+		repo.FindAllReturns([]models.Stack{
+			{Name: "cflinuxfs3", Description: "Cloud Foundry Linux-based filesystem (Ubuntu 18.04)"},
+			{Name: "cflinuxfs4", Description: "Cloud Foundry Linux-based filesystem (Ubuntu 22.04)"},
+		}, nil)
+		commandUI := terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+		cmd := &commands.ListStacks{}
+		cmd.SetDependency(commandregistry.Dependency{
+			UI:          commandUI,
+			Config:      config,
+			RepoLocator: deps.RepoLocator.SetStackRepository(repo),
+		}, false)
+
+		Expect(cmd.Execute(flags.NewFlagContext(cmd.MetaData().Flags))).To(Succeed())
+		Expect(commandUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+		Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`[
+			{"name": "cflinuxfs3", "description": "Cloud Foundry Linux-based filesystem (Ubuntu 18.04)"},
+			{"name": "cflinuxfs4", "description": "Cloud Foundry Linux-based filesystem (Ubuntu 22.04)"}
+		]`))
+	})
 })
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type TargetSave struct {
+	ui     terminal.UI
+	config coreconfig.ReadWriter
+}
+
+func init() {
+	commandregistry.Register(&TargetSave{})
+}
+
+func (cmd *TargetSave) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "target-save",
+		Description: T("Save the current target, with its login, as a named context"),
+		Usage: []string{
+			T("CF_NAME target-save NAME"),
+		},
+		Examples: []string{
+			"CF_NAME target-save prod",
+			"CF_NAME --context prod apps",
+		},
+	}
+}
+
+func (cmd *TargetSave) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	usageReq := requirements.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+		T("Requires NAME as an argument"),
+		func() bool {
+			return len(fc.Args()) != 1
+		},
+	)
+
+	reqs := []requirements.Requirement{
+		usageReq,
+		requirementsFactory.NewAPIEndpointRequirement(),
+	}
+
+	return reqs, nil
+}
+
+func (cmd *TargetSave) SetDependency(deps commandregistry.Dependency, _ bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	return cmd
+}
+
+func (cmd *TargetSave) Execute(c flags.FlagContext) error {
+	name := c.Args()[0]
+
+	cmd.ui.Say(T("Saving the current target as context {{.Name}}...",
+		map[string]interface{}{"Name": terminal.EntityNameColor(name)}))
+
+	cmd.config.SaveContext(name)
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Switch back to it with '{{.Command}}', or run a single command against it with '{{.ContextCommand}}'",
+		map[string]interface{}{
+			"Command":        terminal.CommandColor(cf.Name + " target-use " + name),
+			"ContextCommand": terminal.CommandColor(cf.Name + " --context " + name + " COMMAND"),
+		}))
+	return nil
+}
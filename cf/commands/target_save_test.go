@@ -0,0 +1,106 @@
+package commands_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	"code.cloudfoundry.org/cli/cf/commands"
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+)
+
+var _ = Describe("target-save and target-use commands", func() {
+	var (
+		requirementsFactory *requirementsfakes.FakeFactory
+		config              coreconfig.Repository
+		ui                  *testterm.FakeUI
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("target-save").SetDependency(deps, pluginCall))
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("target-use").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = new(testterm.FakeUI)
+		config = testconfig.NewRepositoryWithDefaults()
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		requirementsFactory.NewAPIEndpointRequirementReturns(requirements.Passing{})
+	})
+
+	runCommand := func(name string, args ...string) bool {
+		return testcmd.RunCLICommand(name, args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("target-save", func() {
+		It("fails with usage when no name is given", func() {
+			cmd := new(commands.TargetSave)
+			cmd.SetDependency(deps, false)
+			flagContext := flags.NewFlagContext(cmd.MetaData().Flags)
+
+			reqs, err := cmd.Requirements(requirementsFactory, flagContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = testcmd.RunRequirements(reqs)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Incorrect Usage"))
+			Expect(err.Error()).To(ContainSubstring("Requires NAME as an argument"))
+		})
+
+		It("fails requirements when there is no api endpoint set", func() {
+			requirementsFactory.NewAPIEndpointRequirementReturns(requirements.Failing{Message: "no api set"})
+			Expect(runCommand("target-save", "prod")).To(BeFalse())
+		})
+
+		It("saves the current target as a context", func() {
+			Expect(runCommand("target-save", "prod")).To(BeTrue())
+			Expect(config.ContextNames()).To(Equal([]string{"prod"}))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Saving the current target as context prod"},
+				[]string{"OK"},
+			))
+		})
+	})
+
+	Describe("target-use", func() {
+		BeforeEach(func() {
+			config.SetAPIEndpoint("https://api.prod.example.com")
+			Expect(runCommand("target-save", "prod")).To(BeTrue())
+
+			config.SetAPIEndpoint("https://api.dev.example.com")
+			config.SetOrganizationFields(models.OrganizationFields{Name: "dev-org"})
+			Expect(runCommand("target-save", "dev")).To(BeTrue())
+		})
+
+		It("switches the current target to the context", func() {
+			Expect(runCommand("target-use", "prod")).To(BeTrue())
+			Expect(config.APIEndpoint()).To(Equal("https://api.prod.example.com"))
+			Expect(config.OrganizationFields().Name).To(Equal("my-org"))
+			Expect(ui.ShowConfigurationCalled).To(BeTrue())
+
+			Expect(runCommand("target-use", "dev")).To(BeTrue())
+			Expect(config.APIEndpoint()).To(Equal("https://api.dev.example.com"))
+			Expect(config.OrganizationFields().Name).To(Equal("dev-org"))
+		})
+
+		It("lists the saved contexts when the context is not found", func() {
+			Expect(runCommand("target-use", "staging")).To(BeFalse())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"Context staging not found. The saved contexts are: dev, prod"},
+			))
+		})
+	})
+})
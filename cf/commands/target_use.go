@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type TargetUse struct {
+	ui     terminal.UI
+	config coreconfig.ReadWriter
+}
+
+func init() {
+	commandregistry.Register(&TargetUse{})
+}
+
+func (cmd *TargetUse) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "target-use",
+		Description: T("Switch the current target to a named context"),
+		Usage: []string{
+			T("CF_NAME target-use NAME"),
+		},
+	}
+}
+
+func (cmd *TargetUse) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	usageReq := requirements.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+		T("Requires NAME as an argument"),
+		func() bool {
+			return len(fc.Args()) != 1
+		},
+	)
+
+	reqs := []requirements.Requirement{
+		usageReq,
+	}
+
+	return reqs, nil
+}
+
+func (cmd *TargetUse) SetDependency(deps commandregistry.Dependency, _ bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	return cmd
+}
+
+func (cmd *TargetUse) Execute(c flags.FlagContext) error {
+	name := c.Args()[0]
+
+	if !cmd.config.UseContext(name) {
+		names := cmd.config.ContextNames()
+		if len(names) == 0 {
+			return errors.New(T("Context {{.Name}} not found. Save the current target as a context with '{{.Command}}'.",
+				map[string]interface{}{"Name": name, "Command": cf.Name + " target-save NAME"}))
+		}
+		return errors.New(T("Context {{.Name}} not found. The saved contexts are: {{.Names}}",
+			map[string]interface{}{"Name": name, "Names": strings.Join(names, ", ")}))
+	}
+
+	err := cmd.ui.ShowConfiguration(cmd.config)
+	if err != nil {
+		return err
+	}
+	if !cmd.config.IsLoggedIn() {
+		return fmt.Errorf(terminal.NotLoggedInText())
+	}
+	return nil
+}
@@ -25,22 +25,39 @@ func init() {
 }
 
 func (cmd *CreateUser) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["origin"] = &flags.StringFlag{Name: "origin", Usage: T("Origin of the user's identity provider, such as ldap (default: uaa)")}
+	fs["external-id"] = &flags.StringFlag{Name: "external-id", Usage: T("ID of the user in the identity provider of a non-uaa origin, such as an LDAP DN (default: USERNAME)")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "create-user",
 		Description: T("Create a new user"),
 		Usage: []string{
-			T("CF_NAME create-user USERNAME PASSWORD"),
+			T("CF_NAME create-user USERNAME [PASSWORD]\n"),
+			T("   CF_NAME create-user USERNAME --origin ORIGIN [--external-id EXTERNAL_ID]\n\n"),
+			T("   You are prompted for the password of a uaa user when PASSWORD is left out. Users from other origins sign in with their identity provider and have no password."),
+		},
+		Examples: []string{
+			"CF_NAME create-user j.smith@example.com S3cr3t",
+			"CF_NAME create-user j.smith --origin ldap --external-id \"uid=j.smith,ou=people,dc=example,dc=com\"",
 		},
+		Flags: fs,
 	}
 }
 
 func (cmd *CreateUser) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
-	if len(fc.Args()) != 2 {
+	if len(fc.Args()) != 1 && len(fc.Args()) != 2 {
 		usage := commandregistry.Commands.CommandUsage("create-user")
 		cmd.ui.Failed(T("Incorrect Usage. Requires arguments\n\n") + usage)
 		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 2)
 	}
 
+	if len(fc.Args()) == 2 && !isUAAOrigin(fc.String("origin")) {
+		usage := commandregistry.Commands.CommandUsage("create-user")
+		cmd.ui.Failed(T("Incorrect Usage. Users from origin {{.Origin}} have no password\n\n", map[string]interface{}{"Origin": fc.String("origin")}) + usage)
+		return nil, fmt.Errorf("Incorrect usage: password given for origin %s", fc.String("origin"))
+	}
+
 	reqs := []requirements.Requirement{
 		requirementsFactory.NewLoginRequirement(),
 	}
@@ -57,7 +74,22 @@ func (cmd *CreateUser) SetDependency(deps commandregistry.Dependency, pluginCall
 
 func (cmd *CreateUser) Execute(c flags.FlagContext) error {
 	username := c.Args()[0]
-	password := c.Args()[1]
+	origin := c.String("origin")
+	externalID := c.String("external-id")
+
+	var password string
+	if isUAAOrigin(origin) {
+		if len(c.Args()) == 2 {
+			password = c.Args()[1]
+		} else {
+			password = cmd.ui.AskForPassword(T("Password"))
+		}
+		if password == "" {
+			return errors.New(T("A password is required for users from origin uaa"))
+		}
+	} else if externalID == "" {
+		externalID = username
+	}
 
 	cmd.ui.Say(T("Creating user {{.TargetUser}}...",
 		map[string]interface{}{
@@ -65,7 +97,12 @@ func (cmd *CreateUser) Execute(c flags.FlagContext) error {
 			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
 		}))
 
-	err := cmd.userRepo.Create(username, password)
+	var err error
+	if origin == "" && externalID == "" {
+		err = cmd.userRepo.Create(username, password)
+	} else {
+		err = cmd.userRepo.CreateWithOrigin(username, password, origin, externalID)
+	}
 	switch err.(type) {
 	case nil:
 	case *errors.ModelAlreadyExistsError:
@@ -82,3 +119,7 @@ func (cmd *CreateUser) Execute(c flags.FlagContext) error {
 	cmd.ui.Say(T("\nTIP: Assign roles with '{{.CurrentUser}} set-org-role' and '{{.CurrentUser}} set-space-role'", map[string]interface{}{"CurrentUser": cf.Name}))
 	return nil
 }
+
+func isUAAOrigin(origin string) bool {
+	return origin == "" || origin == "uaa"
+}
@@ -89,6 +89,70 @@ var _ = Describe("Create user command", func() {
 		})
 	})
 
+	It("prompts for the password when it is left out", func() {
+		ui.Inputs = []string{"my-password"}
+
+		Expect(runCommand("my-user")).To(BeTrue())
+
+		Expect(ui.PasswordPrompts).To(ContainSubstrings([]string{"Password"}))
+		userName, password := userRepo.CreateArgsForCall(0)
+		Expect(userName).To(Equal("my-user"))
+		Expect(password).To(Equal("my-password"))
+	})
+
+	It("fails when the prompted password is empty", func() {
+		ui.Inputs = []string{""}
+
+		Expect(runCommand("my-user")).To(BeFalse())
+
+		Expect(userRepo.CreateCallCount()).To(BeZero())
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"A password is required"},
+		))
+	})
+
+	Context("when an origin is given", func() {
+		It("creates a user without a password from that origin", func() {
+			Expect(runCommand("my-user", "--origin", "ldap", "--external-id", "uid=my-user,dc=example,dc=com")).To(BeTrue())
+
+			Expect(ui.PasswordPrompts).To(BeEmpty())
+			Expect(userRepo.CreateCallCount()).To(BeZero())
+			userName, password, origin, externalID := userRepo.CreateWithOriginArgsForCall(0)
+			Expect(userName).To(Equal("my-user"))
+			Expect(password).To(BeEmpty())
+			Expect(origin).To(Equal("ldap"))
+			Expect(externalID).To(Equal("uid=my-user,dc=example,dc=com"))
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"OK"}))
+		})
+
+		It("uses the username as the external ID by default", func() {
+			Expect(runCommand("my-user", "--origin", "ldap")).To(BeTrue())
+
+			_, _, _, externalID := userRepo.CreateWithOriginArgsForCall(0)
+			Expect(externalID).To(Equal("my-user"))
+		})
+
+		It("still asks for a password when the origin is uaa", func() {
+			ui.Inputs = []string{"my-password"}
+
+			Expect(runCommand("my-user", "--origin", "uaa")).To(BeTrue())
+
+			_, password, origin, externalID := userRepo.CreateWithOriginArgsForCall(0)
+			Expect(password).To(Equal("my-password"))
+			Expect(origin).To(Equal("uaa"))
+			Expect(externalID).To(BeEmpty())
+		})
+
+		It("fails with usage when a password is given for another origin", func() {
+			Expect(runCommand("my-user", "my-password", "--origin", "ldap")).To(BeFalse())
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage", "origin ldap have no password"},
+			))
+		})
+	})
+
 	It("fails when no arguments are passed", func() {
 		Expect(runCommand()).To(BeFalse())
 	})
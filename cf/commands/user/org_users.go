@@ -1,11 +1,14 @@
 package user
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"code.cloudfoundry.org/cli/cf"
 	"code.cloudfoundry.org/cli/cf/actors/userprint"
 	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/spaces"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/flags"
@@ -21,6 +24,7 @@ type OrgUsers struct {
 	config      coreconfig.Reader
 	orgReq      requirements.OrganizationRequirement
 	userRepo    api.UserRepository
+	spaceRepo   spaces.SpaceRepository
 	pluginModel *[]plugin_models.GetOrgUsers_Model
 	pluginCall  bool
 }
@@ -32,14 +36,23 @@ func init() {
 func (cmd *OrgUsers) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["a"] = &flags.BoolFlag{ShortName: "a", Usage: T("List all users in the org")}
+	fs["details"] = &flags.BoolFlag{Name: "details", Usage: T("Show the origin and GUID of each user, looked up in UAA")}
+	fs["role"] = &flags.StringFlag{Name: "role", Usage: T("Only list the users with this role, such as OrgManager, or SpaceDeveloper with --all-spaces")}
+	fs["all-spaces"] = &flags.BoolFlag{Name: "all-spaces", Usage: T("List the space roles of the users in every space of the org")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "org-users",
 		Description: T("Show org users by role"),
 		Usage: []string{
-			T("CF_NAME org-users ORG"),
+			T("CF_NAME org-users ORG [-a | --role ROLE] [--details] [--all-spaces]"),
 		},
-		Flags: fs,
+		Examples: []string{
+			"CF_NAME org-users my-org --details",
+			"CF_NAME org-users my-org --all-spaces --role SpaceDeveloper",
+			"CF_NAME org-users my-org --output json",
+		},
+		Flags:            fs,
+		StructuredOutput: true,
 	}
 }
 
@@ -49,6 +62,11 @@ func (cmd *OrgUsers) Requirements(requirementsFactory requirements.Factory, fc f
 		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 1)
 	}
 
+	if fc.Bool("a") && fc.IsSet("role") {
+		cmd.ui.Failed(T("Incorrect Usage. The -a and --role flags cannot be used together.\n\n") + commandregistry.Commands.CommandUsage("org-users"))
+		return nil, fmt.Errorf("Incorrect usage: -a and --role cannot be used together")
+	}
+
 	cmd.orgReq = requirementsFactory.NewOrganizationRequirement(fc.Args()[0])
 
 	reqs := []requirements.Requirement{
@@ -63,6 +81,7 @@ func (cmd *OrgUsers) SetDependency(deps commandregistry.Dependency, pluginCall b
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.userRepo = deps.RepoLocator.GetUserRepository()
+	cmd.spaceRepo = deps.RepoLocator.GetSpaceRepository()
 	cmd.pluginCall = pluginCall
 	cmd.pluginModel = deps.PluginModels.OrgUsers
 	return cmd
@@ -77,37 +96,125 @@ func (cmd *OrgUsers) Execute(c flags.FlagContext) error {
 			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
 		}))
 
-	printer := cmd.printer(c)
+	if c.Bool("all-spaces") {
+		return cmd.printSpaceUsers(org, c)
+	}
+
+	printer, err := cmd.printer(c)
+	if err != nil {
+		return err
+	}
 	printer.PrintUsers(org.GUID, cmd.config.Username())
 	return nil
 }
 
-func (cmd *OrgUsers) printer(c flags.FlagContext) userprint.UserPrinter {
+func (cmd *OrgUsers) printer(c flags.FlagContext) (userprint.UserPrinter, error) {
 	var roles []models.Role
 	if c.Bool("a") {
 		roles = []models.Role{models.RoleOrgUser}
 	} else {
 		roles = []models.Role{models.RoleOrgManager, models.RoleBillingManager, models.RoleOrgAuditor}
 	}
+	if c.IsSet("role") {
+		role, err := selectRole(c.String("role"), roles)
+		if err != nil {
+			return nil, err
+		}
+		roles = []models.Role{role}
+	}
 
 	if cmd.pluginCall {
 		return userprint.NewOrgUsersPluginPrinter(
 			cmd.pluginModel,
 			cmd.userLister(),
 			roles,
-		)
+		), nil
+	}
+
+	roleDisplayNames := map[models.Role]string{
+		models.RoleOrgUser:        T("USERS"),
+		models.RoleOrgManager:     T("ORG MANAGER"),
+		models.RoleBillingManager: T("BILLING MANAGER"),
+		models.RoleOrgAuditor:     T("ORG AUDITOR"),
+	}
+	if _, structured := cmd.ui.(terminal.DataPrinter); structured || c.Bool("details") {
+		return &userprint.UsersTableUIPrinter{
+			UI:               cmd.ui,
+			UserLister:       cmd.userRepo.ListUsersInOrgForRole,
+			Roles:            roles,
+			RoleDisplayNames: roleDisplayNames,
+		}, nil
 	}
 	return &userprint.OrgUsersUIPrinter{
-		UI:         cmd.ui,
-		UserLister: cmd.userLister(),
-		Roles:      roles,
-		RoleDisplayNames: map[models.Role]string{
-			models.RoleOrgUser:        T("USERS"),
-			models.RoleOrgManager:     T("ORG MANAGER"),
-			models.RoleBillingManager: T("BILLING MANAGER"),
-			models.RoleOrgAuditor:     T("ORG AUDITOR"),
-		},
+		UI:               cmd.ui,
+		UserLister:       cmd.userLister(),
+		Roles:            roles,
+		RoleDisplayNames: roleDisplayNames,
+	}, nil
+}
+
+// printSpaceUsers lists the users with space roles in every space of the org
+// in one table.
+func (cmd *OrgUsers) printSpaceUsers(org models.Organization, c flags.FlagContext) error {
+	roles := []models.Role{models.RoleSpaceManager, models.RoleSpaceDeveloper, models.RoleSpaceAuditor}
+	if c.IsSet("role") {
+		role, err := selectRole(c.String("role"), roles)
+		if err != nil {
+			return err
+		}
+		roles = []models.Role{role}
+	}
+
+	roleDisplayNames := map[models.Role]string{
+		models.RoleSpaceManager:   T("SPACE MANAGER"),
+		models.RoleSpaceDeveloper: T("SPACE DEVELOPER"),
+		models.RoleSpaceAuditor:   T("SPACE AUDITOR"),
 	}
+
+	userLister := cmd.spaceUserLister()
+	_, structured := cmd.ui.(terminal.DataPrinter)
+	details := structured || c.Bool("details")
+	if details {
+		userLister = cmd.userRepo.ListUsersInSpaceForRole
+	}
+
+	headers := []string{T("space"), T("role"), T("user")}
+	fieldKeys := []string{"space", "role", "user"}
+	if details {
+		headers = append(headers, T("origin"), T("guid"))
+		fieldKeys = append(fieldKeys, "origin", "guid")
+	}
+	table := cmd.ui.Table(headers)
+	table.Table.SetFieldKeys(fieldKeys...)
+
+	var listErr error
+	err := cmd.spaceRepo.ListSpacesFromOrg(org.GUID, func(space models.Space) bool {
+		for _, role := range roles {
+			users, err := userLister(space.GUID, role)
+			if err != nil {
+				listErr = err
+				return false
+			}
+
+			for _, user := range users {
+				if details {
+					table.Add(space.Name, roleDisplayNames[role], user.Username, user.Origin, user.GUID)
+				} else {
+					table.Add(space.Name, roleDisplayNames[role], user.Username)
+				}
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if listErr != nil {
+		return listErr
+	}
+
+	cmd.ui.Say("")
+	return table.Print()
 }
 
 func (cmd *OrgUsers) userLister() func(orgGUID string, role models.Role) ([]models.UserFields, error) {
@@ -116,3 +223,29 @@ func (cmd *OrgUsers) userLister() func(orgGUID string, role models.Role) ([]mode
 	}
 	return cmd.userRepo.ListUsersInOrgForRole
 }
+
+func (cmd *OrgUsers) spaceUserLister() func(spaceGUID string, role models.Role) ([]models.UserFields, error) {
+	if cmd.config.IsMinAPIVersion(cf.ListUsersInOrgOrSpaceWithoutUAAMinimumAPIVersion) {
+		return cmd.userRepo.ListUsersInSpaceForRoleWithNoUAA
+	}
+	return cmd.userRepo.ListUsersInSpaceForRole
+}
+
+// selectRole returns the role called name, which has to be one of roles.
+func selectRole(name string, roles []models.Role) (models.Role, error) {
+	role, err := models.RoleFromString(name)
+	if err == nil {
+		for _, allowed := range roles {
+			if role == allowed {
+				return role, nil
+			}
+		}
+	}
+
+	names := []string{}
+	for _, allowed := range roles {
+		names = append(names, strings.TrimPrefix(allowed.ToString(), "Role"))
+	}
+	return models.RoleUnknown, errors.New(T("Role {{.Role}} cannot be listed here; use one of {{.Roles}}",
+		map[string]interface{}{"Role": name, "Roles": strings.Join(names, ", ")}))
+}
@@ -1,12 +1,17 @@
 package user_test
 
 import (
+	"errors"
+	"strings"
+
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/spaces/spacesfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
 	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
 	"code.cloudfoundry.org/cli/plugin/models"
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
@@ -23,23 +28,28 @@ import (
 var _ = Describe("org-users command", func() {
 	var (
 		ui                  *testterm.FakeUI
+		commandUI           terminal.UI
 		requirementsFactory *requirementsfakes.FakeFactory
 		configRepo          coreconfig.Repository
 		userRepo            *apifakes.FakeUserRepository
+		spaceRepo           *spacesfakes.FakeSpaceRepository
 		deps                commandregistry.Dependency
 	)
 
 	updateCommandDependency := func(pluginCall bool) {
-		deps.UI = ui
+		deps.UI = commandUI
 		deps.Config = configRepo
 		deps.RepoLocator = deps.RepoLocator.SetUserRepository(userRepo)
+		deps.RepoLocator = deps.RepoLocator.SetSpaceRepository(spaceRepo)
 
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("org-users").SetDependency(deps, pluginCall))
 	}
 
 	BeforeEach(func() {
 		ui = &testterm.FakeUI{}
+		commandUI = ui
 		userRepo = new(apifakes.FakeUserRepository)
+		spaceRepo = new(spacesfakes.FakeSpaceRepository)
 		configRepo = testconfig.NewRepositoryWithDefaults()
 		requirementsFactory = new(requirementsfakes.FakeFactory)
 		deps = commandregistry.NewDependency(os.Stdout, new(tracefakes.FakePrinter), "")
@@ -269,6 +279,126 @@ var _ = Describe("org-users command", func() {
 				Expect(userRepo.ListUsersInOrgForRoleCallCount()).To(BeNumerically(">=", 1))
 			})
 		})
+
+		Context("when the --details flag is provided", func() {
+			BeforeEach(func() {
+				userRepo.ListUsersInOrgForRoleStub = func(_ string, roleName models.Role) ([]models.UserFields, error) {
+					return map[models.Role][]models.UserFields{
+						models.RoleOrgManager: {{Username: "user1", GUID: "user1-guid", Origin: "ldap"}},
+						models.RoleOrgAuditor: {{Username: "user3", GUID: "user3-guid", Origin: "uaa"}},
+					}[roleName], nil
+				}
+			})
+
+			It("lists the origin and guid of each user, looked up in UAA", func() {
+				configRepo.SetAPIVersion("2.22.0")
+				Expect(runCommand("--details", "the-org")).To(BeTrue())
+
+				Expect(userRepo.ListUsersInOrgForRoleWithNoUAACallCount()).To(Equal(0))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"role", "user", "origin", "guid"},
+					[]string{"ORG MANAGER", "user1", "ldap", "user1-guid"},
+					[]string{"ORG AUDITOR", "user3", "uaa", "user3-guid"},
+				))
+			})
+
+			It("prints the users as JSON", func() {
+				formattedUI := terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+				commandUI = formattedUI
+
+				Expect(runCommand("the-org")).To(BeTrue())
+				Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+				Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`[
+					{"role": "ORG MANAGER", "user": "user1", "origin": "ldap", "guid": "user1-guid"},
+					{"role": "ORG AUDITOR", "user": "user3", "origin": "uaa", "guid": "user3-guid"}
+				]`))
+			})
+		})
+
+		Context("when the --role flag is provided", func() {
+			It("lists only the users with that role", func() {
+				Expect(runCommand("--role", "BillingManager", "the-org")).To(BeTrue())
+
+				Expect(userRepo.ListUsersInOrgForRoleCallCount()).To(Equal(1))
+				_, role := userRepo.ListUsersInOrgForRoleArgsForCall(0)
+				Expect(role).To(Equal(models.RoleBillingManager))
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"BILLING MANAGER"}, []string{"user4"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"ORG MANAGER"}))
+			})
+
+			It("fails for a role that is not an org role", func() {
+				Expect(runCommand("--role", "SpaceDeveloper", "the-org")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Role SpaceDeveloper cannot be listed here; use one of OrgManager, BillingManager, OrgAuditor"},
+				))
+			})
+
+			It("fails with usage together with -a", func() {
+				Expect(runCommand("-a", "--role", "OrgManager", "the-org")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"Incorrect Usage", "-a and --role"},
+				))
+			})
+		})
+
+		Context("when the --all-spaces flag is provided", func() {
+			BeforeEach(func() {
+				spaceRepo.ListSpacesFromOrgStub = func(orgGUID string, cb func(models.Space) bool) error {
+					for _, name := range []string{"dev", "prod"} {
+						space := models.Space{}
+						space.Name = name
+						space.GUID = name + "-guid"
+						if !cb(space) {
+							break
+						}
+					}
+					return nil
+				}
+				userRepo.ListUsersInSpaceForRoleStub = func(spaceGUID string, role models.Role) ([]models.UserFields, error) {
+					if role != models.RoleSpaceDeveloper {
+						return nil, nil
+					}
+					return map[string][]models.UserFields{
+						"dev-guid":  {{Username: "user1", GUID: "user1-guid", Origin: "saml"}, {Username: "user2", GUID: "user2-guid", Origin: "uaa"}},
+						"prod-guid": {{Username: "user1", GUID: "user1-guid", Origin: "saml"}},
+					}[spaceGUID], nil
+				}
+			})
+
+			It("lists the users with space roles in every space of the org", func() {
+				Expect(runCommand("--all-spaces", "--role", "SpaceDeveloper", "the-org")).To(BeTrue())
+
+				orgGUID, _ := spaceRepo.ListSpacesFromOrgArgsForCall(0)
+				Expect(orgGUID).To(Equal("the-org-guid"))
+				Expect(userRepo.ListUsersInSpaceForRoleCallCount()).To(Equal(2))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"space", "role", "user"},
+					[]string{"dev", "SPACE DEVELOPER", "user1"},
+					[]string{"dev", "SPACE DEVELOPER", "user2"},
+					[]string{"prod", "SPACE DEVELOPER", "user1"},
+				))
+			})
+
+			It("includes the origin and guid of each user with --details", func() {
+				Expect(runCommand("--all-spaces", "--details", "the-org")).To(BeTrue())
+
+				Expect(userRepo.ListUsersInSpaceForRoleCallCount()).To(Equal(6))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"space", "role", "user", "origin", "guid"},
+					[]string{"dev", "SPACE DEVELOPER", "user1", "saml", "user1-guid"},
+				))
+			})
+
+			It("fails when the users of a space cannot be listed", func() {
+				userRepo.ListUsersInSpaceForRoleReturns(nil, errors.New("list-err"))
+				userRepo.ListUsersInSpaceForRoleStub = nil
+
+				Expect(runCommand("--all-spaces", "the-org")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"FAILED"}, []string{"list-err"}))
+			})
+		})
 	})
 
 	Describe("when invoked by a plugin", func() {
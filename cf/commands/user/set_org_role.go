@@ -36,16 +36,23 @@ func init() {
 }
 
 func (cmd *SetOrgRole) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["client"] = &flags.BoolFlag{Name: "client", Usage: T("Treat USERNAME as the client ID of a (non-user) UAA client")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "set-org-role",
 		Description: T("Assign an org role to a user"),
 		Usage: []string{
-			T("CF_NAME set-org-role USERNAME ORG ROLE\n\n"),
+			T("CF_NAME set-org-role USERNAME ORG ROLE [--client]\n\n"),
 			T("ROLES:\n"),
 			fmt.Sprintf("   'OrgManager' - %s", T("Invite and manage users, select and change plans, and set spending limits\n")),
 			fmt.Sprintf("   'BillingManager' - %s", T("Create and manage the billing account and payment info\n")),
 			fmt.Sprintf("   'OrgAuditor' - %s", T("Read-only access to org info and reports\n")),
 		},
+		Examples: []string{
+			"CF_NAME set-org-role ci-deployer my-org OrgAuditor --client",
+		},
+		Flags: fs,
 	}
 }
 
@@ -55,6 +62,20 @@ func (cmd *SetOrgRole) Requirements(requirementsFactory requirements.Factory, fc
 		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 3)
 	}
 
+	cmd.orgReq = requirementsFactory.NewOrganizationRequirement(fc.Args()[1])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		cmd.orgReq,
+	}
+
+	// A client ID is the GUID of the client's user in the Cloud Controller,
+	// and clients are not UAA users, so there is nothing to look up.
+	if fc.Bool("client") {
+		cmd.userReq = nil
+		return reqs, nil
+	}
+
 	var wantGUID bool
 	if cmd.config.IsMinAPIVersion(cf.SetRolesByUsernameMinimumAPIVersion) {
 		setRolesByUsernameFlag, err := cmd.flagRepo.FindByName("set_roles_by_username")
@@ -64,15 +85,8 @@ func (cmd *SetOrgRole) Requirements(requirementsFactory requirements.Factory, fc
 	}
 
 	cmd.userReq = requirementsFactory.NewUserRequirement(fc.Args()[0], wantGUID)
-	cmd.orgReq = requirementsFactory.NewOrganizationRequirement(fc.Args()[1])
-
-	reqs := []requirements.Requirement{
-		requirementsFactory.NewLoginRequirement(),
-		cmd.userReq,
-		cmd.orgReq,
-	}
 
-	return reqs, nil
+	return append(reqs, cmd.userReq), nil
 }
 
 func (cmd *SetOrgRole) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
@@ -84,7 +98,7 @@ func (cmd *SetOrgRole) SetDependency(deps commandregistry.Dependency, pluginCall
 }
 
 func (cmd *SetOrgRole) Execute(c flags.FlagContext) error {
-	user := cmd.userReq.GetUser()
+	user := clientOrUser(c, cmd.userReq)
 	org := cmd.orgReq.GetOrganization()
 	roleStr := c.Args()[2]
 	role, err := models.RoleFromString(roleStr)
@@ -100,6 +114,13 @@ func (cmd *SetOrgRole) Execute(c flags.FlagContext) error {
 			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
 		}))
 
+	if c.Bool("client") {
+		err = cmd.userRepo.CreateClientUser(user.GUID)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = cmd.SetOrgRole(org.GUID, role, user.GUID, user.Username)
 	if err != nil {
 		return err
@@ -116,3 +137,13 @@ func (cmd *SetOrgRole) SetOrgRole(orgGUID string, role models.Role, userGUID, us
 
 	return cmd.userRepo.SetOrgRoleByUsername(userName, orgGUID, role)
 }
+
+// clientOrUser returns the user a role is assigned to: the UAA client named
+// by the first argument with --client, otherwise the user the requirement
+// looked up.
+func clientOrUser(c flags.FlagContext, userReq requirements.UserRequirement) models.UserFields {
+	if c.Bool("client") {
+		return models.UserFields{GUID: c.Args()[0], Username: c.Args()[0]}
+	}
+	return userReq.GetUser()
+}
@@ -55,7 +55,7 @@ var _ = Describe("SetOrgRole", func() {
 		cmd = &user.SetOrgRole{}
 		cmd.SetDependency(deps, false)
 
-		flagContext = flags.NewFlagContext(map[string]flags.FlagSet{})
+		flagContext = flags.NewFlagContext(cmd.MetaData().Flags)
 
 		factory = new(requirementsfakes.FakeFactory)
 
@@ -278,4 +278,52 @@ var _ = Describe("SetOrgRole", func() {
 			})
 		})
 	})
+
+	Describe("with --client", func() {
+		BeforeEach(func() {
+			configRepo.SetAPIVersion("2.37.0")
+			flagRepo.FindByNameReturns(models.FeatureFlag{Enabled: true}, nil)
+			flagContext.Parse("the-client-id", "the-org-name", "OrgManager", "--client")
+
+			org := models.Organization{}
+			org.GUID = "the-org-guid"
+			org.Name = "the-org-name"
+			organizationRequirement.GetOrganizationReturns(org)
+		})
+
+		It("does not look the client up as a UAA user", func() {
+			actualRequirements, err := cmd.Requirements(factory, flagContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(factory.NewUserRequirementCallCount()).To(BeZero())
+			Expect(flagRepo.FindByNameCallCount()).To(BeZero())
+			Expect(actualRequirements).To(ConsistOf(loginRequirement, organizationRequirement))
+		})
+
+		It("makes sure the client is a CC user and assigns the role to its ID", func() {
+			cmd.Requirements(factory, flagContext)
+			Expect(cmd.Execute(flagContext)).To(Succeed())
+
+			Expect(userRepo.CreateClientUserCallCount()).To(Equal(1))
+			Expect(userRepo.CreateClientUserArgsForCall(0)).To(Equal("the-client-id"))
+			Expect(userRepo.SetOrgRoleByGUIDCallCount()).To(Equal(1))
+			clientGUID, orgGUID, role := userRepo.SetOrgRoleByGUIDArgsForCall(0)
+			Expect(clientGUID).To(Equal("the-client-id"))
+			Expect(orgGUID).To(Equal("the-org-guid"))
+			Expect(role).To(Equal(models.RoleOrgManager))
+			Expect(userRepo.SetOrgRoleByUsernameCallCount()).To(BeZero())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Assigning role", "the-client-id"},
+				[]string{"OK"},
+			))
+		})
+
+		It("returns an error when the client cannot be made a CC user", func() {
+			userRepo.CreateClientUserReturns(errors.New("create-error"))
+			cmd.Requirements(factory, flagContext)
+
+			err := cmd.Execute(flagContext)
+			Expect(err).To(MatchError("create-error"))
+			Expect(userRepo.SetOrgRoleByGUIDCallCount()).To(BeZero())
+		})
+	})
 })
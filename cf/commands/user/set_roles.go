@@ -0,0 +1,385 @@
+package user
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/featureflags"
+	"code.cloudfoundry.org/cli/cf/api/organizations"
+	"code.cloudfoundry.org/cli/cf/api/spaces"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+// roleAssignment is one row of a set-roles file. Space is empty for org
+// roles.
+type roleAssignment struct {
+	User  string `yaml:"user"`
+	Org   string `yaml:"org"`
+	Space string `yaml:"space"`
+	Role  string `yaml:"role"`
+}
+
+type SetRoles struct {
+	ui        terminal.UI
+	config    coreconfig.Reader
+	orgRepo   organizations.OrganizationRepository
+	spaceRepo spaces.SpaceRepository
+	userRepo  api.UserRepository
+	flagRepo  featureflags.FeatureFlagRepository
+}
+
+func init() {
+	commandregistry.Register(&SetRoles{})
+}
+
+func (cmd *SetRoles) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["file"] = &flags.StringFlag{Name: "file", Usage: T("YAML or CSV file listing the role assignments")}
+	fs["skip-existing"] = &flags.BoolFlag{Name: "skip-existing", Usage: T("Skip the assignments that users already have instead of assigning them again")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "set-roles",
+		Description: T("Assign org and space roles to several users"),
+		Usage: []string{
+			T("CF_NAME set-roles --file FILE [--skip-existing]\n\n"),
+			T("   The file is a YAML list of assignments with user, org, role and, for space roles, space keys, or a CSV file with the columns user,org,space,role. The result of each assignment is listed."),
+		},
+		Examples: []string{
+			"CF_NAME set-roles --file roles.yml",
+			"CF_NAME set-roles --file roles.csv --skip-existing",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *SetRoles) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	usageReq := requirements.NewUsageRequirement(commandregistry.CLICommandUsagePresenter(cmd),
+		T("Requires --file and no arguments"),
+		func() bool {
+			return len(fc.Args()) != 0 || fc.String("file") == ""
+		},
+	)
+
+	reqs := []requirements.Requirement{
+		usageReq,
+		requirementsFactory.NewLoginRequirement(),
+	}
+
+	return reqs, nil
+}
+
+func (cmd *SetRoles) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.orgRepo = deps.RepoLocator.GetOrganizationRepository()
+	cmd.spaceRepo = deps.RepoLocator.GetSpaceRepository()
+	cmd.userRepo = deps.RepoLocator.GetUserRepository()
+	cmd.flagRepo = deps.RepoLocator.GetFeatureFlagRepository()
+	return cmd
+}
+
+func (cmd *SetRoles) Execute(c flags.FlagContext) error {
+	path := c.String("file")
+	assignments, err := readRoleAssignments(path)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(T("Assigning {{.Count}} roles from {{.Path}} as {{.CurrentUser}}...",
+		map[string]interface{}{
+			"Count":       len(assignments),
+			"Path":        terminal.EntityNameColor(path),
+			"CurrentUser": terminal.EntityNameColor(cmd.config.Username()),
+		}))
+	cmd.ui.Say("")
+
+	assigner := &roleAssigner{
+		cmd:          cmd,
+		byUsername:   cmd.setRolesByUsername(),
+		skipExisting: c.Bool("skip-existing"),
+		orgs:         map[string]models.Organization{},
+		spaces:       map[string]models.Space{},
+		userGUIDs:    map[string]string{},
+		roleUsers:    map[string][]models.UserFields{},
+	}
+
+	table := cmd.ui.Table([]string{"", T("user"), T("org"), T("space"), T("role"), T("result")})
+	failed := 0
+	for i, assignment := range assignments {
+		result, err := assigner.assign(assignment)
+		if err != nil {
+			failed++
+			result = terminal.FailureColor(err.Error())
+		}
+		table.Add(fmt.Sprintf("#%d", i+1), assignment.User, assignment.Org, assignment.Space, assignment.Role, result)
+	}
+	err = table.Print()
+	if err != nil {
+		return err
+	}
+	cmd.ui.Say("")
+
+	if failed > 0 {
+		return errors.New(T("{{.Failed}} of {{.Total}} role assignments failed",
+			map[string]interface{}{"Failed": failed, "Total": len(assignments)}))
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
+
+// setRolesByUsername tells whether roles can be assigned by username, as
+// set-org-role and set-space-role decide it, or whether the user's guid has
+// to be looked up first.
+func (cmd *SetRoles) setRolesByUsername() bool {
+	if !cmd.config.IsMinAPIVersion(cf.SetRolesByUsernameMinimumAPIVersion) {
+		return false
+	}
+	flag, err := cmd.flagRepo.FindByName("set_roles_by_username")
+	return err == nil && flag.Enabled
+}
+
+// roleAssigner assigns the rows of a set-roles file, remembering the orgs,
+// spaces, users and role members it has looked up, since files tend to
+// mention the same ones many times.
+type roleAssigner struct {
+	cmd          *SetRoles
+	byUsername   bool
+	skipExisting bool
+
+	orgs      map[string]models.Organization
+	spaces    map[string]models.Space
+	userGUIDs map[string]string
+	roleUsers map[string][]models.UserFields
+}
+
+// assign gives the user the role of the assignment and describes the result,
+// which is that it was skipped when the user already has the role and
+// existing roles are skipped.
+func (assigner *roleAssigner) assign(assignment roleAssignment) (string, error) {
+	role, err := models.RoleFromString(assignment.Role)
+	if err != nil {
+		return "", errors.New(T("Unknown role {{.Role}}", map[string]interface{}{"Role": assignment.Role}))
+	}
+
+	isSpaceRole := role == models.RoleSpaceManager || role == models.RoleSpaceDeveloper || role == models.RoleSpaceAuditor
+	if isSpaceRole && assignment.Space == "" {
+		return "", errors.New(T("Space role {{.Role}} requires a space", map[string]interface{}{"Role": assignment.Role}))
+	}
+	if !isSpaceRole && assignment.Space != "" {
+		return "", errors.New(T("Org role {{.Role}} cannot be assigned in a space", map[string]interface{}{"Role": assignment.Role}))
+	}
+
+	org, err := assigner.findOrg(assignment.Org)
+	if err != nil {
+		return "", err
+	}
+
+	var space models.Space
+	if isSpaceRole {
+		space, err = assigner.findSpace(org, assignment.Space)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if assigner.skipExisting {
+		hasRole, err := assigner.hasRole(assignment.User, org.GUID, space.GUID, role)
+		if err != nil {
+			return "", err
+		}
+		if hasRole {
+			return T("skipped, already assigned"), nil
+		}
+	}
+
+	userRepo := assigner.cmd.userRepo
+	if assigner.byUsername {
+		if isSpaceRole {
+			err = userRepo.SetSpaceRoleByUsername(assignment.User, space.GUID, org.GUID, role)
+		} else {
+			err = userRepo.SetOrgRoleByUsername(assignment.User, org.GUID, role)
+		}
+	} else {
+		var userGUID string
+		userGUID, err = assigner.findUserGUID(assignment.User)
+		if err != nil {
+			return "", err
+		}
+		if isSpaceRole {
+			err = userRepo.SetSpaceRoleByGUID(userGUID, space.GUID, org.GUID, role)
+		} else {
+			err = userRepo.SetOrgRoleByGUID(userGUID, org.GUID, role)
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	key := roleUsersKey(org.GUID, space.GUID, role)
+	if users, ok := assigner.roleUsers[key]; ok {
+		assigner.roleUsers[key] = append(users, models.UserFields{Username: assignment.User})
+	}
+	return T("assigned"), nil
+}
+
+func (assigner *roleAssigner) findOrg(name string) (models.Organization, error) {
+	if org, ok := assigner.orgs[name]; ok {
+		return org, nil
+	}
+
+	org, err := assigner.cmd.orgRepo.FindByName(name)
+	if err != nil {
+		return models.Organization{}, err
+	}
+	assigner.orgs[name] = org
+	return org, nil
+}
+
+func (assigner *roleAssigner) findSpace(org models.Organization, name string) (models.Space, error) {
+	key := org.GUID + "/" + name
+	if space, ok := assigner.spaces[key]; ok {
+		return space, nil
+	}
+
+	space, err := assigner.cmd.spaceRepo.FindByNameInOrg(name, org.GUID)
+	if err != nil {
+		return models.Space{}, err
+	}
+	assigner.spaces[key] = space
+	return space, nil
+}
+
+func (assigner *roleAssigner) findUserGUID(username string) (string, error) {
+	if guid, ok := assigner.userGUIDs[username]; ok {
+		return guid, nil
+	}
+
+	user, err := assigner.cmd.userRepo.FindByUsername(username)
+	if err != nil {
+		return "", err
+	}
+	assigner.userGUIDs[username] = user.GUID
+	return user.GUID, nil
+}
+
+// hasRole tells whether the user has the role in the org, or in the space when
+// spaceGUID is set.
+func (assigner *roleAssigner) hasRole(username string, orgGUID string, spaceGUID string, role models.Role) (bool, error) {
+	key := roleUsersKey(orgGUID, spaceGUID, role)
+	users, ok := assigner.roleUsers[key]
+	if !ok {
+		var err error
+		if spaceGUID != "" {
+			users, err = assigner.cmd.userRepo.ListUsersInSpaceForRoleWithNoUAA(spaceGUID, role)
+		} else {
+			users, err = assigner.cmd.userRepo.ListUsersInOrgForRoleWithNoUAA(orgGUID, role)
+		}
+		if err != nil {
+			return false, err
+		}
+		assigner.roleUsers[key] = users
+	}
+
+	for _, user := range users {
+		if strings.EqualFold(user.Username, username) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func roleUsersKey(orgGUID string, spaceGUID string, role models.Role) string {
+	return fmt.Sprintf("%s/%s/%d", orgGUID, spaceGUID, role)
+}
+
+// readRoleAssignments reads the assignments in a set-roles file. Files ending
+// in .csv are read as CSV with a header row naming the columns; all other
+// files are read as YAML.
+func readRoleAssignments(path string) ([]roleAssignment, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.New(T("Error reading roles file {{.Path}}: {{.Err}}",
+			map[string]interface{}{"Path": path, "Err": err.Error()}))
+	}
+
+	var assignments []roleAssignment
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		assignments, err = parseRoleAssignmentsCSV(string(contents))
+	} else {
+		err = yaml.Unmarshal(contents, &assignments)
+	}
+	if err != nil {
+		return nil, errors.New(T("Error parsing roles file {{.Path}}: {{.Err}}",
+			map[string]interface{}{"Path": path, "Err": err.Error()}))
+	}
+
+	if len(assignments) == 0 {
+		return nil, errors.New(T("The roles file {{.Path}} lists no role assignments", map[string]interface{}{"Path": path}))
+	}
+
+	for i, assignment := range assignments {
+		if assignment.User == "" || assignment.Org == "" || assignment.Role == "" {
+			return nil, errors.New(T("Error parsing roles file {{.Path}}: assignment #{{.Number}} needs a user, an org and a role",
+				map[string]interface{}{"Path": path, "Number": i + 1}))
+		}
+	}
+	return assignments, nil
+}
+
+func parseRoleAssignmentsCSV(contents string) ([]roleAssignment, error) {
+	reader := csv.NewReader(strings.NewReader(contents))
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"user", "org", "role"} {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.New(T("the header row has no {{.Column}} column", map[string]interface{}{"Column": name}))
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	assignments := []roleAssignment{}
+	for _, record := range records[1:] {
+		assignments = append(assignments, roleAssignment{
+			User:  field(record, "user"),
+			Org:   field(record, "org"),
+			Space: field(record, "space"),
+			Role:  field(record, "role"),
+		})
+	}
+	return assignments, nil
+}
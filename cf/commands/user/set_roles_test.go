@@ -0,0 +1,246 @@
+package user_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/featureflags/featureflagsfakes"
+	"code.cloudfoundry.org/cli/cf/api/organizations/organizationsfakes"
+	"code.cloudfoundry.org/cli/cf/api/spaces/spacesfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/commands/user"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SetRoles", func() {
+	var (
+		ui         *testterm.FakeUI
+		configRepo coreconfig.Repository
+		orgRepo    *organizationsfakes.FakeOrganizationRepository
+		spaceRepo  *spacesfakes.FakeSpaceRepository
+		userRepo   *apifakes.FakeUserRepository
+		flagRepo   *featureflagsfakes.FakeFeatureFlagRepository
+
+		cmd         commandregistry.Command
+		factory     *requirementsfakes.FakeFactory
+		flagContext flags.FlagContext
+
+		tmpDir string
+	)
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		configRepo = testconfig.NewRepositoryWithDefaults()
+		orgRepo = new(organizationsfakes.FakeOrganizationRepository)
+		spaceRepo = new(spacesfakes.FakeSpaceRepository)
+		userRepo = new(apifakes.FakeUserRepository)
+		flagRepo = new(featureflagsfakes.FakeFeatureFlagRepository)
+
+		deps := commandregistry.Dependency{
+			UI:     ui,
+			Config: configRepo,
+			RepoLocator: commandregistry.Dependency{}.RepoLocator.
+				SetOrganizationRepository(orgRepo).
+				SetSpaceRepository(spaceRepo).
+				SetUserRepository(userRepo).
+				SetFeatureFlagRepository(flagRepo),
+		}
+
+		cmd = &user.SetRoles{}
+		cmd.SetDependency(deps, false)
+		flagContext = flags.NewFlagContext(cmd.MetaData().Flags)
+		factory = new(requirementsfakes.FakeFactory)
+
+		orgRepo.FindByNameStub = func(name string) (models.Organization, error) {
+			if name == "missing-org" {
+				return models.Organization{}, errors.New("Org missing-org not found")
+			}
+			org := models.Organization{}
+			org.Name = name
+			org.GUID = name + "-guid"
+			return org, nil
+		}
+		spaceRepo.FindByNameInOrgStub = func(name string, orgGUID string) (models.Space, error) {
+			space := models.Space{}
+			space.Name = name
+			space.GUID = name + "-guid"
+			return space, nil
+		}
+		userRepo.FindByUsernameStub = func(username string) (models.UserFields, error) {
+			return models.UserFields{Username: username, GUID: username + "-guid"}, nil
+		}
+
+		var err error
+		tmpDir, err = ioutil.TempDir("", "set-roles")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	writeFile := func(name string, contents string) string {
+		path := filepath.Join(tmpDir, name)
+		Expect(ioutil.WriteFile(path, []byte(contents), 0600)).To(Succeed())
+		return path
+	}
+
+	Describe("Requirements", func() {
+		It("fails with usage when --file is not given", func() {
+			Expect(flagContext.Parse()).To(Succeed())
+
+			reqs, err := cmd.Requirements(factory, flagContext)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = testcmd.RunRequirements(reqs)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Incorrect Usage"))
+			Expect(err.Error()).To(ContainSubstring("Requires --file and no arguments"))
+		})
+
+		It("requires the user to be logged in", func() {
+			Expect(flagContext.Parse("--file", "roles.yml")).To(Succeed())
+
+			_, err := cmd.Requirements(factory, flagContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(factory.NewLoginRequirementCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("Execute", func() {
+		It("assigns the org and space roles listed in a YAML file by user guid", func() {
+			path := writeFile("roles.yml", `---
+- user: alice
+  org: my-org
+  role: OrgManager
+- user: bob
+  org: my-org
+  space: dev
+  role: SpaceDeveloper
+`)
+			Expect(flagContext.Parse("--file", path)).To(Succeed())
+
+			Expect(cmd.Execute(flagContext)).To(Succeed())
+
+			Expect(userRepo.SetOrgRoleByGUIDCallCount()).To(Equal(1))
+			userGUID, orgGUID, role := userRepo.SetOrgRoleByGUIDArgsForCall(0)
+			Expect(userGUID).To(Equal("alice-guid"))
+			Expect(orgGUID).To(Equal("my-org-guid"))
+			Expect(role).To(Equal(models.RoleOrgManager))
+
+			Expect(userRepo.SetSpaceRoleByGUIDCallCount()).To(Equal(1))
+			userGUID, spaceGUID, orgGUID, role := userRepo.SetSpaceRoleByGUIDArgsForCall(0)
+			Expect(userGUID).To(Equal("bob-guid"))
+			Expect(spaceGUID).To(Equal("dev-guid"))
+			Expect(orgGUID).To(Equal("my-org-guid"))
+			Expect(role).To(Equal(models.RoleSpaceDeveloper))
+
+			Expect(orgRepo.FindByNameCallCount()).To(Equal(1))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Assigning 2 roles from", "roles.yml", "my-user"},
+				[]string{"user", "org", "space", "role", "result"},
+				[]string{"#1", "alice", "my-org", "OrgManager", "assigned"},
+				[]string{"#2", "bob", "my-org", "dev", "SpaceDeveloper", "assigned"},
+				[]string{"OK"},
+			))
+		})
+
+		It("reads CSV files with a header row and assigns by username when the API allows it", func() {
+			configRepo.SetAPIVersion("2.37.0")
+			flagRepo.FindByNameReturns(models.FeatureFlag{Enabled: true}, nil)
+			path := writeFile("roles.csv", "user,org,space,role\n# auditors\ncarol,my-org,,OrgAuditor\ndave,my-org,prod,SpaceAuditor\n")
+			Expect(flagContext.Parse("--file", path)).To(Succeed())
+
+			Expect(cmd.Execute(flagContext)).To(Succeed())
+
+			Expect(flagRepo.FindByNameArgsForCall(0)).To(Equal("set_roles_by_username"))
+			Expect(userRepo.FindByUsernameCallCount()).To(Equal(0))
+
+			username, orgGUID, role := userRepo.SetOrgRoleByUsernameArgsForCall(0)
+			Expect(username).To(Equal("carol"))
+			Expect(orgGUID).To(Equal("my-org-guid"))
+			Expect(role).To(Equal(models.RoleOrgAuditor))
+
+			username, spaceGUID, _, role := userRepo.SetSpaceRoleByUsernameArgsForCall(0)
+			Expect(username).To(Equal("dave"))
+			Expect(spaceGUID).To(Equal("prod-guid"))
+			Expect(role).To(Equal(models.RoleSpaceAuditor))
+		})
+
+		It("reports each failed row and carries on with the others", func() {
+			path := writeFile("roles.yml", `---
+- {user: alice, org: missing-org, role: OrgManager}
+- {user: bob, org: my-org, role: SpaceDeveloper}
+- {user: carol, org: my-org, role: Janitor}
+- {user: dave, org: my-org, role: BillingManager}
+`)
+			Expect(flagContext.Parse("--file", path)).To(Succeed())
+
+			err := cmd.Execute(flagContext)
+			Expect(err).To(MatchError("3 of 4 role assignments failed"))
+
+			Expect(userRepo.SetOrgRoleByGUIDCallCount()).To(Equal(1))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"#1", "alice", "Org missing-org not found"},
+				[]string{"#2", "bob", "Space role SpaceDeveloper requires a space"},
+				[]string{"#3", "carol", "Unknown role Janitor"},
+				[]string{"#4", "dave", "assigned"},
+			))
+		})
+
+		It("skips assignments the users already have with --skip-existing", func() {
+			userRepo.ListUsersInOrgForRoleWithNoUAAReturns([]models.UserFields{{Username: "Alice"}}, nil)
+			path := writeFile("roles.yml", `---
+- {user: alice, org: my-org, role: OrgManager}
+- {user: bob, org: my-org, role: OrgManager}
+`)
+			Expect(flagContext.Parse("--file", path, "--skip-existing")).To(Succeed())
+
+			Expect(cmd.Execute(flagContext)).To(Succeed())
+
+			Expect(userRepo.ListUsersInOrgForRoleWithNoUAACallCount()).To(Equal(1))
+			orgGUID, role := userRepo.ListUsersInOrgForRoleWithNoUAAArgsForCall(0)
+			Expect(orgGUID).To(Equal("my-org-guid"))
+			Expect(role).To(Equal(models.RoleOrgManager))
+
+			Expect(userRepo.SetOrgRoleByGUIDCallCount()).To(Equal(1))
+			userGUID, _, _ := userRepo.SetOrgRoleByGUIDArgsForCall(0)
+			Expect(userGUID).To(Equal("bob-guid"))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"#1", "alice", "skipped, already assigned"},
+				[]string{"#2", "bob", "assigned"},
+			))
+		})
+
+		It("fails when a row misses a column", func() {
+			path := writeFile("roles.yml", "- {user: alice, role: OrgManager}\n")
+			Expect(flagContext.Parse("--file", path)).To(Succeed())
+
+			err := cmd.Execute(flagContext)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("assignment #1 needs a user, an org and a role"))
+		})
+
+		It("fails when a CSV file has no header for a required column", func() {
+			path := writeFile("roles.csv", "user,space,role\nalice,dev,SpaceDeveloper\n")
+			Expect(flagContext.Parse("--file", path)).To(Succeed())
+
+			err := cmd.Execute(flagContext)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("the header row has no org column"))
+		})
+	})
+})
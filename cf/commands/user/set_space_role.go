@@ -39,16 +39,23 @@ func init() {
 }
 
 func (cmd *SetSpaceRole) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["client"] = &flags.BoolFlag{Name: "client", Usage: T("Treat USERNAME as the client ID of a (non-user) UAA client")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "set-space-role",
 		Description: T("Assign a space role to a user"),
 		Usage: []string{
-			T("CF_NAME set-space-role USERNAME ORG SPACE ROLE\n\n"),
+			T("CF_NAME set-space-role USERNAME ORG SPACE ROLE [--client]\n\n"),
 			T("ROLES:\n"),
 			fmt.Sprintf("   'SpaceManager' - %s", T("Invite and manage users, and enable features for a given space\n")),
 			fmt.Sprintf("   'SpaceDeveloper' - %s", T("Create and manage apps and services, and see logs and reports\n")),
 			fmt.Sprintf("   'SpaceAuditor' - %s", T("View logs, reports, and settings on this space\n")),
 		},
+		Examples: []string{
+			"CF_NAME set-space-role ci-deployer my-org my-space SpaceDeveloper --client",
+		},
+		Flags: fs,
 	}
 }
 
@@ -58,6 +65,18 @@ func (cmd *SetSpaceRole) Requirements(requirementsFactory requirements.Factory,
 		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 4)
 	}
 
+	cmd.orgReq = requirementsFactory.NewOrganizationRequirement(fc.Args()[1])
+
+	reqs := []requirements.Requirement{
+		requirementsFactory.NewLoginRequirement(),
+		cmd.orgReq,
+	}
+
+	if fc.Bool("client") {
+		cmd.userReq = nil
+		return reqs, nil
+	}
+
 	var wantGUID bool
 	if cmd.config.IsMinAPIVersion(cf.SetRolesByUsernameMinimumAPIVersion) {
 		setRolesByUsernameFlag, err := cmd.flagRepo.FindByName("set_roles_by_username")
@@ -67,15 +86,8 @@ func (cmd *SetSpaceRole) Requirements(requirementsFactory requirements.Factory,
 	}
 
 	cmd.userReq = requirementsFactory.NewUserRequirement(fc.Args()[0], wantGUID)
-	cmd.orgReq = requirementsFactory.NewOrganizationRequirement(fc.Args()[1])
 
-	reqs := []requirements.Requirement{
-		requirementsFactory.NewLoginRequirement(),
-		cmd.userReq,
-		cmd.orgReq,
-	}
-
-	return reqs, nil
+	return append(reqs, cmd.userReq), nil
 }
 
 func (cmd *SetSpaceRole) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
@@ -95,7 +107,7 @@ func (cmd *SetSpaceRole) Execute(c flags.FlagContext) error {
 		return err
 	}
 
-	userFields := cmd.userReq.GetUser()
+	userFields := clientOrUser(c, cmd.userReq)
 	org := cmd.orgReq.GetOrganization()
 
 	space, err := cmd.spaceRepo.FindByNameInOrg(spaceName, org.GUID)
@@ -103,6 +115,13 @@ func (cmd *SetSpaceRole) Execute(c flags.FlagContext) error {
 		return err
 	}
 
+	if c.Bool("client") {
+		err = cmd.userRepo.CreateClientUser(userFields.GUID)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = cmd.SetSpaceRole(space, org.GUID, org.Name, role, userFields.GUID, userFields.Username)
 	if err != nil {
 		return err
@@ -59,7 +59,7 @@ var _ = Describe("SetSpaceRole", func() {
 		cmd = &user.SetSpaceRole{}
 		cmd.SetDependency(deps, false)
 
-		flagContext = flags.NewFlagContext(map[string]flags.FlagSet{})
+		flagContext = flags.NewFlagContext(cmd.MetaData().Flags)
 
 		factory = new(requirementsfakes.FakeFactory)
 
@@ -311,4 +311,57 @@ var _ = Describe("SetSpaceRole", func() {
 			})
 		})
 	})
+
+	Describe("with --client", func() {
+		BeforeEach(func() {
+			configRepo.SetAPIVersion("2.37.0")
+			flagRepo.FindByNameReturns(models.FeatureFlag{Enabled: true}, nil)
+			flagContext.Parse("the-client-id", "the-org-name", "the-space-name", "SpaceDeveloper", "--client")
+
+			org := models.Organization{}
+			org.GUID = "the-org-guid"
+			org.Name = "the-org-name"
+			organizationRequirement.GetOrganizationReturns(org)
+			space := models.Space{}
+			space.GUID = "the-space-guid"
+			space.Name = "the-space-name"
+			spaceRepo.FindByNameInOrgReturns(space, nil)
+		})
+
+		It("does not look the client up as a UAA user", func() {
+			actualRequirements, err := cmd.Requirements(factory, flagContext)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(factory.NewUserRequirementCallCount()).To(BeZero())
+			Expect(flagRepo.FindByNameCallCount()).To(BeZero())
+			Expect(actualRequirements).To(ConsistOf(loginRequirement, organizationRequirement))
+		})
+
+		It("makes sure the client is a CC user and assigns the role to its ID", func() {
+			cmd.Requirements(factory, flagContext)
+			Expect(cmd.Execute(flagContext)).To(Succeed())
+
+			Expect(userRepo.CreateClientUserCallCount()).To(Equal(1))
+			Expect(userRepo.CreateClientUserArgsForCall(0)).To(Equal("the-client-id"))
+			Expect(userRepo.SetSpaceRoleByGUIDCallCount()).To(Equal(1))
+			clientGUID, spaceGUID, orgGUID, role := userRepo.SetSpaceRoleByGUIDArgsForCall(0)
+			Expect(clientGUID).To(Equal("the-client-id"))
+			Expect(spaceGUID).To(Equal("the-space-guid"))
+			Expect(orgGUID).To(Equal("the-org-guid"))
+			Expect(role).To(Equal(models.RoleSpaceDeveloper))
+			Expect(userRepo.SetSpaceRoleByUsernameCallCount()).To(BeZero())
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Assigning role", "the-client-id"},
+				[]string{"OK"},
+			))
+		})
+
+		It("returns an error when the client cannot be made a CC user", func() {
+			userRepo.CreateClientUserReturns(errors.New("create-error"))
+			cmd.Requirements(factory, flagContext)
+
+			err := cmd.Execute(flagContext)
+			Expect(err).To(MatchError("create-error"))
+			Expect(userRepo.SetSpaceRoleByGUIDCallCount()).To(BeZero())
+		})
+	})
 })
@@ -32,12 +32,22 @@ func init() {
 }
 
 func (cmd *SpaceUsers) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["details"] = &flags.BoolFlag{Name: "details", Usage: T("Show the origin and GUID of each user, looked up in UAA")}
+	fs["role"] = &flags.StringFlag{Name: "role", Usage: T("Only list the users with this role, such as SpaceDeveloper")}
+
 	return commandregistry.CommandMetadata{
 		Name:        "space-users",
 		Description: T("Show space users by role"),
 		Usage: []string{
-			T("CF_NAME space-users ORG SPACE"),
+			T("CF_NAME space-users ORG SPACE [--role ROLE] [--details]"),
+		},
+		Examples: []string{
+			"CF_NAME space-users my-org development --details",
+			"CF_NAME space-users my-org development --role SpaceDeveloper --output json",
 		},
+		Flags:            fs,
+		StructuredOutput: true,
 	}
 }
 
@@ -77,20 +87,30 @@ func (cmd *SpaceUsers) Execute(c flags.FlagContext) error {
 		return err
 	}
 
-	printer := cmd.printer(org, space, cmd.config.Username())
+	printer, err := cmd.printer(org, space, cmd.config.Username(), c)
+	if err != nil {
+		return err
+	}
 	printer.PrintUsers(space.GUID, cmd.config.Username())
 	return nil
 }
 
-func (cmd *SpaceUsers) printer(org models.Organization, space models.Space, username string) userprint.UserPrinter {
+func (cmd *SpaceUsers) printer(org models.Organization, space models.Space, username string, c flags.FlagContext) (userprint.UserPrinter, error) {
 	var roles = []models.Role{models.RoleSpaceManager, models.RoleSpaceDeveloper, models.RoleSpaceAuditor}
+	if c.IsSet("role") {
+		role, err := selectRole(c.String("role"), roles)
+		if err != nil {
+			return nil, err
+		}
+		roles = []models.Role{role}
+	}
 
 	if cmd.pluginCall {
 		return userprint.NewSpaceUsersPluginPrinter(
 			cmd.pluginModel,
 			cmd.userLister(),
 			roles,
-		)
+		), nil
 	}
 
 	cmd.ui.Say(T("Getting users in org {{.TargetOrg}} / space {{.TargetSpace}} as {{.CurrentUser}}",
@@ -100,16 +120,25 @@ func (cmd *SpaceUsers) printer(org models.Organization, space models.Space, user
 			"CurrentUser": terminal.EntityNameColor(username),
 		}))
 
-	return &userprint.SpaceUsersUIPrinter{
-		UI:         cmd.ui,
-		UserLister: cmd.userLister(),
-		Roles:      roles,
-		RoleDisplayNames: map[models.Role]string{
-			models.RoleSpaceManager:   T("SPACE MANAGER"),
-			models.RoleSpaceDeveloper: T("SPACE DEVELOPER"),
-			models.RoleSpaceAuditor:   T("SPACE AUDITOR"),
-		},
+	roleDisplayNames := map[models.Role]string{
+		models.RoleSpaceManager:   T("SPACE MANAGER"),
+		models.RoleSpaceDeveloper: T("SPACE DEVELOPER"),
+		models.RoleSpaceAuditor:   T("SPACE AUDITOR"),
 	}
+	if _, structured := cmd.ui.(terminal.DataPrinter); structured || c.Bool("details") {
+		return &userprint.UsersTableUIPrinter{
+			UI:               cmd.ui,
+			UserLister:       cmd.userRepo.ListUsersInSpaceForRole,
+			Roles:            roles,
+			RoleDisplayNames: roleDisplayNames,
+		}, nil
+	}
+	return &userprint.SpaceUsersUIPrinter{
+		UI:               cmd.ui,
+		UserLister:       cmd.userLister(),
+		Roles:            roles,
+		RoleDisplayNames: roleDisplayNames,
+	}, nil
 }
 
 func (cmd *SpaceUsers) userLister() func(spaceGUID string, role models.Role) ([]models.UserFields, error) {
@@ -2,6 +2,7 @@ package user_test
 
 import (
 	"errors"
+	"strings"
 
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
 	"code.cloudfoundry.org/cli/cf/api/spaces/spacesfakes"
@@ -10,6 +11,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/cf/terminal"
 	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
 	"code.cloudfoundry.org/cli/plugin/models"
 	testcmd "code.cloudfoundry.org/cli/testhelpers/commands"
@@ -26,6 +28,7 @@ import (
 var _ = Describe("space-users command", func() {
 	var (
 		ui                  *testterm.FakeUI
+		commandUI           terminal.UI
 		requirementsFactory *requirementsfakes.FakeFactory
 		spaceRepo           *spacesfakes.FakeSpaceRepository
 		userRepo            *apifakes.FakeUserRepository
@@ -34,7 +37,7 @@ var _ = Describe("space-users command", func() {
 	)
 
 	updateCommandDependency := func(pluginCall bool) {
-		deps.UI = ui
+		deps.UI = commandUI
 		deps.Config = configRepo
 		deps.RepoLocator = deps.RepoLocator.SetUserRepository(userRepo)
 		deps.RepoLocator = deps.RepoLocator.SetSpaceRepository(spaceRepo)
@@ -45,6 +48,7 @@ var _ = Describe("space-users command", func() {
 	BeforeEach(func() {
 		configRepo = testconfig.NewRepositoryWithDefaults()
 		ui = &testterm.FakeUI{}
+		commandUI = ui
 		requirementsFactory = new(requirementsfakes.FakeFactory)
 		spaceRepo = new(spacesfakes.FakeSpaceRepository)
 		userRepo = new(apifakes.FakeUserRepository)
@@ -188,6 +192,59 @@ var _ = Describe("space-users command", func() {
 				Expect(userRepo.ListUsersInSpaceForRoleCallCount()).To(BeNumerically(">=", 1))
 			})
 		})
+
+		Context("when the --role flag is provided", func() {
+			It("lists only the users with that role", func() {
+				Expect(runCommand("--role", "SpaceDeveloper", "my-org", "my-space")).To(BeTrue())
+
+				Expect(userRepo.ListUsersInSpaceForRoleCallCount()).To(Equal(1))
+				_, role := userRepo.ListUsersInSpaceForRoleArgsForCall(0)
+				Expect(role).To(Equal(models.RoleSpaceDeveloper))
+				Expect(ui.Outputs()).To(ContainSubstrings([]string{"SPACE DEVELOPER"}, []string{"user4"}))
+				Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"SPACE MANAGER"}))
+			})
+
+			It("fails for a role that is not a space role", func() {
+				Expect(runCommand("--role", "OrgManager", "my-org", "my-space")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Role OrgManager cannot be listed here; use one of SpaceManager, SpaceDeveloper, SpaceAuditor"},
+				))
+			})
+		})
+
+		Context("when the --details flag is provided", func() {
+			BeforeEach(func() {
+				configRepo.SetAPIVersion("2.22.0")
+				userRepo.ListUsersInSpaceForRoleStub = func(_ string, roleName models.Role) ([]models.UserFields, error) {
+					return map[models.Role][]models.UserFields{
+						models.RoleSpaceDeveloper: {{Username: "user4", GUID: "user4-guid", Origin: "ldap"}},
+					}[roleName], nil
+				}
+			})
+
+			It("lists the origin and guid of each user, looked up in UAA", func() {
+				Expect(runCommand("--details", "my-org", "my-space")).To(BeTrue())
+
+				Expect(userRepo.ListUsersInSpaceForRoleWithNoUAACallCount()).To(Equal(0))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"role", "user", "origin", "guid"},
+					[]string{"SPACE DEVELOPER", "user4", "ldap", "user4-guid"},
+				))
+			})
+
+			It("prints the users as JSON", func() {
+				formattedUI := terminal.NewFormattedUI(ui, terminal.OutputFormatJSON)
+				commandUI = formattedUI
+
+				Expect(runCommand("my-org", "my-space")).To(BeTrue())
+				Expect(formattedUI.(terminal.DataPrinter).Flush()).To(Succeed())
+
+				Expect(strings.Join(ui.Outputs(), "\n")).To(MatchJSON(`[
+					{"role": "SPACE DEVELOPER", "user": "user4", "origin": "ldap", "guid": "user4-guid"}
+				]`))
+			})
+		})
 	})
 
 	Context("when logged in and there are no non-managers in the space", func() {
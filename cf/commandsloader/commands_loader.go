@@ -7,6 +7,8 @@ import (
 	"code.cloudfoundry.org/cli/cf/commands/domain"
 	"code.cloudfoundry.org/cli/cf/commands/environmentvariablegroup"
 	"code.cloudfoundry.org/cli/cf/commands/featureflag"
+	"code.cloudfoundry.org/cli/cf/commands/metadata"
+	"code.cloudfoundry.org/cli/cf/commands/networkpolicy"
 	"code.cloudfoundry.org/cli/cf/commands/organization"
 	"code.cloudfoundry.org/cli/cf/commands/plugin"
 	"code.cloudfoundry.org/cli/cf/commands/pluginrepo"
@@ -39,6 +41,8 @@ func Load() {
 	_ = domain.CreateDomain{}
 	_ = environmentvariablegroup.RunningEnvironmentVariableGroup{}
 	_ = featureflag.ShowFeatureFlag{}
+	_ = metadata.Labels{}
+	_ = networkpolicy.NetworkPolicies{}
 	_ = organization.ListOrgs{}
 	_ = plugin.Plugins{}
 	_ = pluginrepo.RepoPlugins{}
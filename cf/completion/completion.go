@@ -0,0 +1,250 @@
+package completion
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/flags"
+)
+
+// CompleteCommand is the hidden command the completion scripts run to get the
+// candidates for the word being completed.
+const CompleteCommand = "__complete"
+
+// ArgumentKind is what an argument or flag value of a command names, when it
+// can be completed.
+type ArgumentKind int
+
+const (
+	NoArgument ArgumentKind = iota
+	AppArgument
+	OrgArgument
+	SpaceArgument
+	CommandArgument
+)
+
+// argumentKinds are the kinds of the placeholders used in command usages.
+var argumentKinds = map[string]ArgumentKind{
+	"APP":             AppArgument,
+	"APP_NAME":        AppArgument,
+	"SOURCE_APP":      AppArgument,
+	"DESTINATION_APP": AppArgument,
+	"ORG":             OrgArgument,
+	"ORG_NAME":        OrgArgument,
+	"SPACE":           SpaceArgument,
+	"SPACE_NAME":      SpaceArgument,
+	"COMMAND":         CommandArgument,
+}
+
+var placeholderRegexp = regexp.MustCompile(`^[A-Z][A-Z_]*$`)
+
+//go:generate counterfeiter . Lookup
+
+// Lookup finds the names of the resources of the target that arguments are
+// completed with.
+type Lookup interface {
+	AppNames() ([]string, error)
+	OrgNames() ([]string, error)
+	SpaceNames() ([]string, error)
+}
+
+type Completer struct {
+	metadatas      []commandregistry.CommandMetadata
+	pluginCommands []string
+	lookup         Lookup
+}
+
+// NewCompleter returns a Completer for the commands of the registry, whose
+// metadatas are given, and the commands of the installed plugins.
+func NewCompleter(metadatas []commandregistry.CommandMetadata, pluginCommands []string, lookup Lookup) Completer {
+	return Completer{
+		metadatas:      metadatas,
+		pluginCommands: pluginCommands,
+		lookup:         lookup,
+	}
+}
+
+// Complete returns the candidates for the last of words, the arguments of cf
+// up to the word being completed, which may be empty.
+func (c Completer) Complete(words []string) []string {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	current := words[len(words)-1]
+
+	if len(words) == 1 {
+		return withPrefix(c.commandNames(), current)
+	}
+
+	metadata, found := c.findCommand(words[0])
+	if !found {
+		return nil
+	}
+
+	if strings.HasPrefix(current, "-") {
+		return withPrefix(flagNames(metadata), current)
+	}
+
+	positionals, flagValues := usageArguments(metadata)
+
+	var kind ArgumentKind
+	if previous := words[len(words)-2]; isValueFlag(metadata, previous) {
+		kind = flagValues[strings.TrimLeft(previous, "-")]
+	} else {
+		position := argumentPosition(metadata, words[1:len(words)-1])
+		if position < len(positionals) {
+			kind = positionals[position]
+		}
+	}
+
+	return withPrefix(c.names(kind), current)
+}
+
+func (c Completer) names(kind ArgumentKind) []string {
+	var (
+		names []string
+		err   error
+	)
+
+	switch kind {
+	case AppArgument:
+		names, err = c.lookup.AppNames()
+	case OrgArgument:
+		names, err = c.lookup.OrgNames()
+	case SpaceArgument:
+		names, err = c.lookup.SpaceNames()
+	case CommandArgument:
+		names = c.commandNames()
+	}
+
+	// a failed lookup leaves the shell to complete the word, as errors
+	// cannot be shown while completing
+	if err != nil {
+		return nil
+	}
+	return names
+}
+
+func (c Completer) commandNames() []string {
+	names := []string{}
+	for _, metadata := range c.metadatas {
+		if !metadata.Hidden {
+			names = append(names, metadata.Name)
+		}
+	}
+	return append(names, c.pluginCommands...)
+}
+
+func (c Completer) findCommand(name string) (commandregistry.CommandMetadata, bool) {
+	for _, metadata := range c.metadatas {
+		if metadata.Name == name || (metadata.ShortName != "" && metadata.ShortName == name) {
+			return metadata, true
+		}
+	}
+	return commandregistry.CommandMetadata{}, false
+}
+
+// usageArguments returns the kinds of the positional arguments of a command,
+// in order, and of the values of its flags, from the placeholders in its
+// usage, such as APP_NAME in 'CF_NAME start APP_NAME'. The arguments of
+// commands that create a resource name a new one, so are not completed.
+func usageArguments(metadata commandregistry.CommandMetadata) ([]ArgumentKind, map[string]ArgumentKind) {
+	positionals := []ArgumentKind{}
+	flagValues := map[string]ArgumentKind{}
+	if len(metadata.Usage) == 0 {
+		return positionals, flagValues
+	}
+
+	usage := strings.SplitN(strings.Join(metadata.Usage, ""), "\n", 2)[0]
+	tokens := strings.Fields(strings.NewReplacer("[", " ", "]", " ", "(", " ", ")", " ", "|", " ", "...", " ").Replace(usage))
+
+	creates := strings.HasPrefix(metadata.Name, "create-")
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		switch {
+		case strings.HasPrefix(token, "-"):
+			if i+1 < len(tokens) && placeholderRegexp.MatchString(tokens[i+1]) {
+				flagValues[strings.TrimLeft(token, "-")] = argumentKinds[tokens[i+1]]
+				i++
+			}
+		case placeholderRegexp.MatchString(token) && token != "CF_NAME":
+			kind := argumentKinds[token]
+			if creates {
+				kind = NoArgument
+			}
+			positionals = append(positionals, kind)
+		}
+	}
+
+	return positionals, flagValues
+}
+
+// argumentPosition returns the position of the argument after args, skipping
+// flags and their values.
+func argumentPosition(metadata commandregistry.CommandMetadata, args []string) int {
+	position := 0
+	for i := 0; i < len(args); i++ {
+		if strings.HasPrefix(args[i], "-") {
+			if isValueFlag(metadata, args[i]) {
+				i++
+			}
+			continue
+		}
+		position++
+	}
+	return position
+}
+
+func isValueFlag(metadata commandregistry.CommandMetadata, arg string) bool {
+	if !strings.HasPrefix(arg, "-") || strings.Contains(arg, "=") {
+		return false
+	}
+
+	flag := findFlag(metadata, strings.TrimLeft(arg, "-"))
+	if flag == nil {
+		return false
+	}
+	_, isBool := flag.(*flags.BoolFlag)
+	return !isBool
+}
+
+func findFlag(metadata commandregistry.CommandMetadata, name string) flags.FlagSet {
+	if name == "" {
+		return nil
+	}
+	for _, flag := range metadata.Flags {
+		if flag.GetName() == name || flag.GetShortName() == name {
+			return flag
+		}
+	}
+	return nil
+}
+
+func flagNames(metadata commandregistry.CommandMetadata) []string {
+	names := []string{}
+	for _, flag := range metadata.Flags {
+		if !flag.Visible() {
+			continue
+		}
+		if flag.GetName() != "" {
+			names = append(names, "--"+flag.GetName())
+		}
+		if flag.GetShortName() != "" {
+			names = append(names, "-"+flag.GetShortName())
+		}
+	}
+	return names
+}
+
+func withPrefix(names []string, prefix string) []string {
+	matches := []string{}
+	for _, name := range names {
+		if strings.HasPrefix(name, prefix) {
+			matches = append(matches, name)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
@@ -0,0 +1,13 @@
+package completion_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestCompletion(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Completion Suite")
+}
@@ -0,0 +1,141 @@
+package completion_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	. "code.cloudfoundry.org/cli/cf/completion"
+	"code.cloudfoundry.org/cli/cf/completion/completionfakes"
+	"code.cloudfoundry.org/cli/cf/flags"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Completer", func() {
+	var (
+		lookup    *completionfakes.FakeLookup
+		completer Completer
+	)
+
+	BeforeEach(func() {
+		lookup = new(completionfakes.FakeLookup)
+		lookup.AppNamesReturns([]string{"web", "worker", "api"}, nil)
+		lookup.OrgNamesReturns([]string{"my-org", "other-org"}, nil)
+		lookup.SpaceNamesReturns([]string{"dev", "prod"}, nil)
+
+		metadatas := []commandregistry.CommandMetadata{
+			{
+				Name:      "start",
+				ShortName: "st",
+				Usage:     []string{"CF_NAME start APP_NAME"},
+			},
+			{
+				Name:  "copy-source",
+				Usage: []string{"CF_NAME copy-source SOURCE_APP TARGET_APP [-s TARGET_SPACE [-o TARGET_ORG]] [--no-restart]\n"},
+			},
+			{
+				Name:  "target",
+				Usage: []string{"CF_NAME target [-o ORG] [-s SPACE]"},
+				Flags: map[string]flags.FlagSet{
+					"o": &flags.StringFlag{ShortName: "o", Usage: "Organization"},
+					"s": &flags.StringFlag{ShortName: "s", Usage: "Space"},
+				},
+			},
+			{
+				Name:  "scale",
+				Usage: []string{"CF_NAME scale APP_NAME [-i INSTANCES] [-m MEMORY] [-f]\n\n   Scales the app"},
+				Flags: map[string]flags.FlagSet{
+					"i": &flags.IntFlag{ShortName: "i", Usage: "Number of instances"},
+					"m": &flags.StringFlag{ShortName: "m", Usage: "Memory limit"},
+					"f": &flags.BoolFlag{ShortName: "f", Usage: "Force restart"},
+				},
+			},
+			{
+				Name:  "create-space",
+				Usage: []string{"CF_NAME create-space SPACE [-o ORG]"},
+				Flags: map[string]flags.FlagSet{
+					"o": &flags.StringFlag{ShortName: "o", Usage: "Organization"},
+				},
+			},
+			{
+				Name:  "help",
+				Usage: []string{"CF_NAME help [COMMAND]"},
+				Flags: map[string]flags.FlagSet{
+					"a":       &flags.BoolFlag{ShortName: "a", Usage: "All commands"},
+					"verbose": &flags.BoolFlag{Name: "verbose", Usage: "Verbose", Hidden: true},
+				},
+			},
+			{
+				Name:   "secret",
+				Hidden: true,
+			},
+		}
+		completer = NewCompleter(metadatas, []string{"echo"}, lookup)
+	})
+
+	It("completes the names of the commands and plugin commands", func() {
+		Expect(completer.Complete([]string{""})).To(Equal([]string{"copy-source", "create-space", "echo", "help", "scale", "start", "target"}))
+		Expect(completer.Complete([]string{"s"})).To(Equal([]string{"scale", "start"}))
+	})
+
+	It("completes the visible flags of a command", func() {
+		Expect(completer.Complete([]string{"scale", "web", "-"})).To(Equal([]string{"-f", "-i", "-m"}))
+		Expect(completer.Complete([]string{"help", "--"})).To(BeEmpty())
+	})
+
+	It("completes app names for arguments that are apps", func() {
+		Expect(completer.Complete([]string{"start", ""})).To(Equal([]string{"api", "web", "worker"}))
+		Expect(completer.Complete([]string{"st", "w"})).To(Equal([]string{"web", "worker"}))
+		Expect(completer.Complete([]string{"copy-source", "w"})).To(Equal([]string{"web", "worker"}))
+	})
+
+	It("completes only the arguments in the usage of the command", func() {
+		Expect(completer.Complete([]string{"start", "web", ""})).To(BeEmpty())
+		Expect(completer.Complete([]string{"copy-source", "web", ""})).To(BeEmpty())
+	})
+
+	It("skips flags and their values when finding the argument being completed", func() {
+		Expect(completer.Complete([]string{"scale", "-i", "2", "-f", ""})).To(Equal([]string{"api", "web", "worker"}))
+		Expect(completer.Complete([]string{"scale", "-m", ""})).To(BeEmpty())
+	})
+
+	It("completes org and space names for the values of flags that are orgs and spaces", func() {
+		Expect(completer.Complete([]string{"target", "-o", ""})).To(Equal([]string{"my-org", "other-org"}))
+		Expect(completer.Complete([]string{"target", "-o", "my-org", "-s", "p"})).To(Equal([]string{"prod"}))
+	})
+
+	It("completes the names of existing resources only for flags of commands that create one", func() {
+		Expect(completer.Complete([]string{"create-space", ""})).To(BeEmpty())
+		Expect(completer.Complete([]string{"create-space", "new-space", "-o", ""})).To(Equal([]string{"my-org", "other-org"}))
+	})
+
+	It("completes command names for the help command", func() {
+		Expect(completer.Complete([]string{"help", "ta"})).To(Equal([]string{"target"}))
+	})
+
+	It("completes nothing for unknown commands", func() {
+		Expect(completer.Complete([]string{"unknown", ""})).To(BeEmpty())
+	})
+
+	It("completes nothing when the names cannot be looked up", func() {
+		lookup.AppNamesReturns(nil, errors.New("not logged in"))
+		Expect(completer.Complete([]string{"start", ""})).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Script", func() {
+	It("returns a script calling back into cf for each shell", func() {
+		for _, shell := range Shells {
+			script, err := Script(shell)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(script).To(ContainSubstring(CompleteCommand))
+			Expect(script).NotTo(ContainSubstring("CF_NAME"))
+		}
+	})
+
+	It("returns an error for other shells", func() {
+		_, err := Script("tcsh")
+		Expect(err).To(MatchError("Completion scripts can only be generated for bash, zsh, fish, powershell, not 'tcsh'"))
+	})
+})
@@ -0,0 +1,138 @@
+// This file was generated by counterfeiter
+package completionfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/completion"
+)
+
+type FakeLookup struct {
+	AppNamesStub        func() ([]string, error)
+	appNamesMutex       sync.RWMutex
+	appNamesArgsForCall []struct{}
+	appNamesReturns     struct {
+		result1 []string
+		result2 error
+	}
+	OrgNamesStub        func() ([]string, error)
+	orgNamesMutex       sync.RWMutex
+	orgNamesArgsForCall []struct{}
+	orgNamesReturns     struct {
+		result1 []string
+		result2 error
+	}
+	SpaceNamesStub        func() ([]string, error)
+	spaceNamesMutex       sync.RWMutex
+	spaceNamesArgsForCall []struct{}
+	spaceNamesReturns     struct {
+		result1 []string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLookup) AppNames() ([]string, error) {
+	fake.appNamesMutex.Lock()
+	fake.appNamesArgsForCall = append(fake.appNamesArgsForCall, struct{}{})
+	fake.recordInvocation("AppNames", []interface{}{})
+	fake.appNamesMutex.Unlock()
+	if fake.AppNamesStub != nil {
+		return fake.AppNamesStub()
+	} else {
+		return fake.appNamesReturns.result1, fake.appNamesReturns.result2
+	}
+}
+
+func (fake *FakeLookup) AppNamesCallCount() int {
+	fake.appNamesMutex.RLock()
+	defer fake.appNamesMutex.RUnlock()
+	return len(fake.appNamesArgsForCall)
+}
+
+func (fake *FakeLookup) AppNamesReturns(result1 []string, result2 error) {
+	fake.AppNamesStub = nil
+	fake.appNamesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLookup) OrgNames() ([]string, error) {
+	fake.orgNamesMutex.Lock()
+	fake.orgNamesArgsForCall = append(fake.orgNamesArgsForCall, struct{}{})
+	fake.recordInvocation("OrgNames", []interface{}{})
+	fake.orgNamesMutex.Unlock()
+	if fake.OrgNamesStub != nil {
+		return fake.OrgNamesStub()
+	} else {
+		return fake.orgNamesReturns.result1, fake.orgNamesReturns.result2
+	}
+}
+
+func (fake *FakeLookup) OrgNamesCallCount() int {
+	fake.orgNamesMutex.RLock()
+	defer fake.orgNamesMutex.RUnlock()
+	return len(fake.orgNamesArgsForCall)
+}
+
+func (fake *FakeLookup) OrgNamesReturns(result1 []string, result2 error) {
+	fake.OrgNamesStub = nil
+	fake.orgNamesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLookup) SpaceNames() ([]string, error) {
+	fake.spaceNamesMutex.Lock()
+	fake.spaceNamesArgsForCall = append(fake.spaceNamesArgsForCall, struct{}{})
+	fake.recordInvocation("SpaceNames", []interface{}{})
+	fake.spaceNamesMutex.Unlock()
+	if fake.SpaceNamesStub != nil {
+		return fake.SpaceNamesStub()
+	} else {
+		return fake.spaceNamesReturns.result1, fake.spaceNamesReturns.result2
+	}
+}
+
+func (fake *FakeLookup) SpaceNamesCallCount() int {
+	fake.spaceNamesMutex.RLock()
+	defer fake.spaceNamesMutex.RUnlock()
+	return len(fake.spaceNamesArgsForCall)
+}
+
+func (fake *FakeLookup) SpaceNamesReturns(result1 []string, result2 error) {
+	fake.SpaceNamesStub = nil
+	fake.spaceNamesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLookup) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.appNamesMutex.RLock()
+	defer fake.appNamesMutex.RUnlock()
+	fake.orgNamesMutex.RLock()
+	defer fake.orgNamesMutex.RUnlock()
+	fake.spaceNamesMutex.RLock()
+	defer fake.spaceNamesMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeLookup) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ completion.Lookup = new(FakeLookup)
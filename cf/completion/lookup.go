@@ -0,0 +1,171 @@
+package completion
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/organizations"
+	"code.cloudfoundry.org/cli/cf/api/spaces"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+// CacheTTL is how long the names found by an APILookup are used before they
+// are looked up again.
+const CacheTTL = 2 * time.Minute
+
+// orgLimit is the most orgs whose names are looked up.
+const orgLimit = 500
+
+// APILookup looks the names up with the API of the target, keeping them in a
+// cache file so that completing a word does not wait for the API each time.
+type APILookup struct {
+	config      coreconfig.Reader
+	appRepo     api.AppSummaryRepository
+	orgRepo     organizations.OrganizationRepository
+	spaceRepo   spaces.SpaceRepository
+	cachePath   string
+	currentTime func() time.Time
+}
+
+type cacheEntry struct {
+	Names     []string  `json:"names"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewAPILookup(
+	config coreconfig.Reader,
+	appRepo api.AppSummaryRepository,
+	orgRepo organizations.OrganizationRepository,
+	spaceRepo spaces.SpaceRepository,
+	cachePath string,
+	currentTime func() time.Time,
+) APILookup {
+	return APILookup{
+		config:      config,
+		appRepo:     appRepo,
+		orgRepo:     orgRepo,
+		spaceRepo:   spaceRepo,
+		cachePath:   cachePath,
+		currentTime: currentTime,
+	}
+}
+
+func (l APILookup) AppNames() ([]string, error) {
+	if !l.config.HasSpace() {
+		return nil, errors.New("no space targeted")
+	}
+
+	return l.cachedNames("apps:"+l.config.SpaceFields().GUID, func() ([]string, error) {
+		apps, err := l.appRepo.GetSummariesInCurrentSpace()
+		if err != nil {
+			return nil, err
+		}
+
+		names := []string{}
+		for _, app := range apps {
+			names = append(names, app.Name)
+		}
+		return names, nil
+	})
+}
+
+func (l APILookup) OrgNames() ([]string, error) {
+	return l.cachedNames("orgs", func() ([]string, error) {
+		orgs, err := l.orgRepo.ListOrgs(orgLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		names := []string{}
+		for _, org := range orgs {
+			names = append(names, org.Name)
+		}
+		return names, nil
+	})
+}
+
+func (l APILookup) SpaceNames() ([]string, error) {
+	if !l.config.HasOrganization() {
+		return nil, errors.New("no org targeted")
+	}
+
+	return l.cachedNames("spaces:"+l.config.OrganizationFields().GUID, func() ([]string, error) {
+		names := []string{}
+		err := l.spaceRepo.ListSpaces(func(space models.Space) bool {
+			names = append(names, space.Name)
+			return true
+		})
+		if err != nil {
+			return nil, err
+		}
+		return names, nil
+	})
+}
+
+// cachedNames returns the names cached under key for the API of the target,
+// or looks them up and caches them when they are missing or older than
+// CacheTTL.
+func (l APILookup) cachedNames(key string, lookup func() ([]string, error)) ([]string, error) {
+	if !l.config.IsLoggedIn() {
+		return nil, errors.New("not logged in")
+	}
+
+	key = l.config.APIEndpoint() + " " + key
+	cache := l.readCache()
+	if entry, ok := cache[key]; ok && l.currentTime().Sub(entry.UpdatedAt) < CacheTTL {
+		return entry.Names, nil
+	}
+
+	names, err := lookup()
+	if err != nil {
+		return nil, err
+	}
+
+	// entries of other targets are dropped once they are stale, so the
+	// cache does not grow with every space ever targeted
+	for cachedKey, entry := range cache {
+		if l.currentTime().Sub(entry.UpdatedAt) >= CacheTTL {
+			delete(cache, cachedKey)
+		}
+	}
+	cache[key] = cacheEntry{Names: names, UpdatedAt: l.currentTime()}
+	l.writeCache(cache)
+
+	return names, nil
+}
+
+// readCache returns the entries of the cache file. An unreadable cache is
+// the same as an empty one, as the names can be looked up again.
+func (l APILookup) readCache() map[string]cacheEntry {
+	cache := map[string]cacheEntry{}
+
+	data, err := ioutil.ReadFile(l.cachePath)
+	if err != nil {
+		return cache
+	}
+
+	err = json.Unmarshal(data, &cache)
+	if err != nil {
+		return map[string]cacheEntry{}
+	}
+	return cache
+}
+
+func (l APILookup) writeCache(cache map[string]cacheEntry) {
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return
+	}
+
+	err = os.MkdirAll(filepath.Dir(l.cachePath), 0700)
+	if err != nil {
+		return
+	}
+	ioutil.WriteFile(l.cachePath, data, 0600)
+}
@@ -0,0 +1,121 @@
+package completion_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/organizations/organizationsfakes"
+	"code.cloudfoundry.org/cli/cf/api/spaces/spacesfakes"
+	. "code.cloudfoundry.org/cli/cf/completion"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("APILookup", func() {
+	var (
+		config    coreconfig.Repository
+		appRepo   *apifakes.FakeAppSummaryRepository
+		orgRepo   *organizationsfakes.FakeOrganizationRepository
+		spaceRepo *spacesfakes.FakeSpaceRepository
+		cacheDir  string
+		now       time.Time
+		lookup    APILookup
+	)
+
+	BeforeEach(func() {
+		config = testconfig.NewRepositoryWithDefaults()
+		appRepo = new(apifakes.FakeAppSummaryRepository)
+		appRepo.GetSummariesInCurrentSpaceReturns([]models.Application{
+			{ApplicationFields: models.ApplicationFields{Name: "web"}},
+			{ApplicationFields: models.ApplicationFields{Name: "worker"}},
+		}, nil)
+		orgRepo = new(organizationsfakes.FakeOrganizationRepository)
+		orgRepo.ListOrgsReturns([]models.Organization{
+			{OrganizationFields: models.OrganizationFields{Name: "my-org"}},
+		}, nil)
+		spaceRepo = new(spacesfakes.FakeSpaceRepository)
+		spaceRepo.ListSpacesStub = func(callback func(models.Space) bool) error {
+			callback(models.Space{SpaceFields: models.SpaceFields{Name: "dev"}})
+			callback(models.Space{SpaceFields: models.SpaceFields{Name: "prod"}})
+			return nil
+		}
+
+		var err error
+		cacheDir, err = ioutil.TempDir("", "completion-cache")
+		Expect(err).NotTo(HaveOccurred())
+
+		now = time.Now()
+		lookup = NewAPILookup(config, appRepo, orgRepo, spaceRepo, filepath.Join(cacheDir, "completion-cache.json"), func() time.Time {
+			return now
+		})
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(cacheDir)
+	})
+
+	It("looks up the names of the apps, orgs and spaces of the target", func() {
+		Expect(lookup.AppNames()).To(Equal([]string{"web", "worker"}))
+		Expect(lookup.OrgNames()).To(Equal([]string{"my-org"}))
+		Expect(lookup.SpaceNames()).To(Equal([]string{"dev", "prod"}))
+	})
+
+	It("uses the cached names until they are older than the cache TTL", func() {
+		Expect(lookup.AppNames()).To(Equal([]string{"web", "worker"}))
+		appRepo.GetSummariesInCurrentSpaceReturns([]models.Application{
+			{ApplicationFields: models.ApplicationFields{Name: "api"}},
+		}, nil)
+
+		now = now.Add(CacheTTL - time.Second)
+		Expect(lookup.AppNames()).To(Equal([]string{"web", "worker"}))
+		Expect(appRepo.GetSummariesInCurrentSpaceCallCount()).To(Equal(1))
+
+		now = now.Add(time.Second)
+		Expect(lookup.AppNames()).To(Equal([]string{"api"}))
+		Expect(appRepo.GetSummariesInCurrentSpaceCallCount()).To(Equal(2))
+	})
+
+	It("caches the apps of each space separately", func() {
+		Expect(lookup.AppNames()).To(Equal([]string{"web", "worker"}))
+
+		config.SetSpaceFields(models.SpaceFields{GUID: "other-space-guid", Name: "other-space"})
+		appRepo.GetSummariesInCurrentSpaceReturns([]models.Application{}, nil)
+		Expect(lookup.AppNames()).To(BeEmpty())
+		Expect(appRepo.GetSummariesInCurrentSpaceCallCount()).To(Equal(2))
+	})
+
+	It("does not cache names that could not be looked up", func() {
+		orgRepo.ListOrgsReturns(nil, errors.New("api down"))
+		_, err := lookup.OrgNames()
+		Expect(err).To(MatchError("api down"))
+
+		orgRepo.ListOrgsReturns([]models.Organization{
+			{OrganizationFields: models.OrganizationFields{Name: "my-org"}},
+		}, nil)
+		Expect(lookup.OrgNames()).To(Equal([]string{"my-org"}))
+	})
+
+	It("looks nothing up when not logged in", func() {
+		config.SetAccessToken("")
+
+		_, err := lookup.OrgNames()
+		Expect(err).To(HaveOccurred())
+		Expect(orgRepo.ListOrgsCallCount()).To(Equal(0))
+	})
+
+	It("looks up no apps when no space is targeted", func() {
+		config.SetSpaceFields(models.SpaceFields{})
+
+		_, err := lookup.AppNames()
+		Expect(err).To(HaveOccurred())
+		Expect(appRepo.GetSummariesInCurrentSpaceCallCount()).To(Equal(0))
+	})
+})
@@ -0,0 +1,76 @@
+package completion
+
+import (
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/errors"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+)
+
+// Shells are the shells that completion scripts are generated for.
+var Shells = []string{"bash", "zsh", "fish", "powershell"}
+
+// Each script runs 'cf __complete' with the words of the command line up to
+// the one being completed, and offers the lines it prints. When it prints
+// none, the shell completes file names instead.
+var scripts = map[string]string{
+	"bash": `# bash completion for CF_NAME
+_CF_NAME_completion() {
+    local IFS=$'\n'
+    COMPREPLY=($("${COMP_WORDS[0]}" __complete "${COMP_WORDS[@]:1:$COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _CF_NAME_completion CF_NAME
+`,
+
+	"zsh": `#compdef CF_NAME
+# zsh completion for CF_NAME
+_CF_NAME() {
+    local candidates
+    candidates=$("${words[1]}" __complete "${(@)words[2,$CURRENT]}" 2>/dev/null)
+    if [[ -n "$candidates" ]]; then
+        compadd -- "${(@f)candidates}"
+    else
+        _files
+    fi
+}
+compdef _CF_NAME CF_NAME
+`,
+
+	"fish": `# fish completion for CF_NAME
+function __CF_NAME_complete
+    set -l words (commandline -opc) (commandline -ct)
+    $words[1] __complete $words[2..-1] 2>/dev/null
+end
+complete -c CF_NAME -a '(__CF_NAME_complete)'
+`,
+
+	"powershell": `# PowerShell completion for CF_NAME
+Register-ArgumentCompleter -Native -CommandName 'CF_NAME' -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $words = @($commandAst.CommandElements |
+        Where-Object { $_.Extent.StartOffset -lt $cursorPosition } |
+        Select-Object -Skip 1 |
+        ForEach-Object { $_.Extent.Text })
+    # PowerShell drops empty arguments to programs, so the empty word is quoted
+    if ($wordToComplete -eq '') { $words += '""' }
+    $PSNativeCommandArgumentPassing = 'Legacy'
+
+    & 'CF_NAME' __complete @words 2>$null | Where-Object { $_ } | ForEach-Object {
+        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+    }
+}
+`,
+}
+
+// Script returns the completion script for shell.
+func Script(shell string) (string, error) {
+	script, ok := scripts[shell]
+	if !ok {
+		return "", errors.New(T("Completion scripts can only be generated for {{.Shells}}, not '{{.Shell}}'",
+			map[string]interface{}{"Shells": strings.Join(Shells, ", "), "Shell": shell}))
+	}
+
+	return strings.Replace(script, "CF_NAME", cf.Name, -1), nil
+}
@@ -3,6 +3,7 @@ package configuration
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 )
 
 const (
@@ -85,6 +86,33 @@ func (dp DiskPersistor) write(data DataInterface) error {
 		return err
 	}
 
-	err = ioutil.WriteFile(dp.filePath, bytes, filePermissions)
-	return err
+	lock := newFileLock(dp.filePath)
+	err = lock.Lock()
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock()
+
+	// Write to a temporary file and rename it over the config, so that
+	// nothing ever reads a partially written file.
+	tempFile, err := ioutil.TempFile(filepath.Dir(dp.filePath), filepath.Base(dp.filePath))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+
+	_, err = tempFile.Write(bytes)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(tempFile.Name(), filePermissions)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile.Name(), dp.filePath)
 }
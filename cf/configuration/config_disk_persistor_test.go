@@ -2,8 +2,11 @@ package configuration_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
+	"sync"
+	"time"
 
 	. "code.cloudfoundry.org/cli/cf/configuration"
 	. "github.com/onsi/ginkgo"
@@ -54,6 +57,62 @@ var _ = Describe("DiskPersistor", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(string(dataBytes)).To(ContainSubstring(d.Info))
 		})
+
+		It("does not leave a lock file behind", func() {
+			err := diskPersistor.Save(&data{Info: "save test"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = os.Stat(tmpFile.Name() + ".lock")
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("keeps the file valid when several saves happen at once", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					err := NewDiskPersistor(tmpFile.Name()).Save(&data{Info: fmt.Sprintf("save %d", i)})
+					Expect(err).ToNot(HaveOccurred())
+				}(i)
+			}
+			wg.Wait()
+
+			d := &data{}
+			err := diskPersistor.Load(d)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(d.Info).To(HavePrefix("save "))
+		})
+
+		It("waits for a lock held by someone else", func() {
+			lockPath := tmpFile.Name() + ".lock"
+			err := ioutil.WriteFile(lockPath, []byte{}, 0600)
+			Expect(err).ToNot(HaveOccurred())
+			defer os.Remove(lockPath)
+
+			saved := make(chan error)
+			go func() {
+				saved <- diskPersistor.Save(&data{Info: "save test"})
+			}()
+
+			Consistently(saved, 100*time.Millisecond).ShouldNot(Receive())
+			Expect(os.Remove(lockPath)).To(Succeed())
+			Eventually(saved).Should(Receive(BeNil()))
+		})
+
+		It("removes a stale lock", func() {
+			lockPath := tmpFile.Name() + ".lock"
+			err := ioutil.WriteFile(lockPath, []byte{}, 0600)
+			Expect(err).ToNot(HaveOccurred())
+			defer os.Remove(lockPath)
+
+			staleTime := time.Now().Add(-time.Hour)
+			Expect(os.Chtimes(lockPath, staleTime, staleTime)).To(Succeed())
+
+			err = diskPersistor.Save(&data{Info: "save test"})
+			Expect(err).ToNot(HaveOccurred())
+		})
 	})
 
 	Describe(".Load", func() {
@@ -7,9 +7,11 @@ import (
 )
 
 type TokenInfo struct {
-	Username string `json:"user_name"`
-	Email    string `json:"email"`
-	UserGUID string `json:"user_id"`
+	Username string   `json:"user_name"`
+	Email    string   `json:"email"`
+	UserGUID string   `json:"user_id"`
+	ClientID string   `json:"client_id"`
+	Scope    []string `json:"scope"`
 }
 
 func NewTokenInfo(accessToken string) (info TokenInfo) {
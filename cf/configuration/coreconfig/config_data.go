@@ -30,18 +30,58 @@ type Data struct {
 	AccessToken              string
 	SSHOAuthClient           string
 	RefreshToken             string
+	UAAGrantType             string
+	UAAOAuthClient           string
+	UAAOAuthClientSecret     string
 	OrganizationFields       models.OrganizationFields
 	SpaceFields              models.SpaceFields
 	SSLDisabled              bool
 	AsyncTimeout             uint
+	KeepAliveTimeout         uint
+	MaxIdleConnections       uint
 	Trace                    string
 	ColorEnabled             string
 	Locale                   string
+	CredentialStore          string
+	PluginSandbox            bool                    `json:",omitempty"`
+	Contexts                 map[string]*ContextData `json:",omitempty"`
+	LoginTargets             map[string]LoginTarget  `json:",omitempty"`
 	PluginRepos              []models.PluginRepo
 	MinCLIVersion            string
 	MinRecommendedCLIVersion string
 }
 
+// ContextData is the part of the config that describes the targeted API, the
+// session with it and the targeted org and space. A context saves a copy of
+// it under a name, so that the target can be switched back to later.
+type ContextData struct {
+	Target                   string
+	APIVersion               string
+	AuthorizationEndpoint    string
+	LoggregatorEndPoint      string
+	DopplerEndPoint          string
+	UaaEndpoint              string
+	RoutingAPIEndpoint       string
+	AccessToken              string
+	SSHOAuthClient           string
+	RefreshToken             string
+	UAAGrantType             string
+	UAAOAuthClient           string
+	UAAOAuthClientSecret     string
+	OrganizationFields       models.OrganizationFields
+	SpaceFields              models.SpaceFields
+	SSLDisabled              bool
+	MinCLIVersion            string
+	MinRecommendedCLIVersion string
+}
+
+// LoginTarget is the org and space last targeted when logging in to an API,
+// which login offers first the next time.
+type LoginTarget struct {
+	OrgName   string
+	SpaceName string
+}
+
 func NewData() *Data {
 	return new(Data)
 }
@@ -64,3 +104,64 @@ func (d *Data) JSONUnmarshalV3(input []byte) error {
 
 	return nil
 }
+
+// TargetData returns a copy of the current target.
+func (d *Data) TargetData() ContextData {
+	return ContextData{
+		Target:                   d.Target,
+		APIVersion:               d.APIVersion,
+		AuthorizationEndpoint:    d.AuthorizationEndpoint,
+		LoggregatorEndPoint:      d.LoggregatorEndPoint,
+		DopplerEndPoint:          d.DopplerEndPoint,
+		UaaEndpoint:              d.UaaEndpoint,
+		RoutingAPIEndpoint:       d.RoutingAPIEndpoint,
+		AccessToken:              d.AccessToken,
+		SSHOAuthClient:           d.SSHOAuthClient,
+		RefreshToken:             d.RefreshToken,
+		UAAGrantType:             d.UAAGrantType,
+		UAAOAuthClient:           d.UAAOAuthClient,
+		UAAOAuthClientSecret:     d.UAAOAuthClientSecret,
+		OrganizationFields:       d.OrganizationFields,
+		SpaceFields:              d.SpaceFields,
+		SSLDisabled:              d.SSLDisabled,
+		MinCLIVersion:            d.MinCLIVersion,
+		MinRecommendedCLIVersion: d.MinRecommendedCLIVersion,
+	}
+}
+
+// SetTargetData replaces the current target with target.
+func (d *Data) SetTargetData(target ContextData) {
+	d.Target = target.Target
+	d.APIVersion = target.APIVersion
+	d.AuthorizationEndpoint = target.AuthorizationEndpoint
+	d.LoggregatorEndPoint = target.LoggregatorEndPoint
+	d.DopplerEndPoint = target.DopplerEndPoint
+	d.UaaEndpoint = target.UaaEndpoint
+	d.RoutingAPIEndpoint = target.RoutingAPIEndpoint
+	d.AccessToken = target.AccessToken
+	d.SSHOAuthClient = target.SSHOAuthClient
+	d.RefreshToken = target.RefreshToken
+	d.UAAGrantType = target.UAAGrantType
+	d.UAAOAuthClient = target.UAAOAuthClient
+	d.UAAOAuthClientSecret = target.UAAOAuthClientSecret
+	d.OrganizationFields = target.OrganizationFields
+	d.SpaceFields = target.SpaceFields
+	d.SSLDisabled = target.SSLDisabled
+	d.MinCLIVersion = target.MinCLIVersion
+	d.MinRecommendedCLIVersion = target.MinRecommendedCLIVersion
+}
+
+// copyContexts gives d its own copy of its contexts, so that the contexts of
+// a copy of the data can be changed without changing the original.
+func (d *Data) copyContexts() {
+	if d.Contexts == nil {
+		return
+	}
+
+	contexts := make(map[string]*ContextData, len(d.Contexts))
+	for name, context := range d.Contexts {
+		copied := *context
+		contexts[name] = &copied
+	}
+	d.Contexts = contexts
+}
@@ -22,6 +22,9 @@ var _ = Describe("V3 Config files", func() {
 		"AccessToken": "the-access-token",
 		"SSHOAuthClient": "ssh-oauth-client-id",
 		"RefreshToken": "the-refresh-token",
+		"UAAGrantType": "client_credentials",
+		"UAAOAuthClient": "some-client",
+		"UAAOAuthClientSecret": "some-secret",
 		"OrganizationFields": {
 			"GUID": "the-org-guid",
 			"Name": "the-org",
@@ -42,9 +45,12 @@ var _ = Describe("V3 Config files", func() {
 		},
 		"SSLDisabled": true,
 		"AsyncTimeout": 1000,
+		"KeepAliveTimeout": 60,
+		"MaxIdleConnections": 20,
 		"Trace": "path/to/some/file",
 		"ColorEnabled": "true",
 		"Locale": "fr_FR",
+		"CredentialStore": "keychain",
 		"PluginRepos": [
 		{
 			"Name": "repo1",
@@ -113,6 +119,9 @@ var _ = Describe("V3 Config files", func() {
 				UaaEndpoint:              "uaa.example.com",
 				AccessToken:              "the-access-token",
 				RefreshToken:             "the-refresh-token",
+				UAAGrantType:             "client_credentials",
+				UAAOAuthClient:           "some-client",
+				UAAOAuthClientSecret:     "some-secret",
 				SSHOAuthClient:           "ssh-oauth-client-id",
 				MinCLIVersion:            "6.0.0",
 				MinRecommendedCLIVersion: "6.9.0",
@@ -124,11 +133,14 @@ var _ = Describe("V3 Config files", func() {
 					GUID: "the-space-guid",
 					Name: "the-space",
 				},
-				SSLDisabled:  true,
-				Trace:        "path/to/some/file",
-				AsyncTimeout: 1000,
-				ColorEnabled: "true",
-				Locale:       "fr_FR",
+				SSLDisabled:        true,
+				Trace:              "path/to/some/file",
+				AsyncTimeout:       1000,
+				KeepAliveTimeout:   60,
+				MaxIdleConnections: 20,
+				ColorEnabled:       "true",
+				Locale:             "fr_FR",
+				CredentialStore:    "keychain",
 				PluginRepos: []models.PluginRepo{
 					{
 						Name: "repo1",
@@ -163,6 +175,9 @@ var _ = Describe("V3 Config files", func() {
 				UaaEndpoint:              "uaa.example.com",
 				AccessToken:              "the-access-token",
 				RefreshToken:             "the-refresh-token",
+				UAAGrantType:             "client_credentials",
+				UAAOAuthClient:           "some-client",
+				UAAOAuthClientSecret:     "some-secret",
 				SSHOAuthClient:           "ssh-oauth-client-id",
 				MinCLIVersion:            "6.0.0",
 				MinRecommendedCLIVersion: "6.9.0",
@@ -174,11 +189,14 @@ var _ = Describe("V3 Config files", func() {
 					GUID: "the-space-guid",
 					Name: "the-space",
 				},
-				SSLDisabled:  true,
-				Trace:        "path/to/some/file",
-				AsyncTimeout: 1000,
-				ColorEnabled: "true",
-				Locale:       "fr_FR",
+				SSLDisabled:        true,
+				Trace:              "path/to/some/file",
+				AsyncTimeout:       1000,
+				KeepAliveTimeout:   60,
+				MaxIdleConnections: 20,
+				ColorEnabled:       "true",
+				Locale:             "fr_FR",
+				CredentialStore:    "keychain",
 				PluginRepos: []models.PluginRepo{
 					{
 						Name: "repo1",
@@ -1,10 +1,12 @@
 package coreconfig
 
 import (
+	"sort"
 	"strings"
 	"sync"
 
 	"code.cloudfoundry.org/cli/cf/configuration"
+	"code.cloudfoundry.org/cli/cf/configuration/credentialstore"
 	"code.cloudfoundry.org/cli/cf/models"
 	"github.com/blang/semver"
 )
@@ -15,6 +17,15 @@ type ConfigRepository struct {
 	initOnce  *sync.Once
 	persistor configuration.Persistor
 	onError   func(error)
+
+	// fileTarget is the org and space targeted in the config file while
+	// they are overridden for a single command
+	fileTarget *targetFields
+}
+
+type targetFields struct {
+	org   models.OrganizationFields
+	space models.SpaceFields
 }
 
 type CCInfo struct {
@@ -28,10 +39,22 @@ type CCInfo struct {
 }
 
 func NewRepositoryFromFilepath(filepath string, errorHandler func(error)) Repository {
+	return NewContextRepositoryFromFilepath(filepath, "", errorHandler)
+}
+
+// NewContextRepositoryFromFilepath returns the config with the named context
+// as its target, or the current target when context is empty.
+func NewContextRepositoryFromFilepath(filepath string, context string, errorHandler func(error)) Repository {
 	if errorHandler == nil {
 		return nil
 	}
-	return NewRepositoryFromPersistor(configuration.NewDiskPersistor(filepath), errorHandler)
+	var persistor configuration.Persistor = NewCredentialPersistor(configuration.NewDiskPersistor(filepath), func(backend string) (credentialstore.Store, error) {
+		return credentialstore.New(backend, "cf-cli:"+filepath)
+	})
+	if context != "" {
+		persistor = NewContextPersistor(persistor, context)
+	}
+	return NewRepositoryFromPersistor(persistor, errorHandler)
 }
 
 func NewRepositoryFromPersistor(persistor configuration.Persistor, errorHandler func(error)) Repository {
@@ -66,6 +89,9 @@ type Reader interface {
 	AccessToken() string
 	SSHOAuthClient() string
 	RefreshToken() string
+	UAAGrantType() string
+	UAAOAuthClient() string
+	UAAOAuthClientSecret() string
 
 	OrganizationFields() models.OrganizationFields
 	HasOrganization() bool
@@ -84,12 +110,22 @@ type Reader interface {
 	MinRecommendedCLIVersion() string
 
 	AsyncTimeout() uint
+	KeepAliveTimeout() uint
+	MaxIdleConnections() uint
 	Trace() string
 
 	ColorEnabled() string
 
 	Locale() string
 
+	CredentialStore() string
+
+	PluginSandbox() bool
+
+	LastLoginTarget(apiEndpoint string) LoginTarget
+
+	ContextNames() []string
+
 	PluginRepos() []models.PluginRepo
 }
 
@@ -110,13 +146,26 @@ type ReadWriter interface {
 	SetAccessToken(string)
 	SetSSHOAuthClient(string)
 	SetRefreshToken(string)
+	SetUAAGrantType(string)
+	SetUAAOAuthClient(string)
+	SetUAAOAuthClientSecret(string)
 	SetOrganizationFields(models.OrganizationFields)
 	SetSpaceFields(models.SpaceFields)
 	SetSSLDisabled(bool)
 	SetAsyncTimeout(uint)
+	SetKeepAliveTimeout(uint)
+	SetMaxIdleConnections(uint)
 	SetTrace(string)
 	SetColorEnabled(string)
 	SetLocale(string)
+	SetCredentialStore(string)
+	SetPluginSandbox(bool)
+	SetLastLoginTarget(apiEndpoint string, target LoginTarget)
+	SaveContext(string)
+	OverrideTarget()
+	UseContext(string) bool
+	RenameContextOrganization(guid string, name string) []string
+	RenameContextSpace(guid string, name string) []string
 	SetPluginRepo(models.PluginRepo)
 	UnSetPluginRepo(int)
 }
@@ -154,7 +203,15 @@ func (c *ConfigRepository) write(cb func()) {
 
 	cb()
 
-	err := c.persistor.Save(c.data)
+	data := c.data
+	if c.fileTarget != nil {
+		fileData := *c.data
+		fileData.OrganizationFields = c.fileTarget.org
+		fileData.SpaceFields = c.fileTarget.space
+		data = &fileData
+	}
+
+	err := c.persistor.Save(data)
 	if err != nil {
 		c.onError(err)
 	}
@@ -254,6 +311,27 @@ func (c *ConfigRepository) RefreshToken() (refreshToken string) {
 	return
 }
 
+func (c *ConfigRepository) UAAGrantType() (grantType string) {
+	c.read(func() {
+		grantType = c.data.UAAGrantType
+	})
+	return
+}
+
+func (c *ConfigRepository) UAAOAuthClient() (clientID string) {
+	c.read(func() {
+		clientID = c.data.UAAOAuthClient
+	})
+	return
+}
+
+func (c *ConfigRepository) UAAOAuthClientSecret() (clientSecret string) {
+	c.read(func() {
+		clientSecret = c.data.UAAOAuthClientSecret
+	})
+	return
+}
+
 func (c *ConfigRepository) OrganizationFields() (org models.OrganizationFields) {
 	c.read(func() {
 		org = c.data.OrganizationFields
@@ -284,7 +362,13 @@ func (c *ConfigRepository) UserGUID() (guid string) {
 
 func (c *ConfigRepository) Username() (name string) {
 	c.read(func() {
-		name = NewTokenInfo(c.data.AccessToken).Username
+		tokenInfo := NewTokenInfo(c.data.AccessToken)
+		name = tokenInfo.Username
+		if name == "" {
+			// tokens from the client credentials grant belong to a client
+			// rather than a user
+			name = tokenInfo.ClientID
+		}
 	})
 	return
 }
@@ -374,6 +458,20 @@ func (c *ConfigRepository) AsyncTimeout() (timeout uint) {
 	return
 }
 
+func (c *ConfigRepository) KeepAliveTimeout() (timeout uint) {
+	c.read(func() {
+		timeout = c.data.KeepAliveTimeout
+	})
+	return
+}
+
+func (c *ConfigRepository) MaxIdleConnections() (connections uint) {
+	c.read(func() {
+		connections = c.data.MaxIdleConnections
+	})
+	return
+}
+
 func (c *ConfigRepository) Trace() (trace string) {
 	c.read(func() {
 		trace = c.data.Trace
@@ -395,6 +493,42 @@ func (c *ConfigRepository) Locale() (locale string) {
 	return
 }
 
+func (c *ConfigRepository) CredentialStore() (backend string) {
+	c.read(func() {
+		backend = c.data.CredentialStore
+	})
+	return
+}
+
+// PluginSandbox is whether plugins run in the sandbox, with a config of their
+// own and a token derived from the user's.
+func (c *ConfigRepository) PluginSandbox() (sandbox bool) {
+	c.read(func() {
+		sandbox = c.data.PluginSandbox
+	})
+	return
+}
+
+// LastLoginTarget returns the org and space last targeted when logging in to
+// the API at apiEndpoint.
+func (c *ConfigRepository) LastLoginTarget(apiEndpoint string) (target LoginTarget) {
+	c.read(func() {
+		target = c.data.LoginTargets[apiEndpoint]
+	})
+	return
+}
+
+// ContextNames returns the names of the saved contexts, sorted.
+func (c *ConfigRepository) ContextNames() (names []string) {
+	c.read(func() {
+		for name := range c.data.Contexts {
+			names = append(names, name)
+		}
+	})
+	sort.Strings(names)
+	return
+}
+
 func (c *ConfigRepository) PluginRepos() (repos []models.PluginRepo) {
 	c.read(func() {
 		repos = c.data.PluginRepos
@@ -408,11 +542,33 @@ func (c *ConfigRepository) ClearSession() {
 	c.write(func() {
 		c.data.AccessToken = ""
 		c.data.RefreshToken = ""
+		c.data.UAAGrantType = ""
+		c.data.UAAOAuthClient = ""
+		c.data.UAAOAuthClientSecret = ""
 		c.data.OrganizationFields = models.OrganizationFields{}
 		c.data.SpaceFields = models.SpaceFields{}
+		if c.fileTarget != nil {
+			c.fileTarget = &targetFields{}
+		}
 	})
 }
 
+// OverrideTarget keeps the org and space targeted in the config file as they
+// are, so that targeting another org or space lasts only as long as this
+// repository.
+func (c *ConfigRepository) OverrideTarget() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.init()
+
+	if c.fileTarget == nil {
+		c.fileTarget = &targetFields{
+			org:   c.data.OrganizationFields,
+			space: c.data.SpaceFields,
+		}
+	}
+}
+
 func (c *ConfigRepository) SetAPIEndpoint(endpoint string) {
 	c.write(func() {
 		c.data.Target = endpoint
@@ -485,6 +641,24 @@ func (c *ConfigRepository) SetRefreshToken(token string) {
 	})
 }
 
+func (c *ConfigRepository) SetUAAGrantType(grantType string) {
+	c.write(func() {
+		c.data.UAAGrantType = grantType
+	})
+}
+
+func (c *ConfigRepository) SetUAAOAuthClient(clientID string) {
+	c.write(func() {
+		c.data.UAAOAuthClient = clientID
+	})
+}
+
+func (c *ConfigRepository) SetUAAOAuthClientSecret(clientSecret string) {
+	c.write(func() {
+		c.data.UAAOAuthClientSecret = clientSecret
+	})
+}
+
 func (c *ConfigRepository) SetOrganizationFields(org models.OrganizationFields) {
 	c.write(func() {
 		c.data.OrganizationFields = org
@@ -509,6 +683,18 @@ func (c *ConfigRepository) SetAsyncTimeout(timeout uint) {
 	})
 }
 
+func (c *ConfigRepository) SetKeepAliveTimeout(timeout uint) {
+	c.write(func() {
+		c.data.KeepAliveTimeout = timeout
+	})
+}
+
+func (c *ConfigRepository) SetMaxIdleConnections(connections uint) {
+	c.write(func() {
+		c.data.MaxIdleConnections = connections
+	})
+}
+
 func (c *ConfigRepository) SetTrace(value string) {
 	c.write(func() {
 		c.data.Trace = value
@@ -527,6 +713,91 @@ func (c *ConfigRepository) SetLocale(locale string) {
 	})
 }
 
+func (c *ConfigRepository) SetCredentialStore(backend string) {
+	c.write(func() {
+		c.data.CredentialStore = backend
+	})
+}
+
+func (c *ConfigRepository) SetPluginSandbox(sandbox bool) {
+	c.write(func() {
+		c.data.PluginSandbox = sandbox
+	})
+}
+
+func (c *ConfigRepository) SetLastLoginTarget(apiEndpoint string, target LoginTarget) {
+	c.write(func() {
+		if c.data.LoginTargets == nil {
+			c.data.LoginTargets = map[string]LoginTarget{}
+		}
+		c.data.LoginTargets[apiEndpoint] = target
+	})
+}
+
+// SaveContext saves the current target as the context called name,
+// replacing any context with that name.
+func (c *ConfigRepository) SaveContext(name string) {
+	c.write(func() {
+		if c.data.Contexts == nil {
+			c.data.Contexts = map[string]*ContextData{}
+		}
+		context := c.data.TargetData()
+		c.data.Contexts[name] = &context
+	})
+}
+
+// UseContext makes the context called name the current target. It returns
+// false when there is no such context.
+func (c *ConfigRepository) UseContext(name string) (found bool) {
+	c.read(func() {
+		_, found = c.data.Contexts[name]
+	})
+	if !found {
+		return false
+	}
+
+	c.write(func() {
+		c.data.SetTargetData(*c.data.Contexts[name])
+		if c.fileTarget != nil {
+			c.fileTarget = &targetFields{
+				org:   c.data.OrganizationFields,
+				space: c.data.SpaceFields,
+			}
+		}
+	})
+	return true
+}
+
+// RenameContextOrganization gives the org with the given guid its new name in
+// every saved context that targets it, and returns the names of those
+// contexts, sorted.
+func (c *ConfigRepository) RenameContextOrganization(guid string, name string) (renamed []string) {
+	c.write(func() {
+		for contextName, context := range c.data.Contexts {
+			if guid != "" && context.OrganizationFields.GUID == guid {
+				context.OrganizationFields.Name = name
+				renamed = append(renamed, contextName)
+			}
+		}
+	})
+	sort.Strings(renamed)
+	return
+}
+
+// RenameContextSpace is RenameContextOrganization for spaces.
+func (c *ConfigRepository) RenameContextSpace(guid string, name string) (renamed []string) {
+	c.write(func() {
+		for contextName, context := range c.data.Contexts {
+			if guid != "" && context.SpaceFields.GUID == guid {
+				context.SpaceFields.Name = name
+				renamed = append(renamed, contextName)
+			}
+		}
+	})
+	sort.Strings(renamed)
+	return
+}
+
 func (c *ConfigRepository) SetPluginRepo(repo models.PluginRepo) {
 	c.write(func() {
 		c.data.PluginRepos = append(c.data.PluginRepos, repo)
@@ -0,0 +1,74 @@
+package coreconfig
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf/configuration"
+)
+
+// ContextPersistor makes a named context look like the current target of the
+// config, for commands run with --context. Changes to the target, such as a
+// refreshed token, are saved to the context, and the current target in the
+// config file is left alone.
+type ContextPersistor struct {
+	persistor configuration.Persistor
+	name      string
+
+	// fileTarget is the current target in the config file
+	fileTarget ContextData
+}
+
+func NewContextPersistor(persistor configuration.Persistor, name string) *ContextPersistor {
+	return &ContextPersistor{
+		persistor: persistor,
+		name:      name,
+	}
+}
+
+func (p *ContextPersistor) Exists() bool {
+	return p.persistor.Exists()
+}
+
+func (p *ContextPersistor) Delete() {
+	p.persistor.Delete()
+}
+
+func (p *ContextPersistor) Load(data configuration.DataInterface) error {
+	err := p.persistor.Load(data)
+	if err != nil {
+		return err
+	}
+
+	configData, ok := data.(*Data)
+	if !ok {
+		return nil
+	}
+
+	context, ok := configData.Contexts[p.name]
+	if !ok {
+		return fmt.Errorf("context %s not found; save it first with target-save", p.name)
+	}
+
+	p.fileTarget = configData.TargetData()
+	configData.SetTargetData(*context)
+	return nil
+}
+
+func (p *ContextPersistor) Save(data configuration.DataInterface) error {
+	configData, ok := data.(*Data)
+	if !ok {
+		return p.persistor.Save(data)
+	}
+
+	fileData := *configData
+	fileData.copyContexts()
+	if fileData.Contexts == nil {
+		fileData.Contexts = map[string]*ContextData{}
+	}
+
+	context := configData.TargetData()
+	fileData.Contexts[p.name] = &context
+	fileData.SetTargetData(p.fileTarget)
+
+	return p.persistor.Save(&fileData)
+}
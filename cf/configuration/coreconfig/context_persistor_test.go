@@ -0,0 +1,71 @@
+package coreconfig_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/configuration"
+	"code.cloudfoundry.org/cli/cf/configuration/configurationfakes"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContextPersistor", func() {
+	var (
+		diskPersistor *configurationfakes.FakePersistor
+		persistor     *coreconfig.ContextPersistor
+		savedData     *coreconfig.Data
+	)
+
+	BeforeEach(func() {
+		diskPersistor = new(configurationfakes.FakePersistor)
+		diskPersistor.LoadStub = func(data configuration.DataInterface) error {
+			configData := data.(*coreconfig.Data)
+			configData.Target = "https://api.dev.example.com"
+			configData.AccessToken = "bearer dev-token"
+			configData.Locale = "fr-FR"
+			configData.Contexts = map[string]*coreconfig.ContextData{
+				"prod": {
+					Target:      "https://api.prod.example.com",
+					AccessToken: "bearer prod-token",
+				},
+			}
+			return nil
+		}
+		diskPersistor.SaveStub = func(data configuration.DataInterface) error {
+			saved := *data.(*coreconfig.Data)
+			savedData = &saved
+			return nil
+		}
+
+		persistor = coreconfig.NewContextPersistor(diskPersistor, "prod")
+	})
+
+	It("loads the context as the current target", func() {
+		data := coreconfig.NewData()
+		Expect(persistor.Load(data)).To(Succeed())
+
+		Expect(data.Target).To(Equal("https://api.prod.example.com"))
+		Expect(data.AccessToken).To(Equal("bearer prod-token"))
+		Expect(data.Locale).To(Equal("fr-FR"))
+	})
+
+	It("returns an error when the context does not exist", func() {
+		persistor = coreconfig.NewContextPersistor(diskPersistor, "staging")
+
+		err := persistor.Load(coreconfig.NewData())
+		Expect(err).To(MatchError("context staging not found; save it first with target-save"))
+	})
+
+	It("saves changes to the target in the context, leaving the current target alone", func() {
+		data := coreconfig.NewData()
+		Expect(persistor.Load(data)).To(Succeed())
+
+		data.AccessToken = "bearer refreshed-prod-token"
+		Expect(persistor.Save(data)).To(Succeed())
+
+		Expect(savedData.Target).To(Equal("https://api.dev.example.com"))
+		Expect(savedData.AccessToken).To(Equal("bearer dev-token"))
+		Expect(savedData.Contexts["prod"].AccessToken).To(Equal("bearer refreshed-prod-token"))
+		Expect(data.Contexts["prod"].AccessToken).To(Equal("bearer prod-token"))
+	})
+})
@@ -76,6 +76,24 @@ type FakeRepository struct {
 	refreshTokenReturns     struct {
 		result1 string
 	}
+	UAAGrantTypeStub        func() string
+	uAAGrantTypeMutex       sync.RWMutex
+	uAAGrantTypeArgsForCall []struct{}
+	uAAGrantTypeReturns     struct {
+		result1 string
+	}
+	UAAOAuthClientStub        func() string
+	uAAOAuthClientMutex       sync.RWMutex
+	uAAOAuthClientArgsForCall []struct{}
+	uAAOAuthClientReturns     struct {
+		result1 string
+	}
+	UAAOAuthClientSecretStub        func() string
+	uAAOAuthClientSecretMutex       sync.RWMutex
+	uAAOAuthClientSecretArgsForCall []struct{}
+	uAAOAuthClientSecretReturns     struct {
+		result1 string
+	}
 	OrganizationFieldsStub        func() models.OrganizationFields
 	organizationFieldsMutex       sync.RWMutex
 	organizationFieldsArgsForCall []struct{}
@@ -164,6 +182,18 @@ type FakeRepository struct {
 	asyncTimeoutReturns     struct {
 		result1 uint
 	}
+	KeepAliveTimeoutStub        func() uint
+	keepAliveTimeoutMutex       sync.RWMutex
+	keepAliveTimeoutArgsForCall []struct{}
+	keepAliveTimeoutReturns     struct {
+		result1 uint
+	}
+	MaxIdleConnectionsStub        func() uint
+	maxIdleConnectionsMutex       sync.RWMutex
+	maxIdleConnectionsArgsForCall []struct{}
+	maxIdleConnectionsReturns     struct {
+		result1 uint
+	}
 	TraceStub        func() string
 	traceMutex       sync.RWMutex
 	traceArgsForCall []struct{}
@@ -182,6 +212,32 @@ type FakeRepository struct {
 	localeReturns     struct {
 		result1 string
 	}
+	CredentialStoreStub        func() string
+	credentialStoreMutex       sync.RWMutex
+	credentialStoreArgsForCall []struct{}
+	credentialStoreReturns     struct {
+		result1 string
+	}
+	PluginSandboxStub        func() bool
+	pluginSandboxMutex       sync.RWMutex
+	pluginSandboxArgsForCall []struct{}
+	pluginSandboxReturns     struct {
+		result1 bool
+	}
+	LastLoginTargetStub        func(apiEndpoint string) coreconfig.LoginTarget
+	lastLoginTargetMutex       sync.RWMutex
+	lastLoginTargetArgsForCall []struct {
+		apiEndpoint string
+	}
+	lastLoginTargetReturns struct {
+		result1 coreconfig.LoginTarget
+	}
+	ContextNamesStub        func() []string
+	contextNamesMutex       sync.RWMutex
+	contextNamesArgsForCall []struct{}
+	contextNamesReturns     struct {
+		result1 []string
+	}
 	PluginReposStub        func() []models.PluginRepo
 	pluginReposMutex       sync.RWMutex
 	pluginReposArgsForCall []struct{}
@@ -251,6 +307,21 @@ type FakeRepository struct {
 	setRefreshTokenArgsForCall []struct {
 		arg1 string
 	}
+	SetUAAGrantTypeStub        func(string)
+	setUAAGrantTypeMutex       sync.RWMutex
+	setUAAGrantTypeArgsForCall []struct {
+		arg1 string
+	}
+	SetUAAOAuthClientStub        func(string)
+	setUAAOAuthClientMutex       sync.RWMutex
+	setUAAOAuthClientArgsForCall []struct {
+		arg1 string
+	}
+	SetUAAOAuthClientSecretStub        func(string)
+	setUAAOAuthClientSecretMutex       sync.RWMutex
+	setUAAOAuthClientSecretArgsForCall []struct {
+		arg1 string
+	}
 	SetOrganizationFieldsStub        func(models.OrganizationFields)
 	setOrganizationFieldsMutex       sync.RWMutex
 	setOrganizationFieldsArgsForCall []struct {
@@ -271,6 +342,16 @@ type FakeRepository struct {
 	setAsyncTimeoutArgsForCall []struct {
 		arg1 uint
 	}
+	SetKeepAliveTimeoutStub        func(uint)
+	setKeepAliveTimeoutMutex       sync.RWMutex
+	setKeepAliveTimeoutArgsForCall []struct {
+		arg1 uint
+	}
+	SetMaxIdleConnectionsStub        func(uint)
+	setMaxIdleConnectionsMutex       sync.RWMutex
+	setMaxIdleConnectionsArgsForCall []struct {
+		arg1 uint
+	}
 	SetTraceStub        func(string)
 	setTraceMutex       sync.RWMutex
 	setTraceArgsForCall []struct {
@@ -286,6 +367,56 @@ type FakeRepository struct {
 	setLocaleArgsForCall []struct {
 		arg1 string
 	}
+	SetCredentialStoreStub        func(string)
+	setCredentialStoreMutex       sync.RWMutex
+	setCredentialStoreArgsForCall []struct {
+		arg1 string
+	}
+	SetPluginSandboxStub        func(bool)
+	setPluginSandboxMutex       sync.RWMutex
+	setPluginSandboxArgsForCall []struct {
+		arg1 bool
+	}
+	SetLastLoginTargetStub        func(apiEndpoint string, target coreconfig.LoginTarget)
+	setLastLoginTargetMutex       sync.RWMutex
+	setLastLoginTargetArgsForCall []struct {
+		apiEndpoint string
+		target      coreconfig.LoginTarget
+	}
+	SaveContextStub        func(string)
+	saveContextMutex       sync.RWMutex
+	saveContextArgsForCall []struct {
+		arg1 string
+	}
+	OverrideTargetStub        func()
+	overrideTargetMutex       sync.RWMutex
+	overrideTargetArgsForCall []struct{}
+	UseContextStub            func(string) bool
+	useContextMutex           sync.RWMutex
+	useContextArgsForCall     []struct {
+		arg1 string
+	}
+	useContextReturns struct {
+		result1 bool
+	}
+	RenameContextOrganizationStub        func(guid string, name string) []string
+	renameContextOrganizationMutex       sync.RWMutex
+	renameContextOrganizationArgsForCall []struct {
+		guid string
+		name string
+	}
+	renameContextOrganizationReturns struct {
+		result1 []string
+	}
+	RenameContextSpaceStub        func(guid string, name string) []string
+	renameContextSpaceMutex       sync.RWMutex
+	renameContextSpaceArgsForCall []struct {
+		guid string
+		name string
+	}
+	renameContextSpaceReturns struct {
+		result1 []string
+	}
 	SetPluginRepoStub        func(models.PluginRepo)
 	setPluginRepoMutex       sync.RWMutex
 	setPluginRepoArgsForCall []struct {
@@ -578,6 +709,81 @@ func (fake *FakeRepository) RefreshTokenReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeRepository) UAAGrantType() string {
+	fake.uAAGrantTypeMutex.Lock()
+	fake.uAAGrantTypeArgsForCall = append(fake.uAAGrantTypeArgsForCall, struct{}{})
+	fake.recordInvocation("UAAGrantType", []interface{}{})
+	fake.uAAGrantTypeMutex.Unlock()
+	if fake.UAAGrantTypeStub != nil {
+		return fake.UAAGrantTypeStub()
+	} else {
+		return fake.uAAGrantTypeReturns.result1
+	}
+}
+
+func (fake *FakeRepository) UAAGrantTypeCallCount() int {
+	fake.uAAGrantTypeMutex.RLock()
+	defer fake.uAAGrantTypeMutex.RUnlock()
+	return len(fake.uAAGrantTypeArgsForCall)
+}
+
+func (fake *FakeRepository) UAAGrantTypeReturns(result1 string) {
+	fake.UAAGrantTypeStub = nil
+	fake.uAAGrantTypeReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) UAAOAuthClient() string {
+	fake.uAAOAuthClientMutex.Lock()
+	fake.uAAOAuthClientArgsForCall = append(fake.uAAOAuthClientArgsForCall, struct{}{})
+	fake.recordInvocation("UAAOAuthClient", []interface{}{})
+	fake.uAAOAuthClientMutex.Unlock()
+	if fake.UAAOAuthClientStub != nil {
+		return fake.UAAOAuthClientStub()
+	} else {
+		return fake.uAAOAuthClientReturns.result1
+	}
+}
+
+func (fake *FakeRepository) UAAOAuthClientCallCount() int {
+	fake.uAAOAuthClientMutex.RLock()
+	defer fake.uAAOAuthClientMutex.RUnlock()
+	return len(fake.uAAOAuthClientArgsForCall)
+}
+
+func (fake *FakeRepository) UAAOAuthClientReturns(result1 string) {
+	fake.UAAOAuthClientStub = nil
+	fake.uAAOAuthClientReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) UAAOAuthClientSecret() string {
+	fake.uAAOAuthClientSecretMutex.Lock()
+	fake.uAAOAuthClientSecretArgsForCall = append(fake.uAAOAuthClientSecretArgsForCall, struct{}{})
+	fake.recordInvocation("UAAOAuthClientSecret", []interface{}{})
+	fake.uAAOAuthClientSecretMutex.Unlock()
+	if fake.UAAOAuthClientSecretStub != nil {
+		return fake.UAAOAuthClientSecretStub()
+	} else {
+		return fake.uAAOAuthClientSecretReturns.result1
+	}
+}
+
+func (fake *FakeRepository) UAAOAuthClientSecretCallCount() int {
+	fake.uAAOAuthClientSecretMutex.RLock()
+	defer fake.uAAOAuthClientSecretMutex.RUnlock()
+	return len(fake.uAAOAuthClientSecretArgsForCall)
+}
+
+func (fake *FakeRepository) UAAOAuthClientSecretReturns(result1 string) {
+	fake.UAAOAuthClientSecretStub = nil
+	fake.uAAOAuthClientSecretReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeRepository) OrganizationFields() models.OrganizationFields {
 	fake.organizationFieldsMutex.Lock()
 	fake.organizationFieldsArgsForCall = append(fake.organizationFieldsArgsForCall, struct{}{})
@@ -944,6 +1150,56 @@ func (fake *FakeRepository) AsyncTimeoutReturns(result1 uint) {
 	}{result1}
 }
 
+func (fake *FakeRepository) KeepAliveTimeout() uint {
+	fake.keepAliveTimeoutMutex.Lock()
+	fake.keepAliveTimeoutArgsForCall = append(fake.keepAliveTimeoutArgsForCall, struct{}{})
+	fake.recordInvocation("KeepAliveTimeout", []interface{}{})
+	fake.keepAliveTimeoutMutex.Unlock()
+	if fake.KeepAliveTimeoutStub != nil {
+		return fake.KeepAliveTimeoutStub()
+	} else {
+		return fake.keepAliveTimeoutReturns.result1
+	}
+}
+
+func (fake *FakeRepository) KeepAliveTimeoutCallCount() int {
+	fake.keepAliveTimeoutMutex.RLock()
+	defer fake.keepAliveTimeoutMutex.RUnlock()
+	return len(fake.keepAliveTimeoutArgsForCall)
+}
+
+func (fake *FakeRepository) KeepAliveTimeoutReturns(result1 uint) {
+	fake.KeepAliveTimeoutStub = nil
+	fake.keepAliveTimeoutReturns = struct {
+		result1 uint
+	}{result1}
+}
+
+func (fake *FakeRepository) MaxIdleConnections() uint {
+	fake.maxIdleConnectionsMutex.Lock()
+	fake.maxIdleConnectionsArgsForCall = append(fake.maxIdleConnectionsArgsForCall, struct{}{})
+	fake.recordInvocation("MaxIdleConnections", []interface{}{})
+	fake.maxIdleConnectionsMutex.Unlock()
+	if fake.MaxIdleConnectionsStub != nil {
+		return fake.MaxIdleConnectionsStub()
+	} else {
+		return fake.maxIdleConnectionsReturns.result1
+	}
+}
+
+func (fake *FakeRepository) MaxIdleConnectionsCallCount() int {
+	fake.maxIdleConnectionsMutex.RLock()
+	defer fake.maxIdleConnectionsMutex.RUnlock()
+	return len(fake.maxIdleConnectionsArgsForCall)
+}
+
+func (fake *FakeRepository) MaxIdleConnectionsReturns(result1 uint) {
+	fake.MaxIdleConnectionsStub = nil
+	fake.maxIdleConnectionsReturns = struct {
+		result1 uint
+	}{result1}
+}
+
 func (fake *FakeRepository) Trace() string {
 	fake.traceMutex.Lock()
 	fake.traceArgsForCall = append(fake.traceArgsForCall, struct{}{})
@@ -1019,6 +1275,114 @@ func (fake *FakeRepository) LocaleReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeRepository) CredentialStore() string {
+	fake.credentialStoreMutex.Lock()
+	fake.credentialStoreArgsForCall = append(fake.credentialStoreArgsForCall, struct{}{})
+	fake.recordInvocation("CredentialStore", []interface{}{})
+	fake.credentialStoreMutex.Unlock()
+	if fake.CredentialStoreStub != nil {
+		return fake.CredentialStoreStub()
+	} else {
+		return fake.credentialStoreReturns.result1
+	}
+}
+
+func (fake *FakeRepository) CredentialStoreCallCount() int {
+	fake.credentialStoreMutex.RLock()
+	defer fake.credentialStoreMutex.RUnlock()
+	return len(fake.credentialStoreArgsForCall)
+}
+
+func (fake *FakeRepository) CredentialStoreReturns(result1 string) {
+	fake.CredentialStoreStub = nil
+	fake.credentialStoreReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) PluginSandbox() bool {
+	fake.pluginSandboxMutex.Lock()
+	fake.pluginSandboxArgsForCall = append(fake.pluginSandboxArgsForCall, struct{}{})
+	fake.recordInvocation("PluginSandbox", []interface{}{})
+	fake.pluginSandboxMutex.Unlock()
+	if fake.PluginSandboxStub != nil {
+		return fake.PluginSandboxStub()
+	} else {
+		return fake.pluginSandboxReturns.result1
+	}
+}
+
+func (fake *FakeRepository) PluginSandboxCallCount() int {
+	fake.pluginSandboxMutex.RLock()
+	defer fake.pluginSandboxMutex.RUnlock()
+	return len(fake.pluginSandboxArgsForCall)
+}
+
+func (fake *FakeRepository) PluginSandboxReturns(result1 bool) {
+	fake.PluginSandboxStub = nil
+	fake.pluginSandboxReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeRepository) LastLoginTarget(apiEndpoint string) coreconfig.LoginTarget {
+	fake.lastLoginTargetMutex.Lock()
+	fake.lastLoginTargetArgsForCall = append(fake.lastLoginTargetArgsForCall, struct {
+		apiEndpoint string
+	}{apiEndpoint})
+	fake.recordInvocation("LastLoginTarget", []interface{}{apiEndpoint})
+	fake.lastLoginTargetMutex.Unlock()
+	if fake.LastLoginTargetStub != nil {
+		return fake.LastLoginTargetStub(apiEndpoint)
+	} else {
+		return fake.lastLoginTargetReturns.result1
+	}
+}
+
+func (fake *FakeRepository) LastLoginTargetCallCount() int {
+	fake.lastLoginTargetMutex.RLock()
+	defer fake.lastLoginTargetMutex.RUnlock()
+	return len(fake.lastLoginTargetArgsForCall)
+}
+
+func (fake *FakeRepository) LastLoginTargetArgsForCall(i int) string {
+	fake.lastLoginTargetMutex.RLock()
+	defer fake.lastLoginTargetMutex.RUnlock()
+	return fake.lastLoginTargetArgsForCall[i].apiEndpoint
+}
+
+func (fake *FakeRepository) LastLoginTargetReturns(result1 coreconfig.LoginTarget) {
+	fake.LastLoginTargetStub = nil
+	fake.lastLoginTargetReturns = struct {
+		result1 coreconfig.LoginTarget
+	}{result1}
+}
+
+func (fake *FakeRepository) ContextNames() []string {
+	fake.contextNamesMutex.Lock()
+	fake.contextNamesArgsForCall = append(fake.contextNamesArgsForCall, struct{}{})
+	fake.recordInvocation("ContextNames", []interface{}{})
+	fake.contextNamesMutex.Unlock()
+	if fake.ContextNamesStub != nil {
+		return fake.ContextNamesStub()
+	} else {
+		return fake.contextNamesReturns.result1
+	}
+}
+
+func (fake *FakeRepository) ContextNamesCallCount() int {
+	fake.contextNamesMutex.RLock()
+	defer fake.contextNamesMutex.RUnlock()
+	return len(fake.contextNamesArgsForCall)
+}
+
+func (fake *FakeRepository) ContextNamesReturns(result1 []string) {
+	fake.ContextNamesStub = nil
+	fake.contextNamesReturns = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *FakeRepository) PluginRepos() []models.PluginRepo {
 	fake.pluginReposMutex.Lock()
 	fake.pluginReposArgsForCall = append(fake.pluginReposArgsForCall, struct{}{})
@@ -1348,6 +1712,78 @@ func (fake *FakeRepository) SetRefreshTokenArgsForCall(i int) string {
 	return fake.setRefreshTokenArgsForCall[i].arg1
 }
 
+func (fake *FakeRepository) SetUAAGrantType(arg1 string) {
+	fake.setUAAGrantTypeMutex.Lock()
+	fake.setUAAGrantTypeArgsForCall = append(fake.setUAAGrantTypeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SetUAAGrantType", []interface{}{arg1})
+	fake.setUAAGrantTypeMutex.Unlock()
+	if fake.SetUAAGrantTypeStub != nil {
+		fake.SetUAAGrantTypeStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetUAAGrantTypeCallCount() int {
+	fake.setUAAGrantTypeMutex.RLock()
+	defer fake.setUAAGrantTypeMutex.RUnlock()
+	return len(fake.setUAAGrantTypeArgsForCall)
+}
+
+func (fake *FakeRepository) SetUAAGrantTypeArgsForCall(i int) string {
+	fake.setUAAGrantTypeMutex.RLock()
+	defer fake.setUAAGrantTypeMutex.RUnlock()
+	return fake.setUAAGrantTypeArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetUAAOAuthClient(arg1 string) {
+	fake.setUAAOAuthClientMutex.Lock()
+	fake.setUAAOAuthClientArgsForCall = append(fake.setUAAOAuthClientArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SetUAAOAuthClient", []interface{}{arg1})
+	fake.setUAAOAuthClientMutex.Unlock()
+	if fake.SetUAAOAuthClientStub != nil {
+		fake.SetUAAOAuthClientStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetUAAOAuthClientCallCount() int {
+	fake.setUAAOAuthClientMutex.RLock()
+	defer fake.setUAAOAuthClientMutex.RUnlock()
+	return len(fake.setUAAOAuthClientArgsForCall)
+}
+
+func (fake *FakeRepository) SetUAAOAuthClientArgsForCall(i int) string {
+	fake.setUAAOAuthClientMutex.RLock()
+	defer fake.setUAAOAuthClientMutex.RUnlock()
+	return fake.setUAAOAuthClientArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetUAAOAuthClientSecret(arg1 string) {
+	fake.setUAAOAuthClientSecretMutex.Lock()
+	fake.setUAAOAuthClientSecretArgsForCall = append(fake.setUAAOAuthClientSecretArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SetUAAOAuthClientSecret", []interface{}{arg1})
+	fake.setUAAOAuthClientSecretMutex.Unlock()
+	if fake.SetUAAOAuthClientSecretStub != nil {
+		fake.SetUAAOAuthClientSecretStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetUAAOAuthClientSecretCallCount() int {
+	fake.setUAAOAuthClientSecretMutex.RLock()
+	defer fake.setUAAOAuthClientSecretMutex.RUnlock()
+	return len(fake.setUAAOAuthClientSecretArgsForCall)
+}
+
+func (fake *FakeRepository) SetUAAOAuthClientSecretArgsForCall(i int) string {
+	fake.setUAAOAuthClientSecretMutex.RLock()
+	defer fake.setUAAOAuthClientSecretMutex.RUnlock()
+	return fake.setUAAOAuthClientSecretArgsForCall[i].arg1
+}
+
 func (fake *FakeRepository) SetOrganizationFields(arg1 models.OrganizationFields) {
 	fake.setOrganizationFieldsMutex.Lock()
 	fake.setOrganizationFieldsArgsForCall = append(fake.setOrganizationFieldsArgsForCall, struct {
@@ -1444,6 +1880,54 @@ func (fake *FakeRepository) SetAsyncTimeoutArgsForCall(i int) uint {
 	return fake.setAsyncTimeoutArgsForCall[i].arg1
 }
 
+func (fake *FakeRepository) SetKeepAliveTimeout(arg1 uint) {
+	fake.setKeepAliveTimeoutMutex.Lock()
+	fake.setKeepAliveTimeoutArgsForCall = append(fake.setKeepAliveTimeoutArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	fake.recordInvocation("SetKeepAliveTimeout", []interface{}{arg1})
+	fake.setKeepAliveTimeoutMutex.Unlock()
+	if fake.SetKeepAliveTimeoutStub != nil {
+		fake.SetKeepAliveTimeoutStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetKeepAliveTimeoutCallCount() int {
+	fake.setKeepAliveTimeoutMutex.RLock()
+	defer fake.setKeepAliveTimeoutMutex.RUnlock()
+	return len(fake.setKeepAliveTimeoutArgsForCall)
+}
+
+func (fake *FakeRepository) SetKeepAliveTimeoutArgsForCall(i int) uint {
+	fake.setKeepAliveTimeoutMutex.RLock()
+	defer fake.setKeepAliveTimeoutMutex.RUnlock()
+	return fake.setKeepAliveTimeoutArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetMaxIdleConnections(arg1 uint) {
+	fake.setMaxIdleConnectionsMutex.Lock()
+	fake.setMaxIdleConnectionsArgsForCall = append(fake.setMaxIdleConnectionsArgsForCall, struct {
+		arg1 uint
+	}{arg1})
+	fake.recordInvocation("SetMaxIdleConnections", []interface{}{arg1})
+	fake.setMaxIdleConnectionsMutex.Unlock()
+	if fake.SetMaxIdleConnectionsStub != nil {
+		fake.SetMaxIdleConnectionsStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetMaxIdleConnectionsCallCount() int {
+	fake.setMaxIdleConnectionsMutex.RLock()
+	defer fake.setMaxIdleConnectionsMutex.RUnlock()
+	return len(fake.setMaxIdleConnectionsArgsForCall)
+}
+
+func (fake *FakeRepository) SetMaxIdleConnectionsArgsForCall(i int) uint {
+	fake.setMaxIdleConnectionsMutex.RLock()
+	defer fake.setMaxIdleConnectionsMutex.RUnlock()
+	return fake.setMaxIdleConnectionsArgsForCall[i].arg1
+}
+
 func (fake *FakeRepository) SetTrace(arg1 string) {
 	fake.setTraceMutex.Lock()
 	fake.setTraceArgsForCall = append(fake.setTraceArgsForCall, struct {
@@ -1516,6 +2000,220 @@ func (fake *FakeRepository) SetLocaleArgsForCall(i int) string {
 	return fake.setLocaleArgsForCall[i].arg1
 }
 
+func (fake *FakeRepository) SetCredentialStore(arg1 string) {
+	fake.setCredentialStoreMutex.Lock()
+	fake.setCredentialStoreArgsForCall = append(fake.setCredentialStoreArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SetCredentialStore", []interface{}{arg1})
+	fake.setCredentialStoreMutex.Unlock()
+	if fake.SetCredentialStoreStub != nil {
+		fake.SetCredentialStoreStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetCredentialStoreCallCount() int {
+	fake.setCredentialStoreMutex.RLock()
+	defer fake.setCredentialStoreMutex.RUnlock()
+	return len(fake.setCredentialStoreArgsForCall)
+}
+
+func (fake *FakeRepository) SetCredentialStoreArgsForCall(i int) string {
+	fake.setCredentialStoreMutex.RLock()
+	defer fake.setCredentialStoreMutex.RUnlock()
+	return fake.setCredentialStoreArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetPluginSandbox(arg1 bool) {
+	fake.setPluginSandboxMutex.Lock()
+	fake.setPluginSandboxArgsForCall = append(fake.setPluginSandboxArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	fake.recordInvocation("SetPluginSandbox", []interface{}{arg1})
+	fake.setPluginSandboxMutex.Unlock()
+	if fake.SetPluginSandboxStub != nil {
+		fake.SetPluginSandboxStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetPluginSandboxCallCount() int {
+	fake.setPluginSandboxMutex.RLock()
+	defer fake.setPluginSandboxMutex.RUnlock()
+	return len(fake.setPluginSandboxArgsForCall)
+}
+
+func (fake *FakeRepository) SetPluginSandboxArgsForCall(i int) bool {
+	fake.setPluginSandboxMutex.RLock()
+	defer fake.setPluginSandboxMutex.RUnlock()
+	return fake.setPluginSandboxArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetLastLoginTarget(apiEndpoint string, target coreconfig.LoginTarget) {
+	fake.setLastLoginTargetMutex.Lock()
+	fake.setLastLoginTargetArgsForCall = append(fake.setLastLoginTargetArgsForCall, struct {
+		apiEndpoint string
+		target      coreconfig.LoginTarget
+	}{apiEndpoint, target})
+	fake.recordInvocation("SetLastLoginTarget", []interface{}{apiEndpoint, target})
+	fake.setLastLoginTargetMutex.Unlock()
+	if fake.SetLastLoginTargetStub != nil {
+		fake.SetLastLoginTargetStub(apiEndpoint, target)
+	}
+}
+
+func (fake *FakeRepository) SetLastLoginTargetCallCount() int {
+	fake.setLastLoginTargetMutex.RLock()
+	defer fake.setLastLoginTargetMutex.RUnlock()
+	return len(fake.setLastLoginTargetArgsForCall)
+}
+
+func (fake *FakeRepository) SetLastLoginTargetArgsForCall(i int) (string, coreconfig.LoginTarget) {
+	fake.setLastLoginTargetMutex.RLock()
+	defer fake.setLastLoginTargetMutex.RUnlock()
+	return fake.setLastLoginTargetArgsForCall[i].apiEndpoint, fake.setLastLoginTargetArgsForCall[i].target
+}
+
+func (fake *FakeRepository) SaveContext(arg1 string) {
+	fake.saveContextMutex.Lock()
+	fake.saveContextArgsForCall = append(fake.saveContextArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SaveContext", []interface{}{arg1})
+	fake.saveContextMutex.Unlock()
+	if fake.SaveContextStub != nil {
+		fake.SaveContextStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SaveContextCallCount() int {
+	fake.saveContextMutex.RLock()
+	defer fake.saveContextMutex.RUnlock()
+	return len(fake.saveContextArgsForCall)
+}
+
+func (fake *FakeRepository) SaveContextArgsForCall(i int) string {
+	fake.saveContextMutex.RLock()
+	defer fake.saveContextMutex.RUnlock()
+	return fake.saveContextArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) OverrideTarget() {
+	fake.overrideTargetMutex.Lock()
+	fake.overrideTargetArgsForCall = append(fake.overrideTargetArgsForCall, struct{}{})
+	fake.recordInvocation("OverrideTarget", []interface{}{})
+	fake.overrideTargetMutex.Unlock()
+	if fake.OverrideTargetStub != nil {
+		fake.OverrideTargetStub()
+	}
+}
+
+func (fake *FakeRepository) OverrideTargetCallCount() int {
+	fake.overrideTargetMutex.RLock()
+	defer fake.overrideTargetMutex.RUnlock()
+	return len(fake.overrideTargetArgsForCall)
+}
+
+func (fake *FakeRepository) UseContext(arg1 string) bool {
+	fake.useContextMutex.Lock()
+	fake.useContextArgsForCall = append(fake.useContextArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("UseContext", []interface{}{arg1})
+	fake.useContextMutex.Unlock()
+	if fake.UseContextStub != nil {
+		return fake.UseContextStub(arg1)
+	} else {
+		return fake.useContextReturns.result1
+	}
+}
+
+func (fake *FakeRepository) UseContextCallCount() int {
+	fake.useContextMutex.RLock()
+	defer fake.useContextMutex.RUnlock()
+	return len(fake.useContextArgsForCall)
+}
+
+func (fake *FakeRepository) UseContextArgsForCall(i int) string {
+	fake.useContextMutex.RLock()
+	defer fake.useContextMutex.RUnlock()
+	return fake.useContextArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) UseContextReturns(result1 bool) {
+	fake.UseContextStub = nil
+	fake.useContextReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeRepository) RenameContextOrganization(guid string, name string) []string {
+	fake.renameContextOrganizationMutex.Lock()
+	fake.renameContextOrganizationArgsForCall = append(fake.renameContextOrganizationArgsForCall, struct {
+		guid string
+		name string
+	}{guid, name})
+	fake.recordInvocation("RenameContextOrganization", []interface{}{guid, name})
+	fake.renameContextOrganizationMutex.Unlock()
+	if fake.RenameContextOrganizationStub != nil {
+		return fake.RenameContextOrganizationStub(guid, name)
+	} else {
+		return fake.renameContextOrganizationReturns.result1
+	}
+}
+
+func (fake *FakeRepository) RenameContextOrganizationCallCount() int {
+	fake.renameContextOrganizationMutex.RLock()
+	defer fake.renameContextOrganizationMutex.RUnlock()
+	return len(fake.renameContextOrganizationArgsForCall)
+}
+
+func (fake *FakeRepository) RenameContextOrganizationArgsForCall(i int) (string, string) {
+	fake.renameContextOrganizationMutex.RLock()
+	defer fake.renameContextOrganizationMutex.RUnlock()
+	return fake.renameContextOrganizationArgsForCall[i].guid, fake.renameContextOrganizationArgsForCall[i].name
+}
+
+func (fake *FakeRepository) RenameContextOrganizationReturns(result1 []string) {
+	fake.RenameContextOrganizationStub = nil
+	fake.renameContextOrganizationReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeRepository) RenameContextSpace(guid string, name string) []string {
+	fake.renameContextSpaceMutex.Lock()
+	fake.renameContextSpaceArgsForCall = append(fake.renameContextSpaceArgsForCall, struct {
+		guid string
+		name string
+	}{guid, name})
+	fake.recordInvocation("RenameContextSpace", []interface{}{guid, name})
+	fake.renameContextSpaceMutex.Unlock()
+	if fake.RenameContextSpaceStub != nil {
+		return fake.RenameContextSpaceStub(guid, name)
+	} else {
+		return fake.renameContextSpaceReturns.result1
+	}
+}
+
+func (fake *FakeRepository) RenameContextSpaceCallCount() int {
+	fake.renameContextSpaceMutex.RLock()
+	defer fake.renameContextSpaceMutex.RUnlock()
+	return len(fake.renameContextSpaceArgsForCall)
+}
+
+func (fake *FakeRepository) RenameContextSpaceArgsForCall(i int) (string, string) {
+	fake.renameContextSpaceMutex.RLock()
+	defer fake.renameContextSpaceMutex.RUnlock()
+	return fake.renameContextSpaceArgsForCall[i].guid, fake.renameContextSpaceArgsForCall[i].name
+}
+
+func (fake *FakeRepository) RenameContextSpaceReturns(result1 []string) {
+	fake.RenameContextSpaceStub = nil
+	fake.renameContextSpaceReturns = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *FakeRepository) SetPluginRepo(arg1 models.PluginRepo) {
 	fake.setPluginRepoMutex.Lock()
 	fake.setPluginRepoArgsForCall = append(fake.setPluginRepoArgsForCall, struct {
@@ -1605,6 +2303,12 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.sSHOAuthClientMutex.RUnlock()
 	fake.refreshTokenMutex.RLock()
 	defer fake.refreshTokenMutex.RUnlock()
+	fake.uAAGrantTypeMutex.RLock()
+	defer fake.uAAGrantTypeMutex.RUnlock()
+	fake.uAAOAuthClientMutex.RLock()
+	defer fake.uAAOAuthClientMutex.RUnlock()
+	fake.uAAOAuthClientSecretMutex.RLock()
+	defer fake.uAAOAuthClientSecretMutex.RUnlock()
 	fake.organizationFieldsMutex.RLock()
 	defer fake.organizationFieldsMutex.RUnlock()
 	fake.hasOrganizationMutex.RLock()
@@ -1633,12 +2337,24 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.minRecommendedCLIVersionMutex.RUnlock()
 	fake.asyncTimeoutMutex.RLock()
 	defer fake.asyncTimeoutMutex.RUnlock()
+	fake.keepAliveTimeoutMutex.RLock()
+	defer fake.keepAliveTimeoutMutex.RUnlock()
+	fake.maxIdleConnectionsMutex.RLock()
+	defer fake.maxIdleConnectionsMutex.RUnlock()
 	fake.traceMutex.RLock()
 	defer fake.traceMutex.RUnlock()
 	fake.colorEnabledMutex.RLock()
 	defer fake.colorEnabledMutex.RUnlock()
 	fake.localeMutex.RLock()
 	defer fake.localeMutex.RUnlock()
+	fake.credentialStoreMutex.RLock()
+	defer fake.credentialStoreMutex.RUnlock()
+	fake.pluginSandboxMutex.RLock()
+	defer fake.pluginSandboxMutex.RUnlock()
+	fake.lastLoginTargetMutex.RLock()
+	defer fake.lastLoginTargetMutex.RUnlock()
+	fake.contextNamesMutex.RLock()
+	defer fake.contextNamesMutex.RUnlock()
 	fake.pluginReposMutex.RLock()
 	defer fake.pluginReposMutex.RUnlock()
 	fake.clearSessionMutex.RLock()
@@ -1667,6 +2383,12 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.setSSHOAuthClientMutex.RUnlock()
 	fake.setRefreshTokenMutex.RLock()
 	defer fake.setRefreshTokenMutex.RUnlock()
+	fake.setUAAGrantTypeMutex.RLock()
+	defer fake.setUAAGrantTypeMutex.RUnlock()
+	fake.setUAAOAuthClientMutex.RLock()
+	defer fake.setUAAOAuthClientMutex.RUnlock()
+	fake.setUAAOAuthClientSecretMutex.RLock()
+	defer fake.setUAAOAuthClientSecretMutex.RUnlock()
 	fake.setOrganizationFieldsMutex.RLock()
 	defer fake.setOrganizationFieldsMutex.RUnlock()
 	fake.setSpaceFieldsMutex.RLock()
@@ -1675,12 +2397,32 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.setSSLDisabledMutex.RUnlock()
 	fake.setAsyncTimeoutMutex.RLock()
 	defer fake.setAsyncTimeoutMutex.RUnlock()
+	fake.setKeepAliveTimeoutMutex.RLock()
+	defer fake.setKeepAliveTimeoutMutex.RUnlock()
+	fake.setMaxIdleConnectionsMutex.RLock()
+	defer fake.setMaxIdleConnectionsMutex.RUnlock()
 	fake.setTraceMutex.RLock()
 	defer fake.setTraceMutex.RUnlock()
 	fake.setColorEnabledMutex.RLock()
 	defer fake.setColorEnabledMutex.RUnlock()
 	fake.setLocaleMutex.RLock()
 	defer fake.setLocaleMutex.RUnlock()
+	fake.setCredentialStoreMutex.RLock()
+	defer fake.setCredentialStoreMutex.RUnlock()
+	fake.setPluginSandboxMutex.RLock()
+	defer fake.setPluginSandboxMutex.RUnlock()
+	fake.setLastLoginTargetMutex.RLock()
+	defer fake.setLastLoginTargetMutex.RUnlock()
+	fake.saveContextMutex.RLock()
+	defer fake.saveContextMutex.RUnlock()
+	fake.overrideTargetMutex.RLock()
+	defer fake.overrideTargetMutex.RUnlock()
+	fake.useContextMutex.RLock()
+	defer fake.useContextMutex.RUnlock()
+	fake.renameContextOrganizationMutex.RLock()
+	defer fake.renameContextOrganizationMutex.RUnlock()
+	fake.renameContextSpaceMutex.RLock()
+	defer fake.renameContextSpaceMutex.RUnlock()
 	fake.setPluginRepoMutex.RLock()
 	defer fake.setPluginRepoMutex.RUnlock()
 	fake.unSetPluginRepoMutex.RLock()
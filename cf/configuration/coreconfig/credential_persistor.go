@@ -0,0 +1,172 @@
+package coreconfig
+
+import (
+	"code.cloudfoundry.org/cli/cf/configuration"
+	"code.cloudfoundry.org/cli/cf/configuration/credentialstore"
+)
+
+// StoreFactory returns the credential store of a backend, or nil for the
+// plaintext backend.
+type StoreFactory func(backend string) (credentialstore.Store, error)
+
+// CredentialPersistor saves the tokens and client secret of the config in the
+// credential store selected by the config, and everything else with the
+// persistor it wraps. When the store cannot be used, the secrets are left in
+// the config file instead, so that the session is never lost.
+type CredentialPersistor struct {
+	persistor configuration.Persistor
+	newStore  StoreFactory
+
+	// stored holds the secrets known to be in the store of storeBackend, so
+	// that they are only written to the store when they change.
+	storeBackend string
+	store        credentialstore.Store
+	stored       map[string]string
+
+	// unreadable holds the secrets that could not be read from the store
+	// when the config was loaded. They are empty in the config, but may
+	// still be in the store, so they are never deleted from it.
+	unreadable map[string]bool
+}
+
+func NewCredentialPersistor(persistor configuration.Persistor, newStore StoreFactory) *CredentialPersistor {
+	return &CredentialPersistor{
+		persistor:  persistor,
+		newStore:   newStore,
+		stored:     map[string]string{},
+		unreadable: map[string]bool{},
+	}
+}
+
+func secretFields(data *Data) map[string]*string {
+	fields := map[string]*string{
+		"access-token":            &data.AccessToken,
+		"refresh-token":           &data.RefreshToken,
+		"uaa-oauth-client-secret": &data.UAAOAuthClientSecret,
+	}
+
+	for name, context := range data.Contexts {
+		prefix := "contexts/" + name + "/"
+		fields[prefix+"access-token"] = &context.AccessToken
+		fields[prefix+"refresh-token"] = &context.RefreshToken
+		fields[prefix+"uaa-oauth-client-secret"] = &context.UAAOAuthClientSecret
+	}
+
+	return fields
+}
+
+func (p *CredentialPersistor) Exists() bool {
+	return p.persistor.Exists()
+}
+
+func (p *CredentialPersistor) Delete() {
+	p.persistor.Delete()
+	p.switchStore("")
+}
+
+func (p *CredentialPersistor) Load(data configuration.DataInterface) error {
+	err := p.persistor.Load(data)
+	if err != nil {
+		return err
+	}
+
+	configData, ok := data.(*Data)
+	if !ok {
+		return nil
+	}
+
+	p.switchStore(configData.CredentialStore)
+	if p.store == nil {
+		return nil
+	}
+
+	for key, field := range secretFields(configData) {
+		// a secret still in the config file is moved to the store on the
+		// next save
+		if *field != "" {
+			continue
+		}
+
+		value, err := p.store.Get(key)
+		switch err {
+		case nil:
+			*field = value
+			p.stored[key] = value
+		case credentialstore.ErrNotFound:
+		default:
+			p.unreadable[key] = true
+		}
+	}
+
+	return nil
+}
+
+func (p *CredentialPersistor) Save(data configuration.DataInterface) error {
+	configData, ok := data.(*Data)
+	if !ok {
+		return p.persistor.Save(data)
+	}
+
+	p.switchStore(configData.CredentialStore)
+	if p.store == nil {
+		return p.persistor.Save(data)
+	}
+
+	fileData := *configData
+	fileData.copyContexts()
+	fields := secretFields(&fileData)
+	for key, field := range fields {
+		value := *field
+		if stored, ok := p.stored[key]; ok && stored == value {
+			*field = ""
+			continue
+		}
+
+		if value == "" {
+			if !p.unreadable[key] {
+				_ = p.store.Delete(key)
+			}
+			delete(p.stored, key)
+			continue
+		}
+
+		if p.store.Set(key, value) == nil {
+			p.stored[key] = value
+			delete(p.unreadable, key)
+			*field = ""
+		}
+	}
+
+	// the secrets of contexts that have been deleted
+	for key := range p.stored {
+		if _, ok := fields[key]; !ok {
+			_ = p.store.Delete(key)
+			delete(p.stored, key)
+		}
+	}
+
+	return p.persistor.Save(&fileData)
+}
+
+// switchStore opens the store of backend, removing the secrets from the
+// store that was used before, if any.
+func (p *CredentialPersistor) switchStore(backend string) {
+	if p.store != nil && backend == p.storeBackend {
+		return
+	}
+
+	if p.store != nil {
+		for key := range p.stored {
+			_ = p.store.Delete(key)
+		}
+		p.stored = map[string]string{}
+	}
+	p.unreadable = map[string]bool{}
+
+	p.storeBackend = backend
+	p.store = nil
+	store, err := p.newStore(backend)
+	if err == nil {
+		p.store = store
+	}
+}
@@ -0,0 +1,219 @@
+package coreconfig_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/configuration"
+	"code.cloudfoundry.org/cli/cf/configuration/configurationfakes"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/configuration/credentialstore"
+	"code.cloudfoundry.org/cli/cf/configuration/credentialstore/credentialstorefakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CredentialPersistor", func() {
+	var (
+		diskPersistor *configurationfakes.FakePersistor
+		store         *credentialstorefakes.FakeStore
+		secrets       map[string]string
+		backends      []string
+		persistor     *coreconfig.CredentialPersistor
+		savedData     *coreconfig.Data
+	)
+
+	BeforeEach(func() {
+		diskPersistor = new(configurationfakes.FakePersistor)
+		diskPersistor.SaveStub = func(data configuration.DataInterface) error {
+			saved := *data.(*coreconfig.Data)
+			savedData = &saved
+			return nil
+		}
+
+		secrets = map[string]string{}
+		store = new(credentialstorefakes.FakeStore)
+		store.GetStub = func(key string) (string, error) {
+			value, ok := secrets[key]
+			if !ok {
+				return "", credentialstore.ErrNotFound
+			}
+			return value, nil
+		}
+		store.SetStub = func(key, value string) error {
+			secrets[key] = value
+			return nil
+		}
+		store.DeleteStub = func(key string) error {
+			delete(secrets, key)
+			return nil
+		}
+
+		backends = nil
+		persistor = coreconfig.NewCredentialPersistor(diskPersistor, func(backend string) (credentialstore.Store, error) {
+			backends = append(backends, backend)
+			if backend == "keychain" {
+				return store, nil
+			}
+			return nil, nil
+		})
+	})
+
+	Context("when the plaintext store is used", func() {
+		It("saves the secrets in the config file", func() {
+			data := &coreconfig.Data{AccessToken: "bearer access", RefreshToken: "refresh"}
+			Expect(persistor.Save(data)).To(Succeed())
+
+			Expect(savedData.AccessToken).To(Equal("bearer access"))
+			Expect(savedData.RefreshToken).To(Equal("refresh"))
+			Expect(store.SetCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when a credential store is selected", func() {
+		It("saves the secrets in the store rather than the config file", func() {
+			data := &coreconfig.Data{
+				CredentialStore:      "keychain",
+				AccessToken:          "bearer access",
+				RefreshToken:         "refresh",
+				UAAOAuthClientSecret: "client-secret",
+			}
+			Expect(persistor.Save(data)).To(Succeed())
+
+			Expect(savedData.AccessToken).To(BeEmpty())
+			Expect(savedData.RefreshToken).To(BeEmpty())
+			Expect(savedData.UAAOAuthClientSecret).To(BeEmpty())
+			Expect(savedData.CredentialStore).To(Equal("keychain"))
+			Expect(secrets).To(Equal(map[string]string{
+				"access-token":            "bearer access",
+				"refresh-token":           "refresh",
+				"uaa-oauth-client-secret": "client-secret",
+			}))
+
+			Expect(data.AccessToken).To(Equal("bearer access"))
+		})
+
+		It("saves the secrets of contexts in the store", func() {
+			data := &coreconfig.Data{
+				CredentialStore: "keychain",
+				Contexts: map[string]*coreconfig.ContextData{
+					"prod": {AccessToken: "bearer prod-access", RefreshToken: "prod-refresh"},
+				},
+			}
+			Expect(persistor.Save(data)).To(Succeed())
+
+			Expect(savedData.Contexts["prod"].AccessToken).To(BeEmpty())
+			Expect(secrets).To(Equal(map[string]string{
+				"contexts/prod/access-token":  "bearer prod-access",
+				"contexts/prod/refresh-token": "prod-refresh",
+			}))
+			Expect(data.Contexts["prod"].AccessToken).To(Equal("bearer prod-access"))
+
+			delete(data.Contexts, "prod")
+			Expect(persistor.Save(data)).To(Succeed())
+			Expect(secrets).To(BeEmpty())
+		})
+
+		It("only writes the secrets to the store when they change", func() {
+			data := &coreconfig.Data{CredentialStore: "keychain", AccessToken: "bearer access", RefreshToken: "refresh"}
+			Expect(persistor.Save(data)).To(Succeed())
+			Expect(store.SetCallCount()).To(Equal(2))
+
+			data.AccessToken = "bearer new-access"
+			Expect(persistor.Save(data)).To(Succeed())
+			Expect(store.SetCallCount()).To(Equal(3))
+			Expect(secrets["access-token"]).To(Equal("bearer new-access"))
+			Expect(backends).To(Equal([]string{"keychain"}))
+		})
+
+		It("removes a secret from the store once it is cleared", func() {
+			data := &coreconfig.Data{CredentialStore: "keychain", AccessToken: "bearer access"}
+			Expect(persistor.Save(data)).To(Succeed())
+
+			data.AccessToken = ""
+			Expect(persistor.Save(data)).To(Succeed())
+			Expect(secrets).NotTo(HaveKey("access-token"))
+		})
+
+		It("loads the secrets from the store", func() {
+			secrets["access-token"] = "bearer access"
+			secrets["refresh-token"] = "refresh"
+			diskPersistor.LoadStub = func(data configuration.DataInterface) error {
+				data.(*coreconfig.Data).CredentialStore = "keychain"
+				return nil
+			}
+
+			data := coreconfig.NewData()
+			Expect(persistor.Load(data)).To(Succeed())
+			Expect(data.AccessToken).To(Equal("bearer access"))
+			Expect(data.RefreshToken).To(Equal("refresh"))
+			Expect(data.UAAOAuthClientSecret).To(BeEmpty())
+		})
+
+		Context("when a secret cannot be read from the store", func() {
+			BeforeEach(func() {
+				secrets["access-token"] = "bearer access"
+				secrets["refresh-token"] = "refresh"
+				store.GetStub = func(key string) (string, error) {
+					if key == "refresh-token" {
+						return "", errors.New("keychain locked")
+					}
+					value, ok := secrets[key]
+					if !ok {
+						return "", credentialstore.ErrNotFound
+					}
+					return value, nil
+				}
+				diskPersistor.LoadStub = func(data configuration.DataInterface) error {
+					data.(*coreconfig.Data).CredentialStore = "keychain"
+					return nil
+				}
+			})
+
+			It("does not delete it from the store when the config is saved", func() {
+				data := coreconfig.NewData()
+				Expect(persistor.Load(data)).To(Succeed())
+				Expect(data.AccessToken).To(Equal("bearer access"))
+				Expect(data.RefreshToken).To(BeEmpty())
+
+				Expect(persistor.Save(data)).To(Succeed())
+				Expect(secrets["refresh-token"]).To(Equal("refresh"))
+			})
+
+			It("replaces it in the store once it is set again", func() {
+				data := coreconfig.NewData()
+				Expect(persistor.Load(data)).To(Succeed())
+
+				data.RefreshToken = "new-refresh"
+				Expect(persistor.Save(data)).To(Succeed())
+				Expect(secrets["refresh-token"]).To(Equal("new-refresh"))
+
+				data.RefreshToken = ""
+				Expect(persistor.Save(data)).To(Succeed())
+				Expect(secrets).NotTo(HaveKey("refresh-token"))
+			})
+		})
+
+		It("moves the secrets back to the config file when the plaintext store is selected again", func() {
+			data := &coreconfig.Data{CredentialStore: "keychain", AccessToken: "bearer access"}
+			Expect(persistor.Save(data)).To(Succeed())
+
+			data.CredentialStore = "plaintext"
+			Expect(persistor.Save(data)).To(Succeed())
+			Expect(savedData.AccessToken).To(Equal("bearer access"))
+			Expect(secrets).To(BeEmpty())
+		})
+
+		Context("when the store cannot save a secret", func() {
+			BeforeEach(func() {
+				store.SetReturns(errors.New("no keychain"))
+			})
+
+			It("falls back to saving it in the config file", func() {
+				data := &coreconfig.Data{CredentialStore: "keychain", AccessToken: "bearer access"}
+				Expect(persistor.Save(data)).To(Succeed())
+				Expect(savedData.AccessToken).To(Equal("bearer access"))
+			})
+		})
+	})
+})
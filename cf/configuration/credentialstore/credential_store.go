@@ -0,0 +1,51 @@
+package credentialstore
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+)
+
+const (
+	Plaintext = "plaintext"
+	Keychain  = "keychain"
+	WinCred   = "wincred"
+	Libsecret = "libsecret"
+)
+
+// Backends lists the names accepted by New.
+var Backends = []string{Plaintext, Keychain, WinCred, Libsecret}
+
+var ErrNotFound = errors.New("credential not found")
+
+//go:generate counterfeiter . Store
+
+// Store keeps secrets, such as tokens, out of the config file. Each secret is
+// saved under the service given to New and a key naming the secret.
+type Store interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+// New returns the store for backend. Secrets saved for different services,
+// such as the config files of different CF_HOMEs, are kept apart. The
+// plaintext backend has no store, since the secrets stay in the config file.
+func New(backend, service string) (Store, error) {
+	switch backend {
+	case "", Plaintext:
+		return nil, nil
+	case Keychain:
+		return NewKeychainStore(service, exec.Command), nil
+	case WinCred:
+		return newWinCredStore(service)
+	case Libsecret:
+		return NewLibsecretStore(service, exec.Command), nil
+	default:
+		return nil, fmt.Errorf("unknown credential store %s", backend)
+	}
+}
+
+// CommandFunc builds the command that runs a credential helper. It has the
+// signature of exec.Command.
+type CommandFunc func(name string, arg ...string) *exec.Cmd
@@ -0,0 +1,160 @@
+package credentialstore_test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	. "code.cloudfoundry.org/cli/cf/configuration/credentialstore"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Credential stores", func() {
+	var (
+		tmpDir  string
+		calls   [][]string
+		script  string
+		command CommandFunc
+	)
+
+	BeforeEach(func() {
+		if runtime.GOOS == "windows" {
+			Skip("the fake credential helpers are shell scripts")
+		}
+
+		var err error
+		tmpDir, err = ioutil.TempDir("", "credentialstore")
+		Expect(err).NotTo(HaveOccurred())
+
+		calls = nil
+		script = "exit 0"
+		command = func(name string, arg ...string) *exec.Cmd {
+			calls = append(calls, append([]string{name}, arg...))
+			return exec.Command("sh", "-c", script)
+		}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	stdinFile := func() string {
+		return filepath.Join(tmpDir, "stdin")
+	}
+
+	Describe("New", func() {
+		It("has no store for the plaintext backend", func() {
+			store, err := New("plaintext", "some-service")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(store).To(BeNil())
+		})
+
+		It("returns an error for an unknown backend", func() {
+			_, err := New("vault", "some-service")
+			Expect(err).To(MatchError("unknown credential store vault"))
+		})
+	})
+
+	Describe("KeychainStore", func() {
+		var store KeychainStore
+
+		BeforeEach(func() {
+			store = NewKeychainStore("some-service", command)
+		})
+
+		It("reads the secret with security", func() {
+			script = "echo some-secret"
+
+			value, err := store.Get("access-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal("some-secret"))
+			Expect(calls).To(Equal([][]string{
+				{"security", "find-generic-password", "-s", "some-service", "-a", "access-token", "-w"},
+			}))
+		})
+
+		It("returns ErrNotFound when the keychain has no such item", func() {
+			script = "exit 44"
+
+			_, err := store.Get("access-token")
+			Expect(err).To(Equal(ErrNotFound))
+		})
+
+		It("returns the output of security when it fails", func() {
+			script = "echo 'keychain locked' >&2; exit 1"
+
+			_, err := store.Get("access-token")
+			Expect(err).To(MatchError(ContainSubstring("keychain locked")))
+		})
+
+		It("writes the secret through the input of security", func() {
+			script = "cat > " + stdinFile()
+
+			Expect(store.Set("access-token", "bearer some-secret")).To(Succeed())
+			Expect(calls).To(Equal([][]string{{"security", "-i"}}))
+
+			input, err := ioutil.ReadFile(stdinFile())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(input)).To(Equal(`add-generic-password -U -s "some-service" -a "access-token" -w "bearer some-secret"` + "\n"))
+		})
+
+		It("ignores secrets that are already deleted", func() {
+			script = "exit 44"
+
+			Expect(store.Delete("access-token")).To(Succeed())
+			Expect(calls).To(Equal([][]string{
+				{"security", "delete-generic-password", "-s", "some-service", "-a", "access-token"},
+			}))
+		})
+	})
+
+	Describe("LibsecretStore", func() {
+		var store LibsecretStore
+
+		BeforeEach(func() {
+			store = NewLibsecretStore("some-service", command)
+		})
+
+		It("reads the secret with secret-tool", func() {
+			script = "printf some-secret"
+
+			value, err := store.Get("refresh-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal("some-secret"))
+			Expect(calls).To(Equal([][]string{
+				{"secret-tool", "lookup", "service", "some-service", "account", "refresh-token"},
+			}))
+		})
+
+		It("returns ErrNotFound when there is no such secret", func() {
+			script = "exit 1"
+
+			_, err := store.Get("refresh-token")
+			Expect(err).To(Equal(ErrNotFound))
+		})
+
+		It("writes the secret through the input of secret-tool", func() {
+			script = "cat > " + stdinFile()
+
+			Expect(store.Set("refresh-token", "some-secret")).To(Succeed())
+			Expect(calls).To(Equal([][]string{
+				{"secret-tool", "store", "--label", "Cloud Foundry CLI refresh-token", "service", "some-service", "account", "refresh-token"},
+			}))
+
+			input, err := ioutil.ReadFile(stdinFile())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(input)).To(Equal("some-secret"))
+		})
+
+		It("clears the secret", func() {
+			Expect(store.Delete("refresh-token")).To(Succeed())
+			Expect(calls).To(Equal([][]string{
+				{"secret-tool", "clear", "service", "some-service", "account", "refresh-token"},
+			}))
+		})
+	})
+})
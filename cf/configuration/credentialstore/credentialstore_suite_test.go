@@ -0,0 +1,13 @@
+package credentialstore_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestCredentialstore(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Credentialstore Suite")
+}
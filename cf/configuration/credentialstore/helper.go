@@ -0,0 +1,25 @@
+package credentialstore
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+func exitStatus(err error) int {
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return status.ExitStatus()
+		}
+	}
+	return -1
+}
+
+func helperError(name string, err error, output string) error {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return fmt.Errorf("%s failed: %s", name, err.Error())
+	}
+	return fmt.Errorf("%s failed: %s: %s", name, err.Error(), output)
+}
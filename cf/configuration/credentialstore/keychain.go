@@ -0,0 +1,59 @@
+package credentialstore
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// keychainItemNotFound is the exit status of the security tool when the
+// requested item is not in the keychain.
+const keychainItemNotFound = 44
+
+// KeychainStore saves secrets as generic passwords in the macOS login
+// keychain, using the security tool.
+type KeychainStore struct {
+	service string
+	command CommandFunc
+}
+
+func NewKeychainStore(service string, command CommandFunc) KeychainStore {
+	return KeychainStore{service: service, command: command}
+}
+
+func (s KeychainStore) Get(key string) (string, error) {
+	cmd := s.command("security", "find-generic-password", "-s", s.service, "-a", key, "-w")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if exitStatus(err) == keychainItemNotFound {
+			return "", ErrNotFound
+		}
+		return "", helperError("security", err, stderr.String())
+	}
+
+	return strings.TrimSuffix(string(output), "\n"), nil
+}
+
+// Set runs security interactively, so that the secret is read from its input
+// rather than passed as an argument that other users could see.
+func (s KeychainStore) Set(key, value string) error {
+	cmd := s.command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+		strconv.Quote(s.service), strconv.Quote(key), strconv.Quote(value)))
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return helperError("security", err, string(output))
+	}
+	return nil
+}
+
+func (s KeychainStore) Delete(key string) error {
+	output, err := s.command("security", "delete-generic-password", "-s", s.service, "-a", key).CombinedOutput()
+	if err != nil && exitStatus(err) != keychainItemNotFound {
+		return helperError("security", err, string(output))
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package credentialstore
+
+import (
+	"bytes"
+	"strings"
+)
+
+// LibsecretStore saves secrets with the Secret Service, such as GNOME
+// Keyring or KWallet, using the secret-tool of libsecret.
+type LibsecretStore struct {
+	service string
+	command CommandFunc
+}
+
+func NewLibsecretStore(service string, command CommandFunc) LibsecretStore {
+	return LibsecretStore{service: service, command: command}
+}
+
+func (s LibsecretStore) Get(key string) (string, error) {
+	cmd := s.command("secret-tool", "lookup", "service", s.service, "account", key)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		// secret-tool exits with 1 and prints nothing when there is no secret
+		if exitStatus(err) == 1 && stderr.Len() == 0 {
+			return "", ErrNotFound
+		}
+		return "", helperError("secret-tool", err, stderr.String())
+	}
+
+	return strings.TrimSuffix(string(output), "\n"), nil
+}
+
+// Set gives the secret to secret-tool on its input rather than as an
+// argument that other users could see.
+func (s LibsecretStore) Set(key, value string) error {
+	cmd := s.command("secret-tool", "store", "--label", "Cloud Foundry CLI "+key, "service", s.service, "account", key)
+	cmd.Stdin = strings.NewReader(value)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return helperError("secret-tool", err, string(output))
+	}
+	return nil
+}
+
+func (s LibsecretStore) Delete(key string) error {
+	output, err := s.command("secret-tool", "clear", "service", s.service, "account", key).CombinedOutput()
+	if err != nil {
+		return helperError("secret-tool", err, string(output))
+	}
+	return nil
+}
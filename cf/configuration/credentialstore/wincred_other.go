@@ -0,0 +1,10 @@
+//go:build !windows
+// +build !windows
+
+package credentialstore
+
+import "errors"
+
+func newWinCredStore(service string) (Store, error) {
+	return nil, errors.New("the Windows Credential Manager is only available on Windows")
+}
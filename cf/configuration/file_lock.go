@@ -0,0 +1,54 @@
+package configuration
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	lockRetryInterval = 10 * time.Millisecond
+	lockTimeout       = 5 * time.Second
+	staleLockAge      = 30 * time.Second
+)
+
+// fileLock keeps other cf processes, such as plugins calling back into the
+// CLI, from writing a file at the same time. The lock is a file next to the
+// locked one that only the holder of the lock has created.
+type fileLock struct {
+	path string
+}
+
+func newFileLock(filePath string) fileLock {
+	return fileLock{path: filePath + ".lock"}
+}
+
+// Lock waits until the lock is free and takes it. A lock older than
+// staleLockAge is assumed to be left over from a process that was killed
+// while holding it, and is removed.
+func (l fileLock) Lock() error {
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		file, err := os.OpenFile(l.path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, filePermissions)
+		if err == nil {
+			return file.Close()
+		}
+		if !os.IsExist(err) {
+			return err
+		}
+
+		if info, statErr := os.Stat(l.path); statErr == nil && time.Since(info.ModTime()) > staleLockAge {
+			_ = os.Remove(l.path)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for lock %s", l.path)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+func (l fileLock) Unlock() error {
+	return os.Remove(l.path)
+}
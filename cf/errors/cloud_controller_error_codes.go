@@ -5,6 +5,7 @@ const (
 	InvalidRelation                        = "1002"
 	NotAuthorized                          = "10003"
 	BadQueryParameter                      = "10005"
+	UaaIDTaken                             = "20002"
 	UserNotFound                           = "20003"
 	OrganizationNameTaken                  = "30002"
 	SpaceNameTaken                         = "40002"
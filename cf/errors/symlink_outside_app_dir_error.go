@@ -0,0 +1,22 @@
+package errors
+
+import (
+	. "code.cloudfoundry.org/cli/cf/i18n"
+)
+
+type SymlinkOutsideAppDirError struct {
+	path   string
+	target string
+}
+
+func NewSymlinkOutsideAppDirError(path string, target string) error {
+	return &SymlinkOutsideAppDirError{path: path, target: target}
+}
+
+func (err *SymlinkOutsideAppDirError) Error() string {
+	return T("Symbolic link {{.Path}} points to {{.Target}}, which is outside the app directory",
+		map[string]interface{}{
+			"Path":   err.path,
+			"Target": err.target,
+		})
+}
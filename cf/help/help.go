@@ -119,6 +119,9 @@ func newAppPresenter() appPresenter {
 				}, {
 					presentCommand("api"),
 					presentCommand("auth"),
+				}, {
+					presentCommand("target-save"),
+					presentCommand("target-use"),
 				},
 			},
 		}, {
@@ -129,6 +132,7 @@ func newAppPresenter() appPresenter {
 					presentCommand("app"),
 				}, {
 					presentCommand("push"),
+					presentCommand("zero-downtime-push"),
 					presentCommand("scale"),
 					presentCommand("delete"),
 					presentCommand("rename"),
@@ -138,10 +142,33 @@ func newAppPresenter() appPresenter {
 					presentCommand("restart"),
 					presentCommand("restage"),
 					presentCommand("restart-app-instance"),
+					presentCommand("rollback"),
+					presentCommand("revisions"),
+					presentCommand("download-droplet"),
+					presentCommand("set-droplet"),
+				}, {
+					presentCommand("deployments"),
+					presentCommand("cancel-deployment"),
+					presentCommand("continue-deployment"),
+				}, {
+					presentCommand("packages"),
+					presentCommand("create-package"),
+					presentCommand("stage-package"),
+					presentCommand("builds"),
+					presentCommand("create-build"),
+					presentCommand("logs-build"),
+				}, {
+					presentCommand("run-task"),
+					presentCommand("tasks"),
+					presentCommand("terminate-task"),
+					presentCommand("sidecars"),
 				}, {
 					presentCommand("events"),
+					presentCommand("app-history"),
+					presentCommand("crash-info"),
 					presentCommand("files"),
 					presentCommand("logs"),
+					presentCommand("app-metrics"),
 				}, {
 					presentCommand("env"),
 					presentCommand("set-env"),
@@ -149,10 +176,14 @@ func newAppPresenter() appPresenter {
 				}, {
 					presentCommand("stacks"),
 					presentCommand("stack"),
+					presentCommand("change-stack"),
 				}, {
 					presentCommand("copy-source"),
 				}, {
 					presentCommand("create-app-manifest"),
+					presentCommand("validate-manifest"),
+					presentCommand("migrate-manifest"),
+					presentCommand("apply-manifest"),
 				}, {
 					presentCommand("get-health-check"),
 					presentCommand("set-health-check"),
@@ -160,6 +191,11 @@ func newAppPresenter() appPresenter {
 					presentCommand("disable-ssh"),
 					presentCommand("ssh-enabled"),
 					presentCommand("ssh"),
+					presentCommand("scp"),
+				}, {
+					presentCommand("app-features"),
+					presentCommand("enable-app-feature"),
+					presentCommand("disable-app-feature"),
 				},
 			},
 		}, {
@@ -178,13 +214,16 @@ func newAppPresenter() appPresenter {
 					presentCommand("create-service-key"),
 					presentCommand("service-keys"),
 					presentCommand("service-key"),
+					presentCommand("service-key-env"),
 					presentCommand("delete-service-key"),
+					presentCommand("tunnel"),
 				}, {
 					presentCommand("bind-service"),
 					presentCommand("unbind-service"),
 				}, {
 					presentCommand("bind-route-service"),
 					presentCommand("unbind-route-service"),
+					presentCommand("route-services"),
 				}, {
 					presentCommand("create-user-provided-service"),
 					presentCommand("update-user-provided-service"),
@@ -245,6 +284,15 @@ func newAppPresenter() appPresenter {
 					presentCommand("delete-orphaned-routes"),
 				},
 			},
+		}, {
+			Name: T("NETWORK POLICIES"),
+			CommandSubGroups: [][]cmdPresenter{
+				{
+					presentCommand("network-policies"),
+					presentCommand("add-network-policy"),
+					presentCommand("remove-network-policy"),
+				},
+			},
 		}, {
 			Name: T("BUILDPACKS"),
 			CommandSubGroups: [][]cmdPresenter{
@@ -270,6 +318,8 @@ func newAppPresenter() appPresenter {
 					presentCommand("space-users"),
 					presentCommand("set-space-role"),
 					presentCommand("unset-space-role"),
+				}, {
+					presentCommand("set-roles"),
 				},
 			},
 		}, {
@@ -278,6 +328,7 @@ func newAppPresenter() appPresenter {
 				{
 					presentCommand("quotas"),
 					presentCommand("quota"),
+					presentCommand("quota-usage"),
 					presentCommand("set-quota"),
 				}, {
 					presentCommand("create-quota"),
@@ -316,6 +367,7 @@ func newAppPresenter() appPresenter {
 					presentCommand("update-service-broker"),
 					presentCommand("delete-service-broker"),
 					presentCommand("rename-service-broker"),
+					presentCommand("broker-catalog-diff"),
 				}, {
 					presentCommand("migrate-service-instances"),
 					presentCommand("purge-service-offering"),
@@ -358,6 +410,16 @@ func newAppPresenter() appPresenter {
 				},
 			},
 		},
+		{
+			Name: T("METADATA"),
+			CommandSubGroups: [][]cmdPresenter{
+				{
+					presentCommand("labels"),
+					presentCommand("set-label"),
+					presentCommand("unset-label"),
+				},
+			},
+		},
 		{
 			Name: T("FEATURE FLAGS"),
 			CommandSubGroups: [][]cmdPresenter{
@@ -374,6 +436,7 @@ func newAppPresenter() appPresenter {
 				{
 					presentCommand("curl"),
 					presentCommand("config"),
+					presentCommand("completion"),
 					presentCommand("oauth-token"),
 					presentCommand("ssh-code"),
 				},
@@ -395,6 +458,7 @@ func newAppPresenter() appPresenter {
 					presentCommand("plugins"),
 					presentCommand("install-plugin"),
 					presentCommand("uninstall-plugin"),
+					presentCommand("update-plugins"),
 				},
 			},
 		}, {
@@ -17,17 +17,25 @@ func GetHelpTemplate() string {
 {{end}}{{end}}{{end}}
 {{.Title "` + T("ENVIRONMENT VARIABLES:") + `"}}
    CF_COLOR=false                     ` + T("Do not colorize output") + `
+   CF_CONTEXT=prod                    ` + T("Run commands against a context saved with target-save") + `
    CF_HOME=path/to/dir/               ` + T("Override path to default config directory") + `
    CF_DIAL_TIMEOUT=5                  ` + T("Max wait time to establish a connection, including name resolution, in seconds") + `
+   CF_NON_INTERACTIVE=true            ` + T("Fail instead of prompting for input or confirmation") + `
    CF_PLUGIN_HOME=path/to/dir/        ` + T("Override path to default plugin config directory") + `
    CF_STAGING_TIMEOUT=15              ` + T("Max wait time for buildpack staging, in minutes") + `
    CF_STARTUP_TIMEOUT=5               ` + T("Max wait time for app instance startup, in minutes") + `
    CF_TRACE=true                      ` + T("Print API request diagnostics to stdout") + `
-   CF_TRACE=path/to/trace.log         ` + T("Append API request diagnostics to a log file") + `
+   CF_TRACE=path/to/trace.log         ` + T("Append API request diagnostics to a log file, rotated at 10MB") + `
    https_proxy=proxy.example.com:8080 ` + T("Enable HTTP proxying for API requests") + `
 
 {{.Title "` + T("GLOBAL OPTIONS:") + `"}}
    --help, -h                         ` + T("Show help") + `
    -v                                 ` + T("Print API request diagnostics to stdout") + `
+   --trace[=path/to/trace.log]        ` + T("Print API request diagnostics for this command to stdout, or append them to a log file") + `
+   --output FORMAT                    ` + T("Print the data of list and show commands as table, json or yaml") + `
+   --context NAME                     ` + T("Run the command against a context saved with target-save, leaving the current target alone") + `
+   --org ORG                          ` + T("Run the command in this org, leaving the targeted org alone") + `
+   --space SPACE                      ` + T("Run the command in this space, leaving the targeted space alone") + `
+   --non-interactive                  ` + T("Fail instead of prompting for input or confirmation") + `
 `
 }
@@ -0,0 +1,72 @@
+package hooks
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/errors"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+)
+
+// Hook is a script that push runs on the local machine, such as one that
+// compiles the assets of an app before it is uploaded.
+type Hook struct {
+	Script string
+	// Dir is the directory the script runs in
+	Dir string
+	// Env is added to the environment of the CLI for the script
+	Env     []string
+	Timeout time.Duration
+	// Output receives what the script writes to stdout and stderr
+	Output io.Writer
+}
+
+//go:generate counterfeiter . Runner
+
+type Runner interface {
+	Run(hook Hook) error
+}
+
+type ScriptRunner struct{}
+
+func NewRunner() Runner {
+	return ScriptRunner{}
+}
+
+// Run runs the script of hook with the shell of the platform, killing it
+// once it has run for longer than the timeout of the hook.
+func (runner ScriptRunner) Run(hook Hook) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", hook.Script)
+	} else {
+		cmd = exec.Command("/bin/sh", "-c", hook.Script)
+	}
+	cmd.Dir = hook.Dir
+	cmd.Env = append(os.Environ(), hook.Env...)
+	cmd.Stdout = hook.Output
+	cmd.Stderr = hook.Output
+
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	select {
+	case err = <-done:
+		return err
+	case <-time.After(hook.Timeout):
+		// processes the script started may outlive it and keep its output
+		// open, so the script is not waited for once it has been killed
+		_ = cmd.Process.Kill()
+		return errors.New(T("Timed out after {{.Timeout}}", map[string]interface{}{"Timeout": hook.Timeout}))
+	}
+}
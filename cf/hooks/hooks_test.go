@@ -0,0 +1,73 @@
+package hooks_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	. "code.cloudfoundry.org/cli/cf/hooks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ScriptRunner", func() {
+	var (
+		runner Runner
+		dir    string
+		output *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		if runtime.GOOS == "windows" {
+			Skip("the scripts in these tests are for sh")
+		}
+
+		runner = NewRunner()
+		output = new(bytes.Buffer)
+
+		var err error
+		dir, err = ioutil.TempDir("", "hooks")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("runs the script in its directory with the extra environment", func() {
+		err := runner.Run(Hook{
+			Script:  `echo "$CF_APP_NAME" > app-name && echo done`,
+			Dir:     dir,
+			Env:     []string{"CF_APP_NAME=my-app"},
+			Timeout: time.Minute,
+			Output:  output,
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		contents, err := ioutil.ReadFile(filepath.Join(dir, "app-name"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(contents)).To(Equal("my-app\n"))
+		Expect(output.String()).To(Equal("done\n"))
+	})
+
+	It("writes what the script writes to stderr to the output", func() {
+		err := runner.Run(Hook{Script: "echo oops >&2", Dir: dir, Timeout: time.Minute, Output: output})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(output.String()).To(Equal("oops\n"))
+	})
+
+	It("fails when the script exits with an error", func() {
+		err := runner.Run(Hook{Script: "exit 3", Dir: dir, Timeout: time.Minute, Output: output})
+		Expect(err).To(MatchError("exit status 3"))
+	})
+
+	It("kills the script once it times out", func() {
+		start := time.Now()
+		err := runner.Run(Hook{Script: "sleep 10", Dir: dir, Timeout: 100 * time.Millisecond, Output: output})
+		Expect(err).To(MatchError("Timed out after 100ms"))
+		Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+	})
+})
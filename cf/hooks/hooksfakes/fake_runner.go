@@ -0,0 +1,76 @@
+// This file was generated by counterfeiter
+package hooksfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/hooks"
+)
+
+type FakeRunner struct {
+	RunStub        func(hook hooks.Hook) error
+	runMutex       sync.RWMutex
+	runArgsForCall []struct {
+		hook hooks.Hook
+	}
+	runReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRunner) Run(hook hooks.Hook) error {
+	fake.runMutex.Lock()
+	fake.runArgsForCall = append(fake.runArgsForCall, struct {
+		hook hooks.Hook
+	}{hook})
+	fake.recordInvocation("Run", []interface{}{hook})
+	fake.runMutex.Unlock()
+	if fake.RunStub != nil {
+		return fake.RunStub(hook)
+	} else {
+		return fake.runReturns.result1
+	}
+}
+
+func (fake *FakeRunner) RunCallCount() int {
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	return len(fake.runArgsForCall)
+}
+
+func (fake *FakeRunner) RunArgsForCall(i int) hooks.Hook {
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	return fake.runArgsForCall[i].hook
+}
+
+func (fake *FakeRunner) RunReturns(result1 error) {
+	fake.RunStub = nil
+	fake.runReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeRunner) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRunner) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ hooks.Runner = new(FakeRunner)
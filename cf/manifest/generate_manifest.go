@@ -20,6 +20,7 @@ type App interface {
 	DiskQuota(string, int64)
 	Memory(string, int64)
 	Service(string, string)
+	ServiceBinding(string, string, string)
 	StartCommand(string, string)
 	EnvironmentVars(string, string, string)
 	HealthCheckTimeout(string, int)
@@ -28,6 +29,10 @@ type App interface {
 	GetContents() []models.Application
 	Stack(string, string)
 	AppPorts(string, []int)
+	Docker(string, string, string)
+	Process(string, models.Process)
+	Sidecar(string, models.Sidecar)
+	Metadata(string, models.Metadata)
 	Save(f io.Writer) error
 }
 
@@ -40,11 +45,64 @@ type Application struct {
 	Routes    []map[string]string    `yaml:"routes,omitempty"`
 	NoRoute   bool                   `yaml:"no-route,omitempty"`
 	Buildpack string                 `yaml:"buildpack,omitempty"`
+	Docker    *Docker                `yaml:"docker,omitempty"`
 	Command   string                 `yaml:"command,omitempty"`
 	Env       map[string]interface{} `yaml:"env,omitempty"`
-	Services  []string               `yaml:"services,omitempty"`
+	Services  []interface{}          `yaml:"services,omitempty"`
 	Stack     string                 `yaml:"stack,omitempty"`
 	Timeout   int                    `yaml:"timeout,omitempty"`
+
+	HealthCheck `yaml:",inline"`
+
+	Processes []Process `yaml:"processes,omitempty"`
+	Sidecars  []Sidecar `yaml:"sidecars,omitempty"`
+	Metadata  *Metadata `yaml:"metadata,omitempty"`
+}
+
+// HealthCheck is the health and readiness check of the web process, at the
+// top level of an app, or of another process in its 'processes'.
+type HealthCheck struct {
+	HealthCheckType              string `yaml:"health-check-type,omitempty"`
+	HealthCheckHTTPEndpoint      string `yaml:"health-check-http-endpoint,omitempty"`
+	HealthCheckInvocationTimeout int    `yaml:"health-check-invocation-timeout,omitempty"`
+
+	ReadinessHealthCheckType         string `yaml:"readiness-health-check-type,omitempty"`
+	ReadinessHealthCheckHTTPEndpoint string `yaml:"readiness-health-check-http-endpoint,omitempty"`
+	ReadinessHealthCheckInterval     int    `yaml:"readiness-health-check-interval,omitempty"`
+}
+
+// Docker is the image of a docker app. The password of the registry is never
+// written; push reads it from CF_DOCKER_PASSWORD.
+type Docker struct {
+	Image    string `yaml:"image"`
+	Username string `yaml:"username,omitempty"`
+}
+
+type Process struct {
+	Type        string `yaml:"type"`
+	Instances   int    `yaml:"instances"`
+	Memory      string `yaml:"memory,omitempty"`
+	DiskQuota   string `yaml:"disk_quota,omitempty"`
+	HealthCheck `yaml:",inline"`
+}
+
+type Sidecar struct {
+	Name         string   `yaml:"name"`
+	Command      string   `yaml:"command"`
+	ProcessTypes []string `yaml:"process_types,omitempty"`
+	Memory       string   `yaml:"memory,omitempty"`
+}
+
+// ServiceBinding is a service the app is bound to under a binding name. The
+// services bound without one are written as their name alone.
+type ServiceBinding struct {
+	Name        string `yaml:"name"`
+	BindingName string `yaml:"binding_name"`
+}
+
+type Metadata struct {
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
 }
 
 type Applications struct {
@@ -53,10 +111,22 @@ type Applications struct {
 
 type appManifest struct {
 	contents []models.Application
+	details  map[string]*appDetails
+}
+
+// appDetails holds what an app has beyond the fields of models.Application.
+type appDetails struct {
+	bindingNames   map[string]string
+	dockerUsername string
+	processes      []models.Process
+	sidecars       []models.Sidecar
+	metadata       *models.Metadata
 }
 
 func NewGenerator() App {
-	return &appManifest{}
+	return &appManifest{
+		details: map[string]*appDetails{},
+	}
 }
 
 func (m *appManifest) Stack(appName string, stackName string) {
@@ -104,6 +174,11 @@ func (m *appManifest) Service(appName string, name string) {
 	})
 }
 
+func (m *appManifest) ServiceBinding(appName string, name string, bindingName string) {
+	m.Service(appName, name)
+	m.detailsOf(appName).bindingNames[name] = bindingName
+}
+
 func (m *appManifest) Route(appName, host, domain, path string, port int) {
 	i := m.findOrCreateApplication(appName)
 	m.contents[i].Routes = append(m.contents[i].Routes, models.RouteSummary{
@@ -127,11 +202,31 @@ func (m *appManifest) AppPorts(appName string, appPorts []int) {
 	m.contents[i].AppPorts = appPorts
 }
 
+func (m *appManifest) Docker(appName string, image string, username string) {
+	i := m.findOrCreateApplication(appName)
+	m.contents[i].DockerImage = image
+	m.detailsOf(appName).dockerUsername = username
+}
+
+func (m *appManifest) Process(appName string, process models.Process) {
+	details := m.detailsOf(appName)
+	details.processes = append(details.processes, process)
+}
+
+func (m *appManifest) Sidecar(appName string, sidecar models.Sidecar) {
+	details := m.detailsOf(appName)
+	details.sidecars = append(details.sidecars, sidecar)
+}
+
+func (m *appManifest) Metadata(appName string, metadata models.Metadata) {
+	m.detailsOf(appName).metadata = &metadata
+}
+
 func (m *appManifest) GetContents() []models.Application {
 	return m.contents
 }
 
-func generateAppMap(app models.Application) (Application, error) {
+func generateAppMap(app models.Application, details *appDetails) (Application, error) {
 	if app.Stack == nil {
 		return Application{}, errors.New(T("required attribute 'stack' missing"))
 	}
@@ -148,9 +243,13 @@ func generateAppMap(app models.Application) (Application, error) {
 		return Application{}, errors.New(T("required attribute 'instances' missing"))
 	}
 
-	var services []string
+	var services []interface{}
 	for _, s := range app.Services {
-		services = append(services, s.Name)
+		if bindingName := details.bindingNames[s.Name]; bindingName != "" {
+			services = append(services, ServiceBinding{Name: s.Name, BindingName: bindingName})
+		} else {
+			services = append(services, s.Name)
+		}
 	}
 
 	var routes []map[string]string
@@ -177,14 +276,72 @@ func generateAppMap(app models.Application) (Application, error) {
 
 	}
 
+	if app.DockerImage != "" {
+		m.Buildpack = ""
+		m.Docker = &Docker{
+			Image:    app.DockerImage,
+			Username: details.dockerUsername,
+		}
+	}
+
+	// the web process is the app itself, so its checks go at the top level
+	for _, process := range details.processes {
+		if process.Type == "web" {
+			m.HealthCheck = buildHealthCheck(process)
+			continue
+		}
+
+		m.Processes = append(m.Processes, Process{
+			Type:        process.Type,
+			Instances:   process.Instances,
+			Memory:      megabytes(process.MemoryInMB),
+			DiskQuota:   megabytes(process.DiskInMB),
+			HealthCheck: buildHealthCheck(process),
+		})
+	}
+
+	for _, sidecar := range details.sidecars {
+		m.Sidecars = append(m.Sidecars, Sidecar{
+			Name:         sidecar.Name,
+			Command:      sidecar.Command,
+			ProcessTypes: sidecar.ProcessTypes,
+			Memory:       megabytes(sidecar.MemoryInMB),
+		})
+	}
+
+	if details.metadata != nil && (len(details.metadata.Labels) > 0 || len(details.metadata.Annotations) > 0) {
+		m.Metadata = &Metadata{
+			Labels:      details.metadata.Labels,
+			Annotations: details.metadata.Annotations,
+		}
+	}
+
 	return m, nil
 }
 
+func buildHealthCheck(process models.Process) HealthCheck {
+	return HealthCheck{
+		HealthCheckType:                  process.HealthCheckType,
+		HealthCheckHTTPEndpoint:          process.HealthCheckHTTPEndpoint,
+		HealthCheckInvocationTimeout:     process.HealthCheckInvocationTimeout,
+		ReadinessHealthCheckType:         process.ReadinessHealthCheckType,
+		ReadinessHealthCheckHTTPEndpoint: process.ReadinessHealthCheckHTTPEndpoint,
+		ReadinessHealthCheckInterval:     process.ReadinessHealthCheckInterval,
+	}
+}
+
+func megabytes(size int64) string {
+	if size == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%dM", size)
+}
+
 func (m *appManifest) Save(f io.Writer) error {
 	apps := Applications{}
 
 	for _, app := range m.contents {
-		appMap, mapErr := generateAppMap(app)
+		appMap, mapErr := generateAppMap(app, m.detailsOf(app.Name))
 		if mapErr != nil {
 			return fmt.Errorf(T("Error saving manifest: {{.Error}}", map[string]interface{}{
 				"Error": mapErr.Error(),
@@ -237,6 +394,16 @@ func (m *appManifest) findOrCreateApplication(name string) int {
 	return len(m.contents) - 1
 }
 
+func (m *appManifest) detailsOf(name string) *appDetails {
+	m.findOrCreateApplication(name)
+	details, ok := m.details[name]
+	if !ok {
+		details = &appDetails{bindingNames: map[string]string{}}
+		m.details[name] = details
+	}
+	return details
+}
+
 func (m *appManifest) addApplication(name string) {
 	m.contents = append(m.contents, models.Application{
 		ApplicationFields: models.ApplicationFields{
@@ -6,6 +6,7 @@ import (
 	"bytes"
 
 	. "code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/models"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -97,6 +98,121 @@ var _ = Describe("generate_manifest", func() {
 				})
 			})
 
+			Context("when an application has services bound under a binding name", func() {
+				BeforeEach(func() {
+					m.Service("app1", "service1")
+					m.ServiceBinding("app1", "service2", "my-binding")
+				})
+
+				It("includes the binding name with those services", func() {
+					err := m.Save(f)
+					Expect(err).NotTo(HaveOccurred())
+					application := getYaml(f).Applications[0]
+					Expect(application.Services).To(Equal([]interface{}{
+						"service1",
+						map[interface{}]interface{}{"name": "service2", "binding_name": "my-binding"},
+					}))
+				})
+			})
+
+			Context("when an application is a docker app", func() {
+				BeforeEach(func() {
+					m.BuildpackURL("app1", "some-buildpack")
+					m.Docker("app1", "my-org/my-image", "my-user")
+				})
+
+				It("includes the image and username, and no buildpack", func() {
+					err := m.Save(f)
+					Expect(err).NotTo(HaveOccurred())
+					application := getYaml(f).Applications[0]
+					Expect(application.Docker).To(Equal(map[string]string{"image": "my-org/my-image", "username": "my-user"}))
+					Expect(application.Buildpack).To(BeEmpty())
+					Expect(f.String()).NotTo(ContainSubstring("password"))
+				})
+			})
+
+			Context("when an application has processes", func() {
+				BeforeEach(func() {
+					m.Process("app1", models.Process{
+						Type:                         "web",
+						Instances:                    2,
+						MemoryInMB:                   1024,
+						HealthCheckType:              "http",
+						HealthCheckHTTPEndpoint:      "/health",
+						HealthCheckInvocationTimeout: 5,
+						ReadinessHealthCheckType:     "http",
+						ReadinessHealthCheckInterval: 10,
+					})
+					m.Process("app1", models.Process{
+						Type:            "worker",
+						Instances:       0,
+						MemoryInMB:      256,
+						DiskInMB:        512,
+						HealthCheckType: "process",
+					})
+				})
+
+				It("includes the checks of the web process at the top level", func() {
+					err := m.Save(f)
+					Expect(err).NotTo(HaveOccurred())
+					application := getYaml(f).Applications[0]
+					Expect(application.HealthCheckType).To(Equal("http"))
+					Expect(application.HealthCheckHTTPEndpoint).To(Equal("/health"))
+					Expect(application.HealthCheckInvocationTimeout).To(Equal(5))
+					Expect(application.ReadinessHealthCheckType).To(Equal("http"))
+					Expect(application.ReadinessHealthCheckInterval).To(Equal(10))
+				})
+
+				It("includes the other processes with their scale and checks", func() {
+					err := m.Save(f)
+					Expect(err).NotTo(HaveOccurred())
+					application := getYaml(f).Applications[0]
+					Expect(application.Processes).To(Equal([]map[string]interface{}{
+						{"type": "worker", "instances": 0, "memory": "256M", "disk_quota": "512M", "health-check-type": "process"},
+					}))
+				})
+			})
+
+			Context("when an application has sidecars", func() {
+				BeforeEach(func() {
+					m.Sidecar("app1", models.Sidecar{Name: "proxy", Command: "./proxy", ProcessTypes: []string{"web", "worker"}, MemoryInMB: 64})
+				})
+
+				It("includes the sidecars", func() {
+					err := m.Save(f)
+					Expect(err).NotTo(HaveOccurred())
+					application := getYaml(f).Applications[0]
+					Expect(application.Sidecars).To(Equal([]map[string]interface{}{
+						{"name": "proxy", "command": "./proxy", "process_types": []interface{}{"web", "worker"}, "memory": "64M"},
+					}))
+				})
+			})
+
+			Context("when an application has metadata", func() {
+				It("includes the labels and annotations", func() {
+					m.Metadata("app1", models.Metadata{
+						Labels:      map[string]string{"team": "payments"},
+						Annotations: map[string]string{"contact": "payments@example.com"},
+					})
+
+					err := m.Save(f)
+					Expect(err).NotTo(HaveOccurred())
+					application := getYaml(f).Applications[0]
+					Expect(application.Metadata).To(Equal(map[string]map[string]string{
+						"labels":      {"team": "payments"},
+						"annotations": {"contact": "payments@example.com"},
+					}))
+				})
+
+				It("leaves out empty metadata", func() {
+					m.Metadata("app1", models.Metadata{})
+
+					err := m.Save(f)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(f.String()).NotTo(ContainSubstring("metadata"))
+				})
+			})
+
 			Context("when an application has a buildpack", func() {
 				BeforeEach(func() {
 					m.BuildpackURL("app1", "buildpack")
@@ -312,7 +428,7 @@ type YManifest struct {
 
 type YApplication struct {
 	Name      string                 `yaml:"name"`
-	Services  []string               `yaml:"services"`
+	Services  []interface{}          `yaml:"services"`
 	Buildpack string                 `yaml:"buildpack"`
 	Memory    string                 `yaml:"memory"`
 	Command   string                 `yaml:"command"`
@@ -324,6 +440,17 @@ type YApplication struct {
 	DiskQuota string                 `yaml:"disk_quota"`
 	Stack     string                 `yaml:"stack"`
 	AppPorts  []int                  `yaml:"app-ports"`
+	Docker    map[string]string      `yaml:"docker"`
+
+	HealthCheckType              string `yaml:"health-check-type"`
+	HealthCheckHTTPEndpoint      string `yaml:"health-check-http-endpoint"`
+	HealthCheckInvocationTimeout int    `yaml:"health-check-invocation-timeout"`
+	ReadinessHealthCheckType     string `yaml:"readiness-health-check-type"`
+	ReadinessHealthCheckInterval int    `yaml:"readiness-health-check-interval"`
+
+	Processes []map[string]interface{}     `yaml:"processes"`
+	Sidecars  []map[string]interface{}     `yaml:"sidecars"`
+	Metadata  map[string]map[string]string `yaml:"metadata"`
 }
 
 func getYaml(f *bytes.Buffer) YManifest {
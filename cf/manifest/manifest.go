@@ -19,6 +19,14 @@ import (
 type Manifest struct {
 	Path string
 	Data generic.Map
+
+	// Inherited lists the paths of the manifests this manifest inherits
+	// from, nearest first. Their attributes are already merged into Data.
+	Inherited []string
+
+	// Problems lists the attributes of the manifest files that do not match
+	// the schema push understands; see CheckSchema.
+	Problems []SchemaProblem
 }
 
 func NewEmptyManifest() (m *Manifest) {
@@ -173,6 +181,8 @@ func mapToAppParams(basePath string, yamlMap generic.Map) (models.AppParams, err
 	var appParams models.AppParams
 	var errs []error
 	appParams.BuildpackURL = stringValOrDefault(yamlMap, "buildpack", &errs)
+	appParams.Buildpacks = sliceOrNil(yamlMap, "buildpacks", &errs)
+	appParams.Lifecycle = stringVal(yamlMap, "lifecycle", &errs)
 	appParams.DiskQuota = bytesVal(yamlMap, "disk_quota", &errs)
 
 	domainAry := sliceOrNil(yamlMap, "domains", &errs)
@@ -201,11 +211,22 @@ func mapToAppParams(basePath string, yamlMap generic.Map) (models.AppParams, err
 	appParams.NoRoute = boolVal(yamlMap, "no-route", &errs)
 	appParams.NoHostname = boolOrNil(yamlMap, "no-hostname", &errs)
 	appParams.UseRandomRoute = boolVal(yamlMap, "random-route", &errs)
-	appParams.ServicesToBind = sliceOrNil(yamlMap, "services", &errs)
+	appParams.RandomRouteStrategy = stringVal(yamlMap, "random-route-strategy", &errs)
+	parseServices(yamlMap, &appParams, &errs)
 	appParams.EnvironmentVars = envVarOrEmptyMap(yamlMap, &errs)
 	appParams.HealthCheckType = stringVal(yamlMap, "health-check-type", &errs)
+	appParams.HealthCheckHTTPEndpoint = stringVal(yamlMap, "health-check-http-endpoint", &errs)
+	appParams.HealthCheckInvocationTimeout = intVal(yamlMap, "health-check-invocation-timeout", &errs)
+	appParams.ReadinessHealthCheckType = stringVal(yamlMap, "readiness-health-check-type", &errs)
+	appParams.ReadinessHealthCheckHTTPEndpoint = stringVal(yamlMap, "readiness-health-check-http-endpoint", &errs)
+	appParams.ReadinessHealthCheckInterval = intVal(yamlMap, "readiness-health-check-interval", &errs)
 	appParams.AppPorts = intSliceVal(yamlMap, "app-ports", &errs)
 	appParams.Routes = parseRoutes(yamlMap, &errs)
+	appParams.Sidecars = parseSidecars(yamlMap, &errs)
+	appParams.Processes = parseProcesses(yamlMap, &errs)
+	appParams.Metadata = parseMetadata(yamlMap, &errs)
+	parseHooks(yamlMap, &appParams, &errs)
+	parseDocker(yamlMap, &appParams, &errs)
 
 	if appParams.Path != nil {
 		path := *appParams.Path
@@ -487,6 +508,123 @@ func validateEnvVars(input generic.Map) (errs []error) {
 	return
 }
 
+func parseDocker(input generic.Map, appParams *models.AppParams, errs *[]error) {
+	if !input.Has("docker") {
+		return
+	}
+
+	if !generic.IsMappable(input.Get("docker")) {
+		*errs = append(*errs, fmt.Errorf(T("'docker' should be a set of key => value")))
+		return
+	}
+
+	docker := generic.NewMap(input.Get("docker"))
+	appParams.DockerImage = stringVal(docker, "image", errs)
+	appParams.DockerUsername = stringVal(docker, "username", errs)
+	appParams.DockerPassword = stringVal(docker, "password", errs)
+}
+
+// parseHooks reads the scripts push runs locally before and after pushing
+// the app, and how long they may take.
+func parseHooks(input generic.Map, appParams *models.AppParams, errs *[]error) {
+	if !input.Has("hooks") {
+		return
+	}
+
+	if !generic.IsMappable(input.Get("hooks")) {
+		*errs = append(*errs, fmt.Errorf(T("'hooks' should be a set of key => value")))
+		return
+	}
+
+	hooks := generic.NewMap(input.Get("hooks"))
+	appParams.PrePushScript = stringVal(hooks, "pre-push", errs)
+	appParams.PostPushScript = stringVal(hooks, "post-push", errs)
+	appParams.HookTimeout = intVal(hooks, "timeout", errs)
+	if appParams.HookTimeout != nil && *appParams.HookTimeout < 1 {
+		*errs = append(*errs, fmt.Errorf(T("The timeout of 'hooks' must be a positive number of seconds")))
+		appParams.HookTimeout = nil
+	}
+}
+
+// parseServices reads the services to bind the app to. Each service is either
+// the name of a service instance or a map with its 'name' and the
+// 'binding_name' to bind it with.
+func parseServices(input generic.Map, appParams *models.AppParams, errs *[]error) {
+	if !input.Has("services") {
+		return
+	}
+
+	genericServices, ok := input.Get("services").([]interface{})
+	if !ok {
+		*errs = append(*errs, fmt.Errorf(T("Expected {{.PropertyName}} to be a list of strings.", map[string]interface{}{"PropertyName": "services"})))
+		return
+	}
+
+	appParams.ServicesToBind = []string{}
+	for _, genericService := range genericServices {
+		if name, ok := genericService.(string); ok {
+			appParams.ServicesToBind = append(appParams.ServicesToBind, name)
+			continue
+		}
+
+		if !generic.IsMappable(genericService) {
+			*errs = append(*errs, fmt.Errorf(T("each service in 'services' must be a name or have a 'name'")))
+			continue
+		}
+
+		serviceMap := generic.NewMap(genericService)
+		name := stringVal(serviceMap, "name", errs)
+		if name == nil {
+			*errs = append(*errs, fmt.Errorf(T("each service in 'services' must be a name or have a 'name'")))
+			continue
+		}
+
+		appParams.ServicesToBind = append(appParams.ServicesToBind, *name)
+		if bindingName := stringVal(serviceMap, "binding_name", errs); bindingName != nil {
+			if appParams.ServiceBindingNames == nil {
+				appParams.ServiceBindingNames = map[string]string{}
+			}
+			appParams.ServiceBindingNames[*name] = *bindingName
+		}
+	}
+}
+
+// parseMetadata reads the labels and annotations of the app.
+func parseMetadata(input generic.Map, errs *[]error) *models.Metadata {
+	if !input.Has("metadata") {
+		return nil
+	}
+
+	if !generic.IsMappable(input.Get("metadata")) {
+		*errs = append(*errs, fmt.Errorf(T("'metadata' should be a set of key => value")))
+		return nil
+	}
+
+	metadataMap := generic.NewMap(input.Get("metadata"))
+	return &models.Metadata{
+		Labels:      stringMapVal(metadataMap, "labels", errs),
+		Annotations: stringMapVal(metadataMap, "annotations", errs),
+	}
+}
+
+func stringMapVal(yamlMap generic.Map, key string, errs *[]error) map[string]string {
+	if !yamlMap.Has(key) {
+		return nil
+	}
+
+	if !generic.IsMappable(yamlMap.Get(key)) {
+		*errs = append(*errs, fmt.Errorf(T("Expected {{.Name}} to be a set of key => value, but it was a {{.Type}}.",
+			map[string]interface{}{"Name": key, "Type": yamlMap.Get(key)})))
+		return nil
+	}
+
+	values := map[string]string{}
+	generic.Each(generic.NewMap(yamlMap.Get(key)), func(name, value interface{}) {
+		values[fmt.Sprintf("%v", name)] = coerceToString(value)
+	})
+	return values
+}
+
 func parseRoutes(input generic.Map, errs *[]error) []models.ManifestRoute {
 	if !input.Has("routes") {
 		return nil
@@ -517,3 +655,90 @@ func parseRoutes(input generic.Map, errs *[]error) []models.ManifestRoute {
 
 	return manifestRoutes
 }
+
+func parseSidecars(input generic.Map, errs *[]error) []models.Sidecar {
+	if !input.Has("sidecars") {
+		return nil
+	}
+
+	genericSidecars, ok := input.Get("sidecars").([]interface{})
+	if !ok {
+		*errs = append(*errs, fmt.Errorf(T("'sidecars' should be a list")))
+		return nil
+	}
+
+	sidecars := []models.Sidecar{}
+	for _, genericSidecar := range genericSidecars {
+		if !generic.IsMappable(genericSidecar) {
+			*errs = append(*errs, fmt.Errorf(T("each sidecar in 'sidecars' must have a 'name' and a 'command'")))
+			continue
+		}
+
+		sidecarMap := generic.NewMap(genericSidecar)
+		name := stringVal(sidecarMap, "name", errs)
+		command := stringVal(sidecarMap, "command", errs)
+		if name == nil || command == nil {
+			*errs = append(*errs, fmt.Errorf(T("each sidecar in 'sidecars' must have a 'name' and a 'command'")))
+			continue
+		}
+
+		sidecar := models.Sidecar{
+			Name:         *name,
+			Command:      *command,
+			ProcessTypes: sliceOrNil(sidecarMap, "process_types", errs),
+		}
+		if sidecar.ProcessTypes == nil {
+			sidecar.ProcessTypes = []string{"web"}
+		}
+		if memory := bytesVal(sidecarMap, "memory", errs); memory != nil {
+			sidecar.MemoryInMB = *memory
+		}
+
+		sidecars = append(sidecars, sidecar)
+	}
+
+	return sidecars
+}
+
+func parseProcesses(input generic.Map, errs *[]error) []models.ProcessParams {
+	if !input.Has("processes") {
+		return nil
+	}
+
+	genericProcesses, ok := input.Get("processes").([]interface{})
+	if !ok {
+		*errs = append(*errs, fmt.Errorf(T("'processes' should be a list")))
+		return nil
+	}
+
+	processes := []models.ProcessParams{}
+	for _, genericProcess := range genericProcesses {
+		if !generic.IsMappable(genericProcess) {
+			*errs = append(*errs, fmt.Errorf(T("each process in 'processes' must have a 'type'")))
+			continue
+		}
+
+		processMap := generic.NewMap(genericProcess)
+		processType := stringVal(processMap, "type", errs)
+		if processType == nil {
+			*errs = append(*errs, fmt.Errorf(T("each process in 'processes' must have a 'type'")))
+			continue
+		}
+
+		processes = append(processes, models.ProcessParams{
+			Type:                         *processType,
+			Instances:                    intVal(processMap, "instances", errs),
+			MemoryInMB:                   bytesVal(processMap, "memory", errs),
+			DiskInMB:                     bytesVal(processMap, "disk_quota", errs),
+			HealthCheckType:              stringVal(processMap, "health-check-type", errs),
+			HealthCheckHTTPEndpoint:      stringVal(processMap, "health-check-http-endpoint", errs),
+			HealthCheckInvocationTimeout: intVal(processMap, "health-check-invocation-timeout", errs),
+
+			ReadinessHealthCheckType:         stringVal(processMap, "readiness-health-check-type", errs),
+			ReadinessHealthCheckHTTPEndpoint: stringVal(processMap, "readiness-health-check-http-endpoint", errs),
+			ReadinessHealthCheckInterval:     intVal(processMap, "readiness-health-check-interval", errs),
+		})
+	}
+
+	return processes
+}
@@ -35,52 +35,19 @@ func (repo DiskRepository) ReadManifest(inputPath string) (*Manifest, error) {
 
 	m.Path = manifestPath
 
-	mapp, err := repo.readAllYAMLFiles(manifestPath)
+	resolver := newResolver()
+	mapp, err := resolver.resolve(manifestPath)
 	if err != nil {
 		return m, err
 	}
 
 	m.Data = mapp
+	m.Inherited = resolver.inherited
+	m.Problems = resolver.problems
 
 	return m, nil
 }
 
-func (repo DiskRepository) readAllYAMLFiles(path string) (mergedMap generic.Map, err error) {
-	file, err := os.Open(filepath.Clean(path))
-	if err != nil {
-		return
-	}
-	defer file.Close()
-
-	mapp, err := parseManifest(file)
-	if err != nil {
-		return
-	}
-
-	if !mapp.Has("inherit") {
-		mergedMap = mapp
-		return
-	}
-
-	inheritedPath, ok := mapp.Get("inherit").(string)
-	if !ok {
-		err = errors.New(T("invalid inherit path in manifest"))
-		return
-	}
-
-	if !filepath.IsAbs(inheritedPath) {
-		inheritedPath = filepath.Join(filepath.Dir(path), inheritedPath)
-	}
-
-	inheritedMap, err := repo.readAllYAMLFiles(inheritedPath)
-	if err != nil {
-		return
-	}
-
-	mergedMap = generic.DeepMerge(inheritedMap, mapp)
-	return
-}
-
 func parseManifest(file io.Reader) (yamlMap generic.Map, err error) {
 	manifest, err := ioutil.ReadAll(file)
 	if err != nil {
@@ -135,6 +135,7 @@ var _ = Describe("ManifestDiskRepository", func() {
 	It("merges manifests with their 'inherited' manifests", func() {
 		m, err := repo.ReadManifest("../../fixtures/manifests/inherited-manifest.yml")
 		Expect(err).NotTo(HaveOccurred())
+		Expect(m.Inherited).To(Equal([]string{filepath.Clean("../../fixtures/manifests/base-manifest.yml")}))
 
 		applications, err := m.Applications()
 		Expect(err).NotTo(HaveOccurred())
@@ -155,6 +156,34 @@ var _ = Describe("ManifestDiskRepository", func() {
 		Expect(services).To(Equal([]string{"base-service", "foo-service"}))
 	})
 
+	It("merges applications with the same name as an inherited application into it", func() {
+		m, err := repo.ReadManifest("../../fixtures/manifests/inherit-override-manifest.yml")
+		Expect(err).NotTo(HaveOccurred())
+
+		applications, err := m.Applications()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applications).To(HaveLen(2))
+
+		Expect(*applications[0].Name).To(Equal("base-app"))
+		Expect(*applications[0].InstanceCount).To(Equal(4))
+		Expect(*applications[0].Memory).To(Equal(int64(1024)))
+		Expect(applications[0].ServicesToBind).To(Equal([]string{"base-service"}))
+		Expect(*applications[0].EnvironmentVars).To(Equal(map[string]interface{}{
+			"foo":                "overridden",
+			"will-be-overridden": "baz",
+		}))
+
+		Expect(*applications[1].Name).To(Equal("other-app"))
+		Expect(*applications[1].InstanceCount).To(Equal(2))
+		Expect(*applications[1].Memory).To(Equal(int64(1024)))
+	})
+
+	It("returns an error when manifests inherit from each other", func() {
+		_, err := repo.ReadManifest("../../fixtures/manifests/inherit-cycle-a.yml")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("inherit-cycle-a.yml inherits from itself"))
+	})
+
 	It("supports yml merges", func() {
 		m, err := repo.ReadManifest("../../fixtures/manifests/merge-manifest.yml")
 		Expect(err).NotTo(HaveOccurred())
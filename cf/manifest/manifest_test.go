@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/cli/cf/manifest"
+	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/utils/generic"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -277,21 +278,22 @@ var _ = Describe("Manifests", func() {
 		m := NewManifest("/some/path", generic.NewMap(map[interface{}]interface{}{
 			"applications": []interface{}{
 				map[interface{}]interface{}{
-					"buildpack":         "my-buildpack",
-					"disk_quota":        "512M",
-					"domain":            "my-domain",
-					"domains":           []interface{}{"domain1.test", "domain2.test"},
-					"host":              "my-hostname",
-					"hosts":             []interface{}{"host-1", "host-2"},
-					"name":              "my-app-name",
-					"stack":             "my-stack",
-					"memory":            "256M",
-					"health-check-type": "none",
-					"instances":         1,
-					"timeout":           11,
-					"no-route":          true,
-					"no-hostname":       true,
-					"random-route":      true,
+					"buildpack":             "my-buildpack",
+					"disk_quota":            "512M",
+					"domain":                "my-domain",
+					"domains":               []interface{}{"domain1.test", "domain2.test"},
+					"host":                  "my-hostname",
+					"hosts":                 []interface{}{"host-1", "host-2"},
+					"name":                  "my-app-name",
+					"stack":                 "my-stack",
+					"memory":                "256M",
+					"health-check-type":     "none",
+					"instances":             1,
+					"timeout":               11,
+					"no-route":              true,
+					"no-hostname":           true,
+					"random-route":          true,
+					"random-route-strategy": "uuid",
 				},
 			},
 		}))
@@ -313,6 +315,7 @@ var _ = Describe("Manifests", func() {
 		Expect(apps[0].NoRoute).To(BeTrue())
 		Expect(*apps[0].NoHostname).To(BeTrue())
 		Expect(apps[0].UseRandomRoute).To(BeTrue())
+		Expect(*apps[0].RandomRouteStrategy).To(Equal("uuid"))
 	})
 
 	It("removes duplicated values in 'hosts' and 'domains'", func() {
@@ -541,6 +544,163 @@ var _ = Describe("Manifests", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(app[0].ServicesToBind).To(Equal([]string{"service-1", "service-2"}))
+			Expect(app[0].ServiceBindingNames).To(BeNil())
+		})
+
+		It("can read services with a binding name", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"services": []interface{}{
+					"service-1",
+					map[interface{}]interface{}{"name": "service-2", "binding_name": "my-binding"},
+					map[interface{}]interface{}{"name": "service-3"},
+				},
+			}))
+
+			app, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(app[0].ServicesToBind).To(Equal([]string{"service-1", "service-2", "service-3"}))
+			Expect(app[0].ServiceBindingNames).To(Equal(map[string]string{"service-2": "my-binding"}))
+		})
+
+		It("errors when a service has no name", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"services": []interface{}{
+					map[interface{}]interface{}{"binding_name": "my-binding"},
+				},
+			}))
+
+			_, err := m.Applications()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("each service in 'services' must be a name or have a 'name'"))
+		})
+	})
+
+	Context("parsing metadata", func() {
+		It("reads the labels and annotations of the app", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"metadata": map[interface{}]interface{}{
+							"labels":      map[interface{}]interface{}{"team": "payments", "tier": 1},
+							"annotations": map[interface{}]interface{}{"contact": "payments@example.com"},
+						},
+					}),
+				},
+			}))
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apps[0].Metadata).To(Equal(&models.Metadata{
+				Labels:      map[string]string{"team": "payments", "tier": "1"},
+				Annotations: map[string]string{"contact": "payments@example.com"},
+			}))
+		})
+
+		It("errors when the labels are not a map", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"metadata": map[interface{}]interface{}{
+							"labels": []interface{}{"team"},
+						},
+					}),
+				},
+			}))
+
+			_, err := m.Applications()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected labels to be a set of key => value"))
+		})
+	})
+
+	Context("parsing hooks", func() {
+		It("reads the pre-push and post-push scripts and their timeout", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"hooks": map[interface{}]interface{}{
+							"pre-push":  "npm run build",
+							"post-push": "./smoke-test.sh",
+							"timeout":   120,
+						},
+					}),
+				},
+			}))
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*apps[0].PrePushScript).To(Equal("npm run build"))
+			Expect(*apps[0].PostPushScript).To(Equal("./smoke-test.sh"))
+			Expect(*apps[0].HookTimeout).To(Equal(120))
+		})
+
+		It("errors when the timeout is not positive", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"hooks": map[interface{}]interface{}{"pre-push": "make", "timeout": 0},
+					}),
+				},
+			}))
+
+			_, err := m.Applications()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("The timeout of 'hooks' must be a positive number of seconds"))
+		})
+	})
+
+	Context("when docker properties are provided", func() {
+		It("parses the docker image and credentials", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"docker": map[interface{}]interface{}{
+							"image":    "some-org/some-image:latest",
+							"username": "some-user",
+							"password": "some-password",
+						},
+					}),
+				},
+			}))
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*apps[0].DockerImage).To(Equal("some-org/some-image:latest"))
+			Expect(*apps[0].DockerUsername).To(Equal("some-user"))
+			Expect(*apps[0].DockerPassword).To(Equal("some-password"))
+		})
+
+		It("leaves the credentials unset when only an image is given", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"docker": map[interface{}]interface{}{
+							"image": "some-image",
+						},
+					}),
+				},
+			}))
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*apps[0].DockerImage).To(Equal("some-image"))
+			Expect(apps[0].DockerUsername).To(BeNil())
+			Expect(apps[0].DockerPassword).To(BeNil())
+		})
+
+		It("returns an error when docker is not a map", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"docker": "some-image",
+					}),
+				},
+			}))
+
+			_, err := m.Applications()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("'docker' should be a set of key => value"))
 		})
 	})
 
@@ -719,4 +879,200 @@ var _ = Describe("Manifests", func() {
 			})
 		})
 	})
+
+	Context("when buildpacks are provided", func() {
+		It("parses the buildpacks into app params", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"buildpacks": []interface{}{"go_buildpack", "binary_buildpack"},
+					}),
+				},
+			}))
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apps[0].Buildpacks).To(Equal([]string{"go_buildpack", "binary_buildpack"}))
+			Expect(apps[0].BuildpackURL).To(BeNil())
+		})
+
+		It("parses the lifecycle into app params", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"lifecycle":  "cnb",
+						"buildpacks": []interface{}{"docker://registry.example.com/go-cnb"},
+					}),
+				},
+			}))
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*apps[0].Lifecycle).To(Equal("cnb"))
+		})
+
+		It("errors when 'buildpacks' is not a list of strings", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"buildpacks": "go_buildpack",
+					}),
+				},
+			}))
+
+			_, err := m.Applications()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Expected buildpacks to be a list of strings."))
+		})
+	})
+
+	Context("when sidecars are provided", func() {
+		It("parses the sidecars into app params", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"sidecars": []interface{}{
+							map[interface{}]interface{}{"name": "proxy", "command": "./proxy", "memory": "64M"},
+							map[interface{}]interface{}{"name": "shipper", "command": "./ship-logs", "process_types": []interface{}{"web", "worker"}},
+						},
+					}),
+				},
+			}))
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apps[0].Sidecars).To(Equal([]models.Sidecar{
+				{Name: "proxy", Command: "./proxy", ProcessTypes: []string{"web"}, MemoryInMB: 64},
+				{Name: "shipper", Command: "./ship-logs", ProcessTypes: []string{"web", "worker"}},
+			}))
+		})
+
+		It("errors when 'sidecars' is not a list", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"sidecars": "proxy",
+					}),
+				},
+			}))
+
+			_, err := m.Applications()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("'sidecars' should be a list"))
+		})
+
+		It("errors when a sidecar has no command", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"sidecars": []interface{}{
+							map[interface{}]interface{}{"name": "proxy"},
+						},
+					}),
+				},
+			}))
+
+			_, err := m.Applications()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("each sidecar in 'sidecars' must have a 'name' and a 'command'"))
+		})
+	})
+
+	Context("when health checks are provided", func() {
+		It("parses the health check of the app and of each process", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"health-check-type":               "http",
+						"health-check-http-endpoint":      "/health",
+						"health-check-invocation-timeout": 5,
+						"processes": []interface{}{
+							map[interface{}]interface{}{"type": "worker", "health-check-type": "process"},
+							map[interface{}]interface{}{"type": "api", "health-check-type": "http", "health-check-http-endpoint": "/ping", "health-check-invocation-timeout": 3},
+						},
+					}),
+				},
+			}))
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*apps[0].HealthCheckType).To(Equal("http"))
+			Expect(*apps[0].HealthCheckHTTPEndpoint).To(Equal("/health"))
+			Expect(*apps[0].HealthCheckInvocationTimeout).To(Equal(5))
+
+			processType := "process"
+			httpType := "http"
+			endpoint := "/ping"
+			invocationTimeout := 3
+			Expect(apps[0].Processes).To(Equal([]models.ProcessParams{
+				{Type: "worker", HealthCheckType: &processType},
+				{Type: "api", HealthCheckType: &httpType, HealthCheckHTTPEndpoint: &endpoint, HealthCheckInvocationTimeout: &invocationTimeout},
+			}))
+		})
+
+		It("parses the readiness check of the app and of each process", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"readiness-health-check-type":          "http",
+						"readiness-health-check-http-endpoint": "/ready",
+						"readiness-health-check-interval":      10,
+						"processes": []interface{}{
+							map[interface{}]interface{}{"type": "worker", "readiness-health-check-type": "process", "readiness-health-check-interval": 30},
+						},
+					}),
+				},
+			}))
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(*apps[0].ReadinessHealthCheckType).To(Equal("http"))
+			Expect(*apps[0].ReadinessHealthCheckHTTPEndpoint).To(Equal("/ready"))
+			Expect(*apps[0].ReadinessHealthCheckInterval).To(Equal(10))
+
+			processType := "process"
+			interval := 30
+			Expect(apps[0].Processes).To(Equal([]models.ProcessParams{
+				{Type: "worker", ReadinessHealthCheckType: &processType, ReadinessHealthCheckInterval: &interval},
+			}))
+		})
+
+		It("parses the instances, memory and disk of each process", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"processes": []interface{}{
+							map[interface{}]interface{}{"type": "worker", "instances": 3, "memory": "256M", "disk_quota": "1G"},
+						},
+					}),
+				},
+			}))
+
+			apps, err := m.Applications()
+			Expect(err).NotTo(HaveOccurred())
+
+			instances := 3
+			memory := int64(256)
+			disk := int64(1024)
+			Expect(apps[0].Processes).To(Equal([]models.ProcessParams{
+				{Type: "worker", Instances: &instances, MemoryInMB: &memory, DiskInMB: &disk},
+			}))
+		})
+
+		It("errors when a process has no type", func() {
+			m := NewManifest("/some/path/manifest.yml", generic.NewMap(map[interface{}]interface{}{
+				"applications": []interface{}{
+					generic.NewMap(map[interface{}]interface{}{
+						"processes": []interface{}{
+							map[interface{}]interface{}{"health-check-type": "port"},
+						},
+					}),
+				},
+			}))
+
+			_, err := m.Applications()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("each process in 'processes' must have a 'type'"))
+		})
+	})
 })
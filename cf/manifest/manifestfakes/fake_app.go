@@ -34,6 +34,13 @@ type FakeApp struct {
 		arg1 string
 		arg2 string
 	}
+	ServiceBindingStub        func(string, string, string)
+	serviceBindingMutex       sync.RWMutex
+	serviceBindingArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
 	StartCommandStub        func(string, string)
 	startCommandMutex       sync.RWMutex
 	startCommandArgsForCall []struct {
@@ -86,6 +93,31 @@ type FakeApp struct {
 		arg1 string
 		arg2 []int
 	}
+	DockerStub        func(string, string, string)
+	dockerMutex       sync.RWMutex
+	dockerArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	ProcessStub        func(string, models.Process)
+	processMutex       sync.RWMutex
+	processArgsForCall []struct {
+		arg1 string
+		arg2 models.Process
+	}
+	SidecarStub        func(string, models.Sidecar)
+	sidecarMutex       sync.RWMutex
+	sidecarArgsForCall []struct {
+		arg1 string
+		arg2 models.Sidecar
+	}
+	MetadataStub        func(string, models.Metadata)
+	metadataMutex       sync.RWMutex
+	metadataArgsForCall []struct {
+		arg1 string
+		arg2 models.Metadata
+	}
 	SaveStub        func(f io.Writer) error
 	saveMutex       sync.RWMutex
 	saveArgsForCall []struct {
@@ -198,6 +230,32 @@ func (fake *FakeApp) ServiceArgsForCall(i int) (string, string) {
 	return fake.serviceArgsForCall[i].arg1, fake.serviceArgsForCall[i].arg2
 }
 
+func (fake *FakeApp) ServiceBinding(arg1 string, arg2 string, arg3 string) {
+	fake.serviceBindingMutex.Lock()
+	fake.serviceBindingArgsForCall = append(fake.serviceBindingArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ServiceBinding", []interface{}{arg1, arg2, arg3})
+	fake.serviceBindingMutex.Unlock()
+	if fake.ServiceBindingStub != nil {
+		fake.ServiceBindingStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeApp) ServiceBindingCallCount() int {
+	fake.serviceBindingMutex.RLock()
+	defer fake.serviceBindingMutex.RUnlock()
+	return len(fake.serviceBindingArgsForCall)
+}
+
+func (fake *FakeApp) ServiceBindingArgsForCall(i int) (string, string, string) {
+	fake.serviceBindingMutex.RLock()
+	defer fake.serviceBindingMutex.RUnlock()
+	return fake.serviceBindingArgsForCall[i].arg1, fake.serviceBindingArgsForCall[i].arg2, fake.serviceBindingArgsForCall[i].arg3
+}
+
 func (fake *FakeApp) StartCommand(arg1 string, arg2 string) {
 	fake.startCommandMutex.Lock()
 	fake.startCommandArgsForCall = append(fake.startCommandArgsForCall, struct {
@@ -407,6 +465,107 @@ func (fake *FakeApp) AppPortsArgsForCall(i int) (string, []int) {
 	return fake.appPortsArgsForCall[i].arg1, fake.appPortsArgsForCall[i].arg2
 }
 
+func (fake *FakeApp) Docker(arg1 string, arg2 string, arg3 string) {
+	fake.dockerMutex.Lock()
+	fake.dockerArgsForCall = append(fake.dockerArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("Docker", []interface{}{arg1, arg2, arg3})
+	fake.dockerMutex.Unlock()
+	if fake.DockerStub != nil {
+		fake.DockerStub(arg1, arg2, arg3)
+	}
+}
+
+func (fake *FakeApp) DockerCallCount() int {
+	fake.dockerMutex.RLock()
+	defer fake.dockerMutex.RUnlock()
+	return len(fake.dockerArgsForCall)
+}
+
+func (fake *FakeApp) DockerArgsForCall(i int) (string, string, string) {
+	fake.dockerMutex.RLock()
+	defer fake.dockerMutex.RUnlock()
+	return fake.dockerArgsForCall[i].arg1, fake.dockerArgsForCall[i].arg2, fake.dockerArgsForCall[i].arg3
+}
+
+func (fake *FakeApp) Process(arg1 string, arg2 models.Process) {
+	fake.processMutex.Lock()
+	fake.processArgsForCall = append(fake.processArgsForCall, struct {
+		arg1 string
+		arg2 models.Process
+	}{arg1, arg2})
+	fake.recordInvocation("Process", []interface{}{arg1, arg2})
+	fake.processMutex.Unlock()
+	if fake.ProcessStub != nil {
+		fake.ProcessStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeApp) ProcessCallCount() int {
+	fake.processMutex.RLock()
+	defer fake.processMutex.RUnlock()
+	return len(fake.processArgsForCall)
+}
+
+func (fake *FakeApp) ProcessArgsForCall(i int) (string, models.Process) {
+	fake.processMutex.RLock()
+	defer fake.processMutex.RUnlock()
+	return fake.processArgsForCall[i].arg1, fake.processArgsForCall[i].arg2
+}
+
+func (fake *FakeApp) Sidecar(arg1 string, arg2 models.Sidecar) {
+	fake.sidecarMutex.Lock()
+	fake.sidecarArgsForCall = append(fake.sidecarArgsForCall, struct {
+		arg1 string
+		arg2 models.Sidecar
+	}{arg1, arg2})
+	fake.recordInvocation("Sidecar", []interface{}{arg1, arg2})
+	fake.sidecarMutex.Unlock()
+	if fake.SidecarStub != nil {
+		fake.SidecarStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeApp) SidecarCallCount() int {
+	fake.sidecarMutex.RLock()
+	defer fake.sidecarMutex.RUnlock()
+	return len(fake.sidecarArgsForCall)
+}
+
+func (fake *FakeApp) SidecarArgsForCall(i int) (string, models.Sidecar) {
+	fake.sidecarMutex.RLock()
+	defer fake.sidecarMutex.RUnlock()
+	return fake.sidecarArgsForCall[i].arg1, fake.sidecarArgsForCall[i].arg2
+}
+
+func (fake *FakeApp) Metadata(arg1 string, arg2 models.Metadata) {
+	fake.metadataMutex.Lock()
+	fake.metadataArgsForCall = append(fake.metadataArgsForCall, struct {
+		arg1 string
+		arg2 models.Metadata
+	}{arg1, arg2})
+	fake.recordInvocation("Metadata", []interface{}{arg1, arg2})
+	fake.metadataMutex.Unlock()
+	if fake.MetadataStub != nil {
+		fake.MetadataStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeApp) MetadataCallCount() int {
+	fake.metadataMutex.RLock()
+	defer fake.metadataMutex.RUnlock()
+	return len(fake.metadataArgsForCall)
+}
+
+func (fake *FakeApp) MetadataArgsForCall(i int) (string, models.Metadata) {
+	fake.metadataMutex.RLock()
+	defer fake.metadataMutex.RUnlock()
+	return fake.metadataArgsForCall[i].arg1, fake.metadataArgsForCall[i].arg2
+}
+
 func (fake *FakeApp) Save(f io.Writer) error {
 	fake.saveMutex.Lock()
 	fake.saveArgsForCall = append(fake.saveArgsForCall, struct {
@@ -451,6 +610,8 @@ func (fake *FakeApp) Invocations() map[string][][]interface{} {
 	defer fake.memoryMutex.RUnlock()
 	fake.serviceMutex.RLock()
 	defer fake.serviceMutex.RUnlock()
+	fake.serviceBindingMutex.RLock()
+	defer fake.serviceBindingMutex.RUnlock()
 	fake.startCommandMutex.RLock()
 	defer fake.startCommandMutex.RUnlock()
 	fake.environmentVarsMutex.RLock()
@@ -467,6 +628,14 @@ func (fake *FakeApp) Invocations() map[string][][]interface{} {
 	defer fake.stackMutex.RUnlock()
 	fake.appPortsMutex.RLock()
 	defer fake.appPortsMutex.RUnlock()
+	fake.dockerMutex.RLock()
+	defer fake.dockerMutex.RUnlock()
+	fake.processMutex.RLock()
+	defer fake.processMutex.RUnlock()
+	fake.sidecarMutex.RLock()
+	defer fake.sidecarMutex.RUnlock()
+	fake.metadataMutex.RLock()
+	defer fake.metadataMutex.RUnlock()
 	fake.saveMutex.RLock()
 	defer fake.saveMutex.RUnlock()
 	return fake.invocations
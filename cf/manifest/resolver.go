@@ -0,0 +1,194 @@
+package manifest
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/errors"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/utils/generic"
+)
+
+// resolver reads a manifest along with the chain of manifests it inherits
+// from and merges them into one. A manifest's properties take precedence over
+// those of the manifest it inherits from:
+//
+//   - maps, such as env, are merged key by key
+//   - lists, such as services, are concatenated, the inherited entries first
+//   - applications with the same name are merged as maps, in the position of
+//     the inherited application; other applications are appended
+//   - any other value, or a value whose kind differs from the inherited one,
+//     replaces the inherited value
+//
+// YAML anchors and aliases, including << merge keys, are expanded by the YAML
+// parser within each file before the files are merged. Every value is copied
+// while merging, so aliased maps are never shared between applications.
+type resolver struct {
+	chain     []string
+	inherited []string
+	problems  []SchemaProblem
+}
+
+func newResolver() *resolver {
+	return &resolver{}
+}
+
+func (r *resolver) resolve(path string) (generic.Map, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, visited := range r.chain {
+		if visited == absPath {
+			return nil, errors.New(T("Manifest {{.Path}} inherits from itself", map[string]interface{}{"Path": path}))
+		}
+	}
+	r.chain = append(r.chain, absPath)
+	defer func() {
+		r.chain = r.chain[:len(r.chain)-1]
+	}()
+
+	source, err := ioutil.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	mapp, err := parseManifest(bytes.NewReader(source))
+	if err != nil {
+		return nil, err
+	}
+	r.problems = append(r.problems, checkSchema(path, source, mapp)...)
+
+	if !mapp.Has("inherit") {
+		return copyValue(mapp).(generic.Map), nil
+	}
+
+	inheritedPath, ok := mapp.Get("inherit").(string)
+	if !ok {
+		return nil, errors.New(T("invalid inherit path in manifest"))
+	}
+
+	if !filepath.IsAbs(inheritedPath) {
+		inheritedPath = filepath.Join(filepath.Dir(path), inheritedPath)
+	}
+
+	r.inherited = append(r.inherited, inheritedPath)
+	inheritedMap, err := r.resolve(inheritedPath)
+	if err != nil {
+		return nil, err
+	}
+
+	mapp = mapp.Except([]interface{}{"inherit"})
+	return mergeMaps(inheritedMap, mapp), nil
+}
+
+func mergeMaps(base generic.Map, override generic.Map) generic.Map {
+	merged := copyValue(base).(generic.Map)
+
+	generic.Each(override, func(key, value interface{}) {
+		if !merged.Has(key) {
+			merged.Set(key, copyValue(value))
+			return
+		}
+
+		if key == "applications" {
+			merged.Set(key, mergeApplications(merged.Get(key), value))
+			return
+		}
+
+		merged.Set(key, mergeValues(merged.Get(key), value))
+	})
+
+	return merged
+}
+
+func mergeValues(base interface{}, override interface{}) interface{} {
+	switch {
+	case generic.IsMappable(base) && generic.IsMappable(override):
+		return mergeMaps(generic.NewMap(base), generic.NewMap(override))
+	case isList(base) && isList(override):
+		merged := copyValue(base).([]interface{})
+		return append(merged, copyValue(override).([]interface{})...)
+	default:
+		return copyValue(override)
+	}
+}
+
+func mergeApplications(base interface{}, override interface{}) interface{} {
+	if !isList(base) || !isList(override) {
+		return copyValue(override)
+	}
+
+	merged := copyValue(base).([]interface{})
+	for _, app := range override.([]interface{}) {
+		index := indexOfApplication(merged, applicationName(app))
+		if index < 0 {
+			merged = append(merged, copyValue(app))
+			continue
+		}
+
+		merged[index] = mergeValues(merged[index], app)
+	}
+
+	return merged
+}
+
+func indexOfApplication(apps []interface{}, name interface{}) int {
+	if name == nil {
+		return -1
+	}
+
+	for i, app := range apps {
+		if applicationName(app) == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func applicationName(app interface{}) interface{} {
+	if !generic.IsMappable(app) {
+		return nil
+	}
+
+	name, ok := generic.NewMap(app).Get("name").(string)
+	if !ok {
+		return nil
+	}
+	return name
+}
+
+func isList(value interface{}) bool {
+	_, ok := value.([]interface{})
+	return ok
+}
+
+// copyValue deep copies maps and lists, keeping maps read from YAML as plain
+// maps since parts of the manifest parser expect them that way.
+func copyValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case generic.Map:
+		copied := generic.NewMap()
+		generic.Each(value, func(key, val interface{}) {
+			copied.Set(key, copyValue(val))
+		})
+		return copied
+	case map[interface{}]interface{}:
+		copied := make(map[interface{}]interface{}, len(value))
+		for key, val := range value {
+			copied[key] = copyValue(val)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(value))
+		for i, val := range value {
+			copied[i] = copyValue(val)
+		}
+		return copied
+	default:
+		return value
+	}
+}
@@ -0,0 +1,354 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/formatters"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/utils/generic"
+)
+
+type ProblemKind int
+
+const (
+	UnknownAttribute ProblemKind = iota
+	TypeMismatch
+	DeprecatedAttribute
+)
+
+// SchemaProblem is an attribute of a manifest file that does not match what
+// push understands.
+type SchemaProblem struct {
+	Kind    ProblemKind
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p SchemaProblem) Error() string {
+	if p.Line == 0 {
+		return fmt.Sprintf("%s: %s", p.File, p.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", p.File, p.Line, p.Column, p.Message)
+}
+
+// IsError tells whether the problem makes the manifest invalid, rather than
+// being worth a warning.
+func (p SchemaProblem) IsError(lenient bool) bool {
+	switch p.Kind {
+	case DeprecatedAttribute:
+		return false
+	case UnknownAttribute:
+		return !lenient
+	default:
+		return true
+	}
+}
+
+// CheckSchema returns the deprecated attributes of the manifest as warnings
+// and an error listing its attributes of the wrong type and the attributes
+// push does not know. With lenient, unknown attributes are warnings instead,
+// and are ignored as they were before the schema was checked.
+func (m Manifest) CheckSchema(lenient bool) ([]SchemaProblem, error) {
+	warnings := []SchemaProblem{}
+	messages := []string{}
+
+	for _, problem := range m.Problems {
+		if !problem.IsError(lenient) {
+			warnings = append(warnings, problem)
+			continue
+		}
+		messages = append(messages, problem.Error())
+	}
+
+	if len(messages) > 0 {
+		return warnings, errors.New(strings.Join(messages, "\n"))
+	}
+	return warnings, nil
+}
+
+type valueKind int
+
+const (
+	stringKind valueKind = iota
+	boolKind
+	intKind
+	bytesKind
+	stringListKind
+	intListKind
+	keyValueKind
+	objectKind
+	objectListKind
+	serviceListKind
+)
+
+type attribute struct {
+	kind       valueKind
+	fields     map[string]attribute
+	deprecated string
+}
+
+var healthCheckAttributes = map[string]attribute{
+	"health-check-type":                    {kind: stringKind},
+	"health-check-http-endpoint":           {kind: stringKind},
+	"health-check-invocation-timeout":      {kind: intKind},
+	"readiness-health-check-type":          {kind: stringKind},
+	"readiness-health-check-http-endpoint": {kind: stringKind},
+	"readiness-health-check-interval":      {kind: intKind},
+}
+
+var appAttributes = withAttributes(healthCheckAttributes, map[string]attribute{
+	"name":                  {kind: stringKind},
+	"path":                  {kind: stringKind},
+	"buildpack":             {kind: stringKind, deprecated: "buildpacks"},
+	"buildpacks":            {kind: stringListKind},
+	"lifecycle":             {kind: stringKind},
+	"command":               {kind: stringKind},
+	"stack":                 {kind: stringKind},
+	"memory":                {kind: bytesKind},
+	"disk_quota":            {kind: bytesKind},
+	"instances":             {kind: intKind},
+	"timeout":               {kind: intKind},
+	"domain":                {kind: stringKind, deprecated: "routes"},
+	"domains":               {kind: stringListKind, deprecated: "routes"},
+	"host":                  {kind: stringKind, deprecated: "routes"},
+	"hosts":                 {kind: stringListKind, deprecated: "routes"},
+	"no-hostname":           {kind: boolKind, deprecated: "routes"},
+	"no-route":              {kind: boolKind},
+	"random-route":          {kind: boolKind},
+	"random-route-strategy": {kind: stringKind},
+	"app-ports":             {kind: intListKind},
+	"env":                   {kind: keyValueKind},
+	"services":              {kind: serviceListKind},
+	"routes": {kind: objectListKind, fields: map[string]attribute{
+		"route": {kind: stringKind},
+	}},
+	"docker": {kind: objectKind, fields: map[string]attribute{
+		"image":    {kind: stringKind},
+		"username": {kind: stringKind},
+		"password": {kind: stringKind},
+	}},
+	"hooks": {kind: objectKind, fields: map[string]attribute{
+		"pre-push":  {kind: stringKind},
+		"post-push": {kind: stringKind},
+		"timeout":   {kind: intKind},
+	}},
+	"metadata": {kind: objectKind, fields: map[string]attribute{
+		"labels":      {kind: keyValueKind},
+		"annotations": {kind: keyValueKind},
+	}},
+	"sidecars": {kind: objectListKind, fields: map[string]attribute{
+		"name":          {kind: stringKind},
+		"command":       {kind: stringKind},
+		"process_types": {kind: stringListKind},
+		"memory":        {kind: bytesKind},
+	}},
+	"processes": {kind: objectListKind, fields: withAttributes(healthCheckAttributes, map[string]attribute{
+		"type":       {kind: stringKind},
+		"instances":  {kind: intKind},
+		"memory":     {kind: bytesKind},
+		"disk_quota": {kind: bytesKind},
+	})},
+})
+
+var serviceAttributes = map[string]attribute{
+	"name":         {kind: stringKind},
+	"binding_name": {kind: stringKind},
+}
+
+// the top level of a manifest takes the attributes of an app as well, which
+// are then shared by all of its apps
+var manifestAttributes = withAttributes(appAttributes, map[string]attribute{
+	"inherit":      {kind: stringKind},
+	"applications": {kind: objectListKind, fields: appAttributes},
+})
+
+// IsTopLevelAttribute tells whether push reads the attribute name at the top
+// level of a manifest.
+func IsTopLevelAttribute(name string) bool {
+	_, ok := manifestAttributes[name]
+	return ok
+}
+
+func withAttributes(base map[string]attribute, extra map[string]attribute) map[string]attribute {
+	attributes := map[string]attribute{}
+	for name, attr := range base {
+		attributes[name] = attr
+	}
+	for name, attr := range extra {
+		attributes[name] = attr
+	}
+	return attributes
+}
+
+type schemaChecker struct {
+	file      string
+	positions yamlPositions
+	problems  []SchemaProblem
+}
+
+// checkSchema compares the parsed manifest file at path, whose YAML is
+// source, with the attributes push understands.
+func checkSchema(path string, source []byte, data generic.Map) []SchemaProblem {
+	checker := &schemaChecker{
+		file:      path,
+		positions: newYAMLPositions(source),
+	}
+
+	checker.checkFields("", data, manifestAttributes, true)
+	sort.Stable(problemsByPosition(checker.problems))
+	return checker.problems
+}
+
+func (c *schemaChecker) checkFields(path string, data generic.Map, attributes map[string]attribute, topLevel bool) {
+	keys := []string{}
+	values := map[string]interface{}{}
+	generic.Each(data, func(key, value interface{}) {
+		name := fmt.Sprintf("%v", key)
+		keys = append(keys, name)
+		values[name] = value
+	})
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := joinPath(path, key)
+
+		attr, ok := attributes[key]
+		if !ok {
+			// a top level key that only holds YAML anchors for the apps to
+			// refer to is not meant for push
+			if topLevel && c.positions.definesAnchor(keyPath) {
+				continue
+			}
+			c.add(UnknownAttribute, keyPath, T("Unknown attribute '{{.Name}}'", map[string]interface{}{"Name": keyPath}))
+			continue
+		}
+
+		if attr.deprecated != "" {
+			c.add(DeprecatedAttribute, keyPath, T("Attribute '{{.Name}}' is deprecated; use '{{.Replacement}}' instead",
+				map[string]interface{}{"Name": keyPath, "Replacement": attr.deprecated}))
+		}
+
+		c.checkValue(keyPath, values[key], attr)
+	}
+}
+
+func (c *schemaChecker) checkValue(path string, value interface{}, attr attribute) {
+	if value == nil {
+		// nulls are reported by the manifest parser
+		return
+	}
+
+	switch attr.kind {
+	case stringKind:
+		if _, ok := value.(string); !ok {
+			c.mismatch(path, T("a string"))
+		}
+	case boolKind:
+		switch value.(type) {
+		case bool, string:
+		default:
+			c.mismatch(path, T("a boolean"))
+		}
+	case intKind:
+		switch value := value.(type) {
+		case int, int64:
+		case string:
+			if _, err := strconv.Atoi(value); err != nil {
+				c.mismatch(path, T("a number"))
+			}
+		default:
+			c.mismatch(path, T("a number"))
+		}
+	case bytesKind:
+		if _, err := formatters.ToMegabytes(coerceToString(value)); err != nil {
+			c.mismatch(path, T("a size, such as 256M or 1G"))
+		}
+	case stringListKind, intListKind:
+		items, ok := value.([]interface{})
+		if !ok {
+			c.mismatch(path, T("a list"))
+			return
+		}
+		for i, item := range items {
+			_, isString := item.(string)
+			_, isInt := item.(int)
+			if attr.kind == stringListKind && !isString {
+				c.mismatch(listItemPath(path, i), T("a string"))
+			}
+			if attr.kind == intListKind && !isInt {
+				c.mismatch(listItemPath(path, i), T("an integer"))
+			}
+		}
+	case keyValueKind:
+		if !generic.IsMappable(value) {
+			c.mismatch(path, T("a set of key => value"))
+		}
+	case objectKind:
+		if !generic.IsMappable(value) {
+			c.mismatch(path, T("a set of key => value"))
+			return
+		}
+		c.checkFields(path, generic.NewMap(value), attr.fields, false)
+	case objectListKind, serviceListKind:
+		items, ok := value.([]interface{})
+		if !ok {
+			c.mismatch(path, T("a list"))
+			return
+		}
+		for i, item := range items {
+			itemPath := listItemPath(path, i)
+			if _, isString := item.(string); isString && attr.kind == serviceListKind {
+				continue
+			}
+			if !generic.IsMappable(item) {
+				c.mismatch(itemPath, T("a set of key => value"))
+				continue
+			}
+
+			fields := attr.fields
+			if attr.kind == serviceListKind {
+				fields = serviceAttributes
+			}
+			c.checkFields(itemPath, generic.NewMap(item), fields, false)
+		}
+	}
+}
+
+func (c *schemaChecker) mismatch(path string, expected string) {
+	c.add(TypeMismatch, path, T("Attribute '{{.Name}}' must be {{.Expected}}",
+		map[string]interface{}{"Name": path, "Expected": expected}))
+}
+
+func (c *schemaChecker) add(kind ProblemKind, path string, message string) {
+	problem := SchemaProblem{
+		Kind:    kind,
+		File:    c.file,
+		Message: message,
+	}
+	if pos, ok := c.positions.find(path); ok {
+		problem.Line = pos.Line
+		problem.Column = pos.Column
+	}
+	c.problems = append(c.problems, problem)
+}
+
+func listItemPath(path string, index int) string {
+	return path + "[" + strconv.Itoa(index) + "]"
+}
+
+type problemsByPosition []SchemaProblem
+
+func (p problemsByPosition) Len() int      { return len(p) }
+func (p problemsByPosition) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p problemsByPosition) Less(i, j int) bool {
+	if p[i].Line != p[j].Line {
+		return p[i].Line < p[j].Line
+	}
+	return p[i].Column < p[j].Column
+}
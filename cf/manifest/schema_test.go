@@ -0,0 +1,173 @@
+package manifest_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "code.cloudfoundry.org/cli/cf/manifest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckSchema", func() {
+	var (
+		dir          string
+		manifestPath string
+	)
+
+	readManifest := func(yaml string) *Manifest {
+		err := ioutil.WriteFile(manifestPath, []byte(yaml), 0600)
+		Expect(err).NotTo(HaveOccurred())
+
+		m, err := NewDiskRepository().ReadManifest(manifestPath)
+		Expect(err).NotTo(HaveOccurred())
+		return m
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "manifest-schema")
+		Expect(err).NotTo(HaveOccurred())
+		manifestPath = filepath.Join(dir, "manifest.yml")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("accepts a manifest that only uses known attributes", func() {
+		m := readManifest(`---
+env:
+  SHARED: value
+applications:
+- name: my-app
+  memory: 256M
+  instances: 2
+  buildpacks: [ruby_buildpack]
+  routes:
+  - route: my-app.example.com
+  services:
+  - my-db
+  - name: my-queue
+    binding_name: queue
+  metadata:
+    labels:
+      team: payments
+  processes:
+  - type: worker
+    instances: 1
+    health-check-type: process
+`)
+
+		warnings, err := m.CheckSchema(false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	It("reports unknown attributes with their line and column", func() {
+		m := readManifest(`---
+applications:
+- name: my-app
+  memroy: 256M
+  docker:
+    image: my/image
+    tag: latest
+`)
+
+		_, err := m.CheckSchema(false)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal(
+			manifestPath + ":4:3: Unknown attribute 'applications[0].memroy'\n" +
+				manifestPath + ":7:5: Unknown attribute 'applications[0].docker.tag'"))
+	})
+
+	It("reports attributes of the wrong type with their line and column", func() {
+		m := readManifest(`---
+applications:
+  - name: my-app
+    instances: many
+    memory: lots
+    buildpacks:
+    - ruby_buildpack
+    - 42
+    routes: my-app.example.com
+`)
+
+		_, err := m.CheckSchema(true)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal(
+			manifestPath + ":4:5: Attribute 'applications[0].instances' must be a number\n" +
+				manifestPath + ":5:5: Attribute 'applications[0].memory' must be a size, such as 256M or 1G\n" +
+				manifestPath + ":8:7: Attribute 'applications[0].buildpacks[1]' must be a string\n" +
+				manifestPath + ":9:5: Attribute 'applications[0].routes' must be a list"))
+	})
+
+	It("warns about deprecated attributes", func() {
+		m := readManifest(`---
+applications:
+- name: my-app
+  host: my-app
+  domain: example.com
+`)
+
+		warnings, err := m.CheckSchema(false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(HaveLen(2))
+		Expect(warnings[0].Kind).To(Equal(DeprecatedAttribute))
+		Expect(warnings[0].Error()).To(Equal(manifestPath + ":4:3: Attribute 'applications[0].host' is deprecated; use 'routes' instead"))
+		Expect(warnings[1].Line).To(Equal(5))
+	})
+
+	It("only warns about unknown attributes when lenient", func() {
+		m := readManifest(`---
+applications:
+- name: my-app
+  memroy: 256M
+`)
+
+		warnings, err := m.CheckSchema(true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(HaveLen(1))
+		Expect(warnings[0].Kind).To(Equal(UnknownAttribute))
+		Expect(warnings[0].Line).To(Equal(4))
+	})
+
+	It("accepts top level attributes that only hold YAML anchors", func() {
+		m := readManifest(`---
+defaults: &defaults
+  memory: 256M
+applications:
+- name: my-app
+  <<: *defaults
+`)
+
+		_, err := m.CheckSchema(false)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("skips the contents of block scalars when finding positions", func() {
+		m := readManifest(`---
+applications:
+- name: my-app
+  command: |
+    bundle exec rake db:migrate &&
+    bundle exec rails s
+  colour: blue
+`)
+
+		_, err := m.CheckSchema(false)
+		Expect(err).To(MatchError(manifestPath + ":7:3: Unknown attribute 'applications[0].colour'"))
+	})
+
+	It("reports the problems of inherited manifests in their own files", func() {
+		basePath := filepath.Join(dir, "base.yml")
+		err := ioutil.WriteFile(basePath, []byte("---\nmemroy: 256M\n"), 0600)
+		Expect(err).NotTo(HaveOccurred())
+
+		m := readManifest("---\ninherit: base.yml\napplications:\n- name: my-app\n")
+
+		_, err = m.CheckSchema(false)
+		Expect(err).To(MatchError(basePath + ":2:1: Unknown attribute 'memroy'"))
+	})
+})
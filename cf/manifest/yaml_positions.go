@@ -0,0 +1,154 @@
+package manifest
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// position is the place of a key or list item in a manifest file. Lines and
+// columns count from 1.
+type position struct {
+	Line   int
+	Column int
+}
+
+// yamlPositions maps the paths of the keys and list items of a YAML document
+// to their positions. Paths are written as in "applications[0].env.FOO".
+// The YAML parser does not report positions, so they are worked out from the
+// indentation of block style YAML; keys inside flow style maps and lists, such
+// as {a: 1}, are not found and take the position of the nearest enclosing key.
+type yamlPositions struct {
+	positions map[string]position
+	anchored  map[string]bool
+}
+
+type yamlContainer struct {
+	indent    int
+	path      string
+	isList    bool
+	nextIndex int
+}
+
+var yamlKeyRegex = regexp.MustCompile(`^("[^"]*"|'[^']*'|[^\s#"'][^#]*?)\s*:(\s|$)`)
+
+func newYAMLPositions(source []byte) yamlPositions {
+	p := yamlPositions{
+		positions: map[string]position{},
+		anchored:  map[string]bool{},
+	}
+
+	stack := []yamlContainer{{indent: -1}}
+	pending := ""
+	blockScalarIndent := -1
+
+	for i, line := range strings.Split(string(source), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		content := strings.TrimLeft(line, " ")
+		col := len(line) - len(content)
+
+		if blockScalarIndent >= 0 {
+			if content == "" || col > blockScalarIndent {
+				continue
+			}
+			blockScalarIndent = -1
+		}
+
+		if content == "" || strings.HasPrefix(content, "#") || strings.HasPrefix(content, "%") ||
+			content == "---" || strings.HasPrefix(content, "--- ") || content == "..." {
+			continue
+		}
+
+		for len(stack) > 1 && stack[len(stack)-1].indent > col {
+			stack = stack[:len(stack)-1]
+		}
+		if top := stack[len(stack)-1]; top.isList && top.indent == col && !isListItem(content) {
+			stack = stack[:len(stack)-1]
+		}
+
+		for isListItem(content) {
+			top := &stack[len(stack)-1]
+			if !top.isList || top.indent != col {
+				stack = append(stack, yamlContainer{indent: col, path: pending, isList: true})
+				top = &stack[len(stack)-1]
+			}
+
+			pending = top.path + "[" + strconv.Itoa(top.nextIndex) + "]"
+			top.nextIndex++
+
+			rest := strings.TrimLeft(content[1:], " ")
+			col += len(content) - len(rest)
+			content = rest
+			p.positions[pending] = position{Line: i + 1, Column: col + 1}
+			if strings.HasPrefix(content, "&") {
+				p.anchored[pending] = true
+			}
+		}
+
+		matches := yamlKeyRegex.FindStringSubmatch(content)
+		if matches == nil {
+			if strings.HasPrefix(content, "|") || strings.HasPrefix(content, ">") {
+				blockScalarIndent = col - 1
+			}
+			continue
+		}
+
+		if top := stack[len(stack)-1]; top.isList || top.indent != col {
+			stack = append(stack, yamlContainer{indent: col, path: pending})
+		}
+
+		key := strings.Trim(matches[1], `"'`)
+		pending = joinPath(stack[len(stack)-1].path, key)
+		p.positions[pending] = position{Line: i + 1, Column: col + 1}
+
+		value := strings.TrimSpace(content[len(matches[0]):])
+		if strings.HasPrefix(value, "&") {
+			p.anchored[pending] = true
+		}
+		if strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+			blockScalarIndent = col
+		}
+	}
+
+	return p
+}
+
+func isListItem(content string) bool {
+	return content == "-" || strings.HasPrefix(content, "- ")
+}
+
+func joinPath(parent string, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+// find returns the position of path, or of the nearest key or list item that
+// encloses it when path itself was not found.
+func (p yamlPositions) find(path string) (position, bool) {
+	for path != "" {
+		if pos, ok := p.positions[path]; ok {
+			return pos, true
+		}
+
+		cut := strings.LastIndexAny(path, ".[")
+		if cut < 0 {
+			break
+		}
+		path = path[:cut]
+	}
+
+	return position{}, false
+}
+
+// definesAnchor tells whether the value at path, or any value inside it,
+// defines a YAML anchor for use elsewhere in the file.
+func (p yamlPositions) definesAnchor(path string) bool {
+	for anchored := range p.anchored {
+		if anchored == path || strings.HasPrefix(anchored, path+".") || strings.HasPrefix(anchored, path+"[") {
+			return true
+		}
+	}
+	return false
+}
@@ -9,4 +9,16 @@ type EventFields struct {
 	Description string
 	Actor       string
 	ActorName   string
+	ActeeType   string
+	ActeeName   string
+
+	// Crash is only set for the events of an app instance crashing.
+	Crash *CrashFields
+}
+
+type CrashFields struct {
+	InstanceIndex   int
+	ExitStatus      int
+	ExitDescription string
+	Reason          string
 }
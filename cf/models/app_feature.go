@@ -0,0 +1,21 @@
+package models
+
+const (
+	AppFeatureSSH       = "ssh"
+	AppFeatureRevisions = "revisions"
+)
+
+// AppFeature is a feature that can be turned on and off for a single app.
+type AppFeature struct {
+	Name        string
+	Description string
+	Enabled     bool
+}
+
+// SSHEnabled tells whether SSH to the instances of an app is allowed, taking
+// the settings of its space and of the foundation into account as well. The
+// reason says where SSH was disabled.
+type SSHEnabled struct {
+	Enabled bool
+	Reason  string
+}
@@ -5,4 +5,8 @@ type AppFileFields struct {
 	Sha1 string
 	Size int64
 	Mode string
+
+	// Symlink is set for symbolic links that are uploaded as links rather
+	// than as the files they point to.
+	Symlink bool
 }
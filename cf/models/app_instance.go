@@ -21,4 +21,8 @@ type AppInstanceFields struct {
 	DiskUsage int64
 	MemQuota  int64
 	MemUsage  int64
+
+	// Routable is whether the readiness check of the instance passes, or nil
+	// when its process has no readiness check or the API does not say.
+	Routable *bool
 }
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type AppInstanceMetrics struct {
+	Index     int
+	State     InstanceState
+	Uptime    time.Duration
+	CPUUsage  float64 // percentage
+	MemUsage  int64   // in bytes
+	MemQuota  int64
+	DiskUsage int64
+	DiskQuota int64
+}
@@ -69,6 +69,7 @@ type ApplicationFields struct {
 	Buildpack            string
 	DetectedBuildpack    string
 	DockerImage          string
+	DockerUsername       string
 	EnableSSH            bool
 	AppPorts             []int
 }
@@ -83,36 +84,81 @@ const (
 	ApplicationStateStarting = "starting"
 )
 
+const (
+	AppLifecycleBuildpack = "buildpack"
+	AppLifecycleCNB       = "cnb"
+)
+
+// AppLifecycle says how an app is staged. Only the v3 apps API knows about
+// it; the v2 apps API stages every non-docker app with the buildpack
+// lifecycle and a single buildpack.
+type AppLifecycle struct {
+	Type        string
+	Buildpacks  []string
+	Credentials map[string]RegistryCredentials
+}
+
+// RegistryCredentials authenticate against a private registry the
+// buildpacks of a cnb app are pulled from.
+type RegistryCredentials struct {
+	Username string
+	Password string
+}
+
 type AppParams struct {
-	BuildpackURL       *string
-	Command            *string
-	DiskQuota          *int64
-	Domains            []string
-	EnvironmentVars    *map[string]interface{}
-	GUID               *string
-	HealthCheckType    *string
-	HealthCheckTimeout *int
-	DockerImage        *string
-	Diego              *bool
-	EnableSSH          *bool
-	Hosts              []string
-	RoutePath          *string
-	InstanceCount      *int
-	Memory             *int64
-	Name               *string
-	NoHostname         *bool
-	NoRoute            bool
-	UseRandomRoute     bool
-	UseRandomPort      bool
-	Path               *string
-	ServicesToBind     []string
-	SpaceGUID          *string
-	StackGUID          *string
-	StackName          *string
-	State              *string
-	PackageUpdatedAt   *time.Time
-	AppPorts           *[]int
-	Routes             []ManifestRoute
+	BuildpackURL        *string
+	Buildpacks          []string
+	Lifecycle           *string
+	CNBCredentials      map[string]RegistryCredentials
+	Command             *string
+	DiskQuota           *int64
+	Domains             []string
+	EnvironmentVars     *map[string]interface{}
+	GUID                *string
+	HealthCheckType     *string
+	HealthCheckTimeout  *int
+	DockerImage         *string
+	DockerUsername      *string
+	DockerPassword      *string
+	Diego               *bool
+	EnableSSH           *bool
+	Hosts               []string
+	RoutePath           *string
+	InstanceCount       *int
+	Memory              *int64
+	Name                *string
+	NoHostname          *bool
+	NoRoute             bool
+	UseRandomRoute      bool
+	UseRandomPort       bool
+	RandomRouteStrategy *string
+	Path                *string
+	ServicesToBind      []string
+	ServiceBindingNames map[string]string
+	SpaceGUID           *string
+	StackGUID           *string
+	StackName           *string
+	State               *string
+	PackageUpdatedAt    *time.Time
+	AppPorts            *[]int
+	Routes              []ManifestRoute
+	Sidecars            []Sidecar
+	Metadata            *Metadata
+
+	HealthCheckHTTPEndpoint      *string
+	HealthCheckInvocationTimeout *int
+	Processes                    []ProcessParams
+
+	ReadinessHealthCheckType         *string
+	ReadinessHealthCheckHTTPEndpoint *string
+	ReadinessHealthCheckInterval     *int
+
+	// PrePushScript and PostPushScript run on the local machine, before the
+	// app is created or updated and once it has been started. HookTimeout is
+	// the number of seconds each of them may take.
+	PrePushScript  *string
+	PostPushScript *string
+	HookTimeout    *int
 }
 
 func (app *AppParams) Merge(other *AppParams) {
@@ -121,6 +167,17 @@ func (app *AppParams) Merge(other *AppParams) {
 	}
 	if other.BuildpackURL != nil {
 		app.BuildpackURL = other.BuildpackURL
+		app.Buildpacks = nil
+	}
+	if other.Buildpacks != nil {
+		app.Buildpacks = other.Buildpacks
+		app.BuildpackURL = nil
+	}
+	if other.Lifecycle != nil {
+		app.Lifecycle = other.Lifecycle
+	}
+	if other.CNBCredentials != nil {
+		app.CNBCredentials = other.CNBCredentials
 	}
 	if other.Command != nil {
 		app.Command = other.Command
@@ -131,6 +188,12 @@ func (app *AppParams) Merge(other *AppParams) {
 	if other.DockerImage != nil {
 		app.DockerImage = other.DockerImage
 	}
+	if other.DockerUsername != nil {
+		app.DockerUsername = other.DockerUsername
+	}
+	if other.DockerPassword != nil {
+		app.DockerPassword = other.DockerPassword
+	}
 	if other.Domains != nil {
 		app.Domains = other.Domains
 	}
@@ -149,6 +212,15 @@ func (app *AppParams) Merge(other *AppParams) {
 	if other.HealthCheckTimeout != nil {
 		app.HealthCheckTimeout = other.HealthCheckTimeout
 	}
+	if other.HealthCheckHTTPEndpoint != nil {
+		app.HealthCheckHTTPEndpoint = other.HealthCheckHTTPEndpoint
+	}
+	if other.HealthCheckInvocationTimeout != nil {
+		app.HealthCheckInvocationTimeout = other.HealthCheckInvocationTimeout
+	}
+	if other.HookTimeout != nil {
+		app.HookTimeout = other.HookTimeout
+	}
 	if other.Hosts != nil {
 		app.Hosts = other.Hosts
 	}
@@ -158,18 +230,48 @@ func (app *AppParams) Merge(other *AppParams) {
 	if other.Memory != nil {
 		app.Memory = other.Memory
 	}
+	if other.Metadata != nil {
+		app.Metadata = other.Metadata
+	}
 	if other.Name != nil {
 		app.Name = other.Name
 	}
 	if other.Path != nil {
 		app.Path = other.Path
 	}
+	if other.PostPushScript != nil {
+		app.PostPushScript = other.PostPushScript
+	}
+	if other.PrePushScript != nil {
+		app.PrePushScript = other.PrePushScript
+	}
+	if other.Processes != nil {
+		app.Processes = other.Processes
+	}
 	if other.RoutePath != nil {
 		app.RoutePath = other.RoutePath
 	}
+	if other.ReadinessHealthCheckType != nil {
+		app.ReadinessHealthCheckType = other.ReadinessHealthCheckType
+	}
+	if other.ReadinessHealthCheckHTTPEndpoint != nil {
+		app.ReadinessHealthCheckHTTPEndpoint = other.ReadinessHealthCheckHTTPEndpoint
+	}
+	if other.ReadinessHealthCheckInterval != nil {
+		app.ReadinessHealthCheckInterval = other.ReadinessHealthCheckInterval
+	}
+	if other.RandomRouteStrategy != nil {
+		app.RandomRouteStrategy = other.RandomRouteStrategy
+	}
 	if other.ServicesToBind != nil {
 		app.ServicesToBind = other.ServicesToBind
 	}
+	if other.ServiceBindingNames != nil {
+		app.ServiceBindingNames = other.ServiceBindingNames
+	}
+	if other.Sidecars != nil {
+		app.Sidecars = other.Sidecars
+	}
 	if other.SpaceGUID != nil {
 		app.SpaceGUID = other.SpaceGUID
 	}
@@ -0,0 +1,21 @@
+package models
+
+// BrokerCatalog is the catalog of services a service broker offers, as the
+// broker itself returns it.
+type BrokerCatalog struct {
+	Services []BrokerCatalogService
+}
+
+type BrokerCatalogService struct {
+	ID          string
+	Name        string
+	Description string
+	Plans       []BrokerCatalogPlan
+}
+
+type BrokerCatalogPlan struct {
+	ID          string
+	Name        string
+	Description string
+	Free        bool
+}
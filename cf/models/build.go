@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+const (
+	BuildStateStaging = "STAGING"
+	BuildStateStaged  = "STAGED"
+	BuildStateFailed  = "FAILED"
+)
+
+// Build stages a package of an app into a droplet.
+type Build struct {
+	GUID        string
+	State       string
+	Error       string
+	AppGUID     string
+	PackageGUID string
+	DropletGUID string
+	CreatedAt   time.Time
+}
@@ -8,4 +8,5 @@ type Buildpack struct {
 	Key      string
 	Filename string
 	Locked   *bool
+	Stack    string
 }
@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+const (
+	DeploymentStrategyRolling = "rolling"
+	DeploymentStrategyCanary  = "canary"
+
+	DeploymentStatusActive    = "ACTIVE"
+	DeploymentStatusFinalized = "FINALIZED"
+	DeploymentReasonDeployed  = "DEPLOYED"
+	DeploymentReasonPaused    = "PAUSED"
+)
+
+// Deployment replaces the instances of an app with ones running another
+// droplet, or the same droplet again, a few at a time instead of all at once.
+type Deployment struct {
+	GUID         string
+	State        string
+	StatusValue  string
+	StatusReason string
+	Strategy     string
+	DropletGUID  string
+	CreatedAt    time.Time
+}
+
+// IsFinished reports whether the deployment has stopped replacing instances,
+// either because it is done or because it was cancelled or superseded. Older
+// APIs report this in the state of the deployment instead of its status.
+func (deployment Deployment) IsFinished() bool {
+	switch {
+	case deployment.StatusValue != "":
+		return deployment.StatusValue == DeploymentStatusFinalized
+	default:
+		return deployment.State != "" && deployment.State != "DEPLOYING"
+	}
+}
+
+// Succeeded reports whether the deployment finished replacing every instance.
+func (deployment Deployment) Succeeded() bool {
+	if deployment.StatusValue != "" {
+		return deployment.StatusReason == DeploymentReasonDeployed
+	}
+	return deployment.State == DeploymentReasonDeployed
+}
+
+// IsPaused reports whether the deployment is a canary deployment waiting to
+// be continued before it replaces more instances.
+func (deployment Deployment) IsPaused() bool {
+	return deployment.StatusValue == DeploymentStatusActive && deployment.StatusReason == DeploymentReasonPaused
+}
+
+// StatusDescription returns the status of the deployment as shown to users,
+// such as "ACTIVE (DEPLOYING)", or its state on older APIs.
+func (deployment Deployment) StatusDescription() string {
+	switch {
+	case deployment.StatusValue == "":
+		return deployment.State
+	case deployment.StatusReason == "":
+		return deployment.StatusValue
+	default:
+		return deployment.StatusValue + " (" + deployment.StatusReason + ")"
+	}
+}
+
+// Outcome returns why the deployment finished, such as CANCELED.
+func (deployment Deployment) Outcome() string {
+	if deployment.StatusReason != "" {
+		return deployment.StatusReason
+	}
+	return deployment.State
+}
+
+// DeploymentParams describe a deployment to create. InstanceSteps only apply
+// to canary deployments: each is the percentage of instances to replace before
+// the deployment pauses again. A revision replaces the droplet, as it brings
+// its own droplet along with the environment variables and process commands
+// the app had when it was created.
+type DeploymentParams struct {
+	DropletGUID   string
+	RevisionGUID  string
+	Strategy      string
+	MaxInFlight   *int
+	InstanceSteps []int
+}
@@ -7,6 +7,9 @@ type DomainFields struct {
 	RouterGroupGUID        string
 	RouterGroupType        string
 	Shared                 bool
+	// Internal domains are for container-to-container traffic; their routes
+	// are not reachable through the routers.
+	Internal bool
 }
 
 func (model DomainFields) URLForHostAndPath(host, path string, port int) string {
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+const (
+	DropletStateStaged  = "STAGED"
+	DropletStateFailed  = "FAILED"
+	DropletStateExpired = "EXPIRED"
+)
+
+type Droplet struct {
+	GUID      string
+	State     string
+	Lifecycle string
+	Buildpack string
+	Stack     string
+	CreatedAt time.Time
+}
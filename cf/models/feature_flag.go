@@ -4,4 +4,5 @@ type FeatureFlag struct {
 	Name         string `json:"name"`
 	Enabled      bool   `json:"enabled"`
 	ErrorMessage string `json:"error_message"`
+	Description  string `json:"description"`
 }
@@ -0,0 +1,22 @@
+package models
+
+// Metadata is the labels and annotations of a v3 resource. Labels can be
+// used to select resources; annotations only carry information.
+type Metadata struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// MetadataUpdate changes the metadata of a resource. Keys that are left out
+// are kept, and keys with a nil value are removed.
+type MetadataUpdate struct {
+	Labels      map[string]*string
+	Annotations map[string]*string
+}
+
+// LabeledResource is any v3 resource that has a name and metadata.
+type LabeledResource struct {
+	GUID     string
+	Name     string
+	Metadata Metadata
+}
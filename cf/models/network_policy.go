@@ -0,0 +1,22 @@
+package models
+
+import "strconv"
+
+// NetworkPolicy allows the instances of the source app to open connections
+// to the destination app directly, container to container, on a range of
+// ports.
+type NetworkPolicy struct {
+	SourceGUID      string
+	DestinationGUID string
+	Protocol        string
+	StartPort       int
+	EndPort         int
+}
+
+// Ports is the port range of the policy, such as "8080" or "8080-8090".
+func (policy NetworkPolicy) Ports() string {
+	if policy.StartPort == policy.EndPort {
+		return strconv.Itoa(policy.StartPort)
+	}
+	return strconv.Itoa(policy.StartPort) + "-" + strconv.Itoa(policy.EndPort)
+}
@@ -0,0 +1,22 @@
+package models_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NetworkPolicy", func() {
+	Describe("Ports", func() {
+		It("is the port when the policy is for a single port", func() {
+			policy := models.NetworkPolicy{StartPort: 8080, EndPort: 8080}
+			Expect(policy.Ports()).To(Equal("8080"))
+		})
+
+		It("is the range when the policy is for several ports", func() {
+			policy := models.NetworkPolicy{StartPort: 9000, EndPort: 9010}
+			Expect(policy.Ports()).To(Equal("9000-9010"))
+		})
+	})
+})
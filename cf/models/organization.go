@@ -12,3 +12,12 @@ type Organization struct {
 	Domains     []DomainFields
 	SpaceQuotas []SpaceQuota
 }
+
+// OrganizationUsage is how much of the resources limited by its quota an
+// organization currently uses.
+type OrganizationUsage struct {
+	MemoryInMB       int64
+	Routes           int
+	ServiceInstances int
+	AppInstances     int
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+const (
+	PackageTypeBits   = "bits"
+	PackageTypeDocker = "docker"
+
+	PackageStateReady   = "READY"
+	PackageStateFailed  = "FAILED"
+	PackageStateExpired = "EXPIRED"
+)
+
+// Package holds the bits, or the docker image, of an app that a build stages
+// into a droplet.
+type Package struct {
+	GUID      string
+	Type      string
+	State     string
+	CreatedAt time.Time
+}
@@ -0,0 +1,97 @@
+package models
+
+// Process is one of the process types of an app, such as web or worker, as
+// described by the Procfile of the app and scaled through the v3 processes
+// API.
+type Process struct {
+	GUID       string
+	Type       string
+	Instances  int
+	MemoryInMB int64
+	DiskInMB   int64
+
+	HealthCheckType              string
+	HealthCheckHTTPEndpoint      string
+	HealthCheckInvocationTimeout int
+
+	ReadinessHealthCheckType         string
+	ReadinessHealthCheckHTTPEndpoint string
+	ReadinessHealthCheckInterval     int
+}
+
+type ProcessScaleParams struct {
+	Instances  *int
+	MemoryInMB *int64
+	DiskInMB   *int64
+}
+
+// ProcessHealthCheck is the health or readiness check of a process. Fields
+// left nil are not changed when the check is updated.
+type ProcessHealthCheck struct {
+	Type              *string
+	HTTPEndpoint      *string
+	InvocationTimeout *int
+	Interval          *int
+}
+
+// ProcessParams configures one of the process types of an app, from the
+// 'processes' section of its manifest.
+type ProcessParams struct {
+	Type                         string
+	Instances                    *int
+	MemoryInMB                   *int64
+	DiskInMB                     *int64
+	HealthCheckType              *string
+	HealthCheckHTTPEndpoint      *string
+	HealthCheckInvocationTimeout *int
+
+	ReadinessHealthCheckType         *string
+	ReadinessHealthCheckHTTPEndpoint *string
+	ReadinessHealthCheckInterval     *int
+}
+
+// HasScale reports whether the instances, memory or disk of the process are
+// configured.
+func (params ProcessParams) HasScale() bool {
+	return params.Instances != nil || params.MemoryInMB != nil || params.DiskInMB != nil
+}
+
+// Scale returns the instances, memory and disk configured for the process.
+func (params ProcessParams) Scale() ProcessScaleParams {
+	return ProcessScaleParams{
+		Instances:  params.Instances,
+		MemoryInMB: params.MemoryInMB,
+		DiskInMB:   params.DiskInMB,
+	}
+}
+
+// HasHealthCheck reports whether the health check of the process is
+// configured.
+func (params ProcessParams) HasHealthCheck() bool {
+	return params.HealthCheckType != nil || params.HealthCheckHTTPEndpoint != nil || params.HealthCheckInvocationTimeout != nil
+}
+
+// HasReadinessHealthCheck reports whether the readiness check of the process,
+// which decides whether its instances are routable, is configured.
+func (params ProcessParams) HasReadinessHealthCheck() bool {
+	return params.ReadinessHealthCheckType != nil || params.ReadinessHealthCheckHTTPEndpoint != nil || params.ReadinessHealthCheckInterval != nil
+}
+
+// HealthCheck returns the health check configured for the process.
+func (params ProcessParams) HealthCheck() ProcessHealthCheck {
+	return ProcessHealthCheck{
+		Type:              params.HealthCheckType,
+		HTTPEndpoint:      params.HealthCheckHTTPEndpoint,
+		InvocationTimeout: params.HealthCheckInvocationTimeout,
+	}
+}
+
+// ReadinessHealthCheck returns the readiness check configured for the
+// process.
+func (params ProcessParams) ReadinessHealthCheck() ProcessHealthCheck {
+	return ProcessHealthCheck{
+		Type:         params.ReadinessHealthCheckType,
+		HTTPEndpoint: params.ReadinessHealthCheckHTTPEndpoint,
+		Interval:     params.ReadinessHealthCheckInterval,
+	}
+}
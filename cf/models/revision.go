@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// Revision records the droplet, environment variables and process commands
+// of an app at the time they changed, so that the app can be deployed as it
+// was. Versions count up from 1 for each app.
+type Revision struct {
+	GUID        string
+	Version     int
+	Description string
+	Deployable  bool
+	DropletGUID string
+	CreatedAt   time.Time
+}
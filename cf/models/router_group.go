@@ -1,9 +1,41 @@
 package models
 
+import (
+	"strconv"
+	"strings"
+)
+
 type RouterGroups []RouterGroup
 
 type RouterGroup struct {
-	GUID string `json:"guid"`
-	Name string `json:"name"`
-	Type string `json:"type"`
+	GUID            string `json:"guid"`
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+	ReservablePorts string `json:"reservable_ports"`
+}
+
+// ReservesPort reports whether port is one of the ReservablePorts of the
+// group, a comma separated list of ports and port ranges such as
+// "1024-1033,2000". Malformed entries are skipped.
+func (group RouterGroup) ReservesPort(port int) bool {
+	for _, entry := range strings.Split(group.ReservablePorts, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(entry), "-", 2)
+
+		low, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			continue
+		}
+		high := low
+		if len(bounds) == 2 {
+			high, err = strconv.Atoi(strings.TrimSpace(bounds[1]))
+			if err != nil {
+				continue
+			}
+		}
+
+		if port >= low && port <= high {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,37 @@
+package models_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/models"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RouterGroup", func() {
+	Describe("ReservesPort", func() {
+		var group models.RouterGroup
+
+		BeforeEach(func() {
+			group = models.RouterGroup{ReservablePorts: "1024-1033, 2000,bogus,3000-x"}
+		})
+
+		It("is true for the ports in a range, including its bounds", func() {
+			Expect(group.ReservesPort(1024)).To(BeTrue())
+			Expect(group.ReservesPort(1030)).To(BeTrue())
+			Expect(group.ReservesPort(1033)).To(BeTrue())
+		})
+
+		It("is true for a single port", func() {
+			Expect(group.ReservesPort(2000)).To(BeTrue())
+		})
+
+		It("is false for other ports and skips malformed entries", func() {
+			Expect(group.ReservesPort(1034)).To(BeFalse())
+			Expect(group.ReservesPort(3000)).To(BeFalse())
+		})
+
+		It("is false when the group reserves no ports", func() {
+			Expect(models.RouterGroup{}.ReservesPort(1024)).To(BeFalse())
+		})
+	})
+})
@@ -3,11 +3,14 @@ package models
 type ServiceBindingRequest struct {
 	AppGUID             string                 `json:"app_guid"`
 	ServiceInstanceGUID string                 `json:"service_instance_guid"`
+	Name                string                 `json:"name,omitempty"`
 	Params              map[string]interface{} `json:"parameters,omitempty"`
 }
 
 type ServiceBindingFields struct {
-	GUID    string
-	URL     string
-	AppGUID string
+	GUID                string
+	URL                 string
+	Name                string
+	AppGUID             string
+	ServiceInstanceGUID string
 }
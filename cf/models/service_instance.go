@@ -29,6 +29,7 @@ type ServiceInstanceFields struct {
 	SysLogDrainURL   string
 	RouteServiceURL  string
 	ApplicationNames []string
+	BindingNames     []string // name of the binding of each of ApplicationNames, if it has one
 	Params           map[string]interface{}
 	DashboardURL     string
 	Tags             []string
@@ -9,6 +9,14 @@ type ServicePlanFields struct {
 	Active              bool
 	ServiceOfferingGUID string
 	OrgNames            []string
+	Costs               []ServicePlanCost
+}
+
+// ServicePlanCost is one of the costs the broker lists in the metadata of a
+// plan, with its amount in each currency, e.g. {"usd": 10.0}.
+type ServicePlanCost struct {
+	Amount map[string]float64 `json:"amount"`
+	Unit   string             `json:"unit"`
 }
 
 type ServicePlan struct {
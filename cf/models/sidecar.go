@@ -0,0 +1,11 @@
+package models
+
+// Sidecar is an additional process, such as a log shipper or a proxy, that
+// runs in the same container as the processes of the listed types.
+type Sidecar struct {
+	GUID         string
+	Name         string
+	Command      string
+	ProcessTypes []string
+	MemoryInMB   int64
+}
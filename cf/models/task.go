@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Task is a one-off process, such as a database migration, run with the
+// droplet of an app by the v3 tasks API.
+type Task struct {
+	GUID       string
+	SequenceID int
+	Name       string
+	Command    string
+	State      string
+	MemoryInMB int64
+	DiskInMB   int64
+	CreatedAt  time.Time
+}
+
+type TaskParams struct {
+	Name       string
+	Command    string
+	MemoryInMB int64
+	DiskInMB   int64
+}
@@ -5,4 +5,8 @@ type UserFields struct {
 	Username string
 	Password string
 	IsAdmin  bool
+
+	// Origin is the identity provider the user logs in with, such as uaa,
+	// ldap or a SAML provider. It is only known for users looked up in UAA.
+	Origin string
 }
@@ -14,6 +14,13 @@ import (
 type ccErrorResponse struct {
 	Code        int
 	Description string
+
+	// Errors holds the errors of the v3 API, which replaces the code and
+	// description of the v2 API with a list of errors.
+	Errors []struct {
+		Code   int
+		Detail string
+	}
 }
 
 const invalidTokenCode = 1000
@@ -22,6 +29,11 @@ func cloudControllerErrorHandler(statusCode int, body []byte) error {
 	response := ccErrorResponse{}
 	_ = json.Unmarshal(body, &response)
 
+	if len(response.Errors) > 0 {
+		response.Code = response.Errors[0].Code
+		response.Description = response.Errors[0].Detail
+	}
+
 	if response.Code == invalidTokenCode {
 		return errors.NewInvalidTokenError(response.Description)
 	}
@@ -30,7 +42,7 @@ func cloudControllerErrorHandler(statusCode int, body []byte) error {
 }
 
 func NewCloudControllerGateway(config coreconfig.Reader, clock func() time.Time, ui terminal.UI, logger trace.Printer, envDialTimeout string) Gateway {
-	return Gateway{
+	gateway := Gateway{
 		errHandler:      cloudControllerErrorHandler,
 		config:          config,
 		PollingThrottle: DefaultPollingThrottle,
@@ -40,5 +52,10 @@ func NewCloudControllerGateway(config coreconfig.Reader, clock func() time.Time,
 		logger:          logger,
 		PollingEnabled:  true,
 		DialTimeout:     dialTimeout(envDialTimeout),
+		RetryBackoff:    DefaultRetryBackoff,
+		connections:     newConnectionPool(),
+		pageListener:    &pageListener{},
 	}
+	gateway.SetMaxRetries(DefaultMaxRetries)
+	return gateway
 }
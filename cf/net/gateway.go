@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"math/rand"
 	"net"
 	"net/http"
 	"net/url"
@@ -14,6 +15,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf"
@@ -29,6 +31,11 @@ const (
 	JobFailed              = "failed"
 	DefaultPollingThrottle = 5 * time.Second
 	DefaultDialTimeout     = 5 * time.Second
+	DefaultMaxRetries      = 3
+	DefaultRetryBackoff    = 1 * time.Second
+	DefaultKeepAlive       = 90 * time.Second
+	DefaultMaxIdleConns    = 10
+	maxRetryBackoff        = 30 * time.Second
 )
 
 type JobResource struct {
@@ -49,7 +56,7 @@ type AsyncResource struct {
 type apiErrorHandler func(statusCode int, body []byte) error
 
 type tokenRefresher interface {
-	RefreshAuthToken() (string, error)
+	RefreshStaleAuthToken(staleToken string) (string, error)
 }
 
 type Request struct {
@@ -62,14 +69,20 @@ type Gateway struct {
 	errHandler      apiErrorHandler
 	PollingEnabled  bool
 	PollingThrottle time.Duration
-	trustedCerts    []tls.Certificate
 	config          coreconfig.Reader
 	warnings        *[]string
 	Clock           func() time.Time
-	transport       *http.Transport
+	connections     *connectionPool
 	ui              terminal.UI
 	logger          trace.Printer
 	DialTimeout     time.Duration
+	RetryBackoff    time.Duration
+	maxRetries      *int
+	pageListener    *pageListener
+}
+
+type pageListener struct {
+	pageDone func()
 }
 
 func (gateway *Gateway) AsyncTimeout() time.Duration {
@@ -84,6 +97,26 @@ func (gateway *Gateway) SetTokenRefresher(auth tokenRefresher) {
 	gateway.authenticator = auth
 }
 
+// SetMaxRetries sets how many times an idempotent request is retried when the
+// server is temporarily unavailable. It applies to every copy of the gateway.
+func (gateway *Gateway) SetMaxRetries(retries int) {
+	if gateway.maxRetries == nil {
+		gateway.maxRetries = new(int)
+	}
+	*gateway.maxRetries = retries
+}
+
+// SetPageListener sets a function that is called whenever ListPaginatedResources
+// has passed every resource of a page to its callback, before the next page is
+// requested, so that commands can show the resources as they arrive. It
+// applies to every copy of the gateway; nil removes the listener.
+func (gateway *Gateway) SetPageListener(pageDone func()) {
+	if gateway.pageListener == nil {
+		gateway.pageListener = &pageListener{}
+	}
+	gateway.pageListener.pageDone = pageDone
+}
+
 func (gateway Gateway) GetResource(url string, resource interface{}) (err error) {
 	request, err := gateway.NewRequest("GET", url, gateway.config.AccessToken(), nil)
 	if err != nil {
@@ -157,6 +190,10 @@ func (gateway Gateway) ListPaginatedResources(
 			}
 		}
 
+		if gateway.pageListener != nil && gateway.pageListener.pageDone != nil {
+			gateway.pageListener.pageDone()
+		}
+
 		path = pagination.NextURL
 	}
 
@@ -198,7 +235,6 @@ func (gateway Gateway) newRequest(request *http.Request, accessToken string, bod
 	}
 
 	request.Header.Set("accept", "application/json")
-	request.Header.Set("Connection", "close")
 	request.Header.Set("content-type", "application/json")
 	request.Header.Set("User-Agent", "go-cli "+cf.Version+" / "+runtime.GOOS)
 
@@ -206,27 +242,28 @@ func (gateway Gateway) newRequest(request *http.Request, accessToken string, bod
 }
 
 func (gateway Gateway) NewRequestForFile(method, fullURL, accessToken string, body *os.File) (*Request, error) {
-	progressReader := NewProgressReader(body, gateway.ui, 5*time.Second)
-	_, _ = progressReader.Seek(0, 0)
-
 	fileStats, err := body.Stat()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %s", T("Error getting file info"), err.Error())
 	}
 
-	request, err := http.NewRequest(method, fullURL, progressReader)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %s", T("Error building request"), err.Error())
-	}
+	return gateway.NewRequestForReader(method, fullURL, accessToken, body, fileStats.Size())
+}
 
-	fileSize := fileStats.Size()
-	progressReader.SetTotalSize(fileSize)
-	request.ContentLength = fileSize
+// NewRequestForReader builds a request that sends the size bytes of body and
+// shows the progress of sending them, like NewRequestForFile does for a file.
+func (gateway Gateway) NewRequestForReader(method, fullURL, accessToken string, body io.ReadSeeker, size int64) (*Request, error) {
+	progressReader := NewProgressReader(body, gateway.ui, 5*time.Second)
+	_, _ = progressReader.Seek(0, 0)
 
+	request, err := http.NewRequest(method, fullURL, progressReader)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %s", T("Error building request"), err.Error())
 	}
 
+	progressReader.SetTotalSize(size)
+	request.ContentLength = size
+
 	return gateway.newRequest(request, accessToken, progressReader), nil
 }
 
@@ -364,7 +401,7 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (*http.Response,
 	}
 
 	// perform request
-	rawResponse, err := gateway.doRequestAndHandlerError(request)
+	rawResponse, err := gateway.doRequestRetryingUnavailable(request)
 	if err == nil || gateway.authenticator == nil {
 		return rawResponse, err
 	}
@@ -373,7 +410,7 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (*http.Response,
 	case *errors.InvalidTokenError:
 		// refresh the auth token
 		var newToken string
-		newToken, err = gateway.authenticator.RefreshAuthToken()
+		newToken, err = gateway.authenticator.RefreshStaleAuthToken(httpReq.Header.Get("Authorization"))
 		if err != nil {
 			return rawResponse, err
 		}
@@ -386,12 +423,69 @@ func (gateway Gateway) doRequestHandlingAuth(request *Request) (*http.Response,
 		}
 
 		// make the request again
+		rawResponse, err = gateway.doRequestRetryingUnavailable(request)
+	}
+
+	return rawResponse, err
+}
+
+// doRequestRetryingUnavailable retries idempotent requests that fail because
+// the server or a proxy in front of it is temporarily unavailable, waiting
+// exponentially longer, with jitter, between each attempt.
+func (gateway Gateway) doRequestRetryingUnavailable(request *Request) (*http.Response, error) {
+	rawResponse, err := gateway.doRequestAndHandlerError(request)
+
+	maxRetries := 0
+	if gateway.maxRetries != nil {
+		maxRetries = *gateway.maxRetries
+	}
+
+	for attempt := 0; attempt < maxRetries && isRetryable(request.HTTPReq, rawResponse, err); attempt++ {
+		backoff := retryBackoff(gateway.RetryBackoff, attempt)
+		gateway.logger.Printf("Server responded with status %d, retrying in %s", rawResponse.StatusCode, backoff)
+		time.Sleep(backoff)
+
+		if request.SeekableBody != nil {
+			_, _ = request.SeekableBody.Seek(0, 0)
+			request.HTTPReq.Body = ioutil.NopCloser(request.SeekableBody)
+		}
+
 		rawResponse, err = gateway.doRequestAndHandlerError(request)
 	}
 
 	return rawResponse, err
 }
 
+func isRetryable(request *http.Request, rawResponse *http.Response, err error) bool {
+	if err == nil || rawResponse == nil {
+		return false
+	}
+
+	switch request.Method {
+	case "GET", "HEAD", "PUT", "DELETE", "OPTIONS":
+	default:
+		return false
+	}
+
+	switch rawResponse.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return true
+	}
+	return false
+}
+
+// retryBackoff doubles base for every attempt, up to maxRetryBackoff, and
+// picks a random duration between half of that and all of it.
+func retryBackoff(base time.Duration, attempt int) time.Duration {
+	backoff := base << uint(attempt)
+	if backoff > maxRetryBackoff || backoff <= 0 {
+		backoff = maxRetryBackoff
+	}
+
+	half := int64(backoff / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
 func (gateway Gateway) doRequestAndHandlerError(request *Request) (*http.Response, error) {
 	rawResponse, err := gateway.doRequest(request.HTTPReq)
 	if err != nil {
@@ -412,11 +506,7 @@ func (gateway Gateway) doRequest(request *http.Request) (*http.Response, error)
 	var response *http.Response
 	var err error
 
-	if gateway.transport == nil {
-		makeHTTPTransport(&gateway)
-	}
-
-	httpClient := NewHTTPClient(gateway.transport, NewRequestDumper(gateway.logger))
+	httpClient := gateway.httpClient()
 
 	httpClient.DumpRequest(request)
 
@@ -445,11 +535,64 @@ func (gateway Gateway) doRequest(request *http.Request) (*http.Response, error)
 	return response, err
 }
 
-func makeHTTPTransport(gateway *Gateway) {
-	gateway.transport = &http.Transport{
-		Dial:            (&net.Dialer{Timeout: gateway.DialTimeout}).Dial,
-		TLSClientConfig: NewTLSConfig(gateway.trustedCerts, gateway.config.IsSSLDisabled()),
-		Proxy:           http.ProxyFromEnvironment,
+// connectionPool holds the HTTP client of a gateway. It is shared by every
+// copy of the gateway, so that all the repositories using it reuse the same
+// connections instead of opening one for every request.
+type connectionPool struct {
+	mutex        sync.Mutex
+	trustedCerts []tls.Certificate
+	sslDisabled  bool
+	transport    *http.Transport
+	client       HTTPClientInterface
+}
+
+func newConnectionPool() *connectionPool {
+	return &connectionPool{}
+}
+
+// httpClient returns the client of the gateway's connection pool, creating it
+// again if SSL validation has been turned on or off since it was created.
+func (gateway Gateway) httpClient() HTTPClientInterface {
+	pool := gateway.connections
+	if pool == nil {
+		pool = newConnectionPool()
+	}
+
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	sslDisabled := gateway.config.IsSSLDisabled()
+	if pool.client == nil || pool.sslDisabled != sslDisabled {
+		if pool.transport != nil {
+			pool.transport.CloseIdleConnections()
+		}
+
+		pool.sslDisabled = sslDisabled
+		pool.transport = gateway.newHTTPTransport(pool.trustedCerts, sslDisabled)
+		pool.client = NewHTTPClient(pool.transport, NewRequestDumper(gateway.logger))
+	}
+
+	return pool.client
+}
+
+func (gateway Gateway) newHTTPTransport(trustedCerts []tls.Certificate, sslDisabled bool) *http.Transport {
+	keepAlive := DefaultKeepAlive
+	if gateway.config.KeepAliveTimeout() > 0 {
+		keepAlive = time.Duration(gateway.config.KeepAliveTimeout()) * time.Second
+	}
+
+	maxIdleConns := DefaultMaxIdleConns
+	if gateway.config.MaxIdleConnections() > 0 {
+		maxIdleConns = int(gateway.config.MaxIdleConnections())
+	}
+
+	return &http.Transport{
+		Dial:                (&net.Dialer{Timeout: gateway.DialTimeout, KeepAlive: keepAlive}).Dial,
+		TLSClientConfig:     NewTLSConfig(trustedCerts, sslDisabled),
+		Proxy:               http.ProxyFromEnvironment,
+		ForceAttemptHTTP2:   true,
+		MaxIdleConnsPerHost: maxIdleConns,
+		IdleConnTimeout:     keepAlive,
 	}
 }
 
@@ -462,6 +605,14 @@ func dialTimeout(envDialTimeout string) time.Duration {
 }
 
 func (gateway *Gateway) SetTrustedCerts(certificates []tls.Certificate) {
-	gateway.trustedCerts = certificates
-	makeHTTPTransport(gateway)
+	if gateway.connections == nil {
+		gateway.connections = newConnectionPool()
+	}
+
+	pool := gateway.connections
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	pool.trustedCerts = certificates
+	pool.client = nil
 }
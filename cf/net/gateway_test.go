@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"io/ioutil"
+	gonet "net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -98,6 +99,193 @@ var _ = Describe("Gateway", func() {
 		})
 	})
 
+	Describe("when the server is temporarily unavailable", func() {
+		var (
+			oldNewHTTPClient func(tr *http.Transport, dumper RequestDumper) HTTPClientInterface
+			bodies           []string
+			statuses         []int
+		)
+
+		BeforeEach(func() {
+			client = new(netfakes.FakeHTTPClientInterface)
+			bodies = []string{}
+			statuses = []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK}
+			client.DoStub = func(request *http.Request) (*http.Response, error) {
+				if request.Body != nil {
+					body, _ := ioutil.ReadAll(request.Body)
+					bodies = append(bodies, string(body))
+				}
+
+				status := statuses[len(statuses)-1]
+				if client.DoCallCount() <= len(statuses) {
+					status = statuses[client.DoCallCount()-1]
+				}
+				return &http.Response{
+					StatusCode: status,
+					Header:     http.Header{},
+					Body:       ioutil.NopCloser(strings.NewReader("{}")),
+				}, nil
+			}
+
+			oldNewHTTPClient = NewHTTPClient
+			NewHTTPClient = func(tr *http.Transport, dumper RequestDumper) HTTPClientInterface {
+				return client
+			}
+
+			ccGateway.RetryBackoff = time.Millisecond
+		})
+
+		AfterEach(func() {
+			NewHTTPClient = oldNewHTTPClient
+		})
+
+		It("retries idempotent requests with the whole body", func() {
+			request, apiErr := ccGateway.NewRequest("PUT", "https://example.com/v2/apps/guid/bits", "BEARER my-access-token", strings.NewReader("the-bits"))
+			Expect(apiErr).ToNot(HaveOccurred())
+
+			_, apiErr = ccGateway.PerformRequest(request)
+			Expect(apiErr).ToNot(HaveOccurred())
+			Expect(client.DoCallCount()).To(Equal(3))
+			Expect(bodies).To(Equal([]string{"the-bits", "the-bits", "the-bits"}))
+		})
+
+		It("does not retry requests that are not idempotent", func() {
+			request, apiErr := ccGateway.NewRequest("POST", "https://example.com/v2/apps", "BEARER my-access-token", strings.NewReader("{}"))
+			Expect(apiErr).ToNot(HaveOccurred())
+
+			_, apiErr = ccGateway.PerformRequest(request)
+			Expect(apiErr).To(HaveOccurred())
+			Expect(client.DoCallCount()).To(Equal(1))
+		})
+
+		It("does not retry other server errors", func() {
+			statuses = []int{http.StatusInternalServerError, http.StatusOK}
+			request, apiErr := ccGateway.NewRequest("GET", "https://example.com/v2/apps", "BEARER my-access-token", nil)
+			Expect(apiErr).ToNot(HaveOccurred())
+
+			_, apiErr = ccGateway.PerformRequest(request)
+			Expect(apiErr).To(HaveOccurred())
+			Expect(client.DoCallCount()).To(Equal(1))
+		})
+
+		It("returns the last error once it runs out of retries", func() {
+			ccGateway.SetMaxRetries(1)
+			request, apiErr := ccGateway.NewRequest("GET", "https://example.com/v2/apps", "BEARER my-access-token", nil)
+			Expect(apiErr).ToNot(HaveOccurred())
+
+			_, apiErr = ccGateway.PerformRequest(request)
+			Expect(client.DoCallCount()).To(Equal(2))
+			Expect(apiErr).To(HaveOccurred())
+			Expect(apiErr.(errors.HTTPError).StatusCode()).To(Equal(http.StatusBadGateway))
+		})
+
+		It("applies the number of retries to every copy of the gateway", func() {
+			gatewayCopy := ccGateway
+			ccGateway.SetMaxRetries(0)
+
+			request, apiErr := gatewayCopy.NewRequest("GET", "https://example.com/v2/apps", "BEARER my-access-token", nil)
+			Expect(apiErr).ToNot(HaveOccurred())
+
+			_, apiErr = gatewayCopy.PerformRequest(request)
+			Expect(apiErr).To(HaveOccurred())
+			Expect(client.DoCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("reusing connections", func() {
+		var (
+			apiServer      *httptest.Server
+			newConnections int
+			protocols      []string
+		)
+
+		BeforeEach(func() {
+			newConnections = 0
+			protocols = []string{}
+
+			apiServer = httptest.NewUnstartedServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				protocols = append(protocols, request.Proto)
+				fmt.Fprintln(writer, `{}`)
+			}))
+			apiServer.EnableHTTP2 = true
+			apiServer.Config.ConnState = func(conn gonet.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					newConnections++
+				}
+			}
+			apiServer.StartTLS()
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+		})
+
+		It("sends every request of a gateway and its copies over one HTTP/2 connection", func() {
+			gatewayCopy := ccGateway
+			ccGateway.SetTrustedCerts(apiServer.TLS.Certificates)
+
+			for _, gateway := range []Gateway{ccGateway, gatewayCopy, ccGateway} {
+				request, apiErr := gateway.NewRequest("GET", apiServer.URL+"/v2/apps", "BEARER my-access-token", nil)
+				Expect(apiErr).NotTo(HaveOccurred())
+
+				_, apiErr = gateway.PerformRequestForJSONResponse(request, &struct{}{})
+				Expect(apiErr).NotTo(HaveOccurred())
+			}
+
+			Expect(protocols).To(Equal([]string{"HTTP/2.0", "HTTP/2.0", "HTTP/2.0"}))
+			Expect(newConnections).To(Equal(1))
+		})
+	})
+
+	Describe("ListPaginatedResources", func() {
+		type resource struct {
+			Name string `json:"name"`
+		}
+
+		var (
+			apiServer *httptest.Server
+			events    []string
+		)
+
+		BeforeEach(func() {
+			events = []string{}
+			apiServer = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				events = append(events, "GET page "+request.URL.Query().Get("page"))
+				if request.URL.Query().Get("page") == "2" {
+					fmt.Fprintln(writer, `{"next_url": "", "resources": [{"name": "c"}]}`)
+					return
+				}
+				fmt.Fprintln(writer, `{"next_url": "/v2/things?page=2", "resources": [{"name": "a"}, {"name": "b"}]}`)
+			}))
+
+			ccGateway.SetPageListener(func() {
+				events = append(events, "page done")
+			})
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+		})
+
+		It("tells the page listener about each page before fetching the next one", func() {
+			err := ccGateway.ListPaginatedResources(apiServer.URL, "/v2/things?page=1", resource{}, func(r interface{}) bool {
+				events = append(events, r.(resource).Name)
+				return true
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(Equal([]string{"GET page 1", "a", "b", "page done", "GET page 2", "c", "page done"}))
+		})
+
+		It("stops without telling the listener when the callback stops", func() {
+			err := ccGateway.ListPaginatedResources(apiServer.URL, "/v2/things?page=1", resource{}, func(r interface{}) bool {
+				events = append(events, r.(resource).Name)
+				return false
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(events).To(Equal([]string{"GET page 1", "a"}))
+		})
+	})
+
 	Describe("NewRequest", func() {
 		var (
 			request *Request
@@ -140,6 +328,21 @@ var _ = Describe("Gateway", func() {
 
 		})
 
+		Context("when the body is a reader of a known size", func() {
+			BeforeEach(func() {
+				request, apiErr = ccGateway.NewRequestForReader("PUT", "https://example.com/v2/apps", "BEARER my-access-token", strings.NewReader("0123"), 4)
+				Expect(apiErr).NotTo(HaveOccurred())
+			})
+
+			It("Uses a ProgressReader as the SeekableBody", func() {
+				Expect(reflect.TypeOf(request.SeekableBody).String()).To(ContainSubstring("ProgressReader"))
+			})
+
+			It("sets the content length to the size of the body", func() {
+				Expect(request.HTTPReq.ContentLength).To(Equal(int64(4)))
+			})
+		})
+
 	})
 
 	Describe("PerformRequestForJSONResponse()", func() {
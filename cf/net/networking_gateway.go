@@ -0,0 +1,46 @@
+package net
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/cf/trace"
+)
+
+type networkingErrorResponse struct {
+	Error string `json:"error"`
+}
+
+var networkingErrorHandler = func(statusCode int, body []byte) error {
+	response := networkingErrorResponse{}
+	_ = json.Unmarshal(body, &response)
+
+	if statusCode == http.StatusUnauthorized {
+		return errors.NewInvalidTokenError(response.Error)
+	}
+
+	return errors.NewHTTPError(statusCode, "", response.Error)
+}
+
+func NewNetworkingGateway(config coreconfig.Reader, clock func() time.Time, ui terminal.UI, logger trace.Printer, envDialTimeout string) Gateway {
+	gateway := Gateway{
+		errHandler:      networkingErrorHandler,
+		config:          config,
+		PollingThrottle: DefaultPollingThrottle,
+		warnings:        &[]string{},
+		Clock:           clock,
+		ui:              ui,
+		logger:          logger,
+		PollingEnabled:  false,
+		DialTimeout:     dialTimeout(envDialTimeout),
+		RetryBackoff:    DefaultRetryBackoff,
+		connections:     newConnectionPool(),
+		pageListener:    &pageListener{},
+	}
+	gateway.SetMaxRetries(DefaultMaxRetries)
+	return gateway
+}
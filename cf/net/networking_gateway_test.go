@@ -0,0 +1,58 @@
+package net_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	. "code.cloudfoundry.org/cli/cf/net"
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Networking Gateway", func() {
+	var (
+		gateway Gateway
+		config  coreconfig.Reader
+	)
+
+	BeforeEach(func() {
+		config = testconfig.NewRepository()
+		gateway = NewNetworkingGateway(config, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "1")
+	})
+
+	It("parses error responses", func() {
+		ts := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintln(writer, `{ "error": "policy is invalid: missing destination" }`)
+		}))
+		defer ts.Close()
+
+		request, apiErr := gateway.NewRequest("GET", ts.URL, "TOKEN", nil)
+		_, apiErr = gateway.PerformRequest(request)
+
+		Expect(apiErr).To(HaveOccurred())
+		Expect(apiErr.Error()).To(ContainSubstring("policy is invalid: missing destination"))
+		Expect(apiErr.(errors.HTTPError).StatusCode()).To(Equal(http.StatusBadRequest))
+	})
+
+	It("parses unauthorized responses as invalid token errors", func() {
+		ts := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintln(writer, `{ "error": "authenticator: token expired" }`)
+		}))
+		defer ts.Close()
+
+		request, apiErr := gateway.NewRequest("GET", ts.URL, "TOKEN", nil)
+		_, apiErr = gateway.PerformRequest(request)
+
+		Expect(apiErr).To(BeAssignableToTypeOf(&errors.InvalidTokenError{}))
+		Expect(apiErr.Error()).To(ContainSubstring("token expired"))
+	})
+})
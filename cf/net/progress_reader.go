@@ -1,8 +1,10 @@
 package net
 
 import (
+	"fmt"
 	"io"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -10,6 +12,11 @@ import (
 	"code.cloudfoundry.org/cli/cf/terminal"
 )
 
+const (
+	progressBarWidth           = 30
+	progressBarRefreshInterval = 200 * time.Millisecond
+)
+
 type ProgressReader struct {
 	ioReadSeeker   io.ReadSeeker
 	bytesRead      int64
@@ -17,6 +24,8 @@ type ProgressReader struct {
 	quit           chan bool
 	ui             terminal.UI
 	outputInterval time.Duration
+	interactive    bool
+	startTime      time.Time
 	mutex          sync.RWMutex
 }
 
@@ -25,6 +34,7 @@ func NewProgressReader(readSeeker io.ReadSeeker, ui terminal.UI, outputInterval
 		ioReadSeeker:   readSeeker,
 		ui:             ui,
 		outputInterval: outputInterval,
+		interactive:    terminal.IsTerminal(),
 		mutex:          sync.RWMutex{},
 	}
 }
@@ -39,6 +49,7 @@ func (progressReader *ProgressReader) Read(p []byte) (int, error) {
 	if progressReader.total > int64(0) {
 		if n > 0 {
 			if progressReader.quit == nil {
+				progressReader.startTime = time.Now()
 				progressReader.quit = make(chan bool)
 				go progressReader.printProgress(progressReader.quit)
 			}
@@ -62,24 +73,80 @@ func (progressReader *ProgressReader) Seek(offset int64, whence int) (int64, err
 }
 
 func (progressReader *ProgressReader) printProgress(quit chan bool) {
-	timer := time.NewTicker(progressReader.outputInterval)
+	interval := progressReader.outputInterval
+	if progressReader.interactive && interval > progressBarRefreshInterval {
+		interval = progressBarRefreshInterval
+	}
+	timer := time.NewTicker(interval)
+	defer timer.Stop()
+
+	lineLength := 0
 
 	for {
 		select {
 		case <-quit:
+			if !progressReader.interactive {
+				progressReader.ui.Say("Done uploading")
+				return
+			}
+
 			//The spaces are there to ensure we overwrite the entire line
 			//before using the terminal printer to output Done Uploading
-			progressReader.ui.PrintCapturingNoOutput("\r                             ")
+			progressReader.ui.PrintCapturingNoOutput("\r%s", strings.Repeat(" ", lineLength))
 			progressReader.ui.Say("\rDone uploading")
 			return
 		case <-timer.C:
-			progressReader.mutex.RLock()
-			progressReader.ui.PrintCapturingNoOutput("\r%s uploaded...", formatters.ByteSize(progressReader.bytesRead))
-			progressReader.mutex.RUnlock()
+			sent, rate, eta := progressReader.progress()
+
+			if !progressReader.interactive {
+				progressReader.ui.Say("%s of %s uploaded (%s/s, ETA %s)",
+					formatters.ByteSize(sent), formatters.ByteSize(progressReader.total), formatters.ByteSize(rate), eta)
+				continue
+			}
+
+			line := fmt.Sprintf("%s %s/%s %s/s ETA %s",
+				progressBar(sent, progressReader.total),
+				formatters.ByteSize(sent), formatters.ByteSize(progressReader.total), formatters.ByteSize(rate), eta)
+			if len(line) > lineLength {
+				lineLength = len(line)
+			}
+			progressReader.ui.PrintCapturingNoOutput("\r%s", line)
 		}
 	}
 }
 
+// progress returns the number of bytes read so far, the average number of
+// bytes read per second since reading started and the estimated time left.
+func (progressReader *ProgressReader) progress() (int64, int64, string) {
+	progressReader.mutex.RLock()
+	sent := progressReader.bytesRead
+	progressReader.mutex.RUnlock()
+
+	elapsed := time.Since(progressReader.startTime).Seconds()
+	if elapsed <= 0 || sent == 0 {
+		return sent, 0, "--"
+	}
+
+	rate := float64(sent) / elapsed
+	secondsLeft := int64(float64(progressReader.total-sent) / rate)
+	return sent, int64(rate), (time.Duration(secondsLeft) * time.Second).String()
+}
+
+func progressBar(sent int64, total int64) string {
+	filled := int(sent * progressBarWidth / total)
+	if filled >= progressBarWidth {
+		return "[" + strings.Repeat("=", progressBarWidth) + "]"
+	}
+	return "[" + strings.Repeat("=", filled) + ">" + strings.Repeat(" ", progressBarWidth-filled-1) + "]"
+}
+
+// SetInteractive sets whether progress is drawn as a bar that is redrawn in
+// place, or printed as a line of its own every output interval. It defaults
+// to whether standard output is a terminal.
+func (progressReader *ProgressReader) SetInteractive(interactive bool) {
+	progressReader.interactive = interactive
+}
+
 func (progressReader *ProgressReader) SetTotalSize(size int64) {
 	progressReader.total = size
 }
@@ -34,7 +34,7 @@ var _ = Describe("ProgressReader", func() {
 		progressReader.SetTotalSize(fileStat.Size())
 	})
 
-	It("prints progress while content is being read", func() {
+	readAll := func() {
 		for {
 			time.Sleep(50 * time.Microsecond)
 			_, err := progressReader.Read(b)
@@ -42,15 +42,48 @@ var _ = Describe("ProgressReader", func() {
 				break
 			}
 		}
+	}
 
-		Expect(ui.SayCallCount()).To(Equal(1))
-		Expect(ui.SayArgsForCall(0)).To(ContainSubstring("\rDone "))
+	Context("when output is a terminal", func() {
+		BeforeEach(func() {
+			progressReader.SetInteractive(true)
+		})
 
-		Expect(ui.PrintCapturingNoOutputCallCount()).To(BeNumerically(">", 0))
-		status, _ := ui.PrintCapturingNoOutputArgsForCall(0)
-		Expect(status).To(ContainSubstring("uploaded..."))
-		status, _ = ui.PrintCapturingNoOutputArgsForCall(ui.PrintCapturingNoOutputCallCount() - 1)
-		Expect(status).To(Equal("\r                             "))
+		It("draws a progress bar while content is being read", func() {
+			readAll()
+
+			Expect(ui.SayCallCount()).To(Equal(1))
+			Expect(ui.SayArgsForCall(0)).To(ContainSubstring("\rDone "))
+
+			Expect(ui.PrintCapturingNoOutputCallCount()).To(BeNumerically(">", 1))
+			format, args := ui.PrintCapturingNoOutputArgsForCall(0)
+			Expect(format).To(Equal("\r%s"))
+			Expect(args[0]).To(MatchRegexp(`^\[=*>? *\] \S+/200K \S+/s ETA \S+$`))
+
+			format, args = ui.PrintCapturingNoOutputArgsForCall(ui.PrintCapturingNoOutputCallCount() - 1)
+			Expect(format).To(Equal("\r%s"))
+			Expect(args[0]).To(MatchRegexp(`^ +$`))
+		})
+	})
+
+	Context("when output is not a terminal", func() {
+		BeforeEach(func() {
+			progressReader.SetInteractive(false)
+		})
+
+		It("prints a line of progress every interval while content is being read", func() {
+			readAll()
+
+			Expect(ui.PrintCapturingNoOutputCallCount()).To(BeZero())
+			Expect(ui.SayCallCount()).To(BeNumerically(">", 1))
+
+			format, args := ui.SayArgsForCall(0)
+			Expect(format).To(Equal("%s of %s uploaded (%s/s, ETA %s)"))
+			Expect(args[1]).To(Equal("200K"))
+
+			format, _ = ui.SayArgsForCall(ui.SayCallCount() - 1)
+			Expect(format).To(Equal("Done uploading"))
+		})
 	})
 
 	It("reads the correct number of bytes", func() {
@@ -27,7 +27,7 @@ func errorHandler(statusCode int, body []byte) error {
 }
 
 func NewRoutingAPIGateway(config coreconfig.Reader, clock func() time.Time, ui terminal.UI, logger trace.Printer, envDialTimeout string) Gateway {
-	return Gateway{
+	gateway := Gateway{
 		errHandler:      errorHandler,
 		config:          config,
 		PollingThrottle: DefaultPollingThrottle,
@@ -37,5 +37,10 @@ func NewRoutingAPIGateway(config coreconfig.Reader, clock func() time.Time, ui t
 		logger:          logger,
 		PollingEnabled:  true,
 		DialTimeout:     dialTimeout(envDialTimeout),
+		RetryBackoff:    DefaultRetryBackoff,
+		connections:     newConnectionPool(),
+		pageListener:    &pageListener{},
 	}
+	gateway.SetMaxRetries(DefaultMaxRetries)
+	return gateway
 }
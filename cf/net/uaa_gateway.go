@@ -27,7 +27,7 @@ var uaaErrorHandler = func(statusCode int, body []byte) error {
 }
 
 func NewUAAGateway(config coreconfig.Reader, ui terminal.UI, logger trace.Printer, envDialTimeout string) Gateway {
-	return Gateway{
+	gateway := Gateway{
 		errHandler:      uaaErrorHandler,
 		config:          config,
 		PollingThrottle: DefaultPollingThrottle,
@@ -37,5 +37,10 @@ func NewUAAGateway(config coreconfig.Reader, ui terminal.UI, logger trace.Printe
 		logger:          logger,
 		PollingEnabled:  false,
 		DialTimeout:     dialTimeout(envDialTimeout),
+		RetryBackoff:    DefaultRetryBackoff,
+		connections:     newConnectionPool(),
+		pageListener:    &pageListener{},
 	}
+	gateway.SetMaxRetries(DefaultMaxRetries)
+	return gateway
 }
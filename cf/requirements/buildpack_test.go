@@ -13,17 +13,24 @@ var _ = Describe("BuildpackRequirement", func() {
 		buildpack := models.Buildpack{Name: "my-buildpack"}
 		buildpackRepo := &apifakes.OldFakeBuildpackRepository{FindByNameBuildpack: buildpack}
 
-		buildpackReq := NewBuildpackRequirement("my-buildpack", buildpackRepo)
+		buildpackReq := NewBuildpackRequirement("my-buildpack", "", buildpackRepo)
 
 		Expect(buildpackReq.Execute()).NotTo(HaveOccurred())
 		Expect(buildpackRepo.FindByNameName).To(Equal("my-buildpack"))
 		Expect(buildpackReq.GetBuildpack()).To(Equal(buildpack))
 	})
 
+	It("looks the buildpack up for the given stack", func() {
+		buildpackRepo := &apifakes.OldFakeBuildpackRepository{FindByNameBuildpack: models.Buildpack{Name: "my-buildpack", Stack: "cflinuxfs2"}}
+
+		Expect(NewBuildpackRequirement("my-buildpack", "cflinuxfs2", buildpackRepo).Execute()).To(Succeed())
+		Expect(buildpackRepo.FindByNameStack).To(Equal("cflinuxfs2"))
+	})
+
 	It("fails when the buildpack cannot be found", func() {
 		buildpackRepo := &apifakes.OldFakeBuildpackRepository{FindByNameNotFound: true}
 
-		err := NewBuildpackRequirement("foo", buildpackRepo).Execute()
+		err := NewBuildpackRequirement("foo", "", buildpackRepo).Execute()
 		Expect(err).To(HaveOccurred())
 		Expect(err.Error()).To(ContainSubstring("Buildpack foo not found"))
 	})
@@ -21,9 +21,10 @@ type Factory interface {
 	NewTargetedSpaceRequirement() Requirement
 	NewTargetedOrgRequirement() TargetedOrgRequirement
 	NewOrganizationRequirement(name string) OrganizationRequirement
+	NewTargetOverrideRequirement(orgName, spaceName string) Requirement
 	NewDomainRequirement(name string) DomainRequirement
 	NewUserRequirement(username string, wantGUID bool) UserRequirement
-	NewBuildpackRequirement(buildpack string) BuildpackRequirement
+	NewBuildpackRequirement(buildpack, stack string) BuildpackRequirement
 	NewAPIEndpointRequirement() Requirement
 	NewMinAPIVersionRequirement(commandName string, requiredVersion semver.Version) Requirement
 	NewMaxAPIVersionRequirement(commandName string, maximumVersion semver.Version) Requirement
@@ -111,6 +112,16 @@ func (f apiRequirementFactory) NewOrganizationRequirement(name string) Organizat
 	)
 }
 
+func (f apiRequirementFactory) NewTargetOverrideRequirement(orgName, spaceName string) Requirement {
+	return NewTargetOverrideRequirement(
+		orgName,
+		spaceName,
+		f.config,
+		f.repoLocator.GetOrganizationRepository(),
+		f.repoLocator.GetSpaceRepository(),
+	)
+}
+
 func (f apiRequirementFactory) NewDomainRequirement(name string) DomainRequirement {
 	return NewDomainRequirement(
 		name,
@@ -127,9 +138,10 @@ func (f apiRequirementFactory) NewUserRequirement(username string, wantGUID bool
 	)
 }
 
-func (f apiRequirementFactory) NewBuildpackRequirement(buildpack string) BuildpackRequirement {
+func (f apiRequirementFactory) NewBuildpackRequirement(buildpack, stack string) BuildpackRequirement {
 	return NewBuildpackRequirement(
 		buildpack,
+		stack,
 		f.repoLocator.GetBuildpackRepository(),
 	)
 }
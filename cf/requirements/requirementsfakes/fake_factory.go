@@ -81,6 +81,15 @@ type FakeFactory struct {
 	newOrganizationRequirementReturns struct {
 		result1 requirements.OrganizationRequirement
 	}
+	NewTargetOverrideRequirementStub        func(orgName, spaceName string) requirements.Requirement
+	newTargetOverrideRequirementMutex       sync.RWMutex
+	newTargetOverrideRequirementArgsForCall []struct {
+		orgName   string
+		spaceName string
+	}
+	newTargetOverrideRequirementReturns struct {
+		result1 requirements.Requirement
+	}
 	NewDomainRequirementStub        func(name string) requirements.DomainRequirement
 	newDomainRequirementMutex       sync.RWMutex
 	newDomainRequirementArgsForCall []struct {
@@ -98,10 +107,11 @@ type FakeFactory struct {
 	newUserRequirementReturns struct {
 		result1 requirements.UserRequirement
 	}
-	NewBuildpackRequirementStub        func(buildpack string) requirements.BuildpackRequirement
+	NewBuildpackRequirementStub        func(buildpack, stack string) requirements.BuildpackRequirement
 	newBuildpackRequirementMutex       sync.RWMutex
 	newBuildpackRequirementArgsForCall []struct {
 		buildpack string
+		stack     string
 	}
 	newBuildpackRequirementReturns struct {
 		result1 requirements.BuildpackRequirement
@@ -451,6 +461,40 @@ func (fake *FakeFactory) NewOrganizationRequirementReturns(result1 requirements.
 	}{result1}
 }
 
+func (fake *FakeFactory) NewTargetOverrideRequirement(orgName string, spaceName string) requirements.Requirement {
+	fake.newTargetOverrideRequirementMutex.Lock()
+	fake.newTargetOverrideRequirementArgsForCall = append(fake.newTargetOverrideRequirementArgsForCall, struct {
+		orgName   string
+		spaceName string
+	}{orgName, spaceName})
+	fake.recordInvocation("NewTargetOverrideRequirement", []interface{}{orgName, spaceName})
+	fake.newTargetOverrideRequirementMutex.Unlock()
+	if fake.NewTargetOverrideRequirementStub != nil {
+		return fake.NewTargetOverrideRequirementStub(orgName, spaceName)
+	} else {
+		return fake.newTargetOverrideRequirementReturns.result1
+	}
+}
+
+func (fake *FakeFactory) NewTargetOverrideRequirementCallCount() int {
+	fake.newTargetOverrideRequirementMutex.RLock()
+	defer fake.newTargetOverrideRequirementMutex.RUnlock()
+	return len(fake.newTargetOverrideRequirementArgsForCall)
+}
+
+func (fake *FakeFactory) NewTargetOverrideRequirementArgsForCall(i int) (string, string) {
+	fake.newTargetOverrideRequirementMutex.RLock()
+	defer fake.newTargetOverrideRequirementMutex.RUnlock()
+	return fake.newTargetOverrideRequirementArgsForCall[i].orgName, fake.newTargetOverrideRequirementArgsForCall[i].spaceName
+}
+
+func (fake *FakeFactory) NewTargetOverrideRequirementReturns(result1 requirements.Requirement) {
+	fake.NewTargetOverrideRequirementStub = nil
+	fake.newTargetOverrideRequirementReturns = struct {
+		result1 requirements.Requirement
+	}{result1}
+}
+
 func (fake *FakeFactory) NewDomainRequirement(name string) requirements.DomainRequirement {
 	fake.newDomainRequirementMutex.Lock()
 	fake.newDomainRequirementArgsForCall = append(fake.newDomainRequirementArgsForCall, struct {
@@ -518,15 +562,16 @@ func (fake *FakeFactory) NewUserRequirementReturns(result1 requirements.UserRequ
 	}{result1}
 }
 
-func (fake *FakeFactory) NewBuildpackRequirement(buildpack string) requirements.BuildpackRequirement {
+func (fake *FakeFactory) NewBuildpackRequirement(buildpack string, stack string) requirements.BuildpackRequirement {
 	fake.newBuildpackRequirementMutex.Lock()
 	fake.newBuildpackRequirementArgsForCall = append(fake.newBuildpackRequirementArgsForCall, struct {
 		buildpack string
-	}{buildpack})
-	fake.recordInvocation("NewBuildpackRequirement", []interface{}{buildpack})
+		stack     string
+	}{buildpack, stack})
+	fake.recordInvocation("NewBuildpackRequirement", []interface{}{buildpack, stack})
 	fake.newBuildpackRequirementMutex.Unlock()
 	if fake.NewBuildpackRequirementStub != nil {
-		return fake.NewBuildpackRequirementStub(buildpack)
+		return fake.NewBuildpackRequirementStub(buildpack, stack)
 	} else {
 		return fake.newBuildpackRequirementReturns.result1
 	}
@@ -538,10 +583,10 @@ func (fake *FakeFactory) NewBuildpackRequirementCallCount() int {
 	return len(fake.newBuildpackRequirementArgsForCall)
 }
 
-func (fake *FakeFactory) NewBuildpackRequirementArgsForCall(i int) string {
+func (fake *FakeFactory) NewBuildpackRequirementArgsForCall(i int) (string, string) {
 	fake.newBuildpackRequirementMutex.RLock()
 	defer fake.newBuildpackRequirementMutex.RUnlock()
-	return fake.newBuildpackRequirementArgsForCall[i].buildpack
+	return fake.newBuildpackRequirementArgsForCall[i].buildpack, fake.newBuildpackRequirementArgsForCall[i].stack
 }
 
 func (fake *FakeFactory) NewBuildpackRequirementReturns(result1 requirements.BuildpackRequirement) {
@@ -741,6 +786,8 @@ func (fake *FakeFactory) Invocations() map[string][][]interface{} {
 	defer fake.newTargetedOrgRequirementMutex.RUnlock()
 	fake.newOrganizationRequirementMutex.RLock()
 	defer fake.newOrganizationRequirementMutex.RUnlock()
+	fake.newTargetOverrideRequirementMutex.RLock()
+	defer fake.newTargetOverrideRequirementMutex.RUnlock()
 	fake.newDomainRequirementMutex.RLock()
 	defer fake.newDomainRequirementMutex.RUnlock()
 	fake.newUserRequirementMutex.RLock()
@@ -0,0 +1,69 @@
+package requirements
+
+import (
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/cli/cf/api/organizations"
+	"code.cloudfoundry.org/cli/cf/api/spaces"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+)
+
+// TargetOverrideRequirement targets the org and space given with the global
+// --org and --space options for a single command, leaving the target in the
+// config file alone.
+type TargetOverrideRequirement struct {
+	orgName   string
+	spaceName string
+	config    coreconfig.ReadWriter
+	orgRepo   organizations.OrganizationRepository
+	spaceRepo spaces.SpaceRepository
+}
+
+func NewTargetOverrideRequirement(orgName, spaceName string, config coreconfig.ReadWriter, orgRepo organizations.OrganizationRepository, spaceRepo spaces.SpaceRepository) TargetOverrideRequirement {
+	return TargetOverrideRequirement{
+		orgName:   orgName,
+		spaceName: spaceName,
+		config:    config,
+		orgRepo:   orgRepo,
+		spaceRepo: spaceRepo,
+	}
+}
+
+func (req TargetOverrideRequirement) Execute() error {
+	err := NewLoginRequirement(req.config).Execute()
+	if err != nil {
+		return err
+	}
+
+	req.config.OverrideTarget()
+
+	if req.orgName != "" {
+		org, apiErr := req.orgRepo.FindByName(req.orgName)
+		if apiErr != nil {
+			return fmt.Errorf(T("Could not target org.\n{{.APIErr}}",
+				map[string]interface{}{"APIErr": apiErr.Error()}))
+		}
+
+		req.config.SetOrganizationFields(org.OrganizationFields)
+		req.config.SetSpaceFields(models.SpaceFields{})
+	}
+
+	if req.spaceName != "" {
+		if !req.config.HasOrganization() {
+			return errors.New(T("An org must be targeted before targeting a space"))
+		}
+
+		space, apiErr := req.spaceRepo.FindByName(req.spaceName)
+		if apiErr != nil {
+			return fmt.Errorf(T("Unable to access space {{.SpaceName}}.\n{{.APIErr}}",
+				map[string]interface{}{"SpaceName": req.spaceName, "APIErr": apiErr.Error()}))
+		}
+
+		req.config.SetSpaceFields(space.SpaceFields)
+	}
+
+	return nil
+}
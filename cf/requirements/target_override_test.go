@@ -0,0 +1,94 @@
+package requirements_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/api/organizations/organizationsfakes"
+	"code.cloudfoundry.org/cli/cf/api/spaces/spacesfakes"
+	"code.cloudfoundry.org/cli/cf/configuration"
+	"code.cloudfoundry.org/cli/cf/configuration/configurationfakes"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	. "code.cloudfoundry.org/cli/cf/requirements"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TargetOverrideRequirement", func() {
+	var (
+		persistor *configurationfakes.FakePersistor
+		savedData *coreconfig.Data
+		config    coreconfig.Repository
+		orgRepo   *organizationsfakes.FakeOrganizationRepository
+		spaceRepo *spacesfakes.FakeSpaceRepository
+	)
+
+	BeforeEach(func() {
+		persistor = new(configurationfakes.FakePersistor)
+		persistor.ExistsReturns(true)
+		persistor.SaveStub = func(data configuration.DataInterface) error {
+			saved := *data.(*coreconfig.Data)
+			savedData = &saved
+			return nil
+		}
+		config = coreconfig.NewRepositoryFromPersistor(persistor, func(err error) { panic(err) })
+		config.SetAPIEndpoint("https://api.example.com")
+		config.SetAccessToken("bearer some-token")
+		config.SetOrganizationFields(models.OrganizationFields{Name: "file-org", GUID: "file-org-guid"})
+		config.SetSpaceFields(models.SpaceFields{Name: "file-space", GUID: "file-space-guid"})
+
+		orgRepo = new(organizationsfakes.FakeOrganizationRepository)
+		orgRepo.FindByNameReturns(models.Organization{
+			OrganizationFields: models.OrganizationFields{Name: "other-org", GUID: "other-org-guid"},
+		}, nil)
+		spaceRepo = new(spacesfakes.FakeSpaceRepository)
+		spaceRepo.FindByNameReturns(models.Space{
+			SpaceFields: models.SpaceFields{Name: "other-space", GUID: "other-space-guid"},
+		}, nil)
+	})
+
+	It("targets the org and space without changing the config file", func() {
+		err := NewTargetOverrideRequirement("other-org", "other-space", config, orgRepo, spaceRepo).Execute()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(orgRepo.FindByNameArgsForCall(0)).To(Equal("other-org"))
+		Expect(spaceRepo.FindByNameArgsForCall(0)).To(Equal("other-space"))
+		Expect(config.OrganizationFields().GUID).To(Equal("other-org-guid"))
+		Expect(config.SpaceFields().GUID).To(Equal("other-space-guid"))
+
+		Expect(savedData.OrganizationFields.GUID).To(Equal("file-org-guid"))
+		Expect(savedData.SpaceFields.GUID).To(Equal("file-space-guid"))
+	})
+
+	It("keeps the targeted org when only the space is given", func() {
+		err := NewTargetOverrideRequirement("", "other-space", config, orgRepo, spaceRepo).Execute()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(orgRepo.FindByNameCallCount()).To(Equal(0))
+		Expect(config.OrganizationFields().GUID).To(Equal("file-org-guid"))
+		Expect(config.SpaceFields().GUID).To(Equal("other-space-guid"))
+	})
+
+	It("untargets the space when only the org is given", func() {
+		err := NewTargetOverrideRequirement("other-org", "", config, orgRepo, spaceRepo).Execute()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(config.HasSpace()).To(BeFalse())
+		Expect(savedData.SpaceFields.GUID).To(Equal("file-space-guid"))
+	})
+
+	It("fails when the org cannot be found", func() {
+		orgRepo.FindByNameReturns(models.Organization{}, errors.New("org not found"))
+
+		err := NewTargetOverrideRequirement("other-org", "", config, orgRepo, spaceRepo).Execute()
+		Expect(err).To(MatchError("Could not target org.\norg not found"))
+	})
+
+	It("fails when the user is not logged in", func() {
+		config.SetAccessToken("")
+
+		err := NewTargetOverrideRequirement("other-org", "", config, orgRepo, spaceRepo).Execute()
+		Expect(err).To(HaveOccurred())
+		Expect(orgRepo.FindByNameCallCount()).To(Equal(0))
+	})
+})
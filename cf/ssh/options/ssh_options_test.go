@@ -20,6 +20,7 @@ var _ = Describe("SSHOptions", func() {
 		BeforeEach(func() {
 			fc = flags.New()
 			fc.NewStringSliceFlag("L", "", "")
+			fc.NewStringSliceFlag("D", "", "")
 			fc.NewStringSliceFlag("command", "c", "")
 			fc.NewIntFlag("app-instance-index", "i", "")
 			fc.NewBoolFlag("skip-host-validation", "k", "")
@@ -281,6 +282,44 @@ var _ = Describe("SSHOptions", func() {
 			})
 		})
 
+		Context("when dynamic port forwarding is requested", func() {
+			BeforeEach(func() {
+				args = append(args, "app-name")
+			})
+
+			Context("without an explicit bind address", func() {
+				BeforeEach(func() {
+					args = append(args, "-D", "1080")
+				})
+
+				It("listens on localhost", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.DynamicForwardSpecs).To(ConsistOf("localhost:1080"))
+				})
+			})
+
+			Context("with an explicit bind address", func() {
+				BeforeEach(func() {
+					args = append(args, "-D", "[::]:1080", "-D", "*:1081")
+				})
+
+				It("listens on the bind addresses", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.DynamicForwardSpecs).To(ConsistOf("[::]:1080", ":1081"))
+				})
+			})
+
+			Context("with too many parts", func() {
+				BeforeEach(func() {
+					args = append(args, "-D", "localhost:1080:remote")
+				})
+
+				It("returns an error", func() {
+					Expect(parseError).To(MatchError(`Unable to parse dynamic forwarding argument: "localhost:1080:remote"`))
+				})
+			})
+		})
+
 		Context("when -N is specified", func() {
 			BeforeEach(func() {
 				args = append(args, "app-name", "-N")
@@ -0,0 +1,345 @@
+package sshCmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/formatters"
+)
+
+// Upload copies a local file, or with recursive a local directory, to
+// remotePath in the app instance. It runs scp in sink mode in the container,
+// which the Diego SSH daemon implements, and speaks the scp protocol to it.
+// The name and progress of each file copied are written to progress.
+func (c *secureShell) Upload(localPath string, remotePath string, recursive bool, progress io.Writer) error {
+	absPath, err := filepath.Abs(localPath)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() && !recursive {
+		return fmt.Errorf("%s is a directory; use -r to copy it", localPath)
+	}
+
+	return c.runSCP(scpCommand("-t", remotePath, recursive), func(w io.Writer, r *bufio.Reader) error {
+		err := readSCPAck(r)
+		if err != nil {
+			return err
+		}
+
+		return sendSCPEntry(w, r, absPath, filepath.Base(absPath), info, progress)
+	})
+}
+
+// Download copies remotePath in the app instance, or with recursive the
+// directory at remotePath, to localPath. If localPath is an existing directory
+// the copy is made inside it. The name and progress of each file copied are
+// written to progress.
+func (c *secureShell) Download(remotePath string, localPath string, recursive bool, progress io.Writer) error {
+	return c.runSCP(scpCommand("-f", remotePath, recursive), func(w io.Writer, r *bufio.Reader) error {
+		return receiveSCPEntries(w, r, localPath, progress)
+	})
+}
+
+func (c *secureShell) runSCP(command string, transfer func(io.Writer, *bufio.Reader) error) error {
+	session, err := c.secureClient.NewSession()
+	if err != nil {
+		return fmt.Errorf("SSH session allocation failed: %s", err.Error())
+	}
+	defer session.Close()
+
+	inPipe, err := session.StdinPipe()
+	if err != nil {
+		return err
+	}
+
+	outPipe, err := session.StdoutPipe()
+	if err != nil {
+		return err
+	}
+
+	err = session.Start(command)
+	if err != nil {
+		return err
+	}
+
+	transferErr := transfer(inPipe, bufio.NewReader(outPipe))
+	_ = inPipe.Close()
+
+	waitErr := session.Wait()
+	if transferErr != nil {
+		return transferErr
+	}
+	return waitErr
+}
+
+func scpCommand(mode string, path string, recursive bool) string {
+	command := "scp " + mode
+	if recursive {
+		command += " -r"
+	}
+	return command + " '" + strings.Replace(path, "'", `'\''`, -1) + "'"
+}
+
+func sendSCPEntry(w io.Writer, r *bufio.Reader, path string, name string, info os.FileInfo, progress io.Writer) error {
+	if info.IsDir() {
+		_, err := fmt.Fprintf(w, "D%04o 0 %s\n", info.Mode().Perm(), name)
+		if err != nil {
+			return err
+		}
+		err = readSCPAck(r)
+		if err != nil {
+			return err
+		}
+
+		entries, err := ioutil.ReadDir(path)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			entryPath := filepath.Join(path, entry.Name())
+
+			// follow symlinks, as scp does, and skip anything that is not a
+			// file or a directory
+			entryInfo, err := os.Stat(entryPath)
+			if err != nil || !(entryInfo.IsDir() || entryInfo.Mode().IsRegular()) {
+				continue
+			}
+
+			err = sendSCPEntry(w, r, entryPath, entry.Name(), entryInfo, progress)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = fmt.Fprint(w, "E\n")
+		if err != nil {
+			return err
+		}
+		return readSCPAck(r)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(w, "C%04o %d %s\n", info.Mode().Perm(), info.Size(), name)
+	if err != nil {
+		return err
+	}
+	err = readSCPAck(r)
+	if err != nil {
+		return err
+	}
+
+	reporter := newSCPProgress(progress, name, info.Size())
+	_, err = io.Copy(w, io.TeeReader(file, reporter))
+	if err != nil {
+		return err
+	}
+	reporter.Done()
+
+	err = sendSCPAck(w)
+	if err != nil {
+		return err
+	}
+	return readSCPAck(r)
+}
+
+func receiveSCPEntries(w io.Writer, r *bufio.Reader, target string, progress io.Writer) error {
+	dirs := []string{}
+	pathFor := func(name string) string {
+		if len(dirs) > 0 {
+			return filepath.Join(dirs[len(dirs)-1], name)
+		}
+		if info, err := os.Stat(target); err == nil && info.IsDir() {
+			return filepath.Join(target, name)
+		}
+		return target
+	}
+
+	err := sendSCPAck(w)
+	if err != nil {
+		return err
+	}
+
+	for {
+		line, err := r.ReadString('\n')
+		if err == io.EOF && line == "" {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch line[0] {
+		case 1, 2:
+			return errors.New(strings.TrimSpace(line[1:]))
+		case 'T':
+		case 'E':
+			if len(dirs) == 0 {
+				return fmt.Errorf("unexpected scp message %q", strings.TrimSpace(line))
+			}
+			dirs = dirs[:len(dirs)-1]
+		case 'C', 'D':
+			mode, size, name, err := parseSCPHeader(line)
+			if err != nil {
+				return err
+			}
+			path := pathFor(name)
+
+			if line[0] == 'D' {
+				err = os.Mkdir(path, mode)
+				if err != nil && !os.IsExist(err) {
+					return err
+				}
+				dirs = append(dirs, path)
+				break
+			}
+
+			err = sendSCPAck(w)
+			if err != nil {
+				return err
+			}
+
+			err = receiveSCPFile(r, path, mode, size, newSCPProgress(progress, name, size))
+			if err != nil {
+				return err
+			}
+
+			err = readSCPAck(r)
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unexpected scp message %q", strings.TrimSpace(line))
+		}
+
+		err = sendSCPAck(w)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func receiveSCPFile(r io.Reader, path string, mode os.FileMode, size int64, reporter *scpProgress) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.CopyN(file, io.TeeReader(r, reporter), size)
+	if err != nil {
+		return err
+	}
+	reporter.Done()
+
+	return nil
+}
+
+// parseSCPHeader parses a "C0644 1234 name" or "D0755 0 name" message.
+func parseSCPHeader(line string) (os.FileMode, int64, string, error) {
+	parts := strings.SplitN(strings.TrimSuffix(line[1:], "\n"), " ", 3)
+	if len(parts) != 3 {
+		return 0, 0, "", fmt.Errorf("unexpected scp message %q", strings.TrimSpace(line))
+	}
+
+	mode, err := strconv.ParseUint(parts[0], 8, 32)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("unexpected scp message %q", strings.TrimSpace(line))
+	}
+
+	size, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("unexpected scp message %q", strings.TrimSpace(line))
+	}
+
+	name := parts[2]
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return 0, 0, "", fmt.Errorf("refusing to copy file with invalid name %q", name)
+	}
+
+	return os.FileMode(mode).Perm(), size, name, nil
+}
+
+func sendSCPAck(w io.Writer) error {
+	_, err := w.Write([]byte{0})
+	return err
+}
+
+// readSCPAck reads the reply to an scp message: a zero byte, or a 1 or 2
+// followed by a warning or error message.
+func readSCPAck(r *bufio.Reader) error {
+	status, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+
+	if status == 0 {
+		return nil
+	}
+
+	message, err := r.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+	return errors.New(strings.TrimSpace(message))
+}
+
+// scpProgress writes the percentage of a file copied so far, overwriting the
+// same line, and its size once it has been copied.
+type scpProgress struct {
+	out     io.Writer
+	name    string
+	size    int64
+	copied  int64
+	percent int
+}
+
+func newSCPProgress(out io.Writer, name string, size int64) *scpProgress {
+	if out == nil {
+		out = ioutil.Discard
+	}
+
+	p := &scpProgress{out: out, name: name, size: size, percent: -1}
+	p.print()
+	return p
+}
+
+func (p *scpProgress) Write(data []byte) (int, error) {
+	p.copied += int64(len(data))
+	p.print()
+	return len(data), nil
+}
+
+func (p *scpProgress) Done() {
+	fmt.Fprintf(p.out, "\r%s 100%% %s\n", p.name, formatters.ByteSize(p.size))
+}
+
+func (p *scpProgress) print() {
+	percent := 100
+	if p.size > 0 {
+		percent = int(p.copied * 100 / p.size)
+	}
+
+	if percent != p.percent && percent < 100 {
+		fmt.Fprintf(p.out, "\r%s %3d%%", p.name, percent)
+	}
+	p.percent = percent
+}
@@ -0,0 +1,109 @@
+package sshCmd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+)
+
+// The subset of SOCKS5 (RFC 1928) a dynamic port forward speaks: no
+// authentication and CONNECT requests only.
+const (
+	socks5Version = 0x05
+
+	socksNoAuthentication   = 0x00
+	socksNoAcceptableMethod = 0xff
+
+	socksConnect = 0x01
+
+	socksAddressIPv4   = 0x01
+	socksAddressDomain = 0x03
+	socksAddressIPv6   = 0x04
+
+	socksSucceeded               = 0x00
+	socksHostUnreachable         = 0x04
+	socksCommandNotSupported     = 0x07
+	socksAddressTypeNotSupported = 0x08
+)
+
+// readSOCKSRequest negotiates a SOCKS5 session with a client and returns the
+// host:port it asks to connect to. The caller must answer the request with
+// writeSOCKSReply.
+func readSOCKSRequest(conn io.ReadWriter) (string, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return "", err
+	}
+	if header[0] != socks5Version {
+		return "", fmt.Errorf("unsupported SOCKS version %d", header[0])
+	}
+
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		return "", err
+	}
+	if bytes.IndexByte(methods, socksNoAuthentication) == -1 {
+		_, _ = conn.Write([]byte{socks5Version, socksNoAcceptableMethod})
+		return "", errors.New("SOCKS client requires authentication")
+	}
+	if _, err := conn.Write([]byte{socks5Version, socksNoAuthentication}); err != nil {
+		return "", err
+	}
+
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(conn, request); err != nil {
+		return "", err
+	}
+	if request[0] != socks5Version {
+		return "", fmt.Errorf("unsupported SOCKS version %d", request[0])
+	}
+	if request[1] != socksConnect {
+		_ = writeSOCKSReply(conn, socksCommandNotSupported)
+		return "", fmt.Errorf("unsupported SOCKS command %d", request[1])
+	}
+
+	var host string
+	switch request[3] {
+	case socksAddressIPv4, socksAddressIPv6:
+		size := net.IPv4len
+		if request[3] == socksAddressIPv6 {
+			size = net.IPv6len
+		}
+		ip := make([]byte, size)
+		if _, err := io.ReadFull(conn, ip); err != nil {
+			return "", err
+		}
+		host = net.IP(ip).String()
+	case socksAddressDomain:
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return "", err
+		}
+		domain := make([]byte, length[0])
+		if _, err := io.ReadFull(conn, domain); err != nil {
+			return "", err
+		}
+		host = string(domain)
+	default:
+		_ = writeSOCKSReply(conn, socksAddressTypeNotSupported)
+		return "", fmt.Errorf("unsupported SOCKS address type %d", request[3])
+	}
+
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return "", err
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), nil
+}
+
+// writeSOCKSReply answers a SOCKS5 request. The bound address is left empty,
+// since the connection is made by the SSH server rather than by the CLI.
+func writeSOCKSReply(w io.Writer, status byte) error {
+	_, err := w.Write([]byte{socks5Version, status, 0x00, socksAddressIPv4, 0, 0, 0, 0, 0, 0})
+	return err
+}
@@ -35,6 +35,9 @@ type SecureShell interface {
 	Connect(opts *options.SSHOptions) error
 	InteractiveSession() error
 	LocalPortForward() error
+	DynamicPortForward() error
+	Upload(localPath string, remotePath string, recursive bool, progress io.Writer) error
+	Download(remotePath string, localPath string, recursive bool, progress io.Writer) error
 	Wait() error
 	Close() error
 }
@@ -152,13 +155,33 @@ func (c *secureShell) LocalPortForward() error {
 		}
 		c.localListeners = append(c.localListeners, listener)
 
-		go c.localForwardAcceptLoop(listener, forwardSpec.ConnectAddress)
+		connectAddress := forwardSpec.ConnectAddress
+		go c.forwardAcceptLoop(listener, func(conn net.Conn) {
+			c.handleForwardConnection(conn, connectAddress)
+		})
 	}
 
 	return nil
 }
 
-func (c *secureShell) localForwardAcceptLoop(listener net.Listener, addr string) {
+// DynamicPortForward listens on each dynamic forward address for SOCKS5
+// clients and connects them, through the SSH connection, to the host and port
+// they ask for.
+func (c *secureShell) DynamicPortForward() error {
+	for _, listenAddress := range c.opts.DynamicForwardSpecs {
+		listener, err := c.listenerFactory.Listen("tcp", listenAddress)
+		if err != nil {
+			return err
+		}
+		c.localListeners = append(c.localListeners, listener)
+
+		go c.forwardAcceptLoop(listener, c.handleDynamicForwardConnection)
+	}
+
+	return nil
+}
+
+func (c *secureShell) forwardAcceptLoop(listener net.Listener, handle func(net.Conn)) {
 	defer listener.Close()
 
 	for {
@@ -171,7 +194,7 @@ func (c *secureShell) localForwardAcceptLoop(listener net.Listener, addr string)
 			return
 		}
 
-		go c.handleForwardConnection(conn, addr)
+		go handle(conn)
 	}
 }
 
@@ -193,6 +216,36 @@ func (c *secureShell) handleForwardConnection(conn net.Conn, targetAddr string)
 	wg.Wait()
 }
 
+func (c *secureShell) handleDynamicForwardConnection(conn net.Conn) {
+	defer conn.Close()
+
+	targetAddr, err := readSOCKSRequest(conn)
+	if err != nil {
+		fmt.Printf("SOCKS request failed: %s\n", err.Error())
+		return
+	}
+
+	target, err := c.secureClient.Dial("tcp", targetAddr)
+	if err != nil {
+		_ = writeSOCKSReply(conn, socksHostUnreachable)
+		fmt.Printf("connect to %s failed: %s\n", targetAddr, err.Error())
+		return
+	}
+	defer target.Close()
+
+	err = writeSOCKSReply(conn, socksSucceeded)
+	if err != nil {
+		return
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+
+	go copyAndClose(wg, conn, target)
+	go copyAndClose(wg, target, conn)
+	wg.Wait()
+}
+
 func copyAndClose(wg *sync.WaitGroup, dest io.WriteCloser, src io.Reader) {
 	_, _ = io.Copy(dest, src)
 	_ = dest.Close()
@@ -310,13 +363,28 @@ func (c *secureShell) InteractiveSession() error {
 	return result
 }
 
+// Wait blocks until the SSH connection is closed, or until the CLI is
+// interrupted or terminated, so that port forwards can be stopped with Ctrl-C
+// and the caller still gets to close its listeners and the connection.
 func (c *secureShell) Wait() error {
 	keepaliveStopCh := make(chan struct{})
 	defer close(keepaliveStopCh)
 
 	go keepalive(c.secureClient.Conn(), time.NewTicker(c.keepAliveInterval), keepaliveStopCh)
 
-	return c.secureClient.Wait()
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupted)
+
+	waitErr := make(chan error, 1)
+	go func() { waitErr <- c.secureClient.Wait() }()
+
+	select {
+	case err := <-waitErr:
+		return err
+	case <-interrupted:
+		return nil
+	}
 }
 
 func (c *secureShell) validateTarget(opts *options.SSHOptions) error {
@@ -7,11 +7,17 @@
 package sshCmd_test
 
 import (
+	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -1123,6 +1129,361 @@ var _ = Describe("SSH", func() {
 		})
 	})
 
+	Describe("DynamicPortForward", func() {
+		var (
+			opts                *options.SSHOptions
+			dynamicForwardError error
+
+			echoAddress  string
+			echoListener net.Listener
+
+			localAddress      string
+			realLocalListener net.Listener
+		)
+
+		BeforeEach(func() {
+			var err error
+			echoListener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			echoAddress = echoListener.Addr().String()
+
+			go func() {
+				for {
+					conn, acceptErr := echoListener.Accept()
+					if acceptErr != nil {
+						return
+					}
+					go func() {
+						io.Copy(conn, conn)
+						conn.Close()
+					}()
+				}
+			}()
+
+			realLocalListener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			localAddress = realLocalListener.Addr().String()
+			fakeListenerFactory.ListenReturns(realLocalListener, nil)
+
+			opts = &options.SSHOptions{
+				AppName:             "app-1",
+				DynamicForwardSpecs: []string{localAddress},
+			}
+
+			currentApp.State = "STARTED"
+			currentApp.Diego = true
+
+			fakeSecureClient.DialStub = net.Dial
+		})
+
+		JustBeforeEach(func() {
+			connectErr := secureShell.Connect(opts)
+			Expect(connectErr).NotTo(HaveOccurred())
+
+			dynamicForwardError = secureShell.DynamicPortForward()
+		})
+
+		AfterEach(func() {
+			Expect(secureShell.Close()).To(Succeed())
+			echoListener.Close()
+			realLocalListener.Close()
+		})
+
+		connectThroughProxy := func(request []byte) (net.Conn, []byte) {
+			conn, err := net.Dial("tcp", localAddress)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = conn.Write([]byte{0x05, 0x01, 0x00})
+			Expect(err).NotTo(HaveOccurred())
+
+			method := make([]byte, 2)
+			_, err = io.ReadFull(conn, method)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(method).To(Equal([]byte{0x05, 0x00}))
+
+			_, err = conn.Write(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			reply := make([]byte, 10)
+			_, err = io.ReadFull(conn, reply)
+			Expect(err).NotTo(HaveOccurred())
+
+			return conn, reply
+		}
+
+		It("listens on the dynamic forward address", func() {
+			Expect(dynamicForwardError).NotTo(HaveOccurred())
+			Expect(fakeListenerFactory.ListenCallCount()).To(Equal(1))
+
+			network, addr := fakeListenerFactory.ListenArgsForCall(0)
+			Expect(network).To(Equal("tcp"))
+			Expect(addr).To(Equal(localAddress))
+		})
+
+		It("connects SOCKS clients to the address they request", func() {
+			host, port, err := net.SplitHostPort(echoAddress)
+			Expect(err).NotTo(HaveOccurred())
+			portNumber, err := strconv.Atoi(port)
+			Expect(err).NotTo(HaveOccurred())
+
+			request := []byte{0x05, 0x01, 0x00, 0x03, byte(len(host))}
+			request = append(request, []byte(host)...)
+			request = append(request, byte(portNumber>>8), byte(portNumber))
+
+			conn, reply := connectThroughProxy(request)
+			defer conn.Close()
+			Expect(reply[1]).To(Equal(byte(0x00)))
+
+			network, addr := fakeSecureClient.DialArgsForCall(0)
+			Expect(network).To(Equal("tcp"))
+			Expect(addr).To(Equal(echoAddress))
+
+			msg := "Hello through SOCKS\n"
+			_, err = conn.Write([]byte(msg))
+			Expect(err).NotTo(HaveOccurred())
+
+			response := make([]byte, len(msg))
+			_, err = io.ReadFull(conn, response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(response)).To(Equal(msg))
+		})
+
+		Context("when dialing the requested address fails", func() {
+			BeforeEach(func() {
+				fakeSecureClient.DialStub = nil
+				fakeSecureClient.DialReturns(nil, errors.New("boom"))
+			})
+
+			It("replies that the host is unreachable", func() {
+				conn, reply := connectThroughProxy([]byte{0x05, 0x01, 0x00, 0x01, 10, 0, 0, 1, 0x1f, 0x90})
+				defer conn.Close()
+
+				Expect(reply[1]).To(Equal(byte(0x04)))
+				_, addr := fakeSecureClient.DialArgsForCall(0)
+				Expect(addr).To(Equal("10.0.0.1:8080"))
+			})
+		})
+
+		Context("when listen fails", func() {
+			BeforeEach(func() {
+				fakeListenerFactory.ListenReturns(nil, errors.New("failure is an option"))
+			})
+
+			It("returns the error", func() {
+				Expect(dynamicForwardError).To(MatchError("failure is an option"))
+			})
+		})
+	})
+
+	Describe("Upload and Download", func() {
+		var (
+			remoteIn  *io.PipeReader
+			remoteOut *io.PipeWriter
+			remote    func(in *bufio.Reader, out io.Writer)
+			done      chan struct{}
+			tempDir   string
+			progress  *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = ioutil.TempDir("", "scp")
+			Expect(err).NotTo(HaveOccurred())
+
+			progress = &bytes.Buffer{}
+			done = make(chan struct{})
+
+			stdinReader, stdinWriter := io.Pipe()
+			stdoutReader, stdoutWriter := io.Pipe()
+			remoteIn, remoteOut = stdinReader, stdoutWriter
+			fakeSecureSession.StdinPipeReturns(stdinWriter, nil)
+			fakeSecureSession.StdoutPipeReturns(stdoutReader, nil)
+
+			fakeSecureSession.StartStub = func(command string) error {
+				go func() {
+					defer close(done)
+					remote(bufio.NewReader(remoteIn), remoteOut)
+					remoteOut.Close()
+					io.Copy(ioutil.Discard, remoteIn)
+				}()
+				return nil
+			}
+			fakeSecureSession.WaitStub = func() error {
+				<-done
+				return nil
+			}
+
+			currentApp.State = "STARTED"
+			currentApp.Diego = true
+		})
+
+		JustBeforeEach(func() {
+			Expect(secureShell.Connect(&options.SSHOptions{AppName: "app-1"})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tempDir)
+		})
+
+		Describe("Upload", func() {
+			var received []string
+
+			BeforeEach(func() {
+				received = []string{}
+
+				// a sink that records the messages and file contents it receives
+				remote = func(in *bufio.Reader, out io.Writer) {
+					out.Write([]byte{0})
+					for {
+						line, err := in.ReadString('\n')
+						if err != nil {
+							return
+						}
+						received = append(received, strings.TrimSuffix(line, "\n"))
+						out.Write([]byte{0})
+
+						if line[0] == 'C' {
+							size, _ := strconv.Atoi(strings.Split(line, " ")[1])
+							content := make([]byte, size+1)
+							io.ReadFull(in, content)
+							received = append(received, string(content[:size]))
+							out.Write([]byte{0})
+						}
+					}
+				}
+
+				Expect(os.MkdirAll(filepath.Join(tempDir, "public", "css"), 0755)).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(tempDir, "public", "index.html"), []byte("<html>"), 0644)).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(tempDir, "public", "css", "app.css"), []byte("body {}"), 0600)).To(Succeed())
+			})
+
+			It("runs scp in sink mode with the quoted remote path", func() {
+				err := secureShell.Upload(filepath.Join(tempDir, "public", "index.html"), "/home/vcap/it's here", false, progress)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeSecureSession.StartArgsForCall(0)).To(Equal(`scp -t '/home/vcap/it'\''s here'`))
+			})
+
+			It("sends a file", func() {
+				err := secureShell.Upload(filepath.Join(tempDir, "public", "index.html"), "/tmp", false, progress)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(received).To(Equal([]string{"C0644 6 index.html", "<html>"}))
+				Expect(progress.String()).To(ContainSubstring("index.html 100% 6B\n"))
+			})
+
+			It("sends a directory and its contents with recursive", func() {
+				err := secureShell.Upload(filepath.Join(tempDir, "public"), "/tmp", true, progress)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeSecureSession.StartArgsForCall(0)).To(Equal(`scp -t -r '/tmp'`))
+				Expect(received).To(Equal([]string{
+					"D0755 0 public",
+					"D0755 0 css",
+					"C0600 7 app.css",
+					"body {}",
+					"E",
+					"C0644 6 index.html",
+					"<html>",
+					"E",
+				}))
+			})
+
+			It("refuses to send a directory without recursive", func() {
+				err := secureShell.Upload(filepath.Join(tempDir, "public"), "/tmp", false, progress)
+				Expect(err).To(MatchError(ContainSubstring("is a directory; use -r to copy it")))
+			})
+
+			Context("when the remote reports an error", func() {
+				BeforeEach(func() {
+					remote = func(in *bufio.Reader, out io.Writer) {
+						out.Write([]byte{0})
+						in.ReadString('\n')
+						out.Write([]byte("\x02scp: /tmp/index.html: Permission denied\n"))
+					}
+				})
+
+				It("returns the error", func() {
+					err := secureShell.Upload(filepath.Join(tempDir, "public", "index.html"), "/tmp", false, progress)
+					Expect(err).To(MatchError("scp: /tmp/index.html: Permission denied"))
+				})
+			})
+		})
+
+		Describe("Download", func() {
+			BeforeEach(func() {
+				// a source that sends a directory with a file in it
+				remote = func(in *bufio.Reader, out io.Writer) {
+					in.ReadByte()
+					out.Write([]byte("D0755 0 logs\n"))
+					in.ReadByte()
+					out.Write([]byte("C0640 5 app.log\n"))
+					in.ReadByte()
+					out.Write([]byte("hello\x00"))
+					in.ReadByte()
+					out.Write([]byte("E\n"))
+					in.ReadByte()
+				}
+			})
+
+			It("runs scp in source mode", func() {
+				err := secureShell.Download("/home/vcap/logs", tempDir, true, progress)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeSecureSession.StartArgsForCall(0)).To(Equal(`scp -f -r '/home/vcap/logs'`))
+			})
+
+			It("copies into an existing local directory", func() {
+				err := secureShell.Download("/home/vcap/logs", tempDir, true, progress)
+				Expect(err).NotTo(HaveOccurred())
+
+				content, err := ioutil.ReadFile(filepath.Join(tempDir, "logs", "app.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal("hello"))
+
+				info, err := os.Stat(filepath.Join(tempDir, "logs", "app.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(info.Mode().Perm()).To(Equal(os.FileMode(0640)))
+
+				Expect(progress.String()).To(ContainSubstring("app.log 100% 5B\n"))
+			})
+
+			It("copies to a new local path", func() {
+				err := secureShell.Download("/home/vcap/logs", filepath.Join(tempDir, "copy"), true, progress)
+				Expect(err).NotTo(HaveOccurred())
+
+				content, err := ioutil.ReadFile(filepath.Join(tempDir, "copy", "app.log"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(Equal("hello"))
+			})
+
+			Context("when the remote sends a file name with a path in it", func() {
+				BeforeEach(func() {
+					remote = func(in *bufio.Reader, out io.Writer) {
+						in.ReadByte()
+						out.Write([]byte("C0644 5 ../evil\n"))
+						in.ReadByte()
+					}
+				})
+
+				It("refuses to copy it", func() {
+					err := secureShell.Download("/tmp/file", tempDir, false, progress)
+					Expect(err).To(MatchError(`refusing to copy file with invalid name "../evil"`))
+				})
+			})
+
+			Context("when the remote reports an error", func() {
+				BeforeEach(func() {
+					remote = func(in *bufio.Reader, out io.Writer) {
+						in.ReadByte()
+						out.Write([]byte("\x01scp: /tmp/missing: No such file or directory\n"))
+					}
+				})
+
+				It("returns the error", func() {
+					err := secureShell.Download("/tmp/missing", tempDir, false, progress)
+					Expect(err).To(MatchError("scp: /tmp/missing: No such file or directory"))
+				})
+			})
+		})
+	})
+
 	Describe("Wait", func() {
 		var opts *options.SSHOptions
 		var waitErr error
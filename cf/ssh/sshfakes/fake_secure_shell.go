@@ -2,9 +2,10 @@
 package sshfakes
 
 import (
+	"io"
 	"sync"
 
-	"code.cloudfoundry.org/cli/cf/ssh"
+	sshCmd "code.cloudfoundry.org/cli/cf/ssh"
 	"code.cloudfoundry.org/cli/cf/ssh/options"
 )
 
@@ -29,6 +30,34 @@ type FakeSecureShell struct {
 	localPortForwardReturns     struct {
 		result1 error
 	}
+	DynamicPortForwardStub        func() error
+	dynamicPortForwardMutex       sync.RWMutex
+	dynamicPortForwardArgsForCall []struct{}
+	dynamicPortForwardReturns     struct {
+		result1 error
+	}
+	UploadStub        func(localPath string, remotePath string, recursive bool, progress io.Writer) error
+	uploadMutex       sync.RWMutex
+	uploadArgsForCall []struct {
+		localPath  string
+		remotePath string
+		recursive  bool
+		progress   io.Writer
+	}
+	uploadReturns struct {
+		result1 error
+	}
+	DownloadStub        func(remotePath string, localPath string, recursive bool, progress io.Writer) error
+	downloadMutex       sync.RWMutex
+	downloadArgsForCall []struct {
+		remotePath string
+		localPath  string
+		recursive  bool
+		progress   io.Writer
+	}
+	downloadReturns struct {
+		result1 error
+	}
 	WaitStub        func() error
 	waitMutex       sync.RWMutex
 	waitArgsForCall []struct{}
@@ -128,6 +157,103 @@ func (fake *FakeSecureShell) LocalPortForwardReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSecureShell) DynamicPortForward() error {
+	fake.dynamicPortForwardMutex.Lock()
+	fake.dynamicPortForwardArgsForCall = append(fake.dynamicPortForwardArgsForCall, struct{}{})
+	fake.recordInvocation("DynamicPortForward", []interface{}{})
+	fake.dynamicPortForwardMutex.Unlock()
+	if fake.DynamicPortForwardStub != nil {
+		return fake.DynamicPortForwardStub()
+	} else {
+		return fake.dynamicPortForwardReturns.result1
+	}
+}
+
+func (fake *FakeSecureShell) DynamicPortForwardCallCount() int {
+	fake.dynamicPortForwardMutex.RLock()
+	defer fake.dynamicPortForwardMutex.RUnlock()
+	return len(fake.dynamicPortForwardArgsForCall)
+}
+
+func (fake *FakeSecureShell) DynamicPortForwardReturns(result1 error) {
+	fake.DynamicPortForwardStub = nil
+	fake.dynamicPortForwardReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecureShell) Upload(localPath string, remotePath string, recursive bool, progress io.Writer) error {
+	fake.uploadMutex.Lock()
+	fake.uploadArgsForCall = append(fake.uploadArgsForCall, struct {
+		localPath  string
+		remotePath string
+		recursive  bool
+		progress   io.Writer
+	}{localPath, remotePath, recursive, progress})
+	fake.recordInvocation("Upload", []interface{}{localPath, remotePath, recursive, progress})
+	fake.uploadMutex.Unlock()
+	if fake.UploadStub != nil {
+		return fake.UploadStub(localPath, remotePath, recursive, progress)
+	} else {
+		return fake.uploadReturns.result1
+	}
+}
+
+func (fake *FakeSecureShell) UploadCallCount() int {
+	fake.uploadMutex.RLock()
+	defer fake.uploadMutex.RUnlock()
+	return len(fake.uploadArgsForCall)
+}
+
+func (fake *FakeSecureShell) UploadArgsForCall(i int) (string, string, bool, io.Writer) {
+	fake.uploadMutex.RLock()
+	defer fake.uploadMutex.RUnlock()
+	return fake.uploadArgsForCall[i].localPath, fake.uploadArgsForCall[i].remotePath, fake.uploadArgsForCall[i].recursive, fake.uploadArgsForCall[i].progress
+}
+
+func (fake *FakeSecureShell) UploadReturns(result1 error) {
+	fake.UploadStub = nil
+	fake.uploadReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecureShell) Download(remotePath string, localPath string, recursive bool, progress io.Writer) error {
+	fake.downloadMutex.Lock()
+	fake.downloadArgsForCall = append(fake.downloadArgsForCall, struct {
+		remotePath string
+		localPath  string
+		recursive  bool
+		progress   io.Writer
+	}{remotePath, localPath, recursive, progress})
+	fake.recordInvocation("Download", []interface{}{remotePath, localPath, recursive, progress})
+	fake.downloadMutex.Unlock()
+	if fake.DownloadStub != nil {
+		return fake.DownloadStub(remotePath, localPath, recursive, progress)
+	} else {
+		return fake.downloadReturns.result1
+	}
+}
+
+func (fake *FakeSecureShell) DownloadCallCount() int {
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	return len(fake.downloadArgsForCall)
+}
+
+func (fake *FakeSecureShell) DownloadArgsForCall(i int) (string, string, bool, io.Writer) {
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
+	return fake.downloadArgsForCall[i].remotePath, fake.downloadArgsForCall[i].localPath, fake.downloadArgsForCall[i].recursive, fake.downloadArgsForCall[i].progress
+}
+
+func (fake *FakeSecureShell) DownloadReturns(result1 error) {
+	fake.DownloadStub = nil
+	fake.downloadReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeSecureShell) Wait() error {
 	fake.waitMutex.Lock()
 	fake.waitArgsForCall = append(fake.waitArgsForCall, struct{}{})
@@ -187,6 +313,12 @@ func (fake *FakeSecureShell) Invocations() map[string][][]interface{} {
 	defer fake.interactiveSessionMutex.RUnlock()
 	fake.localPortForwardMutex.RLock()
 	defer fake.localPortForwardMutex.RUnlock()
+	fake.dynamicPortForwardMutex.RLock()
+	defer fake.dynamicPortForwardMutex.RUnlock()
+	fake.uploadMutex.RLock()
+	defer fake.uploadMutex.RUnlock()
+	fake.downloadMutex.RLock()
+	defer fake.downloadMutex.RUnlock()
 	fake.waitMutex.RLock()
 	defer fake.waitMutex.RUnlock()
 	fake.closeMutex.RLock()
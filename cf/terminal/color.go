@@ -20,7 +20,7 @@ const (
 
 var (
 	colorize               func(message string, textColor color.Attribute, bold int) string
-	TerminalSupportsColors = isTerminal()
+	TerminalSupportsColors = IsTerminal()
 	UserAskedForColors     = ""
 )
 
@@ -139,6 +139,7 @@ func LogSysHeaderColor(message string) string {
 	return ColorizeBold(message, cyan)
 }
 
-func isTerminal() bool {
+// IsTerminal returns true if standard output is a terminal.
+func IsTerminal() bool {
 	return terminal.IsTerminal(int(os.Stdout.Fd()))
 }
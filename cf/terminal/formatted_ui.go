@@ -0,0 +1,119 @@
+package terminal
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	OutputFormatTable = "table"
+	OutputFormatJSON  = "json"
+	OutputFormatYAML  = "yaml"
+)
+
+var OutputFormats = []string{OutputFormatTable, OutputFormatJSON, OutputFormatYAML}
+
+// DataPrinter is implemented by UIs that print the data a command shows in a
+// machine readable format instead of as text.
+type DataPrinter interface {
+	// SetData sets what is printed by Flush, for commands whose output is
+	// not a table.
+	SetData(data interface{})
+
+	// Flush prints the data of the command; it is called once the command
+	// has finished.
+	Flush() error
+}
+
+type formattedUI struct {
+	UI
+	out     UI
+	format  string
+	records []map[string]string
+	data    interface{}
+}
+
+// NewFormattedUI returns a UI that collects the rows of every table printed
+// to it and prints them, when flushed, to ui as a single JSON or YAML list.
+// All other output except failures and prompts is dropped.
+func NewFormattedUI(ui UI, format string) UI {
+	return &formattedUI{
+		UI:      NewSilentUI(ui),
+		out:     ui,
+		format:  format,
+		records: []map[string]string{},
+	}
+}
+
+// IsValidOutputFormat returns true if format is one of OutputFormats.
+func IsValidOutputFormat(format string) bool {
+	for _, valid := range OutputFormats {
+		if format == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func (ui *formattedUI) Table(headers []string) *UITable {
+	return &UITable{
+		UI:    ui,
+		Table: NewTable(headers),
+	}
+}
+
+func (ui *formattedUI) SetData(data interface{}) {
+	ui.data = data
+}
+
+func (ui *formattedUI) Flush() error {
+	var data interface{} = ui.records
+	if ui.data != nil {
+		data = ui.data
+	}
+
+	var (
+		out []byte
+		err error
+	)
+	switch ui.format {
+	case OutputFormatYAML:
+		out, err = yaml.Marshal(data)
+		out = []byte(strings.TrimSuffix(string(out), "\n"))
+	default:
+		out, err = json.MarshalIndent(data, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+
+	ui.out.Say("%s", string(out))
+	return nil
+}
+
+// addRecords keeps the rows of table, keyed by its field keys, and clears it
+// like printing it would.
+func (ui *formattedUI) addRecords(table *Table) {
+	keys := table.FieldKeys()
+	for i := range keys {
+		if keys[i] == "" {
+			keys[i] = fmt.Sprintf("column_%d", i+1)
+		}
+	}
+
+	for _, row := range table.rows {
+		record := map[string]string{}
+		for i, cell := range row {
+			if i >= len(keys) {
+				break
+			}
+			record[keys[i]] = Decolorize(cell)
+		}
+		ui.records = append(ui.records, record)
+	}
+
+	table.rows = [][]string{}
+}
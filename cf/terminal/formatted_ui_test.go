@@ -0,0 +1,111 @@
+package terminal_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FormattedUI", func() {
+	var (
+		fakeUI *terminalfakes.FakeUI
+		ui     terminal.UI
+	)
+
+	printed := func() string {
+		Expect(fakeUI.SayCallCount()).To(Equal(1))
+		message, args := fakeUI.SayArgsForCall(0)
+		Expect(message).To(Equal("%s"))
+		return args[0].(string)
+	}
+
+	printTables := func() {
+		ui.Say("Getting apps...")
+		ui.Ok()
+
+		table := ui.Table([]string{"name", "requested state", ""})
+		table.Add(terminal.EntityNameColor("app-1"), "started", "*")
+		Expect(table.Print()).To(Succeed())
+
+		table.Add("app-2", "stopped", "")
+		Expect(table.Print()).To(Succeed())
+	}
+
+	Context("when the format is json", func() {
+		BeforeEach(func() {
+			fakeUI = new(terminalfakes.FakeUI)
+			ui = terminal.NewFormattedUI(fakeUI, terminal.OutputFormatJSON)
+		})
+
+		It("prints the rows of every table as one list when flushed", func() {
+			printTables()
+			Expect(fakeUI.SayCallCount()).To(BeZero())
+
+			Expect(ui.(terminal.DataPrinter).Flush()).To(Succeed())
+			Expect(printed()).To(MatchJSON(`[
+				{"name": "app-1", "requested_state": "started", "column_3": "*"},
+				{"name": "app-2", "requested_state": "stopped", "column_3": ""}
+			]`))
+		})
+
+		It("keys the rows by the field keys of the table instead of its headers", func() {
+			table := ui.Table([]string{"Name", "Angeforderter Status"})
+			table.Table.SetFieldKeys("name", "requested_state")
+			table.Add("app-1", "started")
+			Expect(table.Print()).To(Succeed())
+
+			Expect(ui.(terminal.DataPrinter).Flush()).To(Succeed())
+			Expect(printed()).To(MatchJSON(`[{"name": "app-1", "requested_state": "started"}]`))
+		})
+
+		It("prints an empty list when no table was printed", func() {
+			Expect(ui.(terminal.DataPrinter).Flush()).To(Succeed())
+			Expect(printed()).To(Equal("[]"))
+		})
+
+		It("prints the data it was given instead of the tables", func() {
+			printTables()
+			ui.(terminal.DataPrinter).SetData(map[string]string{"FOO": "bar"})
+
+			Expect(ui.(terminal.DataPrinter).Flush()).To(Succeed())
+			Expect(printed()).To(MatchJSON(`{"FOO": "bar"}`))
+		})
+
+		It("passes failures through", func() {
+			ui.Failed("it broke")
+			Expect(fakeUI.FailedCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the format is yaml", func() {
+		BeforeEach(func() {
+			fakeUI = new(terminalfakes.FakeUI)
+			ui = terminal.NewFormattedUI(fakeUI, terminal.OutputFormatYAML)
+		})
+
+		It("prints the rows of every table as one list when flushed", func() {
+			printTables()
+
+			Expect(ui.(terminal.DataPrinter).Flush()).To(Succeed())
+			Expect(printed()).To(MatchYAML(`
+- name: app-1
+  requested_state: started
+  column_3: "*"
+- name: app-2
+  requested_state: stopped
+  column_3: ""
+`))
+		})
+	})
+
+	Describe("IsValidOutputFormat", func() {
+		It("accepts table, json and yaml", func() {
+			Expect(terminal.IsValidOutputFormat("table")).To(BeTrue())
+			Expect(terminal.IsValidOutputFormat("json")).To(BeTrue())
+			Expect(terminal.IsValidOutputFormat("yaml")).To(BeTrue())
+			Expect(terminal.IsValidOutputFormat("xml")).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,82 @@
+package terminal
+
+import (
+	. "code.cloudfoundry.org/cli/cf/i18n"
+)
+
+type nonInteractiveUI struct {
+	UI
+	exit func(code int)
+}
+
+// NewNonInteractiveUI returns a UI that fails with exit instead of waiting
+// for an answer whenever a command asks the user something, so that scripts
+// run with --non-interactive or CF_NON_INTERACTIVE=true never hang on a
+// prompt. Commands that take -f do not ask for confirmation when it is given.
+func NewNonInteractiveUI(ui UI, exit func(code int)) UI {
+	return &nonInteractiveUI{
+		UI:   ui,
+		exit: exit,
+	}
+}
+
+func (ui *nonInteractiveUI) Ask(prompt string) string {
+	ui.failPrompt(prompt)
+	return ""
+}
+
+func (ui *nonInteractiveUI) AskForPassword(prompt string) string {
+	ui.failPrompt(prompt)
+	return ""
+}
+
+func (ui *nonInteractiveUI) Confirm(message string) bool {
+	ui.failConfirm(message)
+	return false
+}
+
+func (ui *nonInteractiveUI) ConfirmDelete(modelType, modelName string) bool {
+	ui.failConfirm(T("Really delete the {{.ModelType}} {{.ModelName}}?",
+		map[string]interface{}{
+			"ModelType": modelType,
+			"ModelName": EntityNameColor(modelName),
+		}))
+	return false
+}
+
+func (ui *nonInteractiveUI) ConfirmDeleteWithAssociations(modelType, modelName string) bool {
+	ui.failConfirm(T("Really delete the {{.ModelType}} {{.ModelName}} and everything associated with it?",
+		map[string]interface{}{
+			"ModelType": modelType,
+			"ModelName": EntityNameColor(modelName),
+		}))
+	return false
+}
+
+func (ui *nonInteractiveUI) failPrompt(prompt string) {
+	ui.UI.Failed("%s", T("Cannot prompt for {{.Prompt}} in non-interactive mode; provide it with a flag or argument instead",
+		map[string]interface{}{"Prompt": prompt}))
+	ui.exit(1)
+}
+
+func (ui *nonInteractiveUI) failConfirm(message string) {
+	ui.UI.Failed("%s", T("Cannot confirm in non-interactive mode: {{.Question}}\nUse -f to run the command without confirmation",
+		map[string]interface{}{"Question": message}))
+	ui.exit(1)
+}
+
+type nonInteractivePicker struct{}
+
+// NewNonInteractivePicker returns a Picker that never lets the user pick, as
+// in non-interactive mode.
+func NewNonInteractivePicker() Picker {
+	return nonInteractivePicker{}
+}
+
+func (nonInteractivePicker) Interactive() bool {
+	return false
+}
+
+func (nonInteractivePicker) Pick(prompt string, options []string, selected int) (int, error) {
+	return -1, ErrPickerUnavailable
+}
@@ -0,0 +1,89 @@
+package terminal_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NonInteractiveUI", func() {
+	var (
+		fakeUI    *terminalfakes.FakeUI
+		ui        terminal.UI
+		exitCodes []int
+	)
+
+	BeforeEach(func() {
+		fakeUI = new(terminalfakes.FakeUI)
+		exitCodes = nil
+		ui = terminal.NewNonInteractiveUI(fakeUI, func(code int) {
+			exitCodes = append(exitCodes, code)
+		})
+	})
+
+	failure := func() string {
+		Expect(fakeUI.FailedCallCount()).To(Equal(1))
+		message, args := fakeUI.FailedArgsForCall(0)
+		Expect(message).To(Equal("%s"))
+		return args[0].(string)
+	}
+
+	It("fails instead of asking", func() {
+		Expect(ui.Ask("API endpoint")).To(BeEmpty())
+
+		Expect(fakeUI.AskCallCount()).To(Equal(0))
+		Expect(failure()).To(Equal("Cannot prompt for API endpoint in non-interactive mode; provide it with a flag or argument instead"))
+		Expect(exitCodes).To(Equal([]int{1}))
+	})
+
+	It("fails instead of asking for a password", func() {
+		Expect(ui.AskForPassword("Password")).To(BeEmpty())
+
+		Expect(fakeUI.AskForPasswordCallCount()).To(Equal(0))
+		Expect(failure()).To(ContainSubstring("Cannot prompt for Password"))
+		Expect(exitCodes).To(Equal([]int{1}))
+	})
+
+	It("fails instead of asking for confirmation, suggesting -f", func() {
+		Expect(ui.Confirm("Really scale?")).To(BeFalse())
+
+		Expect(fakeUI.ConfirmCallCount()).To(Equal(0))
+		Expect(failure()).To(Equal("Cannot confirm in non-interactive mode: Really scale?\nUse -f to run the command without confirmation"))
+		Expect(exitCodes).To(Equal([]int{1}))
+	})
+
+	It("fails instead of confirming deletes", func() {
+		Expect(ui.ConfirmDelete("app", "my-app")).To(BeFalse())
+		Expect(failure()).To(ContainSubstring("Really delete the app"))
+		Expect(failure()).To(ContainSubstring("my-app"))
+		Expect(exitCodes).To(Equal([]int{1}))
+	})
+
+	It("fails instead of confirming deletes with associations", func() {
+		Expect(ui.ConfirmDeleteWithAssociations("org", "my-org")).To(BeFalse())
+		Expect(failure()).To(ContainSubstring("and everything associated with it"))
+		Expect(exitCodes).To(Equal([]int{1}))
+	})
+
+	It("passes output through to the underlying UI", func() {
+		ui.Say("hello %s", "world")
+
+		Expect(fakeUI.SayCallCount()).To(Equal(1))
+		message, args := fakeUI.SayArgsForCall(0)
+		Expect(message).To(Equal("hello %s"))
+		Expect(args).To(Equal([]interface{}{"world"}))
+		Expect(exitCodes).To(BeEmpty())
+	})
+})
+
+var _ = Describe("NonInteractivePicker", func() {
+	It("never lets the user pick", func() {
+		picker := terminal.NewNonInteractivePicker()
+		Expect(picker.Interactive()).To(BeFalse())
+
+		_, err := picker.Pick("Select an org:", []string{"my-org"}, 0)
+		Expect(err).To(Equal(terminal.ErrPickerUnavailable))
+	})
+})
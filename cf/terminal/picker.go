@@ -0,0 +1,167 @@
+package terminal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"github.com/docker/docker/pkg/term"
+)
+
+// ErrPickerUnavailable is returned by Pick when the options cannot be picked
+// with the arrow keys, such as when the terminal cannot be put in raw mode,
+// so that the caller can prompt for a number instead.
+var ErrPickerUnavailable = errors.New("picking with the arrow keys is not available")
+
+// ErrPickInterrupted is returned by Pick when the user presses Ctrl-C.
+var ErrPickInterrupted = errors.New("interrupted")
+
+// pickerHeight is the most options shown at a time; the others are scrolled
+// to.
+const pickerHeight = 10
+
+//go:generate counterfeiter . Picker
+
+// Picker lets the user pick one of a list of options with the arrow keys.
+type Picker interface {
+	// Interactive is whether the user can be asked to pick at all, which
+	// they cannot when the input is not a terminal.
+	Interactive() bool
+
+	// Pick shows the options, starting at the one at index selected, and
+	// returns the index of the one picked, or -1 when the user skipped
+	// picking.
+	Pick(prompt string, options []string, selected int) (int, error)
+}
+
+type picker struct {
+	in  io.Reader
+	out io.Writer
+}
+
+func NewPicker(in io.Reader, out io.Writer) Picker {
+	return &picker{
+		in:  in,
+		out: out,
+	}
+}
+
+func (p *picker) Interactive() bool {
+	_, isTerminal := term.GetFdInfo(p.in)
+	return isTerminal
+}
+
+func (p *picker) Pick(prompt string, options []string, selected int) (int, error) {
+	inFd, inIsTerminal := term.GetFdInfo(p.in)
+	_, outIsTerminal := term.GetFdInfo(p.out)
+
+	// the Windows console does not move the cursor for the escape codes the
+	// options are redrawn with
+	if !inIsTerminal || !outIsTerminal || runtime.GOOS == "windows" {
+		return -1, ErrPickerUnavailable
+	}
+
+	state, err := term.MakeRaw(inFd)
+	if err != nil {
+		return -1, ErrPickerUnavailable
+	}
+	defer term.RestoreTerminal(inFd, state)
+
+	return PickWithKeys(p.in, p.out, prompt, options, selected)
+}
+
+// PickWithKeys shows the options on out and moves through them with the keys
+// read from in, which is a terminal in raw mode: the up and down arrows, or k
+// and j, move, enter picks and q or escape skips picking. The options are
+// erased once one is picked.
+func PickWithKeys(in io.Reader, out io.Writer, prompt string, options []string, selected int) (int, error) {
+	if selected < 0 || selected >= len(options) {
+		selected = 0
+	}
+
+	list := pickerList{out: out, options: options, selected: selected}
+	fmt.Fprintf(out, "%s\r\n", prompt)
+	fmt.Fprintf(out, "%s\r\n", T("(use the arrow keys to move, enter to select or q to skip)"))
+	list.draw()
+
+	defer list.erase()
+
+	key := make([]byte, 3)
+	for {
+		n, err := in.Read(key)
+		if err != nil {
+			if err == io.EOF {
+				return -1, nil
+			}
+			return -1, err
+		}
+
+		switch string(key[:n]) {
+		case "\x1b[A", "k":
+			list.move(-1)
+		case "\x1b[B", "j":
+			list.move(1)
+		case "\r", "\n":
+			return list.selected, nil
+		case "q", "\x1b":
+			return -1, nil
+		case "\x03":
+			return -1, ErrPickInterrupted
+		}
+	}
+}
+
+type pickerList struct {
+	out      io.Writer
+	options  []string
+	selected int
+	top      int
+	drawn    bool
+}
+
+func (l *pickerList) visible() int {
+	if len(l.options) < pickerHeight {
+		return len(l.options)
+	}
+	return pickerHeight
+}
+
+func (l *pickerList) move(by int) {
+	l.selected += by
+	if l.selected < 0 {
+		l.selected = 0
+	}
+	if l.selected >= len(l.options) {
+		l.selected = len(l.options) - 1
+	}
+	l.draw()
+}
+
+func (l *pickerList) draw() {
+	if l.selected < l.top {
+		l.top = l.selected
+	}
+	if l.selected >= l.top+l.visible() {
+		l.top = l.selected - l.visible() + 1
+	}
+
+	if l.drawn {
+		fmt.Fprintf(l.out, "\x1b[%dA", l.visible())
+	}
+	for i := l.top; i < l.top+l.visible(); i++ {
+		line := "  " + l.options[i]
+		if i == l.selected {
+			line = PromptColor(">") + " " + EntityNameColor(l.options[i])
+		}
+		fmt.Fprintf(l.out, "\r\x1b[K%s\r\n", line)
+	}
+	l.drawn = true
+}
+
+// erase removes the prompt, the hint and the options, as the caller shows
+// what was picked.
+func (l *pickerList) erase() {
+	fmt.Fprintf(l.out, "\x1b[%dA\r\x1b[J", l.visible()+2)
+}
@@ -0,0 +1,101 @@
+package terminal_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	. "code.cloudfoundry.org/cli/cf/terminal"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PickWithKeys", func() {
+	var (
+		out     *bytes.Buffer
+		options []string
+	)
+
+	BeforeEach(func() {
+		out = new(bytes.Buffer)
+		options = []string{"dev", "staging", "prod"}
+	})
+
+	// each key is read on its own, as it is from a terminal in raw mode
+	keys := func(keys ...string) *keyReader {
+		return &keyReader{keys: keys}
+	}
+
+	It("shows the prompt and the options", func() {
+		_, err := PickWithKeys(keys("\r"), out, "Select a space:", options, 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(out.String()).To(ContainSubstring("Select a space:"))
+		Expect(out.String()).To(ContainSubstring("use the arrow keys"))
+		for _, option := range options {
+			Expect(out.String()).To(ContainSubstring(option))
+		}
+	})
+
+	It("picks the selected option with enter", func() {
+		Expect(PickWithKeys(keys("\r"), out, "Select a space:", options, 1)).To(Equal(1))
+	})
+
+	It("moves with the arrow keys", func() {
+		Expect(PickWithKeys(keys("\x1b[B", "\x1b[B", "\r"), out, "Select a space:", options, 0)).To(Equal(2))
+		Expect(PickWithKeys(keys("\x1b[A", "\r"), out, "Select a space:", options, 2)).To(Equal(1))
+	})
+
+	It("moves with j and k", func() {
+		Expect(PickWithKeys(keys("j", "j", "k", "\r"), out, "Select a space:", options, 0)).To(Equal(1))
+	})
+
+	It("does not move past the first or last option", func() {
+		Expect(PickWithKeys(keys("k", "\r"), out, "Select a space:", options, 0)).To(Equal(0))
+		Expect(PickWithKeys(keys("j", "j", "j", "\r"), out, "Select a space:", options, 1)).To(Equal(2))
+	})
+
+	It("starts at the first option when the selected one is out of range", func() {
+		Expect(PickWithKeys(keys("\r"), out, "Select a space:", options, -1)).To(Equal(0))
+	})
+
+	It("skips picking with q or escape", func() {
+		Expect(PickWithKeys(keys("q"), out, "Select a space:", options, 0)).To(Equal(-1))
+		Expect(PickWithKeys(keys("\x1b"), out, "Select a space:", options, 0)).To(Equal(-1))
+	})
+
+	It("skips picking when the input ends", func() {
+		Expect(PickWithKeys(keys("j"), out, "Select a space:", options, 0)).To(Equal(-1))
+	})
+
+	It("returns an error when interrupted", func() {
+		_, err := PickWithKeys(keys("\x03"), out, "Select a space:", options, 0)
+		Expect(err).To(Equal(ErrPickInterrupted))
+	})
+
+	It("scrolls through options that do not fit", func() {
+		options = nil
+		for i := 0; i < 15; i++ {
+			options = append(options, fmt.Sprintf("space-%02d", i))
+		}
+
+		Expect(PickWithKeys(keys("\r"), out, "Select a space:", options, 14)).To(Equal(14))
+		Expect(out.String()).To(ContainSubstring(options[14]))
+		Expect(out.String()).NotTo(ContainSubstring(options[3]))
+	})
+})
+
+type keyReader struct {
+	keys []string
+}
+
+func (r *keyReader) Read(p []byte) (int, error) {
+	if len(r.keys) == 0 {
+		return 0, io.EOF
+	}
+
+	n := copy(p, r.keys[0])
+	r.keys = r.keys[1:]
+	return n, nil
+}
@@ -0,0 +1,81 @@
+package terminal
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	. "code.cloudfoundry.org/cli/cf/i18n"
+)
+
+// outputMutex keeps the lines written through prefixed UIs that share the
+// same underlying UI from being interleaved mid-message.
+var outputMutex sync.Mutex
+
+type prefixedUI struct {
+	UI
+	prefix string
+}
+
+// NewPrefixedUI returns a UI that writes every line of output to ui with
+// "[prefix] " in front of it, so the output of work done concurrently, such
+// as pushing several apps at once, can be told apart.
+func NewPrefixedUI(ui UI, prefix string) UI {
+	return &prefixedUI{
+		UI:     ui,
+		prefix: fmt.Sprintf("[%s] ", prefix),
+	}
+}
+
+func (ui *prefixedUI) PrintPaginator(rows []string, err error) {
+	if err != nil {
+		ui.Failed(err.Error())
+		return
+	}
+
+	for _, row := range rows {
+		ui.Say(row)
+	}
+}
+
+func (ui *prefixedUI) PrintCapturingNoOutput(message string, args ...interface{}) {
+	ui.Say(message, args...)
+}
+
+func (ui *prefixedUI) Say(message string, args ...interface{}) {
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+
+	ui.say(message)
+}
+
+func (ui *prefixedUI) Warn(message string, args ...interface{}) {
+	ui.say(WarningColor(fmt.Sprintf(message, args...)))
+}
+
+func (ui *prefixedUI) Ok() {
+	ui.say(SuccessColor(T("OK")))
+}
+
+func (ui *prefixedUI) Failed(message string, args ...interface{}) {
+	outputMutex.Lock()
+	defer outputMutex.Unlock()
+
+	ui.UI.Failed("%s", ui.prefixLines(fmt.Sprintf(message, args...)))
+}
+
+func (ui *prefixedUI) say(message string) {
+	outputMutex.Lock()
+	defer outputMutex.Unlock()
+
+	ui.UI.Say("%s", ui.prefixLines(message))
+}
+
+func (ui *prefixedUI) prefixLines(message string) string {
+	lines := strings.Split(message, "\n")
+	for i, line := range lines {
+		lines[i] = ui.prefix + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,67 @@
+package terminal_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PrefixedUI", func() {
+	var (
+		fakeUI *terminalfakes.FakeUI
+		ui     terminal.UI
+	)
+
+	BeforeEach(func() {
+		fakeUI = new(terminalfakes.FakeUI)
+		ui = terminal.NewPrefixedUI(fakeUI, "my-app")
+	})
+
+	sayOutput := func(i int) string {
+		message, args := fakeUI.SayArgsForCall(i)
+		Expect(message).To(Equal("%s"))
+		Expect(args).To(HaveLen(1))
+		return args[0].(string)
+	}
+
+	It("prefixes every line it says", func() {
+		ui.Say("Uploading %s...\nDone", "my-app")
+
+		Expect(fakeUI.SayCallCount()).To(Equal(1))
+		Expect(sayOutput(0)).To(Equal("[my-app] Uploading my-app...\n[my-app] Done"))
+	})
+
+	It("does not format messages without args", func() {
+		ui.Say("100%s")
+		Expect(sayOutput(0)).To(Equal("[my-app] 100%s"))
+	})
+
+	It("prefixes OK", func() {
+		ui.Ok()
+		Expect(sayOutput(0)).To(ContainSubstring("[my-app] "))
+		Expect(sayOutput(0)).To(ContainSubstring("OK"))
+	})
+
+	It("prefixes warnings", func() {
+		ui.Warn("watch out for %s", "that")
+		Expect(sayOutput(0)).To(ContainSubstring("[my-app] "))
+		Expect(sayOutput(0)).To(ContainSubstring("watch out for that"))
+	})
+
+	It("prefixes failures", func() {
+		ui.Failed("it broke")
+
+		Expect(fakeUI.FailedCallCount()).To(Equal(1))
+		message, args := fakeUI.FailedArgsForCall(0)
+		Expect(message).To(Equal("%s"))
+		Expect(args).To(Equal([]interface{}{"[my-app] it broke"}))
+	})
+
+	It("passes questions through to the underlying UI", func() {
+		fakeUI.ConfirmReturns(true)
+		Expect(ui.Confirm("sure?")).To(BeTrue())
+		Expect(fakeUI.ConfirmArgsForCall(0)).To(Equal("sure?"))
+	})
+})
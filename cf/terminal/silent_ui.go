@@ -0,0 +1,44 @@
+package terminal
+
+import (
+	"io"
+	"io/ioutil"
+)
+
+type silentUI struct {
+	UI
+}
+
+// NewSilentUI returns a UI that drops everything written to it other than
+// failures, for commands that report their progress in a machine readable
+// form instead. Prompts are still passed through to ui.
+func NewSilentUI(ui UI) UI {
+	return &silentUI{UI: ui}
+}
+
+func (ui *silentUI) PrintPaginator(rows []string, err error) {
+	if err != nil {
+		ui.Failed(err.Error())
+	}
+}
+
+func (ui *silentUI) PrintCapturingNoOutput(message string, args ...interface{}) {}
+
+func (ui *silentUI) Say(message string, args ...interface{}) {}
+
+func (ui *silentUI) Warn(message string, args ...interface{}) {}
+
+func (ui *silentUI) Ok() {}
+
+func (ui *silentUI) LoadingIndication() {}
+
+func (ui *silentUI) Table(headers []string) *UITable {
+	return &UITable{
+		UI:    ui,
+		Table: NewTable(headers),
+	}
+}
+
+func (ui *silentUI) Writer() io.Writer {
+	return ioutil.Discard
+}
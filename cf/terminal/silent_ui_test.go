@@ -0,0 +1,53 @@
+package terminal_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SilentUI", func() {
+	var (
+		fakeUI *terminalfakes.FakeUI
+		ui     terminal.UI
+	)
+
+	BeforeEach(func() {
+		fakeUI = new(terminalfakes.FakeUI)
+		ui = terminal.NewSilentUI(fakeUI)
+	})
+
+	It("drops what it is told to say", func() {
+		ui.Say("Uploading %s...", "my-app")
+		ui.Warn("watch out")
+		ui.Ok()
+		ui.PrintPaginator([]string{"row"}, nil)
+
+		table := ui.Table([]string{"name"})
+		table.Add("my-app")
+		Expect(table.Print()).To(Succeed())
+
+		Expect(fakeUI.SayCallCount()).To(BeZero())
+		Expect(fakeUI.WarnCallCount()).To(BeZero())
+		Expect(fakeUI.OkCallCount()).To(BeZero())
+	})
+
+	It("passes failures through", func() {
+		ui.Failed("it broke")
+		ui.PrintPaginator(nil, errors.New("paging broke"))
+
+		Expect(fakeUI.FailedCallCount()).To(Equal(2))
+		message, _ := fakeUI.FailedArgsForCall(1)
+		Expect(message).To(Equal("paging broke"))
+	})
+
+	It("passes prompts through", func() {
+		fakeUI.ConfirmReturns(true)
+		Expect(ui.Confirm("really?")).To(BeTrue())
+		Expect(fakeUI.ConfirmCallCount()).To(Equal(1))
+	})
+})
@@ -2,9 +2,12 @@ package terminal
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
+
+	. "code.cloudfoundry.org/cli/cf/i18n"
 )
 
 // PrintableTable is an implementation of the Table interface. It
@@ -13,12 +16,14 @@ import (
 type Table struct {
 	ui            UI
 	headers       []string
+	fieldKeys     []string
 	headerPrinted bool
 	columnWidth   []int
 	rowHeight     []int
 	rows          [][]string
 	colSpacing    string
 	transformer   []Transformer
+	selected      []int
 }
 
 // Transformer is the type of functions used to modify the content of
@@ -64,7 +69,101 @@ func (t *Table) SetTransformer(columnIndex int, tr Transformer) {
 
 // Add extends the table by another row.
 func (t *Table) Add(row ...string) {
-	t.rows = append(t.rows, row)
+	t.rows = append(t.rows, t.project(row))
+}
+
+// FieldKey is the name by which the column with the given header is
+// selected with SelectFields and keyed in JSON or YAML output: the header in
+// lower case, with spaces replaced by underscores.
+func FieldKey(header string) string {
+	return strings.Replace(strings.ToLower(strings.TrimSpace(Decolorize(header))), " ", "_", -1)
+}
+
+// SetFieldKeys sets the names by which the columns are selected with
+// SelectFields and keyed in JSON or YAML output, one per header. Commands
+// whose headers are translated set them so that the names do not change with
+// the locale.
+func (t *Table) SetFieldKeys(keys ...string) {
+	t.fieldKeys = keys
+}
+
+// FieldKeys returns the names of the columns: the keys set with
+// SetFieldKeys, or else the FieldKey of each header.
+func (t *Table) FieldKeys() []string {
+	keys := make([]string, len(t.headers))
+	for i, header := range t.headers {
+		if i < len(t.fieldKeys) {
+			keys[i] = t.fieldKeys[i]
+		} else {
+			keys[i] = FieldKey(header)
+		}
+	}
+	return keys
+}
+
+// SelectFields limits the table to the columns whose FieldKey is one of
+// fields, in the order of fields, for commands that let the user choose what
+// they are shown.
+func (t *Table) SelectFields(fields []string) error {
+	keys := t.FieldKeys()
+
+	selected := []int{}
+	for _, field := range fields {
+		field = strings.ToLower(strings.TrimSpace(field))
+		index := -1
+		for i, key := range keys {
+			if key != "" && key == field {
+				index = i
+				break
+			}
+		}
+
+		if index == -1 {
+			return errors.New(T("Unknown field {{.Field}}; the fields are {{.Fields}}",
+				map[string]interface{}{
+					"Field":  field,
+					"Fields": strings.Join(keys, ", "),
+				}))
+		}
+		selected = append(selected, index)
+	}
+
+	headers := make([]string, len(selected))
+	fieldKeys := make([]string, len(selected))
+	transformer := make([]Transformer, len(selected))
+	for i, index := range selected {
+		headers[i] = t.headers[index]
+		fieldKeys[i] = keys[index]
+		transformer[i] = t.transformer[index]
+	}
+
+	rows := t.rows
+	t.rows = [][]string{}
+	t.selected = selected
+	t.headers = headers
+	t.fieldKeys = fieldKeys
+	t.transformer = transformer
+	t.columnWidth = make([]int, len(selected))
+	for _, row := range rows {
+		t.rows = append(t.rows, t.project(row))
+	}
+	return nil
+}
+
+// project keeps the cells of row in the selected columns, if any were
+// selected.
+func (t *Table) project(row []string) []string {
+	if t.selected == nil {
+		return row
+	}
+
+	projected := make([]string, len(t.selected))
+	for i, index := range t.selected {
+		if index < len(row) {
+			projected[i] = row[index]
+		}
+	}
+	return projected
 }
 
 // PrintTo is the core functionality for printing the table, placing
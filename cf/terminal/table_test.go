@@ -201,6 +201,52 @@ var _ = Describe("Table", func() {
 		))
 	})
 
+	Describe("SelectFields", func() {
+		BeforeEach(func() {
+			table = NewTable([]string{"name", "total memory", "routes"})
+			table.Add("default", "10G", "1000")
+		})
+
+		It("prints only the selected columns, in the order they were selected", func() {
+			Expect(table.SelectFields([]string{"routes", " Name"})).To(Succeed())
+			table.Add("small", "1G", "10")
+
+			Expect(table.PrintTo(outputs)).To(Succeed())
+			Expect(Decolorize(string(outputs.Bytes()))).To(Equal(strings.Join([]string{
+				"routes   name",
+				"1000     default",
+				"10       small",
+				"",
+			}, "\n")))
+		})
+
+		It("selects columns by their field key", func() {
+			Expect(table.SelectFields([]string{"total_memory"})).To(Succeed())
+
+			Expect(table.PrintTo(outputs)).To(Succeed())
+			Expect(Decolorize(string(outputs.Bytes()))).To(ContainSubstring("10G"))
+			Expect(Decolorize(string(outputs.Bytes()))).NotTo(ContainSubstring("default"))
+		})
+
+		It("selects columns by the field keys set for them rather than by their headers", func() {
+			table = NewTable([]string{"Name", "Gesamtspeicher"})
+			table.SetFieldKeys("name", "total_memory")
+			table.Add("default", "10G")
+
+			Expect(table.SelectFields([]string{"total_memory"})).To(Succeed())
+			Expect(table.FieldKeys()).To(Equal([]string{"total_memory"}))
+
+			Expect(table.PrintTo(outputs)).To(Succeed())
+			Expect(Decolorize(string(outputs.Bytes()))).To(ContainSubstring("Gesamtspeicher"))
+			Expect(Decolorize(string(outputs.Bytes()))).NotTo(ContainSubstring("default"))
+		})
+
+		It("fails for an unknown field, listing the fields", func() {
+			err := table.SelectFields([]string{"name", "color"})
+			Expect(err).To(MatchError("Unknown field color; the fields are name, total_memory, routes"))
+		})
+	})
+
 	Describe("aligning columns", func() {
 		It("aligns rows to the header when the header is longest", func() {
 			table.Add("a", "b", "c")
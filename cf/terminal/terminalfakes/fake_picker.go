@@ -0,0 +1,120 @@
+// This file was generated by counterfeiter
+package terminalfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type FakePicker struct {
+	InteractiveStub        func() bool
+	interactiveMutex       sync.RWMutex
+	interactiveArgsForCall []struct{}
+	interactiveReturns     struct {
+		result1 bool
+	}
+	PickStub        func(prompt string, options []string, selected int) (int, error)
+	pickMutex       sync.RWMutex
+	pickArgsForCall []struct {
+		prompt   string
+		options  []string
+		selected int
+	}
+	pickReturns struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePicker) Interactive() bool {
+	fake.interactiveMutex.Lock()
+	fake.interactiveArgsForCall = append(fake.interactiveArgsForCall, struct{}{})
+	fake.recordInvocation("Interactive", []interface{}{})
+	fake.interactiveMutex.Unlock()
+	if fake.InteractiveStub != nil {
+		return fake.InteractiveStub()
+	} else {
+		return fake.interactiveReturns.result1
+	}
+}
+
+func (fake *FakePicker) InteractiveCallCount() int {
+	fake.interactiveMutex.RLock()
+	defer fake.interactiveMutex.RUnlock()
+	return len(fake.interactiveArgsForCall)
+}
+
+func (fake *FakePicker) InteractiveReturns(result1 bool) {
+	fake.InteractiveStub = nil
+	fake.interactiveReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakePicker) Pick(prompt string, options []string, selected int) (int, error) {
+	var optionsCopy []string
+	if options != nil {
+		optionsCopy = make([]string, len(options))
+		copy(optionsCopy, options)
+	}
+	fake.pickMutex.Lock()
+	fake.pickArgsForCall = append(fake.pickArgsForCall, struct {
+		prompt   string
+		options  []string
+		selected int
+	}{prompt, optionsCopy, selected})
+	fake.recordInvocation("Pick", []interface{}{prompt, optionsCopy, selected})
+	fake.pickMutex.Unlock()
+	if fake.PickStub != nil {
+		return fake.PickStub(prompt, options, selected)
+	} else {
+		return fake.pickReturns.result1, fake.pickReturns.result2
+	}
+}
+
+func (fake *FakePicker) PickCallCount() int {
+	fake.pickMutex.RLock()
+	defer fake.pickMutex.RUnlock()
+	return len(fake.pickArgsForCall)
+}
+
+func (fake *FakePicker) PickArgsForCall(i int) (string, []string, int) {
+	fake.pickMutex.RLock()
+	defer fake.pickMutex.RUnlock()
+	return fake.pickArgsForCall[i].prompt, fake.pickArgsForCall[i].options, fake.pickArgsForCall[i].selected
+}
+
+func (fake *FakePicker) PickReturns(result1 int, result2 error) {
+	fake.PickStub = nil
+	fake.pickReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePicker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.interactiveMutex.RLock()
+	defer fake.interactiveMutex.RUnlock()
+	fake.pickMutex.RLock()
+	defer fake.pickMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakePicker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ terminal.Picker = new(FakePicker)
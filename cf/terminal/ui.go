@@ -270,6 +270,11 @@ func (u *UITable) Add(row ...string) {
 // the time of the construction. Afterwards the table is cleared,
 // becoming ready for another round of rows and printing.
 func (u *UITable) Print() error {
+	if formatted, ok := u.UI.(*formattedUI); ok {
+		formatted.addRecords(u.Table)
+		return nil
+	}
+
 	result := &bytes.Buffer{}
 	t := u.Table
 
@@ -5,8 +5,6 @@ import (
 	"strconv"
 
 	. "code.cloudfoundry.org/cli/cf/i18n"
-
-	"code.cloudfoundry.org/gofileutils/fileutils"
 )
 
 func NewLogger(writer io.Writer, verbose bool, cfTrace, configTrace string) Printer {
@@ -21,7 +19,7 @@ func NewLogger(writer io.Writer, verbose bool, cfTrace, configTrace string) Prin
 		LoggingToStdout = LoggingToStdout || b
 
 		if path != "" && err != nil {
-			file, err := fileutils.Open(path)
+			file, err := NewRotatingFile(path, DefaultMaxFileSize, DefaultMaxBackups)
 
 			if err == nil {
 				printers = append(printers, NewWriterPrinter(file, false))
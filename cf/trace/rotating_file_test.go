@@ -0,0 +1,87 @@
+package trace_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "code.cloudfoundry.org/cli/cf/trace"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewRotatingFile", func() {
+	var (
+		dir  string
+		path string
+		file io.WriteCloser
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "rotating-file-test")
+		Expect(err).NotTo(HaveOccurred())
+		path = filepath.Join(dir, "trace.log")
+	})
+
+	AfterEach(func() {
+		if file != nil {
+			file.Close()
+		}
+		os.RemoveAll(dir)
+	})
+
+	readFile := func(path string) string {
+		contents, err := ioutil.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		return string(contents)
+	}
+
+	It("appends to an existing file", func() {
+		err := ioutil.WriteFile(path, []byte("old\n"), 0666)
+		Expect(err).NotTo(HaveOccurred())
+
+		file, err = NewRotatingFile(path, 100, 2)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = file.Write([]byte("new\n"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(readFile(path)).To(Equal("old\nnew\n"))
+	})
+
+	It("moves the file aside when a write would take it past the maximum size", func() {
+		var err error
+		file, err = NewRotatingFile(path, 10, 2)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+			_, err = file.Write([]byte(line))
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		Expect(readFile(path)).To(Equal("fourth\n"))
+		Expect(readFile(path + ".1")).To(Equal("third\n"))
+		Expect(readFile(path + ".2")).To(Equal("second\n"))
+		_, err = os.Stat(path + ".3")
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("does not rotate an empty file for a write larger than the maximum size", func() {
+		var err error
+		file, err = NewRotatingFile(path, 4, 2)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = file.Write([]byte("a long line\n"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(readFile(path)).To(Equal("a long line\n"))
+		_, err = os.Stat(path + ".1")
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("returns an error when the file cannot be opened", func() {
+		_, err := NewRotatingFile("/dev/null/whoops", 10, 2)
+		Expect(err).To(HaveOccurred())
+	})
+})
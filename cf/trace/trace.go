@@ -13,11 +13,12 @@ func Sanitize(input string) string {
 	re := regexp.MustCompile(`(?m)^Authorization: .*`)
 	sanitized := re.ReplaceAllString(input, "Authorization: "+PrivateDataPlaceholder())
 
-	re = regexp.MustCompile(`password=[^&]*&`)
-	sanitized = re.ReplaceAllString(sanitized, "password="+PrivateDataPlaceholder()+"&")
+	re = regexp.MustCompile(`\b(password|passcode|code|refresh_token|client_secret)=[^&\s]*`)
+	sanitized = re.ReplaceAllString(sanitized, "$1="+PrivateDataPlaceholder())
 
 	sanitized = sanitizeJSON("token", sanitized)
 	sanitized = sanitizeJSON("password", sanitized)
+	sanitized = sanitizeJSON("secret", sanitized)
 
 	return sanitized
 }
@@ -54,6 +54,42 @@ grant_type=password&password=[PRIVATE DATA HIDDEN]&scope=&username=mgehard%2Bcli
 				Expect(Sanitize(request)).To(Equal(expected))
 			})
 
+			It("hides passwords, refresh tokens and client secrets at the end of the query args", func() {
+				request := `
+POST /oauth/token HTTP/1.1
+Content-Type: application/x-www-form-urlencoded
+
+grant_type=refresh_token&client_secret=shh&refresh_token=some-refresh-token
+username=admin&password=some-password
+`
+
+				expected := `
+POST /oauth/token HTTP/1.1
+Content-Type: application/x-www-form-urlencoded
+
+grant_type=refresh_token&client_secret=[PRIVATE DATA HIDDEN]&refresh_token=[PRIVATE DATA HIDDEN]
+username=admin&password=[PRIVATE DATA HIDDEN]
+`
+				Expect(Sanitize(request)).To(Equal(expected))
+			})
+
+			It("hides the authorization code exchanged for tokens", func() {
+				request := `
+POST /oauth/token HTTP/1.1
+Content-Type: application/x-www-form-urlencoded
+
+grant_type=authorization_code&code=some-code&redirect_uri=http%3A%2F%2Flocalhost%3A8080
+`
+
+				expected := `
+POST /oauth/token HTTP/1.1
+Content-Type: application/x-www-form-urlencoded
+
+grant_type=authorization_code&code=[PRIVATE DATA HIDDEN]&redirect_uri=http%3A%2F%2Flocalhost%3A8080
+`
+				Expect(Sanitize(request)).To(Equal(expected))
+			})
+
 			It("hides passwords in the JSON-formatted request body", func() {
 				request := `
 REQUEST: [2014-03-07T10:53:36-08:00]
@@ -223,6 +259,24 @@ HTTP/1.1 200 OK
 Content-Type: application/json;charset=utf-8
 
 {"guid":"99fefc8e-845e-47f3-a8b1-26e8a00222d9","name":"example","environment_json":{"password":"[PRIVATE DATA HIDDEN]","PASSWORD":"[PRIVATE DATA HIDDEN]","foo_password_bar":"[PRIVATE DATA HIDDEN]","FOO_PASSWORD_BAR":"[PRIVATE DATA HIDDEN]"},"memory":1024,"instances":1}
+`
+
+				Expect(Sanitize(response)).To(Equal(expected))
+			})
+
+			It("hides the value of any key matching case-insensitive substring 'secret'", func() {
+				response := `
+HTTP/1.1 200 OK
+Content-Type: application/json;charset=utf-8
+
+{"name":"example","environment_json":{"client_secret":"shh","API_SECRET":"shh"},"memory":1024}
+`
+
+				expected := `
+HTTP/1.1 200 OK
+Content-Type: application/json;charset=utf-8
+
+{"name":"example","environment_json":{"client_secret":"[PRIVATE DATA HIDDEN]","API_SECRET":"[PRIVATE DATA HIDDEN]"},"memory":1024}
 `
 
 				Expect(Sanitize(response)).To(Equal(expected))
@@ -0,0 +1,51 @@
+package uihelpers
+
+import "code.cloudfoundry.org/cli/cf/terminal"
+
+// PagedTable prints the rows of a table a page at a time, as the pages of a
+// paginated list arrive, instead of once the whole list has been fetched.
+type PagedTable struct {
+	table   *terminal.UITable
+	rows    int
+	pending bool
+	printed bool
+	err     error
+}
+
+func NewPagedTable(table *terminal.UITable) *PagedTable {
+	return &PagedTable{table: table}
+}
+
+func (t *PagedTable) Add(row ...string) {
+	t.table.Add(row...)
+	t.rows++
+	t.pending = true
+}
+
+// Rows returns the number of rows added to the table so far.
+func (t *PagedTable) Rows() int {
+	return t.rows
+}
+
+// PageDone prints the rows added since the previous page. It is meant to be
+// given to net.Gateway.SetPageListener.
+func (t *PagedTable) PageDone() {
+	if t.pending && t.err == nil {
+		t.print()
+	}
+}
+
+// Print prints the rows that have not been printed yet, or just the headers
+// if the table is empty, and returns the first error printing the table.
+func (t *PagedTable) Print() error {
+	if t.err == nil && (t.pending || !t.printed) {
+		t.print()
+	}
+	return t.err
+}
+
+func (t *PagedTable) print() {
+	t.err = t.table.Print()
+	t.pending = false
+	t.printed = true
+}
@@ -0,0 +1,44 @@
+package uihelpers_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/terminal"
+	. "code.cloudfoundry.org/cli/cf/uihelpers"
+	testterm "code.cloudfoundry.org/cli/testhelpers/terminal"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PagedTable", func() {
+	var (
+		ui    *testterm.FakeUI
+		table *PagedTable
+	)
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		table = NewPagedTable(ui.Table([]string{"name"}))
+	})
+
+	It("prints the rows of every page when the page is done", func() {
+		table.Add("row-1")
+		table.Add("row-2")
+		table.PageDone()
+		Expect(terminal.Decolorize(ui.Outputs()[0])).To(MatchRegexp(`^name\s*$`))
+		Expect(ui.Outputs()).To(HaveLen(3))
+
+		table.PageDone()
+		Expect(ui.Outputs()).To(HaveLen(3))
+
+		table.Add("row-3")
+		Expect(table.Print()).To(Succeed())
+		Expect(ui.Outputs()).To(HaveLen(4))
+		Expect(ui.Outputs()[3]).To(ContainSubstring("row-3"))
+		Expect(table.Rows()).To(Equal(3))
+	})
+
+	It("prints the headers of an empty table", func() {
+		Expect(table.Print()).To(Succeed())
+		Expect(ui.Outputs()).To(HaveLen(1))
+	})
+})
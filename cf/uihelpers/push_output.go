@@ -0,0 +1,82 @@
+package uihelpers
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+const (
+	PushOutputText = "text"
+	PushOutputJSON = "json"
+)
+
+// PushOutput receives the progress of a push as it happens, for tools that
+// follow a push without reading its human readable output.
+type PushOutput interface {
+	FilesMatched(appName string, fileCount int, filesToUpload int)
+	BytesUploaded(appName string, bytes int64)
+	StagingStateChanged(appName string, state string)
+	AppPushed(appName string, routes []string)
+	PushFailed(err error)
+}
+
+type jsonPushOutput struct {
+	writer io.Writer
+	mutex  sync.Mutex
+}
+
+// NewJSONPushOutput returns a PushOutput that writes every event to writer as
+// a JSON object on a line of its own.
+func NewJSONPushOutput(writer io.Writer) PushOutput {
+	return &jsonPushOutput{writer: writer}
+}
+
+func (output *jsonPushOutput) FilesMatched(appName string, fileCount int, filesToUpload int) {
+	output.write(map[string]interface{}{
+		"event":           "files_matched",
+		"app":             appName,
+		"files":           fileCount,
+		"files_to_upload": filesToUpload,
+	})
+}
+
+func (output *jsonPushOutput) BytesUploaded(appName string, bytes int64) {
+	output.write(map[string]interface{}{
+		"event": "bytes_uploaded",
+		"app":   appName,
+		"bytes": bytes,
+	})
+}
+
+func (output *jsonPushOutput) StagingStateChanged(appName string, state string) {
+	output.write(map[string]interface{}{
+		"event": "staging_state",
+		"app":   appName,
+		"state": state,
+	})
+}
+
+func (output *jsonPushOutput) AppPushed(appName string, routes []string) {
+	output.write(map[string]interface{}{
+		"event":  "app_pushed",
+		"app":    appName,
+		"routes": routes,
+	})
+}
+
+func (output *jsonPushOutput) PushFailed(err error) {
+	output.write(map[string]interface{}{
+		"event": "push_failed",
+		"error": err.Error(),
+	})
+}
+
+func (output *jsonPushOutput) write(event map[string]interface{}) {
+	output.mutex.Lock()
+	defer output.mutex.Unlock()
+
+	// maps of strings and numbers always marshal
+	line, _ := json.Marshal(event)
+	_, _ = output.writer.Write(append(line, '\n'))
+}
@@ -0,0 +1,68 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	. "code.cloudfoundry.org/cli/cf/i18n"
+)
+
+var dotEnvLine = regexp.MustCompile(`^(?:export\s+)?([A-Za-z_][A-Za-z0-9_.]*)\s*=\s*(.*)$`)
+
+// ParseDotEnv reads NAME=VALUE lines, as written for tools such as docker and
+// foreman. Values may be quoted: double quoted values understand \n and other
+// escapes, single quoted values are taken as they are, and unquoted values end
+// at a " #" comment. Every variable in the file has a value, none are nil.
+func ParseDotEnv(path string, contents []byte) (map[string]*string, error) {
+	vars := map[string]*string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		invalidLine := errors.New(T("Invalid line {{.Line}} in {{.Path}}; expected NAME=VALUE",
+			map[string]interface{}{"Line": lineNumber, "Path": path}))
+
+		match := dotEnvLine.FindStringSubmatch(line)
+		if match == nil {
+			return nil, invalidLine
+		}
+
+		value := match[2]
+		switch {
+		case strings.HasPrefix(value, `"`):
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, invalidLine
+			}
+			value = unquoted
+		case strings.HasPrefix(value, "'"):
+			if len(value) < 2 || !strings.HasSuffix(value, "'") {
+				return nil, invalidLine
+			}
+			value = value[1 : len(value)-1]
+		default:
+			if i := strings.Index(value, " #"); i != -1 {
+				value = value[:i]
+			}
+			value = strings.TrimSpace(value)
+		}
+
+		vars[match[1]] = &value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return vars, nil
+}
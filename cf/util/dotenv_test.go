@@ -0,0 +1,31 @@
+package util_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/util"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseDotEnv", func() {
+	It("reads NAME=VALUE lines, skipping blank lines and comments", func() {
+		vars, err := util.ParseDotEnv("vars.env", []byte(`
+# database
+export DB_HOST=db.example.com
+GREETING="hello\nworld"
+RAW='a "quoted" $value'
+LOG_LEVEL=debug # noisy
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(vars).To(HaveLen(4))
+		Expect(*vars["DB_HOST"]).To(Equal("db.example.com"))
+		Expect(*vars["GREETING"]).To(Equal("hello\nworld"))
+		Expect(*vars["RAW"]).To(Equal(`a "quoted" $value`))
+		Expect(*vars["LOG_LEVEL"]).To(Equal("debug"))
+	})
+
+	It("fails on lines that are not NAME=VALUE", func() {
+		_, err := util.ParseDotEnv("vars.env", []byte("GOOD=1\nnot a variable\n"))
+		Expect(err).To(MatchError("Invalid line 2 in vars.env; expected NAME=VALUE"))
+	})
+})
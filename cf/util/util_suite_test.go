@@ -1,6 +1,8 @@
 package util_test
 
 import (
+	"code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/testhelpers/configuration"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -8,6 +10,9 @@ import (
 )
 
 func TestUtil(t *testing.T) {
+	config := configuration.NewRepositoryWithDefaults()
+	i18n.T = i18n.Init(config)
+
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Util Suite")
 }
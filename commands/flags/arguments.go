@@ -4,6 +4,10 @@ type AppName struct {
 	AppName string `positional-arg-name:"APP_NAME" required:"true" description:"The application name"`
 }
 
+type OptionalAppName struct {
+	AppName string `positional-arg-name:"APP_NAME" description:"The application name"`
+}
+
 type Buildpack struct {
 	Buildpack string `positional-arg-name:"BUILDPACK" required:"true" description:"The buildpack"`
 }
@@ -12,6 +16,10 @@ type CommandName struct {
 	CommandName string `positional-arg-name:"COMMAND_NAME" description:"The command name"`
 }
 
+type ContextName struct {
+	ContextName string `positional-arg-name:"NAME" required:"true" description:"The context name"`
+}
+
 type Domain struct {
 	Domain string `positional-arg-name:"DOMAIN" required:"true" description:"The domain"`
 }
@@ -24,6 +32,10 @@ type ParamsAsJSON struct {
 	JSON string `positional-arg-name:"JSON" required:"true" description:"Parameters as JSON"`
 }
 
+type EnvironmentVariableGroupArgs struct {
+	JSON string `positional-arg-name:"JSON" description:"Environment variables as a JSON object, or the path to a file containing one"`
+}
+
 type Service struct {
 	Service string `positional-arg-name:"SERVICE" required:"true" description:"The service offering name"`
 }
@@ -60,6 +72,10 @@ type ServiceBroker struct {
 	ServiceBroker string `positional-arg-name:"SERVICE_BROKER" required:"true" description:"The service broker"`
 }
 
+type Shell struct {
+	Shell string `positional-arg-name:"SHELL" required:"true" description:"The shell: bash, zsh, fish or powershell"`
+}
+
 type Space struct {
 	Space string `positional-arg-name:"SPACE" required:"true" description:"The space"`
 }
@@ -85,6 +101,11 @@ type Authentication struct {
 	Password string `positional-arg-name:"PASSWORD" required:"true" description:"The password"`
 }
 
+type CreateUserArgs struct {
+	Username string `positional-arg-name:"USERNAME" required:"true" description:"The username"`
+	Password string `positional-arg-name:"PASSWORD" description:"The password"`
+}
+
 type AppInstance struct {
 	AppName string `positional-arg-name:"APP_NAME" required:"true" description:"The application name"`
 	Index   int    `positional-arg-name:"INDEX" required:"true" description:"The index of the application instance"`
@@ -120,6 +141,11 @@ type SpaceDomain struct {
 	Domain string `positional-arg-name:"DOMAIN" required:"true" description:"The domain"`
 }
 
+type CreateRouteArgs struct {
+	Space  string `positional-arg-name:"SPACE" required:"true" description:"The space, or the domain when the route is created in the targeted space"`
+	Domain string `positional-arg-name:"DOMAIN" description:"The domain"`
+}
+
 type BindSecurityGroupArgs struct {
 	SecurityGroupName string `positional-arg-name:"SECURITY_GROUP" required:"true" description:"The security group name"`
 	OrganizationName  string `positional-arg-name:"ORG" required:"true" description:"The organization group name"`
@@ -148,6 +174,11 @@ type UnsetEnvironmentArgs struct {
 	EnvironmentVariableName string `positional-arg-name:"ENV_VAR_NAME" required:"true" description:"The environment variable name"`
 }
 
+type NetworkPolicyArgs struct {
+	SourceApp      string `positional-arg-name:"SOURCE_APP" required:"true" description:"The app that opens the connections"`
+	DestinationApp string `positional-arg-name:"DESTINATION_APP" required:"true" description:"The app that accepts the connections"`
+}
+
 type CopySourceArgs struct {
 	SourceAppName string `positional-arg-name:"SOURCE-APP" required:"true" description:"The old application name"`
 	TargetAppName string `positional-arg-name:"TARGET-NAME" required:"true" description:"The new application name"`
@@ -208,7 +239,7 @@ type SetHealthCheckArgs struct {
 type CreateBuildpackArgs struct {
 	Buildpack string `positional-arg-name:"BUILDPACK" required:"true" description:"The buildpack"`
 	Path      string `positional-arg-name:"PATH" required:"true" description:"The path to the buildpack file"`
-	Position  string `positional-arg-name:"POSITION" required:"true" description:"The position that sets priority"`
+	Position  string `positional-arg-name:"POSITION" description:"The position that sets priority"`
 }
 
 type RenameBuildpackArgs struct {
@@ -247,6 +278,13 @@ type ServiceBrokerArgs struct {
 	URL           string `positional-arg-name:"URL" required:"true" description:"The URL of the service broker"`
 }
 
+type UpdateServiceBrokerArgs struct {
+	ServiceBroker string `positional-arg-name:"SERVICE_BROKER" required:"true" description:"The service broker name"`
+	Username      string `positional-arg-name:"USERNAME" description:"The username"`
+	Password      string `positional-arg-name:"PASSWORD" description:"The password"`
+	URL           string `positional-arg-name:"URL" description:"The URL of the service broker"`
+}
+
 type RenameServiceBrokerArgs struct {
 	OldServiceBrokerName string `positional-arg-name:"SERVICE_BROKER" required:"true" description:"The old service broker name"`
 	NewServiceBrokerName string `positional-arg-name:"NEW_SERVICE_BROKER" required:"true" description:"The new service broker name"`
@@ -274,3 +312,62 @@ type InstallPluginArgs struct {
 	LocalPath string `positional-arg-name:"LOCAL_PATH/TO/PLUGIN" description:"The local path to the plugin, if the plugin exists locally"`
 	URL       string `positional-arg-name:"URL" description:"The URL to the plugin, if the plugin exists online"`
 }
+
+type RunTaskArgs struct {
+	AppName string `positional-arg-name:"APP_NAME" required:"true" description:"The application name"`
+	Command string `positional-arg-name:"COMMAND" required:"true" description:"The command to run as a task"`
+}
+
+type TerminateTaskArgs struct {
+	AppName string `positional-arg-name:"APP_NAME" required:"true" description:"The application name"`
+	TaskID  int    `positional-arg-name:"TASK_ID" required:"true" description:"The id of the task, as shown by tasks"`
+}
+
+type SCPArgs struct {
+	Source string `positional-arg-name:"SOURCE" required:"true" description:"The file or directory to copy, as a local path or APP_NAME:PATH"`
+	Target string `positional-arg-name:"TARGET" required:"true" description:"Where to copy it to, as a local path or APP_NAME:PATH"`
+}
+
+type ChangeStackArgs struct {
+	AppName  string `positional-arg-name:"APP_NAME" required:"true" description:"The application name"`
+	NewStack string `positional-arg-name:"NEW_STACK" required:"true" description:"The name of the stack to move the app to"`
+}
+
+type StagePackageArgs struct {
+	AppName     string `positional-arg-name:"APP_NAME" required:"true" description:"The application name"`
+	PackageGUID string `positional-arg-name:"PACKAGE_GUID" required:"true" description:"The guid of the package to stage"`
+}
+
+type CreateBuildArgs struct {
+	PackageGUID string `positional-arg-name:"PACKAGE_GUID" required:"true" description:"The guid of the package to stage"`
+}
+
+type LogsBuildArgs struct {
+	BuildGUID string `positional-arg-name:"BUILD_GUID" required:"true" description:"The guid of the build"`
+}
+
+type AppFeatureArgs struct {
+	AppName     string `positional-arg-name:"APP_NAME" required:"true" description:"The application name"`
+	FeatureName string `positional-arg-name:"FEATURE_NAME" required:"true" description:"The name of the app feature, such as ssh or revisions"`
+}
+
+type LabelsArgs struct {
+	ResourceType string `positional-arg-name:"RESOURCE_TYPE" required:"true" description:"The type of the resource: app, buildpack, org, service-instance, space or stack"`
+	ResourceName string `positional-arg-name:"RESOURCE_NAME" required:"true" description:"The name of the resource"`
+}
+
+type SetLabelArgs struct {
+	ResourceType string   `positional-arg-name:"RESOURCE_TYPE" required:"true" description:"The type of the resource: app, buildpack, org, service-instance, space or stack"`
+	ResourceName string   `positional-arg-name:"RESOURCE_NAME" required:"true" description:"The name of the resource"`
+	Labels       []string `positional-arg-name:"KEY=VALUE" required:"1" description:"The labels to set"`
+}
+
+type UnsetLabelArgs struct {
+	ResourceType string   `positional-arg-name:"RESOURCE_TYPE" required:"true" description:"The type of the resource: app, buildpack, org, service-instance, space or stack"`
+	ResourceName string   `positional-arg-name:"RESOURCE_NAME" required:"true" description:"The name of the resource"`
+	Keys         []string `positional-arg-name:"KEY" required:"1" description:"The keys of the labels to remove"`
+}
+
+type PluginNames struct {
+	PluginNames []string `positional-arg-name:"PLUGIN_NAME" description:"The names of the plugins to update"`
+}
@@ -0,0 +1,26 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type AddNetworkPolicyCommand struct {
+	RequiredArgs    flags.NetworkPolicyArgs `positional-args:"yes"`
+	Port            string                  `long:"port" description:"Port or range of ports the destination app listens on, such as 8080 or 8080-8090 (Default: 8080)"`
+	Protocol        string                  `long:"protocol" description:"Protocol to connect with: tcp or udp (Default: tcp)"`
+	usage           interface{}             `usage:"CF_NAME add-network-policy SOURCE_APP DESTINATION_APP [--port PORT] [--protocol (tcp | udp)]\n\nEXAMPLES:\n   CF_NAME add-network-policy frontend backend\n   CF_NAME add-network-policy frontend backend --port 8081 --protocol udp\n   CF_NAME add-network-policy frontend backend --port 9000-9010"`
+	relatedCommands interface{}             `related_commands:"apps, network-policies, remove-network-policy"`
+}
+
+func (_ AddNetworkPolicyCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ AddNetworkPolicyCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -11,7 +11,7 @@ import (
 type AppCommand struct {
 	RequiredArgs    flags.AppName `positional-args:"yes"`
 	GUID            bool          `long:"guid" description:"Retrieve and display the given app's guid.  All other health and status output for the app is suppressed."`
-	usage           interface{}   `usage:"CF_NAME app APP_NAME"`
+	usage           interface{}   `usage:"CF_NAME app APP_NAME [--guid]\n\nEXAMPLES:\n   CF_NAME app my-app\n   CF_NAME app my-app --output json"`
 	relatedCommands interface{}   `related_commands:"apps, events, logs, map-route, unmap-route, push"`
 }
 
@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type AppFeaturesCommand struct {
+	RequiredArgs    flags.AppName `positional-args:"yes"`
+	usage           interface{}   `usage:"CF_NAME app-features APP_NAME"`
+	relatedCommands interface{}   `related_commands:"enable-app-feature, disable-app-feature, ssh-enabled"`
+}
+
+func (_ AppFeaturesCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ AppFeaturesCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -0,0 +1,25 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type AppMetricsCommand struct {
+	RequiredArgs    flags.AppName `positional-args:"yes"`
+	Watch           bool          `long:"watch" short:"w" description:"Refresh the metrics every 5 seconds until interrupted"`
+	usage           interface{}   `usage:"CF_NAME app-metrics APP_NAME [--watch]\n\nEXAMPLES:\n   CF_NAME app-metrics my-app\n   CF_NAME app-metrics my-app --watch --output json"`
+	relatedCommands interface{}   `related_commands:"app, logs, scale"`
+}
+
+func (_ AppMetricsCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ AppMetricsCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -0,0 +1,25 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+)
+
+type ApplyManifestCommand struct {
+	PathToManifest  string      `short:"f" description:"Path to the manifest of the space"`
+	Force           bool        `long:"force" description:"Delete the apps that are not in the manifest without asking for confirmation"`
+	Lenient         bool        `long:"lenient" description:"Warn about manifest attributes that push does not know instead of failing"`
+	usage           interface{} `usage:"CF_NAME apply-manifest -f MANIFEST_PATH [--force] [--lenient]\n\n   Apps in the manifest are pushed as with 'CF_NAME push -f'. Apps in the space that are not in the manifest are deleted, after confirmation.\n\n   An app that already has the instances, memory, disk, buildpack, environment variables and routes the manifest declares is not pushed again. Use 'CF_NAME push' to deploy new app bits.\n\nEXAMPLES:\n   CF_NAME apply-manifest -f space-manifest.yml\n   CF_NAME apply-manifest -f space-manifest.yml --force"`
+	relatedCommands interface{} `related_commands:"push, create-app-manifest, validate-manifest"`
+}
+
+func (_ ApplyManifestCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ ApplyManifestCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
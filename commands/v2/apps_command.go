@@ -8,8 +8,13 @@ import (
 )
 
 type AppsCommand struct {
-	usage           interface{} `usage:"CF_NAME apps"`
-	relatedCommands interface{} `related_commands:"events, logs, map-route, push, scale, start, stop, restart"`
+	NameFilter      string      `long:"name-filter" description:"Only list apps whose name matches this regular expression"`
+	State           string      `long:"state" description:"Only list apps in this requested state (started or stopped)"`
+	Buildpack       string      `long:"buildpack" description:"Only list apps using this buildpack, either specified or detected"`
+	Labels          string      `long:"labels" description:"Only list apps whose labels match this selector, such as 'env=prod,tier!=web'"`
+	Stats           bool        `long:"stats" description:"Also show the CPU, memory and disk usage of the running instances of each app"`
+	usage           interface{} `usage:"CF_NAME apps [--name-filter REGEX] [--state (started | stopped)] [--buildpack BUILDPACK] [--labels SELECTOR] [--stats]\n\nEXAMPLES:\n   CF_NAME apps --name-filter \"^billing-\"\n   CF_NAME apps --state stopped --buildpack ruby_buildpack\n   CF_NAME apps --state started --stats\n   CF_NAME apps --labels 'env in (prod,staging),!deprecated'"`
+	relatedCommands interface{} `related_commands:"events, logs, map-route, push, scale, start, stop, restart, labels"`
 }
 
 func (_ AppsCommand) Setup(config commands.Config, ui commands.UI) error {
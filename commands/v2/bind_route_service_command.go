@@ -13,8 +13,8 @@ type BindRouteServiceCommand struct {
 	ParametersAsJSON       string                 `short:"c" description:"Valid JSON object containing service-specific configuration parameters, provided inline or in a file. For a list of supported configuration parameters, see documentation for the particular service offering."`
 	Hostname               string                 `long:"hostname" short:"n" description:"Hostname used in combination with DOMAIN to specify the route to bind"`
 	Path                   string                 `long:"path" description:"Path for the HTTP route"`
-	usage                  interface{}            `usage:"CF_NAME bind-route-service DOMAIN SERVICE_INSTANCE [--hostname HOSTNAME] [--path PATH] [-c PARAMETERS_AS_JSON]\n\nEXAMPLES:\n   CF_NAME bind-route-service example.com myratelimiter --hostname myapp --path foo\n   CF_NAME bind-route-service example.com myratelimiter -c file.json\n   CF_NAME bind-route-service example.com myratelimiter -c '{\"valid\":\"json\"}'\n\n   In Windows PowerShell use double-quoted, escaped JSON: \"{\\\"valid\\\":\\\"json\\\"}\"\n   In Windows Command Line use single-quoted, escaped JSON: '{\\\"valid\\\":\\\"json\\\"}'"`
-	relatedCommands        interface{}            `related_commands:"routes, services"`
+	usage                  interface{}            `usage:"CF_NAME bind-route-service DOMAIN SERVICE_INSTANCE [--hostname HOSTNAME] [--path PATH] [-c PARAMETERS_AS_JSON]\n\nEXAMPLES:\n   CF_NAME bind-route-service example.com myratelimiter --hostname myapp --path foo\n   CF_NAME bind-route-service example.com myratelimiter -c file.json\n   CF_NAME bind-route-service example.com myratelimiter -c '{\"valid\":\"json\"}'\n\n   In Windows PowerShell use double-quoted, escaped JSON: \"{\\\"valid\\\":\\\"json\\\"}\"\n   In Windows Command Line use single-quoted, escaped JSON: '{\\\"valid\\\":\\\"json\\\"}'\n   CF_NAME bind-route-service example.com myratelimiter --hostname myapp --output json"`
+	relatedCommands        interface{}            `related_commands:"route-services, routes, services"`
 	BackwardsCompatibility bool                   `short:"f" hidden:"true" description:"This is for backwards compatibility"`
 }
 
@@ -0,0 +1,26 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type BrokerCatalogDiffCommand struct {
+	RequiredArgs    flags.ServiceBroker `positional-args:"yes"`
+	Username        string              `long:"username" description:"Username to fetch the catalog with (Default: the username the broker is registered with)"`
+	Password        string              `long:"password" description:"Password to fetch the catalog with; prompted for when not given"`
+	usage           interface{}         `usage:"CF_NAME broker-catalog-diff SERVICE_BROKER [--username USERNAME] [--password PASSWORD]\n\n   The catalog is fetched from the broker itself, so its URL has to be reachable from this machine."`
+	relatedCommands interface{}         `related_commands:"service-brokers, update-service-broker, enable-service-access, service-access"`
+}
+
+func (_ BrokerCatalogDiffCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ BrokerCatalogDiffCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -8,7 +8,7 @@ import (
 )
 
 type BuildpacksCommand struct {
-	usage           interface{} `usage:"CF_NAME buildpacks"`
+	usage           interface{} `usage:"CF_NAME buildpacks\n\nEXAMPLES:\n   CF_NAME buildpacks --output json"`
 	relatedCommands interface{} `related_commands:"push"`
 }
 
@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type BuildsCommand struct {
+	RequiredArgs    flags.AppName `positional-args:"yes"`
+	usage           interface{}   `usage:"CF_NAME builds APP_NAME"`
+	relatedCommands interface{}   `related_commands:"create-build, logs-build, packages"`
+}
+
+func (_ BuildsCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ BuildsCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -4,6 +4,12 @@ var Commands commandList
 
 type commandList struct {
 	VerboseOrVersion                   bool                                      `short:"v" long:"version" description:"verbose and version flag"`
+	Output                             string                                    `long:"output" description:"Print the data of list and show commands as table, json or yaml"`
+	Trace                              string                                    `long:"trace" optional:"yes" optional-value:"true" description:"Print API request diagnostics for this command to stdout, or append them to a log file"`
+	Context                            string                                    `long:"context" description:"Run the command against a context saved with target-save, leaving the current target alone"`
+	OrgOverride                        string                                    `long:"org" description:"Run the command in this org, leaving the targeted org alone"`
+	SpaceOverride                      string                                    `long:"space" description:"Run the command in this space, leaving the targeted space alone"`
+	NonInteractive                     bool                                      `long:"non-interactive" description:"Fail instead of prompting for input or confirmation"`
 	App                                AppCommand                                `command:"app" description:"Display health and status for app"`
 	Help                               HelpCommand                               `command:"help" alias:"h" description:"Show help"`
 	Version                            VersionCommand                            `command:"version" description:"Print the version"`
@@ -13,8 +19,11 @@ type commandList struct {
 	Target                             TargetCommand                             `command:"target" alias:"t" description:"Set or view the targeted org or space"`
 	Api                                ApiCommand                                `command:"api" description:"Set or view target api url"`
 	Auth                               AuthCommand                               `command:"auth" description:"Authenticate user non-interactively"`
+	TargetSave                         TargetSaveCommand                         `command:"target-save" description:"Save the current target, with its login, as a named context"`
+	TargetUse                          TargetUseCommand                          `command:"target-use" description:"Switch the current target to a named context"`
 	Apps                               AppsCommand                               `command:"apps" alias:"a" description:"List all apps in the target space"`
 	Push                               PushCommand                               `command:"push" alias:"p" description:"Push a new app or sync changes to an existing app"`
+	ZeroDowntimePush                   ZeroDowntimePushCommand                   `command:"zero-downtime-push" description:"Push a new version of an app and switch its routes over once it is running"`
 	Scale                              ScaleCommand                              `command:"scale" description:"Change or view the instance count, disk space limit, and memory limit for an app"`
 	Delete                             DeleteCommand                             `command:"delete" alias:"d" description:"Delete an app"`
 	Rename                             RenameCommand                             `command:"rename" description:"Rename an app"`
@@ -23,22 +32,50 @@ type commandList struct {
 	Restart                            RestartCommand                            `command:"restart" alias:"rs" description:"Stop all instances of the app, then start them again. This may cause downtime."`
 	Restage                            RestageCommand                            `command:"restage" alias:"rg" description:"Recreate the app's executable artifact using the latest pushed app files and the latest environment (variables, service bindings, buildpack, stack, etc.)"`
 	RestartAppInstance                 RestartAppInstanceCommand                 `command:"restart-app-instance" description:"Terminate the running application Instance at the given index and instantiate a new instance of the application with the same index"`
+	Rollback                           RollbackCommand                           `command:"rollback" description:"Restart an app on a droplet from an earlier push"`
+	Revisions                          RevisionsCommand                          `command:"revisions" description:"List the revisions of an app, newest first"`
+	DownloadDroplet                    DownloadDropletCommand                    `command:"download-droplet" description:"Download the droplet of an app as a gzipped tarball"`
+	SetDroplet                         SetDropletCommand                         `command:"set-droplet" description:"Set the droplet an app runs on, uploading it first when it comes from a file"`
+	Packages                           PackagesCommand                           `command:"packages" description:"List the packages of an app, newest first"`
+	CreatePackage                      CreatePackageCommand                      `command:"create-package" description:"Upload app files into a new package of an app, without staging or restarting it"`
+	StagePackage                       StagePackageCommand                       `command:"stage-package" description:"Stage a package of an app into a new droplet, without changing the droplet the app runs"`
+	Builds                             BuildsCommand                             `command:"builds" description:"List the builds of an app, newest first"`
+	CreateBuild                        CreateBuildCommand                        `command:"create-build" description:"Start staging a package into a new droplet, without waiting for staging to finish"`
+	LogsBuild                          LogsBuildCommand                          `command:"logs-build" description:"Show the staging logs of a build, following them until staging finishes"`
+	Deployments                        DeploymentsCommand                        `command:"deployments" description:"List the deployments of an app, newest first"`
+	CancelDeployment                   CancelDeploymentCommand                   `command:"cancel-deployment" description:"Cancel the deployment in progress for an app, rolling it back to the droplet it ran before"`
+	ContinueDeployment                 ContinueDeploymentCommand                 `command:"continue-deployment" description:"Continue the paused canary deployment of an app, replacing the rest of its instances"`
+	RunTask                            RunTaskCommand                            `command:"run-task" alias:"rt" description:"Run a one-off task on an app"`
+	Tasks                              TasksCommand                              `command:"tasks" description:"List the tasks of an app"`
+	TerminateTask                      TerminateTaskCommand                      `command:"terminate-task" description:"Terminate a running task of an app"`
+	Sidecars                           SidecarsCommand                           `command:"sidecars" description:"List the sidecars of an app"`
 	Events                             EventsCommand                             `command:"events" description:"Show recent app events"`
+	AppHistory                         AppHistoryCommand                         `command:"app-history" description:"Show the droplets an app was pushed with, and who pushed them"`
+	CrashInfo                          CrashInfoCommand                          `command:"crash-info" description:"Show the recent crashes of an app with the exit description and last logs of each crashed instance"`
 	Files                              FilesCommand                              `command:"files" alias:"f" description:"Print out a list of files in a directory or the contents of a specific file of an app running on the DEA backend"`
 	Logs                               LogsCommand                               `command:"logs" description:"Tail or show recent logs for an app"`
+	AppMetrics                         AppMetricsCommand                         `command:"app-metrics" description:"Show CPU, memory and disk usage of the instances of an app"`
 	Env                                EnvCommand                                `command:"env" alias:"e" description:"Show all env variables for an app"`
 	SetEnv                             SetEnvCommand                             `command:"set-env" alias:"se" description:"Set an env variable for an app"`
 	UnsetEnv                           UnsetEnvCommand                           `command:"unset-env" description:"Remove an env variable"`
 	Stacks                             StacksCommand                             `command:"stacks" description:"List all stacks (a stack is a pre-built file system, including an operating system, that can run apps)"`
 	Stack                              StackCommand                              `command:"stack" description:"Show information for a stack (a stack is a pre-built file system, including an operating system, that can run apps)"`
+	ChangeStack                        ChangeStackCommand                        `command:"change-stack" description:"Move an app to another stack, restaging it and replacing its instances a few at a time"`
 	CopySource                         CopySourceCommand                         `command:"copy-source" description:"Copies the source code of an application to another existing application (and restarts that application)"`
 	CreateAppManifest                  CreateAppManifestCommand                  `command:"create-app-manifest" description:"Create an app manifest for an app that has been pushed successfully"`
+	MigrateManifest                    MigrateManifestCommand                    `command:"migrate-manifest" description:"Rewrite a manifest without deprecated attributes or inherited manifests"`
+	ApplyManifest                      ApplyManifestCommand                      `command:"apply-manifest" description:"Make the apps in the targeted space match a manifest, creating, updating and deleting apps"`
+	ValidateManifest                   ValidateManifestCommand                   `command:"validate-manifest" description:"Check an app manifest for problems without contacting Cloud Foundry"`
 	GetHealthCheck                     GetHealthCheckCommand                     `command:"get-health-check" description:"Get the health_check_type value of an app"`
 	SetHealthCheck                     SetHealthCheckCommand                     `command:"set-health-check" description:"Set health_check_type flag to either 'port' or 'none'"`
 	EnableSSH                          EnableSSHCommand                          `command:"enable-ssh" description:"Enable ssh for the application"`
 	DisableSSH                         DisableSSHCommand                         `command:"disable-ssh" description:"Disable ssh for the application"`
 	SSHEnabled                         SSHEnabledCommand                         `command:"ssh-enabled" description:"Reports whether SSH is enabled on an application container instance"`
+	AppFeatures                        AppFeaturesCommand                        `command:"app-features" description:"List the features of an app and whether they are enabled"`
+	EnableAppFeature                   EnableAppFeatureCommand                   `command:"enable-app-feature" description:"Enable a feature of an app"`
+	DisableAppFeature                  DisableAppFeatureCommand                  `command:"disable-app-feature" description:"Disable a feature of an app"`
 	SSH                                SSHCommand                                `command:"ssh" description:"SSH to an application container instance"`
+	SCP                                SCPCommand                                `command:"scp" description:"Copy files to or from an application container instance"`
 	Marketplace                        MarketplaceCommand                        `command:"marketplace" alias:"m" description:"List available offerings in the marketplace"`
 	Services                           ServicesCommand                           `command:"services" alias:"s" description:"List all service instances in the target space"`
 	Service                            ServiceCommand                            `command:"service" description:"Show service instance info"`
@@ -49,10 +86,13 @@ type commandList struct {
 	CreateServiceKey                   CreateServiceKeyCommand                   `command:"create-service-key" alias:"csk" description:"Create key for a service instance"`
 	ServiceKeys                        ServiceKeysCommand                        `command:"service-keys" alias:"sk" description:"List keys for a service instance"`
 	ServiceKey                         ServiceKeyCommand                         `command:"service-key" description:"Show service key info"`
+	ServiceKeyEnv                      ServiceKeyEnvCommand                      `command:"service-key-env" description:"Print the credentials of a service key as statements that set env variables in a shell"`
 	DeleteServiceKey                   DeleteServiceKeyCommand                   `command:"delete-service-key" alias:"dsk" description:"Delete a service key"`
+	Tunnel                             TunnelCommand                             `command:"tunnel" description:"Forward a local port to a service instance through an SSH tunnel"`
 	BindService                        BindServiceCommand                        `command:"bind-service" alias:"bs" description:"Bind a service instance to an app"`
 	UnbindService                      UnbindServiceCommand                      `command:"unbind-service" alias:"us" description:"Unbind a service instance from an app"`
 	BindRouteService                   BindRouteServiceCommand                   `command:"bind-route-service" alias:"brs" description:"Bind a service instance to an HTTP route"`
+	RouteServices                      RouteServicesCommand                      `command:"route-services" description:"List the routes in the target space that are bound to a route service"`
 	UnbindRouteService                 UnbindRouteServiceCommand                 `command:"unbind-route-service" alias:"urs" description:"Unbind a service instance from an HTTP route"`
 	CreateUserProvidedService          CreateUserProvidedServiceCommand          `command:"create-user-provided-service" alias:"cups" description:"Make a user-provided service instance available to CF apps"`
 	UpdateUserProvidedService          UpdateUserProvidedServiceCommand          `command:"update-user-provided-service" alias:"uups" description:"Update user-provided service instance"`
@@ -82,6 +122,9 @@ type commandList struct {
 	UnmapRoute                         UnmapRouteCommand                         `command:"unmap-route" description:"Remove a url route from an app"`
 	DeleteRoute                        DeleteRouteCommand                        `command:"delete-route" description:"Delete a route"`
 	DeleteOrphanedRoutes               DeleteOrphanedRoutesCommand               `command:"delete-orphaned-routes" description:"Delete all orphaned routes (i.e. those that are not mapped to an app)"`
+	AddNetworkPolicy                   AddNetworkPolicyCommand                   `command:"add-network-policy" description:"Allow direct network traffic from one app to another"`
+	NetworkPolicies                    NetworkPoliciesCommand                    `command:"network-policies" description:"List the network policies of the apps in the target space"`
+	RemoveNetworkPolicy                RemoveNetworkPolicyCommand                `command:"remove-network-policy" description:"Stop allowing direct network traffic from one app to another"`
 	Buildpacks                         BuildpacksCommand                         `command:"buildpacks" description:"List all buildpacks"`
 	CreateBuildpack                    CreateBuildpackCommand                    `command:"create-buildpack" description:"Create a buildpack"`
 	UpdateBuildpack                    UpdateBuildpackCommand                    `command:"update-buildpack" description:"Update a buildpack"`
@@ -95,8 +138,10 @@ type commandList struct {
 	SpaceUsers                         SpaceUsersCommand                         `command:"space-users" description:"Show space users by role"`
 	SetSpaceRole                       SetSpaceRoleCommand                       `command:"set-space-role" description:"Assign a space role to a user"`
 	UnsetSpaceRole                     UnsetSpaceRoleCommand                     `command:"unset-space-role" description:"Remove a space role from a user"`
-	Quotas                             QuotasCommand                             `command:"quotas" description:"List available usage quotas"`
+	SetRoles                           SetRolesCommand                           `command:"set-roles" description:"Assign org and space roles to several users"`
+	Quotas                             QuotasCommand                             `command:"quotas" alias:"org-quotas" description:"List available usage quotas"`
 	Quota                              QuotaCommand                              `command:"quota" description:"Show quota info"`
+	QuotaUsage                         QuotaUsageCommand                         `command:"quota-usage" description:"Show how much of the limits of its quota an org uses"`
 	SetQuota                           SetQuotaCommand                           `command:"set-quota" description:"Assign a quota to an org"`
 	CreateQuota                        CreateQuotaCommand                        `command:"create-quota" description:"Define a new resource quota"`
 	DeleteQuota                        DeleteQuotaCommand                        `command:"delete-quota" description:"Delete a quota"`
@@ -119,6 +164,7 @@ type commandList struct {
 	UpdateServiceBroker                UpdateServiceBrokerCommand                `command:"update-service-broker" description:"Update a service broker"`
 	DeleteServiceBroker                DeleteServiceBrokerCommand                `command:"delete-service-broker" description:"Delete a service broker"`
 	RenameServiceBroker                RenameServiceBrokerCommand                `command:"rename-service-broker" description:"Rename a service broker"`
+	BrokerCatalogDiff                  BrokerCatalogDiffCommand                  `command:"broker-catalog-diff" description:"Show how the catalog of a service broker differs from its registered services and plans, and what updating the broker would change about service access"`
 	MigrateServiceInstances            MigrateServiceInstancesCommand            `command:"migrate-service-instances" description:"Migrate service instances from one service plan to another"`
 	PurgeServiceOffering               PurgeServiceOfferingCommand               `command:"purge-service-offering" description:"Recursively remove a service and child objects from Cloud Foundry database without making requests to a service broker"`
 	PurgeServiceInstance               PurgeServiceInstanceCommand               `command:"purge-service-instance" description:"Recursively remove a service instance and child objects from Cloud Foundry database without making requests to a service broker"`
@@ -142,12 +188,16 @@ type commandList struct {
 	StagingEnvironmentVariableGroup    StagingEnvironmentVariableGroupCommand    `command:"staging-environment-variable-group" alias:"sevg" description:"Retrieve the contents of the staging environment variable group"`
 	SetStagingEnvironmentVariableGroup SetStagingEnvironmentVariableGroupCommand `command:"set-staging-environment-variable-group" alias:"ssevg" description:"Pass parameters as JSON to create a staging environment variable group"`
 	SetRunningEnvironmentVariableGroup SetRunningEnvironmentVariableGroupCommand `command:"set-running-environment-variable-group" alias:"srevg" description:"Pass parameters as JSON to create a running environment variable group"`
+	Labels                             LabelsCommand                             `command:"labels" description:"List all labels (key-value pairs) for a resource"`
+	SetLabel                           SetLabelCommand                           `command:"set-label" description:"Set a label (key-value pairs) for a resource"`
+	UnsetLabel                         UnsetLabelCommand                         `command:"unset-label" description:"Unset a label (key-value pairs) for a resource"`
 	FeatureFlags                       FeatureFlagsCommand                       `command:"feature-flags" description:"Retrieve list of feature flags with status of each flag-able feature"`
 	FeatureFlag                        FeatureFlagCommand                        `command:"feature-flag" description:"Retrieve an individual feature flag with status"`
 	EnableFeatureFlag                  EnableFeatureFlagCommand                  `command:"enable-feature-flag" description:"Enable the use of a feature so that users have access to and can use the feature"`
 	DisableFeatureFlag                 DisableFeatureFlagCommand                 `command:"disable-feature-flag" description:"Disable the use of a feature so that users have access to and can use the feature"`
 	Curl                               CurlCommand                               `command:"curl" description:"Executes a request to the targeted API endpoint"`
 	Config                             ConfigCommand                             `command:"config" description:"Write default values to the config"`
+	Completion                         CompletionCommand                         `command:"completion" description:"Print a script that completes commands, flags and the names of apps, orgs and spaces in a shell"`
 	OauthToken                         OauthTokenCommand                         `command:"oauth-token" description:"Retrieve and display the OAuth token for the current session"`
 	SSHCode                            SSHCodeCommand                            `command:"ssh-code" description:"Get a one time password for ssh clients"`
 	AddPluginRepo                      AddPluginRepoCommand                      `command:"add-plugin-repo" description:"Add a new plugin repository"`
@@ -157,4 +207,5 @@ type commandList struct {
 	Plugins                            PluginsCommand                            `command:"plugins" description:"List all available plugin commands"`
 	InstallPlugin                      InstallPluginCommand                      `command:"install-plugin" description:"Install CLI plugin"`
 	UninstallPlugin                    UninstallPluginCommand                    `command:"uninstall-plugin" description:"Uninstall the plugin defined in command argument"`
+	UpdatePlugins                      UpdatePluginsCommand                      `command:"update-plugins" description:"Update installed plugins to the newest versions in the plugin repositories"`
 }
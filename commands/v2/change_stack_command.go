@@ -0,0 +1,25 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type ChangeStackCommand struct {
+	RequiredArgs    flags.ChangeStackArgs `positional-args:"yes"`
+	MaxInFlight     int                   `long:"max-in-flight" description:"Number of instances the rolling deployment replaces at the same time (Default: 1)"`
+	usage           interface{}           `usage:"CF_NAME change-stack APP_NAME NEW_STACK [--max-in-flight NUM]\n\nThe app keeps running on its current stack while it stages on the new one. If staging fails, the app is moved back to its current stack.\n\nEXAMPLES:\n   CF_NAME change-stack my-app cflinuxfs4\n   CF_NAME change-stack my-app cflinuxfs4 --max-in-flight 2"`
+	relatedCommands interface{}           `related_commands:"stacks, app, restage"`
+}
+
+func (_ ChangeStackCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ ChangeStackCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
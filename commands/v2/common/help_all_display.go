@@ -6,19 +6,24 @@ var HelpCategoryList = []HelpCategory{
 		CommandList: [][]string{
 			{"help", "version", "login", "logout", "passwd", "target"},
 			{"api", "auth"},
+			{"target-save", "target-use"},
 		},
 	},
 	{
 		CategoryName: "APPS:",
 		CommandList: [][]string{
 			{"apps", "app"},
-			{"push", "scale", "delete", "rename"},
-			{"start", "stop", "restart", "restage", "restart-app-instance"},
-			{"events", "files", "logs"},
+			{"push", "zero-downtime-push", "scale", "delete", "rename"},
+			{"start", "stop", "restart", "restage", "restart-app-instance", "rollback", "revisions", "download-droplet", "set-droplet"},
+			{"deployments", "cancel-deployment", "continue-deployment"},
+			{"packages", "create-package", "stage-package", "builds", "create-build", "logs-build"},
+			{"run-task", "tasks", "terminate-task", "sidecars"},
+			{"events", "app-history", "crash-info", "files", "logs", "app-metrics"},
 			{"env", "set-env", "unset-env"},
-			{"stacks", "stack"},
-			{"copy-source", "create-app-manifest"},
-			{"get-health-check", "set-health-check", "enable-ssh", "disable-ssh", "ssh-enabled", "ssh"},
+			{"stacks", "stack", "change-stack"},
+			{"copy-source", "create-app-manifest", "validate-manifest", "migrate-manifest", "apply-manifest"},
+			{"get-health-check", "set-health-check", "enable-ssh", "disable-ssh", "ssh-enabled", "ssh", "scp"},
+			{"app-features", "enable-app-feature", "disable-app-feature"},
 		},
 	},
 	{
@@ -26,9 +31,9 @@ var HelpCategoryList = []HelpCategory{
 		CommandList: [][]string{
 			{"marketplace", "services", "service"},
 			{"create-service", "update-service", "delete-service", "rename-service"},
-			{"create-service-key", "service-keys", "service-key", "delete-service-key"},
+			{"create-service-key", "service-keys", "service-key", "service-key-env", "delete-service-key", "tunnel"},
 			{"bind-service", "unbind-service"},
-			{"bind-route-service", "unbind-route-service"},
+			{"bind-route-service", "unbind-route-service", "route-services"},
 			{"create-user-provided-service", "update-user-provided-service"},
 		},
 	},
@@ -60,6 +65,12 @@ var HelpCategoryList = []HelpCategory{
 			{"routes", "create-route", "check-route", "map-route", "unmap-route", "delete-route", "delete-orphaned-routes"},
 		},
 	},
+	{
+		CategoryName: "NETWORK POLICIES:",
+		CommandList: [][]string{
+			{"network-policies", "add-network-policy", "remove-network-policy"},
+		},
+	},
 	{
 		CategoryName: "BUILDPACKS:",
 		CommandList: [][]string{
@@ -72,12 +83,13 @@ var HelpCategoryList = []HelpCategory{
 			{"create-user", "delete-user"},
 			{"org-users", "set-org-role", "unset-org-role"},
 			{"space-users", "set-space-role", "unset-space-role"},
+			{"set-roles"},
 		},
 	},
 	{
 		CategoryName: "ORG ADMIN:",
 		CommandList: [][]string{
-			{"quotas", "quota", "set-quota"},
+			{"quotas", "quota", "quota-usage", "set-quota"},
 			{"create-quota", "delete-quota", "update-quota"},
 			{"share-private-domain", "unshare-private-domain"},
 		},
@@ -94,7 +106,7 @@ var HelpCategoryList = []HelpCategory{
 		CategoryName: "SERVICE ADMIN:",
 		CommandList: [][]string{
 			{"service-auth-tokens", "create-service-auth-token", "update-service-auth-token", "delete-service-auth-token"},
-			{"service-brokers", "create-service-broker", "update-service-broker", "delete-service-broker", "rename-service-broker"},
+			{"service-brokers", "create-service-broker", "update-service-broker", "delete-service-broker", "rename-service-broker", "broker-catalog-diff"},
 			{"migrate-service-instances", "purge-service-offering", "purge-service-instance"},
 			{"service-access", "enable-service-access", "disable-service-access"},
 		},
@@ -113,6 +125,12 @@ var HelpCategoryList = []HelpCategory{
 			{"running-environment-variable-group", "staging-environment-variable-group", "set-staging-environment-variable-group", "set-running-environment-variable-group"},
 		},
 	},
+	{
+		CategoryName: "METADATA:",
+		CommandList: [][]string{
+			{"labels", "set-label", "unset-label"},
+		},
+	},
 	{
 		CategoryName: "FEATURE FLAGS:",
 		CommandList: [][]string{
@@ -122,7 +140,7 @@ var HelpCategoryList = []HelpCategory{
 	{
 		CategoryName: "ADVANCED:",
 		CommandList: [][]string{
-			{"curl", "config", "oauth-token", "ssh-code"},
+			{"curl", "config", "completion", "oauth-token", "ssh-code"},
 		},
 	},
 	{
@@ -134,7 +152,7 @@ var HelpCategoryList = []HelpCategory{
 	{
 		CategoryName: "ADD/REMOVE PLUGIN:",
 		CommandList: [][]string{
-			{"plugins", "install-plugin", "uninstall-plugin"},
+			{"plugins", "install-plugin", "uninstall-plugin", "update-plugins"},
 		},
 	},
 }
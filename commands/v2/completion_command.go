@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type CompletionCommand struct {
+	RequiredArgs    flags.Shell `positional-args:"yes"`
+	usage           interface{} `usage:"CF_NAME completion SHELL\n\n   Prints the completion script for SHELL, which is bash, zsh, fish or powershell. The names of apps, orgs and spaces are looked up with the API of the target and kept for a few minutes.\n\n   To complete in every new shell:\n      bash:       add 'source <(CF_NAME completion bash)' to ~/.bashrc\n      zsh:        add 'source <(CF_NAME completion zsh)' to ~/.zshrc, after compinit\n      fish:       run 'CF_NAME completion fish > ~/.config/fish/completions/CF_NAME.fish'\n      PowerShell: add 'CF_NAME completion powershell | Out-String | Invoke-Expression' to $PROFILE\n\nEXAMPLES:\n   CF_NAME completion bash > /etc/bash_completion.d/CF_NAME"`
+	relatedCommands interface{} `related_commands:"config, help"`
+}
+
+func (_ CompletionCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ CompletionCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
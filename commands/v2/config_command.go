@@ -8,11 +8,15 @@ import (
 )
 
 type ConfigCommand struct {
-	AsyncTimeout int         `long:"async-timeout" description:"Timeout for async HTTP requests"`
-	Color        string      `long:"color" description:"Enable or disable color"`
-	Locale       string      `long:"locale" description:"Set default locale. If LOCALE is 'CLEAR', previous locale is deleted."`
-	Trace        string      `long:"trace" description:"Trace HTTP requests"`
-	usage        interface{} `usage:"CF_NAME config [--async-timeout TIMEOUT_IN_MINUTES] [--trace (true | false | path/to/file)] [--color (true | false)] [--locale (LOCALE | CLEAR)]"`
+	AsyncTimeout       int         `long:"async-timeout" description:"Timeout for async HTTP requests"`
+	Color              string      `long:"color" description:"Enable or disable color"`
+	CredentialStore    string      `long:"credential-store" description:"Where to keep tokens: the config file (plaintext), the macOS Keychain (keychain), the Windows Credential Manager (wincred) or the Secret Service (libsecret). Tokens stay in the config file if the store is unavailable."`
+	KeepAlive          int         `long:"keep-alive" description:"Time in seconds to keep idle connections to the API open for reuse (Default: 90)"`
+	Locale             string      `long:"locale" description:"Set default locale. If LOCALE is 'CLEAR', previous locale is deleted."`
+	MaxIdleConnections int         `long:"max-idle-connections" description:"Number of idle connections to keep open to each API host (Default: 10)"`
+	PluginSandbox      string      `long:"plugin-sandbox" description:"Run plugins with a config of their own and a token derived from yours, which cannot manage users or passwords and is removed from that config when the plugin exits"`
+	Trace              string      `long:"trace" description:"Trace HTTP requests"`
+	usage              interface{} `usage:"CF_NAME config [--async-timeout TIMEOUT_IN_MINUTES] [--keep-alive TIMEOUT_IN_SECONDS] [--max-idle-connections NUM_CONNECTIONS]\n   [--trace (true | false | path/to/file)] [--color (true | false)] [--locale (LOCALE | CLEAR)]\n   [--credential-store (plaintext | keychain | wincred | libsecret)] [--plugin-sandbox (true | false)]"`
 }
 
 func (_ ConfigCommand) Setup(config commands.Config, ui commands.UI) error {
@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type ContinueDeploymentCommand struct {
+	RequiredArgs    flags.AppName `positional-args:"yes"`
+	usage           interface{}   `usage:"CF_NAME continue-deployment APP_NAME"`
+	relatedCommands interface{}   `related_commands:"cancel-deployment, deployments, push"`
+}
+
+func (_ ContinueDeploymentCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ ContinueDeploymentCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -13,8 +13,10 @@ type CopySourceCommand struct {
 	NoRestart           bool                 `long:"no-restart" description:"Override restart of the application in target environment after copy-source completes"`
 	Organization        string               `short:"o" description:"Org that contains the target application"`
 	Space               string               `short:"s" description:"Space that contains the target application"`
-	usage               interface{}          `usage:"CF_NAME copy-source SOURCE_APP TARGET_APP [-s TARGET_SPACE [-o TARGET_ORG]] [--no-restart]"`
-	relatedCommands     interface{}          `related_commands:"apps, push, restart, target"`
+	TargetContext       string               `long:"target-context" description:"Context saved with target-save that the target application is in, such as another foundation"`
+	CopyEnv             bool                 `long:"copy-env" description:"Copy the environment variables of the source application over those of the target application"`
+	usage               interface{}          `usage:"CF_NAME copy-source SOURCE_APP TARGET_APP [-s TARGET_SPACE [-o TARGET_ORG]] [--target-context NAME] [--copy-env] [--no-restart]\n\n   The services bound to the source application are listed after the copy, since they are never copied.\n\nEXAMPLES:\n   CF_NAME copy-source my-app my-app-copy -o other-org -s staging --copy-env\n   CF_NAME copy-source my-app my-app --target-context prod --no-restart"`
+	relatedCommands     interface{}          `related_commands:"apps, push, restart, target, target-save"`
 	envCFStagingTimeout interface{}          `environmentName:"CF_STAGING_TIMEOUT" environmentDescription:"Max wait time for buildpack staging, in minutes" environmentDefault:"15"`
 	envCFStartupTimeout interface{}          `environmentName:"CF_STARTUP_TIMEOUT" environmentDescription:"Max wait time for app instance startup, in minutes" environmentDefault:"5"`
 }
@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type CreateBuildCommand struct {
+	RequiredArgs    flags.CreateBuildArgs `positional-args:"yes"`
+	usage           interface{}           `usage:"CF_NAME create-build PACKAGE_GUID\n\n   Use logs-build to follow staging and set-droplet to run an app on the droplet staged.\n\nEXAMPLES:\n   CF_NAME create-build 3b4c5d6e-7f80-4a21-9b2c-5d6e7f8a9b10"`
+	relatedCommands interface{}           `related_commands:"builds, logs-build, packages, set-droplet"`
+}
+
+func (_ CreateBuildCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ CreateBuildCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -12,7 +12,8 @@ type CreateBuildpackCommand struct {
 	RequiredArgs    flags.CreateBuildpackArgs `positional-args:"yes"`
 	Disable         bool                      `long:"disable" description:"Disable the buildpack from being used for staging"`
 	Enable          bool                      `long:"enable" description:"Enable the buildpack to be used for staging"`
-	usage           interface{}               `usage:"CF_NAME create-buildpack BUILDPACK PATH POSITION [--enable|--disable]\n\nTIP:\n   Path should be a zip file, a url to a zip file, or a local directory. Position is a positive integer, sets priority, and is sorted from lowest to highest."`
+	Stack           string                    `long:"stack" description:"Stack the buildpack is for, so that buildpacks for other stacks can have the same name"`
+	usage           interface{}               `usage:"CF_NAME create-buildpack BUILDPACK PATH [POSITION] [--enable|--disable] [--stack STACK]\n\nTIP:\n   Path should be a zip file, a url to a zip file, or a local directory. Position is a positive integer, sets priority, and is sorted from lowest to highest. Without a position the buildpack is checked after all existing buildpacks.\n\nEXAMPLES:\n   CF_NAME create-buildpack java-buildpack-offline ./java-buildpack-offline.zip\n   CF_NAME create-buildpack ruby_buildpack ./ruby_buildpack-windows.zip 3 --stack windows2012R2"`
 	relatedCommands interface{}               `related_commands:"buildpacks, push"`
 }
 
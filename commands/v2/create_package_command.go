@@ -0,0 +1,25 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type CreatePackageCommand struct {
+	RequiredArgs    flags.AppName `positional-args:"yes"`
+	AppPath         string        `short:"p" long:"path" description:"Path to app directory or to a zip file of the contents of the app directory (Default: the current directory)"`
+	usage           interface{}   `usage:"CF_NAME create-package APP_NAME [-p PATH]\n\n   Use stage-package to stage the new package into a droplet.\n\nEXAMPLES:\n   CF_NAME create-package my-app\n   CF_NAME create-package my-app -p build/my-app.zip"`
+	relatedCommands interface{}   `related_commands:"packages, push, stage-package"`
+}
+
+func (_ CreatePackageCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ CreatePackageCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -9,13 +9,13 @@ import (
 )
 
 type CreateRouteCommand struct {
-	RequiredArgs    flags.SpaceDomain `positional-args:"yes"`
-	Hostname        string            `long:"hostname" short:"n" description:"Hostname for the HTTP route (required for shared domains)"`
-	Path            string            `long:"path" description:"Path for the HTTP route"`
-	Port            int               `long:"port" description:"Port for the TCP route"`
-	RandomPort      bool              `long:"random-port" description:"Create a random port for the TCP route"`
-	usage           interface{}       `usage:"Create an HTTP route:\n      CF_NAME create-route SPACE DOMAIN [--hostname HOSTNAME] [--path PATH]\n\n   Create a TCP route:\n      CF_NAME create-route SPACE DOMAIN (--port PORT | --random-port)\n\nEXAMPLES:\n   CF_NAME create-route my-space example.com                             # example.com\n   CF_NAME create-route my-space example.com --hostname myapp            # myapp.example.com\n   CF_NAME create-route my-space example.com --hostname myapp --path foo # myapp.example.com/foo\n   CF_NAME create-route my-space example.com --port 5000                 # example.com:5000"`
-	relatedCommands interface{}       `related_commands:"check-route, domains, map-route"`
+	RequiredArgs    flags.CreateRouteArgs `positional-args:"yes"`
+	Hostname        string                `long:"hostname" short:"n" description:"Hostname for the HTTP route (required for shared domains)"`
+	Path            string                `long:"path" description:"Path for the HTTP route"`
+	Port            int                   `long:"port" description:"Port for the TCP route"`
+	RandomPort      bool                  `long:"random-port" description:"Create a random port for the TCP route"`
+	usage           interface{}           `usage:"Create an HTTP route:\n      CF_NAME create-route SPACE DOMAIN [--hostname HOSTNAME] [--path PATH]\n\n   Create a TCP route:\n      CF_NAME create-route SPACE DOMAIN (--port PORT | --random-port)\n\n   Without SPACE, the route is created in the targeted space.\n\nEXAMPLES:\n   CF_NAME create-route my-space example.com                             # example.com\n   CF_NAME create-route my-space example.com --hostname myapp            # myapp.example.com\n   CF_NAME create-route my-space example.com --hostname myapp --path foo # myapp.example.com/foo\n   CF_NAME create-route my-space example.com --port 5000                 # example.com:5000\n   CF_NAME create-route tcp.example.com --port 60001                     # tcp.example.com:60001 in the targeted space"`
+	relatedCommands interface{}           `related_commands:"check-route, domains, map-route"`
 }
 
 func (_ CreateRouteCommand) Setup(config commands.Config, ui commands.UI) error {
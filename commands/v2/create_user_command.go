@@ -9,8 +9,10 @@ import (
 )
 
 type CreateUserCommand struct {
-	RequiredArgs    flags.Authentication `positional-args:"yes"`
-	usage           interface{}          `usage:"CF_NAME create-user USERNAME PASSWORD"`
+	RequiredArgs    flags.CreateUserArgs `positional-args:"yes"`
+	Origin          string               `long:"origin" description:"Origin of the user's identity provider, such as ldap (default: uaa)"`
+	ExternalID      string               `long:"external-id" description:"ID of the user in the identity provider of a non-uaa origin, such as an LDAP DN (default: USERNAME)"`
+	usage           interface{}          `usage:"CF_NAME create-user USERNAME [PASSWORD]\n   CF_NAME create-user USERNAME --origin ORIGIN [--external-id EXTERNAL_ID]\n\n   You are prompted for the password of a uaa user when PASSWORD is left out. Users from other origins sign in with their identity provider and have no password.\n\nEXAMPLES:\n   CF_NAME create-user j.smith@example.com S3cr3t\n   CF_NAME create-user j.smith --origin ldap --external-id \"uid=j.smith,ou=people,dc=example,dc=com\""`
 	relatedCommands interface{}          `related_commands:"passwd, set-org-role, set-space-role"`
 }
 
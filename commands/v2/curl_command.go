@@ -15,7 +15,9 @@ type CurlCommand struct {
 	HTTPData              string        `short:"d" description:"HTTP data to include in the request body, or '@' followed by a file name to read the data from"`
 	IncludeReponseHeaders bool          `short:"i" description:"Include response headers in the output"`
 	OutputFile            string        `long:"output" description:"Write curl body to FILE instead of stdout"`
-	usage                 interface{}   `usage:"CF_NAME curl PATH [-iv] [-X METHOD] [-H HEADER] [-d DATA] [--output FILE]\n\n   By default 'CF_NAME curl' will perform a GET to the specified PATH. If data\n   is provided via -d, a POST will be performed instead, and the Content-Type\n   will be set to application/json. You may override headers with -H and the\n   request method with -X.\n\n   For API documentation, please visit http://apidocs.cloudfoundry.org.\n\nEXAMPLES:\n   CF_NAME curl \"/v2/apps\" -X GET -H \"Content-Type: application/x-www-form-urlencoded\" -d 'q=name:myapp'\n   CF_NAME curl \"/v2/apps\" -d @/path/to/file"`
+	Fail                  bool          `long:"fail" description:"Exit with an error when the response status is not 2xx"`
+	Paginate              bool          `long:"paginate" description:"Follow the next page links of a paginated GET and print the resources of all pages as one JSON array"`
+	usage                 interface{}   `usage:"CF_NAME curl PATH [-iv] [-X METHOD] [-H HEADER]... [-d DATA] [--output FILE] [--fail] [--paginate]\n\n   By default 'CF_NAME curl' will perform a GET to the specified PATH. If data\n   is provided via -d, a POST will be performed instead, and the Content-Type\n   will be set to application/json. You may override headers with -H and the\n   request method with -X.\n\n   With --paginate, the next page links of the response are followed and the\n   resources of every page are printed as a single JSON array.\n\n   For API documentation, please visit http://apidocs.cloudfoundry.org.\n\nEXAMPLES:\n   CF_NAME curl \"/v2/apps\" -X GET -H \"Content-Type: application/x-www-form-urlencoded\" -d 'q=name:myapp'\n   CF_NAME curl \"/v2/apps\" -d @/path/to/file\n   CF_NAME curl \"/v2/apps?results-per-page=100\" --paginate --fail"`
 }
 
 func (_ CurlCommand) Setup(config commands.Config, ui commands.UI) error {
@@ -11,7 +11,8 @@ import (
 type DeleteBuildpackCommand struct {
 	RequiredArgs    flags.Buildpack `positional-args:"yes"`
 	Force           bool            `short:"f" description:"Force deletion without confirmation"`
-	usage           interface{}     `usage:"CF_NAME delete-buildpack BUILDPACK [-f]"`
+	Stack           string          `long:"stack" description:"Stack of the buildpack, to pick it from buildpacks with the same name"`
+	usage           interface{}     `usage:"CF_NAME delete-buildpack BUILDPACK [-f] [--stack STACK]"`
 	relatedCommands interface{}     `related_commands:"buildpacks"`
 }
 
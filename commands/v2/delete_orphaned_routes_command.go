@@ -9,7 +9,8 @@ import (
 
 type DeleteOrphanedRoutesCommand struct {
 	Force           bool        `short:"f" description:"Force deletion without confirmation"`
-	usage           interface{} `usage:"CF_NAME delete-orphaned-routes [-f]"`
+	DryRun          bool        `long:"dry-run" description:"List the orphaned routes that would be deleted without deleting them"`
+	usage           interface{} `usage:"CF_NAME delete-orphaned-routes [-f] [--dry-run]\n\nEXAMPLES:\n   CF_NAME delete-orphaned-routes --dry-run\n   CF_NAME delete-orphaned-routes -f"`
 	relatedCommands interface{} `related_commands:"delete-route, routes"`
 }
 
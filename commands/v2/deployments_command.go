@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type DeploymentsCommand struct {
+	RequiredArgs    flags.AppName `positional-args:"yes"`
+	usage           interface{}   `usage:"CF_NAME deployments APP_NAME"`
+	relatedCommands interface{}   `related_commands:"app, cancel-deployment, continue-deployment, restart"`
+}
+
+func (_ DeploymentsCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ DeploymentsCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -0,0 +1,26 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type DownloadDropletCommand struct {
+	RequiredArgs    flags.AppName `positional-args:"yes"`
+	Droplet         string        `long:"droplet" description:"Guid of the droplet to download (Default: the droplet the app is running on)"`
+	Output          string        `short:"o" description:"Path of the file to save the droplet to (Default: droplet_DROPLET_GUID.tgz in the current directory)"`
+	usage           interface{}   `usage:"CF_NAME download-droplet APP_NAME [--droplet DROPLET_GUID] [-o PATH]\n\nEXAMPLES:\n   CF_NAME download-droplet my-app -o my-app.tgz\n   CF_NAME download-droplet my-app --droplet 7f8a9b2c-5d6e-4f10-8a21-3b4c5d6e7f80"`
+	relatedCommands interface{}   `related_commands:"set-droplet, rollback"`
+}
+
+func (_ DownloadDropletCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ DownloadDropletCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
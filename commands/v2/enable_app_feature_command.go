@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type EnableAppFeatureCommand struct {
+	RequiredArgs    flags.AppFeatureArgs `positional-args:"yes"`
+	usage           interface{}          `usage:"CF_NAME enable-app-feature APP_NAME FEATURE_NAME\n\n   Features: ssh, revisions. Use app-features to see which features are enabled.\n\nEXAMPLES:\n   CF_NAME enable-app-feature my-app ssh\n   CF_NAME enable-app-feature my-app revisions"`
+	relatedCommands interface{}          `related_commands:"app-features, disable-app-feature"`
+}
+
+func (_ EnableAppFeatureCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ EnableAppFeatureCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
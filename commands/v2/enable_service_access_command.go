@@ -11,8 +11,9 @@ import (
 type EnableServiceAccessCommand struct {
 	RequiredArgs    flags.Service `positional-args:"yes"`
 	Organization    string        `short:"o" description:"Enable access for a specified organization"`
-	ServicePlan     string        `short:"p" description:"Enable access to a specified service plan"`
-	usage           interface{}   `usage:"CF_NAME enable-service-access SERVICE [-p PLAN] [-o ORG]"`
+	OrgFile         string        `long:"org-file" description:"Enable access for the organizations listed in a file, one per line"`
+	ServicePlans    []string      `short:"p" description:"Enable access to a specified service plan, or the plans matching a glob pattern. This flag can be defined more than once."`
+	usage           interface{}   `usage:"CF_NAME enable-service-access SERVICE [-p PLAN]... [-o ORG] [--org-file FILE]\n\n   With several plans, a plan pattern such as 'gold-*' or --org-file, the plans and orgs are updated concurrently and the result of each update is listed.\n\nEXAMPLES:\n   CF_NAME enable-service-access mysql -p small -p medium\n   CF_NAME enable-service-access mysql -p 'gold-*' --org-file orgs.txt"`
 	relatedCommands interface{}   `related_commands:"marketplace, service-access, service-brokers"`
 }
 
@@ -10,7 +10,8 @@ import (
 
 type EnvCommand struct {
 	RequiredArgs    flags.AppName `positional-args:"yes"`
-	usage           interface{}   `usage:"CF_NAME env APP_NAME"`
+	Diff            string        `long:"diff" description:"Show the env variables of the app in this manifest that are not set on the app, or are set to another value"`
+	usage           interface{}   `usage:"CF_NAME env APP_NAME [--diff MANIFEST_PATH]\n\nEXAMPLES:\n   CF_NAME env my-app --output json\n   CF_NAME env my-app --diff manifest.yml"`
 	relatedCommands interface{}   `related_commands:"app, apps, set-env, unset-env, running-environment-variable-group, staging-environment-variable-group"`
 }
 
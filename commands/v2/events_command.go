@@ -9,8 +9,12 @@ import (
 )
 
 type EventsCommand struct {
-	RequiredArgs flags.AppName `positional-args:"yes"`
-	usage        interface{}   `usage:"CF_NAME events APP_NAME"`
+	OptionalArgs flags.OptionalAppName `positional-args:"yes"`
+	Follow       bool                  `long:"follow" short:"f" description:"Keep checking for new events every 5 seconds until interrupted"`
+	Type         string                `long:"type" description:"Only show events of a comma-separated list of types, e.g. audit.app.update,audit.app.restage"`
+	AllInSpace   bool                  `long:"all-in-space" description:"Show the events of everything in the targeted space"`
+	AllInOrg     bool                  `long:"all-in-org" description:"Show the events of everything in the targeted org"`
+	usage        interface{}           `usage:"CF_NAME events APP_NAME [--follow] [--type TYPES]\n   CF_NAME events --all-in-space|--all-in-org [--follow] [--type TYPES]\n\nEXAMPLES:\n   CF_NAME events my-app --follow\n   CF_NAME events --all-in-space --type audit.app.create,audit.app.delete-request"`
 }
 
 func (_ EventsCommand) Setup(config commands.Config, ui commands.UI) error {
@@ -10,7 +10,7 @@ import (
 
 type FeatureFlagCommand struct {
 	RequiredArgs    flags.Feature `positional-args:"yes"`
-	usage           interface{}   `usage:"CF_NAME feature-flag FEATURE_NAME"`
+	usage           interface{}   `usage:"CF_NAME feature-flag FEATURE_NAME\n\nEXAMPLES:\n   CF_NAME feature-flag route_creation\n   CF_NAME feature-flag route_creation --output json"`
 	relatedCommands interface{}   `related_commands:"disable-feature-flag, enable-feature-flag, feature-flags"`
 }
 
@@ -8,7 +8,7 @@ import (
 )
 
 type FeatureFlagsCommand struct {
-	usage           interface{} `usage:"CF_NAME feature-flags"`
+	usage           interface{} `usage:"CF_NAME feature-flags\n\nEXAMPLES:\n   CF_NAME feature-flags\n   CF_NAME feature-flags --output json"`
 	relatedCommands interface{} `related_commands:"disable-feature-flag, enable-feature-flag"`
 }
 
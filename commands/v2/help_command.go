@@ -170,6 +170,42 @@ func (cmd HelpCommand) displayCommonCommands() {
 			"ENVName":     "-v",
 			"Description": "Print API request diagnostics to stdout",
 		})
+	cmd.UI.DisplayTextWithKeyTranslations(prefix+"{{.ENVName}}                    {{.Description}}",
+		[]string{"Description"},
+		map[string]interface{}{
+			"ENVName":     "--output FORMAT",
+			"Description": "Print the data of list and show commands as table, json or yaml",
+		})
+	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}}        {{.Description}}",
+		[]string{"Description"},
+		map[string]interface{}{
+			"ENVName":     "--trace[=path/to/trace.log]",
+			"Description": "Print API request diagnostics for this command to stdout, or append them to a log file",
+		})
+	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}}                     {{.Description}}",
+		[]string{"Description"},
+		map[string]interface{}{
+			"ENVName":     "--context NAME",
+			"Description": "Run the command against a context saved with target-save, leaving the current target alone",
+		})
+	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}}                          {{.Description}}",
+		[]string{"Description"},
+		map[string]interface{}{
+			"ENVName":     "--org ORG",
+			"Description": "Run the command in this org, leaving the targeted org alone",
+		})
+	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}}                      {{.Description}}",
+		[]string{"Description"},
+		map[string]interface{}{
+			"ENVName":     "--space SPACE",
+			"Description": "Run the command in this space, leaving the targeted space alone",
+		})
+	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}}                  {{.Description}}",
+		[]string{"Description"},
+		map[string]interface{}{
+			"ENVName":     "--non-interactive",
+			"Description": "Fail instead of prompting for input or confirmation",
+		})
 	cmd.UI.DisplayNewline()
 	cmd.UI.DisplayText("'cf help -a' lists all commands with short descriptions. See 'cf help <command>' to read about a specific command.")
 }
@@ -216,6 +252,12 @@ func (cmd HelpCommand) displayHelpFooter() {
 			"ENVName":     "CF_COLOR=false",
 			"Description": "Do not colorize output",
 		})
+	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}}                    {{.Description}}",
+		[]string{"Description"},
+		map[string]interface{}{
+			"ENVName":     "CF_CONTEXT=prod",
+			"Description": "Run commands against a context saved with target-save",
+		})
 	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}}                  {{.Description}}",
 		[]string{"Description"},
 		map[string]interface{}{
@@ -228,6 +270,12 @@ func (cmd HelpCommand) displayHelpFooter() {
 			"ENVName":     "CF_HOME=path/to/dir/",
 			"Description": "Override path to default config directory",
 		})
+	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}}            {{.Description}}",
+		[]string{"Description"},
+		map[string]interface{}{
+			"ENVName":     "CF_NON_INTERACTIVE=true",
+			"Description": "Fail instead of prompting for input or confirmation",
+		})
 	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}}        {{.Description}}",
 		[]string{"Description"},
 		map[string]interface{}{
@@ -244,7 +292,7 @@ func (cmd HelpCommand) displayHelpFooter() {
 		[]string{"Description"},
 		map[string]interface{}{
 			"ENVName":     "CF_TRACE=path/to/trace.log",
-			"Description": "Append API request diagnostics to a log file",
+			"Description": "Append API request diagnostics to a log file, rotated at 10MB",
 		})
 	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}} {{.Description}}",
 		[]string{"Description"},
@@ -267,6 +315,42 @@ func (cmd HelpCommand) displayHelpFooter() {
 			"ENVName":     "-v",
 			"Description": "Print API request diagnostics to stdout",
 		})
+	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}}                    {{.Description}}",
+		[]string{"Description"},
+		map[string]interface{}{
+			"ENVName":     "--output FORMAT",
+			"Description": "Print the data of list and show commands as table, json or yaml",
+		})
+	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}}        {{.Description}}",
+		[]string{"Description"},
+		map[string]interface{}{
+			"ENVName":     "--trace[=path/to/trace.log]",
+			"Description": "Print API request diagnostics for this command to stdout, or append them to a log file",
+		})
+	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}}                     {{.Description}}",
+		[]string{"Description"},
+		map[string]interface{}{
+			"ENVName":     "--context NAME",
+			"Description": "Run the command against a context saved with target-save, leaving the current target alone",
+		})
+	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}}                          {{.Description}}",
+		[]string{"Description"},
+		map[string]interface{}{
+			"ENVName":     "--org ORG",
+			"Description": "Run the command in this org, leaving the targeted org alone",
+		})
+	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}}                      {{.Description}}",
+		[]string{"Description"},
+		map[string]interface{}{
+			"ENVName":     "--space SPACE",
+			"Description": "Run the command in this space, leaving the targeted space alone",
+		})
+	cmd.UI.DisplayTextWithKeyTranslations("   {{.ENVName}}                  {{.Description}}",
+		[]string{"Description"},
+		map[string]interface{}{
+			"ENVName":     "--non-interactive",
+			"Description": "Fail instead of prompting for input or confirmation",
+		})
 }
 
 func (cmd HelpCommand) displayCommand() error {
@@ -12,7 +12,9 @@ type InstallPluginCommand struct {
 	OptionalArgs         flags.InstallPluginArgs `positional-args:"yes"`
 	Force                bool                    `short:"f" description:"Force install of plugin without confirmation"`
 	RegisteredRepository string                  `short:"r" description:"Name of a registered repository where the specified plugin is located"`
-	usage                interface{}             `usage:"CF_NAME install-plugin (LOCAL-PATH/TO/PLUGIN | URL | -r REPO_NAME PLUGIN_NAME) [-f]\n\n   Prompts for confirmation unless '-f' is provided.\n\nEXAMPLES:\n   CF_NAME install-plugin ~/Downloads/plugin-foobar\n   CF_NAME install-plugin https://example.com/plugin-foobar_linux_amd64\n   CF_NAME install-plugin -r My-Repo plugin-echo"`
+	Version              string                  `long:"version" description:"Version of the plugin to install from the repository, instead of the newest one"`
+	SkipChecksum         bool                    `long:"skip-checksum" description:"Install the plugin from the repository even if its SHA256 checksum cannot be verified"`
+	usage                interface{}             `usage:"CF_NAME install-plugin (LOCAL-PATH/TO/PLUGIN | URL | -r REPO_NAME PLUGIN_NAME [--version VERSION] [--skip-checksum]) [-f]\n\n   Prompts for confirmation unless '-f' is provided.\n\n   Plugins installed from a repository are checked against the SHA256 checksum the repository gives for them, unless '--skip-checksum' is provided.\n\nEXAMPLES:\n   CF_NAME install-plugin ~/Downloads/plugin-foobar\n   CF_NAME install-plugin https://example.com/plugin-foobar_linux_amd64\n   CF_NAME install-plugin -r My-Repo plugin-echo\n   CF_NAME install-plugin -r My-Repo plugin-echo --version 1.0.2"`
 	relatedCommands      interface{}             `related_commands:"add-plugin-repo, list-plugin-repos, plugins"`
 }
 
@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type LabelsCommand struct {
+	RequiredArgs    flags.LabelsArgs `positional-args:"yes"`
+	usage           interface{}      `usage:"CF_NAME labels RESOURCE_TYPE RESOURCE_NAME\n\nRESOURCE_TYPES:\n   app, buildpack, org, service-instance, space, stack\n\nEXAMPLES:\n   CF_NAME labels app dora\n   CF_NAME labels space my-space"`
+	relatedCommands interface{}      `related_commands:"set-label, unset-label"`
+}
+
+func (_ LabelsCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ LabelsCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type LogsBuildCommand struct {
+	RequiredArgs    flags.LogsBuildArgs `positional-args:"yes"`
+	usage           interface{}         `usage:"CF_NAME logs-build BUILD_GUID\n\nEXAMPLES:\n   CF_NAME logs-build 8d2e1f3a-5b6c-4d7e-9f80-1a2b3c4d5e6f"`
+	relatedCommands interface{}         `related_commands:"builds, create-build, logs"`
+}
+
+func (_ LogsBuildCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ LogsBuildCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -11,7 +11,14 @@ import (
 type LogsCommand struct {
 	RequiredArgs    flags.AppName `positional-args:"yes"`
 	Recent          bool          `long:"recent" description:"Dump recent logs instead of tailing"`
-	usage           interface{}   `usage:"CF_NAME logs APP_NAME"`
+	Filter          string        `long:"filter" description:"Only show log messages matching a regular expression"`
+	SourceType      string        `long:"source-type" description:"Only show log messages from a source type: app, rtr or stg"`
+	Instance        int           `long:"instance" description:"Only show log messages from an app instance index"`
+	JSON            bool          `long:"json" description:"Print each log message as a JSON object"`
+	Since           string        `long:"since" description:"Only show recent log messages since a duration ago (e.g. 1h, 30m) or a time (e.g. 2016-11-02T15:04:05Z)"`
+	Until           string        `long:"until" description:"Only show recent log messages until a duration ago (e.g. 10m) or a time (e.g. 2016-11-02T16:04:05Z)"`
+	Lines           int           `long:"lines" description:"Only show the last number of recent log messages"`
+	usage           interface{}   `usage:"CF_NAME logs APP_NAME [--recent [--since DURATION|TIME] [--until DURATION|TIME] [--lines NUMBER]] [--filter REGEX] [--source-type app|rtr|stg] [--instance INDEX] [--json]\n\nEXAMPLES:\n   CF_NAME logs my-app --filter 'ERROR|WARN'\n   CF_NAME logs my-app --recent --source-type rtr --json\n   CF_NAME logs my-app --recent --since 1h --until 30m --lines 100"`
 	relatedCommands interface{}   `related_commands:"app, apps, ssh"`
 }
 
@@ -8,8 +8,10 @@ import (
 )
 
 type MarketplaceCommand struct {
-	ServicePlanInfo string      `short:"s" description:"Show plan details for a particular service offering"`
-	usage           interface{} `usage:"CF_NAME marketplace [-s SERVICE]"`
+	ServicePlanInfo string      `short:"s" long:"service" description:"Show plan details for a particular service offering"`
+	Broker          string      `long:"broker" description:"Only show the service offerings of this service broker"`
+	Plan            string      `long:"plan" description:"Only show the service plans with this name"`
+	usage           interface{} `usage:"CF_NAME marketplace [-s SERVICE] [--broker BROKER] [--plan PLAN]\n\nEXAMPLES:\n   CF_NAME marketplace -s p-mysql --plan 100mb\n   CF_NAME marketplace --broker my-broker --output json"`
 	relatedCommands interface{} `related_commands:"create-service, services"`
 }
 
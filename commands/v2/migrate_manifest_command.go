@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+)
+
+type MigrateManifestCommand struct {
+	OutputPath      string      `short:"o" description:"Path to write the migrated manifest to"`
+	Domain          string      `short:"d" description:"Domain for the routes of apps that have hosts but no domain, usually the default shared domain"`
+	usage           interface{} `usage:"CF_NAME migrate-manifest MANIFEST_PATH -o NEW_MANIFEST_PATH [-d DOMAIN]\n\n   The host, hosts, domain, domains and no-hostname attributes of each app are replaced with the routes they stand for, and the manifests it inherits from are merged into it. Relative app paths are rewritten to point at the same directories from the new manifest.\n\nEXAMPLES:\n   CF_NAME migrate-manifest manifest.yml -o manifest.yml\n   CF_NAME migrate-manifest old.yml -o new.yml -d apps.example.com"`
+	relatedCommands interface{} `related_commands:"validate-manifest, push"`
+}
+
+func (_ MigrateManifestCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ MigrateManifestCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
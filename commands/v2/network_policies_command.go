@@ -0,0 +1,23 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+)
+
+type NetworkPoliciesCommand struct {
+	Source          string      `long:"source" description:"Only list the policies of this source app"`
+	usage           interface{} `usage:"CF_NAME network-policies [--source SOURCE_APP]"`
+	relatedCommands interface{} `related_commands:"add-network-policy, apps, remove-network-policy"`
+}
+
+func (_ NetworkPoliciesCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ NetworkPoliciesCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
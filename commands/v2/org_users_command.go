@@ -11,7 +11,10 @@ import (
 type OrgUsersCommand struct {
 	RequiredArgs    flags.Organization `positional-args:"yes"`
 	AllUsers        bool               `short:"a" description:"List all users in the org"`
-	usage           interface{}        `usage:"CF_NAME org-users ORG"`
+	Details         bool               `long:"details" description:"Show the origin and GUID of each user, looked up in UAA"`
+	Role            string             `long:"role" description:"Only list the users with this role, such as OrgManager, or SpaceDeveloper with --all-spaces"`
+	AllSpaces       bool               `long:"all-spaces" description:"List the space roles of the users in every space of the org"`
+	usage           interface{}        `usage:"CF_NAME org-users ORG [-a | --role ROLE] [--details] [--all-spaces]\n\nEXAMPLES:\n   CF_NAME org-users my-org --details\n   CF_NAME org-users my-org --all-spaces --role SpaceDeveloper\n   CF_NAME org-users my-org --output json"`
 	relatedCommands interface{}        `related_commands:"orgs"`
 }
 
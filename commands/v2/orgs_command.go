@@ -8,7 +8,8 @@ import (
 )
 
 type OrgsCommand struct {
-	usage interface{} `usage:"CF_NAME orgs"`
+	MaxResults int         `long:"max-results" description:"Stop after listing this many orgs"`
+	usage      interface{} `usage:"CF_NAME orgs [--max-results NUM]"`
 }
 
 func (_ OrgsCommand) Setup(config commands.Config, ui commands.UI) error {
@@ -9,8 +9,9 @@ import (
 
 type PluginsCommand struct {
 	Checksum        bool        `long:"checksum" description:"Compute and show the sha1 value of the plugin binary file"`
-	usage           interface{} `usage:"CF_NAME plugins [--checksum]"`
-	relatedCommands interface{} `related_commands:"install-plugin, repo-plugins, uninstall-plugin"`
+	Outdated        bool        `long:"outdated" description:"Search the plugin repositories for newer versions of the installed plugins"`
+	usage           interface{} `usage:"CF_NAME plugins [--checksum | --outdated]"`
+	relatedCommands interface{} `related_commands:"install-plugin, repo-plugins, uninstall-plugin, update-plugins"`
 }
 
 func (_ PluginsCommand) Setup(config commands.Config, ui commands.UI) error {
@@ -8,30 +8,54 @@ import (
 )
 
 type PushCommand struct {
-	AppPorts             string      `long:"app-ports" description:"Comma delimited list of ports the application may listen on" hidden:"true"` //TODO: Custom AppPorts flag
-	BuildpackName        string      `short:"b" description:"Custom buildpack by name (e.g. my-buildpack) or Git URL (e.g. 'https://github.com/cloudfoundry/java-buildpack.git') or Git URL with a branch or tag (e.g. 'https://github.com/cloudfoundry/java-buildpack.git#v3.3.0' for 'v3.3.0' tag). To use built-in buildpacks only, specify 'default' or 'null'"`
-	StartupCommand       string      `short:"c" description:"Startup command, set to null to reset to default start command"`
-	Domain               string      `short:"d" description:"Domain (e.g. example.com)"`
-	DockerImage          string      `long:"docker-image" short:"o" description:"Docker-image to be used (e.g. user/docker-image-name)"`
-	PathToManifest       string      `short:"f" description:"Path to manifest"` //TODO: Custom Path flag that does validation
-	HealthCheckType      string      `long:"health-check-type" short:"u" description:"Application health check type (e.g. 'port' or 'none')"`
-	Hostname             string      `long:"hostname" short:"n" description:"Hostname (e.g. my-subdomain)"`
-	NumInstances         string      `short:"i" description:"Number of instances"`
-	DiskLimit            string      `short:"k" description:"Disk limit (e.g. 256M, 1024M, 1G)"`
-	MemoryLimit          string      `short:"m" description:"Memory limit (e.g. 256M, 1024M, 1G)"`
-	NoHostname           bool        `long:"no-hostname" description:"Map the root domain to this app"`
-	NoManifest           bool        `long:"no-manifest" description:"Ignore manifest file"`
-	NoRoute              bool        `long:"no-route" description:"Do not map a route to this app and remove routes from previous pushes of this app"`
-	NoStart              bool        `long:"no-start" description:"Do not start an app after pushing"`
-	DirectoryPath        string      `short:"p" description:"Path to app directory or to a zip file of the contents of the app directory"` //TODO: Custom Directory flag that does validation
-	RandomRoute          bool        `long:"random-route" description:"Create a random route for this app"`
-	RoutePath            string      `long:"route-path" description:"Path for the route"`
-	Stack                string      `short:"s" description:"Stack to use (a stack is a pre-built file system, including an operating system, that can run apps)"`
-	ApplicationStartTime int         `short:"t" description:"Maximum time (in seconds) for CLI to wait for application start, other server side timeouts may apply"`
-	usage                interface{} `usage:"Push a single app (with or without a manifest):\n   CF_NAME push APP_NAME [-b BUILDPACK_NAME] [-c COMMAND] [-d DOMAIN] [-f MANIFEST_PATH] [--docker-image DOCKER_IMAGE]\n   [-i NUM_INSTANCES] [-k DISK] [-m MEMORY] [--hostname HOST] [-p PATH] [-s STACK] [-t TIMEOUT] [-u HEALTH_CHECK_TYPE] [--route-path ROUTE_PATH]\n   [--no-hostname] [--no-manifest] [--no-route] [--no-start] [--random-route]\n\n   Push multiple apps with a manifest:\n   cf push [-f MANIFEST_PATH]"`
-	envCFStagingTimeout  interface{} `environmentName:"CF_STAGING_TIMEOUT" environmentDescription:"Max wait time for buildpack staging, in minutes" environmentDefault:"15"`
-	envCFStartupTimeout  interface{} `environmentName:"CF_STARTUP_TIMEOUT" environmentDescription:"Max wait time for app instance startup, in minutes" environmentDefault:"5"`
-	relatedCommands      interface{} `related_commands:"apps, create-app-manifest, logs, ssh, start"`
+	AppPorts                         string      `long:"app-ports" description:"Comma delimited list of ports the application may listen on" hidden:"true"` //TODO: Custom AppPorts flag
+	BuildpackName                    []string    `short:"b" description:"Custom buildpack by name (e.g. my-buildpack) or Git URL (e.g. 'https://github.com/cloudfoundry/java-buildpack.git') or Git URL with a branch or tag (e.g. 'https://github.com/cloudfoundry/java-buildpack.git#v3.3.0' for 'v3.3.0' tag). To use built-in buildpacks only, specify 'default' or 'null'. Specify multiple times to use multiple buildpacks, the last one being the final buildpack"`
+	StartupCommand                   string      `short:"c" description:"Startup command, set to null to reset to default start command"`
+	Domain                           string      `short:"d" description:"Domain (e.g. example.com)"`
+	DockerImage                      string      `long:"docker-image" short:"o" description:"Docker-image to be used (e.g. user/docker-image-name)"`
+	DockerUsername                   string      `long:"docker-username" description:"Repository username; used with password from environment variable CF_DOCKER_PASSWORD"`
+	DryRun                           bool        `long:"dry-run" description:"Show the files, routes, environment variables and services the push would change, without changing anything"`
+	PathToManifest                   string      `short:"f" description:"Path to manifest"` //TODO: Custom Path flag that does validation
+	HealthCheckType                  string      `long:"health-check-type" short:"u" description:"Application health check type: 'port', 'process', 'http' or 'none'"`
+	HealthCheckHTTPEndpoint          string      `long:"health-check-http-endpoint" description:"Path the 'http' health check of the web process requests (e.g. '/health')"`
+	HealthCheckInvocationTimeout     int         `long:"health-check-invocation-timeout" description:"Time (in seconds) each health check of the web process may take before it fails"`
+	ReadinessHealthCheckType         string      `long:"readiness-health-check-type" description:"Readiness check type of the web process, which decides whether its instances get traffic: 'port', 'process' or 'http'"`
+	ReadinessHealthCheckHTTPEndpoint string      `long:"readiness-health-check-http-endpoint" description:"Path the 'http' readiness check of the web process requests (e.g. '/ready')"`
+	ReadinessHealthCheckInterval     int         `long:"readiness-health-check-interval" description:"Time (in seconds) between readiness checks of the web process"`
+	InstanceSteps                    string      `long:"instance-steps" description:"Comma separated percentages of instances a canary deployment replaces, pausing after each step (e.g. '1,10,50,100')"`
+	MaxInFlight                      int         `long:"max-in-flight" description:"Number of instances a deployment replaces at the same time (Default: 1)"`
+	Hostname                         string      `long:"hostname" short:"n" description:"Hostname (e.g. my-subdomain)"`
+	NumInstances                     string      `short:"i" description:"Number of instances"`
+	DiskLimit                        string      `short:"k" description:"Disk limit (e.g. 256M, 1024M, 1G)"`
+	MemoryLimit                      string      `short:"m" description:"Memory limit (e.g. 256M, 1024M, 1G)"`
+	NoHashCache                      bool        `long:"no-hash-cache" description:"Hash every app file again instead of reusing the digests of files that have not changed since the last push"`
+	NoHostname                       bool        `long:"no-hostname" description:"Map the root domain to this app"`
+	Lenient                          bool        `long:"lenient" description:"Warn about manifest attributes that push does not know instead of failing"`
+	NoManifest                       bool        `long:"no-manifest" description:"Ignore manifest file"`
+	NoRoute                          bool        `long:"no-route" description:"Do not map a route to this app and remove routes from previous pushes of this app"`
+	NoStart                          bool        `long:"no-start" description:"Do not start an app after pushing"`
+	Parallel                         int         `long:"parallel" description:"Number of apps from the manifest to create, upload and bind at the same time. The apps are still started one at a time"`
+	Output                           string      `long:"output" description:"Output format: 'text' (the default) or 'json', which prints one JSON event per line instead of the usual output"`
+	DirectoryPath                    string      `short:"p" description:"Path to app directory or to a zip file of the contents of the app directory"` //TODO: Custom Directory flag that does validation
+	PostPushScript                   string      `long:"post-push-script" description:"Command to run on this machine in the app directory once the app has been pushed and started (e.g. './smoke-test.sh')"`
+	PrePushScript                    string      `long:"pre-push-script" description:"Command to run on this machine in the app directory before the app is created or updated (e.g. 'npm run build'); the push stops if it fails"`
+	PreserveSymlinks                 bool        `long:"preserve-symlinks" description:"Upload symbolic links in the app directory as links instead of leaving them out. Links must point to a file or directory inside the app directory"`
+	RandomRoute                      bool        `long:"random-route" description:"Create a random route for this app"`
+	RandomRouteStrategy              string      `long:"random-route-strategy" description:"How to generate the hostname of a random route: 'word' (adjective-noun, the default), 'uuid' or 'timestamp'"`
+	Retries                          int         `long:"retries" description:"Number of times to retry a request, such as an upload, when the API is temporarily unavailable (Default: 3)"`
+	RoutePath                        string      `long:"route-path" description:"Path for the route"`
+	Lifecycle                        string      `long:"lifecycle" description:"Lifecycle to stage the app with: 'buildpack' (the default) or 'cnb' for Cloud Native Buildpacks. Credentials for private buildpack registries are read as JSON from environment variable CNB_CREDENTIALS (e.g. '{\"registry.example.com\": {\"username\": \"user\", \"password\": \"pass\"}}')"`
+	Strategy                         string      `long:"strategy" description:"Deployment strategy for an app that is already running: 'rolling' replaces its instances a few at a time, 'canary' replaces a few first and pauses until the deployment is continued"`
+	Wait                             bool        `long:"wait" description:"Continue a canary deployment after each step without asking"`
+	StagingTimeout                   int         `long:"staging-timeout" description:"Maximum time (in seconds) for CLI to wait for staging to make progress; the wait starts again whenever staging logs a line"`
+	StartupTimeout                   int         `long:"startup-timeout" description:"Maximum time (in seconds) for CLI to wait for an instance to run once staging has finished, without changing the health check timeout of the app"`
+	Stack                            string      `short:"s" long:"stack" description:"Stack to use (a stack is a pre-built file system, including an operating system, that can run apps)"`
+	ApplicationStartTime             int         `short:"t" description:"Maximum time (in seconds) for CLI to wait for application start, other server side timeouts may apply"`
+	usage                            interface{} `usage:"Push a single app (with or without a manifest):\n   CF_NAME push APP_NAME [-b BUILDPACK_NAME] [-c COMMAND] [-d DOMAIN] [-f MANIFEST_PATH] [--docker-image DOCKER_IMAGE] [--docker-username USERNAME]\n   [-i NUM_INSTANCES] [-k DISK] [-m MEMORY] [--hostname HOST] [-p PATH] [-s STACK] [-t TIMEOUT] [-u HEALTH_CHECK_TYPE] [--route-path ROUTE_PATH] [--lifecycle LIFECYCLE]\n   [--health-check-http-endpoint ENDPOINT] [--health-check-invocation-timeout TIMEOUT]\n   [--readiness-health-check-type READINESS_HEALTH_CHECK_TYPE] [--readiness-health-check-http-endpoint ENDPOINT] [--readiness-health-check-interval INTERVAL]\n   [--strategy STRATEGY] [--max-in-flight NUM] [--instance-steps PERCENTAGES] [--wait]\n   [--no-hostname] [--no-manifest] [--lenient] [--no-route] [--no-start] [--random-route] [--dry-run]\n   [--preserve-symlinks] [--no-hash-cache] [--parallel NUM_APPS] [--random-route-strategy STRATEGY] [--staging-timeout TIMEOUT] [--startup-timeout TIMEOUT] [--retries NUM_RETRIES] [--output FORMAT]\n   [--pre-push-script COMMAND] [--post-push-script COMMAND]\n\n   Push multiple apps with a manifest:\n   cf push [-f MANIFEST_PATH] [--lenient]"`
+	envCFDockerPassword              interface{} `environmentName:"CF_DOCKER_PASSWORD" environmentDescription:"Password used for private docker repository"`
+	envCFStagingTimeout              interface{} `environmentName:"CF_STAGING_TIMEOUT" environmentDescription:"Max wait time for buildpack staging to make progress, in minutes" environmentDefault:"15"`
+	envCFStartupTimeout              interface{} `environmentName:"CF_STARTUP_TIMEOUT" environmentDescription:"Max wait time for app instance startup, in minutes" environmentDefault:"5"`
+	relatedCommands                  interface{} `related_commands:"apps, create-app-manifest, logs, ssh, start"`
 }
 
 func (_ PushCommand) Setup(config commands.Config, ui commands.UI) error {
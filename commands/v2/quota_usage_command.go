@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type QuotaUsageCommand struct {
+	RequiredArgs    flags.Organization `positional-args:"yes"`
+	usage           interface{}        `usage:"CF_NAME quota-usage ORG"`
+	relatedCommands interface{}        `related_commands:"org, quota, set-quota"`
+}
+
+func (_ QuotaUsageCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ QuotaUsageCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
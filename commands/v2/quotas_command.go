@@ -8,7 +8,8 @@ import (
 )
 
 type QuotasCommand struct {
-	usage interface{} `usage:"CF_NAME quotas"`
+	Fields string      `long:"fields" description:"Comma separated list of the columns to show, named in lower case with underscores for spaces, such as name,total_memory"`
+	usage  interface{} `usage:"CF_NAME quotas [--fields FIELDS]\n\nEXAMPLES:\n   CF_NAME quotas --fields name,total_memory,app_instances\n   CF_NAME org-quotas --output json"`
 }
 
 func (_ QuotasCommand) Setup(config commands.Config, ui commands.UI) error {
@@ -0,0 +1,26 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type RemoveNetworkPolicyCommand struct {
+	RequiredArgs    flags.NetworkPolicyArgs `positional-args:"yes"`
+	Port            string                  `long:"port" description:"Port or range of ports of the policy, such as 8080 or 8080-8090 (Default: 8080)"`
+	Protocol        string                  `long:"protocol" description:"Protocol of the policy: tcp or udp (Default: tcp)"`
+	usage           interface{}             `usage:"CF_NAME remove-network-policy SOURCE_APP DESTINATION_APP [--port PORT] [--protocol (tcp | udp)]\n\nEXAMPLES:\n   CF_NAME remove-network-policy frontend backend\n   CF_NAME remove-network-policy frontend backend --port 9000-9010 --protocol udp"`
+	relatedCommands interface{}             `related_commands:"apps, network-policies"`
+}
+
+func (_ RemoveNetworkPolicyCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ RemoveNetworkPolicyCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
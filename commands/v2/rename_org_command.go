@@ -10,7 +10,8 @@ import (
 
 type RenameOrgCommand struct {
 	RequiredArgs flags.RenameOrgArgs `positional-args:"yes"`
-	usage        interface{}         `usage:"CF_NAME rename-org ORG NEW_ORG"`
+	Path         string              `long:"path" short:"p" description:"Path to the app directory or manifest to check for references to the old name (Default: current directory)"`
+	usage        interface{}         `usage:"CF_NAME rename-org ORG NEW_ORG [-p APP_PATH]"`
 }
 
 func (_ RenameOrgCommand) Setup(config commands.Config, ui commands.UI) error {
@@ -10,7 +10,8 @@ import (
 
 type RenameSpaceCommand struct {
 	RequiredArgs flags.RenameSpaceArgs `positional-args:"yes"`
-	usage        interface{}           `usage:"CF_NAME rename-space SPACE NEW_SPACE"`
+	Path         string                `long:"path" short:"p" description:"Path to the app directory or manifest to check for references to the old name (Default: current directory)"`
+	usage        interface{}           `usage:"CF_NAME rename-space SPACE NEW_SPACE [-p APP_PATH]"`
 }
 
 func (_ RenameSpaceCommand) Setup(config commands.Config, ui commands.UI) error {
@@ -9,7 +9,8 @@ import (
 
 type RepoPluginsCommand struct {
 	RegisteredRepository string      `short:"r" description:"Name of a registered repository"`
-	usage                interface{} `usage:"CF_NAME repo-plugins [-r REPO_NAME]\n\nEXAMPLES:\n   CF_NAME repo-plugins -r PrivateRepo"`
+	Search               string      `long:"search" description:"Only list the plugins whose name contains the given text"`
+	usage                interface{} `usage:"CF_NAME repo-plugins [-r REPO_NAME] [--search NAME]\n\nEXAMPLES:\n   CF_NAME repo-plugins -r PrivateRepo\n   CF_NAME repo-plugins --search echo"`
 	relatedCommands      interface{} `related_commands:"add-plugin-repo, delete-plugin-repo, install-plugin"`
 }
 
@@ -10,7 +10,9 @@ import (
 
 type RestageCommand struct {
 	RequiredArgs        flags.AppName `positional-args:"yes"`
-	usage               interface{}   `usage:"CF_NAME restage APP_NAME"`
+	Strategy            string        `long:"strategy" description:"Deployment strategy: 'rolling' replaces the instances of the app a few at a time, so it keeps serving traffic"`
+	MaxInFlight         int           `long:"max-in-flight" description:"Number of instances a rolling deployment replaces at the same time (Default: 1)"`
+	usage               interface{}   `usage:"CF_NAME restage APP_NAME [--strategy rolling [--max-in-flight NUM]]\n\nEXAMPLES:\n   CF_NAME restage my-app\n   CF_NAME restage my-app --strategy rolling --max-in-flight 2"`
 	relatedCommands     interface{}   `related_commands:"restart"`
 	envCFStagingTimeout interface{}   `environmentName:"CF_STAGING_TIMEOUT" environmentDescription:"Max wait time for buildpack staging, in minutes" environmentDefault:"15"`
 	envCFStartupTimeout interface{}   `environmentName:"CF_STARTUP_TIMEOUT" environmentDescription:"Max wait time for app instance startup, in minutes" environmentDefault:"5"`
@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type RevisionsCommand struct {
+	RequiredArgs    flags.AppName `positional-args:"yes"`
+	usage           interface{}   `usage:"CF_NAME revisions APP_NAME\n\n   Revisions are only recorded for apps with the revisions app feature enabled. Use rollback --revision to deploy an earlier revision."`
+	relatedCommands interface{}   `related_commands:"enable-app-feature, rollback"`
+}
+
+func (_ RevisionsCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ RevisionsCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -0,0 +1,27 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type RollbackCommand struct {
+	RequiredArgs    flags.AppName `positional-args:"yes"`
+	Droplet         string        `long:"droplet" description:"Guid of the droplet to roll back to"`
+	Revision        int           `long:"revision" description:"Number of the revision to roll back to, as listed by revisions"`
+	Force           bool          `short:"f" description:"Roll back to the previous droplet without asking"`
+	usage           interface{}   `usage:"CF_NAME rollback APP_NAME [--droplet DROPLET_GUID | --revision REVISION] [-f]\n\n   Rolling back to a revision also restores the environment variables the app had then, and replaces its instances a few at a time.\n\nEXAMPLES:\n   CF_NAME rollback my-app\n   CF_NAME rollback my-app -f\n   CF_NAME rollback my-app --droplet 7f8a9b2c-5d6e-4f10-8a21-3b4c5d6e7f80\n   CF_NAME rollback my-app --revision 3"`
+	relatedCommands interface{}   `related_commands:"app, push, restart, revisions"`
+}
+
+func (_ RollbackCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ RollbackCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -0,0 +1,22 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+)
+
+type RouteServicesCommand struct {
+	usage           interface{} `usage:"CF_NAME route-services"`
+	relatedCommands interface{} `related_commands:"bind-route-service, routes, unbind-route-service"`
+}
+
+func (_ RouteServicesCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ RouteServicesCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -8,8 +8,9 @@ import (
 )
 
 type RoutesCommand struct {
+	MaxResults      int         `long:"max-results" description:"Stop after listing this many routes"`
 	OrgLevel        bool        `long:"orglevel" description:"List all the routes for all spaces of current organization"`
-	usage           interface{} `usage:"CF_NAME routes [--orglevel]"`
+	usage           interface{} `usage:"CF_NAME routes [--orglevel] [--max-results NUM]"`
 	relatedCommands interface{} `related_commands:"check-route, domains, map-route, unmap-route"`
 }
 
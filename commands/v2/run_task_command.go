@@ -0,0 +1,27 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type RunTaskCommand struct {
+	RequiredArgs    flags.RunTaskArgs `positional-args:"yes"`
+	Name            string            `long:"name" description:"Name to give the task (generated if omitted)"`
+	Memory          string            `short:"m" long:"memory" description:"Memory limit (e.g. 256M, 1024M, 1G)"`
+	Disk            string            `short:"k" long:"disk" description:"Disk limit (e.g. 256M, 1024M, 1G)"`
+	usage           interface{}       `usage:"CF_NAME run-task APP_NAME COMMAND [--name TASK_NAME] [-m MEMORY] [-k DISK]\n\nEXAMPLES:\n   CF_NAME run-task my-app \"bundle exec rake db:migrate\" --name migrate\n   CF_NAME run-task my-app \"python report.py\" -m 512M -k 2G"`
+	relatedCommands interface{}       `related_commands:"logs, tasks, terminate-task"`
+}
+
+func (_ RunTaskCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ RunTaskCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -14,7 +14,8 @@ type ScaleCommand struct {
 	NumInstances    int           `short:"i" description:"Number of instances"`
 	DiskLimit       string        `short:"k" description:"Disk limit (e.g. 256M, 1024M, 1G)"`
 	MemoryLimit     string        `short:"m" description:"Memory limit (e.g. 256M, 1024M, 1G)"`
-	usage           interface{}   `usage:"CF_NAME scale APP_NAME [-i INSTANCES] [-k DISK] [-m MEMORY] [-f]"`
+	Process         string        `long:"process" description:"Process type to scale, such as worker, for apps with several process types"`
+	usage           interface{}   `usage:"CF_NAME scale APP_NAME [--process PROCESS_TYPE] [-i INSTANCES] [-k DISK] [-m MEMORY] [-f]\n\nEXAMPLES:\n   CF_NAME scale my-app -i 5\n   CF_NAME scale my-app --process worker -i 3 -m 256M"`
 	relatedCommands interface{}   `related_commands:"push"`
 }
 
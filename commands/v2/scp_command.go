@@ -0,0 +1,27 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type SCPCommand struct {
+	RequiredArgs       flags.SCPArgs `positional-args:"yes"`
+	AppInstanceIndex   int           `long:"app-instance-index" short:"i" description:"Application instance index"`
+	Recursive          bool          `long:"recursive" short:"r" description:"Copy directories and their contents"`
+	SkipHostValidation bool          `long:"skip-host-validation" short:"k" description:"Skip host key validation"`
+	usage              interface{}   `usage:"CF_NAME scp [-i app-instance-index] [-r] [--skip-host-validation] SOURCE TARGET\n\n   One of SOURCE and TARGET is a path in the app instance, written as APP_NAME:PATH. The other is a local path.\n\n   Files are copied with the scp protocol. For an interactive SFTP session, connect an SFTP client to the SSH endpoint with a one-time passcode from CF_NAME ssh-code.\n\nEXAMPLES:\n   CF_NAME scp my-app:/home/vcap/logs/app.log .\n   CF_NAME scp -i 1 config.yml my-app:/home/vcap/app/config.yml\n   CF_NAME scp -r my-app:/home/vcap/app/public ./public"`
+	relatedCommands    interface{}   `related_commands:"ssh, ssh-code"`
+}
+
+func (_ SCPCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ SCPCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -11,7 +11,7 @@ import (
 type ServiceKeyCommand struct {
 	RequiredArgs flags.ServiceInstanceKey `positional-args:"yes"`
 	GUID         bool                     `long:"guid" description:"Retrieve and display the given service-key's guid.  All other output for the service is suppressed."`
-	usage        interface{}              `usage:"CF_NAME service-key SERVICE_INSTANCE SERVICE_KEY\n\nEXAMPLES:\n   CF_NAME service-key mydb mykey"`
+	usage        interface{}              `usage:"CF_NAME service-key SERVICE_INSTANCE SERVICE_KEY\n\nEXAMPLES:\n   CF_NAME service-key mydb mykey\n   CF_NAME service-key mydb mykey --output json"`
 }
 
 func (_ ServiceKeyCommand) Setup(config commands.Config, ui commands.UI) error {
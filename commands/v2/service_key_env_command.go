@@ -0,0 +1,26 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type ServiceKeyEnvCommand struct {
+	RequiredArgs    flags.ServiceInstanceKey `positional-args:"yes"`
+	Shell           string                   `long:"shell" description:"Syntax of the statements: 'sh' for bash, zsh and other POSIX shells, or 'powershell' (Default: 'powershell' on Windows, 'sh' elsewhere)"`
+	Prefix          string                   `long:"prefix" description:"Prefix for the names of the env variables, e.g. MYDB_"`
+	usage           interface{}              `usage:"CF_NAME service-key-env SERVICE_INSTANCE SERVICE_KEY [--shell (sh | powershell)] [--prefix PREFIX]\n\n   Nested credentials are joined into one name with underscores, e.g. {\"db\": {\"host\": ...}} becomes DB_HOST. Lists are set as JSON.\n\nEXAMPLES:\n   eval \"$(CF_NAME service-key-env mydb mykey --prefix MYDB_)\"\n   CF_NAME service-key-env mydb mykey --shell powershell | Invoke-Expression"`
+	relatedCommands interface{}              `related_commands:"service-key, service-keys, create-service-key"`
+}
+
+func (_ ServiceKeyEnvCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ ServiceKeyEnvCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -8,8 +8,10 @@ import (
 )
 
 type ServicesCommand struct {
-	usage           interface{} `usage:"CF_NAME services"`
-	relatedCommands interface{} `related_commands:"create-service, marketplace"`
+	Filter          string      `long:"filter" description:"Only list the service instances whose last operation matches state=STATE, e.g. 'state=create failed' or 'state=in progress'"`
+	Labels          string      `long:"labels" description:"Only list the service instances whose labels match this selector, such as 'env=prod,tier!=web'"`
+	usage           interface{} `usage:"CF_NAME services [--filter state=STATE] [--labels SELECTOR]\n\nEXAMPLES:\n   CF_NAME services --filter 'state=create failed'\n   CF_NAME services --filter state=failed\n   CF_NAME services --labels env=prod"`
+	relatedCommands interface{} `related_commands:"create-service, labels, marketplace"`
 }
 
 func (_ ServicesCommand) Setup(config commands.Config, ui commands.UI) error {
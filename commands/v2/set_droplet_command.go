@@ -0,0 +1,26 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type SetDropletCommand struct {
+	RequiredArgs    flags.AppName `positional-args:"yes"`
+	DropletGUID     string        `long:"droplet-guid" description:"Guid of a staged droplet of the app"`
+	File            string        `long:"file" description:"Path of a droplet tarball to upload, such as one saved by download-droplet"`
+	usage           interface{}   `usage:"CF_NAME set-droplet APP_NAME (--droplet-guid DROPLET_GUID | --file PATH)\n\n   The app runs on the droplet from its next start; restart it to use the droplet right away.\n\nEXAMPLES:\n   CF_NAME set-droplet my-app --droplet-guid 7f8a9b2c-5d6e-4f10-8a21-3b4c5d6e7f80\n   CF_NAME set-droplet my-app --file my-app.tgz"`
+	relatedCommands interface{}   `related_commands:"download-droplet, restart, rollback"`
+}
+
+func (_ SetDropletCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ SetDropletCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
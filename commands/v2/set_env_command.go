@@ -13,7 +13,7 @@ const WorkAroundPrefix = "\U000026f3"
 
 type SetEnvCommand struct {
 	RequiredArgs    flags.SetEnvironmentArgs `positional-args:"yes"`
-	usage           interface{}              `usage:"CF_NAME set-env APP_NAME ENV_VAR_NAME ENV_VAR_VALUE"`
+	usage           interface{}              `usage:"CF_NAME set-env APP_NAME ENV_VAR_NAME ENV_VAR_VALUE\n   CF_NAME set-env APP_NAME --from-file PATH_TO_DOTENV_FILE\n   CF_NAME set-env APP_NAME --from-yaml PATH_TO_YAML_FILE\n\nEXAMPLES:\n   CF_NAME set-env my-app LOG_LEVEL debug\n   CF_NAME set-env my-app --from-file production.env\n   CF_NAME set-env my-app --from-yaml vars.yml   (a variable set to null in the file is removed from the app)"`
 	relatedCommands interface{}              `related_commands:"apps, env, restart, set-staging-environment-variable-group, set-running-environment-variable-group"`
 }
 
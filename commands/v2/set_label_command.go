@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type SetLabelCommand struct {
+	RequiredArgs    flags.SetLabelArgs `positional-args:"yes"`
+	usage           interface{}        `usage:"CF_NAME set-label RESOURCE_TYPE RESOURCE_NAME KEY=VALUE...\n\nRESOURCE_TYPES:\n   app, buildpack, org, service-instance, space, stack\n\nEXAMPLES:\n   CF_NAME set-label app dora env=production\n   CF_NAME set-label org business pci=true public-facing=false"`
+	relatedCommands interface{}        `related_commands:"labels, unset-label"`
+}
+
+func (_ SetLabelCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ SetLabelCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
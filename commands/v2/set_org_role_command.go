@@ -10,7 +10,8 @@ import (
 
 type SetOrgRoleCommand struct {
 	RequiredArgs    flags.SetOrgRoleArgs `positional-args:"yes"`
-	usage           interface{}          `usage:"CF_NAME set-org-role USERNAME ORG ROLE\n\nROLES:\n   'OrgManager' - Invite and manage users, select and change plans, and set spending limits\n   'BillingManager' - Create and manage the billing account and payment info\n   'OrgAuditor' - Read-only access to org info and reports"`
+	Client          bool                 `long:"client" description:"Treat USERNAME as the client ID of a (non-user) UAA client"`
+	usage           interface{}          `usage:"CF_NAME set-org-role USERNAME ORG ROLE [--client]\n\nROLES:\n   'OrgManager' - Invite and manage users, select and change plans, and set spending limits\n   'BillingManager' - Create and manage the billing account and payment info\n   'OrgAuditor' - Read-only access to org info and reports\n\nEXAMPLES:\n   CF_NAME set-org-role ci-deployer my-org OrgAuditor --client"`
 	relatedCommands interface{}          `related_commands:"org-users, set-space-role"`
 }
 
@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+)
+
+type SetRolesCommand struct {
+	File            string      `long:"file" description:"YAML or CSV file listing the role assignments"`
+	SkipExisting    bool        `long:"skip-existing" description:"Skip the assignments that users already have instead of assigning them again"`
+	usage           interface{} `usage:"CF_NAME set-roles --file FILE [--skip-existing]\n\n   The file is a YAML list of assignments with user, org, role and, for space roles, space keys, or a CSV file with the columns user,org,space,role. The result of each assignment is listed.\n\nEXAMPLES:\n   CF_NAME set-roles --file roles.yml\n   CF_NAME set-roles --file roles.csv --skip-existing"`
+	relatedCommands interface{} `related_commands:"org-users, set-org-role, set-space-role, space-users"`
+}
+
+func (_ SetRolesCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ SetRolesCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
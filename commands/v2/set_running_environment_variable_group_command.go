@@ -9,9 +9,10 @@ import (
 )
 
 type SetRunningEnvironmentVariableGroupCommand struct {
-	RequiredArgs    flags.ParamsAsJSON `positional-args:"yes"`
-	usage           interface{}        `usage:"CF_NAME set-running-environment-variable-group '{\"name\":\"value\",\"name\":\"value\"}'"`
-	relatedCommands interface{}        `related_commands:"set-env, running-environment-variable-group"`
+	RequiredArgs    flags.EnvironmentVariableGroupArgs `positional-args:"yes"`
+	FromFile        string                             `long:"from-file" description:"Read the variables from a dotenv file of NAME=VALUE lines instead of JSON"`
+	usage           interface{}                        `usage:"CF_NAME set-running-environment-variable-group '{\"name\":\"value\",\"name\":\"value\"}'\n   CF_NAME set-running-environment-variable-group PATH_TO_JSON_FILE\n   CF_NAME set-running-environment-variable-group --from-file PATH_TO_DOTENV_FILE\n\nEXAMPLES:\n   CF_NAME set-running-environment-variable-group '{\"LOG_LEVEL\":\"info\"}'\n   CF_NAME set-running-environment-variable-group running-env.json\n   CF_NAME set-running-environment-variable-group --from-file running.env"`
+	relatedCommands interface{}                        `related_commands:"set-env, running-environment-variable-group"`
 }
 
 func (_ SetRunningEnvironmentVariableGroupCommand) Setup(config commands.Config, ui commands.UI) error {
@@ -10,7 +10,8 @@ import (
 
 type SetSpaceRoleCommand struct {
 	RequiredArgs    flags.SetSpaceRoleArgs `positional-args:"yes"`
-	usage           interface{}            `usage:"CF_NAME set-space-role USERNAME ORG SPACE ROLE\n\nROLES:\n   'SpaceManager' - Invite and manage users, and enable features for a given space\n   'SpaceDeveloper' - Create and manage apps and services, and see logs and reports\n   'SpaceAuditor' - View logs, reports, and settings on this space"`
+	Client          bool                   `long:"client" description:"Treat USERNAME as the client ID of a (non-user) UAA client"`
+	usage           interface{}            `usage:"CF_NAME set-space-role USERNAME ORG SPACE ROLE [--client]\n\nROLES:\n   'SpaceManager' - Invite and manage users, and enable features for a given space\n   'SpaceDeveloper' - Create and manage apps and services, and see logs and reports\n   'SpaceAuditor' - View logs, reports, and settings on this space\n\nEXAMPLES:\n   CF_NAME set-space-role ci-deployer my-org my-space SpaceDeveloper --client"`
 	relatedCommands interface{}            `related_commands:"space-users"`
 }
 
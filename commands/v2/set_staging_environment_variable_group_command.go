@@ -9,9 +9,10 @@ import (
 )
 
 type SetStagingEnvironmentVariableGroupCommand struct {
-	RequiredArgs    flags.ParamsAsJSON `positional-args:"yes"`
-	usage           interface{}        `usage:"CF_NAME set-staging-environment-variable-group '{\"name\":\"value\",\"name\":\"value\"}'"`
-	relatedCommands interface{}        `related_commands:"set-env, staging-environment-variable-group"`
+	RequiredArgs    flags.EnvironmentVariableGroupArgs `positional-args:"yes"`
+	FromFile        string                             `long:"from-file" description:"Read the variables from a dotenv file of NAME=VALUE lines instead of JSON"`
+	usage           interface{}                        `usage:"CF_NAME set-staging-environment-variable-group '{\"name\":\"value\",\"name\":\"value\"}'\n   CF_NAME set-staging-environment-variable-group PATH_TO_JSON_FILE\n   CF_NAME set-staging-environment-variable-group --from-file PATH_TO_DOTENV_FILE\n\nEXAMPLES:\n   CF_NAME set-staging-environment-variable-group '{\"LOG_LEVEL\":\"info\"}'\n   CF_NAME set-staging-environment-variable-group staging-env.json\n   CF_NAME set-staging-environment-variable-group --from-file staging.env"`
+	relatedCommands interface{}                        `related_commands:"set-env, staging-environment-variable-group"`
 }
 
 func (_ SetStagingEnvironmentVariableGroupCommand) Setup(config commands.Config, ui commands.UI) error {
@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type SidecarsCommand struct {
+	RequiredArgs    flags.AppName `positional-args:"yes"`
+	usage           interface{}   `usage:"CF_NAME sidecars APP_NAME\n\n   Sidecars are defined in the 'sidecars' section of an app in the manifest and are created or updated by push:\n\n   applications:\n   - name: my-app\n     memory: 512M\n     sidecars:\n     - name: proxy\n       command: ./proxy\n       process_types: [web]\n       memory: 64M"`
+	relatedCommands interface{}   `related_commands:"app, push"`
+}
+
+func (_ SidecarsCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ SidecarsCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -8,7 +8,8 @@ import (
 )
 
 type SpaceQuotasCommand struct {
-	usage           interface{} `usage:"CF_NAME space-quotas"`
+	Fields          string      `long:"fields" description:"Comma separated list of the columns to show, named in lower case with underscores for spaces, such as name,total_memory"`
+	usage           interface{} `usage:"CF_NAME space-quotas [--fields FIELDS]\n\nEXAMPLES:\n   CF_NAME space-quotas --fields name,total_memory,app_instances\n   CF_NAME space-quotas --output json"`
 	relatedCommands interface{} `related_commands:"set-space-quota"`
 }
 
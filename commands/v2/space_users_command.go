@@ -10,7 +10,9 @@ import (
 
 type SpaceUsersCommand struct {
 	RequiredArgs    flags.OrgSpace `positional-args:"yes"`
-	usage           interface{}    `usage:"CF_NAME space-users ORG SPACE"`
+	Details         bool           `long:"details" description:"Show the origin and GUID of each user, looked up in UAA"`
+	Role            string         `long:"role" description:"Only list the users with this role, such as SpaceDeveloper"`
+	usage           interface{}    `usage:"CF_NAME space-users ORG SPACE [--role ROLE] [--details]\n\nEXAMPLES:\n   CF_NAME space-users my-org development --details\n   CF_NAME space-users my-org development --role SpaceDeveloper --output json"`
 	relatedCommands interface{}    `related_commands:"org-users, set-space-role, unset-space-role, orgs, spaces"`
 }
 
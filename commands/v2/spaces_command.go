@@ -8,7 +8,8 @@ import (
 )
 
 type SpacesCommand struct {
-	usage           interface{} `usage:"CF_NAME spaces"`
+	MaxResults      int         `long:"max-results" description:"Stop after listing this many spaces"`
+	usage           interface{} `usage:"CF_NAME spaces [--max-results NUM]"`
 	relatedCommands interface{} `related_commands:"target"`
 }
 
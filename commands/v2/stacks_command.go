@@ -8,7 +8,7 @@ import (
 )
 
 type StacksCommand struct {
-	usage           interface{} `usage:"CF_NAME stacks"`
+	usage           interface{} `usage:"CF_NAME stacks\n\nEXAMPLES:\n   CF_NAME stacks --output json"`
 	relatedCommands interface{} `related_commands:"app, push"`
 }
 
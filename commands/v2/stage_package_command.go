@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type StagePackageCommand struct {
+	RequiredArgs    flags.StagePackageArgs `positional-args:"yes"`
+	usage           interface{}            `usage:"CF_NAME stage-package APP_NAME PACKAGE_GUID\n\n   Use set-droplet to run the app on the new droplet.\n\nEXAMPLES:\n   CF_NAME stage-package my-app 3b4c5d6e-7f80-4a21-9b2c-5d6e7f8a9b10"`
+	relatedCommands interface{}            `related_commands:"create-package, packages, set-droplet"`
+}
+
+func (_ StagePackageCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ StagePackageCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
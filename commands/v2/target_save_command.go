@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type TargetSaveCommand struct {
+	RequiredArgs    flags.ContextName `positional-args:"yes"`
+	usage           interface{}       `usage:"CF_NAME target-save NAME\n\nEXAMPLES:\n   CF_NAME target-save prod\n   CF_NAME --context prod apps"`
+	relatedCommands interface{}       `related_commands:"api, login, target, target-use"`
+}
+
+func (_ TargetSaveCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ TargetSaveCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type TargetUseCommand struct {
+	RequiredArgs    flags.ContextName `positional-args:"yes"`
+	usage           interface{}       `usage:"CF_NAME target-use NAME"`
+	relatedCommands interface{}       `related_commands:"target, target-save"`
+}
+
+func (_ TargetUseCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ TargetUseCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type TasksCommand struct {
+	RequiredArgs    flags.AppName `positional-args:"yes"`
+	usage           interface{}   `usage:"CF_NAME tasks APP_NAME"`
+	relatedCommands interface{}   `related_commands:"apps, logs, run-task, terminate-task"`
+}
+
+func (_ TasksCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ TasksCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
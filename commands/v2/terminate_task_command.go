@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type TerminateTaskCommand struct {
+	RequiredArgs    flags.TerminateTaskArgs `positional-args:"yes"`
+	usage           interface{}             `usage:"CF_NAME terminate-task APP_NAME TASK_ID\n\nEXAMPLES:\n   CF_NAME terminate-task my-app 3"`
+	relatedCommands interface{}             `related_commands:"tasks"`
+}
+
+func (_ TerminateTaskCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ TerminateTaskCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -0,0 +1,27 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type TunnelCommand struct {
+	RequiredArgs       flags.ServiceInstance `positional-args:"yes"`
+	LocalPort          int                   `long:"local-port" short:"p" description:"Local port to forward to the service instance (Default: the port of the service instance)"`
+	AppName            string                `long:"app" description:"Running app to tunnel through, instead of pushing or reusing the cf-tunnel app"`
+	SkipHostValidation bool                  `long:"skip-host-validation" short:"k" description:"Skip host key validation"`
+	usage              interface{}           `usage:"CF_NAME tunnel SERVICE_INSTANCE [--local-port PORT] [--app APP_NAME] [--skip-host-validation]\n\n   The host and port of the service instance are read from a service key that the tunnel creates, and deletes when it closes. Unless --app is given, the tunnel runs through the 'cf-tunnel' app, which is pushed to the space if it does not exist.\n\nEXAMPLES:\n   CF_NAME tunnel my-db --local-port 5432\n   CF_NAME tunnel my-cache --app my-app"`
+	relatedCommands    interface{}           `related_commands:"ssh, create-service-key, service-key"`
+}
+
+func (_ TunnelCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ TunnelCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -13,8 +13,8 @@ type UnbindRouteServiceCommand struct {
 	Force           bool                   `short:"f" description:"Force unbinding without confirmation"`
 	Hostname        string                 `long:"hostname" short:"n" description:"Hostname used in combination with DOMAIN to specify the route to unbind"`
 	Path            string                 `long:"path" description:"Path for HTTP route"`
-	usage           interface{}            `usage:"CF_NAME unbind-route-service DOMAIN SERVICE_INSTANCE [--hostname HOSTNAME] [--path PATH] [-f]\n\nEXAMPLES:\n   CF_NAME unbind-route-service example.com myratelimiter --hostname myapp --path foo"`
-	relatedCommands interface{}            `related_commands:"delete-service, routes, services"`
+	usage           interface{}            `usage:"CF_NAME unbind-route-service DOMAIN SERVICE_INSTANCE [--hostname HOSTNAME] [--path PATH] [-f]\n\nEXAMPLES:\n   CF_NAME unbind-route-service example.com myratelimiter --hostname myapp --path foo\n   CF_NAME unbind-route-service example.com myratelimiter --hostname myapp -f --output json"`
+	relatedCommands interface{}            `related_commands:"delete-service, route-services, routes, services"`
 }
 
 func (_ UnbindRouteServiceCommand) Setup(config commands.Config, ui commands.UI) error {
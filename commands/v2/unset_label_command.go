@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type UnsetLabelCommand struct {
+	RequiredArgs    flags.UnsetLabelArgs `positional-args:"yes"`
+	usage           interface{}          `usage:"CF_NAME unset-label RESOURCE_TYPE RESOURCE_NAME KEY...\n\nRESOURCE_TYPES:\n   app, buildpack, org, service-instance, space, stack\n\nEXAMPLES:\n   CF_NAME unset-label app dora env\n   CF_NAME unset-label org business pci public-facing"`
+	relatedCommands interface{}          `related_commands:"labels, set-label"`
+}
+
+func (_ UnsetLabelCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ UnsetLabelCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -16,7 +16,8 @@ type UpdateBuildpackCommand struct {
 	Lock            bool            `long:"lock" description:"Lock the buildpack to prevent updates"`
 	Path            string          `short:"p" description:"Path to directory or zip file"`
 	Unlock          bool            `long:"unlock" description:"Unlock the buildpack to enable updates"`
-	usage           interface{}     `usage:"CF_NAME update-buildpack BUILDPACK [-p PATH] [-i POSITION] [--enable|--disable] [--lock|--unlock]\n\nTIP:\n   Path should be a zip file, a url to a zip file, or a local directory. Position is a positive integer, sets priority, and is sorted from lowest to highest."`
+	Stack           string          `long:"stack" description:"Stack of the buildpack, to pick it from buildpacks with the same name"`
+	usage           interface{}     `usage:"CF_NAME update-buildpack BUILDPACK [-p PATH] [-i POSITION] [--enable|--disable] [--lock|--unlock] [--stack STACK]\n\nTIP:\n   Path should be a zip file, a url to a zip file, or a local directory. Position is a positive integer, sets priority, and is sorted from lowest to highest."`
 	relatedCommands interface{}     `related_commands:"buildpacks, rename-buildpack"`
 }
 
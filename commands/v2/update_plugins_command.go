@@ -0,0 +1,26 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type UpdatePluginsCommand struct {
+	OptionalArgs    flags.PluginNames `positional-args:"yes"`
+	Force           bool              `short:"f" description:"Force update of the plugins without confirmation"`
+	SkipChecksum    bool              `long:"skip-checksum" description:"Update the plugins even if their SHA256 checksums cannot be verified"`
+	usage           interface{}       `usage:"CF_NAME update-plugins [PLUGIN_NAME...] [-f] [--skip-checksum]\n\n   Updates all the installed plugins that have a newer version, with a binary for this machine, in any of the plugin repositories, or only the plugins named. The new version of each plugin is downloaded from the repository with the newest version and verified before the installed version is uninstalled.\n\n   Prompts for confirmation unless '-f' is provided.\n\nEXAMPLES:\n   CF_NAME update-plugins\n   CF_NAME update-plugins plugin-echo -f"`
+	relatedCommands interface{}       `related_commands:"install-plugin, plugins, repo-plugins"`
+}
+
+func (_ UpdatePluginsCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ UpdatePluginsCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
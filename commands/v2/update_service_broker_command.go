@@ -9,9 +9,12 @@ import (
 )
 
 type UpdateServiceBrokerCommand struct {
-	RequiredArgs    flags.ServiceBrokerArgs `positional-args:"yes"`
-	usage           interface{}             `usage:"CF_NAME update-service-broker SERVICE_BROKER USERNAME PASSWORD URL"`
-	relatedCommands interface{}             `related_commands:"rename-service-broker, service-brokers"`
+	RequiredArgs    flags.UpdateServiceBrokerArgs `positional-args:"yes"`
+	Username        string                        `long:"username" description:"New username for the service broker"`
+	Password        string                        `long:"password" description:"New password for the service broker"`
+	URL             string                        `long:"url" description:"New URL of the service broker"`
+	usage           interface{}                   `usage:"CF_NAME update-service-broker SERVICE_BROKER USERNAME PASSWORD URL\n   CF_NAME update-service-broker SERVICE_BROKER [--username USERNAME] [--password PASSWORD] [--url URL]\n\nEXAMPLES:\n   CF_NAME update-service-broker my-broker --password n3wPa55"`
+	relatedCommands interface{}                   `related_commands:"rename-service-broker, service-brokers, broker-catalog-diff"`
 }
 
 func (_ UpdateServiceBrokerCommand) Setup(config commands.Config, ui commands.UI) error {
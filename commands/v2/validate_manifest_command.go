@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+)
+
+type ValidateManifestCommand struct {
+	PathToManifest  string      `short:"f" description:"Path to manifest"`
+	Lenient         bool        `long:"lenient" description:"Warn about manifest attributes that push does not know instead of failing"`
+	usage           interface{} `usage:"CF_NAME validate-manifest [-f MANIFEST_PATH] [--lenient]"`
+	relatedCommands interface{} `related_commands:"create-app-manifest, push"`
+}
+
+func (_ ValidateManifestCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ ValidateManifestCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -0,0 +1,39 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/commands"
+	"code.cloudfoundry.org/cli/commands/flags"
+)
+
+type ZeroDowntimePushCommand struct {
+	RequiredArgs        flags.AppName `positional-args:"yes"`
+	Buildpack           string        `short:"b" description:"Custom buildpack by name (e.g. my-buildpack) or Git URL (e.g. 'https://github.com/cloudfoundry/java-buildpack.git') or Git URL with a branch or tag (e.g. 'https://github.com/cloudfoundry/java-buildpack.git#v3.3.0' for 'v3.3.0' tag). To use built-in buildpacks only, specify 'default' or 'null'"`
+	StartupCommand      string        `short:"c" description:"Startup command, set to null to reset to default start command"`
+	PathToManifest      string        `short:"f" description:"Path to manifest"`
+	NumInstances        int           `short:"i" description:"Number of instances"`
+	DiskLimit           string        `short:"k" description:"Disk limit (e.g. 256M, 1024M, 1G)"`
+	MemoryLimit         string        `short:"m" description:"Memory limit (e.g. 256M, 1024M, 1G)"`
+	AppPath             string        `short:"p" description:"Path to app directory or to a zip file of the contents of the app directory"`
+	Stack               string        `short:"s" description:"Stack to use (a stack is a pre-built file system, including an operating system, that can run apps)"`
+	Timeout             string        `short:"t" description:"Maximum time (in seconds) for CLI to wait for application start, other server side timeouts may apply"`
+	DockerImage         string        `long:"docker-image" short:"o" description:"Docker-image to be used (e.g. user/docker-image-name)"`
+	DockerUsername      string        `long:"docker-username" description:"Repository username; used with password from environment variable CF_DOCKER_PASSWORD"`
+	HealthCheckType     string        `long:"health-check-type" short:"u" description:"Application health check type (e.g. 'port' or 'none')"`
+	NoManifest          bool          `long:"no-manifest" description:"Ignore manifest file"`
+	KeepOldApp          bool          `long:"keep-old-app" description:"Keep the old version of the app, renamed to APP_NAME-venerable, instead of deleting it"`
+	usage               interface{}   `usage:"CF_NAME zero-downtime-push APP_NAME [-b BUILDPACK_NAME] [-c COMMAND] [-f MANIFEST_PATH] [--docker-image DOCKER_IMAGE] [--docker-username USERNAME]\n   [-i NUM_INSTANCES] [-k DISK] [-m MEMORY] [-p PATH] [-s STACK] [-t TIMEOUT] [-u HEALTH_CHECK_TYPE]\n   [--no-manifest] [--keep-old-app]\n\nEXAMPLES:\n   CF_NAME zero-downtime-push my-app -f manifest.yml\n   CF_NAME zero-downtime-push my-app -p ./build --keep-old-app"`
+	relatedCommands     interface{}   `related_commands:"apps, push, rename"`
+	envCFDockerPassword interface{}   `environmentName:"CF_DOCKER_PASSWORD" environmentDescription:"Password used for private docker repository"`
+}
+
+func (_ ZeroDowntimePushCommand) Setup(config commands.Config, ui commands.UI) error {
+	return nil
+}
+
+func (_ ZeroDowntimePushCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -46,7 +46,9 @@ func parse(args []string) {
 				},
 			)
 
-			if found && flagErr.Type == flags.ErrUnknownFlag && parser.Active.Name == "set-env" {
+			// set-env takes its args as they are, including ones that look like
+			// options, such as the global --org with no value
+			if found && (flagErr.Type == flags.ErrUnknownFlag || flagErr.Type == flags.ErrExpectedArgument) && parser.Active.Name == "set-env" {
 				newArgs := []string{}
 				for _, arg := range args {
 					if arg[0] == '-' {
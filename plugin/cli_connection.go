@@ -1,16 +1,23 @@
 package plugin
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/rpc"
 	"os"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/cli/plugin/models"
+	"code.cloudfoundry.org/cli/plugin/v2models"
 )
 
+// ErrUnsupportedByCLI is returned by the methods of CliConnectionV2 when the
+// CLI running the plugin does not have them.
+var ErrUnsupportedByCLI = errors.New("The CLI running this plugin does not support this plugin API; upgrade the CLI to use it")
+
 type cliConnection struct {
 	cliServerPort string
 }
@@ -381,3 +388,139 @@ func (c *cliConnection) GetService(serviceInstance string) (plugin_models.GetSer
 
 	return result, err
 }
+
+func (c *cliConnection) PushApp(params plugin_models.PushAppParams) ([]plugin_models.PushAppModel, error) {
+	var result []plugin_models.PushAppModel
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.PushApp", params, &result)
+	})
+
+	return result, err
+}
+
+// StreamLogs sends the log messages of an app on the returned channel until
+// stop is closed or the stream ends. An error ends the stream and is sent on
+// the error channel. Both channels are closed once the stream has ended.
+func (c *cliConnection) StreamLogs(appName string, stop <-chan struct{}) (<-chan plugin_models.LogMessage, <-chan error) {
+	messages := make(chan plugin_models.LogMessage)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(messages)
+
+		var started bool
+		err := c.withClientDo(func(client *rpc.Client) error {
+			return client.Call("CliRpcCmd.StartLogStream", appName, &started)
+		})
+		if err != nil {
+			errs <- err
+			return
+		}
+
+		defer c.withClientDo(func(client *rpc.Client) error {
+			var stopped bool
+			return client.Call("CliRpcCmd.StopLogStream", "", &stopped)
+		})
+
+		for {
+			var batch plugin_models.LogStreamBatch
+			err = c.withClientDo(func(client *rpc.Client) error {
+				return client.Call("CliRpcCmd.ReadLogStream", "", &batch)
+			})
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			for _, message := range batch.Messages {
+				select {
+				case messages <- message:
+				case <-stop:
+					return
+				}
+			}
+
+			if batch.Ended {
+				return
+			}
+
+			select {
+			case <-stop:
+				return
+			default:
+			}
+		}
+	}()
+
+	return messages, errs
+}
+
+// callV2 calls a method of version 2 of the plugin API and decodes the JSON
+// it returns into result.
+func (c *cliConnection) callV2(method string, args interface{}, result interface{}) error {
+	var data []byte
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call(method, args, &data)
+	})
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "rpc: can't find method ") {
+			return ErrUnsupportedByCLI
+		}
+		return err
+	}
+
+	return json.Unmarshal(data, result)
+}
+
+// APICapabilities returns what the CLI running the plugin offers it; for
+// CLIs without version 2 of the plugin API it returns version 1 and no
+// resources.
+func (c *cliConnection) APICapabilities() (v2models.Capabilities, error) {
+	var result v2models.Capabilities
+
+	err := c.callV2("CliRpcCmd.APICapabilities", "", &result)
+	if err == ErrUnsupportedByCLI {
+		return v2models.Capabilities{APIVersion: 1}, nil
+	}
+
+	return result, err
+}
+
+func (c *cliConnection) GetAppV2(appName string) (v2models.App, error) {
+	var result v2models.App
+	err := c.callV2("CliRpcCmd.GetAppV2", appName, &result)
+	return result, err
+}
+
+func (c *cliConnection) GetAppsV2() ([]v2models.App, error) {
+	var result []v2models.App
+	err := c.callV2("CliRpcCmd.GetAppsV2", "", &result)
+	return result, err
+}
+
+func (c *cliConnection) GetRoutesV2() ([]v2models.Route, error) {
+	var result []v2models.Route
+	err := c.callV2("CliRpcCmd.GetRoutesV2", "", &result)
+	return result, err
+}
+
+func (c *cliConnection) GetServiceInstancesV2() ([]v2models.ServiceInstance, error) {
+	var result []v2models.ServiceInstance
+	err := c.callV2("CliRpcCmd.GetServiceInstancesV2", "", &result)
+	return result, err
+}
+
+func (c *cliConnection) GetOrgsV2() ([]v2models.Org, error) {
+	var result []v2models.Org
+	err := c.callV2("CliRpcCmd.GetOrgsV2", "", &result)
+	return result, err
+}
+
+func (c *cliConnection) GetSpacesV2() ([]v2models.Space, error) {
+	var result []v2models.Space
+	err := c.callV2("CliRpcCmd.GetSpacesV2", "", &result)
+	return result, err
+}
@@ -0,0 +1,114 @@
+package plugin_test
+
+import (
+	"encoding/json"
+	"net"
+	"net/rpc"
+	"strconv"
+
+	"code.cloudfoundry.org/cli/plugin"
+	"code.cloudfoundry.org/cli/plugin/v2models"
+	"code.cloudfoundry.org/cli/testhelpers/rpcserver"
+	"code.cloudfoundry.org/cli/testhelpers/rpcserver/rpcserverfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// oldCLI has the RPC methods of a CLI without version 2 of the plugin API
+type oldCLI struct{}
+
+func (oldCLI) IsMinCliVersion(_ string, retVal *bool) error {
+	*retVal = true
+	return nil
+}
+
+var _ = Describe("CliConnection", func() {
+	Describe("version 2 of the plugin API", func() {
+		var (
+			rpcHandlers *rpcserverfakes.FakeHandlers
+			ts          *rpcserver.TestServer
+			connection  plugin.CliConnectionV2
+		)
+
+		BeforeEach(func() {
+			var err error
+			rpcHandlers = new(rpcserverfakes.FakeHandlers)
+			ts, err = rpcserver.NewTestRPCServer(rpcHandlers)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ts.Start()
+			Expect(err).NotTo(HaveOccurred())
+
+			var ok bool
+			connection, ok = plugin.CliConnection(plugin.NewCliConnection(ts.Port())).(plugin.CliConnectionV2)
+			Expect(ok).To(BeTrue())
+		})
+
+		AfterEach(func() {
+			ts.Stop()
+		})
+
+		It("decodes the capabilities of the CLI", func() {
+			rpcHandlers.APICapabilitiesStub = func(_ string, retVal *[]byte) error {
+				*retVal = []byte(`{"api_version": 2, "cli_version": "6.99.0", "resources": ["apps", "routes"], "added_later": true}`)
+				return nil
+			}
+
+			capabilities, err := connection.APICapabilities()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(capabilities).To(Equal(v2models.Capabilities{
+				APIVersion: 2,
+				CLIVersion: "6.99.0",
+				Resources:  []string{"apps", "routes"},
+			}))
+		})
+
+		It("decodes the apps", func() {
+			rpcHandlers.GetAppsV2Stub = func(_ string, retVal *[]byte) error {
+				data, err := json.Marshal([]v2models.App{{GUID: "app-guid", Name: "app-name"}})
+				*retVal = data
+				return err
+			}
+
+			apps, err := connection.GetAppsV2()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apps).To(Equal([]v2models.App{{GUID: "app-guid", Name: "app-name"}}))
+		})
+	})
+
+	Context("when the CLI does not have version 2 of the plugin API", func() {
+		var (
+			listener   net.Listener
+			connection plugin.CliConnectionV2
+		)
+
+		BeforeEach(func() {
+			server := rpc.NewServer()
+			err := server.RegisterName("CliRpcCmd", oldCLI{})
+			Expect(err).NotTo(HaveOccurred())
+
+			listener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			go server.Accept(listener)
+
+			port := strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+			connection = plugin.CliConnection(plugin.NewCliConnection(port)).(plugin.CliConnectionV2)
+		})
+
+		AfterEach(func() {
+			listener.Close()
+		})
+
+		It("reports version 1 without resources", func() {
+			capabilities, err := connection.APICapabilities()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(capabilities.APIVersion).To(Equal(1))
+			Expect(capabilities.Supports(v2models.ResourceApps)).To(BeFalse())
+		})
+
+		It("returns ErrUnsupportedByCLI from the other methods", func() {
+			_, err := connection.GetAppsV2()
+			Expect(err).To(Equal(plugin.ErrUnsupportedByCLI))
+		})
+	})
+})
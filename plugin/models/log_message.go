@@ -0,0 +1,21 @@
+package plugin_models
+
+import "time"
+
+type LogMessage struct {
+	Timestamp      time.Time
+	AppGuid        string
+	SourceType     string
+	SourceInstance string
+	// MessageType is OUT for messages the app wrote to stdout and ERR for
+	// those it wrote to stderr
+	MessageType string
+	Message     string
+}
+
+// LogStreamBatch holds the log messages that arrived since a plugin last
+// read its log stream. Ended is true once the stream has no more messages.
+type LogStreamBatch struct {
+	Messages []LogMessage
+	Ended    bool
+}
@@ -0,0 +1,30 @@
+package plugin_models
+
+// PushAppParams are the options of a push started by a plugin. Options that
+// are left empty are not given to push, so the manifest and the defaults of
+// push apply as they do for cf push.
+type PushAppParams struct {
+	AppName      string
+	ManifestPath string
+	NoManifest   bool
+	Path         string
+	Buildpacks   []string
+	DockerImage  string
+	Instances    int
+	Memory       string
+	DiskQuota    string
+	Stack        string
+	Strategy     string
+	NoRoute      bool
+	NoStart      bool
+	// ShowOutput prints the output of the push in the terminal, as cf push
+	// does; otherwise the push is silent
+	ShowOutput bool
+}
+
+type PushAppModel struct {
+	Name   string
+	Guid   string
+	State  string
+	Routes []string
+}
@@ -1,6 +1,9 @@
 package plugin
 
-import "code.cloudfoundry.org/cli/plugin/models"
+import (
+	"code.cloudfoundry.org/cli/plugin/models"
+	"code.cloudfoundry.org/cli/plugin/v2models"
+)
 
 /**
 	Command interface needs to be implemented for a runnable plugin of `cf`
@@ -42,6 +45,26 @@ type CliConnection interface {
 	GetService(string) (plugin_models.GetService_Model, error)
 	GetOrg(string) (plugin_models.GetOrg_Model, error)
 	GetSpace(string) (plugin_models.GetSpace_Model, error)
+	PushApp(plugin_models.PushAppParams) ([]plugin_models.PushAppModel, error)
+	StreamLogs(appName string, stop <-chan struct{}) (<-chan plugin_models.LogMessage, <-chan error)
+}
+
+//go:generate counterfeiter . CliConnectionV2
+/**
+	Version 2 of the plugin API. The CliConnection given to Run also
+	implements CliConnectionV2; plugins should call APICapabilities to check
+	that the CLI running them supports the resources they need, since older
+	CLIs return ErrUnsupportedByCLI for the other methods.
+**/
+type CliConnectionV2 interface {
+	CliConnection
+	APICapabilities() (v2models.Capabilities, error)
+	GetAppV2(appName string) (v2models.App, error)
+	GetAppsV2() ([]v2models.App, error)
+	GetRoutesV2() ([]v2models.Route, error)
+	GetServiceInstancesV2() ([]v2models.ServiceInstance, error)
+	GetOrgsV2() ([]v2models.Org, error)
+	GetSpacesV2() ([]v2models.Space, error)
 }
 
 type VersionType struct {
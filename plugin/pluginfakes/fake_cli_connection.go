@@ -216,6 +216,25 @@ type FakeCliConnection struct {
 		result1 plugin_models.GetSpace_Model
 		result2 error
 	}
+	PushAppStub        func(plugin_models.PushAppParams) ([]plugin_models.PushAppModel, error)
+	pushAppMutex       sync.RWMutex
+	pushAppArgsForCall []struct {
+		arg1 plugin_models.PushAppParams
+	}
+	pushAppReturns struct {
+		result1 []plugin_models.PushAppModel
+		result2 error
+	}
+	StreamLogsStub        func(appName string, stop <-chan struct{}) (<-chan plugin_models.LogMessage, <-chan error)
+	streamLogsMutex       sync.RWMutex
+	streamLogsArgsForCall []struct {
+		appName string
+		stop    <-chan struct{}
+	}
+	streamLogsReturns struct {
+		result1 <-chan plugin_models.LogMessage
+		result2 <-chan error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -988,6 +1007,75 @@ func (fake *FakeCliConnection) GetSpaceReturns(result1 plugin_models.GetSpace_Mo
 	}{result1, result2}
 }
 
+func (fake *FakeCliConnection) PushApp(arg1 plugin_models.PushAppParams) ([]plugin_models.PushAppModel, error) {
+	fake.pushAppMutex.Lock()
+	fake.pushAppArgsForCall = append(fake.pushAppArgsForCall, struct {
+		arg1 plugin_models.PushAppParams
+	}{arg1})
+	fake.recordInvocation("PushApp", []interface{}{arg1})
+	fake.pushAppMutex.Unlock()
+	if fake.PushAppStub != nil {
+		return fake.PushAppStub(arg1)
+	} else {
+		return fake.pushAppReturns.result1, fake.pushAppReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) PushAppCallCount() int {
+	fake.pushAppMutex.RLock()
+	defer fake.pushAppMutex.RUnlock()
+	return len(fake.pushAppArgsForCall)
+}
+
+func (fake *FakeCliConnection) PushAppArgsForCall(i int) plugin_models.PushAppParams {
+	fake.pushAppMutex.RLock()
+	defer fake.pushAppMutex.RUnlock()
+	return fake.pushAppArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnection) PushAppReturns(result1 []plugin_models.PushAppModel, result2 error) {
+	fake.PushAppStub = nil
+	fake.pushAppReturns = struct {
+		result1 []plugin_models.PushAppModel
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnection) StreamLogs(appName string, stop <-chan struct{}) (<-chan plugin_models.LogMessage, <-chan error) {
+	fake.streamLogsMutex.Lock()
+	fake.streamLogsArgsForCall = append(fake.streamLogsArgsForCall, struct {
+		appName string
+		stop    <-chan struct{}
+	}{appName, stop})
+	fake.recordInvocation("StreamLogs", []interface{}{appName, stop})
+	fake.streamLogsMutex.Unlock()
+	if fake.StreamLogsStub != nil {
+		return fake.StreamLogsStub(appName, stop)
+	} else {
+		return fake.streamLogsReturns.result1, fake.streamLogsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnection) StreamLogsCallCount() int {
+	fake.streamLogsMutex.RLock()
+	defer fake.streamLogsMutex.RUnlock()
+	return len(fake.streamLogsArgsForCall)
+}
+
+func (fake *FakeCliConnection) StreamLogsArgsForCall(i int) (string, <-chan struct{}) {
+	fake.streamLogsMutex.RLock()
+	defer fake.streamLogsMutex.RUnlock()
+	return fake.streamLogsArgsForCall[i].appName, fake.streamLogsArgsForCall[i].stop
+}
+
+func (fake *FakeCliConnection) StreamLogsReturns(result1 <-chan plugin_models.LogMessage, result2 <-chan error) {
+	fake.StreamLogsStub = nil
+	fake.streamLogsReturns = struct {
+		result1 <-chan plugin_models.LogMessage
+		result2 <-chan error
+	}{result1, result2}
+}
+
 func (fake *FakeCliConnection) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1045,6 +1133,10 @@ func (fake *FakeCliConnection) Invocations() map[string][][]interface{} {
 	defer fake.getOrgMutex.RUnlock()
 	fake.getSpaceMutex.RLock()
 	defer fake.getSpaceMutex.RUnlock()
+	fake.pushAppMutex.RLock()
+	defer fake.pushAppMutex.RUnlock()
+	fake.streamLogsMutex.RLock()
+	defer fake.streamLogsMutex.RUnlock()
 	return fake.invocations
 }
 
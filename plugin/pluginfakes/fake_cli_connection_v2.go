@@ -0,0 +1,1411 @@
+// This file was generated by counterfeiter
+package pluginfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/plugin"
+	"code.cloudfoundry.org/cli/plugin/models"
+	"code.cloudfoundry.org/cli/plugin/v2models"
+)
+
+type FakeCliConnectionV2 struct {
+	CliCommandWithoutTerminalOutputStub        func(args ...string) ([]string, error)
+	cliCommandWithoutTerminalOutputMutex       sync.RWMutex
+	cliCommandWithoutTerminalOutputArgsForCall []struct {
+		args []string
+	}
+	cliCommandWithoutTerminalOutputReturns struct {
+		result1 []string
+		result2 error
+	}
+	CliCommandStub        func(args ...string) ([]string, error)
+	cliCommandMutex       sync.RWMutex
+	cliCommandArgsForCall []struct {
+		args []string
+	}
+	cliCommandReturns struct {
+		result1 []string
+		result2 error
+	}
+	GetCurrentOrgStub        func() (plugin_models.Organization, error)
+	getCurrentOrgMutex       sync.RWMutex
+	getCurrentOrgArgsForCall []struct{}
+	getCurrentOrgReturns     struct {
+		result1 plugin_models.Organization
+		result2 error
+	}
+	GetCurrentSpaceStub        func() (plugin_models.Space, error)
+	getCurrentSpaceMutex       sync.RWMutex
+	getCurrentSpaceArgsForCall []struct{}
+	getCurrentSpaceReturns     struct {
+		result1 plugin_models.Space
+		result2 error
+	}
+	UsernameStub        func() (string, error)
+	usernameMutex       sync.RWMutex
+	usernameArgsForCall []struct{}
+	usernameReturns     struct {
+		result1 string
+		result2 error
+	}
+	UserGuidStub        func() (string, error)
+	userGuidMutex       sync.RWMutex
+	userGuidArgsForCall []struct{}
+	userGuidReturns     struct {
+		result1 string
+		result2 error
+	}
+	UserEmailStub        func() (string, error)
+	userEmailMutex       sync.RWMutex
+	userEmailArgsForCall []struct{}
+	userEmailReturns     struct {
+		result1 string
+		result2 error
+	}
+	IsLoggedInStub        func() (bool, error)
+	isLoggedInMutex       sync.RWMutex
+	isLoggedInArgsForCall []struct{}
+	isLoggedInReturns     struct {
+		result1 bool
+		result2 error
+	}
+	IsSSLDisabledStub        func() (bool, error)
+	isSSLDisabledMutex       sync.RWMutex
+	isSSLDisabledArgsForCall []struct{}
+	isSSLDisabledReturns     struct {
+		result1 bool
+		result2 error
+	}
+	HasOrganizationStub        func() (bool, error)
+	hasOrganizationMutex       sync.RWMutex
+	hasOrganizationArgsForCall []struct{}
+	hasOrganizationReturns     struct {
+		result1 bool
+		result2 error
+	}
+	HasSpaceStub        func() (bool, error)
+	hasSpaceMutex       sync.RWMutex
+	hasSpaceArgsForCall []struct{}
+	hasSpaceReturns     struct {
+		result1 bool
+		result2 error
+	}
+	ApiEndpointStub        func() (string, error)
+	apiEndpointMutex       sync.RWMutex
+	apiEndpointArgsForCall []struct{}
+	apiEndpointReturns     struct {
+		result1 string
+		result2 error
+	}
+	ApiVersionStub        func() (string, error)
+	apiVersionMutex       sync.RWMutex
+	apiVersionArgsForCall []struct{}
+	apiVersionReturns     struct {
+		result1 string
+		result2 error
+	}
+	HasAPIEndpointStub        func() (bool, error)
+	hasAPIEndpointMutex       sync.RWMutex
+	hasAPIEndpointArgsForCall []struct{}
+	hasAPIEndpointReturns     struct {
+		result1 bool
+		result2 error
+	}
+	LoggregatorEndpointStub        func() (string, error)
+	loggregatorEndpointMutex       sync.RWMutex
+	loggregatorEndpointArgsForCall []struct{}
+	loggregatorEndpointReturns     struct {
+		result1 string
+		result2 error
+	}
+	DopplerEndpointStub        func() (string, error)
+	dopplerEndpointMutex       sync.RWMutex
+	dopplerEndpointArgsForCall []struct{}
+	dopplerEndpointReturns     struct {
+		result1 string
+		result2 error
+	}
+	AccessTokenStub        func() (string, error)
+	accessTokenMutex       sync.RWMutex
+	accessTokenArgsForCall []struct{}
+	accessTokenReturns     struct {
+		result1 string
+		result2 error
+	}
+	GetAppStub        func(string) (plugin_models.GetAppModel, error)
+	getAppMutex       sync.RWMutex
+	getAppArgsForCall []struct {
+		arg1 string
+	}
+	getAppReturns struct {
+		result1 plugin_models.GetAppModel
+		result2 error
+	}
+	GetAppsStub        func() ([]plugin_models.GetAppsModel, error)
+	getAppsMutex       sync.RWMutex
+	getAppsArgsForCall []struct{}
+	getAppsReturns     struct {
+		result1 []plugin_models.GetAppsModel
+		result2 error
+	}
+	GetOrgsStub        func() ([]plugin_models.GetOrgs_Model, error)
+	getOrgsMutex       sync.RWMutex
+	getOrgsArgsForCall []struct{}
+	getOrgsReturns     struct {
+		result1 []plugin_models.GetOrgs_Model
+		result2 error
+	}
+	GetSpacesStub        func() ([]plugin_models.GetSpaces_Model, error)
+	getSpacesMutex       sync.RWMutex
+	getSpacesArgsForCall []struct{}
+	getSpacesReturns     struct {
+		result1 []plugin_models.GetSpaces_Model
+		result2 error
+	}
+	GetOrgUsersStub        func(string, ...string) ([]plugin_models.GetOrgUsers_Model, error)
+	getOrgUsersMutex       sync.RWMutex
+	getOrgUsersArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	getOrgUsersReturns struct {
+		result1 []plugin_models.GetOrgUsers_Model
+		result2 error
+	}
+	GetSpaceUsersStub        func(string, string) ([]plugin_models.GetSpaceUsers_Model, error)
+	getSpaceUsersMutex       sync.RWMutex
+	getSpaceUsersArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	getSpaceUsersReturns struct {
+		result1 []plugin_models.GetSpaceUsers_Model
+		result2 error
+	}
+	GetServicesStub        func() ([]plugin_models.GetServices_Model, error)
+	getServicesMutex       sync.RWMutex
+	getServicesArgsForCall []struct{}
+	getServicesReturns     struct {
+		result1 []plugin_models.GetServices_Model
+		result2 error
+	}
+	GetServiceStub        func(string) (plugin_models.GetService_Model, error)
+	getServiceMutex       sync.RWMutex
+	getServiceArgsForCall []struct {
+		arg1 string
+	}
+	getServiceReturns struct {
+		result1 plugin_models.GetService_Model
+		result2 error
+	}
+	GetOrgStub        func(string) (plugin_models.GetOrg_Model, error)
+	getOrgMutex       sync.RWMutex
+	getOrgArgsForCall []struct {
+		arg1 string
+	}
+	getOrgReturns struct {
+		result1 plugin_models.GetOrg_Model
+		result2 error
+	}
+	GetSpaceStub        func(string) (plugin_models.GetSpace_Model, error)
+	getSpaceMutex       sync.RWMutex
+	getSpaceArgsForCall []struct {
+		arg1 string
+	}
+	getSpaceReturns struct {
+		result1 plugin_models.GetSpace_Model
+		result2 error
+	}
+	PushAppStub        func(plugin_models.PushAppParams) ([]plugin_models.PushAppModel, error)
+	pushAppMutex       sync.RWMutex
+	pushAppArgsForCall []struct {
+		arg1 plugin_models.PushAppParams
+	}
+	pushAppReturns struct {
+		result1 []plugin_models.PushAppModel
+		result2 error
+	}
+	StreamLogsStub        func(appName string, stop <-chan struct{}) (<-chan plugin_models.LogMessage, <-chan error)
+	streamLogsMutex       sync.RWMutex
+	streamLogsArgsForCall []struct {
+		appName string
+		stop    <-chan struct{}
+	}
+	streamLogsReturns struct {
+		result1 <-chan plugin_models.LogMessage
+		result2 <-chan error
+	}
+	APICapabilitiesStub        func() (v2models.Capabilities, error)
+	aPICapabilitiesMutex       sync.RWMutex
+	aPICapabilitiesArgsForCall []struct{}
+	aPICapabilitiesReturns     struct {
+		result1 v2models.Capabilities
+		result2 error
+	}
+	GetAppV2Stub        func(appName string) (v2models.App, error)
+	getAppV2Mutex       sync.RWMutex
+	getAppV2ArgsForCall []struct {
+		appName string
+	}
+	getAppV2Returns struct {
+		result1 v2models.App
+		result2 error
+	}
+	GetAppsV2Stub        func() ([]v2models.App, error)
+	getAppsV2Mutex       sync.RWMutex
+	getAppsV2ArgsForCall []struct{}
+	getAppsV2Returns     struct {
+		result1 []v2models.App
+		result2 error
+	}
+	GetRoutesV2Stub        func() ([]v2models.Route, error)
+	getRoutesV2Mutex       sync.RWMutex
+	getRoutesV2ArgsForCall []struct{}
+	getRoutesV2Returns     struct {
+		result1 []v2models.Route
+		result2 error
+	}
+	GetServiceInstancesV2Stub        func() ([]v2models.ServiceInstance, error)
+	getServiceInstancesV2Mutex       sync.RWMutex
+	getServiceInstancesV2ArgsForCall []struct{}
+	getServiceInstancesV2Returns     struct {
+		result1 []v2models.ServiceInstance
+		result2 error
+	}
+	GetOrgsV2Stub        func() ([]v2models.Org, error)
+	getOrgsV2Mutex       sync.RWMutex
+	getOrgsV2ArgsForCall []struct{}
+	getOrgsV2Returns     struct {
+		result1 []v2models.Org
+		result2 error
+	}
+	GetSpacesV2Stub        func() ([]v2models.Space, error)
+	getSpacesV2Mutex       sync.RWMutex
+	getSpacesV2ArgsForCall []struct{}
+	getSpacesV2Returns     struct {
+		result1 []v2models.Space
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCliConnectionV2) CliCommandWithoutTerminalOutput(args ...string) ([]string, error) {
+	fake.cliCommandWithoutTerminalOutputMutex.Lock()
+	fake.cliCommandWithoutTerminalOutputArgsForCall = append(fake.cliCommandWithoutTerminalOutputArgsForCall, struct {
+		args []string
+	}{args})
+	fake.recordInvocation("CliCommandWithoutTerminalOutput", []interface{}{args})
+	fake.cliCommandWithoutTerminalOutputMutex.Unlock()
+	if fake.CliCommandWithoutTerminalOutputStub != nil {
+		return fake.CliCommandWithoutTerminalOutputStub(args...)
+	} else {
+		return fake.cliCommandWithoutTerminalOutputReturns.result1, fake.cliCommandWithoutTerminalOutputReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) CliCommandWithoutTerminalOutputCallCount() int {
+	fake.cliCommandWithoutTerminalOutputMutex.RLock()
+	defer fake.cliCommandWithoutTerminalOutputMutex.RUnlock()
+	return len(fake.cliCommandWithoutTerminalOutputArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) CliCommandWithoutTerminalOutputArgsForCall(i int) []string {
+	fake.cliCommandWithoutTerminalOutputMutex.RLock()
+	defer fake.cliCommandWithoutTerminalOutputMutex.RUnlock()
+	return fake.cliCommandWithoutTerminalOutputArgsForCall[i].args
+}
+
+func (fake *FakeCliConnectionV2) CliCommandWithoutTerminalOutputReturns(result1 []string, result2 error) {
+	fake.CliCommandWithoutTerminalOutputStub = nil
+	fake.cliCommandWithoutTerminalOutputReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) CliCommand(args ...string) ([]string, error) {
+	fake.cliCommandMutex.Lock()
+	fake.cliCommandArgsForCall = append(fake.cliCommandArgsForCall, struct {
+		args []string
+	}{args})
+	fake.recordInvocation("CliCommand", []interface{}{args})
+	fake.cliCommandMutex.Unlock()
+	if fake.CliCommandStub != nil {
+		return fake.CliCommandStub(args...)
+	} else {
+		return fake.cliCommandReturns.result1, fake.cliCommandReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) CliCommandCallCount() int {
+	fake.cliCommandMutex.RLock()
+	defer fake.cliCommandMutex.RUnlock()
+	return len(fake.cliCommandArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) CliCommandArgsForCall(i int) []string {
+	fake.cliCommandMutex.RLock()
+	defer fake.cliCommandMutex.RUnlock()
+	return fake.cliCommandArgsForCall[i].args
+}
+
+func (fake *FakeCliConnectionV2) CliCommandReturns(result1 []string, result2 error) {
+	fake.CliCommandStub = nil
+	fake.cliCommandReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetCurrentOrg() (plugin_models.Organization, error) {
+	fake.getCurrentOrgMutex.Lock()
+	fake.getCurrentOrgArgsForCall = append(fake.getCurrentOrgArgsForCall, struct{}{})
+	fake.recordInvocation("GetCurrentOrg", []interface{}{})
+	fake.getCurrentOrgMutex.Unlock()
+	if fake.GetCurrentOrgStub != nil {
+		return fake.GetCurrentOrgStub()
+	} else {
+		return fake.getCurrentOrgReturns.result1, fake.getCurrentOrgReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetCurrentOrgCallCount() int {
+	fake.getCurrentOrgMutex.RLock()
+	defer fake.getCurrentOrgMutex.RUnlock()
+	return len(fake.getCurrentOrgArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetCurrentOrgReturns(result1 plugin_models.Organization, result2 error) {
+	fake.GetCurrentOrgStub = nil
+	fake.getCurrentOrgReturns = struct {
+		result1 plugin_models.Organization
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetCurrentSpace() (plugin_models.Space, error) {
+	fake.getCurrentSpaceMutex.Lock()
+	fake.getCurrentSpaceArgsForCall = append(fake.getCurrentSpaceArgsForCall, struct{}{})
+	fake.recordInvocation("GetCurrentSpace", []interface{}{})
+	fake.getCurrentSpaceMutex.Unlock()
+	if fake.GetCurrentSpaceStub != nil {
+		return fake.GetCurrentSpaceStub()
+	} else {
+		return fake.getCurrentSpaceReturns.result1, fake.getCurrentSpaceReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetCurrentSpaceCallCount() int {
+	fake.getCurrentSpaceMutex.RLock()
+	defer fake.getCurrentSpaceMutex.RUnlock()
+	return len(fake.getCurrentSpaceArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetCurrentSpaceReturns(result1 plugin_models.Space, result2 error) {
+	fake.GetCurrentSpaceStub = nil
+	fake.getCurrentSpaceReturns = struct {
+		result1 plugin_models.Space
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) Username() (string, error) {
+	fake.usernameMutex.Lock()
+	fake.usernameArgsForCall = append(fake.usernameArgsForCall, struct{}{})
+	fake.recordInvocation("Username", []interface{}{})
+	fake.usernameMutex.Unlock()
+	if fake.UsernameStub != nil {
+		return fake.UsernameStub()
+	} else {
+		return fake.usernameReturns.result1, fake.usernameReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) UsernameCallCount() int {
+	fake.usernameMutex.RLock()
+	defer fake.usernameMutex.RUnlock()
+	return len(fake.usernameArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) UsernameReturns(result1 string, result2 error) {
+	fake.UsernameStub = nil
+	fake.usernameReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) UserGuid() (string, error) {
+	fake.userGuidMutex.Lock()
+	fake.userGuidArgsForCall = append(fake.userGuidArgsForCall, struct{}{})
+	fake.recordInvocation("UserGuid", []interface{}{})
+	fake.userGuidMutex.Unlock()
+	if fake.UserGuidStub != nil {
+		return fake.UserGuidStub()
+	} else {
+		return fake.userGuidReturns.result1, fake.userGuidReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) UserGuidCallCount() int {
+	fake.userGuidMutex.RLock()
+	defer fake.userGuidMutex.RUnlock()
+	return len(fake.userGuidArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) UserGuidReturns(result1 string, result2 error) {
+	fake.UserGuidStub = nil
+	fake.userGuidReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) UserEmail() (string, error) {
+	fake.userEmailMutex.Lock()
+	fake.userEmailArgsForCall = append(fake.userEmailArgsForCall, struct{}{})
+	fake.recordInvocation("UserEmail", []interface{}{})
+	fake.userEmailMutex.Unlock()
+	if fake.UserEmailStub != nil {
+		return fake.UserEmailStub()
+	} else {
+		return fake.userEmailReturns.result1, fake.userEmailReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) UserEmailCallCount() int {
+	fake.userEmailMutex.RLock()
+	defer fake.userEmailMutex.RUnlock()
+	return len(fake.userEmailArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) UserEmailReturns(result1 string, result2 error) {
+	fake.UserEmailStub = nil
+	fake.userEmailReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) IsLoggedIn() (bool, error) {
+	fake.isLoggedInMutex.Lock()
+	fake.isLoggedInArgsForCall = append(fake.isLoggedInArgsForCall, struct{}{})
+	fake.recordInvocation("IsLoggedIn", []interface{}{})
+	fake.isLoggedInMutex.Unlock()
+	if fake.IsLoggedInStub != nil {
+		return fake.IsLoggedInStub()
+	} else {
+		return fake.isLoggedInReturns.result1, fake.isLoggedInReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) IsLoggedInCallCount() int {
+	fake.isLoggedInMutex.RLock()
+	defer fake.isLoggedInMutex.RUnlock()
+	return len(fake.isLoggedInArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) IsLoggedInReturns(result1 bool, result2 error) {
+	fake.IsLoggedInStub = nil
+	fake.isLoggedInReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) IsSSLDisabled() (bool, error) {
+	fake.isSSLDisabledMutex.Lock()
+	fake.isSSLDisabledArgsForCall = append(fake.isSSLDisabledArgsForCall, struct{}{})
+	fake.recordInvocation("IsSSLDisabled", []interface{}{})
+	fake.isSSLDisabledMutex.Unlock()
+	if fake.IsSSLDisabledStub != nil {
+		return fake.IsSSLDisabledStub()
+	} else {
+		return fake.isSSLDisabledReturns.result1, fake.isSSLDisabledReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) IsSSLDisabledCallCount() int {
+	fake.isSSLDisabledMutex.RLock()
+	defer fake.isSSLDisabledMutex.RUnlock()
+	return len(fake.isSSLDisabledArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) IsSSLDisabledReturns(result1 bool, result2 error) {
+	fake.IsSSLDisabledStub = nil
+	fake.isSSLDisabledReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) HasOrganization() (bool, error) {
+	fake.hasOrganizationMutex.Lock()
+	fake.hasOrganizationArgsForCall = append(fake.hasOrganizationArgsForCall, struct{}{})
+	fake.recordInvocation("HasOrganization", []interface{}{})
+	fake.hasOrganizationMutex.Unlock()
+	if fake.HasOrganizationStub != nil {
+		return fake.HasOrganizationStub()
+	} else {
+		return fake.hasOrganizationReturns.result1, fake.hasOrganizationReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) HasOrganizationCallCount() int {
+	fake.hasOrganizationMutex.RLock()
+	defer fake.hasOrganizationMutex.RUnlock()
+	return len(fake.hasOrganizationArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) HasOrganizationReturns(result1 bool, result2 error) {
+	fake.HasOrganizationStub = nil
+	fake.hasOrganizationReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) HasSpace() (bool, error) {
+	fake.hasSpaceMutex.Lock()
+	fake.hasSpaceArgsForCall = append(fake.hasSpaceArgsForCall, struct{}{})
+	fake.recordInvocation("HasSpace", []interface{}{})
+	fake.hasSpaceMutex.Unlock()
+	if fake.HasSpaceStub != nil {
+		return fake.HasSpaceStub()
+	} else {
+		return fake.hasSpaceReturns.result1, fake.hasSpaceReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) HasSpaceCallCount() int {
+	fake.hasSpaceMutex.RLock()
+	defer fake.hasSpaceMutex.RUnlock()
+	return len(fake.hasSpaceArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) HasSpaceReturns(result1 bool, result2 error) {
+	fake.HasSpaceStub = nil
+	fake.hasSpaceReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) ApiEndpoint() (string, error) {
+	fake.apiEndpointMutex.Lock()
+	fake.apiEndpointArgsForCall = append(fake.apiEndpointArgsForCall, struct{}{})
+	fake.recordInvocation("ApiEndpoint", []interface{}{})
+	fake.apiEndpointMutex.Unlock()
+	if fake.ApiEndpointStub != nil {
+		return fake.ApiEndpointStub()
+	} else {
+		return fake.apiEndpointReturns.result1, fake.apiEndpointReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) ApiEndpointCallCount() int {
+	fake.apiEndpointMutex.RLock()
+	defer fake.apiEndpointMutex.RUnlock()
+	return len(fake.apiEndpointArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) ApiEndpointReturns(result1 string, result2 error) {
+	fake.ApiEndpointStub = nil
+	fake.apiEndpointReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) ApiVersion() (string, error) {
+	fake.apiVersionMutex.Lock()
+	fake.apiVersionArgsForCall = append(fake.apiVersionArgsForCall, struct{}{})
+	fake.recordInvocation("ApiVersion", []interface{}{})
+	fake.apiVersionMutex.Unlock()
+	if fake.ApiVersionStub != nil {
+		return fake.ApiVersionStub()
+	} else {
+		return fake.apiVersionReturns.result1, fake.apiVersionReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) ApiVersionCallCount() int {
+	fake.apiVersionMutex.RLock()
+	defer fake.apiVersionMutex.RUnlock()
+	return len(fake.apiVersionArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) ApiVersionReturns(result1 string, result2 error) {
+	fake.ApiVersionStub = nil
+	fake.apiVersionReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) HasAPIEndpoint() (bool, error) {
+	fake.hasAPIEndpointMutex.Lock()
+	fake.hasAPIEndpointArgsForCall = append(fake.hasAPIEndpointArgsForCall, struct{}{})
+	fake.recordInvocation("HasAPIEndpoint", []interface{}{})
+	fake.hasAPIEndpointMutex.Unlock()
+	if fake.HasAPIEndpointStub != nil {
+		return fake.HasAPIEndpointStub()
+	} else {
+		return fake.hasAPIEndpointReturns.result1, fake.hasAPIEndpointReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) HasAPIEndpointCallCount() int {
+	fake.hasAPIEndpointMutex.RLock()
+	defer fake.hasAPIEndpointMutex.RUnlock()
+	return len(fake.hasAPIEndpointArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) HasAPIEndpointReturns(result1 bool, result2 error) {
+	fake.HasAPIEndpointStub = nil
+	fake.hasAPIEndpointReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) LoggregatorEndpoint() (string, error) {
+	fake.loggregatorEndpointMutex.Lock()
+	fake.loggregatorEndpointArgsForCall = append(fake.loggregatorEndpointArgsForCall, struct{}{})
+	fake.recordInvocation("LoggregatorEndpoint", []interface{}{})
+	fake.loggregatorEndpointMutex.Unlock()
+	if fake.LoggregatorEndpointStub != nil {
+		return fake.LoggregatorEndpointStub()
+	} else {
+		return fake.loggregatorEndpointReturns.result1, fake.loggregatorEndpointReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) LoggregatorEndpointCallCount() int {
+	fake.loggregatorEndpointMutex.RLock()
+	defer fake.loggregatorEndpointMutex.RUnlock()
+	return len(fake.loggregatorEndpointArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) LoggregatorEndpointReturns(result1 string, result2 error) {
+	fake.LoggregatorEndpointStub = nil
+	fake.loggregatorEndpointReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) DopplerEndpoint() (string, error) {
+	fake.dopplerEndpointMutex.Lock()
+	fake.dopplerEndpointArgsForCall = append(fake.dopplerEndpointArgsForCall, struct{}{})
+	fake.recordInvocation("DopplerEndpoint", []interface{}{})
+	fake.dopplerEndpointMutex.Unlock()
+	if fake.DopplerEndpointStub != nil {
+		return fake.DopplerEndpointStub()
+	} else {
+		return fake.dopplerEndpointReturns.result1, fake.dopplerEndpointReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) DopplerEndpointCallCount() int {
+	fake.dopplerEndpointMutex.RLock()
+	defer fake.dopplerEndpointMutex.RUnlock()
+	return len(fake.dopplerEndpointArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) DopplerEndpointReturns(result1 string, result2 error) {
+	fake.DopplerEndpointStub = nil
+	fake.dopplerEndpointReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) AccessToken() (string, error) {
+	fake.accessTokenMutex.Lock()
+	fake.accessTokenArgsForCall = append(fake.accessTokenArgsForCall, struct{}{})
+	fake.recordInvocation("AccessToken", []interface{}{})
+	fake.accessTokenMutex.Unlock()
+	if fake.AccessTokenStub != nil {
+		return fake.AccessTokenStub()
+	} else {
+		return fake.accessTokenReturns.result1, fake.accessTokenReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) AccessTokenCallCount() int {
+	fake.accessTokenMutex.RLock()
+	defer fake.accessTokenMutex.RUnlock()
+	return len(fake.accessTokenArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) AccessTokenReturns(result1 string, result2 error) {
+	fake.AccessTokenStub = nil
+	fake.accessTokenReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetApp(arg1 string) (plugin_models.GetAppModel, error) {
+	fake.getAppMutex.Lock()
+	fake.getAppArgsForCall = append(fake.getAppArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetApp", []interface{}{arg1})
+	fake.getAppMutex.Unlock()
+	if fake.GetAppStub != nil {
+		return fake.GetAppStub(arg1)
+	} else {
+		return fake.getAppReturns.result1, fake.getAppReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetAppCallCount() int {
+	fake.getAppMutex.RLock()
+	defer fake.getAppMutex.RUnlock()
+	return len(fake.getAppArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetAppArgsForCall(i int) string {
+	fake.getAppMutex.RLock()
+	defer fake.getAppMutex.RUnlock()
+	return fake.getAppArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnectionV2) GetAppReturns(result1 plugin_models.GetAppModel, result2 error) {
+	fake.GetAppStub = nil
+	fake.getAppReturns = struct {
+		result1 plugin_models.GetAppModel
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetApps() ([]plugin_models.GetAppsModel, error) {
+	fake.getAppsMutex.Lock()
+	fake.getAppsArgsForCall = append(fake.getAppsArgsForCall, struct{}{})
+	fake.recordInvocation("GetApps", []interface{}{})
+	fake.getAppsMutex.Unlock()
+	if fake.GetAppsStub != nil {
+		return fake.GetAppsStub()
+	} else {
+		return fake.getAppsReturns.result1, fake.getAppsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetAppsCallCount() int {
+	fake.getAppsMutex.RLock()
+	defer fake.getAppsMutex.RUnlock()
+	return len(fake.getAppsArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetAppsReturns(result1 []plugin_models.GetAppsModel, result2 error) {
+	fake.GetAppsStub = nil
+	fake.getAppsReturns = struct {
+		result1 []plugin_models.GetAppsModel
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetOrgs() ([]plugin_models.GetOrgs_Model, error) {
+	fake.getOrgsMutex.Lock()
+	fake.getOrgsArgsForCall = append(fake.getOrgsArgsForCall, struct{}{})
+	fake.recordInvocation("GetOrgs", []interface{}{})
+	fake.getOrgsMutex.Unlock()
+	if fake.GetOrgsStub != nil {
+		return fake.GetOrgsStub()
+	} else {
+		return fake.getOrgsReturns.result1, fake.getOrgsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetOrgsCallCount() int {
+	fake.getOrgsMutex.RLock()
+	defer fake.getOrgsMutex.RUnlock()
+	return len(fake.getOrgsArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetOrgsReturns(result1 []plugin_models.GetOrgs_Model, result2 error) {
+	fake.GetOrgsStub = nil
+	fake.getOrgsReturns = struct {
+		result1 []plugin_models.GetOrgs_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetSpaces() ([]plugin_models.GetSpaces_Model, error) {
+	fake.getSpacesMutex.Lock()
+	fake.getSpacesArgsForCall = append(fake.getSpacesArgsForCall, struct{}{})
+	fake.recordInvocation("GetSpaces", []interface{}{})
+	fake.getSpacesMutex.Unlock()
+	if fake.GetSpacesStub != nil {
+		return fake.GetSpacesStub()
+	} else {
+		return fake.getSpacesReturns.result1, fake.getSpacesReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetSpacesCallCount() int {
+	fake.getSpacesMutex.RLock()
+	defer fake.getSpacesMutex.RUnlock()
+	return len(fake.getSpacesArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetSpacesReturns(result1 []plugin_models.GetSpaces_Model, result2 error) {
+	fake.GetSpacesStub = nil
+	fake.getSpacesReturns = struct {
+		result1 []plugin_models.GetSpaces_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetOrgUsers(arg1 string, arg2 ...string) ([]plugin_models.GetOrgUsers_Model, error) {
+	fake.getOrgUsersMutex.Lock()
+	fake.getOrgUsersArgsForCall = append(fake.getOrgUsersArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2})
+	fake.recordInvocation("GetOrgUsers", []interface{}{arg1, arg2})
+	fake.getOrgUsersMutex.Unlock()
+	if fake.GetOrgUsersStub != nil {
+		return fake.GetOrgUsersStub(arg1, arg2...)
+	} else {
+		return fake.getOrgUsersReturns.result1, fake.getOrgUsersReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetOrgUsersCallCount() int {
+	fake.getOrgUsersMutex.RLock()
+	defer fake.getOrgUsersMutex.RUnlock()
+	return len(fake.getOrgUsersArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetOrgUsersArgsForCall(i int) (string, []string) {
+	fake.getOrgUsersMutex.RLock()
+	defer fake.getOrgUsersMutex.RUnlock()
+	return fake.getOrgUsersArgsForCall[i].arg1, fake.getOrgUsersArgsForCall[i].arg2
+}
+
+func (fake *FakeCliConnectionV2) GetOrgUsersReturns(result1 []plugin_models.GetOrgUsers_Model, result2 error) {
+	fake.GetOrgUsersStub = nil
+	fake.getOrgUsersReturns = struct {
+		result1 []plugin_models.GetOrgUsers_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetSpaceUsers(arg1 string, arg2 string) ([]plugin_models.GetSpaceUsers_Model, error) {
+	fake.getSpaceUsersMutex.Lock()
+	fake.getSpaceUsersArgsForCall = append(fake.getSpaceUsersArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetSpaceUsers", []interface{}{arg1, arg2})
+	fake.getSpaceUsersMutex.Unlock()
+	if fake.GetSpaceUsersStub != nil {
+		return fake.GetSpaceUsersStub(arg1, arg2)
+	} else {
+		return fake.getSpaceUsersReturns.result1, fake.getSpaceUsersReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetSpaceUsersCallCount() int {
+	fake.getSpaceUsersMutex.RLock()
+	defer fake.getSpaceUsersMutex.RUnlock()
+	return len(fake.getSpaceUsersArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetSpaceUsersArgsForCall(i int) (string, string) {
+	fake.getSpaceUsersMutex.RLock()
+	defer fake.getSpaceUsersMutex.RUnlock()
+	return fake.getSpaceUsersArgsForCall[i].arg1, fake.getSpaceUsersArgsForCall[i].arg2
+}
+
+func (fake *FakeCliConnectionV2) GetSpaceUsersReturns(result1 []plugin_models.GetSpaceUsers_Model, result2 error) {
+	fake.GetSpaceUsersStub = nil
+	fake.getSpaceUsersReturns = struct {
+		result1 []plugin_models.GetSpaceUsers_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetServices() ([]plugin_models.GetServices_Model, error) {
+	fake.getServicesMutex.Lock()
+	fake.getServicesArgsForCall = append(fake.getServicesArgsForCall, struct{}{})
+	fake.recordInvocation("GetServices", []interface{}{})
+	fake.getServicesMutex.Unlock()
+	if fake.GetServicesStub != nil {
+		return fake.GetServicesStub()
+	} else {
+		return fake.getServicesReturns.result1, fake.getServicesReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetServicesCallCount() int {
+	fake.getServicesMutex.RLock()
+	defer fake.getServicesMutex.RUnlock()
+	return len(fake.getServicesArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetServicesReturns(result1 []plugin_models.GetServices_Model, result2 error) {
+	fake.GetServicesStub = nil
+	fake.getServicesReturns = struct {
+		result1 []plugin_models.GetServices_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetService(arg1 string) (plugin_models.GetService_Model, error) {
+	fake.getServiceMutex.Lock()
+	fake.getServiceArgsForCall = append(fake.getServiceArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetService", []interface{}{arg1})
+	fake.getServiceMutex.Unlock()
+	if fake.GetServiceStub != nil {
+		return fake.GetServiceStub(arg1)
+	} else {
+		return fake.getServiceReturns.result1, fake.getServiceReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetServiceCallCount() int {
+	fake.getServiceMutex.RLock()
+	defer fake.getServiceMutex.RUnlock()
+	return len(fake.getServiceArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetServiceArgsForCall(i int) string {
+	fake.getServiceMutex.RLock()
+	defer fake.getServiceMutex.RUnlock()
+	return fake.getServiceArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnectionV2) GetServiceReturns(result1 plugin_models.GetService_Model, result2 error) {
+	fake.GetServiceStub = nil
+	fake.getServiceReturns = struct {
+		result1 plugin_models.GetService_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetOrg(arg1 string) (plugin_models.GetOrg_Model, error) {
+	fake.getOrgMutex.Lock()
+	fake.getOrgArgsForCall = append(fake.getOrgArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetOrg", []interface{}{arg1})
+	fake.getOrgMutex.Unlock()
+	if fake.GetOrgStub != nil {
+		return fake.GetOrgStub(arg1)
+	} else {
+		return fake.getOrgReturns.result1, fake.getOrgReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetOrgCallCount() int {
+	fake.getOrgMutex.RLock()
+	defer fake.getOrgMutex.RUnlock()
+	return len(fake.getOrgArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetOrgArgsForCall(i int) string {
+	fake.getOrgMutex.RLock()
+	defer fake.getOrgMutex.RUnlock()
+	return fake.getOrgArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnectionV2) GetOrgReturns(result1 plugin_models.GetOrg_Model, result2 error) {
+	fake.GetOrgStub = nil
+	fake.getOrgReturns = struct {
+		result1 plugin_models.GetOrg_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetSpace(arg1 string) (plugin_models.GetSpace_Model, error) {
+	fake.getSpaceMutex.Lock()
+	fake.getSpaceArgsForCall = append(fake.getSpaceArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetSpace", []interface{}{arg1})
+	fake.getSpaceMutex.Unlock()
+	if fake.GetSpaceStub != nil {
+		return fake.GetSpaceStub(arg1)
+	} else {
+		return fake.getSpaceReturns.result1, fake.getSpaceReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetSpaceCallCount() int {
+	fake.getSpaceMutex.RLock()
+	defer fake.getSpaceMutex.RUnlock()
+	return len(fake.getSpaceArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetSpaceArgsForCall(i int) string {
+	fake.getSpaceMutex.RLock()
+	defer fake.getSpaceMutex.RUnlock()
+	return fake.getSpaceArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnectionV2) GetSpaceReturns(result1 plugin_models.GetSpace_Model, result2 error) {
+	fake.GetSpaceStub = nil
+	fake.getSpaceReturns = struct {
+		result1 plugin_models.GetSpace_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) PushApp(arg1 plugin_models.PushAppParams) ([]plugin_models.PushAppModel, error) {
+	fake.pushAppMutex.Lock()
+	fake.pushAppArgsForCall = append(fake.pushAppArgsForCall, struct {
+		arg1 plugin_models.PushAppParams
+	}{arg1})
+	fake.recordInvocation("PushApp", []interface{}{arg1})
+	fake.pushAppMutex.Unlock()
+	if fake.PushAppStub != nil {
+		return fake.PushAppStub(arg1)
+	} else {
+		return fake.pushAppReturns.result1, fake.pushAppReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) PushAppCallCount() int {
+	fake.pushAppMutex.RLock()
+	defer fake.pushAppMutex.RUnlock()
+	return len(fake.pushAppArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) PushAppArgsForCall(i int) plugin_models.PushAppParams {
+	fake.pushAppMutex.RLock()
+	defer fake.pushAppMutex.RUnlock()
+	return fake.pushAppArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnectionV2) PushAppReturns(result1 []plugin_models.PushAppModel, result2 error) {
+	fake.PushAppStub = nil
+	fake.pushAppReturns = struct {
+		result1 []plugin_models.PushAppModel
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) StreamLogs(appName string, stop <-chan struct{}) (<-chan plugin_models.LogMessage, <-chan error) {
+	fake.streamLogsMutex.Lock()
+	fake.streamLogsArgsForCall = append(fake.streamLogsArgsForCall, struct {
+		appName string
+		stop    <-chan struct{}
+	}{appName, stop})
+	fake.recordInvocation("StreamLogs", []interface{}{appName, stop})
+	fake.streamLogsMutex.Unlock()
+	if fake.StreamLogsStub != nil {
+		return fake.StreamLogsStub(appName, stop)
+	} else {
+		return fake.streamLogsReturns.result1, fake.streamLogsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) StreamLogsCallCount() int {
+	fake.streamLogsMutex.RLock()
+	defer fake.streamLogsMutex.RUnlock()
+	return len(fake.streamLogsArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) StreamLogsArgsForCall(i int) (string, <-chan struct{}) {
+	fake.streamLogsMutex.RLock()
+	defer fake.streamLogsMutex.RUnlock()
+	return fake.streamLogsArgsForCall[i].appName, fake.streamLogsArgsForCall[i].stop
+}
+
+func (fake *FakeCliConnectionV2) StreamLogsReturns(result1 <-chan plugin_models.LogMessage, result2 <-chan error) {
+	fake.StreamLogsStub = nil
+	fake.streamLogsReturns = struct {
+		result1 <-chan plugin_models.LogMessage
+		result2 <-chan error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) APICapabilities() (v2models.Capabilities, error) {
+	fake.aPICapabilitiesMutex.Lock()
+	fake.aPICapabilitiesArgsForCall = append(fake.aPICapabilitiesArgsForCall, struct{}{})
+	fake.recordInvocation("APICapabilities", []interface{}{})
+	fake.aPICapabilitiesMutex.Unlock()
+	if fake.APICapabilitiesStub != nil {
+		return fake.APICapabilitiesStub()
+	} else {
+		return fake.aPICapabilitiesReturns.result1, fake.aPICapabilitiesReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) APICapabilitiesCallCount() int {
+	fake.aPICapabilitiesMutex.RLock()
+	defer fake.aPICapabilitiesMutex.RUnlock()
+	return len(fake.aPICapabilitiesArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) APICapabilitiesReturns(result1 v2models.Capabilities, result2 error) {
+	fake.APICapabilitiesStub = nil
+	fake.aPICapabilitiesReturns = struct {
+		result1 v2models.Capabilities
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetAppV2(appName string) (v2models.App, error) {
+	fake.getAppV2Mutex.Lock()
+	fake.getAppV2ArgsForCall = append(fake.getAppV2ArgsForCall, struct {
+		appName string
+	}{appName})
+	fake.recordInvocation("GetAppV2", []interface{}{appName})
+	fake.getAppV2Mutex.Unlock()
+	if fake.GetAppV2Stub != nil {
+		return fake.GetAppV2Stub(appName)
+	} else {
+		return fake.getAppV2Returns.result1, fake.getAppV2Returns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetAppV2CallCount() int {
+	fake.getAppV2Mutex.RLock()
+	defer fake.getAppV2Mutex.RUnlock()
+	return len(fake.getAppV2ArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetAppV2ArgsForCall(i int) string {
+	fake.getAppV2Mutex.RLock()
+	defer fake.getAppV2Mutex.RUnlock()
+	return fake.getAppV2ArgsForCall[i].appName
+}
+
+func (fake *FakeCliConnectionV2) GetAppV2Returns(result1 v2models.App, result2 error) {
+	fake.GetAppV2Stub = nil
+	fake.getAppV2Returns = struct {
+		result1 v2models.App
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetAppsV2() ([]v2models.App, error) {
+	fake.getAppsV2Mutex.Lock()
+	fake.getAppsV2ArgsForCall = append(fake.getAppsV2ArgsForCall, struct{}{})
+	fake.recordInvocation("GetAppsV2", []interface{}{})
+	fake.getAppsV2Mutex.Unlock()
+	if fake.GetAppsV2Stub != nil {
+		return fake.GetAppsV2Stub()
+	} else {
+		return fake.getAppsV2Returns.result1, fake.getAppsV2Returns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetAppsV2CallCount() int {
+	fake.getAppsV2Mutex.RLock()
+	defer fake.getAppsV2Mutex.RUnlock()
+	return len(fake.getAppsV2ArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetAppsV2Returns(result1 []v2models.App, result2 error) {
+	fake.GetAppsV2Stub = nil
+	fake.getAppsV2Returns = struct {
+		result1 []v2models.App
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetRoutesV2() ([]v2models.Route, error) {
+	fake.getRoutesV2Mutex.Lock()
+	fake.getRoutesV2ArgsForCall = append(fake.getRoutesV2ArgsForCall, struct{}{})
+	fake.recordInvocation("GetRoutesV2", []interface{}{})
+	fake.getRoutesV2Mutex.Unlock()
+	if fake.GetRoutesV2Stub != nil {
+		return fake.GetRoutesV2Stub()
+	} else {
+		return fake.getRoutesV2Returns.result1, fake.getRoutesV2Returns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetRoutesV2CallCount() int {
+	fake.getRoutesV2Mutex.RLock()
+	defer fake.getRoutesV2Mutex.RUnlock()
+	return len(fake.getRoutesV2ArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetRoutesV2Returns(result1 []v2models.Route, result2 error) {
+	fake.GetRoutesV2Stub = nil
+	fake.getRoutesV2Returns = struct {
+		result1 []v2models.Route
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetServiceInstancesV2() ([]v2models.ServiceInstance, error) {
+	fake.getServiceInstancesV2Mutex.Lock()
+	fake.getServiceInstancesV2ArgsForCall = append(fake.getServiceInstancesV2ArgsForCall, struct{}{})
+	fake.recordInvocation("GetServiceInstancesV2", []interface{}{})
+	fake.getServiceInstancesV2Mutex.Unlock()
+	if fake.GetServiceInstancesV2Stub != nil {
+		return fake.GetServiceInstancesV2Stub()
+	} else {
+		return fake.getServiceInstancesV2Returns.result1, fake.getServiceInstancesV2Returns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetServiceInstancesV2CallCount() int {
+	fake.getServiceInstancesV2Mutex.RLock()
+	defer fake.getServiceInstancesV2Mutex.RUnlock()
+	return len(fake.getServiceInstancesV2ArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetServiceInstancesV2Returns(result1 []v2models.ServiceInstance, result2 error) {
+	fake.GetServiceInstancesV2Stub = nil
+	fake.getServiceInstancesV2Returns = struct {
+		result1 []v2models.ServiceInstance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetOrgsV2() ([]v2models.Org, error) {
+	fake.getOrgsV2Mutex.Lock()
+	fake.getOrgsV2ArgsForCall = append(fake.getOrgsV2ArgsForCall, struct{}{})
+	fake.recordInvocation("GetOrgsV2", []interface{}{})
+	fake.getOrgsV2Mutex.Unlock()
+	if fake.GetOrgsV2Stub != nil {
+		return fake.GetOrgsV2Stub()
+	} else {
+		return fake.getOrgsV2Returns.result1, fake.getOrgsV2Returns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetOrgsV2CallCount() int {
+	fake.getOrgsV2Mutex.RLock()
+	defer fake.getOrgsV2Mutex.RUnlock()
+	return len(fake.getOrgsV2ArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetOrgsV2Returns(result1 []v2models.Org, result2 error) {
+	fake.GetOrgsV2Stub = nil
+	fake.getOrgsV2Returns = struct {
+		result1 []v2models.Org
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) GetSpacesV2() ([]v2models.Space, error) {
+	fake.getSpacesV2Mutex.Lock()
+	fake.getSpacesV2ArgsForCall = append(fake.getSpacesV2ArgsForCall, struct{}{})
+	fake.recordInvocation("GetSpacesV2", []interface{}{})
+	fake.getSpacesV2Mutex.Unlock()
+	if fake.GetSpacesV2Stub != nil {
+		return fake.GetSpacesV2Stub()
+	} else {
+		return fake.getSpacesV2Returns.result1, fake.getSpacesV2Returns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV2) GetSpacesV2CallCount() int {
+	fake.getSpacesV2Mutex.RLock()
+	defer fake.getSpacesV2Mutex.RUnlock()
+	return len(fake.getSpacesV2ArgsForCall)
+}
+
+func (fake *FakeCliConnectionV2) GetSpacesV2Returns(result1 []v2models.Space, result2 error) {
+	fake.GetSpacesV2Stub = nil
+	fake.getSpacesV2Returns = struct {
+		result1 []v2models.Space
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV2) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.cliCommandWithoutTerminalOutputMutex.RLock()
+	defer fake.cliCommandWithoutTerminalOutputMutex.RUnlock()
+	fake.cliCommandMutex.RLock()
+	defer fake.cliCommandMutex.RUnlock()
+	fake.getCurrentOrgMutex.RLock()
+	defer fake.getCurrentOrgMutex.RUnlock()
+	fake.getCurrentSpaceMutex.RLock()
+	defer fake.getCurrentSpaceMutex.RUnlock()
+	fake.usernameMutex.RLock()
+	defer fake.usernameMutex.RUnlock()
+	fake.userGuidMutex.RLock()
+	defer fake.userGuidMutex.RUnlock()
+	fake.userEmailMutex.RLock()
+	defer fake.userEmailMutex.RUnlock()
+	fake.isLoggedInMutex.RLock()
+	defer fake.isLoggedInMutex.RUnlock()
+	fake.isSSLDisabledMutex.RLock()
+	defer fake.isSSLDisabledMutex.RUnlock()
+	fake.hasOrganizationMutex.RLock()
+	defer fake.hasOrganizationMutex.RUnlock()
+	fake.hasSpaceMutex.RLock()
+	defer fake.hasSpaceMutex.RUnlock()
+	fake.apiEndpointMutex.RLock()
+	defer fake.apiEndpointMutex.RUnlock()
+	fake.apiVersionMutex.RLock()
+	defer fake.apiVersionMutex.RUnlock()
+	fake.hasAPIEndpointMutex.RLock()
+	defer fake.hasAPIEndpointMutex.RUnlock()
+	fake.loggregatorEndpointMutex.RLock()
+	defer fake.loggregatorEndpointMutex.RUnlock()
+	fake.dopplerEndpointMutex.RLock()
+	defer fake.dopplerEndpointMutex.RUnlock()
+	fake.accessTokenMutex.RLock()
+	defer fake.accessTokenMutex.RUnlock()
+	fake.getAppMutex.RLock()
+	defer fake.getAppMutex.RUnlock()
+	fake.getAppsMutex.RLock()
+	defer fake.getAppsMutex.RUnlock()
+	fake.getOrgsMutex.RLock()
+	defer fake.getOrgsMutex.RUnlock()
+	fake.getSpacesMutex.RLock()
+	defer fake.getSpacesMutex.RUnlock()
+	fake.getOrgUsersMutex.RLock()
+	defer fake.getOrgUsersMutex.RUnlock()
+	fake.getSpaceUsersMutex.RLock()
+	defer fake.getSpaceUsersMutex.RUnlock()
+	fake.getServicesMutex.RLock()
+	defer fake.getServicesMutex.RUnlock()
+	fake.getServiceMutex.RLock()
+	defer fake.getServiceMutex.RUnlock()
+	fake.getOrgMutex.RLock()
+	defer fake.getOrgMutex.RUnlock()
+	fake.getSpaceMutex.RLock()
+	defer fake.getSpaceMutex.RUnlock()
+	fake.pushAppMutex.RLock()
+	defer fake.pushAppMutex.RUnlock()
+	fake.streamLogsMutex.RLock()
+	defer fake.streamLogsMutex.RUnlock()
+	fake.aPICapabilitiesMutex.RLock()
+	defer fake.aPICapabilitiesMutex.RUnlock()
+	fake.getAppV2Mutex.RLock()
+	defer fake.getAppV2Mutex.RUnlock()
+	fake.getAppsV2Mutex.RLock()
+	defer fake.getAppsV2Mutex.RUnlock()
+	fake.getRoutesV2Mutex.RLock()
+	defer fake.getRoutesV2Mutex.RUnlock()
+	fake.getServiceInstancesV2Mutex.RLock()
+	defer fake.getServiceInstancesV2Mutex.RUnlock()
+	fake.getOrgsV2Mutex.RLock()
+	defer fake.getOrgsV2Mutex.RUnlock()
+	fake.getSpacesV2Mutex.RLock()
+	defer fake.getSpacesV2Mutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeCliConnectionV2) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ plugin.CliConnectionV2 = new(FakeCliConnectionV2)
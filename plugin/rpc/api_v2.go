@@ -0,0 +1,248 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/plugin/v2models"
+)
+
+// The methods of version 2 of the plugin API return their models as JSON,
+// so that fields can be added to the models without breaking plugins built
+// against older ones. Plugins find out whether the CLI has them with
+// APICapabilities.
+
+const pluginAPIVersion = 2
+
+func (cmd *CliRpcCmd) APICapabilities(_ string, retVal *[]byte) error {
+	return marshalModel(v2models.Capabilities{
+		APIVersion: pluginAPIVersion,
+		CLIVersion: cf.Version,
+		Resources: []string{
+			v2models.ResourceApps,
+			v2models.ResourceRoutes,
+			v2models.ResourceServiceInstances,
+			v2models.ResourceOrgs,
+			v2models.ResourceSpaces,
+		},
+	}, retVal)
+}
+
+func (cmd *CliRpcCmd) GetAppV2(appName string, retVal *[]byte) error {
+	err := cmd.requireTarget(true)
+	if err != nil {
+		return err
+	}
+
+	app, err := cmd.repoLocator.GetApplicationRepository().Read(appName)
+	if err != nil {
+		return err
+	}
+
+	summary, err := cmd.repoLocator.GetAppSummaryRepository().GetSummary(app.GUID)
+	if err != nil {
+		return err
+	}
+
+	return marshalModel(appModel(summary), retVal)
+}
+
+func (cmd *CliRpcCmd) GetAppsV2(_ string, retVal *[]byte) error {
+	err := cmd.requireTarget(true)
+	if err != nil {
+		return err
+	}
+
+	summaries, err := cmd.repoLocator.GetAppSummaryRepository().GetSummariesInCurrentSpace()
+	if err != nil {
+		return err
+	}
+
+	apps := []v2models.App{}
+	for _, summary := range summaries {
+		apps = append(apps, appModel(summary))
+	}
+	return marshalModel(apps, retVal)
+}
+
+func (cmd *CliRpcCmd) GetRoutesV2(_ string, retVal *[]byte) error {
+	err := cmd.requireTarget(true)
+	if err != nil {
+		return err
+	}
+
+	routes := []v2models.Route{}
+	err = cmd.repoLocator.GetRouteRepository().ListRoutes(func(route models.Route) bool {
+		model := routeModel(models.RouteSummary{
+			GUID:   route.GUID,
+			Host:   route.Host,
+			Domain: route.Domain,
+			Path:   route.Path,
+			Port:   route.Port,
+		})
+		for _, app := range route.Apps {
+			model.Apps = append(model.Apps, app.Name)
+		}
+		model.ServiceInstance = route.ServiceInstance.Name
+		routes = append(routes, model)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	return marshalModel(routes, retVal)
+}
+
+func (cmd *CliRpcCmd) GetServiceInstancesV2(_ string, retVal *[]byte) error {
+	err := cmd.requireTarget(true)
+	if err != nil {
+		return err
+	}
+
+	instances, err := cmd.repoLocator.GetServiceSummaryRepository().GetSummariesInCurrentSpace()
+	if err != nil {
+		return err
+	}
+
+	serviceInstances := []v2models.ServiceInstance{}
+	for _, instance := range instances {
+		model := v2models.ServiceInstance{
+			GUID:         instance.GUID,
+			Name:         instance.Name,
+			Service:      instance.ServiceOffering.Label,
+			Plan:         instance.ServicePlan.Name,
+			UserProvided: instance.IsUserProvided(),
+			Tags:         instance.Tags,
+			DashboardURL: instance.DashboardURL,
+			Apps:         instance.ApplicationNames,
+		}
+		if model.Apps == nil {
+			model.Apps = []string{}
+		}
+		if instance.LastOperation.Type != "" {
+			model.LastOperation = &v2models.LastOperation{
+				Type:        instance.LastOperation.Type,
+				State:       instance.LastOperation.State,
+				Description: instance.LastOperation.Description,
+			}
+		}
+		serviceInstances = append(serviceInstances, model)
+	}
+	return marshalModel(serviceInstances, retVal)
+}
+
+func (cmd *CliRpcCmd) GetOrgsV2(_ string, retVal *[]byte) error {
+	err := cmd.requireTarget(false)
+	if err != nil {
+		return err
+	}
+
+	orgs, err := cmd.repoLocator.GetOrganizationRepository().ListOrgs(0)
+	if err != nil {
+		return err
+	}
+
+	orgModels := []v2models.Org{}
+	for _, org := range orgs {
+		orgModels = append(orgModels, v2models.Org{
+			GUID:  org.GUID,
+			Name:  org.Name,
+			Quota: org.QuotaDefinition.Name,
+		})
+	}
+	return marshalModel(orgModels, retVal)
+}
+
+func (cmd *CliRpcCmd) GetSpacesV2(_ string, retVal *[]byte) error {
+	err := cmd.requireTarget(false)
+	if err != nil {
+		return err
+	}
+	if !cmd.cliConfig.HasOrganization() {
+		return errors.New("No org targeted")
+	}
+
+	spaces := []v2models.Space{}
+	err = cmd.repoLocator.GetSpaceRepository().ListSpaces(func(space models.Space) bool {
+		spaces = append(spaces, v2models.Space{
+			GUID:     space.GUID,
+			Name:     space.Name,
+			AllowSSH: space.AllowSSH,
+		})
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	return marshalModel(spaces, retVal)
+}
+
+// requireTarget returns an error when the user is not logged in or, if
+// space is true, has not targeted a space.
+func (cmd *CliRpcCmd) requireTarget(space bool) error {
+	if !cmd.cliConfig.IsLoggedIn() {
+		return errors.New("Not logged in")
+	}
+	if space && !cmd.cliConfig.HasSpace() {
+		return errors.New("No space targeted")
+	}
+	return nil
+}
+
+func marshalModel(model interface{}, retVal *[]byte) error {
+	data, err := json.Marshal(model)
+	if err != nil {
+		return err
+	}
+
+	*retVal = data
+	return nil
+}
+
+func appModel(app models.Application) v2models.App {
+	model := v2models.App{
+		GUID:             app.GUID,
+		Name:             app.Name,
+		State:            app.State,
+		Instances:        app.InstanceCount,
+		RunningInstances: app.RunningInstances,
+		MemoryInMB:       app.Memory,
+		DiskQuotaInMB:    app.DiskQuota,
+		Buildpack:        app.Buildpack,
+		DockerImage:      app.DockerImage,
+		Command:          app.Command,
+		HealthCheckType:  app.HealthCheckType,
+		PackageUpdatedAt: app.PackageUpdatedAt,
+		Routes:           []v2models.Route{},
+		ServiceInstances: []string{},
+	}
+	if model.Buildpack == "" {
+		model.Buildpack = app.DetectedBuildpack
+	}
+	if app.Stack != nil {
+		model.Stack = app.Stack.Name
+	}
+
+	for _, route := range app.Routes {
+		model.Routes = append(model.Routes, routeModel(route))
+	}
+	for _, service := range app.Services {
+		model.ServiceInstances = append(model.ServiceInstances, service.Name)
+	}
+	return model
+}
+
+func routeModel(route models.RouteSummary) v2models.Route {
+	return v2models.Route{
+		GUID:   route.GUID,
+		URL:    route.URL(),
+		Host:   route.Host,
+		Domain: route.Domain.Name,
+		Path:   route.Path,
+		Port:   route.Port,
+	}
+}
@@ -0,0 +1,259 @@
+package rpc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/rpc"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
+	"code.cloudfoundry.org/cli/cf/api/organizations/organizationsfakes"
+	"code.cloudfoundry.org/cli/cf/api/spaces/spacesfakes"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/models"
+	. "code.cloudfoundry.org/cli/plugin/rpc"
+	"code.cloudfoundry.org/cli/plugin/v2models"
+	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Plugin API v2", func() {
+	var (
+		client         *rpc.Client
+		config         coreconfig.Repository
+		appRepo        *applicationsfakes.FakeRepository
+		appSummaryRepo *apifakes.FakeAppSummaryRepository
+		routeRepo      *apifakes.FakeRouteRepository
+		serviceRepo    *apifakes.FakeServiceSummaryRepository
+		orgRepo        *organizationsfakes.FakeOrganizationRepository
+		spaceRepo      *spacesfakes.FakeSpaceRepository
+	)
+
+	call := func(method string, args string, result interface{}) error {
+		var data []byte
+		err := client.Call("CliRpcCmd."+method, args, &data)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, result)
+	}
+
+	BeforeEach(func() {
+		rpc.DefaultServer = rpc.NewServer()
+		config = testconfig.NewRepositoryWithDefaults()
+
+		appRepo = new(applicationsfakes.FakeRepository)
+		appSummaryRepo = new(apifakes.FakeAppSummaryRepository)
+		routeRepo = new(apifakes.FakeRouteRepository)
+		serviceRepo = new(apifakes.FakeServiceSummaryRepository)
+		orgRepo = new(organizationsfakes.FakeOrganizationRepository)
+		spaceRepo = new(spacesfakes.FakeSpaceRepository)
+	})
+
+	JustBeforeEach(func() {
+		locator := api.RepositoryLocator{}
+		locator = locator.SetApplicationRepository(appRepo)
+		locator = locator.SetAppSummaryRepository(appSummaryRepo)
+		locator = locator.SetRouteRepository(routeRepo)
+		locator = locator.SetServiceSummaryRepository(serviceRepo)
+		locator = locator.SetOrganizationRepository(orgRepo)
+		locator = locator.SetSpaceRepository(spaceRepo)
+
+		var err error
+		rpcService, err = NewRpcService(nil, nil, config, locator, nil, nil, nil, rpc.DefaultServer)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = rpcService.Start()
+		Expect(err).ToNot(HaveOccurred())
+
+		pingCli(rpcService.Port())
+
+		client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		client.Close()
+		rpcService.Stop()
+
+		//give time for server to stop
+		time.Sleep(50 * time.Millisecond)
+	})
+
+	It("returns the capabilities of the CLI", func() {
+		var capabilities v2models.Capabilities
+		Expect(call("APICapabilities", "", &capabilities)).To(Succeed())
+
+		Expect(capabilities.APIVersion).To(Equal(2))
+		Expect(capabilities.CLIVersion).To(Equal(cf.Version))
+		Expect(capabilities.Supports(v2models.ResourceApps)).To(BeTrue())
+		Expect(capabilities.Supports(v2models.ResourceServiceInstances)).To(BeTrue())
+		Expect(capabilities.Supports("droplets")).To(BeFalse())
+	})
+
+	Describe("apps", func() {
+		BeforeEach(func() {
+			app := models.Application{}
+			app.GUID = "app-guid"
+			app.Name = "app-name"
+			app.State = "started"
+			app.InstanceCount = 2
+			app.RunningInstances = 1
+			app.Memory = 256
+			app.DiskQuota = 1024
+			app.DetectedBuildpack = "go_buildpack"
+			app.Stack = &models.Stack{Name: "cflinuxfs2"}
+			app.Routes = []models.RouteSummary{
+				{GUID: "route-guid", Host: "app-name", Domain: models.DomainFields{Name: "example.com"}, Path: "/api"},
+			}
+			app.Services = []models.ServicePlanSummary{{Name: "db"}}
+
+			appRepo.ReadReturns(models.Application{ApplicationFields: models.ApplicationFields{GUID: "app-guid"}}, nil)
+			appSummaryRepo.GetSummaryReturns(app, nil)
+			appSummaryRepo.GetSummariesInCurrentSpaceReturns([]models.Application{app}, nil)
+		})
+
+		It("returns an app by name", func() {
+			var app v2models.App
+			Expect(call("GetAppV2", "app-name", &app)).To(Succeed())
+
+			Expect(appRepo.ReadArgsForCall(0)).To(Equal("app-name"))
+			Expect(appSummaryRepo.GetSummaryArgsForCall(0)).To(Equal("app-guid"))
+			Expect(app).To(Equal(v2models.App{
+				GUID:             "app-guid",
+				Name:             "app-name",
+				State:            "started",
+				Instances:        2,
+				RunningInstances: 1,
+				MemoryInMB:       256,
+				DiskQuotaInMB:    1024,
+				Buildpack:        "go_buildpack",
+				Stack:            "cflinuxfs2",
+				Routes: []v2models.Route{
+					{GUID: "route-guid", URL: "app-name.example.com/api", Host: "app-name", Domain: "example.com", Path: "/api"},
+				},
+				ServiceInstances: []string{"db"},
+			}))
+		})
+
+		It("returns the apps of the targeted space", func() {
+			var apps []v2models.App
+			Expect(call("GetAppsV2", "", &apps)).To(Succeed())
+
+			Expect(apps).To(HaveLen(1))
+			Expect(apps[0].Name).To(Equal("app-name"))
+		})
+
+		It("returns the error of the repository", func() {
+			appRepo.ReadReturns(models.Application{}, errors.New("app not found"))
+
+			var app v2models.App
+			Expect(call("GetAppV2", "app-name", &app)).To(MatchError("app not found"))
+		})
+
+		Context("when no space is targeted", func() {
+			BeforeEach(func() {
+				config.SetSpaceFields(models.SpaceFields{})
+			})
+
+			It("fails", func() {
+				var apps []v2models.App
+				Expect(call("GetAppsV2", "", &apps)).To(MatchError("No space targeted"))
+				Expect(appSummaryRepo.GetSummariesInCurrentSpaceCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	It("returns the routes of the targeted space with their apps", func() {
+		routeRepo.ListRoutesStub = func(cb func(models.Route) bool) error {
+			cb(models.Route{
+				GUID:   "route-guid",
+				Host:   "www",
+				Domain: models.DomainFields{Name: "example.com"},
+				Apps: []models.ApplicationFields{
+					{Name: "app1"},
+					{Name: "app2"},
+				},
+				ServiceInstance: models.ServiceInstanceFields{Name: "route-service"},
+			})
+			return nil
+		}
+
+		var routes []v2models.Route
+		Expect(call("GetRoutesV2", "", &routes)).To(Succeed())
+		Expect(routes).To(Equal([]v2models.Route{{
+			GUID:            "route-guid",
+			URL:             "www.example.com",
+			Host:            "www",
+			Domain:          "example.com",
+			Apps:            []string{"app1", "app2"},
+			ServiceInstance: "route-service",
+		}}))
+	})
+
+	It("returns the service instances of the targeted space", func() {
+		instance := models.ServiceInstance{}
+		instance.GUID = "instance-guid"
+		instance.Name = "db"
+		instance.ApplicationNames = []string{"app1"}
+		instance.ServiceOffering.Label = "postgres"
+		instance.ServicePlan = models.ServicePlanFields{GUID: "plan-guid", Name: "small"}
+		instance.LastOperation = models.LastOperationFields{Type: "create", State: "succeeded"}
+		serviceRepo.GetSummariesInCurrentSpaceReturns([]models.ServiceInstance{instance}, nil)
+
+		var instances []v2models.ServiceInstance
+		Expect(call("GetServiceInstancesV2", "", &instances)).To(Succeed())
+		Expect(instances).To(Equal([]v2models.ServiceInstance{{
+			GUID:          "instance-guid",
+			Name:          "db",
+			Service:       "postgres",
+			Plan:          "small",
+			LastOperation: &v2models.LastOperation{Type: "create", State: "succeeded"},
+			Apps:          []string{"app1"},
+		}}))
+	})
+
+	It("returns the orgs", func() {
+		org := models.Organization{}
+		org.GUID = "org-guid"
+		org.Name = "my-org"
+		org.QuotaDefinition.Name = "default"
+		orgRepo.ListOrgsReturns([]models.Organization{org}, nil)
+
+		var orgs []v2models.Org
+		Expect(call("GetOrgsV2", "", &orgs)).To(Succeed())
+		Expect(orgRepo.ListOrgsArgsForCall(0)).To(BeZero())
+		Expect(orgs).To(Equal([]v2models.Org{{GUID: "org-guid", Name: "my-org", Quota: "default"}}))
+	})
+
+	It("returns the spaces of the targeted org", func() {
+		spaceRepo.ListSpacesStub = func(cb func(models.Space) bool) error {
+			space := models.Space{}
+			space.GUID = "space-guid"
+			space.Name = "my-space"
+			space.AllowSSH = true
+			cb(space)
+			return nil
+		}
+
+		var spaces []v2models.Space
+		Expect(call("GetSpacesV2", "", &spaces)).To(Succeed())
+		Expect(spaces).To(Equal([]v2models.Space{{GUID: "space-guid", Name: "my-space", AllowSSH: true}}))
+	})
+
+	Context("when not logged in", func() {
+		BeforeEach(func() {
+			config = testconfig.NewRepository()
+		})
+
+		It("fails", func() {
+			var orgs []v2models.Org
+			Expect(call("GetOrgsV2", "", &orgs)).To(MatchError("Not logged in"))
+			Expect(orgRepo.ListOrgsCallCount()).To(BeZero())
+		})
+	})
+})
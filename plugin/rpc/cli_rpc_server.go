@@ -1,11 +1,14 @@
 package rpc
 
 import (
+	"errors"
 	"os"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf"
 	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/logs"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/terminal"
@@ -28,6 +31,10 @@ import (
 
 var dialTimeout = os.Getenv("CF_DIAL_TIMEOUT")
 
+// logStreamWait is how long ReadLogStream waits for log messages to arrive
+// before it returns none, so that plugins do not poll in a busy loop.
+var logStreamWait = 1 * time.Second
+
 type CliRpcService struct {
 	listener net.Listener
 	stopCh   chan struct{}
@@ -47,6 +54,9 @@ type CliRpcCmd struct {
 	outputBucket         *bytes.Buffer
 	logger               trace.Printer
 	stdout               io.Writer
+	logStream            *logStream
+	logStreamMutex       sync.Mutex
+	sandbox              *pluginSandbox
 }
 
 //go:generate counterfeiter . TerminalOutputSwitch
@@ -177,7 +187,7 @@ func (cmd *CliRpcCmd) CallCoreCommand(args []string, retVal *bool) error {
 	cmd.outputCapture.SetOutputBucket(cmd.outputBucket)
 
 	if cmdRegistry.CommandExists(args[0]) {
-		deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
+		deps := cmd.newDependency()
 
 		//set deps objs to be the one used by all other commands
 		//once all commands are converted, we can make fresh deps for each command run
@@ -202,6 +212,15 @@ func (cmd *CliRpcCmd) CallCoreCommand(args []string, retVal *bool) error {
 	return nil
 }
 
+// newDependency makes the dependencies of a command run for the plugin, which
+// read the sandbox config when the plugin is sandboxed.
+func (cmd *CliRpcCmd) newDependency() commandregistry.Dependency {
+	if cmd.sandbox != nil {
+		return commandregistry.NewDependencyWithConfig(cmd.stdout, cmd.logger, dialTimeout, cmd.sandbox.configPath, "")
+	}
+	return commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
+}
+
 func (cmd *CliRpcCmd) GetOutputAndReset(args bool, retVal *[]string) error {
 	v := strings.TrimSuffix(cmd.outputBucket.String(), "\n")
 	*retVal = strings.Split(v, "\n")
@@ -294,6 +313,18 @@ func (cmd *CliRpcCmd) DopplerEndpoint(args string, retVal *string) error {
 }
 
 func (cmd *CliRpcCmd) AccessToken(args string, retVal *string) error {
+	if cmd.sandbox != nil {
+		token, err := cmd.sandbox.deriveToken()
+		if err != nil {
+			return err
+		}
+
+		cmd.cliConfig.SetAccessToken(token)
+		*retVal = token
+
+		return nil
+	}
+
 	token, err := cmd.repoLocator.GetAuthenticationRepository().RefreshAuthToken()
 	if err != nil {
 		return err
@@ -305,7 +336,7 @@ func (cmd *CliRpcCmd) AccessToken(args string, retVal *string) error {
 }
 
 func (cmd *CliRpcCmd) GetApp(appName string, retVal *plugin_models.GetAppModel) error {
-	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
+	deps := cmd.newDependency()
 
 	//set deps objs to be the one used by all other commands
 	//once all commands are converted, we can make fresh deps for each command run
@@ -319,7 +350,7 @@ func (cmd *CliRpcCmd) GetApp(appName string, retVal *plugin_models.GetAppModel)
 }
 
 func (cmd *CliRpcCmd) GetApps(_ string, retVal *[]plugin_models.GetAppsModel) error {
-	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
+	deps := cmd.newDependency()
 
 	//set deps objs to be the one used by all other commands
 	//once all commands are converted, we can make fresh deps for each command run
@@ -333,7 +364,7 @@ func (cmd *CliRpcCmd) GetApps(_ string, retVal *[]plugin_models.GetAppsModel) er
 }
 
 func (cmd *CliRpcCmd) GetOrgs(_ string, retVal *[]plugin_models.GetOrgs_Model) error {
-	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
+	deps := cmd.newDependency()
 
 	//set deps objs to be the one used by all other commands
 	//once all commands are converted, we can make fresh deps for each command run
@@ -347,7 +378,7 @@ func (cmd *CliRpcCmd) GetOrgs(_ string, retVal *[]plugin_models.GetOrgs_Model) e
 }
 
 func (cmd *CliRpcCmd) GetSpaces(_ string, retVal *[]plugin_models.GetSpaces_Model) error {
-	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
+	deps := cmd.newDependency()
 
 	//set deps objs to be the one used by all other commands
 	//once all commands are converted, we can make fresh deps for each command run
@@ -361,7 +392,7 @@ func (cmd *CliRpcCmd) GetSpaces(_ string, retVal *[]plugin_models.GetSpaces_Mode
 }
 
 func (cmd *CliRpcCmd) GetServices(_ string, retVal *[]plugin_models.GetServices_Model) error {
-	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
+	deps := cmd.newDependency()
 
 	//set deps objs to be the one used by all other commands
 	//once all commands are converted, we can make fresh deps for each command run
@@ -376,7 +407,7 @@ func (cmd *CliRpcCmd) GetServices(_ string, retVal *[]plugin_models.GetServices_
 }
 
 func (cmd *CliRpcCmd) GetOrgUsers(args []string, retVal *[]plugin_models.GetOrgUsers_Model) error {
-	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
+	deps := cmd.newDependency()
 
 	//set deps objs to be the one used by all other commands
 	//once all commands are converted, we can make fresh deps for each command run
@@ -390,7 +421,7 @@ func (cmd *CliRpcCmd) GetOrgUsers(args []string, retVal *[]plugin_models.GetOrgU
 }
 
 func (cmd *CliRpcCmd) GetSpaceUsers(args []string, retVal *[]plugin_models.GetSpaceUsers_Model) error {
-	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
+	deps := cmd.newDependency()
 
 	//set deps objs to be the one used by all other commands
 	//once all commands are converted, we can make fresh deps for each command run
@@ -404,7 +435,7 @@ func (cmd *CliRpcCmd) GetSpaceUsers(args []string, retVal *[]plugin_models.GetSp
 }
 
 func (cmd *CliRpcCmd) GetOrg(orgName string, retVal *plugin_models.GetOrg_Model) error {
-	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
+	deps := cmd.newDependency()
 
 	//set deps objs to be the one used by all other commands
 	//once all commands are converted, we can make fresh deps for each command run
@@ -418,7 +449,7 @@ func (cmd *CliRpcCmd) GetOrg(orgName string, retVal *plugin_models.GetOrg_Model)
 }
 
 func (cmd *CliRpcCmd) GetSpace(spaceName string, retVal *plugin_models.GetSpace_Model) error {
-	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
+	deps := cmd.newDependency()
 
 	//set deps objs to be the one used by all other commands
 	//once all commands are converted, we can make fresh deps for each command run
@@ -432,7 +463,7 @@ func (cmd *CliRpcCmd) GetSpace(spaceName string, retVal *plugin_models.GetSpace_
 }
 
 func (cmd *CliRpcCmd) GetService(serviceInstance string, retVal *plugin_models.GetService_Model) error {
-	deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
+	deps := cmd.newDependency()
 
 	//set deps objs to be the one used by all other commands
 	//once all commands are converted, we can make fresh deps for each command run
@@ -444,3 +475,122 @@ func (cmd *CliRpcCmd) GetService(serviceInstance string, retVal *plugin_models.G
 
 	return cmd.newCmdRunner.Command([]string{"service", serviceInstance}, deps, true)
 }
+
+func (cmd *CliRpcCmd) PushApp(params plugin_models.PushAppParams, retVal *[]plugin_models.PushAppModel) error {
+	deps := cmd.newDependency()
+
+	//set deps objs to be the one used by all other commands
+	//once all commands are converted, we can make fresh deps for each command run
+	deps.Config = cmd.cliConfig
+	deps.RepoLocator = cmd.repoLocator
+	deps.PluginModels.PushedApps = retVal
+	cmd.terminalOutputSwitch.DisableTerminalOutput(!params.ShowOutput)
+	deps.UI = terminal.NewUI(os.Stdin, cmd.stdout, cmd.terminalOutputSwitch.(*terminal.TeePrinter), cmd.logger)
+
+	return cmd.newCmdRunner.Command(pushArgs(params), deps, true)
+}
+
+// pushArgs returns the arguments of the push command for params.
+func pushArgs(params plugin_models.PushAppParams) []string {
+	args := []string{"push"}
+	if params.AppName != "" {
+		args = append(args, params.AppName)
+	}
+	if params.ManifestPath != "" {
+		args = append(args, "-f", params.ManifestPath)
+	}
+	if params.NoManifest {
+		args = append(args, "--no-manifest")
+	}
+	if params.Path != "" {
+		args = append(args, "-p", params.Path)
+	}
+	for _, buildpack := range params.Buildpacks {
+		args = append(args, "-b", buildpack)
+	}
+	if params.DockerImage != "" {
+		args = append(args, "--docker-image", params.DockerImage)
+	}
+	if params.Instances > 0 {
+		args = append(args, "-i", strconv.Itoa(params.Instances))
+	}
+	if params.Memory != "" {
+		args = append(args, "-m", params.Memory)
+	}
+	if params.DiskQuota != "" {
+		args = append(args, "-k", params.DiskQuota)
+	}
+	if params.Stack != "" {
+		args = append(args, "-s", params.Stack)
+	}
+	if params.Strategy != "" {
+		args = append(args, "--strategy", params.Strategy)
+	}
+	if params.NoRoute {
+		args = append(args, "--no-route")
+	}
+	if params.NoStart {
+		args = append(args, "--no-start")
+	}
+	return args
+}
+
+// StartLogStream starts tailing the logs of an app for ReadLogStream to
+// return. A plugin can tail the logs of one app at a time.
+func (cmd *CliRpcCmd) StartLogStream(appName string, retVal *bool) error {
+	cmd.logStreamMutex.Lock()
+	defer cmd.logStreamMutex.Unlock()
+
+	if cmd.logStream != nil {
+		return errors.New("A log stream is already open; stop it before starting another")
+	}
+
+	app, err := cmd.repoLocator.GetApplicationRepository().Read(appName)
+	if err != nil {
+		return err
+	}
+
+	logChan := make(chan logs.Loggable)
+	errChan := make(chan error)
+	go cmd.repoLocator.GetLogsRepository().TailLogsFor(app.GUID, func() {}, logChan, errChan)
+
+	cmd.logStream = newLogStream()
+	go cmd.logStream.tail(logChan, errChan)
+
+	*retVal = true
+	return nil
+}
+
+func (cmd *CliRpcCmd) ReadLogStream(_ string, retVal *plugin_models.LogStreamBatch) error {
+	cmd.logStreamMutex.Lock()
+	stream := cmd.logStream
+	cmd.logStreamMutex.Unlock()
+
+	if stream == nil {
+		return errors.New("No log stream is open")
+	}
+
+	messages, ended, err := stream.read(logStreamWait)
+	if err != nil && len(messages) == 0 {
+		return err
+	}
+
+	// the error is returned by the next read, once the plugin has the
+	// messages that arrived before it
+	retVal.Messages = messages
+	retVal.Ended = ended && err == nil
+	return nil
+}
+
+func (cmd *CliRpcCmd) StopLogStream(_ string, retVal *bool) error {
+	cmd.logStreamMutex.Lock()
+	defer cmd.logStreamMutex.Unlock()
+
+	if cmd.logStream != nil {
+		cmd.repoLocator.GetLogsRepository().Close()
+		cmd.logStream = nil
+	}
+
+	*retVal = true
+	return nil
+}
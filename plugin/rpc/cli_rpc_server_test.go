@@ -2,14 +2,20 @@ package rpc_test
 
 import (
 	"errors"
+	"io/ioutil"
 	"net"
 	"net/rpc"
 	"os"
+	"path/filepath"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf"
 	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
 	"code.cloudfoundry.org/cli/cf/api/authentication/authenticationfakes"
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	"code.cloudfoundry.org/cli/cf/api/logs/logsfakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/terminal"
@@ -20,6 +26,8 @@ import (
 	. "code.cloudfoundry.org/cli/plugin/rpc/fakecommand"
 	"code.cloudfoundry.org/cli/plugin/rpc/rpcfakes"
 	testconfig "code.cloudfoundry.org/cli/testhelpers/configuration"
+	"github.com/cloudfoundry/sonde-go/events"
+	"github.com/gogo/protobuf/proto"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -419,6 +427,178 @@ var _ = Describe("Server", func() {
 			Expect(pluginApiCall).To(BeTrue())
 		})
 
+		It("calls PushApp() with the push arguments of the params and returns the pushed apps", func() {
+			runner.CommandStub = func(_ []string, deps commandregistry.Dependency, _ bool) error {
+				*deps.PluginModels.PushedApps = append(*deps.PluginModels.PushedApps, plugin_models.PushAppModel{
+					Name:   "fake-app",
+					Guid:   "fake-app-guid",
+					State:  "started",
+					Routes: []string{"fake-app.example.com"},
+				})
+				return nil
+			}
+
+			result := []plugin_models.PushAppModel{}
+			err = client.Call("CliRpcCmd.PushApp", plugin_models.PushAppParams{
+				AppName:    "fake-app",
+				Path:       "/some/app",
+				Buildpacks: []string{"go_buildpack", "binary_buildpack"},
+				Instances:  2,
+				Memory:     "256M",
+				NoStart:    true,
+			}, &result)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(runner.CommandCallCount()).To(Equal(1))
+			arg1, _, pluginApiCall := runner.CommandArgsForCall(0)
+			Expect(arg1).To(Equal([]string{
+				"push", "fake-app",
+				"-p", "/some/app",
+				"-b", "go_buildpack", "-b", "binary_buildpack",
+				"-i", "2",
+				"-m", "256M",
+				"--no-start",
+			}))
+			Expect(pluginApiCall).To(BeTrue())
+
+			Expect(result).To(Equal([]plugin_models.PushAppModel{{
+				Name:   "fake-app",
+				Guid:   "fake-app-guid",
+				State:  "started",
+				Routes: []string{"fake-app.example.com"},
+			}}))
+		})
+
+		It("returns the error of PushApp()", func() {
+			runner.CommandReturns(errors.New("push-error"))
+
+			result := []plugin_models.PushAppModel{}
+			err = client.Call("CliRpcCmd.PushApp", plugin_models.PushAppParams{AppName: "fake-app"}, &result)
+			Expect(err).To(MatchError("push-error"))
+		})
+	})
+
+	Describe("log streams", func() {
+		var (
+			appRepo  *applicationsfakes.FakeRepository
+			logsRepo *logsfakes.FakeRepository
+			started  bool
+		)
+
+		readAll := func() ([]plugin_models.LogMessage, error) {
+			messages := []plugin_models.LogMessage{}
+			for {
+				var batch plugin_models.LogStreamBatch
+				err := client.Call("CliRpcCmd.ReadLogStream", "", &batch)
+				if err != nil {
+					return messages, err
+				}
+				messages = append(messages, batch.Messages...)
+				if batch.Ended {
+					return messages, nil
+				}
+			}
+		}
+
+		BeforeEach(func() {
+			appRepo = new(applicationsfakes.FakeRepository)
+			appRepo.ReadReturns(models.Application{ApplicationFields: models.ApplicationFields{Name: "fake-app", GUID: "fake-app-guid"}}, nil)
+
+			logsRepo = new(logsfakes.FakeRepository)
+			logsRepo.TailLogsForStub = func(appGUID string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+				logChan <- logs.NewNoaaLogMessage(noaaLogMessage("message 1", events.LogMessage_OUT))
+				logChan <- logs.NewNoaaLogMessage(noaaLogMessage("message 2", events.LogMessage_ERR))
+				close(logChan)
+				close(errChan)
+			}
+
+			locator := api.RepositoryLocator{}
+			locator = locator.SetApplicationRepository(appRepo)
+			locator = locator.SetLogsRepository(logsRepo)
+
+			rpcService, err = NewRpcService(nil, nil, nil, locator, nil, nil, nil, rpc.DefaultServer)
+			Expect(err).ToNot(HaveOccurred())
+
+			err := rpcService.Start()
+			Expect(err).ToNot(HaveOccurred())
+
+			pingCli(rpcService.Port())
+
+			client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			rpcService.Stop()
+
+			//give time for server to stop
+			time.Sleep(50 * time.Millisecond)
+		})
+
+		It("tails the logs of the app and returns its messages until the stream ends", func() {
+			err = client.Call("CliRpcCmd.StartLogStream", "fake-app", &started)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			Expect(appRepo.ReadArgsForCall(0)).To(Equal("fake-app"))
+
+			messages, err := readAll()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(messages).To(HaveLen(2))
+			Expect(messages[0].Message).To(Equal("message 1"))
+			Expect(messages[0].MessageType).To(Equal("OUT"))
+			Expect(messages[0].AppGuid).To(Equal("fake-app-guid"))
+			Expect(messages[0].SourceType).To(Equal("APP"))
+			Expect(messages[1].Message).To(Equal("message 2"))
+			Expect(messages[1].MessageType).To(Equal("ERR"))
+
+			appGUID, _, _, _ := logsRepo.TailLogsForArgsForCall(0)
+			Expect(appGUID).To(Equal("fake-app-guid"))
+		})
+
+		It("returns the error that ends the stream after the messages before it", func() {
+			logsRepo.TailLogsForStub = func(appGUID string, onConnect func(), logChan chan<- logs.Loggable, errChan chan<- error) {
+				logChan <- logs.NewNoaaLogMessage(noaaLogMessage("message 1", events.LogMessage_OUT))
+				errChan <- errors.New("connection lost")
+			}
+
+			err = client.Call("CliRpcCmd.StartLogStream", "fake-app", &started)
+			Expect(err).ToNot(HaveOccurred())
+
+			messages, err := readAll()
+			Expect(err).To(MatchError("connection lost"))
+			Expect(messages).To(HaveLen(1))
+		})
+
+		It("fails when the app cannot be found", func() {
+			appRepo.ReadReturns(models.Application{}, errors.New("app not found"))
+
+			err = client.Call("CliRpcCmd.StartLogStream", "fake-app", &started)
+			Expect(err).To(MatchError("app not found"))
+			Expect(logsRepo.TailLogsForCallCount()).To(BeZero())
+		})
+
+		It("allows one stream at a time until it is stopped", func() {
+			err = client.Call("CliRpcCmd.StartLogStream", "fake-app", &started)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = client.Call("CliRpcCmd.StartLogStream", "fake-app", &started)
+			Expect(err).To(MatchError("A log stream is already open; stop it before starting another"))
+
+			var stopped bool
+			err = client.Call("CliRpcCmd.StopLogStream", "", &stopped)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(logsRepo.CloseCallCount()).To(Equal(1))
+
+			err = client.Call("CliRpcCmd.StartLogStream", "fake-app", &started)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("fails to read when no stream is open", func() {
+			var batch plugin_models.LogStreamBatch
+			err = client.Call("CliRpcCmd.ReadLogStream", "", &batch)
+			Expect(err).To(MatchError("No log stream is open"))
+		})
 	})
 
 	Describe(".CallCoreCommand", func() {
@@ -744,6 +924,129 @@ var _ = Describe("Server", func() {
 				})
 			})
 
+			Context(".EnterSandbox", func() {
+				var (
+					authRepo      *authenticationfakes.FakeRepository
+					pluginHome    string
+					oldPluginHome string
+					sandboxConfig string
+				)
+
+				BeforeEach(func() {
+					pluginHome, err = ioutil.TempDir("", "plugin-sandbox")
+					Expect(err).ToNot(HaveOccurred())
+					oldPluginHome = os.Getenv("CF_PLUGIN_HOME")
+					os.Setenv("CF_PLUGIN_HOME", pluginHome)
+					sandboxConfig = filepath.Join(pluginHome, ".cf", "plugins", "sandbox", "some-plugin", ".cf", "config.json")
+
+					userToken, err := testconfig.EncodeAccessToken(coreconfig.TokenInfo{
+						Username: "some-user",
+						Scope:    []string{"cloud_controller.read", "cloud_controller.write", "password.write", "scim.read", "openid"},
+					})
+					Expect(err).ToNot(HaveOccurred())
+					config.SetAccessToken(userToken)
+					config.SetRefreshToken("user-refresh-token")
+
+					authRepo = new(authenticationfakes.FakeRepository)
+					authRepo.DeriveAuthTokenReturns("bearer derived-token", nil)
+					locator := api.RepositoryLocator{}
+					locator = locator.SetAuthenticationRepository(authRepo)
+
+					rpcService, err = NewRpcService(nil, nil, config, locator, nil, nil, nil, rpc.DefaultServer)
+					Expect(err).ToNot(HaveOccurred())
+					err = rpcService.Start()
+					Expect(err).ToNot(HaveOccurred())
+
+					pingCli(rpcService.Port())
+				})
+
+				AfterEach(func() {
+					os.Setenv("CF_PLUGIN_HOME", oldPluginHome)
+					os.RemoveAll(pluginHome)
+				})
+
+				It("runs the plugin with a config home of its own", func() {
+					env, err := rpcService.EnterSandbox("some-plugin")
+					Expect(err).ToNot(HaveOccurred())
+					defer rpcService.LeaveSandbox()
+
+					Expect(env).To(ConsistOf(
+						"CF_HOME="+filepath.Join(pluginHome, ".cf", "plugins", "sandbox", "some-plugin"),
+						"CF_PLUGIN_HOME="+pluginHome,
+						"CF_CONTEXT=",
+					))
+				})
+
+				It("derives a token without the scopes to manage users and passwords", func() {
+					_, err := rpcService.EnterSandbox("some-plugin")
+					Expect(err).ToNot(HaveOccurred())
+					defer rpcService.LeaveSandbox()
+
+					Expect(authRepo.DeriveAuthTokenCallCount()).To(Equal(1))
+					Expect(authRepo.DeriveAuthTokenArgsForCall(0)).To(Equal([]string{"cloud_controller.read", "cloud_controller.write", "openid"}))
+				})
+
+				It("gives the plugin the user's target and the derived token, but not the refresh token", func() {
+					_, err := rpcService.EnterSandbox("some-plugin")
+					Expect(err).ToNot(HaveOccurred())
+					defer rpcService.LeaveSandbox()
+
+					written := coreconfig.NewRepositoryFromFilepath(sandboxConfig, func(err error) {
+						Fail(err.Error())
+					})
+					Expect(written.APIEndpoint()).To(Equal(config.APIEndpoint()))
+					Expect(written.OrganizationFields()).To(Equal(config.OrganizationFields()))
+					Expect(written.SpaceFields()).To(Equal(config.SpaceFields()))
+					Expect(written.AccessToken()).To(Equal("bearer derived-token"))
+					Expect(written.RefreshToken()).To(BeEmpty())
+				})
+
+				It("returns a newly derived token from AccessToken instead of refreshing the user's", func() {
+					_, err := rpcService.EnterSandbox("some-plugin")
+					Expect(err).ToNot(HaveOccurred())
+					defer rpcService.LeaveSandbox()
+
+					authRepo.DeriveAuthTokenReturns("bearer newer-derived-token", nil)
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result string
+					err = client.Call("CliRpcCmd.AccessToken", "", &result)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result).To(Equal("bearer newer-derived-token"))
+					Expect(authRepo.RefreshAuthTokenCallCount()).To(Equal(0))
+				})
+
+				It("removes the derived token and goes back to the user's config when the plugin is done", func() {
+					_, err := rpcService.EnterSandbox("some-plugin")
+					Expect(err).ToNot(HaveOccurred())
+					rpcService.LeaveSandbox()
+
+					written := coreconfig.NewRepositoryFromFilepath(sandboxConfig, func(err error) {
+						Fail(err.Error())
+					})
+					Expect(written.AccessToken()).To(BeEmpty())
+
+					authRepo.RefreshAuthTokenReturns("user-token", nil)
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result string
+					err = client.Call("CliRpcCmd.AccessToken", "", &result)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result).To(Equal("user-token"))
+				})
+
+				It("returns an error when the token of the user has no scopes to give the plugin", func() {
+					config.SetAccessToken("bearer not-a-jwt")
+
+					_, err := rpcService.EnterSandbox("some-plugin")
+					Expect(err).To(HaveOccurred())
+					Expect(authRepo.DeriveAuthTokenCallCount()).To(Equal(0))
+				})
+			})
+
 		})
 
 		Context("fail", func() {
@@ -792,6 +1095,16 @@ var _ = Describe("Server", func() {
 	})
 })
 
+func noaaLogMessage(message string, messageType events.LogMessage_MessageType) *events.LogMessage {
+	return &events.LogMessage{
+		Message:     []byte(message),
+		AppId:       proto.String("fake-app-guid"),
+		MessageType: &messageType,
+		SourceType:  proto.String("APP"),
+		Timestamp:   proto.Int64(1000),
+	}
+}
+
 func pingCli(port string) {
 	var connErr error
 	var conn net.Conn
@@ -0,0 +1,107 @@
+package rpc
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api/logs"
+	"code.cloudfoundry.org/cli/plugin/models"
+)
+
+// logStream keeps the log messages of the app a plugin tails until the
+// plugin reads them.
+type logStream struct {
+	mutex    sync.Mutex
+	messages []plugin_models.LogMessage
+	ended    bool
+	err      error
+	arrived  chan struct{}
+}
+
+func newLogStream() *logStream {
+	return &logStream{
+		arrived: make(chan struct{}, 1),
+	}
+}
+
+// tail keeps the messages sent on logChan until it is closed or an error is
+// sent on errChan.
+func (stream *logStream) tail(logChan <-chan logs.Loggable, errChan <-chan error) {
+	for logChan != nil {
+		select {
+		case msg, ok := <-logChan:
+			if !ok {
+				logChan = nil
+				break
+			}
+			stream.add(msg.ToEnvelope())
+		case err, ok := <-errChan:
+			if !ok {
+				errChan = nil
+				break
+			}
+			if err != nil {
+				stream.end(err)
+				return
+			}
+		}
+	}
+
+	stream.end(nil)
+}
+
+func (stream *logStream) add(envelope logs.Envelope) {
+	stream.mutex.Lock()
+	stream.messages = append(stream.messages, plugin_models.LogMessage{
+		Timestamp:      envelope.Timestamp,
+		AppGuid:        envelope.AppGUID,
+		SourceType:     envelope.SourceType,
+		SourceInstance: envelope.SourceInstance,
+		MessageType:    envelope.MessageType,
+		Message:        envelope.Message,
+	})
+	stream.mutex.Unlock()
+
+	stream.notify()
+}
+
+func (stream *logStream) end(err error) {
+	stream.mutex.Lock()
+	stream.ended = true
+	stream.err = err
+	stream.mutex.Unlock()
+
+	stream.notify()
+}
+
+func (stream *logStream) notify() {
+	select {
+	case stream.arrived <- struct{}{}:
+	default:
+	}
+}
+
+// read returns the messages that arrived since it was last called, waiting
+// up to wait for some when there are none yet.
+func (stream *logStream) read(wait time.Duration) ([]plugin_models.LogMessage, bool, error) {
+	if !stream.ready() {
+		select {
+		case <-stream.arrived:
+		case <-time.After(wait):
+		}
+	}
+
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+
+	messages := stream.messages
+	stream.messages = nil
+	return messages, stream.ended, stream.err
+}
+
+func (stream *logStream) ready() bool {
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+
+	return len(stream.messages) > 0 || stream.ended
+}
@@ -7,32 +7,50 @@ import (
 	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
 )
 
-func RunMethodIfExists(rpcService *CliRpcService, args []string, pluginList map[string]pluginconfig.PluginMetadata) bool {
-	for _, metadata := range pluginList {
+func RunMethodIfExists(rpcService *CliRpcService, args []string, pluginList map[string]pluginconfig.PluginMetadata) (bool, error) {
+	for pluginName, metadata := range pluginList {
 		for _, command := range metadata.Commands {
 			if command.Name == args[0] || command.Alias == args[0] {
 				args[0] = command.Name
 
-				rpcService.Start()
-				defer rpcService.Stop()
-
-				pluginArgs := append([]string{rpcService.Port()}, args...)
+				env := os.Environ()
+				if rpcService.RpcCmd.cliConfig.PluginSandbox() {
+					sandboxEnv, err := rpcService.EnterSandbox(pluginName)
+					if err != nil {
+						return true, err
+					}
+					env = append(env, sandboxEnv...)
+				}
 
-				cmd := exec.Command(metadata.Location, pluginArgs...)
-				cmd.Stdout = os.Stdout
-				cmd.Stdin = os.Stdin
-				cmd.Stderr = os.Stderr
+				err := runPlugin(rpcService, metadata.Location, args, env)
 
-				defer stopPlugin(cmd)
-				err := cmd.Run()
+				// the plugin's token is removed before exiting, whether or
+				// not the plugin succeeded
+				rpcService.LeaveSandbox()
 				if err != nil {
 					os.Exit(1)
 				}
-				return true
+				return true, nil
 			}
 		}
 	}
-	return false
+	return false, nil
+}
+
+func runPlugin(rpcService *CliRpcService, location string, args []string, env []string) error {
+	rpcService.Start()
+	defer rpcService.Stop()
+
+	pluginArgs := append([]string{rpcService.Port()}, args...)
+
+	cmd := exec.Command(location, pluginArgs...)
+	cmd.Stdout = os.Stdout
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+	cmd.Env = env
+
+	defer stopPlugin(cmd)
+	return cmd.Run()
 }
 
 func stopPlugin(plugin *exec.Cmd) {
@@ -0,0 +1,137 @@
+package rpc
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/confighelpers"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+)
+
+// sandboxExcludedScopes are the prefixes of the scopes left out of the tokens
+// given to sandboxed plugins, so that they cannot manage users, passwords,
+// OAuth clients or identity zones.
+var sandboxExcludedScopes = []string{"password.", "scim.", "clients.", "uaa.admin", "zones."}
+
+// pluginSandbox is the config namespace of a plugin run with the plugin
+// sandbox on, and the user's own config, which is kept to derive tokens from.
+type pluginSandbox struct {
+	configPath      string
+	userConfig      coreconfig.Repository
+	userRepoLocator api.RepositoryLocator
+}
+
+func sandboxDir(pluginName string) string {
+	return filepath.Join(confighelpers.PluginRepoDir(), ".cf", "plugins", "sandbox", pluginName)
+}
+
+// EnterSandbox gives the plugin a config of its own, holding the user's
+// target and a token derived from the user's, which the RPC methods use in
+// place of the user's config until LeaveSandbox is called. It returns the
+// environment the plugin has to be run with, so that a cf run by the plugin
+// also uses that config.
+func (cli *CliRpcService) EnterSandbox(pluginName string) ([]string, error) {
+	cmd := cli.RpcCmd
+	dir := sandboxDir(pluginName)
+	sandbox := &pluginSandbox{
+		configPath:      filepath.Join(dir, ".cf", "config.json"),
+		userConfig:      cmd.cliConfig,
+		userRepoLocator: cmd.repoLocator,
+	}
+
+	deps := commandregistry.NewDependencyWithConfig(cmd.stdout, cmd.logger, dialTimeout, sandbox.configPath, "")
+	copySandboxConfig(sandbox.userConfig, deps.Config)
+
+	if sandbox.userConfig.IsLoggedIn() {
+		token, err := sandbox.deriveToken()
+		if err != nil {
+			deps.Config.Close()
+			return nil, err
+		}
+		deps.Config.SetAccessToken(token)
+	}
+
+	cmd.sandbox = sandbox
+	cmd.cliConfig = deps.Config
+	cmd.repoLocator = deps.RepoLocator
+
+	return []string{
+		"CF_HOME=" + dir,
+		"CF_PLUGIN_HOME=" + confighelpers.PluginRepoDir(),
+		"CF_CONTEXT=",
+	}, nil
+}
+
+// LeaveSandbox removes the plugin's token from its config, as the plugin may
+// only use it while it runs, and goes back to the user's config.
+func (cli *CliRpcService) LeaveSandbox() {
+	cmd := cli.RpcCmd
+	if cmd.sandbox == nil {
+		return
+	}
+
+	cmd.cliConfig.SetAccessToken("")
+	cmd.cliConfig.Close()
+
+	cmd.cliConfig = cmd.sandbox.userConfig
+	cmd.repoLocator = cmd.sandbox.userRepoLocator
+	cmd.sandbox = nil
+}
+
+// deriveToken gets a token for the plugin with the scopes of the user's token
+// that are not in sandboxExcludedScopes.
+func (sandbox *pluginSandbox) deriveToken() (string, error) {
+	tokenInfo := coreconfig.NewTokenInfo(sandbox.userConfig.AccessToken())
+
+	scopes := []string{}
+	for _, scope := range tokenInfo.Scope {
+		if !sandboxExcludedScope(scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	// an empty scope would give the plugin every scope of the user
+	if len(scopes) == 0 {
+		return "", errors.New(T("Could not find the scopes of your token to give the plugin a token of its own. Log in again, or turn the plugin sandbox off with 'cf config --plugin-sandbox false'"))
+	}
+
+	return sandbox.userRepoLocator.GetAuthenticationRepository().DeriveAuthToken(scopes)
+}
+
+func sandboxExcludedScope(scope string) bool {
+	for _, excluded := range sandboxExcludedScopes {
+		if strings.HasPrefix(scope, excluded) {
+			return true
+		}
+	}
+	return false
+}
+
+// copySandboxConfig gives the sandbox config the target and settings of the
+// user's config, but none of its tokens or client secrets.
+func copySandboxConfig(from coreconfig.Reader, to coreconfig.ReadWriter) {
+	to.ClearSession()
+	to.SetAPIEndpoint(from.APIEndpoint())
+	to.SetAPIVersion(from.APIVersion())
+	to.SetMinCLIVersion(from.MinCLIVersion())
+	to.SetMinRecommendedCLIVersion(from.MinRecommendedCLIVersion())
+	to.SetAuthenticationEndpoint(from.AuthenticationEndpoint())
+	to.SetLoggregatorEndpoint(from.LoggregatorEndpoint())
+	to.SetDopplerEndpoint(from.DopplerEndpoint())
+	to.SetUaaEndpoint(from.UaaEndpoint())
+	to.SetRoutingAPIEndpoint(from.RoutingAPIEndpoint())
+	to.SetSSHOAuthClient(from.SSHOAuthClient())
+	to.SetOrganizationFields(from.OrganizationFields())
+	to.SetSpaceFields(from.SpaceFields())
+	to.SetSSLDisabled(from.IsSSLDisabled())
+	to.SetAsyncTimeout(from.AsyncTimeout())
+	to.SetKeepAliveTimeout(from.KeepAliveTimeout())
+	to.SetMaxIdleConnections(from.MaxIdleConnections())
+	to.SetTrace(from.Trace())
+	to.SetColorEnabled(from.ColorEnabled())
+	to.SetLocale(from.Locale())
+}
@@ -0,0 +1,98 @@
+// Package v2models holds the models of version 2 of the plugin API. They are
+// sent to plugins as JSON, so a plugin built against older models ignores
+// the fields added to them since.
+package v2models
+
+import "time"
+
+// The resources a CLI may give plugins with version 2 of the plugin API
+const (
+	ResourceApps             = "apps"
+	ResourceRoutes           = "routes"
+	ResourceServiceInstances = "service_instances"
+	ResourceOrgs             = "orgs"
+	ResourceSpaces           = "spaces"
+)
+
+// Capabilities is what the CLI running a plugin offers it. APIVersion is 1
+// for CLIs that only have the original plugin API.
+type Capabilities struct {
+	APIVersion int      `json:"api_version"`
+	CLIVersion string   `json:"cli_version,omitempty"`
+	Resources  []string `json:"resources,omitempty"`
+}
+
+// Supports returns whether the CLI gives plugins resource, which is one of
+// the Resource constants.
+func (capabilities Capabilities) Supports(resource string) bool {
+	for _, r := range capabilities.Resources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+type App struct {
+	GUID             string     `json:"guid"`
+	Name             string     `json:"name"`
+	State            string     `json:"state"`
+	Instances        int        `json:"instances"`
+	RunningInstances int        `json:"running_instances"`
+	MemoryInMB       int64      `json:"memory_in_mb"`
+	DiskQuotaInMB    int64      `json:"disk_quota_in_mb"`
+	Buildpack        string     `json:"buildpack,omitempty"`
+	DockerImage      string     `json:"docker_image,omitempty"`
+	Stack            string     `json:"stack,omitempty"`
+	Command          string     `json:"command,omitempty"`
+	HealthCheckType  string     `json:"health_check_type,omitempty"`
+	PackageUpdatedAt *time.Time `json:"package_updated_at,omitempty"`
+	Routes           []Route    `json:"routes"`
+	// ServiceInstances are the names of the service instances bound to the
+	// app
+	ServiceInstances []string `json:"service_instances"`
+}
+
+type Route struct {
+	GUID   string `json:"guid"`
+	URL    string `json:"url"`
+	Host   string `json:"host,omitempty"`
+	Domain string `json:"domain"`
+	Path   string `json:"path,omitempty"`
+	Port   int    `json:"port,omitempty"`
+	// Apps are the names of the apps the route is mapped to. It is only
+	// given when routes are listed.
+	Apps            []string `json:"apps,omitempty"`
+	ServiceInstance string   `json:"service_instance,omitempty"`
+}
+
+type ServiceInstance struct {
+	GUID          string         `json:"guid"`
+	Name          string         `json:"name"`
+	Service       string         `json:"service,omitempty"`
+	Plan          string         `json:"plan,omitempty"`
+	UserProvided  bool           `json:"user_provided"`
+	Tags          []string       `json:"tags,omitempty"`
+	DashboardURL  string         `json:"dashboard_url,omitempty"`
+	LastOperation *LastOperation `json:"last_operation,omitempty"`
+	// Apps are the names of the apps bound to the service instance
+	Apps []string `json:"apps"`
+}
+
+type LastOperation struct {
+	Type        string `json:"type"`
+	State       string `json:"state"`
+	Description string `json:"description,omitempty"`
+}
+
+type Org struct {
+	GUID  string `json:"guid"`
+	Name  string `json:"name"`
+	Quota string `json:"quota,omitempty"`
+}
+
+type Space struct {
+	GUID     string `json:"guid"`
+	Name     string `json:"name"`
+	AllowSSH bool   `json:"allow_ssh"`
+}
@@ -280,6 +280,105 @@ type FakeHandlers struct {
 	getServiceReturns struct {
 		result1 error
 	}
+	PushAppStub        func(params plugin_models.PushAppParams, retVal *[]plugin_models.PushAppModel) error
+	pushAppMutex       sync.RWMutex
+	pushAppArgsForCall []struct {
+		params plugin_models.PushAppParams
+		retVal *[]plugin_models.PushAppModel
+	}
+	pushAppReturns struct {
+		result1 error
+	}
+	StartLogStreamStub        func(appName string, retVal *bool) error
+	startLogStreamMutex       sync.RWMutex
+	startLogStreamArgsForCall []struct {
+		appName string
+		retVal  *bool
+	}
+	startLogStreamReturns struct {
+		result1 error
+	}
+	ReadLogStreamStub        func(args string, retVal *plugin_models.LogStreamBatch) error
+	readLogStreamMutex       sync.RWMutex
+	readLogStreamArgsForCall []struct {
+		args   string
+		retVal *plugin_models.LogStreamBatch
+	}
+	readLogStreamReturns struct {
+		result1 error
+	}
+	StopLogStreamStub        func(args string, retVal *bool) error
+	stopLogStreamMutex       sync.RWMutex
+	stopLogStreamArgsForCall []struct {
+		args   string
+		retVal *bool
+	}
+	stopLogStreamReturns struct {
+		result1 error
+	}
+	APICapabilitiesStub        func(args string, retVal *[]byte) error
+	aPICapabilitiesMutex       sync.RWMutex
+	aPICapabilitiesArgsForCall []struct {
+		args   string
+		retVal *[]byte
+	}
+	aPICapabilitiesReturns struct {
+		result1 error
+	}
+	GetAppV2Stub        func(appName string, retVal *[]byte) error
+	getAppV2Mutex       sync.RWMutex
+	getAppV2ArgsForCall []struct {
+		appName string
+		retVal  *[]byte
+	}
+	getAppV2Returns struct {
+		result1 error
+	}
+	GetAppsV2Stub        func(args string, retVal *[]byte) error
+	getAppsV2Mutex       sync.RWMutex
+	getAppsV2ArgsForCall []struct {
+		args   string
+		retVal *[]byte
+	}
+	getAppsV2Returns struct {
+		result1 error
+	}
+	GetRoutesV2Stub        func(args string, retVal *[]byte) error
+	getRoutesV2Mutex       sync.RWMutex
+	getRoutesV2ArgsForCall []struct {
+		args   string
+		retVal *[]byte
+	}
+	getRoutesV2Returns struct {
+		result1 error
+	}
+	GetServiceInstancesV2Stub        func(args string, retVal *[]byte) error
+	getServiceInstancesV2Mutex       sync.RWMutex
+	getServiceInstancesV2ArgsForCall []struct {
+		args   string
+		retVal *[]byte
+	}
+	getServiceInstancesV2Returns struct {
+		result1 error
+	}
+	GetOrgsV2Stub        func(args string, retVal *[]byte) error
+	getOrgsV2Mutex       sync.RWMutex
+	getOrgsV2ArgsForCall []struct {
+		args   string
+		retVal *[]byte
+	}
+	getOrgsV2Returns struct {
+		result1 error
+	}
+	GetSpacesV2Stub        func(args string, retVal *[]byte) error
+	getSpacesV2Mutex       sync.RWMutex
+	getSpacesV2ArgsForCall []struct {
+		args   string
+		retVal *[]byte
+	}
+	getSpacesV2Returns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -1319,6 +1418,380 @@ func (fake *FakeHandlers) GetServiceReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeHandlers) PushApp(params plugin_models.PushAppParams, retVal *[]plugin_models.PushAppModel) error {
+	fake.pushAppMutex.Lock()
+	fake.pushAppArgsForCall = append(fake.pushAppArgsForCall, struct {
+		params plugin_models.PushAppParams
+		retVal *[]plugin_models.PushAppModel
+	}{params, retVal})
+	fake.recordInvocation("PushApp", []interface{}{params, retVal})
+	fake.pushAppMutex.Unlock()
+	if fake.PushAppStub != nil {
+		return fake.PushAppStub(params, retVal)
+	} else {
+		return fake.pushAppReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) PushAppCallCount() int {
+	fake.pushAppMutex.RLock()
+	defer fake.pushAppMutex.RUnlock()
+	return len(fake.pushAppArgsForCall)
+}
+
+func (fake *FakeHandlers) PushAppArgsForCall(i int) (plugin_models.PushAppParams, *[]plugin_models.PushAppModel) {
+	fake.pushAppMutex.RLock()
+	defer fake.pushAppMutex.RUnlock()
+	return fake.pushAppArgsForCall[i].params, fake.pushAppArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) PushAppReturns(result1 error) {
+	fake.PushAppStub = nil
+	fake.pushAppReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) StartLogStream(appName string, retVal *bool) error {
+	fake.startLogStreamMutex.Lock()
+	fake.startLogStreamArgsForCall = append(fake.startLogStreamArgsForCall, struct {
+		appName string
+		retVal  *bool
+	}{appName, retVal})
+	fake.recordInvocation("StartLogStream", []interface{}{appName, retVal})
+	fake.startLogStreamMutex.Unlock()
+	if fake.StartLogStreamStub != nil {
+		return fake.StartLogStreamStub(appName, retVal)
+	} else {
+		return fake.startLogStreamReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) StartLogStreamCallCount() int {
+	fake.startLogStreamMutex.RLock()
+	defer fake.startLogStreamMutex.RUnlock()
+	return len(fake.startLogStreamArgsForCall)
+}
+
+func (fake *FakeHandlers) StartLogStreamArgsForCall(i int) (string, *bool) {
+	fake.startLogStreamMutex.RLock()
+	defer fake.startLogStreamMutex.RUnlock()
+	return fake.startLogStreamArgsForCall[i].appName, fake.startLogStreamArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) StartLogStreamReturns(result1 error) {
+	fake.StartLogStreamStub = nil
+	fake.startLogStreamReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) ReadLogStream(args string, retVal *plugin_models.LogStreamBatch) error {
+	fake.readLogStreamMutex.Lock()
+	fake.readLogStreamArgsForCall = append(fake.readLogStreamArgsForCall, struct {
+		args   string
+		retVal *plugin_models.LogStreamBatch
+	}{args, retVal})
+	fake.recordInvocation("ReadLogStream", []interface{}{args, retVal})
+	fake.readLogStreamMutex.Unlock()
+	if fake.ReadLogStreamStub != nil {
+		return fake.ReadLogStreamStub(args, retVal)
+	} else {
+		return fake.readLogStreamReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) ReadLogStreamCallCount() int {
+	fake.readLogStreamMutex.RLock()
+	defer fake.readLogStreamMutex.RUnlock()
+	return len(fake.readLogStreamArgsForCall)
+}
+
+func (fake *FakeHandlers) ReadLogStreamArgsForCall(i int) (string, *plugin_models.LogStreamBatch) {
+	fake.readLogStreamMutex.RLock()
+	defer fake.readLogStreamMutex.RUnlock()
+	return fake.readLogStreamArgsForCall[i].args, fake.readLogStreamArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) ReadLogStreamReturns(result1 error) {
+	fake.ReadLogStreamStub = nil
+	fake.readLogStreamReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) StopLogStream(args string, retVal *bool) error {
+	fake.stopLogStreamMutex.Lock()
+	fake.stopLogStreamArgsForCall = append(fake.stopLogStreamArgsForCall, struct {
+		args   string
+		retVal *bool
+	}{args, retVal})
+	fake.recordInvocation("StopLogStream", []interface{}{args, retVal})
+	fake.stopLogStreamMutex.Unlock()
+	if fake.StopLogStreamStub != nil {
+		return fake.StopLogStreamStub(args, retVal)
+	} else {
+		return fake.stopLogStreamReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) StopLogStreamCallCount() int {
+	fake.stopLogStreamMutex.RLock()
+	defer fake.stopLogStreamMutex.RUnlock()
+	return len(fake.stopLogStreamArgsForCall)
+}
+
+func (fake *FakeHandlers) StopLogStreamArgsForCall(i int) (string, *bool) {
+	fake.stopLogStreamMutex.RLock()
+	defer fake.stopLogStreamMutex.RUnlock()
+	return fake.stopLogStreamArgsForCall[i].args, fake.stopLogStreamArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) StopLogStreamReturns(result1 error) {
+	fake.StopLogStreamStub = nil
+	fake.stopLogStreamReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) APICapabilities(args string, retVal *[]byte) error {
+	fake.aPICapabilitiesMutex.Lock()
+	fake.aPICapabilitiesArgsForCall = append(fake.aPICapabilitiesArgsForCall, struct {
+		args   string
+		retVal *[]byte
+	}{args, retVal})
+	fake.recordInvocation("APICapabilities", []interface{}{args, retVal})
+	fake.aPICapabilitiesMutex.Unlock()
+	if fake.APICapabilitiesStub != nil {
+		return fake.APICapabilitiesStub(args, retVal)
+	} else {
+		return fake.aPICapabilitiesReturns.result1
+	}
+}
+
+func (fake *FakeHandlers) APICapabilitiesCallCount() int {
+	fake.aPICapabilitiesMutex.RLock()
+	defer fake.aPICapabilitiesMutex.RUnlock()
+	return len(fake.aPICapabilitiesArgsForCall)
+}
+
+func (fake *FakeHandlers) APICapabilitiesArgsForCall(i int) (string, *[]byte) {
+	fake.aPICapabilitiesMutex.RLock()
+	defer fake.aPICapabilitiesMutex.RUnlock()
+	return fake.aPICapabilitiesArgsForCall[i].args, fake.aPICapabilitiesArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) APICapabilitiesReturns(result1 error) {
+	fake.APICapabilitiesStub = nil
+	fake.aPICapabilitiesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetAppV2(appName string, retVal *[]byte) error {
+	fake.getAppV2Mutex.Lock()
+	fake.getAppV2ArgsForCall = append(fake.getAppV2ArgsForCall, struct {
+		appName string
+		retVal  *[]byte
+	}{appName, retVal})
+	fake.recordInvocation("GetAppV2", []interface{}{appName, retVal})
+	fake.getAppV2Mutex.Unlock()
+	if fake.GetAppV2Stub != nil {
+		return fake.GetAppV2Stub(appName, retVal)
+	} else {
+		return fake.getAppV2Returns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetAppV2CallCount() int {
+	fake.getAppV2Mutex.RLock()
+	defer fake.getAppV2Mutex.RUnlock()
+	return len(fake.getAppV2ArgsForCall)
+}
+
+func (fake *FakeHandlers) GetAppV2ArgsForCall(i int) (string, *[]byte) {
+	fake.getAppV2Mutex.RLock()
+	defer fake.getAppV2Mutex.RUnlock()
+	return fake.getAppV2ArgsForCall[i].appName, fake.getAppV2ArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetAppV2Returns(result1 error) {
+	fake.GetAppV2Stub = nil
+	fake.getAppV2Returns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetAppsV2(args string, retVal *[]byte) error {
+	fake.getAppsV2Mutex.Lock()
+	fake.getAppsV2ArgsForCall = append(fake.getAppsV2ArgsForCall, struct {
+		args   string
+		retVal *[]byte
+	}{args, retVal})
+	fake.recordInvocation("GetAppsV2", []interface{}{args, retVal})
+	fake.getAppsV2Mutex.Unlock()
+	if fake.GetAppsV2Stub != nil {
+		return fake.GetAppsV2Stub(args, retVal)
+	} else {
+		return fake.getAppsV2Returns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetAppsV2CallCount() int {
+	fake.getAppsV2Mutex.RLock()
+	defer fake.getAppsV2Mutex.RUnlock()
+	return len(fake.getAppsV2ArgsForCall)
+}
+
+func (fake *FakeHandlers) GetAppsV2ArgsForCall(i int) (string, *[]byte) {
+	fake.getAppsV2Mutex.RLock()
+	defer fake.getAppsV2Mutex.RUnlock()
+	return fake.getAppsV2ArgsForCall[i].args, fake.getAppsV2ArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetAppsV2Returns(result1 error) {
+	fake.GetAppsV2Stub = nil
+	fake.getAppsV2Returns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetRoutesV2(args string, retVal *[]byte) error {
+	fake.getRoutesV2Mutex.Lock()
+	fake.getRoutesV2ArgsForCall = append(fake.getRoutesV2ArgsForCall, struct {
+		args   string
+		retVal *[]byte
+	}{args, retVal})
+	fake.recordInvocation("GetRoutesV2", []interface{}{args, retVal})
+	fake.getRoutesV2Mutex.Unlock()
+	if fake.GetRoutesV2Stub != nil {
+		return fake.GetRoutesV2Stub(args, retVal)
+	} else {
+		return fake.getRoutesV2Returns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetRoutesV2CallCount() int {
+	fake.getRoutesV2Mutex.RLock()
+	defer fake.getRoutesV2Mutex.RUnlock()
+	return len(fake.getRoutesV2ArgsForCall)
+}
+
+func (fake *FakeHandlers) GetRoutesV2ArgsForCall(i int) (string, *[]byte) {
+	fake.getRoutesV2Mutex.RLock()
+	defer fake.getRoutesV2Mutex.RUnlock()
+	return fake.getRoutesV2ArgsForCall[i].args, fake.getRoutesV2ArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetRoutesV2Returns(result1 error) {
+	fake.GetRoutesV2Stub = nil
+	fake.getRoutesV2Returns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetServiceInstancesV2(args string, retVal *[]byte) error {
+	fake.getServiceInstancesV2Mutex.Lock()
+	fake.getServiceInstancesV2ArgsForCall = append(fake.getServiceInstancesV2ArgsForCall, struct {
+		args   string
+		retVal *[]byte
+	}{args, retVal})
+	fake.recordInvocation("GetServiceInstancesV2", []interface{}{args, retVal})
+	fake.getServiceInstancesV2Mutex.Unlock()
+	if fake.GetServiceInstancesV2Stub != nil {
+		return fake.GetServiceInstancesV2Stub(args, retVal)
+	} else {
+		return fake.getServiceInstancesV2Returns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetServiceInstancesV2CallCount() int {
+	fake.getServiceInstancesV2Mutex.RLock()
+	defer fake.getServiceInstancesV2Mutex.RUnlock()
+	return len(fake.getServiceInstancesV2ArgsForCall)
+}
+
+func (fake *FakeHandlers) GetServiceInstancesV2ArgsForCall(i int) (string, *[]byte) {
+	fake.getServiceInstancesV2Mutex.RLock()
+	defer fake.getServiceInstancesV2Mutex.RUnlock()
+	return fake.getServiceInstancesV2ArgsForCall[i].args, fake.getServiceInstancesV2ArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetServiceInstancesV2Returns(result1 error) {
+	fake.GetServiceInstancesV2Stub = nil
+	fake.getServiceInstancesV2Returns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetOrgsV2(args string, retVal *[]byte) error {
+	fake.getOrgsV2Mutex.Lock()
+	fake.getOrgsV2ArgsForCall = append(fake.getOrgsV2ArgsForCall, struct {
+		args   string
+		retVal *[]byte
+	}{args, retVal})
+	fake.recordInvocation("GetOrgsV2", []interface{}{args, retVal})
+	fake.getOrgsV2Mutex.Unlock()
+	if fake.GetOrgsV2Stub != nil {
+		return fake.GetOrgsV2Stub(args, retVal)
+	} else {
+		return fake.getOrgsV2Returns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetOrgsV2CallCount() int {
+	fake.getOrgsV2Mutex.RLock()
+	defer fake.getOrgsV2Mutex.RUnlock()
+	return len(fake.getOrgsV2ArgsForCall)
+}
+
+func (fake *FakeHandlers) GetOrgsV2ArgsForCall(i int) (string, *[]byte) {
+	fake.getOrgsV2Mutex.RLock()
+	defer fake.getOrgsV2Mutex.RUnlock()
+	return fake.getOrgsV2ArgsForCall[i].args, fake.getOrgsV2ArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetOrgsV2Returns(result1 error) {
+	fake.GetOrgsV2Stub = nil
+	fake.getOrgsV2Returns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHandlers) GetSpacesV2(args string, retVal *[]byte) error {
+	fake.getSpacesV2Mutex.Lock()
+	fake.getSpacesV2ArgsForCall = append(fake.getSpacesV2ArgsForCall, struct {
+		args   string
+		retVal *[]byte
+	}{args, retVal})
+	fake.recordInvocation("GetSpacesV2", []interface{}{args, retVal})
+	fake.getSpacesV2Mutex.Unlock()
+	if fake.GetSpacesV2Stub != nil {
+		return fake.GetSpacesV2Stub(args, retVal)
+	} else {
+		return fake.getSpacesV2Returns.result1
+	}
+}
+
+func (fake *FakeHandlers) GetSpacesV2CallCount() int {
+	fake.getSpacesV2Mutex.RLock()
+	defer fake.getSpacesV2Mutex.RUnlock()
+	return len(fake.getSpacesV2ArgsForCall)
+}
+
+func (fake *FakeHandlers) GetSpacesV2ArgsForCall(i int) (string, *[]byte) {
+	fake.getSpacesV2Mutex.RLock()
+	defer fake.getSpacesV2Mutex.RUnlock()
+	return fake.getSpacesV2ArgsForCall[i].args, fake.getSpacesV2ArgsForCall[i].retVal
+}
+
+func (fake *FakeHandlers) GetSpacesV2Returns(result1 error) {
+	fake.GetSpacesV2Stub = nil
+	fake.getSpacesV2Returns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeHandlers) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -1382,6 +1855,28 @@ func (fake *FakeHandlers) Invocations() map[string][][]interface{} {
 	defer fake.getSpaceMutex.RUnlock()
 	fake.getServiceMutex.RLock()
 	defer fake.getServiceMutex.RUnlock()
+	fake.pushAppMutex.RLock()
+	defer fake.pushAppMutex.RUnlock()
+	fake.startLogStreamMutex.RLock()
+	defer fake.startLogStreamMutex.RUnlock()
+	fake.readLogStreamMutex.RLock()
+	defer fake.readLogStreamMutex.RUnlock()
+	fake.stopLogStreamMutex.RLock()
+	defer fake.stopLogStreamMutex.RUnlock()
+	fake.aPICapabilitiesMutex.RLock()
+	defer fake.aPICapabilitiesMutex.RUnlock()
+	fake.getAppV2Mutex.RLock()
+	defer fake.getAppV2Mutex.RUnlock()
+	fake.getAppsV2Mutex.RLock()
+	defer fake.getAppsV2Mutex.RUnlock()
+	fake.getRoutesV2Mutex.RLock()
+	defer fake.getRoutesV2Mutex.RUnlock()
+	fake.getServiceInstancesV2Mutex.RLock()
+	defer fake.getServiceInstancesV2Mutex.RUnlock()
+	fake.getOrgsV2Mutex.RLock()
+	defer fake.getOrgsV2Mutex.RUnlock()
+	fake.getSpacesV2Mutex.RLock()
+	defer fake.getSpacesV2Mutex.RUnlock()
 	return fake.invocations
 }
 
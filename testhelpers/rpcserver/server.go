@@ -46,6 +46,17 @@ type Handlers interface {
 	GetOrg(orgName string, retVal *plugin_models.GetOrg_Model) error
 	GetSpace(spaceName string, retVal *plugin_models.GetSpace_Model) error
 	GetService(serviceInstance string, retVal *plugin_models.GetService_Model) error
+	PushApp(params plugin_models.PushAppParams, retVal *[]plugin_models.PushAppModel) error
+	StartLogStream(appName string, retVal *bool) error
+	ReadLogStream(args string, retVal *plugin_models.LogStreamBatch) error
+	StopLogStream(args string, retVal *bool) error
+	APICapabilities(args string, retVal *[]byte) error
+	GetAppV2(appName string, retVal *[]byte) error
+	GetAppsV2(args string, retVal *[]byte) error
+	GetRoutesV2(args string, retVal *[]byte) error
+	GetServiceInstancesV2(args string, retVal *[]byte) error
+	GetOrgsV2(args string, retVal *[]byte) error
+	GetSpacesV2(args string, retVal *[]byte) error
 }
 
 type TestServer struct {
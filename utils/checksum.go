@@ -2,9 +2,11 @@ package utils
 
 import (
 	"crypto/sha1"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 )
 
 //go:generate counterfeiter . Sha1Checksum
@@ -56,3 +58,50 @@ func (c *sha1Checksum) CheckSha1(targetSha1 string) bool {
 func (c *sha1Checksum) SetFilePath(filepath string) {
 	c.filepath = filepath
 }
+
+//go:generate counterfeiter . Sha256Checksum
+
+type Sha256Checksum interface {
+	ComputeFileSha256() ([]byte, error)
+	CheckSha256(string) bool
+	SetFilePath(string)
+}
+
+type sha256Checksum struct {
+	filepath string
+}
+
+func NewSha256Checksum(filepath string) Sha256Checksum {
+	return &sha256Checksum{
+		filepath: filepath,
+	}
+}
+
+func (c *sha256Checksum) ComputeFileSha256() ([]byte, error) {
+	hash := sha256.New()
+
+	f, err := os.Open(c.filepath)
+	if err != nil {
+		return []byte{}, err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(hash, f); err != nil {
+		return []byte{}, err
+	}
+
+	return hash.Sum(nil), nil
+}
+
+func (c *sha256Checksum) CheckSha256(targetSha256 string) bool {
+	sha256, err := c.ComputeFileSha256()
+	if err != nil {
+		return false
+	}
+
+	return fmt.Sprintf("%x", sha256) == strings.ToLower(targetSha256)
+}
+
+func (c *sha256Checksum) SetFilePath(filepath string) {
+	c.filepath = filepath
+}
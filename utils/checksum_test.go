@@ -100,3 +100,55 @@ var _ = Describe("Sha1Checksum", func() {
 
 	})
 })
+
+var _ = Describe("Sha256Checksum", func() {
+	var (
+		checksum Sha256Checksum
+		f        *os.File
+		err      error
+	)
+
+	BeforeEach(func() {
+		f, err = ioutil.TempFile("", "sha256_test_")
+		Expect(err).NotTo(HaveOccurred())
+		defer f.Close()
+		f.Write([]byte("abc"))
+
+		checksum = NewSha256Checksum(f.Name())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(f.Name())
+	})
+
+	Describe("ComputeFileSha256", func() {
+		It("returns the sha256 of a file", func() {
+			sha256, err := checksum.ComputeFileSha256()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fmt.Sprintf("%x", sha256)).To(Equal("ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"))
+		})
+
+		It("returns error if the file does not exist", func() {
+			checksum.SetFilePath("file/path/to/no/where")
+
+			sha256, err := checksum.ComputeFileSha256()
+			Expect(sha256).To(BeEmpty())
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("CheckSha256", func() {
+		It("returns true if sha256 matches, ignoring case", func() {
+			Expect(checksum.CheckSha256("BA7816BF8F01CFEA414140DE5DAE2223B00361A396177A9CB410FF61F20015AD")).To(BeTrue())
+		})
+
+		It("returns false if sha256 doesn't match", func() {
+			Expect(checksum.CheckSha256("a9993e364706816aba3e25717850c26c9cd0d89d")).To(BeFalse())
+		})
+
+		It("returns false if the file does not exist", func() {
+			checksum.SetFilePath("file/path/to/no/where")
+			Expect(checksum.CheckSha256("ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad")).To(BeFalse())
+		})
+	})
+})
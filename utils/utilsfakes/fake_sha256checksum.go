@@ -0,0 +1,142 @@
+// This file was generated by counterfeiter
+package utilsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/utils"
+)
+
+type FakeSha256Checksum struct {
+	ComputeFileSha256Stub        func() ([]byte, error)
+	computeFileSha256Mutex       sync.RWMutex
+	computeFileSha256ArgsForCall []struct{}
+	computeFileSha256Returns     struct {
+		result1 []byte
+		result2 error
+	}
+	CheckSha256Stub        func(string) bool
+	checkSha256Mutex       sync.RWMutex
+	checkSha256ArgsForCall []struct {
+		arg1 string
+	}
+	checkSha256Returns struct {
+		result1 bool
+	}
+	SetFilePathStub        func(string)
+	setFilePathMutex       sync.RWMutex
+	setFilePathArgsForCall []struct {
+		arg1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSha256Checksum) ComputeFileSha256() ([]byte, error) {
+	fake.computeFileSha256Mutex.Lock()
+	fake.computeFileSha256ArgsForCall = append(fake.computeFileSha256ArgsForCall, struct{}{})
+	fake.recordInvocation("ComputeFileSha256", []interface{}{})
+	fake.computeFileSha256Mutex.Unlock()
+	if fake.ComputeFileSha256Stub != nil {
+		return fake.ComputeFileSha256Stub()
+	} else {
+		return fake.computeFileSha256Returns.result1, fake.computeFileSha256Returns.result2
+	}
+}
+
+func (fake *FakeSha256Checksum) ComputeFileSha256CallCount() int {
+	fake.computeFileSha256Mutex.RLock()
+	defer fake.computeFileSha256Mutex.RUnlock()
+	return len(fake.computeFileSha256ArgsForCall)
+}
+
+func (fake *FakeSha256Checksum) ComputeFileSha256Returns(result1 []byte, result2 error) {
+	fake.ComputeFileSha256Stub = nil
+	fake.computeFileSha256Returns = struct {
+		result1 []byte
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSha256Checksum) CheckSha256(arg1 string) bool {
+	fake.checkSha256Mutex.Lock()
+	fake.checkSha256ArgsForCall = append(fake.checkSha256ArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("CheckSha256", []interface{}{arg1})
+	fake.checkSha256Mutex.Unlock()
+	if fake.CheckSha256Stub != nil {
+		return fake.CheckSha256Stub(arg1)
+	} else {
+		return fake.checkSha256Returns.result1
+	}
+}
+
+func (fake *FakeSha256Checksum) CheckSha256CallCount() int {
+	fake.checkSha256Mutex.RLock()
+	defer fake.checkSha256Mutex.RUnlock()
+	return len(fake.checkSha256ArgsForCall)
+}
+
+func (fake *FakeSha256Checksum) CheckSha256ArgsForCall(i int) string {
+	fake.checkSha256Mutex.RLock()
+	defer fake.checkSha256Mutex.RUnlock()
+	return fake.checkSha256ArgsForCall[i].arg1
+}
+
+func (fake *FakeSha256Checksum) CheckSha256Returns(result1 bool) {
+	fake.CheckSha256Stub = nil
+	fake.checkSha256Returns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeSha256Checksum) SetFilePath(arg1 string) {
+	fake.setFilePathMutex.Lock()
+	fake.setFilePathArgsForCall = append(fake.setFilePathArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SetFilePath", []interface{}{arg1})
+	fake.setFilePathMutex.Unlock()
+	if fake.SetFilePathStub != nil {
+		fake.SetFilePathStub(arg1)
+	}
+}
+
+func (fake *FakeSha256Checksum) SetFilePathCallCount() int {
+	fake.setFilePathMutex.RLock()
+	defer fake.setFilePathMutex.RUnlock()
+	return len(fake.setFilePathArgsForCall)
+}
+
+func (fake *FakeSha256Checksum) SetFilePathArgsForCall(i int) string {
+	fake.setFilePathMutex.RLock()
+	defer fake.setFilePathMutex.RUnlock()
+	return fake.setFilePathArgsForCall[i].arg1
+}
+
+func (fake *FakeSha256Checksum) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.computeFileSha256Mutex.RLock()
+	defer fake.computeFileSha256Mutex.RUnlock()
+	fake.checkSha256Mutex.RLock()
+	defer fake.checkSha256Mutex.RUnlock()
+	fake.setFilePathMutex.RLock()
+	defer fake.setFilePathMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeSha256Checksum) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ utils.Sha256Checksum = new(FakeSha256Checksum)
@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nu7hatch/gouuid"
+)
+
+const (
+	WordStrategy      = "word"
+	UUIDStrategy      = "uuid"
+	TimestampStrategy = "timestamp"
+)
+
+// Strategies lists the names accepted by NewGenerator.
+var Strategies = []string{WordStrategy, UUIDStrategy, TimestampStrategy}
+
+// NewGenerator returns the generator for the named strategy. An empty name
+// selects the word strategy.
+func NewGenerator(strategy string) (WordGenerator, error) {
+	switch strategy {
+	case "", WordStrategy:
+		return NewWordGenerator(), nil
+	case UUIDStrategy:
+		return NewUUIDGenerator(), nil
+	case TimestampStrategy:
+		return NewTimestampGenerator(), nil
+	default:
+		return nil, fmt.Errorf("unknown strategy %q", strategy)
+	}
+}
+
+// IsValidStrategy reports whether NewGenerator accepts the named strategy.
+func IsValidStrategy(strategy string) bool {
+	_, err := NewGenerator(strategy)
+	return err == nil
+}
+
+type uuidGenerator struct{}
+
+// NewUUIDGenerator returns a generator of random version 4 UUIDs.
+func NewUUIDGenerator() WordGenerator {
+	return uuidGenerator{}
+}
+
+func (uuidGenerator) Babble() string {
+	guid, err := uuid.NewV4()
+	if err != nil {
+		return NewTimestampGenerator().Babble()
+	}
+	return guid.String()
+}
+
+type timestampGenerator struct {
+	now func() time.Time
+}
+
+// NewTimestampGenerator returns a generator of the current UTC time down to
+// the millisecond, e.g. 20161104-153012-042, so the words it generates sort
+// in the order they were made.
+func NewTimestampGenerator() WordGenerator {
+	return timestampGenerator{now: time.Now}
+}
+
+func (tg timestampGenerator) Babble() string {
+	return strings.Replace(tg.now().UTC().Format("20060102-150405.000"), ".", "-", 1)
+}
@@ -0,0 +1,40 @@
+package generator_test
+
+import (
+	. "code.cloudfoundry.org/cli/utils/words/generator"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewGenerator", func() {
+	It("generates adjective-noun words by default", func() {
+		for _, strategy := range []string{"", WordStrategy} {
+			wordGenerator, err := NewGenerator(strategy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(wordGenerator.Babble()).To(MatchRegexp(`^[a-z]+-[a-z]+$`))
+		}
+	})
+
+	It("generates UUIDs", func() {
+		wordGenerator, err := NewGenerator(UUIDStrategy)
+		Expect(err).NotTo(HaveOccurred())
+
+		word := wordGenerator.Babble()
+		Expect(word).To(MatchRegexp(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[0-9a-f]{4}-[0-9a-f]{12}$`))
+		Expect(wordGenerator.Babble()).NotTo(Equal(word))
+	})
+
+	It("generates timestamps", func() {
+		wordGenerator, err := NewGenerator(TimestampStrategy)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wordGenerator.Babble()).To(MatchRegexp(`^\d{8}-\d{6}-\d{3}$`))
+	})
+
+	It("returns an error for an unknown strategy", func() {
+		_, err := NewGenerator("dice")
+		Expect(err).To(MatchError(`unknown strategy "dice"`))
+		Expect(IsValidStrategy("dice")).To(BeFalse())
+		Expect(IsValidStrategy(UUIDStrategy)).To(BeTrue())
+	})
+})